@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/conf"
 	"github.com/apache/answer/internal/base/path"
@@ -59,6 +60,22 @@ var (
 	resetPasswordEmail string
 	// resetPasswordPassword new password for password reset
 	resetPasswordPassword string
+	// searchSyncBatchSize the number of contents indexed in one batch
+	searchSyncBatchSize int
+	// searchSyncSince only index the contents updated since the time
+	searchSyncSince string
+	// searchSyncRestart ignore the saved cursor of the last interrupted sync
+	searchSyncRestart bool
+	// exportDataPath the directory of the exported content file
+	exportDataPath string
+	// importFilePath the exported content file to import
+	importFilePath string
+	// seImportDumpDir the directory of the Stack Exchange data dump
+	seImportDumpDir string
+	// seImportDryRun only report the counts and conflicts of the Stack Exchange import
+	seImportDryRun bool
+	// storageMigrateRemoveLocal remove the local files after uploading them to the object storage
+	storageMigrateRemoveLocal bool
 )
 
 func init() {
@@ -85,7 +102,28 @@ func init() {
 	resetPasswordCmd.Flags().StringVarP(&resetPasswordEmail, "email", "e", "", "user email address")
 	resetPasswordCmd.Flags().StringVarP(&resetPasswordPassword, "password", "p", "", "new password (not recommended, will be recorded in shell history)")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, resetPasswordCmd} {
+	searchReindexCmd.Flags().IntVarP(&searchSyncBatchSize, "batch-size", "b", cli.DefaultSearchSyncBatchSize, "the number of contents indexed in one batch")
+
+	searchSyncCmd.Flags().IntVarP(&searchSyncBatchSize, "batch-size", "b", cli.DefaultSearchSyncBatchSize, "the number of contents indexed in one batch")
+	searchSyncCmd.Flags().StringVarP(&searchSyncSince, "since", "s", "", "only index the contents updated since the time, eg: -s 2024-01-02 or -s 2024-01-02T15:04:05Z")
+	searchSyncCmd.Flags().BoolVar(&searchSyncRestart, "restart", false, "ignore the saved cursor of the last interrupted sync")
+
+	searchCmd.AddCommand(searchReindexCmd, searchSyncCmd)
+
+	exportCmd.Flags().StringVarP(&exportDataPath, "path", "p", "./", "export data path, eg: -p ./export/data/")
+
+	importCmd.Flags().StringVarP(&importFilePath, "file", "f", "", "the exported content file, eg: -f ./answer_export_2024-01-02.zip")
+
+	importStackExchangeCmd.Flags().StringVarP(&seImportDumpDir, "dir", "d", "", "the directory of the extracted data dump, eg: -d ./dump/")
+	importStackExchangeCmd.Flags().BoolVar(&seImportDryRun, "dry-run", false, "report the counts and conflicts without saving anything")
+
+	importCmd.AddCommand(importStackExchangeCmd)
+
+	storageMigrateCmd.Flags().BoolVar(&storageMigrateRemoveLocal, "remove-local", false, "remove the local files after uploading them")
+
+	storageCmd.AddCommand(storageMigrateCmd)
+
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd, resetPasswordCmd, searchCmd, exportCmd, importCmd, storageCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -183,6 +221,98 @@ To run answer, use:
 		},
 	}
 
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export site content",
+		Long: `Export the questions, answers, comments, tags, users and votes into a zip file of newline-delimited JSON files.
+The passwords of the users are not exported.`,
+		Example: `  answer export -C ./answer-data -p ./export/`,
+		Run: func(_ *cobra.Command, _ []string) {
+			fmt.Println("Answer is exporting content")
+			path.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(path.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			fileName, err := cli.ExportContent(c.Data.Database, exportDataPath)
+			if err != nil {
+				fmt.Println("export failed: ", err.Error())
+				return
+			}
+			fmt.Printf("Answer exported the content to %s successfully.\n", fileName)
+		},
+	}
+
+	importCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import site content",
+		Long: `Import the content exported by the export command.
+The export file must be from the same database version, the existing rows with the same ids are overwritten.
+The imported users need to reset their passwords.`,
+		Example: `  answer import -C ./answer-data -f ./answer_export_2024-01-02.zip`,
+		Run: func(_ *cobra.Command, _ []string) {
+			if len(importFilePath) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: the export file is required, eg: -f ./answer_export_2024-01-02.zip")
+				os.Exit(1)
+			}
+			fmt.Println("Answer is importing content")
+			path.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(path.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			manifest, err := cli.ImportContent(c.Data.Database, importFilePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: import failed: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Answer imported the content exported at %s successfully.\n", manifest.CreatedAt.Format(time.RFC3339))
+		},
+	}
+
+	importStackExchangeCmd = &cobra.Command{
+		Use:   "stackexchange",
+		Short: "Import a Stack Exchange data dump",
+		Long: `Import the Users.xml, Tags.xml, Posts.xml, Comments.xml and Votes.xml files of a Stack Exchange data dump.
+The posts keep their creation dates, scores and accepted answers, the users keep their reputation.
+The dump has no email and password of the users, the imported users can not log in until an admin sets them.`,
+		Example: `  answer import stackexchange -C ./answer-data -d ./dump/ --dry-run
+  answer import stackexchange -C ./answer-data -d ./dump/`,
+		Run: func(_ *cobra.Command, _ []string) {
+			if len(seImportDumpDir) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: the directory of the data dump is required, eg: -d ./dump/")
+				os.Exit(1)
+			}
+			path.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(path.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			report, err := cli.ImportStackExchange(c.Data.Database, &cli.StackExchangeImportOptions{
+				DumpDir: seImportDumpDir,
+				DryRun:  seImportDryRun,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: import failed: %v\n", err)
+				os.Exit(1)
+			}
+			for _, conflict := range report.Conflicts {
+				fmt.Println("conflict:", conflict)
+			}
+			fmt.Printf("users: %d, tags: %d, questions: %d, answers: %d, comments: %d, votes: %d, skipped: %d, conflicts: %d\n",
+				report.Users, report.Tags, report.Questions, report.Answers, report.Comments, report.Votes,
+				report.Skipped, len(report.Conflicts))
+			if seImportDryRun {
+				fmt.Println("This is a dry run, nothing is saved.")
+				return
+			}
+			fmt.Println("Answer imported the data dump successfully.")
+		},
+	}
+
 	checkCmd = &cobra.Command{
 		Use:   "check",
 		Short: "Check the required environment",
@@ -332,8 +462,93 @@ To run answer, use:
 			}
 		},
 	}
+
+	searchCmd = &cobra.Command{
+		Use:   "search",
+		Short: "Manage the search index",
+		Long:  "Manage the index of the enabled search plugin",
+	}
+
+	searchReindexCmd = &cobra.Command{
+		Use:     "reindex",
+		Short:   "Rebuild the search index",
+		Long:    "Drop the index of the enabled search plugin and rebuild it from the database",
+		Example: `  answer search reindex -C ./answer-data`,
+		Run: func(_ *cobra.Command, _ []string) {
+			opts := &cli.SearchSyncOptions{Full: true, BatchSize: searchSyncBatchSize}
+			if err := cli.SearchReindex(context.Background(), dataDirPath, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	searchSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Update the search index incrementally",
+		Long: `Update the index of the enabled search plugin from the database in batches.
+The index is not dropped, so it can be searched during the sync.
+The progress is saved after every batch, an interrupted sync resumes from it when it runs with the same --since.`,
+		Example: `  answer search sync -C ./answer-data
+  answer search sync -C ./answer-data --since 2024-01-02 --batch-size 500`,
+		Run: func(_ *cobra.Command, _ []string) {
+			opts := &cli.SearchSyncOptions{BatchSize: searchSyncBatchSize, Restart: searchSyncRestart}
+			if len(searchSyncSince) > 0 {
+				since, err := parseSearchSyncSince(searchSyncSince)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: invalid --since %q, use 2006-01-02 or RFC3339 format\n", searchSyncSince)
+					os.Exit(1)
+				}
+				opts.Since = since
+			}
+			if err := cli.SearchReindex(context.Background(), dataDirPath, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	storageCmd = &cobra.Command{
+		Use:   "storage",
+		Short: "Manage the upload file storage",
+		Long:  "Manage the storage of the uploaded files",
+	}
+
+	storageMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the uploaded files to the object storage",
+		Long: `Upload the files in the upload directory to the S3-compatible object storage configured in the admin files settings.
+The files keep their paths, so the URLs of the files uploaded before switching keep working.
+Run it after switching to the object storage, the files that are not migrated yet are still served from the local disk.`,
+		Example: `  answer storage migrate -C ./answer-data
+  answer storage migrate -C ./answer-data --remove-local`,
+		Run: func(_ *cobra.Command, _ []string) {
+			path.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(path.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			report, err := cli.MigrateUploadsToObjectStorage(c.Data.Database, c.ServiceConfig.UploadPath, storageMigrateRemoveLocal)
+			if report != nil {
+				fmt.Printf("uploaded: %d, removed: %d\n", report.Uploaded, report.Removed)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: migrate failed: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("Answer migrated the uploaded files to the object storage successfully.")
+		},
+	}
 )
 
+func parseSearchSyncSince(value string) (time.Time, error) {
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, nil
+	}
+	return time.ParseInLocation(time.DateOnly, value, time.Local)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -29,6 +29,10 @@ import (
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/cron"
 	"github.com/apache/answer/internal/base/path"
+	answerserver "github.com/apache/answer/internal/base/server"
+	"github.com/apache/answer/internal/base/tracing"
+	_ "github.com/apache/answer/internal/plugin/connector_oidc"
+	_ "github.com/apache/answer/internal/plugin/search_elasticsearch"
 	"github.com/apache/answer/internal/schema"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -36,6 +40,7 @@ import (
 	"github.com/segmentfault/pacman/contrib/log/zap"
 	"github.com/segmentfault/pacman/contrib/server/http"
 	"github.com/segmentfault/pacman/log"
+	pacmanserver "github.com/segmentfault/pacman/server"
 )
 
 func init() {
@@ -89,6 +94,8 @@ func runApp() {
 	fmt.Println("answer Version:", constant.Version, " Revision:", constant.Revision)
 
 	defer cleanup()
+	shutdownTracing := tracing.Init(c.Server.HTTP.GetTracing())
+	defer shutdownTracing()
 	if err := app.Run(context.Background()); err != nil {
 		panic(err)
 	}
@@ -96,9 +103,13 @@ func runApp() {
 
 func newApplication(serverConf *conf.Server, server *gin.Engine, manager *cron.ScheduledTaskManager) *pacman.Application {
 	manager.Run()
+	servers := []pacmanserver.Server{http.NewServer(server, serverConf.HTTP.Addr)}
+	if metricsServer := answerserver.NewMetricsServer(serverConf.HTTP); metricsServer != nil {
+		servers = append(servers, http.NewServer(metricsServer, serverConf.HTTP.GetMetrics().Addr))
+	}
 	return pacman.NewApp(
 		pacman.WithName(Name),
 		pacman.WithVersion(Version),
-		pacman.WithServer(http.NewServer(server, serverConf.HTTP.Addr)),
+		pacman.WithServer(servers...),
 	)
 }
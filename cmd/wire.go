@@ -64,6 +64,7 @@ func initApplication(
 		repo.ProviderSetRepo,
 		translator.ProviderSet,
 		middleware.ProviderSetMiddleware,
+		wire.FieldsOf(new(*conf.Server), "HTTP"),
 		newApplication,
 	))
 }
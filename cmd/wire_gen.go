@@ -41,20 +41,26 @@ import (
 	"github.com/apache/answer/internal/repo/ai_conversation"
 	"github.com/apache/answer/internal/repo/answer"
 	"github.com/apache/answer/internal/repo/api_key"
+	"github.com/apache/answer/internal/repo/audit_log"
 	"github.com/apache/answer/internal/repo/auth"
 	"github.com/apache/answer/internal/repo/badge"
 	"github.com/apache/answer/internal/repo/badge_award"
 	"github.com/apache/answer/internal/repo/badge_group"
+	"github.com/apache/answer/internal/repo/bounty"
 	"github.com/apache/answer/internal/repo/captcha"
 	"github.com/apache/answer/internal/repo/collection"
 	"github.com/apache/answer/internal/repo/comment"
 	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/draft"
 	"github.com/apache/answer/internal/repo/export"
 	"github.com/apache/answer/internal/repo/file_record"
+	"github.com/apache/answer/internal/repo/guest_post"
+	"github.com/apache/answer/internal/repo/job"
 	"github.com/apache/answer/internal/repo/limit"
 	"github.com/apache/answer/internal/repo/meta"
-	notification2 "github.com/apache/answer/internal/repo/notification"
+	"github.com/apache/answer/internal/repo/notification"
 	"github.com/apache/answer/internal/repo/plugin_config"
+	"github.com/apache/answer/internal/repo/posting_restriction"
 	"github.com/apache/answer/internal/repo/question"
 	"github.com/apache/answer/internal/repo/rank"
 	"github.com/apache/answer/internal/repo/reason"
@@ -62,14 +68,21 @@ import (
 	"github.com/apache/answer/internal/repo/review"
 	"github.com/apache/answer/internal/repo/revision"
 	"github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/repo/saved_search"
 	"github.com/apache/answer/internal/repo/search_common"
 	"github.com/apache/answer/internal/repo/site_info"
+	"github.com/apache/answer/internal/repo/sitemap"
 	"github.com/apache/answer/internal/repo/tag"
 	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/tag_moderator"
 	"github.com/apache/answer/internal/repo/unique"
 	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/repo/user_data_export"
 	"github.com/apache/answer/internal/repo/user_external_login"
+	"github.com/apache/answer/internal/repo/user_mute"
 	"github.com/apache/answer/internal/repo/user_notification_config"
+	"github.com/apache/answer/internal/repo/user_two_factor"
+	"github.com/apache/answer/internal/repo/webhook"
 	"github.com/apache/answer/internal/router"
 	"github.com/apache/answer/internal/service/action"
 	activity2 "github.com/apache/answer/internal/service/activity"
@@ -78,30 +91,43 @@ import (
 	ai_conversation2 "github.com/apache/answer/internal/service/ai_conversation"
 	"github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/apikey"
+	audit_log2 "github.com/apache/answer/internal/service/audit_log"
 	auth2 "github.com/apache/answer/internal/service/auth"
 	badge2 "github.com/apache/answer/internal/service/badge"
+	bounty2 "github.com/apache/answer/internal/service/bounty"
 	collection2 "github.com/apache/answer/internal/service/collection"
 	"github.com/apache/answer/internal/service/collection_common"
 	comment2 "github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/comment_common"
 	config2 "github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/content_processor"
 	"github.com/apache/answer/internal/service/dashboard"
+	draft2 "github.com/apache/answer/internal/service/draft"
 	"github.com/apache/answer/internal/service/embedding"
 	"github.com/apache/answer/internal/service/eventqueue"
 	export2 "github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/feature_toggle"
+	"github.com/apache/answer/internal/service/feed"
 	file_record2 "github.com/apache/answer/internal/service/file_record"
 	"github.com/apache/answer/internal/service/follow"
+	guest_post2 "github.com/apache/answer/internal/service/guest_post"
+	"github.com/apache/answer/internal/service/health"
+	"github.com/apache/answer/internal/service/impersonation"
 	"github.com/apache/answer/internal/service/importer"
+	"github.com/apache/answer/internal/service/jobqueue"
 	meta2 "github.com/apache/answer/internal/service/meta"
 	"github.com/apache/answer/internal/service/meta_common"
 	"github.com/apache/answer/internal/service/noticequeue"
-	"github.com/apache/answer/internal/service/notification"
+	notification2 "github.com/apache/answer/internal/service/notification"
 	"github.com/apache/answer/internal/service/notification_common"
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/plugin_common"
+	"github.com/apache/answer/internal/service/post_lock"
+	"github.com/apache/answer/internal/service/post_wiki"
+	posting_restriction2 "github.com/apache/answer/internal/service/posting_restriction"
 	"github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_view"
 	rank2 "github.com/apache/answer/internal/service/rank"
 	reason2 "github.com/apache/answer/internal/service/reason"
 	report2 "github.com/apache/answer/internal/service/report"
@@ -109,68 +135,100 @@ import (
 	review2 "github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	role2 "github.com/apache/answer/internal/service/role"
+	saved_search2 "github.com/apache/answer/internal/service/saved_search"
 	"github.com/apache/answer/internal/service/search_parser"
 	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	sitemap2 "github.com/apache/answer/internal/service/sitemap"
+	"github.com/apache/answer/internal/service/spam"
+	"github.com/apache/answer/internal/service/stale_question"
 	tag2 "github.com/apache/answer/internal/service/tag"
 	tag_common2 "github.com/apache/answer/internal/service/tag_common"
+	tag_moderator2 "github.com/apache/answer/internal/service/tag_moderator"
 	"github.com/apache/answer/internal/service/uploader"
 	"github.com/apache/answer/internal/service/user_admin"
 	"github.com/apache/answer/internal/service/user_common"
+	user_data_export2 "github.com/apache/answer/internal/service/user_data_export"
 	user_external_login2 "github.com/apache/answer/internal/service/user_external_login"
+	user_mute2 "github.com/apache/answer/internal/service/user_mute"
 	user_notification_config2 "github.com/apache/answer/internal/service/user_notification_config"
+	user_two_factor2 "github.com/apache/answer/internal/service/user_two_factor"
 	"github.com/apache/answer/internal/service/vector_sync"
+	webhook2 "github.com/apache/answer/internal/service/webhook"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
 
+import (
+	_ "github.com/apache/answer/internal/plugin/connector_oidc"
+	_ "github.com/apache/answer/internal/plugin/search_elasticsearch"
+)
+
 // Injectors from wire.go:
 
 // initApplication init application.
 func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database, cacheConf *data.CacheConf, i18nConf *translator.I18n, swaggerConf *router.SwaggerConfig, serviceConf *service_config.ServiceConfig, uiConf *server.UI, logConf log.Logger) (*pacman.Application, func(), error) {
-	staticRouter := router.NewStaticRouter(serviceConf)
-	i18nTranslator, err := translator.NewTranslator(i18nConf)
+	engine, err := data.NewDB(debug, dbConf)
 	if err != nil {
 		return nil, nil, err
 	}
-	engine, err := data.NewDB(debug, dbConf)
+	replicas, cleanup, err := data.NewReplicas(debug, dbConf)
 	if err != nil {
 		return nil, nil, err
 	}
-	cache, cleanup, err := data.NewCache(cacheConf)
+	cache, cleanup2, err := data.NewCache(cacheConf)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	dataData, cleanup2, err := data.NewData(engine, cache)
+	dataData, cleanup3, err := data.NewData(engine, replicas, cache)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	siteInfoRepo := site_info.NewSiteInfo(dataData)
 	siteInfoCommonService := siteinfo_common.NewSiteInfoCommonService(siteInfoRepo)
-	langController := controller.NewLangController(i18nTranslator, siteInfoCommonService)
+	fileRecordRepo := file_record.NewFileRecordRepo(dataData)
+	uniqueIDRepo := unique.NewUniqueIDRepo(dataData)
+	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
+	userRepo := user.NewUserRepo(dataData)
+	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
+	roleRepo := role.NewRoleRepo(dataData)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	powerRepo := role.NewPowerRepo(dataData)
+	roleService := role2.NewRoleService(roleRepo, rolePowerRelRepo, powerRepo, userRoleRelRepo)
+	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
 	authRepo := auth.NewAuthRepo(dataData)
 	apiKeyRepo := api_key.NewAPIKeyRepo(dataData)
-	authService := auth2.NewAuthService(authRepo, apiKeyRepo)
-	userRepo := user.NewUserRepo(dataData)
-	uniqueIDRepo := unique.NewUniqueIDRepo(dataData)
+	authService := auth2.NewAuthService(authRepo, apiKeyRepo, siteInfoCommonService)
+	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
+	fileRecordService := file_record2.NewFileRecordService(fileRecordRepo, revisionRepo, serviceConf, siteInfoCommonService, userCommon)
+	uploaderService := uploader.NewUploaderService(serviceConf, siteInfoCommonService, fileRecordService)
+	staticRouter := router.NewStaticRouter(serviceConf, uploaderService)
+	i18nTranslator, err := translator.NewTranslator(i18nConf)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	langController := controller.NewLangController(i18nTranslator, siteInfoCommonService)
 	configRepo := config.NewConfigRepo(dataData)
 	configService := config2.NewConfigService(configRepo)
 	activityRepo := activity_common.NewActivityRepo(dataData, uniqueIDRepo, configService)
 	userRankRepo := rank.NewUserRankRepo(dataData, configService)
 	userActiveActivityRepo := activity.NewUserActiveActivityRepo(dataData, activityRepo, userRankRepo, configService)
 	emailRepo := export.NewEmailRepo(dataData)
-	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
-	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
-	roleRepo := role.NewRoleRepo(dataData)
-	roleService := role2.NewRoleService(roleRepo)
-	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
-	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
+	jobRepo := job.NewJobRepo(dataData)
+	service := jobqueue.NewService(jobRepo, serviceConf)
+	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService, service)
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
 	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo)
-	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
+	eventqueueService := eventqueue.NewService()
+	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService, eventqueueService)
 	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
 	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
@@ -178,23 +236,29 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	tagCommonRepo := tag_common.NewTagCommonRepo(dataData, uniqueIDRepo)
 	tagRelRepo := tag.NewTagRelRepo(dataData, uniqueIDRepo)
 	tagRepo := tag.NewTagRepo(dataData, uniqueIDRepo)
-	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
+	userTagRankRepo := rank.NewUserTagRankRepo(dataData)
 	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo)
-	service := activityqueue.NewService()
-	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, service)
+	activityqueueService := activityqueue.NewService()
+	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, userTagRankRepo, revisionService, siteInfoCommonService, activityqueueService)
 	collectionRepo := collection.NewCollectionRepo(dataData, uniqueIDRepo)
 	collectionCommon := collectioncommon.NewCollectionCommon(collectionRepo)
 	answerCommon := answercommon.NewAnswerCommon(answerRepo)
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
-	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, service, revisionRepo, siteInfoCommonService, dataData)
-	eventqueueService := eventqueue.NewService()
-	fileRecordRepo := file_record.NewFileRecordRepo(dataData)
-	fileRecordService := file_record2.NewFileRecordService(fileRecordRepo, revisionRepo, serviceConf, siteInfoCommonService, userCommon)
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventqueueService, fileRecordService)
+	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityqueueService, revisionRepo, siteInfoCommonService, dataData, service)
+	userTwoFactorRepo := user_two_factor.NewUserTwoFactorRepo(dataData)
+	userTwoFactorService := user_two_factor2.NewUserTwoFactorService(userTwoFactorRepo, userRepo, siteInfoCommonService)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventqueueService, fileRecordService, userTwoFactorService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
-	captchaService := action.NewCaptchaService(captchaRepo)
-	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
+	captchaService := action.NewCaptchaService(captchaRepo, siteInfoCommonService, userRepo)
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(dataData)
+	auditLogRepo := audit_log.NewAuditLogRepo(dataData)
+	auditLogService := audit_log2.NewAuditLogService(auditLogRepo, userCommon)
+	userDataExportService := user_data_export2.NewUserDataExportService(userDataExportRepo, userRepo, configService, emailService, siteInfoCommonService, auditLogService)
+	apiKeyService := apikey.NewAPIKeyService(apiKeyRepo)
+	limitRepo := limit.NewRateLimitRepo(dataData)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo, serviceConf)
+	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService, userTwoFactorService, userDataExportService, apiKeyService, rateLimitMiddleware)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
 	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
@@ -202,27 +266,40 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	externalService := noticequeue.NewExternalService()
 	reviewRepo := review.NewReviewRepo(dataData)
 	vector_syncService := vector_sync.NewService(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalService, tagCommonService, questionCommon, noticequeueService, siteInfoCommonService, commentCommonRepo, vector_syncService)
-	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, noticequeueService, externalService, service, eventqueueService, reviewService, vector_syncService)
-	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	spamService := spam.NewSpamService(siteInfoCommonService, userRoleRelService)
+	guestPostRepo := guest_post.NewGuestPostRepo(dataData)
+	guestPostService := guest_post2.NewGuestPostService(guestPostRepo, siteInfoCommonService, metaCommonService, emailService, questionRepo, userCommon)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalService, tagCommonService, questionCommon, noticequeueService, siteInfoCommonService, commentCommonRepo, vector_syncService, spamService, guestPostService)
+	userMuteRepo := user_mute.NewUserMuteRepo(dataData)
+	userMuteService := user_mute2.NewUserMuteService(userMuteRepo, userCommon)
+	contentProcessorService := content_processor.NewContentProcessorService(metaCommonService)
+	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, noticequeueService, externalService, activityqueueService, eventqueueService, reviewService, vector_syncService, userMuteService, contentProcessorService)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
-	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
-	limitRepo := limit.NewRateLimitRepo(dataData)
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
-	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
+	tagModeratorRepo := tag_moderator.NewTagModeratorRepo(dataData)
+	tagModeratorService := tag_moderator2.NewTagModeratorService(tagModeratorRepo, tagCommonService, objService, userCommon, auditLogService)
+	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService, tagCommonService, tagModeratorService)
+	postLockService := post_lock.NewPostLockService(metaCommonService, objService, auditLogService)
+	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware, postLockService)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, service)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, activityRepo, siteInfoCommonService, activityqueueService)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, noticequeueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
-	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalService, userExternalLoginRepo, siteInfoCommonService)
-	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, noticequeueService, externalService, service, siteInfoCommonService, externalNotificationService, reviewService, configService, eventqueueService, reviewRepo, vector_syncService)
-	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, noticequeueService, externalService, service, reviewService, eventqueueService, vector_syncService)
+	newQuestionDigestRepo := notification.NewNewQuestionDigestRepo(dataData)
+	notificationDigestRepo := notification.NewNotificationDigestRepo(dataData)
+	externalNotificationService := notification2.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalService, userExternalLoginRepo, siteInfoCommonService, noticequeueService, newQuestionDigestRepo, notificationDigestRepo)
+	draftRepo := draft.NewDraftRepo(dataData)
+	draftService := draft2.NewDraftService(draftRepo, serviceConf)
+	questionViewRepo := question.NewQuestionViewRepo(dataData)
+	questionViewService := question_view.NewQuestionViewService(questionViewRepo, questionRepo)
+	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, noticequeueService, externalService, activityqueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventqueueService, reviewRepo, vector_syncService, serviceConf, auditLogService, draftService, contentProcessorService, postLockService, questionViewService)
+	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, noticequeueService, externalService, activityqueueService, reviewService, eventqueueService, vector_syncService, auditLogService, draftService, contentProcessorService, postLockService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
-	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventqueueService)
+	flagActivityRepo := activity.NewFlagActivityRepo(dataData, userRankRepo, configService)
+	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventqueueService, userRepo, emailService, auditLogService, flagActivityRepo)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, noticequeueService)
 	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventqueueService)
-	voteController := controller.NewVoteController(voteService, rankService, captchaService)
+	voteController := controller.NewVoteController(voteService, rankService, captchaService, postLockService)
 	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo)
@@ -230,39 +307,40 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	collectionGroupRepo := collection.NewCollectionGroupRepo(dataData)
 	collectionService := collection2.NewCollectionService(collectionRepo, collectionGroupRepo, questionCommon)
 	collectionController := controller.NewCollectionController(collectionService)
-	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
-	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware)
+	postingRestrictionRepo := posting_restriction.NewPostingRestrictionRepo(dataData)
+	postingRestrictionService := posting_restriction2.NewPostingRestrictionService(postingRestrictionRepo, siteInfoCommonService, userRepo, userRoleRelService)
+	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware, postingRestrictionService, postLockService)
+	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware, postingRestrictionService, postLockService)
 	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
 	searchService := content.NewSearchService(searchParser, searchRepo)
 	searchController := controller.NewSearchController(searchService, captchaService)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, noticequeueService, service, reportRepo, reviewService, reviewActivityRepo)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, noticequeueService, activityqueueService, reportRepo, reviewService, reviewActivityRepo)
 	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
 	rankController := controller.NewRankController(rankService)
-	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
-	notificationRepo := notification2.NewNotificationRepo(dataData)
+	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo, userRankRepo)
+	notificationRepo := notification.NewNotificationRepo(dataData)
 	pluginUserConfigRepo := plugin_config.NewPluginUserConfigRepo(dataData)
 	badgeAwardRepo := badge_award.NewBadgeAwardRepo(dataData, uniqueIDRepo)
-	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, userExternalLoginRepo, notificationRepo, pluginUserConfigRepo, badgeAwardRepo, apiKeyRepo)
-	userAdminController := controller_admin.NewUserAdminController(userAdminService)
+	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, userExternalLoginRepo, notificationRepo, pluginUserConfigRepo, badgeAwardRepo, apiKeyRepo, auditLogService, serviceConf, eventqueueService)
+	userAdminController := controller_admin.NewUserAdminController(userAdminService, userTwoFactorService, userDataExportService)
 	reasonRepo := reason.NewReasonRepo(configService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
-	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, fileRecordService)
+	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, fileRecordService, auditLogService)
 	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
 	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
-	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, noticequeueService, userExternalLoginRepo, siteInfoCommonService)
+	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, noticequeueService, userExternalLoginRepo, siteInfoCommonService, externalService, userNotificationConfigRepo)
 	badgeRepo := badge.NewBadgeRepo(dataData, uniqueIDRepo)
-	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService, badgeRepo)
+	notificationService := notification2.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService, badgeRepo)
 	notificationController := controller.NewNotificationController(notificationService, rankService)
 	dashboardService := dashboard.NewDashboardService(questionRepo, answerRepo, commentCommonRepo, voteRepo, userRepo, reportRepo, configService, siteInfoCommonService, serviceConf, reviewService, revisionRepo, dataData)
 	dashboardController := controller.NewDashboardController(dashboardService)
-	uploaderService := uploader.NewUploaderService(serviceConf, siteInfoCommonService, fileRecordService)
 	uploadController := controller.NewUploadController(uploaderService)
 	activityActivityRepo := activity.NewActivityRepo(dataData, configService)
-	activityCommon := activity_common2.NewActivityCommon(activityRepo, service)
+	activityCommon := activity_common2.NewActivityCommon(activityRepo, activityqueueService)
 	commentCommonService := comment_common.NewCommentCommonService(commentCommonRepo)
 	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
 	activityController := controller.NewActivityController(activityService)
@@ -274,7 +352,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	permissionController := controller.NewPermissionController(rankService)
 	userPluginController := controller.NewUserPluginController(pluginCommonService)
 	reviewController := controller.NewReviewController(reviewService, rankService, captchaService)
-	metaService := meta2.NewMetaService(metaCommonService, userCommon, answerRepo, questionRepo, eventqueueService)
+	metaService := meta2.NewMetaService(metaCommonService, userCommon, answerRepo, questionRepo, commentCommonRepo, siteInfoCommonService, eventqueueService)
 	metaController := controller.NewMetaController(metaService)
 	badgeGroupRepo := badge_group.NewBadgeGroupRepo(dataData, uniqueIDRepo)
 	eventRuleRepo := badge.NewEventRuleRepo(dataData)
@@ -283,7 +361,6 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService)
 	badgeController := controller.NewBadgeController(badgeService, badgeAwardService)
 	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
-	apiKeyService := apikey.NewAPIKeyService(apiKeyRepo)
 	adminAPIKeyController := controller_admin.NewAdminAPIKeyController(apiKeyService)
 	featureToggleService := feature_toggle.NewFeatureToggleService(siteInfoRepo)
 	embeddingService := embedding.NewEmbeddingService()
@@ -293,27 +370,59 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	aiController := controller.NewAIController(searchService, siteInfoCommonService, tagCommonService, questionCommon, commentRepo, userCommon, answerRepo, mcpController, aiConversationService, featureToggleService)
 	aiConversationController := controller.NewAIConversationController(aiConversationService, featureToggleService)
 	aiConversationAdminController := controller_admin.NewAIConversationAdminController(aiConversationService, featureToggleService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, adminAPIKeyController, aiController, aiConversationController, aiConversationAdminController, mcpController)
+	webhookRepo := webhook.NewWebhookRepo(dataData)
+	webhookService := webhook2.NewWebhookService(webhookRepo, siteInfoCommonService, objService, userRepo, eventqueueService, service)
+	webhookController := controller_admin.NewWebhookController(webhookService)
+	auditLogController := controller_admin.NewAuditLogController(auditLogService)
+	jobQueueController := controller_admin.NewJobQueueController(service)
+	bountyRepo := bounty.NewBountyRepo(dataData, userRankRepo)
+	bountyService := bounty2.NewBountyService(bountyRepo, questionRepo, answerRepo, userCommon, configService, noticequeueService)
+	bountyController := controller.NewBountyController(bountyService)
+	graphQLController := controller.NewGraphQLController(questionService, answerService, commentService, tagService, userService, rankService)
+	draftController := controller.NewDraftController(draftService)
+	userMuteController := controller.NewUserMuteController(userMuteService)
+	postLockController := controller.NewPostLockController(postLockService, tagModeratorService)
+	postWikiService := post_wiki.NewPostWikiService(questionRepo, answerRepo, auditLogService)
+	postWikiController := controller.NewPostWikiController(postWikiService)
+	savedSearchRepo := saved_search.NewSavedSearchRepo(dataData)
+	savedSearchService := saved_search2.NewSavedSearchService(savedSearchRepo, searchService, userRepo, emailService, noticequeueService)
+	savedSearchController := controller.NewSavedSearchController(savedSearchService)
+	guestPostController := controller.NewGuestPostController(guestPostService, questionService, captchaService)
+	tagModeratorController := controller_admin.NewTagModeratorController(tagModeratorService)
+	impersonationService := impersonation.NewImpersonationService(authService, userCommon, userRoleRelService, siteInfoCommonService, auditLogService)
+	impersonationController := controller.NewImpersonationController(impersonationService)
+	conditionalGetMiddleware := middleware.NewConditionalGetMiddleware(dataData)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, adminAPIKeyController, aiController, aiConversationController, aiConversationAdminController, mcpController, webhookController, auditLogController, jobQueueController, bountyController, graphQLController, draftController, userMuteController, postLockController, postWikiController, savedSearchController, guestPostController, tagModeratorController, impersonationController, conditionalGetMiddleware)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, userCommon, rateLimitMiddleware, roleService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
-	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
+	replicaMiddleware := middleware.NewReplicaMiddleware(dataData)
+	healthService := health.NewHealthService(dataData)
+	healthController := controller.NewHealthController(healthService)
+	sitemapRepo := sitemap.NewSitemapRepo(dataData)
+	sitemapService := sitemap2.NewSitemapService(sitemapRepo, siteInfoCommonService)
+	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, sitemapService)
 	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService, eventqueueService, userService, questionService)
-	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, authUserMiddleware)
+	feedService := feed.NewFeedService(questionRepo, answerRepo, tagCommonService, userCommon, siteInfoCommonService)
+	feedController := controller.NewFeedController(feedService, siteInfoCommonService)
+	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, feedController, authUserMiddleware, conditionalGetMiddleware)
 	connectorController := controller.NewConnectorController(siteInfoCommonService, emailService, userExternalLoginService)
-	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService)
+	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService, eventqueueService)
 	userCenterController := controller.NewUserCenterController(userCenterLoginService, siteInfoCommonService)
 	captchaController := controller.NewCaptchaController()
 	embedController := controller.NewEmbedController()
 	renderController := controller.NewRenderController()
 	sidebarController := controller.NewSidebarController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController, renderController, sidebarController)
-	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, fileRecordService, userAdminService, serviceConf)
+	http := serverConf.HTTP
+	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, replicaMiddleware, healthController, templateRouter, pluginAPIRouter, uiConf, http)
+	staleQuestionService := stale_question.NewStaleQuestionService(questionRepo, questionService, commentRepo, userRepo, tagCommonService, configService, siteInfoCommonService, auditLogService)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, fileRecordService, userAdminService, serviceConf, bountyService, externalNotificationService, staleQuestionService, savedSearchService, badgeEventService, sitemapService)
 	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
 	return application, func() {
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
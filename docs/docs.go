@@ -601,6 +601,114 @@ const docTemplate = `{
                 }
             }
         },
+        "/answer/admin/api/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the audit log of administrative and moderation actions by page, the newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get the audit log of administrative and moderation actions by page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the user who did the action",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "action type, such as user.status.change, setting.change",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "question",
+                            "answer",
+                            "setting"
+                        ],
+                        "type": "string",
+                        "description": "object type",
+                        "name": "object_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "object id, the setting type if object type is setting",
+                        "name": "object_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start time, unix timestamp in seconds",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end time, unix timestamp in seconds",
+                        "name": "end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetAuditLogResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/answer/admin/api/badge/status": {
             "put": {
                 "security": [
@@ -781,46 +889,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/language/options": {
+        "/answer/admin/api/deleted-post/page": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get language options",
-                "produces": [
+                "description": "list the questions or answers deleted in the retention window, the latest deleted first",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Lang"
-                ],
-                "summary": "Get language options",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/admin/api/mcp-config": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get MCP configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get MCP configuration",
+                "summary": "admin deleted post page",
+                "parameters": [
+                    {
+                        "enum": [
+                            "question",
+                            "answer"
+                        ],
+                        "type": "string",
+                        "description": "object type",
+                        "name": "object_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -833,7 +944,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteMCPResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.AdminDeletedPostInfo"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -841,29 +967,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/admin/api/deleted-post/restore": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update MCP configuration",
+                "description": "admin restore deleted question or answer and reapply its reputation changes",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update MCP configuration",
+                "summary": "admin restore deleted question or answer",
                 "parameters": [
                     {
-                        "description": "MCP config",
+                        "description": "AdminRestoreDeletedPostReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteMCPReq"
+                            "$ref": "#/definitions/schema.AdminRestoreDeletedPostReq"
                         }
                     }
                 ],
@@ -877,28 +1008,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/plugin/config": {
+        "/answer/admin/api/jobs/dead-letters": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get plugin config",
+                "description": "list the jobs failed after all the attempts by page, the newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
+                    "admin"
                 ],
-                "summary": "get plugin config",
+                "summary": "list the jobs failed after all the attempts by page",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "plugin_slug_name",
-                        "name": "plugin_slug_name",
-                        "in": "query",
-                        "required": true
+                        "description": "job type",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -913,7 +1055,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetJobDeadLetterResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -921,61 +1078,240 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/jobs/status": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update plugin config",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the amount of the pending, running, retrying and failed jobs of each type",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
-                ],
-                "summary": "update plugin config",
-                "parameters": [
-                    {
-                        "description": "UpdatePluginConfigReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdatePluginConfigReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "get the status of the background job queue",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetJobQueueStatusResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/admin/api/plugin/status": {
-            "put": {
+        "/answer/admin/api/language/options": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update plugin status",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get language options",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
+                    "Lang"
+                ],
+                "summary": "Get language options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/mcp-config": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get MCP configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get MCP configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteMCPResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update MCP configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "update MCP configuration",
+                "parameters": [
+                    {
+                        "description": "MCP config",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteMCPReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/plugin/config": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get plugin config",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AdminPlugin"
+                ],
+                "summary": "get plugin config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "plugin_slug_name",
+                        "name": "plugin_slug_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update plugin config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AdminPlugin"
+                ],
+                "summary": "update plugin config",
+                "parameters": [
+                    {
+                        "description": "UpdatePluginConfigReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdatePluginConfigReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/plugin/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update plugin status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AdminPlugin"
                 ],
                 "summary": "update plugin status",
                 "parameters": [
@@ -1056,6 +1392,46 @@ const docTemplate = `{
                 }
             }
         },
+        "/answer/admin/api/powers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the permissions that can be granted to the roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get the permissions that can be granted to the roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetPowerResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/answer/admin/api/question/page": {
             "get": {
                 "security": [
@@ -1211,61 +1587,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/roles": {
-            "get": {
+        "/answer/admin/api/role": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get role list",
+                "description": "update the custom role, the permissions of the role are replaced. The built-in roles can not be updated.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get role list",
+                "summary": "update the custom role",
+                "parameters": [
+                    {
+                        "description": "role",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateRoleReq"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetRoleResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/setting/privileges": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetPrivilegesConfig get privileges config",
+                "description": "add the custom role with the permissions, it can be assigned to the users",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "GetPrivilegesConfig get privileges config",
+                "summary": "add the custom role",
+                "parameters": [
+                    {
+                        "description": "role",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddRoleReq"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1278,7 +1665,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetPrivilegesConfigResp"
+                                            "$ref": "#/definitions/schema.AddRoleResp"
                                         }
                                     }
                                 }
@@ -1287,28 +1674,31 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update privileges config",
+                "description": "remove the custom role that is not assigned to any user. The built-in roles can not be removed.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update privileges config",
+                "summary": "remove the custom role",
                 "parameters": [
                     {
-                        "description": "config",
+                        "description": "role",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdatePrivilegesConfigReq"
+                            "$ref": "#/definitions/schema.RemoveRoleReq"
                         }
                     }
                 ],
@@ -1322,21 +1712,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/setting/smtp": {
+        "/answer/admin/api/roles": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetSMTPConfig get smtp config",
+                "description": "get role list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "GetSMTPConfig get smtp config",
+                "summary": "get role list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1349,7 +1739,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetSMTPConfigResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetRoleResp"
+                                            }
                                         }
                                     }
                                 }
@@ -1357,57 +1750,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update smtp config",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "update smtp config",
-                "parameters": [
-                    {
-                        "description": "smtp config",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateSMTPConfigReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
             }
         },
-        "/answer/admin/api/siteinfo/advanced": {
+        "/answer/admin/api/setting/accept-reputation": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site advanced setting",
+                "description": "get the reputation the answerer and the asker gain when an answer is accepted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site advanced setting",
+                "summary": "get accepted answer reputation configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1420,7 +1779,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteAdvancedResp"
+                                            "$ref": "#/definitions/schema.SiteAcceptReputationResp"
                                         }
                                     }
                                 }
@@ -1435,22 +1794,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site advanced info",
+                "description": "update the reputation of the accepted answer and whether the asker gains the bonus",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site advanced info",
+                "summary": "update accepted answer reputation configuration",
                 "parameters": [
                     {
-                        "description": "advanced settings",
+                        "description": "accepted answer reputation config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteAdvancedReq"
+                            "$ref": "#/definitions/schema.SiteAcceptReputationReq"
                         }
                     }
                 ],
@@ -1464,21 +1823,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/branding": {
+        "/answer/admin/api/setting/auto-close": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site interface",
+                "description": "get how the stale unanswered questions are closed or archived automatically",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site interface",
+                "summary": "get auto-close configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1491,7 +1850,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteBrandingResp"
+                                            "$ref": "#/definitions/schema.SiteAutoCloseResp"
                                         }
                                     }
                                 }
@@ -1506,22 +1865,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info branding",
+                "description": "update how the stale unanswered questions are closed or archived automatically",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info branding",
+                "summary": "update auto-close configuration",
                 "parameters": [
                     {
-                        "description": "branding info",
+                        "description": "auto-close config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteBrandingReq"
+                            "$ref": "#/definitions/schema.SiteAutoCloseReq"
                         }
                     }
                 ],
@@ -1535,21 +1894,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/custom-css-html": {
+        "/answer/admin/api/setting/captcha": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site info custom html css config",
+                "description": "get the captcha configuration of the third-party provider, the secret key is masked",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site info custom html css config",
+                "summary": "get captcha configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1562,7 +1921,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteCustomCssHTMLResp"
+                                            "$ref": "#/definitions/schema.SiteCaptchaResp"
                                         }
                                     }
                                 }
@@ -1577,22 +1936,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site custom css html config",
+                "description": "update the provider and the keys of the captcha, hCaptcha, reCAPTCHA or Turnstile",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site custom css html config",
+                "summary": "update captcha configuration",
                 "parameters": [
                     {
-                        "description": "login info",
+                        "description": "captcha config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteCustomCssHTMLReq"
+                            "$ref": "#/definitions/schema.SiteCaptchaReq"
                         }
                     }
                 ],
@@ -1606,21 +1965,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/general": {
+        "/answer/admin/api/setting/cors": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site general information",
+                "description": "get the CORS configuration of the API routes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site general information",
+                "summary": "get CORS configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1633,7 +1992,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteGeneralResp"
+                                            "$ref": "#/definitions/schema.SiteCORSResp"
                                         }
                                     }
                                 }
@@ -1648,22 +2007,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site general information",
+                "description": "update the allowed origins, methods and headers of the cross-origin requests to the API routes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site general information",
+                "summary": "update CORS configuration",
                 "parameters": [
                     {
-                        "description": "general",
+                        "description": "CORS config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteGeneralReq"
+                            "$ref": "#/definitions/schema.SiteCORSReq"
                         }
                     }
                 ],
@@ -1677,64 +2036,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/interface": {
-            "get": {
+        "/answer/admin/api/setting/email-template": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site interface",
+                "description": "save the custom email template of the type in the language, the unknown variables are rejected",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site interface",
+                "summary": "save the custom email template of the type in the language",
+                "parameters": [
+                    {
+                        "description": "email template",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SaveEmailTemplateReq"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SiteInterfaceSettingsResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info interface",
+                "description": "remove the custom email template, the default template is used again",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info interface",
+                "summary": "remove the custom email template, the default template is used again",
                 "parameters": [
                     {
-                        "description": "general",
+                        "description": "email template",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteInterfaceReq"
+                            "$ref": "#/definitions/schema.RemoveEmailTemplateReq"
                         }
                     }
                 ],
@@ -1748,21 +2112,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/login": {
+        "/answer/admin/api/setting/email-templates": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site info login config",
+                "description": "get the custom email templates and the variables that can be used in them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site info login config",
+                "summary": "get the custom email templates and the variables that can be used in them",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1775,7 +2139,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteLoginResp"
+                                            "$ref": "#/definitions/schema.GetEmailTemplatesResp"
                                         }
                                     }
                                 }
@@ -1783,57 +2147,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update site login",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "update site login",
-                "parameters": [
-                    {
-                        "description": "login info",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.SiteLoginReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
             }
         },
-        "/answer/admin/api/siteinfo/polices": {
+        "/answer/admin/api/setting/guest-posting": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get the policies information for the site",
+                "description": "get whether the guests can ask questions without logging in",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get the policies information for the site",
+                "summary": "get guest posting configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1846,7 +2176,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SitePoliciesResp"
+                                            "$ref": "#/definitions/schema.SiteGuestPostingResp"
                                         }
                                     }
                                 }
@@ -1861,22 +2191,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site policies configuration",
+                "description": "turn on or off the guest posting, the questions of the guests are held for review",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site policies configuration",
+                "summary": "update guest posting configuration",
                 "parameters": [
                     {
-                        "description": "write info",
+                        "description": "guest posting config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SitePoliciesReq"
+                            "$ref": "#/definitions/schema.SiteGuestPostingReq"
                         }
                     }
                 ],
@@ -1890,21 +2220,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/question": {
+        "/answer/admin/api/setting/ldap": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site questions setting",
+                "description": "get LDAP login configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site questions setting",
+                "summary": "get LDAP login configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1917,7 +2247,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteQuestionsResp"
+                                            "$ref": "#/definitions/schema.SiteLDAPResp"
                                         }
                                     }
                                 }
@@ -1932,22 +2262,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site question settings",
+                "description": "update LDAP login configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site question settings",
+                "summary": "update LDAP login configuration",
                 "parameters": [
                     {
-                        "description": "questions settings",
+                        "description": "LDAP config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteQuestionsReq"
+                            "$ref": "#/definitions/schema.SiteLDAPReq"
                         }
                     }
                 ],
@@ -1961,21 +2291,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/security": {
+        "/answer/admin/api/setting/maintenance": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get the security information for the site",
+                "description": "get whether the site is in the read-only maintenance mode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get the security information for the site",
+                "summary": "get maintenance mode configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1988,7 +2318,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteSecurityResp"
+                                            "$ref": "#/definitions/schema.SiteMaintenanceResp"
                                         }
                                     }
                                 }
@@ -2003,22 +2333,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site security configuration",
+                "description": "turn on or off the read-only maintenance mode, only the admins can change the data in this mode",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site security configuration",
+                "summary": "update maintenance mode configuration",
                 "parameters": [
                     {
-                        "description": "write info",
+                        "description": "maintenance config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteSecurityReq"
+                            "$ref": "#/definitions/schema.SiteMaintenanceReq"
                         }
                     }
                 ],
@@ -2032,21 +2362,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/seo": {
+        "/answer/admin/api/setting/posting-restriction": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site seo information",
+                "description": "get the posting restrictions of the new accounts and the users with low reputation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site seo information",
+                "summary": "get posting restriction configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2059,7 +2389,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteSeoResp"
+                                            "$ref": "#/definitions/schema.SitePostingRestrictionResp"
                                         }
                                     }
                                 }
@@ -2074,22 +2404,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site seo information",
+                "description": "update the thresholds of the trusted users and the posting limits of the others",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site seo information",
+                "summary": "update posting restriction configuration",
                 "parameters": [
                     {
-                        "description": "seo",
+                        "description": "posting restriction config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteSeoReq"
+                            "$ref": "#/definitions/schema.SitePostingRestrictionReq"
                         }
                     }
                 ],
@@ -2103,21 +2433,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/tag": {
+        "/answer/admin/api/setting/privileges": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site tags setting",
+                "description": "GetPrivilegesConfig get privileges config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site tags setting",
+                "summary": "GetPrivilegesConfig get privileges config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2130,7 +2460,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteTagsResp"
+                                            "$ref": "#/definitions/schema.GetPrivilegesConfigResp"
                                         }
                                     }
                                 }
@@ -2145,22 +2475,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site tag settings",
+                "description": "update privileges config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site tag settings",
+                "summary": "update privileges config",
                 "parameters": [
                     {
-                        "description": "tags settings",
+                        "description": "config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteTagsReq"
+                            "$ref": "#/definitions/schema.UpdatePrivilegesConfigReq"
                         }
                     }
                 ],
@@ -2174,21 +2504,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/theme": {
+        "/answer/admin/api/setting/reaction": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site info theme config",
+                "description": "get the emojis the users can react to the posts with",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site info theme config",
+                "summary": "get reaction configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2201,7 +2531,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteThemeResp"
+                                            "$ref": "#/definitions/schema.SiteReactionResp"
                                         }
                                     }
                                 }
@@ -2216,22 +2546,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site custom css html config",
+                "description": "update the emojis the users can react to the posts with",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site custom css html config",
+                "summary": "update reaction configuration",
                 "parameters": [
                     {
-                        "description": "login info",
+                        "description": "reaction config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteThemeReq"
+                            "$ref": "#/definitions/schema.SiteReactionReq"
                         }
                     }
                 ],
@@ -2245,21 +2575,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/users": {
+        "/answer/admin/api/setting/registration-blocklist": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site user config",
+                "description": "get the blocked ip ranges and email domains of the registration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site user config",
+                "summary": "get registration blocklist",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2272,7 +2602,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteUsersResp"
+                                            "$ref": "#/definitions/schema.SiteRegistrationBlocklistResp"
                                         }
                                     }
                                 }
@@ -2287,22 +2617,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info config about users",
+                "description": "update the blocked ip ranges and email domains of the registration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info config about users",
+                "summary": "update registration blocklist",
                 "parameters": [
                     {
-                        "description": "users info",
+                        "description": "registration blocklist",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteUsersReq"
+                            "$ref": "#/definitions/schema.SiteRegistrationBlocklistReq"
                         }
                     }
                 ],
@@ -2316,26 +2646,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/users-settings": {
-            "get": {
+        "/answer/admin/api/setting/registration-blocklist/disposable-domains": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site interface",
+                "description": "bulk import the disposable email domains, one domain per line",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site interface",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
+                "summary": "import disposable email domains",
+                "parameters": [
+                    {
+                        "description": "disposable email domains",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ImportDisposableEmailDomainsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
                                 {
                                     "$ref": "#/definitions/handler.RespBody"
                                 },
@@ -2343,7 +2684,44 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteUsersSettingsResp"
+                                            "$ref": "#/definitions/schema.ImportDisposableEmailDomainsResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/setting/saml": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get SAML configuration and the service provider URLs to register at the identity provider",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get SAML configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteSAMLResp"
                                         }
                                     }
                                 }
@@ -2358,22 +2736,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info users settings",
+                "description": "update SAML configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info users settings",
+                "summary": "update SAML configuration",
                 "parameters": [
                     {
-                        "description": "general",
+                        "description": "SAML config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteUsersSettingsReq"
+                            "$ref": "#/definitions/schema.SiteSAMLReq"
                         }
                     }
                 ],
@@ -2387,57 +2765,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/theme/options": {
+        "/answer/admin/api/setting/slow-query": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get theme options",
+                "description": "get the milliseconds above which the database queries are logged",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get theme options",
+                "summary": "get slow query log configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SlowQueryConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/user": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "change the threshold of the slow query log at runtime, it is reset to the config file when the server restarts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "add user",
+                "summary": "update slow query log configuration",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "slow query config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddUserReq"
+                            "$ref": "#/definitions/schema.SlowQueryConfigReq"
                         }
                     }
                 ],
@@ -2451,30 +2836,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/activation": {
+        "/answer/admin/api/setting/smtp": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user activation",
+                "description": "GetSMTPConfig get smtp config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get user activation",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user id",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                "summary": "GetSMTPConfig get smtp config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2487,7 +2863,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetUserActivationResp"
+                                            "$ref": "#/definitions/schema.GetSMTPConfigResp"
                                         }
                                     }
                                 }
@@ -2495,34 +2871,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/user/password": {
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user password",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update smtp config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update user password",
+                "summary": "update smtp config",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "smtp config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserPasswordReq"
+                            "$ref": "#/definitions/schema.UpdateSMTPConfigReq"
                         }
                     }
                 ],
@@ -2536,32 +2907,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/profile": {
-            "put": {
+        "/answer/admin/api/setting/smtp/test": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "edit user profile",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "send test email with the saved smtp config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "edit user profile",
+                "summary": "send test email with the saved smtp config",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "test email recipient",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.EditUserProfileReq"
+                            "$ref": "#/definitions/schema.SendTestEmailReq"
                         }
                     }
                 ],
@@ -2575,32 +2943,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/role": {
-            "put": {
+        "/answer/admin/api/setting/spam": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user role",
-                "consumes": [
+                "description": "get spam check configuration, the Akismet API key is masked",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get spam check configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteSpamResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update the threshold of the spam score, whether to hold the spam posts and the Akismet integration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update user role",
+                "summary": "update spam check configuration",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "spam check config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserRoleReq"
+                            "$ref": "#/definitions/schema.SiteSpamReq"
                         }
                     }
                 ],
@@ -2614,32 +3014,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/status": {
-            "put": {
+        "/answer/admin/api/setting/storage": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user",
-                "consumes": [
+                "description": "get upload file storage configuration, the secret access key is masked",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get upload file storage configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteStorageResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update upload file storage configuration, local disk or S3-compatible object storage",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update user",
+                "summary": "update upload file storage configuration",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "storage config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserStatusReq"
+                            "$ref": "#/definitions/schema.SiteStorageReq"
                         }
                     }
                 ],
@@ -2653,68 +3085,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/users": {
-            "post": {
+        "/answer/admin/api/setting/username-policy": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add users",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the allowed characters, the length bounds and the reserved names of the usernames",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "add users",
-                "parameters": [
-                    {
-                        "description": "user",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AddUsersReq"
-                        }
-                    }
-                ],
+                "summary": "get username policy",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteUsernamePolicyResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/users/activation": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "send user activation",
+                "description": "update the policy the usernames are checked with when they are registered or changed,\nthe existing usernames are kept even if they break the new policy",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "send user activation",
+                "summary": "update username policy",
                 "parameters": [
                     {
-                        "description": "SendUserActivationReq",
+                        "description": "username policy",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SendUserActivationReq"
+                            "$ref": "#/definitions/schema.SiteUsernamePolicyReq"
                         }
                     }
                 ],
@@ -2728,58 +3156,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/users/page": {
+        "/answer/admin/api/setting/webhook": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user page",
+                "description": "get outgoing webhook configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get user page",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search query: email, username or id:[id]",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "staff user",
-                        "name": "staff",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "suspended",
-                            "deleted",
-                            "inactive"
-                        ],
-                        "type": "string",
-                        "description": "user status",
-                        "name": "status",
-                        "in": "query"
-                    }
-                ],
+                "summary": "get outgoing webhook configuration",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2792,22 +3183,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "records": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetUserPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.SiteWebhookResp"
                                         }
                                     }
                                 }
@@ -2815,90 +3191,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/activity/timeline": {
-            "get": {
-                "description": "get object timeline",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update outgoing webhook configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "get object timeline",
+                "summary": "update outgoing webhook configuration",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "object id",
-                        "name": "object_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "tag slug name",
-                        "name": "tag_slug_name",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "question",
-                            "answer",
-                            "tag"
-                        ],
-                        "type": "string",
-                        "description": "object type",
-                        "name": "object_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "is show vote",
-                        "name": "show_vote",
-                        "in": "query"
+                        "description": "webhook config",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteWebhookReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/activity/timeline/detail": {
+        "/answer/admin/api/siteinfo/advanced": {
             "get": {
-                "description": "get object timeline detail",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get site advanced setting",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "get object timeline detail",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "revision id",
-                        "name": "revision_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site advanced setting",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2911,7 +3254,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
+                                            "$ref": "#/definitions/schema.SiteAdvancedResp"
                                         }
                                     }
                                 }
@@ -2919,79 +3262,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/ai/conversation": {
-            "get": {
-                "description": "get conversation detail",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site advanced info",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai-conversation"
+                    "admin"
                 ],
-                "summary": "get conversation detail",
+                "summary": "update site advanced info",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "conversation id",
-                        "name": "conversation_id",
-                        "in": "query",
-                        "required": true
+                        "description": "advanced settings",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteAdvancedReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.AIConversationDetailResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/ai/conversation/page": {
+        "/answer/admin/api/siteinfo/branding": {
             "get": {
-                "description": "get conversation list",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai-conversation"
-                ],
-                "summary": "get conversation list",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "get site interface",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3004,22 +3325,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.AIConversationListItem"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.SiteBrandingResp"
                                         }
                                     }
                                 }
@@ -3027,29 +3333,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/ai/conversation/vote": {
-            "post": {
-                "description": "vote record",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site info branding",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai-conversation"
+                    "admin"
                 ],
-                "summary": "vote record",
+                "summary": "update site info branding",
                 "parameters": [
                     {
-                        "description": "vote request",
+                        "description": "branding info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AIConversationVoteReq"
+                            "$ref": "#/definitions/schema.SiteBrandingReq"
                         }
                     }
                 ],
@@ -3063,69 +3369,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/answer": {
-            "put": {
+        "/answer/admin/api/siteinfo/custom-css-html": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update Answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get site info custom html css config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
-                ],
-                "summary": "Update Answer",
-                "parameters": [
-                    {
-                        "description": "AnswerUpdateReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AnswerUpdateReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "get site info custom html css config",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteCustomCssHTMLResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site custom css html config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "Add Answer",
+                "summary": "update site custom css html config",
                 "parameters": [
                     {
-                        "description": "add answer request",
+                        "description": "login info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AnswerAddReq"
+                            "$ref": "#/definitions/schema.SiteCustomCssHTMLReq"
                         }
                     }
                 ],
@@ -3137,71 +3438,66 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/answer/admin/api/siteinfo/general": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get site general information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
-                ],
-                "summary": "delete answer",
-                "parameters": [
-                    {
-                        "description": "answer",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.RemoveAnswerReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "get site general information",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteGeneralResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/answer/acceptance": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Accept Answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site general information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "Accept Answer",
+                "summary": "update site general information",
                 "parameters": [
                     {
-                        "description": "AcceptAnswerReq",
+                        "description": "general",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AcceptAnswerReq"
+                            "$ref": "#/definitions/schema.SiteGeneralReq"
                         }
                     }
                 ],
@@ -3215,28 +3511,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/answer/info": {
+        "/answer/admin/api/siteinfo/interface": {
             "get": {
-                "description": "Get Answer Detail",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
-                ],
-                "summary": "Get Answer Detail",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site interface",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3249,7 +3538,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetAnswerInfoResp"
+                                            "$ref": "#/definitions/schema.SiteInterfaceSettingsResp"
                                         }
                                     }
                                 }
@@ -3257,87 +3546,100 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/answer/page": {
-            "get": {
-                "description": "AnswerList \u003cbr\u003e \u003cb\u003eorder\u003c/b\u003e (default or updated)",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site info interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "AnswerList",
+                "summary": "update site info interface",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "question_id",
-                        "name": "question_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "general",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteInterfaceReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/answer/recover": {
-            "post": {
+        "/answer/admin/api/siteinfo/login": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "recover the deleted answer",
-                "consumes": [
+                "description": "get site info login config",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get site info login config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update site login",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "recover answer",
+                "summary": "update site login",
                 "parameters": [
                     {
-                        "description": "answer",
+                        "description": "login info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RecoverAnswerReq"
+                            "$ref": "#/definitions/schema.SiteLoginReq"
                         }
                     }
                 ],
@@ -3351,29 +3653,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/badge": {
+        "/answer/admin/api/siteinfo/polices": {
             "get": {
-                "description": "get badge info",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the policies information for the site",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
-                ],
-                "summary": "get badge info",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Get the policies information for the site",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3386,7 +3680,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
+                                            "$ref": "#/definitions/schema.SitePoliciesResp"
                                         }
                                     }
                                 }
@@ -3394,92 +3688,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/badge/awards/page": {
-            "get": {
-                "description": "get badge award list",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "api-badge"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
-                "summary": "get badge award list",
+                "description": "update site policies configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "update site policies configuration",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "badge id",
-                        "name": "badge_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "only list the award by username",
-                        "name": "username",
-                        "in": "query"
+                        "description": "write info",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SitePoliciesReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/badge/user/awards": {
+        "/answer/admin/api/siteinfo/question": {
             "get": {
-                "description": "get user badge award list",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site questions setting",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
-                ],
-                "summary": "get user badge award list",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user name",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site questions setting",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3492,10 +3751,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteQuestionsResp"
                                         }
                                     }
                                 }
@@ -3503,68 +3759,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/badge/user/awards/recent": {
-            "get": {
-                "description": "get user badge award list",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site question settings",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
+                    "admin"
                 ],
-                "summary": "get user badge award list",
+                "summary": "update site question settings",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user name",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "questions settings",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteQuestionsReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/badges": {
+        "/answer/admin/api/siteinfo/security": {
             "get": {
-                "description": "list all badges group by group",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get the security information for the site",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
+                    "admin"
                 ],
-                "summary": "list all badges group by group",
+                "summary": "Get the security information for the site",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3577,10 +3822,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetBadgeListResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteSecurityResp"
                                         }
                                     }
                                 }
@@ -3588,34 +3830,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/collection/switch": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add collection",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site security configuration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collection"
+                    "admin"
                 ],
-                "summary": "add collection",
+                "summary": "update site security configuration",
                 "parameters": [
                     {
-                        "description": "collection",
+                        "description": "write info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.CollectionSwitchReq"
+                            "$ref": "#/definitions/schema.SiteSecurityReq"
                         }
                     }
                 ],
@@ -3623,43 +3860,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.CollectionSwitchResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/comment": {
+        "/answer/admin/api/siteinfo/seo": {
             "get": {
-                "description": "get comment by id",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get site seo information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "get comment by id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site seo information",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3672,22 +3893,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetCommentResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.SiteSeoResp"
                                         }
                                     }
                                 }
@@ -3702,25 +3908,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update comment",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site seo information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "update comment",
+                "summary": "update site seo information",
                 "parameters": [
                     {
-                        "description": "comment",
+                        "description": "seo",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateCommentReq"
+                            "$ref": "#/definitions/schema.SiteSeoReq"
                         }
                     }
                 ],
@@ -3732,35 +3935,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/answer/admin/api/siteinfo/tag": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add comment",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get site tags setting",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "add comment",
-                "parameters": [
-                    {
-                        "description": "comment",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AddCommentReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "get site tags setting",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3773,7 +3964,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetCommentResp"
+                                            "$ref": "#/definitions/schema.SiteTagsResp"
                                         }
                                     }
                                 }
@@ -3782,31 +3973,28 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "remove comment",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site tag settings",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "remove comment",
+                "summary": "update site tag settings",
                 "parameters": [
                     {
-                        "description": "comment",
+                        "description": "tags settings",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RemoveCommentReq"
+                            "$ref": "#/definitions/schema.SiteTagsReq"
                         }
                     }
                 ],
@@ -3820,46 +4008,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/comment/page": {
+        "/answer/admin/api/siteinfo/theme": {
             "get": {
-                "description": "get comment page",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get site info theme config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "get comment page",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "object id",
-                        "name": "object_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "vote"
-                        ],
-                        "type": "string",
-                        "description": "query condition",
-                        "name": "query_cond",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "get site info theme config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3872,22 +4035,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetCommentResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.SiteThemeResp"
                                         }
                                     }
                                 }
@@ -3895,29 +4043,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/connector/binding/email": {
-            "post": {
-                "description": "external login binding user send email",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site custom css html config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "external login binding user send email",
+                "summary": "update site custom css html config",
                 "parameters": [
                     {
-                        "description": "external login binding user send email",
+                        "description": "login info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailReq"
+                            "$ref": "#/definitions/schema.SiteThemeReq"
                         }
                     }
                 ],
@@ -3925,39 +4073,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/connector/info": {
+        "/answer/admin/api/siteinfo/users": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all enabled connectors",
+                "description": "get site user config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "get all enabled connectors",
+                "summary": "get site user config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3970,10 +4106,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.ConnectorInfoResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteUsersResp"
                                         }
                                     }
                                 }
@@ -3981,23 +4114,57 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update site info config about users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "update site info config about users",
+                "parameters": [
+                    {
+                        "description": "users info",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteUsersReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
             }
         },
-        "/answer/api/v1/connector/user/info": {
+        "/answer/admin/api/siteinfo/users-settings": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all connectors info about user",
+                "description": "get site interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "get all connectors info about user",
+                "summary": "get site interface",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4010,10 +4177,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.ConnectorUserInfoResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteUsersSettingsResp"
                                         }
                                     }
                                 }
@@ -4021,34 +4185,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/connector/user/unbinding": {
-            "delete": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "unbind external user login",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site info users settings",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "unbind external user login",
+                "summary": "update site info users settings",
                 "parameters": [
                     {
-                        "description": "ExternalLoginUnbindingReq",
+                        "description": "general",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.ExternalLoginUnbindingReq"
+                            "$ref": "#/definitions/schema.SiteUsersSettingsReq"
                         }
                     }
                 ],
@@ -4062,9 +4221,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/embed/config": {
-            "get": {
-                "description": "get embed plugin config",
+        "/answer/admin/api/tag/moderator": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "assign the user as the moderator of the tag, the global role of the user is not changed",
                 "consumes": [
                     "application/json"
                 ],
@@ -4072,120 +4236,89 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Plugin"
+                    "admin"
+                ],
+                "summary": "assign the user as the moderator of the tag",
+                "parameters": [
+                    {
+                        "description": "tag moderator",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddTagModeratorReq"
+                        }
+                    }
                 ],
-                "summary": "get embed plugin config",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/plugin.EmbedConfig"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/file": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "upload file",
+                "description": "remove the user from the moderators of the tag",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "Upload"
+                    "admin"
                 ],
-                "summary": "upload file",
+                "summary": "remove the user from the moderators of the tag",
                 "parameters": [
                     {
-                        "enum": [
-                            "post",
-                            "post_attachment",
-                            "avatar",
-                            "branding"
-                        ],
-                        "type": "string",
-                        "description": "identify the source of the file upload",
-                        "name": "source",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "tag moderator",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveTagModeratorReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/follow": {
-            "post": {
+        "/answer/admin/api/tag/moderators": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "follow object or cancel follow operation",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the moderators of the tag, they can edit, close, reopen, pin and lock the questions with the tag",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "admin"
                 ],
-                "summary": "follow object or cancel follow operation",
+                "summary": "get the moderators of the tag",
                 "parameters": [
                     {
-                        "description": "follow",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.FollowReq"
-                        }
+                        "type": "string",
+                        "description": "tag id",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4200,7 +4333,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.FollowResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.TagModeratorResp"
+                                            }
                                         }
                                     }
                                 }
@@ -4210,14 +4346,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/follow/tags": {
-            "put": {
+        "/answer/admin/api/theme/options": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user follow tags",
+                "description": "Get theme options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get theme options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/user": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add user",
                 "consumes": [
                     "application/json"
                 ],
@@ -4225,17 +4386,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "admin"
                 ],
-                "summary": "update user follow tags",
+                "summary": "add user",
                 "parameters": [
                     {
-                        "description": "follow",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateFollowTagsReq"
+                            "$ref": "#/definitions/schema.AddUserReq"
                         }
                     }
                 ],
@@ -4247,25 +4408,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/language/config": {
-            "get": {
-                "description": "get language config mapping",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete the account of a user and remove the personal data of it, the posts are anonymized or deleted by the account deletion policy",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lang"
+                    "admin"
                 ],
-                "summary": "get language config mapping",
+                "summary": "delete the account of a user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Accept-Language",
-                        "name": "Accept-Language",
-                        "in": "header",
-                        "required": true
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.EraseUserReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -4278,16 +4447,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/language/options": {
-            "get": {
-                "description": "Get language options",
+        "/answer/admin/api/user/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "turn off the two-factor authentication of a user who lost the authenticator and the recovery codes",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lang"
+                    "admin"
+                ],
+                "summary": "reset user two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ResetUserTwoFactorReq"
+                        }
+                    }
                 ],
-                "summary": "Get language options",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4298,24 +4486,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/meta/reaction": {
+        "/answer/admin/api/user/activation": {
             "get": {
-                "description": "get reaction for an object",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get user activation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Meta"
+                    "admin"
                 ],
-                "summary": "get reaction",
+                "summary": "get user activation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "object_id",
-                        "name": "object_id",
+                        "description": "user id",
+                        "name": "user_id",
                         "in": "query",
                         "required": true
                     }
@@ -4332,7 +4522,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.ReactionRespItem"
+                                            "$ref": "#/definitions/schema.GetUserActivationResp"
                                         }
                                     }
                                 }
@@ -4340,14 +4530,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/user/data-export": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update reaction. if not exist, add one",
+                "description": "compile all the data of a user into a zip file in background for the data export request of the user, the download link is sent to the email of the admin when it is ready",
                 "consumes": [
                     "application/json"
                 ],
@@ -4355,17 +4547,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Meta"
+                    "admin"
                 ],
-                "summary": "add or update reaction",
+                "summary": "export the data of a user",
                 "parameters": [
                     {
-                        "description": "reaction",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateReactionReq"
+                            "$ref": "#/definitions/schema.UserDataExportReq"
                         }
                     }
                 ],
@@ -4379,14 +4571,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/page": {
-            "get": {
+        "/answer/admin/api/user/impersonation": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get notification list",
+                "description": "start a session of the user for the admin, the access token of it is used instead of the admin one\nuntil the impersonation is stopped. The session is read-only unless the site security config allows\nthe full access, and the sensitive self-service actions are always forbidden.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4394,65 +4586,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "get notification list",
+                "summary": "view as the user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "inbox",
-                            "achievement"
-                        ],
-                        "type": "string",
-                        "description": "type",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "all",
-                            "posts",
-                            "invites",
-                            "votes"
-                        ],
-                        "type": "string",
-                        "description": "inbox_type",
-                        "name": "inbox_type",
-                        "in": "query",
-                        "required": true
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.StartImpersonationReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.StartImpersonationResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/notification/read/state": {
+        "/answer/admin/api/user/password": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ClearUnRead",
+                "description": "update user password",
                 "consumes": [
                     "application/json"
                 ],
@@ -4460,17 +4637,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "ClearUnRead",
+                "summary": "update user password",
                 "parameters": [
                     {
-                        "description": "NotificationClearIDRequest",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.NotificationClearIDRequest"
+                            "$ref": "#/definitions/schema.UpdateUserPasswordReq"
                         }
                     }
                 ],
@@ -4484,14 +4661,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/read/state/all": {
+        "/answer/admin/api/user/profile": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ClearUnRead",
+                "description": "edit user profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -4499,17 +4676,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "ClearUnRead",
+                "summary": "edit user profile",
                 "parameters": [
                     {
-                        "description": "NotificationClearRequest",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.NotificationClearRequest"
+                            "$ref": "#/definitions/schema.EditUserProfileReq"
                         }
                     }
                 ],
@@ -4523,14 +4700,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/status": {
-            "get": {
+        "/answer/admin/api/user/role": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetRedDot",
+                "description": "update user role",
                 "consumes": [
                     "application/json"
                 ],
@@ -4538,9 +4715,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
+                ],
+                "summary": "update user role",
+                "parameters": [
+                    {
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserRoleReq"
+                        }
+                    }
                 ],
-                "summary": "GetRedDot",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4549,14 +4737,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/user/sessions": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "DelRedDot",
+                "description": "log out all the active sessions of a user immediately, the api keys of the user are removed too if revoke_api_keys is true",
                 "consumes": [
                     "application/json"
                 ],
@@ -4564,17 +4754,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "DelRedDot",
+                "summary": "revoke all the sessions of a user",
                 "parameters": [
                     {
-                        "description": "NotificationClearRequest",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.NotificationClearRequest"
+                            "$ref": "#/definitions/schema.RevokeUserSessionsReq"
                         }
                     }
                 ],
@@ -4588,110 +4778,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/permission": {
-            "get": {
+        "/answer/admin/api/user/status": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "check user permission",
+                "description": "update user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "admin"
                 ],
-                "summary": "check user permission",
+                "summary": "update user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "access-token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "question.add",
-                            "question.edit",
-                            "question.edit_without_review",
-                            "question.delete",
-                            "question.close",
-                            "question.reopen",
-                            "question.vote_up",
-                            "question.vote_down",
-                            "question.pin",
-                            "question.unpin",
-                            "question.hide",
-                            "question.show",
-                            "answer.add",
-                            "answer.edit",
-                            "answer.edit_without_review",
-                            "answer.delete",
-                            "answer.accept",
-                            "answer.vote_up",
-                            "answer.vote_down",
-                            "answer.invite_someone_to_answer",
-                            "comment.add",
-                            "comment.edit",
-                            "comment.delete",
-                            "comment.vote_up",
-                            "comment.vote_down",
-                            "report.add",
-                            "tag.add",
-                            "tag.edit",
-                            "tag.edit_slug_name",
-                            "tag.edit_without_review",
-                            "tag.delete",
-                            "tag.synonym",
-                            "link.url_limit",
-                            "vote.detail",
-                            "answer.audit",
-                            "question.audit",
-                            "tag.audit",
-                            "tag.use_reserved_tag"
-                        ],
-                        "type": "string",
-                        "description": "permission key",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserStatusReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "boolean"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/personal/answer/page": {
-            "get": {
+        "/answer/admin/api/users": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list personal answers",
+                "description": "add users",
                 "consumes": [
                     "application/json"
                 ],
@@ -4699,44 +4832,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Personal"
+                    "admin"
                 ],
-                "summary": "list personal answers",
+                "summary": "add users",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "score"
-                        ],
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "0",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "20",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddUsersReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -4749,40 +4856,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/collection/page": {
-            "get": {
+        "/answer/admin/api/users/activation": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list personal collections",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "send user activation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collection"
+                    "admin"
                 ],
-                "summary": "list personal collections",
+                "summary": "send user activation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "0",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "20",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "SendUserActivationReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SendUserActivationReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -4795,20 +4892,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/comment/page": {
+        "/answer/admin/api/users/page": {
             "get": {
-                "description": "user personal comment list",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "user personal comment list",
+                "summary": "get user page",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "page",
+                        "description": "page size",
                         "name": "page",
                         "in": "query"
                     },
@@ -4820,8 +4922,25 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
+                        "description": "search query: email, username or id:[id]",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "staff user",
+                        "name": "staff",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "suspended",
+                            "deleted",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "user status",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
@@ -4844,10 +4963,10 @@ const docTemplate = `{
                                                 {
                                                     "type": "object",
                                                     "properties": {
-                                                        "list": {
+                                                        "records": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/schema.GetCommentPersonalWithPageResp"
+                                                                "$ref": "#/definitions/schema.GetUserPageResp"
                                                             }
                                                         }
                                                     }
@@ -4862,49 +4981,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/qa/top": {
+        "/answer/admin/api/webhook/dead-letters": {
             "get": {
-                "description": "UserTop",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Question"
-                ],
-                "summary": "UserTop",
-                "parameters": [
+                "security": [
                     {
-                        "type": "string",
-                        "default": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/personal/rank/page": {
-            "get": {
-                "description": "user personal rank list",
+                "description": "list the permanently failed webhook deliveries by page, the newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Rank"
+                    "admin"
                 ],
-                "summary": "user personal rank list",
+                "summary": "list the permanently failed webhook deliveries by page",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4917,12 +5008,6 @@ const docTemplate = `{
                         "description": "page size",
                         "name": "page_size",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4947,7 +5032,7 @@ const docTemplate = `{
                                                         "list": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/schema.GetRankPersonalPageResp"
+                                                                "$ref": "#/definitions/schema.GetWebhookDeadLetterResp"
                                                             }
                                                         }
                                                     }
@@ -4962,29 +5047,84 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/user/info": {
+        "/answer/api/v1/activity/timeline": {
             "get": {
-                "security": [
+                "description": "get object timeline",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "get object timeline",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag slug name",
+                        "name": "tag_slug_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "question",
+                            "answer",
+                            "tag"
+                        ],
+                        "type": "string",
+                        "description": "object type",
+                        "name": "object_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is show vote",
+                        "name": "show_vote",
+                        "in": "query"
                     }
                 ],
-                "description": "GetOtherUserInfoByUsername",
-                "consumes": [
-                    "application/json"
-                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/activity/timeline/detail": {
+            "get": {
+                "description": "get object timeline detail",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Comment"
                 ],
-                "summary": "GetOtherUserInfoByUsername",
+                "summary": "get object timeline detail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
+                        "description": "revision id",
+                        "name": "revision_id",
                         "in": "query",
                         "required": true
                     }
@@ -5001,7 +5141,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetOtherUserInfoResp"
+                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
                                         }
                                     }
                                 }
@@ -5011,14 +5151,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/vote/page": {
+        "/answer/api/v1/ai/conversation": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get user personal votes",
+                "description": "get conversation detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -5026,21 +5161,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "ai-conversation"
                 ],
-                "summary": "get user personal votes",
+                "summary": "get conversation detail",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "conversation_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5055,22 +5185,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetVoteWithPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.AIConversationDetailResp"
                                         }
                                     }
                                 }
@@ -5080,9 +5195,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/plugin/status": {
+        "/answer/api/v1/ai/conversation/page": {
             "get": {
-                "description": "get all plugins status",
+                "description": "get conversation list",
                 "consumes": [
                     "application/json"
                 ],
@@ -5090,9 +5205,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Plugin"
+                    "ai-conversation"
+                ],
+                "summary": "get conversation list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get all plugins status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -5105,10 +5234,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetPluginListResp"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.AIConversationListItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -5118,14 +5259,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/post/render": {
+        "/answer/api/v1/ai/conversation/vote": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "render post content",
+                "description": "vote record",
                 "consumes": [
                     "application/json"
                 ],
@@ -5133,17 +5269,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Upload"
+                    "ai-conversation"
                 ],
-                "summary": "render post content",
+                "summary": "vote record",
                 "parameters": [
                     {
-                        "description": "PostRenderReq",
+                        "description": "vote request",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.PostRenderReq"
+                            "$ref": "#/definitions/schema.AIConversationVoteReq"
                         }
                     }
                 ],
@@ -5157,14 +5293,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question": {
+        "/answer/api/v1/answer": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update question",
+                "description": "Update Answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -5172,17 +5308,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "update question",
+                "summary": "Update Answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "AnswerUpdateReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionUpdate"
+                            "$ref": "#/definitions/schema.AnswerUpdateReq"
                         }
                     }
                 ],
@@ -5201,7 +5337,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add question",
+                "description": "add answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -5209,17 +5345,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "add question",
+                "summary": "Add Answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "add answer request",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionAdd"
+                            "$ref": "#/definitions/schema.AnswerAddReq"
                         }
                     }
                 ],
@@ -5238,7 +5374,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete question",
+                "description": "delete answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -5246,17 +5382,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "delete question",
+                "summary": "delete answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "answer",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RemoveQuestionReq"
+                            "$ref": "#/definitions/schema.RemoveAnswerReq"
                         }
                     }
                 ],
@@ -5270,14 +5406,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/answer": {
+        "/answer/api/v1/answer/acceptance": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add question and answer",
+                "description": "Accept Answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -5285,17 +5421,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "add question and answer",
+                "summary": "Accept Answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "AcceptAnswerReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionAddByAnswer"
+                            "$ref": "#/definitions/schema.AcceptAnswerReq"
                         }
                     }
                 ],
@@ -5309,9 +5445,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/info": {
+        "/answer/api/v1/answer/info": {
             "get": {
-                "description": "get question details",
+                "description": "Get Answer Detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -5319,14 +5455,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "get question details",
+                "summary": "Get Answer Detail",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "1",
-                        "description": "Question TagID",
+                        "description": "id",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -5336,15 +5471,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetAnswerInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/invite": {
+        "/answer/api/v1/answer/page": {
             "get": {
-                "description": "get question invite user info",
+                "description": "AnswerList \u003cbr\u003e \u003cb\u003eorder\u003c/b\u003e (default or updated) \u003cbr\u003e \u003cb\u003epagination\u003c/b\u003e (offset or cursor), the cursor pagination returns the next_cursor instead of the count",
                 "consumes": [
                     "application/json"
                 ],
@@ -5352,17 +5499,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "get question invite user info",
+                "summary": "AnswerList",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "1",
-                        "description": "Question ID",
-                        "name": "id",
+                        "description": "question_id",
+                        "name": "question_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page_size",
+                        "name": "page_size",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -5373,14 +5552,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/api/v1/answer/recover": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update question invite user",
+                "description": "recover the deleted answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -5388,17 +5569,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "update question invite user",
+                "summary": "recover answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "answer",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionUpdateInviteUser"
+                            "$ref": "#/definitions/schema.RecoverAnswerReq"
                         }
                     }
                 ],
@@ -5412,50 +5593,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/link": {
+        "/answer/api/v1/badge": {
             "get": {
-                "description": "get question link",
+                "description": "get badge info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "get question link",
+                "summary": "get badge info",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "in_days",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "active",
-                            "hot",
-                            "score",
-                            "unanswered",
-                            "recommend",
-                            "frequent"
-                        ],
-                        "type": "string",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "page_size",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "name": "question_id",
+                        "default": "string",
+                        "description": "id",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
@@ -5472,22 +5628,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.QuestionPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
                                         }
                                     }
                                 }
@@ -5497,14 +5638,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/operation": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Operation question \\n operation [pin unpin hide show]",
+        "/answer/api/v1/badge/awards/page": {
+            "get": {
+                "description": "get badge award list",
                 "consumes": [
                     "application/json"
                 ],
@@ -5512,33 +5648,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "Operation question",
+                "summary": "get badge award list",
                 "parameters": [
                     {
-                        "description": "question",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.OperationQuestionReq"
-                        }
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "badge id",
+                        "name": "badge_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only list the award by username",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/page": {
+        "/answer/api/v1/badge/user/awards": {
             "get": {
-                "description": "get questions by page",
+                "description": "get user badge award list",
                 "consumes": [
                     "application/json"
                 ],
@@ -5546,18 +5710,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "get questions by page",
+                "summary": "get user badge award list",
                 "parameters": [
                     {
-                        "description": "QuestionPageReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.QuestionPageReq"
-                        }
+                        "type": "string",
+                        "description": "user name",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5572,22 +5734,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.QuestionPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
+                                            }
                                         }
                                     }
                                 }
@@ -5597,9 +5747,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/recommend/page": {
+        "/answer/api/v1/badge/user/awards/recent": {
             "get": {
-                "description": "get recommend questions by page",
+                "description": "get user badge award list",
                 "consumes": [
                     "application/json"
                 ],
@@ -5607,18 +5757,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "get recommend questions by page",
+                "summary": "get user badge award list",
                 "parameters": [
                     {
-                        "description": "QuestionPageReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.QuestionPageReq"
-                        }
+                        "type": "string",
+                        "description": "user name",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5633,22 +5781,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.QuestionPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
+                                            }
                                         }
                                     }
                                 }
@@ -5658,14 +5794,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/recover": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "recover deleted question",
+        "/answer/api/v1/badges": {
+            "get": {
+                "description": "list all badges group by group",
                 "consumes": [
                     "application/json"
                 ],
@@ -5673,38 +5804,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
-                ],
-                "summary": "recover deleted question",
-                "parameters": [
-                    {
-                        "description": "question",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.QuestionRecoverReq"
-                        }
-                    }
+                    "api-badge"
                 ],
+                "summary": "list all badges group by group",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetBadgeListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/reopen": {
-            "put": {
+        "/answer/api/v1/collection/switch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "reopen question",
+                "description": "add collection",
                 "consumes": [
                     "application/json"
                 ],
@@ -5712,87 +5847,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Collection"
                 ],
-                "summary": "reopen question",
+                "summary": "add collection",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "collection",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.ReopenQuestionReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "$ref": "#/definitions/schema.CollectionSwitchReq"
                         }
                     }
-                }
-            }
-        },
-        "/answer/api/v1/question/similar": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "fuzzy query similar questions based on title",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Question"
-                ],
-                "summary": "fuzzy query similar questions based on title",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "string",
-                        "description": "title",
-                        "name": "title",
-                        "in": "query",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.CollectionSwitchResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/similar/tag": {
+        "/answer/api/v1/comment": {
             "get": {
-                "description": "Search Similar Question",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get comment by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Comment"
                 ],
-                "summary": "Search Similar Question",
+                "summary": "get comment by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "question_id",
-                        "name": "question_id",
+                        "description": "id",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
@@ -5801,20 +5906,45 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetCommentResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/question/status": {
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Close question",
+                "description": "update comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -5822,17 +5952,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Comment"
                 ],
-                "summary": "Close question",
+                "summary": "update comment",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "comment",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.CloseQuestionReq"
+                            "$ref": "#/definitions/schema.UpdateCommentReq"
                         }
                     }
                 ],
@@ -5844,29 +5974,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/question/tags": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get tag list",
+                "description": "add comment",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Comment"
                 ],
-                "summary": "get tag list",
+                "summary": "add comment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "tag",
-                        "name": "tag",
-                        "in": "query"
+                        "description": "comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddCommentReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -5881,10 +6015,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetTagBasicResp"
-                                            }
+                                            "$ref": "#/definitions/schema.GetCommentResp"
                                         }
                                     }
                                 }
@@ -5892,16 +6023,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/reasons": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get reasons by object type and action",
+                "description": "remove comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -5909,35 +6038,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reason"
+                    "Comment"
                 ],
-                "summary": "get reasons by object type and action",
+                "summary": "remove comment",
                 "parameters": [
                     {
-                        "enum": [
-                            "question",
-                            "answer",
-                            "comment",
-                            "user"
-                        ],
-                        "type": "string",
-                        "description": "object_type",
-                        "name": "object_type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "status",
-                            "close",
-                            "flag",
-                            "review"
-                        ],
-                        "type": "string",
-                        "description": "action",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "description": "comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveCommentReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -5950,19 +6062,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/render/config": {
+        "/answer/api/v1/comment/page": {
             "get": {
-                "description": "GetRenderConfig",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get comment page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginRender"
+                    "Comment"
+                ],
+                "summary": "get comment page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "vote"
+                        ],
+                        "type": "string",
+                        "description": "query condition",
+                        "name": "query_cond",
+                        "in": "query"
+                    }
                 ],
-                "summary": "GetRenderConfig",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -5975,7 +6114,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/plugin.RenderConfig"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetCommentResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -5985,14 +6139,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/report": {
+        "/answer/api/v1/connector/binding/email": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "add report \u003cbr\u003e source (question, answer, comment, user)",
+                "description": "external login binding user send email",
                 "consumes": [
                     "application/json"
                 ],
@@ -6000,17 +6149,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "PluginConnector"
                 ],
-                "summary": "add report",
+                "summary": "external login binding user send email",
                 "parameters": [
                     {
-                        "description": "report",
+                        "description": "external login binding user send email",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddReportReq"
+                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailReq"
                         }
                     }
                 ],
@@ -6018,77 +6167,79 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/report/review": {
-            "put": {
+        "/answer/api/v1/connector/info": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "review report",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get all enabled connectors",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
-                ],
-                "summary": "review report",
-                "parameters": [
-                    {
-                        "description": "flag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.ReviewReportReq"
-                        }
-                    }
+                    "PluginConnector"
                 ],
+                "summary": "get all enabled connectors",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.ConnectorInfoResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/report/unreviewed/post": {
+        "/answer/api/v1/connector/user/info": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get unreviewed report post page",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get all connectors info about user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
-                ],
-                "summary": "get unreviewed report post page",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    }
+                    "PluginConnector"
                 ],
+                "summary": "get all connectors info about user",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6101,22 +6252,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetReportListPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.ConnectorUserInfoResp"
+                                            }
                                         }
                                     }
                                 }
@@ -6126,14 +6265,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/review/pending/post": {
-            "put": {
+        "/answer/api/v1/connector/user/unbinding": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update review",
+                "description": "unbind external user login",
                 "consumes": [
                     "application/json"
                 ],
@@ -6141,17 +6280,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Review"
+                    "PluginConnector"
                 ],
-                "summary": "update review",
+                "summary": "unbind external user login",
                 "parameters": [
                     {
-                        "description": "review",
+                        "description": "ExternalLoginUnbindingReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateReviewReq"
+                            "$ref": "#/definitions/schema.ExternalLoginUnbindingReq"
                         }
                     }
                 ],
@@ -6165,35 +6304,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/review/pending/post/page": {
+        "/answer/api/v1/draft": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get unreviewed post page",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the latest auto-saved draft of the new question or the answer to the question, null if none",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Review"
+                    "Draft"
                 ],
-                "summary": "get unreviewed post page",
+                "summary": "get the draft of the editor",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
+                        "enum": [
+                            "question",
+                            "answer"
+                        ],
+                        "type": "string",
+                        "description": "target type",
+                        "name": "target_type",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "object_id",
-                        "name": "object_id",
+                        "description": "question id, required if the target type is answer",
+                        "name": "question_id",
                         "in": "query"
                     }
                 ],
@@ -6209,22 +6350,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetUnreviewedPostPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.DraftResp"
                                         }
                                     }
                                 }
@@ -6232,65 +6358,33 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/reviewing/type": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get reviewing type",
-                "produces": [
+                "description": "save the draft of the new question or the answer to the question, it replaces the previous one",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Revision"
-                ],
-                "summary": "get reviewing type",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetReviewingTypeResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/revisions": {
-            "get": {
-                "description": "get revision list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
+                    "Draft"
                 ],
-                "summary": "get revision list",
+                "summary": "auto-save the draft of the editor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "object id",
-                        "name": "object_id",
-                        "in": "query",
-                        "required": true
+                        "description": "draft",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SaveDraftReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -6305,10 +6399,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetRevisionResp"
-                                            }
+                                            "$ref": "#/definitions/schema.DraftResp"
                                         }
                                     }
                                 }
@@ -6316,31 +6407,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/revisions/audit": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "revision audit operation:approve or reject",
+                "description": "discard the draft of the new question or the answer to the question",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
+                    "Draft"
                 ],
-                "summary": "revision audit",
+                "summary": "discard the draft of the editor",
                 "parameters": [
                     {
-                        "description": "audit",
+                        "description": "draft",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RevisionAuditReq"
+                            "$ref": "#/definitions/schema.RemoveDraftReq"
                         }
                     }
                 ],
@@ -6354,14 +6446,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/revisions/edit/check": {
+        "/answer/api/v1/embed/config": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "check can update revision",
+                "description": "get embed plugin config",
                 "consumes": [
                     "application/json"
                 ],
@@ -6369,50 +6456,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
-                ],
-                "summary": "check can update revision",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Plugin"
                 ],
+                "summary": "get embed plugin config",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/plugin.EmbedConfig"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/revisions/unreviewed": {
-            "get": {
+        "/answer/api/v1/file": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get unreviewed revision list",
-                "produces": [
-                    "application/json"
+                "description": "upload file",
+                "consumes": [
+                    "multipart/form-data"
                 ],
                 "tags": [
-                    "Revision"
+                    "Upload"
                 ],
-                "summary": "get unreviewed revision list",
+                "summary": "upload file",
                 "parameters": [
                     {
+                        "enum": [
+                            "post",
+                            "post_attachment",
+                            "avatar",
+                            "branding"
+                        ],
                         "type": "string",
-                        "description": "page id",
-                        "name": "page",
-                        "in": "query",
+                        "description": "identify the source of the file upload",
+                        "name": "source",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -6428,22 +6533,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetUnreviewedRevisionResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -6453,41 +6543,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/search": {
-            "get": {
+        "/answer/api/v1/follow": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "search object",
+                "description": "follow object or cancel follow operation",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "Activity"
                 ],
-                "summary": "search object",
+                "summary": "follow object or cancel follow operation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "query string",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "active",
-                            "score",
-                            "relevance"
-                        ],
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
+                        "description": "follow",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.FollowReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -6502,7 +6584,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SearchResp"
+                                            "$ref": "#/definitions/schema.FollowResp"
                                         }
                                     }
                                 }
@@ -6512,90 +6594,100 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/search/desc": {
-            "get": {
-                "description": "get search description",
+        "/answer/api/v1/follow/tags": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user follow tags",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "Activity"
+                ],
+                "summary": "update user follow tags",
+                "parameters": [
+                    {
+                        "description": "follow",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateFollowTagsReq"
+                        }
+                    }
                 ],
-                "summary": "get search description",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SearchResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/siteinfo": {
-            "get": {
-                "description": "get site info",
+        "/answer/api/v1/graphql": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "query the questions, answers, comments, tags and users with the fields that are needed.\nThe query depth and the amount of the objects in the query are limited.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "site"
+                    "GraphQL"
                 ],
-                "summary": "get site info",
-                "responses": {
+                "summary": "execute the graphql query",
+                "parameters": [
+                    {
+                        "description": "graphql query",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.GraphQLReq"
+                        }
+                    }
+                ],
+                "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "the graphql response with the data and the errors",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SiteInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "object"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/siteinfo/legal": {
+        "/answer/api/v1/language/config": {
             "get": {
-                "description": "get site legal info",
+                "description": "get language config mapping",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "site"
+                    "Lang"
                 ],
-                "summary": "get site legal info",
+                "summary": "get language config mapping",
                 "parameters": [
                     {
-                        "enum": [
-                            "tos",
-                            "privacy"
-                        ],
                         "type": "string",
-                        "description": "legal information type",
-                        "name": "info_type",
-                        "in": "query",
+                        "description": "Accept-Language",
+                        "name": "Accept-Language",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -6603,27 +6695,35 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetSiteLegalInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tag": {
+        "/answer/api/v1/language/options": {
             "get": {
-                "description": "get tag one",
+                "description": "Get language options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lang"
+                ],
+                "summary": "Get language options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/meta/reaction": {
+            "get": {
+                "description": "get reaction for an object",
                 "consumes": [
                     "application/json"
                 ],
@@ -6631,21 +6731,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Meta"
                 ],
-                "summary": "get tag one",
+                "summary": "get reaction",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "tag id",
-                        "name": "tag_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "tag name",
-                        "name": "tag_name",
+                        "description": "object_id",
+                        "name": "object_id",
                         "in": "query",
                         "required": true
                     }
@@ -6662,7 +6755,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetTagResp"
+                                            "$ref": "#/definitions/schema.ReactionRespItem"
                                         }
                                     }
                                 }
@@ -6677,7 +6770,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update tag",
+                "description": "update reaction. if not exist, add one",
                 "consumes": [
                     "application/json"
                 ],
@@ -6685,17 +6778,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Meta"
                 ],
-                "summary": "update tag",
+                "summary": "add or update reaction",
                 "parameters": [
                     {
-                        "description": "tag",
+                        "description": "reaction",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateTagReq"
+                            "$ref": "#/definitions/schema.UpdateReactionReq"
                         }
                     }
                 ],
@@ -6707,14 +6800,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/answer/api/v1/moderation/queue": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add tag",
+                "description": "get the flagged posts, comments and users grouped by the object, the objects with more flags come first",
                 "consumes": [
                     "application/json"
                 ],
@@ -6722,75 +6817,98 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Report"
                 ],
-                "summary": "add tag",
+                "summary": "get the flagged objects with their pending flags",
                 "parameters": [
                     {
-                        "description": "tag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AddTagReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
                     {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "delete tag",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Tag"
-                ],
-                "summary": "delete tag",
-                "parameters": [
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
-                        "description": "tag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.RemoveTagReq"
-                        }
+                        "enum": [
+                            "question",
+                            "answer",
+                            "comment",
+                            "user"
+                        ],
+                        "type": "string",
+                        "description": "object type",
+                        "name": "object_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the reason of the flags",
+                        "name": "report_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the flags raised at least these hours ago",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the flags raised within these hours",
+                        "name": "max_age",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.ModerationQueueItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tag/merge": {
-            "post": {
+        "/answer/api/v1/moderation/resolve": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "merge tag",
+                "description": "dismiss the flags, delete or edit the flagged post, or warn the author, all the pending flags of the object are resolved",
                 "consumes": [
                     "application/json"
                 ],
@@ -6798,17 +6916,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Report"
                 ],
-                "summary": "merge tag",
+                "summary": "resolve all the pending flags of the flagged object",
                 "parameters": [
                     {
-                        "description": "tag",
+                        "description": "resolve",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddTagReq"
+                            "$ref": "#/definitions/schema.ResolveModerationQueueItemReq"
                         }
                     }
                 ],
@@ -6816,20 +6934,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.ResolveModerationQueueItemResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tag/recover": {
-            "post": {
+        "/answer/api/v1/notification/page": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "recover delete tag",
+                "description": "get notification list",
                 "consumes": [
                     "application/json"
                 ],
@@ -6837,18 +6967,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Notification"
                 ],
-                "summary": "recover delete tag",
+                "summary": "get notification list",
                 "parameters": [
                     {
-                        "description": "tag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.RecoverTagReq"
-                        }
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "inbox",
+                            "achievement"
+                        ],
+                        "type": "string",
+                        "description": "type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "posts",
+                            "invites",
+                            "votes"
+                        ],
+                        "type": "string",
+                        "description": "inbox_type",
+                        "name": "inbox_type",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -6861,14 +7018,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tag/synonym": {
+        "/answer/api/v1/notification/read/state": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update tag",
+                "description": "ClearUnRead",
                 "consumes": [
                     "application/json"
                 ],
@@ -6876,17 +7033,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Notification"
                 ],
-                "summary": "update tag",
+                "summary": "ClearUnRead",
                 "parameters": [
                     {
-                        "description": "tag",
+                        "description": "NotificationClearIDRequest",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateTagSynonymReq"
+                            "$ref": "#/definitions/schema.NotificationClearIDRequest"
                         }
                     }
                 ],
@@ -6900,109 +7057,181 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tag/synonyms": {
-            "get": {
-                "description": "get tag synonyms",
+        "/answer/api/v1/notification/read/state/all": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ClearUnRead",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Notification"
                 ],
-                "summary": "get tag synonyms",
+                "summary": "ClearUnRead",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "tag id",
-                        "name": "tag_id",
-                        "in": "query",
-                        "required": true
+                        "description": "NotificationClearRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.NotificationClearRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetTagSynonymsResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tags": {
+        "/answer/api/v1/notification/status": {
             "get": {
-                "description": "get tags list by slug name",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "GetRedDot",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Notification"
                 ],
-                "summary": "get tags list",
+                "summary": "GetRedDot",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "DelRedDot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "DelRedDot",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "string collection",
-                        "name": "tags",
-                        "in": "query"
+                        "description": "NotificationClearRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.NotificationClearRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetTagBasicResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tags/following": {
+        "/answer/api/v1/permission": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get following tag list",
+                "description": "check user permission",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Permission"
+                ],
+                "summary": "check user permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "access-token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "question.add",
+                            "question.edit",
+                            "question.edit_without_review",
+                            "question.delete",
+                            "question.close",
+                            "question.reopen",
+                            "question.vote_up",
+                            "question.vote_down",
+                            "question.pin",
+                            "question.unpin",
+                            "question.hide",
+                            "question.show",
+                            "answer.add",
+                            "answer.edit",
+                            "answer.edit_without_review",
+                            "answer.delete",
+                            "answer.accept",
+                            "answer.vote_up",
+                            "answer.vote_down",
+                            "answer.invite_someone_to_answer",
+                            "comment.add",
+                            "comment.edit",
+                            "comment.delete",
+                            "comment.vote_up",
+                            "comment.vote_down",
+                            "report.add",
+                            "tag.add",
+                            "tag.edit",
+                            "tag.edit_slug_name",
+                            "tag.edit_without_review",
+                            "tag.delete",
+                            "tag.synonym",
+                            "link.url_limit",
+                            "vote.detail",
+                            "answer.audit",
+                            "question.audit",
+                            "tag.audit",
+                            "tag.use_reserved_tag"
+                        ],
+                        "type": "string",
+                        "description": "permission key",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "get following tag list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7015,9 +7244,9 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetFollowingTagsResp"
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "boolean"
                                             }
                                         }
                                     }
@@ -7028,106 +7257,103 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tags/page": {
+        "/answer/api/v1/personal/answer/page": {
             "get": {
-                "description": "get tag page",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "list personal answers",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Personal"
                 ],
-                "summary": "get tag page",
+                "summary": "list personal answers",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
+                        "type": "string",
+                        "default": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    },
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "enum": [
+                            "newest",
+                            "score"
+                        ],
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "slug_name",
-                        "name": "slug_name",
-                        "in": "query"
+                        "default": "0",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "popular",
-                            "name",
-                            "newest"
-                        ],
                         "type": "string",
-                        "description": "query condition",
-                        "name": "query_cond",
-                        "in": "query"
+                        "default": "20",
+                        "description": "page_size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetTagPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/action/record": {
+        "/answer/api/v1/personal/collection/page": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ActionRecord",
+                "description": "list personal collections",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Collection"
                 ],
-                "summary": "ActionRecord",
+                "summary": "list personal collections",
                 "parameters": [
                     {
-                        "enum": [
-                            "login",
-                            "e_mail",
-                            "find_pass"
-                        ],
                         "type": "string",
-                        "description": "action",
-                        "name": "action",
+                        "default": "0",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "20",
+                        "description": "page_size",
+                        "name": "page_size",
                         "in": "query",
                         "required": true
                     }
@@ -7136,71 +7362,82 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.ActionRecordResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/email": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "user change email verification",
-                "consumes": [
-                    "application/json"
-                ],
+        "/answer/api/v1/personal/comment/page": {
+            "get": {
+                "description": "user personal comment list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Comment"
                 ],
-                "summary": "user change email verification",
+                "summary": "user personal comment list",
                 "parameters": [
                     {
-                        "description": "UserChangeEmailVerifyReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UserChangeEmailVerifyReq"
-                        }
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetCommentPersonalWithPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/email/change/code": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "send email to the user email then change their email",
+        "/answer/api/v1/personal/qa/top": {
+            "get": {
+                "description": "UserTop",
                 "consumes": [
                     "application/json"
                 ],
@@ -7208,18 +7445,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
                 ],
-                "summary": "send email to the user email then change their email",
+                "summary": "UserTop",
                 "parameters": [
                     {
-                        "description": "UserChangeEmailSendCodeReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UserChangeEmailSendCodeReq"
-                        }
+                        "type": "string",
+                        "default": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -7232,27 +7468,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/email/verification": {
-            "post": {
-                "description": "UserVerifyEmail",
-                "consumes": [
-                    "application/json"
-                ],
+        "/answer/api/v1/personal/rank/page": {
+            "get": {
+                "description": "user personal rank list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Rank"
                 ],
-                "summary": "UserVerifyEmail",
+                "summary": "user personal rank list",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -7267,78 +7510,76 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.UserLoginResp"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/user/email/verification/send": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetRankPersonalPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
-                ],
-                "description": "UserVerifyEmailSend",
-                "consumes": [
-                    "application/json"
-                ],
+                }
+            }
+        },
+        "/answer/api/v1/personal/reputation/page": {
+            "get": {
+                "description": "list every reputation change of the user, the newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Rank"
                 ],
-                "summary": "UserVerifyEmailSend",
+                "summary": "user reputation ledger",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "",
-                        "description": "captcha_id",
-                        "name": "captcha_id",
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "captcha_code",
-                        "name": "captcha_code",
+                        "description": "username",
+                        "name": "username",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/user/info": {
-            "get": {
-                "security": [
+                    },
                     {
-                        "ApiKeyAuth": []
+                        "enum": [
+                            "upvote",
+                            "downvote",
+                            "accept",
+                            "bounty",
+                            "edit",
+                            "moderation",
+                            "reversal"
+                        ],
+                        "type": "string",
+                        "description": "only list the changes of this reason",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
-                "description": "get user info, if user no login response http code is 200, but user info is null",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "GetUserInfoByUserID",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7351,7 +7592,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetCurrentLoginUserInfoResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetReputationLedgerResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -7359,60 +7615,89 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "UserUpdateInfo update user info",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/answer/api/v1/personal/reputation/tag/page": {
+            "get": {
+                "description": "list the reputation the user gained from the votes in each tag, the highest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Rank"
                 ],
-                "summary": "UserUpdateInfo update user info",
+                "summary": "user reputation in the tags",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "access-token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
                     },
                     {
-                        "description": "UpdateInfoRequest",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateInfoRequest"
-                        }
+                        "type": "string",
+                        "description": "only get the reputation in this tag slug name",
+                        "name": "tag",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetUserTagRankResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/info/search": {
+        "/answer/api/v1/personal/user/info": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "SearchUserListByName",
+                "description": "GetOtherUserInfoByUsername",
                 "consumes": [
                     "application/json"
                 ],
@@ -7422,7 +7707,7 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "SearchUserListByName",
+                "summary": "GetOtherUserInfoByUsername",
                 "parameters": [
                     {
                         "type": "string",
@@ -7454,14 +7739,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/interface": {
-            "put": {
+        "/answer/api/v1/personal/vote/page": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "UserUpdateInterface update user interface config",
+                "description": "get user personal votes",
                 "consumes": [
                     "application/json"
                 ],
@@ -7469,40 +7754,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Activity"
                 ],
-                "summary": "UserUpdateInterface update user interface config",
+                "summary": "get user personal votes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "access-token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "UpdateInfoRequest",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserInterfaceRequest"
-                        }
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetVoteWithPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/login/email": {
-            "post": {
-                "description": "UserEmailLogin",
+        "/answer/api/v1/plugin/status": {
+            "get": {
+                "description": "get all plugins status",
                 "consumes": [
                     "application/json"
                 ],
@@ -7510,20 +7818,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "UserEmailLogin",
-                "parameters": [
-                    {
-                        "description": "UserEmailLogin",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UserEmailLoginReq"
-                        }
-                    }
+                    "Plugin"
                 ],
+                "summary": "get all plugins status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7536,7 +7833,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetPluginListResp"
+                                            }
                                         }
                                     }
                                 }
@@ -7546,14 +7846,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/logout": {
-            "get": {
+        "/answer/api/v1/post/lock": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "user logout",
+                "description": "the locked post is still readable but it can not be answered, commented on or edited,\nthe votes are also locked if lock_vote is true. Only for the admins, the moderators\nand the moderators of the tags of the question.",
                 "consumes": [
                     "application/json"
                 ],
@@ -7561,9 +7861,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Post"
+                ],
+                "summary": "lock the question or the answer",
+                "parameters": [
+                    {
+                        "description": "lock",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LockPostReq"
+                        }
+                    }
                 ],
-                "summary": "user logout",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7572,16 +7883,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/notification/config": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user's notification config",
+                "description": "only for the admins, the moderators and the moderators of the tags of the question",
                 "consumes": [
                     "application/json"
                 ],
@@ -7589,17 +7898,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Post"
                 ],
-                "summary": "update user's notification config",
+                "summary": "unlock the question or the answer",
                 "parameters": [
                     {
-                        "description": "UpdateUserNotificationConfigReq",
+                        "description": "unlock",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserNotificationConfigReq"
+                            "$ref": "#/definitions/schema.UnlockPostReq"
                         }
                     }
                 ],
@@ -7611,14 +7920,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/api/v1/post/render": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user's notification config",
+                "description": "render post content",
                 "consumes": [
                     "application/json"
                 ],
@@ -7626,34 +7937,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Upload"
+                ],
+                "summary": "render post content",
+                "parameters": [
+                    {
+                        "description": "PostRenderReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.PostRenderReq"
+                        }
+                    }
                 ],
-                "summary": "get user's notification config",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetUserNotificationConfigResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/notification/unsubscribe": {
+        "/answer/api/v1/post/wiki": {
             "put": {
-                "description": "unsubscribe notification",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "the admins and the moderators can convert the post to the community wiki post,\nonly the admins can convert it back.",
                 "consumes": [
                     "application/json"
                 ],
@@ -7661,17 +7976,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Post"
                 ],
-                "summary": "unsubscribe notification",
+                "summary": "convert the question or the answer to the community wiki post",
                 "parameters": [
                     {
-                        "description": "UserUnsubscribeNotificationReq",
+                        "description": "wiki",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserUnsubscribeNotificationReq"
+                            "$ref": "#/definitions/schema.UpdatePostWikiReq"
                         }
                     }
                 ],
@@ -7685,14 +8000,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/password": {
+        "/answer/api/v1/question": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "UserModifyPassWord",
+                "description": "update question",
                 "consumes": [
                     "application/json"
                 ],
@@ -7700,17 +8015,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
                 ],
-                "summary": "UserModifyPassWord",
+                "summary": "update question",
                 "parameters": [
                     {
-                        "description": "UserModifyPasswordReq",
+                        "description": "question",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserModifyPasswordReq"
+                            "$ref": "#/definitions/schema.QuestionUpdate"
                         }
                     }
                 ],
@@ -7722,11 +8037,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/password/replacement": {
+            },
             "post": {
-                "description": "UseRePassWord",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add question",
                 "consumes": [
                     "application/json"
                 ],
@@ -7734,17 +8052,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
                 ],
-                "summary": "UseRePassWord",
+                "summary": "add question",
                 "parameters": [
                     {
-                        "description": "UserRePassWordRequest",
+                        "description": "question",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserRePassWordRequest"
+                            "$ref": "#/definitions/schema.QuestionAdd"
                         }
                     }
                 ],
@@ -7752,15 +8070,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/password/reset": {
-            "post": {
-                "description": "RetrievePassWord",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete question",
                 "consumes": [
                     "application/json"
                 ],
@@ -7768,17 +8089,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
                 ],
-                "summary": "RetrievePassWord",
+                "summary": "delete question",
                 "parameters": [
                     {
-                        "description": "UserRetrievePassWordRequest",
+                        "description": "question",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserRetrievePassWordRequest"
+                            "$ref": "#/definitions/schema.RemoveQuestionReq"
                         }
                     }
                 ],
@@ -7786,32 +8107,66 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/plugin/config": {
-            "get": {
+        "/answer/api/v1/question/answer": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user plugin config",
+                "description": "add question and answer",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserPlugin"
+                    "Question"
                 ],
-                "summary": "get user plugin config",
+                "summary": "add question and answer",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionAddByAnswer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/bounty": {
+            "get": {
+                "description": "get the bounties of a question, the newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get the bounties of a question",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "plugin_slug_name",
-                        "name": "plugin_slug_name",
+                        "description": "question id",
+                        "name": "question_id",
                         "in": "query",
                         "required": true
                     }
@@ -7828,7 +8183,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.QuestionBountyResp"
+                                            }
                                         }
                                     }
                                 }
@@ -7837,13 +8195,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user plugin config",
+                "description": "offer a bounty on a question, the amount will be deducted from the reputation",
                 "consumes": [
                     "application/json"
                 ],
@@ -7851,17 +8209,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UserPlugin"
+                    "Question"
                 ],
-                "summary": "update user plugin config",
+                "summary": "offer a bounty on a question",
                 "parameters": [
                     {
-                        "description": "UpdatePluginConfigReq",
+                        "description": "bounty",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserPluginConfigReq"
+                            "$ref": "#/definitions/schema.AddQuestionBountyReq"
                         }
                     }
                 ],
@@ -7875,14 +8233,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/plugin/configs": {
-            "get": {
+        "/answer/api/v1/question/bounty/award": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get plugin list that used for user.",
+                "description": "award the bounty of a question to an answer, only the user who offered the bounty can do it",
                 "consumes": [
                     "application/json"
                 ],
@@ -7890,37 +8248,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UserPlugin"
+                    "Question"
+                ],
+                "summary": "award the bounty of a question to an answer",
+                "parameters": [
+                    {
+                        "description": "award",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AwardQuestionBountyReq"
+                        }
+                    }
                 ],
-                "summary": "get plugin list that used for user.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetUserPluginListResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/ranking": {
-            "get": {
-                "description": "get user ranking",
+        "/answer/api/v1/question/guest": {
+            "post": {
+                "description": "add question without logging in, the captcha is required and the question is held for review.\nThe guest is sent an email to claim the question when it is approved if the email is given.",
                 "consumes": [
                     "application/json"
                 ],
@@ -7928,9 +8282,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
+                ],
+                "summary": "add question as a guest",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddGuestQuestionReq"
+                        }
+                    }
                 ],
-                "summary": "get user ranking",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7943,7 +8308,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.UserRankingResp"
+                                            "$ref": "#/definitions/schema.AddGuestQuestionResp"
                                         }
                                     }
                                 }
@@ -7953,9 +8318,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/register/email": {
+        "/answer/api/v1/question/guest/claim": {
             "post": {
-                "description": "UserRegisterByEmail",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "the question is reassigned to the login user by the code in the email sent to the guest",
                 "consumes": [
                     "application/json"
                 ],
@@ -7963,17 +8333,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
                 ],
-                "summary": "UserRegisterByEmail",
+                "summary": "claim the question asked as a guest",
                 "parameters": [
                     {
-                        "description": "UserRegisterReq",
+                        "description": "claim",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserRegisterReq"
+                            "$ref": "#/definitions/schema.ClaimGuestQuestionReq"
                         }
                     }
                 ],
@@ -7989,7 +8359,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                            "$ref": "#/definitions/schema.ClaimGuestQuestionResp"
                                         }
                                     }
                                 }
@@ -7999,9 +8369,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/staff": {
+        "/answer/api/v1/question/info": {
             "get": {
-                "description": "get user staff",
+                "description": "get question details",
                 "consumes": [
                     "application/json"
                 ],
@@ -8009,21 +8379,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Question"
                 ],
-                "summary": "get user staff",
+                "summary": "get question details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "page_size",
-                        "name": "page_size",
+                        "default": "1",
+                        "description": "Question TagID",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
@@ -8032,32 +8396,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetUserStaffResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/vote/down": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "add vote",
+        "/answer/api/v1/question/invite": {
+            "get": {
+                "description": "get question invite user info",
                 "consumes": [
                     "application/json"
                 ],
@@ -8065,50 +8412,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "Question"
                 ],
-                "summary": "vote down",
+                "summary": "get question invite user info",
                 "parameters": [
                     {
-                        "description": "vote",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.VoteReq"
-                        }
+                        "type": "string",
+                        "default": "1",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.VoteResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/vote/up": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add vote",
+                "description": "update question invite user",
                 "consumes": [
                     "application/json"
                 ],
@@ -8116,19 +8448,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "Question"
                 ],
-                "summary": "vote up",
+                "summary": "update question invite user",
                 "parameters": [
                     {
-                        "description": "vote",
+                        "description": "question",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.VoteReq"
+                            "$ref": "#/definitions/schema.QuestionUpdateInviteUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
+                }
+            }
+        },
+        "/answer/api/v1/question/link": {
+            "get": {
+                "description": "get question link",
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get question link",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "in_days",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "active",
+                            "hot",
+                            "score",
+                            "unanswered",
+                            "recommend",
+                            "frequent",
+                            "trending"
+                        ],
+                        "type": "string",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "question_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -8142,7 +8533,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.VoteResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QuestionPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -8152,29 +8558,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/custom.css": {
-            "get": {
-                "description": "get site custom CSS",
-                "produces": [
-                    "text/css"
-                ],
-                "tags": [
-                    "site"
-                ],
-                "summary": "get site custom CSS",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
+        "/answer/api/v1/question/operation": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
-                }
-            }
-        },
-        "/installation/base-info": {
-            "post": {
-                "description": "init base info",
+                ],
+                "description": "Operation question \\n operation [pin unpin hide show] \\n pin or unpin within the tag if the tag is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -8182,17 +8573,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Question"
                 ],
-                "summary": "init base info",
+                "summary": "Operation question",
                 "parameters": [
                     {
-                        "description": "InitBaseInfoReq",
+                        "description": "question",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/install.InitBaseInfoReq"
+                            "$ref": "#/definitions/schema.OperationQuestionReq"
                         }
                     }
                 ],
@@ -8206,9 +8597,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/config-file/check": {
-            "post": {
-                "description": "check config file if exist when installation",
+        "/answer/api/v1/question/page": {
+            "get": {
+                "description": "get questions by page, the cursor pagination returns pager.CursorPageModel with the next cursor instead of the count",
                 "consumes": [
                     "application/json"
                 ],
@@ -8216,9 +8607,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Question"
+                ],
+                "summary": "get questions by page",
+                "parameters": [
+                    {
+                        "description": "QuestionPageReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionPageReq"
+                        }
+                    }
                 ],
-                "summary": "check config file if exist when installation",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -8231,7 +8633,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QuestionPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -8241,9 +8658,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/db/check": {
-            "post": {
-                "description": "check database if exist when installation",
+        "/answer/api/v1/question/recommend/page": {
+            "get": {
+                "description": "get recommend questions by page",
                 "consumes": [
                     "application/json"
                 ],
@@ -8251,17 +8668,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Question"
                 ],
-                "summary": "check database if exist when installation",
+                "summary": "get recommend questions by page",
                 "parameters": [
                     {
-                        "description": "CheckDatabaseReq",
+                        "description": "QuestionPageReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/install.CheckDatabaseReq"
+                            "$ref": "#/definitions/schema.QuestionPageReq"
                         }
                     }
                 ],
@@ -8277,7 +8694,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QuestionPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -8287,9 +8719,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/init": {
+        "/answer/api/v1/question/recover": {
             "post": {
-                "description": "init environment",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "recover deleted question",
                 "consumes": [
                     "application/json"
                 ],
@@ -8297,17 +8734,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Question"
                 ],
-                "summary": "init environment",
+                "summary": "recover deleted question",
                 "parameters": [
                     {
-                        "description": "CheckDatabaseReq",
+                        "description": "question",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/install.CheckDatabaseReq"
+                            "$ref": "#/definitions/schema.QuestionRecoverReq"
                         }
                     }
                 ],
@@ -8321,45 +8758,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/language/config": {
+        "/answer/api/v1/question/related": {
             "get": {
-                "description": "get installation language config mapping",
+                "description": "get the questions related to the question by the shared tags and terms, ranked by relevance and recency",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lang"
+                    "Question"
                 ],
-                "summary": "get installation language config mapping",
+                "summary": "get the questions related to the question by the shared tags and terms",
                 "parameters": [
+                    {
+                        "maximum": 20,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "installation language",
-                        "name": "lang",
+                        "name": "question_id",
                         "in": "query",
                         "required": true
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/installation/language/options": {
-            "get": {
-                "description": "get installation language options",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Lang"
-                ],
-                "summary": "get installation language options",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -8374,7 +8797,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/translator.LangOption"
+                                                "$ref": "#/definitions/schema.RelatedQuestionResp"
                                             }
                                         }
                                     }
@@ -8385,14 +8808,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/personal/question/page": {
-            "get": {
+        "/answer/api/v1/question/reopen": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list personal questions",
+                "description": "reopen question",
                 "consumes": [
                     "application/json"
                 ],
@@ -8400,42 +8823,130 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Personal"
+                    "Question"
                 ],
-                "summary": "list personal questions",
+                "summary": "reopen question",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    },
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReopenQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/schedule": {
+            "put": {
+                "security": [
                     {
-                        "enum": [
-                            "newest",
-                            "score"
-                        ],
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "change the publish time of the scheduled question before it is published",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "update question schedule",
+                "parameters": [
                     {
-                        "type": "string",
-                        "default": "0",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
+                        "description": "question schedule",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateQuestionScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "cancel the schedule of the question, it is kept unpublished until it is scheduled again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "cancel question schedule",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.CancelQuestionScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/similar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "fuzzy query similar questions based on title",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "fuzzy query similar questions based on title",
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "20",
-                        "description": "page_size",
-                        "name": "page_size",
+                        "default": "string",
+                        "description": "title",
+                        "name": "title",
                         "in": "query",
                         "required": true
                     }
@@ -8450,16 +8961,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/robots.txt": {
+        "/answer/api/v1/question/similar/tag": {
             "get": {
-                "description": "get site robots information",
+                "description": "Search Similar Question",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "site"
+                    "Question"
+                ],
+                "summary": "Search Similar Question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "question_id",
+                        "name": "question_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "get site robots information",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -8469,1286 +8993,7064 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "constant.NotificationChannelKey": {
-            "type": "string",
-            "enum": [
-                "email"
-            ],
-            "x-enum-varnames": [
-                "EmailChannel"
-            ]
         },
-        "constant.Privilege": {
-            "type": "object",
-            "properties": {
-                "key": {
-                    "type": "string"
-                },
-                "label": {
-                    "type": "string"
-                },
+        "/answer/api/v1/question/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Close question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "Close question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.CloseQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get tag list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetTagBasicResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/reasons": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reasons by object type and action",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason"
+                ],
+                "summary": "get reasons by object type and action",
+                "parameters": [
+                    {
+                        "enum": [
+                            "question",
+                            "answer",
+                            "comment",
+                            "user"
+                        ],
+                        "type": "string",
+                        "description": "object_type",
+                        "name": "object_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "status",
+                            "close",
+                            "flag",
+                            "review"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/render/config": {
+            "get": {
+                "description": "GetRenderConfig",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PluginRender"
+                ],
+                "summary": "GetRenderConfig",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/plugin.RenderConfig"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/report": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add report \u003cbr\u003e source (question, answer, comment, user)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "add report",
+                "parameters": [
+                    {
+                        "description": "report",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddReportReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/report/review": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "review report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "review report",
+                "parameters": [
+                    {
+                        "description": "flag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReviewReportReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/report/unreviewed/post": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get unreviewed report post page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "get unreviewed report post page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetReportListPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/review/pending/post": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "update review",
+                "parameters": [
+                    {
+                        "description": "review",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/review/pending/post/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get unreviewed post page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "get unreviewed post page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "object_id",
+                        "name": "object_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetUnreviewedPostPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/reviewing/type": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reviewing type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get reviewing type",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetReviewingTypeResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions": {
+            "get": {
+                "description": "get revision list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get revision list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetRevisionResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/audit": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "revision audit operation:approve or reject",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "revision audit",
+                "parameters": [
+                    {
+                        "description": "audit",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RevisionAuditReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/diff": {
+            "get": {
+                "description": "get the diff of title, content and tags between two revisions of the object",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get the diff between two revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the older revision id",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the newer revision id",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetRevisionDiffResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/edit/check": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "check can update revision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "check can update revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "restore the object to the revision, a new revision is created with the same content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "roll back to the revision",
+                "parameters": [
+                    {
+                        "description": "rollback",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RollbackRevisionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.RollbackRevisionResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/unreviewed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get unreviewed revision list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get unreviewed revision list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "page id",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetUnreviewedRevisionResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/saml/metadata": {
+            "get": {
+                "description": "get the SAML service provider metadata to register at the identity provider",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "PluginConnector"
+                ],
+                "summary": "get the SAML service provider metadata",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "search object, the free text can be combined with the operators:\n[tag] or tag:tag, user:username or user:me, score:3 score:\u003e3 score:\u003e=3 or score:=3,\ncreated:2024-01-01 created:2024-01-01..2024-02-01 created:\u003e2024-01-01 or created:\u003c=2024-01-01,\nis:question is:answer is:answered, views:10, answers:0, hasaccepted:no, isaccepted:yes and inquestion:id.\nThe unsupported operators and the operators with invalid values return the bad request error,\nthe operators that filter the results are returned in the operators of the response.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "search object",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "query string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "active",
+                            "score",
+                            "relevance"
+                        ],
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/search/desc": {
+            "get": {
+                "description": "get search description",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "get search description",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/siteinfo": {
+            "get": {
+                "description": "get site info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/siteinfo/legal": {
+            "get": {
+                "description": "get site legal info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site legal info",
+                "parameters": [
+                    {
+                        "enum": [
+                            "tos",
+                            "privacy"
+                        ],
+                        "type": "string",
+                        "description": "legal information type",
+                        "name": "info_type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetSiteLegalInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag": {
+            "get": {
+                "description": "get tag one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tag id",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag name",
+                        "name": "tag_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetTagResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "update tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "add tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "delete tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "merge tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "merge tag",
+                "parameters": [
+                    {
+                        "description": "merge tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.MergeTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/recover": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "recover delete tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "recover delete tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RecoverTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/synonym": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "update tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateTagSynonymReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/synonyms": {
+            "get": {
+                "description": "get tag synonyms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag synonyms",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetTagSynonymsResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tags": {
+            "get": {
+                "description": "get tags list by slug name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tags list",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "string collection",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetTagBasicResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tags/following": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get following tag list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get following tag list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetFollowingTagsResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tags/page": {
+            "get": {
+                "description": "get tag page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "slug_name",
+                        "name": "slug_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "popular",
+                            "name",
+                            "newest"
+                        ],
+                        "type": "string",
+                        "description": "query condition",
+                        "name": "query_cond",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetTagPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/2fa": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get two-factor authentication status of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get two-factor authentication status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserTwoFactorStatusResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "disable two-factor authentication with a code of the authenticator or a recovery code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "UserTwoFactorDisableReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserTwoFactorDisableReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/2fa/activation": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "verify the first code of the authenticator to enable two-factor authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "activate two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "UserTwoFactorActivateReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserTwoFactorActivateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/2fa/enrollment": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "generate a secret and recovery codes, the two-factor authentication is enabled after the first code is verified",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "enroll two-factor authentication",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserTwoFactorEnrollResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/action/record": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ActionRecord",
+                "tags": [
+                    "User"
+                ],
+                "summary": "ActionRecord",
+                "parameters": [
+                    {
+                        "enum": [
+                            "login",
+                            "e_mail",
+                            "find_pass"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.ActionRecordResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/api-key": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "issue a personal api key for the login user, the key is only returned once.\nThe key is sent in the Authorization header as a Bearer token and acts as the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "issue a personal api key for the login user",
+                "parameters": [
+                    {
+                        "description": "api key",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddUserAPIKeyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.AddAPIKeyResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "revoke the personal api key of the login user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "revoke the personal api key of the login user",
+                "parameters": [
+                    {
+                        "description": "api key",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RevokeUserAPIKeyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/api-key/all": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the personal api keys of the login user, the keys are masked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get the personal api keys of the login user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetAPIKeyResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/data-export": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "compile all the data of the login user into a zip file in background, the download link is sent to the email of the user when it is ready",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "export the data of the login user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/data-export/download": {
+            "get": {
+                "description": "download the zip file of the user data export with the token in the email",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "download the export of the user data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the token in the email",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "user change email verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "user change email verification",
+                "parameters": [
+                    {
+                        "description": "UserChangeEmailVerifyReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserChangeEmailVerifyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email/change/code": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "send email to the user email then change their email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "send email to the user email then change their email",
+                "parameters": [
+                    {
+                        "description": "UserChangeEmailSendCodeReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserChangeEmailSendCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email/verification": {
+            "post": {
+                "description": "UserVerifyEmail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserVerifyEmail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email/verification/send": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserVerifyEmailSend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserVerifyEmailSend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "captcha_id",
+                        "name": "captcha_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "captcha_code",
+                        "name": "captcha_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/impersonation/stop": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "stop the impersonation session, the access token of it can not be used anymore",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "stop viewing as the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user info, if user no login response http code is 200, but user info is null",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "GetUserInfoByUserID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetCurrentLoginUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserUpdateInfo update user info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserUpdateInfo update user info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "access-token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateInfoRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/info/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "SearchUserListByName",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "SearchUserListByName",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetOtherUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/interface": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserUpdateInterface update user interface config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserUpdateInterface update user interface config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "access-token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateInfoRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserInterfaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/leaderboard": {
+            "get": {
+                "description": "list the users who gained the most reputation in the window, the most first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rank"
+                ],
+                "summary": "reputation leaderboard",
+                "parameters": [
+                    {
+                        "enum": [
+                            "today",
+                            "week",
+                            "month",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "the period the reputation is gained in, default is all",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only count the reputation gained from the questions with this tag slug name",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetReputationLeaderboardResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/login/2fa": {
+            "post": {
+                "description": "finish the password login with a code of the authenticator or a recovery code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "finish the password login with two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "UserTwoFactorLoginReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserTwoFactorLoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/login/email": {
+            "post": {
+                "description": "UserEmailLogin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserEmailLogin",
+                "parameters": [
+                    {
+                        "description": "UserEmailLogin",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserEmailLoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/logout": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "user logout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "user logout",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/logout/all": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "log out all the sessions of the login user on every device, including the current one. The personal api keys are removed too if revoke_api_keys is true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "log out all the sessions of the user",
+                "parameters": [
+                    {
+                        "description": "UserLogoutEverywhereReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserLogoutEverywhereReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/mute": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "the questions of the muted user are hidden from the question lists and the comments are collapsed,\nonly for the login user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "mute the user",
+                "parameters": [
+                    {
+                        "description": "muted user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.MuteUserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "remove the user from the ignore list of the login user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "unmute the user",
+                "parameters": [
+                    {
+                        "description": "muted user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.MuteUserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/mute/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the ignore list of the login user, the latest muted users come first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get the muted users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.MutedUserResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/notification/config": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user's notification config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "update user's notification config",
+                "parameters": [
+                    {
+                        "description": "UpdateUserNotificationConfigReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserNotificationConfigReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user's notification config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user's notification config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetUserNotificationConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/notification/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user's notification preferences of each event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user's notification preferences of each event",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetNotificationPreferencesResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user's notification preferences of each event, in-app, email, or nothing if both are disabled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "update user's notification preferences of each event",
+                "parameters": [
+                    {
+                        "description": "UpdateNotificationPreferencesReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateNotificationPreferencesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/notification/unsubscribe": {
+            "put": {
+                "description": "unsubscribe notification with the signed unsubscribe token in the email link, no login is required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "unsubscribe notification",
+                "parameters": [
+                    {
+                        "description": "UserUnsubscribeNotificationReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserUnsubscribeNotificationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/notification/unsubscribe/one-click": {
+            "post": {
+                "description": "the List-Unsubscribe-Post endpoint of RFC 8058 that the mail clients post to, no login is required",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "unsubscribe notification in one click",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "unsubscribe token",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserModifyPassWord",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserModifyPassWord",
+                "parameters": [
+                    {
+                        "description": "UserModifyPasswordReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserModifyPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/password/replacement": {
+            "post": {
+                "description": "UseRePassWord",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UseRePassWord",
+                "parameters": [
+                    {
+                        "description": "UserRePassWordRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserRePassWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/password/reset": {
+            "post": {
+                "description": "RetrievePassWord",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "RetrievePassWord",
+                "parameters": [
+                    {
+                        "description": "UserRetrievePassWordRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserRetrievePassWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/plugin/config": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user plugin config",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserPlugin"
+                ],
+                "summary": "get user plugin config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "plugin_slug_name",
+                        "name": "plugin_slug_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user plugin config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserPlugin"
+                ],
+                "summary": "update user plugin config",
+                "parameters": [
+                    {
+                        "description": "UpdatePluginConfigReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserPluginConfigReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/plugin/configs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get plugin list that used for user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserPlugin"
+                ],
+                "summary": "get plugin list that used for user.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetUserPluginListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/ranking": {
+            "get": {
+                "description": "get user ranking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user ranking",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserRankingResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/register/email": {
+            "post": {
+                "description": "UserRegisterByEmail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserRegisterByEmail",
+                "parameters": [
+                    {
+                        "description": "UserRegisterReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserRegisterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/saved-search": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update the saved search of the login user, the alert only notifies the content created after\nit is enabled or the query is changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "update the saved search",
+                "parameters": [
+                    {
+                        "description": "saved search",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateSavedSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SavedSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "save the search query with a name, the alert of it notifies the login user of the new matching content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "save the search query",
+                "parameters": [
+                    {
+                        "description": "saved search",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddSavedSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SavedSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "remove the saved search of the login user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "remove the saved search",
+                "parameters": [
+                    {
+                        "description": "saved search",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveSavedSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/saved-search/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the saved searches of the login user, the latest saved ones come first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "get the saved searches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.SavedSearchResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/staff": {
+            "get": {
+                "description": "get user staff",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page_size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetUserStaffResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/vote/down": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add vote",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Activity"
+                ],
+                "summary": "vote down",
+                "parameters": [
+                    {
+                        "description": "vote",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.VoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.VoteResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/vote/up": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add vote",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Activity"
+                ],
+                "summary": "vote up",
+                "parameters": [
+                    {
+                        "description": "vote",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.VoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.VoteResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/custom.css": {
+            "get": {
+                "description": "get site custom CSS",
+                "produces": [
+                    "text/css"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site custom CSS",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/{format}/questions": {
+            "get": {
+                "description": "the feed of the newest questions in rss or atom format",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "the feed of the newest questions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "description": "feed format",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/{format}/tags/{tag}": {
+            "get": {
+                "description": "the feed of the newest questions of the tag and its synonyms in rss or atom format",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "the feed of the newest questions of the tag",
+                "parameters": [
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "description": "feed format",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag slug name",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/{format}/users/{username}": {
+            "get": {
+                "description": "the feed of the newest questions and answers of the user in rss or atom format",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "the feed of the newest questions and answers of the user",
+                "parameters": [
+                    {
+                        "enum": [
+                            "rss",
+                            "atom"
+                        ],
+                        "type": "string",
+                        "description": "feed format",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "returns 200 if the process is alive, the dependencies are not checked",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/base-info": {
+            "post": {
+                "description": "init base info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "init base info",
+                "parameters": [
+                    {
+                        "description": "InitBaseInfoReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/install.InitBaseInfoReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/config-file/check": {
+            "post": {
+                "description": "check config file if exist when installation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "check config file if exist when installation",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/db/check": {
+            "post": {
+                "description": "check database if exist when installation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "check database if exist when installation",
+                "parameters": [
+                    {
+                        "description": "CheckDatabaseReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/install.CheckDatabaseReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/init": {
+            "post": {
+                "description": "init environment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "init environment",
+                "parameters": [
+                    {
+                        "description": "CheckDatabaseReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/install.CheckDatabaseReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/language/config": {
+            "get": {
+                "description": "get installation language config mapping",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lang"
+                ],
+                "summary": "get installation language config mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "installation language",
+                        "name": "lang",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/language/options": {
+            "get": {
+                "description": "get installation language options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lang"
+                ],
+                "summary": "get installation language options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/translator.LangOption"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/personal/question/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "list personal questions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personal"
+                ],
+                "summary": "list personal questions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "score"
+                        ],
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "20",
+                        "description": "page_size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "check the database, the cache and the search backend, returns 503 with the status of each of them if not ready",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReadinessResp"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReadinessResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/robots.txt": {
+            "get": {
+                "description": "get site robots information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site robots information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "constant.EmailTemplateType": {
+            "type": "string",
+            "enum": [
+                "new_answer",
+                "new_comment",
+                "invited_you_to_answer",
+                "new_question",
+                "notification_event"
+            ],
+            "x-enum-varnames": [
+                "EmailTemplateTypeNewAnswer",
+                "EmailTemplateTypeNewComment",
+                "EmailTemplateTypeInvitedAnswer",
+                "EmailTemplateTypeNewQuestion",
+                "EmailTemplateTypeNotificationEvent"
+            ]
+        },
+        "constant.NotificationChannelKey": {
+            "type": "string",
+            "enum": [
+                "email",
+                "in_app"
+            ],
+            "x-enum-varnames": [
+                "EmailChannel",
+                "InAppChannel"
+            ]
+        },
+        "constant.NotificationSource": {
+            "type": "string",
+            "enum": [
+                "inbox",
+                "all_new_question",
+                "all_new_question_for_following_tags",
+                "answer_to_my_question",
+                "comment_on_my_post",
+                "mention",
+                "accepted",
+                "badge_earned",
+                "notification_digest"
+            ],
+            "x-enum-varnames": [
+                "InboxSource",
+                "AllNewQuestionSource",
+                "AllNewQuestionForFollowingTagsSource",
+                "AnswerToMyQuestionSource",
+                "CommentOnMyPostSource",
+                "MentionSource",
+                "AcceptedSource",
+                "BadgeEarnedSource",
+                "NotificationDigestSource"
+            ]
+        },
+        "constant.Privilege": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "diff.Line": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "equal, insert or delete",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BadgeLevel": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "BadgeLevelBronze",
+                "BadgeLevelSilver",
+                "BadgeLevelGold"
+            ]
+        },
+        "entity.BadgeRule": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "metric": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RespBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "http code",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "response data"
+                },
+                "msg": {
+                    "description": "response message",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "reason key",
+                    "type": "string"
+                }
+            }
+        },
+        "install.CheckConfigFileResp": {
+            "type": "object",
+            "properties": {
+                "config_file_exist": {
+                    "type": "boolean"
+                },
+                "db_connection_success": {
+                    "type": "boolean"
+                },
+                "db_table_exist": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "install.CheckDatabaseReq": {
+            "type": "object",
+            "required": [
+                "db_type"
+            ],
+            "properties": {
+                "db_charset": {
+                    "description": "DbCharset MySQL charset, use DefaultMySQLCharset if not set",
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "db_collation": {
+                    "description": "DbCollation MySQL collation, use the default collation of the charset if not set",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "db_file": {
+                    "type": "string"
+                },
+                "db_host": {
+                    "type": "string"
+                },
+                "db_name": {
+                    "type": "string"
+                },
+                "db_password": {
+                    "type": "string"
+                },
+                "db_schema": {
+                    "description": "DbSchema PostgreSQL schema the tables are created in, use DefaultPgSQLSchema if not set",
+                    "type": "string",
+                    "maxLength": 63
+                },
+                "db_timeout": {
+                    "description": "DbTimeout connection timeout in seconds, use DefaultDBConnectTimeout if not set",
+                    "type": "integer",
+                    "maximum": 300,
+                    "minimum": 1
+                },
+                "db_type": {
+                    "type": "string",
+                    "enum": [
+                        "postgres",
+                        "sqlite3",
+                        "mysql",
+                        "mariadb"
+                    ]
+                },
+                "db_username": {
+                    "type": "string"
+                },
+                "ssl_cert": {
+                    "type": "string"
+                },
+                "ssl_enabled": {
+                    "type": "boolean"
+                },
+                "ssl_key": {
+                    "type": "string"
+                },
+                "ssl_mode": {
+                    "type": "string"
+                },
+                "ssl_root_cert": {
+                    "type": "string"
+                }
+            }
+        },
+        "install.InitBaseInfoReq": {
+            "type": "object",
+            "required": [
+                "contact_email",
+                "email",
+                "external_content_display",
+                "lang",
+                "name",
+                "password",
+                "site_name",
+                "site_url"
+            ],
+            "properties": {
+                "contact_email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "external_content_display": {
+                    "type": "string",
+                    "enum": [
+                        "always_display",
+                        "ask_before_display"
+                    ]
+                },
+                "lang": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "login_required": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 8
+                },
+                "site_name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "site_url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "pager.PageModel": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "list": {}
+            }
+        },
+        "plugin.EmbedConfig": {
+            "type": "object",
+            "properties": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "platform": {
+                    "type": "string"
+                }
+            }
+        },
+        "plugin.RenderConfig": {
+            "type": "object",
+            "properties": {
+                "select_theme": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AIConversationAdminDeleteReq": {
+            "type": "object",
+            "required": [
+                "conversation_id"
+            ],
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AIConversationAdminDetailResp": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.AIConversationRecord"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.AIConversationUserInfo"
+                }
+            }
+        },
+        "schema.AIConversationAdminListItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "helpful_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "unhelpful_count": {
+                    "type": "integer"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.AIConversationUserInfo"
+                }
+            }
+        },
+        "schema.AIConversationDetailResp": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.AIConversationRecord"
+                    }
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.AIConversationListItem": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AIConversationRecord": {
+            "type": "object",
+            "properties": {
+                "chat_completion_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "helpful": {
+                    "type": "integer"
+                },
+                "reasoning_content": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "unhelpful": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.AIConversationUserInfo": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AIConversationVoteReq": {
+            "type": "object",
+            "required": [
+                "chat_completion_id",
+                "vote_type"
+            ],
+            "properties": {
+                "cancel": {
+                    "type": "boolean"
+                },
+                "chat_completion_id": {
+                    "type": "string"
+                },
+                "vote_type": {
+                    "type": "string",
+                    "enum": [
+                        "helpful",
+                        "unhelpful"
+                    ]
+                }
+            }
+        },
+        "schema.AIPromptConfig": {
+            "type": "object",
+            "properties": {
+                "en_us": {
+                    "type": "string"
+                },
+                "zh_cn": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AcceptAnswerReq": {
+            "type": "object",
+            "required": [
+                "question_id"
+            ],
+            "properties": {
+                "answer_id": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "schema.ActObjectInfo": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "main_tag_slug_name": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ActObjectTimeline": {
+            "type": "object",
+            "properties": {
+                "activity_id": {
+                    "type": "string"
+                },
+                "activity_type": {
+                    "type": "string"
+                },
+                "cancelled": {
+                    "type": "boolean"
+                },
+                "cancelled_at": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "object_id": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "revision_id": {
+                    "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
+        "schema.ActionRecordResp": {
+            "type": "object",
+            "properties": {
+                "captcha_id": {
+                    "type": "string"
+                },
+                "captcha_img": {
+                    "type": "string"
+                },
+                "verify": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.AddAPIKeyReq": {
+            "type": "object",
+            "required": [
+                "description",
+                "scope"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "read-only",
+                        "global"
+                    ]
+                }
+            }
+        },
+        "schema.AddAPIKeyResp": {
+            "type": "object",
+            "properties": {
+                "access_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AddCommentReq": {
+            "type": "object",
+            "required": [
+                "object_id",
+                "original_text"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "mention_username_list": {
+                    "description": "@ user id list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
+                },
+                "original_text": {
+                    "description": "original comment content",
+                    "type": "string",
+                    "maxLength": 600,
+                    "minLength": 2
+                },
+                "reply_comment_id": {
+                    "description": "reply comment id",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AddGuestQuestionReq": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "captcha_token": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 0
+                },
+                "email": {
+                    "description": "Email the guest is told when the question is published and can claim it, it is optional",
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagItem"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 6
+                }
+            }
+        },
+        "schema.AddGuestQuestionResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "pending": {
+                    "description": "Pending the question is awaiting review",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.AddQuestionBountyReq": {
+            "type": "object",
+            "required": [
+                "amount",
+                "question_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 50
+                },
+                "question_id": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "schema.AddReportReq": {
+            "type": "object",
+            "required": [
+                "object_id",
+                "report_type"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "description": "captcha_id",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "report content",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "object_id": {
+                    "description": "object id, it is the user id if the object type is user",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "object_type": {
+                    "description": "object type, it is only required when flagging a user",
+                    "type": "string",
+                    "enum": [
+                        "user"
+                    ]
+                },
+                "report_type": {
+                    "description": "report type",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.AddRoleReq": {
+            "type": "object",
+            "required": [
+                "name",
+                "powers"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "powers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.AddRoleResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.AddSavedSearchReq": {
+            "type": "object",
+            "required": [
+                "name",
+                "query"
+            ],
+            "properties": {
+                "alert_email": {
+                    "description": "AlertEmail also notify the user by email, only works when the alert is enabled",
+                    "type": "boolean"
+                },
+                "alert_enabled": {
+                    "description": "AlertEnabled notify the user in the inbox when the new content matches the query",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "order": {
+                    "type": "string",
+                    "enum": [
+                        "newest",
+                        "active",
+                        "score",
+                        "relevance"
+                    ]
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "minLength": 1
+                }
+            }
+        },
+        "schema.AddTagModeratorReq": {
+            "type": "object",
+            "required": [
+                "tag_id",
+                "username"
+            ],
+            "properties": {
+                "tag_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "schema.AddTagReq": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "original_text",
+                "slug_name"
+            ],
+            "properties": {
+                "display_name": {
+                    "description": "display_name",
+                    "type": "string",
+                    "maxLength": 35
+                },
+                "original_text": {
+                    "description": "original text",
+                    "type": "string",
+                    "maxLength": 65536
+                },
+                "slug_name": {
+                    "description": "slug_name",
+                    "type": "string",
+                    "maxLength": 35
+                }
+            }
+        },
+        "schema.AddUserAPIKeyReq": {
+            "type": "object",
+            "required": [
+                "description",
+                "scope"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "rate_limit": {
+                    "description": "RateLimit the max requests per minute, 0 means the default limit",
+                    "type": "integer",
+                    "maximum": 6000,
+                    "minimum": 0
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "read-only",
+                        "read-write"
+                    ]
+                }
+            }
+        },
+        "schema.AddUserReq": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 8
+                }
+            }
+        },
+        "schema.AddUsersReq": {
+            "type": "object",
+            "properties": {
+                "users": {
+                    "description": "users info line by line",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AdminDeletedPostInfo": {
+            "type": "object",
+            "properties": {
+                "create_time": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "integer"
+                },
+                "excerpt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "purge_at": {
+                    "description": "PurgeAt the post will be deleted permanently after this time, 0 means never",
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
+        "schema.AdminRestoreDeletedPostReq": {
+            "type": "object",
+            "required": [
+                "object_id"
+            ],
+            "properties": {
+                "object_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AdminUpdateAnswerStatusReq": {
+            "type": "object",
+            "required": [
+                "answer_id",
+                "status"
+            ],
+            "properties": {
+                "answer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "deleted"
+                    ]
+                }
+            }
+        },
+        "schema.AdminUpdateQuestionStatusReq": {
+            "type": "object",
+            "required": [
+                "question_id",
+                "status"
+            ],
+            "properties": {
+                "question_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "closed",
+                        "deleted"
+                    ]
+                }
+            }
+        },
+        "schema.AnswerAddReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "captcha_token": {
+                    "description": "CaptchaToken the token of the captcha widget of the third-party provider",
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 6
+                },
+                "question_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AnswerInfo": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer"
+                },
+                "collected": {
+                    "type": "boolean"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "integer"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lock": {
+                    "$ref": "#/definitions/schema.PostLockInfo"
+                },
+                "member_actions": {
+                    "description": "MemberActions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "question_info": {
+                    "$ref": "#/definitions/schema.QuestionInfoResp"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "update_time": {
+                    "type": "integer"
+                },
+                "update_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "vote_count": {
+                    "type": "integer"
+                },
+                "vote_status": {
+                    "type": "string"
+                },
+                "wiki": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.AnswerUpdateReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 6
+                },
+                "edit_summary": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AvatarInfo": {
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "gravatar": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "type": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "schema.AwardQuestionBountyReq": {
+            "type": "object",
+            "required": [
+                "answer_id",
+                "question_id"
+            ],
+            "properties": {
+                "answer_id": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "question_id": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "schema.BadgeListInfo": {
+            "type": "object",
+            "properties": {
+                "award_count": {
+                    "description": "badge award count",
+                    "type": "integer"
+                },
+                "earned_count": {
+                    "description": "badge earned count",
+                    "type": "integer"
+                },
+                "icon": {
+                    "description": "badge icon",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "badge id",
+                    "type": "string"
+                },
+                "is_single": {
+                    "description": "badge is single or multiple",
+                    "type": "boolean"
+                },
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.BadgeStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "BadgeStatusActive",
+                "BadgeStatusInactive"
+            ]
+        },
+        "schema.CancelQuestionScheduleReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ClaimGuestQuestionReq": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "schema.ClaimGuestQuestionResp": {
+            "type": "object",
+            "properties": {
+                "question_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CloseQuestionReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "close_msg": {
+                    "description": "close_type",
+                    "type": "string"
+                },
+                "close_type": {
+                    "description": "close_type",
+                    "type": "integer"
+                },
+                "duplicate_question_id": {
+                    "description": "the original question when closing as a duplicate, the question url in close_msg also works",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CollectionSwitchReq": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "object_id"
+            ],
+            "properties": {
+                "bookmark": {
+                    "type": "boolean"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "object_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CollectionSwitchResp": {
+            "type": "object",
+            "properties": {
+                "object_collection_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.ConfigField": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ConfigFieldOption"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "ui_options": {
+                    "$ref": "#/definitions/schema.ConfigFieldUIOptions"
+                },
+                "value": {}
+            }
+        },
+        "schema.ConfigFieldOption": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
                 "value": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ConfigFieldUIOptions": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/schema.UIOptionAction"
+                },
+                "class_name": {
+                    "type": "string"
+                },
+                "field_class_name": {
+                    "type": "string"
+                },
+                "input_type": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "placeholder": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ConnectorInfoResp": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ConnectorUserInfoResp": {
+            "type": "object",
+            "properties": {
+                "binding": {
+                    "type": "boolean"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CustomEmailTemplate": {
+            "type": "object",
+            "properties": {
+                "html_body": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text_body": {
+                    "description": "TextBody the plain text alternative of the html body, the email only has the html body if it is empty",
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/constant.EmailTemplateType"
+                }
+            }
+        },
+        "schema.DeleteAPIKeyReq": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.DeletePermanentlyReq": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "users",
+                        "questions",
+                        "answers"
+                    ]
+                }
+            }
+        },
+        "schema.DependencyHealth": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "description": "how long the check takes in milliseconds",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "ok, unavailable, timeout or skipped",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.DraftResp": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_type": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt the unix time the draft is saved",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.DuplicateQuestionInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.EditUserProfileReq": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "email",
+                "user_id"
+            ],
+            "properties": {
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                }
+            }
+        },
+        "schema.EmailTemplateVariable": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.EraseUserReq": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ExternalLoginBindingUserSendEmailReq": {
+            "type": "object",
+            "required": [
+                "binding_key",
+                "email"
+            ],
+            "properties": {
+                "binding_key": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "must": {
+                    "description": "If must is true, whatever email if exists, try to bind user.\nIf must is false, when email exist, will only be prompted with a warning.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.ExternalLoginBindingUserSendEmailResp": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "email_exist_and_must_be_confirmed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.ExternalLoginUnbindingReq": {
+            "type": "object",
+            "required": [
+                "external_id"
+            ],
+            "properties": {
+                "external_id": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "schema.FollowReq": {
+            "type": "object",
+            "required": [
+                "object_id"
+            ],
+            "properties": {
+                "is_cancel": {
+                    "description": "is cancel",
+                    "type": "boolean"
+                },
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.FollowResp": {
+            "type": "object",
+            "properties": {
+                "follows": {
+                    "description": "the followers of object",
+                    "type": "integer"
+                },
+                "is_followed": {
+                    "description": "if user is followed object will be true,otherwise false",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.GetAIModelResp": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "owned_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetAIProviderResp": {
+            "type": "object",
+            "properties": {
+                "default_api_host": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetAPIKeyResp": {
+            "type": "object",
+            "properties": {
+                "access_key": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "integer"
+                },
+                "rate_limit": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetAnswerInfoResp": {
+            "type": "object",
+            "properties": {
+                "info": {
+                    "$ref": "#/definitions/schema.AnswerInfo"
+                },
+                "question": {
+                    "$ref": "#/definitions/schema.QuestionInfoResp"
+                }
+            }
+        },
+        "schema.GetAuditLogResp": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "object_id": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetBadgeInfoResp": {
+            "type": "object",
+            "properties": {
+                "award_count": {
+                    "description": "badge award count",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "badge description",
+                    "type": "string"
+                },
+                "earned_count": {
+                    "description": "badge earned count",
+                    "type": "integer"
+                },
+                "icon": {
+                    "description": "badge icon",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "badge id",
+                    "type": "string"
+                },
+                "is_single": {
+                    "description": "badge is single or multiple",
+                    "type": "boolean"
+                },
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
+                    "type": "string"
                 }
             }
         },
-        "entity.BadgeLevel": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3
-            ],
-            "x-enum-varnames": [
-                "BadgeLevelBronze",
-                "BadgeLevelSilver",
-                "BadgeLevelGold"
-            ]
+        "schema.GetBadgeListPagedResp": {
+            "type": "object",
+            "properties": {
+                "award_count": {
+                    "description": "badge award count",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "badge description",
+                    "type": "string"
+                },
+                "earned": {
+                    "description": "badge earned count",
+                    "type": "boolean"
+                },
+                "group_name": {
+                    "description": "badge group name",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "badge icon",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "badge id",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "badge rule, only for the badges awarded by the declarative rule",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeRule"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "badge status",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.BadgeStatus"
+                        }
+                    ]
+                }
+            }
         },
-        "handler.RespBody": {
+        "schema.GetBadgeListResp": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "http code",
+                "badges": {
+                    "description": "badge list info",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.BadgeListInfo"
+                    }
+                },
+                "group_name": {
+                    "description": "badge group name",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetCommentPersonalWithPageResp": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "description": "answer id",
+                    "type": "string"
+                },
+                "comment_id": {
+                    "description": "comment id",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "content",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "create time",
                     "type": "integer"
                 },
-                "data": {
-                    "description": "response data"
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
                 },
-                "msg": {
-                    "description": "response message",
+                "object_type": {
+                    "description": "object type",
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "tag",
+                        "comment"
+                    ]
+                },
+                "question_id": {
+                    "description": "question id",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "title",
+                    "type": "string"
+                },
+                "url_title": {
+                    "description": "url title",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetCommentResp": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "description": "comment id",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "create time",
+                    "type": "integer"
+                },
+                "deleted": {
+                    "description": "the top-level comment is deleted, it is kept as a placeholder of its replies",
+                    "type": "boolean"
+                },
+                "is_vote": {
+                    "description": "current user if already vote this comment",
+                    "type": "boolean"
+                },
+                "member_actions": {
+                    "description": "MemberActions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
+                },
+                "muted": {
+                    "description": "the author is muted by the current user, the comment should be collapsed",
+                    "type": "boolean"
+                },
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
+                },
+                "original_text": {
+                    "description": "original comment content",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "the id of the top-level comment of the thread, empty for the top-level comment",
+                    "type": "string"
+                },
+                "parsed_text": {
+                    "description": "parsed comment content",
+                    "type": "string"
+                },
+                "replies": {
+                    "description": "the replies of the top-level comment, the earlier replies come first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.GetCommentResp"
+                    }
+                },
+                "reply_comment_id": {
+                    "description": "reply comment id",
+                    "type": "string"
+                },
+                "reply_user_display_name": {
+                    "description": "reply user display name",
+                    "type": "string"
+                },
+                "reply_user_id": {
+                    "description": "reply user id",
+                    "type": "string"
+                },
+                "reply_user_status": {
+                    "description": "reply user status",
+                    "type": "string"
+                },
+                "reply_username": {
+                    "description": "reply user username",
+                    "type": "string"
+                },
+                "user_avatar": {
+                    "description": "user avatar",
+                    "type": "string"
+                },
+                "user_display_name": {
+                    "description": "user display name",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "user id",
+                    "type": "string"
+                },
+                "user_status": {
+                    "description": "user status",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "username",
+                    "type": "string"
+                },
+                "vote_count": {
+                    "description": "user vote amount",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.GetCurrentLoginUserInfoResp": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "access token",
+                    "type": "string"
+                },
+                "answer_count": {
+                    "description": "answer count",
+                    "type": "integer"
+                },
+                "authority_group": {
+                    "description": "authority group",
+                    "type": "integer"
+                },
+                "avatar": {
+                    "$ref": "#/definitions/schema.AvatarInfo"
+                },
+                "bio": {
+                    "description": "bio markdown",
+                    "type": "string"
+                },
+                "bio_html": {
+                    "description": "bio html",
+                    "type": "string"
+                },
+                "color_scheme": {
+                    "description": "Color scheme",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "create time",
+                    "type": "integer"
+                },
+                "display_name": {
+                    "description": "display name",
                     "type": "string"
                 },
-                "reason": {
-                    "description": "reason key",
+                "e_mail": {
+                    "description": "email",
                     "type": "string"
-                }
-            }
-        },
-        "install.CheckConfigFileResp": {
-            "type": "object",
-            "properties": {
-                "config_file_exist": {
-                    "type": "boolean"
                 },
-                "db_connection_success": {
-                    "type": "boolean"
+                "follow_count": {
+                    "description": "follow count",
+                    "type": "integer"
                 },
-                "db_table_exist": {
+                "have_password": {
+                    "description": "user have password",
                     "type": "boolean"
-                }
-            }
-        },
-        "install.CheckDatabaseReq": {
-            "type": "object",
-            "required": [
-                "db_type"
-            ],
-            "properties": {
-                "db_file": {
-                    "type": "string"
                 },
-                "db_host": {
+                "id": {
+                    "description": "user id",
                     "type": "string"
                 },
-                "db_name": {
+                "impersonation": {
+                    "description": "Impersonation it is set if an admin is viewing as the user in the current session",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.ImpersonationInfo"
+                        }
+                    ]
+                },
+                "language": {
+                    "description": "language",
                     "type": "string"
                 },
-                "db_password": {
+                "last_login_date": {
+                    "description": "last login date",
+                    "type": "integer"
+                },
+                "location": {
+                    "description": "location",
                     "type": "string"
                 },
-                "db_type": {
-                    "type": "string",
-                    "enum": [
-                        "postgres",
-                        "sqlite3",
-                        "mysql"
-                    ]
+                "mail_status": {
+                    "description": "mail status(1 pass 2 to be verified)",
+                    "type": "integer"
                 },
-                "db_username": {
+                "mobile": {
+                    "description": "mobile",
                     "type": "string"
                 },
-                "ssl_cert": {
+                "notice_status": {
+                    "description": "notice status(1 on 2off)",
+                    "type": "integer"
+                },
+                "question_count": {
+                    "description": "question count",
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "rank",
+                    "type": "integer"
+                },
+                "role_id": {
+                    "description": "role id",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "user status",
                     "type": "string"
                 },
-                "ssl_enabled": {
+                "suspended_until": {
+                    "description": "suspended until timestamp",
+                    "type": "integer"
+                },
+                "two_factor_required": {
+                    "description": "two-factor authentication is required to finish the login, the other fields are empty",
                     "type": "boolean"
                 },
-                "ssl_key": {
+                "two_factor_token": {
+                    "description": "two-factor challenge token used to finish the login",
                     "type": "string"
                 },
-                "ssl_mode": {
+                "username": {
+                    "description": "username",
                     "type": "string"
                 },
-                "ssl_root_cert": {
+                "visit_token": {
+                    "description": "visit token",
+                    "type": "string"
+                },
+                "website": {
+                    "description": "website",
                     "type": "string"
                 }
             }
         },
-        "install.InitBaseInfoReq": {
+        "schema.GetEmailTemplatesResp": {
             "type": "object",
-            "required": [
-                "contact_email",
-                "email",
-                "external_content_display",
-                "lang",
-                "name",
-                "password",
-                "site_name",
-                "site_url"
-            ],
             "properties": {
-                "contact_email": {
-                    "type": "string",
-                    "maxLength": 500
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.CustomEmailTemplate"
+                    }
                 },
-                "email": {
-                    "type": "string",
-                    "maxLength": 500
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constant.EmailTemplateType"
+                    }
                 },
-                "external_content_display": {
-                    "type": "string",
-                    "enum": [
-                        "always_display",
-                        "ask_before_display"
-                    ]
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.EmailTemplateVariable"
+                    }
+                }
+            }
+        },
+        "schema.GetFollowingTagsResp": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "description": "display name",
+                    "type": "string"
                 },
-                "lang": {
-                    "type": "string",
-                    "maxLength": 30
+                "main_tag_slug_name": {
+                    "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
+                    "type": "string"
                 },
-                "login_required": {
+                "recommend": {
                     "type": "boolean"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 8
+                "reserved": {
+                    "type": "boolean"
                 },
-                "site_name": {
-                    "type": "string",
-                    "maxLength": 30
+                "slug_name": {
+                    "description": "slug name",
+                    "type": "string"
                 },
-                "site_url": {
-                    "type": "string",
-                    "maxLength": 512
+                "tag_id": {
+                    "description": "tag id",
+                    "type": "string"
                 }
             }
         },
-        "pager.PageModel": {
+        "schema.GetJobDeadLetterResp": {
             "type": "object",
             "properties": {
-                "count": {
+                "attempts": {
                     "type": "integer"
                 },
-                "list": {}
-            }
-        },
-        "plugin.EmbedConfig": {
-            "type": "object",
-            "properties": {
-                "enable": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "integer"
                 },
-                "platform": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "plugin.RenderConfig": {
+        "schema.GetJobQueueStatusResp": {
             "type": "object",
             "properties": {
-                "select_theme": {
-                    "type": "string"
+                "dead_letters": {
+                    "description": "the total amount of the jobs failed after all the attempts",
+                    "type": "integer"
+                },
+                "depth": {
+                    "description": "the total amount of the jobs waiting to run",
+                    "type": "integer"
+                },
+                "queues": {
+                    "description": "the jobs of each type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.JobQueueStatus"
+                    }
                 }
             }
         },
-        "schema.AIConversationAdminDeleteReq": {
+        "schema.GetNotificationPreferencesResp": {
             "type": "object",
-            "required": [
-                "conversation_id"
-            ],
             "properties": {
-                "conversation_id": {
+                "email_frequency": {
+                    "description": "immediate, hourly or daily",
                     "type": "string"
+                },
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.NotificationPreference"
+                    }
                 }
             }
         },
-        "schema.AIConversationAdminDetailResp": {
+        "schema.GetObjectTimelineResp": {
             "type": "object",
             "properties": {
-                "conversation_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "integer"
+                "object_info": {
+                    "$ref": "#/definitions/schema.ActObjectInfo"
                 },
-                "records": {
+                "timeline": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.AIConversationRecord"
+                        "$ref": "#/definitions/schema.ActObjectTimeline"
                     }
-                },
-                "topic": {
-                    "type": "string"
-                },
-                "user_info": {
-                    "$ref": "#/definitions/schema.AIConversationUserInfo"
                 }
             }
         },
-        "schema.AIConversationAdminListItem": {
+        "schema.GetOtherUserInfoByUsernameResp": {
             "type": "object",
             "properties": {
+                "answer_count": {
+                    "description": "answer count",
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "avatar",
+                    "type": "string"
+                },
+                "bio": {
+                    "description": "bio markdown",
+                    "type": "string"
+                },
+                "bio_html": {
+                    "description": "bio html",
+                    "type": "string"
+                },
                 "created_at": {
+                    "description": "create time",
+                    "type": "integer"
+                },
+                "display_name": {
+                    "description": "display name",
+                    "type": "string"
+                },
+                "follow_count": {
+                    "description": "email\nfollow count",
                     "type": "integer"
                 },
-                "helpful_count": {
+                "id": {
+                    "description": "user id",
+                    "type": "string"
+                },
+                "last_login_date": {
+                    "description": "last login date",
                     "type": "integer"
                 },
-                "id": {
+                "location": {
+                    "description": "location",
                     "type": "string"
                 },
-                "topic": {
+                "mobile": {
+                    "description": "mobile",
                     "type": "string"
                 },
-                "unhelpful_count": {
+                "question_count": {
+                    "description": "question count",
                     "type": "integer"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.AIConversationUserInfo"
-                }
-            }
-        },
-        "schema.AIConversationDetailResp": {
-            "type": "object",
-            "properties": {
-                "conversation_id": {
-                    "type": "string"
-                },
-                "created_at": {
+                "rank": {
+                    "description": "rank",
                     "type": "integer"
                 },
-                "records": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.AIConversationRecord"
-                    }
+                "status": {
+                    "type": "string"
                 },
-                "topic": {
+                "status_msg": {
                     "type": "string"
                 },
-                "updated_at": {
+                "suspended_until": {
+                    "description": "suspended until timestamp",
                     "type": "integer"
+                },
+                "username": {
+                    "description": "username",
+                    "type": "string"
+                },
+                "website": {
+                    "description": "website",
+                    "type": "string"
                 }
             }
         },
-        "schema.AIConversationListItem": {
+        "schema.GetOtherUserInfoResp": {
             "type": "object",
             "properties": {
-                "conversation_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "integer"
-                },
-                "topic": {
-                    "type": "string"
+                "info": {
+                    "$ref": "#/definitions/schema.GetOtherUserInfoByUsernameResp"
                 }
             }
         },
-        "schema.AIConversationRecord": {
+        "schema.GetPluginConfigResp": {
             "type": "object",
             "properties": {
-                "chat_completion_id": {
-                    "type": "string"
+                "config_fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ConfigField"
+                    }
                 },
-                "content": {
+                "description": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "integer"
-                },
-                "helpful": {
-                    "type": "integer"
-                },
-                "reasoning_content": {
+                "name": {
                     "type": "string"
                 },
-                "role": {
+                "slug_name": {
                     "type": "string"
                 },
-                "unhelpful": {
-                    "type": "integer"
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "schema.AIConversationUserInfo": {
+        "schema.GetPluginListResp": {
             "type": "object",
             "properties": {
-                "avatar": {
+                "description": {
                     "type": "string"
                 },
-                "display_name": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "have_config": {
+                    "type": "boolean"
+                },
+                "link": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "rank": {
-                    "type": "integer"
+                "slug_name": {
+                    "type": "string"
                 },
-                "username": {
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "schema.AIConversationVoteReq": {
+        "schema.GetPowerResp": {
             "type": "object",
-            "required": [
-                "chat_completion_id",
-                "vote_type"
-            ],
             "properties": {
-                "cancel": {
-                    "type": "boolean"
-                },
-                "chat_completion_id": {
+                "description": {
                     "type": "string"
                 },
-                "vote_type": {
-                    "type": "string",
-                    "enum": [
-                        "helpful",
-                        "unhelpful"
-                    ]
-                }
-            }
-        },
-        "schema.AIPromptConfig": {
-            "type": "object",
-            "properties": {
-                "en_us": {
+                "name": {
                     "type": "string"
                 },
-                "zh_cn": {
+                "power_type": {
                     "type": "string"
                 }
             }
         },
-        "schema.AcceptAnswerReq": {
+        "schema.GetPrivilegesConfigResp": {
             "type": "object",
-            "required": [
-                "question_id"
-            ],
             "properties": {
-                "answer_id": {
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PrivilegeOption"
+                    }
                 },
-                "question_id": {
-                    "type": "string",
-                    "maxLength": 30
+                "selected_level": {
+                    "$ref": "#/definitions/schema.PrivilegeLevel"
                 }
             }
         },
-        "schema.ActObjectInfo": {
+        "schema.GetRankPersonalPageResp": {
             "type": "object",
             "properties": {
                 "answer_id": {
+                    "description": "answer id",
                     "type": "string"
                 },
-                "display_name": {
+                "content": {
+                    "description": "content",
                     "type": "string"
                 },
-                "main_tag_slug_name": {
+                "created_at": {
+                    "description": "create time",
+                    "type": "integer"
+                },
+                "object_id": {
+                    "description": "object id",
                     "type": "string"
                 },
                 "object_type": {
-                    "type": "string"
+                    "description": "object type",
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "tag",
+                        "comment"
+                    ]
                 },
                 "question_id": {
+                    "description": "question id",
+                    "type": "string"
+                },
+                "rank_type": {
+                    "description": "rank type",
                     "type": "string"
                 },
+                "reputation": {
+                    "description": "reputation",
+                    "type": "integer"
+                },
                 "title": {
+                    "description": "title",
                     "type": "string"
                 },
-                "username": {
+                "url_title": {
+                    "description": "url title",
                     "type": "string"
                 }
             }
         },
-        "schema.ActObjectTimeline": {
+        "schema.GetReportListPageResp": {
             "type": "object",
             "properties": {
-                "activity_id": {
-                    "type": "string"
-                },
-                "activity_type": {
-                    "type": "string"
-                },
-                "cancelled": {
+                "answer_accepted": {
                     "type": "boolean"
                 },
-                "cancelled_at": {
+                "answer_count": {
                     "type": "integer"
                 },
-                "comment": {
+                "answer_id": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "integer"
+                "author_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "object_id": {
+                "comment_id": {
                     "type": "string"
                 },
-                "object_type": {
-                    "type": "string"
+                "created_at": {
+                    "type": "integer"
                 },
-                "revision_id": {
+                "flag_id": {
                     "type": "string"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                }
-            }
-        },
-        "schema.ActionRecordResp": {
-            "type": "object",
-            "properties": {
-                "captcha_id": {
+                "object_id": {
                     "type": "string"
                 },
-                "captcha_img": {
-                    "type": "string"
+                "object_show_status": {
+                    "type": "integer"
                 },
-                "verify": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "schema.AddAPIKeyReq": {
-            "type": "object",
-            "required": [
-                "description",
-                "scope"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 150
+                "object_status": {
+                    "type": "integer"
                 },
-                "scope": {
+                "object_type": {
                     "type": "string",
                     "enum": [
-                        "read-only",
-                        "global"
-                    ]
-                }
-            }
-        },
-        "schema.AddAPIKeyResp": {
-            "type": "object",
-            "properties": {
-                "access_key": {
+                        "question",
+                        "answer",
+                        "comment"
+                    ]
+                },
+                "original_text": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.AddCommentReq": {
-            "type": "object",
-            "required": [
-                "object_id",
-                "original_text"
-            ],
-            "properties": {
-                "captcha_code": {
+                },
+                "parsed_text": {
                     "type": "string"
                 },
-                "captcha_id": {
+                "question_id": {
                     "type": "string"
                 },
-                "mention_username_list": {
-                    "description": "@ user id list",
+                "reason": {
+                    "$ref": "#/definitions/schema.ReasonItem"
+                },
+                "reason_content": {
+                    "type": "string"
+                },
+                "submit_at": {
+                    "type": "integer"
+                },
+                "submitter_user": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schema.TagResp"
                     }
                 },
-                "object_id": {
-                    "description": "object id",
+                "title": {
                     "type": "string"
                 },
-                "original_text": {
-                    "description": "original comment content",
-                    "type": "string",
-                    "maxLength": 600,
-                    "minLength": 2
-                },
-                "reply_comment_id": {
-                    "description": "reply comment id",
+                "url_title": {
                     "type": "string"
                 }
             }
         },
-        "schema.AddReportReq": {
+        "schema.GetReputationLeaderboardResp": {
             "type": "object",
-            "required": [
-                "object_id",
-                "report_type"
-            ],
             "properties": {
-                "captcha_code": {
+                "avatar": {
+                    "description": "avatar",
                     "type": "string"
                 },
-                "captcha_id": {
-                    "description": "captcha_id",
+                "display_name": {
+                    "description": "display name",
                     "type": "string"
                 },
-                "content": {
-                    "description": "report content",
-                    "type": "string",
-                    "maxLength": 500
+                "position": {
+                    "description": "position in the leaderboard, starts from 1",
+                    "type": "integer"
                 },
-                "object_id": {
-                    "description": "object id",
-                    "type": "string",
-                    "maxLength": 20
+                "rank": {
+                    "description": "the reputation of the user",
+                    "type": "integer"
                 },
-                "report_type": {
-                    "description": "report type",
+                "reputation_gained": {
+                    "description": "the reputation gained in the window",
                     "type": "integer"
+                },
+                "username": {
+                    "description": "username",
+                    "type": "string"
                 }
             }
         },
-        "schema.AddTagReq": {
+        "schema.GetReputationLedgerResp": {
             "type": "object",
-            "required": [
-                "display_name",
-                "original_text",
-                "slug_name"
-            ],
             "properties": {
-                "display_name": {
-                    "description": "display_name",
-                    "type": "string",
-                    "maxLength": 35
+                "answer_id": {
+                    "description": "answer id",
+                    "type": "string"
                 },
-                "original_text": {
-                    "description": "original text",
-                    "type": "string",
-                    "maxLength": 65536
+                "balance": {
+                    "description": "The reputation after this change. It is only returned without reason filter,\nthe newest balance always equals the reputation of the user.",
+                    "type": "integer"
                 },
-                "slug_name": {
-                    "description": "slug_name",
-                    "type": "string",
-                    "maxLength": 35
-                }
-            }
-        },
-        "schema.AddUserReq": {
-            "type": "object",
-            "required": [
-                "display_name",
-                "email",
-                "password"
-            ],
-            "properties": {
-                "display_name": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
+                "created_at": {
+                    "description": "the time of the change",
+                    "type": "integer"
                 },
-                "email": {
-                    "type": "string",
-                    "maxLength": 500
+                "delta": {
+                    "description": "reputation delta",
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 8
-                }
-            }
-        },
-        "schema.AddUsersReq": {
-            "type": "object",
-            "properties": {
-                "users": {
-                    "description": "users info line by line",
-                    "type": "string"
-                }
-            }
-        },
-        "schema.AdminUpdateAnswerStatusReq": {
-            "type": "object",
-            "required": [
-                "answer_id",
-                "status"
-            ],
-            "properties": {
-                "answer_id": {
+                "object_id": {
+                    "description": "object id",
                     "type": "string"
                 },
-                "status": {
+                "object_type": {
+                    "description": "object type",
                     "type": "string",
                     "enum": [
-                        "available",
-                        "deleted"
+                        "question",
+                        "answer",
+                        "tag",
+                        "comment"
                     ]
-                }
-            }
-        },
-        "schema.AdminUpdateQuestionStatusReq": {
-            "type": "object",
-            "required": [
-                "question_id",
-                "status"
-            ],
-            "properties": {
+                },
                 "question_id": {
+                    "description": "question id",
                     "type": "string"
                 },
-                "status": {
+                "rank_type": {
+                    "description": "readable activity of the change, for reversal it is the activity that was reversed",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "reason of the change",
                     "type": "string",
                     "enum": [
-                        "available",
-                        "closed",
-                        "deleted"
+                        "upvote",
+                        "downvote",
+                        "accept",
+                        "bounty",
+                        "edit",
+                        "moderation",
+                        "reversal",
+                        "other"
                     ]
+                },
+                "title": {
+                    "description": "title",
+                    "type": "string"
+                },
+                "url_title": {
+                    "description": "url title",
+                    "type": "string"
                 }
             }
         },
-        "schema.AnswerAddReq": {
+        "schema.GetReviewingTypeResp": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "captcha_code": {
+                "label": {
                     "type": "string"
                 },
-                "captcha_id": {
+                "name": {
                     "type": "string"
                 },
-                "content": {
-                    "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 6
-                },
-                "question_id": {
-                    "type": "string"
+                "todo_amount": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.AnswerInfo": {
+        "schema.GetRevisionDiffResp": {
             "type": "object",
             "properties": {
-                "accepted": {
-                    "type": "integer"
-                },
-                "collected": {
-                    "type": "boolean"
-                },
                 "content": {
-                    "type": "string"
-                },
-                "create_time": {
-                    "type": "integer"
-                },
-                "html": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "member_actions": {
-                    "description": "MemberActions",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                        "$ref": "#/definitions/diff.Line"
                     }
                 },
-                "question_id": {
-                    "type": "string"
-                },
-                "question_info": {
-                    "$ref": "#/definitions/schema.QuestionInfoResp"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "update_time": {
-                    "type": "integer"
-                },
-                "update_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "from": {
+                    "$ref": "#/definitions/schema.GetRevisionResp"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/diff.Line"
+                    }
                 },
-                "vote_count": {
-                    "type": "integer"
+                "title": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/diff.Line"
+                    }
                 },
-                "vote_status": {
-                    "type": "string"
+                "to": {
+                    "$ref": "#/definitions/schema.GetRevisionResp"
                 }
             }
         },
-        "schema.AnswerUpdateReq": {
+        "schema.GetRevisionResp": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
+                "content": {},
+                "create_at": {
+                    "type": "integer"
                 },
-                "captcha_id": {
+                "id": {
                     "type": "string"
                 },
-                "content": {
-                    "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 6
+                "object_id": {
+                    "type": "string"
                 },
-                "edit_summary": {
+                "reason": {
                     "type": "string"
                 },
-                "id": {
+                "reject_reason": {
                     "type": "string"
                 },
+                "status": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string"
+                },
+                "url_title": {
+                    "type": "string"
+                },
+                "use_id": {
+                    "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 }
             }
         },
-        "schema.AvatarInfo": {
+        "schema.GetRoleResp": {
             "type": "object",
             "properties": {
-                "custom": {
-                    "type": "string",
-                    "maxLength": 200
+                "built_in": {
+                    "description": "BuiltIn the built-in roles user, admin and moderator can not be modified or deleted",
+                    "type": "boolean"
                 },
-                "gravatar": {
-                    "type": "string",
-                    "maxLength": 200
+                "description": {
+                    "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "maxLength": 100
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "powers": {
+                    "description": "Powers the permissions of the role",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "schema.BadgeListInfo": {
+        "schema.GetSMTPConfigResp": {
             "type": "object",
             "properties": {
-                "award_count": {
-                    "description": "badge award count",
-                    "type": "integer"
+                "encryption": {
+                    "description": "\"\" SSL TLS",
+                    "type": "string"
                 },
-                "earned_count": {
-                    "description": "badge earned count",
-                    "type": "integer"
+                "from_email": {
+                    "type": "string"
                 },
-                "icon": {
-                    "description": "badge icon",
+                "from_name": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "badge id",
+                "smtp_authentication": {
+                    "type": "boolean"
+                },
+                "smtp_host": {
                     "type": "string"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
+                "smtp_password": {
+                    "type": "string"
                 },
-                "name": {
-                    "description": "badge name",
+                "smtp_port": {
+                    "type": "integer"
+                },
+                "smtp_username": {
                     "type": "string"
                 }
             }
         },
-        "schema.BadgeStatus": {
-            "type": "string",
-            "enum": [
-                "active",
-                "inactive"
-            ],
-            "x-enum-varnames": [
-                "BadgeStatusActive",
-                "BadgeStatusInactive"
-            ]
-        },
-        "schema.CloseQuestionReq": {
+        "schema.GetSiteLegalInfoResp": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "close_msg": {
-                    "description": "close_type",
+                "privacy_policy_original_text": {
                     "type": "string"
                 },
-                "close_type": {
-                    "description": "close_type",
-                    "type": "integer"
+                "privacy_policy_parsed_text": {
+                    "type": "string"
                 },
-                "id": {
+                "terms_of_service_original_text": {
+                    "type": "string"
+                },
+                "terms_of_service_parsed_text": {
                     "type": "string"
                 }
             }
         },
-        "schema.CollectionSwitchReq": {
+        "schema.GetTagBasicResp": {
             "type": "object",
-            "required": [
-                "group_id",
-                "object_id"
-            ],
             "properties": {
-                "bookmark": {
+                "display_name": {
+                    "type": "string"
+                },
+                "recommend": {
                     "type": "boolean"
                 },
-                "group_id": {
+                "reserved": {
+                    "type": "boolean"
+                },
+                "slug_name": {
                     "type": "string"
                 },
-                "object_id": {
+                "tag_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.CollectionSwitchResp": {
+        "schema.GetTagPageResp": {
             "type": "object",
             "properties": {
-                "object_collection_count": {
+                "created_at": {
+                    "description": "created time",
                     "type": "integer"
-                }
-            }
-        },
-        "schema.ConfigField": {
-            "type": "object",
-            "properties": {
+                },
                 "description": {
+                    "description": "description",
                     "type": "string"
                 },
-                "name": {
+                "display_name": {
+                    "description": "display_name",
                     "type": "string"
                 },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.ConfigFieldOption"
-                    }
+                "excerpt": {
+                    "description": "excerpt",
+                    "type": "string"
                 },
-                "required": {
+                "follow_count": {
+                    "description": "follower amount",
+                    "type": "integer"
+                },
+                "is_follower": {
+                    "description": "is follower",
                     "type": "boolean"
                 },
-                "title": {
+                "original_text": {
+                    "description": "original text",
                     "type": "string"
                 },
-                "type": {
+                "parsed_text": {
+                    "description": "parsed_text",
                     "type": "string"
                 },
-                "ui_options": {
-                    "$ref": "#/definitions/schema.ConfigFieldUIOptions"
+                "question_count": {
+                    "description": "question amount",
+                    "type": "integer"
                 },
-                "value": {}
-            }
-        },
-        "schema.ConfigFieldOption": {
-            "type": "object",
-            "properties": {
-                "label": {
+                "recommend": {
+                    "type": "boolean"
+                },
+                "reserved": {
+                    "type": "boolean"
+                },
+                "slug_name": {
+                    "description": "slug_name",
                     "type": "string"
                 },
-                "value": {
+                "tag_id": {
+                    "description": "tag_id",
                     "type": "string"
+                },
+                "updated_at": {
+                    "description": "updated time",
+                    "type": "integer"
                 }
             }
         },
-        "schema.ConfigFieldUIOptions": {
+        "schema.GetTagResp": {
             "type": "object",
             "properties": {
-                "action": {
-                    "$ref": "#/definitions/schema.UIOptionAction"
+                "created_at": {
+                    "type": "integer"
                 },
-                "class_name": {
+                "description": {
                     "type": "string"
                 },
-                "field_class_name": {
+                "display_name": {
                     "type": "string"
                 },
-                "input_type": {
+                "excerpt": {
                     "type": "string"
                 },
-                "label": {
-                    "type": "string"
+                "follow_count": {
+                    "type": "integer"
                 },
-                "placeholder": {
-                    "type": "string"
+                "is_follower": {
+                    "type": "boolean"
                 },
-                "rows": {
+                "main_tag_slug_name": {
+                    "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
                     "type": "string"
                 },
-                "text": {
-                    "type": "string"
+                "member_actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
                 },
-                "variant": {
-                    "type": "string"
-                }
-            }
-        },
-        "schema.ConnectorInfoResp": {
-            "type": "object",
-            "properties": {
-                "icon": {
+                "original_text": {
                     "type": "string"
                 },
-                "link": {
+                "parsed_text": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "schema.ConnectorUserInfoResp": {
-            "type": "object",
-            "properties": {
-                "binding": {
+                "question_count": {
+                    "type": "integer"
+                },
+                "recommend": {
+                    "type": "boolean"
+                },
+                "reserved": {
                     "type": "boolean"
                 },
-                "external_id": {
+                "slug_name": {
                     "type": "string"
                 },
-                "icon": {
+                "status": {
                     "type": "string"
                 },
-                "link": {
+                "tag_id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "schema.DeleteAPIKeyReq": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "updated_at": {
                     "type": "integer"
                 }
             }
         },
-        "schema.DeletePermanentlyReq": {
+        "schema.GetTagSynonymsResp": {
             "type": "object",
-            "required": [
-                "type"
-            ],
             "properties": {
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "users",
-                        "questions",
-                        "answers"
-                    ]
+                "member_actions": {
+                    "description": "MemberActions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
+                },
+                "synonyms": {
+                    "description": "synonyms",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagSynonym"
+                    }
                 }
             }
         },
-        "schema.EditUserProfileReq": {
+        "schema.GetUnreviewedPostPageResp": {
             "type": "object",
-            "required": [
-                "display_name",
-                "email",
-                "user_id"
-            ],
             "properties": {
-                "display_name": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
+                "answer_id": {
+                    "type": "string"
                 },
-                "email": {
-                    "type": "string",
-                    "maxLength": 500
+                "author_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "user_id": {
+                "comment_id": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
-                }
-            }
-        },
-        "schema.ExternalLoginBindingUserSendEmailReq": {
-            "type": "object",
-            "required": [
-                "binding_key",
-                "email"
-            ],
-            "properties": {
-                "binding_key": {
-                    "type": "string",
-                    "maxLength": 100
+                "created_at": {
+                    "type": "integer"
                 },
-                "email": {
+                "object_id": {
+                    "type": "string"
+                },
+                "object_show_status": {
+                    "type": "integer"
+                },
+                "object_status": {
+                    "type": "integer"
+                },
+                "object_type": {
                     "type": "string",
-                    "maxLength": 512
+                    "enum": [
+                        "question",
+                        "answer",
+                        "comment"
+                    ]
                 },
-                "must": {
-                    "description": "If must is true, whatever email if exists, try to bind user.\nIf must is false, when email exist, will only be prompted with a warning.",
-                    "type": "boolean"
-                }
-            }
-        },
-        "schema.ExternalLoginBindingUserSendEmailResp": {
-            "type": "object",
-            "properties": {
-                "access_token": {
+                "original_text": {
                     "type": "string"
                 },
-                "email_exist_and_must_be_confirmed": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "schema.ExternalLoginUnbindingReq": {
-            "type": "object",
-            "required": [
-                "external_id"
-            ],
-            "properties": {
-                "external_id": {
-                    "type": "string",
-                    "maxLength": 128
-                }
-            }
-        },
-        "schema.FollowReq": {
-            "type": "object",
-            "required": [
-                "object_id"
-            ],
-            "properties": {
-                "is_cancel": {
-                    "description": "is cancel",
-                    "type": "boolean"
+                "parsed_text": {
+                    "type": "string"
                 },
-                "object_id": {
-                    "description": "object id",
+                "question_id": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.FollowResp": {
-            "type": "object",
-            "properties": {
-                "follows": {
-                    "description": "the followers of object",
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "review_id": {
                     "type": "integer"
                 },
-                "is_followed": {
-                    "description": "if user is followed object will be true,otherwise false",
-                    "type": "boolean"
-                }
-            }
-        },
-        "schema.GetAIModelResp": {
-            "type": "object",
-            "properties": {
-                "created": {
+                "submit_at": {
                     "type": "integer"
                 },
-                "id": {
+                "submitter_display_name": {
                     "type": "string"
                 },
-                "object": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagResp"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
-                "owned_by": {
+                "url_title": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetAIProviderResp": {
+        "schema.GetUnreviewedRevisionResp": {
             "type": "object",
             "properties": {
-                "default_api_host": {
-                    "type": "string"
+                "info": {
+                    "$ref": "#/definitions/schema.UnreviewedRevisionInfoInfo"
                 },
-                "display_name": {
+                "type": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "unreviewed_info": {
+                    "$ref": "#/definitions/schema.GetRevisionResp"
                 }
             }
         },
-        "schema.GetAPIKeyResp": {
+        "schema.GetUserActivationResp": {
             "type": "object",
             "properties": {
-                "access_key": {
+                "activation_url": {
                     "type": "string"
-                },
-                "created_at": {
+                }
+            }
+        },
+        "schema.GetUserBadgeAwardListResp": {
+            "type": "object",
+            "properties": {
+                "earned_count": {
+                    "description": "badge award count, it is at most 1 for the single badge",
                     "type": "integer"
                 },
-                "description": {
+                "icon": {
+                    "description": "badge icon",
                     "type": "string"
                 },
                 "id": {
-                    "type": "integer"
+                    "description": "badge id",
+                    "type": "string"
                 },
-                "last_used_at": {
-                    "type": "integer"
+                "is_single": {
+                    "description": "badge is single or multiple",
+                    "type": "boolean"
                 },
-                "scope": {
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
                     "type": "string"
                 }
             }
         },
-        "schema.GetAnswerInfoResp": {
+        "schema.GetUserNotificationConfigResp": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/schema.AnswerInfo"
+                "all_new_question": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
                 },
-                "question": {
-                    "$ref": "#/definitions/schema.QuestionInfoResp"
+                "all_new_question_for_following_tags": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                },
+                "inbox": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
                 }
             }
         },
-        "schema.GetBadgeInfoResp": {
+        "schema.GetUserPageResp": {
             "type": "object",
             "properties": {
-                "award_count": {
-                    "description": "badge award count",
+                "avatar": {
+                    "description": "avatar",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "create time",
                     "type": "integer"
                 },
-                "description": {
-                    "description": "badge description",
+                "deleted_at": {
+                    "description": "delete time",
+                    "type": "integer"
+                },
+                "display_name": {
+                    "description": "display name",
+                    "type": "string"
+                },
+                "e_mail": {
+                    "description": "email",
                     "type": "string"
                 },
-                "earned_count": {
-                    "description": "badge earned count",
+                "rank": {
+                    "description": "rank",
                     "type": "integer"
                 },
-                "icon": {
-                    "description": "badge icon",
+                "role_id": {
+                    "description": "role id",
+                    "type": "integer"
+                },
+                "role_name": {
+                    "description": "role name",
                     "type": "string"
                 },
-                "id": {
-                    "description": "badge id",
+                "status": {
+                    "description": "user status(normal,suspended,deleted,inactive)",
                     "type": "string"
                 },
-                "is_single": {
-                    "description": "badge is single or multiple",
-                    "type": "boolean"
+                "suspended_at": {
+                    "description": "suspended time",
+                    "type": "integer"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
+                "suspended_until": {
+                    "description": "suspended until time",
+                    "type": "integer"
                 },
-                "name": {
-                    "description": "badge name",
+                "user_id": {
+                    "description": "user id",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "username",
                     "type": "string"
                 }
             }
         },
-        "schema.GetBadgeListPagedResp": {
+        "schema.GetUserPluginListResp": {
             "type": "object",
             "properties": {
-                "award_count": {
-                    "description": "badge award count",
-                    "type": "integer"
-                },
-                "description": {
-                    "description": "badge description",
+                "name": {
                     "type": "string"
                 },
-                "earned": {
-                    "description": "badge earned count",
-                    "type": "boolean"
-                },
-                "group_name": {
-                    "description": "badge group name",
+                "slug_name": {
                     "type": "string"
-                },
-                "icon": {
-                    "description": "badge icon",
+                }
+            }
+        },
+        "schema.GetUserStaffResp": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "description": "avatar",
                     "type": "string"
                 },
-                "id": {
-                    "description": "badge id",
+                "display_name": {
+                    "description": "display name",
                     "type": "string"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
-                },
-                "name": {
-                    "description": "badge name",
+                "username": {
+                    "description": "username",
                     "type": "string"
-                },
-                "status": {
-                    "description": "badge status",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/schema.BadgeStatus"
-                        }
-                    ]
                 }
             }
         },
-        "schema.GetBadgeListResp": {
+        "schema.GetUserTagRankResp": {
             "type": "object",
             "properties": {
-                "badges": {
-                    "description": "badge list info",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.BadgeListInfo"
-                    }
+                "display_name": {
+                    "description": "display name",
+                    "type": "string"
                 },
-                "group_name": {
-                    "description": "badge group name",
+                "rank": {
+                    "description": "the reputation gained from the votes of the questions with the tag and their answers",
+                    "type": "integer"
+                },
+                "slug_name": {
+                    "description": "slug name",
+                    "type": "string"
+                },
+                "tag_id": {
+                    "description": "tag id",
                     "type": "string"
                 }
             }
         },
-        "schema.GetCommentPersonalWithPageResp": {
+        "schema.GetVoteWithPageResp": {
             "type": "object",
             "properties": {
                 "answer_id": {
                     "description": "answer id",
                     "type": "string"
                 },
-                "comment_id": {
-                    "description": "comment id",
-                    "type": "string"
-                },
                 "content": {
                     "description": "content",
                     "type": "string"
@@ -9782,833 +16084,875 @@ const docTemplate = `{
                 "url_title": {
                     "description": "url title",
                     "type": "string"
+                },
+                "vote_type": {
+                    "description": "vote type",
+                    "type": "string"
                 }
             }
         },
-        "schema.GetCommentResp": {
+        "schema.GetWebhookDeadLetterResp": {
             "type": "object",
             "properties": {
-                "comment_id": {
-                    "description": "comment id",
-                    "type": "string"
+                "attempts": {
+                    "type": "integer"
                 },
                 "created_at": {
-                    "description": "create time",
                     "type": "integer"
                 },
-                "is_vote": {
-                    "description": "current user if already vote this comment",
-                    "type": "boolean"
-                },
-                "member_actions": {
-                    "description": "MemberActions",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
-                    }
-                },
-                "object_id": {
-                    "description": "object id",
-                    "type": "string"
-                },
-                "original_text": {
-                    "description": "original comment content",
-                    "type": "string"
-                },
-                "parsed_text": {
-                    "description": "parsed comment content",
-                    "type": "string"
-                },
-                "reply_comment_id": {
-                    "description": "reply comment id",
-                    "type": "string"
-                },
-                "reply_user_display_name": {
-                    "description": "reply user display name",
-                    "type": "string"
-                },
-                "reply_user_id": {
-                    "description": "reply user id",
+                "delivery_id": {
                     "type": "string"
                 },
-                "reply_user_status": {
-                    "description": "reply user status",
+                "error": {
                     "type": "string"
                 },
-                "reply_username": {
-                    "description": "reply user username",
+                "event": {
                     "type": "string"
                 },
-                "user_avatar": {
-                    "description": "user avatar",
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "user_display_name": {
-                    "description": "user display name",
+                "object_id": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "user id",
+                "payload": {
                     "type": "string"
                 },
-                "user_status": {
-                    "description": "user status",
-                    "type": "string"
+                "status_code": {
+                    "type": "integer"
                 },
-                "username": {
-                    "description": "username",
+                "url": {
                     "type": "string"
-                },
-                "vote_count": {
-                    "description": "user vote amount",
-                    "type": "integer"
                 }
             }
         },
-        "schema.GetCurrentLoginUserInfoResp": {
+        "schema.GraphQLReq": {
             "type": "object",
+            "required": [
+                "query"
+            ],
             "properties": {
-                "access_token": {
-                    "description": "access token",
-                    "type": "string"
-                },
-                "answer_count": {
-                    "description": "answer count",
-                    "type": "integer"
-                },
-                "authority_group": {
-                    "description": "authority group",
-                    "type": "integer"
-                },
-                "avatar": {
-                    "$ref": "#/definitions/schema.AvatarInfo"
-                },
-                "bio": {
-                    "description": "bio markdown",
-                    "type": "string"
-                },
-                "bio_html": {
-                    "description": "bio html",
-                    "type": "string"
-                },
-                "color_scheme": {
-                    "description": "Color scheme",
-                    "type": "string"
-                },
-                "created_at": {
-                    "description": "create time",
-                    "type": "integer"
-                },
-                "display_name": {
-                    "description": "display name",
+                "operationName": {
                     "type": "string"
                 },
-                "e_mail": {
-                    "description": "email",
+                "query": {
                     "type": "string"
                 },
-                "follow_count": {
-                    "description": "follow count",
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "schema.ImpersonationInfo": {
+            "type": "object",
+            "properties": {
+                "expire_at": {
                     "type": "integer"
                 },
-                "have_password": {
-                    "description": "user have password",
-                    "type": "boolean"
-                },
-                "id": {
-                    "description": "user id",
+                "impersonator_id": {
                     "type": "string"
                 },
-                "language": {
-                    "description": "language",
+                "mode": {
                     "type": "string"
-                },
-                "last_login_date": {
-                    "description": "last login date",
-                    "type": "integer"
-                },
-                "location": {
-                    "description": "location",
+                }
+            }
+        },
+        "schema.ImportDisposableEmailDomainsReq": {
+            "type": "object",
+            "required": [
+                "domains"
+            ],
+            "properties": {
+                "domains": {
+                    "description": "Domains the domains separated by lines or commas, the lines start with # are ignored",
                     "type": "string"
                 },
-                "mail_status": {
-                    "description": "mail status(1 pass 2 to be verified)",
+                "replace": {
+                    "description": "Replace replace the imported domains, otherwise the domains are appended to them",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.ImportDisposableEmailDomainsResp": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "description": "Imported the amount of the new domains",
                     "type": "integer"
                 },
-                "mobile": {
-                    "description": "mobile",
-                    "type": "string"
-                },
-                "notice_status": {
-                    "description": "notice status(1 on 2off)",
+                "total": {
+                    "description": "Total the amount of all the disposable email domains",
                     "type": "integer"
-                },
-                "question_count": {
-                    "description": "question count",
+                }
+            }
+        },
+        "schema.JobQueueStatus": {
+            "type": "object",
+            "properties": {
+                "dead_letters": {
+                    "description": "failed after all the attempts",
                     "type": "integer"
                 },
-                "rank": {
-                    "description": "rank",
+                "pending": {
+                    "description": "waiting to run, including the ones will be retried",
                     "type": "integer"
                 },
-                "role_id": {
-                    "description": "role id",
+                "retrying": {
+                    "description": "failed before and waiting to be retried",
                     "type": "integer"
                 },
-                "status": {
-                    "description": "user status",
-                    "type": "string"
-                },
-                "suspended_until": {
-                    "description": "suspended until timestamp",
+                "running": {
+                    "description": "running now",
                     "type": "integer"
                 },
-                "username": {
-                    "description": "username",
-                    "type": "string"
-                },
-                "visit_token": {
-                    "description": "visit token",
-                    "type": "string"
-                },
-                "website": {
-                    "description": "website",
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetFollowingTagsResp": {
+        "schema.LoadingAction": {
             "type": "object",
             "properties": {
-                "display_name": {
-                    "description": "display name",
+                "state": {
                     "type": "string"
                 },
-                "main_tag_slug_name": {
-                    "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
+                "text": {
                     "type": "string"
-                },
-                "recommend": {
-                    "type": "boolean"
-                },
-                "reserved": {
+                }
+            }
+        },
+        "schema.LockPostReq": {
+            "type": "object",
+            "required": [
+                "object_id"
+            ],
+            "properties": {
+                "lock_vote": {
+                    "description": "LockVote the post can not be voted either",
                     "type": "boolean"
                 },
-                "slug_name": {
-                    "description": "slug name",
+                "object_id": {
                     "type": "string"
                 },
-                "tag_id": {
-                    "description": "tag id",
-                    "type": "string"
+                "reason": {
+                    "description": "Reason shown to the users why the post is locked",
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
-        "schema.GetObjectTimelineResp": {
+        "schema.MergeTagReq": {
             "type": "object",
+            "required": [
+                "source_tag_id",
+                "target_tag_id"
+            ],
             "properties": {
-                "object_info": {
-                    "$ref": "#/definitions/schema.ActObjectInfo"
+                "description": {
+                    "description": "the description kept by the target tag, source or target, the longer one if empty",
+                    "type": "string",
+                    "enum": [
+                        "source",
+                        "target"
+                    ]
                 },
-                "timeline": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.ActObjectTimeline"
-                    }
+                "source_tag_id": {
+                    "description": "source tag id",
+                    "type": "string"
+                },
+                "target_tag_id": {
+                    "description": "target tag id",
+                    "type": "string"
                 }
             }
         },
-        "schema.GetOtherUserInfoByUsernameResp": {
+        "schema.ModerationQueueFlag": {
             "type": "object",
             "properties": {
-                "answer_count": {
-                    "description": "answer count",
-                    "type": "integer"
-                },
-                "avatar": {
-                    "description": "avatar",
+                "flag_id": {
                     "type": "string"
                 },
-                "bio": {
-                    "description": "bio markdown",
-                    "type": "string"
+                "reason": {
+                    "$ref": "#/definitions/schema.ReasonItem"
                 },
-                "bio_html": {
-                    "description": "bio html",
+                "reason_content": {
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "create time",
+                "submit_at": {
                     "type": "integer"
                 },
-                "display_name": {
-                    "description": "display name",
+                "submitter_user": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
+        "schema.ModerationQueueItem": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
                     "type": "string"
                 },
-                "follow_count": {
-                    "description": "email\nfollow count",
-                    "type": "integer"
+                "author_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "id": {
-                    "description": "user id",
+                "comment_id": {
                     "type": "string"
                 },
-                "last_login_date": {
-                    "description": "last login date",
+                "first_flagged_at": {
                     "type": "integer"
                 },
-                "location": {
-                    "description": "location",
-                    "type": "string"
+                "flag_count": {
+                    "type": "integer"
                 },
-                "mobile": {
-                    "description": "mobile",
-                    "type": "string"
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ModerationQueueFlag"
+                    }
                 },
-                "question_count": {
-                    "description": "question count",
+                "last_flagged_at": {
                     "type": "integer"
                 },
-                "rank": {
-                    "description": "rank",
-                    "type": "integer"
+                "object_id": {
+                    "type": "string"
                 },
-                "status": {
+                "object_type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "comment",
+                        "user"
+                    ]
+                },
+                "original_text": {
                     "type": "string"
                 },
-                "status_msg": {
+                "question_id": {
                     "type": "string"
                 },
-                "suspended_until": {
-                    "description": "suspended until timestamp",
-                    "type": "integer"
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ModerationQueueReason"
+                    }
                 },
-                "username": {
-                    "description": "username",
+                "title": {
                     "type": "string"
                 },
-                "website": {
-                    "description": "website",
+                "url_title": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetOtherUserInfoResp": {
+        "schema.ModerationQueueReason": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/schema.GetOtherUserInfoByUsernameResp"
+                "count": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/schema.ReasonItem"
                 }
             }
         },
-        "schema.GetPluginConfigResp": {
+        "schema.MuteUserReq": {
             "type": "object",
+            "required": [
+                "username"
+            ],
             "properties": {
-                "config_fields": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.ConfigField"
-                    }
-                },
-                "description": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "slug_name": {
-                    "type": "string"
-                },
-                "version": {
-                    "type": "string"
+                "username": {
+                    "type": "string",
+                    "maxLength": 30
                 }
             }
         },
-        "schema.GetPluginListResp": {
+        "schema.MutedUserResp": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "enabled": {
-                    "type": "boolean"
+                "muted_at": {
+                    "description": "MutedAt the unix time the user is muted",
+                    "type": "integer"
                 },
-                "have_config": {
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
+        "schema.NotificationChannelConfig": {
+            "type": "object",
+            "properties": {
+                "enable": {
                     "type": "boolean"
                 },
-                "link": {
-                    "type": "string"
+                "frequency": {
+                    "description": "only for the new questions in the following tags: immediate, daily or weekly",
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "daily",
+                        "weekly"
+                    ]
                 },
-                "name": {
+                "key": {
+                    "$ref": "#/definitions/constant.NotificationChannelKey"
+                }
+            }
+        },
+        "schema.NotificationClearIDRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
+                }
+            }
+        },
+        "schema.NotificationClearRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "inbox",
+                        "achievement"
+                    ]
+                }
+            }
+        },
+        "schema.NotificationPreference": {
+            "type": "object",
+            "required": [
+                "event"
+            ],
+            "properties": {
+                "email": {
+                    "type": "boolean"
                 },
-                "slug_name": {
-                    "type": "string"
+                "event": {
+                    "enum": [
+                        "answer_to_my_question",
+                        "comment_on_my_post",
+                        "mention",
+                        "accepted",
+                        "badge_earned"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.NotificationSource"
+                        }
+                    ]
                 },
-                "version": {
-                    "type": "string"
+                "in_app": {
+                    "type": "boolean"
                 }
             }
         },
-        "schema.GetPrivilegesConfigResp": {
+        "schema.OnCompleteAction": {
             "type": "object",
             "properties": {
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PrivilegeOption"
-                    }
+                "refresh_form_config": {
+                    "type": "boolean"
                 },
-                "selected_level": {
-                    "$ref": "#/definitions/schema.PrivilegeLevel"
+                "toast_return_message": {
+                    "type": "boolean"
                 }
             }
         },
-        "schema.GetRankPersonalPageResp": {
+        "schema.Operation": {
             "type": "object",
             "properties": {
-                "answer_id": {
-                    "description": "answer id",
-                    "type": "string"
-                },
-                "content": {
-                    "description": "content",
-                    "type": "string"
-                },
-                "created_at": {
-                    "description": "create time",
-                    "type": "integer"
-                },
-                "object_id": {
-                    "description": "object id",
+                "description": {
                     "type": "string"
                 },
-                "object_type": {
-                    "description": "object type",
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "tag",
-                        "comment"
+                "duplicate_question": {
+                    "description": "the original question when the question is closed as a duplicate",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.DuplicateQuestionInfo"
+                        }
                     ]
                 },
-                "question_id": {
-                    "description": "question id",
-                    "type": "string"
+                "level": {
+                    "$ref": "#/definitions/schema.OperationLevel"
                 },
-                "rank_type": {
-                    "description": "rank type",
+                "msg": {
                     "type": "string"
                 },
-                "reputation": {
-                    "description": "reputation",
+                "time": {
                     "type": "integer"
                 },
-                "title": {
-                    "description": "title",
-                    "type": "string"
-                },
-                "url_title": {
-                    "description": "url title",
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetReportListPageResp": {
+        "schema.OperationLevel": {
+            "type": "string",
+            "enum": [
+                "info",
+                "danger",
+                "warning",
+                "secondary"
+            ],
+            "x-enum-varnames": [
+                "OperationLevelInfo",
+                "OperationLevelDanger",
+                "OperationLevelWarning",
+                "OperationLevelSecondary"
+            ]
+        },
+        "schema.OperationQuestionReq": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "answer_accepted": {
-                    "type": "boolean"
-                },
-                "answer_count": {
-                    "type": "integer"
-                },
-                "answer_id": {
-                    "type": "string"
-                },
-                "author_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                },
-                "comment_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "integer"
-                },
-                "flag_id": {
+                "id": {
                     "type": "string"
                 },
-                "object_id": {
+                "operation": {
+                    "description": "operation [pin unpin hide show]",
                     "type": "string"
                 },
-                "object_show_status": {
-                    "type": "integer"
-                },
-                "object_status": {
-                    "type": "integer"
+                "pin_expired_at": {
+                    "description": "PinExpiredAt the unix time the pin expires at, the pin never expires if it is 0",
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "object_type": {
+                "tag": {
+                    "description": "Tag the slug name of the tag to pin or unpin the question within, it is pinned globally if empty",
                     "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "comment"
-                    ]
-                },
-                "original_text": {
-                    "type": "string"
-                },
-                "parsed_text": {
+                    "maxLength": 35
+                }
+            }
+        },
+        "schema.PermissionMemberAction": {
+            "type": "object",
+            "properties": {
+                "action": {
                     "type": "string"
                 },
-                "question_id": {
+                "name": {
                     "type": "string"
                 },
-                "reason": {
-                    "$ref": "#/definitions/schema.ReasonItem"
-                },
-                "reason_content": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "schema.PostLockInfo": {
+            "type": "object",
+            "properties": {
+                "lock_vote": {
+                    "type": "boolean"
                 },
-                "submit_at": {
+                "locked_at": {
                     "type": "integer"
                 },
-                "submitter_user": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagResp"
-                    }
-                },
-                "title": {
-                    "type": "string"
-                },
-                "url_title": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetReviewingTypeResp": {
+        "schema.PostRenderReq": {
             "type": "object",
             "properties": {
-                "label": {
-                    "type": "string"
-                },
-                "name": {
+                "content": {
                     "type": "string"
-                },
-                "todo_amount": {
-                    "type": "integer"
                 }
             }
         },
-        "schema.GetRevisionResp": {
+        "schema.PrivilegeLevel": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                99
+            ],
+            "x-enum-varnames": [
+                "PrivilegeLevel1",
+                "PrivilegeLevel2",
+                "PrivilegeLevel3",
+                "PrivilegeLevelCustom"
+            ]
+        },
+        "schema.PrivilegeOption": {
             "type": "object",
             "properties": {
-                "content": {},
-                "create_at": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
+                "level": {
+                    "$ref": "#/definitions/schema.PrivilegeLevel"
                 },
-                "object_id": {
+                "level_desc": {
                     "type": "string"
                 },
-                "reason": {
+                "privileges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constant.Privilege"
+                    }
+                }
+            }
+        },
+        "schema.QuestionAdd": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "captcha_code": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
-                },
-                "title": {
+                "captcha_id": {
+                    "description": "captcha_id",
                     "type": "string"
                 },
-                "url_title": {
+                "captcha_token": {
+                    "description": "CaptchaToken the token of the captcha widget of the third-party provider",
                     "type": "string"
                 },
-                "use_id": {
-                    "type": "string"
+                "content": {
+                    "description": "content",
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 0
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                }
-            }
-        },
-        "schema.GetRoleResp": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
+                "scheduled_at": {
+                    "description": "ScheduledAt the unix time the question is published at, only the admins and the moderators can schedule",
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "id": {
-                    "type": "integer"
+                "tags": {
+                    "description": "tags",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagItem"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "title": {
+                    "description": "question title",
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 6
                 }
             }
         },
-        "schema.GetSMTPConfigResp": {
+        "schema.QuestionAddByAnswer": {
             "type": "object",
+            "required": [
+                "answer_content",
+                "title"
+            ],
             "properties": {
-                "encryption": {
-                    "description": "\"\" SSL TLS",
-                    "type": "string"
+                "answer_content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 6
                 },
-                "from_email": {
+                "captcha_code": {
                     "type": "string"
                 },
-                "from_name": {
+                "captcha_id": {
+                    "description": "captcha_id",
                     "type": "string"
                 },
-                "smtp_authentication": {
-                    "type": "boolean"
-                },
-                "smtp_host": {
+                "captcha_token": {
+                    "description": "CaptchaToken the token of the captcha widget of the third-party provider",
                     "type": "string"
                 },
-                "smtp_password": {
-                    "type": "string"
+                "content": {
+                    "description": "content",
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 0
                 },
-                "smtp_port": {
-                    "type": "integer"
+                "mention_username_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "smtp_username": {
-                    "type": "string"
+                "tags": {
+                    "description": "tags",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagItem"
+                    }
+                },
+                "title": {
+                    "description": "question title",
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 6
                 }
             }
         },
-        "schema.GetSiteLegalInfoResp": {
+        "schema.QuestionBountyResp": {
             "type": "object",
             "properties": {
-                "privacy_policy_original_text": {
+                "amount": {
+                    "type": "integer"
+                },
+                "awarded_amount": {
+                    "type": "integer"
+                },
+                "awarded_answer_id": {
                     "type": "string"
                 },
-                "privacy_policy_parsed_text": {
+                "awarded_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "terms_of_service_original_text": {
+                "question_id": {
                     "type": "string"
                 },
-                "terms_of_service_parsed_text": {
+                "status": {
                     "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 }
             }
         },
-        "schema.GetTagBasicResp": {
+        "schema.QuestionInfoResp": {
             "type": "object",
             "properties": {
-                "display_name": {
+                "accepted_answer_id": {
                     "type": "string"
                 },
-                "recommend": {
+                "answer_count": {
+                    "type": "integer"
+                },
+                "answered": {
                     "type": "boolean"
                 },
-                "reserved": {
+                "collected": {
                     "type": "boolean"
                 },
-                "slug_name": {
-                    "type": "string"
+                "collection_count": {
+                    "type": "integer"
                 },
-                "tag_id": {
+                "content": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.GetTagPageResp": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "created time",
+                },
+                "create_time": {
                     "type": "integer"
                 },
                 "description": {
-                    "description": "description",
                     "type": "string"
                 },
-                "display_name": {
-                    "description": "display_name",
-                    "type": "string"
+                "edit_time": {
+                    "type": "integer"
                 },
-                "excerpt": {
-                    "description": "excerpt",
+                "extends_actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
+                },
+                "first_answer_id": {
                     "type": "string"
                 },
                 "follow_count": {
-                    "description": "follower amount",
                     "type": "integer"
                 },
-                "is_follower": {
-                    "description": "is follower",
-                    "type": "boolean"
-                },
-                "original_text": {
-                    "description": "original text",
+                "html": {
                     "type": "string"
                 },
-                "parsed_text": {
-                    "description": "parsed_text",
+                "id": {
                     "type": "string"
                 },
-                "question_count": {
-                    "description": "question amount",
-                    "type": "integer"
-                },
-                "recommend": {
-                    "type": "boolean"
-                },
-                "reserved": {
+                "is_followed": {
                     "type": "boolean"
                 },
-                "slug_name": {
-                    "description": "slug_name",
+                "last_answer_id": {
                     "type": "string"
                 },
-                "tag_id": {
-                    "description": "tag_id",
-                    "type": "string"
+                "last_answered_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "updated_at": {
-                    "description": "updated time",
-                    "type": "integer"
-                }
-            }
-        },
-        "schema.GetTagResp": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "integer"
+                "lock": {
+                    "$ref": "#/definitions/schema.PostLockInfo"
                 },
-                "description": {
-                    "type": "string"
+                "member_actions": {
+                    "description": "MemberActions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
                 },
-                "display_name": {
-                    "type": "string"
+                "operation": {
+                    "$ref": "#/definitions/schema.Operation"
                 },
-                "excerpt": {
-                    "type": "string"
+                "pin": {
+                    "type": "integer"
                 },
-                "follow_count": {
+                "pin_expired_at": {
                     "type": "integer"
                 },
-                "is_follower": {
-                    "type": "boolean"
+                "scheduled_at": {
+                    "type": "integer"
                 },
-                "main_tag_slug_name": {
-                    "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
-                    "type": "string"
+                "show": {
+                    "type": "integer"
                 },
-                "member_actions": {
+                "status": {
+                    "type": "integer"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                        "$ref": "#/definitions/schema.TagResp"
                     }
                 },
-                "original_text": {
+                "title": {
                     "type": "string"
                 },
-                "parsed_text": {
+                "unique_view_count": {
+                    "type": "integer"
+                },
+                "update_time": {
+                    "type": "integer"
+                },
+                "update_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "url_title": {
                     "type": "string"
                 },
-                "question_count": {
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "view_count": {
                     "type": "integer"
                 },
-                "recommend": {
-                    "type": "boolean"
+                "vote_count": {
+                    "type": "integer"
                 },
-                "reserved": {
+                "vote_status": {
+                    "type": "string"
+                },
+                "wiki": {
                     "type": "boolean"
+                }
+            }
+        },
+        "schema.QuestionPageReq": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "Cursor the next cursor returned by the previous page, empty for the first page",
+                    "type": "string",
+                    "maxLength": 512
                 },
-                "slug_name": {
-                    "type": "string"
+                "in_days": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "status": {
-                    "type": "string"
+                "order": {
+                    "type": "string",
+                    "enum": [
+                        "newest",
+                        "active",
+                        "hot",
+                        "score",
+                        "unanswered",
+                        "recommend",
+                        "frequent",
+                        "trending"
+                    ]
                 },
-                "tag_id": {
-                    "type": "string"
+                "page": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "pagination": {
+                    "description": "Pagination the cursor pagination is used if it is cursor or the cursor is not empty, the page is ignored",
+                    "type": "string",
+                    "enum": [
+                        "offset",
+                        "cursor"
+                    ]
                 },
-                "updated_at": {
-                    "type": "integer"
-                }
-            }
-        },
-        "schema.GetTagSynonymsResp": {
-            "type": "object",
-            "properties": {
-                "member_actions": {
-                    "description": "MemberActions",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
-                    }
+                "tag": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "synonyms": {
-                    "description": "synonyms",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagSynonym"
-                    }
+                "username": {
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
-        "schema.GetUnreviewedPostPageResp": {
+        "schema.QuestionPageResp": {
             "type": "object",
             "properties": {
-                "answer_id": {
+                "accepted_answer_id": {
+                    "description": "answer information",
                     "type": "string"
                 },
-                "author_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "answer_count": {
+                    "type": "integer"
                 },
-                "comment_id": {
-                    "type": "string"
+                "collection_count": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "integer"
                 },
-                "object_id": {
+                "description": {
                     "type": "string"
                 },
-                "object_show_status": {
-                    "type": "integer"
-                },
-                "object_status": {
+                "follow_count": {
                     "type": "integer"
                 },
-                "object_type": {
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "comment"
-                    ]
-                },
-                "original_text": {
+                "id": {
                     "type": "string"
                 },
-                "parsed_text": {
+                "last_answer_id": {
                     "type": "string"
                 },
-                "question_id": {
-                    "type": "string"
+                "operated_at": {
+                    "description": "operator information",
+                    "type": "integer"
                 },
-                "reason": {
+                "operation_type": {
                     "type": "string"
                 },
-                "review_id": {
+                "operator": {
+                    "$ref": "#/definitions/schema.QuestionPageRespOperator"
+                },
+                "pin": {
+                    "description": "1: unpin, 2: pin",
                     "type": "integer"
                 },
-                "submit_at": {
+                "show": {
+                    "description": "0: show, 1: hide",
                     "type": "integer"
                 },
-                "submitter_display_name": {
-                    "type": "string"
+                "status": {
+                    "type": "integer"
+                },
+                "tag_pin": {
+                    "description": "pinned within the tag of the tag list",
+                    "type": "boolean"
                 },
                 "tags": {
                     "type": "array",
@@ -10619,300 +16963,348 @@ const docTemplate = `{
                 "title": {
                     "type": "string"
                 },
+                "unique_view_count": {
+                    "type": "integer"
+                },
                 "url_title": {
                     "type": "string"
+                },
+                "view_count": {
+                    "description": "question statistical information",
+                    "type": "integer"
+                },
+                "vote_count": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetUnreviewedRevisionResp": {
+        "schema.QuestionPageRespOperator": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/schema.UnreviewedRevisionInfoInfo"
+                "avatar": {
+                    "type": "string"
                 },
-                "type": {
+                "display_name": {
                     "type": "string"
                 },
-                "unreviewed_info": {
-                    "$ref": "#/definitions/schema.GetRevisionResp"
+                "id": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "schema.GetUserActivationResp": {
+        "schema.QuestionRecoverReq": {
             "type": "object",
+            "required": [
+                "question_id"
+            ],
             "properties": {
-                "activation_url": {
+                "question_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetUserBadgeAwardListResp": {
+        "schema.QuestionUpdate": {
             "type": "object",
+            "required": [
+                "id",
+                "title"
+            ],
             "properties": {
-                "earned_count": {
-                    "description": "badge award count",
-                    "type": "integer"
+                "captcha_code": {
+                    "type": "string"
                 },
-                "icon": {
-                    "description": "badge icon",
+                "captcha_id": {
+                    "description": "captcha_id",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "content",
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 0
+                },
+                "edit_summary": {
+                    "description": "edit summary",
                     "type": "string"
                 },
                 "id": {
-                    "description": "badge id",
+                    "description": "question id",
                     "type": "string"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
+                "invite_user": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "name": {
-                    "description": "badge name",
-                    "type": "string"
+                "tags": {
+                    "description": "tags",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagItem"
+                    }
+                },
+                "title": {
+                    "description": "question title",
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 6
                 }
             }
         },
-        "schema.GetUserNotificationConfigResp": {
+        "schema.QuestionUpdateInviteUser": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "all_new_question": {
-                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                "captcha_code": {
+                    "type": "string"
                 },
-                "all_new_question_for_following_tags": {
-                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                "captcha_id": {
+                    "description": "captcha_id",
+                    "type": "string"
                 },
-                "inbox": {
-                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                "id": {
+                    "type": "string"
+                },
+                "invite_user": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "schema.GetUserPageResp": {
+        "schema.ReactionRespItem": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "description": "avatar",
-                    "type": "string"
-                },
-                "created_at": {
-                    "description": "create time",
-                    "type": "integer"
-                },
-                "deleted_at": {
-                    "description": "delete time",
+                "count": {
+                    "description": "Count is the number of users who reacted",
                     "type": "integer"
                 },
-                "display_name": {
-                    "description": "display name",
-                    "type": "string"
-                },
-                "e_mail": {
-                    "description": "email",
+                "emoji": {
+                    "description": "Emoji is the reaction emoji",
                     "type": "string"
                 },
-                "rank": {
-                    "description": "rank",
-                    "type": "integer"
-                },
-                "role_id": {
-                    "description": "role id",
-                    "type": "integer"
+                "is_active": {
+                    "description": "IsActive is if current user has reacted",
+                    "type": "boolean"
                 },
-                "role_name": {
-                    "description": "role name",
+                "tooltip": {
+                    "description": "Tooltip is the user's name who reacted",
                     "type": "string"
+                }
+            }
+        },
+        "schema.ReadinessResp": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "the status of each dependency",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/schema.DependencyHealth"
+                    }
                 },
                 "status": {
-                    "description": "user status(normal,suspended,deleted,inactive)",
+                    "description": "ok if all the dependencies are ready, otherwise unavailable",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ReasonItem": {
+            "type": "object",
+            "properties": {
+                "content_type": {
                     "type": "string"
                 },
-                "suspended_at": {
-                    "description": "suspended time",
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "suspended_until": {
-                    "description": "suspended until time",
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "user_id": {
-                    "description": "user id",
+                "placeholder": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "username",
+                "reason_key": {
                     "type": "string"
+                },
+                "reason_type": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetUserPluginListResp": {
+        "schema.RecoverAnswerReq": {
             "type": "object",
+            "required": [
+                "answer_id"
+            ],
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "slug_name": {
+                "answer_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetUserStaffResp": {
+        "schema.RecoverTagReq": {
             "type": "object",
+            "required": [
+                "tag_id"
+            ],
             "properties": {
-                "avatar": {
-                    "description": "avatar",
-                    "type": "string"
-                },
-                "display_name": {
-                    "description": "display name",
-                    "type": "string"
-                },
-                "username": {
-                    "description": "username",
+                "tag_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetVoteWithPageResp": {
+        "schema.RelatedQuestionResp": {
             "type": "object",
             "properties": {
-                "answer_id": {
-                    "description": "answer id",
-                    "type": "string"
+                "accepted_answer": {
+                    "type": "boolean"
                 },
-                "content": {
-                    "description": "content",
-                    "type": "string"
+                "answer_count": {
+                    "type": "integer"
                 },
                 "created_at": {
-                    "description": "create time",
                     "type": "integer"
                 },
-                "object_id": {
-                    "description": "object id",
-                    "type": "string"
-                },
-                "object_type": {
-                    "description": "object type",
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "tag",
-                        "comment"
-                    ]
-                },
-                "question_id": {
-                    "description": "question id",
+                "id": {
                     "type": "string"
                 },
                 "title": {
-                    "description": "title",
                     "type": "string"
                 },
                 "url_title": {
-                    "description": "url title",
                     "type": "string"
                 },
-                "vote_type": {
-                    "description": "vote type",
-                    "type": "string"
+                "view_count": {
+                    "type": "integer"
+                },
+                "vote_count": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.LoadingAction": {
+        "schema.RemoveAnswerReq": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "state": {
+                "captcha_code": {
                     "type": "string"
                 },
-                "text": {
+                "captcha_id": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.NotificationChannelConfig": {
-            "type": "object",
-            "properties": {
-                "enable": {
-                    "type": "boolean"
                 },
-                "key": {
-                    "$ref": "#/definitions/constant.NotificationChannelKey"
+                "id": {
+                    "type": "string"
                 }
             }
         },
-        "schema.NotificationClearIDRequest": {
+        "schema.RemoveCommentReq": {
             "type": "object",
+            "required": [
+                "comment_id"
+            ],
             "properties": {
-                "id": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "comment_id": {
+                    "description": "comment id",
                     "type": "string"
                 }
             }
         },
-        "schema.NotificationClearRequest": {
+        "schema.RemoveDraftReq": {
             "type": "object",
             "required": [
-                "type"
+                "target_type"
             ],
             "properties": {
-                "type": {
+                "question_id": {
+                    "description": "QuestionID the question the answer belongs to, it is required if the target type is answer",
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "target_type": {
                     "type": "string",
                     "enum": [
-                        "inbox",
-                        "achievement"
+                        "question",
+                        "answer"
                     ]
                 }
             }
         },
-        "schema.OnCompleteAction": {
+        "schema.RemoveEmailTemplateReq": {
             "type": "object",
+            "required": [
+                "language",
+                "type"
+            ],
             "properties": {
-                "refresh_form_config": {
-                    "type": "boolean"
+                "language": {
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "toast_return_message": {
-                    "type": "boolean"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "new_answer",
+                        "new_comment",
+                        "invited_you_to_answer",
+                        "new_question",
+                        "notification_event"
+                    ]
                 }
             }
         },
-        "schema.Operation": {
+        "schema.RemoveQuestionReq": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "description": {
+                "captcha_code": {
                     "type": "string"
                 },
-                "level": {
-                    "$ref": "#/definitions/schema.OperationLevel"
-                },
-                "msg": {
+                "captcha_id": {
+                    "description": "captcha_id",
                     "type": "string"
                 },
-                "time": {
-                    "type": "integer"
-                },
-                "type": {
+                "id": {
+                    "description": "question id",
                     "type": "string"
                 }
             }
         },
-        "schema.OperationLevel": {
-            "type": "string",
-            "enum": [
-                "info",
-                "danger",
-                "warning",
-                "secondary"
+        "schema.RemoveRoleReq": {
+            "type": "object",
+            "required": [
+                "id"
             ],
-            "x-enum-varnames": [
-                "OperationLevelInfo",
-                "OperationLevelDanger",
-                "OperationLevelWarning",
-                "OperationLevelSecondary"
-            ]
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
         },
-        "schema.OperationQuestionReq": {
+        "schema.RemoveSavedSearchReq": {
             "type": "object",
             "required": [
                 "id"
@@ -10920,944 +17312,986 @@ const docTemplate = `{
             "properties": {
                 "id": {
                     "type": "string"
-                },
-                "operation": {
-                    "description": "operation [pin unpin hide show]",
-                    "type": "string"
                 }
             }
         },
-        "schema.PermissionMemberAction": {
+        "schema.RemoveTagModeratorReq": {
             "type": "object",
+            "required": [
+                "tag_id",
+                "user_id"
+            ],
             "properties": {
-                "action": {
-                    "type": "string"
-                },
-                "name": {
+                "tag_id": {
                     "type": "string"
                 },
-                "type": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.PostRenderReq": {
+        "schema.RemoveTagReq": {
             "type": "object",
+            "required": [
+                "tag_id"
+            ],
             "properties": {
-                "content": {
+                "tag_id": {
+                    "description": "tag_id",
                     "type": "string"
                 }
             }
         },
-        "schema.PrivilegeLevel": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                99
-            ],
-            "x-enum-varnames": [
-                "PrivilegeLevel1",
-                "PrivilegeLevel2",
-                "PrivilegeLevel3",
-                "PrivilegeLevelCustom"
-            ]
-        },
-        "schema.PrivilegeOption": {
+        "schema.ReopenQuestionReq": {
             "type": "object",
             "properties": {
-                "level": {
-                    "$ref": "#/definitions/schema.PrivilegeLevel"
-                },
-                "level_desc": {
+                "question_id": {
                     "type": "string"
-                },
-                "privileges": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/constant.Privilege"
-                    }
                 }
             }
         },
-        "schema.QuestionAdd": {
+        "schema.ResetUserTwoFactorReq": {
             "type": "object",
             "required": [
-                "title"
+                "user_id"
             ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
-                },
-                "captcha_id": {
-                    "description": "captcha_id",
+                "user_id": {
                     "type": "string"
-                },
-                "content": {
-                    "description": "content",
-                    "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 0
-                },
-                "tags": {
-                    "description": "tags",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagItem"
-                    }
-                },
-                "title": {
-                    "description": "question title",
-                    "type": "string",
-                    "maxLength": 150,
-                    "minLength": 6
                 }
             }
         },
-        "schema.QuestionAddByAnswer": {
+        "schema.ResolveModerationQueueItemReq": {
             "type": "object",
             "required": [
-                "answer_content",
-                "title"
+                "object_id",
+                "object_type",
+                "operation_type"
             ],
             "properties": {
-                "answer_content": {
+                "content": {
                     "type": "string",
                     "maxLength": 65535,
                     "minLength": 6
                 },
-                "captcha_code": {
-                    "type": "string"
+                "message": {
+                    "description": "Message the warning sent to the author, it is required when the operation type is warn",
+                    "type": "string",
+                    "maxLength": 1000
                 },
-                "captcha_id": {
-                    "description": "captcha_id",
+                "object_id": {
                     "type": "string"
                 },
-                "content": {
-                    "description": "content",
+                "object_type": {
                     "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 0
+                    "enum": [
+                        "question",
+                        "answer",
+                        "comment",
+                        "user"
+                    ]
                 },
-                "mention_username_list": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "operation_type": {
+                    "type": "string",
+                    "enum": [
+                        "dismiss",
+                        "delete",
+                        "edit",
+                        "warn"
+                    ]
                 },
                 "tags": {
-                    "description": "tags",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/schema.TagItem"
                     }
                 },
                 "title": {
-                    "description": "question title",
                     "type": "string",
                     "maxLength": 150,
                     "minLength": 6
                 }
             }
         },
-        "schema.QuestionInfoResp": {
+        "schema.ResolveModerationQueueItemResp": {
             "type": "object",
             "properties": {
-                "accepted_answer_id": {
-                    "type": "string"
-                },
-                "answer_count": {
-                    "type": "integer"
-                },
-                "answered": {
-                    "type": "boolean"
-                },
-                "collected": {
-                    "type": "boolean"
-                },
-                "collection_count": {
-                    "type": "integer"
-                },
-                "content": {
-                    "type": "string"
-                },
-                "create_time": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "edit_time": {
+                "resolved_count": {
+                    "description": "ResolvedCount the number of the flags that are resolved",
                     "type": "integer"
-                },
-                "extends_actions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
-                    }
-                },
-                "first_answer_id": {
+                }
+            }
+        },
+        "schema.ReviewReportReq": {
+            "type": "object",
+            "required": [
+                "flag_id",
+                "operation_type"
+            ],
+            "properties": {
+                "close_msg": {
                     "type": "string"
                 },
-                "follow_count": {
+                "close_type": {
                     "type": "integer"
                 },
-                "html": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_followed": {
-                    "type": "boolean"
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 6
                 },
-                "last_answer_id": {
+                "flag_id": {
                     "type": "string"
                 },
-                "last_answered_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                },
-                "member_actions": {
-                    "description": "MemberActions",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
-                    }
-                },
-                "operation": {
-                    "$ref": "#/definitions/schema.Operation"
-                },
-                "pin": {
-                    "type": "integer"
-                },
-                "show": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "integer"
+                "operation_type": {
+                    "type": "string",
+                    "enum": [
+                        "edit_post",
+                        "close_post",
+                        "delete_post",
+                        "unlist_post",
+                        "ignore_report"
+                    ]
                 },
                 "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.TagResp"
+                        "$ref": "#/definitions/schema.TagItem"
                     }
                 },
                 "title": {
-                    "type": "string"
-                },
-                "unique_view_count": {
-                    "type": "integer"
-                },
-                "update_time": {
-                    "type": "integer"
-                },
-                "update_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                },
-                "url_title": {
-                    "type": "string"
-                },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
-                },
-                "view_count": {
-                    "type": "integer"
-                },
-                "vote_count": {
-                    "type": "integer"
-                },
-                "vote_status": {
-                    "type": "string"
-                }
-            }
-        },
-        "schema.QuestionPageReq": {
-            "type": "object",
-            "properties": {
-                "in_days": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "order": {
-                    "type": "string",
-                    "enum": [
-                        "newest",
-                        "active",
-                        "hot",
-                        "score",
-                        "unanswered",
-                        "recommend",
-                        "frequent"
-                    ]
-                },
-                "page": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "page_size": {
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "tag": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "username": {
                     "type": "string",
-                    "maxLength": 100
+                    "maxLength": 150,
+                    "minLength": 6
                 }
             }
         },
-        "schema.QuestionPageResp": {
+        "schema.RevisionAuditReq": {
             "type": "object",
+            "required": [
+                "id",
+                "operation"
+            ],
             "properties": {
-                "accepted_answer_id": {
-                    "description": "answer information",
-                    "type": "string"
-                },
-                "answer_count": {
-                    "type": "integer"
-                },
-                "collection_count": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "follow_count": {
-                    "type": "integer"
-                },
                 "id": {
+                    "description": "object id",
                     "type": "string"
                 },
-                "last_answer_id": {
-                    "type": "string"
-                },
-                "operated_at": {
-                    "description": "operator information",
-                    "type": "integer"
-                },
-                "operation_type": {
-                    "type": "string"
-                },
-                "operator": {
-                    "$ref": "#/definitions/schema.QuestionPageRespOperator"
-                },
-                "pin": {
-                    "description": "1: unpin, 2: pin",
-                    "type": "integer"
-                },
-                "show": {
-                    "description": "0: show, 1: hide",
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagResp"
-                    }
-                },
-                "title": {
-                    "type": "string"
-                },
-                "unique_view_count": {
-                    "type": "integer"
-                },
-                "url_title": {
-                    "type": "string"
-                },
-                "view_count": {
-                    "description": "question statistical information",
-                    "type": "integer"
+                "operation": {
+                    "description": "approve or reject",
+                    "type": "string"
                 },
-                "vote_count": {
-                    "type": "integer"
+                "reason": {
+                    "description": "the reason why the revision is rejected, it is sent to the submitter",
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "schema.QuestionPageRespOperator": {
+        "schema.RevokeUserAPIKeyReq": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "avatar": {
-                    "type": "string"
-                },
-                "display_name": {
-                    "type": "string"
-                },
                 "id": {
-                    "type": "string"
-                },
-                "rank": {
                     "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
                 }
             }
         },
-        "schema.QuestionRecoverReq": {
+        "schema.RevokeUserSessionsReq": {
             "type": "object",
             "required": [
-                "question_id"
+                "user_id"
             ],
             "properties": {
-                "question_id": {
+                "revoke_api_keys": {
+                    "description": "RevokeAPIKeys remove the api keys of the user too",
+                    "type": "boolean"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.QuestionUpdate": {
+        "schema.RollbackRevisionReq": {
             "type": "object",
             "required": [
-                "id",
-                "title"
+                "id"
             ],
             "properties": {
-                "captcha_code": {
+                "id": {
+                    "description": "revision id",
                     "type": "string"
                 },
-                "captcha_id": {
-                    "description": "captcha_id",
+                "reason": {
+                    "description": "why to roll back, the default log is used when empty",
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "schema.RollbackRevisionResp": {
+            "type": "object",
+            "properties": {
+                "revision_id": {
+                    "description": "the new revision id",
                     "type": "string"
-                },
+                }
+            }
+        },
+        "schema.SaveDraftReq": {
+            "type": "object",
+            "required": [
+                "target_type"
+            ],
+            "properties": {
                 "content": {
-                    "description": "content",
                     "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 0
-                },
-                "edit_summary": {
-                    "description": "edit summary",
-                    "type": "string"
+                    "maxLength": 65535
                 },
-                "id": {
-                    "description": "question id",
-                    "type": "string"
+                "question_id": {
+                    "description": "QuestionID the question the answer belongs to, it is required if the target type is answer",
+                    "type": "string",
+                    "maxLength": 30
                 },
-                "invite_user": {
+                "tags": {
                     "type": "array",
+                    "maxItems": 10,
                     "items": {
                         "type": "string"
                     }
                 },
-                "tags": {
-                    "description": "tags",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagItem"
-                    }
+                "target_type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer"
+                    ]
                 },
                 "title": {
-                    "description": "question title",
                     "type": "string",
-                    "maxLength": 150,
-                    "minLength": 6
+                    "maxLength": 150
                 }
             }
         },
-        "schema.QuestionUpdateInviteUser": {
+        "schema.SaveEmailTemplateReq": {
             "type": "object",
             "required": [
-                "id"
+                "html_body",
+                "language",
+                "subject",
+                "type"
             ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
+                "html_body": {
+                    "type": "string",
+                    "maxLength": 65535
                 },
-                "captcha_id": {
-                    "description": "captcha_id",
-                    "type": "string"
+                "language": {
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "id": {
-                    "type": "string"
+                "subject": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "invite_user": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "text_body": {
+                    "type": "string",
+                    "maxLength": 65535
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "new_answer",
+                        "new_comment",
+                        "invited_you_to_answer",
+                        "new_question",
+                        "notification_event"
+                    ]
                 }
             }
         },
-        "schema.ReactionRespItem": {
+        "schema.SavedSearchResp": {
             "type": "object",
             "properties": {
-                "count": {
-                    "description": "Count is the number of users who reacted",
+                "alert_email": {
+                    "type": "boolean"
+                },
+                "alert_enabled": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "integer"
                 },
-                "emoji": {
-                    "description": "Emoji is the reaction emoji",
+                "id": {
                     "type": "string"
                 },
-                "is_active": {
-                    "description": "IsActive is if current user has reacted",
-                    "type": "boolean"
+                "last_checked_at": {
+                    "description": "LastCheckedAt the unix time the alert checked the new content last time, zero if the alert is disabled",
+                    "type": "integer"
                 },
-                "tooltip": {
-                    "description": "Tooltip is the user's name who reacted",
+                "name": {
+                    "type": "string"
+                },
+                "normalized_query": {
+                    "description": "NormalizedQuery the query that is actually searched",
+                    "type": "string"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "query": {
                     "type": "string"
                 }
             }
         },
-        "schema.ReasonItem": {
+        "schema.SearchObject": {
             "type": "object",
             "properties": {
-                "content_type": {
+                "accepted": {
+                    "type": "boolean"
+                },
+                "answer_count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "excerpt": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "name": {
+                "question_id": {
                     "type": "string"
                 },
-                "placeholder": {
+                "status": {
+                    "description": "Status",
                     "type": "string"
                 },
-                "reason_key": {
+                "tags": {
+                    "description": "tags",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagResp"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
-                "reason_type": {
+                "url_title": {
+                    "type": "string"
+                },
+                "user_info": {
+                    "description": "user info",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.SearchObjectUser"
+                        }
+                    ]
+                },
+                "vote_count": {
                     "type": "integer"
                 }
             }
         },
-        "schema.RecoverAnswerReq": {
+        "schema.SearchObjectUser": {
             "type": "object",
-            "required": [
-                "answer_id"
-            ],
             "properties": {
-                "answer_id": {
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "schema.RecoverTagReq": {
+        "schema.SearchOperator": {
             "type": "object",
-            "required": [
-                "tag_id"
-            ],
             "properties": {
-                "tag_id": {
+                "operator": {
+                    "type": "string",
+                    "enum": [
+                        "tag",
+                        "user",
+                        "score",
+                        "created",
+                        "views",
+                        "answers",
+                        "is",
+                        "hasaccepted",
+                        "isaccepted",
+                        "inquestion"
+                    ]
+                },
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "schema.RemoveAnswerReq": {
+        "schema.SearchResp": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "captcha_id": {
-                    "type": "string"
+                "list": {
+                    "description": "search response",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SearchResult"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "operators": {
+                    "description": "Operators the operators in the query that filter the results",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SearchOperator"
+                    }
                 }
             }
         },
-        "schema.RemoveCommentReq": {
+        "schema.SearchResult": {
             "type": "object",
-            "required": [
-                "comment_id"
-            ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
-                },
-                "captcha_id": {
-                    "type": "string"
+                "object": {
+                    "description": "this object",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.SearchObject"
+                        }
+                    ]
                 },
-                "comment_id": {
-                    "description": "comment id",
+                "object_type": {
+                    "description": "object_type",
                     "type": "string"
                 }
             }
         },
-        "schema.RemoveQuestionReq": {
+        "schema.SendTestEmailReq": {
             "type": "object",
             "required": [
-                "id"
+                "email"
             ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
-                },
-                "captcha_id": {
-                    "description": "captcha_id",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "question id",
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
-        "schema.RemoveTagReq": {
+        "schema.SendUserActivationReq": {
             "type": "object",
             "required": [
-                "tag_id"
+                "user_id"
             ],
             "properties": {
-                "tag_id": {
-                    "description": "tag_id",
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.ReopenQuestionReq": {
+        "schema.SiteAIProvider": {
             "type": "object",
             "properties": {
-                "question_id": {
-                    "type": "string"
+                "api_host": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "api_key": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "model": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 50
                 }
             }
         },
-        "schema.ReviewReportReq": {
+        "schema.SiteAIReq": {
             "type": "object",
-            "required": [
-                "flag_id",
-                "operation_type"
-            ],
             "properties": {
-                "close_msg": {
-                    "type": "string"
-                },
-                "close_type": {
-                    "type": "integer"
+                "ai_providers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SiteAIProvider"
+                    }
                 },
-                "content": {
+                "chosen_provider": {
                     "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 6
-                },
-                "flag_id": {
-                    "type": "string"
+                    "maxLength": 50
                 },
-                "operation_type": {
-                    "type": "string",
-                    "enum": [
-                        "edit_post",
-                        "close_post",
-                        "delete_post",
-                        "unlist_post",
-                        "ignore_report"
-                    ]
+                "enabled": {
+                    "type": "boolean"
                 },
-                "tags": {
+                "prompt_config": {
+                    "$ref": "#/definitions/schema.AIPromptConfig"
+                }
+            }
+        },
+        "schema.SiteAIResp": {
+            "type": "object",
+            "properties": {
+                "ai_providers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.TagItem"
+                        "$ref": "#/definitions/schema.SiteAIProvider"
                     }
                 },
-                "title": {
+                "chosen_provider": {
                     "type": "string",
-                    "maxLength": 150,
-                    "minLength": 6
+                    "maxLength": 50
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "prompt_config": {
+                    "$ref": "#/definitions/schema.AIPromptConfig"
                 }
             }
         },
-        "schema.RevisionAuditReq": {
+        "schema.SiteAcceptReputationReq": {
             "type": "object",
-            "required": [
-                "id",
-                "operation"
-            ],
             "properties": {
-                "id": {
-                    "description": "object id",
-                    "type": "string"
+                "answerer_reputation": {
+                    "description": "AnswererReputation the reputation the author of the accepted answer gains",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
                 },
-                "operation": {
-                    "description": "approve or reject",
-                    "type": "string"
+                "asker_bonus_enabled": {
+                    "type": "boolean"
+                },
+                "asker_reputation": {
+                    "description": "AskerReputation the reputation the asker gains for accepting an answer",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
                 }
             }
         },
-        "schema.SearchObject": {
+        "schema.SiteAcceptReputationResp": {
             "type": "object",
             "properties": {
-                "accepted": {
-                    "type": "boolean"
-                },
-                "answer_count": {
-                    "type": "integer"
+                "answerer_reputation": {
+                    "description": "AnswererReputation the reputation the author of the accepted answer gains",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
                 },
-                "created_at": {
-                    "type": "integer"
+                "asker_bonus_enabled": {
+                    "type": "boolean"
                 },
-                "excerpt": {
-                    "type": "string"
+                "asker_reputation": {
+                    "description": "AskerReputation the reputation the asker gains for accepting an answer",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SiteAdvancedReq": {
+            "type": "object",
+            "properties": {
+                "authorized_attachment_extensions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "authorized_attachment_mime_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "question_id": {
-                    "type": "string"
+                "authorized_avatar_mime_types": {
+                    "description": "the mime types detected from the content of the files, all types are allowed if it is empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "status": {
-                    "description": "Status",
-                    "type": "string"
+                "authorized_image_extensions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "tags": {
-                    "description": "tags",
+                "authorized_image_mime_types": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.TagResp"
+                        "type": "string"
                     }
                 },
-                "title": {
-                    "type": "string"
+                "max_attachment_size": {
+                    "type": "integer"
                 },
-                "url_title": {
-                    "type": "string"
+                "max_avatar_size": {
+                    "type": "integer"
                 },
-                "user_info": {
-                    "description": "user info",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/schema.SearchObjectUser"
-                        }
-                    ]
+                "max_image_megapixel": {
+                    "type": "integer"
                 },
-                "vote_count": {
+                "max_image_size": {
                     "type": "integer"
+                },
+                "thumbnail_size": {
+                    "description": "the resized variants of the post images, in pixels",
+                    "type": "integer",
+                    "maximum": 2048
+                },
+                "web_image_max_width": {
+                    "type": "integer",
+                    "maximum": 8192
                 }
             }
         },
-        "schema.SearchObjectUser": {
+        "schema.SiteAdvancedResp": {
             "type": "object",
             "properties": {
-                "display_name": {
-                    "type": "string"
+                "authorized_attachment_extensions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "authorized_attachment_mime_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "rank": {
+                "authorized_avatar_mime_types": {
+                    "description": "the mime types detected from the content of the files, all types are allowed if it is empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "authorized_image_extensions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "authorized_image_mime_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_attachment_size": {
                     "type": "integer"
                 },
-                "status": {
-                    "type": "string"
+                "max_avatar_size": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string"
+                "max_image_megapixel": {
+                    "type": "integer"
+                },
+                "max_image_size": {
+                    "type": "integer"
+                },
+                "thumbnail_size": {
+                    "description": "the resized variants of the post images, in pixels",
+                    "type": "integer",
+                    "maximum": 2048
+                },
+                "web_image_max_width": {
+                    "type": "integer",
+                    "maximum": 8192
                 }
             }
         },
-        "schema.SearchResp": {
+        "schema.SiteAutoCloseReq": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "close",
+                        "archive"
+                    ]
                 },
-                "list": {
-                    "description": "search response",
+                "comment": {
+                    "description": "Comment the comment posted to the stale questions, nothing is posted if it is empty",
+                    "type": "string",
+                    "maxLength": 600
+                },
+                "days": {
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exempt_tags": {
+                    "description": "ExemptTags the slug names of the tags whose questions are never closed or archived",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.SearchResult"
+                        "type": "string"
                     }
+                },
+                "username": {
+                    "description": "Username the user the job acts as, it is recorded as the operator and posts the comments",
+                    "type": "string",
+                    "maxLength": 30
                 }
             }
         },
-        "schema.SearchResult": {
+        "schema.SiteAutoCloseResp": {
             "type": "object",
             "properties": {
-                "object": {
-                    "description": "this object",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/schema.SearchObject"
-                        }
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "close",
+                        "archive"
                     ]
                 },
-                "object_type": {
-                    "description": "object_type",
-                    "type": "string"
-                }
-            }
-        },
-        "schema.SendUserActivationReq": {
-            "type": "object",
-            "required": [
-                "user_id"
-            ],
-            "properties": {
-                "user_id": {
-                    "type": "string"
+                "comment": {
+                    "description": "Comment the comment posted to the stale questions, nothing is posted if it is empty",
+                    "type": "string",
+                    "maxLength": 600
+                },
+                "days": {
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exempt_tags": {
+                    "description": "ExemptTags the slug names of the tags whose questions are never closed or archived",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "username": {
+                    "description": "Username the user the job acts as, it is recorded as the operator and posts the comments",
+                    "type": "string",
+                    "maxLength": 30
                 }
             }
         },
-        "schema.SiteAIProvider": {
+        "schema.SiteBrandingReq": {
             "type": "object",
             "properties": {
-                "api_host": {
+                "favicon": {
                     "type": "string",
                     "maxLength": 512
                 },
-                "api_key": {
+                "logo": {
                     "type": "string",
-                    "maxLength": 256
+                    "maxLength": 512
                 },
-                "model": {
+                "mobile_logo": {
                     "type": "string",
-                    "maxLength": 100
+                    "maxLength": 512
                 },
-                "provider": {
+                "square_icon": {
                     "type": "string",
-                    "maxLength": 50
+                    "maxLength": 512
                 }
             }
         },
-        "schema.SiteAIReq": {
+        "schema.SiteBrandingResp": {
             "type": "object",
             "properties": {
-                "ai_providers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.SiteAIProvider"
-                    }
+                "favicon": {
+                    "type": "string",
+                    "maxLength": 512
                 },
-                "chosen_provider": {
+                "logo": {
                     "type": "string",
-                    "maxLength": 50
+                    "maxLength": 512
                 },
-                "enabled": {
-                    "type": "boolean"
+                "mobile_logo": {
+                    "type": "string",
+                    "maxLength": 512
                 },
-                "prompt_config": {
-                    "$ref": "#/definitions/schema.AIPromptConfig"
+                "square_icon": {
+                    "type": "string",
+                    "maxLength": 512
                 }
             }
         },
-        "schema.SiteAIResp": {
+        "schema.SiteCORSReq": {
             "type": "object",
             "properties": {
-                "ai_providers": {
+                "allow_credentials": {
+                    "type": "boolean"
+                },
+                "allowed_headers": {
                     "type": "array",
+                    "maxItems": 50,
                     "items": {
-                        "$ref": "#/definitions/schema.SiteAIProvider"
+                        "type": "string"
                     }
                 },
-                "chosen_provider": {
-                    "type": "string",
-                    "maxLength": 50
+                "allowed_methods": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_origins": {
+                    "description": "AllowedOrigins the origins such as https://example.com, \"*\" allows all the origins",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "enabled": {
                     "type": "boolean"
                 },
-                "prompt_config": {
-                    "$ref": "#/definitions/schema.AIPromptConfig"
+                "exposed_headers": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_age": {
+                    "description": "MaxAge the seconds the result of the preflight request can be cached",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
                 }
             }
         },
-        "schema.SiteAdvancedReq": {
+        "schema.SiteCORSResp": {
             "type": "object",
             "properties": {
-                "authorized_attachment_extensions": {
+                "allow_credentials": {
+                    "type": "boolean"
+                },
+                "allowed_headers": {
                     "type": "array",
+                    "maxItems": 50,
                     "items": {
                         "type": "string"
                     }
                 },
-                "authorized_image_extensions": {
+                "allowed_methods": {
                     "type": "array",
+                    "maxItems": 20,
                     "items": {
                         "type": "string"
                     }
                 },
-                "max_attachment_size": {
-                    "type": "integer"
-                },
-                "max_image_megapixel": {
-                    "type": "integer"
-                },
-                "max_image_size": {
-                    "type": "integer"
-                }
-            }
-        },
-        "schema.SiteAdvancedResp": {
-            "type": "object",
-            "properties": {
-                "authorized_attachment_extensions": {
+                "allowed_origins": {
+                    "description": "AllowedOrigins the origins such as https://example.com, \"*\" allows all the origins",
                     "type": "array",
+                    "maxItems": 100,
                     "items": {
                         "type": "string"
                     }
                 },
-                "authorized_image_extensions": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exposed_headers": {
                     "type": "array",
+                    "maxItems": 50,
                     "items": {
                         "type": "string"
                     }
                 },
-                "max_attachment_size": {
-                    "type": "integer"
+                "max_age": {
+                    "description": "MaxAge the seconds the result of the preflight request can be cached",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SiteCaptchaPublicResp": {
+            "type": "object",
+            "properties": {
+                "first_post": {
+                    "type": "boolean"
                 },
-                "max_image_megapixel": {
-                    "type": "integer"
+                "provider": {
+                    "type": "string"
                 },
-                "max_image_size": {
-                    "type": "integer"
+                "site_key": {
+                    "type": "string"
                 }
             }
         },
-        "schema.SiteBrandingReq": {
+        "schema.SiteCaptchaReq": {
             "type": "object",
             "properties": {
-                "favicon": {
-                    "type": "string",
-                    "maxLength": 512
+                "enabled": {
+                    "type": "boolean"
                 },
-                "logo": {
+                "first_post": {
+                    "type": "boolean"
+                },
+                "provider": {
                     "type": "string",
-                    "maxLength": 512
+                    "enum": [
+                        "hcaptcha",
+                        "recaptcha",
+                        "turnstile"
+                    ]
                 },
-                "mobile_logo": {
+                "secret_key": {
                     "type": "string",
-                    "maxLength": 512
+                    "maxLength": 256
                 },
-                "square_icon": {
+                "site_key": {
                     "type": "string",
-                    "maxLength": 512
+                    "maxLength": 256
                 }
             }
         },
-        "schema.SiteBrandingResp": {
+        "schema.SiteCaptchaResp": {
             "type": "object",
             "properties": {
-                "favicon": {
-                    "type": "string",
-                    "maxLength": 512
+                "enabled": {
+                    "type": "boolean"
                 },
-                "logo": {
+                "first_post": {
+                    "type": "boolean"
+                },
+                "provider": {
                     "type": "string",
-                    "maxLength": 512
+                    "enum": [
+                        "hcaptcha",
+                        "recaptcha",
+                        "turnstile"
+                    ]
                 },
-                "mobile_logo": {
+                "secret_key": {
                     "type": "string",
-                    "maxLength": 512
+                    "maxLength": 256
                 },
-                "square_icon": {
+                "site_key": {
                     "type": "string",
-                    "maxLength": 512
+                    "maxLength": 256
                 }
             }
         },
@@ -11971,6 +18405,22 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteGuestPostingReq": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.SiteGuestPostingResp": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
         "schema.SiteInfoResp": {
             "type": "object",
             "properties": {
@@ -11980,21 +18430,34 @@ const docTemplate = `{
                 "branding": {
                     "$ref": "#/definitions/schema.SiteBrandingResp"
                 },
+                "captcha": {
+                    "$ref": "#/definitions/schema.SiteCaptchaPublicResp"
+                },
                 "custom_css_html": {
                     "$ref": "#/definitions/schema.SiteCustomCssHTMLResp"
                 },
                 "general": {
                     "$ref": "#/definitions/schema.SiteGeneralResp"
                 },
+                "guest_posting_enabled": {
+                    "description": "GuestPostingEnabled the guests can ask questions without logging in",
+                    "type": "boolean"
+                },
                 "interface": {
                     "$ref": "#/definitions/schema.SiteInterfaceSettingsResp"
                 },
                 "login": {
                     "$ref": "#/definitions/schema.SiteLoginResp"
                 },
+                "maintenance": {
+                    "$ref": "#/definitions/schema.SiteMaintenanceResp"
+                },
                 "mcp_enabled": {
                     "type": "boolean"
                 },
+                "reaction": {
+                    "$ref": "#/definitions/schema.SiteReactionResp"
+                },
                 "revision": {
                     "type": "string"
                 },
@@ -12039,26 +18502,118 @@ const docTemplate = `{
             "properties": {
                 "language": {
                     "type": "string",
-                    "maxLength": 128
+                    "maxLength": 128
+                },
+                "time_zone": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "schema.SiteInterfaceSettingsResp": {
+            "type": "object",
+            "required": [
+                "language",
+                "time_zone"
+            ],
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "time_zone": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "schema.SiteLDAPReq": {
+            "type": "object",
+            "properties": {
+                "base_dn": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "bind_dn": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "bind_password": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "display_name_attribute": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "email_attribute": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "insecure_skip_verify": {
+                    "type": "boolean"
+                },
+                "server_url": {
+                    "type": "string",
+                    "maxLength": 512
                 },
-                "time_zone": {
+                "start_tls": {
+                    "type": "boolean"
+                },
+                "user_filter": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "username_attribute": {
                     "type": "string",
                     "maxLength": 128
                 }
             }
         },
-        "schema.SiteInterfaceSettingsResp": {
+        "schema.SiteLDAPResp": {
             "type": "object",
-            "required": [
-                "language",
-                "time_zone"
-            ],
             "properties": {
-                "language": {
+                "base_dn": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "bind_dn": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "bind_password": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "display_name_attribute": {
                     "type": "string",
                     "maxLength": 128
                 },
-                "time_zone": {
+                "email_attribute": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "insecure_skip_verify": {
+                    "type": "boolean"
+                },
+                "server_url": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "start_tls": {
+                    "type": "boolean"
+                },
+                "user_filter": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "username_attribute": {
                     "type": "string",
                     "maxLength": 128
                 }
@@ -12100,8 +18655,26 @@ const docTemplate = `{
                 "allow_password_login": {
                     "type": "boolean"
                 },
+                "remember_me_timeout": {
+                    "description": "RememberMeTimeout the days the session of a \"remember me\" login lasts, 0 means the default",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
                 "require_email_verification": {
                     "type": "boolean"
+                },
+                "session_absolute_timeout": {
+                    "description": "SessionAbsoluteTimeout the hours after login after which the session expires, 0 means the default",
+                    "type": "integer",
+                    "maximum": 8760,
+                    "minimum": 1
+                },
+                "session_idle_timeout": {
+                    "description": "SessionIdleTimeout the minutes of inactivity after which the session expires, 0 means the default",
+                    "type": "integer",
+                    "maximum": 525600,
+                    "minimum": 5
                 }
             }
         },
@@ -12123,8 +18696,17 @@ const docTemplate = `{
                 "allow_password_login": {
                     "type": "boolean"
                 },
+                "remember_me_timeout": {
+                    "type": "integer"
+                },
                 "require_email_verification": {
                     "type": "boolean"
+                },
+                "session_absolute_timeout": {
+                    "type": "integer"
+                },
+                "session_idle_timeout": {
+                    "type": "integer"
                 }
             }
         },
@@ -12153,6 +18735,32 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteMaintenanceReq": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "description": "Message the message shown to the users when they try to change anything, the default one is used if empty",
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "schema.SiteMaintenanceResp": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "description": "Message the message shown to the users when they try to change anything, the default one is used if empty",
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
         "schema.SitePoliciesReq": {
             "type": "object",
             "properties": {
@@ -12187,6 +18795,80 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SitePostingRestrictionReq": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_links_per_post": {
+                    "description": "MaxLinksPerPost the max amount of the links in a post, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "max_posts_per_day": {
+                    "description": "MaxPostsPerDay the max amount of the questions and answers in the last 24 hours, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "post_interval_seconds": {
+                    "description": "PostIntervalSeconds the seconds the user must wait after the last post, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "trusted_account_days": {
+                    "description": "TrustedAccountDays the accounts registered for these days are not restricted",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0
+                },
+                "trusted_reputation": {
+                    "description": "TrustedReputation the users whose reputation reaches it are not restricted",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SitePostingRestrictionResp": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_links_per_post": {
+                    "description": "MaxLinksPerPost the max amount of the links in a post, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "max_posts_per_day": {
+                    "description": "MaxPostsPerDay the max amount of the questions and answers in the last 24 hours, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "post_interval_seconds": {
+                    "description": "PostIntervalSeconds the seconds the user must wait after the last post, 0 means no limit",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0
+                },
+                "trusted_account_days": {
+                    "description": "TrustedAccountDays the accounts registered for these days are not restricted",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0
+                },
+                "trusted_reputation": {
+                    "description": "TrustedReputation the users whose reputation reaches it are not restricted",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
         "schema.SiteQuestionsReq": {
             "type": "object",
             "properties": {
@@ -12200,8 +18882,24 @@ const docTemplate = `{
                     "maximum": 5,
                     "minimum": 0
                 },
+                "min_title": {
+                    "description": "MinimumTitle the minimum characters of the title, 0 means no limit except the length of the title field",
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 0
+                },
+                "reject_title_as_content": {
+                    "description": "RejectTitleAsContent reject the question whose body is only the title repeated",
+                    "type": "boolean"
+                },
                 "restrict_answer": {
                     "type": "boolean"
+                },
+                "trending_half_life": {
+                    "description": "TrendingHalfLife the hours after which the trending score of the question halves, 0 means 24",
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 0
                 }
             }
         },
@@ -12213,13 +18911,189 @@ const docTemplate = `{
                     "maximum": 65535,
                     "minimum": 0
                 },
-                "min_tags": {
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 0
+                "min_tags": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "min_title": {
+                    "description": "MinimumTitle the minimum characters of the title, 0 means no limit except the length of the title field",
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 0
+                },
+                "reject_title_as_content": {
+                    "description": "RejectTitleAsContent reject the question whose body is only the title repeated",
+                    "type": "boolean"
+                },
+                "restrict_answer": {
+                    "type": "boolean"
+                },
+                "trending_half_life": {
+                    "description": "TrendingHalfLife the hours after which the trending score of the question halves, 0 means 24",
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SiteReactionReq": {
+            "type": "object",
+            "properties": {
+                "emojis": {
+                    "description": "Emojis the names of the allowed emojis, the default ones are allowed if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.SiteReactionResp": {
+            "type": "object",
+            "properties": {
+                "emojis": {
+                    "description": "Emojis the names of the allowed emojis, the default ones are allowed if empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.SiteRegistrationBlocklistReq": {
+            "type": "object",
+            "properties": {
+                "block_disposable_email": {
+                    "type": "boolean"
+                },
+                "blocked_email_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "blocked_ip_ranges": {
+                    "description": "BlockedIPRanges the ip addresses or the CIDR ranges, such as 203.0.113.0/24",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.SiteRegistrationBlocklistResp": {
+            "type": "object",
+            "properties": {
+                "block_disposable_email": {
+                    "type": "boolean"
+                },
+                "blocked_email_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "blocked_ip_ranges": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "disposable_email_domains": {
+                    "description": "DisposableEmailDomains the bulk imported disposable email domains, they are blocked if BlockDisposableEmail is true",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.SiteSAMLReq": {
+            "type": "object",
+            "properties": {
+                "allow_idp_initiated": {
+                    "type": "boolean"
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "display_name_attribute": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "email_attribute": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "idp_certificate": {
+                    "type": "string",
+                    "maxLength": 16384
+                },
+                "idp_entity_id": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "idp_sso_url": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "username_attribute": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "schema.SiteSAMLResp": {
+            "type": "object",
+            "properties": {
+                "acs_url": {
+                    "type": "string"
+                },
+                "allow_idp_initiated": {
+                    "type": "boolean"
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "display_name_attribute": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "email_attribute": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "idp_certificate": {
+                    "type": "string",
+                    "maxLength": 16384
+                },
+                "idp_entity_id": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "idp_sso_url": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "metadata_url": {
+                    "type": "string"
+                },
+                "sp_entity_id": {
+                    "description": "SPEntityID, AcsURL and MetadataURL are generated from the site URL for registering at the IdP",
+                    "type": "string"
                 },
-                "restrict_answer": {
-                    "type": "boolean"
+                "username_attribute": {
+                    "type": "string",
+                    "maxLength": 256
                 }
             }
         },
@@ -12239,6 +19113,15 @@ const docTemplate = `{
                         "ask_before_display"
                     ]
                 },
+                "impersonation": {
+                    "description": "Impersonation whether the admins can view as the users, read_only by default",
+                    "type": "string",
+                    "enum": [
+                        "disabled",
+                        "read_only",
+                        "full"
+                    ]
+                },
                 "login_required": {
                     "type": "boolean"
                 }
@@ -12260,6 +19143,15 @@ const docTemplate = `{
                         "ask_before_display"
                     ]
                 },
+                "impersonation": {
+                    "description": "Impersonation whether the admins can view as the users, read_only by default",
+                    "type": "string",
+                    "enum": [
+                        "disabled",
+                        "read_only",
+                        "full"
+                    ]
+                },
                 "login_required": {
                     "type": "boolean"
                 }
@@ -12272,6 +19164,11 @@ const docTemplate = `{
                 "robots"
             ],
             "properties": {
+                "feed_item_limit": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
                 "permalink": {
                     "type": "integer",
                     "maximum": 4,
@@ -12289,6 +19186,11 @@ const docTemplate = `{
                 "robots"
             ],
             "properties": {
+                "feed_item_limit": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
                 "permalink": {
                     "type": "integer",
                     "maximum": 4,
@@ -12299,6 +19201,178 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteSpamReq": {
+            "type": "object",
+            "properties": {
+                "akismet_api_key": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "akismet_enabled": {
+                    "type": "boolean"
+                },
+                "auto_hold": {
+                    "description": "AutoHold the spam posts are held for review if it is true, otherwise they are published and added to the review queue",
+                    "type": "boolean"
+                },
+                "bypass_reputation": {
+                    "description": "BypassReputation the posts of the users whose reputation reaches it are not checked",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "new_account_days": {
+                    "description": "NewAccountDays the accounts registered within these days are considered as new",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0
+                },
+                "threshold": {
+                    "description": "Threshold the posts whose score reaches it are considered as spam",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                }
+            }
+        },
+        "schema.SiteSpamResp": {
+            "type": "object",
+            "properties": {
+                "akismet_api_key": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "akismet_enabled": {
+                    "type": "boolean"
+                },
+                "auto_hold": {
+                    "description": "AutoHold the spam posts are held for review if it is true, otherwise they are published and added to the review queue",
+                    "type": "boolean"
+                },
+                "bypass_reputation": {
+                    "description": "BypassReputation the posts of the users whose reputation reaches it are not checked",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "new_account_days": {
+                    "description": "NewAccountDays the accounts registered within these days are considered as new",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0
+                },
+                "threshold": {
+                    "description": "Threshold the posts whose score reaches it are considered as spam",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                }
+            }
+        },
+        "schema.SiteStorageReq": {
+            "type": "object",
+            "properties": {
+                "access_key_id": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "bucket": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "endpoint": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "path_prefix": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "public_url": {
+                    "description": "PublicURL is the base URL of the bucket for public files, such as a CDN. Files are served by signed URLs if it is empty.",
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "secret_access_key": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "signed_url_expiry": {
+                    "description": "SignedURLExpiry is the validity of the signed URLs in seconds",
+                    "type": "integer",
+                    "maximum": 604800,
+                    "minimum": 0
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "s3"
+                    ]
+                },
+                "use_path_style": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.SiteStorageResp": {
+            "type": "object",
+            "properties": {
+                "access_key_id": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "bucket": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "endpoint": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "path_prefix": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "public_url": {
+                    "description": "PublicURL is the base URL of the bucket for public files, such as a CDN. Files are served by signed URLs if it is empty.",
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "secret_access_key": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "signed_url_expiry": {
+                    "description": "SignedURLExpiry is the validity of the signed URLs in seconds",
+                    "type": "integer",
+                    "maximum": 604800,
+                    "minimum": 0
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "s3"
+                    ]
+                },
+                "use_path_style": {
+                    "type": "boolean"
+                }
+            }
+        },
         "schema.SiteTagsReq": {
             "type": "object",
             "properties": {
@@ -12390,6 +19464,74 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteUsernamePolicyReq": {
+            "type": "object",
+            "properties": {
+                "allow_unicode": {
+                    "description": "AllowUnicode the letters and the digits of all the languages are allowed, otherwise only the ascii ones",
+                    "type": "boolean"
+                },
+                "allowed_symbols": {
+                    "description": "AllowedSymbols the symbols allowed besides the letters and the digits, such as \"_.- \"",
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_length": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "min_length": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "reserved_usernames": {
+                    "description": "ReservedUsernames they are reserved in addition to the built-in ones, they are compared case-insensitively",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.SiteUsernamePolicyResp": {
+            "type": "object",
+            "properties": {
+                "allow_unicode": {
+                    "description": "AllowUnicode the letters and the digits of all the languages are allowed, otherwise only the ascii ones",
+                    "type": "boolean"
+                },
+                "allowed_symbols": {
+                    "description": "AllowedSymbols the symbols allowed besides the letters and the digits, such as \"_.- \"",
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_length": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "min_length": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "reserved_usernames": {
+                    "description": "ReservedUsernames they are reserved in addition to the built-in ones, they are compared case-insensitively",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "schema.SiteUsersReq": {
             "type": "object",
             "required": [
@@ -12462,52 +19604,150 @@ const docTemplate = `{
                 }
             }
         },
-        "schema.SiteUsersSettingsReq": {
+        "schema.SiteUsersSettingsReq": {
+            "type": "object",
+            "required": [
+                "default_avatar"
+            ],
+            "properties": {
+                "default_avatar": {
+                    "type": "string",
+                    "enum": [
+                        "system",
+                        "gravatar"
+                    ]
+                },
+                "gravatar_base_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.SiteUsersSettingsResp": {
+            "type": "object",
+            "required": [
+                "default_avatar"
+            ],
+            "properties": {
+                "default_avatar": {
+                    "type": "string",
+                    "enum": [
+                        "system",
+                        "gravatar"
+                    ]
+                },
+                "gravatar_base_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.SiteWebhookReq": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "schema.SiteWebhookResp": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "schema.SiteWriteTag": {
             "type": "object",
             "required": [
-                "default_avatar"
+                "slug_name"
             ],
             "properties": {
-                "default_avatar": {
-                    "type": "string",
-                    "enum": [
-                        "system",
-                        "gravatar"
-                    ]
+                "display_name": {
+                    "type": "string"
                 },
-                "gravatar_base_url": {
+                "slug_name": {
                     "type": "string"
                 }
             }
         },
-        "schema.SiteUsersSettingsResp": {
+        "schema.SlowQueryConfigReq": {
+            "type": "object",
+            "properties": {
+                "threshold": {
+                    "description": "Threshold the milliseconds above which the queries are logged, 0 disables the slow query log",
+                    "type": "integer",
+                    "maximum": 600000,
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SlowQueryConfigResp": {
+            "type": "object",
+            "properties": {
+                "threshold": {
+                    "description": "Threshold the milliseconds above which the queries are logged, 0 disables the slow query log",
+                    "type": "integer",
+                    "maximum": 600000,
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.StartImpersonationReq": {
             "type": "object",
             "required": [
-                "default_avatar"
+                "user_id"
             ],
             "properties": {
-                "default_avatar": {
-                    "type": "string",
-                    "enum": [
-                        "system",
-                        "gravatar"
-                    ]
-                },
-                "gravatar_base_url": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "schema.SiteWriteTag": {
+        "schema.StartImpersonationResp": {
             "type": "object",
-            "required": [
-                "slug_name"
-            ],
             "properties": {
-                "display_name": {
+                "access_token": {
                     "type": "string"
                 },
-                "slug_name": {
+                "expire_at": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Mode read_only or full",
+                    "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "visit_token": {
                     "type": "string"
                 }
             }
@@ -12531,6 +19771,18 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.TagModeratorResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt the unix time the user is assigned as the moderator",
+                    "type": "integer"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
         "schema.TagResp": {
             "type": "object",
             "properties": {
@@ -12601,6 +19853,17 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UnlockPostReq": {
+            "type": "object",
+            "required": [
+                "object_id"
+            ],
+            "properties": {
+                "object_id": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.UnreviewedRevisionInfoInfo": {
             "type": "object",
             "properties": {
@@ -12762,6 +20025,28 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateNotificationPreferencesReq": {
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "email_frequency": {
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "hourly",
+                        "daily"
+                    ]
+                },
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.NotificationPreference"
+                    }
+                }
+            }
+        },
         "schema.UpdatePluginConfigReq": {
             "type": "object",
             "required": [
@@ -12793,6 +20078,20 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdatePostWikiReq": {
+            "type": "object",
+            "required": [
+                "object_id"
+            ],
+            "properties": {
+                "object_id": {
+                    "type": "string"
+                },
+                "wiki": {
+                    "type": "boolean"
+                }
+            }
+        },
         "schema.UpdatePrivilegesConfigReq": {
             "type": "object",
             "required": [
@@ -12815,6 +20114,22 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateQuestionScheduleReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "scheduled_at"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "description": "ScheduledAt the unix time the question is published at",
+                    "type": "integer"
+                }
+            }
+        },
         "schema.UpdateReactionReq": {
             "type": "object",
             "required": [
@@ -12825,11 +20140,7 @@ const docTemplate = `{
             "properties": {
                 "emoji": {
                     "type": "string",
-                    "enum": [
-                        "heart",
-                        "smile",
-                        "frown"
-                    ]
+                    "maxLength": 35
                 },
                 "object_id": {
                     "type": "string"
@@ -12862,6 +20173,33 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateRoleReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "powers"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "powers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "schema.UpdateSMTPConfigReq": {
             "type": "object",
             "properties": {
@@ -12906,6 +20244,43 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateSavedSearchReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "query"
+            ],
+            "properties": {
+                "alert_email": {
+                    "type": "boolean"
+                },
+                "alert_enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "order": {
+                    "type": "string",
+                    "enum": [
+                        "newest",
+                        "active",
+                        "score",
+                        "relevance"
+                    ]
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "minLength": 1
+                }
+            }
+        },
         "schema.UpdateTagReq": {
             "type": "object",
             "required": [
@@ -13149,6 +20524,17 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UserDataExportReq": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.UserEmailLoginReq": {
             "type": "object",
             "required": [
@@ -13170,6 +20556,10 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 32,
                     "minLength": 8
+                },
+                "remember_me": {
+                    "description": "RememberMe keep the login for longer, it lasts across browser restarts",
+                    "type": "boolean"
                 }
             }
         },
@@ -13272,6 +20662,14 @@ const docTemplate = `{
                     "description": "suspended until timestamp",
                     "type": "integer"
                 },
+                "two_factor_required": {
+                    "description": "two-factor authentication is required to finish the login, the other fields are empty",
+                    "type": "boolean"
+                },
+                "two_factor_token": {
+                    "description": "two-factor challenge token used to finish the login",
+                    "type": "string"
+                },
                 "username": {
                     "description": "username",
                     "type": "string"
@@ -13286,6 +20684,15 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UserLogoutEverywhereReq": {
+            "type": "object",
+            "properties": {
+                "revoke_api_keys": {
+                    "description": "RevokeAPIKeys remove the personal api keys too",
+                    "type": "boolean"
+                }
+            }
+        },
         "schema.UserModifyPasswordReq": {
             "type": "object",
             "required": [
@@ -13389,6 +20796,10 @@ const docTemplate = `{
                 "captcha_id": {
                     "type": "string"
                 },
+                "captcha_token": {
+                    "description": "CaptchaToken the token of the captcha widget of the third-party provider",
+                    "type": "string"
+                },
                 "e_mail": {
                     "type": "string",
                     "maxLength": 500
@@ -13417,12 +20828,89 @@ const docTemplate = `{
                 "captcha_id": {
                     "type": "string"
                 },
+                "captcha_token": {
+                    "description": "CaptchaToken the token of the captcha widget of the third-party provider",
+                    "type": "string"
+                },
                 "e_mail": {
                     "type": "string",
                     "maxLength": 500
                 }
             }
         },
+        "schema.UserTwoFactorActivateReq": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                }
+            }
+        },
+        "schema.UserTwoFactorDisableReq": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                }
+            }
+        },
+        "schema.UserTwoFactorEnrollResp": {
+            "type": "object",
+            "properties": {
+                "otpauth_url": {
+                    "type": "string"
+                },
+                "recovery_codes": {
+                    "description": "RecoveryCodes only be shown once, the user should save them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.UserTwoFactorLoginReq": {
+            "type": "object",
+            "required": [
+                "code",
+                "token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "schema.UserTwoFactorStatusResp": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "recovery_codes_remaining": {
+                    "type": "integer"
+                }
+            }
+        },
         "schema.UserUnsubscribeNotificationReq": {
             "type": "object",
             "required": [
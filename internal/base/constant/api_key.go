@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+import "time"
+
+const (
+	// APIKeyScopeReadOnly the key can only be used for the GET requests
+	APIKeyScopeReadOnly = "read-only"
+	// APIKeyScopeReadWrite the key can be used for all the requests the owner can do
+	APIKeyScopeReadWrite = "read-write"
+	// APIKeyScopeGlobal the site key created by the admin
+	APIKeyScopeGlobal = "global"
+)
+
+const (
+	// DefaultAPIKeyRateLimit the requests per minute of the key if the rate limit is not set
+	DefaultAPIKeyRateLimit = 60
+	// MaxAPIKeyRateLimit the max requests per minute of the key
+	MaxAPIKeyRateLimit = 6000
+	// APIKeyRateLimitWindow the window of the rate limit of the keys
+	APIKeyRateLimitWindow = time.Minute
+	// APIKeyLastUsedUpdateInterval the last used time of the key is only updated once in the interval
+	APIKeyLastUsedUpdateInterval = time.Minute
+	// MaxUserAPIKeys the max amount of the keys a user can issue
+	MaxUserAPIKeys = 20
+)
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+// audit log actions
+const (
+	AuditActionUserStatusChange     = "user.status.change"
+	AuditActionUserRoleChange       = "user.role.change"
+	AuditActionUserDataExport       = "user.data.export"
+	AuditActionUserErase            = "user.erase"
+	AuditActionUserSessionRevoke    = "user.session.revoke"
+	AuditActionUserImpersonateStart = "user.impersonate.start"
+	AuditActionUserImpersonateStop  = "user.impersonate.stop"
+	AuditActionQuestionStatusChange = "question.status.change"
+	AuditActionQuestionDelete       = "question.delete"
+	AuditActionQuestionRecover      = "question.recover"
+	AuditActionAnswerDelete         = "answer.delete"
+	AuditActionAnswerRecover        = "answer.recover"
+	AuditActionSettingChange        = "setting.change"
+	AuditActionModerationResolve    = "moderation.resolve"
+	AuditActionQuestionAutoClose    = "question.auto_close"
+	AuditActionPostLock             = "post.lock"
+	AuditActionPostUnlock           = "post.unlock"
+	AuditActionPostWikiChange       = "post.wiki.change"
+	AuditActionTagModeratorAdd      = "tag.moderator.add"
+	AuditActionTagModeratorRemove   = "tag.moderator.remove"
+)
+
+// SettingObjectType the object type of the audit log of the setting change, the object id is the setting type
+const SettingObjectType = "setting"
+
+// AuditRedactedValue replaces the sensitive values in the audit log
+const AuditRedactedValue = "[REDACTED]"
@@ -32,9 +32,16 @@ const (
 	AdminTokenCacheKey                         = "answer:admin:token:"
 	AdminTokenCacheTime                        = 7 * 24 * time.Hour
 	UserTokenMappingCacheKey                   = "answer:user-token:mapping:"
+	UserTokenMappingCacheTime                  = 366 * 24 * time.Hour
+	UserSessionRevokedCacheKey                 = "answer:user:session-revoked:"
 	UserEmailCodeCacheKey                      = "answer:user:email-code:"
 	UserEmailCodeCacheTime                     = 10 * time.Minute
+	GuestQuestionClaimCodeCacheTime            = 7 * 24 * time.Hour
+	UserChangeEmailCodeCacheTime               = 1 * time.Hour
 	UserLatestEmailCodeCacheKey                = "answer:user-id:email-code:"
+	UserTwoFactorChallengeCacheKey             = "answer:user:two-factor-challenge:"
+	UserTwoFactorChallengeCacheTime            = 5 * time.Minute
+	UserTwoFactorChallengeAttemptsCacheKey     = "answer:user:two-factor-challenge-attempts:"
 	SiteInfoCacheKey                           = "answer:site-info:"
 	SiteInfoCacheTime                          = 1 * time.Hour
 	ConfigID2KEYCacheKeyPrefix                 = "answer:config:id:"
@@ -45,8 +52,10 @@ const (
 	ConnectorOAuthStateCacheKey                = "answer:connector:oauth-state:"
 	ConnectorOAuthStateCacheTime               = 10 * time.Minute
 	ConnectorOAuthBindStateCacheTime           = 5 * time.Minute
-	SiteMapQuestionCacheKeyPrefix              = "answer:sitemap:question:%d"
-	SiteMapQuestionCacheTime                   = time.Hour
+	ConnectorSAMLAssertionCacheKey             = "answer:connector:saml-assertion:"
+	SitemapFileCacheKey                        = "answer:sitemap:file:"
+	SitemapIndexCacheKey                       = "answer:sitemap:index"
+	SitemapCacheTime                           = 3 * time.Hour
 	SitemapMaxSize                             = 50000
 	NewQuestionNotificationLimitCacheKeyPrefix = "answer:new-question-notification-limit:"
 	NewQuestionNotificationLimitCacheTime      = 7 * 24 * time.Hour
@@ -55,4 +64,28 @@ const (
 	RateLimitCacheTime                         = 5 * time.Minute
 	RedDotCacheKey                             = "answer:red-dot:%s:%s"
 	RedDotCacheTime                            = 30 * 24 * time.Hour
+	UserDataExportRunningCacheKey              = "answer:user:data-export:running:"
+	UserDataExportRunningCacheTime             = 1 * time.Hour
+	UserDraftCacheKey                          = "answer:user:draft:"
+	UserDraftCacheTime                         = 7 * 24 * time.Hour
+	QuestionRelatedCacheKey                    = "answer:question:related:"
+	QuestionRelatedCacheTime                   = 10 * time.Minute
+	ConditionalGetCacheKey                     = "answer:conditional-get:"
+	ConditionalGetCacheTime                    = 24 * time.Hour
+	QuestionPayloadCacheKey                    = "answer:question:payload:"
+	QuestionPayloadVersionCacheKey             = "answer:question:payload-version:"
+	QuestionPayloadCacheTime                   = 5 * time.Minute
+	ReplicaStickyCacheKey                      = "answer:db:replica-sticky:"
+	HealthProbeCacheKey                        = "answer:health:probe"
+	HealthProbeCacheTime                       = 1 * time.Minute
+	RolePowerCacheKey                          = "answer:role:power:"
+	RolePowerCacheTime                         = 10 * time.Minute
+	ReputationLeaderboardCacheKey              = "answer:reputation-leaderboard:"
+	ReputationLeaderboardCacheTime             = 5 * time.Minute
+	QuestionViewedCacheKey                     = "answer:question:viewed:%s:%s"
+	QuestionViewedCacheTime                    = 30 * time.Minute
+	QuestionPendingViewCacheKey                = "answer:question:pending-view:"
+	QuestionPendingViewIDsCacheKey             = "answer:question:pending-view-ids"
+	QuestionPendingViewFlushLockCacheKey       = "answer:question:pending-view-flush-lock"
+	QuestionPendingViewFlushLockCacheTime      = 5 * time.Minute
 )
@@ -19,10 +19,21 @@
 
 package constant
 
+import "time"
+
+// TestEmailSendTimeout a wrong smtp port may hang the connection, so the test email gives up after this
+const TestEmailSendTimeout = 10 * time.Second
+
+// UnsubscribeTokenExpiration the unsubscribe links in the notification emails work for this long
+const UnsubscribeTokenExpiration = 180 * 24 * time.Hour
+
 const (
 	EmailTplKeyChangeEmailTitle = "email_tpl.change_email.title"
 	EmailTplKeyChangeEmailBody  = "email_tpl.change_email.body"
 
+	EmailTplKeyChangeEmailNoticeTitle = "email_tpl.change_email_notice.title"
+	EmailTplKeyChangeEmailNoticeBody  = "email_tpl.change_email_notice.body"
+
 	EmailTplKeyNewAnswerTitle = "email_tpl.new_answer.title"
 	EmailTplKeyNewAnswerBody  = "email_tpl.new_answer.body"
 
@@ -43,4 +54,60 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeyNewQuestionDigestTitle = "email_tpl.new_question_digest.title"
+	EmailTplKeyNewQuestionDigestBody  = "email_tpl.new_question_digest.body"
+
+	EmailTplKeySavedSearchAlertTitle = "email_tpl.saved_search_alert.title"
+	EmailTplKeySavedSearchAlertBody  = "email_tpl.saved_search_alert.body"
+
+	EmailTplKeyUserDataExportTitle = "email_tpl.user_data_export.title"
+	EmailTplKeyUserDataExportBody  = "email_tpl.user_data_export.body"
+
+	EmailTplKeyModerationWarningTitle = "email_tpl.moderation_warning.title"
+	EmailTplKeyModerationWarningBody  = "email_tpl.moderation_warning.body"
+
+	EmailTplKeyGuestQuestionClaimTitle = "email_tpl.guest_question_claim.title"
+	EmailTplKeyGuestQuestionClaimBody  = "email_tpl.guest_question_claim.body"
+
+	EmailTplKeyNotificationEventTitle = "email_tpl.notification_event.title"
+	EmailTplKeyNotificationEventBody  = "email_tpl.notification_event.body"
+
+	EmailTplKeyNotificationDigestTitle = "email_tpl.notification_digest.title"
+	EmailTplKeyNotificationDigestBody  = "email_tpl.notification_digest.body"
+	// EmailTplKeyNotificationDigestGroupPrefix the names of the groups of the events in the digest email
+	EmailTplKeyNotificationDigestGroupPrefix = "email_tpl.notification_digest.groups."
+
+	// EmailTplKeyVariablePrefix the descriptions of the variables of the custom email templates
+	EmailTplKeyVariablePrefix = "email_tpl.variables."
 )
+
+// EmailTemplateType the notification emails whose templates can be customized by the admins
+type EmailTemplateType string
+
+const (
+	EmailTemplateTypeNewAnswer         EmailTemplateType = "new_answer"
+	EmailTemplateTypeNewComment        EmailTemplateType = "new_comment"
+	EmailTemplateTypeInvitedAnswer     EmailTemplateType = "invited_you_to_answer"
+	EmailTemplateTypeNewQuestion       EmailTemplateType = "new_question"
+	EmailTemplateTypeNotificationEvent EmailTemplateType = "notification_event"
+)
+
+// EmailTemplateTypes all the email template types that can be customized
+var EmailTemplateTypes = []EmailTemplateType{
+	EmailTemplateTypeNewAnswer,
+	EmailTemplateTypeNewComment,
+	EmailTemplateTypeInvitedAnswer,
+	EmailTemplateTypeNewQuestion,
+	EmailTemplateTypeNotificationEvent,
+}
+
+// EmailTemplateVariables the variables that can be used in the custom email templates, such as {{.SiteName}}
+var EmailTemplateVariables = []string{
+	"SiteName",
+	"UserName",
+	"PostTitle",
+	"ActionUrl",
+	"Summary",
+	"UnsubscribeUrl",
+}
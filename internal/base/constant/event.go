@@ -40,11 +40,16 @@ const (
 	eventShare  = "share"  // the object share link has been clicked
 	eventFlag   = "flag"
 	eventReact  = "react"
+	eventBan    = "ban"
+	eventRole   = "role"
 )
 
 const (
+	EventUserCreate EventType = eventUser + "." + eventCreate
 	EventUserUpdate EventType = eventUser + "." + eventUpdate
 	EventUserShare  EventType = eventUser + "." + eventShare
+	EventUserBan    EventType = eventUser + "." + eventBan
+	EventUserRole   EventType = eventUser + "." + eventRole
 )
 
 const (
@@ -72,4 +77,5 @@ const (
 	EventCommentDelete EventType = eventComment + "." + eventDelete
 	EventCommentVote   EventType = eventComment + "." + eventVote
 	EventCommentFlag   EventType = eventComment + "." + eventFlag
+	EventCommentReact  EventType = eventComment + "." + eventReact
 )
@@ -0,0 +1,33 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+const (
+	// GraphQLMaxDepth the max depth of the fields in the graphql query
+	GraphQLMaxDepth = 10
+	// GraphQLMaxComplexity the max amount of the objects the graphql query can resolve
+	GraphQLMaxComplexity = 1000
+	// GraphQLMaxQueryLength the max length of the graphql query
+	GraphQLMaxQueryLength = 10000
+	// GraphQLDefaultPageSize the page size of the lists in the graphql query if it is not set
+	GraphQLDefaultPageSize = 10
+	// GraphQLMaxPageSize the max page size of the lists in the graphql query
+	GraphQLMaxPageSize = 50
+)
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+import "time"
+
+const (
+	JobTypeEmailSend           = "email.send"
+	JobTypeWebhookDeliver      = "webhook.deliver"
+	JobTypeSearchIndexQuestion = "search.index.question"
+	JobTypeSearchIndexAnswer   = "search.index.answer"
+)
+
+const (
+	// JobDefaultConcurrency the amount of the jobs run at the same time by each instance
+	JobDefaultConcurrency = 4
+	// JobDefaultMaxAttempts the job is moved to the dead letters after these attempts
+	JobDefaultMaxAttempts = 5
+	// JobPollInterval how often the workers look for the due jobs, the jobs enqueued by the instance wake them up
+	JobPollInterval = time.Second
+	// JobTimeout the job is cancelled after it
+	JobTimeout = 5 * time.Minute
+	// JobLockTimeout the job of a crashed worker is run again after it is unlocked
+	JobLockTimeout = JobTimeout + time.Minute
+	// JobRetryBaseDelay the delay before the first retry, it doubles for each retry
+	JobRetryBaseDelay = 10 * time.Second
+	// JobRetryMaxDelay the max delay between the retries
+	JobRetryMaxDelay = time.Hour
+)
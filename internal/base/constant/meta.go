@@ -22,3 +22,11 @@ package constant
 const (
 	ReactionTooltipLabel = "reaction.tooltip"
 )
+
+const (
+	// ReactionEmojiMaxAmount the max amount of the emojis the admins can allow for the reactions
+	ReactionEmojiMaxAmount = 20
+)
+
+// DefaultReactionEmojis the emojis allowed for the reactions if the admins have not configured them
+var DefaultReactionEmojis = []string{"heart", "smile", "frown"}
@@ -58,6 +58,14 @@ const (
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
 	// NotificationEarnedBadge earned badge
 	NotificationEarnedBadge = "notification.action.earned_badge"
+	// NotificationNewQuestionInFollowingTag new question in the following tag
+	NotificationNewQuestionInFollowingTag = "notification.action.new_question_in_following_tag"
+	// NotificationSavedSearchAlert new content matching the saved search
+	NotificationSavedSearchAlert = "notification.action.saved_search_alert"
+	// NotificationEditApproved the suggested edit is approved by the reviewer
+	NotificationEditApproved = "notification.action.edit_approved"
+	// NotificationEditRejected the suggested edit is rejected by the reviewer
+	NotificationEditRejected = "notification.action.edit_rejected"
 )
 
 type NotificationChannelKey string
@@ -69,8 +77,55 @@ const (
 	AllNewQuestionForFollowingTagsSource NotificationSource = "all_new_question_for_following_tags"
 )
 
+// the events of the notification preferences, the user chooses the channels of each event
+const (
+	AnswerToMyQuestionSource NotificationSource = "answer_to_my_question"
+	CommentOnMyPostSource    NotificationSource = "comment_on_my_post"
+	MentionSource            NotificationSource = "mention"
+	AcceptedSource           NotificationSource = "accepted"
+	BadgeEarnedSource        NotificationSource = "badge_earned"
+	// NotificationDigestSource the frequency of the emails of the events above, they may be batched into the digest
+	NotificationDigestSource NotificationSource = "notification_digest"
+)
+
 const (
 	EmailChannel NotificationChannelKey = "email"
+	InAppChannel NotificationChannelKey = "in_app"
+)
+
+var (
+	NotificationPreferenceSources = []NotificationSource{
+		AnswerToMyQuestionSource,
+		CommentOnMyPostSource,
+		MentionSource,
+		AcceptedSource,
+		BadgeEarnedSource,
+	}
+	// NotificationActionPreferenceSource the notification actions that are controlled by the preferences
+	NotificationActionPreferenceSource = map[string]NotificationSource{
+		NotificationAnswerTheQuestion: AnswerToMyQuestionSource,
+		NotificationCommentQuestion:   CommentOnMyPostSource,
+		NotificationCommentAnswer:     CommentOnMyPostSource,
+		NotificationReplyToYou:        CommentOnMyPostSource,
+		NotificationMentionYou:        MentionSource,
+		NotificationAcceptAnswer:      AcceptedSource,
+		NotificationEarnedBadge:       BadgeEarnedSource,
+	}
+)
+
+// the frequency of the new question emails for the following tags, and the emails of the notification events
+const (
+	NotificationFrequencyImmediate = "immediate"
+	NotificationFrequencyHourly    = "hourly"
+	NotificationFrequencyDaily     = "daily"
+	NotificationFrequencyWeekly    = "weekly"
+)
+
+const (
+	// NewQuestionDigestMaxQuestions the max number of questions in one digest email
+	NewQuestionDigestMaxQuestions = 50
+	// NotificationDigestMaxItems the max number of notifications in one digest email
+	NotificationDigestMaxItems = 100
 )
 
 const (
@@ -81,23 +136,27 @@ const (
 
 var (
 	NotificationMsgTypeMapping = map[string]int{
-		NotificationUpdateQuestion:         1,
-		NotificationAnswerTheQuestion:      1,
-		NotificationUpVotedTheQuestion:     2,
-		NotificationDownVotedTheQuestion:   2,
-		NotificationUpdateAnswer:           1,
-		NotificationAcceptAnswer:           1,
-		NotificationUpVotedTheAnswer:       2,
-		NotificationDownVotedTheAnswer:     2,
-		NotificationCommentQuestion:        1,
-		NotificationCommentAnswer:          1,
-		NotificationUpVotedTheComment:      2,
-		NotificationReplyToYou:             1,
-		NotificationMentionYou:             1,
-		NotificationYourQuestionIsClosed:   1,
-		NotificationYourQuestionWasDeleted: 1,
-		NotificationYourAnswerWasDeleted:   1,
-		NotificationYourCommentWasDeleted:  1,
-		NotificationInvitedYouToAnswer:     3,
+		NotificationUpdateQuestion:            1,
+		NotificationAnswerTheQuestion:         1,
+		NotificationUpVotedTheQuestion:        2,
+		NotificationDownVotedTheQuestion:      2,
+		NotificationUpdateAnswer:              1,
+		NotificationAcceptAnswer:              1,
+		NotificationUpVotedTheAnswer:          2,
+		NotificationDownVotedTheAnswer:        2,
+		NotificationCommentQuestion:           1,
+		NotificationCommentAnswer:             1,
+		NotificationUpVotedTheComment:         2,
+		NotificationReplyToYou:                1,
+		NotificationMentionYou:                1,
+		NotificationYourQuestionIsClosed:      1,
+		NotificationYourQuestionWasDeleted:    1,
+		NotificationYourAnswerWasDeleted:      1,
+		NotificationYourCommentWasDeleted:     1,
+		NotificationInvitedYouToAnswer:        3,
+		NotificationNewQuestionInFollowingTag: 1,
+		NotificationSavedSearchAlert:          1,
+		NotificationEditApproved:              1,
+		NotificationEditRejected:              1,
 	}
 )
@@ -61,6 +61,8 @@ const (
 	RankQuestionCloseKey             = "rank.question.close"
 	RankQuestionReopenKey            = "rank.question.reopen"
 	RankTagUseReservedTagKey         = "rank.tag.use_reserved_tag"
+	RankQuestionBountyKey            = "rank.question.bounty"
+	RankPostWikiEditKey              = "rank.post.wiki_edit"
 )
 
 var (
@@ -70,6 +72,7 @@ var (
 		{Label: reason.RankCommentAddLabel, Key: RankCommentAddKey},
 		{Label: reason.RankReportAddLabel, Key: RankReportAddKey},
 		{Label: reason.RankCommentVoteUpLabel, Key: RankCommentVoteUpKey},
+		{Label: reason.RankCommentVoteDownLabel, Key: RankCommentVoteDownKey},
 		{Label: reason.RankLinkUrlLimitLabel, Key: RankLinkUrlLimitKey},
 		{Label: reason.RankQuestionVoteUpLabel, Key: RankQuestionVoteUpKey},
 		{Label: reason.RankAnswerVoteUpLabel, Key: RankAnswerVoteUpKey},
@@ -87,5 +90,7 @@ var (
 		{Label: reason.RankTagAuditLabel, Key: RankTagAuditKey},
 		{Label: reason.RankTagEditWithoutReviewLabel, Key: RankTagEditWithoutReviewKey},
 		{Label: reason.RankTagSynonymLabel, Key: RankTagSynonymKey},
+		{Label: reason.RankQuestionBountyLabel, Key: RankQuestionBountyKey},
+		{Label: reason.RankPostWikiEditLabel, Key: RankPostWikiEditKey},
 	}
 )
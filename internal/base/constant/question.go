@@ -19,9 +19,63 @@
 
 package constant
 
+import "time"
+
 const (
 	DeletedQuestionTitleTrKey = "question.deleted_title"
 	QuestionsTitleTrKey       = "question.questions_title"
 	TagsListTitleTrKey        = "tag.tags_title"
 	TagHasNoDescription       = "tag.no_description"
 )
+
+const (
+	// BountyDuration is how long a bounty stays open for answers.
+	BountyDuration = 7 * 24 * time.Hour
+	// BountyGracePeriod is how long the owner can still award the bounty after it expires,
+	// after that the bounty will be settled automatically.
+	BountyGracePeriod = 24 * time.Hour
+	// BountyAutoAwardMinVotes is the minimal votes for an answer to receive an unawarded bounty.
+	BountyAutoAwardMinVotes = 2
+)
+
+const (
+	// ScheduledQuestionPublishBatchSize is the max amount of the scheduled questions published in one run of the job,
+	// the rest of the due questions are published in the next run.
+	ScheduledQuestionPublishBatchSize = 100
+)
+
+const (
+	// DraftTargetQuestion the draft of a new question
+	DraftTargetQuestion = "question"
+	// DraftTargetAnswer the draft of an answer to a question
+	DraftTargetAnswer = "answer"
+)
+
+const (
+	// RelatedQuestionMaxSize is the max amount of the related questions of a question, the ranked list of this
+	// size is cached and the requested amount is taken from it.
+	RelatedQuestionMaxSize = 20
+	// RelatedQuestionDefaultSize is the amount of the related questions returned if it is not requested
+	RelatedQuestionDefaultSize = 5
+	// RelatedQuestionCandidateSize is the max amount of the candidates got by each way of matching before ranking
+	RelatedQuestionCandidateSize = 50
+)
+
+const (
+	// QuestionPinMaxAmount is the max amount of the questions pinned globally at the same time
+	QuestionPinMaxAmount = 10
+	// TagQuestionPinMaxAmount is the max amount of the questions pinned within a tag at the same time
+	TagQuestionPinMaxAmount = 5
+)
+
+const (
+	// AutoCloseActionClose the stale questions are closed
+	AutoCloseActionClose = "close"
+	// AutoCloseActionArchive the stale questions are hidden from the lists
+	AutoCloseActionArchive = "archive"
+	// StaleQuestionBatchSize is the amount of the stale questions handled in one batch
+	StaleQuestionBatchSize = 100
+	// StaleQuestionMaxBatches is the max amount of the batches in one run of the job, the rest of the stale
+	// questions are handled in the next run.
+	StaleQuestionMaxBatches = 10
+)
@@ -39,4 +39,5 @@ const (
 	ReasonNeedsEdit         = "reason.needs_edit"
 	ReasonNeedsClose        = "reason.needs_close"
 	ReasonNeedsDelete       = "reason.needs_delete"
+	ReasonStale             = "reason.stale"
 )
@@ -37,8 +37,23 @@ const (
 	ReportOperationIgnoreReport = "ignore_report"
 )
 
+// the operations to resolve the flagged object in the moderation queue
+const (
+	ModerationOperationDismiss = "dismiss"
+	ModerationOperationDelete  = "delete"
+	ModerationOperationEdit    = "edit"
+	ModerationOperationWarn    = "warn"
+)
+
 const (
 	ReviewQueuedPostLabel        = "review.queued_post"
 	ReviewFlaggedPostLabel       = "review.flagged_post"
 	ReviewSuggestedPostEditLabel = "review.suggested_post_edit"
 )
+
+const (
+	RevisionRollbackLog = "revision.rollback_log"
+)
+
+// RevisionMaxPendingPerUser the amount of the suggested edits of a user that can wait for review at the same time
+const RevisionMaxPendingPerUser = 5
@@ -19,6 +19,8 @@
 
 package constant
 
+import "time"
+
 const (
 	DefaultGravatarBaseURL = "https://www.gravatar.com/avatar/"
 	DefaultAvatar          = "system"
@@ -50,10 +52,168 @@ const (
 
 const (
 	EmailConfigKey = "email.config"
+	// EmailUnsubscribeSecretKey the secret that signs the unsubscribe tokens, it is generated when the site is installed or upgraded
+	EmailUnsubscribeSecretKey = "email.unsubscribe_secret"
+	// EmailTemplatesConfigKey the email templates customized by the admins, per template type and language
+	EmailTemplatesConfigKey = "email.templates"
 )
 
 const (
 	DefaultMaxImageMegapixel = 40 * 1000 * 1000
 	DefaultMaxImageSize      = 4 * 1024 * 1024
 	DefaultMaxAttachmentSize = 8 * 1024 * 1024
+	DefaultThumbnailSize     = 320
+	DefaultWebImageMaxWidth  = 1280
+)
+
+const (
+	StorageTypeLocal = "local"
+	StorageTypeS3    = "s3"
+
+	DefaultStorageRegion          = "us-east-1"
+	DefaultStorageSignedURLExpiry = 15 * time.Minute
+)
+
+const (
+	// LDAPProvider is the external login provider name of users logged in via LDAP
+	LDAPProvider = "ldap"
+	// LDAPLoginPlaceholder is replaced by the login name in the LDAP user filter
+	LDAPLoginPlaceholder = "%s"
+
+	DefaultLDAPUserFilter           = "(&(objectClass=person)(mail=%s))"
+	DefaultLDAPUsernameAttribute    = "uid"
+	DefaultLDAPEmailAttribute       = "mail"
+	DefaultLDAPDisplayNameAttribute = "displayName"
+	DefaultLDAPTimeout              = 10 * time.Second
+)
+
+const (
+	// SAMLProvider is the external login provider name of users logged in via SAML
+	SAMLProvider = "saml"
+
+	SAMLMetadataURLPath = "/answer/api/v1/saml/metadata"
+	SAMLAcsURLPath      = "/answer/api/v1/saml/acs"
+	SAMLLoginURLPath    = "/answer/api/v1/saml/login"
+
+	SAMLLogoSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" fill="currentColor" viewBox="0 0 16 16"><path d="M5.338 1.59a61 61 0 0 0-2.837.856.48.48 0 0 0-.328.39c-.554 4.157.726 7.19 2.253 9.188a10.7 10.7 0 0 0 2.287 2.233c.346.244.652.42.893.533q.18.085.293.118a1 1 0 0 0 .101.025 1 1 0 0 0 .1-.025q.114-.034.294-.118c.24-.113.547-.29.893-.533a10.7 10.7 0 0 0 2.287-2.233c1.527-1.997 2.807-5.031 2.253-9.188a.48.48 0 0 0-.328-.39c-.651-.213-1.75-.56-2.837-.855C9.552 1.29 8.531 1.067 8 1.067c-.53 0-1.552.223-2.662.524zM5.072.56C6.157.265 7.31 0 8 0s1.843.265 2.928.56c1.11.3 2.229.655 2.887.87a1.54 1.54 0 0 1 1.044 1.262c.596 4.477-.787 7.795-2.465 9.99a11.8 11.8 0 0 1-2.517 2.453 7 7 0 0 1-1.048.625c-.28.132-.581.24-.829.24s-.548-.108-.829-.24a7 7 0 0 1-1.048-.625 11.8 11.8 0 0 1-2.517-2.453C1.928 10.487.545 7.169 1.141 2.692A1.54 1.54 0 0 1 2.185 1.43 63 63 0 0 1 5.072.56"/></svg>`
+
+	DefaultSAMLDisplayName          = "SAML SSO"
+	DefaultSAMLEmailAttribute       = "email"
+	DefaultSAMLDisplayNameAttribute = "displayName"
+	DefaultSAMLUsernameAttribute    = "username"
+)
+
+const (
+	WebhookEventQuestionCreated = "question.created"
+	WebhookEventAnswerCreated   = "answer.created"
+	WebhookEventAnswerAccepted  = "answer.accepted"
+	WebhookEventUserCreated     = "user.created"
+	WebhookEventUserUpdated     = "user.updated"
+	WebhookEventUserBanned      = "user.banned"
+	WebhookEventUserRoleChanged = "user.role_changed"
+
+	WebhookSignatureHeader = "X-Answer-Signature"
+	WebhookEventHeader     = "X-Answer-Event"
+	WebhookDeliveryHeader  = "X-Answer-Delivery"
+	WebhookTimestampHeader = "X-Answer-Timestamp"
+
+	// WebhookMaxAttempts is the number of delivery attempts before a delivery is dead-lettered
+	WebhookMaxAttempts    = 4
+	WebhookRetryBaseDelay = time.Second
+	WebhookTimeout        = 10 * time.Second
+)
+
+const (
+	// SpamCheckSubmitter is the submitter of the reviews added by the spam check
+	SpamCheckSubmitter = "spam_check"
+	// GuestPostSubmitter is the submitter of the reviews of the questions asked by the guests
+	GuestPostSubmitter = "guest_post"
+
+	DefaultSpamThreshold        = 50
+	DefaultSpamBypassReputation = 200
+	DefaultSpamNewAccountDays   = 3
+	DefaultSpamCheckTimeout     = 5 * time.Second
+)
+
+const (
+	// DefaultUsernameMinLength and DefaultUsernameMaxLength the length bounds of the usernames by default
+	DefaultUsernameMinLength = 2
+	DefaultUsernameMaxLength = 30
+	// DefaultUsernameAllowedSymbols the symbols allowed in the usernames besides the letters and the digits by default
+	DefaultUsernameAllowedSymbols = "_.- "
 )
+
+const (
+	// DefaultPostingTrustedReputation the users whose reputation reaches it are not restricted by default
+	DefaultPostingTrustedReputation = 100
+	// DefaultPostingTrustedAccountDays the accounts registered for these days are not restricted by default
+	DefaultPostingTrustedAccountDays = 7
+)
+
+const (
+	// DefaultAcceptAskerReputation the reputation the asker gains for accepting an answer by default
+	DefaultAcceptAskerReputation = 2
+)
+
+const (
+	// DefaultTrendingHalfLife the trending score of the question halves every this amount of hours
+	DefaultTrendingHalfLife = 24
+	// MaxTrendingHalfLife the max half-life can be set, the questions are trending for at most 30 days
+	MaxTrendingHalfLife = 720
+)
+
+const (
+	CaptchaProviderHCaptcha  = "hcaptcha"
+	CaptchaProviderReCaptcha = "recaptcha"
+	CaptchaProviderTurnstile = "turnstile"
+
+	// CaptchaSceneRegister and the other scenes are where the captcha of the third-party provider is verified
+	CaptchaSceneRegister      = "register"
+	CaptchaScenePasswordReset = "password_reset"
+	CaptchaSceneFirstPost     = "first_post"
+	CaptchaSceneGuestPost     = "guest_post"
+
+	DefaultCaptchaVerifyTimeout = 5 * time.Second
+)
+
+const (
+	// CORSWildcardOrigin allows all the origins, it can not be used with credentials
+	CORSWildcardOrigin = "*"
+
+	DefaultCORSMaxAge = 600
+)
+
+var (
+	DefaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
+	DefaultCORSAllowedHeaders = []string{"Authorization", "Content-Type", "Accept-Language", "X-Requested-With"}
+)
+
+const (
+	// DefaultSessionIdleTimeout the session expires if the user is inactive for this duration
+	DefaultSessionIdleTimeout = 7 * 24 * time.Hour
+	// DefaultSessionAbsoluteTimeout the session expires after this duration since login even if the user is active
+	DefaultSessionAbsoluteTimeout = 30 * 24 * time.Hour
+	// DefaultRememberMeTimeout both the idle and the absolute timeout of the sessions of "remember me" logins
+	DefaultRememberMeTimeout = 30 * 24 * time.Hour
+	// UserSessionRefreshInterval the idle timer of the session is refreshed at most once in this interval
+	UserSessionRefreshInterval = time.Minute
+)
+
+// MaxDisposableEmailDomains the max amount of the disposable email domains in the registration blocklist
+const MaxDisposableEmailDomains = 100000
+
+// CaptchaProviderVerifyURLs the server side verification api of the captcha providers
+var CaptchaProviderVerifyURLs = map[string]string{
+	CaptchaProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	CaptchaProviderReCaptcha: "https://www.google.com/recaptcha/api/siteverify",
+	CaptchaProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// ReservedRouteSegments the first path segments of the routes registered by the application.
+// A site url subpath starting with one of them would collide with the application routes,
+// keep it in sync when adding a new top-level route.
+var ReservedRouteSegments = []string{
+	"api", "admin", "install", "installation", "static", "uploads", "swagger", "healthz",
+	"questions", "tags", "users", "search", "sitemap", "sitemap.xml", "robots.txt", "custom.css",
+	"opensearch.xml", "feed", "404", "50x",
+}
@@ -46,4 +46,22 @@ const (
 	SiteTypeAI            = "ai"
 	SiteTypeFeatureToggle = "feature-toggle"
 	SiteTypeMCP           = "mcp"
+	SiteTypeLDAP          = "ldap"
+	SiteTypeSAML          = "saml"
+	SiteTypeWebhook       = "webhook"
+	SiteTypeStorage       = "storage"
+	SiteTypeSpam          = "spam"
+	SiteTypeCaptcha       = "captcha"
+	SiteTypeCORS          = "cors"
+
+	SiteTypePostingRestriction = "posting-restriction"
+	SiteTypeAcceptReputation   = "accept-reputation"
+	SiteTypeGuestPosting       = "guest-posting"
+
+	SiteTypeRegistrationBlocklist = "registration-blocklist"
+	SiteTypeUsernamePolicy        = "username-policy"
+	SiteTypeMaintenance           = "maintenance"
+	SiteTypeAutoClose             = "auto-close"
+	SiteTypeReaction              = "reaction"
+	SiteTypeSlowQuery             = "slow-query"
 )
@@ -19,6 +19,11 @@
 
 package constant
 
+import (
+	"path"
+	"strings"
+)
+
 const (
 	AvatarSubPath      = "avatar"
 	AvatarThumbSubPath = "avatar_thumb"
@@ -27,3 +32,18 @@ const (
 	FilesPostSubPath   = "files/post"
 	DeletedSubPath     = "deleted"
 )
+
+const (
+	// ImageSizeThumb and ImageSizeWeb are the resized variants of the post images, they are requested with the size param
+	ImageSizeThumb = "thumb"
+	ImageSizeWeb   = "web"
+)
+
+// ImageVariants the sizes of the resized variants that are made for the post images
+var ImageVariants = []string{ImageSizeThumb, ImageSizeWeb}
+
+// ImageVariantSubPath returns the sub path of the resized variant, it is next to the original such as post/hash@web.png
+func ImageVariantSubPath(fileSubPath, size string) string {
+	ext := path.Ext(fileSubPath)
+	return strings.TrimSuffix(fileSubPath, ext) + "@" + size + ext
+}
@@ -19,6 +19,8 @@
 
 package constant
 
+import "time"
+
 const (
 	UserNormal    = "normal"
 	UserSuspended = "suspended"
@@ -30,6 +32,30 @@ const (
 	EmailStatusToBeVerified = 2
 )
 
+// DeletedUserPlaceholderUsername the username of the placeholder user who owns the posts of the erased users,
+// it is one of the reserved usernames.
+const DeletedUserPlaceholderUsername = "ghost"
+
+// GuestUserPlaceholderUsername the username of the placeholder user who owns the questions of the guests
+// until they are claimed, it is one of the reserved usernames.
+const GuestUserPlaceholderUsername = "guest"
+
+const (
+	ImpersonationModeDisabled = "disabled"
+	ImpersonationModeReadOnly = "read_only"
+	ImpersonationModeFull     = "full"
+	// ImpersonationSessionTimeout the impersonation session expires after this duration since it is started
+	ImpersonationSessionTimeout = time.Hour
+	// ImpersonatedByHeader the response header that flags the requests in an impersonation session,
+	// its value is the id of the admin who is viewing as the user
+	ImpersonatedByHeader = "X-Answer-Impersonated-By"
+	// ImpersonationModeHeader the response header of the mode of the impersonation session
+	ImpersonationModeHeader = "X-Answer-Impersonation-Mode"
+)
+
+// UserMuteMaxAmount the max amount of the users a user can mute
+const UserMuteMaxAmount = 1000
+
 const (
 	DeletePermanentlyUsers     = "users"
 	DeletePermanentlyQuestions = "questions"
@@ -50,3 +76,23 @@ func ConvertUserStatus(status, mailStatus int) string {
 	}
 	return UserNormal
 }
+
+const (
+	// SavedSearchMaxAmount the max amount of the searches a user can save
+	SavedSearchMaxAmount = 100
+	// SavedSearchMaxAlerts the max amount of the saved searches of a user that alert the new matching content
+	SavedSearchMaxAlerts = 10
+	// SavedSearchAlertMaxResults the max amount of the new matching content notified by one check of an alert
+	SavedSearchAlertMaxResults = 10
+	// SavedSearchAlertBatchSize the amount of the alerts checked in one batch
+	SavedSearchAlertBatchSize = 100
+	// SavedSearchAlertMaxBatches the max batches of the alerts checked in one run of the job
+	SavedSearchAlertMaxBatches = 100
+)
+
+const (
+	ReputationLeaderboardWindowToday = "today"
+	ReputationLeaderboardWindowWeek  = "week"
+	ReputationLeaderboardWindowMonth = "month"
+	ReputationLeaderboardWindowAll   = "all"
+)
@@ -22,11 +22,19 @@ package cron
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/service/badge"
+	"github.com/apache/answer/internal/service/bounty"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/file_record"
+	"github.com/apache/answer/internal/service/notification"
+	"github.com/apache/answer/internal/service/saved_search"
 	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/sitemap"
+	"github.com/apache/answer/internal/service/stale_question"
 	"github.com/apache/answer/internal/service/user_admin"
 	"github.com/robfig/cron/v3"
 	"github.com/segmentfault/pacman/log"
@@ -34,11 +42,17 @@ import (
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService   siteinfo_common.SiteInfoCommonService
-	questionService   *content.QuestionService
-	fileRecordService *file_record.FileRecordService
-	userAdminService  *user_admin.UserAdminService
-	serviceConfig     *service_config.ServiceConfig
+	siteInfoService      siteinfo_common.SiteInfoCommonService
+	questionService      *content.QuestionService
+	fileRecordService    *file_record.FileRecordService
+	userAdminService     *user_admin.UserAdminService
+	serviceConfig        *service_config.ServiceConfig
+	bountyService        *bounty.BountyService
+	notificationService  *notification.ExternalNotificationService
+	staleQuestionService *stale_question.StaleQuestionService
+	savedSearchService   *saved_search.SavedSearchService
+	badgeEventService    *badge.BadgeEventService
+	sitemapService       *sitemap.SitemapService
 }
 
 // NewScheduledTaskManager new scheduled task manager
@@ -48,13 +62,25 @@ func NewScheduledTaskManager(
 	fileRecordService *file_record.FileRecordService,
 	userAdminService *user_admin.UserAdminService,
 	serviceConfig *service_config.ServiceConfig,
+	bountyService *bounty.BountyService,
+	notificationService *notification.ExternalNotificationService,
+	staleQuestionService *stale_question.StaleQuestionService,
+	savedSearchService *saved_search.SavedSearchService,
+	badgeEventService *badge.BadgeEventService,
+	sitemapService *sitemap.SitemapService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService:   siteInfoService,
-		questionService:   questionService,
-		fileRecordService: fileRecordService,
-		userAdminService:  userAdminService,
-		serviceConfig:     serviceConfig,
+		siteInfoService:      siteInfoService,
+		questionService:      questionService,
+		fileRecordService:    fileRecordService,
+		userAdminService:     userAdminService,
+		serviceConfig:        serviceConfig,
+		bountyService:        bountyService,
+		notificationService:  notificationService,
+		staleQuestionService: staleQuestionService,
+		savedSearchService:   savedSearchService,
+		badgeEventService:    badgeEventService,
+		sitemapService:       sitemapService,
 	}
 	return manager
 }
@@ -62,12 +88,13 @@ func NewScheduledTaskManager(
 func (s *ScheduledTaskManager) Run() {
 	log.Infof("cron job manager start")
 
-	s.questionService.SitemapCron(context.Background())
+	s.sitemapService.SitemapCron(context.Background())
+	s.applySlowQueryThreshold(context.Background())
 	c := cron.New()
 	_, err := c.AddFunc("0 */1 * * *", func() {
 		ctx := context.Background()
 		log.Infof("sitemap cron execution")
-		s.questionService.SitemapCron(ctx)
+		s.sitemapService.SitemapCron(ctx)
 	})
 	if err != nil {
 		log.Error(err)
@@ -82,6 +109,14 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	_, err = c.AddFunc("*/15 * * * *", func() {
+		log.Infof("refresh trending cron execution")
+		s.questionService.RefreshTrendingCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	// Check for expired user suspensions every 10 minutes
 	_, err = c.AddFunc("*/10 * * * *", func() {
 		ctx := context.Background()
@@ -95,6 +130,90 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		log.Infof("settle expired bounties cron execution")
+		s.bountyService.SettleExpiredBounties(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("30 */1 * * *", func() {
+		log.Infof("send new question digests cron execution")
+		s.notificationService.SendNewQuestionDigests(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("20 */1 * * *", func() {
+		log.Infof("send saved search alerts cron execution")
+		s.savedSearchService.SendSavedSearchAlerts(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		log.Infof("send notification digests cron execution")
+		s.notificationService.SendNotificationDigests(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("* * * * *", func() {
+		s.questionService.PublishScheduledQuestionsCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("* * * * *", func() {
+		s.questionService.UnpinExpiredQuestionsCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("* * * * *", func() {
+		s.questionService.FlushPendingViewsCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("* * * * *", func() {
+		s.applySlowQueryThreshold(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("45 */1 * * *", func() {
+		log.Infof("auto close stale questions cron execution")
+		s.staleQuestionService.AutoCloseStaleQuestions(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("40 2 * * *", func() {
+		log.Infof("sweep badge rules cron execution")
+		s.badgeEventService.SweepBadgeRules(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	_, err = c.AddFunc("15 3 * * *", func() {
+		log.Infof("purge deleted posts cron execution")
+		s.questionService.PurgeDeletedPostsCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	if s.serviceConfig.CleanUpUploads {
 		log.Infof("clean up uploads cron enabled")
 
@@ -117,3 +236,13 @@ func (s *ScheduledTaskManager) Run() {
 	}
 	c.Start()
 }
+
+// applySlowQueryThreshold apply the threshold of the slow query log saved by the admin, it may be saved on another instance
+func (s *ScheduledTaskManager) applySlowQueryThreshold(ctx context.Context) {
+	slowQuery, err := s.siteInfoService.GetSiteSlowQuery(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	data.SetSlowQueryThreshold(time.Duration(slowQuery.Threshold) * time.Millisecond)
+}
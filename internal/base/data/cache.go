@@ -0,0 +1,273 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/cache"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+)
+
+// SetIfAbsent set the key as a marker only if it does not exist, set is false if it exists. The value of the key
+// is undefined, only the existence of it is meaningful. It is done atomically by the caches implementing
+// plugin.CacheAtomic, the others fall back to Increase, which creates the missing key atomically in the shared
+// caches such as Redis.
+func SetIfAbsent(ctx context.Context, c cache.Cache, key string, ttl time.Duration) (set bool, err error) {
+	return setStringIfAbsent(ctx, c, key, "1", ttl)
+}
+
+// setStringIfAbsent the value is only kept by the atomic caches, the wrappers of the cache delegate to it
+func setStringIfAbsent(ctx context.Context, c cache.Cache, key, value string, ttl time.Duration) (
+	set bool, err error) {
+	if atomicCache, ok := c.(plugin.CacheAtomic); ok {
+		return atomicCache.SetStringIfAbsent(ctx, key, value, ttl)
+	}
+	count, err := c.Increase(ctx, key, 1)
+	if err != nil {
+		// the cache can not increase the missing key, there is nothing atomic to rely on
+		_, exist, err := c.GetString(ctx, key)
+		if err != nil || exist {
+			return false, err
+		}
+		return true, c.SetString(ctx, key, value, ttl)
+	}
+	if count != 1 {
+		return false, nil
+	}
+	if ttl > 0 {
+		err = c.SetInt64(ctx, key, 1, ttl)
+	}
+	return true, err
+}
+
+const (
+	cacheLockRetryInterval = 10 * time.Millisecond
+	cacheSetLockTime       = 5 * time.Second
+)
+
+// Lock acquire the lock of the key in the cache, it is held until unlock is called or the ttl passes.
+// It waits for the lock until the ttl passes, the lock is shared by the instances if the cache is shared.
+func Lock(ctx context.Context, c cache.Cache, key string, ttl time.Duration) (unlock func(), err error) {
+	deadline := time.Now().Add(ttl)
+	for {
+		locked, err := SetIfAbsent(ctx, c, key, ttl)
+		if err != nil {
+			return nil, err
+		}
+		if locked {
+			return func() {
+				_ = c.Del(ctx, key)
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("wait for the lock %s timeout", key)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(cacheLockRetryInterval):
+		}
+	}
+}
+
+// SetAdd add the members into the set of the key. It is done atomically by the caches implementing
+// plugin.CacheSet, the others keep the set as a JSON value which is updated under the lock of the key.
+func SetAdd(ctx context.Context, c cache.Cache, key string, members ...string) (err error) {
+	if setCache, ok := c.(plugin.CacheSet); ok {
+		return setCache.SetAdd(ctx, key, members...)
+	}
+	return updateLockedCacheSet(ctx, c, key, addCacheSetMembers(members))
+}
+
+// SetRemove remove the members from the set of the key, see SetAdd
+func SetRemove(ctx context.Context, c cache.Cache, key string, members ...string) (err error) {
+	if setCache, ok := c.(plugin.CacheSet); ok {
+		return setCache.SetRemove(ctx, key, members...)
+	}
+	return updateLockedCacheSet(ctx, c, key, removeCacheSetMembers(members))
+}
+
+// SetMembers get the members of the set of the key in no particular order, see SetAdd
+func SetMembers(ctx context.Context, c cache.Cache, key string) (members []string, err error) {
+	if setCache, ok := c.(plugin.CacheSet); ok {
+		return setCache.SetMembers(ctx, key)
+	}
+	set, err := getCacheSet(ctx, c, key)
+	if err != nil {
+		return nil, err
+	}
+	return cacheSetMembers(set), nil
+}
+
+func updateLockedCacheSet(ctx context.Context, c cache.Cache, key string, update func(set map[string]bool)) error {
+	unlock, err := Lock(ctx, c, key+":lock", cacheSetLockTime)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return updateCacheSet(ctx, c, key, update)
+}
+
+func updateCacheSet(ctx context.Context, c cache.Cache, key string, update func(set map[string]bool)) error {
+	set, err := getCacheSet(ctx, c, key)
+	if err != nil {
+		return err
+	}
+	update(set)
+	value, _ := json.Marshal(cacheSetMembers(set))
+	return c.SetString(ctx, key, string(value), 0)
+}
+
+func getCacheSet(ctx context.Context, c cache.Cache, key string) (set map[string]bool, err error) {
+	set = make(map[string]bool)
+	value, exist, err := c.GetString(ctx, key)
+	if err != nil || !exist {
+		return set, err
+	}
+	var members []string
+	_ = json.Unmarshal([]byte(value), &members)
+	for _, member := range members {
+		set[member] = true
+	}
+	return set, nil
+}
+
+func addCacheSetMembers(members []string) func(set map[string]bool) {
+	return func(set map[string]bool) {
+		for _, member := range members {
+			set[member] = true
+		}
+	}
+}
+
+func removeCacheSetMembers(members []string) func(set map[string]bool) {
+	return func(set map[string]bool) {
+		for _, member := range members {
+			delete(set, member)
+		}
+	}
+}
+
+func cacheSetMembers(set map[string]bool) (members []string) {
+	members = make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	return members
+}
+
+// SetInt64IfAbsent set the counter of the key only if it does not exist, set is false if it exists.
+// It is done atomically by the caches implementing plugin.CacheAtomic. The others check the key first and
+// fall back to Increase to decide which caller creates it, the increases done meanwhile may be overwritten.
+func SetInt64IfAbsent(ctx context.Context, c cache.Cache, key string, value int64, ttl time.Duration) (
+	set bool, err error) {
+	if atomicCache, ok := c.(plugin.CacheAtomic); ok {
+		return atomicCache.SetInt64IfAbsent(ctx, key, value, ttl)
+	}
+	_, exist, err := c.GetInt64(ctx, key)
+	if err != nil || exist {
+		return false, err
+	}
+	count, err := c.Increase(ctx, key, 1)
+	if err == nil && count != 1 {
+		return false, nil
+	}
+	return true, c.SetInt64(ctx, key, value, ttl)
+}
+
+// IncreaseInWindow increase the counter of the key, the counter is created by the first increase and
+// expires after the window. The counter is never reset by the concurrent increases.
+func IncreaseInWindow(ctx context.Context, c cache.Cache, key string, window time.Duration) (count int64, err error) {
+	if _, err = SetInt64IfAbsent(ctx, c, key, 0, window); err != nil {
+		return 0, err
+	}
+	return c.Increase(ctx, key, 1)
+}
+
+// localCache the built-in memory cache, it is only shared by the goroutines of the process,
+// so the atomic operations are guarded by the lock
+type localCache struct {
+	*memory.Cache
+	lock sync.Mutex
+}
+
+func (c *localCache) SetStringIfAbsent(ctx context.Context, key, value string, ttl time.Duration) (
+	set bool, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, exist, err := c.GetString(ctx, key)
+	if err != nil || exist {
+		return false, err
+	}
+	return true, c.SetString(ctx, key, value, ttl)
+}
+
+func (c *localCache) SetInt64IfAbsent(ctx context.Context, key string, value int64, ttl time.Duration) (
+	set bool, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, exist, err := c.GetString(ctx, key)
+	if err != nil || exist {
+		return false, err
+	}
+	return true, c.SetInt64(ctx, key, value, ttl)
+}
+
+// Increase create the missing key like the shared caches, the memory cache fails to increase it
+func (c *localCache) Increase(ctx context.Context, key string, value int64) (data int64, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, exist, err := c.GetString(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return value, c.SetInt64(ctx, key, value, 0)
+	}
+	return c.Cache.Increase(ctx, key, value)
+}
+
+func (c *localCache) SetAdd(ctx context.Context, key string, members ...string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return updateCacheSet(ctx, c, key, addCacheSetMembers(members))
+}
+
+func (c *localCache) SetRemove(ctx context.Context, key string, members ...string) (err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return updateCacheSet(ctx, c, key, removeCacheSetMembers(members))
+}
+
+func (c *localCache) SetMembers(ctx context.Context, key string) (members []string, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	set, err := getCacheSet(ctx, c, key)
+	if err != nil {
+		return nil, err
+	}
+	return cacheSetMembers(set), nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sharedCache creates the missing key when it is increased like Redis, it has no atomic operations
+type sharedCache struct {
+	*memory.Cache
+	lock sync.Mutex
+}
+
+func (c *sharedCache) Increase(ctx context.Context, key string, value int64) (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	data, _, _ := c.GetInt64(ctx, key)
+	data += value
+	return data, c.SetInt64(ctx, key, data, 0)
+}
+
+func testCaches(t *testing.T) map[string]func() *Data {
+	return map[string]func() *Data{
+		"local": func() *Data {
+			c, _, err := NewCache(&CacheConf{})
+			require.NoError(t, err)
+			d, _, _ := NewData(nil, nil, c)
+			return d
+		},
+		"shared": func() *Data {
+			d, _, _ := NewData(nil, nil, &sharedCache{Cache: memory.NewCache()})
+			return d
+		},
+	}
+}
+
+func TestSetIfAbsent(t *testing.T) {
+	ctx := context.TODO()
+	for name, newData := range testCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			d := newData()
+			var setCount atomic.Int32
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					set, err := SetIfAbsent(ctx, d.Cache, "key", time.Minute)
+					assert.NoError(t, err)
+					if set {
+						setCount.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+			assert.EqualValues(t, 1, setCount.Load())
+
+			_, exist, err := d.Cache.GetInt64(ctx, "key")
+			require.NoError(t, err)
+			assert.True(t, exist)
+		})
+	}
+}
+
+func TestCacheIncrease(t *testing.T) {
+	ctx := context.TODO()
+	for name, newData := range testCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			d := newData()
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := d.Cache.Increase(ctx, "counter", 1)
+					assert.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+
+			count, exist, err := d.Cache.GetInt64(ctx, "counter")
+			require.NoError(t, err)
+			assert.True(t, exist)
+			assert.EqualValues(t, 50, count)
+		})
+	}
+}
+
+func TestCacheSet(t *testing.T) {
+	ctx := context.TODO()
+	for name, newData := range testCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			d := newData()
+			members, err := SetMembers(ctx, d.Cache, "set")
+			require.NoError(t, err)
+			assert.Empty(t, members)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, SetAdd(ctx, d.Cache, "set", strconv.Itoa(i), "shared"))
+				}()
+			}
+			wg.Wait()
+			members, err = SetMembers(ctx, d.Cache, "set")
+			require.NoError(t, err)
+			assert.Len(t, members, 51)
+
+			for i := 0; i < 50; i += 2 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, SetRemove(ctx, d.Cache, "set", strconv.Itoa(i)))
+				}()
+			}
+			wg.Wait()
+			members, err = SetMembers(ctx, d.Cache, "set")
+			require.NoError(t, err)
+			assert.Len(t, members, 26)
+			assert.Contains(t, members, "shared")
+			assert.Contains(t, members, "1")
+			assert.NotContains(t, members, "0")
+		})
+	}
+}
+
+func TestLock(t *testing.T) {
+	ctx := context.TODO()
+	for name, newData := range testCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			d := newData()
+			unlock, err := Lock(ctx, d.Cache, "lock", time.Minute)
+			require.NoError(t, err)
+			timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			_, err = Lock(timeoutCtx, d.Cache, "lock", time.Minute)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+			unlock()
+			unlock, err = Lock(ctx, d.Cache, "lock", time.Minute)
+			require.NoError(t, err)
+			unlock()
+		})
+	}
+}
+
+func TestIncreaseInWindow(t *testing.T) {
+	ctx := context.TODO()
+	for name, newData := range testCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			d := newData()
+			// the shared cache without the atomic operations may lose the increases done while the window is created
+			count, err := IncreaseInWindow(ctx, d.Cache, "window", time.Minute)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, count)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := IncreaseInWindow(ctx, d.Cache, "window", time.Minute)
+					assert.NoError(t, err)
+				}()
+			}
+			wg.Wait()
+			count, err = IncreaseInWindow(ctx, d.Cache, "window", time.Minute)
+			require.NoError(t, err)
+			assert.EqualValues(t, 52, count)
+
+			// the window is not created again once it exists
+			set, err := SetInt64IfAbsent(ctx, d.Cache, "window", 0, time.Minute)
+			require.NoError(t, err)
+			assert.False(t, set)
+		})
+	}
+}
@@ -26,6 +26,17 @@ type Database struct {
 	ConnMaxLifeTime int    `json:"conn_max_life_time" mapstructure:"conn_max_life_time" yaml:"conn_max_life_time,omitempty"`
 	MaxOpenConn     int    `json:"max_open_conn" mapstructure:"max_open_conn" yaml:"max_open_conn,omitempty"`
 	MaxIdleConn     int    `json:"max_idle_conn" mapstructure:"max_idle_conn" yaml:"max_idle_conn,omitempty"`
+	// Replicas the connections of the read replicas, the read-heavy queries go to them
+	Replicas []string `json:"replicas" mapstructure:"replicas" yaml:"replicas,omitempty"`
+	// ReplicaStickiness the seconds the reads of a client go to the primary after it writes
+	ReplicaStickiness int `json:"replica_stickiness" mapstructure:"replica_stickiness" yaml:"replica_stickiness,omitempty"`
+	// SlowQueryThreshold the milliseconds above which the queries are logged, it is disabled if it is 0
+	SlowQueryThreshold int `json:"slow_query_threshold" mapstructure:"slow_query_threshold" yaml:"slow_query_threshold,omitempty"`
+}
+
+// IsMariaDB whether the configured database engine is MariaDB
+func (d *Database) IsMariaDB() bool {
+	return d.Driver == DriverMariaDB
 }
 
 // CacheConf cache
@@ -20,7 +20,9 @@
 package data
 
 import (
+	"context"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/apache/answer/pkg/dir"
@@ -32,24 +34,34 @@ import (
 	"github.com/segmentfault/pacman/log"
 	_ "modernc.org/sqlite"
 	"xorm.io/xorm"
+	"xorm.io/xorm/dialects"
 	ormlog "xorm.io/xorm/log"
 	"xorm.io/xorm/names"
 	"xorm.io/xorm/schemas"
 )
 
+// DriverMariaDB MariaDB is served by the MySQL driver, but the driver name is kept
+// in the config so that version-gating code can tell the two engines apart.
+const DriverMariaDB = "mariadb"
+
 // Data data
 type Data struct {
 	DB    *xorm.Engine
 	Cache cache.Cache
+
+	replicas *Replicas
 }
 
-// NewData new data instance
-func NewData(db *xorm.Engine, cache cache.Cache) (*Data, func(), error) {
+// NewData new data instance, the replicas are optional
+func NewData(db *xorm.Engine, replicas *Replicas, cache cache.Cache) (*Data, func(), error) {
 	cleanup := func() {
 		log.Info("closing the data resources")
 		_ = db.Close()
 	}
-	return &Data{DB: db, Cache: cache}, cleanup, nil
+	if cache != nil {
+		cache = &tracingCache{Cache: &metricsCache{Cache: cache}}
+	}
+	return &Data{DB: db, Cache: cache, replicas: replicas}, cleanup, nil
 }
 
 // NewDB new database instance
@@ -66,7 +78,11 @@ func NewDB(debug bool, dataConf *Database) (*xorm.Engine, error) {
 		}
 		dataConf.MaxOpenConn = 1
 	}
-	engine, err := xorm.NewEngine(dataConf.Driver, dataConf.Connection)
+	driverName := dataConf.Driver
+	if dataConf.IsMariaDB() {
+		driverName = string(schemas.MYSQL)
+	}
+	engine, err := xorm.NewEngine(driverName, dataConf.Connection)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +97,18 @@ func NewDB(debug bool, dataConf *Database) (*xorm.Engine, error) {
 		return nil, err
 	}
 
+	// xorm looks up tables in the `public` schema unless told otherwise,
+	// follow the search_path of the connection so that a custom schema works.
+	if engine.Dialect().URI().DBType == schemas.POSTGRES {
+		schema, err := dialects.QueryDefaultPostgresSchema(context.Background(), engine.DB())
+		if err != nil {
+			return nil, err
+		}
+		if len(schema) > 0 && !strings.HasPrefix(schema, "$") {
+			engine.SetSchema(schema)
+		}
+	}
+
 	if dataConf.MaxIdleConn > 0 {
 		engine.SetMaxIdleConns(dataConf.MaxIdleConn)
 	}
@@ -91,6 +119,11 @@ func NewDB(debug bool, dataConf *Database) (*xorm.Engine, error) {
 		engine.SetConnMaxLifetime(time.Duration(dataConf.ConnMaxLifeTime) * time.Second)
 	}
 	engine.SetColumnMapper(names.GonicMapper{})
+	configSlowQueryThreshold.Store(int64(max(dataConf.SlowQueryThreshold, 0)) * int64(time.Millisecond))
+	SetSlowQueryThreshold(GetConfigSlowQueryThreshold())
+	engine.AddHook(&metricsHook{})
+	engine.AddHook(&slowQueryHook{})
+	engine.AddHook(&tracingHook{})
 	return engine, nil
 }
 
@@ -134,5 +167,5 @@ func NewCache(c *CacheConf) (cache.Cache, func(), error) {
 			log.Warn(err)
 		}
 	}
-	return memCache, cleanup, nil
+	return &localCache{Cache: memCache}, cleanup, nil
 }
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/segmentfault/pacman/cache"
+	"xorm.io/xorm"
+)
+
+// PingDB check whether the database connection is normal, the installation check and the readiness probe
+// share it
+func PingDB(ctx context.Context, db *xorm.Engine) error {
+	return db.PingContext(ctx)
+}
+
+// PingCache check whether the cache is reachable by writing and reading back a probe key
+func PingCache(ctx context.Context, c cache.Cache) error {
+	value := time.Now().Format(time.RFC3339Nano)
+	if err := c.SetString(ctx, constant.HealthProbeCacheKey, value, constant.HealthProbeCacheTime); err != nil {
+		return err
+	}
+	got, exist, err := c.GetString(ctx, constant.HealthProbeCacheKey)
+	if err != nil {
+		return err
+	}
+	// the probes of the other instances may write the key at the same time, it is only required to exist
+	if !exist || len(got) == 0 {
+		return fmt.Errorf("the probe key is not found after it is written")
+	}
+	return nil
+}
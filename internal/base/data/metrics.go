@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/metrics"
+	"github.com/segmentfault/pacman/cache"
+	"xorm.io/xorm/contexts"
+)
+
+// metricsHook record the duration of the database queries by the operation
+type metricsHook struct{}
+
+func (h *metricsHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	return c.Ctx, nil
+}
+
+func (h *metricsHook) AfterProcess(c *contexts.ContextHook) error {
+	operation := queryOperation(c.SQL)
+	metrics.DBQueryDuration.Observe(c.ExecuteTime.Seconds(), operation)
+	if c.Err != nil {
+		metrics.DBQueryErrors.Inc(operation)
+	}
+	return nil
+}
+
+// queryOperation the operation of the sql, the label values are bounded to the common statements
+func queryOperation(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if i := strings.IndexAny(sql, " \t\n("); i > 0 {
+		sql = sql[:i]
+	}
+	switch operation := strings.ToLower(sql); operation {
+	case "select", "insert", "update", "delete", "begin", "commit", "rollback":
+		return operation
+	default:
+		return "other"
+	}
+}
+
+// metricsCache count the hits and the misses of the cache reads
+type metricsCache struct {
+	cache.Cache
+}
+
+func (c *metricsCache) GetString(ctx context.Context, key string) (data string, exist bool, err error) {
+	data, exist, err = c.Cache.GetString(ctx, key)
+	c.count(exist, err)
+	return data, exist, err
+}
+
+func (c *metricsCache) GetInt64(ctx context.Context, key string) (data int64, exist bool, err error) {
+	data, exist, err = c.Cache.GetInt64(ctx, key)
+	c.count(exist, err)
+	return data, exist, err
+}
+
+func (c *metricsCache) SetStringIfAbsent(ctx context.Context, key, value string, ttl time.Duration) (
+	set bool, err error) {
+	return setStringIfAbsent(ctx, c.Cache, key, value, ttl)
+}
+
+func (c *metricsCache) SetInt64IfAbsent(ctx context.Context, key string, value int64, ttl time.Duration) (
+	set bool, err error) {
+	return SetInt64IfAbsent(ctx, c.Cache, key, value, ttl)
+}
+
+func (c *metricsCache) SetAdd(ctx context.Context, key string, members ...string) (err error) {
+	return SetAdd(ctx, c.Cache, key, members...)
+}
+
+func (c *metricsCache) SetRemove(ctx context.Context, key string, members ...string) (err error) {
+	return SetRemove(ctx, c.Cache, key, members...)
+}
+
+func (c *metricsCache) SetMembers(ctx context.Context, key string) (members []string, err error) {
+	return SetMembers(ctx, c.Cache, key)
+}
+
+func (c *metricsCache) count(exist bool, err error) {
+	switch {
+	case err != nil:
+		metrics.CacheRequests.Inc("error")
+	case exist:
+		metrics.CacheRequests.Inc("hit")
+	default:
+		metrics.CacheRequests.Inc("miss")
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// DefaultReplicaStickiness how long the reads of the client go to the primary after it writes,
+// it should be longer than the replication lag
+const DefaultReplicaStickiness = 5 * time.Second
+
+// PrimaryStickyKey the key of the context value that marks the request whose reads must go to the primary
+// database, the gin context passed to the services as the context is marked with it.
+const PrimaryStickyKey = "answer:db:primary-sticky"
+
+// Replicas the read replica databases, the read-heavy queries are spread over them in turn
+type Replicas struct {
+	engines    []*xorm.Engine
+	next       atomic.Uint64
+	stickiness time.Duration
+}
+
+// NewReplicas connect to the read replicas in the config, nil is returned if there is none.
+// The replica that can not be connected is skipped so that the site still works with the primary only.
+func NewReplicas(debug bool, dataConf *Database) (*Replicas, func(), error) {
+	if len(dataConf.Replicas) == 0 || dataConf.Driver == string(schemas.SQLITE) || dataConf.Driver == "sqlite" {
+		return nil, func() {}, nil
+	}
+	replicas := &Replicas{stickiness: DefaultReplicaStickiness}
+	if dataConf.ReplicaStickiness > 0 {
+		replicas.stickiness = time.Duration(dataConf.ReplicaStickiness) * time.Second
+	}
+	for _, connection := range dataConf.Replicas {
+		replicaConf := *dataConf
+		replicaConf.Connection = connection
+		replicaConf.Replicas = nil
+		engine, err := NewDB(debug, &replicaConf)
+		if err != nil {
+			log.Errorf("connect to the read replica failed, it is skipped: %v", err)
+			continue
+		}
+		replicas.engines = append(replicas.engines, engine)
+	}
+	if len(replicas.engines) == 0 {
+		return nil, func() {}, nil
+	}
+	log.Infof("%d read replicas are connected", len(replicas.engines))
+	cleanup := func() {
+		for _, engine := range replicas.engines {
+			_ = engine.Close()
+		}
+	}
+	return replicas, cleanup, nil
+}
+
+// Stickiness how long the reads of the client go to the primary after it writes
+func (r *Replicas) Stickiness() time.Duration {
+	if r == nil {
+		return 0
+	}
+	return r.stickiness
+}
+
+func (r *Replicas) pick() *xorm.Engine {
+	if r == nil || len(r.engines) == 0 {
+		return nil
+	}
+	return r.engines[(r.next.Add(1)-1)%uint64(len(r.engines))]
+}
+
+// HasReplicas whether the read replicas are configured
+func (d *Data) HasReplicas() bool {
+	return d.replicas != nil && len(d.replicas.engines) > 0
+}
+
+// ReplicaStickiness how long the reads of the client go to the primary after it writes
+func (d *Data) ReplicaStickiness() time.Duration {
+	return d.replicas.Stickiness()
+}
+
+// ReadDB get the session for the read-heavy queries, it reads from a replica if there is any.
+// The primary is used if the request writes or has just written, so that the data written is read back.
+func (d *Data) ReadDB(ctx context.Context) *xorm.Session {
+	if sticky, _ := ctx.Value(PrimaryStickyKey).(bool); sticky {
+		return d.DB.Context(ctx)
+	}
+	if engine := d.replicas.pick(); engine != nil {
+		return engine.Context(ctx)
+	}
+	return d.DB.Context(ctx)
+}
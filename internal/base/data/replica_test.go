@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func newTestEngine(t *testing.T) *xorm.Engine {
+	engine, err := xorm.NewEngine("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = engine.Close() })
+	return engine
+}
+
+func TestDataReadDB(t *testing.T) {
+	primary := newTestEngine(t)
+	ctx := context.Background()
+
+	d, _, err := NewData(primary, nil, nil)
+	require.NoError(t, err)
+	assert.False(t, d.HasReplicas())
+	assert.Equal(t, primary, d.ReadDB(ctx).Engine())
+
+	replicas := &Replicas{engines: []*xorm.Engine{newTestEngine(t), newTestEngine(t)}, stickiness: DefaultReplicaStickiness}
+	d, _, err = NewData(primary, replicas, nil)
+	require.NoError(t, err)
+	assert.True(t, d.HasReplicas())
+	assert.Equal(t, DefaultReplicaStickiness, d.ReplicaStickiness())
+	assert.Equal(t, replicas.engines[0], d.ReadDB(ctx).Engine())
+	assert.Equal(t, replicas.engines[1], d.ReadDB(ctx).Engine())
+	assert.Equal(t, replicas.engines[0], d.ReadDB(ctx).Engine())
+
+	// the request marked sticky reads from the primary
+	gin.SetMode(gin.TestMode)
+	ginCtx, _ := gin.CreateTestContext(nil)
+	ginCtx.Set(PrimaryStickyKey, true)
+	assert.Equal(t, primary, d.ReadDB(ginCtx).Engine())
+}
+
+func TestNewReplicas(t *testing.T) {
+	replicas, cleanup, err := NewReplicas(false, &Database{Driver: "mysql"})
+	require.NoError(t, err)
+	cleanup()
+	assert.Nil(t, replicas)
+
+	// the replicas of sqlite are ignored
+	replicas, cleanup, err = NewReplicas(false, &Database{Driver: "sqlite", Replicas: []string{":memory:"}})
+	require.NoError(t, err)
+	cleanup()
+	assert.Nil(t, replicas)
+}
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/tracing"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm/contexts"
+)
+
+const (
+	servicePackagePrefix = "github.com/apache/answer/internal/service/"
+	repoPackagePrefix    = "github.com/apache/answer/internal/repo/"
+	slowQueryMaxFrames   = 64
+)
+
+var (
+	// slowQueryThreshold the duration above which the queries are logged, it is changed at runtime by the admin
+	slowQueryThreshold atomic.Int64
+	// configSlowQueryThreshold the threshold in the config file, it is used until the admin changes it
+	configSlowQueryThreshold atomic.Int64
+)
+
+// SetSlowQueryThreshold set the duration above which the queries are logged, 0 disables the slow query log
+func SetSlowQueryThreshold(threshold time.Duration) {
+	slowQueryThreshold.Store(int64(max(threshold, 0)))
+}
+
+// GetSlowQueryThreshold get the duration above which the queries are logged
+func GetSlowQueryThreshold() time.Duration {
+	return time.Duration(slowQueryThreshold.Load())
+}
+
+// GetConfigSlowQueryThreshold get the threshold in the config file
+func GetConfigSlowQueryThreshold() time.Duration {
+	return time.Duration(configSlowQueryThreshold.Load())
+}
+
+// slowQueryHook log the queries that take longer than the threshold. The statement is logged as it is built
+// with the placeholders, the arguments are not logged because they may contain the personal data.
+type slowQueryHook struct{}
+
+func (h *slowQueryHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	return c.Ctx, nil
+}
+
+func (h *slowQueryHook) AfterProcess(c *contexts.ContextHook) error {
+	threshold := GetSlowQueryThreshold()
+	if threshold <= 0 || c.ExecuteTime < threshold {
+		return nil
+	}
+	log.Warnf("slow query, duration: %s, caller: %s, request id: %s, trace id: %s, args: %d, sql: %s",
+		c.ExecuteTime, queryCaller(), handler.GetRequestID(c.Ctx), tracing.TraceIDFromContext(c.Ctx),
+		len(c.Args), c.SQL)
+	return nil
+}
+
+// queryCaller the service method that runs the query, the repo method is used if the query is not from a service
+func queryCaller() string {
+	pcs := make([]uintptr, slowQueryMaxFrames)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	repoCaller := ""
+	for {
+		frame, more := frames.Next()
+		switch {
+		case strings.HasPrefix(frame.Function, servicePackagePrefix):
+			return shortFuncName(frame.Function)
+		case len(repoCaller) == 0 && strings.HasPrefix(frame.Function, repoPackagePrefix):
+			repoCaller = shortFuncName(frame.Function)
+		}
+		if !more {
+			break
+		}
+	}
+	if len(repoCaller) > 0 {
+		return repoCaller
+	}
+	return "unknown"
+}
+
+// shortFuncName remove the package path of the function name, such as content.(*QuestionService).GetQuestion
+func shortFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm/contexts"
+)
+
+func TestSlowQueryHook(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := log.GetLogger()
+	log.SetLogger(log.NewStdLogger(buf))
+	defer log.SetLogger(logger)
+	defer SetSlowQueryThreshold(GetSlowQueryThreshold())
+
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(nil)
+	ctx.Set(constant.RequestIDFlag, "req-1")
+	ctx.Set(tracing.TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736")
+	hook := &slowQueryHook{}
+	query := func(duration time.Duration) {
+		c := contexts.NewContextHook(ctx, "SELECT * FROM `user` WHERE `e_mail` = ?", []any{"user@example.com"})
+		c.ExecuteTime = duration
+		assert.NoError(t, hook.AfterProcess(c))
+	}
+
+	SetSlowQueryThreshold(0)
+	query(time.Hour)
+	assert.Empty(t, buf.String())
+
+	SetSlowQueryThreshold(100 * time.Millisecond)
+	query(50 * time.Millisecond)
+	assert.Empty(t, buf.String())
+	query(150 * time.Millisecond)
+	line := buf.String()
+	assert.Contains(t, line, "slow query, duration: 150ms")
+	assert.Contains(t, line, "request id: req-1")
+	assert.Contains(t, line, "trace id: 4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Contains(t, line, "SELECT * FROM `user` WHERE `e_mail` = ?")
+	// the arguments are not logged
+	assert.NotContains(t, line, "user@example.com")
+}
+
+func TestShortFuncName(t *testing.T) {
+	assert.Equal(t, "content.(*QuestionService).GetQuestion",
+		shortFuncName("github.com/apache/answer/internal/service/content.(*QuestionService).GetQuestion"))
+	assert.Equal(t, "main.main", shortFuncName("main.main"))
+}
@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/tracing"
+	"github.com/segmentfault/pacman/cache"
+	"xorm.io/xorm/contexts"
+)
+
+// tracingHook record the database queries as the child spans of the request.
+// xorm only keeps the context returned by the last hook, so it must be added after the other hooks.
+type tracingHook struct{}
+
+func (h *tracingHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	span := tracing.StartSpan(c.Ctx, "db."+queryOperation(c.SQL), tracing.SpanKindClient)
+	if span == nil {
+		return c.Ctx, nil
+	}
+	span.SetAttribute("db.statement", c.SQL)
+	return tracing.ContextWithSpan(c.Ctx, span), nil
+}
+
+func (h *tracingHook) AfterProcess(c *contexts.ContextHook) error {
+	span := tracing.SpanFromContext(c.Ctx)
+	// the span of the request is found if the query is not traced, it must not be ended here
+	if span == nil || span.Kind != tracing.SpanKindClient {
+		return nil
+	}
+	span.Finish(c.Err)
+	return nil
+}
+
+// tracingCache record the cache calls as the child spans of the request, the keys are not recorded
+// because they contain the ids of the users
+type tracingCache struct {
+	cache.Cache
+}
+
+func (c *tracingCache) GetString(ctx context.Context, key string) (data string, exist bool, err error) {
+	span := c.start(ctx, "cache.get")
+	data, exist, err = c.Cache.GetString(ctx, key)
+	c.finish(span, exist, err)
+	return data, exist, err
+}
+
+func (c *tracingCache) GetInt64(ctx context.Context, key string) (data int64, exist bool, err error) {
+	span := c.start(ctx, "cache.get")
+	data, exist, err = c.Cache.GetInt64(ctx, key)
+	c.finish(span, exist, err)
+	return data, exist, err
+}
+
+func (c *tracingCache) SetString(ctx context.Context, key, value string, ttl time.Duration) (err error) {
+	span := c.start(ctx, "cache.set")
+	err = c.Cache.SetString(ctx, key, value, ttl)
+	span.Finish(err)
+	return err
+}
+
+func (c *tracingCache) SetInt64(ctx context.Context, key string, value int64, ttl time.Duration) (err error) {
+	span := c.start(ctx, "cache.set")
+	err = c.Cache.SetInt64(ctx, key, value, ttl)
+	span.Finish(err)
+	return err
+}
+
+func (c *tracingCache) SetStringIfAbsent(ctx context.Context, key, value string, ttl time.Duration) (
+	set bool, err error) {
+	span := c.start(ctx, "cache.setnx")
+	set, err = setStringIfAbsent(ctx, c.Cache, key, value, ttl)
+	span.Finish(err)
+	return set, err
+}
+
+func (c *tracingCache) SetInt64IfAbsent(ctx context.Context, key string, value int64, ttl time.Duration) (
+	set bool, err error) {
+	span := c.start(ctx, "cache.setnx")
+	set, err = SetInt64IfAbsent(ctx, c.Cache, key, value, ttl)
+	span.Finish(err)
+	return set, err
+}
+
+func (c *tracingCache) SetAdd(ctx context.Context, key string, members ...string) (err error) {
+	span := c.start(ctx, "cache.sadd")
+	err = SetAdd(ctx, c.Cache, key, members...)
+	span.Finish(err)
+	return err
+}
+
+func (c *tracingCache) SetRemove(ctx context.Context, key string, members ...string) (err error) {
+	span := c.start(ctx, "cache.srem")
+	err = SetRemove(ctx, c.Cache, key, members...)
+	span.Finish(err)
+	return err
+}
+
+func (c *tracingCache) SetMembers(ctx context.Context, key string) (members []string, err error) {
+	span := c.start(ctx, "cache.smembers")
+	members, err = SetMembers(ctx, c.Cache, key)
+	span.Finish(err)
+	return members, err
+}
+
+func (c *tracingCache) Del(ctx context.Context, key string) (err error) {
+	span := c.start(ctx, "cache.del")
+	err = c.Cache.Del(ctx, key)
+	span.Finish(err)
+	return err
+}
+
+func (c *tracingCache) start(ctx context.Context, name string) *tracing.Span {
+	return tracing.StartSpan(ctx, name, tracing.SpanKindClient)
+}
+
+func (c *tracingCache) finish(span *tracing.Span, exist bool, err error) {
+	if exist {
+		span.SetAttribute("cache.hit", "true")
+	} else {
+		span.SetAttribute("cache.hit", "false")
+	}
+	span.Finish(err)
+}
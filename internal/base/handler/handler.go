@@ -42,7 +42,7 @@ func HandleResponse(ctx *gin.Context, err error, data any) {
 	var myErr *myErrors.Error
 	// unknown error
 	if !errors.As(err, &myErr) {
-		log.Error(err, "\n", myErrors.LogStack(2, 5))
+		log.Errorf("request id: %s, err: %v\n%s", GetRequestID(ctx), err, myErrors.LogStack(2, 5))
 		ctx.JSON(http.StatusInternalServerError, NewRespBody(
 			http.StatusInternalServerError, reason.UnknownError).TrMsg(lang))
 		return
@@ -50,7 +50,7 @@ func HandleResponse(ctx *gin.Context, err error, data any) {
 
 	// log internal server error
 	if myErrors.IsInternalServer(myErr) {
-		log.Error(myErr)
+		log.Errorf("request id: %s, err: %v", GetRequestID(ctx), myErr)
 	}
 
 	respBody := NewRespBodyFromError(myErr).TrMsg(lang)
@@ -64,7 +64,7 @@ func HandleResponse(ctx *gin.Context, err error, data any) {
 func BindAndCheck(ctx *gin.Context, data any) bool {
 	lang := GetLangByCtx(ctx)
 	if err := ctx.ShouldBind(data); err != nil {
-		log.Errorf("http_handle BindAndCheck fail, %s", err.Error())
+		log.Errorf("http_handle BindAndCheck fail, request id: %s, %s", GetRequestID(ctx), err.Error())
 		HandleResponse(ctx, myErrors.New(http.StatusBadRequest, reason.RequestFormatError), nil)
 		return true
 	}
@@ -81,7 +81,7 @@ func BindAndCheck(ctx *gin.Context, data any) bool {
 func BindAndCheckReturnErr(ctx *gin.Context, data any) (errFields []*validator.FormErrorField) {
 	lang := GetLangByCtx(ctx)
 	if err := ctx.ShouldBind(data); err != nil {
-		log.Errorf("http_handle BindAndCheck fail, %s", err.Error())
+		log.Errorf("http_handle BindAndCheck fail, request id: %s, %s", GetRequestID(ctx), err.Error())
 		HandleResponse(ctx, myErrors.New(http.StatusBadRequest, reason.RequestFormatError), nil)
 		ctx.Abort()
 		return nil
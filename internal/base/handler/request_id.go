@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package handler
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/gin-gonic/gin"
+)
+
+// GetRequestID get the request id of the request from context, it is empty if the context is not from a request
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if requestID := ginCtx.GetString(constant.RequestIDFlag); len(requestID) > 0 {
+			return requestID
+		}
+	}
+	// the gin context may be wrapped, such as by the spans of the database queries
+	if requestID, ok := ctx.Value(constant.RequestIDFlag).(string); ok {
+		return requestID
+	}
+	requestID, _ := ctx.Value(constant.RequestIDContextKey).(string)
+	return requestID
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import "time"
+
+// Default the registry of the metrics of the site
+var Default = NewRegistry()
+
+var (
+	// HTTPRequests the handled http requests by the method, the route template and the status code
+	HTTPRequests = Default.NewCounterVec("answer_http_requests_total",
+		"The amount of the handled HTTP requests.", "method", "route", "status")
+	// HTTPRequestDuration the duration of the http requests by the method and the route template
+	HTTPRequestDuration = Default.NewHistogramVec("answer_http_request_duration_seconds",
+		"The duration of the HTTP requests in seconds.", nil, "method", "route")
+	// DBQueryDuration the duration of the database queries by the operation, such as select and update
+	DBQueryDuration = Default.NewHistogramVec("answer_db_query_duration_seconds",
+		"The duration of the database queries in seconds.", nil, "operation")
+	// DBQueryErrors the failed database queries by the operation
+	DBQueryErrors = Default.NewCounterVec("answer_db_query_errors_total",
+		"The amount of the failed database queries.", "operation")
+	// CacheRequests the cache reads by the result, hit, miss or error
+	CacheRequests = Default.NewCounterVec("answer_cache_requests_total",
+		"The amount of the cache reads.", "result")
+	// QueueDepth the messages waiting in the background job queues by the queue name
+	QueueDepth = Default.NewGaugeFuncVec("answer_queue_depth",
+		"The amount of the messages waiting in the background job queue.", "queue")
+	// ActiveSessions the sessions that sent requests recently
+	ActiveSessions = Default.NewGaugeFuncVec("answer_active_sessions",
+		"The amount of the sessions that sent requests in the last 15 minutes.", "")
+)
+
+// HTTPRouteUnmatched the route label of the requests that match no route
+const HTTPRouteUnmatched = "unmatched"
+
+// Sessions the tracker of the sessions counted by ActiveSessions
+var Sessions = NewSessionTracker(15 * time.Minute)
+
+func init() {
+	ActiveSessions.Set("", func() float64 {
+		return float64(Sessions.Count())
+	})
+}
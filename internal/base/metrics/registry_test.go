@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "The requests.", "route", "status")
+	duration := r.NewHistogramVec("test_duration_seconds", "The duration.", []float64{0.1, 1}, "route")
+	depth := r.NewGaugeFuncVec("test_queue_depth", "The queue depth.", "queue")
+
+	requests.Inc("/a/:id", "200")
+	requests.Add(2, "/a/:id", "200")
+	requests.Add(-1, "/a/:id", "200")
+	requests.Inc(`/b"\`, "500")
+	duration.Observe(0.05, "/a/:id")
+	duration.Observe(0.5, "/a/:id")
+	depth.Set("event", func() float64 { return 3 })
+
+	assert.Equal(t, float64(3), requests.Value("/a/:id", "200"))
+	assert.Equal(t, uint64(2), duration.Count("/a/:id"))
+
+	buf := &bytes.Buffer{}
+	r.Write(buf)
+	assert.Equal(t, `# HELP test_requests_total The requests.
+# TYPE test_requests_total counter
+test_requests_total{route="/a/:id",status="200"} 3
+test_requests_total{route="/b\"\\",status="500"} 1
+# HELP test_duration_seconds The duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="/a/:id",le="0.1"} 1
+test_duration_seconds_bucket{route="/a/:id",le="1"} 2
+test_duration_seconds_bucket{route="/a/:id",le="+Inf"} 2
+test_duration_seconds_sum{route="/a/:id"} 0.55
+test_duration_seconds_count{route="/a/:id"} 2
+# HELP test_queue_depth The queue depth.
+# TYPE test_queue_depth gauge
+test_queue_depth{queue="event"} 3
+`, buf.String())
+}
+
+func TestSessionTracker(t *testing.T) {
+	tracker := NewSessionTracker(time.Hour)
+	tracker.Touch("a")
+	tracker.Touch("b")
+	tracker.Touch("a")
+	assert.Equal(t, 2, tracker.Count())
+
+	tracker = NewSessionTracker(0)
+	tracker.Touch("a")
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, 0, tracker.Count())
+}
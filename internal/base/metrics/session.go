@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package metrics
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// maxTrackedSessions bound the memory of the session tracker, the sessions beyond it are not counted
+const maxTrackedSessions = 100000
+
+// SessionTracker count the sessions that sent requests in the window, only the hashes of the sessions are kept
+type SessionTracker struct {
+	window time.Duration
+	mu     sync.Mutex
+	seen   map[uint64]time.Time
+}
+
+// NewSessionTracker new session tracker
+func NewSessionTracker(window time.Duration) *SessionTracker {
+	return &SessionTracker{window: window, seen: make(map[uint64]time.Time)}
+}
+
+// Touch mark the session as active now
+func (t *SessionTracker) Touch(session string) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(session))
+	key := h.Sum64()
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seen[key]; !ok && len(t.seen) >= maxTrackedSessions {
+		t.prune(now)
+		if len(t.seen) >= maxTrackedSessions {
+			return
+		}
+	}
+	t.seen[key] = now
+}
+
+// Count get the amount of the sessions that sent requests in the window
+func (t *SessionTracker) Count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune(time.Now())
+	return len(t.seen)
+}
+
+func (t *SessionTracker) prune(now time.Time) {
+	for key, lastSeen := range t.seen {
+		if now.Sub(lastSeen) > t.window {
+			delete(t.seen, key)
+		}
+	}
+}
@@ -20,12 +20,18 @@
 package middleware
 
 import (
+	"net/http"
+
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 )
 
+var ctxAPIKeyAuthKey = "ctxAPIKeyAuthKey"
+
 // AuthAPIKey middleware to authenticate API key
 func (am *AuthUserMiddleware) AuthAPIKey() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -49,3 +55,50 @@ func (am *AuthUserMiddleware) AuthAPIKey() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// authUserAPIKey authenticate the request by the personal api key, the key acts as the user who issued it.
+// Nil is returned if the key is not a personal key of an existing user. If the key cannot be used for
+// the request, the response is written and the request is aborted.
+func (am *AuthUserMiddleware) authUserAPIKey(ctx *gin.Context, accessToken string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	apiKey, exist, err := am.authService.GetAPIKey(ctx, accessToken)
+	if err != nil || !exist || apiKey.Personal != 1 {
+		return nil, err
+	}
+	if ctx.Request.Method != http.MethodGet && apiKey.Scope == constant.APIKeyScopeReadOnly {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.APIKeyReadOnly), nil)
+		ctx.Abort()
+		return nil, nil
+	}
+	rateLimit := apiKey.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = constant.DefaultAPIKeyRateLimit
+	}
+	if am.rateLimitMiddleware.APIKeyRateLimit(ctx, apiKey.ID, rateLimit) {
+		ctx.Abort()
+		return nil, nil
+	}
+	userInfo, exist, err = am.userCommon.GetUserCacheInfoByID(ctx, apiKey.UserID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	am.authService.UpdateAPIKeyLastUsed(ctx, apiKey)
+	ctx.Set(ctxAPIKeyAuthKey, true)
+	return userInfo, nil
+}
+
+// IsAPIKeyAuth the request is authenticated by the personal api key instead of the login token
+func IsAPIKeyAuth(ctx *gin.Context) bool {
+	return ctx.GetBool(ctxAPIKeyAuthKey)
+}
+
+// BanAPIKeyAuth the personal api keys cannot be used to manage the account security, such as the api keys
+// and the password, the user must login to do it.
+func BanAPIKeyAuth(ctx *gin.Context) {
+	if IsAPIKeyAuth(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.APIKeySessionRequired), nil)
+		ctx.Abort()
+		return
+	}
+	ctx.Next()
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/ui"
 	"github.com/gin-gonic/gin"
 
@@ -33,26 +34,46 @@ import (
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/token"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
-var ctxUUIDKey = "ctxUuidKey"
+var (
+	ctxUUIDKey       = "ctxUuidKey"
+	ctxRolePowersKey = "ctxRolePowersKey"
+)
+
+// rolePowers the access powers of the role of the login user
+type rolePowers struct {
+	adminAccess      bool
+	moderationAccess bool
+}
 
 // AuthUserMiddleware auth user middleware
 type AuthUserMiddleware struct {
 	authService           *auth.AuthService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	userCommon            *usercommon.UserCommon
+	rateLimitMiddleware   *RateLimitMiddleware
+	roleService           *role.RoleService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+	rateLimitMiddleware *RateLimitMiddleware,
+	roleService *role.RoleService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
 		authService:           authService,
 		siteInfoCommonService: siteInfoCommonService,
+		userCommon:            userCommon,
+		rateLimitMiddleware:   rateLimitMiddleware,
+		roleService:           roleService,
 	}
 }
 
@@ -64,13 +85,16 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			ctx.Next()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, err := am.getUserInfo(ctx, token)
+		if ctx.IsAborted() {
+			return
+		}
 		if err != nil {
 			ctx.Next()
 			return
 		}
-		if userInfo != nil {
-			ctx.Set(ctxUUIDKey, userInfo)
+		if userInfo != nil && !am.setUserInfo(ctx, userInfo) {
+			return
 		}
 		ctx.Next()
 	}
@@ -116,11 +140,10 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		// Check API key scope
-		if am.AuthAPIKeyScope(ctx, token) {
+		userInfo, err := am.getUserInfo(ctx, token)
+		if ctx.IsAborted() {
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -131,7 +154,9 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		ctx.Set(ctxUUIDKey, userInfo)
+		if !am.setUserInfo(ctx, userInfo) {
+			return
+		}
 		ctx.Next()
 	}
 }
@@ -145,11 +170,10 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		// Check API key scope
-		if am.AuthAPIKeyScope(ctx, token) {
+		userInfo, err := am.getUserInfo(ctx, token)
+		if ctx.IsAborted() {
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
 			ctx.Abort()
@@ -172,7 +196,9 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		ctx.Set(ctxUUIDKey, userInfo)
+		if !am.setUserInfo(ctx, userInfo) {
+			return
+		}
 		ctx.Next()
 	}
 }
@@ -212,7 +238,16 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 				ctx.Abort()
 				return
 			}
-			ctx.Set(ctxUUIDKey, userInfo)
+			// the admin access of the role may be revoked after the user has logged in
+			if !am.roleService.CheckRolePower(ctx, userInfo.RoleID, permission.AdminAccess) {
+				_ = am.authService.RemoveAdminUserCacheInfo(ctx, token)
+				handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
+				ctx.Abort()
+				return
+			}
+			if !am.setUserInfo(ctx, userInfo) {
+				return
+			}
 		}
 		ctx.Next()
 	}
@@ -235,23 +270,24 @@ func (am *AuthUserMiddleware) CheckPrivateMode() gin.HandlerFunc {
 	}
 }
 
-func (am *AuthUserMiddleware) AuthAPIKeyScope(ctx *gin.Context, accessToken string) (apiHaveNoScope bool) {
-	if !strings.HasPrefix(accessToken, "sk_") {
-		return false
-	}
-	var err error
-	pass, err := am.authService.AuthAPIKey(ctx, ctx.Request.Method == "GET", accessToken)
-	if err != nil {
-		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
-		ctx.Abort()
-		return true
-	}
-	if !pass {
-		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
-		ctx.Abort()
-		return true
+// setUserInfo set the login user info and the access powers of its role to context.
+// It returns false if the request is rejected because it is not allowed in the impersonation session.
+func (am *AuthUserMiddleware) setUserInfo(ctx *gin.Context, userInfo *entity.UserCacheInfo) bool {
+	ctx.Set(ctxUUIDKey, userInfo)
+	ctx.Set(ctxRolePowersKey, &rolePowers{
+		adminAccess:      am.roleService.CheckRolePower(ctx, userInfo.RoleID, permission.AdminAccess),
+		moderationAccess: am.roleService.CheckRolePower(ctx, userInfo.RoleID, permission.ModerationAccess),
+	})
+	return checkImpersonation(ctx, userInfo)
+}
+
+// getUserInfo get the user info by the login token or by the personal api key
+func (am *AuthUserMiddleware) getUserInfo(ctx *gin.Context, accessToken string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	if token.IsAPIKey(accessToken) {
+		return am.authUserAPIKey(ctx, accessToken)
 	}
-	return false
+	return am.authService.GetUserCacheInfo(ctx, accessToken)
 }
 
 func ShowIndexPage(ctx *gin.Context) {
@@ -275,15 +311,28 @@ func GetLoginUserIDFromContext(ctx *gin.Context) (userID string) {
 	return userInfo.UserID
 }
 
-// GetIsAdminFromContext get user is admin from context
+// GetIsAdminFromContext get user is admin from context, the role of the user has the admin access
 func GetIsAdminFromContext(ctx *gin.Context) (isAdmin bool) {
 	userInfo := GetUserInfoFromContext(ctx)
 	if userInfo == nil {
 		return false
 	}
+	if powers := getRolePowersFromContext(ctx); powers != nil {
+		return powers.adminAccess
+	}
 	return userInfo.RoleID == role.RoleAdminID
 }
 
+// getRolePowersFromContext get the access powers of the role of the login user, nil if they are not set
+func getRolePowersFromContext(ctx *gin.Context) *rolePowers {
+	powers, exist := ctx.Get(ctxRolePowersKey)
+	if !exist {
+		return nil
+	}
+	p, _ := powers.(*rolePowers)
+	return p
+}
+
 // GetUserInfoFromContext get user info from context
 func GetUserInfoFromContext(ctx *gin.Context) (u *entity.UserCacheInfo) {
 	userInfo, exist := ctx.Get(ctxUUIDKey)
@@ -306,6 +355,9 @@ func GetUserIsAdminModerator(ctx *gin.Context) (isAdminModerator bool) {
 	if !ok {
 		return false
 	}
+	if powers := getRolePowersFromContext(ctx); powers != nil {
+		return powers.adminAccess || powers.moderationAccess
+	}
 	if u.RoleID == role.RoleAdminID || u.RoleID == role.RoleModeratorID {
 		return true
 	}
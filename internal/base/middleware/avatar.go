@@ -57,15 +57,19 @@ func (am *AvatarMiddleware) AvatarThumb() gin.HandlerFunc {
 			size := converter.StringToInt(ctx.Query("s"))
 			uriWithoutQuery, _ := url.Parse(uri)
 			filename := filepath.Base(uriWithoutQuery.Path)
-			filePath := fmt.Sprintf("%s/avatar/%s", am.serviceConfig.UploadPath, filename)
-			var err error
+			var (
+				filePath string
+				err      error
+			)
 			if size != 0 {
 				filePath, err = am.uploaderService.AvatarThumbFile(ctx, filename, size)
-				if err != nil {
-					log.Error(err)
-					ctx.AbortWithStatus(http.StatusNotFound)
-					return
-				}
+			} else {
+				filePath, err = am.uploaderService.AvatarFile(ctx, filename)
+			}
+			if err != nil {
+				log.Error(err)
+				ctx.AbortWithStatus(http.StatusNotFound)
+				return
 			}
 			avatarFile, err := os.ReadFile(filePath)
 			if err != nil {
@@ -0,0 +1,176 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/pkg/encryption"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ConditionalGetMiddleware answer the conditional GET requests of the pages that are fetched repeatedly
+type ConditionalGetMiddleware struct {
+	data *data.Data
+}
+
+// NewConditionalGetMiddleware new conditional get middleware
+func NewConditionalGetMiddleware(data *data.Data) *ConditionalGetMiddleware {
+	return &ConditionalGetMiddleware{data: data}
+}
+
+// ConditionalGet the ETag is the hash of the rendered response, so it changes with everything shown in the page,
+// such as the answer edits, the votes and the comments. The Last-Modified is the time the current ETag of the
+// page is first seen by the viewer. The unchanged page is answered with 304 Not Modified.
+func (cm *ConditionalGetMiddleware) ConditionalGet() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		writer := &bufferedResponseWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		body := writer.buf.Bytes()
+		if writer.status != http.StatusOK || ctx.Request.Method != http.MethodGet {
+			writer.flush(writer.status, body)
+			return
+		}
+
+		userID := GetLoginUserIDFromContext(ctx)
+		etag := fmt.Sprintf(`W/"%x"`, sha256.Sum256(body))
+		lastModified := cm.getLastModified(ctx, userID, etag)
+		header := ctx.Writer.Header()
+		header.Set("ETag", etag)
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+		// the CDN must revalidate the page, and must not share the page of the logged-in user
+		if len(userID) > 0 {
+			header.Set("Cache-Control", "private, no-cache")
+		} else {
+			header.Set("Cache-Control", "public, no-cache")
+		}
+		header.Add("Vary", "Authorization")
+		header.Add("Vary", "Cookie")
+		header.Add("Vary", "Accept-Language")
+
+		if isNotModified(ctx.Request, etag, lastModified) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			writer.flush(http.StatusNotModified, nil)
+			return
+		}
+		writer.flush(http.StatusOK, body)
+	}
+}
+
+// getLastModified the time is reset when the ETag of the page changes, so it is newer than any change
+// of the page even if the page changes back to the former content
+func (cm *ConditionalGetMiddleware) getLastModified(ctx *gin.Context, userID, etag string) time.Time {
+	now := time.Now().Truncate(time.Second)
+	key := constant.ConditionalGetCacheKey + encryption.MD5(strings.Join([]string{
+		ctx.Request.URL.RequestURI(), userID, string(handler.GetLangByCtx(ctx))}, "|"))
+	cached, exist, err := cm.data.Cache.GetString(ctx, key)
+	if err != nil {
+		log.Errorf("get conditional get cache failed: %v", err)
+		return now
+	}
+	if exist {
+		if storedETag, unix, ok := strings.Cut(cached, "|"); ok && storedETag == etag {
+			if sec, err := strconv.ParseInt(unix, 10, 64); err == nil {
+				return time.Unix(sec, 0)
+			}
+		}
+	}
+	value := etag + "|" + strconv.FormatInt(now.Unix(), 10)
+	if err = cm.data.Cache.SetString(ctx, key, value, constant.ConditionalGetCacheTime); err != nil {
+		log.Errorf("set conditional get cache failed: %v", err)
+	}
+	return now
+}
+
+// isNotModified the If-None-Match takes precedence over the If-Modified-Since
+func isNotModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if inm := req.Header.Get("If-None-Match"); len(inm) > 0 {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := req.Header.Get("If-Modified-Since"); len(ims) > 0 {
+		t, err := http.ParseTime(ims)
+		return err == nil && !lastModified.After(t)
+	}
+	return false
+}
+
+// bufferedResponseWriter holds the response until the handler finishes, so the ETag can be calculated from it
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.buf.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.buf.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	return w.buf.Len()
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.buf.Len() > 0
+}
+
+func (w *bufferedResponseWriter) flush(status int, body []byte) {
+	w.ResponseWriter.WriteHeader(status)
+	if len(body) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	if _, err := w.ResponseWriter.Write(body); err != nil {
+		log.Errorf("write response failed: %v", err)
+	}
+}
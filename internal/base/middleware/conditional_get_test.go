@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/gin-gonic/gin"
+)
+
+func TestConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cache, cleanup, err := data.NewCache(&data.CacheConf{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	cm := NewConditionalGetMiddleware(&data.Data{Cache: cache})
+
+	votes := 1
+	r := gin.New()
+	r.GET("/question/info", cm.ConditionalGet(), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"vote_count": votes})
+	})
+	serve := func(header, value string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/question/info?id=1", nil)
+		if len(header) > 0 {
+			req.Header.Set(header, value)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("", "")
+	etag, lastModified := w.Header().Get("ETag"), w.Header().Get("Last-Modified")
+	if w.Code != http.StatusOK || len(etag) == 0 || len(lastModified) == 0 || w.Body.Len() == 0 {
+		t.Fatalf("expected the page with the validators, got %d %v", w.Code, w.Header())
+	}
+	if w = serve("If-None-Match", etag); w.Code != http.StatusNotModified || w.Body.Len() > 0 {
+		t.Errorf("expected 304 for the same etag, got %d", w.Code)
+	}
+	if w = serve("If-Modified-Since", lastModified); w.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for the same last modified, got %d", w.Code)
+	}
+
+	// the vote changes the page, the former validators no longer match
+	votes++
+	if w = serve("If-None-Match", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("expected the new page after the vote, got %d", w.Code)
+	}
+	votes--
+	ims := time.Now().Add(-time.Second).UTC().Format(http.TimeFormat)
+	if w = serve("If-Modified-Since", ims); w.Code != http.StatusOK {
+		t.Errorf("expected the page that changed back is modified, got %d", w.Code)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// CORS handle the cross-origin requests to the routes under the path prefixes by the site CORS configuration.
+// It must be used on the engine, because the preflight requests do not match any route, they are answered here
+// without reaching the handlers.
+func (am *AuthUserMiddleware) CORS(pathPrefixes ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		origin := ctx.GetHeader("Origin")
+		if len(origin) == 0 || !hasAnyPrefix(ctx.Request.URL.Path, pathPrefixes) {
+			ctx.Next()
+			return
+		}
+		preflight := ctx.Request.Method == http.MethodOptions && len(ctx.GetHeader("Access-Control-Request-Method")) > 0
+
+		config, err := am.siteInfoCommonService.GetSiteCORS(ctx)
+		if err != nil {
+			log.Errorf("get cors config failed, err: %v", err)
+			ctx.Next()
+			return
+		}
+		if !config.Enabled {
+			ctx.Next()
+			return
+		}
+		// the response depends on the origin, the caches must not share it between the origins
+		ctx.Writer.Header().Add("Vary", "Origin")
+		if !config.IsOriginAllowed(origin) {
+			if preflight {
+				ctx.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		header := ctx.Writer.Header()
+		if config.AllowCredentials {
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Set("Access-Control-Allow-Credentials", "true")
+		} else if config.IsOriginAllowed(constant.CORSWildcardOrigin) {
+			header.Set("Access-Control-Allow-Origin", constant.CORSWildcardOrigin)
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if !preflight {
+			if len(config.ExposedHeaders) > 0 {
+				header.Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
+			}
+			ctx.Next()
+			return
+		}
+
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		header.Set("Access-Control-Allow-Methods", strings.Join(config.GetAllowedMethods(), ", "))
+		header.Set("Access-Control-Allow-Headers", strings.Join(config.GetAllowedHeaders(), ", "))
+		header.Set("Access-Control-Max-Age", strconv.Itoa(config.GetMaxAge()))
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
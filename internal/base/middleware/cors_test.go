@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func newCORSRouter(t *testing.T, config *schema.SiteCORSResp) (r *gin.Engine, handled *bool) {
+	gin.SetMode(gin.TestMode)
+	siteInfoService := mock.NewMockSiteInfoCommonService(gomock.NewController(t))
+	siteInfoService.EXPECT().GetSiteCORS(gomock.Any()).Return(config, nil).AnyTimes()
+	am := &AuthUserMiddleware{siteInfoCommonService: siteInfoService}
+
+	handled = new(bool)
+	r = gin.New()
+	r.Use(am.CORS("/answer/api/v1"))
+	r.POST("/answer/api/v1/question", func(ctx *gin.Context) {
+		*handled = true
+		ctx.Status(http.StatusOK)
+	})
+	return r, handled
+}
+
+func serveCORS(r *gin.Engine, method, origin string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, "/answer/api/v1/question", nil)
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS(t *testing.T) {
+	r, handled := newCORSRouter(t, &schema.SiteCORSResp{
+		Enabled:          true,
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowCredentials: true,
+	})
+
+	w := serveCORS(r, http.MethodOptions, "https://app.example.com")
+	if w.Code != http.StatusNoContent || *handled {
+		t.Errorf("expected the preflight request is answered without the handler, got %d", w.Code)
+	}
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		w.Header().Get("Access-Control-Allow-Credentials") != "true" ||
+		len(w.Header().Get("Access-Control-Allow-Methods")) == 0 {
+		t.Errorf("unexpected preflight headers: %v", w.Header())
+	}
+
+	w = serveCORS(r, http.MethodPost, "https://app.example.com")
+	if w.Code != http.StatusOK || !*handled || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("expected the allowed request reaches the handler with the cors headers, got %d %v", w.Code, w.Header())
+	}
+
+	w = serveCORS(r, http.MethodOptions, "https://evil.example.com")
+	if w.Code != http.StatusForbidden || len(w.Header().Get("Access-Control-Allow-Origin")) > 0 {
+		t.Errorf("expected the preflight request of the unknown origin is rejected, got %d", w.Code)
+	}
+
+	r, _ = newCORSRouter(t, &schema.SiteCORSResp{Enabled: true, AllowedOrigins: []string{"*"}})
+	w = serveCORS(r, http.MethodPost, "https://any.example.com")
+	if w.Header().Get("Access-Control-Allow-Origin") != "*" || len(w.Header().Get("Access-Control-Allow-Credentials")) > 0 {
+		t.Errorf("expected the wildcard origin without credentials, got %v", w.Header())
+	}
+
+	r, _ = newCORSRouter(t, &schema.SiteCORSResp{})
+	w = serveCORS(r, http.MethodPost, "https://app.example.com")
+	if w.Code != http.StatusOK || len(w.Header().Get("Access-Control-Allow-Origin")) > 0 {
+		t.Errorf("expected no cors headers when cors is disabled, got %v", w.Header())
+	}
+}
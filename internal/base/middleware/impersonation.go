@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// impersonationStopPath the impersonation can always be stopped, even in the read-only sessions
+const impersonationStopPath = "/answer/api/v1/user/impersonation/stop"
+
+// checkImpersonation flag the responses of the impersonation sessions by the headers, and reject the requests
+// that change the data in the read-only sessions. It returns false if the request is rejected.
+func checkImpersonation(ctx *gin.Context, userInfo *entity.UserCacheInfo) bool {
+	if !userInfo.IsImpersonated() {
+		return true
+	}
+	ctx.Header(constant.ImpersonatedByHeader, userInfo.ImpersonatorID)
+	ctx.Header(constant.ImpersonationModeHeader, userInfo.ImpersonationMode)
+	if userInfo.ImpersonationMode == constant.ImpersonationModeFull {
+		return true
+	}
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	if strings.HasSuffix(ctx.FullPath(), impersonationStopPath) {
+		return true
+	}
+	handler.HandleResponse(ctx, errors.Forbidden(reason.ImpersonationReadOnly), nil)
+	ctx.Abort()
+	return false
+}
+
+// IsImpersonated the request is made in a session started by an admin who is viewing as the user
+func IsImpersonated(ctx *gin.Context) bool {
+	userInfo := GetUserInfoFromContext(ctx)
+	return userInfo != nil && userInfo.IsImpersonated()
+}
+
+// BanImpersonation reject the sensitive self-service actions in the impersonation sessions,
+// such as changing the password or the email of the user
+func BanImpersonation(ctx *gin.Context) {
+	if IsImpersonated(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ImpersonationActionForbidden), nil)
+		ctx.Abort()
+		return
+	}
+	ctx.Next()
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+func newImpersonationRouter(userInfo *entity.UserCacheInfo) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		ctx.Set(ctxUUIDKey, userInfo)
+		if !checkImpersonation(ctx, userInfo) {
+			return
+		}
+		ctx.Next()
+	})
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	r.POST("/question", ok)
+	r.GET("/question", ok)
+	r.POST(impersonationStopPath, ok)
+	r.PUT("/user/password", BanImpersonation, ok)
+	return r
+}
+
+func serveImpersonation(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestImpersonation(t *testing.T) {
+	readOnly := newImpersonationRouter(&entity.UserCacheInfo{UserID: "2", ImpersonatorID: "1",
+		ImpersonationMode: constant.ImpersonationModeReadOnly})
+	w := serveImpersonation(readOnly, http.MethodGet, "/question")
+	if w.Code != http.StatusOK || w.Header().Get(constant.ImpersonatedByHeader) != "1" {
+		t.Errorf("expected the read request passes with the impersonation header, got %d %q",
+			w.Code, w.Header().Get(constant.ImpersonatedByHeader))
+	}
+	if w = serveImpersonation(readOnly, http.MethodPost, "/question"); w.Code != http.StatusForbidden {
+		t.Errorf("expected the write request is rejected in the read-only session, got %d", w.Code)
+	}
+	if w = serveImpersonation(readOnly, http.MethodPost, impersonationStopPath); w.Code != http.StatusOK {
+		t.Errorf("expected the impersonation can be stopped in the read-only session, got %d", w.Code)
+	}
+
+	full := newImpersonationRouter(&entity.UserCacheInfo{UserID: "2", ImpersonatorID: "1",
+		ImpersonationMode: constant.ImpersonationModeFull})
+	if w = serveImpersonation(full, http.MethodPost, "/question"); w.Code != http.StatusOK {
+		t.Errorf("expected the write request passes in the full session, got %d", w.Code)
+	}
+	if w = serveImpersonation(full, http.MethodPut, "/user/password"); w.Code != http.StatusForbidden {
+		t.Errorf("expected the sensitive action is rejected in the impersonation session, got %d", w.Code)
+	}
+
+	normal := newImpersonationRouter(&entity.UserCacheInfo{UserID: "2"})
+	w = serveImpersonation(normal, http.MethodPut, "/user/password")
+	if w.Code != http.StatusOK || len(w.Header().Get(constant.ImpersonatedByHeader)) > 0 {
+		t.Errorf("expected the normal session is not affected, got %d", w.Code)
+	}
+}
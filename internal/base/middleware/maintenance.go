@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// MaintenanceMode reject the requests that change the data when the site is in the read-only maintenance mode.
+// The admins are exempt so that they can verify the site. It must be used after the auth middleware.
+func (am *AuthUserMiddleware) MaintenanceMode() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+		maintenance, err := am.siteInfoCommonService.GetSiteMaintenance(ctx)
+		if err != nil {
+			log.Errorf("get maintenance config failed, err: %v", err)
+			ctx.Next()
+			return
+		}
+		if !maintenance.Enabled || GetIsAdminFromContext(ctx) {
+			ctx.Next()
+			return
+		}
+		myErr := errors.ServiceUnavailable(reason.SiteInMaintenance)
+		if len(maintenance.Message) > 0 {
+			myErr = myErr.WithMsg(maintenance.Message)
+		}
+		handler.HandleResponse(ctx, myErr, nil)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func newMaintenanceRouter(t *testing.T, maintenance *schema.SiteMaintenanceResp, roleID int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	siteInfoService := mock.NewMockSiteInfoCommonService(gomock.NewController(t))
+	siteInfoService.EXPECT().GetSiteMaintenance(gomock.Any()).Return(maintenance, nil).AnyTimes()
+	am := &AuthUserMiddleware{siteInfoCommonService: siteInfoService}
+
+	r := gin.New()
+	r.Use(func(ctx *gin.Context) {
+		if roleID > 0 {
+			ctx.Set(ctxUUIDKey, &entity.UserCacheInfo{UserID: "1", RoleID: roleID})
+		}
+	}, am.MaintenanceMode())
+	r.GET("/question", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	r.POST("/question", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	return r
+}
+
+func serveMaintenance(r *gin.Engine, method string) int {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, "/question", nil)
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	enabled := &schema.SiteMaintenanceResp{Enabled: true, Message: "upgrading"}
+
+	r := newMaintenanceRouter(t, enabled, role.RoleUserID)
+	if code := serveMaintenance(r, http.MethodGet); code != http.StatusOK {
+		t.Errorf("expected the read request passes, got %d", code)
+	}
+	if code := serveMaintenance(r, http.MethodPost); code != http.StatusServiceUnavailable {
+		t.Errorf("expected the write request is rejected with 503, got %d", code)
+	}
+
+	r = newMaintenanceRouter(t, enabled, role.RoleAdminID)
+	if code := serveMaintenance(r, http.MethodPost); code != http.StatusOK {
+		t.Errorf("expected the admin is exempt, got %d", code)
+	}
+
+	r = newMaintenanceRouter(t, &schema.SiteMaintenanceResp{}, role.RoleUserID)
+	if code := serveMaintenance(r, http.MethodPost); code != http.StatusOK {
+		t.Errorf("expected the write request passes when the maintenance mode is off, got %d", code)
+	}
+}
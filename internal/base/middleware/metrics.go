@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/answer/internal/base/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics record the amount and the duration of the requests by the route template, not the raw path,
+// so that the label values are bounded. The sessions of the requests with the access token are counted as active.
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if len(route) == 0 {
+			route = metrics.HTTPRouteUnmatched
+		}
+		method := ctx.Request.Method
+		metrics.HTTPRequests.Inc(method, route, strconv.Itoa(ctx.Writer.Status()))
+		metrics.HTTPRequestDuration.Observe(time.Since(start).Seconds(), method, route)
+		if token := ExtractToken(ctx); len(token) > 0 {
+			metrics.Sessions.Touch(token)
+		}
+	}
+}
+
+// MetricsAuth the scraper must send the token as the bearer token, nothing is checked if the token is empty
+func MetricsAuth(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if len(token) == 0 {
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(ExtractToken(ctx)), []byte(token)) != 1 {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/base/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/metrics-test/question/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	before := metrics.HTTPRequests.Value(http.MethodGet, "/metrics-test/question/:id", "200")
+	unmatched := metrics.HTTPRequests.Value(http.MethodGet, metrics.HTTPRouteUnmatched, "404")
+	for _, path := range []string{"/metrics-test/question/1", "/metrics-test/question/2", "/metrics-test/none"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+	}
+	// the requests are counted by the route template, not by the raw path
+	assert.Equal(t, before+2, metrics.HTTPRequests.Value(http.MethodGet, "/metrics-test/question/:id", "200"))
+	assert.Equal(t, unmatched+1, metrics.HTTPRequests.Value(http.MethodGet, metrics.HTTPRouteUnmatched, "404"))
+}
+
+func TestMetricsAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/metrics", MetricsAuth("secret"), gin.WrapH(metrics.Default.Handler()))
+
+	cases := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{name: "no token", status: http.StatusUnauthorized},
+		{name: "wrong token", token: "Bearer wrong", status: http.StatusUnauthorized},
+		{name: "right token", token: "Bearer secret", status: http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+			if len(c.token) > 0 {
+				req.Header.Set("Authorization", c.token)
+			}
+			r.ServeHTTP(w, req)
+			assert.Equal(t, c.status, w.Code)
+			if c.status == http.StatusOK {
+				assert.Contains(t, w.Body.String(), "# TYPE answer_http_requests_total counter")
+			}
+		})
+	}
+}
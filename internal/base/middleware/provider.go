@@ -29,4 +29,6 @@ var ProviderSetMiddleware = wire.NewSet(
 	NewAvatarMiddleware,
 	NewShortIDMiddleware,
 	NewRateLimitMiddleware,
+	NewConditionalGetMiddleware,
+	NewReplicaMiddleware,
 )
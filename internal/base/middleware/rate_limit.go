@@ -22,10 +22,17 @@ package middleware
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/pkg/encryption"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -33,13 +40,15 @@ import (
 )
 
 type RateLimitMiddleware struct {
-	limitRepo *limit.LimitRepo
+	limitRepo     *limit.LimitRepo
+	serviceConfig *service_config.ServiceConfig
 }
 
 // NewRateLimitMiddleware new rate limit middleware
-func NewRateLimitMiddleware(limitRepo *limit.LimitRepo) *RateLimitMiddleware {
+func NewRateLimitMiddleware(limitRepo *limit.LimitRepo, serviceConfig *service_config.ServiceConfig) *RateLimitMiddleware {
 	return &RateLimitMiddleware{
-		limitRepo: limitRepo,
+		limitRepo:     limitRepo,
+		serviceConfig: serviceConfig,
 	}
 }
 
@@ -71,3 +80,42 @@ func (rm *RateLimitMiddleware) DuplicateRequestClear(ctx *gin.Context, key strin
 		log.Errorf("clear rate limit error: %s", err.Error())
 	}
 }
+
+// RequestRateLimit limits the requests of the action by the client IP and by the account, such as the login email.
+// If the limit is exceeded, the 429 response is written and true is returned.
+func (rm *RateLimitMiddleware) RequestRateLimit(ctx *gin.Context, action, account string) (reject bool) {
+	rule := rm.serviceConfig.GetRateLimitRule(action)
+	if rule.Window() <= 0 {
+		return false
+	}
+	if rule.IPLimit > 0 && rm.exceedLimit(ctx, action+":ip:"+ctx.ClientIP(), rule.IPLimit, rule.Window()) {
+		return true
+	}
+	account = strings.ToLower(strings.TrimSpace(account))
+	if rule.AccountLimit > 0 && len(account) > 0 &&
+		rm.exceedLimit(ctx, action+":account:"+encryption.MD5(account), rule.AccountLimit, rule.Window()) {
+		return true
+	}
+	return false
+}
+
+// APIKeyRateLimit limits the requests of the api key in every minute.
+// If the limit is exceeded, the 429 response is written and true is returned.
+func (rm *RateLimitMiddleware) APIKeyRateLimit(ctx *gin.Context, keyID, limitCount int) (reject bool) {
+	return rm.exceedLimit(ctx, fmt.Sprintf("api-key:%d", keyID), limitCount, constant.APIKeyRateLimitWindow)
+}
+
+func (rm *RateLimitMiddleware) exceedLimit(ctx *gin.Context, key string, limitCount int, window time.Duration) bool {
+	count, retryAfter, err := rm.limitRepo.IncreaseInWindow(ctx, key, window)
+	if err != nil {
+		log.Errorf("increase rate limit count error: %s", err.Error())
+		return false
+	}
+	if count <= int64(limitCount) {
+		return false
+	}
+	log.Debugf("rate limit exceeded: [%s] %s", ctx.FullPath(), key)
+	ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	handler.HandleResponse(ctx, errors.New(http.StatusTooManyRequests, reason.TooManyRequestsError), nil)
+	return true
+}
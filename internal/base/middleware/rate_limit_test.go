@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/internal/service/service_config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitMiddleware(t *testing.T, serviceConfig *service_config.ServiceConfig) *RateLimitMiddleware {
+	gin.SetMode(gin.TestMode)
+	c, _, err := data.NewCache(&data.CacheConf{})
+	require.NoError(t, err)
+	d, _, err := data.NewData(nil, nil, c)
+	require.NoError(t, err)
+	return NewRateLimitMiddleware(limit.NewRateLimitRepo(d), serviceConfig)
+}
+
+func serveRateLimit(limited func(ctx *gin.Context) bool) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request, _ = http.NewRequest(http.MethodPost, "/login", nil)
+	ctx.Request.RemoteAddr = "127.0.0.1:1234"
+	if !limited(ctx) {
+		ctx.Status(http.StatusOK)
+	}
+	return w
+}
+
+func TestRateLimitMiddleware_exceedLimit(t *testing.T) {
+	rm := newRateLimitMiddleware(t, nil)
+	exceed := func(key string) func(ctx *gin.Context) bool {
+		return func(ctx *gin.Context) bool {
+			return rm.exceedLimit(ctx, key, 3, time.Hour)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, serveRateLimit(exceed("a")).Code)
+	}
+	w := serveRateLimit(exceed("a"))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// the other keys are counted separately
+	assert.Equal(t, http.StatusOK, serveRateLimit(exceed("b")).Code)
+}
+
+func TestRateLimitMiddleware_exceedLimitConcurrently(t *testing.T) {
+	rm := newRateLimitMiddleware(t, nil)
+	var passed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := serveRateLimit(func(ctx *gin.Context) bool {
+				return rm.exceedLimit(ctx, "concurrent", 10, time.Hour)
+			})
+			if w.Code == http.StatusOK {
+				passed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 10, passed.Load())
+}
+
+func TestRateLimitMiddleware_exceedLimitWindow(t *testing.T) {
+	rm := newRateLimitMiddleware(t, nil)
+	exceed := func(ctx *gin.Context) bool {
+		return rm.exceedLimit(ctx, "window", 1, time.Second)
+	}
+	// start at the beginning of a window so the two requests are in the same one
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	assert.Equal(t, http.StatusOK, serveRateLimit(exceed).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveRateLimit(exceed).Code)
+
+	// the count starts again in the next window
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	assert.Equal(t, http.StatusOK, serveRateLimit(exceed).Code)
+}
+
+func TestRateLimitMiddleware_RequestRateLimit(t *testing.T) {
+	rm := newRateLimitMiddleware(t, &service_config.ServiceConfig{RateLimit: &service_config.RateLimit{
+		Login: &service_config.RateLimitRule{IPLimit: 3, AccountLimit: 2},
+	}})
+	login := func(account string) func(ctx *gin.Context) bool {
+		return func(ctx *gin.Context) bool {
+			return rm.RequestRateLimit(ctx, service_config.RateLimitActionLogin, account)
+		}
+	}
+
+	// the account is counted case insensitively
+	assert.Equal(t, http.StatusOK, serveRateLimit(login("a@answer.test")).Code)
+	assert.Equal(t, http.StatusOK, serveRateLimit(login(" A@answer.test ")).Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveRateLimit(login("a@answer.test")).Code)
+
+	// the ip limit is reached by the requests of the other accounts
+	assert.Equal(t, http.StatusTooManyRequests, serveRateLimit(login("b@answer.test")).Code)
+}
@@ -34,7 +34,7 @@ func Recovery(apiPrefixes ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Errorf("panic recovered: %v\n%s", err, debug.Stack())
+				log.Errorf("panic recovered, request id: %s, err: %v\n%s", handler.GetRequestID(ctx), err, debug.Stack())
 
 				// Headers/body already flushed (SSE or any streamed response).
 				// We can no longer rewrite the response cleanly; just stop the chain.
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ReplicaMiddleware keep the reads of the clients that have just written on the primary database,
+// so that they are not served the data that has not been replicated yet
+type ReplicaMiddleware struct {
+	data *data.Data
+}
+
+// NewReplicaMiddleware new replica middleware
+func NewReplicaMiddleware(data *data.Data) *ReplicaMiddleware {
+	return &ReplicaMiddleware{data: data}
+}
+
+// StickToPrimary the requests that write and the requests of the client in the stickiness window after
+// it writes read from the primary database, it does nothing if there is no read replica
+func (rm *ReplicaMiddleware) StickToPrimary() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !rm.data.HasReplicas() {
+			ctx.Next()
+			return
+		}
+		client := ExtractToken(ctx)
+		if len(client) == 0 {
+			client = ctx.ClientIP()
+		}
+		key := constant.ReplicaStickyCacheKey + client
+
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if _, exist, _ := rm.data.Cache.GetString(ctx, key); exist {
+				ctx.Set(data.PrimaryStickyKey, true)
+			}
+			ctx.Next()
+		default:
+			ctx.Set(data.PrimaryStickyKey, true)
+			ctx.Next()
+			// the window starts when the write is done
+			if err := rm.data.Cache.SetString(ctx, key, "1", rm.data.ReplicaStickiness()); err != nil {
+				log.Errorf("set replica stickiness failed: %v", err)
+			}
+		}
+	}
+}
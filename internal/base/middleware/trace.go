@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/metrics"
+	"github.com/apache/answer/internal/base/tracing"
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDRegexp the request id from the client or the proxy is only accepted if it is safe to be logged
+var requestIDRegexp = regexp.MustCompile(`^[0-9A-Za-z._:-]{1,128}$`)
+
+// Trace set the request id of the request, it is taken from the X-Request-ID header of the proxy or generated.
+// The request id is returned by the X-Request-ID response header and attached to the logs of the request.
+// The trace is continued from the W3C traceparent header, and the span of the request is exported if the
+// tracing is enabled, the spans of the database and the cache calls are the children of it.
+func Trace() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		traceID, parentSpanID, sampled, ok := tracing.ParseTraceParent(ctx.GetHeader("traceparent"))
+		requestID := ctx.GetHeader(constant.RequestIDFlag)
+		if !requestIDRegexp.MatchString(requestID) {
+			requestID = ""
+		}
+		if !ok {
+			// the request id is used as the trace id if it is the same format, then they can be searched together
+			if tracing.IsTraceID(requestID) {
+				traceID = requestID
+			} else {
+				traceID = tracing.NewTraceID()
+			}
+		}
+		if len(requestID) == 0 {
+			requestID = traceID
+		}
+		ctx.Set(constant.RequestIDFlag, requestID)
+		ctx.Set(tracing.TraceIDKey, traceID)
+		ctx.Header(constant.RequestIDFlag, requestID)
+
+		span := tracing.StartRootSpan(traceID, parentSpanID, sampled, ctx.Request.Method)
+		if span == nil {
+			ctx.Next()
+			return
+		}
+		ctx.Set(tracing.SpanKey, span)
+		span.SetAttribute("http.request.method", ctx.Request.Method)
+		span.SetAttribute("url.path", ctx.Request.URL.Path)
+		span.SetAttribute("request.id", requestID)
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if len(route) == 0 {
+			route = metrics.HTTPRouteUnmatched
+		}
+		span.SetName(ctx.Request.Method + " " + route)
+		span.SetAttribute("http.route", route)
+		status := ctx.Writer.Status()
+		span.SetAttribute("http.response.status_code", strconv.Itoa(status))
+		var err error
+		if status >= http.StatusInternalServerError {
+			err = traceStatusError(status)
+		}
+		span.Finish(err)
+	}
+}
+
+type traceStatusError int
+
+func (e traceStatusError) Error() string {
+	return http.StatusText(int(e))
+}
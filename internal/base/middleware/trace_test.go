@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Trace())
+	r.GET("/trace-test", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, handler.GetRequestID(ctx))
+	})
+
+	cases := []struct {
+		name        string
+		requestID   string
+		traceParent string
+		expected    string
+	}{
+		{name: "from proxy", requestID: "req-1.a:b", expected: "req-1.a:b"},
+		{name: "unsafe header is ignored", requestID: "bad id\nvalue"},
+		{name: "from traceparent", traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expected: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{name: "generated"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/trace-test", nil)
+			if len(c.requestID) > 0 {
+				req.Header.Set(constant.RequestIDFlag, c.requestID)
+			}
+			if len(c.traceParent) > 0 {
+				req.Header.Set("traceparent", c.traceParent)
+			}
+			r.ServeHTTP(w, req)
+			requestID := w.Header().Get(constant.RequestIDFlag)
+			assert.Equal(t, requestID, w.Body.String())
+			if len(c.expected) > 0 {
+				assert.Equal(t, c.expected, requestID)
+			} else {
+				assert.True(t, tracing.IsTraceID(requestID))
+			}
+		})
+	}
+}
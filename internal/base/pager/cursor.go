@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package pager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"xorm.io/builder"
+)
+
+// ErrInvalidCursor the cursor is malformed or does not belong to the order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorKey the sort key of the cursor pagination, the last key must be unique to make the order stable
+type CursorKey struct {
+	// Column the column or the expression of the key
+	Column string
+	Desc   bool
+	// Time the value of the key is the unix time of the timestamp column
+	Time bool
+}
+
+// Cursor the position after the last item of the page, it encodes the order and the values of the sort keys
+type Cursor struct {
+	Order  string  `json:"o"`
+	Values []int64 `json:"v"`
+}
+
+// CursorPageModel the page of the cursor pagination, NextCursor is empty if it is the last page
+type CursorPageModel struct {
+	List       any    `json:"list"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// NewCursorPageModel new cursor page model
+func NewCursorPageModel(nextCursor string, records any) *CursorPageModel {
+	sliceValue := reflect.Indirect(reflect.ValueOf(records))
+	if sliceValue.Kind() != reflect.Slice {
+		panic("not a slice")
+	}
+	return &CursorPageModel{
+		List:       records,
+		NextCursor: nextCursor,
+	}
+}
+
+// Encode encode the cursor to the opaque string returned to the client
+func (c *Cursor) Encode() string {
+	if c == nil {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decode the cursor of the order, the empty cursor means the first page and nil is returned
+func DecodeCursor(cursor, order string, keys []CursorKey) (c *Cursor, err error) {
+	if len(cursor) == 0 {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c = &Cursor{}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Order != order || len(c.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// CursorOrderBy the order by clause of the keys
+func CursorOrderBy(keys []CursorKey) string {
+	orders := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.Desc {
+			orders = append(orders, key.Column+" DESC")
+		} else {
+			orders = append(orders, key.Column+" ASC")
+		}
+	}
+	return strings.Join(orders, ", ")
+}
+
+// CursorCond the condition of the items after the cursor in the order of the keys,
+// (a, b, c) after (x, y, z) is a > x OR (a = x AND b > y) OR (a = x AND b = y AND c > z)
+func CursorCond(keys []CursorKey, cursor *Cursor) builder.Cond {
+	if cursor == nil {
+		return builder.NewCond()
+	}
+	cond := builder.NewCond()
+	for i := range keys {
+		and := builder.NewCond()
+		for j := 0; j < i; j++ {
+			and = and.And(builder.Expr(keys[j].Column+" = ?", keys[j].arg(cursor.Values[j])))
+		}
+		op := ">"
+		if keys[i].Desc {
+			op = "<"
+		}
+		and = and.And(builder.Expr(fmt.Sprintf("%s %s ?", keys[i].Column, op), keys[i].arg(cursor.Values[i])))
+		cond = cond.Or(and)
+	}
+	return cond
+}
+
+// arg the time is formatted in the same way as xorm stores it, some drivers format the time.Time with the
+// fractional seconds and the zone, then it never equals the stored one
+func (k CursorKey) arg(value int64) any {
+	if k.Time {
+		return time.Unix(value, 0).Format(time.DateTime)
+	}
+	return value
+}
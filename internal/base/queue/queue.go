@@ -23,6 +23,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/apache/answer/internal/base/metrics"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -55,6 +56,9 @@ func New[T any](name string, bufferSize int) *Queue[T] {
 		queue: make(chan T, bufferSize),
 	}
 	q.startWorker()
+	metrics.QueueDepth.Set(name, func() float64 {
+		return float64(len(q.queue))
+	})
 	return q
 }
 
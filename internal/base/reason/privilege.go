@@ -30,6 +30,7 @@ const (
 	RankCommentAddLabel                = "privilege.rank_comment_add_label"
 	RankReportAddLabel                 = "privilege.rank_report_add_label"
 	RankCommentVoteUpLabel             = "privilege.rank_comment_vote_up_label"
+	RankCommentVoteDownLabel           = "privilege.rank_comment_vote_down_label"
 	RankLinkUrlLimitLabel              = "privilege.rank_link_url_limit_label"
 	RankQuestionVoteUpLabel            = "privilege.rank_question_vote_up_label"
 	RankAnswerVoteUpLabel              = "privilege.rank_answer_vote_up_label"
@@ -47,4 +48,6 @@ const (
 	RankTagAuditLabel                  = "privilege.rank_tag_audit_label"
 	RankTagEditWithoutReviewLabel      = "privilege.rank_tag_edit_without_review_label"
 	RankTagSynonymLabel                = "privilege.rank_tag_synonym_label"
+	RankQuestionBountyLabel            = "privilege.rank_question_bounty_label"
+	RankPostWikiEditLabel              = "privilege.rank_post_wiki_edit_label"
 )
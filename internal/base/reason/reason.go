@@ -34,6 +34,8 @@ const (
 	ForbiddenError = "base.forbidden_error"
 	// DuplicateRequestError duplicate request error
 	DuplicateRequestError = "base.duplicate_request_error"
+	// TooManyRequestsError too many requests error
+	TooManyRequestsError = "base.too_many_requests_error"
 )
 
 const (
@@ -48,6 +50,19 @@ const (
 	QuestionUnderReview              = "error.question.under_review"
 	QuestionContentCannotEmpty       = "error.question.content_cannot_empty"
 	QuestionContentLessThanMinimum   = "error.question.content_less_than_minimum"
+	QuestionTitleLessThanMinimum     = "error.question.title_less_than_minimum"
+	QuestionContentRepeatsTitle      = "error.question.content_repeats_title"
+	QuestionDuplicateNotFound        = "error.question.duplicate_not_found"
+	QuestionDuplicateSelf            = "error.question.duplicate_self"
+	QuestionDuplicateCycle           = "error.question.duplicate_cycle"
+	BountyNotFound                   = "error.bounty.not_found"
+	BountyAlreadyExists              = "error.bounty.already_exists"
+	BountyCannotOffer                = "error.bounty.cannot_offer"
+	BountyNotEnoughReputation        = "error.bounty.not_enough_reputation"
+	BountyNotOwner                   = "error.bounty.not_owner"
+	BountyCannotAwardSelf            = "error.bounty.cannot_award_self"
+	BountyInvalidAnswer              = "error.bounty.invalid_answer"
+	BountyUnavailable                = "error.bounty.unavailable"
 	AnswerNotFound                   = "error.answer.not_found"
 	AnswerCannotDeleted              = "error.answer.cannot_deleted"
 	AnswerCannotUpdate               = "error.answer.cannot_update"
@@ -65,6 +80,7 @@ const (
 	NewObjectAlreadyDeleted          = "error.object.already_deleted"
 	UserNotFound                     = "error.user.not_found"
 	UsernameInvalid                  = "error.user.username_invalid"
+	UsernamePolicyLengthInvalid      = "error.user.username_policy_length_invalid"
 	UsernameDuplicate                = "error.user.username_duplicate"
 	UserSetAvatar                    = "error.user.set_avatar"
 	EmailDuplicate                   = "error.email.duplicate"
@@ -84,24 +100,69 @@ const (
 	NoEnoughRankToOperate            = "error.rank.no_enough_rank_to_operate"
 	ThemeNotFound                    = "error.theme.not_found"
 	LangNotFound                     = "error.lang.not_found"
+	ImpersonationDisabled            = "error.impersonation.disabled"
+	ImpersonationNotAllowed          = "error.impersonation.not_allowed"
+	ImpersonationNotInSession        = "error.impersonation.not_in_session"
+	ImpersonationReadOnly            = "error.impersonation.read_only"
+	ImpersonationActionForbidden     = "error.impersonation.action_forbidden"
 	ReportHandleFailed               = "error.report.handle_failed"
 	ReportNotFound                   = "error.report.not_found"
+	ReportCannotFlagSelf             = "error.report.cannot_flag_self"
+	ReportOperationNotSupported      = "error.report.operation_not_supported"
+	ReportWarnMessageRequired        = "error.report.warn_message_required"
+	RoleNotFound                     = "error.role.not_found"
+	RoleNameDuplicate                = "error.role.name_duplicate"
+	RoleBuiltInCannotModify          = "error.role.built_in_cannot_modify"
+	RoleInUse                        = "error.role.in_use"
+	RolePowerNotFound                = "error.role.power_not_found"
 	ReadConfigFailed                 = "error.config.read_config_failed"
 	DatabaseConnectionFailed         = "error.database.connection_failed"
+	DatabaseSocketNotFound           = "error.database.socket_not_found"
+	DatabaseFileDirNotWritable       = "error.database.file_dir_not_writable"
+	DatabaseCharsetUnsupported       = "error.database.charset_unsupported"
+	DatabaseCollationMismatch        = "error.database.collation_mismatch"
+	DatabaseSSLKeyRequired           = "error.database.ssl_key_required"
 	InstallCreateTableFailed         = "error.database.create_table_failed"
 	InstallConfigFailed              = "error.install.create_config_failed"
 	SiteInfoConfigNotFound           = "error.site_info.config_not_found"
+	SiteURLSubpathReserved           = "error.site_info.site_url_subpath_reserved"
 	UploadFileSourceUnsupported      = "error.upload.source_unsupported"
 	UploadFileUnsupportedFileFormat  = "error.upload.unsupported_file_format"
+	UploadFileTooLarge               = "error.upload.file_too_large"
+	UploadFileTypeNotAllowed         = "error.upload.file_type_not_allowed"
 	RecommendTagNotExist             = "error.tag.recommend_tag_not_found"
 	RecommendTagEnter                = "error.tag.recommend_tag_enter"
 	RevisionReviewUnderway           = "error.revision.review_underway"
 	RevisionNoPermission             = "error.revision.no_permission"
+	RevisionPendingLimitReached      = "error.revision.pending_limit_reached"
+	PostingDailyLimitReached         = "error.posting.daily_limit_reached"
+	PostingTooManyLinks              = "error.posting.too_many_links"
+	PostingTooFrequent               = "error.posting.too_frequent"
+	RevisionNotFound                 = "error.revision.not_found"
+	RevisionAlreadyCurrent           = "error.revision.already_current"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
+	TagCannotMergeWithItself         = "error.tag.cannot_merge_with_itself"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
 	NotAllowedLoginViaPassword       = "error.user.not_allowed_login_via_password"
 	SMTPConfigFromNameCannotBeEmail  = "error.smtp.config_from_name_cannot_be_email"
+	SMTPHostEmpty                    = "error.smtp.host_empty"
+	SMTPTestEmailSendFailed          = "error.smtp.test_email_send_failed"
+	SMTPTestEmailSendTimeout         = "error.smtp.test_email_send_timeout"
+	LDAPServerURLInvalid             = "error.ldap.server_url_invalid"
+	LDAPBaseDNEmpty                  = "error.ldap.base_dn_empty"
+	LDAPUserFilterInvalid            = "error.ldap.user_filter_invalid"
+	SAMLIdPSSOURLEmpty               = "error.saml.idp_sso_url_empty"
+	SAMLIdPCertificateInvalid        = "error.saml.idp_certificate_invalid"
+	StorageEndpointInvalid           = "error.storage.endpoint_invalid"
+	StorageBucketEmpty               = "error.storage.bucket_empty"
+	StoragePublicURLInvalid          = "error.storage.public_url_invalid"
+	CaptchaTokenRequired             = "error.captcha.token_required"
+	CaptchaTokenInvalid              = "error.captcha.token_invalid"
+	CaptchaProviderUnavailable       = "error.captcha.provider_unavailable"
+	CaptchaKeysEmpty                 = "error.captcha.keys_empty"
+	WebhookURLEmpty                  = "error.webhook.url_empty"
+	WebhookURLInvalid                = "error.webhook.url_invalid"
 	AdminCannotUpdateTheirPassword   = "error.admin.cannot_update_their_password"
 	AdminCannotEditTheirProfile      = "error.admin.cannot_edit_their_profile"
 	AdminCannotModifySelfStatus      = "error.admin.cannot_modify_self_status"
@@ -113,6 +174,7 @@ const (
 	MetaObjectNotFound               = "error.meta.object_not_found"
 	BadgeObjectNotFound              = "error.badge.object_not_found"
 	StatusInvalid                    = "error.common.status_invalid"
+	PageCursorInvalid                = "error.common.page_cursor_invalid"
 	UserStatusInactive               = "error.user.status_inactive"
 	UserStatusSuspendedForever       = "error.user.status_suspended_forever"
 	UserStatusSuspendedUntil         = "error.user.status_suspended_until"
@@ -124,4 +186,128 @@ const (
 const (
 	UserExternalLoginUnbindingForbidden = "error.user.external_login_unbinding_forbidden"
 	UserExternalLoginMissingUserID      = "error.user.external_login_missing_user_id"
+	UserExternalLoginEmailExist         = "error.user.external_login_email_exist"
+	UserExternalLoginBoundToOtherUser   = "error.user.external_login_bound_to_other_user"
+	UserExternalLoginProviderBound      = "error.user.external_login_provider_bound"
+)
+
+// user two-factor authentication reasons
+const (
+	UserTwoFactorAlreadyEnabled   = "error.user.two_factor_already_enabled"
+	UserTwoFactorNotEnrolled      = "error.user.two_factor_not_enrolled"
+	UserTwoFactorCodeInvalid      = "error.user.two_factor_code_invalid"
+	UserTwoFactorChallengeExpired = "error.user.two_factor_challenge_expired"
+)
+
+// user data export reasons
+const (
+	UserDataExportRunning = "error.user.data_export_running"
+	UserDataExportExpired = "error.user.data_export_expired"
+)
+
+// api key reasons
+const (
+	APIKeyNotFound        = "error.api_key.not_found"
+	APIKeyReadOnly        = "error.api_key.read_only"
+	APIKeyTooMany         = "error.api_key.too_many"
+	APIKeySessionRequired = "error.api_key.session_required"
+)
+
+// graphql reasons
+const (
+	GraphQLQueryTooComplex = "error.graphql.query_too_complex"
+)
+
+// registration blocklist reasons
+const (
+	RegistrationBlocked                 = "error.registration.blocked"
+	RegistrationBlocklistIPRangeInvalid = "error.registration.ip_range_invalid"
+	RegistrationBlocklistTooManyDomains = "error.registration.too_many_domains"
+)
+
+// maintenance reasons
+const (
+	SiteInMaintenance = "error.site.maintenance"
+)
+
+// question schedule reasons
+const (
+	QuestionScheduled           = "error.question.scheduled"
+	QuestionScheduleCanceled    = "error.question.schedule_canceled"
+	QuestionScheduleTimeInvalid = "error.question.schedule_time_invalid"
+	QuestionNotScheduled        = "error.question.not_scheduled"
+)
+
+// question pin reasons
+const (
+	QuestionPinLimitExceeded     = "error.question.pin_limit_exceeded"
+	QuestionPinExpireTimeInvalid = "error.question.pin_expire_time_invalid"
+	QuestionPinTagInvalid        = "error.question.pin_tag_invalid"
+)
+
+// post lock reasons
+const (
+	QuestionLocked      = "error.question.locked"
+	AnswerLocked        = "error.answer.locked"
+	PostLockUnsupported = "error.object.lock_unsupported"
+	PostNotLocked       = "error.object.not_locked"
+)
+
+// guest posting reasons
+const (
+	GuestPostingDisabled        = "error.guest_posting.disabled"
+	GuestQuestionClaimInvalid   = "error.guest_posting.claim_invalid"
+	GuestQuestionAlreadyClaimed = "error.guest_posting.already_claimed"
+)
+
+// post wiki reasons
+const (
+	PostWikiUnsupported     = "error.object.wiki_unsupported"
+	PostWikiRevertForbidden = "error.object.wiki_revert_forbidden"
+)
+
+// reaction reasons
+const (
+	MetaReactionEmojiNotAllowed = "error.meta.reaction_emoji_not_allowed"
+)
+
+// user mute reasons
+const (
+	UserMuteSelf          = "error.user.mute_self"
+	UserMuteLimitExceeded = "error.user.mute_limit_exceeded"
+)
+
+// search reasons
+const (
+	SearchOperatorUnsupported = "error.search.operator_unsupported"
+	SearchOperatorInvalid     = "error.search.operator_invalid"
+)
+
+// saved search reasons
+const (
+	SavedSearchNotFound           = "error.saved_search.not_found"
+	SavedSearchLimitExceeded      = "error.saved_search.limit_exceeded"
+	SavedSearchAlertLimitExceeded = "error.saved_search.alert_limit_exceeded"
+)
+
+// email template reasons
+const (
+	EmailTemplateInvalid         = "error.email_template.invalid"
+	EmailTemplateUnknownVariable = "error.email_template.unknown_variable"
+)
+
+// content processor reasons
+const (
+	ContentRejectedByPlugin = "error.object.content_rejected_by_plugin"
+)
+
+// plugin reasons
+const (
+	PluginInitFailed = "error.plugin.init_failed"
+)
+
+// cors reasons
+const (
+	CORSOriginInvalid           = "error.cors.origin_invalid"
+	CORSWildcardWithCredentials = "error.cors.wildcard_with_credentials"
 )
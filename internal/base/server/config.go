@@ -19,9 +19,58 @@
 
 package server
 
+import "github.com/apache/answer/internal/base/tracing"
+
 // HTTP http config
 type HTTP struct {
 	Addr string `json:"addr" mapstructure:"addr"`
+	// TrustedProxies the proxies whose forwarded headers are trusted to get the client IP.
+	// If it is not set, only the proxies in the loopback and private networks are trusted.
+	TrustedProxies []string `json:"trusted_proxies" mapstructure:"trusted_proxies" yaml:"trusted_proxies,omitempty"`
+	// RemoteIPHeaders the headers that carry the client IP, such as X-Forwarded-For and X-Real-IP
+	RemoteIPHeaders []string `json:"remote_ip_headers" mapstructure:"remote_ip_headers" yaml:"remote_ip_headers,omitempty"`
+	// Metrics the Prometheus metrics endpoint, it is disabled if neither the address nor the token is set
+	Metrics *Metrics `json:"metrics" mapstructure:"metrics" yaml:"metrics,omitempty"`
+	// Tracing the export of the request spans to the OpenTelemetry collector, it is disabled if it is not set
+	Tracing *tracing.Config `json:"tracing" mapstructure:"tracing" yaml:"tracing,omitempty"`
+}
+
+// Metrics metrics endpoint config
+type Metrics struct {
+	// Addr the separate address the metrics endpoint listens on, such as 127.0.0.1:9100.
+	// If it is not set, the endpoint is served by the site and the token is required.
+	Addr string `json:"addr" mapstructure:"addr" yaml:"addr,omitempty"`
+	// Token the bearer token the scraper must send
+	Token string `json:"token" mapstructure:"token" yaml:"token,omitempty"`
+}
+
+// GetMetrics get the metrics endpoint config, an empty one is returned if it is not set
+func (h *HTTP) GetMetrics() *Metrics {
+	if h == nil || h.Metrics == nil {
+		return &Metrics{}
+	}
+	return h.Metrics
+}
+
+// GetTracing get the tracing config, nil is returned if it is not set
+func (h *HTTP) GetTracing() *tracing.Config {
+	if h == nil {
+		return nil
+	}
+	return h.Tracing
+}
+
+// defaultTrustedProxies reverse proxies usually run on the same host or in a private network
+var defaultTrustedProxies = []string{
+	"127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7",
+}
+
+// GetTrustedProxies get the trusted proxies
+func (h *HTTP) GetTrustedProxies() []string {
+	if h == nil || h.TrustedProxies == nil {
+		return defaultTrustedProxies
+	}
+	return h.TrustedProxies
 }
 
 // UI ui config
@@ -27,10 +27,12 @@ import (
 
 	brotli "github.com/anargu/gin-brotli"
 	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/controller"
 	"github.com/apache/answer/internal/router"
 	"github.com/apache/answer/plugin"
 	"github.com/apache/answer/ui"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
 )
 
 // NewHTTPServer new http server.
@@ -42,9 +44,12 @@ func NewHTTPServer(debug bool,
 	authUserMiddleware *middleware.AuthUserMiddleware,
 	avatarMiddleware *middleware.AvatarMiddleware,
 	shortIDMiddleware *middleware.ShortIDMiddleware,
+	replicaMiddleware *middleware.ReplicaMiddleware,
+	healthController *controller.HealthController,
 	templateRouter *router.TemplateRouter,
 	pluginAPIRouter *router.PluginAPIRouter,
 	uiConf *UI,
+	httpConf *HTTP,
 ) *gin.Engine {
 	if debug {
 		gin.SetMode(gin.DebugMode)
@@ -52,17 +57,32 @@ func NewHTTPServer(debug bool,
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
+	// the client IP is used by the rate limit, so only the headers set by the trusted proxies are used
+	if err := r.SetTrustedProxies(httpConf.GetTrustedProxies()); err != nil {
+		log.Errorf("set trusted proxies failed: %v", err)
+	}
+	if httpConf != nil && len(httpConf.RemoteIPHeaders) > 0 {
+		r.RemoteIPHeaders = httpConf.RemoteIPHeaders
+	}
+	r.Use(middleware.Trace())
+	r.Use(middleware.Metrics())
 	r.Use(middleware.Recovery(
 		uiConf.APIBaseURL+"/answer/api/v1",
 		uiConf.APIBaseURL+"/answer/admin/api",
 	))
+	r.Use(authUserMiddleware.CORS(
+		uiConf.APIBaseURL+"/answer/api/v1",
+		uiConf.APIBaseURL+"/answer/admin/api",
+	))
 	r.Use(func(ctx *gin.Context) {
 		if strings.Contains(ctx.Request.URL.Path, "/chat/completions") {
 			return
 		}
 		brotli.Brotli(brotli.DefaultCompression)(ctx)
-	}, middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag())
-	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(200, "OK") })
+	}, middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag(), replicaMiddleware.StickToPrimary())
+	r.GET("/healthz", healthController.Healthz)
+	r.GET("/readyz", healthController.Readyz)
+	registerMetricsOnSite(r, httpConf)
 
 	templatePath := os.Getenv("ANSWER_TEMPLATE_PATH")
 	if templatePath != "" {
@@ -97,7 +117,7 @@ func NewHTTPServer(debug bool,
 
 	// register api that must be authenticated
 	authV1 := r.Group(uiConf.APIBaseURL + "/answer/api/v1")
-	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
+	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable(), authUserMiddleware.MaintenanceMode())
 	answerRouter.RegisterAnswerAPIRouter(authV1)
 
 	adminauthV1 := r.Group(uiConf.APIBaseURL + "/answer/admin/api")
@@ -111,10 +131,12 @@ func NewHTTPServer(debug bool,
 	pluginAPIRouter.RegisterAuthUserConnectorRouter(authV1)
 	pluginAPIRouter.RegisterAuthAdminConnectorRouter(adminauthV1)
 
+	// the routes of the agents are registered on boot, they are not found while the plugin is disabled
 	_ = plugin.CallAgent(func(agent plugin.Agent) error {
-		agent.RegisterUnAuthRouter(mustUnAuthV1)
-		agent.RegisterAuthUserRouter(authV1)
-		agent.RegisterAuthAdminRouter(adminauthV1)
+		enabled := middleware.PluginEnabled(agent.Info().SlugName)
+		agent.RegisterUnAuthRouter(mustUnAuthV1.Group("", enabled))
+		agent.RegisterAuthUserRouter(authV1.Group("", enabled))
+		agent.RegisterAuthAdminRouter(adminauthV1.Group("", enabled))
 		return nil
 	})
 
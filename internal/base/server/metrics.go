@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package server
+
+import (
+	"github.com/apache/answer/internal/base/metrics"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// metricsPath the path of the Prometheus metrics endpoint
+const metricsPath = "/metrics"
+
+// NewMetricsServer new http server that only serves the metrics on the separate address,
+// nil is returned if the separate address is not set
+func NewMetricsServer(httpConf *HTTP) *gin.Engine {
+	metricsConf := httpConf.GetMetrics()
+	if len(metricsConf.Addr) == 0 {
+		return nil
+	}
+	r := gin.New()
+	r.Use(gin.Recovery())
+	registerMetrics(r, metricsConf)
+	return r
+}
+
+// registerMetricsOnSite serve the metrics on the site, only if the token is set so that it is not publicly scrapeable
+func registerMetricsOnSite(r *gin.Engine, httpConf *HTTP) {
+	metricsConf := httpConf.GetMetrics()
+	if len(metricsConf.Addr) > 0 || len(metricsConf.Token) == 0 {
+		return
+	}
+	registerMetrics(r, metricsConf)
+}
+
+func registerMetrics(r *gin.Engine, metricsConf *Metrics) {
+	r.GET(metricsPath, middleware.MetricsAuth(metricsConf.Token), gin.WrapH(metrics.Default.Handler()))
+}
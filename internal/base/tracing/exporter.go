@@ -0,0 +1,233 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	exportBatchSize     = 256
+	exportQueueSize     = 4096
+	exportFlushInterval = 5 * time.Second
+	exportTimeout       = 10 * time.Second
+)
+
+// exporter batches the finished spans and posts them to the OTLP/HTTP traces endpoint.
+// The spans are dropped if the queue is full, the tracing never slows down the requests.
+type exporter struct {
+	endpoint    string
+	serviceName string
+	httpClient  *http.Client
+	queue       chan *Span
+	done        chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+}
+
+func newExporter(endpoint, serviceName string) *exporter {
+	e := &exporter{
+		endpoint:    endpoint,
+		serviceName: serviceName,
+		httpClient:  &http.Client{Timeout: exportTimeout},
+		queue:       make(chan *Span, exportQueueSize),
+		done:        make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.loop()
+	return e
+}
+
+func (e *exporter) export(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+	}
+}
+
+func (e *exporter) shutdown() {
+	e.stopOnce.Do(func() {
+		close(e.done)
+	})
+	e.wg.Wait()
+}
+
+func (e *exporter) loop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(exportFlushInterval)
+	defer ticker.Stop()
+	batch := make([]*Span, 0, exportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.post(batch); err != nil {
+			log.Warnf("export %d spans failed, err: %v", len(batch), err)
+		}
+		batch = make([]*Span, 0, exportBatchSize)
+	}
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= exportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *exporter) post(spans []*Span) (err error) {
+	body, err := json.Marshal(e.buildRequest(spans))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return &exportStatusError{status: resp.StatusCode}
+	}
+	return nil
+}
+
+type exportStatusError struct {
+	status int
+}
+
+func (e *exportStatusError) Error() string {
+	return "the collector returns status " + strconv.Itoa(e.status)
+}
+
+// the OTLP/HTTP JSON request body, only the fields used here are declared
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []*otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// otlpStatusCodeError the error status code of OTLP
+const otlpStatusCodeError = 2
+
+func (e *exporter) buildRequest(spans []*Span) *otlpRequest {
+	items := make([]*otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		item := &otlpSpan{
+			TraceID:           span.TraceID,
+			SpanID:            span.SpanID,
+			ParentSpanID:      span.ParentSpanID,
+			Name:              span.Name,
+			Kind:              span.Kind,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		}
+		for k, v := range span.Attributes() {
+			item.Attributes = append(item.Attributes, &otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+		}
+		if len(span.Err) > 0 {
+			item.Status = &otlpStatus{Code: otlpStatusCodeError, Message: span.Err}
+		}
+		items = append(items, item)
+	}
+	return &otlpRequest{
+		ResourceSpans: []*otlpResourceSpans{
+			{
+				Resource: otlpResource{Attributes: []*otlpKeyValue{
+					{Key: "service.name", Value: otlpAnyValue{StringValue: e.serviceName}},
+				}},
+				ScopeSpans: []*otlpScopeSpans{
+					{Scope: otlpScope{Name: "github.com/apache/answer"}, Spans: items},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,261 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package tracing records the spans of the requests and exports them to an OpenTelemetry collector,
+// such as Jaeger, by the OTLP/HTTP JSON protocol. Nothing is recorded if the export is not configured.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	mathrand "math/rand/v2"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config the span export config
+type Config struct {
+	// Endpoint the OTLP/HTTP traces endpoint, such as http://jaeger:4318/v1/traces
+	Endpoint string `json:"endpoint" mapstructure:"endpoint" yaml:"endpoint,omitempty"`
+	// ServiceName the service name of the spans, it is answer by default
+	ServiceName string `json:"service_name" mapstructure:"service_name" yaml:"service_name,omitempty"`
+	// SampleRatio the ratio of the traces started here that are exported, all of them are exported by default
+	SampleRatio float64 `json:"sample_ratio" mapstructure:"sample_ratio" yaml:"sample_ratio,omitempty"`
+}
+
+// SpanKind the kind of the span in OTLP
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Span the timed operation of a trace, all the methods of the nil span do nothing
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Kind         SpanKind
+	Start        time.Time
+	End          time.Time
+	Err          string
+
+	mu         sync.Mutex
+	attributes map[string]string
+	ended      bool
+}
+
+var (
+	activeExporter atomic.Pointer[exporter]
+	sampleRatio    atomic.Value
+	traceIDRegexp  = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	// traceParentRegexp the W3C trace context header, version-traceid-parentid-flags
+	traceParentRegexp = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+)
+
+// Init start exporting the spans if the endpoint is configured, the returned function flushes and stops it
+func Init(conf *Config) (shutdown func()) {
+	if conf == nil || len(conf.Endpoint) == 0 {
+		return func() {}
+	}
+	ratio := conf.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	sampleRatio.Store(ratio)
+	serviceName := conf.ServiceName
+	if len(serviceName) == 0 {
+		serviceName = "answer"
+	}
+	e := newExporter(conf.Endpoint, serviceName)
+	activeExporter.Store(e)
+	return func() {
+		activeExporter.Store(nil)
+		e.shutdown()
+	}
+}
+
+// Enabled whether the spans are exported
+func Enabled() bool {
+	return activeExporter.Load() != nil
+}
+
+// NewTraceID generate a random trace id of 32 hex characters
+func NewTraceID() string {
+	return randomHex(16)
+}
+
+// IsTraceID whether the id is a valid trace id
+func IsTraceID(id string) bool {
+	return traceIDRegexp.MatchString(id) && id != "00000000000000000000000000000000"
+}
+
+// ParseTraceParent get the trace id, the parent span id and whether it is sampled from the W3C traceparent header
+func ParseTraceParent(header string) (traceID, parentSpanID string, sampled, ok bool) {
+	matches := traceParentRegexp.FindStringSubmatch(header)
+	if matches == nil || !IsTraceID(matches[1]) {
+		return "", "", false, false
+	}
+	flags, err := hex.DecodeString(matches[3])
+	if err != nil {
+		return "", "", false, false
+	}
+	return matches[1], matches[2], flags[0]&1 == 1, true
+}
+
+// StartRootSpan start the span of the request, nil is returned if the spans are not exported or not sampled.
+// The sampled flag of the incoming trace is followed, the traces started here are sampled by the ratio.
+func StartRootSpan(traceID, parentSpanID string, sampled bool, name string) *Span {
+	if !Enabled() {
+		return nil
+	}
+	if len(parentSpanID) == 0 {
+		ratio, _ := sampleRatio.Load().(float64)
+		sampled = ratio >= 1 || mathrand.Float64() < ratio
+	}
+	if !sampled {
+		return nil
+	}
+	return &Span{TraceID: traceID, SpanID: randomHex(8), ParentSpanID: parentSpanID, Name: name,
+		Kind: SpanKindServer, Start: time.Now()}
+}
+
+// StartSpan start the child span of the span in the context, nil is returned if there is none
+func StartSpan(ctx context.Context, name string, kind SpanKind) *Span {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return nil
+	}
+	return &Span{TraceID: parent.TraceID, SpanID: randomHex(8), ParentSpanID: parent.SpanID, Name: name,
+		Kind: kind, Start: time.Now()}
+}
+
+// SetName change the name of the span, such as the route template that is known after the routing
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Name = name
+}
+
+// SetAttribute set the attribute of the span
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attributes == nil {
+		s.attributes = make(map[string]string)
+	}
+	s.attributes[key] = value
+}
+
+// Attributes get a copy of the attributes of the span
+func (s *Span) Attributes() map[string]string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attributes := make(map[string]string, len(s.attributes))
+	for k, v := range s.attributes {
+		attributes[k] = v
+	}
+	return attributes
+}
+
+// Finish end the span with the error of the operation and export it, it is exported only once
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	if err != nil {
+		s.Err = err.Error()
+	}
+	s.mu.Unlock()
+	if e := activeExporter.Load(); e != nil {
+		e.export(s)
+	}
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan attach the span to the context, the child spans started from the context belong to it
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext get the span attached to the context, the span of the request is looked up by the key
+// of the gin context
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	if span, ok := ctx.Value(spanContextKey{}).(*Span); ok {
+		return span
+	}
+	if span, ok := ctx.Value(SpanKey).(*Span); ok {
+		return span
+	}
+	return nil
+}
+
+const (
+	// SpanKey the key of the request span in the gin context
+	SpanKey = "answer:tracing:span"
+	// TraceIDKey the key of the trace id in the gin context, it is set even if the request is not sampled
+	TraceIDKey = "answer:tracing:trace-id"
+)
+
+// TraceIDFromContext get the trace id of the request, it is empty if the context is not from a request
+func TraceIDFromContext(ctx context.Context) string {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.TraceID
+	}
+	if ctx == nil {
+		return ""
+	}
+	traceID, _ := ctx.Value(TraceIDKey).(string)
+	return traceID
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
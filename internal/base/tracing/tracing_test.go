@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	traceID, parentSpanID, sampled, ok := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.True(t, ok)
+	assert.True(t, sampled)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	assert.Equal(t, "00f067aa0ba902b7", parentSpanID)
+
+	_, _, sampled, ok = ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.True(t, ok)
+	assert.False(t, sampled)
+
+	for _, header := range []string{"", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "invalid"} {
+		_, _, _, ok = ParseTraceParent(header)
+		assert.False(t, ok, header)
+	}
+}
+
+func TestNoExport(t *testing.T) {
+	shutdown := Init(nil)
+	defer shutdown()
+	span := StartRootSpan(NewTraceID(), "", false, "GET /")
+	assert.Nil(t, span)
+	// all the methods of the nil span do nothing
+	span.SetAttribute("key", "value")
+	span.Finish(nil)
+	assert.Nil(t, StartSpan(ContextWithSpan(context.Background(), span), "db.select", SpanKindClient))
+}
+
+func TestExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []*otlpSpan
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := &otlpRequest{}
+		if err := json.NewDecoder(r.Body).Decode(body); err == nil {
+			mu.Lock()
+			for _, rs := range body.ResourceSpans {
+				for _, ss := range rs.ScopeSpans {
+					received = append(received, ss.Spans...)
+				}
+			}
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	shutdown := Init(&Config{Endpoint: collector.URL})
+	traceID := NewTraceID()
+	root := StartRootSpan(traceID, "", false, "GET /questions")
+	require.NotNil(t, root)
+	root.SetAttribute("http.route", "/questions")
+	child := StartSpan(ContextWithSpan(context.Background(), root), "db.select", SpanKindClient)
+	require.NotNil(t, child)
+	child.Finish(errors.New("timeout"))
+	root.Finish(nil)
+	root.Finish(nil)
+	shutdown()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 2)
+	assert.Equal(t, "db.select", received[0].Name)
+	assert.Equal(t, traceID, received[0].TraceID)
+	assert.Equal(t, root.SpanID, received[0].ParentSpanID)
+	assert.Equal(t, otlpStatusCodeError, received[0].Status.Code)
+	assert.Equal(t, "GET /questions", received[1].Name)
+	assert.Empty(t, received[1].ParentSpanID)
+	assert.Equal(t, SpanKindServer, received[1].Kind)
+	assert.Equal(t, "/questions", received[1].Attributes[0].Value.StringValue)
+}
@@ -0,0 +1,463 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/migrations"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+const (
+	// ContentExportVersion the version of the export file format, increase it when the layout of the file changes
+	ContentExportVersion = 1
+	contentManifestName  = "manifest.json"
+	// contentMaxLineSize the max size of one exported row, the content of a post may be large
+	contentMaxLineSize = 64 * 1024 * 1024
+)
+
+// ContentExportManifest describe the exported content, it is the manifest.json file in the zip
+type ContentExportManifest struct {
+	// Version the version of the export file format
+	Version int `json:"version"`
+	// DBVersion the version of the database schema, the rows can only be imported into the same schema
+	DBVersion     int64                `json:"db_version"`
+	AnswerVersion string               `json:"answer_version"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Files         []*ContentExportFile `json:"files"`
+}
+
+// ContentExportFile one newline-delimited JSON file in the zip, a row of the table in each line
+type ContentExportFile struct {
+	Name  string `json:"name"`
+	Table string `json:"table"`
+	Count int64  `json:"count"`
+}
+
+// contentExportTable the table exported
+type contentExportTable struct {
+	name    string
+	newBean func() any
+	// where filter the rows of the table, all rows are exported if it is nil
+	where func(db *xorm.Engine) (query string, args []any, err error)
+	// omitColumns the columns are not exported and are not overwritten when importing
+	omitColumns []string
+	// uniqueID the ids of the rows are generated by the uniqid table
+	uniqueID bool
+}
+
+// contentExportTables the tables exported, in the order of importing
+var contentExportTables = []*contentExportTable{
+	{name: "users", newBean: func() any { return &entity.User{} }, omitColumns: []string{"pass"}},
+	{name: "tags", newBean: func() any { return &entity.Tag{} }, uniqueID: true},
+	{name: "questions", newBean: func() any { return &entity.Question{} }, uniqueID: true},
+	{name: "answers", newBean: func() any { return &entity.Answer{} }, uniqueID: true},
+	{name: "comments", newBean: func() any { return &entity.Comment{} }, uniqueID: true},
+	{name: "tag_rels", newBean: func() any { return &entity.TagRel{} }},
+	{name: "votes", newBean: func() any { return &entity.Activity{} }, where: voteActivityCondition},
+}
+
+// voteActivityKeys the votes are saved as the activities of these types
+var voteActivityKeys = []string{
+	"question.vote_up", "question.vote_down",
+	"answer.vote_up", "answer.vote_down",
+	"comment.vote_up",
+}
+
+func voteActivityCondition(db *xorm.Engine) (query string, args []any, err error) {
+	for _, key := range voteActivityKeys {
+		c := &entity.Config{Key: key}
+		exist, err := db.Get(c)
+		if err != nil {
+			return "", nil, err
+		}
+		if !exist {
+			continue
+		}
+		if len(args) > 0 {
+			query += ","
+		}
+		query += "?"
+		args = append(args, c.ID)
+	}
+	if len(args) == 0 {
+		return "1 = 0", nil, nil
+	}
+	return "activity_type IN (" + query + ")", args, nil
+}
+
+// ExportContent export the questions, answers, comments, tags, users and votes into a zip file of
+// newline-delimited JSON files. The rows are streamed into the file, they are never loaded all at once.
+func ExportContent(dataConf *data.Database, exportPath string) (fileName string, err error) {
+	db, err := data.NewDB(false, dataConf)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if err = db.Ping(); err != nil {
+		return "", err
+	}
+	dbVersion, err := migrations.GetCurrentDBVersion(db)
+	if err != nil {
+		return "", err
+	}
+
+	fileName = filepath.Join(exportPath, fmt.Sprintf("answer_export_%s.zip", time.Now().Format("2006-01-02")))
+	file, err := os.Create(fileName)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+		if err != nil {
+			_ = os.Remove(fileName)
+		}
+	}()
+
+	manifest := &ContentExportManifest{
+		Version:       ContentExportVersion,
+		DBVersion:     dbVersion,
+		AnswerVersion: constant.Version,
+		CreatedAt:     time.Now(),
+	}
+	zw := zip.NewWriter(file)
+	for _, table := range contentExportTables {
+		w, err := zw.Create(table.name + ".ndjson")
+		if err != nil {
+			return "", err
+		}
+		exported, err := exportContentTable(db, table, w)
+		if err != nil {
+			return "", fmt.Errorf("export %s failed: %w", table.name, err)
+		}
+		manifest.Files = append(manifest.Files, exported)
+		fmt.Printf("exported %d %s\n", exported.Count, table.name)
+	}
+
+	if err = writeContentManifest(zw, manifest); err != nil {
+		return "", err
+	}
+	if err = zw.Close(); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
+func exportContentTable(db *xorm.Engine, table *contentExportTable, w io.Writer) (exported *ContentExportFile, err error) {
+	tableInfo, err := db.TableInfo(table.newBean())
+	if err != nil {
+		return nil, err
+	}
+	exported = &ContentExportFile{Name: table.name + ".ndjson", Table: tableInfo.Name}
+
+	session := db.NewSession()
+	defer session.Close()
+	session.Unscoped().Asc("id")
+	if table.where != nil {
+		query, args, err := table.where(db)
+		if err != nil {
+			return nil, err
+		}
+		session.Where(query, args...)
+	}
+	rows, err := session.Rows(table.newBean())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	omitted := make(map[string]bool, len(table.omitColumns))
+	for _, column := range table.omitColumns {
+		omitted[column] = true
+	}
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+	for rows.Next() {
+		bean := table.newBean()
+		if err = rows.Scan(bean); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(tableInfo.Columns()))
+		value := reflect.ValueOf(bean).Elem()
+		for _, column := range tableInfo.Columns() {
+			if omitted[column.Name] || len(column.FieldIndex) == 0 {
+				continue
+			}
+			row[column.Name] = value.FieldByIndex(column.FieldIndex).Interface()
+		}
+		if err = encoder.Encode(row); err != nil {
+			return nil, err
+		}
+		exported.Count++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return exported, buf.Flush()
+}
+
+// ImportContent restore the content exported by ExportContent. The version of the export file format and
+// the database schema in the manifest must be the same as the current ones. The rows are inserted with their
+// original ids in one transaction, the existing rows with the same ids are overwritten. The passwords of the
+// users are not exported, the imported users need to reset their passwords.
+func ImportContent(dataConf *data.Database, fileName string) (manifest *ContentExportManifest, err error) {
+	db, err := data.NewDB(false, dataConf)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.OpenReader(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	manifest, err = readContentManifest(files[contentManifestName])
+	if err != nil {
+		return nil, err
+	}
+	dbVersion, err := migrations.GetCurrentDBVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	exportedFiles, err := checkContentManifest(manifest, dbVersion, files)
+	if err != nil {
+		return nil, err
+	}
+
+	session := db.NewSession()
+	defer session.Close()
+	if err = session.Begin(); err != nil {
+		return nil, err
+	}
+	var maxUniqueID int64
+	for _, table := range contentExportTables {
+		exported := exportedFiles[table.name+".ndjson"]
+		if exported == nil {
+			continue
+		}
+		count, tableMaxUniqueID, err := importContentTable(session, table, files[exported.Name])
+		if err != nil {
+			_ = session.Rollback()
+			return nil, fmt.Errorf("import %s failed: %w", table.name, err)
+		}
+		if count != exported.Count {
+			_ = session.Rollback()
+			return nil, fmt.Errorf("import %s failed: %d rows in the manifest, %d rows in the file",
+				table.name, exported.Count, count)
+		}
+		maxUniqueID = max(maxUniqueID, tableMaxUniqueID)
+		fmt.Printf("imported %d %s\n", count, table.name)
+	}
+	if err = resetContentSequences(db, session, maxUniqueID); err != nil {
+		_ = session.Rollback()
+		return nil, err
+	}
+	if err = session.Commit(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeContentManifest(zw *zip.Writer, manifest *ContentExportManifest) error {
+	w, err := zw.Create(contentManifestName)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+// checkContentManifest check whether the exported content can be imported into the database of dbVersion,
+// the files listed in the manifest are returned by their names
+func checkContentManifest(manifest *ContentExportManifest, dbVersion int64, files map[string]*zip.File) (
+	exportedFiles map[string]*ContentExportFile, err error) {
+	if manifest.Version != ContentExportVersion {
+		return nil, fmt.Errorf("the export file version %d is not supported, expected version %d",
+			manifest.Version, ContentExportVersion)
+	}
+	if manifest.DBVersion != dbVersion {
+		return nil, fmt.Errorf("the export file is from database version %d, the current database version is %d, "+
+			"upgrade the database to the same version before importing", manifest.DBVersion, dbVersion)
+	}
+	exportedFiles = make(map[string]*ContentExportFile, len(manifest.Files))
+	for _, exported := range manifest.Files {
+		if files[exported.Name] == nil {
+			return nil, fmt.Errorf("the file %s in the manifest is missing", exported.Name)
+		}
+		exportedFiles[exported.Name] = exported
+	}
+	return exportedFiles, nil
+}
+
+func readContentManifest(f *zip.File) (manifest *ContentExportManifest, err error) {
+	if f == nil {
+		return nil, fmt.Errorf("%s is missing, it is not an export file", contentManifestName)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	manifest = &ContentExportManifest{}
+	if err = json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("read %s failed: %w", contentManifestName, err)
+	}
+	return manifest, nil
+}
+
+func importContentTable(session *xorm.Session, table *contentExportTable, f *zip.File) (
+	count, maxUniqueID int64, err error) {
+	tableInfo, err := session.Engine().TableInfo(table.newBean())
+	if err != nil {
+		return 0, 0, err
+	}
+	r, err := f.Open()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), contentMaxLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		row := make(map[string]json.RawMessage)
+		if err = json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			return count, 0, fmt.Errorf("line %d: %w", count+1, err)
+		}
+		bean := table.newBean()
+		value := reflect.ValueOf(bean).Elem()
+		for _, column := range tableInfo.Columns() {
+			raw, ok := row[column.Name]
+			if !ok || len(column.FieldIndex) == 0 {
+				continue
+			}
+			if err = json.Unmarshal(raw, value.FieldByIndex(column.FieldIndex).Addr().Interface()); err != nil {
+				return count, 0, fmt.Errorf("line %d column %s: %w", count+1, column.Name, err)
+			}
+		}
+		if _, ok := row["id"]; !ok {
+			return count, 0, fmt.Errorf("line %d: id is missing", count+1)
+		}
+		rowID := value.FieldByIndex(tableInfo.GetColumn("id").FieldIndex).Interface()
+		if err = importContentRow(session, table, tableInfo, rowID, bean); err != nil {
+			return count, 0, fmt.Errorf("line %d: %w", count+1, err)
+		}
+		if table.uniqueID {
+			maxUniqueID = max(maxUniqueID, uniqueIDSequence(fmt.Sprint(rowID)))
+		}
+		count++
+	}
+	return count, maxUniqueID, scanner.Err()
+}
+
+func importContentRow(session *xorm.Session, table *contentExportTable, tableInfo *schemas.Table,
+	id any, bean any) error {
+	exist, err := session.Table(tableInfo.Name).Where("id = ?", id).Exist()
+	if err != nil {
+		return err
+	}
+	if !exist {
+		_, err = session.NoAutoTime().Insert(bean)
+		return err
+	}
+	_, err = session.NoAutoTime().Unscoped().ID(id).AllCols().Omit(table.omitColumns...).Update(bean)
+	return err
+}
+
+// uniqueIDSequence the sequence of the uniqid table in the id, see unique.GenUniqueIDStr
+func uniqueIDSequence(id string) int64 {
+	if len(id) != 17 {
+		return 0
+	}
+	sequence, _ := strconv.ParseInt(id[4:], 10, 64)
+	return sequence
+}
+
+// resetContentSequences make sure the ids generated after importing do not conflict with the imported ones
+func resetContentSequences(db *xorm.Engine, session *xorm.Session, maxUniqueID int64) error {
+	currentUniqueID := &entity.Uniqid{}
+	if _, err := session.Desc("id").Get(currentUniqueID); err != nil {
+		return err
+	}
+	if maxUniqueID > currentUniqueID.ID {
+		if _, err := session.Insert(&entity.Uniqid{ID: maxUniqueID}); err != nil {
+			return err
+		}
+	}
+	// the sequences of postgres are not moved by the rows inserted with ids
+	if db.Dialect().URI().DBType != schemas.POSTGRES {
+		return nil
+	}
+	tables := []string{entity.Uniqid{}.TableName()}
+	for _, table := range contentExportTables {
+		tableInfo, err := db.TableInfo(table.newBean())
+		if err != nil {
+			return err
+		}
+		tables = append(tables, tableInfo.Name)
+	}
+	for _, table := range tables {
+		_, err := session.Exec(fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 1)) FROM %s", table, table))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
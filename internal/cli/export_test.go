@@ -0,0 +1,267 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func newContentTestDB(t *testing.T, beans ...any) *xorm.Engine {
+	db, err := data.NewDB(false, &data.Database{
+		Driver:     "sqlite3",
+		Connection: filepath.Join(t.TempDir(), "answer.db"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	require.NoError(t, db.Sync(beans...))
+	return db
+}
+
+// zipContentFiles write the files into a zip and return the files of it by their names
+func zipContentFiles(t *testing.T, write func(zw *zip.Writer)) map[string]*zip.File {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	write(zw)
+	require.NoError(t, zw.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	return files
+}
+
+func zipContentFile(t *testing.T, name, content string) map[string]*zip.File {
+	return zipContentFiles(t, func(zw *zip.Writer) {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	})
+}
+
+func TestUniqueIDSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want int64
+	}{
+		{name: "question id", id: "10010000000000901", want: 901},
+		{name: "tag id", id: "10030000000000001", want: 1},
+		{name: "large sequence", id: "10029999999999999", want: 9999999999999},
+		{name: "short id", id: "1", want: 0},
+		{name: "long id", id: "100100000000009010", want: 0},
+		{name: "empty id", id: "", want: 0},
+		{name: "not a number", id: "1001000000000a901", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, uniqueIDSequence(tt.id))
+		})
+	}
+}
+
+func TestContentManifest_WriteAndRead(t *testing.T) {
+	manifest := &ContentExportManifest{
+		Version:       ContentExportVersion,
+		DBVersion:     30,
+		AnswerVersion: "1.0.0",
+		CreatedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Files: []*ContentExportFile{
+			{Name: "users.ndjson", Table: "user", Count: 2},
+			{Name: "tags.ndjson", Table: "tag", Count: 3},
+		},
+	}
+	files := zipContentFiles(t, func(zw *zip.Writer) {
+		require.NoError(t, writeContentManifest(zw, manifest))
+	})
+
+	got, err := readContentManifest(files[contentManifestName])
+	require.NoError(t, err)
+	assert.Equal(t, manifest, got)
+}
+
+func TestReadContentManifest_Invalid(t *testing.T) {
+	_, err := readContentManifest(nil)
+	assert.ErrorContains(t, err, "manifest.json is missing")
+
+	files := zipContentFile(t, contentManifestName, "{not json")
+	_, err = readContentManifest(files[contentManifestName])
+	assert.Error(t, err)
+}
+
+func TestCheckContentManifest(t *testing.T) {
+	files := zipContentFiles(t, func(zw *zip.Writer) {
+		for _, name := range []string{contentManifestName, "users.ndjson", "tags.ndjson"} {
+			_, err := zw.Create(name)
+			require.NoError(t, err)
+		}
+	})
+	newManifest := func() *ContentExportManifest {
+		return &ContentExportManifest{
+			Version:   ContentExportVersion,
+			DBVersion: 30,
+			Files: []*ContentExportFile{
+				{Name: "users.ndjson", Table: "user", Count: 2},
+				{Name: "tags.ndjson", Table: "tag", Count: 3},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(manifest *ContentExportManifest)
+		wantErr string
+	}{
+		{name: "valid", modify: func(manifest *ContentExportManifest) {}},
+		{
+			name:    "unsupported export version",
+			modify:  func(manifest *ContentExportManifest) { manifest.Version = ContentExportVersion + 1 },
+			wantErr: "version 2 is not supported",
+		},
+		{
+			name:    "different database version",
+			modify:  func(manifest *ContentExportManifest) { manifest.DBVersion = 29 },
+			wantErr: "database version 29, the current database version is 30",
+		},
+		{
+			name: "missing file",
+			modify: func(manifest *ContentExportManifest) {
+				manifest.Files = append(manifest.Files, &ContentExportFile{Name: "votes.ndjson", Table: "activity"})
+			},
+			wantErr: "votes.ndjson in the manifest is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := newManifest()
+			tt.modify(manifest)
+			exportedFiles, err := checkContentManifest(manifest, 30, files)
+			if len(tt.wantErr) > 0 {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, exportedFiles, 2)
+			assert.Equal(t, int64(2), exportedFiles["users.ndjson"].Count)
+			assert.Equal(t, int64(3), exportedFiles["tags.ndjson"].Count)
+		})
+	}
+}
+
+func TestExportImportContentTable(t *testing.T) {
+	tagsTable := contentExportTables[1]
+	require.Equal(t, "tags", tagsTable.name)
+
+	source := newContentTestDB(t, &entity.Tag{})
+	tags := []*entity.Tag{
+		{ID: "10030000000000001", SlugName: "go", DisplayName: "Go", Status: entity.TagStatusAvailable},
+		{ID: "10030000000000007", SlugName: "rust", DisplayName: "Rust", Status: entity.TagStatusAvailable},
+		{ID: "10030000000000003", SlugName: "java", DisplayName: "Java", Status: entity.TagStatusDeleted},
+	}
+	_, err := source.Insert(tags)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	exported, err := exportContentTable(source, tagsTable, buf)
+	require.NoError(t, err)
+	assert.Equal(t, &ContentExportFile{Name: "tags.ndjson", Table: "tag", Count: 3}, exported)
+
+	files := zipContentFile(t, exported.Name, buf.String())
+	target := newContentTestDB(t, &entity.Tag{})
+	// the existing row with the same id is overwritten
+	_, err = target.Insert(&entity.Tag{ID: "10030000000000001", SlugName: "golang", DisplayName: "Golang"})
+	require.NoError(t, err)
+
+	session := target.NewSession()
+	defer session.Close()
+	count, maxUniqueID, err := importContentTable(session, tagsTable, files[exported.Name])
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, int64(7), maxUniqueID)
+
+	imported := make([]*entity.Tag, 0)
+	require.NoError(t, target.Asc("id").Find(&imported))
+	require.Len(t, imported, 3)
+	assert.Equal(t, "go", imported[0].SlugName)
+	assert.Equal(t, "java", imported[1].SlugName)
+	assert.Equal(t, entity.TagStatusDeleted, imported[1].Status)
+	assert.Equal(t, "rust", imported[2].SlugName)
+}
+
+func TestExportContentTable_OmitColumns(t *testing.T) {
+	usersTable := contentExportTables[0]
+	require.Equal(t, "users", usersTable.name)
+
+	db := newContentTestDB(t, &entity.User{})
+	_, err := db.Insert(&entity.User{ID: "1", Username: "admin", Pass: "hashed", EMail: "admin@example.com"})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	exported, err := exportContentTable(db, usersTable, buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), exported.Count)
+
+	row := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &row))
+	assert.Equal(t, "admin", row["username"])
+	assert.NotContains(t, row, "pass")
+}
+
+func TestImportContentTable_InvalidRows(t *testing.T) {
+	tagsTable := contentExportTables[1]
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "missing id", content: `{"slug_name":"go"}` + "\n", wantErr: "line 1: id is missing"},
+		{
+			name:    "malformed line",
+			content: `{"id":"10030000000000001","slug_name":"go"}` + "\n" + `{"id":` + "\n",
+			wantErr: "line 2",
+		},
+		{name: "wrong column type", content: `{"id":"10030000000000001","status":"x"}` + "\n", wantErr: "column status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newContentTestDB(t, &entity.Tag{})
+			files := zipContentFile(t, "tags.ndjson", tt.content)
+			session := db.NewSession()
+			defer session.Close()
+			_, _, err := importContentTable(session, tagsTable, files["tags.ndjson"])
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -20,6 +20,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/apache/answer/internal/base/data"
@@ -46,7 +47,7 @@ func CheckDBConnection(dataConf *data.Database) bool {
 	defer func() {
 		_ = db.Close()
 	}()
-	if err = db.Ping(); err != nil {
+	if err = data.PingDB(context.Background(), db); err != nil {
 		fmt.Printf("connection ping database failed: %s\n", err)
 		return false
 	}
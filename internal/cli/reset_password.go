@@ -34,8 +34,10 @@ import (
 	"github.com/apache/answer/internal/base/path"
 	"github.com/apache/answer/internal/repo/api_key"
 	"github.com/apache/answer/internal/repo/auth"
+	"github.com/apache/answer/internal/repo/site_info"
 	"github.com/apache/answer/internal/repo/user"
 	authService "github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/pkg/checker"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
@@ -88,7 +90,7 @@ func ResetPassword(ctx context.Context, dataDirPath string, opts *ResetPasswordO
 	}
 	defer cacheCleanup()
 
-	dataData, dataCleanup, err := data.NewData(db, cache)
+	dataData, dataCleanup, err := data.NewData(db, nil, cache)
 	if err != nil {
 		return fmt.Errorf("initialize data layer failed: %w", err)
 	}
@@ -97,7 +99,8 @@ func ResetPassword(ctx context.Context, dataDirPath string, opts *ResetPasswordO
 	userRepo := user.NewUserRepo(dataData)
 	authRepo := auth.NewAuthRepo(dataData)
 	apiKeyRepo := api_key.NewAPIKeyRepo(dataData)
-	authSvc := authService.NewAuthService(authRepo, apiKeyRepo)
+	siteInfoService := siteinfo_common.NewSiteInfoCommonService(site_info.NewSiteInfo(dataData))
+	authSvc := authService.NewAuthService(authRepo, apiKeyRepo, siteInfoService)
 
 	email := strings.TrimSpace(opts.Email)
 	if email == "" {
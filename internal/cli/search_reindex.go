@@ -0,0 +1,217 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/answer/internal/base/conf"
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/path"
+	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/plugin_config"
+	"github.com/apache/answer/internal/repo/search_sync"
+	"github.com/apache/answer/plugin"
+)
+
+const (
+	DefaultSearchSyncBatchSize = 100
+	searchSyncCursorFileName   = "search-sync-cursor.json"
+)
+
+// SearchSyncOptions the options of rebuilding the search index
+type SearchSyncOptions struct {
+	// Full drop the index before rebuilding, otherwise the contents are updated in place
+	// and the index can be searched during the rebuild.
+	Full bool
+	// BatchSize the number of contents read from the database and indexed in one batch
+	BatchSize int
+	// Since only the contents updated since the time are indexed if it is not zero
+	Since time.Time
+	// Restart ignore the saved cursor of the last interrupted incremental rebuild
+	Restart bool
+}
+
+// searchSyncCursor the progress of the incremental rebuild, it is saved after every batch
+// so that an interrupted rebuild can be resumed.
+type searchSyncCursor struct {
+	Since      int64  `json:"since"`
+	ObjectType string `json:"object_type"`
+	LastID     string `json:"last_id"`
+}
+
+// SearchReindex rebuild the index of the enabled search plugin from the database
+func SearchReindex(ctx context.Context, dataDirPath string, opts *SearchSyncOptions) error {
+	path.FormatAllPath(dataDirPath)
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultSearchSyncBatchSize
+	}
+
+	c, err := conf.ReadConfig(path.GetConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("read config file failed: %w", err)
+	}
+
+	db, err := initDatabase(c.Data.Database.Driver, c.Data.Database.Connection)
+	if err != nil {
+		return fmt.Errorf("connect database failed: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	cache, cacheCleanup, err := data.NewCache(c.Data.Cache)
+	if err != nil {
+		return fmt.Errorf("initialize cache failed: %w", err)
+	}
+	defer cacheCleanup()
+
+	dataData, dataCleanup, err := data.NewData(db, nil, cache)
+	if err != nil {
+		return fmt.Errorf("initialize data layer failed: %w", err)
+	}
+	defer dataCleanup()
+
+	if err = loadPluginStatusAndConfig(ctx, dataData); err != nil {
+		return err
+	}
+
+	var finder plugin.Search
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		finder = search
+		return nil
+	})
+	if finder == nil {
+		return fmt.Errorf("no search plugin is enabled")
+	}
+
+	cursorFilePath := filepath.Join(path.CacheDir, searchSyncCursorFileName)
+	cursor := &searchSyncCursor{Since: opts.Since.Unix(), ObjectType: constant.QuestionObjectType, LastID: "0"}
+	if opts.Since.IsZero() {
+		cursor.Since = 0
+	}
+	if opts.Full {
+		fmt.Printf("rebuild search index of plugin %s\n", finder.Info().SlugName)
+		if indexer, ok := finder.(plugin.SearchIndexer); ok {
+			if err = indexer.ResetIndex(ctx); err != nil {
+				return fmt.Errorf("reset search index failed: %w", err)
+			}
+		}
+	} else {
+		fmt.Printf("update search index of plugin %s incrementally\n", finder.Info().SlugName)
+		if saved := loadSearchSyncCursor(cursorFilePath); saved != nil && saved.Since == cursor.Since && !opts.Restart {
+			cursor = saved
+			fmt.Printf("resume from %s %s\n", cursor.ObjectType, cursor.LastID)
+		}
+	}
+
+	syncer := search_sync.NewPluginSyncer(dataData)
+	getBatches := map[string]func(ctx context.Context, cursor string, since time.Time, limit int) (
+		[]*plugin.SearchContent, string, error){
+		constant.QuestionObjectType: syncer.GetQuestionsAfterID,
+		constant.AnswerObjectType:   syncer.GetAnswersAfterID,
+	}
+	objectTypes := []string{constant.QuestionObjectType, constant.AnswerObjectType}
+	for i, objectType := range objectTypes {
+		if cursor.ObjectType != objectType {
+			continue
+		}
+		total := 0
+		for {
+			contents, nextID, err := getBatches[objectType](ctx, cursor.LastID, opts.Since, opts.BatchSize)
+			if err != nil {
+				return fmt.Errorf("get %ss after %s failed: %w", objectType, cursor.LastID, err)
+			}
+			for _, content := range contents {
+				if err = finder.UpdateContent(ctx, content); err != nil {
+					return fmt.Errorf("index %s %s failed: %w", objectType, content.ObjectID, err)
+				}
+			}
+			if nextID == cursor.LastID {
+				break
+			}
+			cursor.LastID = nextID
+			total += len(contents)
+			fmt.Printf("indexed %d %ss, cursor %s\n", total, objectType, cursor.LastID)
+			if !opts.Full {
+				saveSearchSyncCursor(cursorFilePath, cursor)
+			}
+		}
+		if i+1 < len(objectTypes) {
+			cursor.ObjectType, cursor.LastID = objectTypes[i+1], "0"
+		}
+	}
+	_ = os.Remove(cursorFilePath)
+	fmt.Println("rebuild search index successfully")
+	return nil
+}
+
+func loadSearchSyncCursor(filePath string) *searchSyncCursor {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	cursor := &searchSyncCursor{}
+	if err = json.Unmarshal(content, cursor); err != nil || len(cursor.ObjectType) == 0 {
+		return nil
+	}
+	return cursor
+}
+
+func saveSearchSyncCursor(filePath string, cursor *searchSyncCursor) {
+	content, _ := json.Marshal(cursor)
+	if err := os.WriteFile(filePath, content, 0o644); err != nil {
+		fmt.Printf("save search sync cursor failed: %v\n", err)
+	}
+}
+
+// loadPluginStatusAndConfig load the plugin status and config from the database like the application does
+func loadPluginStatusAndConfig(ctx context.Context, dataData *data.Data) error {
+	pluginStatus, err := config.NewConfigRepo(dataData).GetConfigByKeyFromDB(ctx, constant.PluginStatus)
+	if err != nil {
+		return fmt.Errorf("get plugin status failed: %w", err)
+	}
+	if err = plugin.StatusManager.UnmarshalJSON([]byte(pluginStatus.Value)); err != nil {
+		return fmt.Errorf("parse plugin status failed: %w", err)
+	}
+
+	pluginConfigs, err := plugin_config.NewPluginConfigRepo(dataData).GetPluginConfigAll(ctx)
+	if err != nil {
+		return fmt.Errorf("get plugin config failed: %w", err)
+	}
+	for _, pluginConfig := range pluginConfigs {
+		err = plugin.CallConfig(func(fn plugin.Config) error {
+			if fn.Info().SlugName == pluginConfig.PluginSlugName {
+				return fn.ConfigReceiver([]byte(pluginConfig.Value))
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("parse plugin config failed: %s %w", pluginConfig.PluginSlugName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,697 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/mozillazg/go-pinyin"
+	"xorm.io/xorm"
+)
+
+const (
+	sePostTypeQuestion   = 1
+	sePostTypeAnswer     = 2
+	sePostTypeTagExcerpt = 4
+
+	seVoteTypeAcceptedByOriginator = 1
+	seVoteTypeUpMod                = 2
+	seVoteTypeDownMod              = 3
+
+	seTimeLayout        = "2006-01-02T15:04:05.999999999"
+	seTagSlugMaxLength  = 35
+	seUsernameMaxLength = 30
+)
+
+var seUsernameInvalidChars = regexp.MustCompile(`[^\w.\-]+`)
+
+// StackExchangeImportOptions the options of importing a Stack Exchange data dump
+type StackExchangeImportOptions struct {
+	// DumpDir the directory of Users.xml, Tags.xml, Posts.xml, Comments.xml and Votes.xml
+	DumpDir string
+	// DryRun read the whole dump and report the counts and conflicts, nothing is saved
+	DryRun bool
+}
+
+// StackExchangeImportReport the result of importing a Stack Exchange data dump
+type StackExchangeImportReport struct {
+	Users     int64
+	Tags      int64
+	Questions int64
+	Answers   int64
+	Comments  int64
+	// Votes the up and down votes, they are anonymous in the dump and kept in the scores of the posts
+	Votes int64
+	// Skipped the rows not imported, the reasons are in the conflicts
+	Skipped   int64
+	Conflicts []string
+}
+
+// seTime the time in the dump, it is in UTC without the zone
+type seTime struct {
+	time.Time
+}
+
+// UnmarshalXMLAttr parse the time of the dump
+func (t *seTime) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	t.Time, err = time.ParseInLocation(seTimeLayout, attr.Value, time.UTC)
+	return err
+}
+
+type seUserRow struct {
+	ID           int64  `xml:"Id,attr"`
+	Reputation   int    `xml:"Reputation,attr"`
+	CreationDate seTime `xml:"CreationDate,attr"`
+	DisplayName  string `xml:"DisplayName,attr"`
+	LastAccess   seTime `xml:"LastAccessDate,attr"`
+	AboutMe      string `xml:"AboutMe,attr"`
+	WebsiteURL   string `xml:"WebsiteUrl,attr"`
+	Location     string `xml:"Location,attr"`
+}
+
+type seTagRow struct {
+	ID            int64  `xml:"Id,attr"`
+	TagName       string `xml:"TagName,attr"`
+	ExcerptPostID int64  `xml:"ExcerptPostId,attr"`
+}
+
+type sePostRow struct {
+	ID               int64  `xml:"Id,attr"`
+	PostTypeID       int    `xml:"PostTypeId,attr"`
+	ParentID         int64  `xml:"ParentId,attr"`
+	AcceptedAnswerID int64  `xml:"AcceptedAnswerId,attr"`
+	CreationDate     seTime `xml:"CreationDate,attr"`
+	Score            int    `xml:"Score,attr"`
+	ViewCount        int    `xml:"ViewCount,attr"`
+	Body             string `xml:"Body,attr"`
+	OwnerUserID      string `xml:"OwnerUserId,attr"`
+	LastEditorUserID string `xml:"LastEditorUserId,attr"`
+	LastEditDate     seTime `xml:"LastEditDate,attr"`
+	LastActivityDate seTime `xml:"LastActivityDate,attr"`
+	Title            string `xml:"Title,attr"`
+	Tags             string `xml:"Tags,attr"`
+	ClosedDate       seTime `xml:"ClosedDate,attr"`
+}
+
+type seCommentRow struct {
+	ID           int64  `xml:"Id,attr"`
+	PostID       int64  `xml:"PostId,attr"`
+	Score        int    `xml:"Score,attr"`
+	Text         string `xml:"Text,attr"`
+	CreationDate seTime `xml:"CreationDate,attr"`
+	UserID       string `xml:"UserId,attr"`
+}
+
+type seVoteRow struct {
+	ID         int64 `xml:"Id,attr"`
+	PostID     int64 `xml:"PostId,attr"`
+	VoteTypeID int   `xml:"VoteTypeId,attr"`
+}
+
+// seQuestion the imported question, the accepted answer and the counts are updated after the answers are imported
+type seQuestion struct {
+	id               string
+	acceptedAnswerID int64
+	answerCount      int
+	lastAnswerID     string
+	lastAnswerAt     time.Time
+	postUpdateTime   time.Time
+}
+
+type seAnswer struct {
+	id         string
+	questionID string
+}
+
+// seImporter import the dump in passes, the users and tags first, then the questions, answers and comments
+// which refer to them
+type seImporter struct {
+	session   *xorm.Session
+	dumpDir   string
+	report    *StackExchangeImportReport
+	sanitizer *bluemonday.Policy
+
+	fallbackUserID string
+	users          map[int64]string
+	usernames      map[string]bool
+	userQuestions  map[string]int
+	userAnswers    map[string]int
+	tags           map[string]string
+	tagQuestions   map[string]int
+	tagExcerpts    map[int64]string
+	questions      map[int64]*seQuestion
+	answers        map[int64]*seAnswer
+	answerComments map[string]int
+	// acceptedVotes the answers accepted by the votes, they are matched with their questions when importing answers
+	acceptedVotes map[int64]bool
+}
+
+// ImportStackExchange import a Stack Exchange data dump. The posts keep their creation dates, scores and accepted
+// answers, the users keep their reputation. The users have no email and password in the dump, they are imported
+// as accounts that can not log in. Everything is imported in one transaction, it is rolled back in the dry run.
+func ImportStackExchange(dataConf *data.Database, opts *StackExchangeImportOptions) (
+	report *StackExchangeImportReport, err error) {
+	for _, name := range []string{"Users.xml", "Tags.xml", "Posts.xml", "Comments.xml", "Votes.xml"} {
+		if _, err = os.Stat(filepath.Join(opts.DumpDir, name)); err != nil {
+			return nil, fmt.Errorf("%s is required: %w", name, err)
+		}
+	}
+
+	db, err := data.NewDB(false, dataConf)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	session := db.NewSession()
+	defer session.Close()
+	if err = session.Begin(); err != nil {
+		return nil, err
+	}
+
+	im := &seImporter{
+		session:        session,
+		dumpDir:        opts.DumpDir,
+		report:         &StackExchangeImportReport{},
+		sanitizer:      bluemonday.UGCPolicy(),
+		users:          make(map[int64]string),
+		usernames:      make(map[string]bool),
+		userQuestions:  make(map[string]int),
+		userAnswers:    make(map[string]int),
+		tags:           make(map[string]string),
+		tagQuestions:   make(map[string]int),
+		tagExcerpts:    make(map[int64]string),
+		questions:      make(map[int64]*seQuestion),
+		answers:        make(map[int64]*seAnswer),
+		answerComments: make(map[string]int),
+		acceptedVotes:  make(map[int64]bool),
+	}
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"users", im.importUsers},
+		{"tags", im.importTags},
+		{"questions", im.importQuestions},
+		{"votes", im.importVotes},
+		{"answers", im.importAnswers},
+		{"comments", im.importComments},
+		{"counts", im.updateCounts},
+	}
+	for _, step := range steps {
+		if err = step.fn(); err != nil {
+			_ = session.Rollback()
+			return nil, fmt.Errorf("import %s failed: %w", step.name, err)
+		}
+	}
+
+	if opts.DryRun {
+		if err = session.Rollback(); err != nil {
+			return nil, err
+		}
+		return im.report, nil
+	}
+	if err = session.Commit(); err != nil {
+		return nil, err
+	}
+	return im.report, nil
+}
+
+// eachStackExchangeRow decode the row elements of the dump file one by one
+func eachStackExchangeRow[T any](dumpDir, name string, fn func(row *T) error) error {
+	file, err := os.Open(filepath.Join(dumpDir, name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read %s failed: %w", name, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		row := new(T)
+		if err = decoder.DecodeElement(row, &start); err != nil {
+			return fmt.Errorf("read %s failed: %w", name, err)
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+func (im *seImporter) conflict(format string, args ...any) {
+	im.report.Conflicts = append(im.report.Conflicts, fmt.Sprintf(format, args...))
+}
+
+func (im *seImporter) skip(format string, args ...any) {
+	im.report.Skipped++
+	im.conflict(format, args...)
+}
+
+// genUniqueID generate the id in the transaction, see unique.GenUniqueIDStr
+func (im *seImporter) genUniqueID(objectType string) (string, error) {
+	bean := &entity.Uniqid{UniqidType: constant.ObjectTypeStrMapping[objectType]}
+	if _, err := im.session.Insert(bean); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("1%03d%013d", bean.UniqidType, bean.ID), nil
+}
+
+// userID the user of the post or comment, the contents of the deleted users belong to the fallback user
+func (im *seImporter) userID(seUserID, object string, objectID int64) string {
+	if len(seUserID) > 0 {
+		id, _ := strconv.ParseInt(seUserID, 10, 64)
+		if userID, ok := im.users[id]; ok {
+			return userID
+		}
+	}
+	im.conflict("%s %d: the user %q is not in the dump, it belongs to the user %s", object, objectID, seUserID, im.fallbackUserID)
+	return im.fallbackUserID
+}
+
+func (im *seImporter) sanitize(content string) string {
+	return strings.TrimSpace(im.sanitizer.Sanitize(content))
+}
+
+func (im *seImporter) importUsers() error {
+	err := eachStackExchangeRow(im.dumpDir, "Users.xml", func(row *seUserRow) error {
+		username, err := im.makeUsername(row)
+		if err != nil {
+			return err
+		}
+		user := &entity.User{
+			CreatedAt:     row.CreationDate.Time,
+			UpdatedAt:     row.CreationDate.Time,
+			LastLoginDate: row.LastAccess.Time,
+			Username:      username,
+			MailStatus:    entity.EmailStatusToBeVerified,
+			Rank:          max(row.Reputation, 1),
+			Status:        entity.UserStatusAvailable,
+			DisplayName:   truncateRunes(row.DisplayName, 30),
+			Bio:           row.AboutMe,
+			BioHTML:       im.sanitize(row.AboutMe),
+			Website:       truncateRunes(row.WebsiteURL, 255),
+			Location:      truncateRunes(row.Location, 100),
+		}
+		if _, err = im.session.NoAutoTime().Insert(user); err != nil {
+			return err
+		}
+		im.users[row.ID] = user.ID
+		im.report.Users++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the posts of the deleted users belong to the community user of the dump or the admin
+	if userID, ok := im.users[-1]; ok {
+		im.fallbackUserID = userID
+		return nil
+	}
+	admin := &entity.User{}
+	exist, err := im.session.Where("is_admin = ?", true).Asc("id").Get(admin)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("no admin user for the posts of the deleted users")
+	}
+	im.fallbackUserID = admin.ID
+	return nil
+}
+
+// makeUsername make the username from the display name, see UserCommon.MakeUsername
+func (im *seImporter) makeUsername(row *seUserRow) (username string, err error) {
+	displayName := row.DisplayName
+	if checker.IsChinese(displayName) {
+		displayName = strings.Join(pinyin.LazyConvert(displayName, nil), "")
+	}
+	username = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(displayName), " ", "-"))
+	username = strings.Trim(seUsernameInvalidChars.ReplaceAllString(username, ""), ".-")
+	username = truncateRunes(username, seUsernameMaxLength)
+	if checker.IsInvalidUsername(username) || checker.IsReservedUsername(username) {
+		username = fmt.Sprintf("user%d", row.ID)
+	}
+
+	exist, err := im.session.Where("username = ?", username).Exist(&entity.User{})
+	if err != nil {
+		return "", err
+	}
+	if exist {
+		im.conflict("user %d: the username %s exists", row.ID, username)
+	}
+	if exist || im.usernames[username] {
+		suffix := fmt.Sprintf("-%d", row.ID)
+		username = truncateRunes(username, seUsernameMaxLength-len(suffix)) + suffix
+	}
+	im.usernames[username] = true
+	return username, nil
+}
+
+func (im *seImporter) importTags() error {
+	return eachStackExchangeRow(im.dumpDir, "Tags.xml", func(row *seTagRow) error {
+		slugName, err := im.ensureTag(row.TagName)
+		if err != nil {
+			return err
+		}
+		if row.ExcerptPostID > 0 && len(slugName) > 0 {
+			im.tagExcerpts[row.ExcerptPostID] = slugName
+		}
+		return nil
+	})
+}
+
+// ensureTag get the tag by the name, the existing tag is used and the missing tag is created
+func (im *seImporter) ensureTag(tagName string) (slugName string, err error) {
+	slugName = truncateRunes(strings.ToLower(strings.TrimSpace(tagName)), seTagSlugMaxLength)
+	if len(slugName) == 0 {
+		return "", nil
+	}
+	if _, ok := im.tags[slugName]; ok {
+		return slugName, nil
+	}
+
+	tag := &entity.Tag{}
+	exist, err := im.session.Where("slug_name = ?", slugName).Get(tag)
+	if err != nil {
+		return "", err
+	}
+	if exist {
+		im.conflict("tag %s exists, the questions are added to it", slugName)
+		im.tags[slugName] = tag.ID
+		return slugName, nil
+	}
+
+	tag = &entity.Tag{
+		SlugName:    slugName,
+		DisplayName: truncateRunes(strings.TrimSpace(tagName), seTagSlugMaxLength),
+		Status:      entity.TagStatusAvailable,
+		UserID:      im.fallbackUserID,
+	}
+	if tag.ID, err = im.genUniqueID(constant.TagObjectType); err != nil {
+		return "", err
+	}
+	if _, err = im.session.Insert(tag); err != nil {
+		return "", err
+	}
+	im.tags[slugName] = tag.ID
+	im.report.Tags++
+	return slugName, nil
+}
+
+func (im *seImporter) importQuestions() error {
+	return eachStackExchangeRow(im.dumpDir, "Posts.xml", func(row *sePostRow) error {
+		switch row.PostTypeID {
+		case sePostTypeQuestion:
+			return im.importQuestion(row)
+		case sePostTypeTagExcerpt:
+			slugName, ok := im.tagExcerpts[row.ID]
+			if !ok {
+				return nil
+			}
+			_, err := im.session.Where("id = ?", im.tags[slugName]).Cols("original_text", "parsed_text").
+				Update(&entity.Tag{OriginalText: row.Body, ParsedText: im.sanitize(row.Body)})
+			return err
+		}
+		return nil
+	})
+}
+
+func (im *seImporter) importQuestion(row *sePostRow) (err error) {
+	question := &entity.Question{
+		CreatedAt:      row.CreationDate.Time,
+		UpdatedAt:      latestTime(row.CreationDate.Time, row.LastEditDate.Time),
+		UserID:         im.userID(row.OwnerUserID, "post", row.ID),
+		Title:          truncateRunes(html.UnescapeString(row.Title), 150),
+		OriginalText:   row.Body,
+		ParsedText:     im.sanitize(row.Body),
+		Pin:            entity.QuestionUnPin,
+		Show:           entity.QuestionShow,
+		Status:         entity.QuestionStatusAvailable,
+		ViewCount:      row.ViewCount,
+		VoteCount:      row.Score,
+		PostUpdateTime: latestTime(row.CreationDate.Time, row.LastActivityDate.Time),
+	}
+	if len(row.LastEditorUserID) > 0 {
+		question.LastEditUserID = im.userID(row.LastEditorUserID, "post", row.ID)
+	}
+	if !row.ClosedDate.IsZero() {
+		question.Status = entity.QuestionStatusClosed
+	}
+	if question.ID, err = im.genUniqueID(constant.QuestionObjectType); err != nil {
+		return err
+	}
+	if _, err = im.session.Insert(question); err != nil {
+		return err
+	}
+	im.questions[row.ID] = &seQuestion{
+		id:               question.ID,
+		acceptedAnswerID: row.AcceptedAnswerID,
+		postUpdateTime:   question.PostUpdateTime,
+	}
+	im.userQuestions[question.UserID]++
+	im.report.Questions++
+
+	for _, tagName := range parseStackExchangeTags(row.Tags) {
+		slugName, err := im.ensureTag(tagName)
+		if err != nil {
+			return err
+		}
+		if len(slugName) == 0 {
+			continue
+		}
+		tagRel := &entity.TagRel{
+			CreatedAt: question.CreatedAt,
+			UpdatedAt: question.CreatedAt,
+			ObjectID:  question.ID,
+			TagID:     im.tags[slugName],
+			Status:    entity.TagRelStatusAvailable,
+		}
+		if _, err = im.session.NoAutoTime().Insert(tagRel); err != nil {
+			return err
+		}
+		im.tagQuestions[tagRel.TagID]++
+	}
+	return nil
+}
+
+// importVotes the voters of the up and down votes are anonymous in the dump, the votes are kept in the scores
+// of the posts. The answers accepted by the asker are marked when the question has no accepted answer.
+func (im *seImporter) importVotes() error {
+	return eachStackExchangeRow(im.dumpDir, "Votes.xml", func(row *seVoteRow) error {
+		switch row.VoteTypeID {
+		case seVoteTypeUpMod, seVoteTypeDownMod:
+			im.report.Votes++
+		case seVoteTypeAcceptedByOriginator:
+			im.acceptedVotes[row.PostID] = true
+		}
+		return nil
+	})
+}
+
+func (im *seImporter) importAnswers() error {
+	return eachStackExchangeRow(im.dumpDir, "Posts.xml", func(row *sePostRow) (err error) {
+		if row.PostTypeID != sePostTypeAnswer {
+			return nil
+		}
+		question, ok := im.questions[row.ParentID]
+		if !ok {
+			im.skip("answer %d: the question %d is not in the dump", row.ID, row.ParentID)
+			return nil
+		}
+		if question.acceptedAnswerID == 0 && im.acceptedVotes[row.ID] {
+			question.acceptedAnswerID = row.ID
+		}
+
+		answer := &entity.Answer{
+			CreatedAt:    row.CreationDate.Time,
+			UpdatedAt:    latestTime(row.CreationDate.Time, row.LastEditDate.Time),
+			QuestionID:   question.id,
+			UserID:       im.userID(row.OwnerUserID, "post", row.ID),
+			OriginalText: row.Body,
+			ParsedText:   im.sanitize(row.Body),
+			Status:       entity.AnswerStatusAvailable,
+			Accepted:     schema.AnswerAcceptedFailed,
+			VoteCount:    row.Score,
+		}
+		if len(row.LastEditorUserID) > 0 {
+			answer.LastEditUserID = im.userID(row.LastEditorUserID, "post", row.ID)
+		}
+		if question.acceptedAnswerID == row.ID {
+			answer.Accepted = schema.AnswerAcceptedEnable
+		}
+		if answer.ID, err = im.genUniqueID(constant.AnswerObjectType); err != nil {
+			return err
+		}
+		if _, err = im.session.NoAutoTime().Insert(answer); err != nil {
+			return err
+		}
+		im.answers[row.ID] = &seAnswer{id: answer.ID, questionID: question.id}
+		question.answerCount++
+		if !answer.CreatedAt.Before(question.lastAnswerAt) {
+			question.lastAnswerID = answer.ID
+			question.lastAnswerAt = answer.CreatedAt
+		}
+		im.userAnswers[answer.UserID]++
+		im.report.Answers++
+		return nil
+	})
+}
+
+func (im *seImporter) importComments() error {
+	return eachStackExchangeRow(im.dumpDir, "Comments.xml", func(row *seCommentRow) (err error) {
+		comment := &entity.Comment{
+			CreatedAt:    row.CreationDate.Time,
+			UpdatedAt:    row.CreationDate.Time,
+			VoteCount:    row.Score,
+			Status:       entity.CommentStatusAvailable,
+			OriginalText: row.Text,
+			ParsedText:   "<p>" + html.EscapeString(row.Text) + "</p>",
+		}
+		if question, ok := im.questions[row.PostID]; ok {
+			comment.ObjectID, comment.QuestionID = question.id, question.id
+		} else if answer, ok := im.answers[row.PostID]; ok {
+			comment.ObjectID, comment.QuestionID = answer.id, answer.questionID
+			im.answerComments[answer.id]++
+		} else {
+			im.skip("comment %d: the post %d is not in the dump", row.ID, row.PostID)
+			return nil
+		}
+		comment.UserID = im.userID(row.UserID, "comment", row.ID)
+		if comment.ID, err = im.genUniqueID(constant.CommentObjectType); err != nil {
+			return err
+		}
+		if _, err = im.session.NoAutoTime().Insert(comment); err != nil {
+			return err
+		}
+		im.report.Comments++
+		return nil
+	})
+}
+
+// updateCounts update the accepted answers and the counts which depend on the rows imported later
+func (im *seImporter) updateCounts() error {
+	for postID, question := range im.questions {
+		update := &entity.Question{AnswerCount: question.answerCount, LastAnswerID: question.lastAnswerID,
+			PostUpdateTime: latestTime(question.postUpdateTime, question.lastAnswerAt)}
+		if answer, ok := im.answers[question.acceptedAnswerID]; ok && answer.questionID == question.id {
+			update.AcceptedAnswerID = answer.id
+		} else if question.acceptedAnswerID > 0 {
+			im.conflict("question %d: the accepted answer %d is not in the dump", postID, question.acceptedAnswerID)
+		}
+		if len(update.LastAnswerID) == 0 {
+			update.LastAnswerID = "0"
+		}
+		if len(update.AcceptedAnswerID) == 0 {
+			update.AcceptedAnswerID = "0"
+		}
+		_, err := im.session.ID(question.id).
+			Cols("answer_count", "last_answer_id", "accepted_answer_id", "post_update_time").Update(update)
+		if err != nil {
+			return err
+		}
+	}
+	for answerID, count := range im.answerComments {
+		if _, err := im.session.ID(answerID).Cols("comment_count").Update(&entity.Answer{CommentCount: count}); err != nil {
+			return err
+		}
+	}
+	for tagID, count := range im.tagQuestions {
+		if _, err := im.session.ID(tagID).Incr("question_count", count).Update(&entity.Tag{}); err != nil {
+			return err
+		}
+	}
+	for userID, count := range im.userQuestions {
+		if _, err := im.session.ID(userID).Incr("question_count", count).Update(&entity.User{}); err != nil {
+			return err
+		}
+	}
+	for userID, count := range im.userAnswers {
+		if _, err := im.session.ID(userID).Incr("answer_count", count).Update(&entity.User{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseStackExchangeTags parse the tags of the question, they are <a><b> in the old dumps and |a|b| in the new ones
+func parseStackExchangeTags(tags string) (names []string) {
+	tags = strings.TrimSpace(tags)
+	var separated []string
+	if strings.HasPrefix(tags, "<") {
+		separated = strings.Split(strings.Trim(tags, "<>"), "><")
+	} else {
+		separated = strings.Split(strings.Trim(tags, "|"), "|")
+	}
+	for _, name := range separated {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const seTestDumpDir = "testdata/stackexchange"
+
+func TestParseStackExchangeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags string
+		want []string
+	}{
+		{name: "old dump", tags: "<go><concurrency>", want: []string{"go", "concurrency"}},
+		{name: "new dump", tags: "|go|concurrency|", want: []string{"go", "concurrency"}},
+		{name: "one tag", tags: "<c++>", want: []string{"c++"}},
+		{name: "spaces around", tags: "  |go| |sql|  ", want: []string{"go", "sql"}},
+		{name: "empty tags", tags: "<><go>", want: []string{"go"}},
+		{name: "no tags", tags: "", want: nil},
+		{name: "only separators", tags: "||", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseStackExchangeTags(tt.tags))
+		})
+	}
+}
+
+func TestSeImporter_makeUsername(t *testing.T) {
+	db := newContentTestDB(t, &entity.User{})
+	_, err := db.Insert(&entity.User{ID: "1", Username: "taken", EMail: "taken@example.com"})
+	require.NoError(t, err)
+	session := db.NewSession()
+	defer session.Close()
+	im := &seImporter{
+		session:   session,
+		report:    &StackExchangeImportReport{},
+		usernames: make(map[string]bool),
+	}
+
+	// the users are imported in order, the later ones collide with the earlier ones
+	tests := []struct {
+		name        string
+		id          int64
+		displayName string
+		want        string
+	}{
+		{name: "spaces", id: 2, displayName: "Geoff Dalgas", want: "geoff-dalgas"},
+		{name: "collision in the dump", id: 3, displayName: "geoff dalgas", want: "geoff-dalgas-3"},
+		{name: "collision with the existing user", id: 4, displayName: "Taken", want: "taken-4"},
+		{name: "invalid characters", id: 5, displayName: " .John O'Brien!- ", want: "john-obrien"},
+		{name: "chinese", id: 6, displayName: "李小龙", want: "lixiaolong"},
+		{name: "only invalid characters", id: 7, displayName: "!!!", want: "user7"},
+		{name: "too short", id: 8, displayName: "a", want: "user8"},
+		{name: "reserved", id: 9, displayName: "Admin", want: "user9"},
+		{name: "too long", id: 10, displayName: strings.Repeat("abcdefghij", 4), want: strings.Repeat("abcdefghij", 3)},
+		{
+			name:        "too long collision",
+			id:          12345,
+			displayName: strings.Repeat("abcdefghij", 4),
+			want:        strings.Repeat("abcdefghij", 2) + "abcd-12345",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			username, err := im.makeUsername(&seUserRow{ID: tt.id, DisplayName: tt.displayName})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, username)
+			assert.LessOrEqual(t, len(username), seUsernameMaxLength)
+		})
+	}
+	assert.Equal(t, []string{"user 4: the username taken exists"}, im.report.Conflicts)
+}
+
+func TestEachStackExchangeRow(t *testing.T) {
+	users := make([]*seUserRow, 0)
+	err := eachStackExchangeRow(seTestDumpDir, "Users.xml", func(row *seUserRow) error {
+		users = append(users, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, &seUserRow{
+		ID:           -1,
+		Reputation:   1,
+		CreationDate: seTime{time.Date(2010, 7, 28, 16, 38, 27, 683000000, time.UTC)},
+		DisplayName:  "Community",
+		LastAccess:   seTime{time.Date(2010, 7, 28, 16, 38, 27, 683000000, time.UTC)},
+		AboutMe:      "<p>I am not a real person.</p>",
+		Location:     "on the server farm",
+	}, users[0])
+	assert.Equal(t, "http://stackoverflow.com", users[1].WebsiteURL)
+
+	posts := make([]*sePostRow, 0)
+	err = eachStackExchangeRow(seTestDumpDir, "Posts.xml", func(row *sePostRow) error {
+		posts = append(posts, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	question, answer := posts[0], posts[1]
+	assert.Equal(t, sePostTypeQuestion, question.PostTypeID)
+	assert.Equal(t, int64(3), question.AcceptedAnswerID)
+	assert.Equal(t, 25, question.Score)
+	assert.Equal(t, 1936, question.ViewCount)
+	assert.Equal(t, "How to go", question.Title)
+	assert.Equal(t, []string{"go", "concurrency"}, parseStackExchangeTags(question.Tags))
+	assert.Equal(t, "2", question.OwnerUserID)
+	assert.Empty(t, question.LastEditorUserID)
+	assert.True(t, question.ClosedDate.IsZero())
+	assert.Equal(t, sePostTypeAnswer, answer.PostTypeID)
+	assert.Equal(t, int64(1), answer.ParentID)
+	assert.Equal(t, -2, answer.Score)
+	assert.Equal(t, "-1", answer.OwnerUserID)
+}
+
+func TestEachStackExchangeRow_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+		rows    int
+	}{
+		{name: "missing file", file: "Votes.xml", wantErr: "no such file"},
+		{name: "malformed xml", file: "Broken.xml", wantErr: "read Broken.xml failed", rows: 1},
+		{name: "invalid time", file: "BadTime.xml", wantErr: "read BadTime.xml failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := 0
+			err := eachStackExchangeRow(seTestDumpDir, tt.file, func(row *sePostRow) error {
+				rows++
+				return nil
+			})
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Equal(t, tt.rows, rows)
+		})
+	}
+}
+
+func TestEachStackExchangeRow_CallbackError(t *testing.T) {
+	rows := 0
+	err := eachStackExchangeRow(seTestDumpDir, "Posts.xml", func(row *sePostRow) error {
+		rows++
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, rows)
+}
@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+)
+
+// storageMigrateSubPaths the sub paths of the upload directory that are migrated, the avatar thumbnails are made
+// again from the originals and the deleted files are purged, so they are skipped.
+var storageMigrateSubPaths = []string{
+	constant.AvatarSubPath,
+	constant.PostSubPath,
+	constant.BrandingSubPath,
+	constant.FilesPostSubPath,
+}
+
+// StorageMigrateReport the result of migrating the uploaded files to the object storage
+type StorageMigrateReport struct {
+	Uploaded int
+	Removed  int
+}
+
+// MigrateUploadsToObjectStorage upload the files in the upload directory to the object storage configured in the admin.
+// The keys of the objects are the sub paths of the files, which are the same as the paths of the URLs,
+// so the URLs of the already uploaded files keep working after switching to the object storage.
+// The local files are only removed if removeLocal is true, uploading the same files again is harmless.
+func MigrateUploadsToObjectStorage(dataConf *data.Database, uploadPath string, removeLocal bool) (
+	report *StorageMigrateReport, err error) {
+	db, err := data.NewDB(false, dataConf)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	siteInfo := &entity.SiteInfo{Type: constant.SiteTypeStorage}
+	exist, err := db.Get(siteInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get storage config failed: %w", err)
+	}
+	storage := &schema.SiteStorageResp{}
+	if exist {
+		if err = json.Unmarshal([]byte(siteInfo.Content), storage); err != nil {
+			return nil, fmt.Errorf("parse storage config failed: %w", err)
+		}
+	}
+	if !storage.IsObjectStorage() {
+		return nil, fmt.Errorf("the object storage is not configured, set it in the admin files settings first")
+	}
+
+	ctx := context.Background()
+	client := storage.NewClient()
+	report = &StorageMigrateReport{}
+	for _, subPath := range storageMigrateSubPaths {
+		root := filepath.Join(uploadPath, subPath)
+		err = filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			fileSubPath, err := filepath.Rel(uploadPath, filePath)
+			if err != nil {
+				return err
+			}
+			fileSubPath = filepath.ToSlash(fileSubPath)
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+			if err = client.PutObject(ctx, storage.ObjectKey(fileSubPath), content, http.DetectContentType(content)); err != nil {
+				return fmt.Errorf("upload %s failed: %w", fileSubPath, err)
+			}
+			report.Uploaded++
+			fmt.Printf("uploaded %s\n", fileSubPath)
+			if removeLocal {
+				if err = os.Remove(filePath); err != nil {
+					return err
+				}
+				report.Removed++
+			}
+			return nil
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
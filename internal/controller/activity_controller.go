@@ -24,7 +24,6 @@ import (
 	"github.com/apache/answer/internal/base/middleware"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
-	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/gin-gonic/gin"
 )
@@ -59,9 +58,7 @@ func (ac *ActivityController) GetObjectTimeline(ctx *gin.Context) {
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
-	if userInfo := middleware.GetUserInfoFromContext(ctx); userInfo != nil {
-		req.IsAdmin = userInfo.RoleID == role.RoleAdminID
-	}
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
 
 	resp, err := ac.activityService.GetObjectTimeline(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/base/validator"
@@ -33,6 +35,8 @@ import (
 	"github.com/apache/answer/internal/service/action"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/post_lock"
+	"github.com/apache/answer/internal/service/posting_restriction"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/pkg/uid"
@@ -47,6 +51,8 @@ type AnswerController struct {
 	actionService         *action.CaptchaService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
 	rateLimitMiddleware   *middleware.RateLimitMiddleware
+	postingRestriction    *posting_restriction.PostingRestrictionService
+	postLockService       *post_lock.PostLockService
 }
 
 // NewAnswerController new controller
@@ -56,6 +62,8 @@ func NewAnswerController(
 	actionService *action.CaptchaService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	postingRestriction *posting_restriction.PostingRestrictionService,
+	postLockService *post_lock.PostLockService,
 ) *AnswerController {
 	return &AnswerController{
 		answerService:         answerService,
@@ -63,6 +71,8 @@ func NewAnswerController(
 		actionService:         actionService,
 		siteInfoCommonService: siteInfoCommonService,
 		rateLimitMiddleware:   rateLimitMiddleware,
+		postingRestriction:    postingRestriction,
+		postLockService:       postLockService,
 	}
 }
 
@@ -231,6 +241,15 @@ func (ac *AnswerController) AddAnswer(ctx *gin.Context) {
 			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
 			return
 		}
+		if captchaPass, failReason := ac.actionService.VerifyCaptchaToken(ctx, constant.CaptchaSceneFirstPost, req.UserID,
+			req.CaptchaToken, ctx.ClientIP()); !captchaPass {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "captcha_token",
+				ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), failReason),
+			})
+			handler.HandleResponse(ctx, errors.BadRequest(failReason), errFields)
+			return
+		}
 	}
 
 	can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.AnswerAdd, "")
@@ -242,6 +261,14 @@ func (ac *AnswerController) AddAnswer(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if err = ac.postingRestriction.CheckCanPost(ctx, req.UserID, req.HTML); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if err = ac.postLockService.CheckPostEditable(ctx, req.QuestionID, isAdmin); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	write, err := ac.siteInfoCommonService.GetSiteQuestion(ctx)
 	if err != nil {
@@ -334,12 +361,17 @@ func (ac *AnswerController) UpdateAnswer(ctx *gin.Context) {
 	}
 
 	objectOwner := ac.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
-	req.NoNeedReview = canList[1] || objectOwner
+	wikiEditor := ac.rankService.CheckWikiEditPermission(ctx, req.UserID, req.ID)
+	req.CanEdit = canList[0] || objectOwner || wikiEditor
+	req.NoNeedReview = canList[1] || objectOwner || wikiEditor
 	if !req.CanEdit {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if err = ac.postLockService.CheckPostEditable(ctx, uid.DeShortID(req.ID), isAdmin); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	_, err = ac.answerService.Update(ctx, req)
 	if err != nil {
@@ -359,7 +391,7 @@ func (ac *AnswerController) UpdateAnswer(ctx *gin.Context) {
 
 // AnswerList godoc
 // @Summary AnswerList
-// @Description AnswerList <br> <b>order</b> (default or updated)
+// @Description AnswerList <br> <b>order</b> (default or updated) <br> <b>pagination</b> (offset or cursor), the cursor pagination returns the next_cursor instead of the count
 // @Tags Answer
 // @Accept json
 // @Produce json
@@ -367,6 +399,8 @@ func (ac *AnswerController) UpdateAnswer(ctx *gin.Context) {
 // @Param order query string true "order"
 // @Param page query string true "page"
 // @Param page_size query string true "page_size"
+// @Param pagination query string false "pagination"
+// @Param cursor query string false "cursor"
 // @Success 200 {string} string ""
 // @Router /answer/api/v1/answer/page [get]
 func (ac *AnswerController) AnswerList(ctx *gin.Context) {
@@ -383,6 +417,7 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 		permission.AnswerEdit,
 		permission.AnswerDelete,
 		permission.AnswerUnDelete,
+		permission.PostWikiEdit,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -391,6 +426,17 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.CanEdit = canList[0]
 	req.CanDelete = canList[1]
 	req.CanRecover = canList[2]
+	req.CanEditWiki = canList[3]
+
+	if req.IsCursorPagination() {
+		list, nextCursor, err := ac.answerService.SearchListByCursor(ctx, req)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+		handler.HandleResponse(ctx, nil, pager.NewCursorPageModel(nextCursor, list))
+		return
+	}
 
 	list, count, err := ac.answerService.SearchList(ctx, req)
 	if err != nil {
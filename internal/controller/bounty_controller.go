@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/bounty"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+)
+
+// BountyController question bounty controller
+type BountyController struct {
+	bountyService *bounty.BountyService
+}
+
+// NewBountyController new controller
+func NewBountyController(bountyService *bounty.BountyService) *BountyController {
+	return &BountyController{bountyService: bountyService}
+}
+
+// GetQuestionBounties get the bounties of a question
+// @Summary get the bounties of a question
+// @Description get the bounties of a question, the newest first
+// @Tags Question
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionBountyResp}
+// @Router /answer/api/v1/question/bounty [get]
+func (bc *BountyController) GetQuestionBounties(ctx *gin.Context) {
+	req := &schema.GetQuestionBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+
+	resp, err := bc.bountyService.GetQuestionBounties(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddQuestionBounty offer a bounty on a question
+// @Summary offer a bounty on a question
+// @Description offer a bounty on a question, the amount will be deducted from the reputation
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddQuestionBountyReq true "bounty"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/bounty [post]
+func (bc *BountyController) AddQuestionBounty(ctx *gin.Context) {
+	req := &schema.AddQuestionBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := bc.bountyService.AddBounty(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AwardQuestionBounty award the bounty of a question to an answer
+// @Summary award the bounty of a question to an answer
+// @Description award the bounty of a question to an answer, only the user who offered the bounty can do it
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AwardQuestionBountyReq true "award"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/bounty/award [post]
+func (bc *BountyController) AwardQuestionBounty(ctx *gin.Context) {
+	req := &schema.AwardQuestionBountyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.AnswerID = uid.DeShortID(req.AnswerID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := bc.bountyService.AwardBounty(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/answer/internal/service/action"
 	"github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/post_lock"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/gin-gonic/gin"
@@ -44,6 +45,7 @@ type CommentController struct {
 	rankService         *rank.RankService
 	actionService       *action.CaptchaService
 	rateLimitMiddleware *middleware.RateLimitMiddleware
+	postLockService     *post_lock.PostLockService
 }
 
 // NewCommentController new controller
@@ -52,12 +54,14 @@ func NewCommentController(
 	rankService *rank.RankService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	postLockService *post_lock.PostLockService,
 ) *CommentController {
 	return &CommentController{
 		commentService:      commentService,
 		rankService:         rankService,
 		actionService:       actionService,
 		rateLimitMiddleware: rateLimitMiddleware,
+		postLockService:     postLockService,
 	}
 }
 
@@ -120,6 +124,10 @@ func (cc *CommentController) AddComment(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if err = cc.postLockService.CheckPostEditable(ctx, req.ObjectID, isAdmin); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	req.UserAgent = ctx.GetHeader("User-Agent")
 	req.IP = ctx.ClientIP()
@@ -209,6 +217,11 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	err = cc.postLockService.CheckPostEditable(ctx, uid.DeShortID(req.CommentID), middleware.GetUserIsAdminModerator(ctx))
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	if !req.IsAdmin || !linkUrlLimitUser {
 		captchaPass := cc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEdit, req.UserID, req.CaptchaID, req.CaptchaCode)
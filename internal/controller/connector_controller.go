@@ -20,18 +20,23 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/internal/service/user_external_login"
 	"github.com/apache/answer/plugin"
 	"github.com/gin-gonic/gin"
+	myErrors "github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -178,7 +183,7 @@ func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn
 		if stateInfo != nil && stateInfo.Intent == schema.ExternalLoginOAuthStateBindIntent {
 			if err = cc.userExternalService.BindExternalLoginToUser(ctx, stateInfo.UserID, u); err != nil {
 				log.Errorf("bind external login failed: %v", err)
-				ctx.Redirect(http.StatusFound, "/50x")
+				cc.redirectBindError(ctx, err)
 				return
 			}
 			ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/settings/account", siteGeneral.SiteUrl))
@@ -204,6 +209,72 @@ func (cc *ConnectorController) ConnectorRedirect(connector plugin.Connector) (fn
 	}
 }
 
+// SAMLMetadata get the SAML service provider metadata
+// @Summary get the SAML service provider metadata
+// @Description get the SAML service provider metadata to register at the identity provider
+// @Tags PluginConnector
+// @Produce xml
+// @Success 200 {string} string
+// @Router /answer/api/v1/saml/metadata [get]
+func (cc *ConnectorController) SAMLMetadata(ctx *gin.Context) {
+	metadata, err := cc.userExternalService.SAMLMetadata(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// SAMLLogin redirect to the SAML identity provider
+func (cc *ConnectorController) SAMLLogin(ctx *gin.Context) {
+	redirectURL, err := cc.userExternalService.SAMLLoginURL(ctx, ctx.Query("state"))
+	if err != nil {
+		log.Errorf("saml login failed: %v", err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
+	ctx.Redirect(http.StatusFound, redirectURL)
+}
+
+// SAMLAcs the SAML assertion consumer service, receives the response posted by the identity provider
+func (cc *ConnectorController) SAMLAcs(ctx *gin.Context) {
+	siteGeneral, err := cc.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Errorf("get site info failed: %v", err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
+	stateInfo, u, err := cc.userExternalService.SAMLConsumeResponse(ctx,
+		ctx.PostForm("SAMLResponse"), ctx.PostForm("RelayState"))
+	if err != nil {
+		log.Errorf("saml response rejected: %v", err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
+	log.Debugf("saml received: %+v", u)
+	if stateInfo != nil && stateInfo.Intent == schema.ExternalLoginOAuthStateBindIntent {
+		if err = cc.userExternalService.BindExternalLoginToUser(ctx, stateInfo.UserID, u); err != nil {
+			log.Errorf("bind external login failed: %v", err)
+			cc.redirectBindError(ctx, err)
+			return
+		}
+		ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/settings/account", siteGeneral.SiteUrl))
+		return
+	}
+	resp, err := cc.userExternalService.SAMLLogin(ctx, u)
+	if err != nil {
+		log.Errorf("saml login failed: %v", err)
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
+	if len(resp.ErrMsg) > 0 {
+		ctx.Redirect(http.StatusFound, fmt.Sprintf("/50x?title=%s&msg=%s", resp.ErrTitle, resp.ErrMsg))
+		return
+	}
+	ctx.Redirect(http.StatusFound, fmt.Sprintf("%s/users/auth-landing?access_token=%s",
+		siteGeneral.SiteUrl, resp.AccessToken))
+}
+
 // ConnectorsInfo get all enabled connectors
 // @Summary get all enabled connectors
 // @Description get all enabled connectors
@@ -230,6 +301,18 @@ func (cc *ConnectorController) ConnectorsInfo(ctx *gin.Context) {
 		})
 		return nil
 	})
+	samlConfig, err := cc.siteInfoService.GetSiteSAML(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if samlConfig.Enabled {
+		resp = append(resp, &schema.ConnectorInfoResp{
+			Name: samlConfig.GetDisplayName(),
+			Icon: constant.SAMLLogoSVG,
+			Link: general.SiteUrl + constant.SAMLLoginURLPath,
+		})
+	}
 	handler.HandleResponse(ctx, nil, resp)
 }
 
@@ -306,6 +389,32 @@ func (cc *ConnectorController) ConnectorsUserInfo(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
+
+	samlConfig, err := cc.siteInfoService.GetSiteSAML(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if samlConfig.Enabled {
+		externalID := userExternalLoginMapping[constant.SAMLProvider]
+		link := general.SiteUrl + constant.SAMLLoginURLPath
+		if len(externalID) == 0 {
+			state, err := cc.userExternalService.GenerateOAuthState(ctx, constant.SAMLProvider,
+				schema.ExternalLoginOAuthStateBindIntent, userID)
+			if err != nil {
+				handler.HandleResponse(ctx, err, nil)
+				return
+			}
+			link = fmt.Sprintf("%s?state=%s", link, url.QueryEscape(state))
+		}
+		resp = append(resp, &schema.ConnectorUserInfoResp{
+			Name:       samlConfig.GetDisplayName(),
+			Icon:       constant.SAMLLogoSVG,
+			Link:       link,
+			Binding:    len(externalID) > 0,
+			ExternalID: externalID,
+		})
+	}
 	handler.HandleResponse(ctx, nil, resp)
 }
 
@@ -330,3 +439,15 @@ func (cc *ConnectorController) ExternalLoginUnbinding(ctx *gin.Context) {
 	resp, err := cc.userExternalService.ExternalLoginUnbinding(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// redirectBindError shows the reason why the external login can not be bound to the current user
+func (cc *ConnectorController) redirectBindError(ctx *gin.Context, err error) {
+	var myErr *myErrors.Error
+	if !errors.As(err, &myErr) || !myErrors.IsBadRequest(myErr) {
+		ctx.Redirect(http.StatusFound, "/50x")
+		return
+	}
+	lang := handler.GetLangByCtx(ctx)
+	ctx.Redirect(http.StatusFound, fmt.Sprintf("/50x?title=%s&msg=%s",
+		url.QueryEscape(translator.Tr(lang, reason.UserAccessDenied)), url.QueryEscape(translator.Tr(lang, myErr.Reason))))
+}
@@ -57,4 +57,15 @@ var ProviderSetController = wire.NewSet(
 	NewMCPController,
 	NewAIController,
 	NewAIConversationController,
+	NewBountyController,
+	NewFeedController,
+	NewHealthController,
+	NewGraphQLController,
+	NewDraftController,
+	NewUserMuteController,
+	NewGuestPostController,
+	NewImpersonationController,
+	NewPostLockController,
+	NewPostWikiController,
+	NewSavedSearchController,
 )
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/feed"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// feedCacheControl the feed readers poll the feed, let the proxies cache it for a while
+const feedCacheControl = "public, max-age=300"
+
+// FeedController rss and atom feed controller
+type FeedController struct {
+	feedService     *feed.FeedService
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewFeedController new controller
+func NewFeedController(
+	feedService *feed.FeedService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *FeedController {
+	return &FeedController{
+		feedService:     feedService,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// QuestionFeed the feed of the newest questions
+// @Summary the feed of the newest questions
+// @Description the feed of the newest questions in rss or atom format
+// @Tags Feed
+// @Produce xml
+// @Param format path string true "feed format" Enums(rss, atom)
+// @Success 200 {string} string ""
+// @Router /feed/{format}/questions [get]
+func (fc *FeedController) QuestionFeed(ctx *gin.Context) {
+	fc.renderFeed(ctx)
+}
+
+// TagFeed the feed of the newest questions of the tag
+// @Summary the feed of the newest questions of the tag
+// @Description the feed of the newest questions of the tag and its synonyms in rss or atom format
+// @Tags Feed
+// @Produce xml
+// @Param format path string true "feed format" Enums(rss, atom)
+// @Param tag path string true "tag slug name"
+// @Success 200 {string} string ""
+// @Router /feed/{format}/tags/{tag} [get]
+func (fc *FeedController) TagFeed(ctx *gin.Context) {
+	fc.renderFeed(ctx)
+}
+
+// UserFeed the feed of the newest questions and answers of the user
+// @Summary the feed of the newest questions and answers of the user
+// @Description the feed of the newest questions and answers of the user in rss or atom format
+// @Tags Feed
+// @Produce xml
+// @Param format path string true "feed format" Enums(rss, atom)
+// @Param username path string true "username"
+// @Success 200 {string} string ""
+// @Router /feed/{format}/users/{username} [get]
+func (fc *FeedController) UserFeed(ctx *gin.Context) {
+	fc.renderFeed(ctx)
+}
+
+func (fc *FeedController) renderFeed(ctx *gin.Context) {
+	if fc.checkPrivateMode(ctx) {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	req := &schema.GetFeedReq{}
+	if err := ctx.ShouldBindUri(req); err != nil {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if _, err := validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(req); err != nil {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	resp, err := fc.feedService.GetFeed(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	lastModified := resp.Updated.UTC().Truncate(time.Second)
+	if !lastModified.IsZero() {
+		if since, err := http.ParseTime(ctx.GetHeader("If-Modified-Since")); err == nil && !lastModified.After(since) {
+			ctx.Header("Cache-Control", feedCacheControl)
+			ctx.Status(http.StatusNotModified)
+			return
+		}
+		ctx.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	var (
+		content     []byte
+		contentType string
+	)
+	if req.Format == schema.FeedFormatAtom {
+		content, err = resp.Atom()
+		contentType = "application/atom+xml; charset=utf-8"
+	} else {
+		content, err = resp.RSS()
+		contentType = "application/rss+xml; charset=utf-8"
+	}
+	if err != nil {
+		log.Error(err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Header("Cache-Control", feedCacheControl)
+	ctx.Data(http.StatusOK, contentType, content)
+}
+
+func (fc *FeedController) checkPrivateMode(ctx *gin.Context) bool {
+	resp, err := fc.siteInfoService.GetSiteSecurity(ctx)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return resp.LoginRequired
+}
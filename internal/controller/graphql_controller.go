@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	graphqlresolver "github.com/apache/answer/internal/controller/graphql_resolver"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/comment"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/tag"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// GraphQLController the read-only graphql api
+type GraphQLController struct {
+	schema *graphql.Schema
+}
+
+// NewGraphQLController new graphql controller
+func NewGraphQLController(
+	questionService *content.QuestionService,
+	answerService *content.AnswerService,
+	commentService *comment.CommentService,
+	tagService *tag.TagService,
+	userService *content.UserService,
+	rankService *rank.RankService,
+) *GraphQLController {
+	resolver := graphqlresolver.NewResolver(questionService, answerService, commentService, tagService,
+		userService, rankService)
+	return &GraphQLController{
+		schema: graphql.MustParseSchema(graphqlresolver.Schema, resolver,
+			graphql.MaxDepth(constant.GraphQLMaxDepth),
+			graphql.MaxQueryLength(constant.GraphQLMaxQueryLength),
+		),
+	}
+}
+
+// Query execute the graphql query
+// @Summary execute the graphql query
+// @Description query the questions, answers, comments, tags and users with the fields that are needed.
+// @Description The query depth and the amount of the objects in the query are limited.
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.GraphQLReq true "graphql query"
+// @Success 200 {object} object "the graphql response with the data and the errors"
+// @Router /answer/api/v1/graphql [post]
+func (gc *GraphQLController) Query(ctx *gin.Context) {
+	req := &schema.GraphQLReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	queryCtx := graphqlresolver.NewQueryContext(ctx, middleware.GetLoginUserIDFromContext(ctx),
+		middleware.GetUserIsAdminModerator(ctx))
+	resp := gc.schema.Exec(queryCtx, req.Query, req.OperationName, req.Variables)
+	ctx.JSON(http.StatusOK, resp)
+}
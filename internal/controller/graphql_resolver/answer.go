@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type answerPageResolver struct {
+	total int64
+	list  []*answerResolver
+}
+
+func (p *answerPageResolver) Total() int32 {
+	return int32(p.total)
+}
+
+func (p *answerPageResolver) List() []*answerResolver {
+	return p.list
+}
+
+type answerResolver struct {
+	root *Resolver
+	info *schema.AnswerInfo
+}
+
+func (a *answerResolver) ID(ctx context.Context) graphql.ID {
+	return encodeID(ctx, a.info.ID)
+}
+
+func (a *answerResolver) Question(ctx context.Context) (*questionResolver, error) {
+	if err := consumeComplexity(ctx, 1); err != nil {
+		return nil, err
+	}
+	return &questionResolver{root: a.root, id: a.info.QuestionID}, nil
+}
+
+func (a *answerResolver) Content() string {
+	return a.info.Content
+}
+
+func (a *answerResolver) Html() string {
+	return a.info.HTML
+}
+
+func (a *answerResolver) Accepted() bool {
+	return a.info.Accepted == schema.AnswerAcceptedEnable
+}
+
+func (a *answerResolver) VoteCount() int32 {
+	return int32(a.info.VoteCount)
+}
+
+func (a *answerResolver) CreatedAt() graphql.Time {
+	return unixTime(a.info.CreateTime)
+}
+
+func (a *answerResolver) UpdatedAt() graphql.Time {
+	return unixTime(a.info.UpdateTime)
+}
+
+func (a *answerResolver) Author(ctx context.Context) (*userResolver, error) {
+	return newUserResolver(ctx, a.root, a.info.UserInfo)
+}
+
+func (a *answerResolver) Comments(ctx context.Context, args commentPageArgs) (*commentPageResolver, error) {
+	return a.root.commentPage(ctx, a.info.ID, args)
+}
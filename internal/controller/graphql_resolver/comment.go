@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type commentPageArgs struct {
+	pageArgs
+	Order *string
+}
+
+type commentPageResolver struct {
+	total int64
+	list  []*commentResolver
+}
+
+func (p *commentPageResolver) Total() int32 {
+	return int32(p.total)
+}
+
+func (p *commentPageResolver) List() []*commentResolver {
+	return p.list
+}
+
+// commentPage get the comments of the question or the answer
+func (r *Resolver) commentPage(ctx context.Context, objectID string, args commentPageArgs) (
+	*commentPageResolver, error) {
+	v := getViewer(ctx)
+	req := &schema.GetCommentWithPageReq{
+		ObjectID:         objectID,
+		UserID:           v.UserID,
+		IsAdminModerator: v.IsAdminModerator,
+	}
+	var err error
+	req.Page, req.PageSize, err = args.values(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if args.Order != nil {
+		req.QueryCond = *args.Order
+	}
+	if !isOneOf(req.QueryCond, "vote", "created_at") {
+		return nil, newQueryError(ctx, errors.BadRequest(reason.RequestFormatError))
+	}
+	pageModel, err := r.commentService.GetCommentWithPage(ctx, req)
+	if err != nil {
+		return nil, newQueryError(ctx, err)
+	}
+	comments, _ := pageModel.List.([]*schema.GetCommentResp)
+	resp := &commentPageResolver{total: pageModel.Count, list: make([]*commentResolver, 0, len(comments))}
+	for _, c := range comments {
+		resp.list = append(resp.list, &commentResolver{root: r, info: c})
+	}
+	return resp, nil
+}
+
+type commentResolver struct {
+	root *Resolver
+	info *schema.GetCommentResp
+}
+
+func (c *commentResolver) ID(ctx context.Context) graphql.ID {
+	return encodeID(ctx, c.info.CommentID)
+}
+
+func (c *commentResolver) Content() string {
+	return c.info.OriginalText
+}
+
+func (c *commentResolver) Html() string {
+	return c.info.ParsedText
+}
+
+func (c *commentResolver) VoteCount() int32 {
+	return int32(c.info.VoteCount)
+}
+
+func (c *commentResolver) CreatedAt() graphql.Time {
+	return unixTime(c.info.CreatedAt)
+}
+
+func (c *commentResolver) Author(ctx context.Context) (*userResolver, error) {
+	if len(c.info.UserID) == 0 {
+		return nil, nil
+	}
+	return newUserResolver(ctx, c.root, &schema.UserBasicInfo{
+		ID:          c.info.UserID,
+		Username:    c.info.Username,
+		DisplayName: c.info.UserDisplayName,
+		Avatar:      c.info.UserAvatar,
+		Status:      c.info.UserStatus,
+	})
+}
+
+func (c *commentResolver) ReplyTo(ctx context.Context) (*userResolver, error) {
+	if len(c.info.ReplyUserID) == 0 || c.info.ReplyUserID == "0" {
+		return nil, nil
+	}
+	return newUserResolver(ctx, c.root, &schema.UserBasicInfo{
+		ID:          c.info.ReplyUserID,
+		Username:    c.info.ReplyUsername,
+		DisplayName: c.info.ReplyUserDisplayName,
+		Status:      c.info.ReplyUserStatus,
+	})
+}
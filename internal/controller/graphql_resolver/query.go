@@ -0,0 +1,184 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// Question get the question by id, the hidden, pending and deleted questions are only visible to the users
+// who can see them on the question page.
+func (r *Resolver) Question(ctx context.Context, args struct{ ID graphql.ID }) (*questionResolver, error) {
+	if err := consumeComplexity(ctx, 1); err != nil {
+		return nil, err
+	}
+	q := &questionResolver{root: r, id: decodeID(args.ID)}
+	if _, err := q.getDetail(ctx); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Questions get the question list
+func (r *Resolver) Questions(ctx context.Context, args struct {
+	pageArgs
+	Order    *string
+	Tag      *string
+	Username *string
+}) (*questionPageResolver, error) {
+	req := &schema.QuestionPageReq{}
+	if args.Tag != nil {
+		req.Tag = *args.Tag
+	}
+	if args.Username != nil {
+		req.Username = *args.Username
+	}
+	return r.questionPage(ctx, req, args.pageArgs, args.Order)
+}
+
+func (r *Resolver) questionPage(ctx context.Context, req *schema.QuestionPageReq, page pageArgs, order *string) (
+	*questionPageResolver, error) {
+	var err error
+	req.Page, req.PageSize, err = page.values(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if order != nil {
+		req.OrderCond = *order
+	}
+	if !isOneOf(req.OrderCond, schema.QuestionOrderCondNewest, schema.QuestionOrderCondActive,
+		schema.QuestionOrderCondHot, schema.QuestionOrderCondScore, schema.QuestionOrderCondUnanswered,
+		schema.QuestionOrderCondFrequent, schema.QuestionOrderCondTrending) {
+		return nil, newQueryError(ctx, errors.BadRequest(reason.RequestFormatError))
+	}
+	req.LoginUserID = getViewer(ctx).UserID
+	questions, total, err := r.questionService.GetQuestionPage(ctx, req)
+	if err != nil {
+		return nil, newQueryError(ctx, err)
+	}
+	resp := &questionPageResolver{total: total, list: make([]*questionResolver, 0, len(questions))}
+	for _, question := range questions {
+		resp.list = append(resp.list, &questionResolver{root: r, id: question.ID, summary: question})
+	}
+	return resp, nil
+}
+
+// Answer get the answer by id
+func (r *Resolver) Answer(ctx context.Context, args struct{ ID graphql.ID }) (*answerResolver, error) {
+	if err := consumeComplexity(ctx, 1); err != nil {
+		return nil, err
+	}
+	v := getViewer(ctx)
+	info, _, exist, err := r.answerService.Get(ctx, decodeID(args.ID), v.UserID, v.IsAdminModerator)
+	if err != nil {
+		return nil, newQueryError(ctx, err)
+	}
+	if !exist {
+		return nil, newQueryError(ctx, errors.NotFound(reason.AnswerNotFound))
+	}
+	return &answerResolver{root: r, info: info}, nil
+}
+
+// Tag get the tag by the slug name
+func (r *Resolver) Tag(ctx context.Context, args struct{ Name string }) (*tagResolver, error) {
+	if err := consumeComplexity(ctx, 1); err != nil {
+		return nil, err
+	}
+	t := &tagResolver{root: r, slugName: args.Name}
+	if _, err := t.getDetail(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Tags get the tag list
+func (r *Resolver) Tags(ctx context.Context, args struct {
+	pageArgs
+	Order    *string
+	SlugName *string
+}) (*tagPageResolver, error) {
+	req := &schema.GetTagWithPageReq{UserID: getViewer(ctx).UserID}
+	var err error
+	req.Page, req.PageSize, err = args.values(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if args.Order != nil {
+		req.QueryCond = *args.Order
+	}
+	if !isOneOf(req.QueryCond, "popular", "name", "newest") {
+		return nil, newQueryError(ctx, errors.BadRequest(reason.RequestFormatError))
+	}
+	if args.SlugName != nil {
+		req.SlugName = *args.SlugName
+	}
+	pageModel, err := r.tagService.GetTagWithPage(ctx, req)
+	if err != nil {
+		return nil, newQueryError(ctx, err)
+	}
+	tags, _ := pageModel.List.([]*schema.GetTagPageResp)
+	resp := &tagPageResolver{total: pageModel.Count, list: make([]*tagResolver, 0, len(tags))}
+	for _, t := range tags {
+		resp.list = append(resp.list, newTagResolverFromPage(r, t))
+	}
+	return resp, nil
+}
+
+// User get the user by the username
+func (r *Resolver) User(ctx context.Context, args struct{ Username string }) (*userResolver, error) {
+	if err := consumeComplexity(ctx, 1); err != nil {
+		return nil, err
+	}
+	u := &userResolver{root: r, basic: &schema.UserBasicInfo{Username: args.Username}}
+	if _, err := u.getDetail(ctx); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// getQuestionPermission the permission that decides whether the login user can see the deleted questions
+func (r *Resolver) getQuestionPermission(ctx context.Context) (per schema.QuestionPermission, err error) {
+	v := getViewer(ctx)
+	per.IsAdminModerator = v.IsAdminModerator
+	canList, err := r.rankService.CheckOperationPermissions(ctx, v.UserID, []string{permission.QuestionReopen})
+	if err != nil {
+		return per, err
+	}
+	per.CanReopen = canList[0]
+	return per, nil
+}
+
+func isOneOf(value string, options ...string) bool {
+	if len(value) == 0 {
+		return true
+	}
+	for _, option := range options {
+		if value == option {
+			return true
+		}
+	}
+	return false
+}
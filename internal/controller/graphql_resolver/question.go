@@ -0,0 +1,276 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type questionPageResolver struct {
+	total int64
+	list  []*questionResolver
+}
+
+func (p *questionPageResolver) Total() int32 {
+	return int32(p.total)
+}
+
+func (p *questionPageResolver) List() []*questionResolver {
+	return p.list
+}
+
+// questionResolver the summary of the question is got from the list, the detail is only loaded
+// when the fields that are not in the summary are queried.
+type questionResolver struct {
+	root    *Resolver
+	id      string
+	summary *schema.QuestionPageResp
+
+	once   sync.Once
+	detail *schema.QuestionInfoResp
+	err    error
+}
+
+func (q *questionResolver) getDetail(ctx context.Context) (*schema.QuestionInfoResp, error) {
+	q.once.Do(func() {
+		per, err := q.root.getQuestionPermission(ctx)
+		if err != nil {
+			q.err = newQueryError(ctx, err)
+			return
+		}
+		q.detail, err = q.root.questionService.GetQuestion(ctx, q.id, getViewer(ctx).UserID, per)
+		if err != nil {
+			q.err = newQueryError(ctx, err)
+		}
+	})
+	return q.detail, q.err
+}
+
+func (q *questionResolver) ID(ctx context.Context) graphql.ID {
+	return encodeID(ctx, q.id)
+}
+
+func (q *questionResolver) Title(ctx context.Context) (string, error) {
+	if q.summary != nil {
+		return q.summary.Title, nil
+	}
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Title, nil
+}
+
+func (q *questionResolver) UrlTitle(ctx context.Context) (string, error) {
+	if q.summary != nil {
+		return q.summary.UrlTitle, nil
+	}
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.UrlTitle, nil
+}
+
+func (q *questionResolver) Description(ctx context.Context) (string, error) {
+	if q.summary != nil {
+		return q.summary.Description, nil
+	}
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Description, nil
+}
+
+func (q *questionResolver) Content(ctx context.Context) (string, error) {
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Content, nil
+}
+
+func (q *questionResolver) Html(ctx context.Context) (string, error) {
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.HTML, nil
+}
+
+func (q *questionResolver) Status(ctx context.Context) (string, error) {
+	if q.summary != nil {
+		return entity.AdminQuestionSearchStatusIntToString[q.summary.Status], nil
+	}
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return entity.AdminQuestionSearchStatusIntToString[detail.Status], nil
+}
+
+// counts get the counts of the question from the summary or the detail
+func (q *questionResolver) counts(ctx context.Context) (view, vote, answer, collection, follow int, err error) {
+	if q.summary != nil {
+		s := q.summary
+		return s.ViewCount, s.VoteCount, s.AnswerCount, s.CollectionCount, s.FollowCount, nil
+	}
+	d, err := q.getDetail(ctx)
+	if err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+	return d.ViewCount, d.VoteCount, d.AnswerCount, d.CollectionCount, d.FollowCount, nil
+}
+
+func (q *questionResolver) ViewCount(ctx context.Context) (int32, error) {
+	count, _, _, _, _, err := q.counts(ctx)
+	return int32(count), err
+}
+
+func (q *questionResolver) VoteCount(ctx context.Context) (int32, error) {
+	_, count, _, _, _, err := q.counts(ctx)
+	return int32(count), err
+}
+
+func (q *questionResolver) AnswerCount(ctx context.Context) (int32, error) {
+	_, _, count, _, _, err := q.counts(ctx)
+	return int32(count), err
+}
+
+func (q *questionResolver) CollectionCount(ctx context.Context) (int32, error) {
+	_, _, _, count, _, err := q.counts(ctx)
+	return int32(count), err
+}
+
+func (q *questionResolver) FollowCount(ctx context.Context) (int32, error) {
+	_, _, _, _, count, err := q.counts(ctx)
+	return int32(count), err
+}
+
+func (q *questionResolver) AcceptedAnswerId(ctx context.Context) (*graphql.ID, error) {
+	acceptedAnswerID := ""
+	if q.summary != nil {
+		acceptedAnswerID = q.summary.AcceptedAnswerID
+	} else {
+		detail, err := q.getDetail(ctx)
+		if err != nil {
+			return nil, err
+		}
+		acceptedAnswerID = detail.AcceptedAnswerID
+	}
+	if len(acceptedAnswerID) == 0 || acceptedAnswerID == "0" {
+		return nil, nil
+	}
+	id := encodeID(ctx, acceptedAnswerID)
+	return &id, nil
+}
+
+func (q *questionResolver) CreatedAt(ctx context.Context) (graphql.Time, error) {
+	if q.summary != nil {
+		return unixTime(q.summary.CreatedAt), nil
+	}
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return graphql.Time{}, err
+	}
+	return unixTime(detail.CreateTime), nil
+}
+
+func (q *questionResolver) UpdatedAt(ctx context.Context) (graphql.Time, error) {
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return graphql.Time{}, err
+	}
+	return unixTime(detail.PostUpdateTime), nil
+}
+
+func (q *questionResolver) Tags(ctx context.Context) ([]*tagResolver, error) {
+	tags := make([]*schema.TagResp, 0)
+	if q.summary != nil {
+		tags = q.summary.Tags
+	} else {
+		detail, err := q.getDetail(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tags = detail.Tags
+	}
+	if err := consumeComplexity(ctx, len(tags)); err != nil {
+		return nil, err
+	}
+	resp := make([]*tagResolver, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, newTagResolver(q.root, t))
+	}
+	return resp, nil
+}
+
+func (q *questionResolver) Author(ctx context.Context) (*userResolver, error) {
+	detail, err := q.getDetail(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newUserResolver(ctx, q.root, detail.UserInfo)
+}
+
+func (q *questionResolver) Answers(ctx context.Context, args struct {
+	pageArgs
+	Order *string
+}) (*answerPageResolver, error) {
+	v := getViewer(ctx)
+	req := &schema.AnswerListReq{
+		QuestionID:       q.id,
+		UserID:           v.UserID,
+		IsAdminModerator: v.IsAdminModerator,
+	}
+	var err error
+	req.Page, req.PageSize, err = args.values(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if args.Order != nil {
+		req.Order = *args.Order
+	}
+	if !isOneOf(req.Order, entity.AnswerSearchOrderByDefault, entity.AnswerSearchOrderByVote,
+		entity.AnswerSearchOrderByTime, entity.AnswerSearchOrderByTimeAsc) {
+		return nil, newQueryError(ctx, errors.BadRequest(reason.RequestFormatError))
+	}
+	answers, total, err := q.root.answerService.SearchList(ctx, req)
+	if err != nil {
+		return nil, newQueryError(ctx, err)
+	}
+	resp := &answerPageResolver{total: total, list: make([]*answerResolver, 0, len(answers))}
+	for _, answer := range answers {
+		resp.list = append(resp.list, &answerResolver{root: q.root, info: answer})
+	}
+	return resp, nil
+}
+
+func (q *questionResolver) Comments(ctx context.Context, args commentPageArgs) (*commentPageResolver, error) {
+	return q.root.commentPage(ctx, q.id, args)
+}
@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+	_ "embed"
+	stderrors "errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/service/comment"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/tag"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// Schema the graphql schema of the read-only api
+//
+//go:embed schema.graphql
+var Schema string
+
+type viewerContextKey struct{}
+
+type complexityContextKey struct{}
+
+// viewer the login user who makes the query, the user id is empty if the query is made by a guest
+type viewer struct {
+	UserID           string
+	IsAdminModerator bool
+}
+
+// Resolver the root resolver of the graphql schema, all the data is read through the services
+// so that the permissions are checked in the same way as the rest api.
+type Resolver struct {
+	questionService *content.QuestionService
+	answerService   *content.AnswerService
+	commentService  *comment.CommentService
+	tagService      *tag.TagService
+	userService     *content.UserService
+	rankService     *rank.RankService
+}
+
+// NewResolver new root resolver
+func NewResolver(
+	questionService *content.QuestionService,
+	answerService *content.AnswerService,
+	commentService *comment.CommentService,
+	tagService *tag.TagService,
+	userService *content.UserService,
+	rankService *rank.RankService,
+) *Resolver {
+	return &Resolver{
+		questionService: questionService,
+		answerService:   answerService,
+		commentService:  commentService,
+		tagService:      tagService,
+		userService:     userService,
+		rankService:     rankService,
+	}
+}
+
+// NewQueryContext set the login user and the complexity budget of the query to the context.
+// The language and the short id flag of the request are kept for the services, because the
+// resolvers get the context derived from the gin context.
+func NewQueryContext(ctx context.Context, userID string, isAdminModerator bool) context.Context {
+	lang, enableShortID := handler.GetLangByCtx(ctx), handler.GetEnableShortID(ctx)
+	ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, lang)
+	ctx = context.WithValue(ctx, constant.ShortIDContextKey, enableShortID)
+	ctx = context.WithValue(ctx, viewerContextKey{}, &viewer{UserID: userID, IsAdminModerator: isAdminModerator})
+	budget := &atomic.Int64{}
+	budget.Store(constant.GraphQLMaxComplexity)
+	return context.WithValue(ctx, complexityContextKey{}, budget)
+}
+
+func getViewer(ctx context.Context) *viewer {
+	v, ok := ctx.Value(viewerContextKey{}).(*viewer)
+	if !ok {
+		return &viewer{}
+	}
+	return v
+}
+
+// consumeComplexity every object the query resolves costs the complexity budget of the query,
+// the lists cost as many as their page size. The query fails when the budget is used up.
+func consumeComplexity(ctx context.Context, cost int) error {
+	budget, ok := ctx.Value(complexityContextKey{}).(*atomic.Int64)
+	if !ok {
+		return nil
+	}
+	if budget.Add(-int64(cost)) < 0 {
+		return newQueryError(ctx, errors.BadRequest(reason.GraphQLQueryTooComplex))
+	}
+	return nil
+}
+
+// pageArgs the pagination arguments of the lists
+type pageArgs struct {
+	Page     *int32
+	PageSize *int32
+}
+
+// values get the page and the page size, the page size is limited, and the cost is consumed
+func (p pageArgs) values(ctx context.Context) (page, pageSize int, err error) {
+	page, pageSize = 1, constant.GraphQLDefaultPageSize
+	if p.Page != nil && *p.Page > 0 {
+		page = int(*p.Page)
+	}
+	if p.PageSize != nil && *p.PageSize > 0 {
+		pageSize = min(int(*p.PageSize), constant.GraphQLMaxPageSize)
+	}
+	return page, pageSize, consumeComplexity(ctx, pageSize)
+}
+
+// queryError the error in the graphql response, the message is translated as the rest api does
+type queryError struct {
+	code    int
+	reason  string
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+// Extensions the code and the reason are the same as the ones in the response of the rest api
+func (e *queryError) Extensions() map[string]any {
+	return map[string]any{"code": e.code, "reason": e.reason}
+}
+
+func newQueryError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	lang := handler.GetLangByCtx(ctx)
+	var myErr *errors.Error
+	if !stderrors.As(err, &myErr) {
+		log.Error(err)
+		return &queryError{code: http.StatusInternalServerError, reason: reason.UnknownError,
+			message: translator.Tr(lang, reason.UnknownError)}
+	}
+	if errors.IsInternalServer(myErr) {
+		log.Error(myErr)
+	}
+	message := myErr.Message
+	if len(message) == 0 {
+		message = translator.Tr(lang, myErr.Reason)
+	}
+	return &queryError{code: myErr.Code, reason: myErr.Reason, message: message}
+}
+
+// encodeID the ids in the response are short ids if the short id is enabled
+func encodeID(ctx context.Context, id string) graphql.ID {
+	if handler.GetEnableShortID(ctx) {
+		return graphql.ID(uid.EnShortID(id))
+	}
+	return graphql.ID(id)
+}
+
+func decodeID(id graphql.ID) string {
+	return uid.DeShortID(string(id))
+}
+
+func unixTime(t int64) graphql.Time {
+	return graphql.Time{Time: time.Unix(t, 0)}
+}
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSchema(t *testing.T) *graphql.Schema {
+	s, err := graphql.ParseSchema(Schema, NewResolver(nil, nil, nil, nil, nil, nil),
+		graphql.MaxDepth(constant.GraphQLMaxDepth))
+	require.NoError(t, err)
+	return s
+}
+
+func TestSchema_MaxDepth(t *testing.T) {
+	s := newTestSchema(t)
+	query := `{ questions { list { answers { list { comments { list { author { questions { list {
+		tags { questions { list { id } } } } } } } } } } } } }`
+	resp := s.Exec(NewQueryContext(context.TODO(), "", false), query, "", nil)
+	require.NotEmpty(t, resp.Errors)
+	assert.Contains(t, resp.Errors[0].Message, "exceeds max depth")
+}
+
+func TestSchema_NoMutation(t *testing.T) {
+	s := newTestSchema(t)
+	resp := s.Exec(NewQueryContext(context.TODO(), "", false), `mutation { question(id: "1") { id } }`, "", nil)
+	assert.NotEmpty(t, resp.Errors)
+}
+
+func Test_consumeComplexity(t *testing.T) {
+	ctx := NewQueryContext(context.TODO(), "", false)
+	require.NoError(t, consumeComplexity(ctx, constant.GraphQLMaxComplexity-1))
+	require.NoError(t, consumeComplexity(ctx, 1))
+	assert.Error(t, consumeComplexity(ctx, 1))
+
+	// the context without the budget is not limited
+	assert.NoError(t, consumeComplexity(context.TODO(), constant.GraphQLMaxComplexity+1))
+}
+
+func Test_pageArgs_values(t *testing.T) {
+	ctx := NewQueryContext(context.TODO(), "", false)
+	page, pageSize, err := pageArgs{}.values(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, constant.GraphQLDefaultPageSize, pageSize)
+
+	p, size := int32(3), int32(1000)
+	page, pageSize, err = pageArgs{Page: &p, PageSize: &size}.values(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, constant.GraphQLMaxPageSize, pageSize)
+}
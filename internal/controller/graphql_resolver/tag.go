@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/answer/internal/schema"
+)
+
+type tagPageResolver struct {
+	total int64
+	list  []*tagResolver
+}
+
+func (p *tagPageResolver) Total() int32 {
+	return int32(p.total)
+}
+
+func (p *tagPageResolver) List() []*tagResolver {
+	return p.list
+}
+
+// tagResolver the basic info of the tag is got from the question or the list,
+// the detail is only loaded when the other fields are queried.
+type tagResolver struct {
+	root        *Resolver
+	slugName    string
+	displayName string
+	recommend   bool
+	reserved    bool
+	mainTag     string
+
+	once   sync.Once
+	detail *schema.GetTagResp
+	err    error
+}
+
+func newTagResolver(root *Resolver, t *schema.TagResp) *tagResolver {
+	return &tagResolver{
+		root:        root,
+		slugName:    t.SlugName,
+		displayName: t.DisplayName,
+		recommend:   t.Recommend,
+		reserved:    t.Reserved,
+		mainTag:     t.MainTagSlugName,
+	}
+}
+
+func newTagResolverFromPage(root *Resolver, t *schema.GetTagPageResp) *tagResolver {
+	resp := &tagResolver{
+		root:        root,
+		slugName:    t.SlugName,
+		displayName: t.DisplayName,
+		recommend:   t.Recommend,
+		reserved:    t.Reserved,
+		detail: &schema.GetTagResp{
+			TagID:         t.TagID,
+			SlugName:      t.SlugName,
+			DisplayName:   t.DisplayName,
+			Excerpt:       t.Excerpt,
+			OriginalText:  t.OriginalText,
+			ParsedText:    t.ParsedText,
+			Description:   t.Description,
+			FollowCount:   t.FollowCount,
+			QuestionCount: t.QuestionCount,
+			Recommend:     t.Recommend,
+			Reserved:      t.Reserved,
+		},
+	}
+	// the detail is already got from the list
+	resp.once.Do(func() {})
+	return resp
+}
+
+func (t *tagResolver) getDetail(ctx context.Context) (*schema.GetTagResp, error) {
+	t.once.Do(func() {
+		req := &schema.GetTagInfoReq{Name: t.slugName, UserID: getViewer(ctx).UserID}
+		var err error
+		t.detail, err = t.root.tagService.GetTagInfo(ctx, req)
+		if err != nil {
+			t.err = newQueryError(ctx, err)
+			return
+		}
+		t.slugName = t.detail.SlugName
+		t.displayName = t.detail.DisplayName
+		t.recommend = t.detail.Recommend
+		t.reserved = t.detail.Reserved
+		t.mainTag = t.detail.MainTagSlugName
+	})
+	return t.detail, t.err
+}
+
+func (t *tagResolver) SlugName() string {
+	return t.slugName
+}
+
+func (t *tagResolver) DisplayName() string {
+	return t.displayName
+}
+
+func (t *tagResolver) Description(ctx context.Context) (string, error) {
+	detail, err := t.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Description, nil
+}
+
+func (t *tagResolver) Content(ctx context.Context) (string, error) {
+	detail, err := t.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.OriginalText, nil
+}
+
+func (t *tagResolver) Html(ctx context.Context) (string, error) {
+	detail, err := t.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.ParsedText, nil
+}
+
+func (t *tagResolver) FollowCount(ctx context.Context) (int32, error) {
+	detail, err := t.getDetail(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(detail.FollowCount), nil
+}
+
+func (t *tagResolver) QuestionCount(ctx context.Context) (int32, error) {
+	detail, err := t.getDetail(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(detail.QuestionCount), nil
+}
+
+func (t *tagResolver) Recommend() bool {
+	return t.recommend
+}
+
+func (t *tagResolver) Reserved() bool {
+	return t.reserved
+}
+
+func (t *tagResolver) MainTagSlugName() *string {
+	if len(t.mainTag) == 0 {
+		return nil
+	}
+	return &t.mainTag
+}
+
+func (t *tagResolver) Questions(ctx context.Context, args struct {
+	pageArgs
+	Order *string
+}) (*questionPageResolver, error) {
+	return t.root.questionPage(ctx, &schema.QuestionPageReq{Tag: t.slugName}, args.pageArgs, args.Order)
+}
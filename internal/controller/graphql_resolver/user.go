@@ -0,0 +1,185 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package graphqlresolver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// userResolver the basic info of the user is got from the posts,
+// the profile is only loaded when the other fields are queried.
+type userResolver struct {
+	root  *Resolver
+	basic *schema.UserBasicInfo
+
+	once   sync.Once
+	detail *schema.GetOtherUserInfoByUsernameResp
+	err    error
+}
+
+func newUserResolver(ctx context.Context, root *Resolver, basic *schema.UserBasicInfo) (*userResolver, error) {
+	if basic == nil || len(basic.Username) == 0 {
+		return nil, nil
+	}
+	if err := consumeComplexity(ctx, 1); err != nil {
+		return nil, err
+	}
+	return &userResolver{root: root, basic: basic}, nil
+}
+
+func (u *userResolver) getDetail(ctx context.Context) (*schema.GetOtherUserInfoByUsernameResp, error) {
+	u.once.Do(func() {
+		v := getViewer(ctx)
+		req := &schema.GetOtherUserInfoByUsernameReq{
+			Username: u.basic.Username,
+			UserID:   v.UserID,
+			IsAdmin:  v.IsAdminModerator,
+		}
+		var err error
+		u.detail, err = u.root.userService.GetOtherUserInfoByUsername(ctx, req)
+		if err != nil {
+			u.err = newQueryError(ctx, err)
+		}
+	})
+	return u.detail, u.err
+}
+
+func (u *userResolver) ID(ctx context.Context) (graphql.ID, error) {
+	if len(u.basic.ID) > 0 {
+		return graphql.ID(u.basic.ID), nil
+	}
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return graphql.ID(detail.ID), nil
+}
+
+func (u *userResolver) Username() string {
+	return u.basic.Username
+}
+
+func (u *userResolver) DisplayName(ctx context.Context) (string, error) {
+	if len(u.basic.DisplayName) > 0 {
+		return u.basic.DisplayName, nil
+	}
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.DisplayName, nil
+}
+
+func (u *userResolver) Avatar(ctx context.Context) (string, error) {
+	if len(u.basic.Avatar) > 0 {
+		return u.basic.Avatar, nil
+	}
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Avatar, nil
+}
+
+func (u *userResolver) Status(ctx context.Context) (string, error) {
+	if len(u.basic.Status) > 0 {
+		return u.basic.Status, nil
+	}
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Status, nil
+}
+
+func (u *userResolver) Rank(ctx context.Context) (int32, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(detail.Rank), nil
+}
+
+func (u *userResolver) Bio(ctx context.Context) (string, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.BioHTML, nil
+}
+
+func (u *userResolver) Website(ctx context.Context) (string, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Website, nil
+}
+
+func (u *userResolver) Location(ctx context.Context) (string, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return detail.Location, nil
+}
+
+func (u *userResolver) QuestionCount(ctx context.Context) (int32, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(detail.QuestionCount), nil
+}
+
+func (u *userResolver) AnswerCount(ctx context.Context) (int32, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(detail.AnswerCount), nil
+}
+
+func (u *userResolver) FollowCount(ctx context.Context) (int32, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(detail.FollowCount), nil
+}
+
+func (u *userResolver) CreatedAt(ctx context.Context) (graphql.Time, error) {
+	detail, err := u.getDetail(ctx)
+	if err != nil {
+		return graphql.Time{}, err
+	}
+	return unixTime(detail.CreatedAt), nil
+}
+
+func (u *userResolver) Questions(ctx context.Context, args struct {
+	pageArgs
+	Order *string
+}) (*questionPageResolver, error) {
+	return u.root.questionPage(ctx, &schema.QuestionPageReq{Username: u.basic.Username}, args.pageArgs, args.Order)
+}
@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/action"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/guest_post"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// GuestPostController guest post controller
+type GuestPostController struct {
+	guestPostService *guest_post.GuestPostService
+	questionService  *content.QuestionService
+	actionService    *action.CaptchaService
+}
+
+// NewGuestPostController new controller
+func NewGuestPostController(
+	guestPostService *guest_post.GuestPostService,
+	questionService *content.QuestionService,
+	actionService *action.CaptchaService,
+) *GuestPostController {
+	return &GuestPostController{
+		guestPostService: guestPostService,
+		questionService:  questionService,
+		actionService:    actionService,
+	}
+}
+
+// AddGuestQuestion add question as a guest
+// @Summary add question as a guest
+// @Description add question without logging in, the captcha is required and the question is held for review.
+// @Description The guest is sent an email to claim the question when it is approved if the email is given.
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Param data body schema.AddGuestQuestionReq true "question"
+// @Success 200 {object} handler.RespBody{data=schema.AddGuestQuestionResp}
+// @Router /answer/api/v1/question/guest [post]
+func (gc *GuestPostController) AddGuestQuestion(ctx *gin.Context) {
+	req := &schema.AddGuestQuestionReq{}
+	errFields := handler.BindAndCheckReturnErr(ctx, req)
+	if ctx.IsAborted() {
+		return
+	}
+	if err := gc.guestPostService.CheckGuestPostingEnabled(ctx); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	req.IP = ctx.ClientIP()
+	req.UserAgent = ctx.GetHeader("User-Agent")
+	captchaPass := gc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionGuestQuestion, req.IP,
+		req.CaptchaID, req.CaptchaCode)
+	if !captchaPass {
+		errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+			ErrorField: "captcha_code",
+			ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.CaptchaVerificationFailed),
+		})
+		handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
+		return
+	}
+	if captchaPass, failReason := gc.actionService.VerifyCaptchaToken(ctx, constant.CaptchaSceneGuestPost, "",
+		req.CaptchaToken, req.IP); !captchaPass {
+		errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+			ErrorField: "captcha_token",
+			ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), failReason),
+		})
+		handler.HandleResponse(ctx, errors.BadRequest(failReason), errFields)
+		return
+	}
+
+	guestUserID, err := gc.guestPostService.GetGuestUserID(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	// the guests can only use the existing tags that are not reserved
+	questionReq := &schema.QuestionAdd{
+		Title:      req.Title,
+		Content:    req.Content,
+		HTML:       req.HTML,
+		Tags:       req.Tags,
+		UserID:     guestUserID,
+		IP:         req.IP,
+		UserAgent:  req.UserAgent,
+		IsGuest:    true,
+		GuestEmail: req.Email,
+	}
+	questionReq.CanAdd = true
+	hasNewTag, err := gc.questionService.HasNewTag(ctx, questionReq.Tags)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if hasNewTag {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.NoEnoughRankToOperate), nil)
+		return
+	}
+
+	errList, err := gc.questionService.CheckAddQuestion(ctx, questionReq)
+	if err != nil {
+		errlist, ok := errList.([]*validator.FormErrorField)
+		if ok {
+			errFields = append(errFields, errlist...)
+		}
+	}
+	if len(errFields) > 0 {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), errFields)
+		return
+	}
+
+	resp, err := gc.questionService.AddQuestion(ctx, questionReq)
+	if err != nil {
+		errlist, ok := resp.([]*validator.FormErrorField)
+		if ok {
+			errFields = append(errFields, errlist...)
+		}
+	}
+	if len(errFields) > 0 {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), errFields)
+		return
+	}
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	info, ok := resp.(*schema.QuestionInfoResp)
+	if !ok {
+		handler.HandleResponse(ctx, nil, nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, &schema.AddGuestQuestionResp{
+		ID:      info.ID,
+		Pending: info.Status == entity.QuestionStatusPending,
+	})
+}
+
+// ClaimGuestQuestion claim the question asked as a guest
+// @Summary claim the question asked as a guest
+// @Description the question is reassigned to the login user by the code in the email sent to the guest
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ClaimGuestQuestionReq true "claim"
+// @Success 200 {object} handler.RespBody{data=schema.ClaimGuestQuestionResp}
+// @Router /answer/api/v1/question/guest/claim [post]
+func (gc *GuestPostController) ClaimGuestQuestion(ctx *gin.Context) {
+	req := &schema.ClaimGuestQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := gc.guestPostService.ClaimQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/apache/answer/internal/service/health"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthController liveness and readiness probe controller
+type HealthController struct {
+	healthService *health.HealthService
+}
+
+// NewHealthController new controller
+func NewHealthController(healthService *health.HealthService) *HealthController {
+	return &HealthController{healthService: healthService}
+}
+
+// Healthz liveness probe
+// @Summary liveness probe
+// @Description returns 200 if the process is alive, the dependencies are not checked
+// @Tags Health
+// @Produce plain
+// @Success 200 {string} string "OK"
+// @Router /healthz [get]
+func (hc *HealthController) Healthz(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "OK")
+}
+
+// Readyz readiness probe
+// @Summary readiness probe
+// @Description check the database, the cache and the search backend, returns 503 with the status of each of them if not ready
+// @Tags Health
+// @Produce json
+// @Success 200 {object} schema.ReadinessResp
+// @Failure 503 {object} schema.ReadinessResp
+// @Router /readyz [get]
+func (hc *HealthController) Readyz(ctx *gin.Context) {
+	resp := hc.healthService.CheckReadiness(ctx)
+	status := http.StatusOK
+	if !resp.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(status, resp)
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/impersonation"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ImpersonationController impersonation controller
+type ImpersonationController struct {
+	impersonationService *impersonation.ImpersonationService
+}
+
+// NewImpersonationController new impersonation controller
+func NewImpersonationController(impersonationService *impersonation.ImpersonationService) *ImpersonationController {
+	return &ImpersonationController{
+		impersonationService: impersonationService,
+	}
+}
+
+// StartImpersonation view as the user
+// @Summary view as the user
+// @Description start a session of the user for the admin, the access token of it is used instead of the admin one
+// @Description until the impersonation is stopped. The session is read-only unless the site security config allows
+// @Description the full access, and the sensitive self-service actions are always forbidden.
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.StartImpersonationReq true "user"
+// @Success 200 {object} handler.RespBody{data=schema.StartImpersonationResp}
+// @Router /answer/admin/api/user/impersonation [post]
+func (ic *ImpersonationController) StartImpersonation(ctx *gin.Context) {
+	req := &schema.StartImpersonationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.AdminUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := ic.impersonationService.StartImpersonation(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// StopImpersonation stop viewing as the user
+// @Summary stop viewing as the user
+// @Description stop the impersonation session, the access token of it can not be used anymore
+// @Security ApiKeyAuth
+// @Tags User
+// @Produce json
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/impersonation/stop [post]
+func (ic *ImpersonationController) StopImpersonation(ctx *gin.Context) {
+	userInfo := middleware.GetUserInfoFromContext(ctx)
+	if userInfo == nil {
+		handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+		return
+	}
+	req := &schema.StopImpersonationReq{
+		AccessToken:    middleware.ExtractToken(ctx),
+		VisitToken:     userInfo.VisitToken,
+		UserID:         userInfo.UserID,
+		ImpersonatorID: userInfo.ImpersonatorID,
+		Mode:           userInfo.ImpersonationMode,
+	}
+
+	err := ic.impersonationService.StopImpersonation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/post_lock"
+	"github.com/apache/answer/internal/service/tag_moderator"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PostLockController post lock controller
+type PostLockController struct {
+	postLockService     *post_lock.PostLockService
+	tagModeratorService *tag_moderator.TagModeratorService
+}
+
+// NewPostLockController new controller
+func NewPostLockController(
+	postLockService *post_lock.PostLockService,
+	tagModeratorService *tag_moderator.TagModeratorService,
+) *PostLockController {
+	return &PostLockController{
+		postLockService:     postLockService,
+		tagModeratorService: tagModeratorService,
+	}
+}
+
+// LockPost lock the question or the answer
+// @Summary lock the question or the answer
+// @Description the locked post is still readable but it can not be answered, commented on or edited,
+// @Description the votes are also locked if lock_vote is true. Only for the admins, the moderators
+// @Description and the moderators of the tags of the question.
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.LockPostReq true "lock"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/post/lock [post]
+func (pc *PostLockController) LockPost(ctx *gin.Context) {
+	req := &schema.LockPostReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !middleware.GetUserIsAdminModerator(ctx) &&
+		!pc.tagModeratorService.IsObjectModerator(ctx, req.UserID, req.ObjectID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+
+	err := pc.postLockService.LockPost(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UnlockPost unlock the question or the answer
+// @Summary unlock the question or the answer
+// @Description only for the admins, the moderators and the moderators of the tags of the question
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UnlockPostReq true "unlock"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/post/lock [delete]
+func (pc *PostLockController) UnlockPost(ctx *gin.Context) {
+	req := &schema.UnlockPostReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !middleware.GetUserIsAdminModerator(ctx) &&
+		!pc.tagModeratorService.IsObjectModerator(ctx, req.UserID, req.ObjectID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+
+	err := pc.postLockService.UnlockPost(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/post_wiki"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PostWikiController post wiki controller
+type PostWikiController struct {
+	postWikiService *post_wiki.PostWikiService
+}
+
+// NewPostWikiController new controller
+func NewPostWikiController(postWikiService *post_wiki.PostWikiService) *PostWikiController {
+	return &PostWikiController{postWikiService: postWikiService}
+}
+
+// UpdatePostWiki convert the question or the answer to the community wiki post
+// @Summary convert the question or the answer to the community wiki post
+// @Description the admins and the moderators can convert the post to the community wiki post,
+// @Description only the admins can convert it back.
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdatePostWikiReq true "wiki"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/post/wiki [put]
+func (pc *PostWikiController) UpdatePostWiki(ctx *gin.Context) {
+	req := &schema.UpdatePostWikiReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
+
+	err := pc.postWikiService.UpdatePostWiki(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
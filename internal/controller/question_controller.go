@@ -22,6 +22,7 @@ package controller
 import (
 	"net/http"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/middleware"
 	"github.com/apache/answer/internal/base/pager"
@@ -33,8 +34,11 @@ import (
 	"github.com/apache/answer/internal/service/action"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/post_lock"
+	"github.com/apache/answer/internal/service/posting_restriction"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/obj"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/copier"
@@ -49,6 +53,8 @@ type QuestionController struct {
 	siteInfoService     siteinfo_common.SiteInfoCommonService
 	actionService       *action.CaptchaService
 	rateLimitMiddleware *middleware.RateLimitMiddleware
+	postingRestriction  *posting_restriction.PostingRestrictionService
+	postLockService     *post_lock.PostLockService
 }
 
 // NewQuestionController new controller
@@ -59,6 +65,8 @@ func NewQuestionController(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	postingRestriction *posting_restriction.PostingRestrictionService,
+	postLockService *post_lock.PostLockService,
 ) *QuestionController {
 	return &QuestionController{
 		questionService:     questionService,
@@ -67,6 +75,8 @@ func NewQuestionController(
 		siteInfoService:     siteInfoService,
 		actionService:       actionService,
 		rateLimitMiddleware: rateLimitMiddleware,
+		postingRestriction:  postingRestriction,
+		postLockService:     postLockService,
 	}
 }
 
@@ -119,7 +129,7 @@ func (qc *QuestionController) RemoveQuestion(ctx *gin.Context) {
 
 // OperationQuestion Operation question
 // @Summary Operation question
-// @Description Operation question \n operation [pin unpin hide show]
+// @Description Operation question \n operation [pin unpin hide show] \n pin or unpin within the tag if the tag is set
 // @Tags Question
 // @Accept json
 // @Produce json
@@ -146,6 +156,10 @@ func (qc *QuestionController) OperationQuestion(ctx *gin.Context) {
 	}
 	req.CanPin = canList[0]
 	req.CanList = canList[1]
+	// the tag moderators can only pin the question within the tag they moderate, not globally
+	if !req.CanPin && len(req.Tag) > 0 {
+		req.CanPin = qc.rankService.CheckTagModeratorTagPermission(ctx, req.UserID, permission.QuestionPin, req.Tag)
+	}
 	if (req.Operation == schema.QuestionOperationPin || req.Operation == schema.QuestionOperationUnPin) && !req.CanPin {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
@@ -180,6 +194,9 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
+	if !can {
+		can = qc.rankService.CheckTagModeratorPermission(ctx, req.UserID, permission.QuestionClose, req.ID)
+	}
 	if !can {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
@@ -211,6 +228,9 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
+	if !can {
+		can = qc.rankService.CheckTagModeratorPermission(ctx, req.UserID, permission.QuestionReopen, req.QuestionID)
+	}
 	if !can {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
@@ -220,6 +240,52 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UpdateQuestionSchedule update question schedule
+// @Summary update question schedule
+// @Description change the publish time of the scheduled question before it is published
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateQuestionScheduleReq true "question schedule"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/schedule [put]
+func (qc *QuestionController) UpdateQuestionSchedule(ctx *gin.Context) {
+	req := &schema.UpdateQuestionScheduleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	err := qc.questionService.UpdateQuestionSchedule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// CancelQuestionSchedule cancel question schedule
+// @Summary cancel question schedule
+// @Description cancel the schedule of the question, it is kept unpublished until it is scheduled again
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.CancelQuestionScheduleReq true "question"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/schedule [delete]
+func (qc *QuestionController) CancelQuestionSchedule(ctx *gin.Context) {
+	req := &schema.CancelQuestionScheduleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	err := qc.questionService.CancelQuestionSchedule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // GetQuestion get question details
 // @Summary get question details
 // @Description get question details
@@ -235,7 +301,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	userID := middleware.GetLoginUserIDFromContext(ctx)
 	req := schema.QuestionPermission{}
 	req.IsAdminModerator = middleware.GetUserIsAdminModerator(ctx)
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, userID, []string{
+	actions := []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionClose,
@@ -246,14 +312,16 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 		permission.QuestionShow,
 		permission.AnswerInviteSomeoneToAnswer,
 		permission.QuestionUnDelete,
-	})
+	}
+	canList, err := qc.rankService.CheckOperationPermissions(ctx, userID, actions)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
+	qc.rankService.CheckTagModeratorPermissions(ctx, userID, id, actions, canList)
 	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, userID, id)
 
-	req.CanEdit = canList[0] || objectOwner
+	req.CanEdit = canList[0] || objectOwner || qc.rankService.CheckWikiEditPermission(ctx, userID, id)
 	req.CanDelete = canList[1]
 	req.CanClose = canList[2]
 	req.CanReopen = canList[3]
@@ -264,7 +332,7 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	req.CanInviteOtherToAnswer = canList[8]
 	req.CanRecover = canList[9]
 
-	info, err := qc.questionService.GetQuestionAndAddPV(ctx, id, userID, req)
+	info, err := qc.questionService.GetQuestionAndAddPV(ctx, id, userID, ctx.ClientIP(), req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -314,9 +382,27 @@ func (qc *QuestionController) SimilarQuestion(ctx *gin.Context) {
 	})
 }
 
+// GetRelatedQuestions get related questions
+// @Summary get the questions related to the question by the shared tags and terms
+// @Description get the questions related to the question by the shared tags and terms, ranked by relevance and recency
+// @Tags Question
+// @Produce json
+// @Param data query schema.GetRelatedQuestionsReq true "GetRelatedQuestionsReq"
+// @Success 200 {object} handler.RespBody{data=[]schema.RelatedQuestionResp}
+// @Router /answer/api/v1/question/related [get]
+func (qc *QuestionController) GetRelatedQuestions(ctx *gin.Context) {
+	req := &schema.GetRelatedQuestionsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	resp, err := qc.questionService.GetRelatedQuestions(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // QuestionPage get questions by page
 // @Summary get questions by page
-// @Description get questions by page
+// @Description get questions by page, the cursor pagination returns pager.CursorPageModel with the next cursor instead of the count
 // @Tags Question
 // @Accept  json
 // @Produce  json
@@ -330,6 +416,16 @@ func (qc *QuestionController) QuestionPage(ctx *gin.Context) {
 	}
 	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
 
+	if req.IsCursorPagination() {
+		questions, nextCursor, err := qc.questionService.GetQuestionPageByCursor(ctx, req)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+		handler.HandleResponse(ctx, nil, pager.NewCursorPageModel(nextCursor, questions))
+		return
+	}
+
 	questions, total, err := qc.questionService.GetQuestionPage(ctx, req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -425,6 +521,15 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
 			return
 		}
+		if captchaPass, failReason := qc.actionService.VerifyCaptchaToken(ctx, constant.CaptchaSceneFirstPost, req.UserID,
+			req.CaptchaToken, ctx.ClientIP()); !captchaPass {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "captcha_token",
+				ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), failReason),
+			})
+			handler.HandleResponse(ctx, errors.BadRequest(failReason), errFields)
+			return
+		}
 	}
 
 	req.CanAdd = canList[0]
@@ -438,6 +543,14 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if req.ScheduledAt > 0 && !isAdmin {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	if err = qc.postingRestriction.CheckCanPost(ctx, req.UserID, req.HTML); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	// can add tag
 	hasNewTag, err := qc.questionService.HasNewTag(ctx, req.Tags)
@@ -530,6 +643,15 @@ func (qc *QuestionController) AddQuestionByAnswer(ctx *gin.Context) {
 			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
 			return
 		}
+		if captchaPass, failReason := qc.actionService.VerifyCaptchaToken(ctx, constant.CaptchaSceneFirstPost, req.UserID,
+			req.CaptchaToken, ctx.ClientIP()); !captchaPass {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "captcha_token",
+				ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), failReason),
+			})
+			handler.HandleResponse(ctx, errors.BadRequest(failReason), errFields)
+			return
+		}
 	}
 	req.CanAdd = canList[0]
 	req.CanEdit = canList[1]
@@ -541,6 +663,11 @@ func (qc *QuestionController) AddQuestionByAnswer(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	// the question and the answer are checked together, so the answer is not restricted by the question
+	if err = qc.postingRestriction.CheckCanPost(ctx, req.UserID, req.HTML, req.AnswerHTML); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 	questionReq := new(schema.QuestionAdd)
 	err = copier.Copy(questionReq, req)
 	if err != nil {
@@ -628,18 +755,20 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, requireRanks, err := qc.rankService.CheckOperationPermissionsForRanks(ctx, req.UserID, []string{
+	actions := []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
 		permission.QuestionEditWithoutReview,
 		permission.TagUseReservedTag,
 		permission.TagAdd,
 		permission.LinkUrlLimit,
-	})
+	}
+	canList, requireRanks, err := qc.rankService.CheckOperationPermissionsForRanks(ctx, req.UserID, actions)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
+	qc.rankService.CheckTagModeratorPermissions(ctx, req.UserID, req.ID, actions, canList)
 	linkUrlLimitUser := canList[5]
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin || !linkUrlLimitUser {
@@ -655,15 +784,20 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 
 	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
+	wikiEditor := qc.rankService.CheckWikiEditPermission(ctx, req.UserID, req.ID)
+	req.CanEdit = canList[0] || objectOwner || wikiEditor
 	req.CanDelete = canList[1]
-	req.NoNeedReview = canList[2] || objectOwner
+	req.NoNeedReview = canList[2] || objectOwner || wikiEditor
 	req.CanUseReservedTag = canList[3]
 	req.CanAddTag = canList[4]
 	if !req.CanEdit {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if err = qc.postLockService.CheckPostEditable(ctx, req.ID, isAdmin); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	errlist, err := qc.questionService.UpdateQuestionCheckTags(ctx, req)
 	if err != nil {
@@ -976,6 +1110,69 @@ func (qc *QuestionController) AdminUpdateQuestionStatus(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// AdminDeletedPostPage admin deleted post page
+// @Summary admin deleted post page
+// @Description list the questions or answers deleted in the retention window, the latest deleted first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param object_type query string true "object type" Enums(question,answer)
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.AdminDeletedPostInfo}}
+// @Router /answer/admin/api/deleted-post/page [get]
+func (qc *QuestionController) AdminDeletedPostPage(ctx *gin.Context) {
+	req := &schema.AdminDeletedPostPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := qc.questionService.AdminDeletedPostPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminRestoreDeletedPost admin restore deleted post
+// @Summary admin restore deleted question or answer
+// @Description admin restore deleted question or answer and reapply its reputation changes
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AdminRestoreDeletedPostReq true "AdminRestoreDeletedPostReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/deleted-post/restore [put]
+func (qc *QuestionController) AdminRestoreDeletedPost(ctx *gin.Context) {
+	req := &schema.AdminRestoreDeletedPostReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	objectType, err := obj.GetObjectTypeStrByObjectID(req.ObjectID)
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.ObjectNotFound), nil)
+		return
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+		err = qc.questionService.RecoverQuestion(ctx, &schema.QuestionRecoverReq{
+			QuestionID: req.ObjectID,
+			UserID:     req.UserID,
+		})
+	case constant.AnswerObjectType:
+		err = qc.answerService.RecoverAnswer(ctx, &schema.RecoverAnswerReq{
+			AnswerID: req.ObjectID,
+			UserID:   req.UserID,
+		})
+	default:
+		err = errors.BadRequest(reason.ObjectNotFound)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // GetQuestionLink get question link
 // @Summary get question link
 // @Description get question link
@@ -59,3 +59,70 @@ func (cc *RankController) GetRankPersonalWithPage(ctx *gin.Context) {
 	resp, err := cc.rankService.GetRankPersonalPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetReputationLedgerWithPage user reputation ledger
+// @Summary user reputation ledger
+// @Description list every reputation change of the user, the newest first
+// @Tags Rank
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param username query string false "username"
+// @Param reason query string false "only list the changes of this reason" Enums(upvote, downvote, accept, bounty, edit, moderation, reversal)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetReputationLedgerResp}}
+// @Router /answer/api/v1/personal/reputation/page [get]
+func (cc *RankController) GetReputationLedgerWithPage(ctx *gin.Context) {
+	req := &schema.GetReputationLedgerWithPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.rankService.GetReputationLedgerPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetReputationLeaderboard reputation leaderboard
+// @Summary reputation leaderboard
+// @Description list the users who gained the most reputation in the window, the most first
+// @Tags Rank
+// @Produce json
+// @Param window query string false "the period the reputation is gained in, default is all" Enums(today, week, month, all)
+// @Param tag query string false "only count the reputation gained from the questions with this tag slug name"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetReputationLeaderboardResp}}
+// @Router /answer/api/v1/user/leaderboard [get]
+func (cc *RankController) GetReputationLeaderboard(ctx *gin.Context) {
+	req := &schema.GetReputationLeaderboardReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := cc.rankService.GetReputationLeaderboard(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetUserTagRankWithPage user reputation in the tags
+// @Summary user reputation in the tags
+// @Description list the reputation the user gained from the votes in each tag, the highest first
+// @Tags Rank
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param username query string false "username"
+// @Param tag query string false "only get the reputation in this tag slug name"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetUserTagRankResp}}
+// @Router /answer/api/v1/personal/reputation/tag/page [get]
+func (cc *RankController) GetUserTagRankWithPage(ctx *gin.Context) {
+	req := &schema.GetUserTagRankWithPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.rankService.GetUserTagRankPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/middleware"
 	"github.com/apache/answer/internal/base/reason"
@@ -71,7 +72,9 @@ func (rc *ReportController) AddReport(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
-	req.ObjectID = uid.DeShortID(req.ObjectID)
+	if req.ObjectType != constant.UserObjectType {
+		req.ObjectID = uid.DeShortID(req.ObjectID)
+	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
@@ -152,3 +155,61 @@ func (rc *ReportController) ReviewReport(ctx *gin.Context) {
 	err := rc.reportService.ReviewReport(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetModerationQueue get moderation queue
+// @Summary get the flagged objects with their pending flags
+// @Description get the flagged posts, comments and users grouped by the object, the objects with more flags come first
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param object_type query string false "object type" Enums(question, answer, comment, user)
+// @Param report_type query int false "the reason of the flags"
+// @Param min_age query int false "only the flags raised at least these hours ago"
+// @Param max_age query int false "only the flags raised within these hours"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ModerationQueueItem}}
+// @Router /answer/api/v1/moderation/queue [get]
+func (rc *ReportController) GetModerationQueue(ctx *gin.Context) {
+	req := &schema.GetModerationQueueReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+
+	resp, err := rc.reportService.GetModerationQueue(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ResolveModerationQueueItem resolve moderation queue item
+// @Summary resolve all the pending flags of the flagged object
+// @Description dismiss the flags, delete or edit the flagged post, or warn the author, all the pending flags of the object are resolved
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ResolveModerationQueueItemReq true "resolve"
+// @Success 200 {object} handler.RespBody{data=schema.ResolveModerationQueueItemResp}
+// @Router /answer/api/v1/moderation/resolve [put]
+func (rc *ReportController) ResolveModerationQueueItem(ctx *gin.Context) {
+	req := &schema.ResolveModerationQueueItemReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if req.ObjectType != constant.UserObjectType {
+		req.ObjectID = uid.DeShortID(req.ObjectID)
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+	if !req.IsAdmin {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+
+	resp, err := rc.reportService.ResolveModerationQueueItem(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
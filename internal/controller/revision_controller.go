@@ -83,6 +83,62 @@ func (rc *RevisionController) GetRevisionList(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, list)
 }
 
+// GetRevisionDiff godoc
+// @Summary get the diff between two revisions
+// @Description get the diff of title, content and tags between two revisions of the object
+// @Tags Revision
+// @Produce json
+// @Param object_id query string true "object id"
+// @Param from query string true "the older revision id"
+// @Param to query string true "the newer revision id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRevisionDiffResp}
+// @Router /answer/api/v1/revisions/diff [get]
+func (rc *RevisionController) GetRevisionDiff(ctx *gin.Context) {
+	req := &schema.GetRevisionDiffReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := rc.revisionListService.GetRevisionDiff(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RollbackRevision godoc
+// @Summary roll back to the revision
+// @Description restore the object to the revision, a new revision is created with the same content
+// @Tags Revision
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RollbackRevisionReq true "rollback"
+// @Success 200 {object} handler.RespBody{data=schema.RollbackRevisionResp}
+// @Router /answer/api/v1/revisions/rollback [post]
+func (rc *RevisionController) RollbackRevision(ctx *gin.Context) {
+	req := &schema.RollbackRevisionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canList, err := rc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.QuestionAudit,
+		permission.AnswerAudit,
+		permission.TagAudit,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanReviewQuestion = canList[0]
+	req.CanReviewAnswer = canList[1]
+	req.CanReviewTag = canList[2]
+
+	resp, err := rc.revisionListService.RollbackRevision(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetUnreviewedRevisionList godoc
 // @Summary get unreviewed revision list
 // @Description get unreviewed revision list
@@ -53,7 +53,12 @@ func NewSearchController(
 
 // Search godoc
 // @Summary search object
-// @Description search object
+// @Description search object, the free text can be combined with the operators:
+// @Description [tag] or tag:tag, user:username or user:me, score:3 score:>3 score:>=3 or score:=3,
+// @Description created:2024-01-01 created:2024-01-01..2024-02-01 created:>2024-01-01 or created:<=2024-01-01,
+// @Description is:question is:answer is:answered, views:10, answers:0, hasaccepted:no, isaccepted:yes and inquestion:id.
+// @Description The unsupported operators and the operators with invalid values return the bad request error,
+// @Description the operators that filter the results are returned in the operators of the response.
 // @Tags Search
 // @Produce json
 // @Security ApiKeyAuth
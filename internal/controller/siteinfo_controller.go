@@ -118,6 +118,23 @@ func (sc *SiteInfoController) GetSiteInfo(ctx *gin.Context) {
 		resp.MCPEnabled = mcpConf.Enabled
 	}
 
+	if captchaConf, err := sc.siteInfoService.GetSiteCaptcha(ctx); err == nil && captchaConf.Enabled {
+		resp.Captcha = &schema.SiteCaptchaPublicResp{
+			Provider:  captchaConf.Provider,
+			SiteKey:   captchaConf.SiteKey,
+			FirstPost: captchaConf.FirstPost,
+		}
+	}
+	if maintenance, err := sc.siteInfoService.GetSiteMaintenance(ctx); err == nil && maintenance.Enabled {
+		resp.Maintenance = maintenance
+	}
+	if reaction, err := sc.siteInfoService.GetSiteReaction(ctx); err == nil {
+		resp.Reaction = reaction
+	}
+	if guestPosting, err := sc.siteInfoService.GetSiteGuestPosting(ctx); err == nil {
+		resp.GuestPostingEnabled = guestPosting.Enabled
+	}
+
 	handler.HandleResponse(ctx, nil, resp)
 }
 
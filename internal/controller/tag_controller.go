@@ -364,7 +364,7 @@ func (tc *TagController) UpdateTagSynonym(ctx *gin.Context) {
 // @Tags Tag
 // @Accept json
 // @Produce json
-// @Param data body schema.AddTagReq true "tag"
+// @Param data body schema.MergeTagReq true "merge tag"
 // @Success 200 {object} handler.RespBody
 // @Router /answer/api/v1/tag/merge [post]
 func (tc *TagController) MergeTag(ctx *gin.Context) {
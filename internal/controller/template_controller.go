@@ -43,7 +43,6 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/pkg/checker"
-	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/obj"
 	"github.com/apache/answer/pkg/uid"
@@ -54,6 +53,9 @@ import (
 
 var SiteUrl = ""
 
+// sitemapFileRegexp the names of the sitemap files listed in the sitemap index
+var sitemapFileRegexp = regexp.MustCompile(`^(question|tag|user)-[1-9][0-9]*\.xml$`)
+
 type TemplateController struct {
 	scriptPath               []string
 	cssPath                  string
@@ -385,7 +387,10 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	jsonLD.MainEntity.Text = detail.HTML
 	jsonLD.MainEntity.AnswerCount = int(answerCount)
 	jsonLD.MainEntity.UpvoteCount = detail.VoteCount
+	jsonLD.MainEntity.URL = siteInfo.Canonical
+	jsonLD.MainEntity.Image = htmltext.FetchFirstImage(detail.HTML, siteInfo.General.SiteUrl)
 	jsonLD.MainEntity.DateCreated = time.Unix(detail.CreateTime, 0)
+	jsonLD.MainEntity.DateModified = modifiedTime(detail.CreateTime, detail.QuestionUpdateTime)
 	jsonLD.MainEntity.Author.Type = "Person"
 	jsonLD.MainEntity.Author.Name = detail.UserInfo.DisplayName
 	jsonLD.MainEntity.Author.URL = fmt.Sprintf("%s/users/%s", siteInfo.General.SiteUrl, detail.UserInfo.Username)
@@ -396,6 +401,7 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 			acceptedAnswerItem.Type = "Answer"
 			acceptedAnswerItem.Text = answer.HTML
 			acceptedAnswerItem.DateCreated = time.Unix(answer.CreateTime, 0)
+			acceptedAnswerItem.DateModified = modifiedTime(answer.CreateTime, answer.UpdateTime)
 			acceptedAnswerItem.UpvoteCount = answer.VoteCount
 			acceptedAnswerItem.URL = fmt.Sprintf("%s/%s", siteInfo.Canonical, answer.ID)
 			acceptedAnswerItem.Author.Type = "Person"
@@ -407,6 +413,7 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 			item.Type = "Answer"
 			item.Text = answer.HTML
 			item.DateCreated = time.Unix(answer.CreateTime, 0)
+			item.DateModified = modifiedTime(answer.CreateTime, answer.UpdateTime)
 			item.UpvoteCount = answer.VoteCount
 			item.URL = fmt.Sprintf("%s/%s", siteInfo.Canonical, answer.ID)
 			item.Author.Type = "Person"
@@ -422,6 +429,10 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	}
 
 	siteInfo.Description = htmltext.FetchExcerpt(detail.HTML, "...", 240)
+	siteInfo.OGType = "article"
+	siteInfo.OGImage = jsonLD.MainEntity.Image
+	siteInfo.PublishedTime = jsonLD.MainEntity.DateCreated.Format(time.RFC3339)
+	siteInfo.ModifiedTime = jsonLD.MainEntity.DateModified.Format(time.RFC3339)
 	tags := make([]string, 0)
 	for _, tag := range detail.Tags {
 		tags = append(tags, tag.DisplayName)
@@ -434,7 +445,7 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 		"detail":          detail,
 		"answers":         answers,
 		"comments":        comments,
-		"noindex":         detail.Show == entity.QuestionHide,
+		"noindex":         detail.Show == entity.QuestionHide || detail.Status == entity.QuestionStatusClosed,
 		"useTitle":        UrlUseTitle,
 		"relatedQuestion": relatedQuestion,
 	})
@@ -597,6 +608,12 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, siteI
 		data["title"] = siteInfo.General.Name
 	}
 	data["description"] = siteInfo.Description
+	if siteInfo.OGType == "" {
+		siteInfo.OGType = "website"
+	}
+	if siteInfo.OGImage == "" {
+		siteInfo.OGImage = defaultOGImage(siteInfo)
+	}
 	data["language"] = handler.GetLangByCtx(ctx)
 	data["timezone"] = siteInfo.Interface.TimeZone
 	language := strings.ReplaceAll(siteInfo.Interface.Language, "_", "-")
@@ -614,6 +631,29 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, siteI
 	ctx.HTML(code, tpl, data)
 }
 
+// defaultOGImage the square icon is preferred for the link previews, then the favicon of the site
+func defaultOGImage(siteInfo *schema.TemplateSiteInfoResp) string {
+	image := siteInfo.Branding.SquareIcon
+	if image == "" {
+		image = siteInfo.Branding.Favicon
+	}
+	if image == "" {
+		return siteInfo.General.SiteUrl + "/favicon.ico"
+	}
+	if strings.HasPrefix(image, "/") && !strings.HasPrefix(image, "//") {
+		return siteInfo.General.SiteUrl + image
+	}
+	return image
+}
+
+// modifiedTime the post is not modified if the update time is unknown or before the creation
+func modifiedTime(createTime, updateTime int64) time.Time {
+	if updateTime < createTime {
+		return time.Unix(createTime, 0)
+	}
+	return time.Unix(updateTime, 0)
+}
+
 func (tc *TemplateController) OpenSearch(ctx *gin.Context) {
 	if tc.checkPrivateMode(ctx) {
 		tc.Page404(ctx)
@@ -627,7 +667,9 @@ func (tc *TemplateController) Sitemap(ctx *gin.Context) {
 		tc.Page404(ctx)
 		return
 	}
-	tc.templateRenderController.Sitemap(ctx)
+	if err := tc.templateRenderController.Sitemap(ctx); err != nil {
+		tc.Page404(ctx)
+	}
 }
 
 func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
@@ -635,20 +677,12 @@ func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
 		tc.Page404(ctx)
 		return
 	}
-	page := 0
-	pageParam := ctx.Param("page")
-	pageRegexp := regexp.MustCompile(`question-(.*).xml`)
-	pageStr := pageRegexp.FindStringSubmatch(pageParam)
-	if len(pageStr) != 2 {
-		tc.Page404(ctx)
-		return
-	}
-	page = converter.StringToInt(pageStr[1])
-	if page == 0 {
+	name := ctx.Param("page")
+	if !sitemapFileRegexp.MatchString(name) {
 		tc.Page404(ctx)
 		return
 	}
-	err := tc.templateRenderController.SitemapPage(ctx, page)
+	err := tc.templateRenderController.SitemapPage(ctx, name)
 	if err != nil {
 		tc.Page404(ctx)
 		return
@@ -23,7 +23,7 @@ import (
 	"math"
 
 	"github.com/apache/answer/internal/service/content"
-	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/sitemap"
 
 	"github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/siteinfo_common"
@@ -45,7 +45,7 @@ type TemplateRenderController struct {
 	answerService   *content.AnswerService
 	commentService  *comment.CommentService
 	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionRepo    questioncommon.QuestionRepo
+	sitemapService  *sitemap.SitemapService
 }
 
 func NewTemplateRenderController(
@@ -55,7 +55,7 @@ func NewTemplateRenderController(
 	answerService *content.AnswerService,
 	commentService *comment.CommentService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
-	questionRepo questioncommon.QuestionRepo,
+	sitemapService *sitemap.SitemapService,
 ) *TemplateRenderController {
 	return &TemplateRenderController{
 		questionService: questionService,
@@ -63,7 +63,7 @@ func NewTemplateRenderController(
 		tagService:      tagService,
 		answerService:   answerService,
 		commentService:  commentService,
-		questionRepo:    questionRepo,
+		sitemapService:  sitemapService,
 		siteInfoService: siteInfoService,
 	}
 }
@@ -21,12 +21,12 @@ package templaterender
 
 import (
 	"html/template"
-	"math"
 	"net/http"
 
-	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/schema"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -38,55 +38,49 @@ func (t *TemplateRenderController) QuestionDetail(ctx *gin.Context, id string) (
 	return t.questionService.GetQuestion(ctx, id, "", schema.QuestionPermission{})
 }
 
-func (t *TemplateRenderController) Sitemap(ctx *gin.Context) {
+// Sitemap render the sitemap.xml, it is the sitemap index if the urls are more than the limit of a sitemap file
+func (t *TemplateRenderController) Sitemap(ctx *gin.Context) error {
 	general, err := t.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
 		log.Error("get site general failed:", err)
-		return
+		return err
 	}
-	siteInfo, err := t.siteInfoService.GetSiteSeo(ctx)
+	index, exist, err := t.sitemapService.GetSitemapIndex(ctx)
 	if err != nil {
-		log.Error("get site GetSiteSeo failed:", err)
-		return
+		log.Errorf("get sitemap index failed: %s", err)
+		return err
 	}
-
-	questions, err := t.questionRepo.SitemapQuestions(ctx, 1, constant.SitemapMaxSize)
-	if err != nil {
-		log.Errorf("get sitemap questions failed: %s", err)
-		return
+	if !exist {
+		return errors.NotFound(reason.ObjectNotFound)
 	}
 
-	ctx.Header("Content-Type", "application/xml")
-	if len(questions) < constant.SitemapMaxSize {
+	if index.IsSingle() {
+		urls, exist, err := t.sitemapService.GetSingleSitemapFile(ctx)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.NotFound(reason.ObjectNotFound)
+		}
+		ctx.Header("Content-Type", "application/xml")
 		ctx.HTML(
 			http.StatusOK, "sitemap.xml", gin.H{
 				"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-				"list":      questions,
+				"list":      urls,
 				"general":   general,
-				"hastitle": siteInfo.Permalink == constant.PermalinkQuestionIDAndTitle ||
-					siteInfo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID,
 			},
 		)
-		return
-	}
-
-	questionNum, err := t.questionRepo.GetQuestionCount(ctx)
-	if err != nil {
-		log.Error("GetQuestionCount error", err)
-		return
-	}
-	var pageList []int
-	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
-	for i := 1; i <= totalPages; i++ {
-		pageList = append(pageList, i)
+		return nil
 	}
+	ctx.Header("Content-Type", "application/xml")
 	ctx.HTML(
 		http.StatusOK, "sitemap-list.xml", gin.H{
 			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-			"page":      pageList,
+			"files":     index.Files,
 			"general":   general,
 		},
 	)
+	return nil
 }
 
 func (t *TemplateRenderController) OpenSearch(ctx *gin.Context) {
@@ -111,31 +105,27 @@ func (t *TemplateRenderController) OpenSearch(ctx *gin.Context) {
 	)
 }
 
-func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, page int) error {
+// SitemapPage render the sitemap file listed in the sitemap index
+func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, name string) error {
 	general, err := t.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
 		log.Error("get site general failed:", err)
 		return err
 	}
-	siteInfo, err := t.siteInfoService.GetSiteSeo(ctx)
+	urls, exist, err := t.sitemapService.GetSitemapFile(ctx, name)
 	if err != nil {
-		log.Error("get site GetSiteSeo failed:", err)
+		log.Errorf("get sitemap file failed: %s", err)
 		return err
 	}
-
-	questions, err := t.questionRepo.SitemapQuestions(ctx, page, constant.SitemapMaxSize)
-	if err != nil {
-		log.Errorf("get sitemap questions failed: %s", err)
-		return err
+	if !exist {
+		return errors.NotFound(reason.ObjectNotFound)
 	}
 	ctx.Header("Content-Type", "application/xml")
 	ctx.HTML(
 		http.StatusOK, "sitemap.xml", gin.H{
 			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-			"list":      questions,
+			"list":      urls,
 			"general":   general,
-			"hastitle": siteInfo.Permalink == constant.PermalinkQuestionIDAndTitle ||
-				siteInfo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID,
 		},
 	)
 	return nil
@@ -20,6 +20,9 @@
 package controller
 
 import (
+	errpkg "errors"
+	"mime"
+	"net/http"
 	"net/url"
 
 	"github.com/apache/answer/internal/base/constant"
@@ -31,11 +34,15 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/action"
+	"github.com/apache/answer/internal/service/apikey"
 	"github.com/apache/answer/internal/service/auth"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/user_data_export"
 	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/user_two_factor"
 	"github.com/apache/answer/pkg/checker"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -50,6 +57,10 @@ type UserController struct {
 	emailService                  *export.EmailService
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	userTwoFactorService          *user_two_factor.UserTwoFactorService
+	userDataExportService         *user_data_export.UserDataExportService
+	apiKeyService                 *apikey.APIKeyService
+	rateLimitMiddleware           *middleware.RateLimitMiddleware
 }
 
 // NewUserController new controller
@@ -60,6 +71,10 @@ func NewUserController(
 	emailService *export.EmailService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
+	userDataExportService *user_data_export.UserDataExportService,
+	apiKeyService *apikey.APIKeyService,
+	rateLimitMiddleware *middleware.RateLimitMiddleware,
 ) *UserController {
 	return &UserController{
 		authService:                   authService,
@@ -68,6 +83,10 @@ func NewUserController(
 		emailService:                  emailService,
 		siteInfoCommonService:         siteInfoCommonService,
 		userNotificationConfigService: userNotificationConfigService,
+		userTwoFactorService:          userTwoFactorService,
+		userDataExportService:         userDataExportService,
+		apiKeyService:                 apiKeyService,
+		rateLimitMiddleware:           rateLimitMiddleware,
 	}
 }
 
@@ -88,13 +107,25 @@ func (uc *UserController) GetUserInfoByUserID(ctx *gin.Context) {
 	}
 
 	// if user is no login return null in data
-	userInfo, _ := uc.authService.GetUserCacheInfo(ctx, token)
+	var userInfo *entity.UserCacheInfo
+	if middleware.IsAPIKeyAuth(ctx) {
+		userInfo = middleware.GetUserInfoFromContext(ctx)
+	} else {
+		userInfo, _ = uc.authService.GetUserCacheInfo(ctx, token)
+	}
 	if userInfo == nil {
 		handler.HandleResponse(ctx, nil, nil)
 		return
 	}
 
 	resp, err := uc.userService.GetUserInfoByUserID(ctx, token, userInfo.UserID)
+	if resp != nil && userInfo.IsImpersonated() {
+		resp.Impersonation = &schema.ImpersonationInfo{
+			ImpersonatorID: userInfo.ImpersonatorID,
+			Mode:           userInfo.ImpersonationMode,
+			ExpireAt:       userInfo.ExpireAt,
+		}
+	}
 	uc.setVisitCookies(ctx, userInfo.VisitToken, false)
 	handler.HandleResponse(ctx, err, resp)
 }
@@ -136,6 +167,9 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	if uc.rateLimitMiddleware.RequestRateLimit(ctx, service_config.RateLimitActionLogin, req.Email) {
+		return
+	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
 		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
@@ -162,6 +196,10 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if !isAdmin {
 		uc.actionService.ActionRecordDel(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
 	}
+	if resp.TwoFactorRequired {
+		handler.HandleResponse(ctx, nil, resp)
+		return
+	}
 	if resp.Status == constant.UserSuspended {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.UserSuspended),
 			&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeUserSuspended})
@@ -185,6 +223,9 @@ func (uc *UserController) RetrievePassWord(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	if uc.rateLimitMiddleware.RequestRateLimit(ctx, service_config.RateLimitActionPasswordReset, req.Email) {
+		return
+	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
 		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionEmail, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
@@ -196,6 +237,15 @@ func (uc *UserController) RetrievePassWord(ctx *gin.Context) {
 			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
 			return
 		}
+		if captchaPass, failReason := uc.actionService.VerifyCaptchaToken(ctx, constant.CaptchaScenePasswordReset, "",
+			req.CaptchaToken, ctx.ClientIP()); !captchaPass {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "captcha_token",
+				ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), failReason),
+			})
+			handler.HandleResponse(ctx, errors.BadRequest(failReason), errFields)
+			return
+		}
 	}
 	err := uc.userService.RetrievePassWord(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
@@ -215,6 +265,9 @@ func (uc *UserController) UseRePassWord(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	if uc.rateLimitMiddleware.RequestRateLimit(ctx, service_config.RateLimitActionPasswordReset, "") {
+		return
+	}
 
 	req.Content = uc.emailService.VerifyUrlExpired(ctx, req.Code)
 	if len(req.Content) == 0 {
@@ -250,6 +303,33 @@ func (uc *UserController) UserLogout(ctx *gin.Context) {
 	handler.HandleResponse(ctx, nil, nil)
 }
 
+// UserLogoutEverywhere log out all the sessions of the user
+// @Summary log out all the sessions of the user
+// @Description log out all the sessions of the login user on every device, including the current one. The personal api keys are removed too if revoke_api_keys is true
+// @Security ApiKeyAuth
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.UserLogoutEverywhereReq true "UserLogoutEverywhereReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/logout/all [post]
+func (uc *UserController) UserLogoutEverywhere(ctx *gin.Context) {
+	req := &schema.UserLogoutEverywhereReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userService.LogoutEverywhere(ctx, req)
+	if err == nil {
+		_ = uc.authService.RemoveAdminUserCacheInfo(ctx, middleware.ExtractToken(ctx))
+		visitToken, _ := ctx.Cookie(constant.UserVisitCookiesCacheKey)
+		_ = uc.authService.RemoveUserVisitCacheInfo(ctx, visitToken)
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UserRegisterByEmail godoc
 // @Summary UserRegisterByEmail
 // @Description UserRegisterByEmail
@@ -275,10 +355,23 @@ func (uc *UserController) UserRegisterByEmail(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	if uc.rateLimitMiddleware.RequestRateLimit(ctx, service_config.RateLimitActionRegister, req.Email) {
+		return
+	}
 	if !checker.EmailInAllowEmailDomain(req.Email, siteInfo.AllowEmailDomains) {
 		handler.HandleResponse(ctx, errors.BadRequest(reason.EmailIllegalDomainError), nil)
 		return
 	}
+	blocklist, err := uc.siteInfoCommonService.GetSiteRegistrationBlocklist(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	// the blocked registrations get a generic error, nobody can tell whether the ip or the email is blocked
+	if blocklist.IsIPBlocked(ctx.ClientIP()) || blocklist.IsEmailBlocked(req.Email) {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RegistrationBlocked), nil)
+		return
+	}
 	req.RequireEmailVerification = siteInfo.RequireEmailVerification
 	req.IP = ctx.ClientIP()
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
@@ -292,6 +385,15 @@ func (uc *UserController) UserRegisterByEmail(ctx *gin.Context) {
 			handler.HandleResponse(ctx, errors.BadRequest(reason.CaptchaVerificationFailed), errFields)
 			return
 		}
+		if captchaPass, failReason := uc.actionService.VerifyCaptchaToken(ctx, constant.CaptchaSceneRegister, "",
+			req.CaptchaToken, req.IP); !captchaPass {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "captcha_token",
+				ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), failReason),
+			})
+			handler.HandleResponse(ctx, errors.BadRequest(failReason), errFields)
+			return
+		}
 	}
 
 	resp, errFields, err := uc.userService.UserRegisterByEmail(ctx, req)
@@ -548,6 +650,42 @@ func (uc *UserController) UpdateUserNotificationConfig(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetNotificationPreferences get user's notification preferences of each event
+// @Summary get user's notification preferences of each event
+// @Description get user's notification preferences of each event
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.GetNotificationPreferencesResp}
+// @Router /answer/api/v1/user/notification/preferences [get]
+func (uc *UserController) GetNotificationPreferences(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userNotificationConfigService.GetNotificationPreferences(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateNotificationPreferences update user's notification preferences of each event
+// @Summary update user's notification preferences of each event
+// @Description update user's notification preferences of each event, in-app, email, or nothing if both are disabled
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateNotificationPreferencesReq true "UpdateNotificationPreferencesReq"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/user/notification/preferences [put]
+func (uc *UserController) UpdateNotificationPreferences(ctx *gin.Context) {
+	req := &schema.UpdateNotificationPreferencesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userNotificationConfigService.UpdateNotificationPreferences(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UserChangeEmailSendCode send email to the user email then change their email
 // @Summary send email to the user email then change their email
 // @Description send email to the user email then change their email
@@ -669,7 +807,7 @@ func (uc *UserController) UserStaff(ctx *gin.Context) {
 
 // UserUnsubscribeNotification unsubscribe notification
 // @Summary unsubscribe notification
-// @Description unsubscribe notification
+// @Description unsubscribe notification with the signed unsubscribe token in the email link, no login is required
 // @Tags User
 // @Accept json
 // @Produce json
@@ -681,8 +819,32 @@ func (uc *UserController) UserUnsubscribeNotification(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	uc.unsubscribeNotification(ctx, req)
+}
 
-	req.Content = uc.emailService.VerifyUrlExpired(ctx, req.Code)
+// UserOneClickUnsubscribeNotification unsubscribe notification in one click
+// @Summary unsubscribe notification in one click
+// @Description the List-Unsubscribe-Post endpoint of RFC 8058 that the mail clients post to, no login is required
+// @Tags User
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param code query string true "unsubscribe token"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/user/notification/unsubscribe/one-click [post]
+func (uc *UserController) UserOneClickUnsubscribeNotification(ctx *gin.Context) {
+	req := &schema.UserUnsubscribeNotificationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	uc.unsubscribeNotification(ctx, req)
+}
+
+func (uc *UserController) unsubscribeNotification(ctx *gin.Context, req *schema.UserUnsubscribeNotificationReq) {
+	req.Content = uc.emailService.VerifyUnsubscribeToken(ctx, req.Code)
+	if len(req.Content) == 0 {
+		// the codes in the emails sent before the signed tokens are kept in the cache
+		req.Content = uc.emailService.VerifyUrlExpired(ctx, req.Code)
+	}
 	if len(req.Content) == 0 {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.EmailVerifyURLExpired),
 			&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeURLExpired})
@@ -713,6 +875,153 @@ func (uc *UserController) SearchUserListByName(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// UserTwoFactorLogin godoc
+// @Summary finish the password login with two-factor authentication
+// @Description finish the password login with a code of the authenticator or a recovery code
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.UserTwoFactorLoginReq true "UserTwoFactorLoginReq"
+// @Success 200 {object} handler.RespBody{data=schema.UserLoginResp}
+// @Router /answer/api/v1/user/login/2fa [post]
+func (uc *UserController) UserTwoFactorLogin(ctx *gin.Context) {
+	req := &schema.UserTwoFactorLoginReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if uc.rateLimitMiddleware.RequestRateLimit(ctx, service_config.RateLimitActionLogin, "") {
+		return
+	}
+
+	resp, err := uc.userService.TwoFactorLogin(ctx, req)
+	if err != nil {
+		// wrong codes count as failed password logins, so that the captcha is required for the next try
+		uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
+		var myErr *errors.Error
+		if errpkg.As(err, &myErr) && myErr.Reason == reason.UserTwoFactorCodeInvalid {
+			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
+				ErrorField: "code",
+				ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.UserTwoFactorCodeInvalid),
+			})
+			handler.HandleResponse(ctx, err, errFields)
+			return
+		}
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if resp.Status == constant.UserSuspended {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.UserSuspended),
+			&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeUserSuspended})
+		return
+	}
+	uc.setVisitCookies(ctx, resp.VisitToken, true)
+	handler.HandleResponse(ctx, nil, resp)
+}
+
+// GetUserTwoFactorStatus get two-factor authentication status
+// @Summary get two-factor authentication status
+// @Description get two-factor authentication status of the current user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorStatusResp}
+// @Router /answer/api/v1/user/2fa [get]
+func (uc *UserController) GetUserTwoFactorStatus(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userTwoFactorService.GetStatus(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// EnrollUserTwoFactor enroll two-factor authentication
+// @Summary enroll two-factor authentication
+// @Description generate a secret and recovery codes, the two-factor authentication is enabled after the first code is verified
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserTwoFactorEnrollResp}
+// @Router /answer/api/v1/user/2fa/enrollment [post]
+func (uc *UserController) EnrollUserTwoFactor(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userTwoFactorService.Enroll(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ActivateUserTwoFactor activate two-factor authentication
+// @Summary activate two-factor authentication
+// @Description verify the first code of the authenticator to enable two-factor authentication
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UserTwoFactorActivateReq true "UserTwoFactorActivateReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/2fa/activation [post]
+func (uc *UserController) ActivateUserTwoFactor(ctx *gin.Context) {
+	req := &schema.UserTwoFactorActivateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userTwoFactorService.Activate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DisableUserTwoFactor disable two-factor authentication
+// @Summary disable two-factor authentication
+// @Description disable two-factor authentication with a code of the authenticator or a recovery code
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UserTwoFactorDisableReq true "UserTwoFactorDisableReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/2fa [delete]
+func (uc *UserController) DisableUserTwoFactor(ctx *gin.Context) {
+	req := &schema.UserTwoFactorDisableReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userTwoFactorService.Disable(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ExportUserData export the data of the login user
+// @Summary export the data of the login user
+// @Description compile all the data of the login user into a zip file in background, the download link is sent to the email of the user when it is ready
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/data-export [post]
+func (uc *UserController) ExportUserData(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userDataExportService.ExportUserData(ctx, userID)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DownloadUserDataExport download the export of the user data
+// @Summary download the export of the user data
+// @Description download the zip file of the user data export with the token in the email
+// @Tags User
+// @Produce application/zip
+// @Param token query string true "the token in the email"
+// @Success 200 {file} file
+// @Router /answer/api/v1/user/data-export/download [get]
+func (uc *UserController) DownloadUserDataExport(ctx *gin.Context) {
+	req := &schema.UserDataExportDownloadReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	file, err := uc.userDataExportService.GetExportFile(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	ctx.Data(http.StatusOK, "application/zip", file.Content)
+}
+
 func (uc *UserController) setVisitCookies(ctx *gin.Context, visitToken string, force bool) {
 	if !force {
 		cookie, _ := ctx.Cookie(constant.UserVisitCookiesCacheKey)
@@ -734,3 +1043,58 @@ func (uc *UserController) setVisitCookies(ctx *gin.Context, visitToken string, f
 	ctx.SetCookie(constant.UserVisitCookiesCacheKey,
 		visitToken, constant.UserVisitCacheTime, "/", parsedURL.Hostname(), true, true)
 }
+
+// GetUserAPIKeys get the personal api keys of the login user
+// @Summary get the personal api keys of the login user
+// @Description get the personal api keys of the login user, the keys are masked
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetAPIKeyResp}
+// @Router /answer/api/v1/user/api-key/all [get]
+func (uc *UserController) GetUserAPIKeys(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.apiKeyService.GetUserAPIKeyList(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddUserAPIKey issue a personal api key for the login user
+// @Summary issue a personal api key for the login user
+// @Description issue a personal api key for the login user, the key is only returned once.
+// @Description The key is sent in the Authorization header as a Bearer token and acts as the user.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddUserAPIKeyReq true "api key"
+// @Success 200 {object} handler.RespBody{data=schema.AddAPIKeyResp}
+// @Router /answer/api/v1/user/api-key [post]
+func (uc *UserController) AddUserAPIKey(ctx *gin.Context) {
+	req := &schema.AddUserAPIKeyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.apiKeyService.AddUserAPIKey(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeUserAPIKey revoke the personal api key of the login user
+// @Summary revoke the personal api key of the login user
+// @Description revoke the personal api key of the login user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeUserAPIKeyReq true "api key"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/api-key [delete]
+func (uc *UserController) RevokeUserAPIKey(ctx *gin.Context) {
+	req := &schema.RevokeUserAPIKeyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.apiKeyService.RevokeUserAPIKey(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
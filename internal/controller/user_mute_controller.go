@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_mute"
+	"github.com/gin-gonic/gin"
+)
+
+// UserMuteController user mute controller
+type UserMuteController struct {
+	userMuteService *user_mute.UserMuteService
+}
+
+// NewUserMuteController new controller
+func NewUserMuteController(userMuteService *user_mute.UserMuteService) *UserMuteController {
+	return &UserMuteController{userMuteService: userMuteService}
+}
+
+// MuteUser mute the user
+// @Summary mute the user
+// @Description the questions of the muted user are hidden from the question lists and the comments are collapsed,
+// @Description only for the login user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MuteUserReq true "muted user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/mute [post]
+func (uc *UserMuteController) MuteUser(ctx *gin.Context) {
+	req := &schema.MuteUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userMuteService.MuteUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UnmuteUser unmute the user
+// @Summary unmute the user
+// @Description remove the user from the ignore list of the login user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MuteUserReq true "muted user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/mute [delete]
+func (uc *UserMuteController) UnmuteUser(ctx *gin.Context) {
+	req := &schema.MuteUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userMuteService.UnmuteUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetMutedUserPage get the muted users
+// @Summary get the muted users
+// @Description get the ignore list of the login user, the latest muted users come first
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.MutedUserResp}}
+// @Router /answer/api/v1/user/mute/page [get]
+func (uc *UserMuteController) GetMutedUserPage(ctx *gin.Context) {
+	req := &schema.GetMutedUserPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := uc.userMuteService.GetMutedUserPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
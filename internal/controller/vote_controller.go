@@ -29,6 +29,7 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/action"
 	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/post_lock"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/gin-gonic/gin"
@@ -37,9 +38,10 @@ import (
 
 // VoteController activity controller
 type VoteController struct {
-	VoteService   *content.VoteService
-	rankService   *rank.RankService
-	actionService *action.CaptchaService
+	VoteService     *content.VoteService
+	rankService     *rank.RankService
+	actionService   *action.CaptchaService
+	postLockService *post_lock.PostLockService
 }
 
 // NewVoteController new controller
@@ -47,11 +49,13 @@ func NewVoteController(
 	voteService *content.VoteService,
 	rankService *rank.RankService,
 	actionService *action.CaptchaService,
+	postLockService *post_lock.PostLockService,
 ) *VoteController {
 	return &VoteController{
-		VoteService:   voteService,
-		rankService:   rankService,
-		actionService: actionService,
+		VoteService:     voteService,
+		rankService:     rankService,
+		actionService:   actionService,
+		postLockService: postLockService,
 	}
 }
 
@@ -86,6 +90,10 @@ func (vc *VoteController) VoteUp(ctx *gin.Context) {
 	}
 
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
+	if err = vc.postLockService.CheckPostVotable(ctx, req.ObjectID, isAdmin); err != nil {
+		handler.HandleResponse(ctx, err, schema.ErrTypeToast)
+		return
+	}
 	if !isAdmin {
 		captchaPass := vc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionVote, req.UserID, req.CaptchaID, req.CaptchaCode)
 		if !captchaPass {
@@ -139,6 +147,10 @@ func (vc *VoteController) VoteDown(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.NoEnoughRankToOperate).WithMsg(msg), nil)
 		return
 	}
+	if err = vc.postLockService.CheckPostVotable(ctx, req.ObjectID, isAdmin); err != nil {
+		handler.HandleResponse(ctx, err, schema.ErrTypeToast)
+		return
+	}
 
 	if !isAdmin {
 		captchaPass := vc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionVote, req.UserID, req.CaptchaID, req.CaptchaCode)
@@ -31,4 +31,8 @@ var ProviderSetController = wire.NewSet(
 	NewBadgeController,
 	NewAdminAPIKeyController,
 	NewAIConversationAdminController,
+	NewWebhookController,
+	NewAuditLogController,
+	NewJobQueueController,
+	NewTagModeratorController,
 )
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/jobqueue"
+	"github.com/gin-gonic/gin"
+)
+
+type JobQueueController struct {
+	jobQueue *jobqueue.Service
+}
+
+func NewJobQueueController(jobQueue *jobqueue.Service) *JobQueueController {
+	return &JobQueueController{
+		jobQueue: jobQueue,
+	}
+}
+
+// GetJobQueueStatus get the status of the background job queue
+// @Summary get the status of the background job queue
+// @Description get the amount of the pending, running, retrying and failed jobs of each type
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetJobQueueStatusResp}
+// @Router /answer/admin/api/jobs/status [get]
+func (jc *JobQueueController) GetJobQueueStatus(ctx *gin.Context) {
+	resp, err := jc.jobQueue.GetQueueStatus(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetJobDeadLetterPage list the jobs failed after all the attempts by page
+// @Summary list the jobs failed after all the attempts by page
+// @Description list the jobs failed after all the attempts by page, the newest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param type query string false "job type"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetJobDeadLetterResp}}
+// @Router /answer/admin/api/jobs/dead-letters [get]
+func (jc *JobQueueController) GetJobDeadLetterPage(ctx *gin.Context) {
+	req := &schema.GetJobDeadLetterPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := jc.jobQueue.GetDeadLetterPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
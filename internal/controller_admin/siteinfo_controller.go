@@ -555,6 +555,75 @@ func (sc *SiteInfoController) UpdateSMTPConfig(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// SendTestEmail send test email
+// @Summary send test email with the saved smtp config
+// @Description send test email with the saved smtp config
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SendTestEmailReq true "test email recipient"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/smtp/test [post]
+func (sc *SiteInfoController) SendTestEmail(ctx *gin.Context) {
+	req := &schema.SendTestEmailReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SendTestEmail(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetEmailTemplates get the custom email templates
+// @Summary get the custom email templates and the variables that can be used in them
+// @Description get the custom email templates and the variables that can be used in them
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.GetEmailTemplatesResp}
+// @Router /answer/admin/api/setting/email-templates [get]
+func (sc *SiteInfoController) GetEmailTemplates(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetEmailTemplates(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveEmailTemplate save the custom email template
+// @Summary save the custom email template of the type in the language
+// @Description save the custom email template of the type in the language, the unknown variables are rejected
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.SaveEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/email-template [put]
+func (sc *SiteInfoController) SaveEmailTemplate(ctx *gin.Context) {
+	req := &schema.SaveEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveEmailTemplate remove the custom email template
+// @Summary remove the custom email template, the default template is used again
+// @Description remove the custom email template, the default template is used again
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/email-template [delete]
+func (sc *SiteInfoController) RemoveEmailTemplate(ctx *gin.Context) {
+	req := &schema.RemoveEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.RemoveEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // GetPrivilegesConfig get privileges config
 // @Summary GetPrivilegesConfig get privileges config
 // @Description GetPrivilegesConfig get privileges config
@@ -687,3 +756,535 @@ func (sc *SiteInfoController) UpdateMCPConfig(ctx *gin.Context) {
 	err := sc.siteInfoService.SaveSiteMCP(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetLDAPConfig get LDAP login configuration
+// @Summary get LDAP login configuration
+// @Description get LDAP login configuration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteLDAPResp}
+// @Router /answer/admin/api/setting/ldap [get]
+func (sc *SiteInfoController) GetLDAPConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteLDAP(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateLDAPConfig update LDAP login configuration
+// @Summary update LDAP login configuration
+// @Description update LDAP login configuration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteLDAPReq true "LDAP config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/ldap [put]
+func (sc *SiteInfoController) UpdateLDAPConfig(ctx *gin.Context) {
+	req := &schema.SiteLDAPReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteLDAP(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSAMLConfig get SAML configuration
+// @Summary get SAML configuration
+// @Description get SAML configuration and the service provider URLs to register at the identity provider
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteSAMLResp}
+// @Router /answer/admin/api/setting/saml [get]
+func (sc *SiteInfoController) GetSAMLConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteSAML(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSAMLConfig update SAML configuration
+// @Summary update SAML configuration
+// @Description update SAML configuration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteSAMLReq true "SAML config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/saml [put]
+func (sc *SiteInfoController) UpdateSAMLConfig(ctx *gin.Context) {
+	req := &schema.SiteSAMLReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteSAML(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetWebhookConfig get outgoing webhook configuration
+// @Summary get outgoing webhook configuration
+// @Description get outgoing webhook configuration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteWebhookResp}
+// @Router /answer/admin/api/setting/webhook [get]
+func (sc *SiteInfoController) GetWebhookConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteWebhook(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateWebhookConfig update outgoing webhook configuration
+// @Summary update outgoing webhook configuration
+// @Description update outgoing webhook configuration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteWebhookReq true "webhook config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/webhook [put]
+func (sc *SiteInfoController) UpdateWebhookConfig(ctx *gin.Context) {
+	req := &schema.SiteWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetStorageConfig get upload file storage configuration
+// @Summary get upload file storage configuration
+// @Description get upload file storage configuration, the secret access key is masked
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteStorageResp}
+// @Router /answer/admin/api/setting/storage [get]
+func (sc *SiteInfoController) GetStorageConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteStorage(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateStorageConfig update upload file storage configuration
+// @Summary update upload file storage configuration
+// @Description update upload file storage configuration, local disk or S3-compatible object storage
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteStorageReq true "storage config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/storage [put]
+func (sc *SiteInfoController) UpdateStorageConfig(ctx *gin.Context) {
+	req := &schema.SiteStorageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteStorage(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSpamConfig get spam check configuration
+// @Summary get spam check configuration
+// @Description get spam check configuration, the Akismet API key is masked
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteSpamResp}
+// @Router /answer/admin/api/setting/spam [get]
+func (sc *SiteInfoController) GetSpamConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteSpam(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSpamConfig update spam check configuration
+// @Summary update spam check configuration
+// @Description update the threshold of the spam score, whether to hold the spam posts and the Akismet integration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteSpamReq true "spam check config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/spam [put]
+func (sc *SiteInfoController) UpdateSpamConfig(ctx *gin.Context) {
+	req := &schema.SiteSpamReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteSpam(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPostingRestrictionConfig get posting restriction configuration
+// @Summary get posting restriction configuration
+// @Description get the posting restrictions of the new accounts and the users with low reputation
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SitePostingRestrictionResp}
+// @Router /answer/admin/api/setting/posting-restriction [get]
+func (sc *SiteInfoController) GetPostingRestrictionConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSitePostingRestriction(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdatePostingRestrictionConfig update posting restriction configuration
+// @Summary update posting restriction configuration
+// @Description update the thresholds of the trusted users and the posting limits of the others
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SitePostingRestrictionReq true "posting restriction config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/posting-restriction [put]
+func (sc *SiteInfoController) UpdatePostingRestrictionConfig(ctx *gin.Context) {
+	req := &schema.SitePostingRestrictionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSitePostingRestriction(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAcceptReputationConfig get accepted answer reputation configuration
+// @Summary get accepted answer reputation configuration
+// @Description get the reputation the answerer and the asker gain when an answer is accepted
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteAcceptReputationResp}
+// @Router /answer/admin/api/setting/accept-reputation [get]
+func (sc *SiteInfoController) GetAcceptReputationConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteAcceptReputation(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAcceptReputationConfig update accepted answer reputation configuration
+// @Summary update accepted answer reputation configuration
+// @Description update the reputation of the accepted answer and whether the asker gains the bonus
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteAcceptReputationReq true "accepted answer reputation config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/accept-reputation [put]
+func (sc *SiteInfoController) UpdateAcceptReputationConfig(ctx *gin.Context) {
+	req := &schema.SiteAcceptReputationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteAcceptReputation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetCORSConfig get CORS configuration
+// @Summary get CORS configuration
+// @Description get the CORS configuration of the API routes
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteCORSResp}
+// @Router /answer/admin/api/setting/cors [get]
+func (sc *SiteInfoController) GetCORSConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteCORS(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateCORSConfig update CORS configuration
+// @Summary update CORS configuration
+// @Description update the allowed origins, methods and headers of the cross-origin requests to the API routes
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteCORSReq true "CORS config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/cors [put]
+func (sc *SiteInfoController) UpdateCORSConfig(ctx *gin.Context) {
+	req := &schema.SiteCORSReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteCORS(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetCaptchaConfig get captcha configuration
+// @Summary get captcha configuration
+// @Description get the captcha configuration of the third-party provider, the secret key is masked
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteCaptchaResp}
+// @Router /answer/admin/api/setting/captcha [get]
+func (sc *SiteInfoController) GetCaptchaConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteCaptcha(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateCaptchaConfig update captcha configuration
+// @Summary update captcha configuration
+// @Description update the provider and the keys of the captcha, hCaptcha, reCAPTCHA or Turnstile
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteCaptchaReq true "captcha config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/captcha [put]
+func (sc *SiteInfoController) UpdateCaptchaConfig(ctx *gin.Context) {
+	req := &schema.SiteCaptchaReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteCaptcha(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetMaintenanceConfig get maintenance mode configuration
+// @Summary get maintenance mode configuration
+// @Description get whether the site is in the read-only maintenance mode
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteMaintenanceResp}
+// @Router /answer/admin/api/setting/maintenance [get]
+func (sc *SiteInfoController) GetMaintenanceConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteMaintenance(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateMaintenanceConfig update maintenance mode configuration
+// @Summary update maintenance mode configuration
+// @Description turn on or off the read-only maintenance mode, only the admins can change the data in this mode
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteMaintenanceReq true "maintenance config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/maintenance [put]
+func (sc *SiteInfoController) UpdateMaintenanceConfig(ctx *gin.Context) {
+	req := &schema.SiteMaintenanceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteMaintenance(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetGuestPostingConfig get guest posting configuration
+// @Summary get guest posting configuration
+// @Description get whether the guests can ask questions without logging in
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteGuestPostingResp}
+// @Router /answer/admin/api/setting/guest-posting [get]
+func (sc *SiteInfoController) GetGuestPostingConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteGuestPosting(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateGuestPostingConfig update guest posting configuration
+// @Summary update guest posting configuration
+// @Description turn on or off the guest posting, the questions of the guests are held for review
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteGuestPostingReq true "guest posting config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/guest-posting [put]
+func (sc *SiteInfoController) UpdateGuestPostingConfig(ctx *gin.Context) {
+	req := &schema.SiteGuestPostingReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteGuestPosting(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetSlowQueryConfig get slow query log configuration
+// @Summary get slow query log configuration
+// @Description get the milliseconds above which the database queries are logged
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SlowQueryConfigResp}
+// @Router /answer/admin/api/setting/slow-query [get]
+func (sc *SiteInfoController) GetSlowQueryConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSlowQueryConfig(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateSlowQueryConfig update slow query log configuration
+// @Summary update slow query log configuration
+// @Description change the threshold of the slow query log at runtime, it is reset to the config file when the server restarts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SlowQueryConfigReq true "slow query config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/slow-query [put]
+func (sc *SiteInfoController) UpdateSlowQueryConfig(ctx *gin.Context) {
+	req := &schema.SlowQueryConfigReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSlowQueryConfig(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAutoCloseConfig get auto-close configuration
+// @Summary get auto-close configuration
+// @Description get how the stale unanswered questions are closed or archived automatically
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteAutoCloseResp}
+// @Router /answer/admin/api/setting/auto-close [get]
+func (sc *SiteInfoController) GetAutoCloseConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteAutoClose(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAutoCloseConfig update auto-close configuration
+// @Summary update auto-close configuration
+// @Description update how the stale unanswered questions are closed or archived automatically
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteAutoCloseReq true "auto-close config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/auto-close [put]
+func (sc *SiteInfoController) UpdateAutoCloseConfig(ctx *gin.Context) {
+	req := &schema.SiteAutoCloseReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteAutoClose(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetReactionConfig get reaction configuration
+// @Summary get reaction configuration
+// @Description get the emojis the users can react to the posts with
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteReactionResp}
+// @Router /answer/admin/api/setting/reaction [get]
+func (sc *SiteInfoController) GetReactionConfig(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteReaction(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateReactionConfig update reaction configuration
+// @Summary update reaction configuration
+// @Description update the emojis the users can react to the posts with
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteReactionReq true "reaction config"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/reaction [put]
+func (sc *SiteInfoController) UpdateReactionConfig(ctx *gin.Context) {
+	req := &schema.SiteReactionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteReaction(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRegistrationBlocklist get registration blocklist
+// @Summary get registration blocklist
+// @Description get the blocked ip ranges and email domains of the registration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteRegistrationBlocklistResp}
+// @Router /answer/admin/api/setting/registration-blocklist [get]
+func (sc *SiteInfoController) GetRegistrationBlocklist(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteRegistrationBlocklist(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateRegistrationBlocklist update registration blocklist
+// @Summary update registration blocklist
+// @Description update the blocked ip ranges and email domains of the registration
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteRegistrationBlocklistReq true "registration blocklist"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/registration-blocklist [put]
+func (sc *SiteInfoController) UpdateRegistrationBlocklist(ctx *gin.Context) {
+	req := &schema.SiteRegistrationBlocklistReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteRegistrationBlocklist(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetUsernamePolicy get username policy
+// @Summary get username policy
+// @Description get the allowed characters, the length bounds and the reserved names of the usernames
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteUsernamePolicyResp}
+// @Router /answer/admin/api/setting/username-policy [get]
+func (sc *SiteInfoController) GetUsernamePolicy(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteUsernamePolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateUsernamePolicy update username policy
+// @Summary update username policy
+// @Description update the policy the usernames are checked with when they are registered or changed,
+// @Description the existing usernames are kept even if they break the new policy
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.SiteUsernamePolicyReq true "username policy"
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/username-policy [put]
+func (sc *SiteInfoController) UpdateUsernamePolicy(ctx *gin.Context) {
+	req := &schema.SiteUsernamePolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.siteInfoService.SaveSiteUsernamePolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ImportDisposableEmailDomains import disposable email domains
+// @Summary import disposable email domains
+// @Description bulk import the disposable email domains, one domain per line
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param data body schema.ImportDisposableEmailDomainsReq true "disposable email domains"
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.ImportDisposableEmailDomainsResp}
+// @Router /answer/admin/api/setting/registration-blocklist/disposable-domains [put]
+func (sc *SiteInfoController) ImportDisposableEmailDomains(ctx *gin.Context) {
+	req := &schema.ImportDisposableEmailDomainsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := sc.siteInfoService.ImportDisposableEmailDomains(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/user_admin"
+	"github.com/apache/answer/internal/service/user_data_export"
+	"github.com/apache/answer/internal/service/user_two_factor"
 	"github.com/apache/answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -33,12 +35,22 @@ import (
 
 // UserAdminController user controller
 type UserAdminController struct {
-	userService *user_admin.UserAdminService
+	userService           *user_admin.UserAdminService
+	userTwoFactorService  *user_two_factor.UserTwoFactorService
+	userDataExportService *user_data_export.UserDataExportService
 }
 
 // NewUserAdminController new controller
-func NewUserAdminController(userService *user_admin.UserAdminService) *UserAdminController {
-	return &UserAdminController{userService: userService}
+func NewUserAdminController(
+	userService *user_admin.UserAdminService,
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
+	userDataExportService *user_data_export.UserDataExportService,
+) *UserAdminController {
+	return &UserAdminController{
+		userService:           userService,
+		userTwoFactorService:  userTwoFactorService,
+		userDataExportService: userDataExportService,
+	}
 }
 
 // UpdateUserStatus update user
@@ -127,6 +139,8 @@ func (uc *UserAdminController) AddUsers(ctx *gin.Context) {
 		return
 	}
 
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
 	resp, err := uc.userService.AddUsers(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
@@ -153,6 +167,96 @@ func (uc *UserAdminController) UpdateUserPassword(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// ResetUserTwoFactor reset user two-factor authentication
+// @Summary reset user two-factor authentication
+// @Description turn off the two-factor authentication of a user who lost the authenticator and the recovery codes
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ResetUserTwoFactorReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/2fa [delete]
+func (uc *UserAdminController) ResetUserTwoFactor(ctx *gin.Context) {
+	req := &schema.ResetUserTwoFactorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := uc.userTwoFactorService.ResetUserTwoFactor(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// EraseUser delete the account of a user
+// @Summary delete the account of a user
+// @Description delete the account of a user and remove the personal data of it, the posts are anonymized or deleted by the account deletion policy
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.EraseUserReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user [delete]
+func (uc *UserAdminController) EraseUser(ctx *gin.Context) {
+	if u, ok := plugin.GetUserCenter(); ok && u.Description().UserStatusAgentEnabled {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req := &schema.EraseUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userService.EraseUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RevokeUserSessions revoke all the sessions of a user
+// @Summary revoke all the sessions of a user
+// @Description log out all the active sessions of a user immediately, the api keys of the user are removed too if revoke_api_keys is true
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RevokeUserSessionsReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/sessions [delete]
+func (uc *UserAdminController) RevokeUserSessions(ctx *gin.Context) {
+	req := &schema.RevokeUserSessionsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userService.RevokeUserSessions(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ExportUserData export the data of a user
+// @Summary export the data of a user
+// @Description compile all the data of a user into a zip file in background for the data export request of the user, the download link is sent to the email of the admin when it is ready
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UserDataExportReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/data-export [post]
+func (uc *UserAdminController) ExportUserData(ctx *gin.Context) {
+	req := &schema.UserDataExportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userDataExportService.AdminExportUserData(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // EditUserProfile edit user profile
 // @Summary edit user profile
 // @Description edit user profile
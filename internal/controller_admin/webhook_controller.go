@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookService *webhook.WebhookService
+}
+
+func NewWebhookController(webhookService *webhook.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// GetDeadLetterPage list the permanently failed webhook deliveries by page
+// @Summary list the permanently failed webhook deliveries by page
+// @Description list the permanently failed webhook deliveries by page, the newest first
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetWebhookDeadLetterResp}}
+// @Router /answer/admin/api/webhook/dead-letters [get]
+func (wc *WebhookController) GetDeadLetterPage(ctx *gin.Context) {
+	req := &schema.GetWebhookDeadLetterPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := wc.webhookService.GetDeadLetterPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -24,6 +24,9 @@ import "time"
 const (
 	ActivityAvailable = 0
 	ActivityCancelled = 1
+	// ActivityCancelledByDeletion the activity is cancelled because its object was deleted,
+	// it will be available again when the object is recovered.
+	ActivityCancelledByDeletion = 2
 )
 
 // Activity activity
@@ -57,6 +60,17 @@ type ActivityUserVoteStat struct {
 	VoteCount int    `xorm:"vote_count"`
 }
 
+// ActivityReputationChange a reputation change of the user, a cancelled activity
+// produces a reversal change at the time it was cancelled.
+type ActivityReputationChange struct {
+	ID           string    `xorm:"id"`
+	ObjectID     string    `xorm:"object_id"`
+	ActivityType int       `xorm:"activity_type"`
+	Delta        int       `xorm:"delta"`
+	Reversal     int       `xorm:"reversal"`
+	HappenedAt   time.Time `xorm:"happened_at"`
+}
+
 // TableName activity table name
 func (Activity) TableName() string {
 	return "activity"
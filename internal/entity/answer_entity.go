@@ -53,6 +53,9 @@ type Answer struct {
 	CommentCount   int       `xorm:"not null default 0 INT(11) comment_count"`
 	VoteCount      int       `xorm:"not null default 0 INT(11) vote_count"`
 	RevisionID     string    `xorm:"not null default 0 BIGINT(20) revision_id"`
+	DeletedAt      time.Time `xorm:"TIMESTAMP INDEX deleted_at"`
+	// Wiki the community wiki answer can be edited by the whole community and its votes earn no reputation
+	Wiki bool `xorm:"not null default false BOOL wiki"`
 }
 
 type AnswerSearch struct {
@@ -30,10 +30,17 @@ type APIKey struct {
 	UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
 	LastUsedAt  time.Time `xorm:"not null default CURRENT_TIMESTAMP TIMESTAMP last_used_at"`
 	Description string    `xorm:"not null MEDIUMTEXT description"`
-	AccessKey   string    `xorm:"not null unique VARCHAR(255) access_key"`
-	Scope       string    `xorm:"not null VARCHAR(255) scope"`
-	UserID      string    `xorm:"not null default 0 BIGINT(20) user_id"`
-	Hidden      int       `xorm:"not null default 0 INT(11) hidden"`
+	// AccessKey the sha256 hash of the key, the plain key is only shown once when it is created
+	AccessKey string `xorm:"not null unique VARCHAR(255) access_key"`
+	// KeyHint the masked key for display
+	KeyHint string `xorm:"not null default '' VARCHAR(32) key_hint"`
+	Scope   string `xorm:"not null VARCHAR(255) scope"`
+	UserID  string `xorm:"not null default 0 BIGINT(20) user_id"`
+	Hidden  int    `xorm:"not null default 0 INT(11) hidden"`
+	// Personal the key is issued by a user in the settings and authenticates as that user
+	Personal int `xorm:"not null default 0 INT(11) personal"`
+	// RateLimit the max requests per minute, 0 means the default limit
+	RateLimit int `xorm:"not null default 0 INT(11) rate_limit"`
 }
 
 // TableName category table name
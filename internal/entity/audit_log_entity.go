@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// AuditLog a record of an administrative or moderation action
+type AuditLog struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP INDEX created_at"`
+	ActorID    string    `xorm:"not null default 0 BIGINT(20) INDEX actor_id"`
+	Action     string    `xorm:"not null default '' VARCHAR(64) INDEX action"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(32) object_type"`
+	ObjectID   string    `xorm:"not null default '' VARCHAR(64) INDEX object_id"`
+	Before     string    `xorm:"MEDIUMTEXT before_value"`
+	After      string    `xorm:"MEDIUMTEXT after_value"`
+	IP         string    `xorm:"not null default '' VARCHAR(64) ip"`
+}
+
+// TableName table name
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+// AuditLogQueryCond audit log query condition
+type AuditLogQueryCond struct {
+	Page       int
+	PageSize   int
+	ActorID    string
+	Action     string
+	ObjectType string
+	ObjectID   string
+	StartTime  time.Time
+	EndTime    time.Time
+}
@@ -27,4 +27,20 @@ type UserCacheInfo struct {
 	RoleID      int    `json:"role_id"`
 	ExternalID  string `json:"external_id"`
 	VisitToken  string `json:"visit_token"`
+	// RememberMe the session is created by a "remember me" login and lasts longer
+	RememberMe bool `json:"remember_me"`
+	// LoginAt and ActiveAt are the unix time the session is created and last refreshed
+	LoginAt  int64 `json:"login_at"`
+	ActiveAt int64 `json:"active_at"`
+	// ExpireAt the unix time the session token expires if it is not refreshed
+	ExpireAt int64 `json:"expire_at"`
+	// ImpersonatorID the id of the admin who is viewing as the user, it is empty if the session is not impersonated
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+	// ImpersonationMode read_only or full, the mode of the impersonation session
+	ImpersonationMode string `json:"impersonation_mode,omitempty"`
+}
+
+// IsImpersonated the session is started by an admin who is viewing as the user
+func (u *UserCacheInfo) IsImpersonated() bool {
+	return len(u.ImpersonatorID) > 0
 }
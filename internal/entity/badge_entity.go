@@ -38,8 +38,31 @@ const (
 
 	BadgeSingleAward = 1
 	BadgeMultiAward  = 2
+
+	// BadgeRuleHandler the handler of the badges awarded by the declarative rule in the param
+	BadgeRuleHandler = "BadgeRule"
+)
+
+// the metrics the badge rules compare with the amount
+const (
+	// BadgeMetricAnswerScore the score of an answer, the badge is awarded for each answer
+	BadgeMetricAnswerScore = "answer_score"
+	// BadgeMetricQuestionScore the score of a question, the badge is awarded for each question
+	BadgeMetricQuestionScore = "question_score"
+	// BadgeMetricAcceptedAnswers the amount of the accepted answers of the user
+	BadgeMetricAcceptedAnswers = "accepted_answers"
+	// BadgeMetricActiveDays the amount of the distinct days the user posted on
+	BadgeMetricActiveDays = "active_days"
+	// BadgeMetricReputation the reputation of the user
+	BadgeMetricReputation = "reputation"
 )
 
+// BadgeRule the declarative condition of the badge, the badge is awarded when the metric reaches the amount
+type BadgeRule struct {
+	Metric string `json:"metric"`
+	Amount int64  `json:"amount"`
+}
+
 // Badge badge
 type Badge struct {
 	ID           string     `xorm:"not null pk BIGINT(20) id"`
@@ -70,3 +93,29 @@ func (b *Badge) GetIntParam(key string) int64 {
 func (b *Badge) GetStringParam(key string) string {
 	return gjson.Get(b.Param, key).String()
 }
+
+// IsRepeatable the badge can be awarded to the same user repeatedly, once for each award key
+func (b *Badge) IsRepeatable() bool {
+	return b.Single != BadgeSingleAward
+}
+
+// GetEarnedCount get the times the badge is earned by the user from the amount of the awards,
+// the one-time badge is earned once even if it was awarded repeatedly before
+func (b *Badge) GetEarnedCount(awardAmount int64) int64 {
+	if !b.IsRepeatable() {
+		return min(awardAmount, 1)
+	}
+	return awardAmount
+}
+
+// GetRule get the rule of the badge, ok is false if the badge is not awarded by a valid rule
+func (b *Badge) GetRule() (rule *BadgeRule, ok bool) {
+	if b.Handler != BadgeRuleHandler {
+		return nil, false
+	}
+	rule = &BadgeRule{Metric: b.GetStringParam("metric"), Amount: b.GetIntParam("amount")}
+	if len(rule.Metric) == 0 || rule.Amount <= 0 {
+		return nil, false
+	}
+	return rule, true
+}
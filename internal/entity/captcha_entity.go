@@ -32,6 +32,7 @@ const (
 	CaptchaActionReport           = "report"
 	CaptchaActionDelete           = "delete"
 	CaptchaActionVote             = "vote"
+	CaptchaActionGuestQuestion    = "guest_question"
 )
 
 type ActionRecordInfo struct {
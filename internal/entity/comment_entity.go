@@ -41,6 +41,7 @@ type Comment struct {
 	UserID         string        `xorm:"not null default 0 BIGINT(20) user_id"`
 	ReplyUserID    sql.NullInt64 `xorm:"BIGINT(20) reply_user_id"`
 	ReplyCommentID sql.NullInt64 `xorm:"BIGINT(20) reply_comment_id"`
+	ParentID       sql.NullInt64 `xorm:"BIGINT(20) INDEX parent_id"`
 	ObjectID       string        `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
 	QuestionID     string        `xorm:"not null default 0 BIGINT(20) question_id"`
 	VoteCount      int           `xorm:"not null default 0 INT(11) vote_count"`
@@ -70,6 +71,14 @@ func (c *Comment) GetReplyCommentID() string {
 	return ""
 }
 
+// GetParentID get the id of the top-level comment of the thread
+func (c *Comment) GetParentID() string {
+	if c.ParentID.Valid {
+		return fmt.Sprintf("%d", c.ParentID.Int64)
+	}
+	return ""
+}
+
 // SetReplyUserID set reply user id
 func (c *Comment) SetReplyUserID(str string) {
 	if len(str) > 0 {
@@ -88,6 +97,15 @@ func (c *Comment) SetReplyCommentID(str string) {
 	}
 }
 
+// SetParentID set the id of the top-level comment of the thread
+func (c *Comment) SetParentID(str string) {
+	if len(str) > 0 {
+		c.ParentID = sql.NullInt64{Int64: converter.StringToInt64(str), Valid: true}
+	} else {
+		c.ParentID = sql.NullInt64{Valid: false}
+	}
+}
+
 // GetMentionUsernameList get mention username list
 func (c *Comment) GetMentionUsernameList() []string {
 	return converter.GetMentionUsernameList(c.OriginalText)
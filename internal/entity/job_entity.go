@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	JobStatusPending = 1
+	JobStatusRunning = 2
+)
+
+// Job the background job that is persisted until it is done, the job of a crashed worker is run again
+// after it is unlocked
+type Job struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Type        string    `xorm:"not null default '' VARCHAR(64) INDEX type"`
+	Payload     string    `xorm:"MEDIUMTEXT payload"`
+	Status      int       `xorm:"not null default 1 INT(11) INDEX status"`
+	Attempts    int       `xorm:"not null default 0 INT(11) attempts"`
+	RunAt       time.Time `xorm:"not null TIMESTAMP INDEX run_at"`
+	LockedUntil time.Time `xorm:"TIMESTAMP locked_until"`
+	LastError   string    `xorm:"TEXT last_error"`
+}
+
+// TableName table name
+func (Job) TableName() string {
+	return "job"
+}
+
+// JobDeadLetter the job that failed after all the attempts
+type JobDeadLetter struct {
+	ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP INDEX created_at"`
+	JobID     int64     `xorm:"not null default 0 BIGINT(20) job_id"`
+	Type      string    `xorm:"not null default '' VARCHAR(64) INDEX type"`
+	Payload   string    `xorm:"MEDIUMTEXT payload"`
+	Attempts  int       `xorm:"not null default 0 INT(11) attempts"`
+	Error     string    `xorm:"TEXT error"`
+}
+
+// TableName table name
+func (JobDeadLetter) TableName() string {
+	return "job_dead_letter"
+}
+
+// JobStat the amount of the jobs of the type in the status, retrying is the amount of them that failed before
+type JobStat struct {
+	Type     string `xorm:"type"`
+	Status   int    `xorm:"status"`
+	Amount   int64  `xorm:"amount"`
+	Retrying int64  `xorm:"retrying"`
+}
@@ -27,6 +27,12 @@ const (
 	AnswerEditSummaryKey   = "answer.edit.summary"
 	TagEditSummaryKey      = "tag.edit.summary"
 	ObjectReactSummaryKey  = "object.react.summary"
+	// PostLockKey the lock of the question or the answer
+	PostLockKey = "post.lock"
+	// GuestQuestionKey the contact of the guest who asked the question
+	GuestQuestionKey = "question.guest"
+	// ContentProcessorMetaKey the metadata annotated by the content processor plugins
+	ContentProcessorMetaKey = "content.processor.metadata"
 )
 
 // Meta meta
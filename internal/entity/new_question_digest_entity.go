@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// NewQuestionDigest the new question in the following tags waiting to be sent to the user in the digest email
+type NewQuestionDigest struct {
+	ID            string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID        string    `xorm:"not null default 0 BIGINT(20) UNIQUE(uk_user_question) user_id"`
+	QuestionID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(uk_user_question) question_id"`
+	QuestionTitle string    `xorm:"not null default '' VARCHAR(150) question_title"`
+	Tags          string    `xorm:"not null default '' VARCHAR(255) tags"`
+}
+
+// TableName new question digest table name
+func (NewQuestionDigest) TableName() string {
+	return "new_question_digest"
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	BountyStatusActive  = 1
+	BountyStatusAwarded = 2
+	BountyStatusExpired = 3
+	// BountyStatusCancelled the question was deleted when the bounty was settled, the escrow is not refunded
+	BountyStatusCancelled = 4
+)
+
+var BountyStatusDisplayMapping = map[int]string{
+	BountyStatusActive:    "active",
+	BountyStatusAwarded:   "awarded",
+	BountyStatusExpired:   "expired",
+	BountyStatusCancelled: "cancelled",
+}
+
+// QuestionBounty reputation offered by a user to attract answers to a question.
+// The amount is deducted from the offering user when the bounty is placed.
+type QuestionBounty struct {
+	ID              string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID      string    `xorm:"not null default 0 BIGINT(20) INDEX question_id"`
+	UserID          string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Amount          int       `xorm:"not null default 0 INT(11) amount"`
+	Status          int       `xorm:"not null default 1 INDEX INT(11) status"`
+	ExpiresAt       time.Time `xorm:"TIMESTAMP expires_at"`
+	AwardedAnswerID string    `xorm:"not null default 0 BIGINT(20) awarded_answer_id"`
+	AwardedUserID   string    `xorm:"not null default 0 BIGINT(20) awarded_user_id"`
+	AwardedAmount   int       `xorm:"not null default 0 INT(11) awarded_amount"`
+	SettledAt       time.Time `xorm:"TIMESTAMP settled_at"`
+}
+
+// TableName table name
+func (QuestionBounty) TableName() string {
+	return "question_bounty"
+}
@@ -28,6 +28,8 @@ const (
 	QuestionStatusClosed    = 2
 	QuestionStatusDeleted   = 10
 	QuestionStatusPending   = 11
+	// QuestionStatusScheduled the question is hidden until it is published at the scheduled time
+	QuestionStatusScheduled = 12
 	QuestionUnPin           = 1
 	QuestionPin             = 2
 	QuestionShow            = 1
@@ -39,6 +41,7 @@ var AdminQuestionSearchStatus = map[string]int{
 	"closed":    QuestionStatusClosed,
 	"deleted":   QuestionStatusDeleted,
 	"pending":   QuestionStatusPending,
+	"scheduled": QuestionStatusScheduled,
 }
 
 var AdminQuestionSearchStatusIntToString = map[int]string{
@@ -46,6 +49,7 @@ var AdminQuestionSearchStatusIntToString = map[int]string{
 	QuestionStatusClosed:    "closed",
 	QuestionStatusDeleted:   "deleted",
 	QuestionStatusPending:   "pending",
+	QuestionStatusScheduled: "scheduled",
 }
 
 // Question question
@@ -60,6 +64,7 @@ type Question struct {
 	OriginalText     string    `xorm:"not null MEDIUMTEXT original_text"`
 	ParsedText       string    `xorm:"not null MEDIUMTEXT parsed_text"`
 	Pin              int       `xorm:"not null default 1 INT(11) pin"`
+	PinExpiredAt     time.Time `xorm:"TIMESTAMP INDEX pin_expired_at"`
 	Show             int       `xorm:"not null default 1 INT(11) show"`
 	Status           int       `xorm:"not null default 1 INT(11) status"`
 	ViewCount        int       `xorm:"not null default 0 INT(11) view_count"`
@@ -67,6 +72,7 @@ type Question struct {
 	VoteCount        int       `xorm:"not null default 0 INT(11) vote_count"`
 	AnswerCount      int       `xorm:"not null default 0 INT(11) answer_count"`
 	HotScore         int       `xorm:"not null default 0 INT(11) hot_score"`
+	TrendingScore    int       `xorm:"not null default 0 INT(11) trending_score"`
 	CollectionCount  int       `xorm:"not null default 0 INT(11) collection_count"`
 	FollowCount      int       `xorm:"not null default 0 INT(11) follow_count"`
 	AcceptedAnswerID string    `xorm:"not null default 0 BIGINT(20) accepted_answer_id"`
@@ -74,6 +80,10 @@ type Question struct {
 	PostUpdateTime   time.Time `xorm:"post_update_time TIMESTAMP"`
 	RevisionID       string    `xorm:"not null default 0 BIGINT(20) revision_id"`
 	LinkedCount      int       `xorm:"not null default 0 INT(11) linked_count"`
+	DeletedAt        time.Time `xorm:"TIMESTAMP INDEX deleted_at"`
+	ScheduledAt      time.Time `xorm:"TIMESTAMP INDEX scheduled_at"`
+	// Wiki the community wiki question can be edited by the whole community and its votes earn no reputation
+	Wiki bool `xorm:"not null default false BOOL wiki"`
 }
 
 // TableName question table name
@@ -82,6 +92,19 @@ func (Question) TableName() string {
 }
 
 // QuestionWithTagsRevision question
+// QuestionPageCond the condition of the question list
+type QuestionPageCond struct {
+	TagIDs    []string
+	UserID    string
+	OrderCond string
+	InDays    int
+	// ShowHidden show the hidden questions of the user
+	ShowHidden  bool
+	ShowPending bool
+	// MutedByUserID hide the questions of the users muted by the user
+	MutedByUserID string
+}
+
 type QuestionWithTagsRevision struct {
 	Question
 	Tags []*TagSimpleInfoForRevision `json:"tags"`
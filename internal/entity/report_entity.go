@@ -56,3 +56,10 @@ type Report struct {
 func (Report) TableName() string {
 	return "report"
 }
+
+// ReportObjectStat the pending reports of an object
+type ReportObjectStat struct {
+	ObjectID   string `xorm:"object_id"`
+	ObjectType int    `xorm:"object_type"`
+	FlagCount  int64  `xorm:"flag_count"`
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SavedSearch the search query saved by the user, it notifies the user of the new matching content if the
+// alert is enabled. The normalized query is what the search actually runs, so the alert results are reproducible.
+type SavedSearch struct {
+	ID              string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID          string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Name            string    `xorm:"not null default '' VARCHAR(100) name"`
+	Query           string    `xorm:"not null default '' VARCHAR(255) query"`
+	NormalizedQuery string    `xorm:"not null default '' VARCHAR(255) normalized_query"`
+	SearchOrder     string    `xorm:"not null default '' VARCHAR(20) search_order"`
+	AlertEnabled    bool      `xorm:"not null default false BOOL INDEX alert_enabled"`
+	AlertEmail      bool      `xorm:"not null default false BOOL alert_email"`
+	// LastCheckedAt the content created after it is new to the alert
+	LastCheckedAt time.Time `xorm:"TIMESTAMP last_checked_at"`
+}
+
+// TableName saved search table name
+func (SavedSearch) TableName() string {
+	return "saved_search"
+}
@@ -35,6 +35,9 @@ type TagRel struct {
 	ObjectID  string    `xorm:"not null INDEX UNIQUE(s) BIGINT(20) object_id"`
 	TagID     string    `xorm:"not null INDEX UNIQUE(s) BIGINT(20) tag_id"`
 	Status    int       `xorm:"not null default 1 INT(11) status"`
+	// Pin the question is pinned within the tag, 1: unpin, 2: pin
+	Pin          int       `xorm:"not null default 1 INT(11) pin"`
+	PinExpiredAt time.Time `xorm:"TIMESTAMP INDEX pin_expired_at"`
 }
 
 // TableName tag list table name
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// UserDataExport the zip file of a user data export, it is kept in the database so the download works
+// on any instance. It is downloaded with the token in the email until it expires.
+type UserDataExport struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	ExpiredAt time.Time `xorm:"TIMESTAMP INDEX expired_at"`
+	Token     string    `xorm:"not null default '' VARCHAR(64) UNIQUE token"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	FileName  string    `xorm:"not null default '' VARCHAR(255) file_name"`
+	Content   []byte    `xorm:"LONGBLOB content"`
+}
+
+// TableName user data export table name
+func (UserDataExport) TableName() string {
+	return "user_data_export"
+}
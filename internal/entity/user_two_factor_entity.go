@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// UserTwoFactor user two-factor authentication
+type UserTwoFactor struct {
+	ID            int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt     time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID        string    `xorm:"not null unique default 0 BIGINT(20) user_id"`
+	Secret        string    `xorm:"not null default '' VARCHAR(255) secret"`
+	Enabled       bool      `xorm:"not null default false BOOL enabled"`
+	RecoveryCodes string    `xorm:"TEXT recovery_codes"`
+	LastUsedStep  int64     `xorm:"not null default 0 BIGINT(20) last_used_step"`
+}
+
+// TableName table name
+func (UserTwoFactor) TableName() string {
+	return "user_two_factor"
+}
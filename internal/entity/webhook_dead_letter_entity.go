@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// WebhookDeadLetter webhook delivery that failed permanently
+type WebhookDeadLetter struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	DeliveryID string    `xorm:"not null default '' VARCHAR(64) delivery_id"`
+	Event      string    `xorm:"not null default '' VARCHAR(64) event"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
+	URL        string    `xorm:"not null default '' VARCHAR(512) url"`
+	Payload    string    `xorm:"TEXT payload"`
+	StatusCode int       `xorm:"not null default 0 INT(11) status_code"`
+	Error      string    `xorm:"TEXT error"`
+	Attempts   int       `xorm:"not null default 0 INT(11) attempts"`
+}
+
+// TableName table name
+func (WebhookDeadLetter) TableName() string {
+	return "webhook_dead_letter"
+}
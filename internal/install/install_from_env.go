@@ -27,6 +27,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tidwall/gjson"
@@ -40,6 +42,10 @@ type Env struct {
 	DbHost      string `json:"db_host"`
 	DbName      string `json:"db_name"`
 	DbFile      string `json:"db_file"`
+	DbTimeout   string `json:"db_timeout"`
+	DbSchema    string `json:"db_schema"`
+	DbCharset   string `json:"db_charset"`
+	DbCollation string `json:"db_collation"`
 	Language    string `json:"lang"`
 
 	SiteName               string `json:"site_name"`
@@ -70,6 +76,10 @@ func loadEnv() (env *Env) {
 		DbHost:                 os.Getenv("DB_HOST"),
 		DbName:                 os.Getenv("DB_NAME"),
 		DbFile:                 os.Getenv("DB_FILE"),
+		DbTimeout:              os.Getenv("DB_TIMEOUT"),
+		DbSchema:               os.Getenv("DB_SCHEMA"),
+		DbCharset:              os.Getenv("DB_CHARSET"),
+		DbCollation:            os.Getenv("DB_COLLATION"),
 		Language:               os.Getenv("LANGUAGE"),
 		SiteName:               os.Getenv("SITE_NAME"),
 		SiteURL:                os.Getenv("SITE_URL"),
@@ -96,29 +106,44 @@ func initByEnv(env *Env) (err error) {
 }
 
 func dbCheck(env *Env) (err error) {
-	req := &CheckDatabaseReq{
-		DbType:     env.DbType,
-		DbUsername: env.DbUsername,
-		DbPassword: env.DbPassword,
-		DbHost:     env.DbHost,
-		DbName:     env.DbName,
-		DbFile:     env.DbFile,
+	req, err := env.checkDatabaseReq()
+	if err != nil {
+		return err
 	}
 	return requestAPI(req, "POST", "/installation/db/check", CheckDatabase)
 }
 
 func initConfigAndDb(env *Env) (err error) {
-	req := &CheckDatabaseReq{
-		DbType:     env.DbType,
-		DbUsername: env.DbUsername,
-		DbPassword: env.DbPassword,
-		DbHost:     env.DbHost,
-		DbName:     env.DbName,
-		DbFile:     env.DbFile,
+	req, err := env.checkDatabaseReq()
+	if err != nil {
+		return err
 	}
 	return requestAPI(req, "POST", "/installation/init", InitEnvironment)
 }
 
+// checkDatabaseReq build the same database request as the installation page sends,
+// so that the database options from the environment are validated in the same way
+func (env *Env) checkDatabaseReq() (req *CheckDatabaseReq, err error) {
+	req = &CheckDatabaseReq{
+		DbType:      strings.ToLower(strings.TrimSpace(env.DbType)),
+		DbUsername:  env.DbUsername,
+		DbPassword:  env.DbPassword,
+		DbHost:      env.DbHost,
+		DbName:      env.DbName,
+		DbFile:      env.DbFile,
+		DbSchema:    env.DbSchema,
+		DbCharset:   env.DbCharset,
+		DbCollation: env.DbCollation,
+	}
+	if len(env.DbTimeout) > 0 {
+		req.DbTimeout, err = strconv.Atoi(env.DbTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DB_TIMEOUT %q, it should be the seconds of the connection timeout", env.DbTimeout)
+		}
+	}
+	return req, nil
+}
+
 func initBaseInfo(env *Env) (err error) {
 	req := &InitBaseInfoReq{
 		Language:               env.Language,
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv_CheckDatabaseReq(t *testing.T) {
+	env := &Env{
+		DbType:      "MariaDB",
+		DbUsername:  "root",
+		DbPassword:  "root",
+		DbHost:      "db:3306",
+		DbName:      "answer",
+		DbTimeout:   "10",
+		DbCharset:   "utf8mb4",
+		DbCollation: "utf8mb4_unicode_ci",
+	}
+	req, err := env.checkDatabaseReq()
+	require.NoError(t, err)
+	assert.Equal(t, "mariadb", req.DbType)
+	assert.Equal(t, 10, req.DbTimeout)
+	assert.Equal(t, "utf8mb4", req.DbCharset)
+	assert.Equal(t, "utf8mb4_unicode_ci", req.DbCollation)
+	assert.Equal(t,
+		"root:root@tcp(db:3306)/answer?collation=utf8mb4_unicode_ci&parseTime=true&timeout=10s&charset=utf8mb4",
+		req.GetConnection())
+}
+
+func TestEnv_CheckDatabaseReq_PgSQLSchema(t *testing.T) {
+	env := &Env{
+		DbType:     "postgres",
+		DbUsername: "postgres",
+		DbPassword: "postgres",
+		DbHost:     "db:5432",
+		DbName:     "answer",
+		DbSchema:   "answer",
+	}
+	req, err := env.checkDatabaseReq()
+	require.NoError(t, err)
+	assert.Equal(t,
+		"host=db port=5432 user=postgres password=postgres dbname=answer search_path=answer connect_timeout=5 sslmode=disable",
+		req.GetConnection())
+}
+
+func TestEnv_CheckDatabaseReq_InvalidTimeout(t *testing.T) {
+	env := &Env{DbType: "mysql", DbTimeout: "5s"}
+	_, err := env.checkDatabaseReq()
+	assert.Error(t, err)
+}
@@ -21,13 +21,21 @@ package install
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/dir"
+	"github.com/go-sql-driver/mysql"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/xorm/schemas"
 )
@@ -41,7 +49,7 @@ type CheckConfigFileResp struct {
 
 // CheckDatabaseReq check database
 type CheckDatabaseReq struct {
-	DbType      string `validate:"required,oneof=postgres sqlite3 mysql" json:"db_type"`
+	DbType      string `validate:"required,oneof=postgres sqlite3 mysql mariadb" json:"db_type"`
 	DbUsername  string `json:"db_username"`
 	DbPassword  string `json:"db_password"`
 	DbHost      string `json:"db_host"`
@@ -52,6 +60,33 @@ type CheckDatabaseReq struct {
 	SslRootCert string `json:"ssl_root_cert"`
 	SslKey      string `json:"ssl_key"`
 	SslCert     string `json:"ssl_cert"`
+	// DbCharset MySQL charset, use DefaultMySQLCharset if not set
+	DbCharset string `validate:"omitempty,lte=32" json:"db_charset"`
+	// DbCollation MySQL collation, use the default collation of the charset if not set
+	DbCollation string `validate:"omitempty,lte=64" json:"db_collation"`
+	// DbSchema PostgreSQL schema the tables are created in, use DefaultPgSQLSchema if not set
+	DbSchema string `validate:"omitempty,lte=63" json:"db_schema"`
+	// DbTimeout connection timeout in seconds, use DefaultDBConnectTimeout if not set
+	DbTimeout int `validate:"omitempty,gte=1,lte=300" json:"db_timeout"`
+}
+
+// DefaultMySQLCharset utf8mb4 is required to store emoji
+const DefaultMySQLCharset = "utf8mb4"
+
+// MySQLCharsetAllowList supported MySQL charsets
+var MySQLCharsetAllowList = []string{"utf8mb4", "utf8mb3", "utf8"}
+
+// DefaultPgSQLSchema the default PostgreSQL schema
+const DefaultPgSQLSchema = "public"
+
+// DefaultDBConnectTimeout is short enough that a mistyped host fails the check quickly
+const DefaultDBConnectTimeout = 5
+
+func (r *CheckDatabaseReq) connectTimeout() int {
+	if r.DbTimeout > 0 {
+		return r.DbTimeout
+	}
+	return DefaultDBConnectTimeout
 }
 
 // GetConnection get connection string
@@ -59,30 +94,49 @@ func (r *CheckDatabaseReq) GetConnection() string {
 	if r.DbType == string(schemas.SQLITE) {
 		return r.DbFile
 	}
-	if r.DbType == string(schemas.MYSQL) {
-		return fmt.Sprintf("%s:%s@tcp(%s)/%s",
-			r.DbUsername, r.DbPassword, r.DbHost, r.DbName)
+	if r.isMySQL() {
+		cfg := mysql.NewConfig()
+		cfg.User = r.DbUsername
+		cfg.Passwd = r.DbPassword
+		if r.isUnixSocket() {
+			cfg.Net = "unix"
+			cfg.Addr = r.DbHost
+		} else {
+			host, port := parseMySQLHostPort(r.DbHost)
+			cfg.Net = "tcp"
+			cfg.Addr = net.JoinHostPort(host, port)
+		}
+		cfg.DBName = r.DbName
+		cfg.Timeout = time.Duration(r.connectTimeout()) * time.Second
+		cfg.ParseTime = true
+		cfg.Params = map[string]string{"charset": r.mysqlCharset()}
+		if len(r.DbCollation) > 0 {
+			cfg.Collation = r.DbCollation
+		}
+		return cfg.FormatDSN()
 	}
 	if r.DbType == string(schemas.POSTGRES) {
 		host, port := parsePgSQLHostPort(r.DbHost)
+		schema := r.DbSchema
+		if len(schema) == 0 {
+			schema = DefaultPgSQLSchema
+		}
+		connection := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s search_path=%s connect_timeout=%d",
+			quotePgSQLValue(host), quotePgSQLValue(port), quotePgSQLValue(r.DbUsername),
+			quotePgSQLValue(r.DbPassword), quotePgSQLValue(r.DbName), quotePgSQLValue(schema), r.connectTimeout())
 		switch {
 		case !r.Ssl:
-			return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-				host, port, r.DbUsername, r.DbPassword, r.DbName)
-		case r.SslMode == "require":
-			return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-				host, port, r.DbUsername, r.DbPassword, r.DbName, r.SslMode)
-		case r.SslMode == "verify-ca" || r.SslMode == "verify-full":
-			connection := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-				host, port, r.DbUsername, r.DbPassword, r.DbName, r.SslMode)
+			return connection + " sslmode=disable"
+		case r.SslMode == "require" || r.SslMode == "verify-ca" || r.SslMode == "verify-full":
+			connection += fmt.Sprintf(" sslmode=%s", r.SslMode)
 			if len(r.SslRootCert) > 0 && dir.CheckFileExist(r.SslRootCert) {
-				connection += fmt.Sprintf(" sslrootcert=%s", r.SslRootCert)
+				connection += fmt.Sprintf(" sslrootcert=%s", quotePgSQLValue(r.SslRootCert))
 			}
 			if len(r.SslCert) > 0 && dir.CheckFileExist(r.SslCert) {
-				connection += fmt.Sprintf(" sslcert=%s", r.SslCert)
+				connection += fmt.Sprintf(" sslcert=%s", quotePgSQLValue(r.SslCert))
 			}
 			if len(r.SslKey) > 0 && dir.CheckFileExist(r.SslKey) {
-				connection += fmt.Sprintf(" sslkey=%s", r.SslKey)
+				connection += fmt.Sprintf(" sslkey=%s", quotePgSQLValue(r.SslKey))
 			}
 			return connection
 		}
@@ -90,18 +144,147 @@ func (r *CheckDatabaseReq) GetConnection() string {
 	return ""
 }
 
+// Check check whether the database options are usable before trying to connect, e.g. the
+// unix socket exists, or for sqlite3 that the database file directory is writable
+func (r *CheckDatabaseReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if r.DbType == string(schemas.SQLITE) && !checkSQLiteFileDirWritable(r.DbFile) {
+		errField := &validator.FormErrorField{
+			ErrorField: "db_file",
+			ErrorMsg:   reason.DatabaseFileDirNotWritable,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.DatabaseFileDirNotWritable)
+	}
+	if r.isMySQL() {
+		if !slices.Contains(MySQLCharsetAllowList, r.mysqlCharset()) {
+			errField := &validator.FormErrorField{
+				ErrorField: "db_charset",
+				ErrorMsg:   reason.DatabaseCharsetUnsupported,
+			}
+			errFields = append(errFields, errField)
+			return errFields, errors.BadRequest(reason.DatabaseCharsetUnsupported)
+		}
+		if len(r.DbCollation) > 0 && !strings.HasPrefix(r.DbCollation, r.mysqlCharset()+"_") {
+			errField := &validator.FormErrorField{
+				ErrorField: "db_collation",
+				ErrorMsg:   reason.DatabaseCollationMismatch,
+			}
+			errFields = append(errFields, errField)
+			return errFields, errors.BadRequest(reason.DatabaseCollationMismatch)
+		}
+	}
+	if r.DbType == string(schemas.POSTGRES) && r.Ssl && len(r.SslCert) > 0 && len(r.SslKey) == 0 {
+		errField := &validator.FormErrorField{
+			ErrorField: "ssl_key",
+			ErrorMsg:   reason.DatabaseSSLKeyRequired,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.DatabaseSSLKeyRequired)
+	}
+	if r.DbType != string(schemas.SQLITE) && r.isUnixSocket() && !dir.CheckFileExist(r.unixSocketPath()) {
+		errField := &validator.FormErrorField{
+			ErrorField: "db_host",
+			ErrorMsg:   reason.DatabaseSocketNotFound,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.DatabaseSocketNotFound)
+	}
+	return nil, nil
+}
+
+// isMySQL MariaDB shares the MySQL driver and connection options
+func (r *CheckDatabaseReq) isMySQL() bool {
+	return r.DbType == string(schemas.MYSQL) || r.DbType == data.DriverMariaDB
+}
+
+func (r *CheckDatabaseReq) mysqlCharset() string {
+	if len(r.DbCharset) > 0 {
+		return strings.ToLower(r.DbCharset)
+	}
+	return DefaultMySQLCharset
+}
+
+// checkSQLiteFileDirWritable the migration fails halfway and leaves a broken database behind
+// if the directory of the database file can not be written, so check it in advance.
+// The directory is created when the database is initialized, so only the nearest existing
+// parent of it is checked here and nothing is left on disk if the installation is abandoned.
+func checkSQLiteFileDirWritable(dbFile string) bool {
+	dbFileDir := filepath.Clean(filepath.Dir(dbFile))
+	for {
+		info, err := os.Stat(dbFileDir)
+		if err == nil {
+			return info.IsDir() && dir.CheckDirWritable(dbFileDir)
+		}
+		if !os.IsNotExist(err) {
+			return false
+		}
+		parent := filepath.Dir(dbFileDir)
+		if parent == dbFileDir {
+			return false
+		}
+		dbFileDir = parent
+	}
+}
+
+// isUnixSocket an absolute path as the database host means connecting through a unix socket
+func (r *CheckDatabaseReq) isUnixSocket() bool {
+	return strings.HasPrefix(r.DbHost, "/")
+}
+
+// unixSocketPath get the socket file the driver will dial. PostgreSQL takes the socket
+// directory as host and looks for the `.s.PGSQL.<port>` file inside it.
+func (r *CheckDatabaseReq) unixSocketPath() string {
+	if r.DbType == string(schemas.POSTGRES) {
+		host, port := parsePgSQLHostPort(r.DbHost)
+		return filepath.Join(host, ".s.PGSQL."+port)
+	}
+	return r.DbHost
+}
+
+// quotePgSQLValue quote the value of a PostgreSQL key/value connection string.
+// Values containing spaces, quotes or backslashes must be wrapped in single quotes
+// with the quotes and backslashes escaped, otherwise the driver splits them apart.
+func quotePgSQLValue(value string) string {
+	if len(value) > 0 && !strings.ContainsAny(value, " '\\\t\n") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+func parseMySQLHostPort(dbHost string) (host string, port string) {
+	return parseDBHostPort(dbHost, "3306")
+}
+
 func parsePgSQLHostPort(dbHost string) (host string, port string) {
-	if strings.Contains(dbHost, ":") {
+	return parseDBHostPort(dbHost, "5432")
+}
+
+// parseDBHostPort split the database host into host and port, the port falls back to defaultPort if not given.
+// IPv6 literals must be bracketed to carry a port, e.g. [::1]:5432, an unbracketed IPv6 is kept whole.
+func parseDBHostPort(dbHost, defaultPort string) (host string, port string) {
+	switch {
+	case strings.HasPrefix(dbHost, "["):
+		if idx := strings.Index(dbHost, "]"); idx > 0 {
+			host = dbHost[1:idx]
+			port = strings.TrimPrefix(dbHost[idx+1:], ":")
+		} else {
+			host = dbHost
+		}
+	case strings.Count(dbHost, ":") > 1:
+		host = dbHost
+	case strings.Contains(dbHost, ":"):
 		idx := strings.LastIndex(dbHost, ":")
 		host, port = dbHost[:idx], dbHost[idx+1:]
-	} else if len(dbHost) > 0 {
+	default:
 		host = dbHost
 	}
 	if host == "" {
 		host = "127.0.0.1"
 	}
 	if port == "" {
-		port = "5432"
+		port = defaultPort
 	}
 	return host, port
 }
@@ -141,17 +324,56 @@ func (r *InitBaseInfoReq) Check() (errFields []*validator.FormErrorField, err er
 		errFields = append(errFields, errField)
 		return errFields, errors.BadRequest(reason.UsernameInvalid)
 	}
+	if err = r.checkAdminPassword(); err != nil {
+		errField := &validator.FormErrorField{
+			ErrorField: "password",
+			ErrorMsg:   err.Error(),
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(err.Error())
+	}
+	if isReservedSiteSubpath(r.SiteURL) {
+		errField := &validator.FormErrorField{
+			ErrorField: "site_url",
+			ErrorMsg:   reason.SiteURLSubpathReserved,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.SiteURLSubpathReserved)
+	}
 	return
 }
 
+// checkAdminPassword the admin password must contain both letters and digits,
+// set SKIP_PASSWORD_STRENGTH_CHECK to allow a weaker one, e.g. for development.
+func (r *InitBaseInfoReq) checkAdminPassword() error {
+	if err := checker.CheckPassword(r.AdminPassword); err != nil {
+		return err
+	}
+	if len(os.Getenv("SKIP_PASSWORD_STRENGTH_CHECK")) > 0 {
+		return nil
+	}
+	return checker.CheckPasswordStrength(r.AdminPassword)
+}
+
+// isReservedSiteSubpath whether the subpath of the site url starts with a segment used by the application routes
+func isReservedSiteSubpath(siteURL string) bool {
+	parsedUrl, err := url.Parse(siteURL)
+	if err != nil {
+		return false
+	}
+	firstSegment, _, _ := strings.Cut(strings.TrimLeft(parsedUrl.Path, "/"), "/")
+	if len(firstSegment) == 0 {
+		return false
+	}
+	return slices.Contains(constant.ReservedRouteSegments, strings.ToLower(firstSegment))
+}
+
+// FormatSiteUrl keep scheme://host:port/subpath as it is, only drop the query, fragment and trailing slash
 func (r *InitBaseInfoReq) FormatSiteUrl() {
 	parsedUrl, err := url.Parse(r.SiteURL)
 	if err != nil {
 		return
 	}
 	r.SiteURL = fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
-	if len(parsedUrl.Path) > 0 {
-		r.SiteURL += parsedUrl.Path
-		r.SiteURL = strings.TrimSuffix(r.SiteURL, "/")
-	}
+	r.SiteURL += strings.TrimRight(parsedUrl.EscapedPath(), "/")
 }
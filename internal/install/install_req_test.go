@@ -0,0 +1,288 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDatabaseReq_GetConnection_MySQLCredentials(t *testing.T) {
+	req := &CheckDatabaseReq{
+		DbType:     "mysql",
+		DbUsername: "root",
+		DbPassword: "p@ss:w/rd",
+		DbHost:     "db:3306",
+		DbName:     "answer",
+	}
+	cfg, err := mysql.ParseDSN(req.GetConnection())
+	require.NoError(t, err)
+	assert.Equal(t, "root", cfg.User)
+	assert.Equal(t, "p@ss:w/rd", cfg.Passwd)
+	assert.Equal(t, "db:3306", cfg.Addr)
+	assert.Equal(t, "answer", cfg.DBName)
+}
+
+func TestCheckDatabaseReq_GetConnection_PgSQLCredentials(t *testing.T) {
+	req := &CheckDatabaseReq{
+		DbType:     "postgres",
+		DbUsername: "postgres",
+		DbPassword: `it's a \secret`,
+		DbHost:     "db:5432",
+		DbName:     "answer",
+	}
+	assert.Equal(t,
+		`host=db port=5432 user=postgres password='it\'s a \\secret' dbname=answer search_path=public connect_timeout=5 sslmode=disable`,
+		req.GetConnection())
+}
+
+func TestQuotePgSQLValue(t *testing.T) {
+	assert.Equal(t, "answer", quotePgSQLValue("answer"))
+	assert.Equal(t, "''", quotePgSQLValue(""))
+	assert.Equal(t, "'a b'", quotePgSQLValue("a b"))
+}
+
+func TestCheckDatabaseReq_GetConnection_MariaDB(t *testing.T) {
+	req := &CheckDatabaseReq{
+		DbType:     "mariadb",
+		DbUsername: "root",
+		DbPassword: "root",
+		DbHost:     "db:3306",
+		DbName:     "answer",
+	}
+	assert.Equal(t, "root:root@tcp(db:3306)/answer?parseTime=true&timeout=5s&charset=utf8mb4", req.GetConnection())
+}
+
+func TestParseMySQLHostPort(t *testing.T) {
+	tests := []struct {
+		dbHost string
+		host   string
+		port   string
+	}{
+		{dbHost: "db", host: "db", port: "3306"},
+		{dbHost: "db:3307", host: "db", port: "3307"},
+		{dbHost: "", host: "127.0.0.1", port: "3306"},
+		{dbHost: "[::1]:3307", host: "::1", port: "3307"},
+	}
+	for _, tt := range tests {
+		host, port := parseMySQLHostPort(tt.dbHost)
+		assert.Equal(t, tt.host, host, tt.dbHost)
+		assert.Equal(t, tt.port, port, tt.dbHost)
+	}
+
+	req := &CheckDatabaseReq{DbType: "mysql", DbUsername: "root", DbPassword: "root", DbHost: "db:3307", DbName: "answer"}
+	assert.Equal(t, "root:root@tcp(db:3307)/answer?parseTime=true&timeout=5s&charset=utf8mb4", req.GetConnection())
+}
+
+func TestCheckDatabaseReq_GetConnection_UnixSocket(t *testing.T) {
+	req := &CheckDatabaseReq{DbType: "mysql", DbUsername: "root", DbPassword: "root", DbHost: "/run/mysqld/mysqld.sock", DbName: "answer"}
+	assert.Equal(t, "root:root@unix(/run/mysqld/mysqld.sock)/answer?parseTime=true&timeout=5s&charset=utf8mb4", req.GetConnection())
+	assert.Equal(t, "/run/mysqld/mysqld.sock", req.unixSocketPath())
+
+	req = &CheckDatabaseReq{DbType: "postgres", DbUsername: "postgres", DbPassword: "postgres", DbHost: "/var/run/postgresql", DbName: "answer"}
+	assert.Equal(t, "host=/var/run/postgresql port=5432 user=postgres password=postgres dbname=answer search_path=public connect_timeout=5 sslmode=disable", req.GetConnection())
+	assert.Equal(t, "/var/run/postgresql/.s.PGSQL.5432", req.unixSocketPath())
+
+	_, err := req.Check()
+	assert.Error(t, err)
+}
+
+func TestCheckDatabaseReq_GetConnection_Timeout(t *testing.T) {
+	req := &CheckDatabaseReq{DbType: "mysql", DbUsername: "root", DbPassword: "root", DbHost: "db", DbName: "answer", DbTimeout: 30}
+	cfg, err := mysql.ParseDSN(req.GetConnection())
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+
+	req.DbType = "postgres"
+	assert.Contains(t, req.GetConnection(), "connect_timeout=30")
+}
+
+func TestParsePgSQLHostPort(t *testing.T) {
+	tests := []struct {
+		dbHost string
+		host   string
+		port   string
+	}{
+		{dbHost: "db", host: "db", port: "5432"},
+		{dbHost: "db:5433", host: "db", port: "5433"},
+		{dbHost: "", host: "127.0.0.1", port: "5432"},
+		{dbHost: "[::1]:5432", host: "::1", port: "5432"},
+		{dbHost: "[::1]:5433", host: "::1", port: "5433"},
+		{dbHost: "::1", host: "::1", port: "5432"},
+		{dbHost: "[2001:db8::1]", host: "2001:db8::1", port: "5432"},
+		{dbHost: "fe80::1", host: "fe80::1", port: "5432"},
+	}
+	for _, tt := range tests {
+		host, port := parsePgSQLHostPort(tt.dbHost)
+		assert.Equal(t, tt.host, host, tt.dbHost)
+		assert.Equal(t, tt.port, port, tt.dbHost)
+	}
+}
+
+func TestCheckDatabaseReq_Check_SQLiteFileDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	req := &CheckDatabaseReq{DbType: "sqlite3", DbFile: filepath.Join(tmpDir, "data", "sub", "answer.db")}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(tmpDir, "data"))
+
+	notDir := filepath.Join(tmpDir, "file")
+	require.NoError(t, os.WriteFile(notDir, []byte{}, 0o644))
+	req.DbFile = filepath.Join(notDir, "answer.db")
+	errFields, err := req.Check()
+	assert.Error(t, err)
+	require.Len(t, errFields, 1)
+	assert.Equal(t, "db_file", errFields[0].ErrorField)
+}
+
+func TestCheckDatabaseReq_GetConnection_PgSQLSchema(t *testing.T) {
+	req := &CheckDatabaseReq{DbType: "postgres", DbUsername: "postgres", DbPassword: "postgres", DbHost: "db", DbName: "answer", DbSchema: "answer"}
+	assert.Contains(t, req.GetConnection(), " search_path=answer ")
+}
+
+func TestCheckDatabaseReq_MySQLCharset(t *testing.T) {
+	req := &CheckDatabaseReq{DbType: "mysql", DbUsername: "root", DbPassword: "root", DbHost: "db", DbName: "answer",
+		DbCharset: "utf8mb4", DbCollation: "utf8mb4_unicode_ci"}
+	cfg, err := mysql.ParseDSN(req.GetConnection())
+	require.NoError(t, err)
+	assert.Equal(t, "utf8mb4", cfg.Params["charset"])
+	assert.Equal(t, "utf8mb4_unicode_ci", cfg.Collation)
+	assert.True(t, cfg.ParseTime)
+	_, err = req.Check()
+	assert.NoError(t, err)
+
+	req.DbCollation = "latin1_swedish_ci"
+	errFields, err := req.Check()
+	assert.Error(t, err)
+	require.Len(t, errFields, 1)
+	assert.Equal(t, "db_collation", errFields[0].ErrorField)
+
+	req.DbCharset, req.DbCollation = "latin1", ""
+	errFields, err = req.Check()
+	assert.Error(t, err)
+	require.Len(t, errFields, 1)
+	assert.Equal(t, "db_charset", errFields[0].ErrorField)
+}
+
+func TestCheckDatabaseReq_PgSQLClientCert(t *testing.T) {
+	tmpDir := t.TempDir()
+	certFile, keyFile := filepath.Join(tmpDir, "client.crt"), filepath.Join(tmpDir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, []byte{}, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, []byte{}, 0o600))
+
+	req := &CheckDatabaseReq{DbType: "postgres", DbUsername: "postgres", DbPassword: "postgres", DbHost: "db", DbName: "answer",
+		Ssl: true, SslMode: "require", SslCert: certFile, SslKey: keyFile}
+	_, err := req.Check()
+	assert.NoError(t, err)
+	connection := req.GetConnection()
+	assert.Contains(t, connection, " sslmode=require")
+	assert.Contains(t, connection, " sslcert="+certFile)
+	assert.Contains(t, connection, " sslkey="+keyFile)
+
+	req.SslKey = ""
+	errFields, err := req.Check()
+	assert.Error(t, err)
+	require.Len(t, errFields, 1)
+	assert.Equal(t, "ssl_key", errFields[0].ErrorField)
+}
+
+func TestInitBaseInfoReq_FormatSiteUrl(t *testing.T) {
+	tests := []struct {
+		siteURL string
+		want    string
+	}{
+		{siteURL: "http://example.com", want: "http://example.com"},
+		{siteURL: "http://example.com/", want: "http://example.com"},
+		{siteURL: "http://example.com:8080", want: "http://example.com:8080"},
+		{siteURL: "http://example.com:8080/", want: "http://example.com:8080"},
+		{siteURL: "http://example.com:8080/answer", want: "http://example.com:8080/answer"},
+		{siteURL: "http://example.com:8080/answer/", want: "http://example.com:8080/answer"},
+		{siteURL: "http://example.com:8080/answer//", want: "http://example.com:8080/answer"},
+		{siteURL: "https://example.com/community/answer/", want: "https://example.com/community/answer"},
+		{siteURL: "https://example.com/my%20answer", want: "https://example.com/my%20answer"},
+		{siteURL: "http://[::1]:8080/answer?from=install", want: "http://[::1]:8080/answer"},
+	}
+	for _, tt := range tests {
+		req := &InitBaseInfoReq{SiteURL: tt.siteURL}
+		req.FormatSiteUrl()
+		assert.Equal(t, tt.want, req.SiteURL, tt.siteURL)
+	}
+}
+
+func TestInitBaseInfoReq_Check_ReservedSubpath(t *testing.T) {
+	tests := []struct {
+		siteURL  string
+		reserved bool
+	}{
+		{siteURL: "http://example.com", reserved: false},
+		{siteURL: "http://example.com/", reserved: false},
+		{siteURL: "http://example.com:8080/answer", reserved: false},
+		{siteURL: "http://example.com/community/api", reserved: false},
+		{siteURL: "http://example.com/api", reserved: true},
+		{siteURL: "http://example.com/Admin/", reserved: true},
+		{siteURL: "http://example.com/questions/answer", reserved: true},
+	}
+	for _, tt := range tests {
+		req := &InitBaseInfoReq{AdminName: "admin", AdminPassword: "answer123", SiteURL: tt.siteURL}
+		errFields, err := req.Check()
+		if !tt.reserved {
+			assert.NoError(t, err, tt.siteURL)
+			continue
+		}
+		assert.Error(t, err, tt.siteURL)
+		require.Len(t, errFields, 1)
+		assert.Equal(t, "site_url", errFields[0].ErrorField)
+	}
+}
+
+func TestInitBaseInfoReq_Check_AdminPassword(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{password: "12345678", valid: false},
+		{password: "password", valid: false},
+		{password: "pass word1", valid: false},
+		{password: "password1", valid: true},
+		{password: "Answer#2024", valid: true},
+	}
+	for _, tt := range tests {
+		req := &InitBaseInfoReq{AdminName: "admin", AdminPassword: tt.password, SiteURL: "http://example.com"}
+		errFields, err := req.Check()
+		if tt.valid {
+			assert.NoError(t, err, tt.password)
+			continue
+		}
+		assert.Error(t, err, tt.password)
+		require.Len(t, errFields, 1)
+		assert.Equal(t, "password", errFields[0].ErrorField)
+	}
+
+	t.Setenv("SKIP_PASSWORD_STRENGTH_CHECK", "true")
+	req := &InitBaseInfoReq{AdminName: "admin", AdminPassword: "12345678", SiteURL: "http://example.com"}
+	_, err := req.Check()
+	assert.NoError(t, err)
+}
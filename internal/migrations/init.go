@@ -68,6 +68,7 @@ func (m *Mentor) InitDB() error {
 	m.do("init version table", m.initVersionTable)
 	m.do("init admin user", m.initAdminUser)
 	m.do("init config", m.initConfig)
+	m.do("init unsubscribe secret", m.initUnsubscribeSecret)
 	m.do("init default privileges config", m.initDefaultRankPrivileges)
 	m.do("init role", m.initRole)
 	m.do("init power", m.initPower)
@@ -136,6 +137,16 @@ func (m *Mentor) initConfig() {
 	_, m.err = m.engine.Context(m.ctx).Insert(defaultConfigTable)
 }
 
+func (m *Mentor) initUnsubscribeSecret() {
+	secret, err := generateUnsubscribeSecret()
+	if err != nil {
+		m.err = err
+		return
+	}
+	_, m.err = m.engine.Context(m.ctx).ID(138).
+		Cols("value").Update(&entity.Config{Value: secret})
+}
+
 func (m *Mentor) initDefaultRankPrivileges() {
 	chooseOption := schema.DefaultPrivilegeOptions.Choose(schema.PrivilegeLevel2)
 	for _, privilege := range chooseOption.Privileges {
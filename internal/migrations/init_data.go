@@ -59,6 +59,7 @@ var (
 		&entity.SiteInfo{},
 		&entity.Tag{},
 		&entity.TagRel{},
+		&entity.TagModerator{},
 		&entity.Uniqid{},
 		&entity.User{},
 		&entity.Version{},
@@ -79,6 +80,18 @@ var (
 		&entity.APIKey{},
 		&entity.AIConversation{},
 		&entity.AIConversationRecord{},
+		&entity.UserTwoFactor{},
+		&entity.WebhookDeadLetter{},
+		&entity.Job{},
+		&entity.JobDeadLetter{},
+		&entity.QuestionBounty{},
+		&entity.NewQuestionDigest{},
+		&entity.AuditLog{},
+		&entity.UserMute{},
+		&entity.NotificationDigest{},
+		&entity.SavedSearch{},
+		&entity.UserTagRank{},
+		&entity.UserDataExport{},
 	}
 
 	roles = []*entity.Role{
@@ -129,6 +142,7 @@ var (
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
 		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
+		{ID: 42, Name: "moderation access", PowerType: permission.ModerationAccess, Description: "moderation access"},
 	}
 
 	rolePowerRels = []*entity.RolePowerRel{
@@ -174,6 +188,7 @@ var (
 		{RoleID: 2, PowerType: permission.AnswerUnDelete},
 		{RoleID: 2, PowerType: permission.QuestionUnDelete},
 		{RoleID: 2, PowerType: permission.TagUnDelete},
+		{RoleID: 2, PowerType: permission.ModerationAccess},
 
 		{RoleID: 3, PowerType: permission.QuestionAdd},
 		{RoleID: 3, PowerType: permission.QuestionEdit},
@@ -216,6 +231,7 @@ var (
 		{RoleID: 3, PowerType: permission.AnswerUnDelete},
 		{RoleID: 3, PowerType: permission.QuestionUnDelete},
 		{RoleID: 3, PowerType: permission.TagUnDelete},
+		{RoleID: 3, PowerType: permission.ModerationAccess},
 	}
 
 	adminUserRoleRel = &entity.UserRoleRel{
@@ -354,6 +370,15 @@ var (
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
 		{ID: 131, Key: "ai_config.provider", Value: `[{"default_api_host":"https://api.openai.com","display_name":"OpenAI","name":"openai"},{"default_api_host":"https://generativelanguage.googleapis.com","display_name":"Gemini","name":"gemini"},{"default_api_host":"https://api.anthropic.com","display_name":"Anthropic","name":"anthropic"}]`},
+		{ID: 132, Key: "question.bounty_offered", Value: `0`},
+		{ID: 133, Key: "question.bounty_refunded", Value: `0`},
+		{ID: 134, Key: "answer.bounty_awarded", Value: `0`},
+		{ID: 135, Key: "rank.question.bounty", Value: `75`},
+		{ID: 136, Key: "user.flag.reasons", Value: `["reason.spam","reason.rude_or_abusive","reason.something"]`},
+		{ID: 137, Key: "reason.stale", Value: `{"name":"stale","description":"This question has had no answers and no activity for a long time."}`},
+		{ID: 138, Key: "email.unsubscribe_secret", Value: ``},
+		{ID: 139, Key: "email.templates", Value: `[]`},
+		{ID: 140, Key: "rank.post.wiki_edit", Value: `10`},
 	}
 
 	defaultBadgeGroupTable = []*entity.BadgeGroup{
@@ -510,5 +535,71 @@ var (
 			Handler:      "ReachQuestionVote",
 			Param:        `{"amount":"50"}`,
 		},
+		{
+			Name:         "badge.default_badges.tag_contributor.name",
+			Icon:         "tag-fill",
+			Description:  "badge.default_badges.tag_contributor.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 3,
+			Level:        entity.BadgeLevelBronze,
+			Single:       entity.BadgeMultiAward,
+			Handler:      "ReachTagReputation",
+			Param:        `{"amount":"100"}`,
+		},
+		{
+			Name:         "badge.default_badges.tag_expert.name",
+			Icon:         "tag-fill",
+			Description:  "badge.default_badges.tag_expert.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 3,
+			Level:        entity.BadgeLevelSilver,
+			Single:       entity.BadgeMultiAward,
+			Handler:      "ReachTagReputation",
+			Param:        `{"amount":"400"}`,
+		},
+		{
+			Name:         "badge.default_badges.tag_master.name",
+			Icon:         "tag-fill",
+			Description:  "badge.default_badges.tag_master.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 3,
+			Level:        entity.BadgeLevelGold,
+			Single:       entity.BadgeMultiAward,
+			Handler:      "ReachTagReputation",
+			Param:        `{"amount":"1000"}`,
+		},
+		{
+			Name:         "badge.default_badges.regular.name",
+			Icon:         "calendar-check-fill",
+			Description:  "badge.default_badges.regular.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 2,
+			Level:        entity.BadgeLevelBronze,
+			Single:       entity.BadgeSingleAward,
+			Handler:      entity.BadgeRuleHandler,
+			Param:        `{"metric":"active_days","amount":"10"}`,
+		},
+		{
+			Name:         "badge.default_badges.enthusiast.name",
+			Icon:         "calendar-check-fill",
+			Description:  "badge.default_badges.enthusiast.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 2,
+			Level:        entity.BadgeLevelSilver,
+			Single:       entity.BadgeSingleAward,
+			Handler:      entity.BadgeRuleHandler,
+			Param:        `{"metric":"active_days","amount":"30"}`,
+		},
+		{
+			Name:         "badge.default_badges.fanatic.name",
+			Icon:         "calendar-check-fill",
+			Description:  "badge.default_badges.fanatic.desc",
+			Status:       entity.BadgeStatusActive,
+			BadgeGroupID: 2,
+			Level:        entity.BadgeLevelGold,
+			Single:       entity.BadgeSingleAward,
+			Handler:      entity.BadgeRuleHandler,
+			Param:        `{"metric":"active_days","amount":"100"}`,
+		},
 	}
 )
@@ -110,6 +110,32 @@ var migrations = []Migration{
 	NewMigration("v2.0.1", "change avatar type to text", updateAvatarType, false),
 	NewMigration("v2.0.2", "add reasoning content to ai conversation record", addAIConversationReasoningContent, false),
 	NewMigration("v2.0.3", "add require email verification login setting", addRequireEmailVerification, true),
+	NewMigration("v2.0.4", "add user two factor", addUserTwoFactor, false),
+	NewMigration("v2.0.5", "add webhook dead letter", addWebhookDeadLetter, false),
+	NewMigration("v2.0.6", "add question bounty", addQuestionBounty, true),
+	NewMigration("v2.0.7", "add new question digest", addNewQuestionDigest, false),
+	NewMigration("v2.0.8", "add post deleted time", addPostDeletedAt, false),
+	NewMigration("v2.0.9", "add audit log", addAuditLog, false),
+	NewMigration("v2.0.10", "add user flag reasons", addUserFlagReasons, false),
+	NewMigration("v2.0.11", "hash api keys", hashAPIKeys, false),
+	NewMigration("v2.0.12", "add question scheduled time", addQuestionScheduledAt, false),
+	NewMigration("v2.0.13", "add question pin expiry and tag pin", addQuestionTagPin, false),
+	NewMigration("v2.0.14", "add stale question close reason", addStaleQuestionCloseReason, false),
+	NewMigration("v2.0.15", "add comment parent id", addCommentParentID, false),
+	NewMigration("v2.0.16", "add user mute", addUserMute, false),
+	NewMigration("v2.0.17", "add unsubscribe secret config", addUnsubscribeSecretConfig, false),
+	NewMigration("v2.0.18", "add notification digest", addNotificationDigest, false),
+	NewMigration("v2.0.19", "add custom email templates config", addEmailTemplatesConfig, false),
+	NewMigration("v2.0.20", "add job queue", addJobQueue, false),
+	NewMigration("v2.0.21", "add trending score to question table", addQuestionTrendingScore, false),
+	NewMigration("v2.0.22", "add saved search", addSavedSearch, false),
+	NewMigration("v2.0.23", "add user tag rank and tag badges", addUserTagRank, true),
+	NewMigration("v2.0.24", "add badges awarded by rules", addBadgeRuleBadges, true),
+	NewMigration("v2.0.25", "add reject reason of revision", addRevisionRejectReason, false),
+	NewMigration("v2.0.26", "add community wiki posts", addCommunityWiki, false),
+	NewMigration("v2.0.27", "add tag moderators", addTagModerator, false),
+	NewMigration("v2.0.28", "add moderation access permission", addModerationAccessPermission, true),
+	NewMigration("v2.0.29", "add user data export", addUserDataExport, false),
 }
 
 func GetMigrations() []Migration {
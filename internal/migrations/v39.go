@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addPostDeletedAt(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.Question), new(entity.Answer)); err != nil {
+		return fmt.Errorf("sync question and answer table failed: %w", err)
+	}
+
+	// the posts deleted before have no deleted time, keep them for a whole retention window from now
+	now := time.Now()
+	_, err := x.Context(ctx).Where("status = ?", entity.QuestionStatusDeleted).
+		Cols("deleted_at").Update(&entity.Question{DeletedAt: now})
+	if err != nil {
+		return fmt.Errorf("update question deleted_at failed: %w", err)
+	}
+	_, err = x.Context(ctx).Where("status = ?", entity.AnswerStatusDeleted).
+		Cols("deleted_at").Update(&entity.Answer{DeletedAt: now})
+	if err != nil {
+		return fmt.Errorf("update answer deleted_at failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/pkg/token"
+	"xorm.io/xorm"
+)
+
+// hashAPIKeys add the columns of the personal api keys and replace the plain keys with their hashes
+func hashAPIKeys(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.APIKey)); err != nil {
+		return fmt.Errorf("sync api key table failed: %w", err)
+	}
+
+	keys := make([]*entity.APIKey, 0)
+	if err := x.Context(ctx).Where("key_hint = ?", "").Find(&keys); err != nil {
+		return fmt.Errorf("get api keys failed: %w", err)
+	}
+	for _, key := range keys {
+		if !token.IsAPIKey(key.AccessKey) {
+			continue
+		}
+		_, err := x.Context(ctx).ID(key.ID).Cols("access_key", "key_hint").Update(&entity.APIKey{
+			AccessKey: token.HashAPIKey(key.AccessKey),
+			KeyHint:   token.MaskAPIKey(key.AccessKey),
+		})
+		if err != nil {
+			return fmt.Errorf("hash api key failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/entity"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+func addCommentParentID(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.Comment)); err != nil {
+		return fmt.Errorf("sync comment table failed: %w", err)
+	}
+
+	// the existing replies are attached to the top-level comment of the reply chain
+	replies := make([]*entity.Comment, 0)
+	err := x.Context(ctx).Cols("id", "reply_comment_id").
+		Where(builder.NotNull{"reply_comment_id"}).And(builder.IsNull{"parent_id"}).Find(&replies)
+	if err != nil {
+		return fmt.Errorf("get reply comments failed: %w", err)
+	}
+	replyTo := make(map[string]string, len(replies))
+	for _, reply := range replies {
+		replyTo[reply.ID] = reply.GetReplyCommentID()
+	}
+	for _, reply := range replies {
+		parentID := reply.GetReplyCommentID()
+		for range len(replies) {
+			next, ok := replyTo[parentID]
+			if !ok {
+				break
+			}
+			parentID = next
+		}
+		c := &entity.Comment{}
+		c.SetParentID(parentID)
+		if _, err = x.Context(ctx).ID(reply.ID).Cols("parent_id").Update(c); err != nil {
+			return fmt.Errorf("update comment parent id failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func TestAddUnsubscribeSecretConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *entity.Config
+		kept     bool
+	}{
+		{name: "adds the secret for missing row"},
+		{name: "fills the empty secret", existing: &entity.Config{ID: 138, Key: "email.unsubscribe_secret"}},
+		{
+			name:     "keeps the generated secret",
+			existing: &entity.Config{ID: 138, Key: "email.unsubscribe_secret", Value: "generated-secret"},
+			kept:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := xorm.NewEngine("sqlite", ":memory:")
+			require.NoError(t, err)
+			defer func() {
+				_ = x.Close()
+			}()
+			require.NoError(t, x.Sync(new(entity.Config)))
+			if tt.existing != nil {
+				_, err = x.Insert(tt.existing)
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, addUnsubscribeSecretConfig(context.Background(), x))
+
+			c := &entity.Config{ID: 138}
+			exist, err := x.Get(c)
+			require.NoError(t, err)
+			require.True(t, exist)
+			if tt.kept {
+				assert.Equal(t, tt.existing.Value, c.Value)
+			} else {
+				assert.Len(t, c.Value, 64)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/service/activity_type"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+func addUserTagRank(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.UserTagRank)); err != nil {
+		return fmt.Errorf("sync user_tag_rank table failed: %w", err)
+	}
+	if err := addTagReputationBadges(ctx, x); err != nil {
+		return err
+	}
+	return initUserTagRank(ctx, x)
+}
+
+// addTagReputationBadges add the default badges awarded for the reputation in the tags
+func addTagReputationBadges(ctx context.Context, x *xorm.Engine) error {
+	uniqueIDRepo := unique.NewUniqueIDRepo(&data.Data{DB: x})
+	for _, badge := range defaultBadgeTable {
+		if badge.Handler != "ReachTagReputation" {
+			continue
+		}
+		exist, err := x.Context(ctx).Exist(&entity.Badge{Name: badge.Name})
+		if err != nil {
+			return fmt.Errorf("get badge failed: %w", err)
+		}
+		if exist {
+			continue
+		}
+		badge.ID, err = uniqueIDRepo.GenUniqueIDStr(ctx, new(entity.Badge).TableName())
+		if err != nil {
+			return err
+		}
+		if _, err = x.Context(ctx).Insert(badge); err != nil {
+			return fmt.Errorf("insert badge failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// initUserTagRank sum up the votes received by the questions and their answers in the tags of the questions
+func initUserTagRank(ctx context.Context, x *xorm.Engine) error {
+	activityTypes := make([]int, 0)
+	err := x.Context(ctx).Table("config").In("`key`", activity_type.TagReputationActivityTypeList).
+		Cols("id").Find(&activityTypes)
+	if err != nil {
+		return fmt.Errorf("get activity types failed: %w", err)
+	}
+	if len(activityTypes) == 0 {
+		return nil
+	}
+
+	type userTagRank struct {
+		UserID string `xorm:"user_id"`
+		TagID  string `xorm:"tag_id"`
+		Rank   int    `xorm:"rank_amount"`
+	}
+	rows := make([]*userTagRank, 0)
+	err = x.Context(ctx).Table("activity").
+		Select("activity.user_id, tag_rel.tag_id, SUM(activity.`rank`) AS rank_amount").
+		Join("LEFT", "answer", "answer.id = activity.object_id").
+		Join("INNER", "tag_rel", "tag_rel.object_id = COALESCE(answer.question_id, activity.object_id)").
+		Where(builder.Eq{"activity.has_rank": 1, "activity.cancelled": entity.ActivityAvailable}).
+		And(builder.In("activity.activity_type", activityTypes)).
+		And(builder.In("tag_rel.status", entity.TagRelStatusAvailable, entity.TagRelStatusHide)).
+		GroupBy("activity.user_id, tag_rel.tag_id").
+		Find(&rows)
+	if err != nil {
+		return fmt.Errorf("sum user tag rank failed: %w", err)
+	}
+
+	if _, err = x.Context(ctx).Where("1 = 1").Delete(&entity.UserTagRank{}); err != nil {
+		return fmt.Errorf("clean user tag rank failed: %w", err)
+	}
+	beans := make([]*entity.UserTagRank, 0, 100)
+	for i, row := range rows {
+		if row.Rank != 0 {
+			beans = append(beans, &entity.UserTagRank{UserID: row.UserID, TagID: row.TagID, Rank: row.Rank})
+		}
+		if len(beans) == 0 || (len(beans) < 100 && i < len(rows)-1) {
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(beans); err != nil {
+			return fmt.Errorf("insert user tag rank failed: %w", err)
+		}
+		beans = beans[:0]
+	}
+	return nil
+}
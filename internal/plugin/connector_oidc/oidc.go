@@ -0,0 +1,345 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package connector_oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	slugName          = "connector_oidc"
+	connectorSlugName = "oidc"
+
+	defaultConnectorName = "OpenID Connect"
+	defaultScopes        = "openid profile email"
+	httpTimeout          = 10 * time.Second
+
+	i18nPrefix = "plugin.connector_oidc.backend."
+)
+
+// Config the plugin config saved from the admin plugin settings
+type Config struct {
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scopes       string `json:"scopes"`
+	// the claims that are mapped to the local user, the nested claims are separated by dots
+	ClaimUsername    string `json:"claim_username"`
+	ClaimDisplayName string `json:"claim_display_name"`
+	ClaimEmail       string `json:"claim_email"`
+	ClaimAvatar      string `json:"claim_avatar"`
+}
+
+// Connector is the generic OpenID Connect connector, the endpoints and the signing keys are read from
+// the discovery document of the issuer.
+type Connector struct {
+	lock     sync.RWMutex
+	config   *Config
+	provider *provider
+}
+
+func init() {
+	plugin.Register(&Connector{config: &Config{}})
+}
+
+func (c *Connector) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator(i18nPrefix + "info.name"),
+		SlugName:    slugName,
+		Description: plugin.MakeTranslator(i18nPrefix + "info.description"),
+		Author:      "answerdev",
+		Version:     "1.0.0",
+		Link:        "https://github.com/apache/answer",
+	}
+}
+
+func (c *Connector) ConnectorLogoSVG() string {
+	return oidcLogo
+}
+
+// ConnectorName the name configured by the admin is shown on the login button, e.g. Keycloak
+func (c *Connector) ConnectorName() plugin.Translator {
+	return plugin.Translator{Fn: func(ctx *plugin.GinContext) string {
+		c.lock.RLock()
+		defer c.lock.RUnlock()
+		if len(c.config.Name) > 0 {
+			return c.config.Name
+		}
+		return defaultConnectorName
+	}}
+}
+
+func (c *Connector) ConnectorSlugName() string {
+	return connectorSlugName
+}
+
+func (c *Connector) ConnectorSender(ctx *plugin.GinContext, receiverURL string) (redirectURL string) {
+	config, p := c.getProvider()
+	if p == nil {
+		log.Errorf("oidc connector is not configured")
+		return ""
+	}
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	state := ctx.Query("state")
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", config.ClientID)
+	q.Set("redirect_uri", receiverURL)
+	q.Set("scope", config.scopes())
+	q.Set("state", state)
+	q.Set("nonce", config.nonce(state))
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode()
+}
+
+func (c *Connector) ConnectorReceiver(ctx *plugin.GinContext, receiverURL string) (
+	userInfo plugin.ExternalLoginUserInfo, err error) {
+	config, p := c.getProvider()
+	if p == nil {
+		return userInfo, fmt.Errorf("oidc connector is not configured")
+	}
+	if errCode := ctx.Query("error"); len(errCode) > 0 {
+		return userInfo, fmt.Errorf("oidc authorization failed: %s %s", errCode, ctx.Query("error_description"))
+	}
+	code := ctx.Query("code")
+	if len(code) == 0 {
+		return userInfo, fmt.Errorf("oidc authorization code is missing")
+	}
+
+	token, err := c.exchangeCode(ctx, p, config, code, receiverURL)
+	if err != nil {
+		return userInfo, err
+	}
+	claims, err := p.verifyIDToken(ctx, token.IDToken, config.ClientID, config.nonce(ctx.Query("state")))
+	if err != nil {
+		return userInfo, err
+	}
+	if err = c.mergeUserinfo(ctx, p, token.AccessToken, claims); err != nil {
+		return userInfo, err
+	}
+
+	metaInfo, _ := json.Marshal(claims)
+	userInfo = plugin.ExternalLoginUserInfo{
+		ExternalID:  claimString(claims, "sub"),
+		Username:    claimString(claims, config.ClaimUsername),
+		DisplayName: claimString(claims, config.ClaimDisplayName),
+		Avatar:      claimString(claims, config.ClaimAvatar),
+		MetaInfo:    string(metaInfo),
+	}
+	// the email is used to bind the existing user, so it must be verified by the issuer
+	if verified, _ := claims["email_verified"].(bool); verified || claimString(claims, "email_verified") == "true" {
+		userInfo.Email = claimString(claims, config.ClaimEmail)
+	}
+	return userInfo, nil
+}
+
+type tokenResp struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+}
+
+func (c *Connector) exchangeCode(ctx context.Context, p *provider, config *Config, code, receiverURL string) (
+	*tokenResp, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", receiverURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+	token := &tokenResp{}
+	if err = p.doJSON(req, token); err != nil {
+		return nil, fmt.Errorf("exchange oidc authorization code failed: %w", err)
+	}
+	if len(token.IDToken) == 0 {
+		return nil, fmt.Errorf("oidc token response has no id token, the openid scope is required")
+	}
+	return token, nil
+}
+
+// mergeUserinfo the claims that are not in the id token are read from the userinfo endpoint,
+// some issuers only put the profile claims there
+func (c *Connector) mergeUserinfo(ctx context.Context, p *provider, accessToken string, claims map[string]any) error {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return err
+	}
+	if len(d.UserinfoEndpoint) == 0 || len(accessToken) == 0 {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.UserinfoEndpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	userinfo := make(map[string]any)
+	if err = p.doJSON(req, &userinfo); err != nil {
+		return fmt.Errorf("get oidc userinfo failed: %w", err)
+	}
+	if claimString(userinfo, "sub") != claimString(claims, "sub") {
+		return fmt.Errorf("subject of the userinfo does not match the id token")
+	}
+	for k, v := range userinfo {
+		if _, ok := claims[k]; !ok {
+			claims[k] = v
+		}
+	}
+	return nil
+}
+
+func (c *Connector) getProvider() (*Config, *provider) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.config, c.provider
+}
+
+func (c *Connector) ConfigFields() []plugin.ConfigField {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	input := func(name string, value string, required bool, inputType plugin.InputType) plugin.ConfigField {
+		return plugin.ConfigField{
+			Name:        name,
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator(i18nPrefix + "config." + name + ".title"),
+			Description: plugin.MakeTranslator(i18nPrefix + "config." + name + ".description"),
+			Required:    required,
+			UIOptions:   plugin.ConfigFieldUIOptions{InputType: inputType},
+			Value:       value,
+		}
+	}
+	return []plugin.ConfigField{
+		input("name", c.config.Name, false, plugin.InputTypeText),
+		input("issuer", c.config.Issuer, true, plugin.InputTypeUrl),
+		input("client_id", c.config.ClientID, true, plugin.InputTypeText),
+		input("client_secret", c.config.ClientSecret, true, plugin.InputTypePassword),
+		input("scopes", c.config.Scopes, false, plugin.InputTypeText),
+		input("claim_username", c.config.ClaimUsername, false, plugin.InputTypeText),
+		input("claim_display_name", c.config.ClaimDisplayName, false, plugin.InputTypeText),
+		input("claim_email", c.config.ClaimEmail, false, plugin.InputTypeText),
+		input("claim_avatar", c.config.ClaimAvatar, false, plugin.InputTypeText),
+	}
+}
+
+func (c *Connector) ConfigReceiver(config []byte) error {
+	conf := &Config{}
+	if err := json.Unmarshal(config, conf); err != nil {
+		return err
+	}
+	conf.setDefaults()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.config = conf
+	c.provider = nil
+	if len(conf.Issuer) > 0 && len(conf.ClientID) > 0 {
+		c.provider = newProvider(conf.Issuer, &http.Client{Timeout: httpTimeout})
+	}
+	return nil
+}
+
+func (c *Config) setDefaults() {
+	if len(c.ClaimUsername) == 0 {
+		c.ClaimUsername = "preferred_username"
+	}
+	if len(c.ClaimDisplayName) == 0 {
+		c.ClaimDisplayName = "name"
+	}
+	if len(c.ClaimEmail) == 0 {
+		c.ClaimEmail = "email"
+	}
+	if len(c.ClaimAvatar) == 0 {
+		c.ClaimAvatar = "picture"
+	}
+}
+
+// scopes the openid scope is always requested, otherwise the issuer does not return the id token
+func (c *Config) scopes() string {
+	scopes := strings.Fields(strings.ReplaceAll(c.Scopes, ",", " "))
+	if len(scopes) == 0 {
+		scopes = strings.Fields(defaultScopes)
+	}
+	for _, scope := range scopes {
+		if scope == "openid" {
+			return strings.Join(scopes, " ")
+		}
+	}
+	return strings.Join(append([]string{"openid"}, scopes...), " ")
+}
+
+// nonce the nonce is bound to the oauth state by the client secret, so it is not stored
+func (c *Config) nonce(state string) string {
+	mac := hmac.New(sha256.New, []byte(c.ClientSecret))
+	mac.Write([]byte(state))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// claimString get the string claim by the path, the nested claims are separated by dots
+func claimString(claims map[string]any, path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	var value any = claims
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+		value = m[key]
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool, float64:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+const oidcLogo = `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="currentColor"><path d="M11.1 2.7v18.6l3.3-1.6V1.1zM14.4 7.6v2.2c1.3.2 2.5.7 3.4 1.3l-2.1 1.2 6.3 1.4-.5-4.6-1.9 1.1c-1.5-1-3.2-1.6-5.2-1.8zM11.1 7.6c-5.1.6-9 3.6-9 7.2 0 3.7 4.1 6.8 9.4 7.2v-2.1c-3.6-.4-6.2-2.5-6.2-5.1 0-2.4 2.4-4.4 5.8-5z"/></svg>`
@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package connector_oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ti := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	ti.server = httptest.NewServer(mux)
+	t.Cleanup(ti.server.Close)
+
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 ti.server.URL,
+			"authorization_endpoint": ti.server.URL + "/auth",
+			"token_endpoint":         ti.server.URL + "/token",
+			"userinfo_endpoint":      ti.server.URL + "/userinfo",
+			"jwks_uri":               ti.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "answer" || secret != "secret" ||
+			r.FormValue("code") != "test-code" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, map[string]string{"access_token": "test-access-token", "id_token": ti.sign(t, "RS256", ti.claims)})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"sub": ti.claims["sub"], "picture": "https://example.com/avatar.png"})
+	})
+	return ti
+}
+
+func (ti *testIssuer) sign(t *testing.T, alg string, claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": "test-key", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, ti.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestConnector(t *testing.T, issuer string) *Connector {
+	c := &Connector{config: &Config{}}
+	config, _ := json.Marshal(&Config{Issuer: issuer, ClientID: "answer", ClientSecret: "secret",
+		ClaimUsername: "attributes.login"})
+	require.NoError(t, c.ConfigReceiver(config))
+	return c
+}
+
+func newTestGinContext(rawQuery string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/callback?"+rawQuery, nil)
+	return ctx
+}
+
+func TestConnector_Login(t *testing.T) {
+	ti := newTestIssuer(t)
+	c := newTestConnector(t, ti.server.URL+"/")
+
+	redirectURL := c.ConnectorSender(newTestGinContext("state=test-state"), "https://answer.test/callback")
+	assert.True(t, strings.HasPrefix(redirectURL, ti.server.URL+"/auth?"))
+	assert.Contains(t, redirectURL, "scope=openid+profile+email")
+	assert.Contains(t, redirectURL, "nonce="+c.config.nonce("test-state"))
+
+	ti.claims = map[string]any{
+		"iss":            ti.server.URL,
+		"aud":            "answer",
+		"sub":            "user-1",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          c.config.nonce("test-state"),
+		"name":           "Test User",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"attributes":     map[string]any{"login": "tester"},
+	}
+	userInfo, err := c.ConnectorReceiver(newTestGinContext("state=test-state&code=test-code"),
+		"https://answer.test/callback")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userInfo.ExternalID)
+	assert.Equal(t, "tester", userInfo.Username)
+	assert.Equal(t, "Test User", userInfo.DisplayName)
+	assert.Equal(t, "user@example.com", userInfo.Email)
+	assert.Equal(t, "https://example.com/avatar.png", userInfo.Avatar)
+
+	// the email is not trusted if it is not verified
+	ti.claims["email_verified"] = false
+	userInfo, err = c.ConnectorReceiver(newTestGinContext("state=test-state&code=test-code"), "")
+	require.NoError(t, err)
+	assert.Empty(t, userInfo.Email)
+
+	// the id token of another login is rejected
+	_, err = c.ConnectorReceiver(newTestGinContext("state=other-state&code=test-code"), "")
+	assert.Error(t, err)
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	ti := newTestIssuer(t)
+	p := newProvider(ti.server.URL, http.DefaultClient)
+	claims := func() map[string]any {
+		return map[string]any{"iss": ti.server.URL, "aud": []string{"answer"}, "sub": "user-1", "nonce": "n",
+			"exp": time.Now().Add(time.Minute).Unix()}
+	}
+
+	_, err := p.verifyIDToken(t.Context(), ti.sign(t, "RS256", claims()), "answer", "n")
+	assert.NoError(t, err)
+
+	expired := claims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	_, err = p.verifyIDToken(t.Context(), ti.sign(t, "RS256", expired), "answer", "n")
+	assert.Error(t, err)
+
+	_, err = p.verifyIDToken(t.Context(), ti.sign(t, "RS256", claims()), "other-client", "n")
+	assert.Error(t, err)
+
+	token := ti.sign(t, "RS256", claims())
+	parts := strings.Split(token, ".")
+	tampered := claims()
+	tampered["sub"] = "admin"
+	payload, _ := json.Marshal(tampered)
+	_, err = p.verifyIDToken(t.Context(),
+		parts[0]+"."+base64.RawURLEncoding.EncodeToString(payload)+"."+parts[2], "answer", "n")
+	assert.Error(t, err)
+
+	header, _ := json.Marshal(map[string]string{"alg": "none"})
+	_, err = p.verifyIDToken(t.Context(),
+		base64.RawURLEncoding.EncodeToString(header)+"."+parts[1]+".", "answer", "n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,222 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// unavailableBackoff is how long the client fails fast after Elasticsearch was unreachable,
+	// so that every search does not wait for the timeout before falling back to the database.
+	unavailableBackoff = 30 * time.Second
+)
+
+// errUnavailable is returned when Elasticsearch is unreachable
+var errUnavailable = errors.New("elasticsearch is unavailable")
+
+// client is a minimal Elasticsearch REST client
+type client struct {
+	endpoints  []string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu               sync.Mutex
+	next             int
+	unavailableUntil time.Time
+}
+
+func newClient(endpoints []string, username, password string) *client {
+	return &client{
+		endpoints:  endpoints,
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// endpoint returns the endpoints round-robin
+func (c *client) endpoint() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.endpoints) == 0 {
+		return "", fmt.Errorf("elasticsearch endpoint is not configured")
+	}
+	if time.Now().Before(c.unavailableUntil) {
+		return "", errUnavailable
+	}
+	e := c.endpoints[c.next%len(c.endpoints)]
+	c.next++
+	return e, nil
+}
+
+func (c *client) markUnavailable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unavailableUntil = time.Now().Add(unavailableBackoff)
+}
+
+// do send the request, the response body is decoded into result if it is not nil.
+// It returns the status code, a 404 response is not treated as an error.
+func (c *client) do(ctx context.Context, method, path string, body, result any) (statusCode int, err error) {
+	endpoint, err := c.endpoint()
+	if err != nil {
+		return 0, err
+	}
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		content, err := json.Marshal(b)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(endpoint, "/")+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasSuffix(path, "/_bulk") {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.username) > 0 {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.markUnavailable()
+		return 0, fmt.Errorf("%w: %v", errUnavailable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.markUnavailable()
+	}
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusNotFound {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return resp.StatusCode, fmt.Errorf("elasticsearch %s %s failed: %d %s", method, path, resp.StatusCode, content)
+	}
+	if result != nil && resp.StatusCode != http.StatusNotFound {
+		if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// indexExists check whether the index exists
+func (c *client) indexExists(ctx context.Context, index string) (bool, error) {
+	statusCode, err := c.do(ctx, http.MethodHead, "/"+url.PathEscape(index), nil, nil)
+	if err != nil {
+		return false, err
+	}
+	return statusCode == http.StatusOK, nil
+}
+
+func (c *client) createIndex(ctx context.Context, index string, mapping any) error {
+	_, err := c.do(ctx, http.MethodPut, "/"+url.PathEscape(index), mapping, nil)
+	return err
+}
+
+func (c *client) deleteIndex(ctx context.Context, index string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/"+url.PathEscape(index), nil, nil)
+	return err
+}
+
+func (c *client) indexDocument(ctx context.Context, index, id string, doc any) error {
+	_, err := c.do(ctx, http.MethodPut, "/"+url.PathEscape(index)+"/_doc/"+url.PathEscape(id), doc, nil)
+	return err
+}
+
+func (c *client) deleteDocument(ctx context.Context, index, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/"+url.PathEscape(index)+"/_doc/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+// bulkIndex index the documents in one request
+func (c *client) bulkIndex(ctx context.Context, index string, ids []string, docs []any) error {
+	buf := &bytes.Buffer{}
+	for i, doc := range docs {
+		action := map[string]any{"index": map[string]any{"_index": index, "_id": ids[i]}}
+		for _, line := range []any{action, doc} {
+			content, err := json.Marshal(line)
+			if err != nil {
+				return err
+			}
+			buf.Write(content)
+			buf.WriteByte('\n')
+		}
+	}
+	result := &bulkResponse{}
+	if _, err := c.do(ctx, http.MethodPost, "/_bulk", buf.Bytes(), result); err != nil {
+		return err
+	}
+	if result.Errors {
+		return fmt.Errorf("elasticsearch bulk index has failed items")
+	}
+	return nil
+}
+
+func (c *client) search(ctx context.Context, index string, query map[string]any) (resp *searchResponse, err error) {
+	resp = &searchResponse{}
+	statusCode, err := c.do(ctx, http.MethodPost, "/"+url.PathEscape(index)+"/_search", query, resp)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("elasticsearch index %s not found", index)
+	}
+	return resp, nil
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID     string   `json:"_id"`
+			Source document `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
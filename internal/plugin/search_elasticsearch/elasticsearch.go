@@ -0,0 +1,343 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	slugName = "search_elasticsearch"
+
+	defaultIndexName = "answer"
+	defaultAnalyzer  = "standard"
+	syncPageSize     = 100
+
+	i18nPrefix = "plugin.search_elasticsearch.backend."
+)
+
+// Config the plugin config saved from the admin plugin settings
+type Config struct {
+	Endpoints string `json:"endpoints"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	IndexName string `json:"index_name"`
+	Analyzer  string `json:"analyzer"`
+}
+
+// SearchEngine is the Elasticsearch search plugin, questions and answers are indexed
+// on write and the index can be rebuilt from the database.
+type SearchEngine struct {
+	lock   sync.RWMutex
+	config *Config
+	client *client
+}
+
+func init() {
+	plugin.Register(&SearchEngine{config: &Config{}})
+}
+
+func (s *SearchEngine) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator(i18nPrefix + "info.name"),
+		SlugName:    slugName,
+		Description: plugin.MakeTranslator(i18nPrefix + "info.description"),
+		Author:      "answerdev",
+		Version:     "1.0.0",
+		Link:        "https://github.com/apache/answer",
+	}
+}
+
+func (s *SearchEngine) Description() plugin.SearchDesc {
+	return plugin.SearchDesc{
+		Icon: elasticsearchIcon,
+		Link: "https://www.elastic.co/elasticsearch",
+	}
+}
+
+func (s *SearchEngine) ConfigFields() []plugin.ConfigField {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	analyzerOptions := make([]plugin.ConfigFieldOption, 0)
+	for _, analyzer := range []string{"standard", "english", "smartcn", "ik_max_word"} {
+		analyzerOptions = append(analyzerOptions, plugin.ConfigFieldOption{
+			Label: plugin.MakeTranslator(i18nPrefix + "config.analyzer.options." + analyzer),
+			Value: analyzer,
+		})
+	}
+	return []plugin.ConfigField{
+		{
+			Name:        "endpoints",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator(i18nPrefix + "config.endpoints.title"),
+			Description: plugin.MakeTranslator(i18nPrefix + "config.endpoints.description"),
+			Required:    true,
+			UIOptions: plugin.ConfigFieldUIOptions{
+				InputType:   plugin.InputTypeText,
+				Placeholder: plugin.MakeTranslator(i18nPrefix + "config.endpoints.placeholder"),
+			},
+			Value: s.config.Endpoints,
+		},
+		{
+			Name:  "username",
+			Type:  plugin.ConfigTypeInput,
+			Title: plugin.MakeTranslator(i18nPrefix + "config.username.title"),
+			UIOptions: plugin.ConfigFieldUIOptions{
+				InputType: plugin.InputTypeText,
+			},
+			Value: s.config.Username,
+		},
+		{
+			Name:  "password",
+			Type:  plugin.ConfigTypeInput,
+			Title: plugin.MakeTranslator(i18nPrefix + "config.password.title"),
+			UIOptions: plugin.ConfigFieldUIOptions{
+				InputType: plugin.InputTypePassword,
+			},
+			Value: s.config.Password,
+		},
+		{
+			Name:        "index_name",
+			Type:        plugin.ConfigTypeInput,
+			Title:       plugin.MakeTranslator(i18nPrefix + "config.index_name.title"),
+			Description: plugin.MakeTranslator(i18nPrefix + "config.index_name.description"),
+			UIOptions: plugin.ConfigFieldUIOptions{
+				InputType: plugin.InputTypeText,
+			},
+			Value: s.config.IndexName,
+		},
+		{
+			Name:        "analyzer",
+			Type:        plugin.ConfigTypeSelect,
+			Title:       plugin.MakeTranslator(i18nPrefix + "config.analyzer.title"),
+			Description: plugin.MakeTranslator(i18nPrefix + "config.analyzer.description"),
+			Options:     analyzerOptions,
+			Value:       s.config.Analyzer,
+		},
+	}
+}
+
+func (s *SearchEngine) ConfigReceiver(config []byte) error {
+	c := &Config{}
+	if err := json.Unmarshal(config, c); err != nil {
+		return err
+	}
+	endpoints := make([]string, 0)
+	for _, endpoint := range strings.Split(c.Endpoints, ",") {
+		if endpoint = strings.TrimSpace(endpoint); len(endpoint) > 0 {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.config = c
+	s.client = newClient(endpoints, c.Username, c.Password)
+	return nil
+}
+
+func (s *SearchEngine) getClient() (*client, string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.client == nil {
+		return nil, "", fmt.Errorf("elasticsearch search plugin is not configured")
+	}
+	return s.client, s.indexName(), nil
+}
+
+// CheckHealth the cluster is not ready if it is unreachable or its status is red
+func (s *SearchEngine) CheckHealth(ctx context.Context) error {
+	c, _, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	health := &struct {
+		Status string `json:"status"`
+	}{}
+	if _, err = c.do(ctx, http.MethodGet, "/_cluster/health", nil, health); err != nil {
+		return err
+	}
+	if health.Status == "red" {
+		return fmt.Errorf("elasticsearch cluster status is red")
+	}
+	return nil
+}
+
+func (s *SearchEngine) indexName() string {
+	if len(s.config.IndexName) == 0 {
+		return defaultIndexName
+	}
+	return s.config.IndexName
+}
+
+func (s *SearchEngine) analyzer() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if len(s.config.Analyzer) == 0 {
+		return defaultAnalyzer
+	}
+	return s.config.Analyzer
+}
+
+// RegisterSyncer it is called when the plugin config is saved,
+// the index is created and filled from the database if it does not exist.
+func (s *SearchEngine) RegisterSyncer(ctx context.Context, syncer plugin.SearchSyncer) {
+	go func() {
+		ctx := context.Background()
+		created, err := s.ensureIndex(ctx)
+		if err != nil {
+			log.Errorf("create elasticsearch index failed: %v", err)
+			return
+		}
+		if !created {
+			return
+		}
+		if err := s.syncAll(ctx, syncer); err != nil {
+			log.Errorf("sync contents to elasticsearch failed: %v", err)
+		}
+	}()
+}
+
+// ensureIndex create the index with mapping if it does not exist
+func (s *SearchEngine) ensureIndex(ctx context.Context) (created bool, err error) {
+	c, index, err := s.getClient()
+	if err != nil {
+		return false, err
+	}
+	exist, err := c.indexExists(ctx, index)
+	if err != nil || exist {
+		return false, err
+	}
+	if err = c.createIndex(ctx, index, indexMapping(s.analyzer())); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResetIndex drop the index and create it again with the current mapping
+func (s *SearchEngine) ResetIndex(ctx context.Context) error {
+	c, index, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	if err = c.deleteIndex(ctx, index); err != nil {
+		return err
+	}
+	return c.createIndex(ctx, index, indexMapping(s.analyzer()))
+}
+
+func (s *SearchEngine) syncAll(ctx context.Context, syncer plugin.SearchSyncer) error {
+	getPages := []func(ctx context.Context, page, pageSize int) ([]*plugin.SearchContent, error){
+		syncer.GetQuestionsPage,
+		syncer.GetAnswersPage,
+	}
+	for _, getPage := range getPages {
+		for page := 1; ; page++ {
+			contents, err := getPage(ctx, page, syncPageSize)
+			if err != nil {
+				return err
+			}
+			if len(contents) == 0 {
+				break
+			}
+			if err = s.bulkUpdateContents(ctx, contents); err != nil {
+				return err
+			}
+		}
+	}
+	log.Info("sync contents to elasticsearch finished")
+	return nil
+}
+
+func (s *SearchEngine) bulkUpdateContents(ctx context.Context, contents []*plugin.SearchContent) error {
+	c, index, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(contents))
+	docs := make([]any, 0, len(contents))
+	for _, content := range contents {
+		ids = append(ids, content.ObjectID)
+		docs = append(docs, newDocument(content))
+	}
+	return c.bulkIndex(ctx, index, ids, docs)
+}
+
+func (s *SearchEngine) SearchContents(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, "", cond)
+}
+
+func (s *SearchEngine) SearchQuestions(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, constant.QuestionObjectType, cond)
+}
+
+func (s *SearchEngine) SearchAnswers(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, constant.AnswerObjectType, cond)
+}
+
+func (s *SearchEngine) search(ctx context.Context, objectType string, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	c, index, err := s.getClient()
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := c.search(ctx, index, buildQuery(objectType, cond))
+	if err != nil {
+		return nil, 0, err
+	}
+	res = make([]plugin.SearchResult, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		res = append(res, plugin.SearchResult{
+			ID:   hit.Source.ObjectID,
+			Type: hit.Source.Type,
+		})
+	}
+	return res, resp.Hits.Total.Value, nil
+}
+
+func (s *SearchEngine) UpdateContent(ctx context.Context, content *plugin.SearchContent) error {
+	c, index, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	return c.indexDocument(ctx, index, content.ObjectID, newDocument(content))
+}
+
+func (s *SearchEngine) DeleteContent(ctx context.Context, objectID string) error {
+	c, index, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	return c.deleteDocument(ctx, index, objectID)
+}
+
+const elasticsearchIcon = `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor"><path d="M13.394 0C8.683 0 4.609 2.716 2.644 6.667h15.641a4.77 4.77 0 0 0 3.073-1.11A11.97 11.97 0 0 0 13.394 0zM1.804 8.889a12 12 0 0 0 0 6.222h14.7a3.111 3.111 0 1 0 0-6.222zm.84 8.444C4.61 21.283 8.684 24 13.395 24a11.97 11.97 0 0 0 7.964-3.557 4.77 4.77 0 0 0-3.073-1.11z"/></svg>`
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildQuery_QuestionFilters(t *testing.T) {
+	query := buildQuery(constant.QuestionObjectType, &plugin.SearchBasicCond{
+		Page:             2,
+		PageSize:         20,
+		Words:            []string{"golang", "generics"},
+		TagIDs:           [][]string{{"1", "2"}, {"3"}},
+		VoteAmount:       5,
+		ViewAmount:       -1,
+		AnswerAmount:     0,
+		QuestionAccepted: plugin.AcceptedCondFalse,
+		Order:            plugin.SearchScoreOrder,
+		CreatedAfter:     1700000000,
+	})
+	content, err := json.Marshal(query)
+	require.NoError(t, err)
+
+	assert.Equal(t, 20, query["from"])
+	assert.JSONEq(t, `{"bool":{
+		"must":[{"multi_match":{"query":"golang generics","fields":["title^3","content"]}}],
+		"filter":[
+			{"terms":{"status":["1","2"]}},
+			{"term":{"type":"question"}},
+			{"terms":{"tags":["1","2"]}},
+			{"terms":{"tags":["3"]}},
+			{"range":{"score":{"gte":5}}},
+			{"range":{"created":{"gte":1700000000}}},
+			{"term":{"answers":0}},
+			{"term":{"hasAccepted":false}}
+		]}}`, string(mustMarshal(t, query["query"])))
+	assert.Contains(t, string(content), `"sort":[{"score":"desc"},{"created":"desc"}]`)
+}
+
+func TestBuildQuery_AllContentsIgnoreTypedFilters(t *testing.T) {
+	query := buildQuery("", &plugin.SearchBasicCond{
+		Words:        []string{"answer"},
+		VoteAmount:   -1,
+		ViewAmount:   10,
+		AnswerAmount: 3,
+		QuestionID:   "10010000000000001",
+	})
+	assert.JSONEq(t, `{"bool":{
+		"must":[{"multi_match":{"query":"answer","fields":["title^3","content"]}}],
+		"filter":[{"terms":{"status":["1","2"]}}]}}`, string(mustMarshal(t, query["query"])))
+	assert.Equal(t, 0, query["from"])
+	assert.Equal(t, 10, query["size"])
+}
+
+func TestClient_FailFastWhenUnavailable(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newClient([]string{server.URL}, "", "")
+	_, err := c.search(context.TODO(), "answer", map[string]any{})
+	assert.Error(t, err)
+
+	_, err = c.search(context.TODO(), "answer", map[string]any{})
+	assert.True(t, errors.Is(err, errUnavailable))
+	assert.Equal(t, 1, calls)
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	content, err := json.Marshal(v)
+	require.NoError(t, err)
+	return content
+}
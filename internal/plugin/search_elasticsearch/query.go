@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_elasticsearch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/plugin"
+)
+
+// document is the indexed document of a question or an answer
+type document struct {
+	ObjectID    string   `json:"objectID"`
+	Title       string   `json:"title"`
+	Type        string   `json:"type"`
+	Content     string   `json:"content"`
+	Answers     int64    `json:"answers"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	QuestionID  string   `json:"questionID"`
+	UserID      string   `json:"userID"`
+	Views       int64    `json:"views"`
+	Created     int64    `json:"created"`
+	Active      int64    `json:"active"`
+	Score       int64    `json:"score"`
+	HasAccepted bool     `json:"hasAccepted"`
+}
+
+func newDocument(content *plugin.SearchContent) *document {
+	tags := content.Tags
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	return &document{
+		ObjectID:    content.ObjectID,
+		Title:       content.Title,
+		Type:        content.Type,
+		Content:     content.Content,
+		Answers:     content.Answers,
+		Status:      strconv.Itoa(int(content.Status)),
+		Tags:        tags,
+		QuestionID:  content.QuestionID,
+		UserID:      content.UserID,
+		Views:       content.Views,
+		Created:     content.Created,
+		Active:      content.Active,
+		Score:       content.Score,
+		HasAccepted: content.HasAccepted,
+	}
+}
+
+// visibleStatus the status of the contents that can be found, the same as the database search
+// which only excludes the deleted and pending contents
+var visibleStatus = []string{"1", "2"}
+
+// indexMapping returns the index mapping, title and content are analyzed with the analyzer,
+// the ids, tags and status are keywords for filtering.
+func indexMapping(analyzer string) map[string]any {
+	text := map[string]any{"type": "text", "analyzer": analyzer}
+	keyword := map[string]any{"type": "keyword"}
+	long := map[string]any{"type": "long"}
+	date := map[string]any{"type": "date", "format": "epoch_second"}
+	return map[string]any{
+		"mappings": map[string]any{
+			"dynamic": "strict",
+			"properties": map[string]any{
+				"objectID":    keyword,
+				"title":       text,
+				"type":        keyword,
+				"content":     text,
+				"answers":     long,
+				"status":      keyword,
+				"tags":        keyword,
+				"questionID":  keyword,
+				"userID":      keyword,
+				"views":       long,
+				"created":     date,
+				"active":      date,
+				"score":       long,
+				"hasAccepted": map[string]any{"type": "boolean"},
+			},
+		},
+	}
+}
+
+// buildQuery converts the search condition to the Elasticsearch query DSL
+func buildQuery(objectType string, cond *plugin.SearchBasicCond) map[string]any {
+	filter := []any{
+		terms("status", visibleStatus),
+	}
+	must := make([]any, 0)
+
+	if words := strings.TrimSpace(strings.Join(cond.Words, " ")); len(words) > 0 {
+		must = append(must, map[string]any{
+			"multi_match": map[string]any{
+				"query":  words,
+				"fields": []string{"title^3", "content"},
+			},
+		})
+	}
+	if len(objectType) > 0 {
+		filter = append(filter, term("type", objectType))
+	}
+	// every tag group must match, a group matches if any of the tag or its synonyms matches
+	for _, tagGroup := range cond.TagIDs {
+		if len(tagGroup) > 0 {
+			filter = append(filter, terms("tags", tagGroup))
+		}
+	}
+	if len(cond.UserID) > 0 {
+		filter = append(filter, term("userID", cond.UserID))
+	}
+	if cond.VoteAmount == 0 {
+		filter = append(filter, term("score", 0))
+	} else if cond.VoteAmount > 0 {
+		filter = append(filter, rangeGte("score", cond.VoteAmount))
+	}
+	if cond.CreatedAfter > 0 || cond.CreatedBefore > 0 {
+		created := map[string]any{}
+		if cond.CreatedAfter > 0 {
+			created["gte"] = cond.CreatedAfter
+		}
+		if cond.CreatedBefore > 0 {
+			created["lt"] = cond.CreatedBefore
+		}
+		filter = append(filter, map[string]any{"range": map[string]any{"created": created}})
+	}
+
+	switch objectType {
+	case constant.QuestionObjectType:
+		if cond.ViewAmount > -1 {
+			filter = append(filter, rangeGte("views", cond.ViewAmount))
+		}
+		if cond.AnswerAmount == 0 {
+			filter = append(filter, term("answers", 0))
+		} else if cond.AnswerAmount > 0 {
+			filter = append(filter, rangeGte("answers", cond.AnswerAmount))
+		}
+		if cond.QuestionAccepted != plugin.AcceptedCondAll {
+			filter = append(filter, term("hasAccepted", cond.QuestionAccepted == plugin.AcceptedCondTrue))
+		}
+	case constant.AnswerObjectType:
+		if len(cond.QuestionID) > 0 {
+			filter = append(filter, term("questionID", cond.QuestionID))
+		}
+		if cond.AnswerAccepted != plugin.AcceptedCondAll {
+			filter = append(filter, term("hasAccepted", cond.AnswerAccepted == plugin.AcceptedCondTrue))
+		}
+	}
+
+	page, pageSize := cond.Page, cond.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	return map[string]any{
+		"from":             (page - 1) * pageSize,
+		"size":             pageSize,
+		"track_total_hits": true,
+		"_source":          []string{"objectID", "type"},
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must":   must,
+				"filter": filter,
+			},
+		},
+		"sort": buildSort(cond.Order),
+	}
+}
+
+func buildSort(order plugin.SearchOrderCond) []any {
+	switch order {
+	case plugin.SearchActiveOrder:
+		return []any{map[string]any{"active": "desc"}}
+	case plugin.SearchScoreOrder:
+		return []any{map[string]any{"score": "desc"}, map[string]any{"created": "desc"}}
+	case plugin.SearchRelevanceOrder:
+		return []any{"_score", map[string]any{"created": "desc"}}
+	default:
+		return []any{map[string]any{"created": "desc"}}
+	}
+}
+
+func term(field string, value any) map[string]any {
+	return map[string]any{"term": map[string]any{field: value}}
+}
+
+func terms(field string, values []string) map[string]any {
+	return map[string]any{"terms": map[string]any{field: values}}
+}
+
+func rangeGte(field string, value int) map[string]any {
+	return map[string]any{"range": map[string]any{field: map[string]any{"gte": value}}}
+}
@@ -34,9 +34,12 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
 	"github.com/apache/answer/internal/service/activity_common"
+	"github.com/apache/answer/internal/service/activity_type"
 	"github.com/apache/answer/internal/service/noticequeue"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/xorm"
 )
@@ -75,6 +78,17 @@ func (ar *AnswerActivityRepo) SaveAcceptAnswerActivity(ctx context.Context, op *
 			return nil, err
 		}
 
+		for _, act := range op.Activities {
+			act.Rank = rank.ApplyReputationRules(&plugin.ReputationEvent{
+				EventType:    plugin.ReputationEventAccept,
+				ActivityType: act.ActivityTypeKey,
+				ActorUserID:  op.TriggerUserID,
+				TargetUserID: act.ActivityUserID,
+				ObjectID:     op.AnswerObjectID,
+				Delta:        act.Rank,
+			})
+		}
+
 		err = ar.saveActivitiesAvailable(session, op)
 		if err != nil {
 			return nil, err
@@ -104,7 +118,7 @@ func (ar *AnswerActivityRepo) SaveCancelAcceptAnswerActivity(ctx context.Context
 	}
 	var userIDs []string
 	for _, act := range activities {
-		if act.Cancelled == entity.ActivityCancelled {
+		if act.Cancelled != entity.ActivityAvailable {
 			continue
 		}
 		userIDs = append(userIDs, act.UserID)
@@ -142,6 +156,106 @@ func (ar *AnswerActivityRepo) SaveCancelAcceptAnswerActivity(ctx context.Context
 	return nil
 }
 
+// CancelObjectRankActivities cancel the available rank activities of the deleted object and rollback the users' rank.
+// These activities are marked as cancelled by deletion, so they can be recovered with the object.
+func (ar *AnswerActivityRepo) CancelObjectRankActivities(ctx context.Context, objectID string) (err error) {
+	return ar.switchObjectRankActivities(ctx, objectID, entity.ActivityAvailable, entity.ActivityCancelledByDeletion)
+}
+
+// RecoverObjectRankActivities recover the rank activities cancelled by the deletion of the object and reapply the users' rank.
+func (ar *AnswerActivityRepo) RecoverObjectRankActivities(ctx context.Context, objectID string) (err error) {
+	return ar.switchObjectRankActivities(ctx, objectID, entity.ActivityCancelledByDeletion, entity.ActivityAvailable)
+}
+
+func (ar *AnswerActivityRepo) switchObjectRankActivities(ctx context.Context, objectID string, from, to int) (err error) {
+	objectID = uid.DeShortID(objectID)
+	bountyActivityTypes, err := ar.getBountyActivityTypes(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		activities := make([]*entity.Activity, 0)
+		err = session.Where(builder.Eq{"object_id": objectID, "has_rank": 1, "cancelled": from}).
+			And(builder.Neq{"`rank`": 0}).And(builder.NotIn("activity_type", bountyActivityTypes)).Find(&activities)
+		if err != nil {
+			return nil, err
+		}
+		if len(activities) == 0 {
+			return nil, nil
+		}
+
+		ids := make([]string, 0, len(activities))
+		userDelta := make(map[string]int)
+		userIDs := make([]string, 0)
+		for _, act := range activities {
+			ids = append(ids, act.ID)
+			if _, ok := userDelta[act.UserID]; !ok {
+				userIDs = append(userIDs, act.UserID)
+			}
+			if to == entity.ActivityAvailable {
+				userDelta[act.UserID] += act.Rank
+			} else {
+				userDelta[act.UserID] -= act.Rank
+			}
+		}
+
+		userInfoMapping, err := ar.acquireUserInfo(session, userIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		bean := &entity.Activity{Cancelled: to}
+		if to != entity.ActivityAvailable {
+			bean.CancelledAt = time.Now()
+		}
+		if _, err = session.In("id", ids).Cols("cancelled", "cancelled_at").Update(bean); err != nil {
+			return nil, err
+		}
+
+		for _, userID := range userIDs {
+			user := userInfoMapping[userID]
+			if user == nil {
+				continue
+			}
+			if err = ar.userRankRepo.ChangeUserRank(ctx, session, userID, user.Rank, userDelta[userID]); err != nil {
+				return nil, err
+			}
+		}
+		for _, act := range activities {
+			delta := act.Rank
+			if to != entity.ActivityAvailable {
+				delta = -act.Rank
+			}
+			if err = ar.userRankRepo.ChangeUserTagRank(ctx, session, act.UserID, act.ObjectID, act.ActivityType,
+				delta); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// getBountyActivityTypes the escrow and the settlement of the bounties are not switched with the post,
+// the bounty of the deleted question is settled by the bounty service instead
+func (ar *AnswerActivityRepo) getBountyActivityTypes(ctx context.Context) (activityTypes []int, err error) {
+	for _, key := range []string{
+		activity_type.QuestionBountyOffered, activity_type.QuestionBountyRefunded, activity_type.AnswerBountyAwarded,
+	} {
+		activityType, err := ar.activityRepo.GetActivityTypeByConfigKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		activityTypes = append(activityTypes, activityType)
+	}
+	return activityTypes, nil
+}
+
 func (ar *AnswerActivityRepo) acquireUserInfo(session *xorm.Session, userIDs []string) (map[string]*entity.User, error) {
 	us := make([]*entity.User, 0)
 	err := session.In("id", userIDs).ForUpdate().Find(&us)
@@ -224,7 +338,7 @@ func (ar *AnswerActivityRepo) cancelActivities(session *xorm.Session, activities
 			return fmt.Errorf("%s activity not exist", act.ID)
 		}
 		//  If this activity is already cancelled, set activity rank to 0
-		if t.Cancelled == entity.ActivityCancelled {
+		if t.Cancelled != entity.ActivityAvailable {
 			act.Rank = 0
 		}
 		if _, err = session.ID(act.ID).Cols("cancelled", "cancelled_at").
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// FlagActivityRepo flag accepted
+type FlagActivityRepo struct {
+	data          *data.Data
+	userRankRepo  rank.UserRankRepo
+	configService *config.ConfigService
+}
+
+const (
+	ObjectReported = "object.reported"
+)
+
+// NewFlagActivityRepo new repository
+func NewFlagActivityRepo(
+	data *data.Data,
+	userRankRepo rank.UserRankRepo,
+	configService *config.ConfigService,
+) activity.FlagActivityRepo {
+	return &FlagActivityRepo{
+		data:          data,
+		userRankRepo:  userRankRepo,
+		configService: configService,
+	}
+}
+
+// FlagAccepted the core does not change the reputation for the accepted flags, the reputation rules decide it.
+// The author of the object is only changed once no matter how many flags of the object are accepted.
+func (ar *FlagActivityRepo) FlagAccepted(ctx context.Context, act *schema.FlagAcceptedActivity) (err error) {
+	if len(act.UserID) == 0 || act.UserID == "0" {
+		return nil
+	}
+	cfg, err := ar.configService.GetConfigByKey(ctx, ObjectReported)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		user := &entity.User{}
+		exist, err := session.ID(act.UserID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("user not exist")
+		}
+
+		exist, err = session.
+			And(builder.Eq{"user_id": act.UserID}).
+			And(builder.Eq{"activity_type": cfg.ID}).
+			And(builder.Eq{"object_id": act.ObjectID}).
+			And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			Exist(&entity.Activity{})
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, nil
+		}
+
+		delta := rank.ApplyReputationRules(&plugin.ReputationEvent{
+			EventType:    plugin.ReputationEventFlag,
+			ActivityType: ObjectReported,
+			ActorUserID:  act.TriggerUserID,
+			TargetUserID: act.UserID,
+			ObjectID:     act.ObjectID,
+		})
+		if delta == 0 {
+			return nil, nil
+		}
+
+		err = ar.userRankRepo.ChangeUserRank(ctx, session, act.UserID, user.Rank, delta)
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.Activity{
+			UserID:           act.UserID,
+			TriggerUserID:    converter.StringToInt64(act.TriggerUserID),
+			ObjectID:         act.ObjectID,
+			OriginalObjectID: act.ObjectID,
+			ActivityType:     cfg.ID,
+			Rank:             delta,
+			HasRank:          1,
+		})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/xorm"
 )
@@ -107,6 +108,14 @@ func (ar *ReviewActivityRepo) Review(ctx context.Context, act *schema.PassReview
 			return nil, nil
 		}
 
+		addActivity.Rank = rank.ApplyReputationRules(&plugin.ReputationEvent{
+			EventType:    plugin.ReputationEventEdit,
+			ActivityType: EditAccepted,
+			ActorUserID:  act.TriggerUserID,
+			TargetUserID: addActivity.UserID,
+			ObjectID:     addActivity.ObjectID,
+			Delta:        addActivity.Rank,
+		})
 		err = ar.userRankRepo.ChangeUserRank(ctx, session, addActivity.UserID, user.Rank, addActivity.Rank)
 		if err != nil {
 			return nil, err
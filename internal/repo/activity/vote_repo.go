@@ -34,6 +34,8 @@ import (
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/pkg/obj"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
 
 	"xorm.io/builder"
 
@@ -96,6 +98,17 @@ func (vr *VoteRepo) Vote(ctx context.Context, op *schema.VoteOperationInfo) (err
 			return nil, err
 		}
 
+		for _, activity := range op.Activities {
+			activity.Rank = rank.ApplyReputationRules(&plugin.ReputationEvent{
+				EventType:    plugin.ReputationEventVote,
+				ActivityType: activity.ActivityTypeKey,
+				ActorUserID:  op.OperatingUserID,
+				TargetUserID: activity.ActivityUserID,
+				ObjectID:     op.ObjectID,
+				Delta:        activity.Rank,
+			})
+		}
+
 		err = vr.setActivityRankToZeroIfUserReachLimit(ctx, session, op, userInfoMapping, maxDailyRank)
 		if err != nil {
 			return nil, err
@@ -139,7 +152,7 @@ func (vr *VoteRepo) CancelVote(ctx context.Context, op *schema.VoteOperationInfo
 	}
 	var userIDs []string
 	for _, activity := range activities {
-		if activity.Cancelled == entity.ActivityCancelled {
+		if activity.Cancelled != entity.ActivityAvailable {
 			continue
 		}
 		userIDs = append(userIDs, activity.UserID)
@@ -281,6 +294,11 @@ func (vr *VoteRepo) changeUserRank(ctx context.Context, session *xorm.Session,
 			log.Error(err)
 			return err
 		}
+		if err = vr.userRankRepo.ChangeUserTagRank(ctx, session,
+			activity.ActivityUserID, op.ObjectID, activity.ActivityType, activity.Rank); err != nil {
+			log.Error(err)
+			return err
+		}
 	}
 	return nil
 }
@@ -301,6 +319,11 @@ func (vr *VoteRepo) rollbackUserRank(ctx context.Context, session *xorm.Session,
 			log.Error(err)
 			return err
 		}
+		if err = vr.userRankRepo.ChangeUserTagRank(ctx, session,
+			activity.UserID, activity.ObjectID, activity.ActivityType, -activity.Rank); err != nil {
+			log.Error(err)
+			return err
+		}
 	}
 	return nil
 }
@@ -373,7 +396,7 @@ func (vr *VoteRepo) cancelActivities(session *xorm.Session, activities []*entity
 			return fmt.Errorf("%s activity not exist", activity.ID)
 		}
 		//  If this activity is already cancelled, set activity rank to 0
-		if t.Cancelled == entity.ActivityCancelled {
+		if t.Cancelled != entity.ActivityAvailable {
 			activity.Rank = 0
 		}
 		if _, err = session.ID(activity.ID).Cols("cancelled", "cancelled_at").
@@ -449,10 +472,32 @@ func (vr *VoteRepo) updateVotes(ctx context.Context, objectID, objectType string
 	}
 	if err != nil {
 		log.Error(err)
+		return
 	}
+	vr.removeQuestionPayloadCache(ctx, objectID, objectType)
 	return
 }
 
+// removeQuestionPayloadCache remove the cached payloads of the question that shows the vote count of the object
+func (vr *VoteRepo) removeQuestionPayloadCache(ctx context.Context, objectID, objectType string) {
+	questionID := uid.DeShortID(objectID)
+	switch objectType {
+	case constant.QuestionObjectType:
+	case constant.AnswerObjectType:
+		answerID := questionID
+		exist, err := vr.data.DB.Context(ctx).Table(new(entity.Answer).TableName()).
+			Where("id = ?", answerID).Cols("question_id").Get(&questionID)
+		if err != nil || !exist {
+			return
+		}
+	default:
+		return
+	}
+	if err := vr.data.Cache.Del(ctx, constant.QuestionPayloadVersionCacheKey+questionID); err != nil {
+		log.Errorf("remove question payload cache failed, question id: %s, err: %v", questionID, err)
+	}
+}
+
 func (vr *VoteRepo) sendAchievementNotification(ctx context.Context, activityUserID, objectUserID, objectID string) {
 	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
 	if err != nil {
@@ -32,12 +32,16 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity_common"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/jobqueue"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/unique"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // answerRepo answer repository
@@ -80,6 +84,7 @@ func (ar *answerRepo) AddAnswer(ctx context.Context, answer *entity.Answer) (err
 		answer.ID = uid.EnShortID(answer.ID)
 		answer.QuestionID = uid.EnShortID(answer.QuestionID)
 	}
+	_ = ar.removeQuestionPayloadCache(ctx, answer.QuestionID)
 	_ = ar.updateSearch(ctx, answer.ID)
 	return nil
 }
@@ -87,12 +92,14 @@ func (ar *answerRepo) AddAnswer(ctx context.Context, answer *entity.Answer) (err
 // RemoveAnswer delete answer
 func (ar *answerRepo) RemoveAnswer(ctx context.Context, answerID string) (err error) {
 	answerID = uid.DeShortID(answerID)
-	_, err = ar.data.DB.Context(ctx).ID(answerID).Cols("status").Update(&entity.Answer{
-		Status: entity.AnswerStatusDeleted,
+	_, err = ar.data.DB.Context(ctx).ID(answerID).Cols("status", "deleted_at").Update(&entity.Answer{
+		Status:    entity.AnswerStatusDeleted,
+		DeletedAt: time.Now(),
 	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = ar.removeQuestionPayloadCacheByAnswerID(ctx, answerID)
 	_ = ar.updateSearch(ctx, answerID)
 	return nil
 }
@@ -100,12 +107,13 @@ func (ar *answerRepo) RemoveAnswer(ctx context.Context, answerID string) (err er
 // RecoverAnswer recover answer
 func (ar *answerRepo) RecoverAnswer(ctx context.Context, answerID string) (err error) {
 	answerID = uid.DeShortID(answerID)
-	_, err = ar.data.DB.Context(ctx).ID(answerID).Cols("status").Update(&entity.Answer{
+	_, err = ar.data.DB.Context(ctx).ID(answerID).Cols("status", "deleted_at").Update(&entity.Answer{
 		Status: entity.AnswerStatusAvailable,
 	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = ar.removeQuestionPayloadCacheByAnswerID(ctx, answerID)
 	_ = ar.updateSearch(ctx, answerID)
 	return nil
 }
@@ -129,9 +137,10 @@ func (ar *answerRepo) RemoveAllUserAnswer(ctx context.Context, userID string) (e
 	// delete all question
 	session = ar.data.DB.Context(ctx).Where("user_id = ?", userID)
 	session.Where("status != ?", entity.AnswerStatusDeleted)
-	_, err = session.Cols("status", "updated_at").Update(&entity.Answer{
+	_, err = session.Cols("status", "updated_at", "deleted_at").Update(&entity.Answer{
 		UpdatedAt: time.Now(),
 		Status:    entity.AnswerStatusDeleted,
+		DeletedAt: time.Now(),
 	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -139,6 +148,7 @@ func (ar *answerRepo) RemoveAllUserAnswer(ctx context.Context, userID string) (e
 
 	// update search content
 	for _, id := range answerIDs {
+		_ = ar.removeQuestionPayloadCacheByAnswerID(ctx, id)
 		_ = ar.updateSearch(ctx, id)
 	}
 	return nil
@@ -152,16 +162,22 @@ func (ar *answerRepo) UpdateAnswer(ctx context.Context, answer *entity.Answer, c
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = ar.removeQuestionPayloadCacheByAnswerID(ctx, answer.ID)
 	_ = ar.updateSearch(ctx, answer.ID)
 	return err
 }
 
 func (ar *answerRepo) UpdateAnswerStatus(ctx context.Context, answerID string, status int) (err error) {
 	answerID = uid.DeShortID(answerID)
-	_, err = ar.data.DB.Context(ctx).ID(answerID).Cols("status").Update(&entity.Answer{Status: status})
+	answer := &entity.Answer{Status: status}
+	if status == entity.AnswerStatusDeleted {
+		answer.DeletedAt = time.Now()
+	}
+	_, err = ar.data.DB.Context(ctx).ID(answerID).Cols("status", "deleted_at").Update(answer)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = ar.removeQuestionPayloadCacheByAnswerID(ctx, answerID)
 	_ = ar.updateSearch(ctx, answerID)
 	return
 }
@@ -255,6 +271,7 @@ func (ar *answerRepo) UpdateAcceptedStatus(ctx context.Context, acceptedAnswerID
 			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 		}
 	}
+	_ = ar.removeQuestionPayloadCache(ctx, questionID)
 	_ = ar.updateSearch(ctx, acceptedAnswerID)
 	return nil
 }
@@ -331,10 +348,6 @@ func (ar *answerRepo) GetIDsByUserIDAndQuestionID(ctx context.Context, userID st
 
 // SearchList
 func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearch) ([]*entity.Answer, int64, error) {
-	if search.QuestionID != "" {
-		search.QuestionID = uid.DeShortID(search.QuestionID)
-	}
-	search.ID = uid.DeShortID(search.ID)
 	var count int64
 	var err error
 	rows := make([]*entity.Answer, 0)
@@ -347,14 +360,7 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 		search.PageSize = constant.DefaultPageSize
 	}
 	offset := search.Page * search.PageSize
-	session := ar.data.DB.Context(ctx)
-
-	if search.QuestionID != "" {
-		session = session.And("question_id = ?", search.QuestionID)
-	}
-	if len(search.UserID) > 0 {
-		session = session.And("user_id = ?", search.UserID)
-	}
+	session := ar.answerSearchSession(ctx, search)
 	switch search.Order {
 	case entity.AnswerSearchOrderByTime:
 		session = session.OrderBy("created_at desc")
@@ -365,6 +371,68 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 	default:
 		session = session.OrderBy("adopted desc,vote_count desc,created_at asc")
 	}
+
+	session = session.Limit(search.PageSize, offset)
+	count, err = session.FindAndCount(&rows)
+	if err != nil {
+		return rows, count, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	ar.enShortIDs(ctx, rows)
+	return rows, count, nil
+}
+
+// SearchListByCursor get the answers after the cursor in the same order as the answer page,
+// the next cursor is empty if it is the last page
+func (ar *answerRepo) SearchListByCursor(ctx context.Context, search *entity.AnswerSearch, cursor string) (
+	rows []*entity.Answer, nextCursor string, err error) {
+	if search.PageSize <= 0 {
+		search.PageSize = constant.DefaultPageSize
+	}
+	order, keys := answerCursorKeys(search.Order)
+	after, err := pager.DecodeCursor(cursor, order, keys)
+	if err != nil {
+		return nil, "", errors.BadRequest(reason.PageCursorInvalid)
+	}
+
+	rows = make([]*entity.Answer, 0)
+	err = ar.answerSearchSession(ctx, search).And(pager.CursorCond(keys, after)).
+		OrderBy(pager.CursorOrderBy(keys)).Limit(search.PageSize + 1).Find(&rows)
+	if err != nil {
+		return nil, "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(rows) > search.PageSize {
+		rows = rows[:search.PageSize]
+		last := rows[search.PageSize-1]
+		next := &pager.Cursor{Order: order}
+		switch order {
+		case entity.AnswerSearchOrderByTime, entity.AnswerSearchOrderByTimeAsc:
+			next.Values = []int64{last.CreatedAt.Unix()}
+		case entity.AnswerSearchOrderByVote:
+			next.Values = []int64{int64(last.VoteCount)}
+		default:
+			next.Values = []int64{int64(last.Accepted), int64(last.VoteCount), last.CreatedAt.Unix()}
+		}
+		next.Values = append(next.Values, converter.StringToInt64(last.ID))
+		nextCursor = next.Encode()
+	}
+	ar.enShortIDs(ctx, rows)
+	return rows, nextCursor, nil
+}
+
+// answerSearchSession the session of the answers matching the search
+func (ar *answerRepo) answerSearchSession(ctx context.Context, search *entity.AnswerSearch) *xorm.Session {
+	if search.QuestionID != "" {
+		search.QuestionID = uid.DeShortID(search.QuestionID)
+	}
+	search.ID = uid.DeShortID(search.ID)
+	session := ar.data.DB.Context(ctx)
+
+	if search.QuestionID != "" {
+		session = session.And("question_id = ?", search.QuestionID)
+	}
+	if len(search.UserID) > 0 {
+		session = session.And("user_id = ?", search.UserID)
+	}
 	if !search.IncludeDeleted {
 		if search.LoginUserID == "" {
 			session = session.And("status = ? ", entity.AnswerStatusAvailable)
@@ -372,19 +440,44 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 			session = session.And("status = ? OR user_id = ?", entity.AnswerStatusAvailable, search.LoginUserID)
 		}
 	}
+	return session
+}
 
-	session = session.Limit(search.PageSize, offset)
-	count, err = session.FindAndCount(&rows)
-	if err != nil {
-		return rows, count, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+// answerCursorKeys the sort keys of the order of the answer list, the id makes the order stable
+func answerCursorKeys(order string) (string, []pager.CursorKey) {
+	switch order {
+	case entity.AnswerSearchOrderByTime:
+		return order, []pager.CursorKey{
+			{Column: "created_at", Desc: true, Time: true},
+			{Column: "id", Desc: true},
+		}
+	case entity.AnswerSearchOrderByTimeAsc:
+		return order, []pager.CursorKey{
+			{Column: "created_at", Time: true},
+			{Column: "id"},
+		}
+	case entity.AnswerSearchOrderByVote:
+		return order, []pager.CursorKey{
+			{Column: "vote_count", Desc: true},
+			{Column: "id", Desc: true},
+		}
+	default:
+		return entity.AnswerSearchOrderByDefault, []pager.CursorKey{
+			{Column: "adopted", Desc: true},
+			{Column: "vote_count", Desc: true},
+			{Column: "created_at", Time: true},
+			{Column: "id"},
+		}
 	}
+}
+
+func (ar *answerRepo) enShortIDs(ctx context.Context, rows []*entity.Answer) {
 	if handler.GetEnableShortID(ctx) {
 		for _, item := range rows {
 			item.ID = uid.EnShortID(item.ID)
 			item.QuestionID = uid.EnShortID(item.QuestionID)
 		}
 	}
-	return rows, count, nil
 }
 
 // GetPersonalAnswerPage personal answer page
@@ -393,7 +486,7 @@ func (ar *answerRepo) GetPersonalAnswerPage(ctx context.Context, req *entity.Per
 	cond := &entity.Answer{
 		UserID: req.UserID,
 	}
-	session := ar.data.DB.Context(ctx)
+	session := ar.data.ReadDB(ctx)
 	switch req.Order {
 	case entity.AnswerSearchOrderByTime:
 		session = session.OrderBy("created_at desc")
@@ -463,8 +556,48 @@ func (ar *answerRepo) SumVotesByQuestionID(ctx context.Context, questionID strin
 	return count, nil
 }
 
-// updateSearch update search, if search plugin not enable, do nothing
+// removeQuestionPayloadCache remove the cached payloads of the question, they contain the answers of it
+func (ar *answerRepo) removeQuestionPayloadCache(ctx context.Context, questionID string) (err error) {
+	err = ar.data.Cache.Del(ctx, constant.QuestionPayloadVersionCacheKey+uid.DeShortID(questionID))
+	if err != nil {
+		log.Errorf("remove question payload cache failed, question id: %s, err: %v", questionID, err)
+	}
+	return err
+}
+
+func (ar *answerRepo) removeQuestionPayloadCacheByAnswerID(ctx context.Context, answerID string) (err error) {
+	questionID := ""
+	exist, err := ar.data.DB.Context(ctx).Table(new(entity.Answer).TableName()).
+		Where("id = ?", uid.DeShortID(answerID)).Cols("question_id").Get(&questionID)
+	if err != nil || !exist {
+		return err
+	}
+	return ar.removeQuestionPayloadCache(ctx, questionID)
+}
+
+// updateSearch update search by the background job, if search plugin not enable, do nothing
 func (ar *answerRepo) updateSearch(ctx context.Context, answerID string) (err error) {
+	var s plugin.Search
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		s = search
+		return nil
+	})
+	if s == nil {
+		return nil
+	}
+	job, err := jobqueue.NewJob(constant.JobTypeSearchIndexAnswer,
+		&schema.SearchIndexJob{ObjectID: uid.DeShortID(answerID)})
+	if err != nil {
+		return err
+	}
+	if _, err = ar.data.DB.Context(ctx).Insert(job); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// IndexSearch update the search index of the answer, if search plugin not enable, do nothing
+func (ar *answerRepo) IndexSearch(ctx context.Context, answerID string) (err error) {
 	answerID = uid.DeShortID(answerID)
 	// check search plugin
 	var (
@@ -533,27 +666,60 @@ func (ar *answerRepo) updateSearch(ctx context.Context, answerID string) (err er
 	return
 }
 
+// GetDeletedAnswerPage get the answers deleted after the time, the latest deleted first
+func (ar *answerRepo) GetDeletedAnswerPage(ctx context.Context, page, pageSize int, deletedAfter time.Time) (
+	answerList []*entity.Answer, total int64, err error) {
+	answerList = make([]*entity.Answer, 0)
+	session := ar.data.DB.Context(ctx).Where("status = ?", entity.AnswerStatusDeleted)
+	if !deletedAfter.IsZero() {
+		session.And("deleted_at >= ?", deletedAfter)
+	}
+	session.Desc("deleted_at")
+	total, err = pager.Help(page, pageSize, &answerList, &entity.Answer{}, session)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range answerList {
+			item.ID = uid.EnShortID(item.ID)
+			item.QuestionID = uid.EnShortID(item.QuestionID)
+		}
+	}
+	return answerList, total, nil
+}
+
 func (ar *answerRepo) DeletePermanentlyAnswers(ctx context.Context) error {
+	_, err := ar.deletePermanentlyAnswers(ctx, builder.Eq{"status": entity.AnswerStatusDeleted})
+	return err
+}
+
+// PurgeDeletedAnswers delete permanently the answers deleted before the time
+func (ar *answerRepo) PurgeDeletedAnswers(ctx context.Context, deletedBefore time.Time) (count int64, err error) {
+	return ar.deletePermanentlyAnswers(ctx, builder.Eq{"status": entity.AnswerStatusDeleted}.
+		And(builder.Lt{"deleted_at": deletedBefore}))
+}
+
+func (ar *answerRepo) deletePermanentlyAnswers(ctx context.Context, cond builder.Cond) (count int64, err error) {
 	// get all deleted answers ids
 	ids := make([]string, 0)
-	err := ar.data.DB.Context(ctx).Select("id").Table(new(entity.Answer).TableName()).
-		Where("status = ?", entity.AnswerStatusDeleted).Find(&ids)
+	err = ar.data.DB.Context(ctx).Select("id").Table(new(entity.Answer).TableName()).
+		Where(cond).Find(&ids)
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	if len(ids) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	// delete all revisions permanently
 	_, err = ar.data.DB.Context(ctx).In("object_id", ids).Delete(&entity.Revision{})
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
-	_, err = ar.data.DB.Context(ctx).Where("status = ?", entity.AnswerStatusDeleted).Delete(&entity.Answer{})
+	count, err = ar.data.DB.Context(ctx).In("id", ids).Delete(&entity.Answer{})
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	return nil
+	return count, nil
 }
@@ -42,7 +42,16 @@ func NewAPIKeyRepo(data *data.Data) apikey.APIKeyRepo {
 
 func (ar *apiKeyRepo) GetAPIKeyList(ctx context.Context) (keys []*entity.APIKey, err error) {
 	keys = make([]*entity.APIKey, 0)
-	err = ar.data.DB.Context(ctx).Where("hidden = ?", 0).Find(&keys)
+	err = ar.data.DB.Context(ctx).Where("hidden = ?", 0).And("personal = ?", 0).Find(&keys)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (ar *apiKeyRepo) GetUserAPIKeyList(ctx context.Context, userID string) (keys []*entity.APIKey, err error) {
+	keys = make([]*entity.APIKey, 0)
+	err = ar.data.DB.Context(ctx).Where("user_id = ?", userID).And("personal = ?", 1).Desc("id").Find(&keys)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -82,6 +91,15 @@ func (ar *apiKeyRepo) DeleteAPIKey(ctx context.Context, id int) (err error) {
 	return
 }
 
+func (ar *apiKeyRepo) DeleteUserAPIKey(ctx context.Context, id int, userID string) (affected int64, err error) {
+	affected, err = ar.data.DB.Context(ctx).ID(id).Where("user_id = ?", userID).And("personal = ?", 1).
+		Delete(&entity.APIKey{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (ar *apiKeyRepo) DeleteAPIKeysByUserID(ctx context.Context, userID string) (err error) {
 	_, err = ar.data.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.APIKey{})
 	if err != nil {
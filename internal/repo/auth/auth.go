@@ -22,6 +22,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/apache/answer/internal/service/auth"
 
@@ -59,7 +60,7 @@ func (ar *authRepo) GetUserCacheInfo(ctx context.Context, accessToken string) (u
 	return userInfo, nil
 }
 
-// SetUserCacheInfo set user cache info
+// SetUserCacheInfo set user cache info, the token expires at the expire time of the session if it is set
 func (ar *authRepo) SetUserCacheInfo(ctx context.Context,
 	accessToken, visitToken string, userInfo *entity.UserCacheInfo) (err error) {
 	userInfo.VisitToken = visitToken
@@ -67,8 +68,12 @@ func (ar *authRepo) SetUserCacheInfo(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	cacheTime := constant.UserTokenCacheTime
+	if userInfo.ExpireAt > 0 {
+		cacheTime = time.Until(time.Unix(userInfo.ExpireAt, 0))
+	}
 	err = ar.data.Cache.SetString(ctx, constant.UserTokenCacheKey+accessToken,
-		string(userInfoCache), constant.UserTokenCacheTime)
+		string(userInfoCache), cacheTime)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -79,7 +84,7 @@ func (ar *authRepo) SetUserCacheInfo(ctx context.Context,
 		return nil
 	}
 	if err := ar.data.Cache.SetString(ctx, constant.UserVisitTokenCacheKey+visitToken,
-		accessToken, constant.UserTokenCacheTime); err != nil {
+		accessToken, cacheTime); err != nil {
 		log.Error(err)
 	}
 	return nil
@@ -152,6 +157,25 @@ func (ar *authRepo) RemoveUserStatus(ctx context.Context, userID string) (err er
 	return nil
 }
 
+// SetUserSessionRevokedAt set the unix time all the sessions of the user are revoked
+func (ar *authRepo) SetUserSessionRevokedAt(ctx context.Context, userID string, revokedAt int64) (err error) {
+	err = ar.data.Cache.SetInt64(ctx, constant.UserSessionRevokedCacheKey+userID, revokedAt,
+		constant.UserTokenMappingCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetUserSessionRevokedAt get the unix time all the sessions of the user are revoked, 0 if they are never revoked
+func (ar *authRepo) GetUserSessionRevokedAt(ctx context.Context, userID string) (revokedAt int64, err error) {
+	revokedAt, _, err = ar.data.Cache.GetInt64(ctx, constant.UserSessionRevokedCacheKey+userID)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return revokedAt, nil
+}
+
 // GetAdminUserCacheInfo get admin user cache info
 func (ar *authRepo) GetAdminUserCacheInfo(ctx context.Context, accessToken string) (userInfo *entity.UserCacheInfo, err error) {
 	userInfoCache, exist, err := ar.data.Cache.GetString(ctx, constant.AdminTokenCacheKey+accessToken)
@@ -204,7 +228,8 @@ func (ar *authRepo) AddUserTokenMapping(ctx context.Context, userID, accessToken
 	}
 	mapping[accessToken] = true
 	content, _ := json.Marshal(mapping)
-	return ar.data.Cache.SetString(ctx, key, string(content), constant.UserTokenCacheTime)
+	// the mapping outlives the tokens in it, so that all the sessions of the user can be removed
+	return ar.data.Cache.SetString(ctx, key, string(content), constant.UserTokenMappingCacheTime)
 }
 
 // RemoveUserTokens Log out all users under this user id
@@ -29,14 +29,17 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/badge"
+	"github.com/apache/answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
 )
 
 // eventRuleRepo event rule repo
 type eventRuleRepo struct {
 	data             *data.Data
 	EventRuleMapping map[constant.EventType][]badge.EventRuleHandler
+	ruleMetrics      map[string]*badgeRuleMetric
 }
 
 // NewEventRuleRepo creates a new badge repository
@@ -50,14 +53,14 @@ func NewEventRuleRepo(data *data.Data) badge.EventRuleRepo {
 		constant.EventQuestionCreate: nil,
 		constant.EventQuestionUpdate: {b.FirstPostEdit},
 		constant.EventQuestionDelete: nil,
-		constant.EventQuestionVote:   {b.FirstVotedPost, b.ReachQuestionVote},
+		constant.EventQuestionVote:   {b.FirstVotedPost, b.ReachQuestionVote, b.ReachTagReputation},
 		constant.EventQuestionAccept: {b.FirstAcceptAnswer, b.ReachAnswerAcceptedAmount},
 		constant.EventQuestionFlag:   {b.FirstFlaggedPost},
 		constant.EventQuestionReact:  {b.FirstReactedPost},
 		constant.EventAnswerCreate:   nil,
 		constant.EventAnswerUpdate:   {b.FirstPostEdit},
 		constant.EventAnswerDelete:   nil,
-		constant.EventAnswerVote:     {b.FirstVotedPost, b.ReachAnswerVote},
+		constant.EventAnswerVote:     {b.FirstVotedPost, b.ReachAnswerVote, b.ReachTagReputation},
 		constant.EventAnswerFlag:     {b.FirstFlaggedPost},
 		constant.EventAnswerReact:    {b.FirstReactedPost},
 		constant.EventCommentCreate:  nil,
@@ -65,7 +68,9 @@ func NewEventRuleRepo(data *data.Data) badge.EventRuleRepo {
 		constant.EventCommentDelete:  nil,
 		constant.EventCommentVote:    {b.FirstVotedPost},
 		constant.EventCommentFlag:    {b.FirstFlaggedPost},
+		constant.EventCommentReact:   {b.FirstReactedPost},
 	}
+	b.initBadgeRuleMetrics()
 	return b
 }
 
@@ -81,12 +86,20 @@ func (br *eventRuleRepo) HandleEventWithRule(ctx context.Context, msg *schema.Ev
 			awards = append(awards, t...)
 		}
 	}
-	return awards
+	t, err := br.BadgeRule(ctx, msg)
+	if err != nil {
+		log.Errorf("error handling badge rules %+v: %v", msg, err)
+	}
+	return append(awards, t...)
 }
 
 // FirstUpdateUserProfile first update user profile
 func (br *eventRuleRepo) FirstUpdateUserProfile(ctx context.Context,
 	event *schema.EventMsg) (awards []*entity.BadgeAward, err error) {
+	// only the users who update their own profile earn the badge, not the admins who edit it
+	if len(event.TriggerObjectID) > 0 && event.TriggerObjectID != event.UserID {
+		return nil, nil
+	}
 	badges := br.getBadgesByHandler(ctx, "FirstUpdateUserProfile")
 	for _, b := range badges {
 		bean := &entity.User{ID: event.UserID}
@@ -235,6 +248,53 @@ func (br *eventRuleRepo) ReachQuestionVote(ctx context.Context,
 	return awards, nil
 }
 
+// ReachTagReputation reach the reputation in the tags of the voted post, the badge is awarded once for each tag
+func (br *eventRuleRepo) ReachTagReputation(ctx context.Context,
+	event *schema.EventMsg) (awards []*entity.BadgeAward, err error) {
+	badges := br.getBadgesByHandler(ctx, "ReachTagReputation")
+	if len(badges) == 0 {
+		return nil, nil
+	}
+	userID, questionID := event.QuestionUserID, event.QuestionID
+	if len(event.AnswerID) > 0 {
+		userID = event.AnswerUserID
+		answer := &entity.Answer{}
+		exist, err := br.data.DB.Context(ctx).ID(uid.DeShortID(event.AnswerID)).Cols("question_id").Get(answer)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if !exist {
+			return nil, nil
+		}
+		questionID = answer.QuestionID
+	}
+	if len(userID) == 0 || len(questionID) == 0 {
+		return nil, nil
+	}
+
+	tagRanks := make([]*entity.UserTagRank, 0)
+	err = br.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		And(builder.In("tag_id", builder.Select("tag_id").From("tag_rel").
+			Where(builder.Eq{"object_id": uid.DeShortID(questionID)}.
+				And(builder.In("status", entity.TagRelStatusAvailable, entity.TagRelStatusHide))))).
+		Find(&tagRanks)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, b := range badges {
+		requirement := b.GetIntParam("amount")
+		if requirement == 0 {
+			continue
+		}
+		for _, tagRank := range tagRanks {
+			if int64(tagRank.Rank) >= requirement {
+				awards = append(awards, br.createBadgeAward(userID, tagRank.TagID, b))
+			}
+		}
+	}
+	return awards, nil
+}
+
 func (br *eventRuleRepo) getBadgesByHandler(ctx context.Context, handler string) (badges []*entity.Badge) {
 	badges = make([]*entity.Badge, 0)
 	err := br.data.DB.Context(ctx).Where("handler = ?", handler).Find(&badges)
@@ -0,0 +1,262 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// badgeRuleSweepBatchSize the amount of the users evaluated in one batch of the sweep
+const badgeRuleSweepBatchSize = 100
+
+// badgeRuleMetric the metric compared with the amount by the badge rules
+type badgeRuleMetric struct {
+	// events the metric is evaluated on
+	events []constant.EventType
+	// eventValue get the user the metric belongs to, the award key and the value of the metric after the event
+	eventValue func(ctx context.Context, event *schema.EventMsg) (userID, awardKey string, value int64, err error)
+	// userValue get the value of the metric of the user, only the metrics with it are evaluated by the sweep
+	userValue func(ctx context.Context, userID string) (value int64, err error)
+}
+
+// badgeRuleValue the value of the metric of the user
+type badgeRuleValue struct {
+	userID   string
+	awardKey string
+	value    int64
+}
+
+func (br *eventRuleRepo) initBadgeRuleMetrics() {
+	br.ruleMetrics = map[string]*badgeRuleMetric{
+		entity.BadgeMetricAnswerScore: {
+			events:     []constant.EventType{constant.EventAnswerVote},
+			eventValue: br.answerScore,
+		},
+		entity.BadgeMetricQuestionScore: {
+			events:     []constant.EventType{constant.EventQuestionVote},
+			eventValue: br.questionScore,
+		},
+		entity.BadgeMetricAcceptedAnswers: {
+			events: []constant.EventType{constant.EventQuestionAccept},
+			eventValue: func(ctx context.Context, event *schema.EventMsg) (string, string, int64, error) {
+				value, err := br.acceptedAnswers(ctx, event.AnswerUserID)
+				return event.AnswerUserID, entity.BadgeEmptyAwardKey, value, err
+			},
+			userValue: br.acceptedAnswers,
+		},
+		entity.BadgeMetricActiveDays: {
+			events: []constant.EventType{
+				constant.EventQuestionCreate, constant.EventAnswerCreate, constant.EventCommentCreate},
+			eventValue: func(ctx context.Context, event *schema.EventMsg) (string, string, int64, error) {
+				value, err := br.activeDays(ctx, event.UserID)
+				return event.UserID, entity.BadgeEmptyAwardKey, value, err
+			},
+			userValue: br.activeDays,
+		},
+		entity.BadgeMetricReputation: {
+			events: []constant.EventType{constant.EventQuestionVote, constant.EventAnswerVote},
+			eventValue: func(ctx context.Context, event *schema.EventMsg) (string, string, int64, error) {
+				userID := event.QuestionUserID
+				if len(event.AnswerID) > 0 {
+					userID = event.AnswerUserID
+				}
+				value, err := br.reputation(ctx, userID)
+				return userID, entity.BadgeEmptyAwardKey, value, err
+			},
+			userValue: br.reputation,
+		},
+	}
+}
+
+// BadgeRule award the active badges with the rules whose metric is changed by the event
+func (br *eventRuleRepo) BadgeRule(ctx context.Context,
+	event *schema.EventMsg) (awards []*entity.BadgeAward, err error) {
+	values := make(map[string]*badgeRuleValue)
+	for _, b := range br.getBadgesByHandler(ctx, entity.BadgeRuleHandler) {
+		rule, ok := b.GetRule()
+		if !ok || b.Status != entity.BadgeStatusActive {
+			continue
+		}
+		metric, ok := br.ruleMetrics[rule.Metric]
+		if !ok || !slices.Contains(metric.events, event.EventType) {
+			continue
+		}
+		v, ok := values[rule.Metric]
+		if !ok {
+			v = &badgeRuleValue{}
+			v.userID, v.awardKey, v.value, err = metric.eventValue(ctx, event)
+			if err != nil {
+				return nil, err
+			}
+			values[rule.Metric] = v
+		}
+		if len(v.userID) > 0 && v.value >= rule.Amount {
+			awards = append(awards, br.createBadgeAward(v.userID, v.awardKey, b))
+		}
+	}
+	return awards, nil
+}
+
+// SweepBadgeRules award the active badges with the rules of the user metrics to the users who have
+// activities since the time. It awards the badges whose metric is not changed by any event, like the active days.
+func (br *eventRuleRepo) SweepBadgeRules(ctx context.Context, since time.Time) (
+	awards []*entity.BadgeAward, err error) {
+	type sweptRule struct {
+		badge  *entity.Badge
+		rule   *entity.BadgeRule
+		metric *badgeRuleMetric
+	}
+	rules := make([]*sweptRule, 0)
+	for _, b := range br.getBadgesByHandler(ctx, entity.BadgeRuleHandler) {
+		rule, ok := b.GetRule()
+		if !ok || b.Status != entity.BadgeStatusActive {
+			continue
+		}
+		if metric, ok := br.ruleMetrics[rule.Metric]; ok && metric.userValue != nil {
+			rules = append(rules, &sweptRule{badge: b, rule: rule, metric: metric})
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	lastUserID := "0"
+	for {
+		userIDs := make([]string, 0, badgeRuleSweepBatchSize)
+		err = br.data.DB.Context(ctx).Table("activity").
+			Where(builder.Gte{"created_at": since.Format(time.DateTime)}).
+			And(builder.Gt{"user_id": lastUserID}).
+			Distinct("user_id").OrderBy("user_id ASC").Limit(badgeRuleSweepBatchSize).
+			Find(&userIDs)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, userID := range userIDs {
+			values := make(map[string]int64)
+			for _, r := range rules {
+				value, ok := values[r.rule.Metric]
+				if !ok {
+					if value, err = r.metric.userValue(ctx, userID); err != nil {
+						return nil, err
+					}
+					values[r.rule.Metric] = value
+				}
+				if value >= r.rule.Amount {
+					awards = append(awards, br.createBadgeAward(userID, entity.BadgeEmptyAwardKey, r.badge))
+				}
+			}
+		}
+		if len(userIDs) < badgeRuleSweepBatchSize {
+			return awards, nil
+		}
+		lastUserID = userIDs[len(userIDs)-1]
+	}
+}
+
+func (br *eventRuleRepo) answerScore(ctx context.Context, event *schema.EventMsg) (
+	userID, awardKey string, value int64, err error) {
+	if len(event.AnswerID) == 0 {
+		return "", "", 0, nil
+	}
+	answer := &entity.Answer{}
+	exist, err := br.data.DB.Context(ctx).ID(uid.DeShortID(event.AnswerID)).Cols("id", "user_id", "vote_count").
+		Get(answer)
+	if err != nil {
+		return "", "", 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return "", "", 0, nil
+	}
+	return answer.UserID, answer.ID, int64(answer.VoteCount), nil
+}
+
+func (br *eventRuleRepo) questionScore(ctx context.Context, event *schema.EventMsg) (
+	userID, awardKey string, value int64, err error) {
+	if len(event.QuestionID) == 0 {
+		return "", "", 0, nil
+	}
+	question := &entity.Question{}
+	exist, err := br.data.DB.Context(ctx).ID(uid.DeShortID(event.QuestionID)).Cols("id", "user_id", "vote_count").
+		Get(question)
+	if err != nil {
+		return "", "", 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return "", "", 0, nil
+	}
+	return question.UserID, question.ID, int64(question.VoteCount), nil
+}
+
+func (br *eventRuleRepo) acceptedAnswers(ctx context.Context, userID string) (value int64, err error) {
+	if len(userID) == 0 {
+		return 0, nil
+	}
+	value, err = br.data.DB.Context(ctx).Count(&entity.Answer{
+		UserID:   userID,
+		Accepted: schema.AnswerAcceptedEnable,
+		Status:   entity.AnswerStatusAvailable,
+	})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return value, nil
+}
+
+// activeDays count the distinct days the user posted the questions, answers or comments on
+func (br *eventRuleRepo) activeDays(ctx context.Context, userID string) (value int64, err error) {
+	if len(userID) == 0 {
+		return 0, nil
+	}
+	_, err = br.data.DB.Context(ctx).SQL("SELECT COUNT(*) FROM ("+
+		"SELECT DATE(created_at) AS day FROM question WHERE user_id = ? AND status != ?"+
+		" UNION SELECT DATE(created_at) AS day FROM answer WHERE user_id = ? AND status != ?"+
+		" UNION SELECT DATE(created_at) AS day FROM comment WHERE user_id = ? AND status != ?) t",
+		userID, entity.QuestionStatusDeleted, userID, entity.AnswerStatusDeleted, userID, entity.CommentStatusDeleted).
+		Get(&value)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return value, nil
+}
+
+func (br *eventRuleRepo) reputation(ctx context.Context, userID string) (value int64, err error) {
+	if len(userID) == 0 {
+		return 0, nil
+	}
+	user := &entity.User{}
+	exist, err := br.data.DB.Context(ctx).ID(userID).Cols("`rank`").Get(user)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return 0, nil
+	}
+	return int64(user.Rank), nil
+}
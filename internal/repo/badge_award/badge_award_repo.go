@@ -45,11 +45,12 @@ func NewBadgeAwardRepo(data *data.Data, uniqueIDRepo unique.UniqueIDRepo) badge.
 	}
 }
 
-// AwardBadgeForUser award badge for user
-func (r *badgeAwardRepo) AwardBadgeForUser(ctx context.Context, badgeAward *entity.BadgeAward) (err error) {
+// AwardBadgeForUser award badge for user. The badge row is locked while checking the existing awards, so the one-time
+// badge is awarded to the user once and the repeatable badge once for each award key, awarded is false if it was.
+func (r *badgeAwardRepo) AwardBadgeForUser(ctx context.Context, badgeAward *entity.BadgeAward) (awarded bool, err error) {
 	badgeAward.ID, err = r.uniqueIDRepo.GenUniqueIDStr(ctx, entity.BadgeAward{}.TableName())
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	_, err = r.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
@@ -69,7 +70,7 @@ func (r *badgeAwardRepo) AwardBadgeForUser(ctx context.Context, badgeAward *enti
 			BadgeID:        badgeAward.BadgeID,
 			IsBadgeDeleted: entity.IsBadgeNotDeleted,
 		}
-		if badgeInfo.Single != entity.BadgeSingleAward {
+		if badgeInfo.IsRepeatable() {
 			old.AwardKey = badgeAward.AwardKey
 		}
 		exist, err = session.Get(old)
@@ -77,20 +78,20 @@ func (r *badgeAwardRepo) AwardBadgeForUser(ctx context.Context, badgeAward *enti
 			return nil, err
 		}
 		if exist {
-			return nil, fmt.Errorf("badge already awarded")
+			return nil, nil
 		}
 
 		_, err = session.Insert(badgeAward)
 		if err != nil {
 			return nil, err
 		}
-
+		awarded = true
 		return session.ID(badgeInfo.ID).Incr("award_count", 1).Update(&entity.Badge{})
 	})
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	return nil
+	return awarded, nil
 }
 
 // CheckIsAward check this badge is awarded for this user or not
@@ -108,7 +109,8 @@ func (r *badgeAwardRepo) CheckIsAward(ctx context.Context, badgeID, userID, awar
 }
 
 func (r *badgeAwardRepo) CountByUserIdAndBadgeId(ctx context.Context, userID string, badgeID string) (awardCount int64) {
-	awardCount, err := r.data.DB.Context(ctx).Where("user_id = ? AND badge_id = ?", userID, badgeID).Count(&entity.BadgeAward{})
+	awardCount, err := r.data.DB.Context(ctx).Where("user_id = ? AND badge_id = ? AND is_badge_deleted = ?",
+		userID, badgeID, entity.IsBadgeNotDeleted).Count(&entity.BadgeAward{})
 	if err != nil {
 		return 0
 	}
@@ -124,7 +126,11 @@ func (r *badgeAwardRepo) CountByBadgeID(ctx context.Context, badgeID string) (aw
 }
 
 func (r *badgeAwardRepo) SumUserEarnedGroupByBadgeID(ctx context.Context, userID string) (earnedCounts []*entity.BadgeEarnedCount, err error) {
-	err = r.data.DB.Context(ctx).Select("badge_id, count(`id`) AS earned_count").Where("user_id = ?", userID).GroupBy("badge_id").Find(&earnedCounts)
+	err = r.data.DB.Context(ctx).Select("badge_id, count(`id`) AS earned_count").
+		Where("user_id = ? AND is_badge_deleted = ?", userID, entity.IsBadgeNotDeleted).GroupBy("badge_id").Find(&earnedCounts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
 	return
 }
 
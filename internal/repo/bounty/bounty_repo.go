@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/bounty"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+type bountyRepo struct {
+	data         *data.Data
+	userRankRepo rank.UserRankRepo
+}
+
+// NewBountyRepo new repository
+func NewBountyRepo(data *data.Data, userRankRepo rank.UserRankRepo) bounty.BountyRepo {
+	return &bountyRepo{
+		data:         data,
+		userRankRepo: userRankRepo,
+	}
+}
+
+// AddBounty save the bounty and deduct the amount from the user in one transaction.
+// The question row is locked before checking the active bounty, so only one of the concurrent offers is saved.
+func (br *bountyRepo) AddBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (
+	enough bool, err error) {
+	bounty.QuestionID = uid.DeShortID(bounty.QuestionID)
+	act.ObjectID = uid.DeShortID(act.ObjectID)
+	act.OriginalObjectID = uid.DeShortID(act.OriginalObjectID)
+	alreadyExists := false
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Table("question").Where("id = ?", bounty.QuestionID).Cols("id").ForUpdate().
+			Get(&struct {
+				ID string `xorm:"id"`
+			}{})
+		if err != nil {
+			return nil, err
+		}
+		alreadyExists, err = session.Exist(&entity.QuestionBounty{
+			QuestionID: bounty.QuestionID,
+			Status:     entity.BountyStatusActive,
+		})
+		if err != nil || alreadyExists {
+			return nil, err
+		}
+
+		user := &entity.User{}
+		exist, err := session.ID(bounty.UserID).ForUpdate().Get(user)
+		if err != nil || !exist {
+			return nil, err
+		}
+		// the reputation of user never lower than 1
+		if user.Rank-bounty.Amount < 1 {
+			return nil, nil
+		}
+		if _, err = session.Insert(bounty); err != nil {
+			return nil, err
+		}
+		if _, err = session.Insert(act); err != nil {
+			return nil, err
+		}
+		if err = br.userRankRepo.ChangeUserRank(ctx, session, user.ID, user.Rank, act.Rank); err != nil {
+			return nil, err
+		}
+		enough = true
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if alreadyExists {
+		return false, errors.BadRequest(reason.BountyAlreadyExists)
+	}
+	return enough, nil
+}
+
+// SettleBounty close the active bounty and give the reputation of the activity to its user
+func (br *bountyRepo) SettleBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (
+	settled bool, err error) {
+	_, err = br.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		affected, err := session.ID(bounty.ID).
+			Where(builder.Eq{"status": entity.BountyStatusActive}).
+			Cols("status", "awarded_answer_id", "awarded_user_id", "awarded_amount", "settled_at").
+			Update(bounty)
+		if err != nil || affected == 0 {
+			return nil, err
+		}
+		settled = true
+		if act == nil || act.Rank == 0 {
+			return nil, nil
+		}
+
+		user := &entity.User{}
+		exist, err := session.ID(act.UserID).ForUpdate().Get(user)
+		if err != nil || !exist {
+			return nil, err
+		}
+		if _, err = session.Insert(act); err != nil {
+			return nil, err
+		}
+		return nil, br.userRankRepo.ChangeUserRank(ctx, session, user.ID, user.Rank, act.Rank)
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return settled, nil
+}
+
+// GetActiveBounty get the bounty of the question which is not settled
+func (br *bountyRepo) GetActiveBounty(ctx context.Context, questionID string) (
+	bounty *entity.QuestionBounty, exist bool, err error) {
+	bounty = &entity.QuestionBounty{}
+	exist, err = br.data.DB.Context(ctx).
+		Where(builder.Eq{"question_id": uid.DeShortID(questionID)}).
+		And(builder.Eq{"status": entity.BountyStatusActive}).
+		Get(bounty)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBountiesByQuestionID get all bounties of the question, the newest first
+func (br *bountyRepo) GetBountiesByQuestionID(ctx context.Context, questionID string) (
+	bounties []*entity.QuestionBounty, err error) {
+	bounties = make([]*entity.QuestionBounty, 0)
+	err = br.data.DB.Context(ctx).
+		Where(builder.Eq{"question_id": uid.DeShortID(questionID)}).
+		Desc("id").
+		Find(&bounties)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExpiredBounties get active bounties expired before the time
+func (br *bountyRepo) GetExpiredBounties(ctx context.Context, before time.Time, limit int) (
+	bounties []*entity.QuestionBounty, err error) {
+	bounties = make([]*entity.QuestionBounty, 0)
+	err = br.data.DB.Context(ctx).
+		Where(builder.Eq{"status": entity.BountyStatusActive}).
+		And(builder.Lt{"expires_at": before}).
+		Asc("id").
+		Limit(limit).
+		Find(&bounties)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // commentRepo comment repository
@@ -147,9 +148,17 @@ func (cr *commentRepo) GetCommentPage(ctx context.Context, commentQuery *comment
 ) {
 	commentList = make([]*entity.Comment, 0)
 
-	session := cr.data.DB.Context(ctx)
+	session := cr.data.ReadDB(ctx)
 	session.OrderBy(commentQuery.GetOrderBy())
-	session.Where("status = ?", entity.CommentStatusAvailable)
+	if commentQuery.TopLevel {
+		// the deleted comment is kept as a placeholder while it still has replies
+		hasReplies := builder.In("id", builder.Select("parent_id").From("comment").
+			Where(builder.Eq{"status": entity.CommentStatusAvailable}.And(builder.NotNull{"parent_id"})))
+		session.Where(builder.IsNull{"parent_id"}).And(builder.Eq{"status": entity.CommentStatusAvailable}.
+			Or(builder.Eq{"status": entity.CommentStatusDeleted}.And(hasReplies)))
+	} else {
+		session.Where("status = ?", entity.CommentStatusAvailable)
+	}
 
 	cond := &entity.Comment{ObjectID: commentQuery.ObjectID, UserID: commentQuery.UserID}
 	total, err = pager.Help(commentQuery.Page, commentQuery.PageSize, &commentList, cond, session)
@@ -159,6 +168,21 @@ func (cr *commentRepo) GetCommentPage(ctx context.Context, commentQuery *comment
 	return
 }
 
+// GetCommentReplies get the available replies of the top-level comments, the earlier replies come first
+func (cr *commentRepo) GetCommentReplies(ctx context.Context, parentIDs []string) (
+	commentList []*entity.Comment, err error) {
+	commentList = make([]*entity.Comment, 0)
+	if len(parentIDs) == 0 {
+		return commentList, nil
+	}
+	err = cr.data.DB.Context(ctx).In("parent_id", parentIDs).
+		Where("status = ?", entity.CommentStatusAvailable).Asc("created_at", "id").Find(&commentList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // RemoveAllUserComment remove all user comment
 func (cr *commentRepo) RemoveAllUserComment(ctx context.Context, userID string) (err error) {
 	session := cr.data.DB.Context(ctx).Where("user_id = ?", userID)
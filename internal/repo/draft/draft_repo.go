@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/draft"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// draftRepo draft repository
+type draftRepo struct {
+	data *data.Data
+}
+
+// NewDraftRepo new repository
+func NewDraftRepo(data *data.Data) draft.DraftRepo {
+	return &draftRepo{
+		data: data,
+	}
+}
+
+func (dr *draftRepo) SetDraft(ctx context.Context, userID, target string, info *entity.Draft, ttl time.Duration) (
+	err error) {
+	content, _ := json.Marshal(info)
+	err = dr.data.Cache.SetString(ctx, dr.cacheKey(userID, target), string(content), ttl)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (dr *draftRepo) GetDraft(ctx context.Context, userID, target string) (info *entity.Draft, exist bool, err error) {
+	content, exist, err := dr.data.Cache.GetString(ctx, dr.cacheKey(userID, target))
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	info = &entity.Draft{}
+	if err = json.Unmarshal([]byte(content), info); err != nil {
+		return nil, false, nil
+	}
+	return info, true, nil
+}
+
+func (dr *draftRepo) RemoveDraft(ctx context.Context, userID, target string) (err error) {
+	if err = dr.data.Cache.Del(ctx, dr.cacheKey(userID, target)); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// cacheKey the drafts are private, the key of every draft contains its owner
+func (dr *draftRepo) cacheKey(userID, target string) string {
+	return constant.UserDraftCacheKey + userID + ":" + target
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package guest_post
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/guest_post"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+type guestPostRepo struct {
+	data *data.Data
+}
+
+// NewGuestPostRepo new repository
+func NewGuestPostRepo(data *data.Data) guest_post.GuestPostRepo {
+	return &guestPostRepo{
+		data: data,
+	}
+}
+
+// AcquireGuestUser get the guest placeholder that owns the questions of the guests, it is created when
+// the first guest asks. The username of it is reserved, nobody can register it.
+func (gr *guestPostRepo) AcquireGuestUser(ctx context.Context) (userID string, err error) {
+	placeholder := &entity.User{}
+	exist, err := gr.data.DB.Context(ctx).Where("username = ?", constant.GuestUserPlaceholderUsername).Get(placeholder)
+	if err != nil {
+		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return placeholder.ID, nil
+	}
+	placeholder = &entity.User{
+		Username:    constant.GuestUserPlaceholderUsername,
+		DisplayName: constant.GuestUserPlaceholderUsername,
+		Status:      entity.UserStatusAvailable,
+		MailStatus:  entity.EmailStatusToBeVerified,
+		Rank:        1,
+	}
+	if _, err = gr.data.DB.Context(ctx).Insert(placeholder); err != nil {
+		// the placeholder may be created by another guest at the same time
+		existing := &entity.User{}
+		exist, getErr := gr.data.DB.Context(ctx).Where("username = ?", constant.GuestUserPlaceholderUsername).Get(existing)
+		if getErr == nil && exist {
+			return existing.ID, nil
+		}
+		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return placeholder.ID, nil
+}
+
+// ChangeQuestionAuthor reassign the question from the guest placeholder to the user in one transaction,
+// the revisions and the reputation the question earned are moved together.
+// Nothing is changed if the question does not belong to the guest placeholder anymore.
+func (gr *guestPostRepo) ChangeQuestionAuthor(ctx context.Context, questionID, guestUserID, userID string) (
+	changed bool, err error) {
+	_, err = gr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		affected, err := session.Where(builder.Eq{"id": questionID, "user_id": guestUserID}).Cols("user_id").
+			Update(&entity.Question{UserID: userID})
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, nil
+		}
+		changed = true
+
+		_, err = session.Table("question").Where(builder.Eq{"id": questionID, "last_edit_user_id": guestUserID}).
+			Update(map[string]any{"last_edit_user_id": userID})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Table("revision").Where(builder.Eq{"object_id": questionID, "user_id": guestUserID}).
+			Update(map[string]any{"user_id": userID})
+		if err != nil {
+			return nil, err
+		}
+
+		cond := builder.Eq{"object_id": questionID, "user_id": guestUserID}
+		rankSum := &entity.ActivityRankSum{}
+		_, err = session.Table(entity.Activity{}.TableName()).Select("SUM(`rank`) AS `rank`").
+			Where(cond).And(builder.Eq{"has_rank": 1, "cancelled": entity.ActivityAvailable}).Get(rankSum)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = session.Table("activity").Where(cond).Update(map[string]any{"user_id": userID}); err != nil {
+			return nil, err
+		}
+		if rankSum.Rank != 0 {
+			if _, err = session.ID(guestUserID).Decr("`rank`", rankSum.Rank).Update(&entity.User{}); err != nil {
+				return nil, err
+			}
+			if _, err = session.ID(userID).Incr("`rank`", rankSum.Rank).Update(&entity.User{}); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return changed, nil
+}
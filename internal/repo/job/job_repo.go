@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/jobqueue"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+type jobRepo struct {
+	data *data.Data
+}
+
+// NewJobRepo new repository
+func NewJobRepo(data *data.Data) jobqueue.JobRepo {
+	return &jobRepo{
+		data: data,
+	}
+}
+
+// AddJob add the job
+func (jr *jobRepo) AddJob(ctx context.Context, job *entity.Job) (err error) {
+	_, err = jr.data.DB.Context(ctx).Insert(job)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AcquireJobs lock the due jobs and the jobs whose lock is expired. The job is only locked by one worker,
+// because it is updated only if the attempts is not changed by the others.
+func (jr *jobRepo) AcquireJobs(ctx context.Context, types []string, limit int, lockUntil time.Time) (
+	jobs []*entity.Job, err error) {
+	now := time.Now()
+	candidates := make([]*entity.Job, 0)
+	err = jr.data.DB.Context(ctx).In("type", types).And(builder.Or(
+		builder.Eq{"status": entity.JobStatusPending}.And(builder.Lte{"run_at": now}),
+		builder.Eq{"status": entity.JobStatusRunning}.And(builder.Lt{"locked_until": now}),
+	)).Asc("run_at", "id").Limit(limit).Find(&candidates)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	jobs = make([]*entity.Job, 0, len(candidates))
+	for _, job := range candidates {
+		affected, err := jr.data.DB.Context(ctx).Where("id = ? AND attempts = ?", job.ID, job.Attempts).
+			Cols("status", "attempts", "locked_until").
+			Update(&entity.Job{Status: entity.JobStatusRunning, Attempts: job.Attempts + 1, LockedUntil: lockUntil})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 0 {
+			continue
+		}
+		job.Status, job.Attempts, job.LockedUntil = entity.JobStatusRunning, job.Attempts+1, lockUntil
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// DeleteJob delete the job that is done
+func (jr *jobRepo) DeleteJob(ctx context.Context, id int64) (err error) {
+	_, err = jr.data.DB.Context(ctx).ID(id).Delete(&entity.Job{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RetryJob unlock the failed job and run it again at the time
+func (jr *jobRepo) RetryJob(ctx context.Context, id int64, runAt time.Time, lastError string) (err error) {
+	_, err = jr.data.DB.Context(ctx).ID(id).Cols("status", "run_at", "last_error").
+		Update(&entity.Job{Status: entity.JobStatusPending, RunAt: runAt, LastError: lastError})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// MoveToDeadLetter add the dead letter of the job and delete the job in one transaction
+func (jr *jobRepo) MoveToDeadLetter(ctx context.Context, job *entity.Job, lastError string) (err error) {
+	_, err = jr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Insert(&entity.JobDeadLetter{
+			JobID:    job.ID,
+			Type:     job.Type,
+			Payload:  job.Payload,
+			Attempts: job.Attempts,
+			Error:    lastError,
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(job.ID).Delete(&entity.Job{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetJobStats get the amount of the jobs by the type and the status
+func (jr *jobRepo) GetJobStats(ctx context.Context) (stats []*entity.JobStat, err error) {
+	stats = make([]*entity.JobStat, 0)
+	err = jr.data.DB.Context(ctx).Table(entity.Job{}.TableName()).
+		Select("type, status, COUNT(*) AS amount, SUM(CASE WHEN attempts > 0 THEN 1 ELSE 0 END) AS retrying").
+		GroupBy("type, status").Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeadLetterStats get the amount of the dead letters by the type
+func (jr *jobRepo) GetDeadLetterStats(ctx context.Context) (stats []*entity.JobStat, err error) {
+	stats = make([]*entity.JobStat, 0)
+	err = jr.data.DB.Context(ctx).Table(entity.JobDeadLetter{}.TableName()).
+		Select("type, COUNT(*) AS amount").GroupBy("type").Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeadLetterPage get dead letters page, the newest first
+func (jr *jobRepo) GetDeadLetterPage(ctx context.Context, page, pageSize int, jobType string) (
+	deadLetters []*entity.JobDeadLetter, total int64, err error) {
+	deadLetters = make([]*entity.JobDeadLetter, 0)
+	session := jr.data.DB.Context(ctx).Desc("id")
+	if len(jobType) > 0 {
+		session.Where("type = ?", jobType)
+	}
+	total, err = pager.Help(page, pageSize, &deadLetters, &entity.JobDeadLetter{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -63,3 +63,20 @@ func (lr *LimitRepo) CheckAndRecord(ctx context.Context, key string) (limit bool
 func (lr *LimitRepo) ClearRecord(ctx context.Context, key string) error {
 	return lr.data.Cache.Del(ctx, constant.RateLimitCacheKeyPrefix+key)
 }
+
+// IncreaseInWindow count the request of the key in the current fixed time window,
+// returns the count and how long until the window ends
+func (lr *LimitRepo) IncreaseInWindow(ctx context.Context, key string, window time.Duration) (
+	count int64, retryAfter time.Duration, err error) {
+	now := time.Now()
+	windowStart := now.Truncate(window)
+	retryAfter = windowStart.Add(window).Sub(now)
+	cacheKey := fmt.Sprintf("%s%s:%d", constant.RateLimitCacheKeyPrefix, key, windowStart.Unix())
+
+	// the window is created if it does not exist and then always increased, the concurrent requests never reset it
+	count, err = data.IncreaseInWindow(ctx, lr.data.Cache, cacheKey, retryAfter)
+	if err != nil {
+		return 0, retryAfter, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, retryAfter, nil
+}
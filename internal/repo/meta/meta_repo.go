@@ -110,6 +110,20 @@ func (mr *metaRepo) GetMetaByObjectIdAndKey(ctx context.Context, objectID, key s
 	return
 }
 
+// GetMetaListByObjectIDsAndKey get the metas of the objects with the key
+func (mr *metaRepo) GetMetaListByObjectIDsAndKey(ctx context.Context, objectIDs []string, key string) (
+	metaList []*entity.Meta, err error) {
+	metaList = make([]*entity.Meta, 0)
+	if len(objectIDs) == 0 {
+		return metaList, nil
+	}
+	err = mr.data.DB.Context(ctx).In("object_id", objectIDs).And(builder.Eq{"`key`": key}).Find(&metaList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetMetaList get meta list all
 func (mr *metaRepo) GetMetaList(ctx context.Context, meta *entity.Meta) (metaList []*entity.Meta, err error) {
 	metaList = make([]*entity.Meta, 0)
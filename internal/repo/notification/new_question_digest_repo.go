@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/notification"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// newQuestionDigestRepo new question digest repository
+type newQuestionDigestRepo struct {
+	data *data.Data
+}
+
+// NewNewQuestionDigestRepo new repository
+func NewNewQuestionDigestRepo(data *data.Data) notification.NewQuestionDigestRepo {
+	return &newQuestionDigestRepo{
+		data: data,
+	}
+}
+
+// AddNewQuestionDigests add the questions waiting for the digest, the question which is already waiting is ignored
+func (nr *newQuestionDigestRepo) AddNewQuestionDigests(ctx context.Context, digests []*entity.NewQuestionDigest) (err error) {
+	if len(digests) == 0 {
+		return nil
+	}
+	questionID := uid.DeShortID(digests[0].QuestionID)
+	userIDs := make([]string, 0, len(digests))
+	for _, digest := range digests {
+		userIDs = append(userIDs, digest.UserID)
+	}
+	existUserIDs := make([]string, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.NewQuestionDigest{}.TableName()).
+		Where(builder.Eq{"question_id": questionID}).
+		And(builder.In("user_id", userIDs)).
+		Cols("user_id").Find(&existUserIDs)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	existMapping := make(map[string]bool, len(existUserIDs))
+	for _, userID := range existUserIDs {
+		existMapping[userID] = true
+	}
+
+	newDigests := make([]*entity.NewQuestionDigest, 0, len(digests))
+	for _, digest := range digests {
+		if existMapping[digest.UserID] {
+			continue
+		}
+		existMapping[digest.UserID] = true
+		digest.QuestionID = questionID
+		newDigests = append(newDigests, digest)
+	}
+	if len(newDigests) == 0 {
+		return nil
+	}
+	_, err = nr.data.DB.Context(ctx).Insert(newDigests)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetNewQuestionDigestUserIDs get the users who have questions waiting since the time
+func (nr *newQuestionDigestRepo) GetNewQuestionDigestUserIDs(ctx context.Context, before time.Time) (
+	userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.NewQuestionDigest{}.TableName()).
+		Where(builder.Lte{"created_at": before}).
+		Distinct("user_id").Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNewQuestionDigests get the waiting questions of the user which are still visible, the oldest first
+func (nr *newQuestionDigestRepo) GetNewQuestionDigests(ctx context.Context, userID string, limit int) (
+	digests []*entity.NewQuestionDigest, err error) {
+	digests = make([]*entity.NewQuestionDigest, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.NewQuestionDigest{}.TableName()).
+		Select("new_question_digest.*").
+		Join("INNER", "question", "question.id = new_question_digest.question_id").
+		Where(builder.Eq{"new_question_digest.user_id": userID}).
+		And(builder.In("question.status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)).
+		And(builder.Eq{"question.show": entity.QuestionShow}).
+		Asc("new_question_digest.id").
+		Limit(limit).
+		Find(&digests)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveNewQuestionDigests remove the waiting questions of the user up to the max id, all of them if max id is empty
+func (nr *newQuestionDigestRepo) RemoveNewQuestionDigests(ctx context.Context, userID, maxID string) (err error) {
+	cond := builder.NewCond().And(builder.Eq{"user_id": userID})
+	if len(maxID) > 0 {
+		cond = cond.And(builder.Lte{"id": maxID})
+	}
+	_, err = nr.data.DB.Context(ctx).Where(cond).Delete(&entity.NewQuestionDigest{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimNewQuestionDigests remove the waiting questions one by one, the ones removed by another call are skipped
+func (nr *newQuestionDigestRepo) ClaimNewQuestionDigests(ctx context.Context, digests []*entity.NewQuestionDigest) (
+	claimed []*entity.NewQuestionDigest, err error) {
+	claimed = make([]*entity.NewQuestionDigest, 0, len(digests))
+	for _, digest := range digests {
+		affected, err := nr.data.DB.Context(ctx).ID(digest.ID).Delete(&entity.NewQuestionDigest{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 1 {
+			claimed = append(claimed, digest)
+		}
+	}
+	return claimed, nil
+}
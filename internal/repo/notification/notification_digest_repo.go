@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/notification"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// notificationDigestRepo notification digest repository
+type notificationDigestRepo struct {
+	data *data.Data
+}
+
+// NewNotificationDigestRepo new repository
+func NewNotificationDigestRepo(data *data.Data) notification.NotificationDigestRepo {
+	return &notificationDigestRepo{
+		data: data,
+	}
+}
+
+// AddNotificationDigest add the notification waiting for the digest
+func (nr *notificationDigestRepo) AddNotificationDigest(ctx context.Context, digest *entity.NotificationDigest) (err error) {
+	_, err = nr.data.DB.Context(ctx).Insert(digest)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNotificationDigestUserIDs get the users who have notifications waiting since the time
+func (nr *notificationDigestRepo) GetNotificationDigestUserIDs(ctx context.Context, before time.Time) (
+	userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.NotificationDigest{}.TableName()).
+		Where(builder.Lte{"created_at": before}).
+		Distinct("user_id").Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNotificationDigests get the waiting notifications of the user, the oldest first.
+// The notifications that the user has already read in-app are skipped.
+func (nr *notificationDigestRepo) GetNotificationDigests(ctx context.Context, userID string, limit int) (
+	digests []*entity.NotificationDigest, err error) {
+	readObjectIDs := builder.Select("object_id").From(entity.Notification{}.TableName()).
+		Where(builder.Eq{"user_id": userID, "is_read": schema.NotificationRead})
+	digests = make([]*entity.NotificationDigest, 0)
+	err = nr.data.DB.Context(ctx).
+		Where(builder.Eq{"user_id": userID}).
+		And(builder.NotIn("object_id", readObjectIDs)).
+		Asc("id").
+		Limit(limit).
+		Find(&digests)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOldestNotificationDigest get the oldest waiting notification of the user
+func (nr *notificationDigestRepo) GetOldestNotificationDigest(ctx context.Context, userID string) (
+	digest *entity.NotificationDigest, exist bool, err error) {
+	digest = &entity.NotificationDigest{}
+	exist, err = nr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Asc("id").Get(digest)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveNotificationDigests remove the waiting notifications of the user up to the max id, all of them if max id is empty
+func (nr *notificationDigestRepo) RemoveNotificationDigests(ctx context.Context, userID, maxID string) (err error) {
+	cond := builder.NewCond().And(builder.Eq{"user_id": userID})
+	if len(maxID) > 0 {
+		cond = cond.And(builder.Lte{"id": maxID})
+	}
+	_, err = nr.data.DB.Context(ctx).Where(cond).Delete(&entity.NotificationDigest{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimNotificationDigests remove the waiting notifications one by one, the ones removed by another call are skipped
+func (nr *notificationDigestRepo) ClaimNotificationDigests(ctx context.Context, digests []*entity.NotificationDigest) (
+	claimed []*entity.NotificationDigest, err error) {
+	claimed = make([]*entity.NotificationDigest, 0, len(digests))
+	for _, digest := range digests {
+		affected, err := nr.data.DB.Context(ctx).ID(digest.ID).Delete(&entity.NotificationDigest{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 1 {
+			claimed = append(claimed, digest)
+		}
+	}
+	return claimed, nil
+}
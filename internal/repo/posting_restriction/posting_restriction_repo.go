@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package posting_restriction
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/posting_restriction"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// postingRestrictionRepo posting restriction repository
+type postingRestrictionRepo struct {
+	data *data.Data
+}
+
+// NewPostingRestrictionRepo new repository
+func NewPostingRestrictionRepo(data *data.Data) posting_restriction.PostingRestrictionRepo {
+	return &postingRestrictionRepo{
+		data: data,
+	}
+}
+
+// GetUserPostStat get the amount of the questions and answers of the user since the time and the time of the last one.
+// The deleted posts are counted too, so the limits can not be bypassed by deleting the posts.
+func (pr *postingRestrictionRepo) GetUserPostStat(ctx context.Context, userID string, since time.Time) (
+	postAmount int64, lastPostAt time.Time, err error) {
+	for _, post := range []any{&entity.Question{}, &entity.Answer{}} {
+		amount, err := pr.data.DB.Context(ctx).Where("user_id = ?", userID).
+			And("created_at >= ?", since.Format(time.DateTime)).Count(post)
+		if err != nil {
+			return 0, time.Time{}, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		postAmount += amount
+	}
+
+	question := &entity.Question{}
+	_, err = pr.data.DB.Context(ctx).Where("user_id = ?", userID).Cols("created_at").Desc("created_at").Get(question)
+	if err != nil {
+		return 0, time.Time{}, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	answer := &entity.Answer{}
+	_, err = pr.data.DB.Context(ctx).Where("user_id = ?", userID).Cols("created_at").Desc("created_at").Get(answer)
+	if err != nil {
+		return 0, time.Time{}, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	lastPostAt = question.CreatedAt
+	if answer.CreatedAt.After(lastPostAt) {
+		lastPostAt = answer.CreatedAt
+	}
+	return postAmount, lastPostAt, nil
+}
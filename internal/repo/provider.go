@@ -26,20 +26,26 @@ import (
 	"github.com/apache/answer/internal/repo/ai_conversation"
 	"github.com/apache/answer/internal/repo/answer"
 	"github.com/apache/answer/internal/repo/api_key"
+	"github.com/apache/answer/internal/repo/audit_log"
 	"github.com/apache/answer/internal/repo/auth"
 	"github.com/apache/answer/internal/repo/badge"
 	"github.com/apache/answer/internal/repo/badge_award"
 	"github.com/apache/answer/internal/repo/badge_group"
+	"github.com/apache/answer/internal/repo/bounty"
 	"github.com/apache/answer/internal/repo/captcha"
 	"github.com/apache/answer/internal/repo/collection"
 	"github.com/apache/answer/internal/repo/comment"
 	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/draft"
 	"github.com/apache/answer/internal/repo/export"
 	"github.com/apache/answer/internal/repo/file_record"
+	"github.com/apache/answer/internal/repo/guest_post"
+	"github.com/apache/answer/internal/repo/job"
 	"github.com/apache/answer/internal/repo/limit"
 	"github.com/apache/answer/internal/repo/meta"
 	"github.com/apache/answer/internal/repo/notification"
 	"github.com/apache/answer/internal/repo/plugin_config"
+	"github.com/apache/answer/internal/repo/posting_restriction"
 	"github.com/apache/answer/internal/repo/question"
 	"github.com/apache/answer/internal/repo/rank"
 	"github.com/apache/answer/internal/repo/reason"
@@ -47,14 +53,21 @@ import (
 	"github.com/apache/answer/internal/repo/review"
 	"github.com/apache/answer/internal/repo/revision"
 	"github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/repo/saved_search"
 	"github.com/apache/answer/internal/repo/search_common"
 	"github.com/apache/answer/internal/repo/site_info"
+	"github.com/apache/answer/internal/repo/sitemap"
 	"github.com/apache/answer/internal/repo/tag"
 	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/tag_moderator"
 	"github.com/apache/answer/internal/repo/unique"
 	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/repo/user_data_export"
 	"github.com/apache/answer/internal/repo/user_external_login"
+	"github.com/apache/answer/internal/repo/user_mute"
 	"github.com/apache/answer/internal/repo/user_notification_config"
+	"github.com/apache/answer/internal/repo/user_two_factor"
+	"github.com/apache/answer/internal/repo/webhook"
 	"github.com/google/wire"
 )
 
@@ -62,6 +75,7 @@ import (
 var ProviderSetRepo = wire.NewSet(
 	data.NewData,
 	data.NewDB,
+	data.NewReplicas,
 	data.NewCache,
 	comment.NewCommentRepo,
 	comment.NewCommentCommonRepo,
@@ -74,7 +88,9 @@ var ProviderSetRepo = wire.NewSet(
 	user.NewUserRepo,
 	user.NewUserAdminRepo,
 	rank.NewUserRankRepo,
+	rank.NewUserTagRankRepo,
 	question.NewQuestionRepo,
+	question.NewQuestionViewRepo,
 	answer.NewAnswerRepo,
 	activity_common.NewActivityRepo,
 	activity.NewVoteRepo,
@@ -83,6 +99,7 @@ var ProviderSetRepo = wire.NewSet(
 	activity.NewUserActiveActivityRepo,
 	activity.NewActivityRepo,
 	activity.NewReviewActivityRepo,
+	activity.NewFlagActivityRepo,
 	tag.NewTagRepo,
 	tag_common.NewTagCommonRepo,
 	tag.NewTagRelRepo,
@@ -96,11 +113,17 @@ var ProviderSetRepo = wire.NewSet(
 	reason.NewReasonRepo,
 	site_info.NewSiteInfo,
 	notification.NewNotificationRepo,
+	notification.NewNewQuestionDigestRepo,
+	notification.NewNotificationDigestRepo,
 	role.NewRoleRepo,
 	role.NewUserRoleRelRepo,
 	role.NewRolePowerRelRepo,
 	role.NewPowerRepo,
 	user_external_login.NewUserExternalLoginRepo,
+	user_two_factor.NewUserTwoFactorRepo,
+	webhook.NewWebhookRepo,
+	job.NewJobRepo,
+	bounty.NewBountyRepo,
 	plugin_config.NewPluginConfigRepo,
 	user_notification_config.NewUserNotificationConfigRepo,
 	limit.NewRateLimitRepo,
@@ -112,5 +135,14 @@ var ProviderSetRepo = wire.NewSet(
 	badge_award.NewBadgeAwardRepo,
 	file_record.NewFileRecordRepo,
 	api_key.NewAPIKeyRepo,
+	audit_log.NewAuditLogRepo,
+	user_data_export.NewUserDataExportRepo,
 	ai_conversation.NewAIConversationRepo,
+	draft.NewDraftRepo,
+	user_mute.NewUserMuteRepo,
+	posting_restriction.NewPostingRestrictionRepo,
+	saved_search.NewSavedSearchRepo,
+	guest_post.NewGuestPostRepo,
+	sitemap.NewSitemapRepo,
+	tag_moderator.NewTagModeratorRepo,
 )
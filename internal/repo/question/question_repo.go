@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -34,9 +35,10 @@ import (
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/jobqueue"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/unique"
-	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
@@ -83,8 +85,9 @@ func (qr *questionRepo) RemoveQuestion(ctx context.Context, id string) (err erro
 	id = uid.DeShortID(id)
 	_, err = qr.data.DB.Context(ctx).Where("id =?", id).Delete(&entity.Question{})
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, id)
 	return
 }
 
@@ -98,18 +101,46 @@ func (qr *questionRepo) UpdateQuestion(ctx context.Context, question *entity.Que
 	if handler.GetEnableShortID(ctx) {
 		question.ID = uid.EnShortID(question.ID)
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, question.ID)
 	_ = qr.UpdateSearch(ctx, question.ID)
 	return
 }
 
-func (qr *questionRepo) UpdatePvCount(ctx context.Context, questionID string) (err error) {
+// UpdateTrendingScores update the trending scores of the questions, only the score is changed so the caches and
+// the search index are kept
+func (qr *questionRepo) UpdateTrendingScores(ctx context.Context, scores map[string]int) (err error) {
+	for questionID, score := range scores {
+		_, err = qr.data.DB.Context(ctx).ID(uid.DeShortID(questionID)).Cols("trending_score").
+			Update(&entity.Question{TrendingScore: score})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
+
+// ResetTrendingScores clear the trending scores of the questions created before the time
+func (qr *questionRepo) ResetTrendingScores(ctx context.Context, createdBefore time.Time) (err error) {
+	_, err = qr.data.DB.Context(ctx).Where("created_at < ? AND trending_score > 0", createdBefore).
+		Cols("trending_score").Update(&entity.Question{TrendingScore: 0})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdatePvCount add the views to the view count of the question, the view count never decreases
+func (qr *questionRepo) UpdatePvCount(ctx context.Context, questionID string, count int) (err error) {
+	if count <= 0 {
+		return nil
+	}
 	questionID = uid.DeShortID(questionID)
 	question := &entity.Question{}
-	_, err = qr.data.DB.Context(ctx).Where("id =?", questionID).Incr("view_count", 1).Update(question)
+	_, err = qr.data.DB.Context(ctx).Where("id =?", questionID).Incr("view_count", count).Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	_ = qr.UpdateSearch(ctx, question.ID)
+	_ = qr.UpdateSearch(ctx, questionID)
 	return nil
 }
 
@@ -121,6 +152,7 @@ func (qr *questionRepo) UpdateAnswerCount(ctx context.Context, questionID string
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, question.ID)
 	_ = qr.UpdateSearch(ctx, question.ID)
 	return nil
 }
@@ -144,79 +176,196 @@ func (qr *questionRepo) UpdateCollectionCount(ctx context.Context, questionID st
 	if err != nil {
 		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, questionID)
 	return count, nil
 }
 
 func (qr *questionRepo) UpdateQuestionStatus(ctx context.Context, questionID string, status int) (err error) {
 	questionID = uid.DeShortID(questionID)
-	_, err = qr.data.DB.Context(ctx).ID(questionID).Cols("status").Update(&entity.Question{Status: status})
+	question := &entity.Question{Status: status}
+	if status == entity.QuestionStatusDeleted {
+		question.DeletedAt = time.Now()
+	}
+	_, err = qr.data.DB.Context(ctx).ID(questionID).Cols("status", "deleted_at").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, questionID)
 	_ = qr.UpdateSearch(ctx, questionID)
 	return nil
 }
 
 func (qr *questionRepo) UpdateQuestionStatusWithOutUpdateTime(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
-	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("status").Update(question)
+	if question.Status == entity.QuestionStatusDeleted {
+		question.DeletedAt = time.Now()
+	} else {
+		question.DeletedAt = time.Time{}
+	}
+	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("status", "deleted_at").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, question.ID)
 	_ = qr.UpdateSearch(ctx, question.ID)
 	return nil
 }
 
+// UpdateQuestionSchedule change the publish time of the scheduled question, the zero time cancels the schedule.
+// Nothing is updated if the question has been published.
+func (qr *questionRepo) UpdateQuestionSchedule(ctx context.Context, questionID string, scheduledAt time.Time) (
+	updated bool, err error) {
+	affected, err := qr.data.DB.Context(ctx).
+		Where(builder.Eq{"id": uid.DeShortID(questionID), "status": entity.QuestionStatusScheduled}).
+		Cols("scheduled_at").Update(&entity.Question{ScheduledAt: scheduledAt})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, questionID)
+	return affected > 0, nil
+}
+
+// GetDueScheduledQuestions get the scheduled questions whose publish time has come, the earliest ones come first
+func (qr *questionRepo) GetDueScheduledQuestions(ctx context.Context, now time.Time, limit int) (
+	questionList []*entity.Question, err error) {
+	questionList = make([]*entity.Question, 0)
+	err = qr.data.DB.Context(ctx).
+		Where(builder.Eq{"status": entity.QuestionStatusScheduled}.And(builder.Lte{"scheduled_at": now})).
+		Asc("scheduled_at").Limit(limit).Find(&questionList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return questionList, nil
+}
+
+// GetStaleQuestions get the listed available questions that have no answers and no activity since the time,
+// the pinned questions and the questions of the exempt tags are excluded. The oldest ones come first.
+func (qr *questionRepo) GetStaleQuestions(ctx context.Context, inactiveBefore time.Time, exemptTagIDs []string,
+	limit int) (questionList []*entity.Question, err error) {
+	questionList = make([]*entity.Question, 0)
+	cond := builder.Eq{
+		"status":       entity.QuestionStatusAvailable,
+		"`show`":       entity.QuestionShow,
+		"answer_count": 0,
+	}.And(builder.Neq{"pin": entity.QuestionPin}).
+		And(builder.Lt{"created_at": inactiveBefore}).
+		And(builder.IsNull{"post_update_time"}.Or(builder.Lt{"post_update_time": inactiveBefore}))
+	if len(exemptTagIDs) > 0 {
+		cond = cond.And(builder.NotIn("id", builder.Select("object_id").From("tag_rel").
+			Where(builder.In("tag_id", exemptTagIDs).And(builder.Neq{"status": entity.TagRelStatusDeleted}))))
+	}
+	err = qr.data.DB.Context(ctx).Where(cond).Asc("created_at").Limit(limit).Find(&questionList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return questionList, nil
+}
+
+// PublishScheduledQuestion make the scheduled question available as if it is posted at the publish time.
+// Only one of the concurrent callers publishes it, the others get false.
+func (qr *questionRepo) PublishScheduledQuestion(ctx context.Context, questionID string, publishAt time.Time) (
+	published bool, err error) {
+	questionID = uid.DeShortID(questionID)
+	affected, err := qr.data.DB.Context(ctx).
+		Where(builder.Eq{"id": questionID, "status": entity.QuestionStatusScheduled}).
+		Cols("status", "created_at", "post_update_time").
+		Update(&entity.Question{Status: entity.QuestionStatusAvailable, CreatedAt: publishAt, PostUpdateTime: publishAt})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, questionID)
+	_ = qr.UpdateSearch(ctx, questionID)
+	return true, nil
+}
+
 func (qr *questionRepo) DeletePermanentlyQuestions(ctx context.Context) (err error) {
+	_, err = qr.deletePermanentlyQuestions(ctx, builder.Eq{"status": entity.QuestionStatusDeleted})
+	return err
+}
+
+// PurgeDeletedQuestions delete permanently the questions deleted before the time
+func (qr *questionRepo) PurgeDeletedQuestions(ctx context.Context, deletedBefore time.Time) (count int64, err error) {
+	return qr.deletePermanentlyQuestions(ctx, builder.Eq{"status": entity.QuestionStatusDeleted}.
+		And(builder.Lt{"deleted_at": deletedBefore}))
+}
+
+func (qr *questionRepo) deletePermanentlyQuestions(ctx context.Context, cond builder.Cond) (count int64, err error) {
 	// get all deleted question ids
 	ids := make([]string, 0)
 	err = qr.data.DB.Context(ctx).Select("id").Table(new(entity.Question).TableName()).
-		Where("status = ?", entity.QuestionStatusDeleted).Find(&ids)
+		Where(cond).Find(&ids)
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	if len(ids) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	// delete all revisions permanently
 	_, err = qr.data.DB.Context(ctx).In("object_id", ids).Delete(&entity.Revision{})
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 
-	_, err = qr.data.DB.Context(ctx).Where("status = ?", entity.QuestionStatusDeleted).Delete(&entity.Question{})
+	count, err = qr.data.DB.Context(ctx).In("id", ids).Delete(&entity.Question{})
 	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	return nil
+	return count, nil
 }
 
 func (qr *questionRepo) RecoverQuestion(ctx context.Context, questionID string) (err error) {
 	questionID = uid.DeShortID(questionID)
-	_, err = qr.data.DB.Context(ctx).ID(questionID).Cols("status").Update(&entity.Question{Status: entity.QuestionStatusAvailable})
+	_, err = qr.data.DB.Context(ctx).ID(questionID).Cols("status", "deleted_at").Update(&entity.Question{Status: entity.QuestionStatusAvailable})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, questionID)
 	_ = qr.UpdateSearch(ctx, questionID)
 	return nil
 }
 
 func (qr *questionRepo) UpdateQuestionOperation(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
-	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("pin", "show").Update(question)
+	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("pin", "pin_expired_at", "show").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, question.ID)
 	return nil
 }
 
+// GetPinnedQuestionCount count the pinned questions that are not deleted
+func (qr *questionRepo) GetPinnedQuestionCount(ctx context.Context) (count int64, err error) {
+	count, err = qr.data.DB.Context(ctx).Where(builder.Eq{"pin": entity.QuestionPin}).
+		And(builder.Neq{"status": entity.QuestionStatusDeleted}).Count(&entity.Question{})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, nil
+}
+
+// UnpinExpiredQuestions unpin the questions whose pin has expired
+func (qr *questionRepo) UnpinExpiredQuestions(ctx context.Context, now time.Time) (count int64, err error) {
+	count, err = qr.data.DB.Context(ctx).
+		Where(builder.Eq{"pin": entity.QuestionPin}.And(builder.Lte{"pin_expired_at": now})).
+		Cols("pin", "pin_expired_at").Update(&entity.Question{Pin: entity.QuestionUnPin})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, nil
+}
+
 func (qr *questionRepo) UpdateAccepted(ctx context.Context, question *entity.Question) (err error) {
 	question.ID = uid.DeShortID(question.ID)
 	_, err = qr.data.DB.Context(ctx).Where("id =?", question.ID).Cols("accepted_answer_id").Update(question)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, question.ID)
 	_ = qr.UpdateSearch(ctx, question.ID)
 	return nil
 }
@@ -227,6 +376,7 @@ func (qr *questionRepo) UpdateLastAnswer(ctx context.Context, question *entity.Q
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.RemoveQuestionPayloadCache(ctx, question.ID)
 	_ = qr.UpdateSearch(ctx, question.ID)
 	return nil
 }
@@ -268,6 +418,135 @@ func (qr *questionRepo) GetQuestionsByTitle(ctx context.Context, title string, p
 	return
 }
 
+// GetRelatedQuestionsByTags get the available questions sharing the tags with the question except itself,
+// the questions sharing more tags come first, then the newer ones. The amount of the shared tags is returned by id.
+func (qr *questionRepo) GetRelatedQuestionsByTags(ctx context.Context, questionID string, tagIDs []string, limit int) (
+	questionList []*entity.Question, sharedTagCount map[string]int, err error) {
+	questionList = make([]*entity.Question, 0)
+	sharedTagCount = make(map[string]int)
+	if len(tagIDs) == 0 {
+		return questionList, sharedTagCount, nil
+	}
+	rows := make([]*struct {
+		entity.Question `xorm:"extends"`
+		SharedTagCount  int `xorm:"shared_tag_count"`
+	}, 0)
+	err = qr.data.DB.Context(ctx).Table("question").
+		Select("question.*, COUNT(tag_rel.id) AS shared_tag_count").
+		Join("INNER", "tag_rel", "question.id = tag_rel.object_id").
+		In("tag_rel.tag_id", tagIDs).
+		And("tag_rel.status = ?", entity.TagRelStatusAvailable).
+		And("question.id != ?", uid.DeShortID(questionID)).
+		And("question.status = ?", entity.QuestionStatusAvailable).
+		And("question.show = ?", entity.QuestionShow).
+		GroupBy("question.id").
+		OrderBy("shared_tag_count DESC, question.created_at DESC").
+		Limit(limit).
+		Find(&rows)
+	if err != nil {
+		return nil, nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, row := range rows {
+		question := row.Question
+		if handler.GetEnableShortID(ctx) {
+			question.ID = uid.EnShortID(question.ID)
+		}
+		questionList = append(questionList, &question)
+		sharedTagCount[question.ID] = row.SharedTagCount
+	}
+	return questionList, sharedTagCount, nil
+}
+
+// GetRelatedQuestionsCache get the cached related questions of the question
+func (qr *questionRepo) GetRelatedQuestionsCache(ctx context.Context, questionID string) (
+	resp []*schema.RelatedQuestionResp, exist bool, err error) {
+	cacheData, exist, err := qr.data.Cache.GetString(ctx, constant.QuestionRelatedCacheKey+questionID)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	resp = make([]*schema.RelatedQuestionResp, 0)
+	if err = json.Unmarshal([]byte(cacheData), &resp); err != nil {
+		return nil, false, nil
+	}
+	return resp, true, nil
+}
+
+// SetRelatedQuestionsCache cache the related questions of the question for a while
+func (qr *questionRepo) SetRelatedQuestionsCache(ctx context.Context, questionID string,
+	resp []*schema.RelatedQuestionResp) (err error) {
+	cacheData, _ := json.Marshal(resp)
+	err = qr.data.Cache.SetString(ctx, constant.QuestionRelatedCacheKey+questionID, string(cacheData),
+		constant.QuestionRelatedCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetQuestionPayloadVersion get the version of the cached payloads of the question, a new version is created if
+// there is none. It must be got before the payload is read from the database, so that a write committed in the
+// meantime removes the version and the payload cached with it is never read.
+func (qr *questionRepo) GetQuestionPayloadVersion(ctx context.Context, questionID string) (version string, err error) {
+	key := constant.QuestionPayloadVersionCacheKey + uid.DeShortID(questionID)
+	version, exist, err := qr.data.Cache.GetString(ctx, key)
+	if err != nil {
+		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return version, nil
+	}
+	version = strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err = qr.data.Cache.SetString(ctx, key, version, constant.QuestionPayloadCacheTime); err != nil {
+		return "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return version, nil
+}
+
+// GetQuestionPayloadCache get the cached payload of the question in the variant, such as the language of it
+func (qr *questionRepo) GetQuestionPayloadCache(ctx context.Context, questionID, version, variant string,
+	value any) (exist bool, err error) {
+	cacheData, exist, err := qr.data.Cache.GetString(ctx, questionPayloadCacheKey(questionID, version, variant))
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return false, nil
+	}
+	if err = json.Unmarshal([]byte(cacheData), value); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// SetQuestionPayloadCache cache the payload of the question in the variant with the version got before it is read
+func (qr *questionRepo) SetQuestionPayloadCache(ctx context.Context, questionID, version, variant string,
+	value any) (err error) {
+	cacheData, _ := json.Marshal(value)
+	err = qr.data.Cache.SetString(ctx, questionPayloadCacheKey(questionID, version, variant), string(cacheData),
+		constant.QuestionPayloadCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveQuestionPayloadCache remove the version of the cached payloads of the question, all of them are invalid
+func (qr *questionRepo) RemoveQuestionPayloadCache(ctx context.Context, questionID string) (err error) {
+	err = qr.data.Cache.Del(ctx, constant.QuestionPayloadVersionCacheKey+uid.DeShortID(questionID))
+	if err != nil {
+		log.Errorf("remove question payload cache failed, question id: %s, err: %v", questionID, err)
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func questionPayloadCacheKey(questionID, version, variant string) string {
+	return constant.QuestionPayloadCacheKey + uid.DeShortID(questionID) + ":" + version + ":" + variant
+}
+
 func (qr *questionRepo) FindByID(ctx context.Context, id []string) (questionList []*entity.Question, err error) {
 	for key, itemID := range id {
 		id[key] = uid.DeShortID(itemID)
@@ -342,118 +621,232 @@ func (qr *questionRepo) GetUserQuestionCount(ctx context.Context, userID string,
 	return
 }
 
-func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int) (
-	questionIDList []*schema.SiteMapQuestionInfo, err error) {
-	page--
-	questionIDList = make([]*schema.SiteMapQuestionInfo, 0)
+// GetQuestionPage query question page, the questions of the users muted by mutedByUserID are excluded
+// unless the questions of a specific user are queried
+func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
+	tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool, mutedByUserID string) (
+	questionList []*entity.Question, total int64, err error) {
+	questionList = make([]*entity.Question, 0)
+	session := qr.questionPageSession(ctx, &entity.QuestionPageCond{
+		TagIDs:        tagIDs,
+		UserID:        userID,
+		OrderCond:     orderCond,
+		InDays:        inDays,
+		ShowHidden:    showHidden,
+		ShowPending:   showPending,
+		MutedByUserID: mutedByUserID,
+	})
 
-	// try to get sitemap data from cache
-	cacheKey := fmt.Sprintf(constant.SiteMapQuestionCacheKeyPrefix, page)
-	cacheData, exist, err := qr.data.Cache.GetString(ctx, cacheKey)
-	if err == nil && exist {
-		_ = json.Unmarshal([]byte(cacheData), &questionIDList)
-		return questionIDList, nil
+	// the questions pinned within the tag come first in the tag list, then the globally pinned ones
+	pinOrder := "question.pin DESC"
+	if len(tagIDs) > 0 {
+		pinOrder = fmt.Sprintf("MAX(CASE WHEN tag_rel.pin = %d THEN 1 ELSE 0 END) DESC, question.pin DESC",
+			entity.QuestionPin)
+	}
+	switch orderCond {
+	case "newest":
+		session.OrderBy(pinOrder + ",question.created_at DESC")
+	case "active":
+		session.OrderBy(pinOrder + ",question.post_update_time DESC, question.updated_at DESC")
+	case "hot":
+		session.OrderBy(pinOrder + ",question.hot_score DESC")
+	case "trending":
+		session.OrderBy(pinOrder + ",question.trending_score DESC, question.created_at DESC")
+	case "score":
+		session.OrderBy(pinOrder + ",question.vote_count DESC, question.view_count DESC")
+	case "unanswered":
+		session.OrderBy(pinOrder + ",question.created_at DESC")
+	case "frequent":
+		session.OrderBy(pinOrder + ", question.linked_count DESC, question.updated_at DESC")
 	}
 
-	// get sitemap data from db
-	rows := make([]*entity.Question, 0)
-	session := qr.data.DB.Context(ctx)
-	session.Select("id,title,created_at,post_update_time")
-	session.Where("`show` = ?", entity.QuestionShow)
-	session.Where("status = ? OR status = ?", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)
-	session.Limit(pageSize, page*pageSize)
-	session.Asc("created_at")
-	err = session.Find(&rows)
+	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
 	if err != nil {
-		return questionIDList, err
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-
-	// warp data
-	for _, question := range rows {
-		item := &schema.SiteMapQuestionInfo{ID: question.ID}
-		if handler.GetEnableShortID(ctx) {
-			item.ID = uid.EnShortID(question.ID)
-		}
-		item.Title = htmltext.UrlTitle(question.Title)
-		if question.PostUpdateTime.IsZero() {
-			item.UpdateTime = question.CreatedAt.Format(time.RFC3339)
-		} else {
-			item.UpdateTime = question.PostUpdateTime.Format(time.RFC3339)
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
 		}
-		questionIDList = append(questionIDList, item)
 	}
+	return questionList, total, err
+}
 
-	// set sitemap data to cache
-	cacheDataByte, _ := json.Marshal(questionIDList)
-	if err := qr.data.Cache.SetString(ctx, cacheKey, string(cacheDataByte), constant.SiteMapQuestionCacheTime); err != nil {
-		log.Error(err)
+// GetQuestionPageByCursor get the questions after the cursor in the same order as the question page,
+// the next cursor is empty if it is the last page
+func (qr *questionRepo) GetQuestionPageByCursor(ctx context.Context, cond *entity.QuestionPageCond,
+	cursor string, pageSize int) (questionList []*entity.Question, nextCursor string, err error) {
+	_, pageSize = pager.ValPageAndPageSize(0, pageSize)
+	order, keys := questionCursorKeys(cond)
+	after, err := pager.DecodeCursor(cursor, order, keys)
+	if err != nil {
+		return nil, "", errors.BadRequest(reason.PageCursorInvalid)
 	}
-	return questionIDList, nil
-}
 
-// GetQuestionPage query question page
-func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
-	tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
-	questionList []*entity.Question, total int64, err error) {
 	questionList = make([]*entity.Question, 0)
-	session := qr.data.DB.Context(ctx)
+	session := qr.questionPageSession(ctx, cond)
+	err = session.And(pager.CursorCond(keys, after)).OrderBy(pager.CursorOrderBy(keys)).
+		Limit(pageSize + 1).Find(&questionList)
+	if err != nil {
+		return nil, "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(questionList) > pageSize {
+		questionList = questionList[:pageSize]
+		next, err := qr.questionCursor(ctx, cond, order, questionList[pageSize-1])
+		if err != nil {
+			return nil, "", err
+		}
+		nextCursor = next.Encode()
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return questionList, nextCursor, nil
+}
+
+// questionPageSession the session of the questions matching the condition of the question list
+func (qr *questionRepo) questionPageSession(ctx context.Context, cond *entity.QuestionPageCond) *xorm.Session {
+	session := qr.data.ReadDB(ctx)
 	status := []int{entity.QuestionStatusAvailable}
-	if orderCond != "unanswered" {
+	if cond.OrderCond != "unanswered" {
 		status = append(status, entity.QuestionStatusClosed)
 	}
-	if showPending {
+	if cond.ShowPending {
 		status = append(status, entity.QuestionStatusPending)
 	}
 	session.Select("question.*")
 	session.In("question.status", status)
-	if len(tagIDs) > 0 {
+	if len(cond.TagIDs) > 0 {
 		session.Join("LEFT", "tag_rel", "question.id = tag_rel.object_id")
-		session.In("tag_rel.tag_id", tagIDs)
+		session.In("tag_rel.tag_id", cond.TagIDs)
 		session.And("tag_rel.status = ?", entity.TagRelStatusAvailable)
 	}
-	if len(userID) > 0 {
-		session.And("question.user_id = ?", userID)
-		if !showHidden {
+	if len(cond.UserID) > 0 {
+		session.And("question.user_id = ?", cond.UserID)
+		if !cond.ShowHidden {
 			session.And("question.show = ?", entity.QuestionShow)
 		}
 	} else {
 		session.And("question.show = ?", entity.QuestionShow)
+		if len(cond.MutedByUserID) > 0 {
+			session.And(builder.NotIn("question.user_id", builder.Select("muted_user_id").From("user_mute").
+				Where(builder.Eq{"user_id": cond.MutedByUserID})))
+		}
 	}
-	if inDays > 0 {
-		session.And("question.created_at > ?", time.Now().AddDate(0, 0, -inDays))
+	if cond.InDays > 0 {
+		session.And("question.created_at > ?", time.Now().AddDate(0, 0, -cond.InDays))
 	}
-
-	switch orderCond {
-	case "newest":
-		session.OrderBy("question.pin desc,question.created_at DESC")
+	switch cond.OrderCond {
 	case "active":
-		if inDays == 0 {
+		if cond.InDays == 0 {
 			session.And("question.created_at > ?", time.Now().AddDate(0, 0, -180))
 		}
 		session.And("question.post_update_time > ?", time.Now().AddDate(0, 0, -90))
-		session.OrderBy("question.pin desc,question.post_update_time DESC, question.updated_at DESC")
+	case "unanswered":
+		session.And("question.answer_count = 0")
+	}
+	session.GroupBy("question.id")
+	return session
+}
+
+// questionCursorKeys the sort keys of the order of the question list, the pinned questions come first as the
+// question page and the id makes the order stable. The nullable time columns fall back to the created time.
+func questionCursorKeys(cond *entity.QuestionPageCond) (order string, keys []pager.CursorKey) {
+	keys = make([]pager.CursorKey, 0, 5)
+	if len(cond.TagIDs) > 0 {
+		keys = append(keys, pager.CursorKey{Column: tagPinExpr(cond.TagIDs), Desc: true})
+	}
+	keys = append(keys, pager.CursorKey{Column: "question.pin", Desc: true})
+
+	order = cond.OrderCond
+	switch order {
+	case "active":
+		keys = append(keys,
+			pager.CursorKey{Column: "COALESCE(question.post_update_time, question.created_at)", Desc: true, Time: true},
+			pager.CursorKey{Column: "COALESCE(question.updated_at, question.created_at)", Desc: true, Time: true})
 	case "hot":
-		session.OrderBy("question.pin desc,question.hot_score DESC")
+		keys = append(keys, pager.CursorKey{Column: "question.hot_score", Desc: true})
+	case "trending":
+		keys = append(keys,
+			pager.CursorKey{Column: "question.trending_score", Desc: true},
+			pager.CursorKey{Column: "question.created_at", Desc: true, Time: true})
 	case "score":
-		session.OrderBy("question.pin desc,question.vote_count DESC, question.view_count DESC")
-	case "unanswered":
-		session.Where("question.answer_count = 0")
-		session.OrderBy("question.pin desc,question.created_at DESC")
+		keys = append(keys,
+			pager.CursorKey{Column: "question.vote_count", Desc: true},
+			pager.CursorKey{Column: "question.view_count", Desc: true})
 	case "frequent":
-		session.OrderBy("question.pin DESC, question.linked_count DESC, question.updated_at DESC")
+		keys = append(keys,
+			pager.CursorKey{Column: "question.linked_count", Desc: true},
+			pager.CursorKey{Column: "COALESCE(question.updated_at, question.created_at)", Desc: true, Time: true})
+	case "unanswered":
+		keys = append(keys, pager.CursorKey{Column: "question.created_at", Desc: true, Time: true})
+	default:
+		order = "newest"
+		keys = append(keys, pager.CursorKey{Column: "question.created_at", Desc: true, Time: true})
 	}
+	keys = append(keys, pager.CursorKey{Column: "question.id", Desc: true})
+	return order, keys
+}
 
-	session.GroupBy("question.id")
-	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+// tagPinExpr whether the question is pinned within one of the tags, the ids are numbers from the database
+func tagPinExpr(tagIDs []string) string {
+	ids := make([]string, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		if n, err := strconv.ParseInt(id, 10, 64); err == nil {
+			ids = append(ids, strconv.FormatInt(n, 10))
+		}
 	}
-	if handler.GetEnableShortID(ctx) {
-		for _, item := range questionList {
-			item.ID = uid.EnShortID(item.ID)
+	if len(ids) == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("(CASE WHEN EXISTS (SELECT 1 FROM tag_rel tp WHERE tp.object_id = question.id AND "+
+		"tp.status = %d AND tp.pin = %d AND tp.tag_id IN (%s)) THEN 1 ELSE 0 END)",
+		entity.TagRelStatusAvailable, entity.QuestionPin, strings.Join(ids, ","))
+}
+
+// questionCursor the cursor after the question in the order
+func (qr *questionRepo) questionCursor(ctx context.Context, cond *entity.QuestionPageCond, order string,
+	question *entity.Question) (cursor *pager.Cursor, err error) {
+	cursor = &pager.Cursor{Order: order, Values: make([]int64, 0, 5)}
+	if len(cond.TagIDs) > 0 {
+		pinned, err := qr.data.ReadDB(ctx).Table("tag_rel").Where("object_id = ?", question.ID).
+			And("status = ? AND pin = ?", entity.TagRelStatusAvailable, entity.QuestionPin).
+			In("tag_id", cond.TagIDs).Exist()
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 		}
+		var tagPin int64
+		if pinned {
+			tagPin = 1
+		}
+		cursor.Values = append(cursor.Values, tagPin)
 	}
-	return questionList, total, err
+	cursor.Values = append(cursor.Values, int64(question.Pin))
+
+	orElseCreated := func(t time.Time) int64 {
+		if t.IsZero() {
+			return question.CreatedAt.Unix()
+		}
+		return t.Unix()
+	}
+	switch order {
+	case "active":
+		cursor.Values = append(cursor.Values, orElseCreated(question.PostUpdateTime), orElseCreated(question.UpdatedAt))
+	case "hot":
+		cursor.Values = append(cursor.Values, int64(question.HotScore))
+	case "trending":
+		cursor.Values = append(cursor.Values, int64(question.TrendingScore), question.CreatedAt.Unix())
+	case "score":
+		cursor.Values = append(cursor.Values, int64(question.VoteCount), int64(question.ViewCount))
+	case "frequent":
+		cursor.Values = append(cursor.Values, int64(question.LinkedCount), orElseCreated(question.UpdatedAt))
+	default:
+		cursor.Values = append(cursor.Values, question.CreatedAt.Unix())
+	}
+	cursor.Values = append(cursor.Values, converter.StringToInt64(question.ID))
+	return cursor, nil
 }
 
 // GetRecommendQuestionPageByTags get recommend question page by tags
@@ -469,7 +862,7 @@ func (qr *questionRepo) GetRecommendQuestionPageByTags(ctx context.Context, user
 		selectSQL += fmt.Sprintf(", CASE WHEN question.id IN (%s) THEN 0 ELSE 1 END AS order_priority", idStr)
 		orderBySQL = "order_priority, " + orderBySQL
 	}
-	session := qr.data.DB.Context(ctx).Select(selectSQL)
+	session := qr.data.ReadDB(ctx).Select(selectSQL)
 
 	if len(tagIDs) > 0 {
 		session.Where("question.user_id != ?", userID).
@@ -580,8 +973,50 @@ func (qr *questionRepo) AdminQuestionPage(ctx context.Context, search *schema.Ad
 	return rows, count, nil
 }
 
-// UpdateSearch update search, if search plugin not enable, do nothing
+// GetDeletedQuestionPage get the questions deleted after the time, the latest deleted first
+func (qr *questionRepo) GetDeletedQuestionPage(ctx context.Context, page, pageSize int, deletedAfter time.Time) (
+	questionList []*entity.Question, total int64, err error) {
+	questionList = make([]*entity.Question, 0)
+	session := qr.data.DB.Context(ctx).Where("status = ?", entity.QuestionStatusDeleted)
+	if !deletedAfter.IsZero() {
+		session.And("deleted_at >= ?", deletedAfter)
+	}
+	session.Desc("deleted_at")
+	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return questionList, total, nil
+}
+
+// UpdateSearch update search by the background job, if search plugin not enable, do nothing
 func (qr *questionRepo) UpdateSearch(ctx context.Context, questionID string) (err error) {
+	var s plugin.Search
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		s = search
+		return nil
+	})
+	if s == nil {
+		return nil
+	}
+	job, err := jobqueue.NewJob(constant.JobTypeSearchIndexQuestion,
+		&schema.SearchIndexJob{ObjectID: uid.DeShortID(questionID)})
+	if err != nil {
+		return err
+	}
+	if _, err = qr.data.DB.Context(ctx).Insert(job); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// IndexSearch update the search index of the question, if search plugin not enable, do nothing
+func (qr *questionRepo) IndexSearch(ctx context.Context, questionID string) (err error) {
 	// check search plugin
 	var s plugin.Search
 	_ = plugin.CallSearch(func(search plugin.Search) error {
@@ -652,9 +1087,10 @@ func (qr *questionRepo) RemoveAllUserQuestion(ctx context.Context, userID string
 	// delete all question
 	session = qr.data.DB.Context(ctx).Where("user_id = ?", userID)
 	session.Where("status != ?", entity.QuestionStatusDeleted)
-	_, err = session.Cols("status", "updated_at").Update(&entity.Question{
+	_, err = session.Cols("status", "updated_at", "deleted_at").Update(&entity.Question{
 		UpdatedAt: time.Now(),
 		Status:    entity.QuestionStatusDeleted,
+		DeletedAt: time.Now(),
 	})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -662,6 +1098,7 @@ func (qr *questionRepo) RemoveAllUserQuestion(ctx context.Context, userID string
 
 	// update search content
 	for _, id := range questionIDs {
+		_ = qr.RemoveQuestionPayloadCache(ctx, id)
 		_ = qr.UpdateSearch(ctx, id)
 	}
 	return nil
@@ -827,7 +1264,7 @@ func (qr *questionRepo) GetQuestionLink(ctx context.Context, page, pageSize int,
 		).WithStack()
 	}
 
-	session := qr.data.DB.Context(ctx).
+	session := qr.data.ReadDB(ctx).
 		Table("question_link").
 		Join("INNER", "question", "question_link.from_question_id = question.id").
 		Where("question_link.to_question_id = ? AND question.show = ?", questionID, entity.QuestionShow).
@@ -847,6 +1284,8 @@ func (qr *questionRepo) GetQuestionLink(ctx context.Context, page, pageSize int,
 		session.OrderBy("question.pin desc,question.post_update_time DESC, question.updated_at DESC")
 	case "hot":
 		session.OrderBy("question.pin desc,question.hot_score DESC")
+	case "trending":
+		session.OrderBy("question.pin desc,question.trending_score DESC, question.created_at DESC")
 	case "score":
 		session.OrderBy("question.pin desc,question.vote_count DESC, question.view_count DESC")
 	case "unanswered":
@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/service/question_view"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// questionViewRepo keeps the pending views in the cache, so they are not lost when the application restarts.
+// The counters and the set of the questions with pending views are updated atomically in the cache,
+// so they are consistent when several instances share the cache.
+type questionViewRepo struct {
+	data *data.Data
+}
+
+// NewQuestionViewRepo new repository
+func NewQuestionViewRepo(data *data.Data) question_view.QuestionViewRepo {
+	return &questionViewRepo{
+		data: data,
+	}
+}
+
+func (qr *questionViewRepo) MarkViewed(ctx context.Context, questionID, viewer string) (first bool, err error) {
+	key := fmt.Sprintf(constant.QuestionViewedCacheKey, questionID, viewer)
+	first, err = data.SetIfAbsent(ctx, qr.data.Cache, key, constant.QuestionViewedCacheTime)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return first, nil
+}
+
+func (qr *questionViewRepo) AddPendingView(ctx context.Context, questionID string) (err error) {
+	count, err := qr.data.Cache.Increase(ctx, constant.QuestionPendingViewCacheKey+questionID, 1)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	// the question is added into the set by the first pending view, it is removed when all the views are flushed
+	if count != 1 {
+		return nil
+	}
+	if err = data.SetAdd(ctx, qr.data.Cache, constant.QuestionPendingViewIDsCacheKey, questionID); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (qr *questionViewRepo) GetPendingView(ctx context.Context, questionID string) (count int64, err error) {
+	count, _, err = qr.data.Cache.GetInt64(ctx, constant.QuestionPendingViewCacheKey+questionID)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, nil
+}
+
+func (qr *questionViewRepo) GetPendingViews(ctx context.Context) (views map[string]int64, err error) {
+	ids, err := data.SetMembers(ctx, qr.data.Cache, constant.QuestionPendingViewIDsCacheKey)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	views = make(map[string]int64, len(ids))
+	for _, questionID := range ids {
+		count, err := qr.GetPendingView(ctx, questionID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			views[questionID] = count
+		}
+	}
+	return views, nil
+}
+
+func (qr *questionViewRepo) RemovePendingView(ctx context.Context, questionID string, count int64) (err error) {
+	key := constant.QuestionPendingViewCacheKey + questionID
+	// the counter is kept at zero instead of being deleted, the views added meanwhile would be lost by the deletion
+	remain, err := qr.data.Cache.Decrease(ctx, key, count)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if remain > 0 {
+		return nil
+	}
+	err = data.SetRemove(ctx, qr.data.Cache, constant.QuestionPendingViewIDsCacheKey, questionID)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	// the view added between the decrease and the removal does not add the question again, check it after the removal
+	remain, err = qr.GetPendingView(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if remain <= 0 {
+		return nil
+	}
+	if err = data.SetAdd(ctx, qr.data.Cache, constant.QuestionPendingViewIDsCacheKey, questionID); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (qr *questionViewRepo) LockFlush(ctx context.Context) (locked bool, err error) {
+	locked, err = data.SetIfAbsent(ctx, qr.data.Cache, constant.QuestionPendingViewFlushLockCacheKey,
+		constant.QuestionPendingViewFlushLockCacheTime)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return locked, nil
+}
+
+func (qr *questionViewRepo) UnlockFlush(ctx context.Context) (err error) {
+	if err = qr.data.Cache.Del(ctx, constant.QuestionPendingViewFlushLockCacheKey); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
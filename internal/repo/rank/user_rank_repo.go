@@ -21,11 +21,15 @@ package rank
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/plugin"
@@ -213,3 +217,180 @@ func (ur *UserRankRepo) UserRankPage(ctx context.Context, userID string, page, p
 	}
 	return
 }
+
+// UserReputationChangePage get the reputation changes of the user, the newest first.
+// Every activity with rank is a change, and a cancelled one is reversed at the time it was cancelled.
+// If reversal is true only the reversals are listed, otherwise if activityTypes is not empty only the
+// changes of these activities are listed. newerSum is the sum of the changes newer than this page.
+func (ur *UserRankRepo) UserReputationChangePage(ctx context.Context, userID string,
+	activityTypes []int, reversal bool, page, pageSize int) (
+	changes []*entity.ActivityReputationChange, total, newerSum int64, err error) {
+	changes = make([]*entity.ActivityReputationChange, 0)
+	page, pageSize = pager.ValPageAndPageSize(page, pageSize)
+
+	gained := builder.Select("id", "object_id", "activity_type", "`rank` AS delta",
+		"0 AS reversal", "created_at AS happened_at").
+		From("activity").
+		Where(builder.Eq{"user_id": userID, "has_rank": 1}.And(builder.Neq{"`rank`": 0}))
+	if len(activityTypes) > 0 {
+		gained = gained.And(builder.In("activity_type", activityTypes))
+	}
+	reversed := builder.Select("id", "object_id", "activity_type", "0 - `rank` AS delta",
+		"1 AS reversal", "cancelled_at AS happened_at").
+		From("activity").
+		Where(builder.Eq{"user_id": userID, "has_rank": 1}.
+			And(builder.In("cancelled", entity.ActivityCancelled, entity.ActivityCancelledByDeletion)).
+			And(builder.Neq{"`rank`": 0}))
+
+	var (
+		changeSQL  string
+		changeArgs []any
+	)
+	switch {
+	case reversal:
+		changeSQL, changeArgs, err = reversed.ToSQL()
+	case len(activityTypes) > 0:
+		changeSQL, changeArgs, err = gained.ToSQL()
+	default:
+		// not use builder.Union, the parentheses it adds are not supported by sqlite
+		var gainedSQL, reversedSQL string
+		var gainedArgs, reversedArgs []any
+		if gainedSQL, gainedArgs, err = gained.ToSQL(); err == nil {
+			reversedSQL, reversedArgs, err = reversed.ToSQL()
+		}
+		changeSQL = gainedSQL + " UNION ALL " + reversedSQL
+		changeArgs = append(gainedArgs, reversedArgs...)
+	}
+	if err != nil {
+		return nil, 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	changeSQL = "(" + changeSQL + ")"
+	const order = " ORDER BY happened_at DESC, id DESC, reversal DESC"
+
+	_, err = ur.data.DB.Context(ctx).SQL("SELECT COUNT(*) FROM "+changeSQL+" t", changeArgs...).Get(&total)
+	if err != nil {
+		return nil, 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	offset := (page - 1) * pageSize
+	err = ur.data.DB.Context(ctx).
+		SQL("SELECT * FROM "+changeSQL+" t"+order+" LIMIT ? OFFSET ?", append(changeArgs, pageSize, offset)...).
+		Find(&changes)
+	if err != nil {
+		return nil, 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if offset == 0 {
+		return changes, total, 0, nil
+	}
+	_, err = ur.data.DB.Context(ctx).
+		SQL("SELECT COALESCE(SUM(delta), 0) FROM (SELECT delta FROM "+changeSQL+" t"+order+" LIMIT ?) s",
+			append(changeArgs, offset)...).
+		Get(&newerSum)
+	if err != nil {
+		return nil, 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return changes, total, newerSum, nil
+}
+
+// GetReputationLeaderboard get the users who gained the most reputation since the time, the most first.
+// The changes of the reputation ledger since the time are summed up, so a reversal in the period takes back
+// the reputation even if it was gained before. If tagIDs is not empty only the reputation gained
+// from the questions with these tags and their answers is counted. If since is zero and tagIDs is empty
+// the reputation of the users is used. Only the available users who gained reputation are listed.
+func (ur *UserRankRepo) GetReputationLeaderboard(ctx context.Context, since time.Time, tagIDs []string,
+	page, pageSize int) (stats []*entity.ActivityUserRankStat, total int64, err error) {
+	stats = make([]*entity.ActivityUserRankStat, 0)
+	page, pageSize = pager.ValPageAndPageSize(page, pageSize)
+	offset := (page - 1) * pageSize
+
+	if since.IsZero() && len(tagIDs) == 0 {
+		session := ur.data.DB.Context(ctx).Table("user").Select("id AS user_id, `rank` AS rank_amount").
+			Where(builder.Eq{"status": entity.UserStatusAvailable}).And(builder.Gt{"`rank`": 0}).
+			OrderBy("`rank` DESC, id ASC")
+		total, err = session.Limit(pageSize, offset).FindAndCount(&stats)
+		if err != nil {
+			return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return stats, total, nil
+	}
+
+	filter := func(timeColumn string) builder.Cond {
+		cond := builder.Eq{"activity.has_rank": 1}.And(builder.Neq{"activity.`rank`": 0})
+		if !since.IsZero() {
+			cond = cond.And(builder.Gte{timeColumn: since.Format(time.DateTime)})
+		}
+		if len(tagIDs) > 0 {
+			// the activities of the answers belong to the tags of their questions
+			cond = cond.And(builder.In("COALESCE(answer.question_id, activity.object_id)",
+				builder.Select("object_id").From("tag_rel").
+					Where(builder.In("tag_id", tagIDs).And(builder.Eq{"status": entity.TagRelStatusAvailable}))))
+		}
+		return cond
+	}
+	gained := builder.Select("activity.user_id", "activity.`rank` AS delta").From("activity").
+		LeftJoin("answer", "answer.id = activity.object_id").
+		Where(filter("activity.created_at"))
+	reversed := builder.Select("activity.user_id", "0 - activity.`rank` AS delta").From("activity").
+		LeftJoin("answer", "answer.id = activity.object_id").
+		Where(filter("activity.cancelled_at").
+			And(builder.In("activity.cancelled", entity.ActivityCancelled, entity.ActivityCancelledByDeletion)))
+
+	// not use builder.Union, the parentheses it adds are not supported by sqlite
+	gainedSQL, gainedArgs, err := gained.ToSQL()
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	reversedSQL, reversedArgs, err := reversed.ToSQL()
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	statSQL := "SELECT t.user_id, SUM(t.delta) AS rank_amount FROM (" + gainedSQL + " UNION ALL " + reversedSQL +
+		") t INNER JOIN `user` u ON u.id = t.user_id WHERE u.status = ? GROUP BY t.user_id HAVING SUM(t.delta) > 0"
+	statArgs := append(append(gainedArgs, reversedArgs...), entity.UserStatusAvailable)
+
+	_, err = ur.data.DB.Context(ctx).SQL("SELECT COUNT(*) FROM ("+statSQL+") s", statArgs...).Get(&total)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	err = ur.data.DB.Context(ctx).
+		SQL(statSQL+" ORDER BY rank_amount DESC, t.user_id ASC LIMIT ? OFFSET ?", append(statArgs, pageSize, offset)...).
+		Find(&stats)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return stats, total, nil
+}
+
+// reputationLeaderboardCache the cached page of the reputation leaderboard
+type reputationLeaderboardCache struct {
+	Total int64                                  `json:"total"`
+	List  []*schema.GetReputationLeaderboardResp `json:"list"`
+}
+
+// GetReputationLeaderboardCache get the cached page of the reputation leaderboard
+func (ur *UserRankRepo) GetReputationLeaderboardCache(ctx context.Context, key string) (
+	list []*schema.GetReputationLeaderboardResp, total int64, exist bool, err error) {
+	cacheData, exist, err := ur.data.Cache.GetString(ctx, constant.ReputationLeaderboardCacheKey+key)
+	if err != nil {
+		return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, 0, false, nil
+	}
+	cache := &reputationLeaderboardCache{}
+	if err = json.Unmarshal([]byte(cacheData), cache); err != nil {
+		return nil, 0, false, nil
+	}
+	return cache.List, cache.Total, true, nil
+}
+
+// SetReputationLeaderboardCache cache the page of the reputation leaderboard for a while
+func (ur *UserRankRepo) SetReputationLeaderboardCache(ctx context.Context, key string,
+	list []*schema.GetReputationLeaderboardResp, total int64) (err error) {
+	cacheData, _ := json.Marshal(&reputationLeaderboardCache{Total: total, List: list})
+	err = ur.data.Cache.SetString(ctx, constant.ReputationLeaderboardCacheKey+key, string(cacheData),
+		constant.ReputationLeaderboardCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
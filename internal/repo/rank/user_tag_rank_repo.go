@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"slices"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/activity_type"
+	"github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// userTagRankRepo user tag rank repository
+type userTagRankRepo struct {
+	data *data.Data
+}
+
+// NewUserTagRankRepo new repository
+func NewUserTagRankRepo(data *data.Data) tag_common.UserTagRankRepo {
+	return &userTagRankRepo{
+		data: data,
+	}
+}
+
+// ChangeQuestionTagRank add the reputation the users gained from the question and its answers to the tags,
+// or take it back from the tags if add is false. It is called when the tags of the question change.
+func (ur *userTagRankRepo) ChangeQuestionTagRank(ctx context.Context, questionID string, tagIDs []string,
+	add bool) (err error) {
+	if plugin.RankAgentEnabled() || len(tagIDs) == 0 {
+		return nil
+	}
+	questionID = uid.DeShortID(questionID)
+
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		activityTypes, err := getTagRankActivityTypes(session)
+		if err != nil || len(activityTypes) == 0 {
+			return nil, err
+		}
+		stats := make([]*entity.ActivityUserRankStat, 0)
+		err = session.Table("activity").Select("user_id, SUM(`rank`) AS rank_amount").
+			Where(builder.Eq{"has_rank": 1, "cancelled": entity.ActivityAvailable}).
+			And(builder.In("activity_type", activityTypes)).
+			And(builder.Eq{"object_id": questionID}.Or(builder.In("object_id",
+				builder.Select("id").From("answer").Where(builder.Eq{"question_id": questionID})))).
+			GroupBy("user_id").
+			Find(&stats)
+		if err != nil {
+			return nil, err
+		}
+		userDelta := make(map[string]int, len(stats))
+		for _, stat := range stats {
+			if add {
+				userDelta[stat.UserID] = stat.Rank
+			} else {
+				userDelta[stat.UserID] = -stat.Rank
+			}
+		}
+		return nil, changeUserTagRank(session, userDelta, tagIDs)
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// ChangeUserTagRank change the reputation of the user in the tags of the question the object belongs to.
+// Only the votes received by the questions and the answers change the reputation in the tags.
+func (ur *UserRankRepo) ChangeUserTagRank(ctx context.Context, session *xorm.Session,
+	userID, objectID string, activityType, deltaRank int) (err error) {
+	if plugin.RankAgentEnabled() || deltaRank == 0 {
+		return nil
+	}
+	activityTypes, err := getTagRankActivityTypes(session)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(activityTypes, activityType) {
+		return nil
+	}
+
+	questionID := uid.DeShortID(objectID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(questionID)
+	if err != nil {
+		return err
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+	case constant.AnswerObjectType:
+		exist, err := session.Table("answer").Where(builder.Eq{"id": questionID}).Cols("question_id").Get(&questionID)
+		if err != nil || !exist {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	tagIDs := make([]string, 0)
+	err = session.Table("tag_rel").Where(builder.Eq{"object_id": questionID}).
+		And(builder.In("status", entity.TagRelStatusAvailable, entity.TagRelStatusHide)).
+		Cols("tag_id").Find(&tagIDs)
+	if err != nil {
+		return err
+	}
+	return changeUserTagRank(session, map[string]int{userID: deltaRank}, tagIDs)
+}
+
+// GetUserTagRankPage get the reputation of the user in the tags, the highest first
+func (ur *UserRankRepo) GetUserTagRankPage(ctx context.Context, userID string, tagIDs []string, page, pageSize int) (
+	tagRanks []*entity.UserTagRank, total int64, err error) {
+	tagRanks = make([]*entity.UserTagRank, 0)
+	session := ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).And(builder.Neq{"`rank`": 0})
+	if len(tagIDs) > 0 {
+		session.In("tag_id", tagIDs)
+	}
+	session.Desc("`rank`").Asc("tag_id")
+	total, err = pager.Help(page, pageSize, &tagRanks, &entity.UserTagRank{}, session)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return tagRanks, total, nil
+}
+
+// getTagRankActivityTypes get the ids of the activities that change the reputation in the tags.
+// They are read by the session of the transaction, another connection may be blocked by it.
+func getTagRankActivityTypes(session *xorm.Session) (activityTypes []int, err error) {
+	activityTypes = make([]int, 0)
+	err = session.Table("config").In("`key`", activity_type.TagReputationActivityTypeList).
+		Cols("id").Find(&activityTypes)
+	return activityTypes, err
+}
+
+// changeUserTagRank add the reputation delta of the users to their reputation in the tags
+func changeUserTagRank(session *xorm.Session, userDelta map[string]int, tagIDs []string) (err error) {
+	for userID, delta := range userDelta {
+		if delta == 0 {
+			continue
+		}
+		for _, tagID := range tagIDs {
+			affected, err := session.Where(builder.Eq{"user_id": userID, "tag_id": tagID}).
+				Incr("`rank`", delta).Update(&entity.UserTagRank{})
+			if err != nil {
+				return err
+			}
+			if affected > 0 {
+				continue
+			}
+			if _, err = session.Insert(&entity.UserTagRank{UserID: userID, TagID: tagID, Rank: delta}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/api_key"
+	"github.com/apache/answer/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_apiKeyRepo_UserAPIKey(t *testing.T) {
+	apiKeyRepo := api_key.NewAPIKeyRepo(testDataSource)
+	ctx := context.TODO()
+
+	ak := token.GenerateAPIKey()
+	require.NoError(t, apiKeyRepo.AddAPIKey(ctx, entity.APIKey{
+		Description: "personal", AccessKey: token.HashAPIKey(ak), KeyHint: token.MaskAPIKey(ak),
+		Scope: "read-only", UserID: "94001", Personal: 1, RateLimit: 10,
+	}))
+	require.NoError(t, apiKeyRepo.AddAPIKey(ctx, entity.APIKey{
+		Description: "site", AccessKey: token.HashAPIKey(token.GenerateAPIKey()), Scope: "global", UserID: "94001",
+	}))
+
+	keys, err := apiKeyRepo.GetUserAPIKeyList(ctx, "94001")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, token.MaskAPIKey(ak), keys[0].KeyHint)
+	assert.Equal(t, 10, keys[0].RateLimit)
+
+	// the key is found by the hash, the plain key is not stored
+	key, exist, err := apiKeyRepo.GetAPIKey(ctx, token.HashAPIKey(ak))
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, keys[0].ID, key.ID)
+	_, exist, err = apiKeyRepo.GetAPIKey(ctx, ak)
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	siteKeys, err := apiKeyRepo.GetAPIKeyList(ctx)
+	require.NoError(t, err)
+	for _, k := range siteKeys {
+		assert.NotEqual(t, key.ID, k.ID)
+	}
+
+	// the key can only be revoked by the owner
+	affected, err := apiKeyRepo.DeleteUserAPIKey(ctx, key.ID, "94002")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), affected)
+	affected, err = apiKeyRepo.DeleteUserAPIKey(ctx, key.ID, "94001")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	require.NoError(t, apiKeyRepo.DeleteAPIKeysByUserID(ctx, "94001"))
+}
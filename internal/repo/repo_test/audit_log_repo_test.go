@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/audit_log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_auditLogRepo_GetAuditLogPage(t *testing.T) {
+	auditLogRepo := audit_log.NewAuditLogRepo(testDataSource)
+	ctx := context.TODO()
+
+	logs := []*entity.AuditLog{
+		{ActorID: "91001", Action: constant.AuditActionUserRoleChange, ObjectType: constant.UserObjectType, ObjectID: "92001",
+			Before: `{"role_id":1}`, After: `{"role_id":2}`},
+		{ActorID: "91001", Action: constant.AuditActionSettingChange, ObjectType: constant.SettingObjectType, ObjectID: "general"},
+		{ActorID: "91002", Action: constant.AuditActionUserStatusChange, ObjectType: constant.UserObjectType, ObjectID: "92001"},
+	}
+	for _, l := range logs {
+		require.NoError(t, auditLogRepo.AddAuditLog(ctx, l))
+	}
+
+	list, total, err := auditLogRepo.GetAuditLogPage(ctx, &entity.AuditLogQueryCond{Page: 1, PageSize: 10, ActorID: "91001"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, list, 2)
+	// the newest first
+	assert.Equal(t, constant.AuditActionSettingChange, list[0].Action)
+
+	list, total, err = auditLogRepo.GetAuditLogPage(ctx, &entity.AuditLogQueryCond{Page: 1, PageSize: 10,
+		ObjectType: constant.UserObjectType, ObjectID: "92001", Action: constant.AuditActionUserRoleChange})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assert.Equal(t, `{"role_id":2}`, list[0].After)
+
+	_, total, err = auditLogRepo.GetAuditLogPage(ctx, &entity.AuditLogQueryCond{Page: 1, PageSize: 10,
+		ActorID: "91002", StartTime: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
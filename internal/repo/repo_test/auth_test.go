@@ -108,3 +108,18 @@ func Test_authRepo_RemoveAdminUserCacheInfo(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, userInfo)
 }
+
+func Test_authRepo_UserSessionRevokedAt(t *testing.T) {
+	authRepo := auth.NewAuthRepo(testDataSource)
+
+	revokedAt, err := authRepo.GetUserSessionRevokedAt(context.TODO(), "revoked-user")
+	require.NoError(t, err)
+	assert.Zero(t, revokedAt)
+
+	err = authRepo.SetUserSessionRevokedAt(context.TODO(), "revoked-user", 1700000000)
+	require.NoError(t, err)
+
+	revokedAt, err = authRepo.GetUserSessionRevokedAt(context.TODO(), "revoked-user")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), revokedAt)
+}
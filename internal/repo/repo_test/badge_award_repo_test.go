@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/badge_award"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_badgeAwardRepo_AwardBadgeForUser(t *testing.T) {
+	badgeAwardRepo := badge_award.NewBadgeAwardRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+	ctx := context.TODO()
+
+	const userID = "90031"
+	singleBadge := &entity.Badge{ID: "10040000000000851", Name: "single_badge", Status: entity.BadgeStatusActive,
+		Single: entity.BadgeSingleAward}
+	multiBadge := &entity.Badge{ID: "10040000000000852", Name: "multi_badge", Status: entity.BadgeStatusActive,
+		Single: entity.BadgeMultiAward}
+	_, err := testDataSource.DB.Context(ctx).Insert(singleBadge, multiBadge)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = testDataSource.DB.Context(ctx).In("id", singleBadge.ID, multiBadge.ID).Delete(&entity.Badge{})
+		_, _ = testDataSource.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.BadgeAward{})
+	})
+
+	award := func(badge *entity.Badge, awardKey string) bool {
+		awarded, err := badgeAwardRepo.AwardBadgeForUser(ctx, &entity.BadgeAward{
+			UserID: userID, BadgeID: badge.ID, AwardKey: awardKey, IsBadgeDeleted: entity.IsBadgeNotDeleted})
+		require.NoError(t, err)
+		return awarded
+	}
+
+	// the one-time badge is awarded once whatever the award key is
+	assert.True(t, award(singleBadge, "10010000000000851"))
+	assert.False(t, award(singleBadge, "10010000000000852"))
+	// the repeatable badge is awarded once for each award key
+	assert.True(t, award(multiBadge, "10010000000000851"))
+	assert.True(t, award(multiBadge, "10010000000000852"))
+	assert.False(t, award(multiBadge, "10010000000000852"))
+
+	earnedCounts, err := badgeAwardRepo.SumUserEarnedGroupByBadgeID(ctx, userID)
+	require.NoError(t, err)
+	counts := make(map[string]int64)
+	for _, c := range earnedCounts {
+		counts[c.BadgeID] = c.EarnedCount
+	}
+	assert.Equal(t, map[string]int64{singleBadge.ID: 1, multiBadge.ID: 2}, counts)
+
+	badge := &entity.Badge{}
+	_, err = testDataSource.DB.Context(ctx).ID(multiBadge.ID).Get(badge)
+	require.NoError(t, err)
+	assert.Equal(t, 2, badge.AwardCount)
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/badge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_eventRuleRepo_SweepBadgeRules(t *testing.T) {
+	eventRuleRepo := badge.NewEventRuleRepo(testDataSource)
+	ctx := context.TODO()
+
+	const userID = "90021"
+	now := time.Now()
+	activeBadge := &entity.Badge{
+		ID: "10040000000000841", Name: "active_two_days", Status: entity.BadgeStatusActive,
+		Handler: entity.BadgeRuleHandler, Param: `{"metric":"active_days","amount":"2"}`,
+	}
+	unreachedBadge := &entity.Badge{
+		ID: "10040000000000842", Name: "active_three_days", Status: entity.BadgeStatusActive,
+		Handler: entity.BadgeRuleHandler, Param: `{"metric":"active_days","amount":"3"}`,
+	}
+	inactiveBadge := &entity.Badge{
+		ID: "10040000000000843", Name: "inactive_one_day", Status: entity.BadgeStatusInactive,
+		Handler: entity.BadgeRuleHandler, Param: `{"metric":"active_days","amount":"1"}`,
+	}
+	questions := []*entity.Question{
+		{ID: "10010000000000841", UserID: userID, Status: entity.QuestionStatusAvailable, CreatedAt: now},
+		{ID: "10010000000000842", UserID: userID, Status: entity.QuestionStatusAvailable,
+			CreatedAt: now.AddDate(0, 0, -3)},
+		// the deleted posts are not counted
+		{ID: "10010000000000843", UserID: userID, Status: entity.QuestionStatusDeleted,
+			CreatedAt: now.AddDate(0, 0, -5)},
+	}
+	answer := &entity.Answer{ID: "10020000000000841", QuestionID: questions[1].ID, UserID: userID,
+		Status: entity.AnswerStatusAvailable, CreatedAt: now.AddDate(0, 0, -3)}
+	activity := &entity.Activity{UserID: userID, ObjectID: questions[0].ID, ActivityType: 1}
+	_, err := testDataSource.DB.Context(ctx).Insert(activeBadge, unreachedBadge, inactiveBadge, questions, answer, activity)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = testDataSource.DB.Context(ctx).In("id", activeBadge.ID, unreachedBadge.ID, inactiveBadge.ID).
+			Delete(&entity.Badge{})
+		_, _ = testDataSource.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.Question{})
+		_, _ = testDataSource.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.Answer{})
+		_, _ = testDataSource.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.Activity{})
+	})
+
+	awards, err := eventRuleRepo.SweepBadgeRules(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	badgeIDs := make([]string, 0)
+	for _, award := range awards {
+		if award.UserID == userID {
+			badgeIDs = append(badgeIDs, award.BadgeID)
+			assert.Equal(t, entity.BadgeEmptyAwardKey, award.AwardKey)
+		}
+	}
+	assert.Equal(t, []string{activeBadge.ID}, badgeIDs)
+
+	// the users without the activities since the time are not swept
+	awards, err = eventRuleRepo.SweepBadgeRules(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	for _, award := range awards {
+		assert.NotEqual(t, userID, award.UserID)
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/activity"
+	"github.com/apache/answer/internal/repo/activity_common"
+	"github.com/apache/answer/internal/repo/bounty"
+	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/rank"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/service/activity_type"
+	bountyservice "github.com/apache/answer/internal/service/bounty"
+	config2 "github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/noticequeue"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_bountyRepo_AddAndSettleBounty(t *testing.T) {
+	var (
+		userRepo      = user.NewUserRepo(testDataSource)
+		configService = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		bountyRepo    = bounty.NewBountyRepo(testDataSource, rank.NewUserRankRepo(testDataSource, configService))
+	)
+	owner := &entity.User{
+		Username:    "bounty_owner",
+		Pass:        "bounty_owner",
+		EMail:       "bounty_owner@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "bounty_owner",
+		Rank:        100,
+	}
+	require.NoError(t, userRepo.AddUser(context.TODO(), owner))
+	receiver := &entity.User{
+		Username:    "bounty_receiver",
+		Pass:        "bounty_receiver",
+		EMail:       "bounty_receiver@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "bounty_receiver",
+		Rank:        1,
+	}
+	require.NoError(t, userRepo.AddUser(context.TODO(), receiver))
+
+	const questionID = "10010000000000901"
+	newBounty := func(amount int) (*entity.QuestionBounty, *entity.Activity) {
+		return &entity.QuestionBounty{
+			QuestionID: questionID,
+			UserID:     owner.ID,
+			Amount:     amount,
+			Status:     entity.BountyStatusActive,
+			ExpiresAt:  time.Now().Add(-time.Hour),
+		}, &entity.Activity{
+			UserID:   owner.ID,
+			ObjectID: questionID,
+			Rank:     -amount,
+			HasRank:  1,
+		}
+	}
+
+	// the reputation of owner would be lower than 1
+	b, act := newBounty(100)
+	enough, err := bountyRepo.AddBounty(context.TODO(), b, act)
+	require.NoError(t, err)
+	assert.False(t, enough)
+
+	b, act = newBounty(50)
+	enough, err = bountyRepo.AddBounty(context.TODO(), b, act)
+	require.NoError(t, err)
+	assert.True(t, enough)
+	got, exist, err := userRepo.GetByUserID(context.TODO(), owner.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, 50, got.Rank)
+
+	active, exist, err := bountyRepo.GetActiveBounty(context.TODO(), questionID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, 50, active.Amount)
+
+	expired, err := bountyRepo.GetExpiredBounties(context.TODO(), time.Now(), 10)
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+
+	active.Status = entity.BountyStatusAwarded
+	active.AwardedUserID = receiver.ID
+	active.AwardedAmount = 50
+	active.SettledAt = time.Now()
+	award := &entity.Activity{UserID: receiver.ID, ObjectID: "10020000000000901", Rank: 50, HasRank: 1}
+	settled, err := bountyRepo.SettleBounty(context.TODO(), active, award)
+	require.NoError(t, err)
+	assert.True(t, settled)
+
+	// the bounty can only be settled once
+	award = &entity.Activity{UserID: receiver.ID, ObjectID: "10020000000000901", Rank: 50, HasRank: 1}
+	settled, err = bountyRepo.SettleBounty(context.TODO(), active, award)
+	require.NoError(t, err)
+	assert.False(t, settled)
+
+	got, _, err = userRepo.GetByUserID(context.TODO(), receiver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 51, got.Rank)
+
+	_, exist, err = bountyRepo.GetActiveBounty(context.TODO(), questionID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	bounties, err := bountyRepo.GetBountiesByQuestionID(context.TODO(), questionID)
+	require.NoError(t, err)
+	require.Len(t, bounties, 1)
+	assert.Equal(t, entity.BountyStatusAwarded, bounties[0].Status)
+}
+
+func Test_bountyService_SettleBountyOfDeletedQuestion(t *testing.T) {
+	var (
+		uniqueIDRepo       = unique.NewUniqueIDRepo(testDataSource)
+		userRepo           = user.NewUserRepo(testDataSource)
+		questionRepo       = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		configService      = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		userRankRepo       = rank.NewUserRankRepo(testDataSource, configService)
+		activityCommonRepo = activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, configService)
+		answerActivityRepo = activity.NewAnswerActivityRepo(testDataSource, activityCommonRepo, userRankRepo, noticequeue.NewService())
+		bountyRepo         = bounty.NewBountyRepo(testDataSource, userRankRepo)
+		bountyService      = bountyservice.NewBountyService(bountyRepo, questionRepo, nil, nil, configService, noticequeue.NewService())
+	)
+	ctx := context.TODO()
+
+	owner := &entity.User{Username: "deleted_bounty_owner", Pass: "deleted_bounty_owner",
+		EMail: "deleted_bounty_owner@example.com", MailStatus: entity.EmailStatusAvailable,
+		Status: entity.UserStatusAvailable, DisplayName: "deleted_bounty_owner", Rank: 100}
+	require.NoError(t, userRepo.AddUser(ctx, owner))
+	q := &entity.Question{UserID: owner.ID, Title: "deleted bounty question", OriginalText: "deleted bounty",
+		ParsedText: "deleted bounty", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	t.Cleanup(func() {
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+	})
+	userRank := func() int {
+		u, exist, err := userRepo.GetByUserID(ctx, owner.ID)
+		require.NoError(t, err)
+		require.True(t, exist)
+		return u.Rank
+	}
+
+	activityType, err := configService.GetIDByKey(ctx, activity_type.QuestionBountyOffered)
+	require.NoError(t, err)
+	enough, err := bountyRepo.AddBounty(ctx, &entity.QuestionBounty{
+		QuestionID: q.ID,
+		UserID:     owner.ID,
+		Amount:     40,
+		Status:     entity.BountyStatusActive,
+		ExpiresAt:  time.Now().Add(-constant.BountyGracePeriod - time.Hour),
+	}, &entity.Activity{UserID: owner.ID, ObjectID: q.ID, ActivityType: activityType, Rank: -40, HasRank: 1})
+	require.NoError(t, err)
+	require.True(t, enough)
+	assert.Equal(t, 60, userRank())
+
+	// the escrow is not paid back by the deletion of the question
+	require.NoError(t, questionRepo.UpdateQuestionStatus(ctx, q.ID, entity.QuestionStatusDeleted))
+	require.NoError(t, answerActivityRepo.CancelObjectRankActivities(ctx, q.ID))
+	assert.Equal(t, 60, userRank())
+
+	// nothing is refunded or awarded when the bounty of the deleted question expires
+	bountyService.SettleExpiredBounties(ctx)
+	assert.Equal(t, 60, userRank())
+	_, exist, err := bountyRepo.GetActiveBounty(ctx, q.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	bounties, err := bountyRepo.GetBountiesByQuestionID(ctx, q.ID)
+	require.NoError(t, err)
+	require.Len(t, bounties, 1)
+	assert.Equal(t, entity.BountyStatusCancelled, bounties[0].Status)
+
+	// the escrow is not taken again when the question is recovered
+	require.NoError(t, questionRepo.RecoverQuestion(ctx, q.ID))
+	require.NoError(t, answerActivityRepo.RecoverObjectRankActivities(ctx, q.ID))
+	assert.Equal(t, 60, userRank())
+}
+
+func Test_bountyRepo_AddBountyConcurrently(t *testing.T) {
+	var (
+		uniqueIDRepo  = unique.NewUniqueIDRepo(testDataSource)
+		userRepo      = user.NewUserRepo(testDataSource)
+		questionRepo  = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		configService = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		bountyRepo    = bounty.NewBountyRepo(testDataSource, rank.NewUserRankRepo(testDataSource, configService))
+	)
+	ctx := context.TODO()
+
+	q := &entity.Question{UserID: "1", Title: "concurrent bounty question", OriginalText: "concurrent bounty",
+		ParsedText: "concurrent bounty", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	t.Cleanup(func() {
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+	})
+
+	const offers = 5
+	users := make([]*entity.User, 0, offers)
+	for i := 0; i < offers; i++ {
+		name := fmt.Sprintf("concurrent_bounty_%d", i)
+		u := &entity.User{Username: name, Pass: name, EMail: name + "@example.com", MailStatus: entity.EmailStatusAvailable,
+			Status: entity.UserStatusAvailable, DisplayName: name, Rank: 100}
+		require.NoError(t, userRepo.AddUser(ctx, u))
+		users = append(users, u)
+	}
+
+	var saved, rejected atomic.Int32
+	var wg sync.WaitGroup
+	for _, u := range users {
+		wg.Add(1)
+		go func(u *entity.User) {
+			defer wg.Done()
+			enough, err := bountyRepo.AddBounty(ctx, &entity.QuestionBounty{
+				QuestionID: q.ID,
+				UserID:     u.ID,
+				Amount:     50,
+				Status:     entity.BountyStatusActive,
+				ExpiresAt:  time.Now().Add(time.Hour),
+			}, &entity.Activity{UserID: u.ID, ObjectID: q.ID, Rank: -50, HasRank: 1})
+			if err != nil {
+				var myErr *errors.Error
+				if assert.ErrorAs(t, err, &myErr) {
+					assert.Equal(t, reason.BountyAlreadyExists, myErr.Reason)
+				}
+				rejected.Add(1)
+				return
+			}
+			assert.True(t, enough)
+			saved.Add(1)
+		}(u)
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, saved.Load())
+	assert.EqualValues(t, offers-1, rejected.Load())
+
+	bounties, err := bountyRepo.GetBountiesByQuestionID(ctx, q.ID)
+	require.NoError(t, err)
+	assert.Len(t, bounties, 1)
+	paid := 0
+	for _, u := range users {
+		got, _, err := userRepo.GetByUserID(ctx, u.ID)
+		require.NoError(t, err)
+		paid += 100 - got.Rank
+	}
+	assert.Equal(t, 50, paid)
+
+	// the active bounty is cancelled so that it is not settled by the other tests
+	bounties[0].Status = entity.BountyStatusCancelled
+	_, err = bountyRepo.SettleBounty(ctx, bounties[0], nil)
+	require.NoError(t, err)
+}
@@ -22,6 +22,7 @@ package repo_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/entity"
@@ -112,3 +113,58 @@ func Test_commentRepo_CannotGetDeletedComment(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, exist)
 }
+
+func Test_commentRepo_GetCommentReplies(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
+	ctx := context.TODO()
+
+	parent := buildCommentEntity()
+	parent.ObjectID = "2"
+	require.NoError(t, commentRepo.AddComment(ctx, parent))
+	replies := make([]*entity.Comment, 0)
+	for i := range 2 {
+		reply := buildCommentEntity()
+		reply.ObjectID = "2"
+		reply.CreatedAt = time.Now().Add(time.Duration(i) * time.Minute)
+		reply.SetReplyCommentID(parent.ID)
+		reply.SetParentID(parent.ID)
+		require.NoError(t, commentRepo.AddComment(ctx, reply))
+		replies = append(replies, reply)
+	}
+	t.Cleanup(func() {
+		for _, c := range replies {
+			require.NoError(t, commentRepo.RemoveComment(ctx, c.ID))
+		}
+	})
+
+	query := &commentService.CommentQuery{
+		PageCond: pager.PageCond{Page: 1, PageSize: 10},
+		ObjectID: "2",
+		TopLevel: true,
+	}
+	list, total, err := commentRepo.GetCommentPage(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, parent.ID, list[0].ID)
+
+	got, err := commentRepo.GetCommentReplies(ctx, []string{parent.ID})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, replies[0].ID, got[0].ID)
+	assert.Equal(t, replies[1].ID, got[1].ID)
+
+	// the deleted top-level comment is kept while it still has replies
+	require.NoError(t, commentRepo.RemoveComment(ctx, parent.ID))
+	list, total, err = commentRepo.GetCommentPage(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, entity.CommentStatusDeleted, list[0].Status)
+
+	for _, c := range replies {
+		require.NoError(t, commentRepo.RemoveComment(ctx, c.ID))
+	}
+	_, total, err = commentRepo.GetCommentPage(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
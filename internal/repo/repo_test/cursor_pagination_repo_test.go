@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/answer"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_GetQuestionPageByCursor(t *testing.T) {
+	var (
+		uniqueIDRepo  = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo  = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		tagRelRepo    = tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+		tagRepo       = tag.NewTagRepo(testDataSource, uniqueIDRepo)
+		tagCommonRepo = tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	questions := make([]*entity.Question, 0)
+	for i := range 5 {
+		// the questions created at the same time are ordered by the id
+		q := &entity.Question{UserID: "1", Title: "cursor", OriginalText: "cursor", ParsedText: "cursor",
+			Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionUnPin,
+			CreatedAt: createdAt.Add(time.Duration(i/2) * time.Minute), VoteCount: i % 2}
+		require.NoError(t, questionRepo.AddQuestion(ctx, q))
+		questions = append(questions, q)
+	}
+	tags := []*entity.Tag{{SlugName: "cursor-tag", DisplayName: "cursor-tag", Status: entity.TagStatusAvailable}}
+	require.NoError(t, tagCommonRepo.AddTagList(ctx, tags))
+	tagRels := make([]*entity.TagRel, 0, len(questions))
+	for _, q := range questions {
+		tagRels = append(tagRels, &entity.TagRel{TagID: tags[0].ID, ObjectID: q.ID, Status: entity.TagRelStatusAvailable})
+	}
+	require.NoError(t, tagRelRepo.AddTagRelList(ctx, tagRels))
+	require.NoError(t, tagRelRepo.UpdateTagRelPin(ctx, questions[1].ID, tags[0].ID, entity.QuestionPin,
+		time.Now().Add(time.Hour)))
+	t.Cleanup(func() {
+		ids := make([]int64, 0, len(tagRels))
+		for _, rel := range tagRels {
+			ids = append(ids, rel.ID)
+		}
+		require.NoError(t, tagRelRepo.RemoveTagRelListByIDs(ctx, ids))
+		require.NoError(t, tagRepo.RemoveTag(ctx, tags[0].ID))
+		for _, q := range questions {
+			require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+		}
+	})
+
+	for _, order := range []string{"newest", "score", "frequent"} {
+		cond := &entity.QuestionPageCond{TagIDs: []string{tags[0].ID}, OrderCond: order}
+		ids := make([]string, 0)
+		cursor, pages := "", 0
+		for pages < len(questions) {
+			list, next, err := questionRepo.GetQuestionPageByCursor(ctx, cond, cursor, 2)
+			require.NoError(t, err)
+			for _, q := range list {
+				ids = append(ids, q.ID)
+			}
+			pages++
+			if len(next) == 0 {
+				break
+			}
+			cursor = next
+		}
+		assert.Equal(t, 3, pages, order)
+		require.Len(t, ids, len(questions), order)
+		// the question pinned within the tag comes first
+		assert.Equal(t, questions[1].ID, ids[0], order)
+		if order == "newest" {
+			assert.Equal(t, []string{questions[1].ID, questions[4].ID, questions[3].ID, questions[2].ID,
+				questions[0].ID}, ids)
+		}
+
+		// the cursor of the other order is rejected
+		_, _, err := questionRepo.GetQuestionPageByCursor(ctx, &entity.QuestionPageCond{
+			TagIDs: []string{tags[0].ID}, OrderCond: "hot"}, cursor, 2)
+		require.Error(t, err)
+		e, ok := err.(*errors.Error)
+		require.True(t, ok)
+		assert.Equal(t, reason.PageCursorInvalid, e.Reason)
+	}
+}
+
+func Test_answerRepo_SearchListByCursor(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		answerRepo   = answer.NewAnswerRepo(testDataSource, uniqueIDRepo, nil, nil)
+	)
+	ctx := context.TODO()
+
+	q := &entity.Question{UserID: "1", Title: "answer cursor", OriginalText: "answer cursor",
+		ParsedText: "answer cursor", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	answers := make([]*entity.Answer, 0)
+	for i := range 3 {
+		a := &entity.Answer{QuestionID: q.ID, UserID: "1", OriginalText: "answer", ParsedText: "answer",
+			Status: entity.AnswerStatusAvailable, Accepted: 1, VoteCount: i % 2}
+		require.NoError(t, answerRepo.AddAnswer(ctx, a))
+		answers = append(answers, a)
+	}
+	t.Cleanup(func() {
+		for _, a := range answers {
+			require.NoError(t, answerRepo.RemoveAnswer(ctx, a.ID))
+		}
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+	})
+
+	ids := make([]string, 0)
+	cursor := ""
+	for range answers {
+		search := &entity.AnswerSearch{}
+		search.QuestionID = q.ID
+		search.PageSize = 2
+		list, next, err := answerRepo.SearchListByCursor(ctx, search, cursor)
+		require.NoError(t, err)
+		for _, a := range list {
+			ids = append(ids, a.ID)
+		}
+		if len(next) == 0 {
+			break
+		}
+		cursor = next
+	}
+	// the voted answer comes first, then the oldest ones
+	assert.Equal(t, []string{answers[1].ID, answers[0].ID, answers[2].ID}, ids)
+}
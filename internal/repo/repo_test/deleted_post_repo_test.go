@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/activity"
+	"github.com/apache/answer/internal/repo/activity_common"
+	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/rank"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/repo/user"
+	config2 "github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/noticequeue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_SoftDeleteAndPurge(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+
+	recent := &entity.Question{UserID: "1", Title: "recently deleted question", OriginalText: "recent", ParsedText: "recent",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	expired := &entity.Question{UserID: "1", Title: "long ago deleted question", OriginalText: "expired", ParsedText: "expired",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	require.NoError(t, questionRepo.AddQuestion(ctx, recent))
+	require.NoError(t, questionRepo.AddQuestion(ctx, expired))
+
+	require.NoError(t, questionRepo.UpdateQuestionStatus(ctx, recent.ID, entity.QuestionStatusDeleted))
+	require.NoError(t, questionRepo.UpdateQuestionStatus(ctx, expired.ID, entity.QuestionStatusDeleted))
+	_, err := testDataSource.DB.Context(ctx).ID(expired.ID).Cols("deleted_at").
+		Update(&entity.Question{DeletedAt: time.Now().Add(-40 * 24 * time.Hour)})
+	require.NoError(t, err)
+
+	got, exist, err := questionRepo.GetQuestion(ctx, recent.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.False(t, got.DeletedAt.IsZero())
+
+	window := time.Now().Add(-30 * 24 * time.Hour)
+	questions, _, err := questionRepo.GetDeletedQuestionPage(ctx, 1, 100, window)
+	require.NoError(t, err)
+	ids := make([]string, 0)
+	for _, q := range questions {
+		ids = append(ids, q.ID)
+	}
+	assert.Contains(t, ids, recent.ID)
+	assert.NotContains(t, ids, expired.ID)
+
+	count, err := questionRepo.PurgeDeletedQuestions(ctx, window)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, exist, err = questionRepo.GetQuestion(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	require.NoError(t, questionRepo.RecoverQuestion(ctx, recent.ID))
+	got, exist, err = questionRepo.GetQuestion(ctx, recent.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.QuestionStatusAvailable, got.Status)
+	assert.True(t, got.DeletedAt.IsZero())
+
+	t.Cleanup(func() {
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, recent.ID))
+	})
+}
+
+func Test_answerActivityRepo_CancelAndRecoverObjectRankActivities(t *testing.T) {
+	var (
+		uniqueIDRepo       = unique.NewUniqueIDRepo(testDataSource)
+		userRepo           = user.NewUserRepo(testDataSource)
+		configService      = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		activityCommonRepo = activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, configService)
+		userRankRepo       = rank.NewUserRankRepo(testDataSource, configService)
+		answerActivityRepo = activity.NewAnswerActivityRepo(testDataSource, activityCommonRepo, userRankRepo, noticequeue.NewService())
+	)
+	ctx := context.TODO()
+
+	author := &entity.User{Username: "deletedpostauthor", Pass: "deletedpostauthor", EMail: "deletedpostauthor@example.com",
+		MailStatus: entity.EmailStatusAvailable, Status: entity.UserStatusAvailable, DisplayName: "deletedpostauthor", Rank: 100}
+	require.NoError(t, userRepo.AddUser(ctx, author))
+
+	const objectID = "10020000000000901"
+	activities := []*entity.Activity{
+		{UserID: author.ID, ObjectID: objectID, ActivityType: 1, Rank: 10, HasRank: 1},
+		{UserID: author.ID, ObjectID: objectID, ActivityType: 2, Rank: 15, HasRank: 1},
+		// cancelled by the user, not changed by deletion
+		{UserID: author.ID, ObjectID: objectID, ActivityType: 3, Rank: 10, HasRank: 1,
+			Cancelled: entity.ActivityCancelled, CancelledAt: time.Now()},
+	}
+	for _, act := range activities {
+		_, err := testDataSource.DB.Context(ctx).Insert(act)
+		require.NoError(t, err)
+	}
+
+	userRank := func() int {
+		u, exist, err := userRepo.GetByUserID(ctx, author.ID)
+		require.NoError(t, err)
+		require.True(t, exist)
+		return u.Rank
+	}
+
+	require.NoError(t, answerActivityRepo.CancelObjectRankActivities(ctx, objectID))
+	assert.Equal(t, 75, userRank())
+	// cancel again changes nothing
+	require.NoError(t, answerActivityRepo.CancelObjectRankActivities(ctx, objectID))
+	assert.Equal(t, 75, userRank())
+
+	require.NoError(t, answerActivityRepo.RecoverObjectRankActivities(ctx, objectID))
+	assert.Equal(t, 100, userRank())
+
+	act := &entity.Activity{}
+	_, err := testDataSource.DB.Context(ctx).ID(activities[2].ID).Get(act)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ActivityCancelled, act.Cancelled)
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/draft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_draftRepo_Draft(t *testing.T) {
+	ctx := context.TODO()
+	draftRepo := draft.NewDraftRepo(testDataSource)
+
+	info := &entity.Draft{Title: "draft title", Content: "draft content", Tags: []string{"go"}, UpdatedAt: time.Now().Unix()}
+	require.NoError(t, draftRepo.SetDraft(ctx, "1", "answer:10010000000000001", info, time.Minute))
+
+	got, exist, err := draftRepo.GetDraft(ctx, "1", "answer:10010000000000001")
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, info, got)
+
+	// the drafts are private to their owners
+	_, exist, err = draftRepo.GetDraft(ctx, "2", "answer:10010000000000001")
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	require.NoError(t, draftRepo.RemoveDraft(ctx, "1", "answer:10010000000000001"))
+	_, exist, err = draftRepo.GetDraft(ctx, "1", "answer:10010000000000001")
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
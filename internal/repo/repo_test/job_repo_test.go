@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/job"
+	"github.com/apache/answer/internal/service/jobqueue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_jobRepo_AcquireJobs(t *testing.T) {
+	jobRepo := job.NewJobRepo(testDataSource)
+	ctx := context.TODO()
+
+	due, err := jobqueue.NewJob("test.acquire", map[string]string{"id": "1"})
+	require.NoError(t, err)
+	require.NoError(t, jobRepo.AddJob(ctx, due))
+	later, err := jobqueue.NewJob("test.acquire", map[string]string{"id": "2"})
+	require.NoError(t, err)
+	later.RunAt = time.Now().Add(time.Hour)
+	require.NoError(t, jobRepo.AddJob(ctx, later))
+	other, err := jobqueue.NewJob("test.other", map[string]string{"id": "3"})
+	require.NoError(t, err)
+	require.NoError(t, jobRepo.AddJob(ctx, other))
+
+	jobs, err := jobRepo.AcquireJobs(ctx, []string{"test.acquire"}, 10, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, due.ID, jobs[0].ID)
+	assert.Equal(t, 1, jobs[0].Attempts)
+	assert.Equal(t, entity.JobStatusRunning, jobs[0].Status)
+
+	// the locked job is not acquired again until the lock is expired
+	jobs, err = jobRepo.AcquireJobs(ctx, []string{"test.acquire"}, 10, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, jobs, 0)
+
+	require.NoError(t, jobRepo.RetryJob(ctx, due.ID, time.Now().Add(-time.Second), "failed"))
+	jobs, err = jobRepo.AcquireJobs(ctx, []string{"test.acquire"}, 10, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 2, jobs[0].Attempts)
+
+	// the job whose worker is gone is acquired after the lock is expired
+	jobs, err = jobRepo.AcquireJobs(ctx, []string{"test.acquire"}, 10, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 3, jobs[0].Attempts)
+
+	require.NoError(t, jobRepo.DeleteJob(ctx, due.ID))
+	require.NoError(t, jobRepo.DeleteJob(ctx, later.ID))
+	require.NoError(t, jobRepo.DeleteJob(ctx, other.ID))
+}
+
+func Test_jobRepo_MoveToDeadLetter(t *testing.T) {
+	jobRepo := job.NewJobRepo(testDataSource)
+	ctx := context.TODO()
+
+	failed, err := jobqueue.NewJob("test.dead", map[string]string{"id": "1"})
+	require.NoError(t, err)
+	require.NoError(t, jobRepo.AddJob(ctx, failed))
+	pending, err := jobqueue.NewJob("test.dead", map[string]string{"id": "2"})
+	require.NoError(t, err)
+	require.NoError(t, jobRepo.AddJob(ctx, pending))
+
+	jobs, err := jobRepo.AcquireJobs(ctx, []string{"test.dead"}, 1, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.NoError(t, jobRepo.MoveToDeadLetter(ctx, jobs[0], "failed"))
+
+	stats, err := jobRepo.GetJobStats(ctx)
+	require.NoError(t, err)
+	var amount int64
+	for _, stat := range stats {
+		if stat.Type == "test.dead" {
+			amount += stat.Amount
+		}
+	}
+	assert.Equal(t, int64(1), amount)
+
+	stats, err = jobRepo.GetDeadLetterStats(ctx)
+	require.NoError(t, err)
+	amount = 0
+	for _, stat := range stats {
+		if stat.Type == "test.dead" {
+			amount += stat.Amount
+		}
+	}
+	assert.Equal(t, int64(1), amount)
+
+	deadLetters, total, err := jobRepo.GetDeadLetterPage(ctx, 1, 10, "test.dead")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, failed.ID, deadLetters[0].JobID)
+	assert.Equal(t, 1, deadLetters[0].Attempts)
+	assert.Equal(t, "failed", deadLetters[0].Error)
+
+	require.NoError(t, jobRepo.DeleteJob(ctx, pending.ID))
+}
@@ -104,3 +104,24 @@ func Test_metaRepo_UpdateMeta(t *testing.T) {
 	err = metaRepo.RemoveMeta(context.TODO(), metaEnt.ID)
 	require.NoError(t, err)
 }
+
+func Test_metaRepo_GetMetaListByObjectIDsAndKey(t *testing.T) {
+	metaRepo := meta.NewMetaRepo(testDataSource)
+	metaEnt := buildMetaEntity()
+	otherKeyEnt := &entity.Meta{ObjectID: "2", Key: "2", Value: "2"}
+
+	require.NoError(t, metaRepo.AddMeta(context.TODO(), metaEnt))
+	require.NoError(t, metaRepo.AddMeta(context.TODO(), otherKeyEnt))
+
+	gotMetaList, err := metaRepo.GetMetaListByObjectIDsAndKey(context.TODO(), []string{"1", "2"}, metaEnt.Key)
+	require.NoError(t, err)
+	assert.Len(t, gotMetaList, 1)
+	assert.Equal(t, metaEnt.ID, gotMetaList[0].ID)
+
+	gotMetaList, err = metaRepo.GetMetaListByObjectIDsAndKey(context.TODO(), nil, metaEnt.Key)
+	require.NoError(t, err)
+	assert.Empty(t, gotMetaList)
+
+	require.NoError(t, metaRepo.RemoveMeta(context.TODO(), metaEnt.ID))
+	require.NoError(t, metaRepo.RemoveMeta(context.TODO(), otherKeyEnt.ID))
+}
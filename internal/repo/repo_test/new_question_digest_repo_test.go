@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newQuestionDigestRepo_NewQuestionDigests(t *testing.T) {
+	digestRepo := notification.NewNewQuestionDigestRepo(testDataSource)
+	const userID = "10010000000000911"
+	questions := []*entity.Question{
+		{ID: "10010000000000912", Title: "visible", Show: entity.QuestionShow, Status: entity.QuestionStatusAvailable},
+		{ID: "10010000000000913", Title: "hidden", Show: entity.QuestionHide, Status: entity.QuestionStatusAvailable},
+	}
+	for _, question := range questions {
+		_, err := testDataSource.DB.Context(context.TODO()).Insert(question)
+		require.NoError(t, err)
+	}
+
+	for _, question := range questions {
+		err := digestRepo.AddNewQuestionDigests(context.TODO(), []*entity.NewQuestionDigest{
+			{UserID: userID, QuestionID: question.ID, QuestionTitle: question.Title},
+		})
+		require.NoError(t, err)
+	}
+	// the question which is already waiting is ignored
+	err := digestRepo.AddNewQuestionDigests(context.TODO(), []*entity.NewQuestionDigest{
+		{UserID: userID, QuestionID: questions[0].ID, QuestionTitle: questions[0].Title},
+	})
+	require.NoError(t, err)
+
+	userIDs, err := digestRepo.GetNewQuestionDigestUserIDs(context.TODO(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Contains(t, userIDs, userID)
+
+	digests, err := digestRepo.GetNewQuestionDigests(context.TODO(), userID, 10)
+	require.NoError(t, err)
+	require.Len(t, digests, 1)
+	assert.Equal(t, questions[0].ID, digests[0].QuestionID)
+
+	err = digestRepo.RemoveNewQuestionDigests(context.TODO(), userID, "")
+	require.NoError(t, err)
+	userIDs, err = digestRepo.GetNewQuestionDigestUserIDs(context.TODO(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.NotContains(t, userIDs, userID)
+}
+
+func Test_newQuestionDigestRepo_ClaimNewQuestionDigestsConcurrently(t *testing.T) {
+	digestRepo := notification.NewNewQuestionDigestRepo(testDataSource)
+	const userID = "10010000000000921"
+	question := &entity.Question{ID: "10010000000000922", Title: "claimed", Show: entity.QuestionShow,
+		Status: entity.QuestionStatusAvailable}
+	_, err := testDataSource.DB.Context(context.TODO()).Insert(question)
+	require.NoError(t, err)
+	err = digestRepo.AddNewQuestionDigests(context.TODO(), []*entity.NewQuestionDigest{
+		{UserID: userID, QuestionID: question.ID, QuestionTitle: question.Title},
+	})
+	require.NoError(t, err)
+	digests, err := digestRepo.GetNewQuestionDigests(context.TODO(), userID, 10)
+	require.NoError(t, err)
+	require.Len(t, digests, 1)
+
+	// the instances sending the digest at the same time, only one of them claims the question
+	var claimedCount atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := digestRepo.ClaimNewQuestionDigests(context.TODO(), digests)
+			assert.NoError(t, err)
+			claimedCount.Add(int32(len(claimed)))
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, claimedCount.Load())
+
+	digests, err = digestRepo.GetNewQuestionDigests(context.TODO(), userID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, digests)
+}
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/notification"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_notificationDigestRepo_NotificationDigests(t *testing.T) {
+	digestRepo := notification.NewNotificationDigestRepo(testDataSource)
+	const userID = "10010000000000921"
+	readInApp := &entity.Notification{UserID: userID, ObjectID: "10010000000000922", Content: "{}",
+		IsRead: schema.NotificationRead, Status: schema.NotificationStatusNormal}
+	_, err := testDataSource.DB.Context(context.TODO()).Insert(readInApp)
+	require.NoError(t, err)
+	defer func() {
+		_, _ = testDataSource.DB.Context(context.TODO()).ID(readInApp.ID).Delete(&entity.Notification{})
+	}()
+
+	for _, objectID := range []string{"10010000000000922", "10010000000000923", "10010000000000924"} {
+		err = digestRepo.AddNotificationDigest(context.TODO(), &entity.NotificationDigest{
+			UserID: userID, Event: "comment_on_my_post", ObjectID: objectID, Content: "{}"})
+		require.NoError(t, err)
+	}
+
+	userIDs, err := digestRepo.GetNotificationDigestUserIDs(context.TODO(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Contains(t, userIDs, userID)
+
+	oldest, exist, err := digestRepo.GetOldestNotificationDigest(context.TODO(), userID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, "10010000000000922", oldest.ObjectID)
+
+	// the notification read in-app is skipped
+	digests, err := digestRepo.GetNotificationDigests(context.TODO(), userID, 1)
+	require.NoError(t, err)
+	require.Len(t, digests, 1)
+	assert.Equal(t, "10010000000000923", digests[0].ObjectID)
+
+	err = digestRepo.RemoveNotificationDigests(context.TODO(), userID, digests[0].ID)
+	require.NoError(t, err)
+	digests, err = digestRepo.GetNotificationDigests(context.TODO(), userID, 10)
+	require.NoError(t, err)
+	require.Len(t, digests, 1)
+	assert.Equal(t, "10010000000000924", digests[0].ObjectID)
+
+	err = digestRepo.RemoveNotificationDigests(context.TODO(), userID, "")
+	require.NoError(t, err)
+	_, exist, err = digestRepo.GetOldestNotificationDigest(context.TODO(), userID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_notificationDigestRepo_ClaimNotificationDigestsConcurrently(t *testing.T) {
+	digestRepo := notification.NewNotificationDigestRepo(testDataSource)
+	const userID = "10010000000000931"
+	for _, objectID := range []string{"10010000000000932", "10010000000000933"} {
+		err := digestRepo.AddNotificationDigest(context.TODO(), &entity.NotificationDigest{
+			UserID: userID, Event: "comment_on_my_post", ObjectID: objectID, Content: "{}"})
+		require.NoError(t, err)
+	}
+	digests, err := digestRepo.GetNotificationDigests(context.TODO(), userID, 10)
+	require.NoError(t, err)
+	require.Len(t, digests, 2)
+
+	// the instances sending the digest at the same time, each notification is claimed by only one of them
+	var claimedCount atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := digestRepo.ClaimNotificationDigests(context.TODO(), digests)
+			assert.NoError(t, err)
+			claimedCount.Add(int32(len(claimed)))
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 2, claimedCount.Load())
+
+	_, exist, err := digestRepo.GetOldestNotificationDigest(context.TODO(), userID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
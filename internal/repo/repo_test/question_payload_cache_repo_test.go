@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/answer"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_QuestionPayloadCache(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		answerRepo   = answer.NewAnswerRepo(testDataSource, uniqueIDRepo, nil, nil)
+	)
+	ctx := context.TODO()
+
+	q := &entity.Question{UserID: "1", Title: "payload cache", OriginalText: "payload cache",
+		ParsedText: "payload cache", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	a := &entity.Answer{QuestionID: q.ID, UserID: "1", OriginalText: "payload answer",
+		ParsedText: "payload answer", Status: entity.AnswerStatusAvailable}
+	t.Cleanup(func() {
+		require.NoError(t, answerRepo.RemoveAnswer(ctx, a.ID))
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+	})
+
+	cached := func(version string) bool {
+		payload := map[string]string{}
+		exist, err := questionRepo.GetQuestionPayloadCache(ctx, q.ID, version, "test", &payload)
+		require.NoError(t, err)
+		if exist {
+			assert.Equal(t, "payload cache", payload["title"])
+		}
+		return exist
+	}
+	cache := func() (version string) {
+		version, err := questionRepo.GetQuestionPayloadVersion(ctx, q.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, version)
+		require.NoError(t, questionRepo.SetQuestionPayloadCache(ctx, q.ID, version, "test",
+			map[string]string{"title": q.Title}))
+		return version
+	}
+
+	version := cache()
+	assert.True(t, cached(version))
+	same, err := questionRepo.GetQuestionPayloadVersion(ctx, q.ID)
+	require.NoError(t, err)
+	assert.Equal(t, version, same)
+
+	// the question is edited
+	require.NoError(t, questionRepo.UpdateQuestion(ctx, q, []string{"title"}))
+	newVersion, err := questionRepo.GetQuestionPayloadVersion(ctx, q.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, version, newVersion)
+	assert.False(t, cached(newVersion))
+
+	// the question is answered
+	version = cache()
+	require.NoError(t, answerRepo.AddAnswer(ctx, a))
+	version, err = questionRepo.GetQuestionPayloadVersion(ctx, q.ID)
+	require.NoError(t, err)
+	assert.False(t, cached(version))
+
+	// the answer is edited
+	version = cache()
+	require.NoError(t, answerRepo.UpdateAnswer(ctx, a, []string{"original_text"}))
+	version, err = questionRepo.GetQuestionPayloadVersion(ctx, q.ID)
+	require.NoError(t, err)
+	assert.False(t, cached(version))
+
+	// the page views do not change the cached payload
+	version = cache()
+	require.NoError(t, questionRepo.UpdatePvCount(ctx, q.ID, 1))
+	assert.True(t, cached(version))
+
+	require.NoError(t, questionRepo.RemoveQuestionPayloadCache(ctx, q.ID))
+	version, err = questionRepo.GetQuestionPayloadVersion(ctx, q.ID)
+	require.NoError(t, err)
+	assert.False(t, cached(version))
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_TagPin(t *testing.T) {
+	var (
+		uniqueIDRepo  = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo  = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		tagRelRepo    = tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+		tagRepo       = tag.NewTagRepo(testDataSource, uniqueIDRepo)
+		tagCommonRepo = tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+
+	questions := make([]*entity.Question, 0)
+	for i, title := range []string{"pin old", "pin new"} {
+		q := &entity.Question{UserID: "1", Title: title, OriginalText: title, ParsedText: title,
+			Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionUnPin,
+			CreatedAt: time.Now().Add(time.Duration(i-2) * time.Hour)}
+		require.NoError(t, questionRepo.AddQuestion(ctx, q))
+		questions = append(questions, q)
+	}
+	tags := []*entity.Tag{{SlugName: "pin-tag", DisplayName: "pin-tag", Status: entity.TagStatusAvailable}}
+	require.NoError(t, tagCommonRepo.AddTagList(ctx, tags))
+	tagRels := []*entity.TagRel{
+		{TagID: tags[0].ID, ObjectID: questions[0].ID, Status: entity.TagRelStatusAvailable},
+		{TagID: tags[0].ID, ObjectID: questions[1].ID, Status: entity.TagRelStatusAvailable},
+	}
+	require.NoError(t, tagRelRepo.AddTagRelList(ctx, tagRels))
+	t.Cleanup(func() {
+		require.NoError(t, tagRelRepo.RemoveTagRelListByIDs(ctx, []int64{tagRels[0].ID, tagRels[1].ID}))
+		require.NoError(t, tagRepo.RemoveTag(ctx, tags[0].ID))
+		for _, q := range questions {
+			require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+		}
+	})
+
+	listIDs := func() []string {
+		list, total, err := questionRepo.GetQuestionPage(ctx, 1, 10, []string{tags[0].ID}, "", "newest", 0, false, false, "")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		ids := make([]string, 0, len(list))
+		for _, q := range list {
+			ids = append(ids, q.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{questions[1].ID, questions[0].ID}, listIDs())
+
+	// the question pinned within the tag comes first in the tag list
+	require.NoError(t, tagRelRepo.UpdateTagRelPin(ctx, questions[0].ID, tags[0].ID, entity.QuestionPin,
+		time.Now().Add(-time.Minute)))
+	count, err := tagRelRepo.CountPinnedTagRel(ctx, tags[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []string{questions[0].ID, questions[1].ID}, listIDs())
+
+	// the expired pin is removed
+	count, err = tagRelRepo.UnpinExpiredTagRels(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []string{questions[1].ID, questions[0].ID}, listIDs())
+
+	// the pin without expiry time never expires
+	questions[0].Pin = entity.QuestionPin
+	require.NoError(t, questionRepo.UpdateQuestionOperation(ctx, questions[0]))
+	count, err = questionRepo.UnpinExpiredQuestions(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	pinned, err := questionRepo.GetPinnedQuestionCount(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, pinned, int64(1))
+	assert.Equal(t, []string{questions[0].ID, questions[1].ID}, listIDs())
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_GetRelatedQuestionsByTags(t *testing.T) {
+	var (
+		uniqueIDRepo  = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo  = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		tagRelRepo    = tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+		tagRepo       = tag.NewTagRepo(testDataSource, uniqueIDRepo)
+		tagCommonRepo = tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+
+	newQuestion := func(title string, status int) *entity.Question {
+		q := &entity.Question{UserID: "1", Title: title, OriginalText: title, ParsedText: title,
+			Status: status, Show: entity.QuestionShow}
+		require.NoError(t, questionRepo.AddQuestion(ctx, q))
+		return q
+	}
+	source := newQuestion("related source", entity.QuestionStatusAvailable)
+	twoTags := newQuestion("related two tags", entity.QuestionStatusAvailable)
+	oneTag := newQuestion("related one tag", entity.QuestionStatusAvailable)
+	closed := newQuestion("related closed", entity.QuestionStatusClosed)
+	questions := []*entity.Question{source, twoTags, oneTag, closed}
+
+	tags := []*entity.Tag{
+		{SlugName: "related-go", DisplayName: "related-go", Status: entity.TagStatusAvailable},
+		{SlugName: "related-db", DisplayName: "related-db", Status: entity.TagStatusAvailable},
+	}
+	require.NoError(t, tagCommonRepo.AddTagList(ctx, tags))
+	tagRels := []*entity.TagRel{
+		{TagID: tags[0].ID, ObjectID: source.ID, Status: entity.TagRelStatusAvailable},
+		{TagID: tags[1].ID, ObjectID: source.ID, Status: entity.TagRelStatusAvailable},
+		{TagID: tags[0].ID, ObjectID: twoTags.ID, Status: entity.TagRelStatusAvailable},
+		{TagID: tags[1].ID, ObjectID: twoTags.ID, Status: entity.TagRelStatusAvailable},
+		{TagID: tags[1].ID, ObjectID: oneTag.ID, Status: entity.TagRelStatusAvailable},
+		{TagID: tags[0].ID, ObjectID: closed.ID, Status: entity.TagRelStatusAvailable},
+	}
+	require.NoError(t, tagRelRepo.AddTagRelList(ctx, tagRels))
+	t.Cleanup(func() {
+		tagRelIDs := make([]int64, 0, len(tagRels))
+		for _, tagRel := range tagRels {
+			tagRelIDs = append(tagRelIDs, tagRel.ID)
+		}
+		require.NoError(t, tagRelRepo.RemoveTagRelListByIDs(ctx, tagRelIDs))
+		for _, tag := range tags {
+			require.NoError(t, tagRepo.RemoveTag(ctx, tag.ID))
+		}
+		for _, q := range questions {
+			require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+		}
+	})
+
+	related, sharedTagCount, err := questionRepo.GetRelatedQuestionsByTags(ctx, source.ID,
+		[]string{tags[0].ID, tags[1].ID}, 10)
+	require.NoError(t, err)
+	require.Len(t, related, 2)
+	assert.Equal(t, twoTags.ID, related[0].ID)
+	assert.Equal(t, oneTag.ID, related[1].ID)
+	assert.Equal(t, 2, sharedTagCount[twoTags.ID])
+	assert.Equal(t, 1, sharedTagCount[oneTag.ID])
+
+	_, exist, err := questionRepo.GetRelatedQuestionsCache(ctx, source.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	resp := []*schema.RelatedQuestionResp{{ID: twoTags.ID, Title: twoTags.Title}}
+	require.NoError(t, questionRepo.SetRelatedQuestionsCache(ctx, source.ID, resp))
+	cached, exist, err := questionRepo.GetRelatedQuestionsCache(ctx, source.ID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, resp, cached)
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_ScheduledQuestion(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+
+	scheduled := &entity.Question{UserID: "1", Title: "scheduled announcement", OriginalText: "scheduled",
+		ParsedText: "scheduled", Status: entity.QuestionStatusScheduled, Show: entity.QuestionShow,
+		ScheduledAt: time.Now().Add(time.Hour)}
+	require.NoError(t, questionRepo.AddQuestion(ctx, scheduled))
+	t.Cleanup(func() {
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, scheduled.ID))
+	})
+
+	dueIDs := func() []string {
+		questions, err := questionRepo.GetDueScheduledQuestions(ctx, time.Now(), 100)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(questions))
+		for _, q := range questions {
+			ids = append(ids, q.ID)
+		}
+		return ids
+	}
+	assert.NotContains(t, dueIDs(), scheduled.ID)
+
+	// the canceled schedule is never due
+	updated, err := questionRepo.UpdateQuestionSchedule(ctx, scheduled.ID, time.Time{})
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.NotContains(t, dueIDs(), scheduled.ID)
+
+	updated, err = questionRepo.UpdateQuestionSchedule(ctx, scheduled.ID, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Contains(t, dueIDs(), scheduled.ID)
+
+	publishAt := time.Now()
+	published, err := questionRepo.PublishScheduledQuestion(ctx, scheduled.ID, publishAt)
+	require.NoError(t, err)
+	assert.True(t, published)
+	published, err = questionRepo.PublishScheduledQuestion(ctx, scheduled.ID, publishAt)
+	require.NoError(t, err)
+	assert.False(t, published)
+
+	got, exist, err := questionRepo.GetQuestion(ctx, scheduled.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.QuestionStatusAvailable, got.Status)
+	assert.Equal(t, publishAt.Unix(), got.CreatedAt.Unix())
+	assert.NotContains(t, dueIDs(), scheduled.ID)
+
+	// the published question can not be rescheduled
+	updated, err = questionRepo.UpdateQuestionSchedule(ctx, scheduled.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionRepo_GetStaleQuestions(t *testing.T) {
+	var (
+		uniqueIDRepo  = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo  = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		tagRelRepo    = tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+		tagRepo       = tag.NewTagRepo(testDataSource, uniqueIDRepo)
+		tagCommonRepo = tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+	now := time.Now()
+	old := now.AddDate(0, 0, -60)
+
+	newQuestion := func(title string, createdAt time.Time) *entity.Question {
+		return &entity.Question{UserID: "1", Title: title, OriginalText: title, ParsedText: title,
+			Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionUnPin,
+			CreatedAt: createdAt, PostUpdateTime: createdAt}
+	}
+	stale := newQuestion("stale question", old)
+	answered := newQuestion("answered question", old)
+	answered.AnswerCount = 1
+	recent := newQuestion("recent question", now)
+	active := newQuestion("active question", old)
+	active.PostUpdateTime = now
+	pinned := newQuestion("pinned question", old)
+	pinned.Pin = entity.QuestionPin
+	exempt := newQuestion("exempt question", old)
+	questions := []*entity.Question{stale, answered, recent, active, pinned, exempt}
+	for _, q := range questions {
+		require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	}
+	tags := []*entity.Tag{{SlugName: "stale-exempt", DisplayName: "stale-exempt", Status: entity.TagStatusAvailable}}
+	require.NoError(t, tagCommonRepo.AddTagList(ctx, tags))
+	tagRels := []*entity.TagRel{{TagID: tags[0].ID, ObjectID: exempt.ID, Status: entity.TagRelStatusAvailable}}
+	require.NoError(t, tagRelRepo.AddTagRelList(ctx, tagRels))
+	t.Cleanup(func() {
+		require.NoError(t, tagRelRepo.RemoveTagRelListByIDs(ctx, []int64{tagRels[0].ID}))
+		require.NoError(t, tagRepo.RemoveTag(ctx, tags[0].ID))
+		for _, q := range questions {
+			require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+		}
+	})
+
+	staleIDs := func(exemptTagIDs []string) map[string]bool {
+		list, err := questionRepo.GetStaleQuestions(ctx, now.AddDate(0, 0, -30), exemptTagIDs, 100)
+		require.NoError(t, err)
+		ids := make(map[string]bool, len(list))
+		for _, q := range list {
+			ids[q.ID] = true
+		}
+		return ids
+	}
+
+	ids := staleIDs(nil)
+	assert.True(t, ids[stale.ID])
+	assert.True(t, ids[exempt.ID])
+	assert.False(t, ids[answered.ID])
+	assert.False(t, ids[recent.ID])
+	assert.False(t, ids[active.ID])
+	assert.False(t, ids[pinned.ID])
+
+	// the questions in the exempt tags are never stale
+	ids = staleIDs([]string{tags[0].ID})
+	assert.True(t, ids[stale.ID])
+	assert.False(t, ids[exempt.ID])
+}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/service/question_view"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionViewRepo_FlushPendingViews(t *testing.T) {
+	var (
+		uniqueIDRepo        = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo        = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		questionViewRepo    = question.NewQuestionViewRepo(testDataSource)
+		questionViewService = question_view.NewQuestionViewService(questionViewRepo, questionRepo)
+	)
+	ctx := context.TODO()
+
+	q := &entity.Question{UserID: "1", Title: "view count", OriginalText: "view count",
+		ParsedText: "view count", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	t.Cleanup(func() {
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+	})
+
+	// the author and the repeated views in the window are not counted
+	assert.False(t, questionViewService.AddView(ctx, q.ID, "1", "1", "127.0.0.1"))
+	assert.True(t, questionViewService.AddView(ctx, q.ID, "1", "2", "127.0.0.1"))
+	assert.False(t, questionViewService.AddView(ctx, q.ID, "1", "2", "127.0.0.2"))
+	assert.True(t, questionViewService.AddView(ctx, q.ID, "1", "", "127.0.0.1"))
+	assert.False(t, questionViewService.AddView(ctx, q.ID, "1", "", "127.0.0.1"))
+	assert.EqualValues(t, 2, questionViewService.GetPendingView(ctx, q.ID))
+
+	views, err := questionViewRepo.GetPendingViews(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, views[q.ID])
+
+	// the views added during the flush are kept for the next flush
+	assert.True(t, questionViewService.AddView(ctx, q.ID, "1", "3", ""))
+	require.NoError(t, questionViewRepo.RemovePendingView(ctx, q.ID, views[q.ID]))
+	require.NoError(t, questionRepo.UpdatePvCount(ctx, q.ID, int(views[q.ID])))
+	assert.EqualValues(t, 1, questionViewService.GetPendingView(ctx, q.ID))
+
+	questionViewService.FlushPendingViews(ctx)
+	assert.EqualValues(t, 0, questionViewService.GetPendingView(ctx, q.ID))
+	views, err = questionViewRepo.GetPendingViews(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, views, q.ID)
+
+	info, exist, err := questionRepo.GetQuestion(ctx, q.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, 3, info.ViewCount)
+}
+
+func Test_questionViewRepo_ConcurrentViews(t *testing.T) {
+	questionViewRepo := question.NewQuestionViewRepo(testDataSource)
+	ctx := context.TODO()
+	questionID := "10010000000000901"
+
+	var wg sync.WaitGroup
+	var firstViews sync.Map
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			first, err := questionViewRepo.MarkViewed(ctx, questionID, strconv.Itoa(i%10))
+			assert.NoError(t, err)
+			if !first {
+				return
+			}
+			if _, loaded := firstViews.LoadOrStore(i%10, true); loaded {
+				t.Errorf("the viewer %d is counted twice", i%10)
+			}
+			assert.NoError(t, questionViewRepo.AddPendingView(ctx, questionID))
+		}()
+	}
+	wg.Wait()
+
+	count, err := questionViewRepo.GetPendingView(ctx, questionID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, count)
+	views, err := questionViewRepo.GetPendingViews(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, views[questionID])
+
+	// the question with the views added after all the views are flushed is flushed again
+	require.NoError(t, questionViewRepo.RemovePendingView(ctx, questionID, count))
+	views, err = questionViewRepo.GetPendingViews(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, views, questionID)
+	require.NoError(t, questionViewRepo.AddPendingView(ctx, questionID))
+	views, err = questionViewRepo.GetPendingViews(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, views[questionID])
+	require.NoError(t, questionViewRepo.RemovePendingView(ctx, questionID, 1))
+}
+
+func Test_questionViewRepo_LockFlush(t *testing.T) {
+	questionViewRepo := question.NewQuestionViewRepo(testDataSource)
+	ctx := context.TODO()
+
+	locked, err := questionViewRepo.LockFlush(ctx)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	locked, err = questionViewRepo.LockFlush(ctx)
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	require.NoError(t, questionViewRepo.UnlockFlush(ctx))
+	locked, err = questionViewRepo.LockFlush(ctx)
+	require.NoError(t, err)
+	assert.True(t, locked)
+	require.NoError(t, questionViewRepo.UnlockFlush(ctx))
+}
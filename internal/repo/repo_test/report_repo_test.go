@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/report"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reportRepo_ModerationQueue(t *testing.T) {
+	reportRepo := report.NewReportRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+	ctx := context.TODO()
+
+	reports := []*entity.Report{
+		{UserID: "93001", ObjectID: "10010000000093001", ReportedUserID: "93100", ObjectType: 1, ReportType: 1,
+			Status: entity.ReportStatusPending},
+		{UserID: "93002", ObjectID: "10070000000093002", ReportedUserID: "93100", ObjectType: 7, ReportType: 1,
+			Status: entity.ReportStatusPending},
+		{UserID: "93003", ObjectID: "10070000000093002", ReportedUserID: "93100", ObjectType: 7, ReportType: 2,
+			Status: entity.ReportStatusPending},
+		{UserID: "93004", ObjectID: "10070000000093002", ReportedUserID: "93100", ObjectType: 7, ReportType: 1,
+			Status: entity.ReportStatusCompleted},
+	}
+	for _, r := range reports {
+		require.NoError(t, reportRepo.AddReport(ctx, r))
+	}
+
+	stats, total, err := reportRepo.GetPendingReportObjectPage(ctx, &schema.ModerationQueueDTO{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, stats, 2)
+	// the object with more pending flags comes first
+	assert.Equal(t, "10070000000093002", stats[0].ObjectID)
+	assert.Equal(t, int64(2), stats[0].FlagCount)
+	assert.Equal(t, "10010000000093001", stats[1].ObjectID)
+
+	stats, total, err = reportRepo.GetPendingReportObjectPage(ctx, &schema.ModerationQueueDTO{Page: 1, PageSize: 10,
+		ObjectType: 7, ReportType: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(1), stats[0].FlagCount)
+
+	_, total, err = reportRepo.GetPendingReportObjectPage(ctx, &schema.ModerationQueueDTO{Page: 1, PageSize: 10,
+		FlaggedBefore: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	pending, err := reportRepo.GetPendingReportsByObjectIDs(ctx, []string{"10070000000093002"})
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	affected, err := reportRepo.ResolvePendingReports(ctx, "10070000000093002", entity.ReportStatusIgnore)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+
+	pending, err = reportRepo.GetPendingReportsByObjectIDs(ctx, []string{"10070000000093002"})
+	require.NoError(t, err)
+	assert.Len(t, pending, 0)
+	got, exist, err := reportRepo.GetByID(ctx, reports[3].ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.ReportStatusCompleted, got.Status)
+
+	_, err = reportRepo.ResolvePendingReports(ctx, "10010000000093001", entity.ReportStatusIgnore)
+	require.NoError(t, err)
+}
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/repo/question"
 	"github.com/apache/answer/internal/repo/revision"
@@ -119,3 +120,75 @@ func Test_revisionRepo_GetRevisionList(t *testing.T) {
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(revs), 1)
 }
+
+func Test_revisionRepo_UpdateObjectRevisionId(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		revisionRepo = revision.NewRevisionRepo(testDataSource, uniqueIDRepo)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+	)
+	Test_revisionRepo_AddRevision(t)
+
+	content, err := json.Marshal(q)
+	require.NoError(t, err)
+	rev := getRev(q.ID, q.Title, string(content))
+	err = revisionRepo.AddRevision(context.TODO(), rev, false)
+	require.NoError(t, err)
+
+	// without session
+	err = revisionRepo.UpdateObjectRevisionId(context.TODO(), rev, nil)
+	require.NoError(t, err)
+	qr, _, _ := questionRepo.GetQuestion(context.TODO(), q.ID)
+	assert.Equal(t, rev.ID, qr.RevisionID)
+}
+
+func Test_revisionRepo_SuggestRevision(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		revisionRepo = revision.NewRevisionRepo(testDataSource, uniqueIDRepo)
+	)
+	ctx := context.TODO()
+
+	const userID = "90041"
+	suggest := func(objectID string) error {
+		rev := getRev(objectID, "suggested", "suggested")
+		rev.UserID = userID
+		rev.Status = entity.RevisionUnreviewedStatus
+		return revisionRepo.AddRevision(ctx, rev, false)
+	}
+	t.Cleanup(func() {
+		_, _ = testDataSource.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.Revision{})
+	})
+
+	objectIDs := []string{
+		"10010000000000861", "10010000000000862", "10010000000000863",
+		"10010000000000864", "10010000000000865", "10010000000000866",
+	}
+	require.NoError(t, suggest(objectIDs[0]))
+	// only one suggested edit of the object can wait for review
+	assert.Error(t, suggest(objectIDs[0]))
+	for _, objectID := range objectIDs[1:constant.RevisionMaxPendingPerUser] {
+		require.NoError(t, suggest(objectID))
+	}
+	// the user has too many suggested edits waiting for review
+	assert.Error(t, suggest(objectIDs[constant.RevisionMaxPendingPerUser]))
+
+	rev, exist, err := revisionRepo.ExistUnreviewedByObjectID(ctx, objectIDs[0])
+	require.NoError(t, err)
+	require.True(t, exist)
+	reviewed, err := revisionRepo.ReviewRevision(ctx, rev.ID, entity.RevisionReviewRejectStatus, "1", "spam")
+	require.NoError(t, err)
+	assert.True(t, reviewed)
+	// the revision can only be reviewed once
+	reviewed, err = revisionRepo.ReviewRevision(ctx, rev.ID, entity.RevisionReviewPassStatus, "1", "")
+	require.NoError(t, err)
+	assert.False(t, reviewed)
+
+	rev, exist, err = revisionRepo.GetRevisionByID(ctx, rev.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.RevisionReviewRejectStatus, rev.Status)
+	assert.Equal(t, "spam", rev.RejectReason)
+	// the rejected suggested edit does not count
+	require.NoError(t, suggest(objectIDs[constant.RevisionMaxPendingPerUser]))
+}
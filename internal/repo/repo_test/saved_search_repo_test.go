@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/saved_search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_savedSearchRepo(t *testing.T) {
+	savedSearchRepo := saved_search.NewSavedSearchRepo(testDataSource)
+	ctx := context.TODO()
+
+	checkedAt := time.Now().Add(-time.Hour)
+	alert := &entity.SavedSearch{UserID: "301", Name: "golang", Query: "[go]  error", NormalizedQuery: "[go] error",
+		SearchOrder: "newest", AlertEnabled: true, LastCheckedAt: checkedAt}
+	plain := &entity.SavedSearch{UserID: "301", Name: "plain", Query: "plain", NormalizedQuery: "plain",
+		SearchOrder: "relevance"}
+	other := &entity.SavedSearch{UserID: "302", Name: "other", Query: "other", NormalizedQuery: "other",
+		SearchOrder: "relevance", AlertEnabled: true, LastCheckedAt: checkedAt}
+	for _, s := range []*entity.SavedSearch{alert, plain, other} {
+		require.NoError(t, savedSearchRepo.AddSavedSearch(ctx, s))
+	}
+	t.Cleanup(func() {
+		require.NoError(t, savedSearchRepo.RemoveSavedSearch(ctx, "301", alert.ID))
+		require.NoError(t, savedSearchRepo.RemoveSavedSearch(ctx, "302", other.ID))
+	})
+
+	total, alerts, err := savedSearchRepo.CountSavedSearch(ctx, "301")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, int64(1), alerts)
+
+	list, total, err := savedSearchRepo.GetSavedSearchPage(ctx, "301", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, plain.ID, list[0].ID)
+	assert.Equal(t, alert.ID, list[1].ID)
+
+	// the saved search is only got by its user
+	_, exist, err := savedSearchRepo.GetSavedSearch(ctx, "302", alert.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	got, exist, err := savedSearchRepo.GetSavedSearch(ctx, "301", alert.ID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "[go] error", got.NormalizedQuery)
+
+	now := time.Now()
+	alertList, err := savedSearchRepo.GetAlertSavedSearches(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, alertList, 2)
+	assert.Equal(t, alert.ID, alertList[0].ID)
+	assert.Equal(t, other.ID, alertList[1].ID)
+
+	// the alert is claimed by only one of the instances checking it at the same time
+	var claimedCount atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := savedSearchRepo.ClaimSavedSearchAlert(ctx, alertList[0], now)
+			assert.NoError(t, err)
+			if claimed {
+				claimedCount.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, claimedCount.Load())
+
+	// the alert checked now is not got again in the same run
+	alertList, err = savedSearchRepo.GetAlertSavedSearches(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, alertList, 1)
+	assert.Equal(t, other.ID, alertList[0].ID)
+
+	// the other user can not remove the saved search
+	require.NoError(t, savedSearchRepo.RemoveSavedSearch(ctx, "302", plain.ID))
+	_, exist, err = savedSearchRepo.GetSavedSearch(ctx, "301", plain.ID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	require.NoError(t, savedSearchRepo.RemoveSavedSearch(ctx, "301", plain.ID))
+	_, exist, err = savedSearchRepo.GetSavedSearch(ctx, "301", plain.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/search_sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pluginSyncer_GetQuestionsAfterID(t *testing.T) {
+	now := time.Now()
+	questions := []*entity.Question{
+		{ID: "10010000000900001", Title: "sync question 1", UpdatedAt: now.Add(-48 * time.Hour)},
+		{ID: "10010000000900002", Title: "sync question 2", UpdatedAt: now},
+		{ID: "10010000000900003", Title: "sync question 3", UpdatedAt: now},
+	}
+	for _, question := range questions {
+		question.CreatedAt, question.PostUpdateTime = now, now
+		question.OriginalText, question.ParsedText = question.Title, question.Title
+		_, err := testDataSource.DB.Context(context.TODO()).Insert(question)
+		require.NoError(t, err)
+	}
+	defer func() {
+		_, _ = testDataSource.DB.Context(context.TODO()).In("id", "10010000000900001",
+			"10010000000900002", "10010000000900003").Delete(&entity.Question{})
+	}()
+
+	syncer := search_sync.NewPluginSyncer(testDataSource)
+	list, cursor, err := syncer.GetQuestionsAfterID(context.TODO(), "10010000000900000", time.Time{}, 2)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "10010000000900002", cursor)
+
+	list, cursor, err = syncer.GetQuestionsAfterID(context.TODO(), cursor, time.Time{}, 2)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "10010000000900003", cursor)
+
+	list, next, err := syncer.GetQuestionsAfterID(context.TODO(), cursor, time.Time{}, 2)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	assert.Equal(t, cursor, next)
+
+	list, _, err = syncer.GetQuestionsAfterID(context.TODO(), "10010000000900000", now.Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
@@ -112,3 +112,27 @@ func Test_tagListRepo_GetObjectTagRelWithoutStatus(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), count)
 }
+
+func Test_tagListRepo_MigrateTagObjects(t *testing.T) {
+	tagRelRepo := tag.NewTagRelRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource))
+	const (
+		synonymTagID = "10030000000000301"
+		mainTagID    = "10030000000000302"
+	)
+	err := tagRelRepo.AddTagRelList(context.TODO(), []*entity.TagRel{
+		{ObjectID: "10010000000000301", TagID: synonymTagID, Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000000302", TagID: synonymTagID, Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000000302", TagID: mainTagID, Status: entity.TagRelStatusAvailable},
+	})
+	require.NoError(t, err)
+
+	err = tagRelRepo.MigrateTagObjects(context.TODO(), synonymTagID, mainTagID)
+	require.NoError(t, err)
+
+	count, err := tagRelRepo.CountTagRelByTagID(context.TODO(), synonymTagID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	count, err = tagRelRepo.CountTagRelByTagID(context.TODO(), mainTagID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
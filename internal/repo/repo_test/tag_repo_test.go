@@ -198,3 +198,58 @@ func Test_tagRepo_UpdateTagSynonym(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, testTagList[0].ID, fmt.Sprintf("%d", gotTag.MainTagID))
 }
+
+func Test_tagRepo_MergeTag(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagCommonRepo := tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo)
+	tagRelRepo := tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
+	tagRepo := tag.NewTagRepo(testDataSource, uniqueIDRepo)
+	const followActivityType = 10001
+
+	sourceTag := &entity.Tag{SlugName: "merge-source", DisplayName: "merge-source", Status: entity.TagStatusAvailable}
+	targetTag := &entity.Tag{SlugName: "merge-target", DisplayName: "merge-target", Status: entity.TagStatusAvailable}
+	require.NoError(t, tagCommonRepo.AddTagList(context.TODO(), []*entity.Tag{sourceTag, targetTag}))
+	synonymTag := &entity.Tag{SlugName: "merge-synonym", DisplayName: "merge-synonym", Status: entity.TagStatusAvailable,
+		MainTagID: converter.StringToInt64(sourceTag.ID), MainTagSlugName: sourceTag.SlugName}
+	require.NoError(t, tagCommonRepo.AddTagList(context.TODO(), []*entity.Tag{synonymTag}))
+
+	err := tagRelRepo.AddTagRelList(context.TODO(), []*entity.TagRel{
+		{ObjectID: "10010000000000401", TagID: sourceTag.ID, Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000000402", TagID: sourceTag.ID, Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000000402", TagID: targetTag.ID, Status: entity.TagRelStatusAvailable},
+	})
+	require.NoError(t, err)
+	for _, follow := range []struct{ userID, objectID string }{
+		{"10010000000000411", sourceTag.ID},
+		{"10010000000000412", sourceTag.ID},
+		{"10010000000000412", targetTag.ID},
+	} {
+		_, err = testDataSource.DB.Context(context.TODO()).Insert(&entity.Activity{
+			UserID:           follow.userID,
+			ObjectID:         follow.objectID,
+			OriginalObjectID: follow.objectID,
+			ActivityType:     followActivityType,
+			Cancelled:        entity.ActivityAvailable,
+		})
+		require.NoError(t, err)
+	}
+
+	err = tagRepo.MergeTag(context.TODO(), sourceTag, targetTag, followActivityType)
+	require.NoError(t, err)
+
+	gotTarget, exist, err := tagCommonRepo.GetTagByID(context.TODO(), targetTag.ID, true)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 2, gotTarget.QuestionCount)
+	assert.Equal(t, 2, gotTarget.FollowCount)
+
+	gotSource, exist, err := tagRepo.MustGetTagByNameOrID(context.TODO(), sourceTag.ID, "")
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, entity.TagStatusDeleted, gotSource.Status)
+
+	gotSynonym, exist, err := tagCommonRepo.GetTagByID(context.TODO(), synonymTag.ID, true)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, converter.StringToInt64(targetTag.ID), gotSynonym.MainTagID)
+}
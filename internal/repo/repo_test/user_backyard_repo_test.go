@@ -24,15 +24,26 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/repo/auth"
+	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/rank"
 	"github.com/apache/answer/internal/repo/user"
+	config2 "github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/user_admin"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newUserAdminRepo() user_admin.UserAdminRepo {
+	userRankRepo := rank.NewUserRankRepo(testDataSource, config2.NewConfigService(config.NewConfigRepo(testDataSource)))
+	return user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource), userRankRepo)
+}
+
 func Test_userAdminRepo_GetUserInfo(t *testing.T) {
-	userAdminRepo := user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+	userAdminRepo := newUserAdminRepo()
 	got, exist, err := userAdminRepo.GetUserInfo(context.TODO(), "1")
 	require.NoError(t, err)
 	assert.True(t, exist)
@@ -40,7 +51,7 @@ func Test_userAdminRepo_GetUserInfo(t *testing.T) {
 }
 
 func Test_userAdminRepo_GetUserPage(t *testing.T) {
-	userAdminRepo := user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+	userAdminRepo := newUserAdminRepo()
 	got, total, err := userAdminRepo.GetUserPage(context.TODO(), 1, 1, &entity.User{Username: "admin"}, "", false)
 	require.NoError(t, err)
 	assert.Equal(t, int64(1), total)
@@ -48,7 +59,7 @@ func Test_userAdminRepo_GetUserPage(t *testing.T) {
 }
 
 func Test_userAdminRepo_UpdateUserStatus(t *testing.T) {
-	userAdminRepo := user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+	userAdminRepo := newUserAdminRepo()
 	got, exist, err := userAdminRepo.GetUserInfo(context.TODO(), "1")
 	require.NoError(t, err)
 	assert.True(t, exist)
@@ -72,3 +83,59 @@ func Test_userAdminRepo_UpdateUserStatus(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, entity.UserStatusAvailable, got.Status)
 }
+
+func Test_userAdminRepo_EraseUser(t *testing.T) {
+	userAdminRepo := newUserAdminRepo()
+	ctx := context.TODO()
+
+	erased := &entity.User{Username: "erased_user", EMail: "erased@example.com", Status: entity.UserStatusAvailable,
+		MailStatus: entity.EmailStatusAvailable, DisplayName: "erased user"}
+	author := &entity.User{Username: "erase_author", EMail: "erase_author@example.com", Status: entity.UserStatusAvailable,
+		MailStatus: entity.EmailStatusAvailable, DisplayName: "erase author", Rank: 100}
+	_, err := testDataSource.DB.Context(ctx).Insert(erased, author)
+	require.NoError(t, err)
+
+	question := &entity.Question{ID: "10010000000009001", UserID: erased.ID, Title: "erased user question", Status: entity.QuestionStatusAvailable}
+	_, err = testDataSource.DB.Context(ctx).Insert(question)
+	require.NoError(t, err)
+	vote := &entity.Activity{UserID: author.ID, TriggerUserID: converter.StringToInt64(erased.ID), ObjectID: "10020000000009001",
+		ActivityType: 90011, HasRank: 1, Rank: 10}
+	_, err = testDataSource.DB.Context(ctx).Insert(vote)
+	require.NoError(t, err)
+	_, err = testDataSource.DB.Context(ctx).Insert(&entity.UserExternalLogin{UserID: erased.ID, Provider: "github", ExternalID: "erased"})
+	require.NoError(t, err)
+
+	questionIDs, err := userAdminRepo.EraseUser(ctx, erased.ID, false, true)
+	require.NoError(t, err)
+	assert.Contains(t, questionIDs, question.ID)
+
+	_, exist, err := userAdminRepo.GetUserInfo(ctx, erased.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	placeholder := &entity.User{}
+	exist, err = testDataSource.DB.Context(ctx).Where("username = ?", constant.DeletedUserPlaceholderUsername).Get(placeholder)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.UserStatusDeleted, placeholder.Status)
+
+	gotQuestion := &entity.Question{}
+	_, err = testDataSource.DB.Context(ctx).ID(question.ID).Get(gotQuestion)
+	require.NoError(t, err)
+	assert.Equal(t, placeholder.ID, gotQuestion.UserID)
+	assert.Equal(t, entity.QuestionStatusAvailable, gotQuestion.Status)
+
+	gotVote := &entity.Activity{}
+	_, err = testDataSource.DB.Context(ctx).ID(vote.ID).Get(gotVote)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ActivityCancelled, gotVote.Cancelled)
+	assert.Equal(t, converter.StringToInt64(placeholder.ID), gotVote.TriggerUserID)
+
+	gotAuthor, _, err := userAdminRepo.GetUserInfo(ctx, author.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 90, gotAuthor.Rank)
+
+	count, err := testDataSource.DB.Context(ctx).Where("user_id = ?", erased.ID).Count(&entity.UserExternalLogin{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
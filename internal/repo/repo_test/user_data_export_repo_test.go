@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/user_data_export"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_userDataExportRepo_ExportUserData(t *testing.T) {
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(testDataSource)
+	ctx := context.TODO()
+
+	user := &entity.User{Username: "data_export_user", Pass: "secret-hash", EMail: "data_export@example.com",
+		MailStatus: entity.EmailStatusAvailable, Status: entity.UserStatusAvailable, DisplayName: "data export"}
+	_, err := testDataSource.DB.Context(ctx).Insert(user)
+	require.NoError(t, err)
+
+	var rows []map[string]any
+	err = userDataExportRepo.ExportUserProfile(ctx, user.ID, func(row map[string]any) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "data_export_user", rows[0]["username"])
+	assert.NotContains(t, rows[0], "pass")
+
+	activities := []*entity.Activity{
+		{UserID: user.ID, TriggerUserID: 1, ObjectID: "10010000000000001", ActivityType: 90001, HasRank: 1, Rank: 10},
+		{UserID: user.ID, TriggerUserID: 1, ObjectID: "10010000000000001", ActivityType: 90002},
+		{UserID: "1", TriggerUserID: 1, ObjectID: "10010000000000001", ActivityType: 90002},
+	}
+	_, err = testDataSource.DB.Context(ctx).Insert(activities)
+	require.NoError(t, err)
+
+	rows = nil
+	err = userDataExportRepo.ExportUserVotes(ctx, user.ID, []int{90002}, func(row map[string]any) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 90002, rows[0]["activity_type"])
+	assert.NotContains(t, rows[0], "trigger_user_id")
+
+	rows = nil
+	err = userDataExportRepo.ExportUserReputationEvents(ctx, user.ID, func(row map[string]any) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 10, rows[0]["rank"])
+}
+
+func Test_userDataExportRepo_ExportRunning(t *testing.T) {
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(testDataSource)
+	ctx := context.TODO()
+
+	ok, err := userDataExportRepo.SetExportRunning(ctx, "93001")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = userDataExportRepo.SetExportRunning(ctx, "93001")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, userDataExportRepo.ClearExportRunning(ctx, "93001"))
+	ok, err = userDataExportRepo.SetExportRunning(ctx, "93001")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	file, err := userDataExportRepo.GetExportFile(ctx, "unknown-token")
+	require.NoError(t, err)
+	assert.Nil(t, file)
+	require.NoError(t, userDataExportRepo.SetExportFile(ctx, "93001-token",
+		&schema.UserDataExportFile{UserID: "93001", FileName: "user_data.zip", Content: []byte("zip content")},
+		time.Now().Add(time.Hour)))
+	file, err = userDataExportRepo.GetExportFile(ctx, "93001-token")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, "user_data.zip", file.FileName)
+	assert.Equal(t, []byte("zip content"), file.Content)
+}
+
+func Test_userDataExportRepo_SetExportRunningConcurrently(t *testing.T) {
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(testDataSource)
+	ctx := context.TODO()
+
+	var started atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := userDataExportRepo.SetExportRunning(ctx, "93002")
+			assert.NoError(t, err)
+			if ok {
+				started.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), started.Load())
+	require.NoError(t, userDataExportRepo.ClearExportRunning(ctx, "93002"))
+}
+
+func Test_userDataExportRepo_RemoveExpiredExportFiles(t *testing.T) {
+	userDataExportRepo := user_data_export.NewUserDataExportRepo(testDataSource)
+	ctx := context.TODO()
+
+	require.NoError(t, userDataExportRepo.SetExportFile(ctx, "93003-expired",
+		&schema.UserDataExportFile{UserID: "93003", FileName: "user_data.zip"}, time.Now().Add(-time.Minute)))
+	require.NoError(t, userDataExportRepo.SetExportFile(ctx, "93003-valid",
+		&schema.UserDataExportFile{UserID: "93003", FileName: "user_data.zip"}, time.Now().Add(time.Hour)))
+
+	file, err := userDataExportRepo.GetExportFile(ctx, "93003-expired")
+	require.NoError(t, err)
+	assert.Nil(t, file)
+
+	require.NoError(t, userDataExportRepo.RemoveExpiredExportFiles(ctx))
+	count, err := testDataSource.DB.Context(ctx).Where("user_id = ?", "93003").Count(&entity.UserDataExport{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	file, err = userDataExportRepo.GetExportFile(ctx, "93003-valid")
+	require.NoError(t, err)
+	assert.NotNil(t, file)
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/repo/user_external_login"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userExternalLoginRepo_ConsumeCacheSAMLAssertionID(t *testing.T) {
+	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(testDataSource)
+	ctx := context.TODO()
+
+	// the same assertion posted in parallel is only consumed once
+	var consumed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			first, err := userExternalLoginRepo.ConsumeCacheSAMLAssertionID(ctx, "assertion-replay", time.Minute)
+			assert.NoError(t, err)
+			if first {
+				consumed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, consumed.Load())
+
+	first, err := userExternalLoginRepo.ConsumeCacheSAMLAssertionID(ctx, "assertion-other", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, first)
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/repo/user_mute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_userMuteRepo(t *testing.T) {
+	userMuteRepo := user_mute.NewUserMuteRepo(testDataSource)
+	ctx := context.TODO()
+
+	require.NoError(t, userMuteRepo.AddUserMute(ctx, "101", "102"))
+	require.NoError(t, userMuteRepo.AddUserMute(ctx, "101", "103"))
+	// muting the muted user again changes nothing
+	require.NoError(t, userMuteRepo.AddUserMute(ctx, "101", "102"))
+	t.Cleanup(func() {
+		require.NoError(t, userMuteRepo.RemoveUserMute(ctx, "101", "103"))
+	})
+
+	count, err := userMuteRepo.CountUserMute(ctx, "101")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	mutes, total, err := userMuteRepo.GetUserMutePage(ctx, "101", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "103", mutes[0].MutedUserID)
+	assert.Equal(t, "102", mutes[1].MutedUserID)
+
+	// the muting is personal
+	ids, err := userMuteRepo.GetMutedUserIDs(ctx, "102")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	require.NoError(t, userMuteRepo.RemoveUserMute(ctx, "101", "102"))
+	ids, err = userMuteRepo.GetMutedUserIDs(ctx, "101")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"103"}, ids)
+}
+
+func Test_questionRepo_GetQuestionPageExcludeMutedUsers(t *testing.T) {
+	var (
+		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		userMuteRepo = user_mute.NewUserMuteRepo(testDataSource)
+	)
+	ctx := context.TODO()
+
+	q := &entity.Question{UserID: "202", Title: "muted question", OriginalText: "muted", ParsedText: "muted",
+		Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, Pin: entity.QuestionUnPin}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	require.NoError(t, userMuteRepo.AddUserMute(ctx, "201", "202"))
+	t.Cleanup(func() {
+		require.NoError(t, userMuteRepo.RemoveUserMute(ctx, "201", "202"))
+		require.NoError(t, questionRepo.RemoveQuestion(ctx, q.ID))
+	})
+
+	listIDs := func(userID, mutedByUserID string) map[string]bool {
+		list, _, err := questionRepo.GetQuestionPage(ctx, 1, 100, nil, userID, "newest", 0, false, false, mutedByUserID)
+		require.NoError(t, err)
+		ids := make(map[string]bool, len(list))
+		for _, item := range list {
+			ids[item.ID] = true
+		}
+		return ids
+	}
+	assert.True(t, listIDs("", "")[q.ID])
+	assert.True(t, listIDs("", "203")[q.ID])
+	assert.False(t, listIDs("", "201")[q.ID])
+	// the questions of the muted user are still listed on the profile
+	assert.True(t, listIDs("202", "201")[q.ID])
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/rank"
+	"github.com/apache/answer/internal/repo/user"
+	config2 "github.com/apache/answer/internal/service/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_userRankRepo_UserReputationChangePage(t *testing.T) {
+	userRankRepo := rank.NewUserRankRepo(testDataSource, config2.NewConfigService(config.NewConfigRepo(testDataSource)))
+
+	const userID = "90001"
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	activities := []*entity.Activity{
+		{UserID: userID, ObjectID: "10010000000000801", ActivityType: 3, Rank: 10, HasRank: 1},
+		{UserID: userID, ObjectID: "10020000000000801", ActivityType: 1, Rank: 15, HasRank: 1},
+		{UserID: userID, ObjectID: "10020000000000802", ActivityType: 2, Rank: 10, HasRank: 1,
+			Cancelled: entity.ActivityCancelled, CancelledAt: start.Add(5 * time.Minute)},
+		// no rank, not a change
+		{UserID: userID, ObjectID: "10010000000000801", ActivityType: 87, HasRank: 0},
+	}
+	for i, act := range activities {
+		act.CreatedAt = start.Add(time.Duration(i) * time.Minute)
+		act.UpdatedAt = act.CreatedAt
+		_, err := testDataSource.DB.Context(context.TODO()).NoAutoTime().Insert(act)
+		require.NoError(t, err)
+	}
+
+	changes, total, newerSum, err := userRankRepo.UserReputationChangePage(context.TODO(), userID, nil, false, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, int64(0), newerSum)
+	require.Len(t, changes, 4)
+	// the reversal is the newest change
+	assert.Equal(t, 1, changes[0].Reversal)
+	assert.Equal(t, -10, changes[0].Delta)
+	assert.Equal(t, activities[2].ID, changes[0].ID)
+	assert.Equal(t, activities[2].ID, changes[1].ID)
+	assert.Equal(t, activities[0].ID, changes[3].ID)
+
+	changes, total, newerSum, err = userRankRepo.UserReputationChangePage(context.TODO(), userID, nil, false, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, int64(15), newerSum)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 10, changes[0].Delta)
+
+	changes, total, _, err = userRankRepo.UserReputationChangePage(context.TODO(), userID, []int{1}, false, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 15, changes[0].Delta)
+
+	changes, total, _, err = userRankRepo.UserReputationChangePage(context.TODO(), userID, nil, true, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 1, changes[0].Reversal)
+}
+
+func Test_userRankRepo_GetReputationLeaderboard(t *testing.T) {
+	var (
+		userRankRepo = rank.NewUserRankRepo(testDataSource, config2.NewConfigService(config.NewConfigRepo(testDataSource)))
+		userRepo     = user.NewUserRepo(testDataSource)
+	)
+	ctx := context.TODO()
+
+	users := make([]*entity.User, 0, 3)
+	for _, name := range []string{"leaderboarda", "leaderboardb", "leaderboardc"} {
+		u := &entity.User{Username: name, Pass: name, EMail: name + "@example.com", DisplayName: name,
+			MailStatus: entity.EmailStatusAvailable, Status: entity.UserStatusAvailable}
+		require.NoError(t, userRepo.AddUser(ctx, u))
+		users = append(users, u)
+	}
+	const (
+		tagID      = "10030000000000801"
+		questionID = "10010000000000811"
+		answerID   = "10020000000000811"
+	)
+	tagRel := &entity.TagRel{TagID: tagID, ObjectID: questionID, Status: entity.TagRelStatusAvailable}
+	answer := &entity.Answer{ID: answerID, QuestionID: questionID, UserID: users[1].ID, Status: entity.AnswerStatusAvailable}
+	_, err := testDataSource.DB.Context(ctx).Insert(tagRel, answer)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = testDataSource.DB.Context(ctx).ID(tagRel.ID).Delete(&entity.TagRel{})
+		_, _ = testDataSource.DB.Context(ctx).ID(answerID).Delete(&entity.Answer{})
+	})
+
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	activities := []*entity.Activity{
+		// gained before the window
+		{UserID: users[0].ID, ObjectID: "10010000000000812", Rank: 100, HasRank: 1, CreatedAt: since.Add(-time.Hour)},
+		{UserID: users[0].ID, ObjectID: questionID, Rank: 10, HasRank: 1, CreatedAt: since.Add(time.Minute)},
+		{UserID: users[1].ID, ObjectID: answerID, Rank: 15, HasRank: 1, CreatedAt: since.Add(time.Minute)},
+		{UserID: users[1].ID, ObjectID: "10010000000000812", Rank: 10, HasRank: 1, CreatedAt: since.Add(time.Minute)},
+		// gained before the window and reversed in it
+		{UserID: users[2].ID, ObjectID: questionID, Rank: 10, HasRank: 1, CreatedAt: since.Add(-time.Hour),
+			Cancelled: entity.ActivityCancelled, CancelledAt: since.Add(time.Minute)},
+	}
+	for _, act := range activities {
+		act.UpdatedAt = act.CreatedAt
+		_, err = testDataSource.DB.Context(ctx).NoAutoTime().Insert(act)
+		require.NoError(t, err)
+	}
+
+	gained := func(stats []*entity.ActivityUserRankStat) map[string]int {
+		mapping := make(map[string]int)
+		for _, stat := range stats {
+			mapping[stat.UserID] = stat.Rank
+		}
+		return mapping
+	}
+
+	stats, _, err := userRankRepo.GetReputationLeaderboard(ctx, since, nil, 1, 100)
+	require.NoError(t, err)
+	mapping := gained(stats)
+	assert.Equal(t, 10, mapping[users[0].ID])
+	assert.Equal(t, 25, mapping[users[1].ID])
+	// the users who lost reputation in the window are not listed
+	assert.NotContains(t, mapping, users[2].ID)
+
+	stats, total, err := userRankRepo.GetReputationLeaderboard(ctx, time.Time{}, []string{tagID}, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, stats, 2)
+	assert.Equal(t, users[1].ID, stats[0].UserID)
+	assert.Equal(t, 15, stats[0].Rank)
+	assert.Equal(t, users[0].ID, stats[1].UserID)
+	assert.Equal(t, 10, stats[1].Rank)
+
+	stats, total, err = userRankRepo.GetReputationLeaderboard(ctx, since, []string{tagID}, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, stats, 1)
+	assert.Equal(t, users[0].ID, stats[0].UserID)
+
+	// all the time without tag is ordered by the reputation of the users
+	stats, _, err = userRankRepo.GetReputationLeaderboard(ctx, time.Time{}, nil, 1, 100)
+	require.NoError(t, err)
+	for i := 1; i < len(stats); i++ {
+		assert.GreaterOrEqual(t, stats[i-1].Rank, stats[i].Rank)
+	}
+}
+
+func Test_userTagRankRepo_ChangeUserTagRank(t *testing.T) {
+	var (
+		configService   = config2.NewConfigService(config.NewConfigRepo(testDataSource))
+		userRankRepo    = rank.NewUserRankRepo(testDataSource, configService)
+		userTagRankRepo = rank.NewUserTagRankRepo(testDataSource)
+	)
+	ctx := context.TODO()
+
+	const (
+		userID     = "90011"
+		tagID      = "10030000000000821"
+		otherTagID = "10030000000000822"
+		questionID = "10010000000000821"
+		answerID   = "10020000000000821"
+	)
+	tagRel := &entity.TagRel{TagID: tagID, ObjectID: questionID, Status: entity.TagRelStatusAvailable}
+	answer := &entity.Answer{ID: answerID, QuestionID: questionID, UserID: userID, Status: entity.AnswerStatusAvailable}
+	_, err := testDataSource.DB.Context(ctx).Insert(tagRel, answer)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = testDataSource.DB.Context(ctx).ID(tagRel.ID).Delete(&entity.TagRel{})
+		_, _ = testDataSource.DB.Context(ctx).ID(answerID).Delete(&entity.Answer{})
+	})
+
+	votedUp, err := configService.GetConfigByKey(ctx, "answer.voted_up")
+	require.NoError(t, err)
+	accepted, err := configService.GetConfigByKey(ctx, "answer.accepted")
+	require.NoError(t, err)
+	activities := []*entity.Activity{
+		{UserID: userID, ObjectID: answerID, ActivityType: votedUp.ID, Rank: 10, HasRank: 1},
+		{UserID: userID, ObjectID: answerID, ActivityType: accepted.ID, Rank: 15, HasRank: 1},
+	}
+	_, err = testDataSource.DB.Context(ctx).Insert(activities)
+	require.NoError(t, err)
+
+	session := testDataSource.DB.NewSession().Context(ctx)
+	for _, act := range activities {
+		require.NoError(t, userRankRepo.ChangeUserTagRank(ctx, session, act.UserID, act.ObjectID, act.ActivityType, act.Rank))
+	}
+	require.NoError(t, session.Close())
+
+	tagRanks, total, err := userRankRepo.GetUserTagRankPage(ctx, userID, nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, tagRanks, 1)
+	// the accepted answer does not change the reputation in the tags
+	assert.Equal(t, tagID, tagRanks[0].TagID)
+	assert.Equal(t, 10, tagRanks[0].Rank)
+
+	// the tag of the question is replaced by the other tag
+	require.NoError(t, userTagRankRepo.ChangeQuestionTagRank(ctx, questionID, []string{tagID}, false))
+	require.NoError(t, userTagRankRepo.ChangeQuestionTagRank(ctx, questionID, []string{otherTagID}, true))
+	tagRanks, total, err = userRankRepo.GetUserTagRankPage(ctx, userID, nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, tagRanks, 1)
+	assert.Equal(t, otherTagID, tagRanks[0].TagID)
+	assert.Equal(t, 10, tagRanks[0].Rank)
+
+	tagRanks, total, err = userRankRepo.GetUserTagRankPage(ctx, userID, []string{tagID}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, tagRanks)
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/repo/user_two_factor"
+	"github.com/apache/answer/internal/schema"
+	usertwofactor "github.com/apache/answer/internal/service/user_two_factor"
+	"github.com/apache/answer/pkg/totp"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_userTwoFactorService_VerifyLoginChallengeConcurrently(t *testing.T) {
+	var (
+		userTwoFactorRepo    = user_two_factor.NewUserTwoFactorRepo(testDataSource)
+		userTwoFactorService = usertwofactor.NewUserTwoFactorService(userTwoFactorRepo, user.NewUserRepo(testDataSource), nil)
+	)
+	ctx := context.TODO()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	info := &entity.UserTwoFactor{UserID: "1", Secret: secret}
+	require.NoError(t, userTwoFactorRepo.SaveUserTwoFactor(ctx, info))
+	require.NoError(t, userTwoFactorRepo.EnableUserTwoFactor(ctx, info.ID, 0))
+	t.Cleanup(func() {
+		require.NoError(t, userTwoFactorRepo.DeleteUserTwoFactor(ctx, "1"))
+	})
+
+	challengeToken, err := userTwoFactorService.CreateLoginChallenge(ctx, "1", false)
+	require.NoError(t, err)
+
+	// the wrong codes sent at once can not try more codes than allowed
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	reasons := make(map[string]int)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := userTwoFactorService.VerifyLoginChallenge(ctx, &schema.UserTwoFactorLoginReq{
+				Token: challengeToken, Code: "wrong-code",
+			})
+			var myErr *errors.Error
+			if assert.ErrorAs(t, err, &myErr) {
+				lock.Lock()
+				reasons[myErr.Reason]++
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 4, reasons[reason.UserTwoFactorCodeInvalid])
+	assert.Equal(t, 16, reasons[reason.UserTwoFactorChallengeExpired])
+
+	// the challenge is invalidated, the right code does not finish the login
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+	_, err = userTwoFactorService.VerifyLoginChallenge(ctx, &schema.UserTwoFactorLoginReq{
+		Token: challengeToken, Code: code,
+	})
+	var myErr *errors.Error
+	if assert.ErrorAs(t, err, &myErr) {
+		assert.Equal(t, reason.UserTwoFactorChallengeExpired, myErr.Reason)
+	}
+
+	// the right code of a new challenge finishes the login
+	challengeToken, err = userTwoFactorService.CreateLoginChallenge(ctx, "1", true)
+	require.NoError(t, err)
+	challenge, err := userTwoFactorService.VerifyLoginChallenge(ctx, &schema.UserTwoFactorLoginReq{
+		Token: challengeToken, Code: code,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", challenge.UserID)
+	assert.True(t, challenge.RememberMe)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // reportRepo report repository
@@ -100,3 +101,65 @@ func (rr *reportRepo) GetReportCount(ctx context.Context) (count int64, err erro
 	}
 	return
 }
+
+// GetPendingReportObjectPage get the objects that have pending reports, the objects with more reports come first
+func (rr *reportRepo) GetPendingReportObjectPage(ctx context.Context, dto *schema.ModerationQueueDTO) (
+	stats []*entity.ReportObjectStat, total int64, err error) {
+	cond := builder.NewCond().And(builder.Eq{"status": entity.ReportStatusPending})
+	if dto.ObjectType > 0 {
+		cond = cond.And(builder.Eq{"object_type": dto.ObjectType})
+	}
+	if dto.ReportType > 0 {
+		cond = cond.And(builder.Eq{"report_type": dto.ReportType})
+	}
+	if !dto.FlaggedAfter.IsZero() {
+		cond = cond.And(builder.Gte{"created_at": dto.FlaggedAfter})
+	}
+	if !dto.FlaggedBefore.IsZero() {
+		cond = cond.And(builder.Lte{"created_at": dto.FlaggedBefore})
+	}
+
+	total, err = rr.data.DB.Context(ctx).Table("report").Where(cond).GroupBy("object_id, object_type").Count()
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	page, pageSize := pager.ValPageAndPageSize(dto.Page, dto.PageSize)
+	stats = make([]*entity.ReportObjectStat, 0)
+	err = rr.data.DB.Context(ctx).Table("report").
+		Select("object_id, object_type, COUNT(*) AS flag_count, MIN(id) AS first_id").
+		Where(cond).GroupBy("object_id, object_type").
+		OrderBy("flag_count DESC, first_id ASC").
+		Limit(pageSize, (page-1)*pageSize).Find(&stats)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return stats, total, nil
+}
+
+// GetPendingReportsByObjectIDs get the pending reports of the objects
+func (rr *reportRepo) GetPendingReportsByObjectIDs(ctx context.Context, objectIDs []string) (
+	reports []*entity.Report, err error) {
+	reports = make([]*entity.Report, 0)
+	if len(objectIDs) == 0 {
+		return reports, nil
+	}
+	err = rr.data.DB.Context(ctx).In("object_id", objectIDs).
+		Where("status = ?", entity.ReportStatusPending).Asc("id").Find(&reports)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ResolvePendingReports change the status of all the pending reports of the object in one statement,
+// returns the number of the resolved reports
+func (rr *reportRepo) ResolvePendingReports(ctx context.Context, objectID string, status int) (
+	affected int64, err error) {
+	affected, err = rr.data.DB.Context(ctx).Where("object_id = ? AND status = ?", objectID, entity.ReportStatusPending).
+		Cols("status").Update(&entity.Report{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
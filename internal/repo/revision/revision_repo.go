@@ -66,6 +66,11 @@ func (rr *revisionRepo) AddRevision(ctx context.Context, revision *entity.Revisi
 	}
 	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (any, error) {
 		session = session.Context(ctx)
+		if revision.Status == entity.RevisionUnreviewedStatus {
+			if err := rr.checkCanSuggestRevision(session, revision); err != nil {
+				return nil, err
+			}
+		}
 		_, err = session.Insert(revision)
 		if err != nil {
 			_ = session.Rollback()
@@ -84,8 +89,43 @@ func (rr *revisionRepo) AddRevision(ctx context.Context, revision *entity.Revisi
 	return err
 }
 
-// UpdateObjectRevisionId updates the object.revision_id field
+// checkCanSuggestRevision check the suggested edit can wait for review. The object is locked, so there is only one
+// suggested edit of the object in the queue, and the user can not have too many suggested edits in the queue.
+func (rr *revisionRepo) checkCanSuggestRevision(session *xorm.Session, revision *entity.Revision) (err error) {
+	tableName, err := obj.GetObjectTypeStrByObjectID(revision.ObjectID)
+	if err != nil {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	_, err = session.Table(tableName).Where("id = ?", revision.ObjectID).Cols("id").ForUpdate().
+		Get(&struct {
+			ID string `xorm:"id"`
+		}{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	exist, err := session.Exist(&entity.Revision{ObjectID: revision.ObjectID, Status: entity.RevisionUnreviewedStatus})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return errors.BadRequest(reason.RevisionReviewUnderway)
+	}
+	pending, err := session.Count(&entity.Revision{UserID: revision.UserID, Status: entity.RevisionUnreviewedStatus})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if pending >= constant.RevisionMaxPendingPerUser {
+		return errors.BadRequest(reason.RevisionPendingLimitReached)
+	}
+	return nil
+}
+
+// UpdateObjectRevisionId updates the object.revision_id field, a new session is used if the session is nil
 func (rr *revisionRepo) UpdateObjectRevisionId(ctx context.Context, revision *entity.Revision, session *xorm.Session) (err error) {
+	if session == nil {
+		session = rr.data.DB.NewSession().Context(ctx)
+		defer session.Close()
+	}
 	tableName, err := obj.GetObjectTypeStrByObjectID(revision.ObjectID)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -117,6 +157,22 @@ func (rr *revisionRepo) UpdateStatus(ctx context.Context, id string, status int,
 	return nil
 }
 
+// ReviewRevision update the status of the unreviewed revision, reviewed is false if it has been reviewed by others
+func (rr *revisionRepo) ReviewRevision(ctx context.Context, id string, status int, reviewUserID, rejectReason string) (
+	reviewed bool, err error) {
+	affected, err := rr.data.DB.Context(ctx).Where("id = ?", id).And("status = ?", entity.RevisionUnreviewedStatus).
+		Cols("status", "review_user_id", "reject_reason").
+		Update(&entity.Revision{
+			Status:       status,
+			ReviewUserID: converter.StringToInt64(reviewUserID),
+			RejectReason: rejectReason,
+		})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
 // GetRevision get revision one
 func (rr *revisionRepo) GetRevision(ctx context.Context, id string) (
 	revision *entity.Revision, exist bool, err error,
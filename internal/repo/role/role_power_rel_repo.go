@@ -21,12 +21,18 @@ package role
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // rolePowerRelRepo rolePowerRel repository
@@ -41,13 +47,63 @@ func NewRolePowerRelRepo(data *data.Data) role.RolePowerRelRepo {
 	}
 }
 
-// GetRolePowerTypeList get role power type list
+// GetRolePowerTypeList get role power type list, the list is cached because it is checked in every request
 func (rr *rolePowerRelRepo) GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error) {
+	cacheKey := constant.RolePowerCacheKey + strconv.Itoa(roleID)
+	cached, exist, err := rr.data.Cache.GetString(ctx, cacheKey)
+	if err == nil && exist {
+		powers = make([]string, 0)
+		if err = json.Unmarshal([]byte(cached), &powers); err == nil {
+			return powers, nil
+		}
+	}
+
 	powers = make([]string, 0)
 	err = rr.data.DB.Context(ctx).Table("role_power_rel").
 		Cols("power_type").Where(builder.Eq{"role_id": roleID}).Find(&powers)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	value, _ := json.Marshal(powers)
+	if err = rr.data.Cache.SetString(ctx, cacheKey, string(value), constant.RolePowerCacheTime); err != nil {
+		log.Errorf("set role power cache failed: %v", err)
+	}
+	return powers, nil
+}
+
+// GetRoleIDsByPowerTypes get the ids of the roles that have any of the powers
+func (rr *rolePowerRelRepo) GetRoleIDsByPowerTypes(ctx context.Context, powerTypes []string) (roleIDs []int, err error) {
+	roleIDs = make([]int, 0)
+	err = rr.data.DB.Context(ctx).Table("role_power_rel").
+		In("power_type", powerTypes).Distinct("role_id").Find(&roleIDs)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
+
+// SaveRolePowers replace the powers of the role
+func (rr *rolePowerRelRepo) SaveRolePowers(ctx context.Context, roleID int, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("role_id = ?", roleID).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		if len(powerTypes) == 0 {
+			return nil, nil
+		}
+		rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+		for _, powerType := range powerTypes {
+			rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: powerType})
+		}
+		_, err = session.Insert(rels)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = rr.data.Cache.Del(ctx, constant.RolePowerCacheKey+strconv.Itoa(roleID)); err != nil {
+		log.Errorf("delete role power cache failed: %v", err)
+	}
+	return nil
+}
@@ -21,12 +21,16 @@ package role
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	service "github.com/apache/answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
 )
 
 // roleRepo role repository
@@ -63,3 +67,49 @@ func (rr *roleRepo) GetRoleAllMapping(ctx context.Context) (roleMapping map[int]
 	}
 	return roleMapping, nil
 }
+
+// GetRole get the role by id
+func (rr *roleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddRole add the role, the id of the new role is set to the role
+func (rr *roleRepo) AddRole(ctx context.Context, role *entity.Role) (err error) {
+	if _, err = rr.data.DB.Context(ctx).Insert(role); err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update the name and the description of the role
+func (rr *roleRepo) UpdateRole(ctx context.Context, role *entity.Role) (err error) {
+	_, err = rr.data.DB.Context(ctx).ID(role.ID).Cols("name", "description").Update(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole delete the role and its powers
+func (rr *roleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("role_id = ?", roleID).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(roleID).Delete(&entity.Role{})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if err = rr.data.Cache.Del(ctx, constant.RolePowerCacheKey+strconv.Itoa(roleID)); err != nil {
+		log.Errorf("delete role power cache failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/saved_search"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+type savedSearchRepo struct {
+	data *data.Data
+}
+
+// NewSavedSearchRepo new repository
+func NewSavedSearchRepo(data *data.Data) saved_search.SavedSearchRepo {
+	return &savedSearchRepo{
+		data: data,
+	}
+}
+
+// AddSavedSearch add the saved search
+func (sr *savedSearchRepo) AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error) {
+	if _, err = sr.data.DB.Context(ctx).Insert(savedSearch); err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSavedSearch update the columns of the saved search
+func (sr *savedSearchRepo) UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch,
+	cols ...string) (err error) {
+	_, err = sr.data.DB.Context(ctx).ID(savedSearch.ID).Cols(cols...).Update(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClaimSavedSearchAlert update the check time of the alert only if it is not checked by another call since it is got
+func (sr *savedSearchRepo) ClaimSavedSearchAlert(ctx context.Context, savedSearch *entity.SavedSearch,
+	checkedAt time.Time) (claimed bool, err error) {
+	affected, err := sr.data.DB.Context(ctx).ID(savedSearch.ID).
+		And("last_checked_at = ?", savedSearch.LastCheckedAt.Format(time.DateTime)).
+		Cols("last_checked_at").Update(&entity.SavedSearch{LastCheckedAt: checkedAt})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected == 1, nil
+}
+
+// RemoveSavedSearch remove the saved search of the user
+func (sr *savedSearchRepo) RemoveSavedSearch(ctx context.Context, userID, id string) (err error) {
+	_, err = sr.data.DB.Context(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.SavedSearch{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSavedSearch get the saved search of the user
+func (sr *savedSearchRepo) GetSavedSearch(ctx context.Context, userID, id string) (
+	savedSearch *entity.SavedSearch, exist bool, err error) {
+	savedSearch = &entity.SavedSearch{}
+	exist, err = sr.data.DB.Context(ctx).Where("id = ? AND user_id = ?", id, userID).Get(savedSearch)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSavedSearchPage get the saved searches of the user, the latest saved ones come first
+func (sr *savedSearchRepo) GetSavedSearchPage(ctx context.Context, userID string, page, pageSize int) (
+	savedSearches []*entity.SavedSearch, total int64, err error) {
+	savedSearches = make([]*entity.SavedSearch, 0)
+	session := sr.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id")
+	total, err = pager.Help(page, pageSize, &savedSearches, &entity.SavedSearch{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountSavedSearch count the saved searches of the user and the ones of them that alert
+func (sr *savedSearchRepo) CountSavedSearch(ctx context.Context, userID string) (total, alerts int64, err error) {
+	total, err = sr.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.SavedSearch{})
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	alerts, err = sr.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID, "alert_enabled": true}).
+		Count(&entity.SavedSearch{})
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return total, alerts, nil
+}
+
+// GetAlertSavedSearches get the alerts that are not checked since checkedBefore, the earliest checked come first
+func (sr *savedSearchRepo) GetAlertSavedSearches(ctx context.Context, checkedBefore time.Time, limit int) (
+	savedSearches []*entity.SavedSearch, err error) {
+	savedSearches = make([]*entity.SavedSearch, 0)
+	// the time is compared in seconds as it is stored, the alerts checked in the same second are not got again
+	err = sr.data.DB.Context(ctx).Where(builder.Eq{"alert_enabled": true}).
+		And("last_checked_at < ?", checkedBefore.Format(time.DateTime)).Asc("last_checked_at", "id").Limit(limit).Find(&savedSearches)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -99,8 +99,8 @@ func NewSearchRepo(
 }
 
 // SearchContents search question and answer data
-func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes int, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchContents(ctx context.Context, cond *schema.SearchCondition, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 
 	var (
 		b     *builder.Builder
@@ -149,7 +149,7 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	ub.Where(likeConA)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
 		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
 			And(builder.Eq{
@@ -170,24 +170,29 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	}
 
 	// check user
-	if userID != "" {
-		b.Where(builder.Eq{"question.user_id": userID})
-		ub.Where(builder.Eq{"answer.user_id": userID})
-		argsQ = append(argsQ, userID)
-		argsA = append(argsA, userID)
+	if cond.UserID != "" {
+		b.Where(builder.Eq{"question.user_id": cond.UserID})
+		ub.Where(builder.Eq{"answer.user_id": cond.UserID})
+		argsQ = append(argsQ, cond.UserID)
+		argsA = append(argsA, cond.UserID)
 	}
 
 	// check vote
-	if votes == 0 {
-		b.Where(builder.Eq{"question.vote_count": votes})
-		ub.Where(builder.Eq{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-	} else if votes > 0 {
-		b.Where(builder.Gte{"question.vote_count": votes})
-		ub.Where(builder.Gte{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
+	if voteCond := searchVoteCond("question.vote_count", cond); voteCond != nil {
+		b.Where(voteCond)
+		ub.Where(searchVoteCond("answer.vote_count", cond))
+		argsQ = append(argsQ, cond.VoteAmount)
+		argsA = append(argsA, cond.VoteAmount)
+	}
+
+	// check created time
+	for _, createdCond := range searchCreatedConds("question.created_at", cond) {
+		b.Where(createdCond.cond)
+		argsQ = append(argsQ, createdCond.arg)
+	}
+	for _, createdCond := range searchCreatedConds("answer.created_at", cond) {
+		ub.Where(createdCond.cond)
+		argsA = append(argsA, createdCond.arg)
 	}
 
 	// b = b.Union("all", ub)
@@ -223,12 +228,12 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	countArgs = append(countArgs, argsQ...)
 	countArgs = append(countArgs, argsA...)
 
-	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
+	res, err := sr.data.ReadDB(ctx).Query(queryArgs...)
 	if err != nil {
 		return
 	}
 
-	tr, err := sr.data.DB.Context(ctx).Query(countArgs...)
+	tr, err := sr.data.ReadDB(ctx).Query(countArgs...)
 	if len(tr) != 0 {
 		total = converter.StringToInt64(string(tr[0]["total"]))
 	}
@@ -242,8 +247,8 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 }
 
 // SearchQuestions search question data
-func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchQuestions(ctx context.Context, cond *schema.SearchCondition, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 	var (
 		qfs  = qFields
 		args = []any{}
@@ -271,7 +276,7 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 	b.Where(likeConQ)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
 		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
 			And(builder.Eq{
@@ -284,33 +289,43 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 		}
 	}
 
+	// check user
+	if cond.UserID != "" {
+		b.And(builder.Eq{"question.user_id": cond.UserID})
+		args = append(args, cond.UserID)
+	}
+
+	// check vote
+	if voteCond := searchVoteCond("question.vote_count", cond); voteCond != nil {
+		b.And(voteCond)
+		args = append(args, cond.VoteAmount)
+	}
+
+	// check created time
+	for _, createdCond := range searchCreatedConds("question.created_at", cond) {
+		b.And(createdCond.cond)
+		args = append(args, createdCond.arg)
+	}
+
 	// check need filter has not accepted
-	if notAccepted {
+	if cond.NotAccepted {
 		b.And(builder.Eq{"accepted_answer_id": 0})
 		args = append(args, 0)
 	}
 
 	// check views
-	if views > -1 {
-		b.And(builder.Gte{"view_count": views})
-		args = append(args, views)
+	if cond.Views > -1 {
+		b.And(builder.Gte{"view_count": cond.Views})
+		args = append(args, cond.Views)
 	}
 
 	// check answers
-	if answers == 0 {
-		b.And(builder.Eq{"answer_count": answers})
-		args = append(args, answers)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
-	}
-
-	if answers == 0 {
+	if cond.AnswerAmount == 0 {
 		b.And(builder.Eq{"answer_count": 0})
 		args = append(args, 0)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+	} else if cond.AnswerAmount > 0 {
+		b.And(builder.Gte{"answer_count": cond.AnswerAmount})
+		args = append(args, cond.AnswerAmount)
 	}
 
 	queryArgs := []any{}
@@ -332,12 +347,12 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 	countArgs = append(countArgs, countSQL)
 	countArgs = append(countArgs, args...)
 
-	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
+	res, err := sr.data.ReadDB(ctx).Query(queryArgs...)
 	if err != nil {
 		return
 	}
 
-	tr, err := sr.data.DB.Context(ctx).Query(countArgs...)
+	tr, err := sr.data.ReadDB(ctx).Query(countArgs...)
 	if err != nil {
 		return
 	}
@@ -353,8 +368,8 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 }
 
 // SearchAnswers search answer data
-func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchAnswers(ctx context.Context, cond *schema.SearchCondition, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 
 	var (
 		afs  = aFields
@@ -384,7 +399,7 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	b.Where(likeConA)
 
 	// check tag
-	for ti, tagID := range tagIDs {
+	for ti, tagID := range cond.Tags {
 		ast := "tag_rel" + strconv.Itoa(ti)
 		b.Join("INNER", "tag_rel as "+ast, "question_id = "+ast+".object_id").
 			And(builder.Eq{
@@ -397,16 +412,34 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 		}
 	}
 
+	// check user
+	if cond.UserID != "" {
+		b.Where(builder.Eq{"answer.user_id": cond.UserID})
+		args = append(args, cond.UserID)
+	}
+
+	// check vote
+	if voteCond := searchVoteCond("answer.vote_count", cond); voteCond != nil {
+		b.Where(voteCond)
+		args = append(args, cond.VoteAmount)
+	}
+
+	// check created time
+	for _, createdCond := range searchCreatedConds("answer.created_at", cond) {
+		b.Where(createdCond.cond)
+		args = append(args, createdCond.arg)
+	}
+
 	// check limit accepted
-	if accepted {
+	if cond.Accepted {
 		b.Where(builder.Eq{"adopted": schema.AnswerAcceptedEnable})
 		args = append(args, schema.AnswerAcceptedEnable)
 	}
 
 	// check question id
-	if questionID != "" {
-		b.Where(builder.Eq{"question_id": questionID})
-		args = append(args, questionID)
+	if cond.QuestionID != "" {
+		b.Where(builder.Eq{"question_id": cond.QuestionID})
+		args = append(args, cond.QuestionID)
 	}
 
 	queryArgs := []any{}
@@ -429,12 +462,12 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	countArgs = append(countArgs, countSQL)
 	countArgs = append(countArgs, args...)
 
-	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
+	res, err := sr.data.ReadDB(ctx).Query(queryArgs...)
 	if err != nil {
 		return
 	}
 
-	tr, err := sr.data.DB.Context(ctx).Query(countArgs...)
+	tr, err := sr.data.ReadDB(ctx).Query(countArgs...)
 	if err != nil {
 		return
 	}
@@ -447,6 +480,42 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	return
 }
 
+// searchVoteCond the condition of the votes, it is nil if the votes are not limited
+func searchVoteCond(column string, cond *schema.SearchCondition) builder.Cond {
+	switch cond.VoteOperator {
+	case ">":
+		return builder.Gt{column: cond.VoteAmount}
+	case ">=":
+		return builder.Gte{column: cond.VoteAmount}
+	case "=":
+		return builder.Eq{column: cond.VoteAmount}
+	}
+	if cond.VoteAmount == 0 {
+		return builder.Eq{column: cond.VoteAmount}
+	} else if cond.VoteAmount > 0 {
+		return builder.Gte{column: cond.VoteAmount}
+	}
+	return nil
+}
+
+type searchCreatedCond struct {
+	cond builder.Cond
+	arg  any
+}
+
+// searchCreatedConds the conditions of the created time range, the time is compared in the stored format
+func searchCreatedConds(column string, cond *schema.SearchCondition) (conds []*searchCreatedCond) {
+	if cond.CreatedAfter > 0 {
+		after := time.Unix(cond.CreatedAfter, 0).Format(time.DateTime)
+		conds = append(conds, &searchCreatedCond{cond: builder.Gte{column: after}, arg: after})
+	}
+	if cond.CreatedBefore > 0 {
+		before := time.Unix(cond.CreatedBefore, 0).Format(time.DateTime)
+		conds = append(conds, &searchCreatedCond{cond: builder.Lt{column: before}, arg: before})
+	}
+	return conds
+}
+
 func (sr *searchRepo) parseOrder(_ context.Context, order string) (res string) {
 	switch order {
 	case "newest":
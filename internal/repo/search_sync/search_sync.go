@@ -21,6 +21,7 @@ package search_sync
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
@@ -31,7 +32,7 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
-func NewPluginSyncer(data *data.Data) plugin.SearchSyncer {
+func NewPluginSyncer(data *data.Data) *PluginSyncer {
 	return &PluginSyncer{data: data}
 }
 
@@ -61,6 +62,40 @@ func (p *PluginSyncer) GetQuestionsPage(ctx context.Context, page, pageSize int)
 	return p.convertQuestions(ctx, questions)
 }
 
+// GetQuestionsAfterID get the questions whose id is greater than the cursor in id order,
+// only the questions updated since the time are returned if it is not zero.
+func (p *PluginSyncer) GetQuestionsAfterID(ctx context.Context, cursor string, since time.Time, limit int) (
+	questionList []*plugin.SearchContent, nextCursor string, err error) {
+	questions := make([]*entity.Question, 0)
+	session := p.data.DB.Context(ctx).Where("id > ?", cursor)
+	if !since.IsZero() {
+		session.Where("updated_at >= ?", since)
+	}
+	err = session.Asc("id").Limit(limit).Find(&questions)
+	if err != nil || len(questions) == 0 {
+		return nil, cursor, err
+	}
+	questionList, err = p.convertQuestions(ctx, questions)
+	return questionList, questions[len(questions)-1].ID, err
+}
+
+// GetAnswersAfterID get the answers whose id is greater than the cursor in id order,
+// only the answers updated since the time are returned if it is not zero.
+func (p *PluginSyncer) GetAnswersAfterID(ctx context.Context, cursor string, since time.Time, limit int) (
+	answerList []*plugin.SearchContent, nextCursor string, err error) {
+	answers := make([]*entity.Answer, 0)
+	session := p.data.DB.Context(ctx).Where("id > ?", cursor)
+	if !since.IsZero() {
+		session.Where("updated_at >= ?", since)
+	}
+	err = session.Asc("id").Limit(limit).Find(&answers)
+	if err != nil || len(answers) == 0 {
+		return nil, cursor, err
+	}
+	answerList, err = p.convertAnswers(ctx, answers)
+	return answerList, answers[len(answers)-1].ID, err
+}
+
 func (p *PluginSyncer) convertAnswers(ctx context.Context, answers []*entity.Answer) (
 	answerList []*plugin.SearchContent, err error) {
 	for _, answer := range answers {
@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package sitemap
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/sitemap"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+type sitemapRepo struct {
+	data *data.Data
+}
+
+// NewSitemapRepo new repository
+func NewSitemapRepo(data *data.Data) sitemap.SitemapRepo {
+	return &sitemapRepo{
+		data: data,
+	}
+}
+
+// GetQuestionPage get the questions that can be indexed, the closed, hidden and unpublished ones are excluded
+func (sr *sitemapRepo) GetQuestionPage(ctx context.Context, page, pageSize int) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = sr.data.DB.Context(ctx).Select("id, title, created_at, post_update_time").
+		Where(builder.Eq{"status": entity.QuestionStatusAvailable, "`show`": entity.QuestionShow}).
+		Asc("id").Limit(pageSize, (page-1)*pageSize).Find(&questions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return questions, nil
+}
+
+// GetTagPage get the main tags that have questions, the synonyms are redirected to the main tags
+func (sr *sitemapRepo) GetTagPage(ctx context.Context, page, pageSize int) (tags []*entity.Tag, err error) {
+	tags = make([]*entity.Tag, 0)
+	err = sr.data.DB.Context(ctx).Select("id, slug_name, updated_at").
+		Where(builder.Eq{"status": entity.TagStatusAvailable, "main_tag_id": 0}).And(builder.Gt{"question_count": 0}).
+		Asc("id").Limit(pageSize, (page-1)*pageSize).Find(&tags)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return tags, nil
+}
+
+// GetUserPage get the available users who have posted, the guest placeholder is excluded
+func (sr *sitemapRepo) GetUserPage(ctx context.Context, page, pageSize int) (users []*entity.User, err error) {
+	users = make([]*entity.User, 0)
+	err = sr.data.DB.Context(ctx).Select("id, username, updated_at").
+		Where(builder.Eq{"status": entity.UserStatusAvailable}).
+		And(builder.Neq{"username": constant.GuestUserPlaceholderUsername}).
+		And(builder.Expr("question_count + answer_count > 0")).
+		Asc("id").Limit(pageSize, (page-1)*pageSize).Find(&users)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return users, nil
+}
+
+// GetSitemapIndex get the index of the generated sitemap files
+func (sr *sitemapRepo) GetSitemapIndex(ctx context.Context) (index *schema.SitemapIndex, exist bool, err error) {
+	content, exist, err := sr.data.Cache.GetString(ctx, constant.SitemapIndexCacheKey)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	index = &schema.SitemapIndex{}
+	if err = json.Unmarshal([]byte(content), index); err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return index, true, nil
+}
+
+// SetSitemapIndex save the index of the generated sitemap files
+func (sr *sitemapRepo) SetSitemapIndex(ctx context.Context, index *schema.SitemapIndex) (err error) {
+	content, _ := json.Marshal(index)
+	err = sr.data.Cache.SetString(ctx, constant.SitemapIndexCacheKey, string(content), constant.SitemapCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetSitemapFile get the urls of the sitemap file
+func (sr *sitemapRepo) GetSitemapFile(ctx context.Context, name string) (urls []*schema.SitemapURL, exist bool, err error) {
+	content, exist, err := sr.data.Cache.GetString(ctx, constant.SitemapFileCacheKey+name)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	urls = make([]*schema.SitemapURL, 0)
+	if err = json.Unmarshal([]byte(content), &urls); err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return urls, true, nil
+}
+
+// SetSitemapFile save the urls of the sitemap file
+func (sr *sitemapRepo) SetSitemapFile(ctx context.Context, name string, urls []*schema.SitemapURL) (err error) {
+	content, _ := json.Marshal(urls)
+	err = sr.data.Cache.SetString(ctx, constant.SitemapFileCacheKey+name, string(content), constant.SitemapCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveSitemapFile remove the sitemap file that is not generated anymore
+func (sr *sitemapRepo) RemoveSitemapFile(ctx context.Context, name string) (err error) {
+	if err = sr.data.Cache.Del(ctx, constant.SitemapFileCacheKey+name); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
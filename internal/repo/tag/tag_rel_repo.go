@@ -21,7 +21,9 @@ package tag
 
 import (
 	"context"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
@@ -30,6 +32,8 @@ import (
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
 	"xorm.io/xorm"
 )
 
@@ -57,6 +61,9 @@ func (tr *tagRelRepo) AddTagRelList(ctx context.Context, tagList []*entity.TagRe
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	for _, item := range tagList {
+		tr.removeQuestionPayloadCache(ctx, item.ObjectID)
+	}
 	if handler.GetEnableShortID(ctx) {
 		for _, item := range tagList {
 			item.ObjectID = uid.EnShortID(item.ObjectID)
@@ -72,6 +79,7 @@ func (tr *tagRelRepo) RemoveTagRelListByObjectID(ctx context.Context, objectID s
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	tr.removeQuestionPayloadCache(ctx, objectID)
 	return
 }
 
@@ -82,6 +90,7 @@ func (tr *tagRelRepo) RecoverTagRelListByObjectID(ctx context.Context, objectID
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	tr.removeQuestionPayloadCache(ctx, objectID)
 	return
 }
 
@@ -91,6 +100,7 @@ func (tr *tagRelRepo) HideTagRelListByObjectID(ctx context.Context, objectID str
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	tr.removeQuestionPayloadCache(ctx, objectID)
 	return
 }
 
@@ -100,11 +110,13 @@ func (tr *tagRelRepo) ShowTagRelListByObjectID(ctx context.Context, objectID str
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	tr.removeQuestionPayloadCache(ctx, objectID)
 	return
 }
 
 // RemoveTagRelListByIDs delete tag list
 func (tr *tagRelRepo) RemoveTagRelListByIDs(ctx context.Context, ids []int64) (err error) {
+	defer tr.removeQuestionPayloadCacheByIDs(ctx, ids)
 	_, err = tr.data.DB.Context(ctx).In("id", ids).Update(&entity.TagRel{Status: entity.TagRelStatusDeleted})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -112,6 +124,30 @@ func (tr *tagRelRepo) RemoveTagRelListByIDs(ctx context.Context, ids []int64) (e
 	return
 }
 
+// removeQuestionPayloadCache remove the cached payloads of the question, they contain the tags of it
+func (tr *tagRelRepo) removeQuestionPayloadCache(ctx context.Context, objectID string) {
+	objectID = uid.DeShortID(objectID)
+	if err := tr.data.Cache.Del(ctx, constant.QuestionPayloadVersionCacheKey+objectID); err != nil {
+		log.Errorf("remove question payload cache failed, question id: %s, err: %v", objectID, err)
+	}
+}
+
+func (tr *tagRelRepo) removeQuestionPayloadCacheByIDs(ctx context.Context, ids []int64) {
+	if len(ids) == 0 {
+		return
+	}
+	objectIDs := make([]string, 0, len(ids))
+	err := tr.data.DB.Context(ctx).Table(new(entity.TagRel).TableName()).In("id", ids).
+		Distinct("object_id").Find(&objectIDs)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for _, objectID := range objectIDs {
+		tr.removeQuestionPayloadCache(ctx, objectID)
+	}
+}
+
 // GetObjectTagRelWithoutStatus get object tag relation no matter status
 func (tr *tagRelRepo) GetObjectTagRelWithoutStatus(ctx context.Context, objectID, tagID string) (
 	tagRel *entity.TagRel, exist bool, err error,
@@ -136,6 +172,7 @@ func (tr *tagRelRepo) EnableTagRelByIDs(ctx context.Context, ids []int64, hide b
 	if hide {
 		status = entity.TagRelStatusHide
 	}
+	defer tr.removeQuestionPayloadCacheByIDs(ctx, ids)
 	_, err = tr.data.DB.Context(ctx).In("id", ids).Update(&entity.TagRel{Status: status})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
@@ -206,6 +243,39 @@ func (tr *tagRelRepo) GetTagRelDefaultStatusByObjectID(ctx context.Context, obje
 	return entity.TagRelStatusAvailable, nil
 }
 
+// UpdateTagRelPin pin or unpin the object within the tag, the zero expiry time means the pin never expires
+func (tr *tagRelRepo) UpdateTagRelPin(ctx context.Context, objectID, tagID string, pin int,
+	pinExpiredAt time.Time) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where(builder.Eq{"object_id": uid.DeShortID(objectID), "tag_id": tagID}).
+		Cols("pin", "pin_expired_at").Update(&entity.TagRel{Pin: pin, PinExpiredAt: pinExpiredAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountPinnedTagRel count the available objects pinned within the tag
+func (tr *tagRelRepo) CountPinnedTagRel(ctx context.Context, tagID string) (count int64, err error) {
+	count, err = tr.data.DB.Context(ctx).
+		Where(builder.Eq{"tag_id": tagID, "pin": entity.QuestionPin, "status": entity.TagRelStatusAvailable}).
+		Count(&entity.TagRel{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UnpinExpiredTagRels unpin the objects whose pin within the tag has expired
+func (tr *tagRelRepo) UnpinExpiredTagRels(ctx context.Context, now time.Time) (count int64, err error) {
+	count, err = tr.data.DB.Context(ctx).
+		Where(builder.Eq{"pin": entity.QuestionPin}.And(builder.Lte{"pin_expired_at": now})).
+		Cols("pin", "pin_expired_at").Update(&entity.TagRel{Pin: entity.QuestionUnPin})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // MigrateTagObjects migrate tag objects
 func (tr *tagRelRepo) MigrateTagObjects(ctx context.Context, sourceTagId, targetTagId string) error {
 	_, err := tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
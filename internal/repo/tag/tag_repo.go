@@ -21,6 +21,7 @@ package tag
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
@@ -30,6 +31,7 @@ import (
 	"github.com/apache/answer/pkg/converter"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // tagRepo tag repository
@@ -138,3 +140,156 @@ func (tr *tagRepo) GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*
 	}
 	return
 }
+
+// MergeTag move the questions, followers and synonyms of the source tag to the target tag and delete the source tag
+func (tr *tagRepo) MergeTag(ctx context.Context, sourceTag, targetTag *entity.Tag, followActivityType int) (err error) {
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		// 1. the synonyms of the source tag become the synonyms of the target tag
+		_, err = session.Where(builder.Eq{"main_tag_id": converter.StringToInt64(sourceTag.ID)}).
+			MustCols("main_tag_id", "main_tag_slug_name").
+			Update(&entity.Tag{MainTagID: converter.StringToInt64(targetTag.ID), MainTagSlugName: targetTag.SlugName})
+		if err != nil {
+			return nil, err
+		}
+
+		// 2. move the questions, skip the question which already has the target tag
+		if err = tr.migrateTagRel(session, sourceTag.ID, targetTag.ID); err != nil {
+			return nil, err
+		}
+
+		// 3. move the followers, the user who follows both tags only follows the target tag once
+		if err = tr.migrateTagFollowers(session, sourceTag.ID, targetTag.ID, followActivityType); err != nil {
+			return nil, err
+		}
+
+		// 4. refresh the counts of the target tag and update its description
+		questionCount, err := session.Count(&entity.TagRel{TagID: targetTag.ID, Status: entity.TagRelStatusAvailable})
+		if err != nil {
+			return nil, err
+		}
+		followCount, err := session.Where(builder.Eq{
+			"object_id":     targetTag.ID,
+			"activity_type": followActivityType,
+			"cancelled":     entity.ActivityAvailable,
+		}).Count(&entity.Activity{})
+		if err != nil {
+			return nil, err
+		}
+		targetTag.QuestionCount = int(questionCount)
+		targetTag.FollowCount = int(followCount)
+		_, err = session.ID(targetTag.ID).
+			Cols("question_count", "follow_count", "original_text", "parsed_text").
+			Update(targetTag)
+		if err != nil {
+			return nil, err
+		}
+
+		// 5. delete the source tag
+		_, err = session.ID(sourceTag.ID).Cols("status", "question_count", "follow_count").
+			Update(&entity.Tag{Status: entity.TagStatusDeleted})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (tr *tagRepo) migrateTagRel(session *xorm.Session, sourceTagID, targetTagID string) (err error) {
+	sourceRelList := make([]*entity.TagRel, 0)
+	if err = session.Where(builder.Eq{"tag_id": sourceTagID}).Find(&sourceRelList); err != nil {
+		return err
+	}
+	existObjectIDs := make([]string, 0)
+	err = session.Table(entity.TagRel{}.TableName()).Where(builder.Eq{"tag_id": targetTagID}).
+		Cols("object_id").Find(&existObjectIDs)
+	if err != nil {
+		return err
+	}
+	existMapping := make(map[string]bool, len(existObjectIDs))
+	for _, objectID := range existObjectIDs {
+		existMapping[objectID] = true
+	}
+	newRelList := make([]*entity.TagRel, 0, len(sourceRelList))
+	for _, rel := range sourceRelList {
+		if existMapping[rel.ObjectID] {
+			continue
+		}
+		existMapping[rel.ObjectID] = true
+		newRelList = append(newRelList, &entity.TagRel{
+			TagID:    targetTagID,
+			ObjectID: rel.ObjectID,
+			Status:   rel.Status,
+		})
+	}
+	if len(newRelList) > 0 {
+		if _, err = session.Insert(newRelList); err != nil {
+			return err
+		}
+	}
+	_, err = session.Where(builder.Eq{"tag_id": sourceTagID}).Delete(&entity.TagRel{})
+	return err
+}
+
+func (tr *tagRepo) migrateTagFollowers(session *xorm.Session, sourceTagID, targetTagID string,
+	followActivityType int) (err error) {
+	sourceFollowerIDs := make([]string, 0)
+	err = session.Table(entity.Activity{}.TableName()).Where(builder.Eq{
+		"object_id":     sourceTagID,
+		"activity_type": followActivityType,
+		"cancelled":     entity.ActivityAvailable,
+	}).Cols("user_id").Find(&sourceFollowerIDs)
+	if err != nil {
+		return err
+	}
+	_, err = session.Where(builder.Eq{"object_id": sourceTagID, "activity_type": followActivityType}).
+		Delete(&entity.Activity{})
+	if err != nil || len(sourceFollowerIDs) == 0 {
+		return err
+	}
+
+	targetFollowerIDs := make([]string, 0)
+	err = session.Table(entity.Activity{}.TableName()).Where(builder.Eq{
+		"object_id":     targetTagID,
+		"activity_type": followActivityType,
+	}).And(builder.In("user_id", sourceFollowerIDs)).Cols("user_id").Find(&targetFollowerIDs)
+	if err != nil {
+		return err
+	}
+	// the user who cancelled following the target tag follows it again
+	if len(targetFollowerIDs) > 0 {
+		_, err = session.Where(builder.Eq{"object_id": targetTagID, "activity_type": followActivityType}).
+			And(builder.In("user_id", targetFollowerIDs)).
+			Cols("cancelled").
+			Update(&entity.Activity{Cancelled: entity.ActivityAvailable})
+		if err != nil {
+			return err
+		}
+	}
+	existMapping := make(map[string]bool, len(targetFollowerIDs))
+	for _, userID := range targetFollowerIDs {
+		existMapping[userID] = true
+	}
+	now := time.Now()
+	for _, userID := range sourceFollowerIDs {
+		if existMapping[userID] {
+			continue
+		}
+		existMapping[userID] = true
+		_, err = session.Insert(&entity.Activity{
+			UserID:           userID,
+			ObjectID:         targetTagID,
+			OriginalObjectID: targetTagID,
+			ActivityType:     followActivityType,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+			Cancelled:        entity.ActivityAvailable,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/tag_moderator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type tagModeratorRepo struct {
+	data *data.Data
+}
+
+// NewTagModeratorRepo new repository
+func NewTagModeratorRepo(data *data.Data) tag_moderator.TagModeratorRepo {
+	return &tagModeratorRepo{
+		data: data,
+	}
+}
+
+// AddTagModerator assign the user as the moderator of the tag, nothing is changed if the user has been assigned
+func (tr *tagModeratorRepo) AddTagModerator(ctx context.Context, tagID, userID string) (err error) {
+	moderator := &entity.TagModerator{TagID: tagID, UserID: userID}
+	exist, err := tr.data.DB.Context(ctx).Exist(moderator)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	if _, err = tr.data.DB.Context(ctx).Insert(moderator); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveTagModerator remove the user from the moderators of the tag
+func (tr *tagModeratorRepo) RemoveTagModerator(ctx context.Context, tagID, userID string) (err error) {
+	_, err = tr.data.DB.Context(ctx).Where("tag_id = ? AND user_id = ?", tagID, userID).
+		Delete(&entity.TagModerator{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagModerators get the moderators of the tag, the earliest assigned ones come first
+func (tr *tagModeratorRepo) GetTagModerators(ctx context.Context, tagID string) (
+	moderators []*entity.TagModerator, err error) {
+	moderators = make([]*entity.TagModerator, 0)
+	err = tr.data.DB.Context(ctx).Where("tag_id = ?", tagID).Asc("id").Find(&moderators)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModeratedTagIDs get the ids of the tags moderated by the user
+func (tr *tagModeratorRepo) GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.TagModerator{}.TableName()).Where("user_id = ?", userID).
+		Cols("tag_id").Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
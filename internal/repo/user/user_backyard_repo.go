@@ -25,12 +25,15 @@ import (
 	"time"
 
 	"xorm.io/builder"
+	"xorm.io/xorm"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/user_admin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -38,15 +41,17 @@ import (
 
 // userAdminRepo user repository
 type userAdminRepo struct {
-	data     *data.Data
-	authRepo auth.AuthRepo
+	data         *data.Data
+	authRepo     auth.AuthRepo
+	userRankRepo rank.UserRankRepo
 }
 
 // NewUserAdminRepo new repository
-func NewUserAdminRepo(data *data.Data, authRepo auth.AuthRepo) user_admin.UserAdminRepo {
+func NewUserAdminRepo(data *data.Data, authRepo auth.AuthRepo, userRankRepo rank.UserRankRepo) user_admin.UserAdminRepo {
 	return &userAdminRepo{
-		data:     data,
-		authRepo: authRepo,
+		data:         data,
+		authRepo:     authRepo,
+		userRankRepo: userRankRepo,
 	}
 }
 
@@ -205,3 +210,207 @@ func (ur *userAdminRepo) GetExpiredSuspendedUsers(ctx context.Context) (users []
 
 	return users, nil
 }
+
+// EraseUser delete the user and remove the personal data of it in one transaction.
+// The posts of the user are reassigned to the deleted user placeholder, they are also marked as deleted if
+// deleteContent is true. The reputation the other users got from the votes of the user is taken back if
+// revertVotes is true. The ids of the questions whose posts are changed are returned to update the search.
+func (ur *userAdminRepo) EraseUser(ctx context.Context, userID string, deleteContent, revertVotes bool) (
+	questionIDs []string, err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		placeholderID, err := ur.acquireDeletedUserPlaceholder(session)
+		if err != nil {
+			return nil, err
+		}
+
+		questionIDs = make([]string, 0)
+		err = session.Table("question").Where("user_id = ?", userID).Cols("id").Find(&questionIDs)
+		if err != nil {
+			return nil, err
+		}
+		answerQuestionIDs := make([]string, 0)
+		err = session.Table("answer").Where("user_id = ?", userID).Distinct("question_id").Find(&answerQuestionIDs)
+		if err != nil {
+			return nil, err
+		}
+		questionIDs = append(questionIDs, answerQuestionIDs...)
+
+		if deleteContent {
+			now := time.Now()
+			_, err = session.Where("user_id = ?", userID).And("status != ?", entity.QuestionStatusDeleted).
+				Cols("status", "updated_at", "deleted_at").
+				Update(&entity.Question{Status: entity.QuestionStatusDeleted, UpdatedAt: now, DeletedAt: now})
+			if err != nil {
+				return nil, err
+			}
+			_, err = session.Where("user_id = ?", userID).And("status != ?", entity.AnswerStatusDeleted).
+				Cols("status", "updated_at", "deleted_at").
+				Update(&entity.Answer{Status: entity.AnswerStatusDeleted, UpdatedAt: now, DeletedAt: now})
+			if err != nil {
+				return nil, err
+			}
+			_, err = session.Where("user_id = ?", userID).Cols("status").
+				Update(&entity.Comment{Status: entity.CommentStatusDeleted})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if revertVotes {
+			if err = ur.revertUserVoteRanks(ctx, session, userID); err != nil {
+				return nil, err
+			}
+		}
+
+		// the posts and the other records that are kept for the community are reassigned to the placeholder
+		for _, reassign := range erasedUserReassignColumns {
+			_, err = session.Table(reassign.table).Where(builder.Eq{reassign.column: userID}).
+				Update(map[string]any{reassign.column: placeholderID})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// the records that only belong to the user are deleted
+		conversationIDs := make([]string, 0)
+		err = session.Table("ai_conversation").Where("user_id = ?", userID).Cols("conversation_id").Find(&conversationIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(conversationIDs) > 0 {
+			if _, err = session.In("conversation_id", conversationIDs).Delete(&entity.AIConversationRecord{}); err != nil {
+				return nil, err
+			}
+		}
+		for _, table := range erasedUserDeleteTables {
+			if _, err = session.Exec("DELETE FROM "+table+" WHERE user_id = ?", userID); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = session.ID(userID).Delete(&entity.User{}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return questionIDs, nil
+}
+
+// erasedUserReassignColumns the columns that refer to the erased user are reassigned to the deleted user placeholder
+var erasedUserReassignColumns = []struct {
+	table  string
+	column string
+}{
+	{table: "question", column: "user_id"},
+	{table: "question", column: "last_edit_user_id"},
+	{table: "answer", column: "user_id"},
+	{table: "answer", column: "last_edit_user_id"},
+	{table: "comment", column: "user_id"},
+	{table: "comment", column: "reply_user_id"},
+	{table: "revision", column: "user_id"},
+	{table: "revision", column: "review_user_id"},
+	{table: "tag", column: "user_id"},
+	{table: "report", column: "user_id"},
+	{table: "report", column: "reported_user_id"},
+	{table: "review", column: "user_id"},
+	{table: "review", column: "reviewer_user_id"},
+	{table: "question_bounty", column: "user_id"},
+	{table: "question_bounty", column: "awarded_user_id"},
+	{table: "file_record", column: "user_id"},
+	{table: "activity", column: "trigger_user_id"},
+}
+
+// erasedUserDeleteTables the records of the erased user in these tables are deleted
+var erasedUserDeleteTables = []string{
+	"activity",
+	"notification",
+	"user_notification_config",
+	"user_external_login",
+	"user_role_rel",
+	"user_two_factor",
+	"plugin_user_config",
+	"api_key",
+	"collection",
+	"collection_group",
+	"badge_award",
+	"user_tag_rank",
+	"new_question_digest",
+	"notification_digest",
+	"ai_conversation",
+	"user_mute",
+	"saved_search",
+}
+
+// revertUserVoteRanks cancel the activities that changed the reputation of the other users by the votes of the user
+func (ur *userAdminRepo) revertUserVoteRanks(ctx context.Context, session *xorm.Session, userID string) (err error) {
+	activities := make([]*entity.Activity, 0)
+	err = session.Where(builder.Eq{"trigger_user_id": userID, "has_rank": 1, "cancelled": entity.ActivityAvailable}).
+		And(builder.Neq{"`rank`": 0}).Find(&activities)
+	if err != nil {
+		return err
+	}
+	if len(activities) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(activities))
+	userDelta := make(map[string]int)
+	for _, act := range activities {
+		ids = append(ids, act.ID)
+		userDelta[act.UserID] -= act.Rank
+	}
+	_, err = session.In("id", ids).Cols("cancelled", "cancelled_at").
+		Update(&entity.Activity{Cancelled: entity.ActivityCancelled, CancelledAt: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	users := make([]*entity.User, 0)
+	userIDs := make([]string, 0, len(userDelta))
+	for id := range userDelta {
+		userIDs = append(userIDs, id)
+	}
+	if err = session.In("id", userIDs).ForUpdate().Find(&users); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err = ur.userRankRepo.ChangeUserRank(ctx, session, user.ID, user.Rank, userDelta[user.ID]); err != nil {
+			return err
+		}
+	}
+	for _, act := range activities {
+		if err = ur.userRankRepo.ChangeUserTagRank(ctx, session, act.UserID, act.ObjectID, act.ActivityType,
+			-act.Rank); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// acquireDeletedUserPlaceholder get the deleted user placeholder that owns the posts of the erased users,
+// it is created when the first user is erased. The username of it is reserved, nobody can register it.
+func (ur *userAdminRepo) acquireDeletedUserPlaceholder(session *xorm.Session) (userID string, err error) {
+	placeholder := &entity.User{}
+	exist, err := session.Where("username = ?", constant.DeletedUserPlaceholderUsername).Get(placeholder)
+	if err != nil {
+		return "", err
+	}
+	if exist {
+		return placeholder.ID, nil
+	}
+	placeholder = &entity.User{
+		Username:    constant.DeletedUserPlaceholderUsername,
+		DisplayName: constant.DeletedUserPlaceholderUsername,
+		Status:      entity.UserStatusDeleted,
+		MailStatus:  entity.EmailStatusToBeVerified,
+		DeletedAt:   time.Now(),
+	}
+	if _, err = session.Insert(placeholder); err != nil {
+		return "", err
+	}
+	return placeholder.ID, nil
+}
@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data_export
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_data_export"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// activityPrivateColumns the trigger user of an activity is the other user who did it, such as the voter
+// of the post, it is not exported because the votes are anonymous.
+var activityPrivateColumns = []string{"trigger_user_id"}
+
+type userDataExportRepo struct {
+	data *data.Data
+}
+
+// NewUserDataExportRepo new repository
+func NewUserDataExportRepo(data *data.Data) user_data_export.UserDataExportRepo {
+	return &userDataExportRepo{
+		data: data,
+	}
+}
+
+// ExportUserProfile export the profile of the user, the password is not exported
+func (ur *userDataExportRepo) ExportUserProfile(ctx context.Context, userID string,
+	fn func(row map[string]any) error) (err error) {
+	return ur.exportRows(ctx, &entity.User{}, builder.Eq{"id": userID}, []string{"pass"}, fn)
+}
+
+// ExportUserQuestions export the questions of the user
+func (ur *userDataExportRepo) ExportUserQuestions(ctx context.Context, userID string,
+	fn func(row map[string]any) error) (err error) {
+	return ur.exportRows(ctx, &entity.Question{}, builder.Eq{"user_id": userID}, nil, fn)
+}
+
+// ExportUserAnswers export the answers of the user
+func (ur *userDataExportRepo) ExportUserAnswers(ctx context.Context, userID string,
+	fn func(row map[string]any) error) (err error) {
+	return ur.exportRows(ctx, &entity.Answer{}, builder.Eq{"user_id": userID}, nil, fn)
+}
+
+// ExportUserComments export the comments of the user
+func (ur *userDataExportRepo) ExportUserComments(ctx context.Context, userID string,
+	fn func(row map[string]any) error) (err error) {
+	return ur.exportRows(ctx, &entity.Comment{}, builder.Eq{"user_id": userID}, nil, fn)
+}
+
+// ExportUserNotifications export the notifications received by the user
+func (ur *userDataExportRepo) ExportUserNotifications(ctx context.Context, userID string,
+	fn func(row map[string]any) error) (err error) {
+	return ur.exportRows(ctx, &entity.Notification{}, builder.Eq{"user_id": userID}, nil, fn)
+}
+
+// ExportUserVotes export the votes cast by the user, they are the activities of the user with the vote activity types
+func (ur *userDataExportRepo) ExportUserVotes(ctx context.Context, userID string, activityTypes []int,
+	fn func(row map[string]any) error) (err error) {
+	if len(activityTypes) == 0 {
+		return nil
+	}
+	cond := builder.And(builder.Eq{"user_id": userID}, builder.In("activity_type", activityTypes))
+	return ur.exportRows(ctx, &entity.Activity{}, cond, activityPrivateColumns, fn)
+}
+
+// ExportUserReputationEvents export the activities that changed the reputation of the user
+func (ur *userDataExportRepo) ExportUserReputationEvents(ctx context.Context, userID string,
+	fn func(row map[string]any) error) (err error) {
+	cond := builder.Eq{"user_id": userID, "has_rank": 1}
+	return ur.exportRows(ctx, &entity.Activity{}, cond, activityPrivateColumns, fn)
+}
+
+// exportRows stream the rows matching the condition to fn as the maps of the column name to the value,
+// the rows are never loaded all at once. The omitted columns are not in the map.
+func (ur *userDataExportRepo) exportRows(ctx context.Context, bean any, cond builder.Cond,
+	omitColumns []string, fn func(row map[string]any) error) (err error) {
+	tableInfo, err := ur.data.DB.TableInfo(bean)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	omitted := make(map[string]bool, len(omitColumns))
+	for _, column := range omitColumns {
+		omitted[column] = true
+	}
+
+	rows, err := ur.data.DB.Context(ctx).Where(cond).Asc("id").Rows(bean)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	beanType := reflect.TypeOf(bean).Elem()
+	for rows.Next() {
+		value := reflect.New(beanType)
+		if err = rows.Scan(value.Interface()); err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		row := make(map[string]any, len(tableInfo.Columns()))
+		for _, column := range tableInfo.Columns() {
+			if omitted[column.Name] || len(column.FieldIndex) == 0 {
+				continue
+			}
+			row[column.Name] = value.Elem().FieldByIndex(column.FieldIndex).Interface()
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// SetExportRunning mark the export of the user as running, returns false if it is already running
+func (ur *userDataExportRepo) SetExportRunning(ctx context.Context, userID string) (ok bool, err error) {
+	ok, err = data.SetIfAbsent(ctx, ur.data.Cache, constant.UserDataExportRunningCacheKey+userID,
+		constant.UserDataExportRunningCacheTime)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return ok, nil
+}
+
+// ClearExportRunning clear the running mark of the export of the user
+func (ur *userDataExportRepo) ClearExportRunning(ctx context.Context, userID string) (err error) {
+	err = ur.data.Cache.Del(ctx, constant.UserDataExportRunningCacheKey+userID)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// SetExportFile save the export file that can be downloaded with the token until it expires
+func (ur *userDataExportRepo) SetExportFile(ctx context.Context, token string,
+	file *schema.UserDataExportFile, expiredAt time.Time) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(&entity.UserDataExport{
+		ExpiredAt: expiredAt,
+		Token:     token,
+		UserID:    file.UserID,
+		FileName:  file.FileName,
+		Content:   file.Content,
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetExportFile get the export file by the token, the expired file is not returned
+func (ur *userDataExportRepo) GetExportFile(ctx context.Context, token string) (
+	file *schema.UserDataExportFile, err error) {
+	export := &entity.UserDataExport{}
+	exist, err := ur.data.DB.Context(ctx).Where("token = ?", token).
+		And("expired_at > ?", time.Now()).Get(export)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, nil
+	}
+	return &schema.UserDataExportFile{
+		UserID:   export.UserID,
+		FileName: export.FileName,
+		Content:  export.Content,
+	}, nil
+}
+
+// RemoveExpiredExportFiles remove the export files that can no longer be downloaded
+func (ur *userDataExportRepo) RemoveExpiredExportFiles(ctx context.Context) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("expired_at <= ?", time.Now()).Delete(&entity.UserDataExport{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
@@ -162,3 +162,10 @@ func (ur *userExternalLoginRepo) GetCacheOAuthState(
 func (ur *userExternalLoginRepo) DeleteCacheOAuthState(ctx context.Context, state string) (err error) {
 	return ur.data.Cache.Del(ctx, constant.ConnectorOAuthStateCacheKey+state)
 }
+
+// ConsumeCacheSAMLAssertionID remember the SAML assertion until it expires, first is false if it has been consumed.
+// It is checked and set in one atomic operation, so the same assertion posted in parallel is only consumed once.
+func (ur *userExternalLoginRepo) ConsumeCacheSAMLAssertionID(
+	ctx context.Context, assertionID string, duration time.Duration) (first bool, err error) {
+	return data.SetIfAbsent(ctx, ur.data.Cache, constant.ConnectorSAMLAssertionCacheKey+assertionID, duration)
+}
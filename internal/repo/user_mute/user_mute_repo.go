@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_mute
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/user_mute"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type userMuteRepo struct {
+	data *data.Data
+}
+
+// NewUserMuteRepo new repository
+func NewUserMuteRepo(data *data.Data) user_mute.UserMuteRepo {
+	return &userMuteRepo{
+		data: data,
+	}
+}
+
+// AddUserMute mute the user, nothing is changed if the user has been muted
+func (ur *userMuteRepo) AddUserMute(ctx context.Context, userID, mutedUserID string) (err error) {
+	mute := &entity.UserMute{UserID: userID, MutedUserID: mutedUserID}
+	exist, err := ur.data.DB.Context(ctx).Exist(mute)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	if _, err = ur.data.DB.Context(ctx).Insert(mute); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveUserMute unmute the user
+func (ur *userMuteRepo) RemoveUserMute(ctx context.Context, userID, mutedUserID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("user_id = ? AND muted_user_id = ?", userID, mutedUserID).
+		Delete(&entity.UserMute{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserMute count the users muted by the user
+func (ur *userMuteRepo) CountUserMute(ctx context.Context, userID string) (count int64, err error) {
+	count, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.UserMute{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserMutePage get the users muted by the user, the latest muted ones come first
+func (ur *userMuteRepo) GetUserMutePage(ctx context.Context, userID string, page, pageSize int) (
+	mutes []*entity.UserMute, total int64, err error) {
+	mutes = make([]*entity.UserMute, 0)
+	session := ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id")
+	total, err = pager.Help(page, pageSize, &mutes, &entity.UserMute{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetMutedUserIDs get the ids of all the users muted by the user
+func (ur *userMuteRepo) GetMutedUserIDs(ctx context.Context, userID string) (mutedUserIDs []string, err error) {
+	mutedUserIDs = make([]string, 0)
+	err = ur.data.DB.Context(ctx).Table(entity.UserMute{}.TableName()).Where("user_id = ?", userID).
+		Cols("muted_user_id").Find(&mutedUserIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_two_factor
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/user_two_factor"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+type userTwoFactorRepo struct {
+	data *data.Data
+}
+
+// NewUserTwoFactorRepo new repository
+func NewUserTwoFactorRepo(data *data.Data) user_two_factor.UserTwoFactorRepo {
+	return &userTwoFactorRepo{
+		data: data,
+	}
+}
+
+// GetByUserID get two-factor authentication info by user ID
+func (ur *userTwoFactorRepo) GetByUserID(ctx context.Context, userID string) (
+	info *entity.UserTwoFactor, exist bool, err error) {
+	info = &entity.UserTwoFactor{}
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Get(info)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveUserTwoFactor add or replace the two-factor authentication info of the user
+func (ur *userTwoFactorRepo) SaveUserTwoFactor(ctx context.Context, info *entity.UserTwoFactor) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (any, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("user_id = ?", info.UserID).Delete(&entity.UserTwoFactor{}); err != nil {
+			return nil, err
+		}
+		return session.Insert(info)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// EnableUserTwoFactor enable the two-factor authentication and record the used time step
+func (ur *userTwoFactorRepo) EnableUserTwoFactor(ctx context.Context, id, step int64) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(id).Cols("enabled", "last_used_step").
+		Update(&entity.UserTwoFactor{Enabled: true, LastUsedStep: step})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ConsumeStep record the used time step, returns false if the step or a later one has been used
+func (ur *userTwoFactorRepo) ConsumeStep(ctx context.Context, id, step int64) (ok bool, err error) {
+	affected, err := ur.data.DB.Context(ctx).ID(id).Where("last_used_step < ?", step).Cols("last_used_step").
+		Update(&entity.UserTwoFactor{LastUsedStep: step})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// ConsumeRecoveryCodes replace the recovery codes, returns false if they have been changed by another request
+func (ur *userTwoFactorRepo) ConsumeRecoveryCodes(ctx context.Context, id int64, oldCodes, newCodes string) (
+	ok bool, err error) {
+	affected, err := ur.data.DB.Context(ctx).ID(id).Where("recovery_codes = ?", oldCodes).Cols("recovery_codes").
+		Update(&entity.UserTwoFactor{RecoveryCodes: newCodes})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// DeleteUserTwoFactor delete the two-factor authentication info of the user
+func (ur *userTwoFactorRepo) DeleteUserTwoFactor(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Delete(&entity.UserTwoFactor{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SetCacheLoginChallenge cache the pending two-factor login
+func (ur *userTwoFactorRepo) SetCacheLoginChallenge(ctx context.Context, token string,
+	info *schema.UserTwoFactorLoginChallenge, duration time.Duration) (err error) {
+	cacheData, _ := json.Marshal(info)
+	return ur.data.Cache.SetString(ctx, constant.UserTwoFactorChallengeCacheKey+token, string(cacheData), duration)
+}
+
+// GetCacheLoginChallenge get the pending two-factor login
+func (ur *userTwoFactorRepo) GetCacheLoginChallenge(ctx context.Context, token string) (
+	info *schema.UserTwoFactorLoginChallenge, err error) {
+	res, exist, err := ur.data.Cache.GetString(ctx, constant.UserTwoFactorChallengeCacheKey+token)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	info = &schema.UserTwoFactorLoginChallenge{}
+	_ = json.Unmarshal([]byte(res), &info)
+	return info, nil
+}
+
+// IncreaseLoginChallengeAttempts count the attempt of the pending two-factor login atomically,
+// so the concurrent attempts are all counted
+func (ur *userTwoFactorRepo) IncreaseLoginChallengeAttempts(ctx context.Context, token string) (
+	attempts int64, err error) {
+	return data.IncreaseInWindow(ctx, ur.data.Cache, constant.UserTwoFactorChallengeAttemptsCacheKey+token,
+		constant.UserTwoFactorChallengeCacheTime)
+}
+
+// DeleteCacheLoginChallenge delete the pending two-factor login, the attempts are kept until they expire
+// so the attempts sent along with the last one are still counted
+func (ur *userTwoFactorRepo) DeleteCacheLoginChallenge(ctx context.Context, token string) (err error) {
+	return ur.data.Cache.Del(ctx, constant.UserTwoFactorChallengeCacheKey+token)
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/webhook"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type webhookRepo struct {
+	data *data.Data
+}
+
+// NewWebhookRepo new repository
+func NewWebhookRepo(data *data.Data) webhook.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+	}
+}
+
+// AddDeadLetter record a delivery that failed permanently
+func (wr *webhookRepo) AddDeadLetter(ctx context.Context, deadLetter *entity.WebhookDeadLetter) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(deadLetter)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeadLetterPage get dead letters page, the newest first
+func (wr *webhookRepo) GetDeadLetterPage(ctx context.Context, page, pageSize int) (
+	deadLetters []*entity.WebhookDeadLetter, total int64, err error) {
+	deadLetters = make([]*entity.WebhookDeadLetter, 0)
+	session := wr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &deadLetters, &entity.WebhookDeadLetter{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
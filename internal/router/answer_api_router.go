@@ -62,6 +62,20 @@ type AnswerAPIRouter struct {
 	aiConversationController      *controller.AIConversationController
 	aiConversationAdminController *controller_admin.AIConversationAdminController
 	mcpController                 *controller.MCPController
+	webhookController             *controller_admin.WebhookController
+	auditLogController            *controller_admin.AuditLogController
+	jobQueueController            *controller_admin.JobQueueController
+	bountyController              *controller.BountyController
+	graphqlController             *controller.GraphQLController
+	draftController               *controller.DraftController
+	userMuteController            *controller.UserMuteController
+	postLockController            *controller.PostLockController
+	postWikiController            *controller.PostWikiController
+	savedSearchController         *controller.SavedSearchController
+	guestPostController           *controller.GuestPostController
+	tagModeratorController        *controller_admin.TagModeratorController
+	impersonationController       *controller.ImpersonationController
+	conditionalGetMiddleware      *middleware.ConditionalGetMiddleware
 }
 
 func NewAnswerAPIRouter(
@@ -100,6 +114,20 @@ func NewAnswerAPIRouter(
 	aiConversationController *controller.AIConversationController,
 	aiConversationAdminController *controller_admin.AIConversationAdminController,
 	mcpController *controller.MCPController,
+	webhookController *controller_admin.WebhookController,
+	auditLogController *controller_admin.AuditLogController,
+	jobQueueController *controller_admin.JobQueueController,
+	bountyController *controller.BountyController,
+	graphqlController *controller.GraphQLController,
+	draftController *controller.DraftController,
+	userMuteController *controller.UserMuteController,
+	postLockController *controller.PostLockController,
+	postWikiController *controller.PostWikiController,
+	savedSearchController *controller.SavedSearchController,
+	guestPostController *controller.GuestPostController,
+	tagModeratorController *controller_admin.TagModeratorController,
+	impersonationController *controller.ImpersonationController,
+	conditionalGetMiddleware *middleware.ConditionalGetMiddleware,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
 		langController:                langController,
@@ -137,6 +165,20 @@ func NewAnswerAPIRouter(
 		aiConversationController:      aiConversationController,
 		aiConversationAdminController: aiConversationAdminController,
 		mcpController:                 mcpController,
+		webhookController:             webhookController,
+		auditLogController:            auditLogController,
+		jobQueueController:            jobQueueController,
+		bountyController:              bountyController,
+		graphqlController:             graphqlController,
+		draftController:               draftController,
+		userMuteController:            userMuteController,
+		postLockController:            postLockController,
+		postWikiController:            postWikiController,
+		savedSearchController:         savedSearchController,
+		guestPostController:           guestPostController,
+		tagModeratorController:        tagModeratorController,
+		impersonationController:       impersonationController,
+		conditionalGetMiddleware:      conditionalGetMiddleware,
 	}
 }
 
@@ -150,42 +192,55 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	r.GET("/siteinfo/legal", a.siteInfoController.GetSiteLegalInfo)
 
 	// user
-	r.GET("/user/info", a.userController.GetUserInfoByUserID)
+	r.GET("/user/info", authUserMiddleware.Auth(), a.userController.GetUserInfoByUserID)
 	r.GET("/user/action/record", authUserMiddleware.Auth(), a.userController.ActionRecord)
 	routerGroup := r.Group("", middleware.BanAPIForUserCenter)
 	routerGroup.POST("/user/login/email", a.userController.UserEmailLogin)
-	routerGroup.POST("/user/register/email", a.userController.UserRegisterByEmail)
+	routerGroup.POST("/user/register/email", authUserMiddleware.MaintenanceMode(), a.userController.UserRegisterByEmail)
 	routerGroup.POST("/user/email/verification", a.userController.UserVerifyEmail)
 	routerGroup.PUT("/user/email", a.userController.UserChangeEmailVerify)
 	routerGroup.POST("/user/password/reset", a.userController.RetrievePassWord)
 	routerGroup.POST("/user/password/replacement", a.userController.UseRePassWord)
 	routerGroup.PUT("/user/notification/unsubscribe", a.userController.UserUnsubscribeNotification)
+	routerGroup.POST("/user/notification/unsubscribe/one-click", a.userController.UserOneClickUnsubscribeNotification)
+	routerGroup.POST("/user/login/2fa", a.userController.UserTwoFactorLogin)
+	r.GET("/user/data-export/download", a.userController.DownloadUserDataExport)
 
 	// plugins
 	r.GET("/plugin/status", a.pluginController.GetAllPluginStatus)
+
+	// guest posting
+	r.POST("/question/guest", authUserMiddleware.Auth(), authUserMiddleware.EjectUserBySiteInfo(),
+		authUserMiddleware.MaintenanceMode(), a.guestPostController.AddGuestQuestion)
 }
 
 func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	// user
 	r.GET("/personal/user/info", a.userController.GetOtherUserInfoByUsername)
 	r.GET("/user/ranking", a.userController.UserRanking)
+	r.GET("/user/leaderboard", a.rankController.GetReputationLeaderboard)
 	r.GET("/user/staff", a.userController.UserStaff)
 
 	// answer
 	r.GET("/answer/info", a.answerController.GetAnswerInfo)
-	r.GET("/answer/page", a.answerController.AnswerList)
+	r.GET("/answer/page", a.conditionalGetMiddleware.ConditionalGet(), a.answerController.AnswerList)
 	r.GET("/personal/answer/page", a.questionController.PersonalAnswerPage)
 
 	// question
-	r.GET("/question/info", a.questionController.GetQuestion)
+	r.GET("/question/info", a.conditionalGetMiddleware.ConditionalGet(), a.questionController.GetQuestion)
+	r.GET("/question/bounty", a.bountyController.GetQuestionBounties)
 	r.GET("/question/invite", a.questionController.GetQuestionInviteUserInfo)
 	r.GET("/question/page", a.questionController.QuestionPage)
 	r.GET("/question/recommend/page", a.questionController.QuestionRecommendPage)
 	r.GET("/question/similar/tag", a.questionController.SimilarQuestion)
+	r.GET("/question/related", a.questionController.GetRelatedQuestions)
 	r.GET("/personal/qa/top", a.questionController.UserTop)
 	r.GET("/personal/question/page", a.questionController.PersonalQuestionPage)
 	r.GET("/question/link", a.questionController.GetQuestionLink)
 
+	// graphql
+	r.POST("/graphql", a.graphqlController.Query)
+
 	// comment
 	r.GET("/comment/page", a.commentController.GetCommentWithPage)
 	r.GET("/personal/comment/page", a.commentController.GetCommentPersonalWithPage)
@@ -204,6 +259,8 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// rank
 	r.GET("/personal/rank/page", a.rankController.GetRankPersonalWithPage)
+	r.GET("/personal/reputation/page", a.rankController.GetReputationLedgerWithPage)
+	r.GET("/personal/reputation/tag/page", a.rankController.GetUserTagRankWithPage)
 
 	// reaction
 	r.GET("/meta/reaction", a.metaController.GetReaction)
@@ -218,13 +275,18 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 func (a *AnswerAPIRouter) RegisterAuthUserWithAnyStatusAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/user/logout", a.userController.UserLogout)
-	r.POST("/user/email/change/code", middleware.BanAPIForUserCenter, a.userController.UserChangeEmailSendCode)
+	r.POST("/user/logout/all", middleware.BanAPIKeyAuth, middleware.BanImpersonation, a.userController.UserLogoutEverywhere)
+	r.POST("/user/email/change/code", middleware.BanAPIForUserCenter, middleware.BanImpersonation,
+		a.userController.UserChangeEmailSendCode)
 	r.POST("/user/email/verification/send", middleware.BanAPIForUserCenter, a.userController.UserVerifyEmailSend)
+	r.POST("/user/impersonation/stop", a.impersonationController.StopImpersonation)
 }
 
 func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// revisions
 	r.GET("/revisions", a.revisionController.GetRevisionList)
+	r.GET("/revisions/diff", a.revisionController.GetRevisionDiff)
+	r.POST("/revisions/rollback", a.revisionController.RollbackRevision)
 	r.GET("/revisions/unreviewed", a.revisionController.GetUnreviewedRevisionList)
 	r.PUT("/revisions/audit", a.revisionController.RevisionAudit)
 	r.GET("/revisions/edit/check", a.revisionController.CheckCanUpdateRevision)
@@ -239,6 +301,8 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/report", a.reportController.AddReport)
 	r.GET("/report/unreviewed/post", a.reportController.GetUnreviewedReportPostPage)
 	r.PUT("/report/review", a.reportController.ReviewReport)
+	r.GET("/moderation/queue", a.reportController.GetModerationQueue)
+	r.PUT("/moderation/resolve", a.reportController.ResolveModerationQueueItem)
 
 	// review
 	r.GET("/review/pending/post/page", a.reviewController.GetUnreviewedPostPage)
@@ -271,9 +335,16 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/question", a.questionController.UpdateQuestion)
 	r.PUT("/question/invite", a.questionController.UpdateQuestionInviteUser)
 	r.DELETE("/question", a.questionController.RemoveQuestion)
+	r.POST("/question/bounty", a.bountyController.AddQuestionBounty)
+	r.POST("/question/bounty/award", a.bountyController.AwardQuestionBounty)
 	r.PUT("/question/status", a.questionController.CloseQuestion)
 	r.PUT("/question/operation", a.questionController.OperationQuestion)
 	r.PUT("/question/reopen", a.questionController.ReopenQuestion)
+	r.POST("/post/lock", a.postLockController.LockPost)
+	r.DELETE("/post/lock", a.postLockController.UnlockPost)
+	r.PUT("/post/wiki", a.postWikiController.UpdatePostWiki)
+	r.PUT("/question/schedule", a.questionController.UpdateQuestionSchedule)
+	r.DELETE("/question/schedule", a.questionController.CancelQuestionSchedule)
 	r.GET("/question/similar", a.questionController.GetSimilarQuestions)
 	r.POST("/question/recover", a.questionController.QuestionRecover)
 
@@ -284,13 +355,47 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.DELETE("/answer", a.answerController.RemoveAnswer)
 	r.POST("/answer/recover", a.answerController.RecoverAnswer)
 
+	// draft
+	r.GET("/draft", a.draftController.GetDraft)
+	r.PUT("/draft", a.draftController.SaveDraft)
+	r.DELETE("/draft", a.draftController.RemoveDraft)
+
+	// user mute
+	r.GET("/user/mute/page", a.userMuteController.GetMutedUserPage)
+	r.POST("/user/mute", a.userMuteController.MuteUser)
+	r.DELETE("/user/mute", a.userMuteController.UnmuteUser)
+
+	// saved search
+	r.GET("/user/saved-search/page", a.savedSearchController.GetSavedSearchPage)
+	r.POST("/user/saved-search", a.savedSearchController.AddSavedSearch)
+	r.PUT("/user/saved-search", a.savedSearchController.UpdateSavedSearch)
+	r.DELETE("/user/saved-search", a.savedSearchController.RemoveSavedSearch)
+
+	// guest posting
+	r.POST("/question/guest/claim", a.guestPostController.ClaimGuestQuestion)
+
 	// user
-	r.PUT("/user/password", middleware.BanAPIForUserCenter, a.userController.UserModifyPassWord)
+	r.PUT("/user/password", middleware.BanAPIKeyAuth, middleware.BanAPIForUserCenter, middleware.BanImpersonation,
+		a.userController.UserModifyPassWord)
 	r.PUT("/user/info", a.userController.UserUpdateInfo)
 	r.PUT("/user/interface", a.userController.UserUpdateInterface)
 	r.GET("/user/notification/config", a.userController.GetUserNotificationConfig)
 	r.PUT("/user/notification/config", a.userController.UpdateUserNotificationConfig)
+	r.GET("/user/notification/preferences", a.userController.GetNotificationPreferences)
+	r.PUT("/user/notification/preferences", a.userController.UpdateNotificationPreferences)
 	r.GET("/user/info/search", a.userController.SearchUserListByName)
+	r.GET("/user/2fa", middleware.BanAPIKeyAuth, middleware.BanAPIForUserCenter, middleware.BanImpersonation,
+		a.userController.GetUserTwoFactorStatus)
+	r.POST("/user/2fa/enrollment", middleware.BanAPIKeyAuth, middleware.BanAPIForUserCenter, middleware.BanImpersonation,
+		a.userController.EnrollUserTwoFactor)
+	r.POST("/user/2fa/activation", middleware.BanAPIKeyAuth, middleware.BanAPIForUserCenter, middleware.BanImpersonation,
+		a.userController.ActivateUserTwoFactor)
+	r.DELETE("/user/2fa", middleware.BanAPIKeyAuth, middleware.BanAPIForUserCenter, middleware.BanImpersonation,
+		a.userController.DisableUserTwoFactor)
+	r.POST("/user/data-export", middleware.BanImpersonation, a.userController.ExportUserData)
+	r.GET("/user/api-key/all", middleware.BanAPIKeyAuth, middleware.BanImpersonation, a.userController.GetUserAPIKeys)
+	r.POST("/user/api-key", middleware.BanAPIKeyAuth, middleware.BanImpersonation, a.userController.AddUserAPIKey)
+	r.DELETE("/user/api-key", middleware.BanAPIKeyAuth, middleware.BanImpersonation, a.userController.RevokeUserAPIKey)
 
 	// vote
 	r.GET("/personal/vote/page", a.voteController.UserVotes)
@@ -338,6 +443,8 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/question/status", a.questionController.AdminUpdateQuestionStatus)
 	r.GET("/answer/page", a.questionController.AdminAnswerPage)
 	r.PUT("/answer/status", a.answerController.AdminUpdateAnswerStatus)
+	r.GET("/deleted-post/page", a.questionController.AdminDeletedPostPage)
+	r.PUT("/deleted-post/restore", a.questionController.AdminRestoreDeletedPost)
 
 	// user
 	r.GET("/users/page", a.adminUserController.GetUserPage)
@@ -346,8 +453,13 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
 	r.POST("/user", a.adminUserController.AddUser)
+	r.DELETE("/user", a.adminUserController.EraseUser)
+	r.DELETE("/user/sessions", a.adminUserController.RevokeUserSessions)
+	r.POST("/user/impersonation", a.impersonationController.StartImpersonation)
 	r.POST("/users", a.adminUserController.AddUsers)
 	r.PUT("/user/password", a.adminUserController.UpdateUserPassword)
+	r.DELETE("/user/2fa", a.adminUserController.ResetUserTwoFactor)
+	r.POST("/user/data-export", a.adminUserController.ExportUserData)
 	r.PUT("/user/profile", a.adminUserController.EditUserProfile)
 
 	r.DELETE("/delete/permanently", a.adminUserController.DeletePermanently)
@@ -397,14 +509,68 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
 	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
 	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
+	r.POST("/setting/smtp/test", a.adminSiteInfoController.SendTestEmail)
+	r.GET("/setting/email-templates", a.adminSiteInfoController.GetEmailTemplates)
+	r.PUT("/setting/email-template", a.adminSiteInfoController.SaveEmailTemplate)
+	r.DELETE("/setting/email-template", a.adminSiteInfoController.RemoveEmailTemplate)
+	r.GET("/setting/ldap", a.adminSiteInfoController.GetLDAPConfig)
+	r.PUT("/setting/ldap", a.adminSiteInfoController.UpdateLDAPConfig)
+	r.GET("/setting/saml", a.adminSiteInfoController.GetSAMLConfig)
+	r.PUT("/setting/saml", a.adminSiteInfoController.UpdateSAMLConfig)
+	r.GET("/setting/webhook", a.adminSiteInfoController.GetWebhookConfig)
+	r.PUT("/setting/webhook", a.adminSiteInfoController.UpdateWebhookConfig)
+	r.GET("/setting/storage", a.adminSiteInfoController.GetStorageConfig)
+	r.PUT("/setting/storage", a.adminSiteInfoController.UpdateStorageConfig)
+	r.GET("/setting/spam", a.adminSiteInfoController.GetSpamConfig)
+	r.PUT("/setting/spam", a.adminSiteInfoController.UpdateSpamConfig)
+	r.GET("/setting/posting-restriction", a.adminSiteInfoController.GetPostingRestrictionConfig)
+	r.PUT("/setting/posting-restriction", a.adminSiteInfoController.UpdatePostingRestrictionConfig)
+	r.GET("/setting/accept-reputation", a.adminSiteInfoController.GetAcceptReputationConfig)
+	r.PUT("/setting/accept-reputation", a.adminSiteInfoController.UpdateAcceptReputationConfig)
+	r.GET("/setting/cors", a.adminSiteInfoController.GetCORSConfig)
+	r.PUT("/setting/cors", a.adminSiteInfoController.UpdateCORSConfig)
+	r.GET("/setting/captcha", a.adminSiteInfoController.GetCaptchaConfig)
+	r.PUT("/setting/captcha", a.adminSiteInfoController.UpdateCaptchaConfig)
+	r.GET("/setting/registration-blocklist", a.adminSiteInfoController.GetRegistrationBlocklist)
+	r.PUT("/setting/registration-blocklist", a.adminSiteInfoController.UpdateRegistrationBlocklist)
+	r.PUT("/setting/registration-blocklist/disposable-domains", a.adminSiteInfoController.ImportDisposableEmailDomains)
+	r.GET("/setting/username-policy", a.adminSiteInfoController.GetUsernamePolicy)
+	r.PUT("/setting/username-policy", a.adminSiteInfoController.UpdateUsernamePolicy)
+	r.GET("/setting/maintenance", a.adminSiteInfoController.GetMaintenanceConfig)
+	r.PUT("/setting/maintenance", a.adminSiteInfoController.UpdateMaintenanceConfig)
+	r.GET("/setting/guest-posting", a.adminSiteInfoController.GetGuestPostingConfig)
+	r.PUT("/setting/guest-posting", a.adminSiteInfoController.UpdateGuestPostingConfig)
+	r.GET("/setting/auto-close", a.adminSiteInfoController.GetAutoCloseConfig)
+	r.PUT("/setting/auto-close", a.adminSiteInfoController.UpdateAutoCloseConfig)
+	r.GET("/setting/slow-query", a.adminSiteInfoController.GetSlowQueryConfig)
+	r.PUT("/setting/slow-query", a.adminSiteInfoController.UpdateSlowQueryConfig)
+	r.GET("/setting/reaction", a.adminSiteInfoController.GetReactionConfig)
+	r.PUT("/setting/reaction", a.adminSiteInfoController.UpdateReactionConfig)
+	r.GET("/webhook/dead-letters", a.webhookController.GetDeadLetterPage)
 	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
 	r.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
 
+	// audit log
+	r.GET("/audit-logs", a.auditLogController.GetAuditLogPage)
+
+	// job queue
+	r.GET("/jobs/status", a.jobQueueController.GetJobQueueStatus)
+	r.GET("/jobs/dead-letters", a.jobQueueController.GetJobDeadLetterPage)
+
 	// dashboard
 	r.GET("/dashboard", a.dashboardController.DashboardInfo)
 
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
+	r.GET("/powers", a.roleController.GetPowerList)
+	r.POST("/role", a.roleController.AddRole)
+	r.PUT("/role", a.roleController.UpdateRole)
+	r.DELETE("/role", a.roleController.RemoveRole)
+
+	// tag moderators
+	r.GET("/tag/moderators", a.tagModeratorController.GetTagModerators)
+	r.POST("/tag/moderator", a.tagModeratorController.AddTagModerator)
+	r.DELETE("/tag/moderator", a.tagModeratorController.RemoveTagModerator)
 
 	// plugin
 	r.GET("/plugins", a.pluginController.GetPluginList)
@@ -58,6 +58,9 @@ func (pr *PluginAPIRouter) RegisterUnAuthConnectorRouter(r *gin.RouterGroup) {
 	r.GET(controller.ConnectorRedirectRouterPrefix+":name", connectorController.ConnectorRedirectDispatcher)
 	r.GET("/connector/info", connectorController.ConnectorsInfo)
 	r.POST("/connector/binding/email", connectorController.ExternalLoginBindingUserSendEmail)
+	r.GET("/saml/metadata", connectorController.SAMLMetadata)
+	r.GET("/saml/login", connectorController.SAMLLogin)
+	r.POST("/saml/acs", connectorController.SAMLAcs)
 
 	// user center plugin
 	r.GET("/user-center/agent", pr.userCenterController.UserCenterAgent)
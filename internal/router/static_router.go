@@ -21,33 +21,44 @@ package router
 
 import (
 	"net/http"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/service/service_config"
+	"github.com/apache/answer/internal/service/uploader"
 	"github.com/apache/answer/pkg/dir"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
 )
 
 // StaticRouter static api router
 type StaticRouter struct {
-	serviceConfig *service_config.ServiceConfig
+	serviceConfig   *service_config.ServiceConfig
+	uploaderService uploader.UploaderService
 }
 
 // NewStaticRouter new static api router
-func NewStaticRouter(serviceConfig *service_config.ServiceConfig) *StaticRouter {
+func NewStaticRouter(serviceConfig *service_config.ServiceConfig, uploaderService uploader.UploaderService) *StaticRouter {
 	return &StaticRouter{
-		serviceConfig: serviceConfig,
+		serviceConfig:   serviceConfig,
+		uploaderService: uploaderService,
 	}
 }
 
 // RegisterStaticRouter register static api router
 func (a *StaticRouter) RegisterStaticRouter(r *gin.RouterGroup) {
-	r.Static("/uploads/"+constant.AvatarSubPath, filepath.Join(a.serviceConfig.UploadPath, constant.AvatarSubPath))
-	r.Static("/uploads/"+constant.AvatarThumbSubPath, filepath.Join(a.serviceConfig.UploadPath, constant.AvatarThumbSubPath))
-	r.Static("/uploads/"+constant.PostSubPath, filepath.Join(a.serviceConfig.UploadPath, constant.PostSubPath))
-	r.Static("/uploads/"+constant.BrandingSubPath, filepath.Join(a.serviceConfig.UploadPath, constant.BrandingSubPath))
+	for _, subPath := range []string{
+		constant.AvatarSubPath,
+		constant.AvatarThumbSubPath,
+		constant.PostSubPath,
+		constant.BrandingSubPath,
+	} {
+		handler := a.uploadFileHandler(subPath)
+		r.GET("/uploads/"+subPath+"/*filepath", handler)
+		r.HEAD("/uploads/"+subPath+"/*filepath", handler)
+	}
 	r.GET("/uploads/"+constant.FilesPostSubPath+"/*filepath", func(c *gin.Context) {
 		// The filepath such as hash/123.pdf
 		filePath := c.Param("filepath")
@@ -55,6 +66,16 @@ func (a *StaticRouter) RegisterStaticRouter(r *gin.RouterGroup) {
 		originalFilename := filepath.Base(filePath)
 		// The real filename is hash.pdf
 		realFilename := strings.TrimSuffix(filePath, "/"+originalFilename) + filepath.Ext(originalFilename)
+		// The file in the object storage is redirected to the signed URL that downloads it as the original filename
+		fileURL, err := a.uploaderService.StorageFileURL(c,
+			path.Join(constant.FilesPostSubPath, path.Clean("/"+realFilename)), originalFilename)
+		if err != nil {
+			log.Error(err)
+		}
+		if len(fileURL) > 0 {
+			c.Redirect(http.StatusFound, fileURL)
+			return
+		}
 		// The file local path is /uploads/files/post/hash.pdf
 		fileLocalPath := filepath.Join(a.serviceConfig.UploadPath, constant.FilesPostSubPath, realFilename)
 		// If the file is not exist, return 404
@@ -65,3 +86,29 @@ func (a *StaticRouter) RegisterStaticRouter(r *gin.RouterGroup) {
 		c.FileAttachment(fileLocalPath, originalFilename)
 	})
 }
+
+// uploadFileHandler serves the uploaded files of the sub path from the local disk,
+// the files that are in the object storage are redirected to it.
+// The resized variant of the image is served if it is requested by the size param, such as ?size=thumb or ?size=web.
+func (a *StaticRouter) uploadFileHandler(subPath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fileSubPath := path.Join(subPath, path.Clean("/"+c.Param("filepath")))
+		if size := c.Query("size"); len(size) > 0 {
+			fileSubPath = a.uploaderService.ImageVariantSubPath(c, fileSubPath, size)
+		}
+		fileURL, err := a.uploaderService.StorageFileURL(c, fileSubPath, "")
+		if err != nil {
+			log.Error(err)
+		}
+		if len(fileURL) > 0 {
+			c.Redirect(http.StatusFound, fileURL)
+			return
+		}
+		fileLocalPath := filepath.Join(a.serviceConfig.UploadPath, filepath.FromSlash(fileSubPath))
+		if !dir.CheckFileExist(fileLocalPath) {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		c.File(fileLocalPath)
+	}
+}
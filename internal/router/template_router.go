@@ -31,21 +31,26 @@ type TemplateRouter struct {
 	templateController       *controller.TemplateController
 	templateRenderController *templaterender.TemplateRenderController
 	siteInfoController       *controller_admin.SiteInfoController
+	feedController           *controller.FeedController
 	authUserMiddleware       *middleware.AuthUserMiddleware
+	conditionalGetMiddleware *middleware.ConditionalGetMiddleware
 }
 
 func NewTemplateRouter(
 	templateController *controller.TemplateController,
 	templateRenderController *templaterender.TemplateRenderController,
 	siteInfoController *controller_admin.SiteInfoController,
+	feedController *controller.FeedController,
 	authUserMiddleware *middleware.AuthUserMiddleware,
-
+	conditionalGetMiddleware *middleware.ConditionalGetMiddleware,
 ) *TemplateRouter {
 	return &TemplateRouter{
 		templateController:       templateController,
 		templateRenderController: templateRenderController,
 		siteInfoController:       siteInfoController,
+		feedController:           feedController,
 		authUserMiddleware:       authUserMiddleware,
+		conditionalGetMiddleware: conditionalGetMiddleware,
 	}
 }
 
@@ -62,13 +67,18 @@ func (a *TemplateRouter) RegisterTemplateRouter(r *gin.RouterGroup, baseURLPath
 
 	seoNoAuth.GET("/opensearch.xml", a.templateController.OpenSearch)
 
+	seoNoAuth.GET("/feed/:format/questions", a.feedController.QuestionFeed)
+	seoNoAuth.GET("/feed/:format/tags/:tag", a.feedController.TagFeed)
+	seoNoAuth.GET("/feed/:format/users/:username", a.feedController.UserFeed)
+
 	seo := r.Group(baseURLPath)
 	seo.Use(a.authUserMiddleware.CheckPrivateMode())
 	seo.GET("/", a.templateController.Index)
 	seo.GET("/questions", a.templateController.QuestionList)
-	seo.GET("/questions/:id", a.templateController.QuestionInfo)
-	seo.GET("/questions/:id/:title", a.templateController.QuestionInfo)
-	seo.GET("/questions/:id/:title/:answerid", a.templateController.QuestionInfo)
+	seo.GET("/questions/:id", a.conditionalGetMiddleware.ConditionalGet(), a.templateController.QuestionInfo)
+	seo.GET("/questions/:id/:title", a.conditionalGetMiddleware.ConditionalGet(), a.templateController.QuestionInfo)
+	seo.GET("/questions/:id/:title/:answerid", a.conditionalGetMiddleware.ConditionalGet(),
+		a.templateController.QuestionInfo)
 	seo.GET("/tags", a.templateController.TagList)
 	seo.GET("/tags/:tag", a.templateController.TagInfo)
 	seo.GET("/users/:username", a.templateController.UserInfo)
@@ -23,8 +23,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/service/mock"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -57,3 +60,17 @@ func TestUIRouter_FaviconWithNilBranding(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestUIRouter_RouteSegmentsReserved(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	(&UIRouter{}).Register(r, "")
+
+	for _, route := range r.Routes() {
+		segment, _, _ := strings.Cut(strings.TrimLeft(route.Path, "/"), "/")
+		if len(segment) == 0 {
+			continue
+		}
+		assert.True(t, slices.Contains(constant.ReservedRouteSegments, segment), route.Path)
+	}
+}
@@ -112,3 +112,12 @@ type PassReviewActivity struct {
 	OriginalObjectID string `json:"original_object_id"`
 	RevisionID       string `json:"revision_id"`
 }
+
+// FlagAcceptedActivity the flag of the object is accepted by the moderator
+type FlagAcceptedActivity struct {
+	// UserID the author of the flagged object
+	UserID string
+	// TriggerUserID the moderator who handles the flag
+	TriggerUserID string
+	ObjectID      string
+}
@@ -34,6 +34,7 @@ type AcceptAnswerOperationInfo struct {
 // AcceptAnswerActivity accept answer activity
 type AcceptAnswerActivity struct {
 	ActivityType     int
+	ActivityTypeKey  string
 	ActivityUserID   string
 	TriggerUserID    string
 	OriginalObjectID string
@@ -56,8 +56,10 @@ type AnswerAddReq struct {
 	CanRecover  bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-"`
-	UserAgent   string `json:"-"`
+	// CaptchaToken the token of the captcha widget of the third-party provider
+	CaptchaToken string `json:"captcha_token"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
 }
 
 func (req *AnswerAddReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -106,16 +108,27 @@ type AnswerUpdateResp struct {
 }
 
 type AnswerListReq struct {
-	QuestionID       string `json:"question_id" form:"question_id"`
-	Order            string `json:"order" form:"order"`
-	Page             int    `json:"page" form:"page"`
-	PageSize         int    `json:"page_size" form:"page_size"`
+	QuestionID string `json:"question_id" form:"question_id"`
+	Order      string `json:"order" form:"order"`
+	Page       int    `json:"page" form:"page"`
+	PageSize   int    `json:"page_size" form:"page_size"`
+	// Pagination the cursor pagination is used if it is cursor or the cursor is not empty, the page is ignored
+	Pagination string `json:"pagination" validate:"omitempty,oneof=offset cursor" form:"pagination"`
+	// Cursor the next cursor returned by the previous page, empty for the first page
+	Cursor           string `json:"cursor" validate:"omitempty,lte=512" form:"cursor"`
 	UserID           string `json:"-"`
 	IsAdmin          bool   `json:"-"`
 	IsAdminModerator bool   `json:"-"`
 	CanEdit          bool   `json:"-"`
 	CanDelete        bool   `json:"-"`
 	CanRecover       bool   `json:"-"`
+	// CanEditWiki the user can edit the community wiki answers
+	CanEditWiki bool `json:"-"`
+}
+
+// IsCursorPagination whether the client opts into the cursor pagination
+func (r *AnswerListReq) IsCursorPagination() bool {
+	return r.Pagination == PaginationCursor || len(r.Cursor) > 0
 }
 
 type AnswerInfo struct {
@@ -135,6 +148,8 @@ type AnswerInfo struct {
 	VoteCount      int               `json:"vote_count"`
 	QuestionInfo   *QuestionInfoResp `json:"question_info,omitempty"`
 	Status         int               `json:"status"`
+	Lock           *PostLockInfo     `json:"lock,omitempty"`
+	Wiki           bool              `json:"wiki"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
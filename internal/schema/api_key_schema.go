@@ -30,6 +30,7 @@ type GetAPIKeyResp struct {
 	AccessKey   string `json:"access_key"`
 	Description string `json:"description"`
 	Scope       string `json:"scope"`
+	RateLimit   int    `json:"rate_limit"`
 	CreatedAt   int64  `json:"created_at"`
 	LastUsedAt  int64  `json:"last_used_at"`
 }
@@ -58,3 +59,18 @@ type DeleteAPIKeyReq struct {
 	ID     int    `json:"id"`
 	UserID string `json:"-"`
 }
+
+// AddUserAPIKeyReq add the personal api key of the user request
+type AddUserAPIKeyReq struct {
+	Description string `validate:"required,notblank,lte=150" json:"description"`
+	Scope       string `validate:"required,oneof=read-only read-write" json:"scope"`
+	// RateLimit the max requests per minute, 0 means the default limit
+	RateLimit int    `validate:"omitempty,gte=0,lte=6000" json:"rate_limit"`
+	UserID    string `json:"-"`
+}
+
+// RevokeUserAPIKeyReq revoke the personal api key of the user request
+type RevokeUserAPIKeyReq struct {
+	ID     int    `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
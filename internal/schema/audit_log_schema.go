@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AuditLogMsg an administrative or moderation action need to be recorded
+type AuditLogMsg struct {
+	// ActorID who did this action, if it is empty, the login user of the request is used
+	ActorID    string
+	Action     string
+	ObjectType string
+	ObjectID   string
+	// Before and After are the state of the object before and after the action, they will be saved as json
+	Before any
+	After  any
+}
+
+// GetAuditLogPageReq get audit log page request
+type GetAuditLogPageReq struct {
+	Page       int    `validate:"omitempty,min=1" form:"page"`
+	PageSize   int    `validate:"omitempty,min=1" form:"page_size"`
+	ActorID    string `validate:"omitempty" form:"actor_id"`
+	Action     string `validate:"omitempty,lte=64" form:"action"`
+	ObjectType string `validate:"omitempty,lte=32" form:"object_type"`
+	ObjectID   string `validate:"omitempty,lte=64" form:"object_id"`
+	// StartTime and EndTime are unix timestamps in seconds
+	StartTime int64 `validate:"omitempty,min=0" form:"start_time"`
+	EndTime   int64 `validate:"omitempty,min=0" form:"end_time"`
+}
+
+// GetAuditLogResp audit log response
+type GetAuditLogResp struct {
+	ID         int64          `json:"id"`
+	CreatedAt  int64          `json:"created_at"`
+	ActorID    string         `json:"actor_id"`
+	Actor      *UserBasicInfo `json:"actor"`
+	Action     string         `json:"action"`
+	ObjectType string         `json:"object_type"`
+	ObjectID   string         `json:"object_id"`
+	Before     string         `json:"before"`
+	After      string         `json:"after"`
+	IP         string         `json:"ip"`
+}
@@ -170,8 +170,9 @@ type AddUserReq struct {
 // AddUsersReq add users request
 type AddUsersReq struct {
 	// users info line by line
-	UsersStr string        `json:"users"`
-	Users    []*AddUserReq `json:"-"`
+	UsersStr    string        `json:"users"`
+	Users       []*AddUserReq `json:"-"`
+	LoginUserID string        `json:"-"`
 }
 
 // DeletePermanentlyReq delete permanently request
@@ -242,6 +243,20 @@ type UpdateUserPasswordReq struct {
 	LoginUserID string `json:"-"`
 }
 
+// EraseUserReq delete the account of the user and remove the personal data of it
+type EraseUserReq struct {
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+}
+
+// RevokeUserSessionsReq revoke all the sessions of the user request
+type RevokeUserSessionsReq struct {
+	UserID string `validate:"required" json:"user_id"`
+	// RevokeAPIKeys remove the api keys of the user too
+	RevokeAPIKeys bool   `json:"revoke_api_keys"`
+	LoginUserID   string `json:"-"`
+}
+
 // GetUserActivationReq get user activation
 type GetUserActivationReq struct {
 	UserID string `validate:"required" form:"user_id"`
@@ -50,6 +50,8 @@ type BadgeListInfo struct {
 	AwardCount int `json:"award_count" `
 	// badge earned count
 	EarnedCount int64 `json:"earned_count" `
+	// badge is single or multiple
+	IsSingle bool `json:"is_single" `
 	// badge level
 	Level entity.BadgeLevel `json:"level" `
 }
@@ -98,6 +100,8 @@ type GetBadgeListPagedResp struct {
 	GroupName string `json:"group_name" `
 	// badge status
 	Status BadgeStatus `json:"status"`
+	// badge rule, only for the badges awarded by the declarative rule
+	Rule *entity.BadgeRule `json:"rule,omitempty"`
 }
 
 type GetBadgeInfoResp struct {
@@ -166,8 +170,10 @@ type GetUserBadgeAwardListResp struct {
 	Name string `json:"name" `
 	// badge icon
 	Icon string `json:"icon" `
-	// badge award count
+	// badge award count, it is at most 1 for the single badge
 	EarnedCount int64 `json:"earned_count" `
+	// badge is single or multiple
+	IsSingle bool `json:"is_single" `
 	// badge level
 	Level entity.BadgeLevel `json:"level" `
 }
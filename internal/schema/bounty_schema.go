@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AddQuestionBountyReq offer a bounty on a question
+type AddQuestionBountyReq struct {
+	QuestionID string `validate:"required,gt=0,lte=30" json:"question_id"`
+	Amount     int    `validate:"required,min=50,max=500" json:"amount"`
+	UserID     string `json:"-"`
+}
+
+// AwardQuestionBountyReq award the bounty of a question to an answer
+type AwardQuestionBountyReq struct {
+	QuestionID string `validate:"required,gt=0,lte=30" json:"question_id"`
+	AnswerID   string `validate:"required,gt=0,lte=30" json:"answer_id"`
+	UserID     string `json:"-"`
+}
+
+// GetQuestionBountyReq get the bounties of a question
+type GetQuestionBountyReq struct {
+	QuestionID string `validate:"required,gt=0,lte=30" form:"question_id"`
+}
+
+// QuestionBountyResp question bounty response
+type QuestionBountyResp struct {
+	ID              string         `json:"id"`
+	QuestionID      string         `json:"question_id"`
+	Amount          int            `json:"amount"`
+	Status          string         `json:"status"`
+	CreatedAt       int64          `json:"created_at"`
+	ExpiresAt       int64          `json:"expires_at"`
+	AwardedAnswerID string         `json:"awarded_answer_id"`
+	AwardedAmount   int            `json:"awarded_amount"`
+	UserInfo        *UserBasicInfo `json:"user_info"`
+	AwardedUserInfo *UserBasicInfo `json:"awarded_user_info,omitempty"`
+}
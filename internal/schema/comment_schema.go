@@ -210,6 +210,14 @@ type GetCommentResp struct {
 	ReplyCommentID string `json:"reply_comment_id"`
 	// reply user status
 	ReplyUserStatus string `json:"reply_user_status"`
+	// the id of the top-level comment of the thread, empty for the top-level comment
+	ParentID string `json:"parent_id"`
+	// the top-level comment is deleted, it is kept as a placeholder of its replies
+	Deleted bool `json:"deleted"`
+	// the replies of the top-level comment, the earlier replies come first
+	Replies []*GetCommentResp `json:"replies,omitempty"`
+	// the author is muted by the current user, the comment should be collapsed
+	Muted bool `json:"muted"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
@@ -221,6 +229,7 @@ func (r *GetCommentResp) SetFromComment(comment *entity.Comment) {
 	r.CreatedAt = comment.CreatedAt.Unix()
 	r.ReplyUserID = comment.GetReplyUserID()
 	r.ReplyCommentID = comment.GetReplyCommentID()
+	r.ParentID = comment.GetParentID()
 }
 
 // GetCommentPersonalWithPageReq get comment list page request
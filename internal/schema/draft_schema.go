@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// DraftTarget the target of the draft, the new question or the answer to the question
+type DraftTarget struct {
+	TargetType string `validate:"required,oneof=question answer" json:"target_type" form:"target_type"`
+	// QuestionID the question the answer belongs to, it is required if the target type is answer
+	QuestionID string `validate:"omitempty,lte=30" json:"question_id" form:"question_id"`
+	UserID     string `json:"-"`
+}
+
+func (t *DraftTarget) Check() (errFields []*validator.FormErrorField, err error) {
+	if t.TargetType != constant.DraftTargetAnswer {
+		t.QuestionID = ""
+		return nil, nil
+	}
+	if len(t.QuestionID) > 0 {
+		return nil, nil
+	}
+	errFields = append(errFields, &validator.FormErrorField{
+		ErrorField: "question_id",
+		ErrorMsg:   reason.RequestFormatError,
+	})
+	return errFields, errors.BadRequest(reason.RequestFormatError)
+}
+
+// GetDraftReq get the draft request
+type GetDraftReq struct {
+	DraftTarget
+}
+
+// SaveDraftReq auto-save the draft request
+type SaveDraftReq struct {
+	DraftTarget
+	Title   string   `validate:"omitempty,lte=150" json:"title"`
+	Content string   `validate:"omitempty,lte=65535" json:"content"`
+	Tags    []string `validate:"omitempty,lte=10,dive,lte=35" json:"tags"`
+}
+
+// RemoveDraftReq discard the draft request
+type RemoveDraftReq struct {
+	DraftTarget
+}
+
+// DraftResp draft response
+type DraftResp struct {
+	TargetType string   `json:"target_type"`
+	QuestionID string   `json:"question_id,omitempty"`
+	Title      string   `json:"title"`
+	Content    string   `json:"content"`
+	Tags       []string `json:"tags"`
+	// UpdatedAt the unix time the draft is saved
+	UpdatedAt int64 `json:"updated_at"`
+}
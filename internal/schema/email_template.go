@@ -26,11 +26,12 @@ import (
 )
 
 const (
-	AccountActivationSourceType EmailSourceType = "account-activation"
-	PasswordResetSourceType     EmailSourceType = "password-reset"
-	ConfirmNewEmailSourceType   EmailSourceType = "password-reset"
-	UnsubscribeSourceType       EmailSourceType = "unsubscribe"
-	BindingSourceType           EmailSourceType = "binding"
+	AccountActivationSourceType  EmailSourceType = "account-activation"
+	PasswordResetSourceType      EmailSourceType = "password-reset"
+	ConfirmNewEmailSourceType    EmailSourceType = "password-reset"
+	UnsubscribeSourceType        EmailSourceType = "unsubscribe"
+	BindingSourceType            EmailSourceType = "binding"
+	GuestQuestionClaimSourceType EmailSourceType = "guest-question-claim"
 )
 
 type EmailSourceType string
@@ -45,6 +46,10 @@ type EmailCodeContent struct {
 	BindingKey string `json:"binding_key,omitempty"`
 	// Skip the validation of the latest code
 	SkipValidationLatestCode bool `json:"skip_validation_latest_code"`
+	// Used for claiming the question asked as a guest
+	QuestionID string `json:"question_id,omitempty"`
+	// Used for changing the email, the pending change is cancelled if the password is changed
+	PassFingerprint string `json:"pass_fingerprint,omitempty"`
 }
 
 func (r *EmailCodeContent) ToJSONString() string {
@@ -66,11 +71,34 @@ type PassResetTemplateData struct {
 	PassResetUrl string
 }
 
+type UserDataExportTemplateData struct {
+	SiteName    string
+	DownloadUrl string
+}
+
+type ModerationWarningTemplateData struct {
+	SiteName string
+	Message  string
+}
+
+type GuestQuestionClaimTemplateData struct {
+	SiteName      string
+	QuestionTitle string
+	ClaimUrl      string
+}
+
 type ChangeEmailTemplateData struct {
 	SiteName       string
 	ChangeEmailUrl string
 }
 
+// ChangeEmailNoticeTemplateData the notice sent to the old address when the change of the email is requested
+type ChangeEmailNoticeTemplateData struct {
+	SiteName string
+	// NewEmail the masked new email address
+	NewEmail string
+}
+
 type TestTemplateData struct {
 	SiteName string
 }
@@ -143,3 +171,102 @@ type NewQuestionTemplateData struct {
 	Tags           string
 	UnsubscribeUrl string
 }
+
+type NewQuestionDigestTemplateRawData struct {
+	Questions       []*NewQuestionTemplateRawData
+	UnsubscribeCode string
+}
+
+type NewQuestionDigestTemplateData struct {
+	SiteName       string
+	QuestionCount  int
+	Questions      []*NewQuestionTemplateData
+	UnsubscribeUrl string
+}
+
+// SavedSearchAlertTemplateRawData the new content matching the saved search of the user
+type SavedSearchAlertTemplateRawData struct {
+	SearchName string
+	Query      string
+	Results    []*SearchResult
+}
+
+type SavedSearchAlertTemplateData struct {
+	SiteName    string
+	SearchName  string
+	ResultCount int
+	Results     []*SavedSearchAlertResultTemplateData
+	SearchUrl   string
+}
+
+type SavedSearchAlertResultTemplateData struct {
+	Title string
+	Url   string
+}
+
+// NotificationEventTemplateRawData the email of the notification events that have no dedicated template,
+// such as the mentions, the accepted answers and the earned badges
+type NotificationEventTemplateRawData struct {
+	Event                  constant.NotificationSource
+	NotificationAction     string
+	TriggerUserDisplayName string
+	// Title the title of the question, or the name of the badge
+	Title      string
+	QuestionID string
+	AnswerID   string
+	CommentID  string
+	BadgeID    string
+	// ObjectID the object of the in-app notification
+	ObjectID        string
+	UnsubscribeCode string
+}
+
+type NotificationEventTemplateData struct {
+	SiteName       string
+	Action         string
+	Title          string
+	Url            string
+	UnsubscribeUrl string
+}
+
+type NotificationDigestTemplateRawData struct {
+	Notifications   []*NotificationEventTemplateRawData
+	UnsubscribeCode string
+}
+
+type NotificationDigestTemplateData struct {
+	SiteName          string
+	NotificationCount int
+	Groups            []*NotificationDigestGroup
+	UnsubscribeUrl    string
+}
+
+// NotificationDigestGroup the notifications of the same event in the digest email
+type NotificationDigestGroup struct {
+	Name  string
+	Items []*NotificationEventTemplateData
+}
+
+// CustomEmailTemplate the email template customized by the admins for the template type in the language
+type CustomEmailTemplate struct {
+	Type     constant.EmailTemplateType `json:"type"`
+	Language string                     `json:"language"`
+	Subject  string                     `json:"subject"`
+	HTMLBody string                     `json:"html_body"`
+	// TextBody the plain text alternative of the html body, the email only has the html body if it is empty
+	TextBody string `json:"text_body"`
+}
+
+// CustomEmailTemplateData the values of the variables of the custom email templates, they are escaped when rendered as html
+type CustomEmailTemplateData struct {
+	SiteName string
+	// UserName the display name of the user who triggers the notification
+	UserName string
+	// PostTitle the title of the question, or the name of the badge
+	PostTitle string
+	// ActionUrl the link to the answer, the comment or the other object of the notification
+	ActionUrl string
+	// Summary the excerpt of the answer or the comment, or what happened for the other notifications
+	Summary        string
+	UnsubscribeUrl string
+}
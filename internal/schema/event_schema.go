@@ -20,10 +20,14 @@
 package schema
 
 import (
+	"strings"
+
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/pkg/uid"
 )
 
+const eventChangedFieldsKey = "changed_fields"
+
 // EventMsg event message
 type EventMsg struct {
 	EventType constant.EventType
@@ -99,6 +103,23 @@ func (e *EventMsg) GetExtra(key string) string {
 	return ""
 }
 
+// ChangedFields set the fields of the object changed by the event
+func (e *EventMsg) ChangedFields(fields ...string) *EventMsg {
+	if len(fields) > 0 {
+		e.ExtraInfo[eventChangedFieldsKey] = strings.Join(fields, ",")
+	}
+	return e
+}
+
+// GetChangedFields get the fields of the object changed by the event
+func (e *EventMsg) GetChangedFields() []string {
+	fields := e.GetExtra(eventChangedFieldsKey)
+	if len(fields) == 0 {
+		return nil
+	}
+	return strings.Split(fields, ",")
+}
+
 // GetObjectID get object id
 func (e *EventMsg) GetObjectID() string {
 	if len(e.TriggerObjectID) > 0 {
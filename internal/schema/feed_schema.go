@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+
+	FeedDefaultItemLimit = 20
+	FeedMaxItemLimit     = 100
+)
+
+// GetFeedReq get feed request
+type GetFeedReq struct {
+	// rss or atom
+	Format string `validate:"required,oneof=rss atom" uri:"format"`
+	// tag slug name, the feed of the tag
+	TagName string `validate:"omitempty,gt=0,lte=35" uri:"tag"`
+	// username, the activity feed of the user
+	Username string `validate:"omitempty,gt=0,lte=100" uri:"username"`
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/pkg/converter"
+)
+
+// AddGuestQuestionReq the question asked by a guest without logging in
+type AddGuestQuestionReq struct {
+	Title   string     `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Content string     `validate:"gte=0,lte=65535" json:"content"`
+	HTML    string     `json:"-"`
+	Tags    []*TagItem `validate:"dive" json:"tags"`
+	// Email the guest is told when the question is published and can claim it, it is optional
+	Email        string `validate:"omitempty,email,gt=0,lte=512" json:"email"`
+	CaptchaID    string `json:"captcha_id"`
+	CaptchaCode  string `json:"captcha_code"`
+	CaptchaToken string `json:"captcha_token"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+func (req *AddGuestQuestionReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.HTML = converter.Markdown2HTML(req.Content)
+	for _, tag := range req.Tags {
+		if len(tag.OriginalText) > 0 {
+			tag.ParsedText = converter.Markdown2HTML(tag.OriginalText)
+		}
+	}
+	return nil, nil
+}
+
+// AddGuestQuestionResp the question of the guest is shown after it is approved
+type AddGuestQuestionResp struct {
+	ID string `json:"id"`
+	// Pending the question is awaiting review
+	Pending bool `json:"pending"`
+}
+
+// ClaimGuestQuestionReq claim the question asked as a guest with the code in the email
+type ClaimGuestQuestionReq struct {
+	Code   string `validate:"required,gt=0,lte=500" json:"code"`
+	UserID string `json:"-"`
+}
+
+// ClaimGuestQuestionResp the claimed question
+type ClaimGuestQuestionResp struct {
+	QuestionID string `json:"question_id"`
+}
+
+// GuestQuestionMeta the contact of the guest saved in the meta of the question
+type GuestQuestionMeta struct {
+	Email string `json:"email"`
+	IP    string `json:"ip"`
+}
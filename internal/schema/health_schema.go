@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+	HealthStatusTimeout     = "timeout"
+	// HealthStatusSkipped the dependency is not used, such as the search plugin that is not enabled
+	HealthStatusSkipped = "skipped"
+
+	HealthCheckDatabase = "database"
+	HealthCheckCache    = "cache"
+	HealthCheckSearch   = "search"
+)
+
+// ReadinessResp readiness probe response
+type ReadinessResp struct {
+	// ok if all the dependencies are ready, otherwise unavailable
+	Status string `json:"status"`
+	// the status of each dependency
+	Checks map[string]*DependencyHealth `json:"checks"`
+}
+
+// DependencyHealth the status of the dependency
+type DependencyHealth struct {
+	// ok, unavailable, timeout or skipped
+	Status string `json:"status"`
+	// how long the check takes in milliseconds
+	Duration int64 `json:"duration_ms"`
+}
+
+// IsReady whether all the dependencies are ready, the skipped ones are not checked
+func (r *ReadinessResp) IsReady() bool {
+	for _, check := range r.Checks {
+		if check.Status != HealthStatusOK && check.Status != HealthStatusSkipped {
+			return false
+		}
+	}
+	return true
+}
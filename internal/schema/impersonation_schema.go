@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// StartImpersonationReq start viewing as the user request
+type StartImpersonationReq struct {
+	UserID string `validate:"required" json:"user_id"`
+	// AdminUserID the id of the admin who starts the impersonation
+	AdminUserID string `json:"-"`
+}
+
+// StartImpersonationResp start viewing as the user response, the access token is used instead of the admin one
+// until the impersonation is stopped
+type StartImpersonationResp struct {
+	AccessToken string `json:"access_token"`
+	VisitToken  string `json:"visit_token"`
+	// Mode read_only or full
+	Mode     string        `json:"mode"`
+	ExpireAt int64         `json:"expire_at"`
+	UserInfo UserBasicInfo `json:"user_info"`
+}
+
+// StopImpersonationReq stop viewing as the user request
+type StopImpersonationReq struct {
+	AccessToken string `json:"-"`
+	VisitToken  string `json:"-"`
+	// UserID the id of the impersonated user
+	UserID string `json:"-"`
+	// ImpersonatorID the id of the admin who started the impersonation
+	ImpersonatorID string `json:"-"`
+	Mode           string `json:"-"`
+}
+
+// ImpersonationInfo the impersonation of the current session, it is shown in the UI
+type ImpersonationInfo struct {
+	ImpersonatorID string `json:"impersonator_id"`
+	Mode           string `json:"mode"`
+	ExpireAt       int64  `json:"expire_at"`
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// EmailJob the email sent by the background job
+type EmailJob struct {
+	To       string `json:"to"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	TextBody string `json:"text_body,omitempty"`
+	// UnsubscribeToken the List-Unsubscribe headers are added if it is not empty
+	UnsubscribeToken string `json:"unsubscribe_token,omitempty"`
+}
+
+// WebhookDeliveryJob the webhook delivery run by the background job
+type WebhookDeliveryJob struct {
+	URL     string          `json:"url"`
+	Payload *WebhookPayload `json:"payload"`
+}
+
+// SearchIndexJob the search index of the question or the answer updated by the background job
+type SearchIndexJob struct {
+	ObjectID string `json:"object_id"`
+}
+
+// GetJobQueueStatusResp job queue status response
+type GetJobQueueStatusResp struct {
+	// the jobs of each type
+	Queues []*JobQueueStatus `json:"queues"`
+	// the total amount of the jobs waiting to run
+	Depth int64 `json:"depth"`
+	// the total amount of the jobs failed after all the attempts
+	DeadLetters int64 `json:"dead_letters"`
+}
+
+// JobQueueStatus the jobs of the type
+type JobQueueStatus struct {
+	Type string `json:"type"`
+	// waiting to run, including the ones will be retried
+	Pending int64 `json:"pending"`
+	// running now
+	Running int64 `json:"running"`
+	// failed before and waiting to be retried
+	Retrying int64 `json:"retrying"`
+	// failed after all the attempts
+	DeadLetters int64 `json:"dead_letters"`
+}
+
+// GetJobDeadLetterPageReq get job dead letter page request
+type GetJobDeadLetterPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// job type
+	Type string `validate:"omitempty,lte=64" form:"type"`
+}
+
+// GetJobDeadLetterResp job dead letter response
+type GetJobDeadLetterResp struct {
+	ID        int64  `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	JobID     int64  `json:"job_id"`
+	Type      string `json:"type"`
+	Payload   string `json:"payload"`
+	Attempts  int    `json:"attempts"`
+	Error     string `json:"error"`
+}
@@ -23,7 +23,7 @@ import "slices"
 
 type UpdateReactionReq struct {
 	ObjectID string `validate:"required" json:"object_id"`
-	Emoji    string `validate:"required,oneof=heart smile frown" json:"emoji"`
+	Emoji    string `validate:"required,gt=0,lte=35" json:"emoji"`
 	Reaction string `validate:"required,oneof=activate deactivate" json:"reaction"`
 	UserID   string `json:"-"`
 }
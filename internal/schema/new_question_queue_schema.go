@@ -33,6 +33,8 @@ type ExternalNotificationMsg struct {
 	NewInviteAnswerTemplateRawData *NewInviteAnswerTemplateRawData `json:"new_invite_answer_template_raw_data,omitempty"`
 	NewCommentTemplateRawData      *NewCommentTemplateRawData      `json:"new_comment_template_raw_data,omitempty"`
 	NewQuestionTemplateRawData     *NewQuestionTemplateRawData     `json:"new_question_template_raw_data,omitempty"`
+
+	NotificationEventTemplateRawData *NotificationEventTemplateRawData `json:"notification_event_template_raw_data,omitempty"`
 }
 
 func CreateNewQuestionNotificationMsg(
@@ -90,6 +90,8 @@ type NotificationMsg struct {
 	NotificationAction string
 	// if true no need to send notification to all followers
 	NoNeedPushAllFollow bool
+	// if true the notification is sent to the follower of the question, it is not controlled by the preferences
+	SentToFollower bool
 	// extra info
 	ExtraInfo map[string]string
 }
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// LockPostReq lock the question or the answer
+type LockPostReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	// Reason shown to the users why the post is locked
+	Reason string `validate:"omitempty,lte=500" json:"reason"`
+	// LockVote the post can not be voted either
+	LockVote bool   `json:"lock_vote"`
+	UserID   string `json:"-"`
+}
+
+// UnlockPostReq unlock the question or the answer
+type UnlockPostReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// PostLockMeta the lock of the post saved in the meta
+type PostLockMeta struct {
+	UserID   string `json:"user_id"`
+	Reason   string `json:"reason"`
+	LockVote bool   `json:"lock_vote"`
+	LockedAt int64  `json:"locked_at"`
+}
+
+// PostLockInfo the lock of the post shown to the users
+type PostLockInfo struct {
+	Reason   string `json:"reason"`
+	LockVote bool   `json:"lock_vote"`
+	LockedAt int64  `json:"locked_at"`
+}
+
+// ToInfo the lock shown to the users, nil if the post is not locked
+func (m *PostLockMeta) ToInfo() *PostLockInfo {
+	if m == nil {
+		return nil
+	}
+	return &PostLockInfo{Reason: m.Reason, LockVote: m.LockVote, LockedAt: m.LockedAt}
+}
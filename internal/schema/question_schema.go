@@ -53,7 +53,9 @@ type CloseQuestionReq struct {
 	ID        string `validate:"required" json:"id"`
 	CloseType int    `json:"close_type"` // close_type
 	CloseMsg  string `json:"close_msg"`  // close_type
-	UserID    string `json:"-"`          // user_id
+	// the original question when closing as a duplicate, the question url in close_msg also works
+	DuplicateQuestionID string `json:"duplicate_question_id"`
+	UserID              string `json:"-"` // user_id
 }
 
 type OperationQuestionReq struct {
@@ -62,11 +64,17 @@ type OperationQuestionReq struct {
 	UserID    string `json:"-"`         // user_id
 	CanPin    bool   `json:"-"`
 	CanList   bool   `json:"-"`
+
+	// Tag the slug name of the tag to pin or unpin the question within, it is pinned globally if empty
+	Tag string `validate:"omitempty,gt=0,lte=35" json:"tag"`
+	// PinExpiredAt the unix time the pin expires at, the pin never expires if it is 0
+	PinExpiredAt int64 `validate:"omitempty,min=0" json:"pin_expired_at"`
 }
 
 type CloseQuestionMeta struct {
-	CloseType int    `json:"close_type"`
-	CloseMsg  string `json:"close_msg"`
+	CloseType           int    `json:"close_type"`
+	CloseMsg            string `json:"close_msg"`
+	DuplicateQuestionID string `json:"duplicate_question_id,omitempty"`
 }
 
 // ReopenQuestionReq reopen question request
@@ -89,8 +97,15 @@ type QuestionAdd struct {
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
+	// CaptchaToken the token of the captcha widget of the third-party provider
+	CaptchaToken string `json:"captcha_token"`
+	// ScheduledAt the unix time the question is published at, only the admins and the moderators can schedule
+	ScheduledAt int64  `validate:"omitempty,gte=0" json:"scheduled_at"`
 	IP          string `json:"-"`
 	UserAgent   string `json:"-"`
+	// IsGuest the question is asked by a guest, it is always held for review
+	IsGuest    bool   `json:"-"`
+	GuestEmail string `json:"-"`
 }
 
 func (req *QuestionAdd) Check() (errFields []*validator.FormErrorField, err error) {
@@ -100,9 +115,45 @@ func (req *QuestionAdd) Check() (errFields []*validator.FormErrorField, err erro
 			tag.ParsedText = converter.Markdown2HTML(tag.OriginalText)
 		}
 	}
+	if req.ScheduledAt > 0 {
+		return checkQuestionScheduledAt(req.ScheduledAt)
+	}
 	return nil, nil
 }
 
+// UpdateQuestionScheduleReq change the publish time of the scheduled question request
+type UpdateQuestionScheduleReq struct {
+	ID string `validate:"required" json:"id"`
+	// ScheduledAt the unix time the question is published at
+	ScheduledAt      int64  `validate:"required,gt=0" json:"scheduled_at"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
+
+func (req *UpdateQuestionScheduleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	return checkQuestionScheduledAt(req.ScheduledAt)
+}
+
+// CancelQuestionScheduleReq cancel the schedule of the question request,
+// the question is kept unpublished until it is scheduled again
+type CancelQuestionScheduleReq struct {
+	ID               string `validate:"required" json:"id"`
+	UserID           string `json:"-"`
+	IsAdminModerator bool   `json:"-"`
+}
+
+// checkQuestionScheduledAt the publish time must be in the future
+func checkQuestionScheduledAt(scheduledAt int64) (errFields []*validator.FormErrorField, err error) {
+	if scheduledAt > time.Now().Unix() {
+		return nil, nil
+	}
+	errFields = append(errFields, &validator.FormErrorField{
+		ErrorField: "scheduled_at",
+		ErrorMsg:   reason.QuestionScheduleTimeInvalid,
+	})
+	return errFields, errors.BadRequest(reason.QuestionScheduleTimeInvalid)
+}
+
 type QuestionAddByAnswer struct {
 	// question title
 	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
@@ -120,8 +171,10 @@ type QuestionAddByAnswer struct {
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-"`
-	UserAgent   string `json:"-"`
+	// CaptchaToken the token of the captcha widget of the third-party provider
+	CaptchaToken string `json:"captcha_token"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
 }
 
 func (req *QuestionAddByAnswer) Check() (errFields []*validator.FormErrorField, err error) {
@@ -250,9 +303,13 @@ type QuestionInfoResp struct {
 	PostUpdateTime       int64          `json:"update_time"`
 	QuestionUpdateTime   int64          `json:"edit_time"`
 	Pin                  int            `json:"pin"`
+	PinExpiredAt         int64          `json:"pin_expired_at,omitempty"`
 	Show                 int            `json:"show"`
 	Status               int            `json:"status"`
+	ScheduledAt          int64          `json:"scheduled_at,omitempty"`
 	Operation            *Operation     `json:"operation,omitempty"`
+	Lock                 *PostLockInfo  `json:"lock,omitempty"`
+	Wiki                 bool           `json:"wiki"`
 	UserID               string         `json:"-"`
 	LastEditUserID       string         `json:"-"`
 	LastAnsweredUserID   string         `json:"-"`
@@ -306,6 +363,16 @@ type Operation struct {
 	Msg         string         `json:"msg"`
 	Time        int64          `json:"time"`
 	Level       OperationLevel `json:"level"`
+	// the original question when the question is closed as a duplicate
+	DuplicateQuestion *DuplicateQuestionInfo `json:"duplicate_question,omitempty"`
+}
+
+// DuplicateQuestionInfo the original question of a duplicate question
+type DuplicateQuestionInfo struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	UrlTitle string `json:"url_title"`
+	Status   int    `json:"status"`
 }
 
 type GetCloseTypeResp struct {
@@ -359,19 +426,32 @@ const (
 	QuestionOrderCondUnanswered = "unanswered"
 	QuestionOrderCondRecommend  = "recommend"
 	QuestionOrderCondFrequent   = "frequent"
+	QuestionOrderCondTrending   = "trending"
 
 	// HotInDays limit max days of the hottest question
 	HotInDays = 90
+	// TrendingInDays limit max days of the trending question, the score of the older ones is almost zero
+	TrendingInDays = 30
+)
+
+// the pagination of the question and the answer lists
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
 )
 
 // QuestionPageReq query questions page
 type QuestionPageReq struct {
 	Page      int    `validate:"omitempty,min=1" form:"page"`
 	PageSize  int    `validate:"omitempty,min=1" form:"page_size"`
-	OrderCond string `validate:"omitempty,oneof=newest active hot score unanswered recommend frequent" form:"order"`
+	OrderCond string `validate:"omitempty,oneof=newest active hot score unanswered recommend frequent trending" form:"order"`
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// Pagination the cursor pagination is used if it is cursor or the cursor is not empty, the page is ignored
+	Pagination string `validate:"omitempty,oneof=offset cursor" form:"pagination"`
+	// Cursor the next cursor returned by the previous page, empty for the first page
+	Cursor string `validate:"omitempty,lte=512" form:"cursor"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -379,6 +459,11 @@ type QuestionPageReq struct {
 	ShowPending      bool   `json:"-"`
 }
 
+// IsCursorPagination whether the client opts into the cursor pagination
+func (r *QuestionPageReq) IsCursorPagination() bool {
+	return r.Pagination == PaginationCursor || len(r.Cursor) > 0
+}
+
 const (
 	QuestionPageRespOperationTypeAsked    = "asked"
 	QuestionPageRespOperationTypeAnswered = "answered"
@@ -391,8 +476,9 @@ type QuestionPageResp struct {
 	Title       string     `json:"title"`
 	UrlTitle    string     `json:"url_title"`
 	Description string     `json:"description"`
-	Pin         int        `json:"pin"`  // 1: unpin, 2: pin
-	Show        int        `json:"show"` // 0: show, 1: hide
+	Pin         int        `json:"pin"`     // 1: unpin, 2: pin
+	TagPin      bool       `json:"tag_pin"` // pinned within the tag of the tag list
+	Show        int        `json:"show"`    // 0: show, 1: hide
 	Status      int        `json:"status"`
 	Tags        []*TagResp `json:"tags"`
 
@@ -489,6 +575,35 @@ type AdminUpdateQuestionStatusReq struct {
 	UserID     string `json:"-"`
 }
 
+// AdminDeletedPostPageReq admin deleted post page request
+type AdminDeletedPostPageReq struct {
+	ObjectType  string `validate:"required,oneof=question answer" form:"object_type"`
+	Page        int    `validate:"omitempty,min=1" form:"page"`
+	PageSize    int    `validate:"omitempty,min=1" form:"page_size"`
+	LoginUserID string `json:"-"`
+}
+
+// AdminDeletedPostInfo admin deleted post info
+type AdminDeletedPostInfo struct {
+	ID         string         `json:"id"`
+	ObjectType string         `json:"object_type"`
+	QuestionID string         `json:"question_id"`
+	Title      string         `json:"title"`
+	Excerpt    string         `json:"excerpt"`
+	UserID     string         `json:"-"`
+	UserInfo   *UserBasicInfo `json:"user_info"`
+	CreateTime int64          `json:"create_time"`
+	DeletedAt  int64          `json:"deleted_at"`
+	// PurgeAt the post will be deleted permanently after this time, 0 means never
+	PurgeAt int64 `json:"purge_at"`
+}
+
+// AdminRestoreDeletedPostReq admin restore deleted post request
+type AdminRestoreDeletedPostReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	UserID   string `json:"-"`
+}
+
 type PersonalQuestionPageReq struct {
 	Page        int    `validate:"omitempty,min=1" form:"page"`
 	PageSize    int    `validate:"omitempty,min=1" form:"page_size"`
@@ -517,7 +632,7 @@ type GetQuestionLinkReq struct {
 	Page       int    `validate:"omitempty,min=1" form:"page"`
 	PageSize   int    `validate:"omitempty,min=1,max=100" form:"page_size"`
 	QuestionID string `validate:"required" form:"question_id"`
-	OrderCond  string `validate:"omitempty,oneof=newest active hot score unanswered recommend frequent" form:"order"`
+	OrderCond  string `validate:"omitempty,oneof=newest active hot score unanswered recommend frequent trending" form:"order"`
 	InDays     int    `validate:"omitempty,min=1" form:"in_days"`
 
 	LoginUserID string `json:"-"`
@@ -526,3 +641,21 @@ type GetQuestionLinkReq struct {
 type GetQuestionLinkResp struct {
 	QuestionPageResp
 }
+
+// GetRelatedQuestionsReq get related questions request
+type GetRelatedQuestionsReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+	PageSize   int    `validate:"omitempty,min=1,max=20" form:"page_size"`
+}
+
+// RelatedQuestionResp related question response
+type RelatedQuestionResp struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	UrlTitle       string `json:"url_title"`
+	ViewCount      int    `json:"view_count"`
+	AnswerCount    int    `json:"answer_count"`
+	VoteCount      int    `json:"vote_count"`
+	AcceptedAnswer bool   `json:"accepted_answer"`
+	CreatedAt      int64  `json:"created_at"`
+}
@@ -54,3 +54,98 @@ type GetRankPersonalPageResp struct {
 	// rank type
 	RankType string `json:"rank_type"`
 }
+
+// GetReputationLedgerWithPageReq get reputation ledger page request
+type GetReputationLedgerWithPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// username
+	Username string `validate:"omitempty,gt=0,lte=100" form:"username"`
+	// only list the changes of this reason
+	Reason string `validate:"omitempty,oneof=upvote downvote accept bounty edit moderation reversal" form:"reason"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// GetReputationLedgerResp reputation ledger entry
+type GetReputationLedgerResp struct {
+	// the time of the change
+	CreatedAt int64 `json:"created_at"`
+	// reputation delta
+	Delta int `json:"delta"`
+	// reason of the change
+	Reason string `json:"reason" enums:"upvote,downvote,accept,bounty,edit,moderation,reversal,other"`
+	// readable activity of the change, for reversal it is the activity that was reversed
+	RankType string `json:"rank_type"`
+	// The reputation after this change. It is only returned without reason filter,
+	// the newest balance always equals the reputation of the user.
+	Balance *int `json:"balance,omitempty"`
+	// object id
+	ObjectID string `json:"object_id"`
+	// question id
+	QuestionID string `json:"question_id"`
+	// answer id
+	AnswerID string `json:"answer_id"`
+	// object type
+	ObjectType string `json:"object_type" enums:"question,answer,tag,comment"`
+	// title
+	Title string `json:"title"`
+	// url title
+	UrlTitle string `json:"url_title"`
+}
+
+// GetReputationLeaderboardReq get reputation leaderboard request
+type GetReputationLeaderboardReq struct {
+	// the period the reputation is gained in, default is all the time
+	Window string `validate:"omitempty,oneof=today week month all" form:"window"`
+	// only count the reputation gained from the questions with this tag and their answers
+	Tag string `validate:"omitempty,gt=0,lte=35" form:"tag"`
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// GetReputationLeaderboardResp reputation leaderboard entry
+type GetReputationLeaderboardResp struct {
+	// position in the leaderboard, starts from 1
+	Position int `json:"position"`
+	// username
+	Username string `json:"username"`
+	// display name
+	DisplayName string `json:"display_name"`
+	// avatar
+	Avatar string `json:"avatar"`
+	// the reputation of the user
+	Rank int `json:"rank"`
+	// the reputation gained in the window
+	ReputationGained int `json:"reputation_gained"`
+}
+
+// GetUserTagRankWithPageReq get the reputation of the user in the tags request
+type GetUserTagRankWithPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// username
+	Username string `validate:"omitempty,gt=0,lte=100" form:"username"`
+	// only get the reputation in this tag
+	Tag string `validate:"omitempty,gt=0,lte=35" form:"tag"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// GetUserTagRankResp the reputation of the user in the tag
+type GetUserTagRankResp struct {
+	// tag id
+	TagID string `json:"tag_id"`
+	// slug name
+	SlugName string `json:"slug_name"`
+	// display name
+	DisplayName string `json:"display_name"`
+	// the reputation gained from the votes of the questions with the tag and their answers
+	Rank int `json:"rank"`
+}
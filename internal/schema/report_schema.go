@@ -19,10 +19,14 @@
 
 package schema
 
+import "time"
+
 // AddReportReq add report request
 type AddReportReq struct {
-	// object id
+	// object id, it is the user id if the object type is user
 	ObjectID string `validate:"required,gt=0,lte=20" json:"object_id"`
+	// object type, it is only required when flagging a user
+	ObjectType string `validate:"omitempty,oneof=user" json:"object_type"`
 	// report type
 	ReportType int `validate:"required" json:"report_type"`
 	// report content
@@ -113,3 +117,81 @@ type ReviewReportReq struct {
 	UserID        string     `json:"-"`
 	IsAdmin       bool       `json:"-"`
 }
+
+// GetModerationQueueReq get moderation queue request
+type GetModerationQueueReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// ObjectType filter the items by the type of the flagged object
+	ObjectType string `validate:"omitempty,oneof=question answer comment user" form:"object_type"`
+	// ReportType filter the items by the reason of the flags
+	ReportType int `validate:"omitempty,min=1" form:"report_type"`
+	// MinAge and MaxAge filter the flags by the hours since they were raised
+	MinAge  int    `validate:"omitempty,min=0" form:"min_age"`
+	MaxAge  int    `validate:"omitempty,min=0" form:"max_age"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// ModerationQueueDTO moderation queue data transfer object
+type ModerationQueueDTO struct {
+	Page          int
+	PageSize      int
+	ObjectType    int
+	ReportType    int
+	FlaggedAfter  time.Time
+	FlaggedBefore time.Time
+}
+
+// ModerationQueueItem the flagged object with all its pending flags
+type ModerationQueueItem struct {
+	ObjectID       string                   `json:"object_id"`
+	ObjectType     string                   `json:"object_type" enums:"question,answer,comment,user"`
+	QuestionID     string                   `json:"question_id"`
+	AnswerID       string                   `json:"answer_id"`
+	CommentID      string                   `json:"comment_id"`
+	Title          string                   `json:"title"`
+	UrlTitle       string                   `json:"url_title"`
+	OriginalText   string                   `json:"original_text"`
+	AuthorUserInfo UserBasicInfo            `json:"author_user_info"`
+	FlagCount      int                      `json:"flag_count"`
+	Reasons        []*ModerationQueueReason `json:"reasons"`
+	Flags          []*ModerationQueueFlag   `json:"flags"`
+	FirstFlaggedAt int64                    `json:"first_flagged_at"`
+	LastFlaggedAt  int64                    `json:"last_flagged_at"`
+}
+
+// ModerationQueueReason the number of the flags with the reason
+type ModerationQueueReason struct {
+	Reason *ReasonItem `json:"reason"`
+	Count  int         `json:"count"`
+}
+
+// ModerationQueueFlag the flag of the moderation queue item
+type ModerationQueueFlag struct {
+	FlagID        string        `json:"flag_id"`
+	Reason        *ReasonItem   `json:"reason"`
+	ReasonContent string        `json:"reason_content"`
+	SubmitterUser UserBasicInfo `json:"submitter_user"`
+	SubmitAt      int64         `json:"submit_at"`
+}
+
+// ResolveModerationQueueItemReq resolve moderation queue item request
+type ResolveModerationQueueItemReq struct {
+	ObjectID      string     `validate:"required" json:"object_id"`
+	ObjectType    string     `validate:"required,oneof=question answer comment user" json:"object_type"`
+	OperationType string     `validate:"required,oneof=dismiss delete edit warn" json:"operation_type"`
+	Title         string     `validate:"omitempty,notblank,gte=6,lte=150" json:"title"`
+	Content       string     `validate:"omitempty,notblank,gte=6,lte=65535" json:"content"`
+	Tags          []*TagItem `validate:"omitempty,dive" json:"tags"`
+	// Message the warning sent to the author, it is required when the operation type is warn
+	Message string `validate:"omitempty,notblank,lte=1000" json:"message"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// ResolveModerationQueueItemResp resolve moderation queue item response
+type ResolveModerationQueueItemResp struct {
+	// ResolvedCount the number of the flags that are resolved
+	ResolvedCount int64 `json:"resolved_count"`
+}
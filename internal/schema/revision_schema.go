@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/pkg/diff"
 )
 
 // AddRevisionDTO add revision request
@@ -54,8 +55,10 @@ const RevisionAuditReject = "reject"
 
 type RevisionAuditReq struct {
 	// object id
-	ID                string `validate:"required" comment:"id" form:"id"`
-	Operation         string `validate:"required" comment:"operation" form:"operation"` // approve or reject
+	ID        string `validate:"required" comment:"id" form:"id"`
+	Operation string `validate:"required" comment:"operation" form:"operation"` // approve or reject
+	// the reason why the revision is rejected, it is sent to the submitter
+	Reason            string `validate:"omitempty,lte=255" json:"reason" form:"reason"`
 	UserID            string `json:"-"`
 	CanReviewQuestion bool   `json:"-"`
 	CanReviewAnswer   bool   `json:"-"`
@@ -105,6 +108,51 @@ type GetRevisionResp struct {
 	CreatedAtParsed int64         `json:"create_at"`
 	UserInfo        UserBasicInfo `json:"user_info"`
 	Log             string        `json:"reason"`
+	RejectReason    string        `json:"reject_reason"`
+}
+
+// GetRevisionDiffReq get the diff between two revisions of the object request
+type GetRevisionDiffReq struct {
+	// object id
+	ObjectID string `validate:"required" form:"object_id"`
+	// the older revision id
+	FromRevisionID string `validate:"required" form:"from"`
+	// the newer revision id
+	ToRevisionID string `validate:"required" form:"to"`
+	IsAdmin      bool   `json:"-"`
+	UserID       string `json:"-"`
+}
+
+// GetRevisionDiffResp get revision diff response
+type GetRevisionDiffResp struct {
+	From    *GetRevisionResp `json:"from"`
+	To      *GetRevisionResp `json:"to"`
+	Title   []*diff.Line     `json:"title"`
+	Content []*diff.Line     `json:"content"`
+	Tags    []*diff.Line     `json:"tags"`
+}
+
+// RollbackRevisionReq restore the object to the revision request
+type RollbackRevisionReq struct {
+	// revision id
+	ID string `validate:"required" json:"id"`
+	// why to roll back, the default log is used when empty
+	Log               string `validate:"omitempty,gt=0,lte=255" json:"reason"`
+	UserID            string `json:"-"`
+	CanReviewQuestion bool   `json:"-"`
+	CanReviewAnswer   bool   `json:"-"`
+	CanReviewTag      bool   `json:"-"`
+}
+
+// RollbackRevisionResp rollback revision response
+type RollbackRevisionResp struct {
+	// the new revision id
+	RevisionID string `json:"revision_id"`
+}
+
+// RevisionRollbackTplData template data as for translate the rollback log
+type RevisionRollbackTplData struct {
+	RevisionID string
 }
 
 // GetReviewingTypeReq get reviewing type request
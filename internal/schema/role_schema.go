@@ -24,4 +24,40 @@ type GetRoleResp struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// BuiltIn the built-in roles user, admin and moderator can not be modified or deleted
+	BuiltIn bool `json:"built_in"`
+	// Powers the permissions of the role
+	Powers []string `json:"powers"`
+}
+
+// GetPowerResp the permission that can be granted to the roles
+type GetPowerResp struct {
+	PowerType   string `json:"power_type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AddRoleReq add the custom role request
+type AddRoleReq struct {
+	Name        string   `validate:"required,notblank,gt=0,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	Powers      []string `validate:"omitempty,dive,required" json:"powers"`
+}
+
+// AddRoleResp add the custom role response
+type AddRoleResp struct {
+	ID int `json:"id"`
+}
+
+// UpdateRoleReq update the custom role request, the powers of the role are replaced
+type UpdateRoleReq struct {
+	ID          int      `validate:"required" json:"id"`
+	Name        string   `validate:"required,notblank,gt=0,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	Powers      []string `validate:"omitempty,dive,required" json:"powers"`
+}
+
+// RemoveRoleReq remove the custom role request
+type RemoveRoleReq struct {
+	ID int `validate:"required" json:"id"`
 }
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AddSavedSearchReq save the search query request
+type AddSavedSearchReq struct {
+	Name  string `validate:"required,gt=0,lte=100" json:"name"`
+	Query string `validate:"required,gte=1,lte=60" json:"query"`
+	Order string `validate:"omitempty,oneof=newest active score relevance" json:"order" enums:"newest,active,score,relevance"`
+	// AlertEnabled notify the user in the inbox when the new content matches the query
+	AlertEnabled bool `json:"alert_enabled"`
+	// AlertEmail also notify the user by email, only works when the alert is enabled
+	AlertEmail bool   `json:"alert_email"`
+	UserID     string `json:"-"`
+}
+
+// UpdateSavedSearchReq update the saved search request
+type UpdateSavedSearchReq struct {
+	ID           string `validate:"required" json:"id"`
+	Name         string `validate:"required,gt=0,lte=100" json:"name"`
+	Query        string `validate:"required,gte=1,lte=60" json:"query"`
+	Order        string `validate:"omitempty,oneof=newest active score relevance" json:"order" enums:"newest,active,score,relevance"`
+	AlertEnabled bool   `json:"alert_enabled"`
+	AlertEmail   bool   `json:"alert_email"`
+	UserID       string `json:"-"`
+}
+
+// RemoveSavedSearchReq remove the saved search request
+type RemoveSavedSearchReq struct {
+	ID     string `validate:"required" json:"id"`
+	UserID string `json:"-"`
+}
+
+// GetSavedSearchPageReq get the saved searches of the user request
+type GetSavedSearchPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// SavedSearchResp the saved search
+type SavedSearchResp struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// NormalizedQuery the query that is actually searched
+	NormalizedQuery string `json:"normalized_query"`
+	Order           string `json:"order"`
+	AlertEnabled    bool   `json:"alert_enabled"`
+	AlertEmail      bool   `json:"alert_email"`
+	// LastCheckedAt the unix time the alert checked the new content last time, zero if the alert is disabled
+	LastCheckedAt int64 `json:"last_checked_at"`
+	CreatedAt     int64 `json:"created_at"`
+}
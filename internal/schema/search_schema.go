@@ -39,12 +39,16 @@ type SearchDTO struct {
 }
 
 func (s *SearchDTO) Check() (errField []*validator.FormErrorField, err error) {
+	s.Query = NormalizeSearchQuery(s.Query)
+	return nil, nil
+}
+
+// NormalizeSearchQuery the query that is actually searched, the key:value pairs and the tags come first
+func NormalizeSearchQuery(query string) string {
 	// Replace special characters.
 	// Special characters will cause the search abnormal, such as search for "#" will get nearly all the content that Markdown format.
-	replacedContent, patterns := ReplaceSearchContent(s.Query)
-	s.Query = strings.Join(append(patterns, replacedContent), " ")
-
-	return nil, nil
+	replacedContent, patterns := ReplaceSearchContent(query)
+	return strings.Join(strings.Fields(strings.Join(append(patterns, replacedContent), " ")), " ")
 }
 
 func ReplaceSearchContent(content string) (string, []string) {
@@ -76,6 +80,8 @@ type SearchCondition struct {
 	UserID string
 	// vote amount
 	VoteAmount int
+	// VoteOperator the comparison of the votes: > >= or =, empty means equal to 0 for 0 and not less than for the others
+	VoteOperator string
 	// only show not accepted answer's question
 	NotAccepted bool
 	// view amount
@@ -90,6 +96,19 @@ type SearchCondition struct {
 	Tags [][]string
 	// search query keywords
 	Words []string
+	// created time range in unix seconds, the after is inclusive and the before is exclusive, zero means unlimited
+	CreatedAfter  int64
+	CreatedBefore int64
+	// Operators the operators in the search query
+	Operators []*SearchOperator
+}
+
+// SearchOperator the operator in the search query like: [tag], tag:go, user:me, score:>5, is:answered,
+// created:>2024-01-01, created:2024-01-01..2024-02-01, views:10, answers:0, hasaccepted:no, isaccepted:yes
+// and inquestion:1
+type SearchOperator struct {
+	Operator string `json:"operator" enums:"tag,user,score,created,views,answers,is,hasaccepted,isaccepted,inquestion"`
+	Value    string `json:"value"`
 }
 
 // SearchAll check if search all
@@ -110,16 +129,22 @@ func (s *SearchCondition) SearchAnswer() bool {
 // Convert2PluginSearchCond convert to plugin search condition
 func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order string) *plugin.SearchBasicCond {
 	basic := &plugin.SearchBasicCond{
-		Page:         page,
-		PageSize:     pageSize,
-		Words:        s.Words,
-		TagIDs:       s.Tags,
-		UserID:       s.UserID,
-		Order:        plugin.SearchOrderCond(order),
-		QuestionID:   s.QuestionID,
-		VoteAmount:   s.VoteAmount,
-		ViewAmount:   s.Views,
-		AnswerAmount: s.AnswerAmount,
+		Page:          page,
+		PageSize:      pageSize,
+		Words:         s.Words,
+		TagIDs:        s.Tags,
+		UserID:        s.UserID,
+		Order:         plugin.SearchOrderCond(order),
+		QuestionID:    s.QuestionID,
+		VoteAmount:    s.VoteAmount,
+		ViewAmount:    s.Views,
+		AnswerAmount:  s.AnswerAmount,
+		CreatedAfter:  s.CreatedAfter,
+		CreatedBefore: s.CreatedBefore,
+	}
+	// the plugin only supports not less than the votes
+	if s.VoteOperator == ">" {
+		basic.VoteAmount++
 	}
 	if s.Accepted {
 		basic.AnswerAccepted = plugin.AcceptedCondTrue
@@ -181,6 +206,8 @@ type SearchResp struct {
 	Total int64 `json:"count"`
 	// search response
 	SearchResults []*SearchResult `json:"list"`
+	// Operators the operators in the query that filter the results
+	Operators []*SearchOperator `json:"operators"`
 }
 
 type SearchDescResp struct {
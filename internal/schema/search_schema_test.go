@@ -39,3 +39,9 @@ func TestReplaceSearchContent(t *testing.T) {
 
 	assert.Equal(t, "user:aaa-sss score:3 [tag1] [tag2] ssssfdfdf as fsadf", ret)
 }
+
+func TestNormalizeSearchQuery(t *testing.T) {
+	assert.Equal(t, "score:3 [tag] hello world", NormalizeSearchQuery("  hello   [tag] world#  score:3 "))
+	assert.Equal(t, NormalizeSearchQuery("hello [tag] world"), NormalizeSearchQuery("[tag]  hello world"))
+	assert.Empty(t, NormalizeSearchQuery(" # "))
+}
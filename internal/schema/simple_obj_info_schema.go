@@ -43,6 +43,8 @@ type SimpleObjectInfo struct {
 	ObjectType            string `json:"object_type"`
 	Title                 string `json:"title"`
 	Content               string `json:"content"`
+	// Wiki the question or the answer is a community wiki post
+	Wiki bool `json:"wiki"`
 }
 
 // IsDeleted is deleted
@@ -109,6 +111,7 @@ func (s *SimpleObjectInfo) isObjectRestricted() bool {
 	case constant.QuestionObjectType:
 		return s.QuestionStatus == entity.QuestionStatusDeleted ||
 			s.QuestionStatus == entity.QuestionStatusPending ||
+			s.QuestionStatus == entity.QuestionStatusScheduled ||
 			s.QuestionShow == entity.QuestionHide
 	case constant.AnswerObjectType:
 		return s.AnswerStatus == entity.AnswerStatusDeleted || s.AnswerStatus == entity.AnswerStatusPending
@@ -124,6 +127,7 @@ func (s *SimpleObjectInfo) isObjectRestricted() bool {
 func (s *SimpleObjectInfo) isParentQuestionRestricted() bool {
 	return s.QuestionStatus == entity.QuestionStatusDeleted ||
 		s.QuestionStatus == entity.QuestionStatusPending ||
+		s.QuestionStatus == entity.QuestionStatusScheduled ||
 		s.QuestionShow == entity.QuestionHide
 }
 
@@ -22,16 +22,23 @@ package schema
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/mail"
 	"net/url"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/pkg/checker"
+	"github.com/apache/answer/pkg/s3"
+	"github.com/apache/answer/pkg/saml"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -44,16 +51,14 @@ type SiteGeneralReq struct {
 	ContactEmail     string `validate:"required,sanitizer,gt=1,lte=512,email" form:"contact_email" json:"contact_email"`
 }
 
+// FormatSiteUrl keep scheme://host:port/subpath as it is, only drop the query, fragment and trailing slash
 func (r *SiteGeneralReq) FormatSiteUrl() {
 	parsedUrl, err := url.Parse(r.SiteUrl)
 	if err != nil {
 		return
 	}
 	r.SiteUrl = fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
-	if len(parsedUrl.Path) > 0 {
-		r.SiteUrl += parsedUrl.Path
-		r.SiteUrl = strings.TrimSuffix(r.SiteUrl, "/")
-	}
+	r.SiteUrl += strings.TrimRight(parsedUrl.EscapedPath(), "/")
 }
 
 // SiteInterfaceReq site interface request
@@ -112,15 +117,29 @@ type SiteQuestionsReq struct {
 	MinimumTags    int  `validate:"omitempty,gte=0,lte=5" json:"min_tags"`
 	MinimumContent int  `validate:"omitempty,gte=0,lte=65535" json:"min_content"`
 	RestrictAnswer bool `validate:"omitempty" json:"restrict_answer"`
+	// TrendingHalfLife the hours after which the trending score of the question halves, 0 means 24
+	TrendingHalfLife int `validate:"omitempty,gte=0,lte=720" json:"trending_half_life"`
+	// MinimumTitle the minimum characters of the title, 0 means no limit except the length of the title field
+	MinimumTitle int `validate:"omitempty,gte=0,lte=150" json:"min_title"`
+	// RejectTitleAsContent reject the question whose body is only the title repeated
+	RejectTitleAsContent bool `validate:"omitempty" json:"reject_title_as_content"`
 }
 
 // SiteAdvancedReq site advanced settings request
 type SiteAdvancedReq struct {
 	MaxImageSize                   int      `validate:"omitempty,gt=0" json:"max_image_size"`
 	MaxAttachmentSize              int      `validate:"omitempty,gt=0" json:"max_attachment_size"`
+	MaxAvatarSize                  int      `validate:"omitempty,gt=0" json:"max_avatar_size"`
 	MaxImageMegapixel              int      `validate:"omitempty,gt=0" json:"max_image_megapixel"`
 	AuthorizedImageExtensions      []string `validate:"omitempty" json:"authorized_image_extensions"`
 	AuthorizedAttachmentExtensions []string `validate:"omitempty" json:"authorized_attachment_extensions"`
+	// the mime types detected from the content of the files, all types are allowed if it is empty
+	AuthorizedAvatarMimeTypes     []string `validate:"omitempty,dive,gt=0,lte=100" json:"authorized_avatar_mime_types"`
+	AuthorizedImageMimeTypes      []string `validate:"omitempty,dive,gt=0,lte=100" json:"authorized_image_mime_types"`
+	AuthorizedAttachmentMimeTypes []string `validate:"omitempty,dive,gt=0,lte=100" json:"authorized_attachment_mime_types"`
+	// the resized variants of the post images, in pixels
+	ThumbnailSize    int `validate:"omitempty,gt=0,lte=2048" json:"thumbnail_size"`
+	WebImageMaxWidth int `validate:"omitempty,gt=0,lte=8192" json:"web_image_max_width"`
 }
 
 // SiteTagsReq site tags settings request
@@ -145,6 +164,30 @@ func (s *SiteAdvancedResp) GetMaxAttachmentSize() int64 {
 	return int64(s.MaxAttachmentSize) * 1024 * 1024
 }
 
+// GetMaxAvatarSize the avatars use the limit of the images if it is not set
+func (s *SiteAdvancedResp) GetMaxAvatarSize() int64 {
+	if s.MaxAvatarSize <= 0 {
+		return s.GetMaxImageSize()
+	}
+	return int64(s.MaxAvatarSize) * 1024 * 1024
+}
+
+// GetThumbnailSize the max width and height of the thumbnails of the post images
+func (s *SiteAdvancedResp) GetThumbnailSize() int {
+	if s.ThumbnailSize <= 0 {
+		return constant.DefaultThumbnailSize
+	}
+	return s.ThumbnailSize
+}
+
+// GetWebImageMaxWidth the max width of the web version of the post images
+func (s *SiteAdvancedResp) GetWebImageMaxWidth() int {
+	if s.WebImageMaxWidth <= 0 {
+		return constant.DefaultWebImageMaxWidth
+	}
+	return s.WebImageMaxWidth
+}
+
 func (s *SiteAdvancedResp) GetMaxImageMegapixel() int {
 	if s.MaxImageMegapixel <= 0 {
 		return constant.DefaultMaxImageMegapixel
@@ -178,11 +221,21 @@ type SiteSecurityReq struct {
 	LoginRequired          bool   `json:"login_required"`
 	ExternalContentDisplay string `validate:"required,oneof=always_display ask_before_display" json:"external_content_display"`
 	CheckUpdate            bool   `validate:"omitempty,sanitizer" form:"check_update" json:"check_update"`
+	// Impersonation whether the admins can view as the users, read_only by default
+	Impersonation string `validate:"omitempty,oneof=disabled read_only full" json:"impersonation"`
 }
 
 type SitePoliciesResp SitePoliciesReq
 type SiteSecurityResp SiteSecurityReq
 
+// GetImpersonationMode get the mode of the impersonation sessions started by the admins
+func (r *SiteSecurityResp) GetImpersonationMode() string {
+	if len(r.Impersonation) == 0 {
+		return constant.ImpersonationModeReadOnly
+	}
+	return r.Impersonation
+}
+
 // GetSiteLegalInfoReq site site legal request
 type GetSiteLegalInfoReq struct {
 	InfoType string `validate:"required,oneof=tos privacy" form:"info_type"`
@@ -223,6 +276,12 @@ type SiteLoginReq struct {
 	AllowPasswordLogin       bool     `json:"allow_password_login"`
 	AllowEmailDomains        []string `json:"allow_email_domains"`
 	RequireEmailVerification *bool    `validate:"required" json:"require_email_verification" swaggertype:"boolean"`
+	// SessionIdleTimeout the minutes of inactivity after which the session expires, 0 means the default
+	SessionIdleTimeout int `validate:"omitempty,gte=5,lte=525600" json:"session_idle_timeout"`
+	// SessionAbsoluteTimeout the hours after login after which the session expires, 0 means the default
+	SessionAbsoluteTimeout int `validate:"omitempty,gte=1,lte=8760" json:"session_absolute_timeout"`
+	// RememberMeTimeout the days the session of a "remember me" login lasts, 0 means the default
+	RememberMeTimeout int `validate:"omitempty,gte=1,lte=365" json:"remember_me_timeout"`
 }
 
 // SiteLoginResp site login response
@@ -232,6 +291,28 @@ type SiteLoginResp struct {
 	AllowPasswordLogin       bool     `json:"allow_password_login"`
 	AllowEmailDomains        []string `json:"allow_email_domains"`
 	RequireEmailVerification bool     `json:"require_email_verification"`
+	SessionIdleTimeout       int      `json:"session_idle_timeout"`
+	SessionAbsoluteTimeout   int      `json:"session_absolute_timeout"`
+	RememberMeTimeout        int      `json:"remember_me_timeout"`
+}
+
+// GetSessionTimeout get the idle and the absolute timeout of the session
+func (s *SiteLoginResp) GetSessionTimeout(rememberMe bool) (idle, absolute time.Duration) {
+	if rememberMe {
+		timeout := constant.DefaultRememberMeTimeout
+		if s.RememberMeTimeout > 0 {
+			timeout = time.Duration(s.RememberMeTimeout) * 24 * time.Hour
+		}
+		return timeout, timeout
+	}
+	idle, absolute = constant.DefaultSessionIdleTimeout, constant.DefaultSessionAbsoluteTimeout
+	if s.SessionIdleTimeout > 0 {
+		idle = time.Duration(s.SessionIdleTimeout) * time.Minute
+	}
+	if s.SessionAbsoluteTimeout > 0 {
+		absolute = time.Duration(s.SessionAbsoluteTimeout) * time.Hour
+	}
+	return idle, absolute
 }
 
 // SiteCustomCssHTMLReq site custom css html
@@ -252,8 +333,9 @@ type SiteThemeReq struct {
 }
 
 type SiteSeoReq struct {
-	Permalink int    `validate:"required,lte=4,gte=0" form:"permalink" json:"permalink"`
-	Robots    string `validate:"required" form:"robots" json:"robots"`
+	Permalink     int    `validate:"required,lte=4,gte=0" form:"permalink" json:"permalink"`
+	Robots        string `validate:"required" form:"robots" json:"robots"`
+	FeedItemLimit int    `validate:"omitempty,gte=0,lte=100" form:"feed_item_limit" json:"feed_item_limit"`
 }
 
 func (s *SiteSeoResp) IsShortLink() bool {
@@ -261,6 +343,14 @@ func (s *SiteSeoResp) IsShortLink() bool {
 		s.Permalink == constant.PermalinkQuestionIDByShortID
 }
 
+// GetFeedItemLimit get the max number of the items in the feed
+func (s *SiteSeoResp) GetFeedItemLimit() int {
+	if s.FeedItemLimit <= 0 {
+		return FeedDefaultItemLimit
+	}
+	return min(s.FeedItemLimit, FeedMaxItemLimit)
+}
+
 // AIPromptConfig AI prompt configuration for different languages
 type AIPromptConfig struct {
 	ZhCN string `json:"zh_cn"`
@@ -311,6 +401,864 @@ type SiteMCPResp struct {
 	HTTPHeader string `json:"http_header"`
 }
 
+// SiteLDAPReq site LDAP login configuration request
+type SiteLDAPReq struct {
+	Enabled              bool   `validate:"omitempty" json:"enabled"`
+	ServerURL            string `validate:"omitempty,gt=0,lte=512" json:"server_url"`
+	StartTLS             bool   `validate:"omitempty" json:"start_tls"`
+	InsecureSkipVerify   bool   `validate:"omitempty" json:"insecure_skip_verify"`
+	BindDN               string `validate:"omitempty,gt=0,lte=512" json:"bind_dn"`
+	BindPassword         string `validate:"omitempty,gt=0,lte=256" json:"bind_password"`
+	BaseDN               string `validate:"omitempty,gt=0,lte=512" json:"base_dn"`
+	UserFilter           string `validate:"omitempty,gt=0,lte=1024" json:"user_filter"`
+	UsernameAttribute    string `validate:"omitempty,gt=0,lte=128" json:"username_attribute"`
+	EmailAttribute       string `validate:"omitempty,gt=0,lte=128" json:"email_attribute"`
+	DisplayNameAttribute string `validate:"omitempty,gt=0,lte=128" json:"display_name_attribute"`
+}
+
+func (r *SiteLDAPReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if !r.Enabled {
+		return nil, nil
+	}
+	serverURL, parseErr := url.Parse(r.ServerURL)
+	if parseErr != nil || len(serverURL.Hostname()) == 0 ||
+		(serverURL.Scheme != "ldap" && serverURL.Scheme != "ldaps") {
+		errField := &validator.FormErrorField{
+			ErrorField: "server_url",
+			ErrorMsg:   reason.LDAPServerURLInvalid,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.LDAPServerURLInvalid)
+	}
+	if len(r.BaseDN) == 0 {
+		errField := &validator.FormErrorField{
+			ErrorField: "base_dn",
+			ErrorMsg:   reason.LDAPBaseDNEmpty,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.LDAPBaseDNEmpty)
+	}
+	if len(r.UserFilter) > 0 && !strings.Contains(r.UserFilter, constant.LDAPLoginPlaceholder) {
+		errField := &validator.FormErrorField{
+			ErrorField: "user_filter",
+			ErrorMsg:   reason.LDAPUserFilterInvalid,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.LDAPUserFilterInvalid)
+	}
+	return nil, nil
+}
+
+// SiteLDAPResp site LDAP login configuration response
+type SiteLDAPResp SiteLDAPReq
+
+func (s *SiteLDAPResp) GetUserFilter() string {
+	if len(s.UserFilter) == 0 {
+		return constant.DefaultLDAPUserFilter
+	}
+	return s.UserFilter
+}
+
+func (s *SiteLDAPResp) GetUsernameAttribute() string {
+	if len(s.UsernameAttribute) == 0 {
+		return constant.DefaultLDAPUsernameAttribute
+	}
+	return s.UsernameAttribute
+}
+
+func (s *SiteLDAPResp) GetEmailAttribute() string {
+	if len(s.EmailAttribute) == 0 {
+		return constant.DefaultLDAPEmailAttribute
+	}
+	return s.EmailAttribute
+}
+
+func (s *SiteLDAPResp) GetDisplayNameAttribute() string {
+	if len(s.DisplayNameAttribute) == 0 {
+		return constant.DefaultLDAPDisplayNameAttribute
+	}
+	return s.DisplayNameAttribute
+}
+
+// SiteStorageReq site upload file storage configuration request
+type SiteStorageReq struct {
+	Type            string `validate:"omitempty,oneof=local s3" json:"type"`
+	Endpoint        string `validate:"omitempty,gt=0,lte=512" json:"endpoint"`
+	Region          string `validate:"omitempty,gt=0,lte=64" json:"region"`
+	Bucket          string `validate:"omitempty,gt=0,lte=255" json:"bucket"`
+	AccessKeyID     string `validate:"omitempty,gt=0,lte=256" json:"access_key_id"`
+	SecretAccessKey string `validate:"omitempty,gt=0,lte=256" json:"secret_access_key"`
+	PathPrefix      string `validate:"omitempty,gt=0,lte=256" json:"path_prefix"`
+	UsePathStyle    bool   `validate:"omitempty" json:"use_path_style"`
+	// PublicURL is the base URL of the bucket for public files, such as a CDN. Files are served by signed URLs if it is empty.
+	PublicURL string `validate:"omitempty,gt=0,lte=512" json:"public_url"`
+	// SignedURLExpiry is the validity of the signed URLs in seconds
+	SignedURLExpiry int `validate:"omitempty,gte=0,lte=604800" json:"signed_url_expiry"`
+}
+
+func (r *SiteStorageReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if r.Type != constant.StorageTypeS3 {
+		return nil, nil
+	}
+	endpoint, parseErr := url.Parse(r.Endpoint)
+	if parseErr != nil || len(endpoint.Hostname()) == 0 ||
+		(endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		errField := &validator.FormErrorField{
+			ErrorField: "endpoint",
+			ErrorMsg:   reason.StorageEndpointInvalid,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.StorageEndpointInvalid)
+	}
+	if len(r.Bucket) == 0 {
+		errField := &validator.FormErrorField{
+			ErrorField: "bucket",
+			ErrorMsg:   reason.StorageBucketEmpty,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.StorageBucketEmpty)
+	}
+	if len(r.PublicURL) > 0 {
+		publicURL, parseErr := url.Parse(r.PublicURL)
+		if parseErr != nil || len(publicURL.Hostname()) == 0 {
+			errField := &validator.FormErrorField{
+				ErrorField: "public_url",
+				ErrorMsg:   reason.StoragePublicURLInvalid,
+			}
+			errFields = append(errFields, errField)
+			return errFields, errors.BadRequest(reason.StoragePublicURLInvalid)
+		}
+	}
+	r.PathPrefix = strings.Trim(r.PathPrefix, "/")
+	return nil, nil
+}
+
+// SiteStorageResp site upload file storage configuration response
+type SiteStorageResp SiteStorageReq
+
+// IsObjectStorage whether the uploaded files are stored in the S3-compatible object storage
+func (s *SiteStorageResp) IsObjectStorage() bool {
+	return s.Type == constant.StorageTypeS3
+}
+
+// NewClient returns the client of the object storage
+func (s *SiteStorageResp) NewClient() *s3.Client {
+	region := s.Region
+	if len(region) == 0 {
+		region = constant.DefaultStorageRegion
+	}
+	return &s3.Client{
+		Endpoint:        s.Endpoint,
+		Region:          region,
+		Bucket:          s.Bucket,
+		AccessKeyID:     s.AccessKeyID,
+		SecretAccessKey: s.SecretAccessKey,
+		UsePathStyle:    s.UsePathStyle,
+	}
+}
+
+// ObjectKey returns the key of the uploaded file in the bucket, the file sub path such as post/hash.png is prefixed.
+func (s *SiteStorageResp) ObjectKey(fileSubPath string) string {
+	return strings.TrimPrefix(path.Join(s.PathPrefix, fileSubPath), "/")
+}
+
+// PublicObjectURL returns the URL of the file under the public URL, it is empty if the public URL is not configured.
+func (s *SiteStorageResp) PublicObjectURL(fileSubPath string) string {
+	if len(s.PublicURL) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(s.PublicURL, "/") + "/" + s.ObjectKey(fileSubPath)
+}
+
+func (s *SiteStorageResp) GetSignedURLExpiry() time.Duration {
+	if s.SignedURLExpiry <= 0 {
+		return constant.DefaultStorageSignedURLExpiry
+	}
+	return time.Duration(s.SignedURLExpiry) * time.Second
+}
+
+// SiteSAMLReq site SAML single sign-on configuration request
+type SiteSAMLReq struct {
+	Enabled              bool   `validate:"omitempty" json:"enabled"`
+	DisplayName          string `validate:"omitempty,gt=0,lte=64" json:"display_name"`
+	IdPEntityID          string `validate:"omitempty,gt=0,lte=512" json:"idp_entity_id"`
+	IdPSSOURL            string `validate:"omitempty,gt=0,lte=512,url" json:"idp_sso_url"`
+	IdPCertificate       string `validate:"omitempty,gt=0,lte=16384" json:"idp_certificate"`
+	AllowIdPInitiated    bool   `validate:"omitempty" json:"allow_idp_initiated"`
+	UsernameAttribute    string `validate:"omitempty,gt=0,lte=256" json:"username_attribute"`
+	EmailAttribute       string `validate:"omitempty,gt=0,lte=256" json:"email_attribute"`
+	DisplayNameAttribute string `validate:"omitempty,gt=0,lte=256" json:"display_name_attribute"`
+}
+
+func (r *SiteSAMLReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if !r.Enabled {
+		return nil, nil
+	}
+	if len(r.IdPSSOURL) == 0 {
+		errField := &validator.FormErrorField{
+			ErrorField: "idp_sso_url",
+			ErrorMsg:   reason.SAMLIdPSSOURLEmpty,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.SAMLIdPSSOURLEmpty)
+	}
+	if _, parseErr := saml.ParseCertificates(r.IdPCertificate); parseErr != nil {
+		errField := &validator.FormErrorField{
+			ErrorField: "idp_certificate",
+			ErrorMsg:   reason.SAMLIdPCertificateInvalid,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.SAMLIdPCertificateInvalid)
+	}
+	return nil, nil
+}
+
+// SiteSAMLResp site SAML single sign-on configuration response
+type SiteSAMLResp struct {
+	SiteSAMLReq
+	// SPEntityID, AcsURL and MetadataURL are generated from the site URL for registering at the IdP
+	SPEntityID  string `json:"sp_entity_id"`
+	AcsURL      string `json:"acs_url"`
+	MetadataURL string `json:"metadata_url"`
+}
+
+// SetServiceProviderURLs set the service provider URLs of the site
+func (s *SiteSAMLResp) SetServiceProviderURLs(siteURL string) {
+	s.MetadataURL = siteURL + constant.SAMLMetadataURLPath
+	s.SPEntityID = s.MetadataURL
+	s.AcsURL = siteURL + constant.SAMLAcsURLPath
+}
+
+func (s *SiteSAMLResp) GetDisplayName() string {
+	if len(s.DisplayName) == 0 {
+		return constant.DefaultSAMLDisplayName
+	}
+	return s.DisplayName
+}
+
+func (s *SiteSAMLResp) GetUsernameAttribute() string {
+	if len(s.UsernameAttribute) == 0 {
+		return constant.DefaultSAMLUsernameAttribute
+	}
+	return s.UsernameAttribute
+}
+
+func (s *SiteSAMLResp) GetEmailAttribute() string {
+	if len(s.EmailAttribute) == 0 {
+		return constant.DefaultSAMLEmailAttribute
+	}
+	return s.EmailAttribute
+}
+
+func (s *SiteSAMLResp) GetDisplayNameAttribute() string {
+	if len(s.DisplayNameAttribute) == 0 {
+		return constant.DefaultSAMLDisplayNameAttribute
+	}
+	return s.DisplayNameAttribute
+}
+
+// SiteWebhookReq site outgoing webhook configuration request
+type SiteWebhookReq struct {
+	Enabled bool     `validate:"omitempty" json:"enabled"`
+	URL     string   `validate:"omitempty,gt=0,lte=512,url" json:"url"`
+	Secret  string   `validate:"omitempty,gt=0,lte=256" json:"secret"`
+	Events  []string `validate:"omitempty,dive,oneof=question.created answer.created answer.accepted user.created user.updated user.banned user.role_changed" json:"events"`
+}
+
+func (r *SiteWebhookReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if !r.Enabled {
+		return nil, nil
+	}
+	if len(r.URL) == 0 {
+		errField := &validator.FormErrorField{
+			ErrorField: "url",
+			ErrorMsg:   reason.WebhookURLEmpty,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.WebhookURLEmpty)
+	}
+	if !strings.HasPrefix(r.URL, "http://") && !strings.HasPrefix(r.URL, "https://") {
+		errField := &validator.FormErrorField{
+			ErrorField: "url",
+			ErrorMsg:   reason.WebhookURLInvalid,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.WebhookURLInvalid)
+	}
+	return nil, nil
+}
+
+// SiteWebhookResp site outgoing webhook configuration response
+type SiteWebhookResp SiteWebhookReq
+
+// IsEventEnabled whether the webhook should be delivered for this event
+func (s *SiteWebhookResp) IsEventEnabled(event string) bool {
+	if !s.Enabled || len(s.URL) == 0 {
+		return false
+	}
+	// no events selected means all events
+	if len(s.Events) == 0 {
+		return true
+	}
+	return slices.Contains(s.Events, event)
+}
+
+// SiteSpamReq site spam check configuration request
+type SiteSpamReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+	// Threshold the posts whose score reaches it are considered as spam
+	Threshold int `validate:"omitempty,gte=1,lte=1000" json:"threshold"`
+	// AutoHold the spam posts are held for review if it is true, otherwise they are published and added to the review queue
+	AutoHold bool `validate:"omitempty" json:"auto_hold"`
+	// BypassReputation the posts of the users whose reputation reaches it are not checked
+	BypassReputation int `validate:"omitempty,gte=0" json:"bypass_reputation"`
+	// NewAccountDays the accounts registered within these days are considered as new
+	NewAccountDays int    `validate:"omitempty,gte=0,lte=365" json:"new_account_days"`
+	AkismetEnabled bool   `validate:"omitempty" json:"akismet_enabled"`
+	AkismetAPIKey  string `validate:"omitempty,gt=0,lte=64" json:"akismet_api_key"`
+}
+
+// SiteSpamResp site spam check configuration response
+type SiteSpamResp SiteSpamReq
+
+// GetThreshold get the spam score threshold
+func (s *SiteSpamResp) GetThreshold() int {
+	if s.Threshold <= 0 {
+		return constant.DefaultSpamThreshold
+	}
+	return s.Threshold
+}
+
+// GetBypassReputation get the reputation that bypasses the spam check
+func (s *SiteSpamResp) GetBypassReputation() int {
+	if s.BypassReputation <= 0 {
+		return constant.DefaultSpamBypassReputation
+	}
+	return s.BypassReputation
+}
+
+// GetNewAccountDays get the days in which the accounts are considered as new
+func (s *SiteSpamResp) GetNewAccountDays() int {
+	if s.NewAccountDays <= 0 {
+		return constant.DefaultSpamNewAccountDays
+	}
+	return s.NewAccountDays
+}
+
+// IsAkismetEnabled whether the content is checked by Akismet
+func (s *SiteSpamResp) IsAkismetEnabled() bool {
+	return s.AkismetEnabled && len(s.AkismetAPIKey) > 0
+}
+
+// SitePostingRestrictionReq site posting restriction configuration request,
+// the restrictions are applied to the new accounts and the users with low reputation
+type SitePostingRestrictionReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+	// TrustedReputation the users whose reputation reaches it are not restricted
+	TrustedReputation int `validate:"omitempty,gte=0" json:"trusted_reputation"`
+	// TrustedAccountDays the accounts registered for these days are not restricted
+	TrustedAccountDays int `validate:"omitempty,gte=0,lte=365" json:"trusted_account_days"`
+	// MaxPostsPerDay the max amount of the questions and answers in the last 24 hours, 0 means no limit
+	MaxPostsPerDay int `validate:"omitempty,gte=0,lte=1000" json:"max_posts_per_day"`
+	// MaxLinksPerPost the max amount of the links in a post, 0 means no limit
+	MaxLinksPerPost int `validate:"omitempty,gte=0,lte=100" json:"max_links_per_post"`
+	// PostIntervalSeconds the seconds the user must wait after the last post, 0 means no limit
+	PostIntervalSeconds int `validate:"omitempty,gte=0,lte=86400" json:"post_interval_seconds"`
+}
+
+// SitePostingRestrictionResp site posting restriction configuration response
+type SitePostingRestrictionResp SitePostingRestrictionReq
+
+// GetTrustedReputation get the reputation the users are not restricted with
+func (s *SitePostingRestrictionResp) GetTrustedReputation() int {
+	if s.TrustedReputation <= 0 {
+		return constant.DefaultPostingTrustedReputation
+	}
+	return s.TrustedReputation
+}
+
+// GetTrustedAccountDays get the days the accounts are not restricted after
+func (s *SitePostingRestrictionResp) GetTrustedAccountDays() int {
+	if s.TrustedAccountDays <= 0 {
+		return constant.DefaultPostingTrustedAccountDays
+	}
+	return s.TrustedAccountDays
+}
+
+// IsTrusted the user is not restricted if both the reputation and the age of the account reach the thresholds
+func (s *SitePostingRestrictionResp) IsTrusted(rank int, registeredAt time.Time) bool {
+	return rank >= s.GetTrustedReputation() &&
+		time.Since(registeredAt) >= time.Duration(s.GetTrustedAccountDays())*24*time.Hour
+}
+
+// SiteGuestPostingReq site guest posting configuration request.
+// The guests can ask questions with the captcha, all of them are held for review.
+type SiteGuestPostingReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+}
+
+// SiteGuestPostingResp site guest posting configuration response
+type SiteGuestPostingResp SiteGuestPostingReq
+
+// SiteAcceptReputationReq site accepted answer reputation configuration request.
+// The answerer always gains the reputation, the asker gains the bonus only if it is enabled.
+type SiteAcceptReputationReq struct {
+	AskerBonusEnabled bool `validate:"omitempty" json:"asker_bonus_enabled"`
+	// AnswererReputation the reputation the author of the accepted answer gains
+	AnswererReputation int `validate:"omitempty,gte=0,lte=1000" json:"answerer_reputation"`
+	// AskerReputation the reputation the asker gains for accepting an answer
+	AskerReputation int `validate:"omitempty,gte=0,lte=100" json:"asker_reputation"`
+}
+
+// SiteAcceptReputationResp site accepted answer reputation configuration response
+type SiteAcceptReputationResp SiteAcceptReputationReq
+
+// PostingRestrictionTrTplData the data of the posting restriction error messages
+type PostingRestrictionTrTplData struct {
+	Amount  int
+	Seconds int
+}
+
+// SiteCORSReq site CORS configuration request, it is applied to the API routes
+type SiteCORSReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+	// AllowedOrigins the origins such as https://example.com, "*" allows all the origins
+	AllowedOrigins   []string `validate:"omitempty,lte=100,dive,gt=0,lte=512" json:"allowed_origins"`
+	AllowedMethods   []string `validate:"omitempty,lte=20,dive,gt=0,lte=32" json:"allowed_methods"`
+	AllowedHeaders   []string `validate:"omitempty,lte=50,dive,gt=0,lte=128" json:"allowed_headers"`
+	ExposedHeaders   []string `validate:"omitempty,lte=50,dive,gt=0,lte=128" json:"exposed_headers"`
+	AllowCredentials bool     `validate:"omitempty" json:"allow_credentials"`
+	// MaxAge the seconds the result of the preflight request can be cached
+	MaxAge int `validate:"omitempty,gte=0,lte=86400" json:"max_age"`
+}
+
+func (r *SiteCORSReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for i, origin := range r.AllowedOrigins {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		r.AllowedOrigins[i] = origin
+		if origin == constant.CORSWildcardOrigin {
+			if !r.AllowCredentials {
+				continue
+			}
+			errField := &validator.FormErrorField{
+				ErrorField: "allowed_origins",
+				ErrorMsg:   reason.CORSWildcardWithCredentials,
+			}
+			errFields = append(errFields, errField)
+			return errFields, errors.BadRequest(reason.CORSWildcardWithCredentials)
+		}
+		u, parseErr := url.Parse(origin)
+		if parseErr != nil || len(u.Host) == 0 || (u.Scheme != "http" && u.Scheme != "https") ||
+			len(u.Path) > 0 || len(u.RawQuery) > 0 {
+			errField := &validator.FormErrorField{
+				ErrorField: "allowed_origins",
+				ErrorMsg:   reason.CORSOriginInvalid,
+			}
+			errFields = append(errFields, errField)
+			return errFields, errors.BadRequest(reason.CORSOriginInvalid)
+		}
+	}
+	for i, method := range r.AllowedMethods {
+		r.AllowedMethods[i] = strings.ToUpper(strings.TrimSpace(method))
+	}
+	return nil, nil
+}
+
+// SiteCORSResp site CORS configuration response
+type SiteCORSResp SiteCORSReq
+
+// IsOriginAllowed whether the origin is in the allow-list
+func (s *SiteCORSResp) IsOriginAllowed(origin string) bool {
+	for _, allowed := range s.AllowedOrigins {
+		if allowed == constant.CORSWildcardOrigin || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllowedMethods get the allowed methods of the cross-origin requests
+func (s *SiteCORSResp) GetAllowedMethods() []string {
+	if len(s.AllowedMethods) == 0 {
+		return constant.DefaultCORSAllowedMethods
+	}
+	return s.AllowedMethods
+}
+
+// GetAllowedHeaders get the allowed request headers of the cross-origin requests
+func (s *SiteCORSResp) GetAllowedHeaders() []string {
+	if len(s.AllowedHeaders) == 0 {
+		return constant.DefaultCORSAllowedHeaders
+	}
+	return s.AllowedHeaders
+}
+
+// GetMaxAge get the seconds the result of the preflight request can be cached
+func (s *SiteCORSResp) GetMaxAge() int {
+	if s.MaxAge <= 0 {
+		return constant.DefaultCORSMaxAge
+	}
+	return s.MaxAge
+}
+
+// SiteCaptchaReq site captcha configuration request, the captcha of the third-party provider is verified on
+// the registration and the password reset, and optionally on the first post of the users
+type SiteCaptchaReq struct {
+	Enabled   bool   `validate:"omitempty" json:"enabled"`
+	Provider  string `validate:"omitempty,oneof=hcaptcha recaptcha turnstile" json:"provider"`
+	SiteKey   string `validate:"omitempty,gt=0,lte=256" json:"site_key"`
+	SecretKey string `validate:"omitempty,gt=0,lte=256" json:"secret_key"`
+	FirstPost bool   `validate:"omitempty" json:"first_post"`
+}
+
+func (r *SiteCaptchaReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if !r.Enabled {
+		return nil, nil
+	}
+	if len(r.Provider) == 0 {
+		r.Provider = constant.CaptchaProviderHCaptcha
+	}
+	if len(r.SiteKey) == 0 || len(r.SecretKey) == 0 {
+		field := "site_key"
+		if len(r.SiteKey) > 0 {
+			field = "secret_key"
+		}
+		errField := &validator.FormErrorField{
+			ErrorField: field,
+			ErrorMsg:   reason.CaptchaKeysEmpty,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.CaptchaKeysEmpty)
+	}
+	return nil, nil
+}
+
+// SiteCaptchaResp site captcha configuration response
+type SiteCaptchaResp SiteCaptchaReq
+
+// IsSceneEnabled whether the captcha should be verified in the scene
+func (s *SiteCaptchaResp) IsSceneEnabled(scene string) bool {
+	if !s.Enabled || len(s.SecretKey) == 0 {
+		return false
+	}
+	if scene == constant.CaptchaSceneFirstPost {
+		return s.FirstPost
+	}
+	return true
+}
+
+// SiteCaptchaPublicResp the captcha configuration for the frontend to render the widget
+type SiteCaptchaPublicResp struct {
+	Provider  string `json:"provider"`
+	SiteKey   string `json:"site_key"`
+	FirstPost bool   `json:"first_post"`
+}
+
+// SiteMaintenanceReq site maintenance request, the site is read-only in the maintenance mode except for the admins
+type SiteMaintenanceReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+	// Message the message shown to the users when they try to change anything, the default one is used if empty
+	Message string `validate:"omitempty,lte=1000" json:"message"`
+}
+
+// SiteMaintenanceResp site maintenance response
+type SiteMaintenanceResp SiteMaintenanceReq
+
+// SlowQueryConfigReq slow query log request, the threshold overrides the one in the config file
+// and all the instances apply it within a minute
+type SlowQueryConfigReq struct {
+	// Threshold the milliseconds above which the queries are logged, 0 disables the slow query log
+	Threshold int `validate:"omitempty,min=0,max=600000" json:"threshold"`
+}
+
+// SlowQueryConfigResp slow query log response
+type SlowQueryConfigResp SlowQueryConfigReq
+
+// SiteAutoCloseReq site auto-close request, the questions without answers and activity for the days are
+// closed or archived by the background job. The archived questions are hidden from the lists.
+type SiteAutoCloseReq struct {
+	Enabled bool   `validate:"omitempty" json:"enabled"`
+	Days    int    `validate:"omitempty,min=1,max=3650" json:"days"`
+	Action  string `validate:"omitempty,oneof=close archive" json:"action"`
+	// Username the user the job acts as, it is recorded as the operator and posts the comments
+	Username string `validate:"omitempty,gt=0,lte=30" json:"username"`
+	// Comment the comment posted to the stale questions, nothing is posted if it is empty
+	Comment string `validate:"omitempty,lte=600" json:"comment"`
+	// ExemptTags the slug names of the tags whose questions are never closed or archived
+	ExemptTags []string `validate:"omitempty,dive,gt=0,lte=35" json:"exempt_tags"`
+}
+
+func (r *SiteAutoCloseReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if len(r.Action) == 0 {
+		r.Action = constant.AutoCloseActionClose
+	}
+	for i, tag := range r.ExemptTags {
+		r.ExemptTags[i] = strings.ToLower(strings.TrimSpace(tag))
+	}
+	if !r.Enabled {
+		return nil, nil
+	}
+	field := ""
+	if r.Days <= 0 {
+		field = "days"
+	} else if len(r.Username) == 0 {
+		field = "username"
+	}
+	if len(field) > 0 {
+		errField := &validator.FormErrorField{
+			ErrorField: field,
+			ErrorMsg:   reason.RequestFormatError,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.RequestFormatError)
+	}
+	return nil, nil
+}
+
+// SiteAutoCloseResp site auto-close response
+type SiteAutoCloseResp SiteAutoCloseReq
+
+// SiteReactionReq site reaction request, the users can only react to the posts with the allowed emojis
+type SiteReactionReq struct {
+	// Emojis the names of the allowed emojis, the default ones are allowed if empty
+	Emojis []string `validate:"omitempty,dive,gt=0,lte=35" json:"emojis"`
+}
+
+func (r *SiteReactionReq) Check() (errFields []*validator.FormErrorField, err error) {
+	emojis := make([]string, 0, len(r.Emojis))
+	for _, emoji := range r.Emojis {
+		emoji = strings.TrimSpace(emoji)
+		if len(emoji) > 0 && !slices.Contains(emojis, emoji) {
+			emojis = append(emojis, emoji)
+		}
+	}
+	r.Emojis = emojis
+	if len(r.Emojis) > constant.ReactionEmojiMaxAmount {
+		errField := &validator.FormErrorField{
+			ErrorField: "emojis",
+			ErrorMsg:   reason.RequestFormatError,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.RequestFormatError)
+	}
+	return nil, nil
+}
+
+// SiteReactionResp site reaction response
+type SiteReactionResp SiteReactionReq
+
+// GetEmojis get the allowed emojis
+func (r *SiteReactionResp) GetEmojis() []string {
+	if len(r.Emojis) == 0 {
+		return constant.DefaultReactionEmojis
+	}
+	return r.Emojis
+}
+
+// IsEmojiAllowed whether the users can react with the emoji
+func (r *SiteReactionResp) IsEmojiAllowed(emoji string) bool {
+	return slices.Contains(r.GetEmojis(), emoji)
+}
+
+// SiteUsernamePolicyReq site username policy request, the built-in policy is used if it is disabled.
+// The policy is checked when the usernames are registered or changed, the existing usernames are kept.
+type SiteUsernamePolicyReq struct {
+	Enabled bool `validate:"omitempty" json:"enabled"`
+	// AllowUnicode the letters and the digits of all the languages are allowed, otherwise only the ascii ones
+	AllowUnicode bool `validate:"omitempty" json:"allow_unicode"`
+	// AllowedSymbols the symbols allowed besides the letters and the digits, such as "_.- "
+	AllowedSymbols string `validate:"omitempty,lte=32" json:"allowed_symbols"`
+	MinLength      int    `validate:"omitempty,gte=1,lte=50" json:"min_length"`
+	MaxLength      int    `validate:"omitempty,gte=1,lte=50" json:"max_length"`
+	// ReservedUsernames they are reserved in addition to the built-in ones, they are compared case-insensitively
+	ReservedUsernames []string `validate:"omitempty,dive,gt=0,lte=50" json:"reserved_usernames"`
+}
+
+func (r *SiteUsernamePolicyReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if r.MinLength > 0 && r.MaxLength > 0 && r.MinLength > r.MaxLength {
+		errField := &validator.FormErrorField{
+			ErrorField: "min_length",
+			ErrorMsg:   reason.UsernamePolicyLengthInvalid,
+		}
+		errFields = append(errFields, errField)
+		return errFields, errors.BadRequest(reason.UsernamePolicyLengthInvalid)
+	}
+	reserved := make([]string, 0, len(r.ReservedUsernames))
+	for _, username := range r.ReservedUsernames {
+		username = strings.ToLower(strings.TrimSpace(username))
+		if len(username) > 0 && !slices.Contains(reserved, username) {
+			reserved = append(reserved, username)
+		}
+	}
+	r.ReservedUsernames = reserved
+	return nil, nil
+}
+
+// SiteUsernamePolicyResp site username policy response
+type SiteUsernamePolicyResp SiteUsernamePolicyReq
+
+// GetMinLength get the min length of the usernames
+func (s *SiteUsernamePolicyResp) GetMinLength() int {
+	if s.MinLength <= 0 {
+		return constant.DefaultUsernameMinLength
+	}
+	return s.MinLength
+}
+
+// GetMaxLength get the max length of the usernames
+func (s *SiteUsernamePolicyResp) GetMaxLength() int {
+	if s.MaxLength <= 0 {
+		return constant.DefaultUsernameMaxLength
+	}
+	return s.MaxLength
+}
+
+// ToPolicy get the policy the usernames are checked with, it is the built-in policy if the config is disabled
+func (s *SiteUsernamePolicyResp) ToPolicy() *checker.UsernamePolicy {
+	if !s.Enabled {
+		return &checker.UsernamePolicy{
+			AllowedSymbols: constant.DefaultUsernameAllowedSymbols,
+			MinLength:      constant.DefaultUsernameMinLength,
+			MaxLength:      constant.DefaultUsernameMaxLength,
+		}
+	}
+	return &checker.UsernamePolicy{
+		AllowUnicode:      s.AllowUnicode,
+		AllowedSymbols:    s.AllowedSymbols,
+		MinLength:         s.GetMinLength(),
+		MaxLength:         s.GetMaxLength(),
+		ReservedUsernames: s.ReservedUsernames,
+	}
+}
+
+// SiteRegistrationBlocklistReq site registration blocklist request, the registrations from the blocked
+// ip ranges or with the emails of the blocked domains are rejected
+type SiteRegistrationBlocklistReq struct {
+	// BlockedIPRanges the ip addresses or the CIDR ranges, such as 203.0.113.0/24
+	BlockedIPRanges      []string `validate:"omitempty,dive,gt=0,lte=64" json:"blocked_ip_ranges"`
+	BlockedEmailDomains  []string `validate:"omitempty,dive,gt=0,lte=256" json:"blocked_email_domains"`
+	BlockDisposableEmail bool     `validate:"omitempty" json:"block_disposable_email"`
+}
+
+func (r *SiteRegistrationBlocklistReq) Check() (errFields []*validator.FormErrorField, err error) {
+	for i, ipRange := range r.BlockedIPRanges {
+		r.BlockedIPRanges[i] = strings.TrimSpace(ipRange)
+		if parseIPRange(r.BlockedIPRanges[i]) == nil {
+			errField := &validator.FormErrorField{
+				ErrorField: "blocked_ip_ranges",
+				ErrorMsg:   reason.RegistrationBlocklistIPRangeInvalid,
+			}
+			errFields = append(errFields, errField)
+			return errFields, errors.BadRequest(reason.RegistrationBlocklistIPRangeInvalid)
+		}
+	}
+	r.BlockedEmailDomains = NormalizeEmailDomains(r.BlockedEmailDomains)
+	return nil, nil
+}
+
+// SiteRegistrationBlocklistResp site registration blocklist response
+type SiteRegistrationBlocklistResp struct {
+	BlockedIPRanges      []string `json:"blocked_ip_ranges"`
+	BlockedEmailDomains  []string `json:"blocked_email_domains"`
+	BlockDisposableEmail bool     `json:"block_disposable_email"`
+	// DisposableEmailDomains the bulk imported disposable email domains, they are blocked if BlockDisposableEmail is true
+	DisposableEmailDomains []string `json:"disposable_email_domains"`
+}
+
+// IsIPBlocked whether the ip is in one of the blocked ranges
+func (s *SiteRegistrationBlocklistResp) IsIPBlocked(ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, ipRange := range s.BlockedIPRanges {
+		if ipNet := parseIPRange(ipRange); ipNet != nil && ipNet.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmailBlocked whether the domain of the email or its parent domain is blocked
+func (s *SiteRegistrationBlocklistResp) IsEmailBlocked(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	blocked := func(domains []string) bool {
+		for _, d := range domains {
+			if domain == d || strings.HasSuffix(domain, "."+d) {
+				return true
+			}
+		}
+		return false
+	}
+	if blocked(s.BlockedEmailDomains) {
+		return true
+	}
+	return s.BlockDisposableEmail && blocked(s.DisposableEmailDomains)
+}
+
+// ImportDisposableEmailDomainsReq bulk import the disposable email domains request
+type ImportDisposableEmailDomainsReq struct {
+	// Domains the domains separated by lines or commas, the lines start with # are ignored
+	Domains string `validate:"required,notblank" json:"domains"`
+	// Replace replace the imported domains, otherwise the domains are appended to them
+	Replace bool `json:"replace"`
+}
+
+// ParseDomains get the domains in the request
+func (r *ImportDisposableEmailDomainsReq) ParseDomains() []string {
+	domains := make([]string, 0)
+	for _, line := range strings.Split(r.Domains, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, strings.Split(line, ",")...)
+	}
+	return NormalizeEmailDomains(domains)
+}
+
+// ImportDisposableEmailDomainsResp bulk import the disposable email domains response
+type ImportDisposableEmailDomainsResp struct {
+	// Imported the amount of the new domains
+	Imported int `json:"imported"`
+	// Total the amount of all the disposable email domains
+	Total int `json:"total"`
+}
+
+// NormalizeEmailDomains lowercase the domains, remove the leading @ and the duplicate ones
+func NormalizeEmailDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	seen := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+		if len(domain) == 0 || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		normalized = append(normalized, domain)
+	}
+	return normalized
+}
+
+// parseIPRange parse the CIDR range, a single ip address is a range that contains only itself
+func parseIPRange(ipRange string) *net.IPNet {
+	if _, ipNet, err := net.ParseCIDR(ipRange); err == nil {
+		return ipNet
+	}
+	ip := net.ParseIP(ipRange)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
 // SiteGeneralResp site general response
 type SiteGeneralResp SiteGeneralReq
 
@@ -356,6 +1304,14 @@ type SiteQuestionsResp SiteQuestionsReq
 type SiteAdvancedResp SiteAdvancedReq
 type SiteTagsResp SiteTagsReq
 
+// GetTrendingHalfLife get the half-life of the trending score
+func (s *SiteQuestionsResp) GetTrendingHalfLife() time.Duration {
+	if s.TrendingHalfLife <= 0 {
+		return constant.DefaultTrendingHalfLife * time.Hour
+	}
+	return time.Duration(min(s.TrendingHalfLife, constant.MaxTrendingHalfLife)) * time.Hour
+}
+
 // SiteLegalResp site write response use SitePoliciesResp and SiteSecurityResp instead
 type SiteLegalResp SiteLegalReq
 
@@ -383,10 +1339,15 @@ type SiteInfoResp struct {
 	Tags          *SiteTagsResp              `json:"site_tags"`
 	Legal         *SiteLegalSimpleResp       `json:"site_legal"`
 	Security      *SiteSecurityResp          `json:"site_security"`
+	Captcha       *SiteCaptchaPublicResp     `json:"captcha,omitempty"`
+	Maintenance   *SiteMaintenanceResp       `json:"maintenance,omitempty"`
+	Reaction      *SiteReactionResp          `json:"reaction"`
 	Version       string                     `json:"version"`
 	Revision      string                     `json:"revision"`
 	AIEnabled     bool                       `json:"ai_enabled"`
 	MCPEnabled    bool                       `json:"mcp_enabled"`
+	// GuestPostingEnabled the guests can ask questions without logging in
+	GuestPostingEnabled bool `json:"guest_posting_enabled"`
 }
 
 type TemplateSiteInfoResp struct {
@@ -401,6 +1362,13 @@ type TemplateSiteInfoResp struct {
 	JsonLD        string
 	Keywords      string
 	Description   string
+	// OGType the open graph type of the page, it is website if empty
+	OGType string
+	// OGImage the open graph image of the page, the site icon is used if empty
+	OGImage string
+	// PublishedTime ModifiedTime the RFC3339 times of the article page
+	PublishedTime string
+	ModifiedTime  string
 }
 
 // UpdateSMTPConfigReq get smtp config request
@@ -427,6 +1395,11 @@ func (r *UpdateSMTPConfigReq) Check() (errField []*validator.FormErrorField, err
 	return nil, nil
 }
 
+// SendTestEmailReq send test email request
+type SendTestEmailReq struct {
+	Email string `validate:"required,email,gt=0,lte=500" json:"email"`
+}
+
 // GetSMTPConfigResp get smtp config response
 type GetSMTPConfigResp struct {
 	FromEmail          string `json:"from_email"`
@@ -439,6 +1412,41 @@ type GetSMTPConfigResp struct {
 	SMTPAuthentication bool   `json:"smtp_authentication"`
 }
 
+// GetEmailTemplatesResp the custom email templates and the variables that can be used in them
+type GetEmailTemplatesResp struct {
+	Types     []constant.EmailTemplateType `json:"types"`
+	Variables []*EmailTemplateVariable     `json:"variables"`
+	Templates []*CustomEmailTemplate       `json:"templates"`
+}
+
+// EmailTemplateVariable the variable of the custom email templates, it is used as {{.Name}}
+type EmailTemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SaveEmailTemplateReq save the custom email template of the type in the language
+type SaveEmailTemplateReq struct {
+	Type     string `validate:"required,oneof=new_answer new_comment invited_you_to_answer new_question notification_event" json:"type"`
+	Language string `validate:"required,gt=0,lte=50" json:"language"`
+	Subject  string `validate:"required,gt=0,lte=500" json:"subject"`
+	HTMLBody string `validate:"required,gt=0,lte=65535" json:"html_body"`
+	TextBody string `validate:"omitempty,lte=65535" json:"text_body"`
+}
+
+func (r *SaveEmailTemplateReq) Check() (errField []*validator.FormErrorField, err error) {
+	if !translator.CheckLanguageIsValid(r.Language) {
+		return nil, errors.BadRequest(reason.LangNotFound)
+	}
+	return nil, nil
+}
+
+// RemoveEmailTemplateReq remove the custom email template, the default template of the language is used again
+type RemoveEmailTemplateReq struct {
+	Type     string `validate:"required,oneof=new_answer new_comment invited_you_to_answer new_question notification_event" json:"type"`
+	Language string `validate:"required,gt=0,lte=50" json:"language"`
+}
+
 // GetManifestJsonResp get manifest json response
 type GetManifestJsonResp struct {
 	ManifestVersion int                `json:"manifest_version"`
@@ -542,6 +1550,7 @@ var (
 		constant.RankCommentAddKey:                {1, 1, 1},
 		constant.RankReportAddKey:                 {1, 1, 1},
 		constant.RankCommentVoteUpKey:             {1, 1, 1},
+		constant.RankCommentVoteDownKey:           {1, 1, 1},
 		constant.RankLinkUrlLimitKey:              {1, 10, 10},
 		constant.RankQuestionVoteUpKey:            {1, 8, 15},
 		constant.RankAnswerVoteUpKey:              {1, 8, 15},
@@ -559,6 +1568,8 @@ var (
 		constant.RankTagAuditKey:                  {1, 2500, 5000},
 		constant.RankTagEditWithoutReviewKey:      {1, 10000, 20000},
 		constant.RankTagSynonymKey:                {1, 10000, 20000},
+		constant.RankQuestionBountyKey:            {75, 75, 75},
+		constant.RankPostWikiEditKey:              {1, 10, 20},
 	}
 )
 
@@ -21,8 +21,11 @@ package schema
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/validator"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/stretchr/testify/require"
@@ -70,3 +73,120 @@ func TestSiteLoginReqRequireEmailVerificationValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultPrivilegeOptionsCoverAllPrivileges(t *testing.T) {
+	for _, option := range DefaultPrivilegeOptions {
+		keys := make(map[string]bool)
+		for _, privilege := range option.Privileges {
+			require.GreaterOrEqual(t, privilege.Value, 1, privilege.Key)
+			keys[privilege.Key] = true
+		}
+		for _, privilege := range constant.RankAllPrivileges {
+			require.True(t, keys[privilege.Key], "level %d has no default for %s", option.Level, privilege.Key)
+		}
+	}
+}
+
+func TestSiteLoginRespGetSessionTimeout(t *testing.T) {
+	idle, absolute := (&SiteLoginResp{}).GetSessionTimeout(false)
+	require.Equal(t, constant.DefaultSessionIdleTimeout, idle)
+	require.Equal(t, constant.DefaultSessionAbsoluteTimeout, absolute)
+
+	config := &SiteLoginResp{SessionIdleTimeout: 30, SessionAbsoluteTimeout: 12, RememberMeTimeout: 60}
+	idle, absolute = config.GetSessionTimeout(false)
+	require.Equal(t, 30*time.Minute, idle)
+	require.Equal(t, 12*time.Hour, absolute)
+	idle, absolute = config.GetSessionTimeout(true)
+	require.Equal(t, 60*24*time.Hour, idle)
+	require.Equal(t, 60*24*time.Hour, absolute)
+}
+
+func TestSiteRegistrationBlocklistResp(t *testing.T) {
+	blocklist := &SiteRegistrationBlocklistResp{
+		BlockedIPRanges:        []string{"203.0.113.0/24", "198.51.100.7", "2001:db8::/32"},
+		BlockedEmailDomains:    []string{"spam.example"},
+		DisposableEmailDomains: []string{"mailinator.com"},
+	}
+	require.True(t, blocklist.IsIPBlocked("203.0.113.42"))
+	require.True(t, blocklist.IsIPBlocked("198.51.100.7"))
+	require.True(t, blocklist.IsIPBlocked("2001:db8::1"))
+	require.False(t, blocklist.IsIPBlocked("198.51.100.8"))
+	require.False(t, blocklist.IsIPBlocked("invalid"))
+
+	require.True(t, blocklist.IsEmailBlocked("a@spam.example"))
+	require.True(t, blocklist.IsEmailBlocked("a@Mail.SPAM.example"))
+	require.False(t, blocklist.IsEmailBlocked("a@notspam.example"))
+	require.False(t, blocklist.IsEmailBlocked("a@mailinator.com"))
+	blocklist.BlockDisposableEmail = true
+	require.True(t, blocklist.IsEmailBlocked("a@mailinator.com"))
+}
+
+func TestImportDisposableEmailDomainsReqParseDomains(t *testing.T) {
+	req := &ImportDisposableEmailDomainsReq{Domains: "# disposable domains\nMailinator.com\n\n@10minutemail.com, yopmail.com\nmailinator.com"}
+	require.Equal(t, []string{"mailinator.com", "10minutemail.com", "yopmail.com"}, req.ParseDomains())
+}
+
+func TestSiteRegistrationBlocklistReqCheck(t *testing.T) {
+	req := &SiteRegistrationBlocklistReq{BlockedIPRanges: []string{" 203.0.113.0/24 "}}
+	_, err := req.Check()
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.0/24", req.BlockedIPRanges[0])
+
+	req = &SiteRegistrationBlocklistReq{BlockedIPRanges: []string{"203.0.113.0/33"}}
+	_, err = req.Check()
+	require.Error(t, err)
+}
+
+func TestSiteAutoCloseReqCheck(t *testing.T) {
+	req := &SiteAutoCloseReq{ExemptTags: []string{" Go "}}
+	_, err := req.Check()
+	require.NoError(t, err)
+	require.Equal(t, constant.AutoCloseActionClose, req.Action)
+	require.Equal(t, "go", req.ExemptTags[0])
+
+	req = &SiteAutoCloseReq{Enabled: true, Days: 30}
+	errFields, err := req.Check()
+	require.Error(t, err)
+	require.Equal(t, "username", errFields[0].ErrorField)
+
+	req = &SiteAutoCloseReq{Enabled: true, Days: 30, Username: "admin", Action: constant.AutoCloseActionArchive}
+	_, err = req.Check()
+	require.NoError(t, err)
+}
+
+func TestSiteReactionReqCheck(t *testing.T) {
+	req := &SiteReactionReq{Emojis: []string{" tada ", "heart", "tada", ""}}
+	_, err := req.Check()
+	require.NoError(t, err)
+	require.Equal(t, []string{"tada", "heart"}, req.Emojis)
+
+	resp := &SiteReactionResp{}
+	require.True(t, resp.IsEmojiAllowed("heart"))
+	require.False(t, resp.IsEmojiAllowed("tada"))
+	resp.Emojis = req.Emojis
+	require.True(t, resp.IsEmojiAllowed("tada"))
+	require.False(t, resp.IsEmojiAllowed("smile"))
+
+	req = &SiteReactionReq{}
+	for i := range constant.ReactionEmojiMaxAmount + 1 {
+		req.Emojis = append(req.Emojis, fmt.Sprintf("emoji%d", i))
+	}
+	_, err = req.Check()
+	require.Error(t, err)
+}
+
+func TestSiteCORSReqCheck(t *testing.T) {
+	req := &SiteCORSReq{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+	_, err := req.Check()
+	require.Error(t, err)
+
+	req = &SiteCORSReq{AllowedOrigins: []string{"https://app.example.com/path"}}
+	_, err = req.Check()
+	require.Error(t, err)
+
+	req = &SiteCORSReq{AllowedOrigins: []string{" https://app.example.com/ ", "*"}, AllowedMethods: []string{"get"}}
+	_, err = req.Check()
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://app.example.com", "*"}, req.AllowedOrigins)
+	require.Equal(t, []string{"GET"}, req.AllowedMethods)
+}
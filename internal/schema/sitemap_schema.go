@@ -19,17 +19,30 @@
 
 package schema
 
-type SiteMapList struct {
-	QuestionIDs []*SiteMapQuestionInfo `json:"question_ids"`
-	MaxPageNum  []int                  `json:"max_page_num"`
+const (
+	// SitemapTypeQuestion and the other types are the prefixes of the sitemap file names, e.g. question-1.xml
+	SitemapTypeQuestion = "question"
+	SitemapTypeTag      = "tag"
+	SitemapTypeUser     = "user"
+	// SitemapTypeAll all the urls are in one sitemap if they are not more than the limit of a sitemap file
+	SitemapTypeAll = "all"
+)
+
+// SitemapURL the url in the sitemap, the path is joined with the site url when it is rendered
+type SitemapURL struct {
+	Path    string `json:"path"`
+	LastMod string `json:"lastmod"`
 }
 
-type SiteMapPageList struct {
-	PageData []*SiteMapQuestionInfo `json:"page_data"`
+// SitemapIndex the sitemap files generated by the schedule
+type SitemapIndex struct {
+	// Files the names of the sitemap files listed in the sitemap index, it is empty if all the urls are in one file
+	Files []string `json:"files"`
+	// GeneratedAt the unix time the sitemap is generated
+	GeneratedAt int64 `json:"generated_at"`
 }
 
-type SiteMapQuestionInfo struct {
-	ID         string `json:"id"`
-	Title      string `json:"title"`
-	UpdateTime string `json:"time"`
+// IsSingle all the urls are in one sitemap file, there is no need for the sitemap index
+func (s *SitemapIndex) IsSingle() bool {
+	return len(s.Files) == 0
 }
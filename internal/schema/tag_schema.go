@@ -314,10 +314,17 @@ type MergeTagReq struct {
 	SourceTagID string `validate:"required" json:"source_tag_id"`
 	// target tag id
 	TargetTagID string `validate:"required" json:"target_tag_id"`
+	// the description kept by the target tag, source or target, the longer one if empty
+	Description string `validate:"omitempty,oneof=source target" json:"description"`
 	// user id
 	UserID string `json:"-"`
 }
 
+const (
+	MergeTagKeepSourceDescription = "source"
+	MergeTagKeepTargetDescription = "target"
+)
+
 // MergeTagResp merge tag response
 type MergeTagResp struct {
 }
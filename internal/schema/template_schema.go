@@ -33,13 +33,16 @@ type QAPageJsonLD struct {
 	Context    string `json:"@context"`
 	Type       string `json:"@type"`
 	MainEntity struct {
-		Type        string    `json:"@type"`
-		Name        string    `json:"name"`
-		Text        string    `json:"text"`
-		AnswerCount int       `json:"answerCount"`
-		UpvoteCount int       `json:"upvoteCount"`
-		DateCreated time.Time `json:"dateCreated"`
-		Author      struct {
+		Type         string    `json:"@type"`
+		Name         string    `json:"name"`
+		Text         string    `json:"text"`
+		AnswerCount  int       `json:"answerCount"`
+		UpvoteCount  int       `json:"upvoteCount"`
+		URL          string    `json:"url"`
+		Image        string    `json:"image,omitempty"`
+		DateCreated  time.Time `json:"dateCreated"`
+		DateModified time.Time `json:"dateModified"`
+		Author       struct {
 			URL  string `json:"url"`
 			Type string `json:"@type"`
 			Name string `json:"name"`
@@ -50,12 +53,13 @@ type QAPageJsonLD struct {
 }
 
 type AcceptedAnswerItem struct {
-	Type        string    `json:"@type"`
-	Text        string    `json:"text"`
-	DateCreated time.Time `json:"dateCreated"`
-	UpvoteCount int       `json:"upvoteCount"`
-	URL         string    `json:"url"`
-	Author      struct {
+	Type         string    `json:"@type"`
+	Text         string    `json:"text"`
+	DateCreated  time.Time `json:"dateCreated"`
+	DateModified time.Time `json:"dateModified"`
+	UpvoteCount  int       `json:"upvoteCount"`
+	URL          string    `json:"url"`
+	Author       struct {
 		URL  string `json:"url"`
 		Type string `json:"@type"`
 		Name string `json:"name"`
@@ -63,12 +67,13 @@ type AcceptedAnswerItem struct {
 }
 
 type SuggestedAnswerItem struct {
-	Type        string    `json:"@type"`
-	Text        string    `json:"text"`
-	DateCreated time.Time `json:"dateCreated"`
-	UpvoteCount int       `json:"upvoteCount"`
-	URL         string    `json:"url"`
-	Author      struct {
+	Type         string    `json:"@type"`
+	Text         string    `json:"text"`
+	DateCreated  time.Time `json:"dateCreated"`
+	DateModified time.Time `json:"dateModified"`
+	UpvoteCount  int       `json:"upvoteCount"`
+	URL          string    `json:"url"`
+	Author       struct {
 		URL  string `json:"url"`
 		Type string `json:"@type"`
 		Name string `json:"name"`
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UserDataExportReq admin export the data of a user
+type UserDataExportReq struct {
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+}
+
+// UserDataExportDownloadReq download the export of the user data
+type UserDataExportDownloadReq struct {
+	Token string `validate:"required" form:"token"`
+}
+
+// UserDataExportFile the export file of the user data that is ready to be downloaded
+type UserDataExportFile struct {
+	UserID   string `json:"user_id"`
+	FileName string `json:"file_name"`
+	Content  []byte `json:"-"`
+}
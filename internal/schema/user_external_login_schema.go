@@ -85,6 +85,8 @@ type ExternalLoginOAuthState struct {
 	Provider string `json:"provider"`
 	Intent   string `json:"intent"`
 	UserID   string `json:"user_id,omitempty"`
+	// RequestID is the ID of the SAML authentication request sent with this state
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // ExternalLoginUnbindingReq external login unbinding user
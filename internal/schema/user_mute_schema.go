@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// MuteUserReq mute or unmute the user request
+type MuteUserReq struct {
+	Username string `validate:"required,gt=0,lte=30" json:"username"`
+	UserID   string `json:"-"`
+}
+
+// GetMutedUserPageReq get the users muted by the user request
+type GetMutedUserPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// MutedUserResp the muted user
+type MutedUserResp struct {
+	UserInfo *UserBasicInfo `json:"user_info"`
+	// MutedAt the unix time the user is muted
+	MutedAt int64 `json:"muted_at"`
+}
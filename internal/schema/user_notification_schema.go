@@ -29,6 +29,13 @@ import (
 type NotificationChannelConfig struct {
 	Key    constant.NotificationChannelKey `json:"key"`
 	Enable bool                            `json:"enable"`
+	// only for the new questions in the following tags: immediate, daily or weekly
+	Frequency string `validate:"omitempty,oneof=immediate daily weekly" json:"frequency,omitempty"`
+}
+
+// IsDigest the emails are batched into the digest instead of sending immediately
+func (n *NotificationChannelConfig) IsDigest() bool {
+	return n.Frequency == constant.NotificationFrequencyDaily || n.Frequency == constant.NotificationFrequencyWeekly
 }
 
 type NotificationChannels []*NotificationChannelConfig
@@ -87,6 +94,11 @@ func (n *NotificationConfig) Format() {
 		n.AllNewQuestionForFollowingTags.Key = constant.EmailChannel
 		n.AllNewQuestionForFollowingTags.Enable = false
 	}
+	if n.AllNewQuestionForFollowingTags.Frequency == "" {
+		n.AllNewQuestionForFollowingTags.Frequency = constant.NotificationFrequencyImmediate
+	}
+	n.Inbox.Frequency = ""
+	n.AllNewQuestion.Frequency = ""
 }
 
 // UpdateUserNotificationConfigReq update user notification config request
@@ -99,3 +111,82 @@ type UpdateUserNotificationConfigReq struct {
 type GetUserNotificationConfigResp struct {
 	NotificationConfig
 }
+
+// NotificationPreference the channels the user receives the notifications of the event from,
+// nothing is sent if both of them are disabled
+type NotificationPreference struct {
+	Event constant.NotificationSource `validate:"required,oneof=answer_to_my_question comment_on_my_post mention accepted badge_earned" json:"event"`
+	InApp bool                        `json:"in_app"`
+	Email bool                        `json:"email"`
+}
+
+// NewNotificationPreference get the preference of the event from all the notification configs of the user.
+// The in-app notifications are enabled by default. The emails of the answers and the comments follow the
+// inbox config until the user sets the preference, the emails of the other events are disabled by default.
+func NewNotificationPreference(source constant.NotificationSource,
+	configs []*entity.UserNotificationConfig) *NotificationPreference {
+	p := &NotificationPreference{Event: source, InApp: true}
+	var inbox *entity.UserNotificationConfig
+	for _, item := range configs {
+		switch item.Source {
+		case string(source):
+			for _, channel := range NewNotificationChannelsFormJson(item.Channels) {
+				switch channel.Key {
+				case constant.InAppChannel:
+					p.InApp = channel.Enable
+				case constant.EmailChannel:
+					p.Email = channel.Enable
+				}
+			}
+			return p
+		case string(constant.InboxSource):
+			inbox = item
+		}
+	}
+	if inbox != nil && (source == constant.AnswerToMyQuestionSource || source == constant.CommentOnMyPostSource) {
+		p.Email = NewNotificationChannelConfigFormJson(inbox.Channels).Enable
+	}
+	return p
+}
+
+// ToEntity convert the preference to the notification config of the user
+func (p *NotificationPreference) ToEntity(userID string) *entity.UserNotificationConfig {
+	channels := NotificationChannels{
+		{Key: constant.InAppChannel, Enable: p.InApp},
+		{Key: constant.EmailChannel, Enable: p.Email},
+	}
+	return &entity.UserNotificationConfig{
+		UserID:   userID,
+		Source:   string(p.Event),
+		Channels: channels.ToJsonString(),
+		Enabled:  p.InApp || p.Email,
+	}
+}
+
+// NewNotificationEmailFrequency get how often the user receives the emails of the notification events,
+// they are sent immediately by default, or batched into the hourly or daily digest
+func NewNotificationEmailFrequency(configs []*entity.UserNotificationConfig) string {
+	for _, item := range configs {
+		if item.Source != string(constant.NotificationDigestSource) {
+			continue
+		}
+		if frequency := NewNotificationChannelConfigFormJson(item.Channels).Frequency; len(frequency) > 0 {
+			return frequency
+		}
+	}
+	return constant.NotificationFrequencyImmediate
+}
+
+// GetNotificationPreferencesResp get notification preferences response
+type GetNotificationPreferencesResp struct {
+	Preferences []*NotificationPreference `json:"preferences"`
+	// immediate, hourly or daily
+	EmailFrequency string `json:"email_frequency"`
+}
+
+// UpdateNotificationPreferencesReq update notification preferences request, the events not in the list are unchanged
+type UpdateNotificationPreferencesReq struct {
+	Preferences    []*NotificationPreference `validate:"required,dive" json:"preferences"`
+	EmailFrequency string                    `validate:"omitempty,oneof=immediate hourly daily" json:"email_frequency"`
+	UserID         string                    `json:"-"`
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNotificationPreference(t *testing.T) {
+	inboxEnabled := &entity.UserNotificationConfig{
+		Source:   string(constant.InboxSource),
+		Channels: `[{"key":"email","enable":true}]`,
+	}
+
+	t.Run("defaults follow the inbox email for answers and comments", func(t *testing.T) {
+		configs := []*entity.UserNotificationConfig{inboxEnabled}
+		for _, source := range constant.NotificationPreferenceSources {
+			p := NewNotificationPreference(source, configs)
+			assert.Equal(t, source, p.Event)
+			assert.True(t, p.InApp)
+			expectEmail := source == constant.AnswerToMyQuestionSource || source == constant.CommentOnMyPostSource
+			assert.Equal(t, expectEmail, p.Email, source)
+		}
+	})
+
+	t.Run("no config at all", func(t *testing.T) {
+		p := NewNotificationPreference(constant.AnswerToMyQuestionSource, nil)
+		assert.True(t, p.InApp)
+		assert.False(t, p.Email)
+	})
+
+	t.Run("saved preference overrides the defaults", func(t *testing.T) {
+		saved := (&NotificationPreference{Event: constant.CommentOnMyPostSource}).ToEntity("1")
+		assert.False(t, saved.Enabled)
+		p := NewNotificationPreference(constant.CommentOnMyPostSource,
+			[]*entity.UserNotificationConfig{inboxEnabled, saved})
+		assert.False(t, p.InApp)
+		assert.False(t, p.Email)
+
+		saved = (&NotificationPreference{Event: constant.BadgeEarnedSource, Email: true}).ToEntity("1")
+		assert.True(t, saved.Enabled)
+		p = NewNotificationPreference(constant.BadgeEarnedSource, []*entity.UserNotificationConfig{saved})
+		assert.False(t, p.InApp)
+		assert.True(t, p.Email)
+	})
+}
+
+func TestNotificationActionPreferenceSource(t *testing.T) {
+	assert.Equal(t, constant.CommentOnMyPostSource, constant.NotificationActionPreferenceSource[constant.NotificationReplyToYou])
+	assert.Equal(t, constant.AcceptedSource, constant.NotificationActionPreferenceSource[constant.NotificationAcceptAnswer])
+	_, ok := constant.NotificationActionPreferenceSource[constant.NotificationUpVotedTheAnswer]
+	assert.False(t, ok)
+}
+
+func TestNewNotificationEmailFrequency(t *testing.T) {
+	assert.Equal(t, constant.NotificationFrequencyImmediate, NewNotificationEmailFrequency(nil))
+	assert.Equal(t, constant.NotificationFrequencyHourly, NewNotificationEmailFrequency([]*entity.UserNotificationConfig{
+		{Source: string(constant.InboxSource), Channels: `[{"key":"email","enable":true}]`},
+		{Source: string(constant.NotificationDigestSource), Channels: `[{"key":"email","enable":true,"frequency":"hourly"}]`},
+	}))
+}
@@ -101,6 +101,10 @@ type UserLoginResp struct {
 	VisitToken string `json:"visit_token"`
 	// suspended until timestamp
 	SuspendedUntil int64 `json:"suspended_until"`
+	// two-factor authentication is required to finish the login, the other fields are empty
+	TwoFactorRequired bool `json:"two_factor_required,omitempty"`
+	// two-factor challenge token used to finish the login
+	TwoFactorToken string `json:"two_factor_token,omitempty"`
 }
 
 func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -117,6 +121,8 @@ func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
 type GetCurrentLoginUserInfoResp struct {
 	*UserLoginResp
 	Avatar *AvatarInfo `json:"avatar"`
+	// Impersonation it is set if an admin is viewing as the user in the current session
+	Impersonation *ImpersonationInfo `json:"impersonation,omitempty"`
 }
 
 func (r *GetCurrentLoginUserInfoResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -215,15 +221,26 @@ type UserEmailLoginReq struct {
 	Pass        string `validate:"required,gte=8,lte=32" json:"pass"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	// RememberMe keep the login for longer, it lasts across browser restarts
+	RememberMe bool `json:"remember_me"`
+}
+
+// UserLogoutEverywhereReq log out all the sessions of the login user request
+type UserLogoutEverywhereReq struct {
+	// RevokeAPIKeys remove the personal api keys too
+	RevokeAPIKeys bool   `json:"revoke_api_keys"`
+	UserID        string `json:"-"`
 }
 
 // UserRegisterReq user register request
 type UserRegisterReq struct {
-	Name                     string `validate:"required,gte=2,lte=30" json:"name"`
-	Email                    string `validate:"required,email,gt=0,lte=500" json:"e_mail" `
-	Pass                     string `validate:"required,gte=8,lte=32" json:"pass"`
-	CaptchaID                string `json:"captcha_id"`
-	CaptchaCode              string `json:"captcha_code"`
+	Name        string `validate:"required,gte=2,lte=30" json:"name"`
+	Email       string `validate:"required,email,gt=0,lte=500" json:"e_mail" `
+	Pass        string `validate:"required,gte=8,lte=32" json:"pass"`
+	CaptchaID   string `json:"captcha_id"`
+	CaptchaCode string `json:"captcha_code"`
+	// CaptchaToken the token of the captcha widget of the third-party provider
+	CaptchaToken             string `json:"captcha_token"`
 	IP                       string `json:"-" `
 	RequireEmailVerification bool   `json:"-"`
 }
@@ -338,6 +355,8 @@ type UserRetrievePassWordRequest struct {
 	Email       string `validate:"required,email,gt=0,lte=500" json:"e_mail"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	// CaptchaToken the token of the captcha widget of the third-party provider
+	CaptchaToken string `json:"captcha_token"`
 }
 
 type UserRePassWordRequest struct {
@@ -358,7 +377,7 @@ func (u *UserRePassWordRequest) Check() (errFields []*validator.FormErrorField,
 }
 
 type ActionRecordReq struct {
-	Action string `validate:"required,oneof=email password edit_userinfo question answer comment edit invitation_answer search report delete vote" form:"action"`
+	Action string `validate:"required,oneof=email password edit_userinfo question answer comment edit invitation_answer search report delete vote guest_question" form:"action"`
 	IP     string `json:"-"`
 	UserID string `json:"-"`
 }
@@ -400,8 +419,8 @@ type UserChangeEmailSendCodeReq struct {
 }
 
 type UserChangeEmailVerifyReq struct {
-	Code    string `validate:"required,gt=0,lte=500" json:"code"`
-	Content string `json:"-"`
+	Code    string `validate:"required,gt=0,lte=500" json:"code" form:"code"`
+	Content string `json:"-" form:"-"`
 }
 
 type UserVerifyEmailSendReq struct {
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UserTwoFactorStatusResp user two-factor authentication status response
+type UserTwoFactorStatusResp struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// UserTwoFactorEnrollResp user two-factor authentication enrollment response
+type UserTwoFactorEnrollResp struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+	// RecoveryCodes only be shown once, the user should save them.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// UserTwoFactorActivateReq activate two-factor authentication with the first code of the authenticator
+type UserTwoFactorActivateReq struct {
+	Code   string `validate:"required,gte=6,lte=32" json:"code"`
+	UserID string `json:"-"`
+}
+
+// UserTwoFactorDisableReq disable two-factor authentication with a code or a recovery code
+type UserTwoFactorDisableReq struct {
+	Code   string `validate:"required,gte=6,lte=32" json:"code"`
+	UserID string `json:"-"`
+}
+
+// UserTwoFactorLoginReq finish the login with the two-factor challenge token and a code or a recovery code
+type UserTwoFactorLoginReq struct {
+	Token string `validate:"required,gt=0,lte=100" json:"token"`
+	Code  string `validate:"required,gte=6,lte=32" json:"code"`
+}
+
+// UserTwoFactorLoginChallenge the pending two-factor login saved in cache
+type UserTwoFactorLoginChallenge struct {
+	UserID     string `json:"user_id"`
+	RememberMe bool   `json:"remember_me"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+// ResetUserTwoFactorReq admin reset the two-factor authentication of a user
+type ResetUserTwoFactorReq struct {
+	UserID string `validate:"required" json:"user_id"`
+}
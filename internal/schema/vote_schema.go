@@ -54,10 +54,11 @@ type VoteOperationInfo struct {
 
 // VoteActivity vote activity
 type VoteActivity struct {
-	ActivityType   int
-	ActivityUserID string
-	TriggerUserID  string
-	Rank           int
+	ActivityType    int
+	ActivityTypeKey string
+	ActivityUserID  string
+	TriggerUserID   string
+	Rank            int
 }
 
 func (v *VoteActivity) HasRank() int {
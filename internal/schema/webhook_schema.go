@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// WebhookPayload the JSON body posted to the outgoing webhook
+type WebhookPayload struct {
+	Event      string `json:"event"`
+	DeliveryID string `json:"delivery_id"`
+	Timestamp  int64  `json:"timestamp"`
+	ObjectID   string `json:"object_id"`
+	ObjectType string `json:"object_type"`
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id,omitempty"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	// Author the author of the question or answer of the content events
+	Author *WebhookUser `json:"author,omitempty"`
+	// User the user of the user lifecycle events
+	User *WebhookUser `json:"user,omitempty"`
+	// Actor the user who triggered the user lifecycle events, such as the admin who banned the user
+	Actor *WebhookUser `json:"actor,omitempty"`
+	// ChangedFields the fields of the user changed by the user lifecycle events
+	ChangedFields []string `json:"changed_fields,omitempty"`
+	// Text and Content are a readable summary, so that the payload can be posted
+	// to Slack (text) and Discord (content) incoming webhooks directly
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// WebhookUser the user in the webhook payload
+type WebhookUser struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	URL         string `json:"url"`
+}
+
+// GetWebhookDeadLetterPageReq get webhook dead letter page request
+type GetWebhookDeadLetterPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// GetWebhookDeadLetterResp webhook dead letter response
+type GetWebhookDeadLetterResp struct {
+	ID         int64  `json:"id"`
+	CreatedAt  int64  `json:"created_at"`
+	DeliveryID string `json:"delivery_id"`
+	Event      string `json:"event"`
+	ObjectID   string `json:"object_id"`
+	URL        string `json:"url"`
+	Payload    string `json:"payload"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+	Attempts   int    `json:"attempts"`
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/segmentfault/pacman/log"
+)
+
+// captchaVerifyResp the response of the server side verification api, it is the same for all the providers
+type captchaVerifyResp struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// VerifyCaptchaToken verify the token of the captcha widget of the third-party provider in the scene.
+// It passes if the captcha is disabled or not required in the scene, otherwise the reason of the failure is returned.
+func (cs *CaptchaService) VerifyCaptchaToken(ctx context.Context, scene, userID, token, ip string) (
+	pass bool, failReason string) {
+	config, err := cs.siteInfoService.GetSiteCaptcha(ctx)
+	if err != nil {
+		log.Errorf("get captcha config failed, err: %v", err)
+		return false, reason.CaptchaProviderUnavailable
+	}
+	if !config.IsSceneEnabled(scene) {
+		return true, ""
+	}
+	// only the users who have never posted need to pass the captcha for the first post
+	if scene == constant.CaptchaSceneFirstPost {
+		user, exist, err := cs.userRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			log.Errorf("get user info failed, err: %v", err)
+			return false, reason.CaptchaProviderUnavailable
+		}
+		if exist && user.QuestionCount+user.AnswerCount > 0 {
+			return true, ""
+		}
+	}
+	if len(token) == 0 {
+		return false, reason.CaptchaTokenRequired
+	}
+
+	verifyURL, ok := cs.captchaVerifyURLs[config.Provider]
+	if !ok {
+		verifyURL = cs.captchaVerifyURLs[constant.CaptchaProviderHCaptcha]
+	}
+	form := url.Values{}
+	form.Set("secret", config.SecretKey)
+	form.Set("response", token)
+	if len(ip) > 0 {
+		form.Set("remoteip", ip)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		log.Errorf("create captcha verify request failed, err: %v", err)
+		return false, reason.CaptchaProviderUnavailable
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := cs.httpClient.Do(req)
+	if err != nil {
+		log.Errorf("request captcha verify api failed, err: %v", err)
+		return false, reason.CaptchaProviderUnavailable
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("captcha verify api returns status %d", resp.StatusCode)
+		return false, reason.CaptchaProviderUnavailable
+	}
+	result := &captchaVerifyResp{}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		log.Errorf("decode captcha verify response failed, err: %v", err)
+		return false, reason.CaptchaProviderUnavailable
+	}
+	if !result.Success {
+		log.Debugf("captcha token is rejected by %s, error codes: %v", config.Provider, result.ErrorCodes)
+		return false, reason.CaptchaTokenInvalid
+	}
+	return true, ""
+}
@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package action
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestCaptchaService(t *testing.T, config *schema.SiteCaptchaResp, verifyURL string) *CaptchaService {
+	ctrl := gomock.NewController(t)
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+	siteInfoService.EXPECT().GetSiteCaptcha(gomock.Any()).Return(config, nil).AnyTimes()
+	return &CaptchaService{
+		siteInfoService:   siteInfoService,
+		httpClient:        http.DefaultClient,
+		captchaVerifyURLs: map[string]string{config.Provider: verifyURL},
+	}
+}
+
+func TestCaptchaService_VerifyCaptchaToken(t *testing.T) {
+	var secret, response, remoteIP string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		secret, response, remoteIP = r.PostForm.Get("secret"), r.PostForm.Get("response"), r.PostForm.Get("remoteip")
+		if response == "good" {
+			_, _ = w.Write([]byte(`{"success":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	cs := newTestCaptchaService(t, &schema.SiteCaptchaResp{Enabled: true, Provider: constant.CaptchaProviderTurnstile,
+		SiteKey: "site", SecretKey: "secret"}, server.URL)
+	ctx := context.TODO()
+
+	pass, failReason := cs.VerifyCaptchaToken(ctx, constant.CaptchaSceneRegister, "", "good", "127.0.0.1")
+	assert.True(t, pass)
+	assert.Empty(t, failReason)
+	assert.Equal(t, "secret", secret)
+	assert.Equal(t, "127.0.0.1", remoteIP)
+
+	pass, failReason = cs.VerifyCaptchaToken(ctx, constant.CaptchaScenePasswordReset, "", "bad", "")
+	assert.False(t, pass)
+	assert.Equal(t, reason.CaptchaTokenInvalid, failReason)
+
+	pass, failReason = cs.VerifyCaptchaToken(ctx, constant.CaptchaSceneRegister, "", "", "")
+	assert.False(t, pass)
+	assert.Equal(t, reason.CaptchaTokenRequired, failReason)
+
+	// the first post is not protected
+	pass, _ = cs.VerifyCaptchaToken(ctx, constant.CaptchaSceneFirstPost, "1", "", "")
+	assert.True(t, pass)
+}
+
+func TestCaptchaService_VerifyCaptchaTokenDisabled(t *testing.T) {
+	cs := newTestCaptchaService(t, &schema.SiteCaptchaResp{Provider: constant.CaptchaProviderHCaptcha}, "")
+	pass, _ := cs.VerifyCaptchaToken(context.TODO(), constant.CaptchaSceneRegister, "", "", "")
+	assert.True(t, pass)
+}
+
+func TestCaptchaService_VerifyCaptchaTokenUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cs := newTestCaptchaService(t, &schema.SiteCaptchaResp{Enabled: true, Provider: constant.CaptchaProviderReCaptcha,
+		SiteKey: "site", SecretKey: "secret"}, server.URL)
+	pass, failReason := cs.VerifyCaptchaToken(context.TODO(), constant.CaptchaSceneRegister, "", "token", "")
+	assert.False(t, pass)
+	assert.Equal(t, reason.CaptchaProviderUnavailable, failReason)
+}
@@ -21,9 +21,13 @@ package action
 
 import (
 	"context"
+	"net/http"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/log"
@@ -41,13 +45,25 @@ type CaptchaRepo interface {
 
 // CaptchaService kit service
 type CaptchaService struct {
-	captchaRepo CaptchaRepo
+	captchaRepo       CaptchaRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	userRepo          usercommon.UserRepo
+	httpClient        *http.Client
+	captchaVerifyURLs map[string]string
 }
 
 // NewCaptchaService captcha service
-func NewCaptchaService(captchaRepo CaptchaRepo) *CaptchaService {
+func NewCaptchaService(
+	captchaRepo CaptchaRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+) *CaptchaService {
 	return &CaptchaService{
-		captchaRepo: captchaRepo,
+		captchaRepo:       captchaRepo,
+		siteInfoService:   siteInfoService,
+		userRepo:          userRepo,
+		httpClient:        &http.Client{Timeout: constant.DefaultCaptchaVerifyTimeout},
+		captchaVerifyURLs: constant.CaptchaProviderVerifyURLs,
 	}
 }
 
@@ -67,6 +67,9 @@ func (cs *CaptchaService) ValidationStrategy(ctx context.Context, unit, actionTy
 		return cs.CaptchaActionDelete(ctx, unit, info)
 	case entity.CaptchaActionVote:
 		return cs.CaptchaActionVote(ctx, unit, info)
+	case entity.CaptchaActionGuestQuestion:
+		// the guests always need to pass the captcha
+		return false
 	}
 	// actionType not found
 	return false
@@ -350,6 +350,7 @@ func isTimelineObjectRestricted(objInfo *schema.SimpleObjectInfo) bool {
 func isTimelineQuestionRestricted(questionInfo *schema.SimpleObjectInfo) bool {
 	return questionInfo.QuestionStatus == entity.QuestionStatusDeleted ||
 		questionInfo.QuestionStatus == entity.QuestionStatusPending ||
+		questionInfo.QuestionStatus == entity.QuestionStatusScheduled ||
 		questionInfo.QuestionShow == entity.QuestionHide
 }
 
@@ -32,6 +32,8 @@ import (
 type AnswerActivityRepo interface {
 	SaveAcceptAnswerActivity(ctx context.Context, op *schema.AcceptAnswerOperationInfo) (err error)
 	SaveCancelAcceptAnswerActivity(ctx context.Context, op *schema.AcceptAnswerOperationInfo) (err error)
+	CancelObjectRankActivities(ctx context.Context, objectID string) (err error)
+	RecoverObjectRankActivities(ctx context.Context, objectID string) (err error)
 }
 
 // AnswerActivityService answer activity service
@@ -70,6 +72,16 @@ func (as *AnswerActivityService) CancelAcceptAnswer(ctx context.Context,
 	return as.answerActivityRepo.SaveCancelAcceptAnswerActivity(ctx, operationInfo)
 }
 
+// DeleteObject rollback the reputation changes caused by the deleted question or answer
+func (as *AnswerActivityService) DeleteObject(ctx context.Context, objectID string) (err error) {
+	return as.answerActivityRepo.CancelObjectRankActivities(ctx, objectID)
+}
+
+// RecoverObject reapply the reputation changes of the recovered question or answer
+func (as *AnswerActivityService) RecoverObject(ctx context.Context, objectID string) (err error) {
+	return as.answerActivityRepo.RecoverObjectRankActivities(ctx, objectID)
+}
+
 func (as *AnswerActivityService) createAcceptAnswerOperationInfo(ctx context.Context, loginUserID,
 	answerObjID, questionObjID, questionUserID, answerUserID string, isSelf bool) *schema.AcceptAnswerOperationInfo {
 	operationInfo := &schema.AcceptAnswerOperationInfo{
@@ -99,7 +111,7 @@ func (as *AnswerActivityService) getActivities(ctx context.Context, op *schema.A
 			log.Warnf("get config by key error: %v", err)
 			continue
 		}
-		t.ActivityType, t.Rank = cfg.ID, cfg.GetIntValue()
+		t.ActivityType, t.ActivityTypeKey, t.Rank = cfg.ID, action, cfg.GetIntValue()
 
 		if action == activity_type.AnswerAccept {
 			t.ActivityUserID = op.QuestionUserID
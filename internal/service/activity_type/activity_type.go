@@ -32,6 +32,22 @@ const (
 	AnswerAccept      = "answer.accept"
 	CommentVoteUp     = "comment.vote_up"
 	EditAccepted      = "edit.accepted"
+
+	QuestionBountyOffered  = "question.bounty_offered"
+	QuestionBountyRefunded = "question.bounty_refunded"
+	AnswerBountyAwarded    = "answer.bounty_awarded"
+)
+
+// reasons of the reputation changes
+const (
+	ReputationReasonUpvote     = "upvote"
+	ReputationReasonDownvote   = "downvote"
+	ReputationReasonAccept     = "accept"
+	ReputationReasonBounty     = "bounty"
+	ReputationReasonEdit       = "edit"
+	ReputationReasonModeration = "moderation"
+	ReputationReasonReversal   = "reversal"
+	ReputationReasonOther      = "other"
 )
 
 var (
@@ -59,6 +75,13 @@ var (
 		AnswerVotedDown,
 		CommentVoteUp,
 	}
+	// TagReputationActivityTypeList the activities that change the reputation of the users in the tags
+	TagReputationActivityTypeList = []string{
+		QuestionVotedUp,
+		QuestionVotedDown,
+		AnswerVotedUp,
+		AnswerVotedDown,
+	}
 	ActivityTypeFlagMapping = map[string]string{
 		QuestionVoteUp:    "action_activity_type.upvote",
 		QuestionVoteDown:  "action_activity_type.downvote",
@@ -72,5 +95,29 @@ var (
 		AnswerAccept:      "action_activity_type.accept",
 		CommentVoteUp:     "action_activity_type.upvote",
 		EditAccepted:      "action_activity_type.edit",
+
+		QuestionBountyOffered:  "action_activity_type.bounty_offered",
+		QuestionBountyRefunded: "action_activity_type.bounty_refunded",
+		AnswerBountyAwarded:    "action_activity_type.bounty_awarded",
+	}
+	// ReputationReasonMapping the reason of the reputation change of each activity,
+	// activities not in the mapping are ReputationReasonOther.
+	ReputationReasonMapping = map[string]string{
+		QuestionVotedUp:        ReputationReasonUpvote,
+		AnswerVotedUp:          ReputationReasonUpvote,
+		QuestionVotedDown:      ReputationReasonDownvote,
+		AnswerVotedDown:        ReputationReasonDownvote,
+		QuestionVoteDown:       ReputationReasonDownvote,
+		AnswerVoteDown:         ReputationReasonDownvote,
+		AnswerAccepted:         ReputationReasonAccept,
+		AnswerAccept:           ReputationReasonAccept,
+		QuestionBountyOffered:  ReputationReasonBounty,
+		QuestionBountyRefunded: ReputationReasonBounty,
+		AnswerBountyAwarded:    ReputationReasonBounty,
+		EditAccepted:           ReputationReasonEdit,
+		"edit.rejected":        ReputationReasonEdit,
+		"tag.edit_accepted":    ReputationReasonEdit,
+		"object.reported":      ReputationReasonModeration,
+		"answer.deleted":       ReputationReasonModeration,
 	}
 )
@@ -21,6 +21,7 @@ package answercommon
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/entity"
@@ -30,6 +31,7 @@ import (
 )
 
 type AnswerRepo interface {
+	IndexSearch(ctx context.Context, answerID string) (err error)
 	AddAnswer(ctx context.Context, answer *entity.Answer) (err error)
 	RemoveAnswer(ctx context.Context, id string) (err error)
 	RecoverAnswer(ctx context.Context, answerID string) (err error)
@@ -37,6 +39,8 @@ type AnswerRepo interface {
 	GetAnswer(ctx context.Context, id string) (answer *entity.Answer, exist bool, err error)
 	GetAnswerList(ctx context.Context, answer *entity.Answer) (answerList []*entity.Answer, err error)
 	GetAnswerPage(ctx context.Context, page, pageSize int, answer *entity.Answer) (answerList []*entity.Answer, total int64, err error)
+	SearchListByCursor(ctx context.Context, search *entity.AnswerSearch, cursor string) (
+		answerList []*entity.Answer, nextCursor string, err error)
 	UpdateAcceptedStatus(ctx context.Context, acceptedAnswerID string, questionID string) error
 	GetByID(ctx context.Context, answerID string) (*entity.Answer, bool, error)
 	GetByIDs(ctx context.Context, answerIDs ...string) ([]*entity.Answer, error)
@@ -52,6 +56,9 @@ type AnswerRepo interface {
 	RemoveAllUserAnswer(ctx context.Context, userID string) (err error)
 	SumVotesByQuestionID(ctx context.Context, questionID string) (float64, error)
 	DeletePermanentlyAnswers(ctx context.Context) (err error)
+	PurgeDeletedAnswers(ctx context.Context, deletedBefore time.Time) (count int64, err error)
+	GetDeletedAnswerPage(ctx context.Context, page, pageSize int, deletedAfter time.Time) (
+		answerList []*entity.Answer, total int64, err error)
 }
 
 // AnswerCommon user service
@@ -114,6 +121,7 @@ func (as *AnswerCommon) ShowFormat(ctx context.Context, data *entity.Answer) *sc
 	info.UserID = data.UserID
 	info.UpdateUserID = data.LastEditUserID
 	info.Status = data.Status
+	info.Wiki = data.Wiki
 	info.MemberActions = make([]*schema.PermissionMemberAction, 0)
 	return &info
 }
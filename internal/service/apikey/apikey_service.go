@@ -21,20 +21,24 @@ package apikey
 
 import (
 	"context"
-	"strings"
 	"time"
 
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
 )
 
 type APIKeyRepo interface {
 	GetAPIKeyList(ctx context.Context) (keys []*entity.APIKey, err error)
+	GetUserAPIKeyList(ctx context.Context, userID string) (keys []*entity.APIKey, err error)
 	GetAPIKey(ctx context.Context, apiKey string) (key *entity.APIKey, exist bool, err error)
 	UpdateAPIKey(ctx context.Context, apiKey entity.APIKey) (err error)
 	AddAPIKey(ctx context.Context, apiKey entity.APIKey) (err error)
 	DeleteAPIKey(ctx context.Context, id int) (err error)
+	DeleteUserAPIKey(ctx context.Context, id int, userID string) (affected int64, err error)
 	DeleteAPIKeysByUserID(ctx context.Context, userID string) (err error)
 }
 
@@ -55,26 +59,7 @@ func (s *APIKeyService) GetAPIKeyList(ctx context.Context, req *schema.GetAPIKey
 	if err != nil {
 		return nil, err
 	}
-	resp = make([]*schema.GetAPIKeyResp, 0)
-	for _, key := range keys {
-		// hide access key middle part, replace with *
-		if len(key.AccessKey) < 10 {
-			// If the access key is too short, do not mask it
-			key.AccessKey = strings.Repeat("*", len(key.AccessKey))
-		} else {
-			key.AccessKey = key.AccessKey[:7] + strings.Repeat("*", 8) + key.AccessKey[len(key.AccessKey)-4:]
-		}
-
-		resp = append(resp, &schema.GetAPIKeyResp{
-			ID:          key.ID,
-			AccessKey:   key.AccessKey,
-			Description: key.Description,
-			Scope:       key.Scope,
-			CreatedAt:   key.CreatedAt.Unix(),
-			LastUsedAt:  key.LastUsedAt.Unix(),
-		})
-	}
-	return resp, nil
+	return convertAPIKeyList(keys), nil
 }
 
 func (s *APIKeyService) UpdateAPIKey(ctx context.Context, req *schema.UpdateAPIKeyReq) (err error) {
@@ -90,10 +75,11 @@ func (s *APIKeyService) UpdateAPIKey(ctx context.Context, req *schema.UpdateAPIK
 }
 
 func (s *APIKeyService) AddAPIKey(ctx context.Context, req *schema.AddAPIKeyReq) (resp *schema.AddAPIKeyResp, err error) {
-	ak := "sk_" + strings.ReplaceAll(token.GenerateToken(), "-", "")
+	ak := token.GenerateAPIKey()
 	apiKey := entity.APIKey{
 		Description: req.Description,
-		AccessKey:   ak,
+		AccessKey:   token.HashAPIKey(ak),
+		KeyHint:     token.MaskAPIKey(ak),
 		Scope:       req.Scope,
 		LastUsedAt:  time.Now(),
 		UserID:      req.UserID,
@@ -103,7 +89,7 @@ func (s *APIKeyService) AddAPIKey(ctx context.Context, req *schema.AddAPIKeyReq)
 		return nil, err
 	}
 	resp = &schema.AddAPIKeyResp{
-		AccessKey: apiKey.AccessKey,
+		AccessKey: ak,
 	}
 	return resp, nil
 }
@@ -119,3 +105,68 @@ func (s *APIKeyService) DeleteAPIKey(ctx context.Context, req *schema.DeleteAPIK
 func (s *APIKeyService) DeleteUserAPIKeys(ctx context.Context, userID string) error {
 	return s.apiKeyRepo.DeleteAPIKeysByUserID(ctx, userID)
 }
+
+// GetUserAPIKeyList get the personal api keys of the user
+func (s *APIKeyService) GetUserAPIKeyList(ctx context.Context, userID string) (resp []*schema.GetAPIKeyResp, err error) {
+	keys, err := s.apiKeyRepo.GetUserAPIKeyList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return convertAPIKeyList(keys), nil
+}
+
+// AddUserAPIKey issue a personal api key for the user, the key is only returned once
+func (s *APIKeyService) AddUserAPIKey(ctx context.Context, req *schema.AddUserAPIKeyReq) (
+	resp *schema.AddAPIKeyResp, err error) {
+	keys, err := s.apiKeyRepo.GetUserAPIKeyList(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) >= constant.MaxUserAPIKeys {
+		return nil, errors.BadRequest(reason.APIKeyTooMany)
+	}
+
+	ak := token.GenerateAPIKey()
+	apiKey := entity.APIKey{
+		Description: req.Description,
+		AccessKey:   token.HashAPIKey(ak),
+		KeyHint:     token.MaskAPIKey(ak),
+		Scope:       req.Scope,
+		LastUsedAt:  time.Now(),
+		UserID:      req.UserID,
+		Personal:    1,
+		RateLimit:   req.RateLimit,
+	}
+	if err = s.apiKeyRepo.AddAPIKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	return &schema.AddAPIKeyResp{AccessKey: ak}, nil
+}
+
+// RevokeUserAPIKey revoke the personal api key of the user, the key cannot be used any more
+func (s *APIKeyService) RevokeUserAPIKey(ctx context.Context, req *schema.RevokeUserAPIKeyReq) (err error) {
+	affected, err := s.apiKeyRepo.DeleteUserAPIKey(ctx, req.ID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.NotFound(reason.APIKeyNotFound)
+	}
+	return nil
+}
+
+func convertAPIKeyList(keys []*entity.APIKey) (resp []*schema.GetAPIKeyResp) {
+	resp = make([]*schema.GetAPIKeyResp, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, &schema.GetAPIKeyResp{
+			ID:          key.ID,
+			AccessKey:   key.KeyHint,
+			Description: key.Description,
+			Scope:       key.Scope,
+			RateLimit:   key.RateLimit,
+			CreatedAt:   key.CreatedAt.Unix(),
+			LastUsedAt:  key.LastUsedAt.Unix(),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,210 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package audit_log
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// AuditLogRepo audit log repository
+type AuditLogRepo interface {
+	AddAuditLog(ctx context.Context, auditLog *entity.AuditLog) (err error)
+	GetAuditLogPage(ctx context.Context, cond *entity.AuditLogQueryCond) (auditLogs []*entity.AuditLog, total int64, err error)
+}
+
+// AuditLogService audit log service
+type AuditLogService struct {
+	auditLogRepo AuditLogRepo
+	userCommon   *usercommon.UserCommon
+}
+
+// NewAuditLogService new audit log service
+func NewAuditLogService(
+	auditLogRepo AuditLogRepo,
+	userCommon *usercommon.UserCommon,
+) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo: auditLogRepo,
+		userCommon:   userCommon,
+	}
+}
+
+// Record save the audit log of the action. The sensitive values in before and after are redacted.
+// Recording never fails the action, the error is only logged.
+func (as *AuditLogService) Record(ctx context.Context, msg *schema.AuditLogMsg) {
+	before, after := marshalAuditValue(msg.Before), marshalAuditValue(msg.After)
+	// nothing changed, no need to record
+	if msg.Before != nil && msg.After != nil && before == after {
+		return
+	}
+
+	auditLog := &entity.AuditLog{
+		ActorID:    msg.ActorID,
+		Action:     msg.Action,
+		ObjectType: msg.ObjectType,
+		ObjectID:   uid.DeShortID(msg.ObjectID),
+		Before:     RedactSensitiveJSON(before),
+		After:      RedactSensitiveJSON(after),
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if len(auditLog.ActorID) == 0 {
+			auditLog.ActorID = middleware.GetLoginUserIDFromContext(ginCtx)
+		}
+		auditLog.IP = ginCtx.ClientIP()
+	}
+	if err := as.auditLogRepo.AddAuditLog(ctx, auditLog); err != nil {
+		log.Errorf("add audit log %s of %s %s failed: %v", msg.Action, msg.ObjectType, msg.ObjectID, err)
+	}
+}
+
+// GetAuditLogPage get audit log page
+func (as *AuditLogService) GetAuditLogPage(ctx context.Context, req *schema.GetAuditLogPageReq) (
+	resp *pager.PageModel, err error) {
+	cond := &entity.AuditLogQueryCond{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		ObjectType: req.ObjectType,
+		ObjectID:   uid.DeShortID(req.ObjectID),
+	}
+	if req.StartTime > 0 {
+		cond.StartTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		cond.EndTime = time.Unix(req.EndTime, 0)
+	}
+	auditLogs, total, err := as.auditLogRepo.GetAuditLogPage(ctx, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		userIDs = append(userIDs, auditLog.ActorID)
+	}
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*schema.GetAuditLogResp, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		list = append(list, &schema.GetAuditLogResp{
+			ID:         auditLog.ID,
+			CreatedAt:  auditLog.CreatedAt.Unix(),
+			ActorID:    auditLog.ActorID,
+			Actor:      userInfoMapping[auditLog.ActorID],
+			Action:     auditLog.Action,
+			ObjectType: auditLog.ObjectType,
+			ObjectID:   auditLog.ObjectID,
+			Before:     auditLog.Before,
+			After:      auditLog.After,
+			IP:         auditLog.IP,
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+func marshalAuditValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// sensitiveKeywords the value of a json field will be redacted if its name contains any of these keywords
+// or ends with a token, e.g. access_token but not max_tokens
+var sensitiveKeywords = []string{"password", "passwd", "secret", "apikey", "privatekey", "credential"}
+
+// RedactSensitiveJSON replace the values of the sensitive fields in the json, such as passwords and secrets.
+// The empty values are kept, so it can be seen whether the field is set or not.
+func RedactSensitiveJSON(content string) string {
+	if len(content) == 0 {
+		return content
+	}
+	var value any
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return content
+	}
+	redacted, err := json.Marshal(redactSensitiveValue(value))
+	if err != nil {
+		return content
+	}
+	return string(redacted)
+}
+
+func redactSensitiveValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isSensitiveKey(key) && !isEmptyValue(item) {
+				v[key] = constant.AuditRedactedValue
+				continue
+			}
+			v[key] = redactSensitiveValue(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactSensitiveValue(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	if strings.HasSuffix(key, "token") {
+		return true
+	}
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(key, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	}
+	return false
+}
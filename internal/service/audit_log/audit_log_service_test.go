@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package audit_log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSensitiveJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty", ``, ``},
+		{"not json", `deleted`, `deleted`},
+		{"smtp password", `{"smtp_host":"smtp.example.com","smtp_password":"p@ss"}`,
+			`{"smtp_host":"smtp.example.com","smtp_password":"[REDACTED]"}`},
+		{"empty secret is kept", `{"client_secret":"","enabled":true}`, `{"client_secret":"","enabled":true}`},
+		{"nested", `{"providers":[{"name":"openai","api_key":"sk-1"}],"access_token":"t"}`,
+			`{"access_token":"[REDACTED]","providers":[{"api_key":"[REDACTED]","name":"openai"}]}`},
+		{"not a token", `{"max_tokens":1024}`, `{"max_tokens":1024}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, RedactSensitiveJSON(c.content))
+		})
+	}
+}
@@ -21,9 +21,12 @@ package auth
 
 import (
 	"context"
+	"time"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/apikey"
+	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/log"
@@ -44,19 +47,27 @@ type AuthRepo interface {
 	RemoveAdminUserCacheInfo(ctx context.Context, accessToken string) (err error)
 	AddUserTokenMapping(ctx context.Context, userID, accessToken string) (err error)
 	RemoveUserTokens(ctx context.Context, userID string, remainToken string)
+	SetUserSessionRevokedAt(ctx context.Context, userID string, revokedAt int64) (err error)
+	GetUserSessionRevokedAt(ctx context.Context, userID string) (revokedAt int64, err error)
 }
 
 // AuthService kit service
 type AuthService struct {
-	authRepo   AuthRepo
-	apiKeyRepo apikey.APIKeyRepo
+	authRepo        AuthRepo
+	apiKeyRepo      apikey.APIKeyRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
 }
 
 // NewAuthService email service
-func NewAuthService(authRepo AuthRepo, apiKeyRepo apikey.APIKeyRepo) *AuthService {
+func NewAuthService(
+	authRepo AuthRepo,
+	apiKeyRepo apikey.APIKeyRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *AuthService {
 	return &AuthService{
-		authRepo:   authRepo,
-		apiKeyRepo: apiKeyRepo,
+		authRepo:        authRepo,
+		apiKeyRepo:      apiKeyRepo,
+		siteInfoService: siteInfoService,
 	}
 }
 
@@ -68,11 +79,47 @@ func (as *AuthService) GetUserCacheInfo(ctx context.Context, accessToken string)
 	if userCacheInfo == nil {
 		return nil, nil
 	}
+	// the tokens issued before the sessions of the user are revoked are rejected,
+	// even if they are not removed from the token mapping of the user
+	revokedAt, err := as.authRepo.GetUserSessionRevokedAt(ctx, userCacheInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if userCacheInfo.LoginAt < revokedAt {
+		if err = as.authRepo.RemoveUserCacheInfo(ctx, accessToken); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	now := time.Now()
+	// the sessions created before the timeouts are configurable start from now
+	if userCacheInfo.LoginAt == 0 {
+		userCacheInfo.LoginAt = now.Unix()
+	}
+	expireAt, err := as.getSessionExpireAt(ctx, userCacheInfo, now)
+	if err != nil {
+		return nil, err
+	}
+	if !expireAt.After(now) {
+		log.Debugf("session of user %s is expired", userCacheInfo.UserID)
+		if err = as.authRepo.RemoveUserCacheInfo(ctx, accessToken); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	// the idle timer slides on activity, it is refreshed at most once in the interval to save cache writes
+	refresh := now.Sub(time.Unix(userCacheInfo.ActiveAt, 0)) >= constant.UserSessionRefreshInterval
 	cacheInfo, _ := as.authRepo.GetUserStatus(ctx, userCacheInfo.UserID)
 	if cacheInfo != nil {
 		userCacheInfo.UserStatus = cacheInfo.UserStatus
 		userCacheInfo.EmailStatus = cacheInfo.EmailStatus
 		userCacheInfo.RoleID = cacheInfo.RoleID
+		refresh = true
+	}
+	if refresh {
+		userCacheInfo.ActiveAt = now.Unix()
+		userCacheInfo.ExpireAt = expireAt.Unix()
 		// update current user cache info
 		err := as.authRepo.SetUserCacheInfo(ctx, accessToken, userCacheInfo.VisitToken, userCacheInfo)
 		if err != nil {
@@ -94,6 +141,13 @@ func (as *AuthService) SetUserCacheInfo(ctx context.Context, userInfo *entity.Us
 	accessToken string, visitToken string, err error) {
 	accessToken = token.GenerateToken()
 	visitToken = token.GenerateToken()
+	now := time.Now()
+	userInfo.LoginAt, userInfo.ActiveAt = now.Unix(), now.Unix()
+	expireAt, err := as.getSessionExpireAt(ctx, userInfo, now)
+	if err != nil {
+		return "", "", err
+	}
+	userInfo.ExpireAt = expireAt.Unix()
 	err = as.authRepo.SetUserCacheInfo(ctx, accessToken, visitToken, userInfo)
 	if err != nil {
 		return "", "", err
@@ -101,6 +155,27 @@ func (as *AuthService) SetUserCacheInfo(ctx context.Context, userInfo *entity.Us
 	return accessToken, visitToken, err
 }
 
+// getSessionExpireAt get the time the session expires if the user is active now,
+// it is the end of the idle timeout but not later than the absolute timeout since login.
+func (as *AuthService) getSessionExpireAt(ctx context.Context, userInfo *entity.UserCacheInfo, now time.Time) (
+	expireAt time.Time, err error) {
+	siteLogin, err := as.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return expireAt, err
+	}
+	idle, absolute := siteLogin.GetSessionTimeout(userInfo.RememberMe)
+	expireAt = now.Add(idle)
+	if deadline := time.Unix(userInfo.LoginAt, 0).Add(absolute); deadline.Before(expireAt) {
+		expireAt = deadline
+	}
+	if userInfo.IsImpersonated() {
+		if deadline := time.Unix(userInfo.LoginAt, 0).Add(constant.ImpersonationSessionTimeout); deadline.Before(expireAt) {
+			expireAt = deadline
+		}
+	}
+	return expireAt, nil
+}
+
 func (as *AuthService) CheckUserVisitToken(ctx context.Context, visitToken string) bool {
 	accessToken, err := as.authRepo.GetUserVisitCacheInfo(ctx, visitToken)
 	if err != nil {
@@ -134,7 +209,23 @@ func (as *AuthService) AddUserTokenMapping(ctx context.Context, userID, accessTo
 
 // RemoveUserAllTokens Log out all users under this user id
 func (as *AuthService) RemoveUserAllTokens(ctx context.Context, userID string) {
+	if err := as.authRepo.SetUserSessionRevokedAt(ctx, userID, time.Now().Unix()); err != nil {
+		log.Errorf("set user %s session revoked time failed: %v", userID, err)
+	}
+	as.authRepo.RemoveUserTokens(ctx, userID, "")
+}
+
+// RevokeUserSessions log out all the sessions of the user immediately, the api keys of the user are
+// removed too if revokeAPIKeys is true, so that nothing issued before can be used to act as the user.
+func (as *AuthService) RevokeUserSessions(ctx context.Context, userID string, revokeAPIKeys bool) (err error) {
+	if err = as.authRepo.SetUserSessionRevokedAt(ctx, userID, time.Now().Unix()); err != nil {
+		return err
+	}
 	as.authRepo.RemoveUserTokens(ctx, userID, "")
+	if revokeAPIKeys {
+		return as.apiKeyRepo.DeleteAPIKeysByUserID(ctx, userID)
+	}
+	return nil
 }
 
 // RemoveTokensExceptCurrentUser remove all tokens except the current user
@@ -189,7 +280,7 @@ func (as *AuthService) RemoveAdminUserCacheInfo(ctx context.Context, accessToken
 	return as.authRepo.RemoveAdminUserCacheInfo(ctx, accessToken)
 }
 func (as *AuthService) AuthAPIKey(ctx context.Context, read bool, apiKey string) (pass bool, err error) {
-	apiKeyInfo, exist, err := as.apiKeyRepo.GetAPIKey(ctx, apiKey)
+	apiKeyInfo, exist, err := as.GetAPIKey(ctx, apiKey)
 	if err != nil {
 		return false, err
 	}
@@ -197,10 +288,28 @@ func (as *AuthService) AuthAPIKey(ctx context.Context, read bool, apiKey string)
 		return false, nil
 	}
 	// If the request is not read-only, check if the API key has write permissions
-	if !read && apiKeyInfo.Scope == "read-only" {
-		log.Warnf("API key %s does not have write permissions", apiKeyInfo.AccessKey)
+	if !read && apiKeyInfo.Scope == constant.APIKeyScopeReadOnly {
+		log.Warnf("API key %s does not have write permissions", apiKeyInfo.KeyHint)
 		return false, nil
 	}
-	log.Infof("API key %s is valid, scope: %s", apiKeyInfo.AccessKey, apiKeyInfo.Scope)
+	log.Infof("API key %s is valid, scope: %s", apiKeyInfo.KeyHint, apiKeyInfo.Scope)
+	as.UpdateAPIKeyLastUsed(ctx, apiKeyInfo)
 	return true, nil
 }
+
+// GetAPIKey get the api key by the plain key, only the hashes of the keys are stored
+func (as *AuthService) GetAPIKey(ctx context.Context, apiKey string) (key *entity.APIKey, exist bool, err error) {
+	return as.apiKeyRepo.GetAPIKey(ctx, token.HashAPIKey(apiKey))
+}
+
+// UpdateAPIKeyLastUsed record the time the api key is used, it is written at most once in the update interval
+func (as *AuthService) UpdateAPIKeyLastUsed(ctx context.Context, apiKey *entity.APIKey) {
+	now := time.Now()
+	if now.Sub(apiKey.LastUsedAt) < constant.APIKeyLastUsedUpdateInterval {
+		return
+	}
+	err := as.apiKeyRepo.UpdateAPIKey(ctx, entity.APIKey{ID: apiKey.ID, LastUsedAt: now})
+	if err != nil {
+		log.Errorf("update api key last used time failed, err: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeAuthRepo struct {
+	AuthRepo
+	tokens    map[string]*entity.UserCacheInfo
+	revokedAt int64
+}
+
+func (f *fakeAuthRepo) GetUserCacheInfo(_ context.Context, accessToken string) (*entity.UserCacheInfo, error) {
+	info, ok := f.tokens[accessToken]
+	if !ok {
+		return nil, nil
+	}
+	c := *info
+	return &c, nil
+}
+
+func (f *fakeAuthRepo) SetUserCacheInfo(_ context.Context, accessToken, visitToken string,
+	userInfo *entity.UserCacheInfo) error {
+	userInfo.VisitToken = visitToken
+	c := *userInfo
+	f.tokens[accessToken] = &c
+	return nil
+}
+
+func (f *fakeAuthRepo) RemoveUserCacheInfo(_ context.Context, accessToken string) error {
+	delete(f.tokens, accessToken)
+	return nil
+}
+
+func (f *fakeAuthRepo) GetUserStatus(_ context.Context, _ string) (*entity.UserCacheInfo, error) {
+	return nil, nil
+}
+
+func (f *fakeAuthRepo) SetUserSessionRevokedAt(_ context.Context, _ string, revokedAt int64) error {
+	f.revokedAt = revokedAt
+	return nil
+}
+
+func (f *fakeAuthRepo) GetUserSessionRevokedAt(_ context.Context, _ string) (int64, error) {
+	return f.revokedAt, nil
+}
+
+func (f *fakeAuthRepo) RemoveUserTokens(_ context.Context, _ string, _ string) {}
+
+func newTestAuthService(t *testing.T, siteLogin *schema.SiteLoginResp) (*AuthService, *fakeAuthRepo) {
+	ctrl := gomock.NewController(t)
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+	siteInfoService.EXPECT().GetSiteLogin(gomock.Any()).Return(siteLogin, nil).AnyTimes()
+	repo := &fakeAuthRepo{tokens: make(map[string]*entity.UserCacheInfo)}
+	return NewAuthService(repo, nil, siteInfoService), repo
+}
+
+func TestAuthService_SetUserCacheInfoTimeout(t *testing.T) {
+	as, repo := newTestAuthService(t, &schema.SiteLoginResp{SessionIdleTimeout: 30, RememberMeTimeout: 90})
+
+	accessToken, _, err := as.SetUserCacheInfo(context.TODO(), &entity.UserCacheInfo{UserID: "1"})
+	assert.NoError(t, err)
+	info := repo.tokens[accessToken]
+	assert.Equal(t, 30*time.Minute, time.Duration(info.ExpireAt-info.LoginAt)*time.Second)
+
+	accessToken, _, err = as.SetUserCacheInfo(context.TODO(), &entity.UserCacheInfo{UserID: "1", RememberMe: true})
+	assert.NoError(t, err)
+	info = repo.tokens[accessToken]
+	assert.Equal(t, 90*24*time.Hour, time.Duration(info.ExpireAt-info.LoginAt)*time.Second)
+}
+
+func TestAuthService_GetUserCacheInfoSliding(t *testing.T) {
+	as, repo := newTestAuthService(t, &schema.SiteLoginResp{SessionIdleTimeout: 30})
+	now := time.Now()
+	activeAt := now.Add(-10 * time.Minute)
+	repo.tokens["token"] = &entity.UserCacheInfo{
+		UserID:   "1",
+		LoginAt:  now.Add(-time.Hour).Unix(),
+		ActiveAt: activeAt.Unix(),
+		ExpireAt: activeAt.Add(30 * time.Minute).Unix(),
+	}
+
+	info, err := as.GetUserCacheInfo(context.TODO(), "token")
+	assert.NoError(t, err)
+	if assert.NotNil(t, info) {
+		assert.GreaterOrEqual(t, repo.tokens["token"].ExpireAt, now.Add(30*time.Minute).Unix())
+		assert.GreaterOrEqual(t, repo.tokens["token"].ActiveAt, now.Unix())
+	}
+}
+
+func TestAuthService_GetUserCacheInfoAbsoluteTimeout(t *testing.T) {
+	as, repo := newTestAuthService(t, &schema.SiteLoginResp{SessionAbsoluteTimeout: 1})
+	repo.tokens["token"] = &entity.UserCacheInfo{
+		UserID:   "1",
+		LoginAt:  time.Now().Add(-time.Hour - time.Minute).Unix(),
+		ActiveAt: time.Now().Unix(),
+	}
+
+	info, err := as.GetUserCacheInfo(context.TODO(), "token")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+	assert.NotContains(t, repo.tokens, "token")
+}
+
+func TestAuthService_RevokeUserSessions(t *testing.T) {
+	as, repo := newTestAuthService(t, &schema.SiteLoginResp{})
+	repo.tokens["token"] = &entity.UserCacheInfo{
+		UserID:   "1",
+		LoginAt:  time.Now().Add(-time.Hour).Unix(),
+		ActiveAt: time.Now().Unix(),
+	}
+	// the token mapping is lost, the token is still rejected
+	assert.NoError(t, as.RevokeUserSessions(context.TODO(), "1", false))
+
+	info, err := as.GetUserCacheInfo(context.TODO(), "token")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// the user can login again after the sessions are revoked
+	accessToken, _, err := as.SetUserCacheInfo(context.TODO(), &entity.UserCacheInfo{UserID: "1"})
+	assert.NoError(t, err)
+	info, err = as.GetUserCacheInfo(context.TODO(), accessToken)
+	assert.NoError(t, err)
+	assert.NotNil(t, info)
+}
@@ -40,7 +40,7 @@ import (
 
 type BadgeAwardRepo interface {
 	CheckIsAward(ctx context.Context, badgeID string, userID string, awardKey string, singleOrMulti int8) (isAward bool, err error)
-	AwardBadgeForUser(ctx context.Context, badgeAward *entity.BadgeAward) (err error)
+	AwardBadgeForUser(ctx context.Context, badgeAward *entity.BadgeAward) (awarded bool, err error)
 
 	CountByUserIdAndBadgeId(ctx context.Context, userID string, badgeID string) (awardCount int64)
 	CountByBadgeID(ctx context.Context, badgeID string) (awardCount int64, err error)
@@ -171,10 +171,14 @@ func (bs *BadgeAwardService) Award(ctx context.Context, badgeID string, userID s
 		BadgeGroupID:   badgeData.BadgeGroupID,
 		IsBadgeDeleted: entity.IsBadgeNotDeleted,
 	}
-	err = bs.badgeAwardRepo.AwardBadgeForUser(ctx, badgeAward)
+	awarded, err := bs.badgeAwardRepo.AwardBadgeForUser(ctx, badgeAward)
 	if err != nil {
 		return err
 	}
+	// the badge has been awarded concurrently
+	if !awarded {
+		return nil
+	}
 
 	msg := &schema.NotificationMsg{
 		TriggerUserID:      badgeAward.UserID,
@@ -228,7 +232,8 @@ func (bs *BadgeAwardService) GetUserBadgeAwardList(
 			ID:          uid.EnShortID(badge.ID),
 			Name:        translator.Tr(handler.GetLangByCtx(ctx), badge.Name),
 			Icon:        badge.Icon,
-			EarnedCount: earnedCount.EarnedCount,
+			EarnedCount: badge.GetEarnedCount(earnedCount.EarnedCount),
+			IsSingle:    !badge.IsRepeatable(),
 			Level:       badge.Level,
 		}
 	}
@@ -269,7 +274,8 @@ func (bs *BadgeAwardService) GetUserRecentBadgeAwardList(ctx *gin.Context, req *
 			ID:          uid.EnShortID(badge.ID),
 			Name:        translator.Tr(handler.GetLangByCtx(ctx), badge.Name),
 			Icon:        badge.Icon,
-			EarnedCount: earnedCount.EarnedCount,
+			EarnedCount: badge.GetEarnedCount(earnedCount.EarnedCount),
+			IsSingle:    !badge.IsRepeatable(),
 			Level:       badge.Level,
 		}
 	}
@@ -21,6 +21,7 @@ package badge
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/entity"
@@ -29,6 +30,9 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// badgeRuleSweepWindow the users active in the window are swept, it is a bit longer than the period of the sweep
+const badgeRuleSweepWindow = 25 * time.Hour
+
 type BadgeEventService struct {
 	data              *data.Data
 	eventQueueService eventqueue.Service
@@ -41,6 +45,7 @@ type EventRuleHandler func(ctx context.Context, event *schema.EventMsg) (awards
 
 type EventRuleRepo interface {
 	HandleEventWithRule(ctx context.Context, msg *schema.EventMsg) (awards []*entity.BadgeAward)
+	SweepBadgeRules(ctx context.Context, since time.Time) (awards []*entity.BadgeAward, err error)
 }
 
 func NewBadgeEventService(
@@ -75,3 +80,18 @@ func (ns *BadgeEventService) Handler(ctx context.Context, msg *schema.EventMsg)
 	}
 	return nil
 }
+
+// SweepBadgeRules award the badges with the rules that are not changed by any event, like the active days,
+// to the users who were active in the last day. The awarded badges are skipped, so it can be run repeatedly.
+func (ns *BadgeEventService) SweepBadgeRules(ctx context.Context) {
+	awards, err := ns.eventRuleRepo.SweepBadgeRules(ctx, time.Now().Add(-badgeRuleSweepWindow))
+	if err != nil {
+		log.Errorf("sweep badge rules failed: %v", err)
+	}
+	for _, award := range awards {
+		err := ns.badgeAwardService.Award(ctx, award.BadgeID, award.UserID, award.AwardKey)
+		if err != nil {
+			log.Debugf("error awarding badge %s: %v", award.BadgeID, err)
+		}
+	}
+}
@@ -121,7 +121,8 @@ func (b *BadgeService) ListByGroup(ctx context.Context, userID string) (resp []*
 			Name:        translator.Tr(handler.GetLangByCtx(ctx), badge.Name),
 			Icon:        badge.Icon,
 			AwardCount:  badge.AwardCount,
-			EarnedCount: earned,
+			EarnedCount: badge.GetEarnedCount(earned),
+			IsSingle:    !badge.IsRepeatable(),
 			Level:       badge.Level,
 		})
 	}
@@ -222,6 +223,9 @@ func (b *BadgeService) ListPaged(ctx context.Context, req *schema.GetBadgeListPa
 			GroupName:   groupMap[badge.BadgeGroupID],
 			Status:      schema.BadgeStatusMap[badge.Status],
 		}
+		if rule, ok := badge.GetRule(); ok {
+			resp[i].Rule = rule
+		}
 	}
 	return
 }
@@ -270,8 +274,8 @@ func (b *BadgeService) GetBadgeInfo(ctx *gin.Context, id string, userID string)
 		Description: translator.TrWithData(handler.GetLangByCtx(ctx), badge.Description, &schema.BadgeTplData{ProfileURL: baseURL + "/users/settings/profile"}),
 		Icon:        badge.Icon,
 		AwardCount:  badge.AwardCount,
-		EarnedCount: earnedTotal,
-		IsSingle:    badge.Single == entity.BadgeSingleAward,
+		EarnedCount: badge.GetEarnedCount(earnedTotal),
+		IsSingle:    !badge.IsRepeatable(),
 		Level:       badge.Level,
 	}
 	return
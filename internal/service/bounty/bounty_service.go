@@ -0,0 +1,362 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_type"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/noticequeue"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// BountyRepo bounty repository
+type BountyRepo interface {
+	// AddBounty save the bounty and deduct the amount from the user, enough is false if the
+	// user reputation is not enough to pay for it. It fails if the question already has an active bounty.
+	AddBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (enough bool, err error)
+	// SettleBounty close an active bounty and give the reputation of act to its user,
+	// settled is false if the bounty has already been settled.
+	SettleBounty(ctx context.Context, bounty *entity.QuestionBounty, act *entity.Activity) (settled bool, err error)
+	GetActiveBounty(ctx context.Context, questionID string) (bounty *entity.QuestionBounty, exist bool, err error)
+	GetBountiesByQuestionID(ctx context.Context, questionID string) (bounties []*entity.QuestionBounty, err error)
+	GetExpiredBounties(ctx context.Context, before time.Time, limit int) (bounties []*entity.QuestionBounty, err error)
+}
+
+// BountyService bounty service
+type BountyService struct {
+	bountyRepo               BountyRepo
+	questionRepo             questioncommon.QuestionRepo
+	answerRepo               answercommon.AnswerRepo
+	userCommon               *usercommon.UserCommon
+	configService            *config.ConfigService
+	notificationQueueService noticequeue.Service
+}
+
+// NewBountyService new bounty service
+func NewBountyService(
+	bountyRepo BountyRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	userCommon *usercommon.UserCommon,
+	configService *config.ConfigService,
+	notificationQueueService noticequeue.Service,
+) *BountyService {
+	return &BountyService{
+		bountyRepo:               bountyRepo,
+		questionRepo:             questionRepo,
+		answerRepo:               answerRepo,
+		userCommon:               userCommon,
+		configService:            configService,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// AddBounty offer a bounty on a question, the amount is escrowed from the user reputation
+func (bs *BountyService) AddBounty(ctx context.Context, req *schema.AddQuestionBountyReq) (err error) {
+	if plugin.RankAgentEnabled() {
+		return errors.BadRequest(reason.BountyUnavailable)
+	}
+	questionInfo, exist, err := bs.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+	if questionInfo.Status != entity.QuestionStatusAvailable || questionInfo.Show != entity.QuestionShow {
+		return errors.BadRequest(reason.BountyCannotOffer)
+	}
+
+	_, exist, err = bs.bountyRepo.GetActiveBounty(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.BadRequest(reason.BountyAlreadyExists)
+	}
+
+	userInfo, exist, err := bs.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	requireRank, err := bs.configService.GetIntValue(ctx, constant.RankQuestionBountyKey)
+	if err != nil {
+		return err
+	}
+	if userInfo.Rank < requireRank {
+		lang := handler.GetLangByCtx(ctx)
+		msg := translator.TrWithData(lang, reason.NoEnoughRankToOperate, &schema.PermissionTrTplData{Rank: requireRank})
+		return errors.Forbidden(reason.NoEnoughRankToOperate).WithMsg(msg)
+	}
+
+	activityType, err := bs.configService.GetIDByKey(ctx, activity_type.QuestionBountyOffered)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	bounty := &entity.QuestionBounty{
+		QuestionID: req.QuestionID,
+		UserID:     req.UserID,
+		Amount:     req.Amount,
+		Status:     entity.BountyStatusActive,
+		ExpiresAt:  now.Add(constant.BountyDuration),
+	}
+	act := &entity.Activity{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         req.QuestionID,
+		OriginalObjectID: req.QuestionID,
+		ActivityType:     activityType,
+		Rank:             -req.Amount,
+		HasRank:          1,
+	}
+	enough, err := bs.bountyRepo.AddBounty(ctx, bounty, act)
+	if err != nil {
+		return err
+	}
+	if !enough {
+		return errors.BadRequest(reason.BountyNotEnoughReputation)
+	}
+	return nil
+}
+
+// AwardBounty the bounty owner award the whole bounty to an answer of the question
+func (bs *BountyService) AwardBounty(ctx context.Context, req *schema.AwardQuestionBountyReq) (err error) {
+	bounty, exist, err := bs.bountyRepo.GetActiveBounty(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.BountyNotFound)
+	}
+	if bounty.UserID != req.UserID {
+		return errors.BadRequest(reason.BountyNotOwner)
+	}
+	questionInfo, exist, err := bs.questionRepo.GetQuestion(ctx, bounty.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+		return errors.BadRequest(reason.QuestionNotFound)
+	}
+
+	answerInfo, exist, err := bs.answerRepo.GetAnswer(ctx, req.AnswerID)
+	if err != nil {
+		return err
+	}
+	if !exist || uid.DeShortID(answerInfo.QuestionID) != bounty.QuestionID ||
+		answerInfo.Status != entity.AnswerStatusAvailable {
+		return errors.BadRequest(reason.BountyInvalidAnswer)
+	}
+	if answerInfo.UserID == bounty.UserID {
+		return errors.BadRequest(reason.BountyCannotAwardSelf)
+	}
+	answerInfo.ID = uid.DeShortID(answerInfo.ID)
+	return bs.award(ctx, bounty, answerInfo, bounty.Amount)
+}
+
+// GetQuestionBounties get all bounties of the question, the newest first
+func (bs *BountyService) GetQuestionBounties(ctx context.Context, req *schema.GetQuestionBountyReq) (
+	resp []*schema.QuestionBountyResp, err error) {
+	bounties, err := bs.bountyRepo.GetBountiesByQuestionID(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(bounties)*2)
+	for _, bounty := range bounties {
+		userIDs = append(userIDs, bounty.UserID)
+		if bounty.AwardedUserID != "0" {
+			userIDs = append(userIDs, bounty.AwardedUserID)
+		}
+	}
+	userInfoMapping, err := bs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	enableShortID := handler.GetEnableShortID(ctx)
+	resp = make([]*schema.QuestionBountyResp, 0, len(bounties))
+	for _, bounty := range bounties {
+		item := &schema.QuestionBountyResp{
+			ID:              bounty.ID,
+			QuestionID:      bounty.QuestionID,
+			Amount:          bounty.Amount,
+			Status:          entity.BountyStatusDisplayMapping[bounty.Status],
+			CreatedAt:       bounty.CreatedAt.Unix(),
+			ExpiresAt:       bounty.ExpiresAt.Unix(),
+			AwardedAnswerID: bounty.AwardedAnswerID,
+			AwardedAmount:   bounty.AwardedAmount,
+			UserInfo:        userInfoMapping[bounty.UserID],
+			AwardedUserInfo: userInfoMapping[bounty.AwardedUserID],
+		}
+		if enableShortID {
+			item.QuestionID = uid.EnShortID(item.QuestionID)
+			if item.AwardedAnswerID != "0" {
+				item.AwardedAnswerID = uid.EnShortID(item.AwardedAnswerID)
+			}
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// SettleExpiredBounties settle the bounties that were not awarded before the end of the grace period.
+// Half of the bounty goes to the highest voted answer that was posted after the bounty started and
+// has at least BountyAutoAwardMinVotes votes, if there is no such answer half of it is refunded.
+func (bs *BountyService) SettleExpiredBounties(ctx context.Context) {
+	const batchSize = 100
+	for {
+		bounties, err := bs.bountyRepo.GetExpiredBounties(ctx, time.Now().Add(-constant.BountyGracePeriod), batchSize)
+		if err != nil {
+			log.Errorf("get expired bounties failed: %v", err)
+			return
+		}
+		for _, bounty := range bounties {
+			if err := bs.settleExpiredBounty(ctx, bounty); err != nil {
+				log.Errorf("settle expired bounty %s failed: %v", bounty.ID, err)
+				return
+			}
+		}
+		if len(bounties) < batchSize {
+			return
+		}
+	}
+}
+
+func (bs *BountyService) settleExpiredBounty(ctx context.Context, bounty *entity.QuestionBounty) (err error) {
+	// the escrow is not paid back when the question is deleted, the bounty is cancelled if the question
+	// is still deleted when it is settled. It goes on as usual if the question is recovered before that.
+	questionInfo, exist, err := bs.questionRepo.GetQuestion(ctx, bounty.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+		bounty.Status = entity.BountyStatusCancelled
+		bounty.SettledAt = time.Now()
+		log.Infof("bounty %s expired on the deleted question %s, cancel it", bounty.ID, bounty.QuestionID)
+		_, err = bs.bountyRepo.SettleBounty(ctx, bounty, nil)
+		return err
+	}
+
+	answerList, err := bs.answerRepo.GetAnswerList(ctx, &entity.Answer{
+		QuestionID: bounty.QuestionID,
+		Status:     entity.AnswerStatusAvailable,
+	})
+	if err != nil {
+		return err
+	}
+	if answerInfo := pickAutoAwardAnswer(bounty, answerList); answerInfo != nil {
+		log.Infof("bounty %s expired, auto award to answer %s", bounty.ID, answerInfo.ID)
+		return bs.award(ctx, bounty, answerInfo, bounty.Amount/2)
+	}
+
+	activityType, err := bs.configService.GetIDByKey(ctx, activity_type.QuestionBountyRefunded)
+	if err != nil {
+		return err
+	}
+	refund := bounty.Amount / 2
+	bounty.Status = entity.BountyStatusExpired
+	bounty.SettledAt = time.Now()
+	act := &entity.Activity{
+		UserID:           bounty.UserID,
+		TriggerUserID:    converter.StringToInt64(bounty.UserID),
+		ObjectID:         bounty.QuestionID,
+		OriginalObjectID: bounty.QuestionID,
+		ActivityType:     activityType,
+		Rank:             refund,
+		HasRank:          1,
+	}
+	log.Infof("bounty %s expired without eligible answer, refund %d to user %s", bounty.ID, refund, bounty.UserID)
+	_, err = bs.bountyRepo.SettleBounty(ctx, bounty, act)
+	return err
+}
+
+func (bs *BountyService) award(ctx context.Context, bounty *entity.QuestionBounty,
+	answerInfo *entity.Answer, amount int) (err error) {
+	activityType, err := bs.configService.GetIDByKey(ctx, activity_type.AnswerBountyAwarded)
+	if err != nil {
+		return err
+	}
+	bounty.Status = entity.BountyStatusAwarded
+	bounty.AwardedAnswerID = answerInfo.ID
+	bounty.AwardedUserID = answerInfo.UserID
+	bounty.AwardedAmount = amount
+	bounty.SettledAt = time.Now()
+	act := &entity.Activity{
+		UserID:           answerInfo.UserID,
+		TriggerUserID:    converter.StringToInt64(bounty.UserID),
+		ObjectID:         answerInfo.ID,
+		OriginalObjectID: answerInfo.ID,
+		ActivityType:     activityType,
+		Rank:             amount,
+		HasRank:          1,
+	}
+	settled, err := bs.bountyRepo.SettleBounty(ctx, bounty, act)
+	if err != nil {
+		return err
+	}
+	if !settled {
+		return errors.BadRequest(reason.BountyNotFound)
+	}
+
+	bs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		Type:           schema.NotificationTypeAchievement,
+		ObjectID:       answerInfo.ID,
+		ObjectType:     constant.AnswerObjectType,
+		ReceiverUserID: answerInfo.UserID,
+		TriggerUserID:  bounty.UserID,
+	})
+	return nil
+}
+
+// pickAutoAwardAnswer the highest voted answer posted during the bounty, the earliest one wins a tie
+func pickAutoAwardAnswer(bounty *entity.QuestionBounty, answerList []*entity.Answer) (picked *entity.Answer) {
+	for _, answerInfo := range answerList {
+		if answerInfo.UserID == bounty.UserID ||
+			answerInfo.VoteCount < constant.BountyAutoAwardMinVotes ||
+			answerInfo.CreatedAt.Before(bounty.CreatedAt) {
+			continue
+		}
+		if picked == nil || answerInfo.VoteCount > picked.VoteCount ||
+			(answerInfo.VoteCount == picked.VoteCount && answerInfo.CreatedAt.Before(picked.CreatedAt)) {
+			picked = answerInfo
+		}
+	}
+	return picked
+}
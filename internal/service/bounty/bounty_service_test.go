@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package bounty
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickAutoAwardAnswer(t *testing.T) {
+	start := time.Now().Add(-8 * 24 * time.Hour)
+	bounty := &entity.QuestionBounty{UserID: "1", CreatedAt: start}
+
+	answerList := []*entity.Answer{
+		// posted by the bounty owner
+		{ID: "1", UserID: "1", VoteCount: 10, CreatedAt: start.Add(time.Hour)},
+		// posted before the bounty started
+		{ID: "2", UserID: "2", VoteCount: 8, CreatedAt: start.Add(-time.Hour)},
+		// not enough votes
+		{ID: "3", UserID: "3", VoteCount: 1, CreatedAt: start.Add(time.Hour)},
+		{ID: "4", UserID: "4", VoteCount: 3, CreatedAt: start.Add(3 * time.Hour)},
+		{ID: "5", UserID: "5", VoteCount: 3, CreatedAt: start.Add(2 * time.Hour)},
+	}
+	picked := pickAutoAwardAnswer(bounty, answerList)
+	if assert.NotNil(t, picked) {
+		assert.Equal(t, "5", picked.ID)
+	}
+
+	assert.Nil(t, pickAutoAwardAnswer(bounty, answerList[:3]))
+}
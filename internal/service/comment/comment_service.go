@@ -35,15 +35,18 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activityqueue"
 	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/content_processor"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/noticequeue"
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/permission"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_mute"
 	"github.com/apache/answer/internal/service/vector_sync"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -58,6 +61,7 @@ type CommentRepo interface {
 	GetComment(ctx context.Context, commentID string) (comment *entity.Comment, exist bool, err error)
 	GetCommentPage(ctx context.Context, commentQuery *CommentQuery) (
 		comments []*entity.Comment, total int64, err error)
+	GetCommentReplies(ctx context.Context, parentIDs []string) (comments []*entity.Comment, err error)
 }
 
 type CommentQuery struct {
@@ -68,6 +72,8 @@ type CommentQuery struct {
 	QueryCond string
 	// user id
 	UserID string
+	// TopLevel only the top-level comments, the deleted ones that still have replies are included
+	TopLevel bool
 }
 
 func (c *CommentQuery) GetOrderBy() string {
@@ -95,6 +101,8 @@ type CommentService struct {
 	eventQueueService                eventqueue.Service
 	reviewService                    *review.ReviewService
 	vectorSyncService                vector_sync.Service
+	userMuteService                  *user_mute.UserMuteService
+	contentProcessorService          *content_processor.ContentProcessorService
 }
 
 // NewCommentService new comment service
@@ -112,6 +120,8 @@ func NewCommentService(
 	eventQueueService eventqueue.Service,
 	reviewService *review.ReviewService,
 	vectorSyncService vector_sync.Service,
+	userMuteService *user_mute.UserMuteService,
+	contentProcessorService *content_processor.ContentProcessorService,
 ) *CommentService {
 	return &CommentService{
 		commentRepo:                      commentRepo,
@@ -127,6 +137,8 @@ func NewCommentService(
 		eventQueueService:                eventQueueService,
 		reviewService:                    reviewService,
 		vectorSyncService:                vectorSyncService,
+		userMuteService:                  userMuteService,
+		contentProcessorService:          contentProcessorService,
 	}
 }
 
@@ -151,25 +163,52 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		comment.QuestionID = objInfo.QuestionID
 	}
 
+	// the replies are single-level, the reply to a reply is attached to the top-level comment of the thread
+	parentUserID := ""
 	if len(req.ReplyCommentID) > 0 {
 		replyComment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.ReplyCommentID)
 		if err != nil {
 			return nil, err
 		}
-		if !exist {
+		if !exist || uid.DeShortID(replyComment.ObjectID) != objInfo.ObjectID {
 			return nil, errors.BadRequest(reason.CommentNotFound)
 		}
 		comment.SetReplyUserID(replyComment.UserID)
 		comment.SetReplyCommentID(replyComment.ID)
+		if parentID := replyComment.GetParentID(); len(parentID) > 0 {
+			comment.SetParentID(parentID)
+			parent, exist, err := cs.commentCommonRepo.GetComment(ctx, parentID)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				parentUserID = parent.UserID
+			}
+		} else {
+			comment.SetParentID(replyComment.ID)
+		}
 	} else {
 		comment.SetReplyUserID("")
 		comment.SetReplyCommentID("")
+		comment.SetParentID("")
+	}
+
+	processing := &plugin.ProcessingContent{
+		ObjectType:   constant.CommentObjectType,
+		UserID:       req.UserID,
+		OriginalText: comment.OriginalText,
+		ParsedText:   comment.ParsedText,
 	}
+	if err = cs.contentProcessorService.Process(ctx, processing); err != nil {
+		return nil, err
+	}
+	comment.OriginalText, comment.ParsedText = processing.OriginalText, processing.ParsedText
 
 	err = cs.commentRepo.AddComment(ctx, comment)
 	if err != nil {
 		return nil, err
 	}
+	cs.contentProcessorService.SaveMetadata(ctx, comment.ID, processing.Metadata)
 
 	comment.Status = cs.reviewService.AddCommentReview(ctx, comment, req.IP, req.UserAgent)
 	if err := cs.commentRepo.UpdateCommentStatus(ctx, comment.ID, comment.Status); err != nil {
@@ -182,7 +221,7 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		time.Now(), req.CanEdit, req.CanDelete)
 
 	if comment.Status == entity.CommentStatusAvailable {
-		if err := cs.addCommentNotification(ctx, req, resp, comment, objInfo); err != nil {
+		if err := cs.addCommentNotification(ctx, req, resp, comment, objInfo, parentUserID); err != nil {
 			return nil, err
 		}
 	}
@@ -232,9 +271,9 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 
 func (cs *CommentService) addCommentNotification(
 	ctx context.Context, req *schema.AddCommentReq, resp *schema.GetCommentResp,
-	comment *entity.Comment, objInfo *schema.SimpleObjectInfo) error {
+	comment *entity.Comment, objInfo *schema.SimpleObjectInfo, parentUserID string) error {
 	// The priority of the notification
-	// 1. reply to user
+	// 1. reply to user, the author of the top-level comment of the thread is also notified
 	// 2. comment mention to user
 	// 3. answer or question was commented
 	alreadyNotifiedUserID := make(map[string]bool)
@@ -253,6 +292,10 @@ func (cs *CommentService) addCommentNotification(
 		cs.notificationCommentReply(ctx, replyUser.ID, comment.ID, req.UserID,
 			objInfo.QuestionID, objInfo.Title, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
 		alreadyNotifiedUserID[replyUser.ID] = true
+		if len(parentUserID) > 0 && parentUserID != req.UserID && !alreadyNotifiedUserID[parentUserID] {
+			cs.notificationCommentReply(ctx, parentUserID, comment.ID, req.UserID,
+				objInfo.QuestionID, objInfo.Title, htmltext.FetchExcerpt(comment.ParsedText, "...", 240))
+		}
 		return nil
 	}
 
@@ -320,9 +363,22 @@ func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateC
 		return nil, errors.BadRequest(reason.CommentCannotEditAfterDeadline)
 	}
 
+	processing := &plugin.ProcessingContent{
+		ObjectType:   constant.CommentObjectType,
+		ObjectID:     old.ID,
+		UserID:       req.UserID,
+		OriginalText: req.OriginalText,
+		ParsedText:   req.ParsedText,
+	}
+	if err = cs.contentProcessorService.Process(ctx, processing); err != nil {
+		return nil, err
+	}
+	req.OriginalText, req.ParsedText = processing.OriginalText, processing.ParsedText
+
 	if err = cs.commentRepo.UpdateCommentContent(ctx, old.ID, req.OriginalText, req.ParsedText); err != nil {
 		return nil, err
 	}
+	cs.contentProcessorService.SaveMetadata(ctx, old.ID, processing.Metadata)
 	resp = &schema.UpdateCommentResp{
 		CommentID:    old.ID,
 		OriginalText: req.OriginalText,
@@ -362,6 +418,7 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 		UserID:         comment.UserID,
 		ReplyUserID:    comment.GetReplyUserID(),
 		ReplyCommentID: comment.GetReplyCommentID(),
+		ParentID:       comment.GetParentID(),
 		ObjectID:       comment.ObjectID,
 		VoteCount:      comment.VoteCount,
 		OriginalText:   comment.OriginalText,
@@ -417,46 +474,113 @@ func (cs *CommentService) GetCommentWithPage(ctx context.Context, req *schema.Ge
 		PageCond:  pager.PageCond{Page: req.Page, PageSize: req.PageSize},
 		ObjectID:  req.ObjectID,
 		QueryCond: req.QueryCond,
+		TopLevel:  true,
 	}
 	commentList, total, err := cs.commentRepo.GetCommentPage(ctx, dto)
 	if err != nil {
 		return nil, err
 	}
-	resp := make([]*schema.GetCommentResp, 0)
-	for _, comment := range commentList {
-		commentResp, err := cs.convertCommentEntity2Resp(ctx, req, comment)
-		if err != nil {
-			return nil, err
-		}
-		resp = append(resp, commentResp)
-	}
 
-	// if user request the specific comment, add it if not exist.
+	// if user request the specific comment, add its thread if not exist.
 	if len(req.CommentID) > 0 {
-		commentExist := false
-		for _, t := range resp {
-			if t.CommentID == req.CommentID {
-				commentExist = true
+		threadExist := false
+		for _, t := range commentList {
+			if t.ID == req.CommentID {
+				threadExist = true
 				break
 			}
 		}
-		if !commentExist {
-			comment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.CommentID)
+		if !threadExist {
+			thread, err := cs.getCommentThread(ctx, req.CommentID)
 			if err != nil {
 				return nil, err
 			}
-			if exist && comment.ObjectID == req.ObjectID {
-				commentResp, err := cs.convertCommentEntity2Resp(ctx, req, comment)
-				if err != nil {
-					return nil, err
-				}
-				resp = append(resp, commentResp)
+			if thread != nil && thread.ObjectID == req.ObjectID {
+				commentList = append(commentList, thread)
 			}
 		}
 	}
+
+	resp, err := cs.convertCommentThreads2Resp(ctx, req, commentList)
+	if err != nil {
+		return nil, err
+	}
 	return pager.NewPageModel(total, resp), nil
 }
 
+// getCommentThread get the top-level comment of the thread that the comment belongs to
+func (cs *CommentService) getCommentThread(ctx context.Context, commentID string) (
+	thread *entity.Comment, err error) {
+	comment, exist, err := cs.commentCommonRepo.GetComment(ctx, commentID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	parentID := comment.GetParentID()
+	if len(parentID) == 0 {
+		return comment, nil
+	}
+	parent, exist, err := cs.commentCommonRepo.GetCommentWithoutStatus(ctx, parentID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// convertCommentThreads2Resp convert the top-level comments with their replies nested in them
+func (cs *CommentService) convertCommentThreads2Resp(ctx context.Context, req *schema.GetCommentWithPageReq,
+	commentList []*entity.Comment) (resp []*schema.GetCommentResp, err error) {
+	parentIDs := make([]string, 0, len(commentList))
+	for _, comment := range commentList {
+		parentIDs = append(parentIDs, comment.ID)
+	}
+	replies, err := cs.commentRepo.GetCommentReplies(ctx, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	parentReplies := make(map[string][]*entity.Comment, len(commentList))
+	for _, reply := range replies {
+		parentReplies[reply.GetParentID()] = append(parentReplies[reply.GetParentID()], reply)
+	}
+	mutedUsers, err := cs.userMuteService.GetMutedUserMapping(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = make([]*schema.GetCommentResp, 0, len(commentList))
+	for _, comment := range commentList {
+		var commentResp *schema.GetCommentResp
+		if comment.Status == entity.CommentStatusAvailable {
+			commentResp, err = cs.convertCommentEntity2Resp(ctx, req, comment)
+			if err != nil {
+				return nil, err
+			}
+			commentResp.Muted = mutedUsers[comment.UserID]
+		} else {
+			if comment.Status != entity.CommentStatusDeleted || len(parentReplies[comment.ID]) == 0 {
+				continue
+			}
+			commentResp = &schema.GetCommentResp{
+				CommentID:     comment.ID,
+				CreatedAt:     comment.CreatedAt.Unix(),
+				ObjectID:      comment.ObjectID,
+				Deleted:       true,
+				MemberActions: make([]*schema.PermissionMemberAction, 0),
+			}
+		}
+		commentResp.Replies = make([]*schema.GetCommentResp, 0, len(parentReplies[comment.ID]))
+		for _, reply := range parentReplies[comment.ID] {
+			replyResp, err := cs.convertCommentEntity2Resp(ctx, req, reply)
+			if err != nil {
+				return nil, err
+			}
+			replyResp.Muted = mutedUsers[reply.UserID]
+			commentResp.Replies = append(commentResp.Replies, replyResp)
+		}
+		resp = append(resp, commentResp)
+	}
+	return resp, nil
+}
+
 func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *schema.GetCommentWithPageReq,
 	comment *entity.Comment) (commentResp *schema.GetCommentResp, err error) {
 	commentResp = &schema.GetCommentResp{
@@ -465,6 +589,7 @@ func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *sc
 		UserID:         comment.UserID,
 		ReplyUserID:    comment.GetReplyUserID(),
 		ReplyCommentID: comment.GetReplyCommentID(),
+		ParentID:       comment.GetParentID(),
 		ObjectID:       comment.ObjectID,
 		VoteCount:      comment.VoteCount,
 		OriginalText:   comment.OriginalText,
@@ -22,11 +22,13 @@ package content
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/apache/answer/internal/service/eventqueue"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
@@ -34,10 +36,14 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activityqueue"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
+	"github.com/apache/answer/internal/service/content_processor"
+	"github.com/apache/answer/internal/service/draft"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/noticequeue"
 	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/post_lock"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
@@ -48,6 +54,7 @@ import (
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
@@ -72,6 +79,10 @@ type AnswerService struct {
 	reviewService                    *review.ReviewService
 	eventQueueService                eventqueue.Service
 	vectorSyncService                vector_sync.Service
+	auditLogService                  *audit_log.AuditLogService
+	draftService                     *draft.DraftService
+	contentProcessorService          *content_processor.ContentProcessorService
+	postLockService                  *post_lock.PostLockService
 }
 
 func NewAnswerService(
@@ -93,6 +104,10 @@ func NewAnswerService(
 	reviewService *review.ReviewService,
 	eventQueueService eventqueue.Service,
 	vectorSyncService vector_sync.Service,
+	auditLogService *audit_log.AuditLogService,
+	draftService *draft.DraftService,
+	contentProcessorService *content_processor.ContentProcessorService,
+	postLockService *post_lock.PostLockService,
 ) *AnswerService {
 	return &AnswerService{
 		answerRepo:                       answerRepo,
@@ -113,6 +128,10 @@ func NewAnswerService(
 		reviewService:                    reviewService,
 		eventQueueService:                eventQueueService,
 		vectorSyncService:                vectorSyncService,
+		auditLogService:                  auditLogService,
+		draftService:                     draftService,
+		contentProcessorService:          contentProcessorService,
+		postLockService:                  postLockService,
 	}
 }
 
@@ -133,7 +152,7 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 	if err != nil {
 		return err
 	}
-	if roleID != role.RoleAdminID && roleID != role.RoleModeratorID {
+	if !as.roleService.IsAdminModeratorRole(ctx, roleID) {
 		if answerInfo.UserID != req.UserID {
 			return errors.BadRequest(reason.AnswerCannotDeleted)
 		}
@@ -156,6 +175,16 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 	if err != nil {
 		return err
 	}
+	if answerInfo.UserID != req.UserID {
+		as.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			ActorID:    req.UserID,
+			Action:     constant.AuditActionAnswerDelete,
+			ObjectType: constant.AnswerObjectType,
+			ObjectID:   answerInfo.ID,
+			Before:     map[string]any{"status": answerInfo.Status},
+			After:      map[string]any{"status": entity.AnswerStatusDeleted},
+		})
+	}
 
 	// user add question count
 	err = as.questionCommon.UpdateAnswerCount(ctx, answerInfo.QuestionID)
@@ -181,12 +210,10 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 		log.Error("RemoveQuestionLink error", err.Error())
 	}
 
-	// #2372 In order to simplify the process and complexity, as well as to consider if it is in-house,
-	// facing the problem of recovery.
-	// err = as.answerActivityService.DeleteAnswer(ctx, answerInfo.ID, answerInfo.CreatedAt, answerInfo.VoteCount)
-	// if err != nil {
-	// 	log.Errorf("delete answer activity change failed: %s", err.Error())
-	// }
+	err = as.answerActivityService.DeleteObject(ctx, answerInfo.ID)
+	if err != nil {
+		log.Errorf("delete answer activity change failed: %s", err.Error())
+	}
 	as.activityQueueService.Send(ctx, &schema.ActivityMsg{
 		UserID:           req.UserID,
 		TriggerUserID:    converter.StringToInt64(req.UserID),
@@ -216,6 +243,19 @@ func (as *AnswerService) RecoverAnswer(ctx context.Context, req *schema.RecoverA
 	if err = as.answerRepo.RecoverAnswer(ctx, req.AnswerID); err != nil {
 		return err
 	}
+	if answerInfo.UserID != req.UserID {
+		as.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			ActorID:    req.UserID,
+			Action:     constant.AuditActionAnswerRecover,
+			ObjectType: constant.AnswerObjectType,
+			ObjectID:   answerInfo.ID,
+			Before:     map[string]any{"status": entity.AnswerStatusDeleted},
+			After:      map[string]any{"status": entity.AnswerStatusAvailable},
+		})
+	}
+	if err = as.answerActivityService.RecoverObject(ctx, answerInfo.ID); err != nil {
+		log.Errorf("recover answer activity change failed: %s", err.Error())
+	}
 	if err = as.questionRepo.RecoverQuestionLink(ctx, &entity.QuestionLink{
 		FromQuestionID: answerInfo.QuestionID,
 		FromAnswerID:   answerInfo.ID,
@@ -258,10 +298,22 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	if !exist {
 		return "", errors.BadRequest(reason.QuestionNotFound)
 	}
-	if questionInfo.Status == entity.QuestionStatusClosed || questionInfo.Status == entity.QuestionStatusDeleted {
+	if questionInfo.Status == entity.QuestionStatusClosed || questionInfo.Status == entity.QuestionStatusDeleted ||
+		questionInfo.Status == entity.QuestionStatusScheduled {
 		err = errors.BadRequest(reason.AnswerCannotAddByClosedQuestion)
 		return "", err
 	}
+	processing := &plugin.ProcessingContent{
+		ObjectType:   constant.AnswerObjectType,
+		UserID:       req.UserID,
+		OriginalText: req.Content,
+		ParsedText:   req.HTML,
+	}
+	if err = as.contentProcessorService.Process(ctx, processing); err != nil {
+		return "", err
+	}
+	req.Content, req.HTML = processing.OriginalText, processing.ParsedText
+
 	insertData := &entity.Answer{}
 	insertData.UserID = req.UserID
 	insertData.OriginalText = req.Content
@@ -275,6 +327,7 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	if err = as.answerRepo.AddAnswer(ctx, insertData); err != nil {
 		return "", err
 	}
+	as.contentProcessorService.SaveMetadata(ctx, insertData.ID, processing.Metadata)
 	insertData.Status = as.reviewService.AddAnswerReview(ctx, insertData, req.IP, req.UserAgent)
 	if err := as.answerRepo.UpdateAnswerStatus(ctx, insertData.ID, insertData.Status); err != nil {
 		return "", err
@@ -344,6 +397,7 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 		as.vectorSyncService.Send(ctx, &vector_sync.Task{Action: vector_sync.ActionUpsert, ObjectType: vector_sync.ObjectTypeAnswer, ObjectID: insertData.ID})
 		as.vectorSyncService.Send(ctx, &vector_sync.Task{Action: vector_sync.ActionUpsert, ObjectType: vector_sync.ObjectTypeQuestion, ObjectID: insertData.QuestionID})
 	}
+	as.draftService.ClearAnswerDraft(ctx, insertData.UserID, insertData.QuestionID)
 	return insertData.ID, nil
 }
 
@@ -381,12 +435,23 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 		return "", nil
 	}
 
+	processing := &plugin.ProcessingContent{
+		ObjectType:   constant.AnswerObjectType,
+		ObjectID:     req.ID,
+		UserID:       req.UserID,
+		OriginalText: req.Content,
+		ParsedText:   req.HTML,
+	}
+	if err = as.contentProcessorService.Process(ctx, processing); err != nil {
+		return "", err
+	}
+
 	insertData := &entity.Answer{}
 	insertData.ID = req.ID
 	insertData.UserID = answerInfo.UserID
 	insertData.QuestionID = questionInfo.ID
-	insertData.OriginalText = req.Content
-	insertData.ParsedText = req.HTML
+	insertData.OriginalText = processing.OriginalText
+	insertData.ParsedText = processing.ParsedText
 	insertData.UpdatedAt = time.Now()
 	insertData.LastEditUserID = "0"
 	if answerInfo.UserID != req.UserID {
@@ -413,6 +478,7 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 		if err = as.answerRepo.UpdateAnswer(ctx, insertData, []string{"original_text", "parsed_text", "updated_at", "last_edit_user_id"}); err != nil {
 			return "", err
 		}
+		as.contentProcessorService.SaveMetadata(ctx, insertData.ID, processing.Metadata)
 		err = as.questionCommon.UpdatePostTime(ctx, questionInfo.ID)
 		if err != nil {
 			return insertData.ID, err
@@ -423,7 +489,7 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 
 	infoJSON, _ := json.Marshal(insertData)
 	revisionDTO.Content = string(infoJSON)
-	revisionID, err := as.revisionService.AddRevision(ctx, revisionDTO, true)
+	revisionID, err := as.revisionService.AddRevision(ctx, revisionDTO, canUpdate)
 	if err != nil {
 		return insertData.ID, err
 	}
@@ -557,6 +623,7 @@ func (as *AnswerService) Get(ctx context.Context, answerID, loginUserID string,
 
 	if (question.Status == entity.QuestionStatusDeleted ||
 		question.Status == entity.QuestionStatusPending ||
+		question.Status == entity.QuestionStatusScheduled ||
 		question.Show == entity.QuestionHide) &&
 		!isAdminModerator && question.UserID != loginUserID {
 		return nil, nil, false, errors.NotFound(reason.AnswerNotFound)
@@ -590,6 +657,10 @@ func (as *AnswerService) Get(ctx context.Context, answerID, loginUserID string,
 	if ok {
 		info.UpdateUserInfo = userInfoMap[answerInfo.LastEditUserID]
 	}
+	info.Lock, err = as.postLockService.GetPostLockInfo(ctx, answerInfo.ID)
+	if err != nil {
+		return nil, nil, has, err
+	}
 
 	if loginUserID == "" {
 		return info, questionInfo, has, nil
@@ -678,19 +749,22 @@ func (as *AnswerService) AdminSetAnswerStatus(ctx context.Context, req *schema.A
 
 func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListReq) ([]*schema.AnswerInfo, int64, error) {
 	list := make([]*schema.AnswerInfo, 0)
-	questionInfo, exist, err := as.questionRepo.GetQuestion(ctx, req.QuestionID)
-	if err != nil {
+	if err := as.checkAnswerListQuestion(ctx, req); err != nil {
 		return list, 0, err
 	}
-	if !exist {
-		return list, 0, errors.NotFound(reason.QuestionNotFound)
-	}
-	if (questionInfo.Status == entity.QuestionStatusDeleted ||
-		questionInfo.Status == entity.QuestionStatusPending ||
-		questionInfo.Show == entity.QuestionHide) &&
-		!req.IsAdminModerator && questionInfo.UserID != req.UserID {
-		return list, 0, errors.NotFound(reason.QuestionNotFound)
+	if as.isSharedAnswerList(ctx, req) {
+		answerList, count, err := as.searchSharedList(ctx, req)
+		if err != nil {
+			return answerList, count, err
+		}
+		answerList, err = as.formatViewerInfo(ctx, answerList, req)
+		if err != nil {
+			return answerList, count, err
+		}
+		answerList, err = as.formatLockInfo(ctx, answerList)
+		return answerList, count, err
 	}
+
 	dbSearch := entity.AnswerSearch{}
 	dbSearch.QuestionID = req.QuestionID
 	dbSearch.Page = req.Page
@@ -706,18 +780,160 @@ func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListR
 	if err != nil {
 		return answerList, count, err
 	}
-	return answerList, count, nil
+	answerList, err = as.formatLockInfo(ctx, answerList)
+	return answerList, count, err
+}
+
+// SearchListByCursor get the answers of the question after the cursor, the next cursor is empty if it is the last page.
+// The answers are always got from the database, the shared cached answer page is only for the offset pagination.
+func (as *AnswerService) SearchListByCursor(ctx context.Context, req *schema.AnswerListReq) (
+	list []*schema.AnswerInfo, nextCursor string, err error) {
+	list = make([]*schema.AnswerInfo, 0)
+	if err = as.checkAnswerListQuestion(ctx, req); err != nil {
+		return list, "", err
+	}
+
+	dbSearch := &entity.AnswerSearch{}
+	dbSearch.QuestionID = req.QuestionID
+	dbSearch.PageSize = req.PageSize
+	dbSearch.Order = req.Order
+	dbSearch.IncludeDeleted = req.CanDelete
+	dbSearch.LoginUserID = req.UserID
+	answerOriginalList, nextCursor, err := as.answerRepo.SearchListByCursor(ctx, dbSearch, req.Cursor)
+	if err != nil {
+		return list, "", err
+	}
+	list, err = as.SearchFormatInfo(ctx, answerOriginalList, req)
+	if err != nil {
+		return list, "", err
+	}
+	list, err = as.formatLockInfo(ctx, list)
+	if err != nil {
+		return list, "", err
+	}
+	return list, nextCursor, nil
+}
+
+// checkAnswerListQuestion the answers of the question that is not available are only listed for the author of it,
+// the admins and the moderators
+func (as *AnswerService) checkAnswerListQuestion(ctx context.Context, req *schema.AnswerListReq) (err error) {
+	questionInfo, exist, err := as.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.NotFound(reason.QuestionNotFound)
+	}
+	if (questionInfo.Status == entity.QuestionStatusDeleted ||
+		questionInfo.Status == entity.QuestionStatusPending ||
+		questionInfo.Status == entity.QuestionStatusScheduled ||
+		questionInfo.Show == entity.QuestionHide) &&
+		!req.IsAdminModerator && questionInfo.UserID != req.UserID {
+		return errors.NotFound(reason.QuestionNotFound)
+	}
+	return nil
+}
+
+// answerPagePayload the cached answer page that is the same for all the viewers, the ids hidden from the
+// json response are kept beside the answers in the same order
+type answerPagePayload struct {
+	List          []*schema.AnswerInfo `json:"list"`
+	Count         int64                `json:"count"`
+	UserIDs       []string             `json:"user_ids"`
+	UpdateUserIDs []string             `json:"update_user_ids"`
+}
+
+// isSharedAnswerList whether the viewer gets the same answers as a guest, the viewers who can see the deleted
+// answers or have their own answers that are not available get the answers from the database directly
+func (as *AnswerService) isSharedAnswerList(ctx context.Context, req *schema.AnswerListReq) bool {
+	if req.CanDelete {
+		return false
+	}
+	if len(req.UserID) == 0 {
+		return true
+	}
+	answers, err := as.answerRepo.GetAnswerList(ctx, &entity.Answer{QuestionID: req.QuestionID, UserID: req.UserID})
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	for _, answer := range answers {
+		if answer.Status != entity.AnswerStatusAvailable {
+			return false
+		}
+	}
+	return true
+}
+
+// searchSharedList get the answer page that is the same for all the viewers through the cache
+func (as *AnswerService) searchSharedList(ctx context.Context, req *schema.AnswerListReq) (
+	list []*schema.AnswerInfo, count int64, err error) {
+	variant := fmt.Sprintf("answers:%d:%d:%s:%s:%t", req.Page, req.PageSize, req.Order,
+		handler.GetLangByCtx(ctx), handler.GetEnableShortID(ctx))
+	version, err := as.questionRepo.GetQuestionPayloadVersion(ctx, req.QuestionID)
+	if err != nil {
+		log.Error(err)
+	} else {
+		payload := &answerPagePayload{}
+		exist, err := as.questionRepo.GetQuestionPayloadCache(ctx, req.QuestionID, version, variant, payload)
+		if err != nil {
+			log.Error(err)
+		}
+		if exist && len(payload.UserIDs) == len(payload.List) && len(payload.UpdateUserIDs) == len(payload.List) {
+			for i, item := range payload.List {
+				item.UserID = payload.UserIDs[i]
+				item.UpdateUserID = payload.UpdateUserIDs[i]
+			}
+			return payload.List, payload.Count, nil
+		}
+	}
+
+	dbSearch := &entity.AnswerSearch{}
+	dbSearch.QuestionID = req.QuestionID
+	dbSearch.Page = req.Page
+	dbSearch.PageSize = req.PageSize
+	dbSearch.Order = req.Order
+	answers, count, err := as.answerRepo.SearchList(ctx, dbSearch)
+	if err != nil {
+		return make([]*schema.AnswerInfo, 0), count, err
+	}
+	list, err = as.formatSharedInfo(ctx, answers)
+	if err != nil || len(version) == 0 {
+		return list, count, err
+	}
+	payload := &answerPagePayload{
+		List:          list,
+		Count:         count,
+		UserIDs:       make([]string, 0, len(list)),
+		UpdateUserIDs: make([]string, 0, len(list)),
+	}
+	for _, item := range list {
+		payload.UserIDs = append(payload.UserIDs, item.UserID)
+		payload.UpdateUserIDs = append(payload.UpdateUserIDs, item.UpdateUserID)
+	}
+	if err = as.questionRepo.SetQuestionPayloadCache(ctx, req.QuestionID, version, variant, payload); err != nil {
+		log.Error(err)
+	}
+	return list, count, nil
 }
 
 func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity.Answer, req *schema.AnswerListReq) (
+	[]*schema.AnswerInfo, error) {
+	list, err := as.formatSharedInfo(ctx, answers)
+	if err != nil {
+		return list, err
+	}
+	return as.formatViewerInfo(ctx, list, req)
+}
+
+// formatSharedInfo format the answers with the information that is the same for all the viewers
+func (as *AnswerService) formatSharedInfo(ctx context.Context, answers []*entity.Answer) (
 	[]*schema.AnswerInfo, error) {
 	list := make([]*schema.AnswerInfo, 0)
-	objectIDs := make([]string, 0)
 	userIDs := make([]string, 0)
 	for _, info := range answers {
 		item := as.ShowFormat(ctx, info)
 		list = append(list, item)
-		objectIDs = append(objectIDs, info.ID)
 		userIDs = append(userIDs, info.UserID, info.LastEditUserID)
 	}
 
@@ -729,9 +945,19 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 		item.UserInfo = userInfoMap[item.UserID]
 		item.UpdateUserInfo = userInfoMap[item.UpdateUserID]
 	}
+	return list, nil
+}
+
+// formatViewerInfo add the vote, the collection and the actions of the viewer to the answers
+func (as *AnswerService) formatViewerInfo(ctx context.Context, list []*schema.AnswerInfo, req *schema.AnswerListReq) (
+	[]*schema.AnswerInfo, error) {
 	if len(req.UserID) == 0 {
 		return list, nil
 	}
+	objectIDs := make([]string, 0, len(list))
+	for _, item := range list {
+		objectIDs = append(objectIDs, item.ID)
+	}
 
 	collectedMap, err := as.collectionCommon.SearchObjectCollected(ctx, req.UserID, objectIDs)
 	if err != nil {
@@ -744,13 +970,30 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 			req.UserID,
 			item.UserID,
 			item.Status,
-			req.CanEdit,
+			req.CanEdit || (item.Wiki && req.CanEditWiki),
 			req.CanDelete,
 			req.CanRecover)
 	}
 	return list, nil
 }
 
+// formatLockInfo add the locks to the answers, they are not cached with the shared answer list
+func (as *AnswerService) formatLockInfo(ctx context.Context, list []*schema.AnswerInfo) (
+	[]*schema.AnswerInfo, error) {
+	objectIDs := make([]string, 0, len(list))
+	for _, item := range list {
+		objectIDs = append(objectIDs, item.ID)
+	}
+	locks, err := as.postLockService.GetPostLocks(ctx, objectIDs...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		item.Lock = locks[item.ID].ToInfo()
+	}
+	return list, nil
+}
+
 func (as *AnswerService) ShowFormat(ctx context.Context, data *entity.Answer) *schema.AnswerInfo {
 	return as.AnswerCommon.ShowFormat(ctx, data)
 }
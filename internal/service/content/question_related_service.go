@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	relatedTagWeight     = 2.0
+	relatedTermWeight    = 2.0
+	relatedRecencyWeight = 1.0
+	// relatedRecencyDays the recency score of a question this many days old is half of a new one
+	relatedRecencyDays = 30.0
+)
+
+// relatedQuestionCandidate the question matched by the shared tags or the terms of the title and the content
+type relatedQuestionCandidate struct {
+	question       *entity.Question
+	sharedTagCount int
+	// termScore the relevance given by the search engine, from 0 to 1
+	termScore float64
+}
+
+// score the relevance of the shared tags and the terms, plus the recency of the question
+func (c *relatedQuestionCandidate) score(tagAmount int, now time.Time) float64 {
+	score := c.termScore * relatedTermWeight
+	if tagAmount > 0 {
+		score += float64(c.sharedTagCount) / float64(tagAmount) * relatedTagWeight
+	}
+	ageDays := max(now.Sub(c.question.CreatedAt).Hours()/24, 0)
+	return score + relatedRecencyWeight/(1+ageDays/relatedRecencyDays)
+}
+
+// GetRelatedQuestions get the questions related to the question, they are ranked by the shared tags, the overlapped
+// terms of the title and the content, and the recency. The ranked list is cached for a while for each question.
+func (qs *QuestionService) GetRelatedQuestions(ctx context.Context, req *schema.GetRelatedQuestionsReq) (
+	resp []*schema.RelatedQuestionResp, err error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = constant.RelatedQuestionDefaultSize
+	}
+	resp, exist, err := qs.questionRepo.GetRelatedQuestionsCache(ctx, req.QuestionID)
+	if err != nil {
+		log.Errorf("get related questions cache failed, err: %v", err)
+	}
+	if !exist {
+		resp, err = qs.getRelatedQuestions(ctx, req.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		if err = qs.questionRepo.SetRelatedQuestionsCache(ctx, req.QuestionID, resp); err != nil {
+			log.Errorf("set related questions cache failed, err: %v", err)
+		}
+	}
+
+	if len(resp) > pageSize {
+		resp = resp[:pageSize]
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range resp {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return resp, nil
+}
+
+// getRelatedQuestions match and rank the related questions, only the available questions are related
+func (qs *QuestionService) getRelatedQuestions(ctx context.Context, questionID string) (
+	resp []*schema.RelatedQuestionResp, err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	tags, err := qs.tagCommon.GetObjectEntityTag(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	candidates := make(map[string]*relatedQuestionCandidate)
+	tagQuestions, sharedTagCount, err := qs.questionRepo.GetRelatedQuestionsByTags(ctx, questionID, tagIDs,
+		constant.RelatedQuestionCandidateSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range tagQuestions {
+		candidate := &relatedQuestionCandidate{question: item, sharedTagCount: sharedTagCount[item.ID]}
+		item.ID = uid.DeShortID(item.ID)
+		candidates[item.ID] = candidate
+	}
+
+	// the terms are scored only if the search engine is available, otherwise the questions are matched by tags only
+	termScores := qs.searchRelatedQuestionTerms(ctx, question)
+	missingIDs := make([]string, 0)
+	for id, termScore := range termScores {
+		if candidate, ok := candidates[id]; ok {
+			candidate.termScore = termScore
+		} else if id != uid.DeShortID(questionID) {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	if len(missingIDs) > 0 {
+		termQuestions, err := qs.questionRepo.FindByID(ctx, missingIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range termQuestions {
+			if item.Status != entity.QuestionStatusAvailable || item.Show != entity.QuestionShow {
+				continue
+			}
+			item.ID = uid.DeShortID(item.ID)
+			candidates[item.ID] = &relatedQuestionCandidate{question: item, termScore: termScores[item.ID]}
+		}
+	}
+
+	list := make([]*relatedQuestionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		list = append(list, candidate)
+	}
+	list = rankRelatedQuestions(list, len(tagIDs), time.Now())
+
+	resp = make([]*schema.RelatedQuestionResp, 0, len(list))
+	for _, candidate := range list {
+		item := candidate.question
+		resp = append(resp, &schema.RelatedQuestionResp{
+			ID:             item.ID,
+			Title:          item.Title,
+			UrlTitle:       htmltext.UrlTitle(item.Title),
+			ViewCount:      item.ViewCount,
+			AnswerCount:    item.AnswerCount,
+			VoteCount:      item.VoteCount,
+			AcceptedAnswer: item.AcceptedAnswerID != "0",
+			CreatedAt:      item.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// searchRelatedQuestionTerms search the questions by the title with the search plugin, the relevance of each
+// question is scored by its rank in the results. It returns nil if there is no search plugin or it fails.
+func (qs *QuestionService) searchRelatedQuestionTerms(ctx context.Context, question *entity.Question) (
+	termScores map[string]float64) {
+	var finder plugin.Search
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		finder = search
+		return nil
+	})
+	if finder == nil {
+		return nil
+	}
+	res, _, err := finder.SearchQuestions(ctx, &plugin.SearchBasicCond{
+		Words:        []string{question.Title},
+		Page:         1,
+		PageSize:     constant.RelatedQuestionCandidateSize,
+		VoteAmount:   -1,
+		ViewAmount:   -1,
+		AnswerAmount: -1,
+	})
+	if err != nil {
+		log.Warnf("search related questions by plugin failed, fall back to tags: %v", err)
+		return nil
+	}
+	termScores = make(map[string]float64, len(res))
+	for i, item := range res {
+		termScores[uid.DeShortID(item.ID)] = 1 - float64(i)/float64(len(res))
+	}
+	return termScores
+}
+
+// rankRelatedQuestions sort the candidates by the score, the newer ones come first if the scores are the same.
+// At most RelatedQuestionMaxSize candidates are kept.
+func rankRelatedQuestions(candidates []*relatedQuestionCandidate, tagAmount int, now time.Time) (
+	ranked []*relatedQuestionCandidate) {
+	scores := make(map[*relatedQuestionCandidate]float64, len(candidates))
+	for _, candidate := range candidates {
+		scores[candidate] = candidate.score(tagAmount, now)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+		return candidates[i].question.CreatedAt.After(candidates[j].question.CreatedAt)
+	})
+	if len(candidates) > constant.RelatedQuestionMaxSize {
+		candidates = candidates[:constant.RelatedQuestionMaxSize]
+	}
+	return candidates
+}
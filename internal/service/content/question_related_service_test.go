@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankRelatedQuestions(t *testing.T) {
+	now := time.Now()
+	newCandidate := func(id string, ageDays, sharedTagCount int, termScore float64) *relatedQuestionCandidate {
+		return &relatedQuestionCandidate{
+			question:       &entity.Question{ID: id, CreatedAt: now.AddDate(0, 0, -ageDays)},
+			sharedTagCount: sharedTagCount,
+			termScore:      termScore,
+		}
+	}
+	ids := func(ranked []*relatedQuestionCandidate) []string {
+		result := make([]string, 0, len(ranked))
+		for _, candidate := range ranked {
+			result = append(result, candidate.question.ID)
+		}
+		return result
+	}
+
+	t.Run("shared tags and terms", func(t *testing.T) {
+		ranked := rankRelatedQuestions([]*relatedQuestionCandidate{
+			newCandidate("one-tag", 1, 1, 0),
+			newCandidate("all-tags", 1, 2, 0),
+			newCandidate("all-tags-and-terms", 1, 2, 0.8),
+		}, 2, now)
+		assert.Equal(t, []string{"all-tags-and-terms", "all-tags", "one-tag"}, ids(ranked))
+	})
+
+	t.Run("the newer ones come first", func(t *testing.T) {
+		ranked := rankRelatedQuestions([]*relatedQuestionCandidate{
+			newCandidate("old", 365, 1, 0),
+			newCandidate("new", 0, 1, 0),
+		}, 1, now)
+		assert.Equal(t, []string{"new", "old"}, ids(ranked))
+	})
+
+	t.Run("tag only", func(t *testing.T) {
+		ranked := rankRelatedQuestions([]*relatedQuestionCandidate{
+			newCandidate("one-tag", 0, 1, 0),
+			newCandidate("three-tags", 10, 3, 0),
+		}, 3, now)
+		assert.Equal(t, []string{"three-tags", "one-tag"}, ids(ranked))
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		candidates := make([]*relatedQuestionCandidate, 0)
+		for i := 0; i < 30; i++ {
+			candidates = append(candidates, newCandidate("q", i, 1, 0))
+		}
+		assert.Len(t, rankRelatedQuestions(candidates, 1, now), 20)
+	})
+}
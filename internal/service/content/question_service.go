@@ -41,17 +41,23 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activityqueue"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/content_processor"
+	"github.com/apache/answer/internal/service/draft"
 	"github.com/apache/answer/internal/service/export"
 	metacommon "github.com/apache/answer/internal/service/meta_common"
 	"github.com/apache/answer/internal/service/noticequeue"
 	"github.com/apache/answer/internal/service/notification"
 	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/post_lock"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_view"
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/internal/service/tag"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
@@ -59,6 +65,7 @@ import (
 	"github.com/apache/answer/internal/service/vector_sync"
 	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/display"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/pkg/uid"
@@ -95,6 +102,12 @@ type QuestionService struct {
 	eventQueueService                eventqueue.Service
 	reviewRepo                       review.ReviewRepo
 	vectorSyncService                vector_sync.Service
+	serviceConfig                    *service_config.ServiceConfig
+	auditLogService                  *audit_log.AuditLogService
+	draftService                     *draft.DraftService
+	contentProcessorService          *content_processor.ContentProcessorService
+	postLockService                  *post_lock.PostLockService
+	questionViewService              *question_view.QuestionViewService
 }
 
 func NewQuestionService(
@@ -122,6 +135,12 @@ func NewQuestionService(
 	eventQueueService eventqueue.Service,
 	reviewRepo review.ReviewRepo,
 	vectorSyncService vector_sync.Service,
+	serviceConfig *service_config.ServiceConfig,
+	auditLogService *audit_log.AuditLogService,
+	draftService *draft.DraftService,
+	contentProcessorService *content_processor.ContentProcessorService,
+	postLockService *post_lock.PostLockService,
+	questionViewService *question_view.QuestionViewService,
 ) *QuestionService {
 	return &QuestionService{
 		activityRepo:                     activityRepo,
@@ -148,6 +167,12 @@ func NewQuestionService(
 		eventQueueService:                eventQueueService,
 		reviewRepo:                       reviewRepo,
 		vectorSyncService:                vectorSyncService,
+		serviceConfig:                    serviceConfig,
+		auditLogService:                  auditLogService,
+		draftService:                     draftService,
+		contentProcessorService:          contentProcessorService,
+		postLockService:                  postLockService,
+		questionViewService:              questionViewService,
 	}
 }
 
@@ -164,8 +189,10 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	if err != nil || cf == nil {
 		return errors.BadRequest(reason.ReportNotFound)
 	}
-	if cf.Key == constant.ReasonADuplicate && !checker.IsURL(req.CloseMsg) {
-		return errors.BadRequest(reason.InvalidURLError)
+	if cf.Key == constant.ReasonADuplicate {
+		if err = qs.checkDuplicateCloseReq(ctx, req); err != nil {
+			return err
+		}
 	}
 
 	questionInfo.Status = entity.QuestionStatusClosed
@@ -175,15 +202,16 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	}
 
 	closeMeta, _ := json.Marshal(schema.CloseQuestionMeta{
-		CloseType: req.CloseType,
-		CloseMsg:  req.CloseMsg,
+		CloseType:           req.CloseType,
+		CloseMsg:            req.CloseMsg,
+		DuplicateQuestionID: req.DuplicateQuestionID,
 	})
 	err = qs.metaService.AddMeta(ctx, req.ID, entity.QuestionCloseReasonKey, string(closeMeta))
 	if err != nil {
 		return err
 	}
 	if cf.Key == constant.ReasonADuplicate {
-		qs.questioncommon.AddQuestionLinkForCloseReason(ctx, questionInfo, req.CloseMsg)
+		qs.questioncommon.AddQuestionLinkForCloseReason(ctx, questionInfo, req.DuplicateQuestionID)
 	}
 
 	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
@@ -195,6 +223,35 @@ func (qs *QuestionService) CloseQuestion(ctx context.Context, req *schema.CloseQ
 	return nil
 }
 
+// checkDuplicateCloseReq check the original question of the duplicate question,
+// and fill in the original question id and url which are not given
+func (qs *QuestionService) checkDuplicateCloseReq(ctx context.Context, req *schema.CloseQuestionReq) (err error) {
+	if len(req.DuplicateQuestionID) == 0 {
+		if !checker.IsURL(req.CloseMsg) {
+			return errors.BadRequest(reason.InvalidURLError)
+		}
+		req.DuplicateQuestionID = qs.questioncommon.GetDuplicateQuestionID(ctx, &schema.CloseQuestionMeta{CloseMsg: req.CloseMsg})
+	}
+	originalQuestion, err := qs.questioncommon.CheckDuplicateQuestion(ctx, req.ID, req.DuplicateQuestionID)
+	if err != nil {
+		return err
+	}
+	req.DuplicateQuestionID = uid.DeShortID(originalQuestion.ID)
+	if len(req.CloseMsg) > 0 {
+		return nil
+	}
+	siteGeneral, err := qs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return err
+	}
+	seoInfo, err := qs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return err
+	}
+	req.CloseMsg = display.QuestionURL(seoInfo.Permalink, siteGeneral.SiteUrl, req.DuplicateQuestionID, originalQuestion.Title)
+	return nil
+}
+
 // ReopenQuestion reopen question
 func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.ReopenQuestionReq) error {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.QuestionID)
@@ -220,6 +277,49 @@ func (qs *QuestionService) ReopenQuestion(ctx context.Context, req *schema.Reope
 	return nil
 }
 
+// UpdateQuestionSchedule change the publish time of the scheduled question, only the author and the admins can change it
+func (qs *QuestionService) UpdateQuestionSchedule(ctx context.Context, req *schema.UpdateQuestionScheduleReq) error {
+	if err := qs.checkQuestionSchedulable(ctx, req.ID, req.UserID, req.IsAdminModerator); err != nil {
+		return err
+	}
+	return qs.updateQuestionSchedule(ctx, req.ID, time.Unix(req.ScheduledAt, 0))
+}
+
+// CancelQuestionSchedule cancel the schedule of the question, it is kept unpublished until it is scheduled again
+func (qs *QuestionService) CancelQuestionSchedule(ctx context.Context, req *schema.CancelQuestionScheduleReq) error {
+	if err := qs.checkQuestionSchedulable(ctx, req.ID, req.UserID, req.IsAdminModerator); err != nil {
+		return err
+	}
+	return qs.updateQuestionSchedule(ctx, req.ID, time.Time{})
+}
+
+func (qs *QuestionService) checkQuestionSchedulable(ctx context.Context, questionID, userID string,
+	isAdminModerator bool) error {
+	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	if !has || (!isAdminModerator && questionInfo.UserID != userID) {
+		return errors.NotFound(reason.QuestionNotFound)
+	}
+	if questionInfo.Status != entity.QuestionStatusScheduled {
+		return errors.BadRequest(reason.QuestionNotScheduled)
+	}
+	return nil
+}
+
+func (qs *QuestionService) updateQuestionSchedule(ctx context.Context, questionID string, scheduledAt time.Time) error {
+	updated, err := qs.questionRepo.UpdateQuestionSchedule(ctx, questionID, scheduledAt)
+	if err != nil {
+		return err
+	}
+	// the question is published by the job in the meantime
+	if !updated {
+		return errors.BadRequest(reason.QuestionNotScheduled)
+	}
+	return nil
+}
+
 func (qs *QuestionService) AddQuestionCheckTags(ctx context.Context, tags []*entity.Tag) ([]string, error) {
 	list := make([]string, 0)
 	for _, tag := range tags {
@@ -246,18 +346,11 @@ func (qs *QuestionService) CheckAddQuestion(ctx context.Context, req *schema.Que
 		err = errors.BadRequest(reason.TagMinCount)
 		return errorlist, err
 	}
-	minimumContentLength, err := qs.questioncommon.GetMinimumContentLength(ctx)
-	if err != nil {
-		return
-	}
-	if len(req.Content) < minimumContentLength {
-		errorlist := make([]*validator.FormErrorField, 0)
-		errorlist = append(errorlist, &validator.FormErrorField{
-			ErrorField: "content",
-			ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionContentLessThanMinimum),
-		})
-		err = errors.BadRequest(reason.QuestionContentLessThanMinimum)
-		return errorlist, err
+	if errorlist, err := qs.questioncommon.CheckQuestionQuality(ctx, req.Title, req.Content, req.HTML); err != nil {
+		if len(errorlist) > 0 {
+			return errorlist, err
+		}
+		return nil, err
 	}
 	recommendExist, err := qs.tagCommon.ExistRecommend(ctx, req.Tags)
 	if err != nil {
@@ -318,18 +411,11 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		err = errors.BadRequest(reason.TagMinCount)
 		return errorlist, err
 	}
-	minimumContentLength, err := qs.questioncommon.GetMinimumContentLength(ctx)
-	if err != nil {
-		return
-	}
-	if len(req.Content) < minimumContentLength {
-		errorlist := make([]*validator.FormErrorField, 0)
-		errorlist = append(errorlist, &validator.FormErrorField{
-			ErrorField: "content",
-			ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionContentLessThanMinimum),
-		})
-		err = errors.BadRequest(reason.QuestionContentLessThanMinimum)
-		return errorlist, err
+	if errorlist, err := qs.questioncommon.CheckQuestionQuality(ctx, req.Title, req.Content, req.HTML); err != nil {
+		if len(errorlist) > 0 {
+			return errorlist, err
+		}
+		return nil, err
 	}
 	recommendExist, err := qs.tagCommon.ExistRecommend(ctx, req.Tags)
 	if err != nil {
@@ -369,6 +455,19 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		}
 	}
 
+	processing := &plugin.ProcessingContent{
+		ObjectType:   constant.QuestionObjectType,
+		UserID:       req.UserID,
+		Title:        req.Title,
+		OriginalText: req.Content,
+		ParsedText:   req.HTML,
+		Tags:         tagNameList,
+	}
+	if err = qs.contentProcessorService.Process(ctx, processing); err != nil {
+		return nil, err
+	}
+	req.Content, req.HTML = processing.OriginalText, processing.ParsedText
+
 	question := &entity.Question{}
 	now := time.Now()
 	question.UserID = req.UserID
@@ -385,12 +484,25 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	question.PostUpdateTime = now
 	question.Pin = entity.QuestionUnPin
 	question.Show = entity.QuestionShow
+	if req.ScheduledAt > 0 {
+		question.ScheduledAt = time.Unix(req.ScheduledAt, 0)
+	}
 	// question.UpdatedAt = nil
 	err = qs.questionRepo.AddQuestion(ctx, question)
 	if err != nil {
 		return
 	}
-	question.Status = qs.reviewService.AddQuestionReview(ctx, question, req.Tags, req.IP, req.UserAgent)
+	qs.contentProcessorService.SaveMetadata(ctx, question.ID, processing.Metadata)
+	if req.IsGuest {
+		question.Status = qs.reviewService.AddGuestQuestionReview(ctx, question, req.Tags, req.GuestEmail,
+			req.IP, req.UserAgent)
+	} else {
+		question.Status = qs.reviewService.AddQuestionReview(ctx, question, req.Tags, req.IP, req.UserAgent)
+	}
+	// the approved question is kept hidden until the scheduled time, the notifications are sent when it is published
+	if question.Status == entity.QuestionStatusAvailable && !question.ScheduledAt.IsZero() {
+		question.Status = entity.QuestionStatusScheduled
+	}
 	if err := qs.questionRepo.UpdateQuestionStatus(ctx, question.ID, question.Status); err != nil {
 		return nil, err
 	}
@@ -458,11 +570,14 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 				schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, newTags))
 		}
 	}
-	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
-		QID(question.ID, question.UserID))
+	if question.Status != entity.QuestionStatusScheduled {
+		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
+			QID(question.ID, question.UserID))
+	}
 	if question.Status == entity.QuestionStatusAvailable {
 		qs.vectorSyncService.Send(ctx, &vector_sync.Task{Action: vector_sync.ActionUpsert, ObjectType: vector_sync.ObjectTypeQuestion, ObjectID: question.ID})
 	}
+	qs.draftService.ClearQuestionDraft(ctx, question.UserID)
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
 	return
@@ -485,6 +600,10 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if questionInfo.Pin == entity.QuestionPin && req.Operation == schema.QuestionOperationHide {
 		return nil
 	}
+	if len(req.Tag) > 0 &&
+		(req.Operation == schema.QuestionOperationPin || req.Operation == schema.QuestionOperationUnPin) {
+		return qs.operationQuestionTagPin(ctx, questionInfo, req)
+	}
 
 	switch req.Operation {
 	case schema.QuestionOperationHide:
@@ -524,9 +643,18 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 			return err
 		}
 	case schema.QuestionOperationPin:
+		pinExpiredAt, err := checkQuestionPin(questionInfo.Pin == entity.QuestionPin, req.PinExpiredAt,
+			constant.QuestionPinMaxAmount, func() (int64, error) {
+				return qs.questionRepo.GetPinnedQuestionCount(ctx)
+			})
+		if err != nil {
+			return err
+		}
 		questionInfo.Pin = entity.QuestionPin
+		questionInfo.PinExpiredAt = pinExpiredAt
 	case schema.QuestionOperationUnPin:
 		questionInfo.Pin = entity.QuestionUnPin
+		questionInfo.PinExpiredAt = time.Time{}
 	}
 
 	err = qs.questionRepo.UpdateQuestionOperation(ctx, questionInfo)
@@ -552,6 +680,81 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	return nil
 }
 
+// operationQuestionTagPin pin or unpin the question within one of its tags, the global pin is not changed
+func (qs *QuestionService) operationQuestionTagPin(ctx context.Context, questionInfo *entity.Question,
+	req *schema.OperationQuestionReq) (err error) {
+	tags, err := qs.tagCommon.GetObjectEntityTag(ctx, questionInfo.ID)
+	if err != nil {
+		return err
+	}
+	tagID := ""
+	for _, tag := range tags {
+		if tag.SlugName == strings.ToLower(req.Tag) {
+			tagID = tag.ID
+			break
+		}
+	}
+	if len(tagID) == 0 {
+		return errors.BadRequest(reason.QuestionPinTagInvalid)
+	}
+
+	activityTypeKey := constant.ActQuestionUnPin
+	if req.Operation == schema.QuestionOperationPin {
+		pinned, err := qs.tagCommon.GetTagPinnedObjectIDs(ctx, []string{questionInfo.ID}, []string{tagID})
+		if err != nil {
+			return err
+		}
+		pinExpiredAt, err := checkQuestionPin(pinned[questionInfo.ID], req.PinExpiredAt,
+			constant.TagQuestionPinMaxAmount, func() (int64, error) {
+				return qs.tagCommon.CountPinnedTagRel(ctx, tagID)
+			})
+		if err != nil {
+			return err
+		}
+		err = qs.tagCommon.UpdateTagRelPin(ctx, questionInfo.ID, tagID, entity.QuestionPin, pinExpiredAt)
+		if err != nil {
+			return err
+		}
+		activityTypeKey = constant.ActQuestionPin
+	} else {
+		err = qs.tagCommon.UpdateTagRelPin(ctx, questionInfo.ID, tagID, entity.QuestionUnPin, time.Time{})
+		if err != nil {
+			return err
+		}
+	}
+
+	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         questionInfo.ID,
+		OriginalObjectID: questionInfo.ID,
+		ActivityTypeKey:  activityTypeKey,
+	})
+	return nil
+}
+
+// checkQuestionPin check the expiry time of the pin and whether the amount of the pinned questions reaches the
+// limit. Pinning the pinned question again only changes the expiry time, so it is not limited.
+func checkQuestionPin(pinned bool, pinExpiredAt int64, maxAmount int, countPinned func() (int64, error)) (
+	expiredAt time.Time, err error) {
+	if pinExpiredAt > 0 {
+		expiredAt = time.Unix(pinExpiredAt, 0)
+		if !expiredAt.After(time.Now()) {
+			return time.Time{}, errors.BadRequest(reason.QuestionPinExpireTimeInvalid)
+		}
+	}
+	if pinned {
+		return expiredAt, nil
+	}
+	count, err := countPinned()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if count >= int64(maxAmount) {
+		return time.Time{}, errors.BadRequest(reason.QuestionPinLimitExceeded)
+	}
+	return expiredAt, nil
+}
+
 // RemoveQuestion delete question
 func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.RemoveQuestionReq) (err error) {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
@@ -592,11 +795,22 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 		}
 	}
 
+	oldStatus := questionInfo.Status
 	questionInfo.Status = entity.QuestionStatusDeleted
 	err = qs.questionRepo.UpdateQuestionStatusWithOutUpdateTime(ctx, questionInfo)
 	if err != nil {
 		return err
 	}
+	if questionInfo.UserID != req.UserID {
+		qs.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			ActorID:    req.UserID,
+			Action:     constant.AuditActionQuestionDelete,
+			ObjectType: constant.QuestionObjectType,
+			ObjectID:   questionInfo.ID,
+			Before:     map[string]any{"status": entity.AdminQuestionSearchStatusIntToString[oldStatus]},
+			After:      map[string]any{"status": entity.AdminQuestionSearchStatusIntToString[questionInfo.Status]},
+		})
+	}
 
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
 	if err != nil {
@@ -636,12 +850,10 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 		log.Error("efreshTagQuestionCount error", err.Error())
 	}
 
-	// #2372 In order to simplify the process and complexity, as well as to consider if it is in-house,
-	// facing the problem of recovery.
-	// err = qs.answerActivityService.DeleteQuestion(ctx, questionInfo.ID, questionInfo.CreatedAt, questionInfo.VoteCount)
-	// if err != nil {
-	// 	 log.Errorf("user DeleteQuestion rank rollback error %s", err.Error())
-	// }
+	err = qs.answerActivityService.DeleteObject(ctx, questionInfo.ID)
+	if err != nil {
+		log.Errorf("user DeleteQuestion rank rollback error %s", err.Error())
+	}
 	err = qs.questionRepo.RemoveQuestionLink(ctx, &entity.QuestionLink{
 		FromQuestionID: questionInfo.ID,
 	}, &entity.QuestionLink{
@@ -745,6 +957,19 @@ func (qs *QuestionService) RecoverQuestion(ctx context.Context, req *schema.Ques
 	if err != nil {
 		return err
 	}
+	if questionInfo.UserID != req.UserID {
+		qs.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			ActorID:    req.UserID,
+			Action:     constant.AuditActionQuestionRecover,
+			ObjectType: constant.QuestionObjectType,
+			ObjectID:   questionInfo.ID,
+			Before:     map[string]any{"status": entity.AdminQuestionSearchStatusIntToString[entity.QuestionStatusDeleted]},
+			After:      map[string]any{"status": entity.AdminQuestionSearchStatusIntToString[entity.QuestionStatusAvailable]},
+		})
+	}
+	if err = qs.answerActivityService.RecoverObject(ctx, questionInfo.ID); err != nil {
+		log.Errorf("recover question rank reapply error %s", err.Error())
+	}
 
 	// update user's question count
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
@@ -939,18 +1164,11 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 	question.UserID = dbinfo.UserID
 	question.LastEditUserID = req.UserID
 
-	minimumContentLength, err := qs.questioncommon.GetMinimumContentLength(ctx)
-	if err != nil {
-		return
-	}
-	if len(req.Content) < minimumContentLength {
-		errorlist := make([]*validator.FormErrorField, 0)
-		errorlist = append(errorlist, &validator.FormErrorField{
-			ErrorField: "content",
-			ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionContentLessThanMinimum),
-		})
-		err = errors.BadRequest(reason.QuestionContentLessThanMinimum)
-		return errorlist, err
+	if errorlist, err := qs.questioncommon.CheckQuestionQuality(ctx, req.Title, req.Content, req.HTML); err != nil {
+		if len(errorlist) > 0 {
+			return errorlist, err
+		}
+		return nil, err
 	}
 
 	oldTags, tagerr := qs.tagCommon.GetObjectEntityTag(ctx, question.ID)
@@ -1021,6 +1239,20 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		return errorlist, err
 	}
 
+	processing := &plugin.ProcessingContent{
+		ObjectType:   constant.QuestionObjectType,
+		ObjectID:     question.ID,
+		UserID:       req.UserID,
+		Title:        question.Title,
+		OriginalText: question.OriginalText,
+		ParsedText:   question.ParsedText,
+		Tags:         tagNameList,
+	}
+	if err = qs.contentProcessorService.Process(ctx, processing); err != nil {
+		return nil, err
+	}
+	question.OriginalText, question.ParsedText = processing.OriginalText, processing.ParsedText
+
 	// Administrators and themselves do not need to be audited
 
 	revisionDTO := &schema.AddRevisionDTO{
@@ -1050,6 +1282,7 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		if saveerr != nil {
 			return questionInfo, saveerr
 		}
+		qs.contentProcessorService.SaveMetadata(ctx, question.ID, processing.Metadata)
 		objectTagData := schema.TagChange{}
 		objectTagData.ObjectID = question.ID
 		objectTagData.Tags = req.Tags
@@ -1063,7 +1296,7 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 	questionWithTagsRevision := qs.changeQuestionToRevision(ctx, question, Tags)
 	infoJSON, _ := json.Marshal(questionWithTagsRevision)
 	revisionDTO.Content = string(infoJSON)
-	revisionID, err := qs.revisionService.AddRevision(ctx, revisionDTO, true)
+	revisionID, err := qs.revisionService.AddRevision(ctx, revisionDTO, canUpdate)
 	if err != nil {
 		return
 	}
@@ -1091,9 +1324,10 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 	if err != nil {
 		return
 	}
-	// If the question is deleted or pending, only the administrator and the author can view it
+	// If the question is deleted, pending or scheduled, only the administrator and the author can view it
 	if (question.Status == entity.QuestionStatusDeleted ||
-		question.Status == entity.QuestionStatusPending) && !per.CanReopen && question.UserID != userID {
+		question.Status == entity.QuestionStatusPending ||
+		question.Status == entity.QuestionStatusScheduled) && !per.CanReopen && question.UserID != userID {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
 	if question.Show == entity.QuestionHide && !per.IsAdminModerator && question.UserID != userID {
@@ -1132,6 +1366,28 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		operation.Level = schema.OperationLevelSecondary
 		question.Operation = operation
 	}
+	if question.Status == entity.QuestionStatusScheduled {
+		operation := &schema.Operation{Time: question.ScheduledAt, Level: schema.OperationLevelInfo}
+		operation.Msg = translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionScheduled)
+		if question.ScheduledAt == 0 {
+			operation.Msg = translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionScheduleCanceled)
+			operation.Level = schema.OperationLevelWarning
+		}
+		question.Operation = operation
+	}
+	question.Lock, err = qs.postLockService.GetPostLockInfo(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Lock != nil && question.Operation == nil {
+		question.Operation = &schema.Operation{
+			Type:        "lock",
+			Description: question.Lock.Reason,
+			Msg:         translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionLocked),
+			Time:        question.Lock.LockedAt,
+			Level:       schema.OperationLevelWarning,
+		}
+	}
 
 	question.Description = htmltext.FetchExcerpt(question.HTML, "...", 240)
 	question.MemberActions = permission.GetQuestionPermission(ctx, userID, question.UserID, question.Status,
@@ -1142,15 +1398,23 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 	return question, nil
 }
 
-// GetQuestionAndAddPV get question one
-func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID string,
+// GetQuestionAndAddPV get question one and count the view of the viewer,
+// the buffered views that are not flushed yet are included in the view count
+func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID, ip string,
 	per schema.QuestionPermission) (
 	resp *schema.QuestionInfoResp, err error) {
-	err = qs.questioncommon.UpdatePv(ctx, questionID)
+	resp, err = qs.GetQuestion(ctx, questionID, loginUserID, per)
 	if err != nil {
-		log.Error(err)
+		return nil, err
 	}
-	return qs.GetQuestion(ctx, questionID, loginUserID, per)
+	qs.questionViewService.AddView(ctx, resp.ID, resp.UserID, loginUserID, ip)
+	resp.ViewCount += int(qs.questionViewService.GetPendingView(ctx, resp.ID))
+	return resp, nil
+}
+
+// FlushPendingViewsCron write the buffered views of the questions to the database
+func (qs *QuestionService) FlushPendingViewsCron(ctx context.Context) {
+	qs.questionViewService.FlushPendingViews(ctx)
 }
 
 func (qs *QuestionService) InviteUserInfo(ctx context.Context, questionID string) (inviteList []*schema.UserBasicInfo, err error) {
@@ -1390,24 +1654,31 @@ func (qs *QuestionService) GetQuestionsByTitle(ctx context.Context, title string
 		// call search plugin if available
 		words := []string{title}
 		res, _, err := finder.SearchQuestions(ctx, &plugin.SearchBasicCond{
-			Words:    words,
-			Page:     1,
-			PageSize: 10,
+			Words:        words,
+			Page:         1,
+			PageSize:     10,
+			VoteAmount:   -1,
+			ViewAmount:   -1,
+			AnswerAmount: -1,
 		})
 		if err != nil {
-			return resp, err
-		}
-		// get question ids from res
-		questionIDs := make([]string, 0)
-		for _, question := range res {
-			questionIDs = append(questionIDs, question.ID)
-		}
-		var questionErr error
-		questions, questionErr = qs.questionRepo.FindByID(ctx, questionIDs)
-		if questionErr != nil {
-			return resp, questionErr
+			// the search engine may be unreachable, fall back to the database
+			log.Warnf("search questions by plugin failed, fall back to database: %v", err)
+			finder = nil
+		} else {
+			// get question ids from res
+			questionIDs := make([]string, 0)
+			for _, question := range res {
+				questionIDs = append(questionIDs, question.ID)
+			}
+			var questionErr error
+			questions, questionErr = qs.questionRepo.FindByID(ctx, questionIDs)
+			if questionErr != nil {
+				return resp, questionErr
+			}
 		}
-	} else {
+	}
+	if finder == nil {
 		var questionErr error
 		questions, questionErr = qs.questionRepo.GetQuestionsByTitle(ctx, title, 10)
 		if questionErr != nil {
@@ -1470,6 +1741,47 @@ func (qs *QuestionService) SimilarQuestion(ctx context.Context, questionID strin
 func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.QuestionPageReq) (
 	questions []*schema.QuestionPageResp, total int64, err error) {
 	questions = make([]*schema.QuestionPageResp, 0)
+	cond, exist, err := qs.questionPageCond(ctx, req)
+	if err != nil || !exist {
+		return questions, 0, err
+	}
+
+	questionList, total, err := qs.questionRepo.GetQuestionPage(ctx, req.Page, req.PageSize,
+		cond.TagIDs, cond.UserID, cond.OrderCond, cond.InDays, cond.ShowHidden, cond.ShowPending, cond.MutedByUserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	questions, err = qs.formatQuestionPage(ctx, questionList, cond, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	return questions, total, nil
+}
+
+// GetQuestionPageByCursor query the questions after the cursor, the next cursor is empty if it is the last page
+func (qs *QuestionService) GetQuestionPageByCursor(ctx context.Context, req *schema.QuestionPageReq) (
+	questions []*schema.QuestionPageResp, nextCursor string, err error) {
+	questions = make([]*schema.QuestionPageResp, 0)
+	cond, exist, err := qs.questionPageCond(ctx, req)
+	if err != nil || !exist {
+		return questions, "", err
+	}
+
+	questionList, nextCursor, err := qs.questionRepo.GetQuestionPageByCursor(ctx, cond, req.Cursor, req.PageSize)
+	if err != nil {
+		return nil, "", err
+	}
+	questions, err = qs.formatQuestionPage(ctx, questionList, cond, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return questions, nextCursor, nil
+}
+
+// questionPageCond the condition of the question list of the request, exist is false if the tag or the user
+// of the request is not found
+func (qs *QuestionService) questionPageCond(ctx context.Context, req *schema.QuestionPageReq) (
+	cond *entity.QuestionPageCond, exist bool, err error) {
 	// query by user role
 	showHidden := false
 	if req.LoginUserID != "" && req.UserIDBeSearched != "" {
@@ -1477,9 +1789,9 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 		if !showHidden {
 			userRole, err := qs.userRoleRelService.GetUserRole(ctx, req.LoginUserID)
 			if err != nil {
-				return nil, 0, err
+				return nil, false, err
 			}
-			showHidden = userRole == role.RoleAdminID || userRole == role.RoleModeratorID
+			showHidden = qs.userRoleRelService.IsAdminModeratorRole(ctx, userRole)
 		}
 	}
 	// query by tag condition
@@ -1487,46 +1799,69 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 	if len(req.Tag) > 0 {
 		tagInfo, exist, err := qs.tagCommon.GetTagBySlugName(ctx, strings.ToLower(req.Tag))
 		if err != nil {
-			return nil, 0, err
+			return nil, false, err
 		}
-		if exist {
-			synTagIds, err := qs.tagCommon.GetTagIDsByMainTagID(ctx, tagInfo.ID)
-			if err != nil {
-				return nil, 0, err
-			}
-			tagIDs = append(tagIDs, synTagIds...)
-			tagIDs = append(tagIDs, tagInfo.ID)
-		} else {
-			return questions, 0, nil
+		if !exist {
+			return nil, false, nil
+		}
+		synTagIds, err := qs.tagCommon.GetTagIDsByMainTagID(ctx, tagInfo.ID)
+		if err != nil {
+			return nil, false, err
 		}
+		tagIDs = append(tagIDs, synTagIds...)
+		tagIDs = append(tagIDs, tagInfo.ID)
 	}
 
 	// query by user condition
 	if req.Username != "" {
 		userinfo, exist, err := qs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
 		if err != nil {
-			return nil, 0, err
+			return nil, false, err
 		}
 		if !exist {
-			return questions, 0, nil
+			return nil, false, nil
 		}
 		req.UserIDBeSearched = userinfo.ID
 	}
 
-	if req.OrderCond == schema.QuestionOrderCondHot {
+	switch req.OrderCond {
+	case schema.QuestionOrderCondHot:
 		req.InDays = schema.HotInDays
-	}
+	case schema.QuestionOrderCondTrending:
+		req.InDays = schema.TrendingInDays
+	}
+	return &entity.QuestionPageCond{
+		TagIDs:        tagIDs,
+		UserID:        req.UserIDBeSearched,
+		OrderCond:     req.OrderCond,
+		InDays:        req.InDays,
+		ShowHidden:    showHidden,
+		ShowPending:   req.ShowPending,
+		MutedByUserID: req.LoginUserID,
+	}, true, nil
+}
 
-	questionList, total, err := qs.questionRepo.GetQuestionPage(ctx, req.Page, req.PageSize,
-		tagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
-	if err != nil {
-		return nil, 0, err
-	}
+// formatQuestionPage format the questions of the question list, the pins within the tag are marked in the tag list
+func (qs *QuestionService) formatQuestionPage(ctx context.Context, questionList []*entity.Question,
+	cond *entity.QuestionPageCond, req *schema.QuestionPageReq) (questions []*schema.QuestionPageResp, err error) {
 	questions, err = qs.questioncommon.FormatQuestionsPage(ctx, questionList, req.LoginUserID, req.OrderCond)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	return questions, total, nil
+	if len(cond.TagIDs) > 0 {
+		questionIDs := make([]string, 0, len(questions))
+		for _, question := range questions {
+			questionIDs = append(questionIDs, question.ID)
+		}
+		pinned, err := qs.tagCommon.GetTagPinnedObjectIDs(ctx, questionIDs, cond.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questions {
+			question.TagPin = pinned[question.ID]
+		}
+	}
+	return questions, nil
 }
 
 // GetRecommendQuestionPage retrieves recommended question page based on following tags and questions.
@@ -1586,15 +1921,21 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	if err != nil {
 		return err
 	}
+	qs.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionQuestionStatusChange,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   questionInfo.ID,
+		Before:     map[string]any{"status": entity.AdminQuestionSearchStatusIntToString[questionInfo.Status]},
+		After:      map[string]any{"status": req.Status},
+	})
 
 	msg := &schema.NotificationMsg{}
 	if setStatus == entity.QuestionStatusDeleted {
-		// #2372 In order to simplify the process and complexity, as well as to consider if it is in-house,
-		// facing the problem of recovery.
-		// err = qs.answerActivityService.DeleteQuestion(ctx, questionInfo.ID, questionInfo.CreatedAt, questionInfo.VoteCount)
-		// if err != nil {
-		// 	log.Errorf("admin delete question then rank rollback error %s", err.Error())
-		// }
+		err = qs.answerActivityService.DeleteObject(ctx, questionInfo.ID)
+		if err != nil {
+			log.Errorf("admin delete question then rank rollback error %s", err.Error())
+		}
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           questionInfo.UserID,
 			TriggerUserID:    converter.StringToInt64(req.UserID),
@@ -1625,6 +1966,10 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 	}
 	// recover
 	if setStatus == entity.QuestionStatusAvailable && questionInfo.Status == entity.QuestionStatusDeleted {
+		err = qs.answerActivityService.RecoverObject(ctx, questionInfo.ID)
+		if err != nil {
+			log.Errorf("admin recover question then rank reapply error %s", err.Error())
+		}
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			TriggerUserID:    converter.StringToInt64(req.UserID),
@@ -1719,6 +2064,181 @@ func (qs *QuestionService) AdminAnswerPage(ctx context.Context, req *schema.Admi
 	return pager.NewPageModel(count, answerResp), nil
 }
 
+// AdminDeletedPostPage get the questions or answers deleted in the retention window, the latest deleted first
+func (qs *QuestionService) AdminDeletedPostPage(ctx context.Context, req *schema.AdminDeletedPostPageReq) (
+	resp *pager.PageModel, err error) {
+	var deletedAfter time.Time
+	retention := qs.deletedPostsRetention()
+	if retention > 0 {
+		deletedAfter = time.Now().Add(-retention)
+	}
+
+	var total int64
+	list := make([]*schema.AdminDeletedPostInfo, 0)
+	questionIDs := make([]string, 0)
+	if req.ObjectType == constant.QuestionObjectType {
+		questionList, count, err := qs.questionRepo.GetDeletedQuestionPage(ctx, req.Page, req.PageSize, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		total = count
+		for _, question := range questionList {
+			list = append(list, &schema.AdminDeletedPostInfo{
+				ID:         question.ID,
+				ObjectType: constant.QuestionObjectType,
+				QuestionID: question.ID,
+				Title:      question.Title,
+				Excerpt:    htmltext.FetchExcerpt(question.ParsedText, "...", 120),
+				UserID:     question.UserID,
+				CreateTime: question.CreatedAt.Unix(),
+				DeletedAt:  question.DeletedAt.Unix(),
+			})
+		}
+	} else {
+		answerList, count, err := qs.answerRepo.GetDeletedAnswerPage(ctx, req.Page, req.PageSize, deletedAfter)
+		if err != nil {
+			return nil, err
+		}
+		total = count
+		for _, answer := range answerList {
+			list = append(list, &schema.AdminDeletedPostInfo{
+				ID:         answer.ID,
+				ObjectType: constant.AnswerObjectType,
+				QuestionID: answer.QuestionID,
+				Excerpt:    htmltext.FetchExcerpt(answer.ParsedText, "...", 120),
+				UserID:     answer.UserID,
+				CreateTime: answer.CreatedAt.Unix(),
+				DeletedAt:  answer.DeletedAt.Unix(),
+			})
+			questionIDs = append(questionIDs, answer.QuestionID)
+		}
+	}
+
+	userIDs := make([]string, 0)
+	for _, item := range list {
+		userIDs = append(userIDs, item.UserID)
+		if retention > 0 {
+			item.PurgeAt = time.Unix(item.DeletedAt, 0).Add(retention).Unix()
+		}
+	}
+	userInfoMap, err := qs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionMaps, err := qs.questioncommon.FindInfoByID(ctx, questionIDs, req.LoginUserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		if u, ok := userInfoMap[item.UserID]; ok {
+			item.UserInfo = u
+		}
+		if q, ok := questionMaps[item.QuestionID]; ok && item.ObjectType == constant.AnswerObjectType {
+			item.Title = q.Title
+		}
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// PurgeDeletedPostsCron delete permanently the questions and answers deleted before the retention window
+func (qs *QuestionService) PurgeDeletedPostsCron(ctx context.Context) {
+	retention := qs.deletedPostsRetention()
+	if retention <= 0 {
+		return
+	}
+	deletedBefore := time.Now().Add(-retention)
+	questionCount, err := qs.questionRepo.PurgeDeletedQuestions(ctx, deletedBefore)
+	if err != nil {
+		log.Errorf("purge deleted questions failed: %v", err)
+	}
+	answerCount, err := qs.answerRepo.PurgeDeletedAnswers(ctx, deletedBefore)
+	if err != nil {
+		log.Errorf("purge deleted answers failed: %v", err)
+	}
+	if questionCount > 0 || answerCount > 0 {
+		log.Infof("purged %d questions and %d answers deleted before %s",
+			questionCount, answerCount, deletedBefore.Format(time.RFC3339))
+	}
+}
+
+// UnpinExpiredQuestionsCron unpin the questions whose pin has expired, both the global pins and the pins within tags
+func (qs *QuestionService) UnpinExpiredQuestionsCron(ctx context.Context) {
+	now := time.Now()
+	count, err := qs.questionRepo.UnpinExpiredQuestions(ctx, now)
+	if err != nil {
+		log.Errorf("unpin expired questions failed, err: %v", err)
+	} else if count > 0 {
+		log.Infof("unpinned %d expired questions", count)
+	}
+	count, err = qs.tagCommon.UnpinExpiredTagRels(ctx, now)
+	if err != nil {
+		log.Errorf("unpin expired questions within tags failed, err: %v", err)
+	} else if count > 0 {
+		log.Infof("unpinned %d expired questions within tags", count)
+	}
+}
+
+// PublishScheduledQuestionsCron publish the scheduled questions whose publish time has come. The schedules are
+// persisted, so the questions that should have been published while the site was down are published in the next run.
+func (qs *QuestionService) PublishScheduledQuestionsCron(ctx context.Context) {
+	questions, err := qs.questionRepo.GetDueScheduledQuestions(ctx, time.Now(), constant.ScheduledQuestionPublishBatchSize)
+	if err != nil {
+		log.Errorf("get due scheduled questions failed: %v", err)
+		return
+	}
+	for _, question := range questions {
+		if err := qs.publishScheduledQuestion(ctx, question); err != nil {
+			log.Errorf("publish scheduled question %s failed: %v", question.ID, err)
+		}
+	}
+}
+
+// publishScheduledQuestion make the question available and notify as if it is just posted
+func (qs *QuestionService) publishScheduledQuestion(ctx context.Context, question *entity.Question) (err error) {
+	now := time.Now()
+	published, err := qs.questionRepo.PublishScheduledQuestion(ctx, question.ID, now)
+	if err != nil || !published {
+		return err
+	}
+	question.Status = entity.QuestionStatusAvailable
+	question.CreatedAt = now
+	question.PostUpdateTime = now
+
+	question.ParsedText, err = qs.questioncommon.UpdateQuestionLink(ctx, question.ID, "", question.ParsedText, question.OriginalText)
+	if err != nil {
+		log.Errorf("update question link failed: %v", err)
+	} else if err = qs.questionRepo.UpdateQuestion(ctx, question, []string{"parsed_text"}); err != nil {
+		log.Errorf("update question parsed text failed: %v", err)
+	}
+	if err = qs.tagCommon.RefreshTagCountByQuestionID(ctx, question.ID); err != nil {
+		log.Errorf("refresh tag count failed: %v", err)
+	}
+	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, question.UserID)
+	if err != nil {
+		log.Errorf("get user question count error %v", err)
+	} else if err = qs.userCommon.UpdateQuestionCount(ctx, question.UserID, userQuestionCount); err != nil {
+		log.Errorf("update user question count error %v", err)
+	}
+
+	tags, err := qs.tagCommon.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		log.Errorf("get question tags failed: %v", err)
+	}
+	qs.externalNotificationQueueService.Send(ctx,
+		schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, tags))
+	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, question.UserID).TID(question.ID).
+		QID(question.ID, question.UserID))
+	qs.vectorSyncService.Send(ctx, &vector_sync.Task{Action: vector_sync.ActionUpsert, ObjectType: vector_sync.ObjectTypeQuestion, ObjectID: question.ID})
+	return nil
+}
+
+func (qs *QuestionService) deletedPostsRetention() time.Duration {
+	if qs.serviceConfig == nil || qs.serviceConfig.DeletedPostsRetentionDays <= 0 {
+		return 0
+	}
+	return time.Duration(qs.serviceConfig.DeletedPostsRetentionDays) * 24 * time.Hour
+}
+
 func (qs *QuestionService) changeQuestionToRevision(_ context.Context, questionInfo *entity.Question, tags []*entity.Tag) (
 	questionRevision *entity.QuestionWithTagsRevision) {
 	questionRevision = &entity.QuestionWithTagsRevision{}
@@ -1732,20 +2252,13 @@ func (qs *QuestionService) changeQuestionToRevision(_ context.Context, questionI
 	return questionRevision
 }
 
-func (qs *QuestionService) SitemapCron(ctx context.Context) {
-	siteSeo, err := qs.siteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		log.Error(err)
-		return
-	}
-	ctx = context.WithValue(ctx, constant.ShortIDContextKey, siteSeo.IsShortLink())
-	qs.questioncommon.SitemapCron(ctx)
-}
-
 func (qs *QuestionService) GetQuestionLink(ctx context.Context, req *schema.GetQuestionLinkReq) (
 	questions []*schema.QuestionPageResp, total int64, err error) {
-	if req.OrderCond == schema.QuestionOrderCondHot {
+	switch req.OrderCond {
+	case schema.QuestionOrderCondHot:
 		req.InDays = schema.HotInDays
+	case schema.QuestionOrderCondTrending:
+		req.InDays = schema.TrendingInDays
 	}
 
 	questionList, total, err := qs.questionRepo.GetQuestionLink(ctx, req.Page, req.PageSize, req.QuestionID, req.OrderCond, req.InDays)
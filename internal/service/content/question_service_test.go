@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckQuestionPin(t *testing.T) {
+	countPinned := func(count int64) func() (int64, error) {
+		return func() (int64, error) {
+			return count, nil
+		}
+	}
+	assertReason := func(t *testing.T, err error, want string) {
+		var e *errors.Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, want, e.Reason)
+	}
+
+	expiredAt, err := checkQuestionPin(false, 0, 10, countPinned(9))
+	require.NoError(t, err)
+	assert.True(t, expiredAt.IsZero())
+
+	future := time.Now().Add(time.Hour).Unix()
+	expiredAt, err = checkQuestionPin(false, future, 10, countPinned(0))
+	require.NoError(t, err)
+	assert.Equal(t, future, expiredAt.Unix())
+
+	_, err = checkQuestionPin(false, time.Now().Add(-time.Hour).Unix(), 10, countPinned(0))
+	assertReason(t, err, reason.QuestionPinExpireTimeInvalid)
+
+	_, err = checkQuestionPin(false, 0, 10, countPinned(10))
+	assertReason(t, err, reason.QuestionPinLimitExceeded)
+
+	// the pinned question only changes its expiry time
+	expiredAt, err = checkQuestionPin(true, future, 10, countPinned(10))
+	require.NoError(t, err)
+	assert.Equal(t, future, expiredAt.Unix())
+}
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	trendingAnswerWeight = 2
+	trendingViewWeight   = 1
+	// trendingScoreScale the score is stored as an integer, it keeps 3 decimals
+	trendingScoreScale = 1000
+)
+
+// RefreshTrendingCron recompute the trending scores of the recent questions with the half-life of the site setting,
+// the scores of the questions older than the trending days are cleared
+func (qs *QuestionService) RefreshTrendingCron(ctx context.Context) {
+	siteQuestion, err := qs.siteInfoService.GetSiteQuestion(ctx)
+	if err != nil {
+		log.Errorf("get site question setting failed: %v", err)
+		return
+	}
+	halfLife := siteQuestion.GetTrendingHalfLife()
+	now := time.Now()
+
+	var (
+		page     = 1
+		pageSize = 100
+	)
+	for {
+		questionList, _, err := qs.questionRepo.GetQuestionPage(ctx, page, pageSize, []string{}, "", "newest",
+			schema.TrendingInDays, false, false, "")
+		if err != nil {
+			log.Errorf("get trending questions failed: %v", err)
+			return
+		}
+
+		scores := make(map[string]int)
+		for _, question := range questionList {
+			score := trendingScore(question.VoteCount, question.AnswerCount, question.ViewCount,
+				now.Sub(question.CreatedAt), halfLife)
+			if score != question.TrendingScore {
+				scores[question.ID] = score
+			}
+		}
+		if err = qs.questionRepo.UpdateTrendingScores(ctx, scores); err != nil {
+			log.Errorf("update trending scores failed: %v", err)
+		}
+
+		if len(questionList) < pageSize {
+			break
+		}
+		page++
+	}
+
+	if err = qs.questionRepo.ResetTrendingScores(ctx, now.AddDate(0, 0, -schema.TrendingInDays)); err != nil {
+		log.Errorf("reset trending scores failed: %v", err)
+	}
+}
+
+// trendingScore the activity of the question decays by half every half-life since it is asked, the same as the
+// gravity of Hacker News but the decay is exponential so that it is easy to tune. The votes count the most, the
+// answers count double and the views only count on the log scale so that the crawlers can not make it trending.
+func trendingScore(votes, answers, views int, age, halfLife time.Duration) int {
+	activity := float64(max(votes, 0)) + trendingAnswerWeight*float64(answers) +
+		trendingViewWeight*math.Log10(float64(max(views, 0))+1)
+	decay := math.Exp2(-max(age, 0).Hours() / halfLife.Hours())
+	return int(min(math.Round(activity*decay*trendingScoreScale), math.MaxInt32))
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendingScore(t *testing.T) {
+	halfLife := 24 * time.Hour
+	fresh := trendingScore(10, 2, 99, 0, halfLife)
+	// 10 votes + 2 * 2 answers + log10(100) views
+	assert.Equal(t, 16000, fresh)
+	assert.Equal(t, fresh/2, trendingScore(10, 2, 99, halfLife, halfLife))
+	assert.Equal(t, fresh/4, trendingScore(10, 2, 99, 2*halfLife, halfLife))
+
+	// the newer question with less activity trends above the older popular one
+	assert.Greater(t, trendingScore(5, 1, 50, 2*time.Hour, halfLife), trendingScore(20, 4, 1000, 72*time.Hour, halfLife))
+	// the longer half-life keeps the older question trending
+	assert.Greater(t, trendingScore(20, 4, 1000, 72*time.Hour, 168*time.Hour), trendingScore(5, 1, 50, 2*time.Hour, 168*time.Hour))
+
+	assert.Equal(t, 0, trendingScore(-5, 0, 0, time.Hour, halfLife))
+	assert.Equal(t, trendingScore(1, 0, 0, 0, halfLife), trendingScore(1, 0, 0, -time.Hour, halfLife))
+}
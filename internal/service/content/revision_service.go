@@ -43,6 +43,7 @@ import (
 	"github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/diff"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/obj"
 	"github.com/apache/answer/pkg/uid"
@@ -122,13 +123,22 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 		if err = checkRevisionAuditPermission(req, objectType); err != nil {
 			return err
 		}
-		err = rs.revisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewRejectStatus, req.UserID)
-		return
+		reviewed, err := rs.revisionRepo.ReviewRevision(ctx, req.ID, entity.RevisionReviewRejectStatus, req.UserID, req.Reason)
+		if err != nil || !reviewed {
+			return err
+		}
+		rs.notifyRevisionReviewed(ctx, revisioninfo, objectType, req.UserID, constant.NotificationEditRejected, req.Reason)
+		return nil
 	}
 	if req.Operation == schema.RevisionAuditApprove {
 		if err = checkRevisionAuditPermission(req, objectType); err != nil {
 			return err
 		}
+		// the revision is claimed before it is applied, so it can not be reviewed by two reviewers concurrently
+		reviewed, err := rs.revisionRepo.ReviewRevision(ctx, req.ID, entity.RevisionReviewPassStatus, req.UserID, "")
+		if err != nil || !reviewed {
+			return err
+		}
 		revisionitem := &schema.GetRevisionResp{}
 		_ = copier.Copy(revisionitem, revisioninfo)
 		rs.parseItem(ctx, revisionitem)
@@ -142,9 +152,13 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 			saveErr = rs.revisionAuditTag(ctx, revisionitem)
 		}
 		if saveErr != nil {
+			// put the revision back to the queue
+			if err = rs.revisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionUnreviewedStatus, "0"); err != nil {
+				log.Errorf("put back revision %s failed: %v", req.ID, err)
+			}
 			return saveErr
 		}
-		err = rs.revisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewPassStatus, req.UserID)
+		err = rs.revisionRepo.UpdateObjectRevisionId(ctx, revisioninfo, nil)
 		if err != nil {
 			return err
 		}
@@ -167,24 +181,49 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 			ObjectType:     objectType,
 		}
 		rs.notificationQueueService.Send(ctx, msg)
-		return
+		rs.notifyRevisionReviewed(ctx, revisioninfo, objectType, req.UserID, constant.NotificationEditApproved, "")
+		return nil
 	}
 
 	return nil
 }
 
+// notifyRevisionReviewed notify the submitter of the suggested edit of the result of the review
+func (rs *RevisionService) notifyRevisionReviewed(ctx context.Context, revisionInfo *entity.Revision,
+	objectType, reviewUserID, action, rejectReason string) {
+	if revisionInfo.UserID == reviewUserID {
+		return
+	}
+	msg := &schema.NotificationMsg{
+		TriggerUserID:      reviewUserID,
+		ReceiverUserID:     revisionInfo.UserID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           revisionInfo.ObjectID,
+		ObjectType:         objectType,
+		NotificationAction: action,
+	}
+	if len(rejectReason) > 0 {
+		msg.ExtraInfo = map[string]string{"reason": rejectReason}
+	}
+	rs.notificationQueueService.Send(ctx, msg)
+}
+
 func checkRevisionAuditPermission(req *schema.RevisionAuditReq, objectType string) error {
+	return checkRevisionPermission(objectType, req.CanReviewQuestion, req.CanReviewAnswer, req.CanReviewTag)
+}
+
+func checkRevisionPermission(objectType string, canReviewQuestion, canReviewAnswer, canReviewTag bool) error {
 	switch objectType {
 	case constant.QuestionObjectType:
-		if !req.CanReviewQuestion {
+		if !canReviewQuestion {
 			return errors.BadRequest(reason.RevisionNoPermission)
 		}
 	case constant.AnswerObjectType:
-		if !req.CanReviewAnswer {
+		if !canReviewAnswer {
 			return errors.BadRequest(reason.RevisionNoPermission)
 		}
 	case constant.TagObjectType:
-		if !req.CanReviewTag {
+		if !canReviewTag {
 			return errors.BadRequest(reason.RevisionNoPermission)
 		}
 	}
@@ -416,28 +455,188 @@ func (rs *RevisionService) GetRevisionList(ctx context.Context, req *schema.GetR
 	}
 
 	for _, r := range revs {
-		var (
-			uinfo schema.UserBasicInfo
-			item  schema.GetRevisionResp
-		)
-
-		_ = copier.Copy(&item, r)
-		rs.parseItem(ctx, &item)
-
-		// get user info
-		userInfo, exists, e := rs.userCommon.GetUserBasicInfoByID(ctx, item.UserID)
+		item, e := rs.formatRevision(ctx, &r)
 		if e != nil {
 			return nil, e
 		}
-		if exists {
-			err = copier.Copy(&uinfo, userInfo)
-			item.UserInfo = uinfo
-		}
-		resp = append(resp, item)
+		resp = append(resp, *item)
 	}
 	return
 }
 
+// formatRevision parse the revision content and fill in the user info
+func (rs *RevisionService) formatRevision(ctx context.Context, revisionInfo *entity.Revision) (
+	item *schema.GetRevisionResp, err error) {
+	item = &schema.GetRevisionResp{}
+	_ = copier.Copy(item, revisionInfo)
+	rs.parseItem(ctx, item)
+
+	userInfo, exists, err := rs.userCommon.GetUserBasicInfoByID(ctx, item.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		_ = copier.Copy(&item.UserInfo, userInfo)
+	}
+	return item, nil
+}
+
+// GetRevisionDiff get the diff between two revisions of the object
+func (rs *RevisionService) GetRevisionDiff(ctx context.Context, req *schema.GetRevisionDiffReq) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err = objInfo.CheckVisibility(req.UserID, req.IsAdmin); err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetRevisionDiffResp{}
+	if resp.From, err = rs.getAppliedRevision(ctx, req.ObjectID, req.FromRevisionID); err != nil {
+		return nil, err
+	}
+	if resp.To, err = rs.getAppliedRevision(ctx, req.ObjectID, req.ToRevisionID); err != nil {
+		return nil, err
+	}
+	fromTitle, fromContent, fromTags := revisionDiffFields(resp.From)
+	toTitle, toContent, toTags := revisionDiffFields(resp.To)
+	resp.Title = diff.Lines(fromTitle, toTitle)
+	resp.Content = diff.Lines(fromContent, toContent)
+	resp.Tags = diff.Strings(fromTags, toTags)
+	return resp, nil
+}
+
+// getAppliedRevision get the revision of the object which has been applied to the object once
+func (rs *RevisionService) getAppliedRevision(ctx context.Context, objectID, revisionID string) (
+	item *schema.GetRevisionResp, err error) {
+	revisionInfo, exist, err := rs.revisionRepo.GetRevisionByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || revisionInfo.ObjectID != objectID || !isAppliedRevision(revisionInfo) {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+	return rs.formatRevision(ctx, revisionInfo)
+}
+
+// isAppliedRevision the revision is applied to the object, not waiting for review or rejected
+func isAppliedRevision(revisionInfo *entity.Revision) bool {
+	return revisionInfo.Status == entity.RevisionNormalStatus || revisionInfo.Status == entity.RevisionReviewPassStatus
+}
+
+// revisionDiffFields get the fields to compare from the parsed revision content
+func revisionDiffFields(item *schema.GetRevisionResp) (title, content string, tags []string) {
+	tags = make([]string, 0)
+	switch info := item.ContentParsed.(type) {
+	case *schema.QuestionInfoResp:
+		for _, tag := range info.Tags {
+			tags = append(tags, tag.SlugName)
+		}
+		return info.Title, info.Content, tags
+	case *schema.AnswerInfo:
+		return "", info.Content, tags
+	case *schema.GetTagResp:
+		return info.DisplayName, info.OriginalText, tags
+	}
+	return item.Title, "", tags
+}
+
+// RollbackRevision restore the object to the content of the revision.
+// The content is saved as a new revision, so the history is never lost.
+func (rs *RevisionService) RollbackRevision(ctx context.Context, req *schema.RollbackRevisionReq) (
+	resp *schema.RollbackRevisionResp, err error) {
+	revisionInfo, exist, err := rs.revisionRepo.GetRevisionByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || !isAppliedRevision(revisionInfo) {
+		return nil, errors.BadRequest(reason.RevisionNotFound)
+	}
+	objectType, err := obj.GetObjectTypeStrByObjectID(revisionInfo.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRevisionPermission(objectType, req.CanReviewQuestion, req.CanReviewAnswer, req.CanReviewTag); err != nil {
+		return nil, err
+	}
+	objInfo, err := rs.objectInfoService.GetInfo(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if objInfo.IsDeleted() {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	_, exist, err = rs.revisionRepo.ExistUnreviewedByObjectID(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.RevisionReviewUnderway)
+	}
+	currentRevision, err := rs.getCurrentRevision(ctx, revisionInfo.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if currentRevision != nil && currentRevision.ID == revisionInfo.ID {
+		return nil, errors.BadRequest(reason.RevisionAlreadyCurrent)
+	}
+
+	if len(req.Log) == 0 {
+		req.Log = translator.TrWithData(handler.GetLangByCtx(ctx), constant.RevisionRollbackLog,
+			&schema.RevisionRollbackTplData{RevisionID: revisionInfo.ID})
+	}
+	newRevision := &entity.Revision{
+		UserID:   req.UserID,
+		ObjectID: revisionInfo.ObjectID,
+		Title:    revisionInfo.Title,
+		Content:  revisionInfo.Content,
+		Log:      req.Log,
+		Status:   entity.RevisionNormalStatus,
+	}
+	// the object revision id is updated after the content is restored
+	if err = rs.revisionRepo.AddRevision(ctx, newRevision, false); err != nil {
+		return nil, err
+	}
+	revisionItem := &schema.GetRevisionResp{}
+	_ = copier.Copy(revisionItem, newRevision)
+	rs.parseItem(ctx, revisionItem)
+
+	now := time.Now().Unix()
+	switch info := revisionItem.ContentParsed.(type) {
+	case *schema.QuestionInfoResp:
+		info.UpdateTime = now
+		err = rs.revisionAuditQuestion(ctx, revisionItem)
+	case *schema.AnswerInfo:
+		info.UpdateTime = now
+		err = rs.revisionAuditAnswer(ctx, revisionItem)
+	case *schema.GetTagResp:
+		err = rs.revisionAuditTag(ctx, revisionItem)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = rs.revisionRepo.UpdateObjectRevisionId(ctx, newRevision, nil); err != nil {
+		return nil, err
+	}
+	return &schema.RollbackRevisionResp{RevisionID: newRevision.ID}, nil
+}
+
+// getCurrentRevision get the latest revision applied to the object
+func (rs *RevisionService) getCurrentRevision(ctx context.Context, objectID string) (
+	revisionInfo *entity.Revision, err error) {
+	revisionList, err := rs.revisionRepo.GetRevisionList(ctx, &entity.Revision{ObjectID: objectID})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range revisionList {
+		if isAppliedRevision(&item) {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
 func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisionResp) {
 	var (
 		err          error
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/answer/internal/service/search_common"
 	"github.com/apache/answer/internal/service/search_parser"
 	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
 )
 
 type SearchService struct {
@@ -52,11 +53,15 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 		return &schema.SearchResp{
 			Total:         0,
 			SearchResults: make([]*schema.SearchResult, 0),
+			Operators:     make([]*schema.SearchOperator, 0),
 		}, nil
 	}
 
 	// search type
-	cond := ss.searchParser.ParseStructure(ctx, dto)
+	cond, err := ss.searchParser.ParseStructure(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
 
 	// check search plugin
 	var finder plugin.Search
@@ -65,23 +70,44 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 		return nil
 	})
 
-	resp = &schema.SearchResp{}
 	// search plugin is not found, call system search
 	if finder == nil {
-		switch {
-		case cond.SearchAll():
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchContents(ctx, cond.Words, cond.Tags, cond.UserID, cond.VoteAmount, dto.Page, dto.Size, dto.Order)
-		case cond.SearchQuestion():
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchQuestions(ctx, cond.Words, cond.Tags, cond.NotAccepted, cond.Views, cond.AnswerAmount, dto.Page, dto.Size, dto.Order)
-		case cond.SearchAnswer():
-			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchAnswers(ctx, cond.Words, cond.Tags, cond.Accepted, cond.QuestionID, dto.Page, dto.Size, dto.Order)
-		}
-		return
+		resp, err = ss.searchByDB(ctx, cond, dto)
+	} else if resp, err = ss.searchByPlugin(ctx, finder, cond, dto); err != nil {
+		// the search engine may be unreachable, fall back to the system search
+		log.Warnf("search by plugin %s failed, fall back to database search: %v", finder.Info().SlugName, err)
+		resp, err = ss.searchByDB(ctx, cond, dto)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.Operators = cond.Operators
+	return resp, nil
+}
+
+// CheckSearchQuery check the operators of the search query, the same as the search
+func (ss *SearchService) CheckSearchQuery(ctx context.Context, query, userID string) (err error) {
+	_, err = ss.searchParser.ParseStructure(ctx, &schema.SearchDTO{
+		Query:  schema.NormalizeSearchQuery(query),
+		UserID: userID,
+	})
+	return err
+}
+
+func (ss *SearchService) searchByDB(ctx context.Context, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
+	resp = &schema.SearchResp{}
+	switch {
+	case cond.SearchAll():
+		resp.SearchResults, resp.Total, err =
+			ss.searchRepo.SearchContents(ctx, cond, dto.Page, dto.Size, dto.Order)
+	case cond.SearchQuestion():
+		resp.SearchResults, resp.Total, err =
+			ss.searchRepo.SearchQuestions(ctx, cond, dto.Page, dto.Size, dto.Order)
+	case cond.SearchAnswer():
+		resp.SearchResults, resp.Total, err =
+			ss.searchRepo.SearchAnswers(ctx, cond, dto.Page, dto.Size, dto.Order)
 	}
-	return ss.searchByPlugin(ctx, finder, cond, dto)
+	return
 }
 
 func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
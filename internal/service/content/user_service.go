@@ -21,8 +21,11 @@ package content
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/apache/answer/internal/service/eventqueue"
@@ -43,10 +46,12 @@ import (
 	"github.com/apache/answer/internal/service/auth"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
+	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_two_factor"
 	"github.com/apache/answer/pkg/checker"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
@@ -70,6 +75,7 @@ type UserService struct {
 	questionService               *questioncommon.QuestionCommon
 	eventQueueService             eventqueue.Service
 	fileRecordService             *file_record.FileRecordService
+	userTwoFactorService          *user_two_factor.UserTwoFactorService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -86,6 +92,7 @@ func NewUserService(userRepo usercommon.UserRepo,
 	questionService *questioncommon.QuestionCommon,
 	eventQueueService eventqueue.Service,
 	fileRecordService *file_record.FileRecordService,
+	userTwoFactorService *user_two_factor.UserTwoFactorService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -102,6 +109,7 @@ func NewUserService(userRepo usercommon.UserRepo,
 		questionService:               questionService,
 		eventQueueService:             eventQueueService,
 		fileRecordService:             fileRecordService,
+		userTwoFactorService:          userTwoFactorService,
 	}
 }
 
@@ -159,27 +167,82 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	if err != nil {
 		return nil, err
 	}
-	if !siteLogin.AllowPasswordLogin {
+	ldapConfig, err := us.siteInfoService.GetSiteLDAP(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !siteLogin.AllowPasswordLogin && !ldapConfig.Enabled {
 		return nil, errors.BadRequest(reason.NotAllowedLoginViaPassword)
 	}
-	userInfo, exist, err := us.userRepo.GetByEmail(ctx, req.Email)
+
+	var userInfo *entity.User
+	if siteLogin.AllowPasswordLogin {
+		userInfo, err = us.localPasswordLogin(ctx, req.Email, req.Pass)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// fall back to the LDAP directory if the local password does not match
+	if userInfo == nil && ldapConfig.Enabled {
+		userInfo, err = us.userExternalLoginService.LDAPLogin(ctx, req.Email, req.Pass)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if userInfo == nil {
+		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
+	}
+	ok, externalID, err := us.userExternalLoginService.CheckUserStatusInUserCenter(ctx, userInfo.ID)
 	if err != nil {
 		return nil, err
 	}
-	if !exist || userInfo.Status == entity.UserStatusDeleted {
+	if !ok {
 		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
 	}
-	if !us.verifyPassword(ctx, req.Pass, userInfo.Pass) {
-		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
+
+	// the password is right, but the login is pending until the two-factor code is verified
+	twoFactorEnabled, err := us.userTwoFactorService.IsEnabled(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactorEnabled {
+		resp = &schema.UserLoginResp{TwoFactorRequired: true}
+		resp.TwoFactorToken, err = us.userTwoFactorService.CreateLoginChallenge(ctx, userInfo.ID, req.RememberMe)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+	return us.passwordLoginSuccess(ctx, userInfo, externalID, req.RememberMe)
+}
+
+// TwoFactorLogin finish the password login with the two-factor code
+func (us *UserService) TwoFactorLogin(ctx context.Context, req *schema.UserTwoFactorLoginReq) (
+	resp *schema.UserLoginResp, err error) {
+	challenge, err := us.userTwoFactorService.VerifyLoginChallenge(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
 	}
 	ok, externalID, err := us.userExternalLoginService.CheckUserStatusInUserCenter(ctx, userInfo.ID)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
-		return nil, errors.BadRequest(reason.EmailOrPasswordWrong)
+		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	return us.passwordLoginSuccess(ctx, userInfo, externalID, challenge.RememberMe)
+}
 
+// passwordLoginSuccess set the user login token after the user passed all the checks
+func (us *UserService) passwordLoginSuccess(ctx context.Context, userInfo *entity.User, externalID string,
+	rememberMe bool) (resp *schema.UserLoginResp, err error) {
 	err = us.userRepo.UpdateLastLoginDate(ctx, userInfo.ID)
 	if err != nil {
 		log.Errorf("update last login data failed, err: %v", err)
@@ -199,13 +262,14 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 		UserStatus:  userInfo.Status,
 		RoleID:      roleID,
 		ExternalID:  externalID,
+		RememberMe:  rememberMe,
 	}
 	resp.AccessToken, resp.VisitToken, err = us.authService.SetUserCacheInfo(ctx, userCacheInfo)
 	if err != nil {
 		return nil, err
 	}
 	resp.RoleID = userCacheInfo.RoleID
-	if resp.RoleID == role.RoleAdminID {
+	if us.userRoleService.CheckRolePower(ctx, resp.RoleID, permission.AdminAccess) {
 		err = us.authService.SetAdminUserCacheInfo(ctx, resp.AccessToken, userCacheInfo)
 		if err != nil {
 			return nil, err
@@ -215,6 +279,22 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	return resp, nil
 }
 
+// localPasswordLogin returns the user if the email and password match a local user
+func (us *UserService) localPasswordLogin(ctx context.Context, email, password string) (
+	userInfo *entity.User, err error) {
+	userInfo, exist, err := us.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, nil
+	}
+	if !us.verifyPassword(ctx, password, userInfo.Pass) {
+		return nil, nil
+	}
+	return userInfo, nil
+}
+
 // RetrievePassWord .
 func (us *UserService) RetrievePassWord(ctx context.Context, req *schema.UserRetrievePassWordRequest) error {
 	userInfo, has, err := us.userRepo.GetByEmail(ctx, req.Email)
@@ -284,6 +364,11 @@ func (us *UserService) UserModifyPassWordVerification(ctx context.Context, req *
 	return true, nil
 }
 
+// LogoutEverywhere log out all the sessions of the user, including the current one
+func (us *UserService) LogoutEverywhere(ctx context.Context, req *schema.UserLogoutEverywhereReq) (err error) {
+	return us.authService.RevokeUserSessions(ctx, req.UserID, req.RevokeAPIKeys)
+}
+
 // UserModifyPassword user modify password
 func (us *UserService) UserModifyPassword(ctx context.Context, req *schema.UserModifyPasswordReq) error {
 	enpass, err := us.encryptPassword(ctx, req.Pass)
@@ -314,15 +399,25 @@ func (us *UserService) UserModifyPassword(ctx context.Context, req *schema.UserM
 // UpdateInfo update user info
 func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoRequest) (
 	errFields []*validator.FormErrorField, err error) {
-	if len(req.Username) > 0 {
-		if checker.IsInvalidUsername(req.Username) {
+	oldUserInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+
+	// the existing username is kept even if it breaks the current username policy
+	if len(req.Username) > 0 && req.Username != oldUserInfo.Username {
+		policy := us.userCommonService.GetUsernamePolicy(ctx)
+		if policy.IsInvalidUsername(req.Username) {
 			return append(errFields, &validator.FormErrorField{
 				ErrorField: "username",
 				ErrorMsg:   reason.UsernameInvalid,
 			}), errors.BadRequest(reason.UsernameInvalid)
 		}
 		// admin can use reserved username
-		if !req.IsAdmin && checker.IsReservedUsername(req.Username) {
+		if !req.IsAdmin && policy.IsReservedUsername(req.Username) {
 			return append(errFields, &validator.FormErrorField{
 				ErrorField: "username",
 				ErrorMsg:   reason.UsernameInvalid,
@@ -346,13 +441,6 @@ func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoReq
 		}
 	}
 
-	oldUserInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
-	if err != nil {
-		return nil, err
-	}
-	if !exist {
-		return nil, errors.BadRequest(reason.UserNotFound)
-	}
 	errFields, err = us.validateAvatarInfo(ctx, req.UserID, oldUserInfo.Avatar, req.Avatar)
 	if err != nil {
 		return errFields, err
@@ -366,7 +454,8 @@ func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoReq
 	if err != nil {
 		return nil, err
 	}
-	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserUpdate, req.UserID))
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserUpdate, req.UserID).TID(req.UserID).
+		ChangedFields(usercommon.ProfileChangedFields(oldUserInfo, cond)...))
 	return nil, err
 }
 
@@ -460,6 +549,7 @@ func (us *UserService) formatUserInfoForUpdateInfo(
 	userInfo.BioHTML = oldUserInfo.BioHTML
 	userInfo.Website = oldUserInfo.Website
 	userInfo.Location = oldUserInfo.Location
+	userInfo.EMail = oldUserInfo.EMail
 	userInfo.ID = req.UserID
 
 	if len(req.DisplayName) > 0 {
@@ -522,6 +612,7 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 	if err != nil {
 		return nil, nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, userInfo.ID).TID(userInfo.ID))
 	if err := us.userNotificationConfigService.SetDefaultUserNotificationConfig(ctx, []string{userInfo.ID}); err != nil {
 		log.Errorf("set default user notification config failed, err: %v", err)
 	}
@@ -561,7 +652,7 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 		return nil, nil, err
 	}
 	resp.RoleID = userCacheInfo.RoleID
-	if resp.RoleID == role.RoleAdminID {
+	if us.userRoleService.CheckRolePower(ctx, resp.RoleID, permission.AdminAccess) {
 		err = us.authService.SetAdminUserCacheInfo(ctx, resp.AccessToken, &entity.UserCacheInfo{UserID: userInfo.ID})
 		if err != nil {
 			return nil, nil, err
@@ -736,8 +827,9 @@ func (us *UserService) UserChangeEmailSendCode(ctx context.Context, req *schema.
 	}
 
 	data := &schema.EmailCodeContent{
-		Email:  req.Email,
-		UserID: req.UserID,
+		Email:           req.Email,
+		UserID:          req.UserID,
+		PassFingerprint: passwordFingerprint(userInfo.Pass),
 	}
 	code := token.GenerateToken()
 	var title, body string
@@ -752,10 +844,27 @@ func (us *UserService) UserChangeEmailSendCode(ctx context.Context, req *schema.
 	}
 	log.Infof("send email confirmation %s", verifyEmailURL)
 
-	go us.emailService.SendAndSaveCode(ctx, userInfo.ID, req.Email, title, body, code, data.ToJSONString())
+	go us.emailService.SendAndSaveCodeWithTime(ctx, userInfo.ID, req.Email, title, body, code, data.ToJSONString(),
+		constant.UserChangeEmailCodeCacheTime)
+
+	// the verified old address is noticed, the owner can cancel the change by changing the password
+	if userInfo.MailStatus == entity.EmailStatusAvailable && len(userInfo.EMail) > 0 {
+		noticeTitle, noticeBody, err := us.emailService.ChangeEmailNoticeTemplate(ctx, req.Email)
+		if err != nil {
+			log.Errorf("get change email notice template failed: %v", err)
+			return nil, nil
+		}
+		go us.emailService.Send(ctx, userInfo.EMail, noticeTitle, noticeBody)
+	}
 	return nil, nil
 }
 
+// passwordFingerprint the fingerprint of the password hash, it changes if the password is changed
+func passwordFingerprint(pass string) string {
+	sum := sha256.Sum256([]byte(pass))
+	return hex.EncodeToString(sum[:8])
+}
+
 // UserChangeEmailVerify user change email verify code
 func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string) (resp *schema.UserLoginResp, err error) {
 	data := &schema.EmailCodeContent{}
@@ -779,6 +888,10 @@ func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string
 	if !exist {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	// the password is changed after the change of the email is requested
+	if len(data.PassFingerprint) > 0 && data.PassFingerprint != passwordFingerprint(userInfo.Pass) {
+		return nil, errors.BadRequest(reason.EmailVerifyURLExpired)
+	}
 	err = us.userRepo.UpdateEmail(ctx, data.UserID, data.Email)
 	if err != nil {
 		return nil, errors.BadRequest(reason.UserNotFound)
@@ -818,7 +931,7 @@ func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string
 		return nil, err
 	}
 	resp.RoleID = userCacheInfo.RoleID
-	if resp.RoleID == role.RoleAdminID {
+	if us.userRoleService.CheckRolePower(ctx, resp.RoleID, permission.AdminAccess) {
 		err = us.authService.SetAdminUserCacheInfo(ctx, resp.AccessToken, &entity.UserCacheInfo{UserID: userInfo.ID})
 		if err != nil {
 			return nil, err
@@ -901,6 +1014,19 @@ func (us *UserService) UserUnsubscribeNotification(
 	}
 
 	for _, source := range data.NotificationSources {
+		// the preference may not be saved yet, it is saved with the email disabled and the in-app unchanged
+		if slices.Contains(constant.NotificationPreferenceSources, source) {
+			notificationConfigs, err := us.userNotificationConfigRepo.GetByUserID(ctx, data.UserID)
+			if err != nil {
+				return err
+			}
+			preference := schema.NewNotificationPreference(source, notificationConfigs)
+			preference.Email = false
+			if err = us.userNotificationConfigRepo.Save(ctx, preference.ToEntity(data.UserID)); err != nil {
+				return err
+			}
+			continue
+		}
 		notificationConfig, exist, err := us.userNotificationConfigRepo.GetByUserIDAndSource(
 			ctx, data.UserID, source)
 		if err != nil {
@@ -970,7 +1096,11 @@ func (us *UserService) getActivityUserVoteStat(ctx context.Context, startTime, e
 
 func (us *UserService) getStaff(ctx context.Context, userIDExist map[string]bool) (
 	userRoleRels []*entity.UserRoleRel, userIDs []string, err error) {
-	userRoleRels, err = us.userRoleService.GetUserByRoleID(ctx, []int{role.RoleAdminID, role.RoleModeratorID})
+	roleIDs, err := us.userRoleService.GetAdminModeratorRoleIDs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	userRoleRels, err = us.userRoleService.GetUserByRoleID(ctx, roleIDs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -153,3 +153,10 @@ func TestApplyRegistrationVerification(t *testing.T) {
 		assert.Equal(t, entity.EmailStatusToBeVerified, userInfo.MailStatus)
 	})
 }
+
+func TestPasswordFingerprint(t *testing.T) {
+	oldPass := "$2a$10$M3F9m1Ihk3o0vwjMI6e6XOZrS2Y2F6lqJ/qO3d1vT0fQ0y0xE1ZxC"
+	newPass := "$2a$10$Akp9w5iI7mI0jWq2hTf8iOBm6G7e7lkJ0lC1b5c9WnCj3q9cS0wQe"
+	assert.Equal(t, passwordFingerprint(oldPass), passwordFingerprint(oldPass))
+	assert.NotEqual(t, passwordFingerprint(oldPass), passwordFingerprint(newPass))
+}
@@ -252,6 +252,12 @@ func (vs *VoteService) createVoteOperationInfo(ctx context.Context,
 		VoteDown:            !voteUp,
 	}
 	voteOperationInfo.Activities = vs.getActivities(ctx, voteOperationInfo)
+	// the votes on the community wiki posts neither earn nor cost reputation
+	if objectInfo.Wiki {
+		for _, activity := range voteOperationInfo.Activities {
+			activity.Rank = 0
+		}
+	}
 	return voteOperationInfo
 }
 
@@ -284,7 +290,7 @@ func (vs *VoteService) getActivities(ctx context.Context, op *schema.VoteOperati
 			log.Warnf("get config by key error: %v", err)
 			continue
 		}
-		t.ActivityType, t.Rank = cfg.ID, cfg.GetIntValue()
+		t.ActivityType, t.ActivityTypeKey, t.Rank = cfg.ID, action, cfg.GetIntValue()
 
 		if strings.Contains(action, "voted") {
 			t.ActivityUserID = op.ObjectCreatorUserID
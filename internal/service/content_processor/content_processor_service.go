@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_processor
+
+import (
+	"context"
+	"encoding/json"
+	errpkg "errors"
+	"maps"
+	"slices"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	metacommon "github.com/apache/answer/internal/service/meta_common"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ContentProcessorService call the content processor plugins before the posts are saved
+type ContentProcessorService struct {
+	metaService *metacommon.MetaCommonService
+}
+
+// NewContentProcessorService new content processor service
+func NewContentProcessorService(metaService *metacommon.MetaCommonService) *ContentProcessorService {
+	return &ContentProcessorService{
+		metaService: metaService,
+	}
+}
+
+// Process call the content processor plugins in the order of registration. The content is changed in place,
+// the error is returned only if one of the plugins rejects the content.
+func (cs *ContentProcessorService) Process(ctx context.Context, content *plugin.ProcessingContent) (err error) {
+	if content.Metadata == nil {
+		content.Metadata = make(map[string]string)
+	}
+	var rejected *plugin.ContentRejectedError
+	_ = plugin.CallContentProcessor(func(processor plugin.ContentProcessor) error {
+		// the plugin works on a copy, so the changes of the failed plugin are discarded
+		processing := *content
+		processing.Tags = slices.Clone(content.Tags)
+		processing.Metadata = maps.Clone(content.Metadata)
+		if err := processor.ProcessContent(&processing); err != nil {
+			if errpkg.As(err, &rejected) {
+				return err
+			}
+			log.Errorf("content processor %s failed, err: %v", processor.Info().SlugName, err)
+			return nil
+		}
+		*content = processing
+		return nil
+	})
+	if rejected != nil {
+		return errors.BadRequest(reason.ContentRejectedByPlugin).WithMsg(rejected.Reason)
+	}
+	return nil
+}
+
+// SaveMetadata save the metadata annotated by the plugins with the content, the old metadata is replaced
+func (cs *ContentProcessorService) SaveMetadata(ctx context.Context, objectID string, metadata map[string]string) {
+	if len(metadata) == 0 {
+		// nothing to replace if the content has never been annotated
+		if _, err := cs.metaService.GetMetaByObjectIdAndKey(ctx, objectID, entity.ContentProcessorMetaKey); err != nil {
+			return
+		}
+	}
+	value, _ := json.Marshal(metadata)
+	err := cs.metaService.AddOrUpdateMetaByObjectIdAndKey(ctx, objectID, entity.ContentProcessorMetaKey,
+		func(meta *entity.Meta, exist bool) (*entity.Meta, error) {
+			meta.ObjectID = objectID
+			meta.Key = entity.ContentProcessorMetaKey
+			meta.Value = string(value)
+			return meta, nil
+		})
+	if err != nil {
+		log.Errorf("save content processor metadata failed, object id: %s, err: %v", objectID, err)
+	}
+}
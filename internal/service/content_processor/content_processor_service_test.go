@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testContentProcessor struct {
+	slugName string
+	process  func(content *plugin.ProcessingContent) error
+}
+
+func (p *testContentProcessor) Info() plugin.Info {
+	return plugin.Info{SlugName: p.slugName}
+}
+
+func (p *testContentProcessor) ProcessContent(content *plugin.ProcessingContent) error {
+	return p.process(content)
+}
+
+func registerTestContentProcessor(slugName string, process func(content *plugin.ProcessingContent) error) {
+	plugin.Register(&testContentProcessor{slugName: slugName, process: process})
+	plugin.StatusManager.Enable(slugName, true)
+}
+
+func TestContentProcessorService_Process(t *testing.T) {
+	rejectContent := false
+	registerTestContentProcessor("test_link_processor", func(content *plugin.ProcessingContent) error {
+		content.ParsedText = strings.ReplaceAll(content.ParsedText, "ANS-1", `<a href="https://example.com/ANS-1">ANS-1</a>`)
+		content.Metadata["tickets"] = "ANS-1"
+		return nil
+	})
+	registerTestContentProcessor("test_failed_processor", func(content *plugin.ProcessingContent) error {
+		content.ParsedText = ""
+		content.Metadata["tickets"] = ""
+		return errors.New("failed")
+	})
+	registerTestContentProcessor("test_reject_processor", func(content *plugin.ProcessingContent) error {
+		content.Metadata["checked"] = "true"
+		if rejectContent {
+			return &plugin.ContentRejectedError{Reason: "rejected"}
+		}
+		return nil
+	})
+
+	cs := NewContentProcessorService(nil)
+	content := &plugin.ProcessingContent{OriginalText: "fix ANS-1", ParsedText: "<p>fix ANS-1</p>"}
+	err := cs.Process(context.TODO(), content)
+	assert.NoError(t, err)
+	assert.Equal(t, `<p>fix <a href="https://example.com/ANS-1">ANS-1</a></p>`, content.ParsedText)
+	assert.Equal(t, map[string]string{"tickets": "ANS-1", "checked": "true"}, content.Metadata)
+
+	rejectContent = true
+	err = cs.Process(context.TODO(), &plugin.ProcessingContent{ParsedText: "<p>spam</p>"})
+	assert.Error(t, err)
+}
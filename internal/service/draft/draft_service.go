@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/service_config"
+	"github.com/segmentfault/pacman/log"
+)
+
+// DraftRepo draft repository
+type DraftRepo interface {
+	SetDraft(ctx context.Context, userID, target string, info *entity.Draft, ttl time.Duration) (err error)
+	GetDraft(ctx context.Context, userID, target string) (info *entity.Draft, exist bool, err error)
+	RemoveDraft(ctx context.Context, userID, target string) (err error)
+}
+
+// DraftService the auto-saved drafts of the question and answer editors
+type DraftService struct {
+	draftRepo     DraftRepo
+	serviceConfig *service_config.ServiceConfig
+}
+
+// NewDraftService new draft service
+func NewDraftService(draftRepo DraftRepo, serviceConfig *service_config.ServiceConfig) *DraftService {
+	return &DraftService{
+		draftRepo:     draftRepo,
+		serviceConfig: serviceConfig,
+	}
+}
+
+// SaveDraft save the latest draft of the user, the expiration is renewed on every save
+func (ds *DraftService) SaveDraft(ctx context.Context, req *schema.SaveDraftReq) (resp *schema.DraftResp, err error) {
+	info := &entity.Draft{
+		Title:     req.Title,
+		Content:   req.Content,
+		Tags:      req.Tags,
+		UpdatedAt: time.Now().Unix(),
+	}
+	target := draftTarget(req.TargetType, req.QuestionID)
+	if err = ds.draftRepo.SetDraft(ctx, req.UserID, target, info, ds.draftTTL()); err != nil {
+		return nil, err
+	}
+	return newDraftResp(req.TargetType, req.QuestionID, info), nil
+}
+
+// GetDraft get the latest draft of the user, nil if there is no draft or it has expired
+func (ds *DraftService) GetDraft(ctx context.Context, req *schema.GetDraftReq) (resp *schema.DraftResp, err error) {
+	info, exist, err := ds.draftRepo.GetDraft(ctx, req.UserID, draftTarget(req.TargetType, req.QuestionID))
+	if err != nil || !exist {
+		return nil, err
+	}
+	return newDraftResp(req.TargetType, req.QuestionID, info), nil
+}
+
+// RemoveDraft discard the draft of the user
+func (ds *DraftService) RemoveDraft(ctx context.Context, req *schema.RemoveDraftReq) (err error) {
+	return ds.draftRepo.RemoveDraft(ctx, req.UserID, draftTarget(req.TargetType, req.QuestionID))
+}
+
+// ClearQuestionDraft clear the draft of the new question after the question is submitted
+func (ds *DraftService) ClearQuestionDraft(ctx context.Context, userID string) {
+	ds.clearDraft(ctx, userID, draftTarget(constant.DraftTargetQuestion, ""))
+}
+
+// ClearAnswerDraft clear the draft of the answer to the question after the answer is submitted
+func (ds *DraftService) ClearAnswerDraft(ctx context.Context, userID, questionID string) {
+	ds.clearDraft(ctx, userID, draftTarget(constant.DraftTargetAnswer, questionID))
+}
+
+func (ds *DraftService) clearDraft(ctx context.Context, userID, target string) {
+	if err := ds.draftRepo.RemoveDraft(ctx, userID, target); err != nil {
+		log.Errorf("clear draft %s of user %s failed: %v", target, userID, err)
+	}
+}
+
+func (ds *DraftService) draftTTL() time.Duration {
+	if ds.serviceConfig == nil || ds.serviceConfig.DraftTTLHours <= 0 {
+		return constant.UserDraftCacheTime
+	}
+	return time.Duration(ds.serviceConfig.DraftTTLHours) * time.Hour
+}
+
+// draftTarget the new question has only one draft, the answers have one draft for each question
+func draftTarget(targetType, questionID string) string {
+	if targetType == constant.DraftTargetAnswer {
+		return targetType + ":" + questionID
+	}
+	return targetType
+}
+
+func newDraftResp(targetType, questionID string, info *entity.Draft) *schema.DraftResp {
+	resp := &schema.DraftResp{
+		TargetType: targetType,
+		Title:      info.Title,
+		Content:    info.Content,
+		Tags:       info.Tags,
+		UpdatedAt:  info.UpdatedAt,
+	}
+	if targetType == constant.DraftTargetAnswer {
+		resp.QuestionID = questionID
+	}
+	if resp.Tags == nil {
+		resp.Tags = make([]string, 0)
+	}
+	return resp
+}
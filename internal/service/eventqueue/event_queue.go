@@ -20,12 +20,46 @@
 package eventqueue
 
 import (
+	"context"
+	"sync"
+
 	"github.com/apache/answer/internal/base/queue"
 	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
 )
 
 type Service queue.Service[*schema.EventMsg]
 
+// eventQueue fans every event out to all registered handlers,
+// so that badges, webhooks and others can subscribe to the same events.
+type eventQueue struct {
+	*queue.Queue[*schema.EventMsg]
+	mu       sync.RWMutex
+	handlers []func(ctx context.Context, msg *schema.EventMsg) error
+}
+
 func NewService() Service {
-	return queue.New[*schema.EventMsg]("event", 128)
+	q := &eventQueue{Queue: queue.New[*schema.EventMsg]("event", 128)}
+	q.Queue.RegisterHandler(q.dispatch)
+	return q
+}
+
+// RegisterHandler adds a handler, the existing handlers are kept.
+func (q *eventQueue) RegisterHandler(handler func(ctx context.Context, msg *schema.EventMsg) error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers = append(q.handlers, handler)
+}
+
+func (q *eventQueue) dispatch(ctx context.Context, msg *schema.EventMsg) error {
+	q.mu.RLock()
+	handlers := q.handlers
+	q.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, msg); err != nil {
+			log.Errorf("[event] handler error: %v", err)
+		}
+	}
+	return nil
 }
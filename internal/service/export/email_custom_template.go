@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	htmltemplate "html/template"
+	"slices"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// GetCustomEmailTemplates get the email templates customized by the admins
+func (es *EmailService) GetCustomEmailTemplates(ctx context.Context) (templates []*schema.CustomEmailTemplate, err error) {
+	templates = make([]*schema.CustomEmailTemplate, 0)
+	val, err := es.configService.GetStringValue(ctx, constant.EmailTemplatesConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(val) == 0 {
+		return templates, nil
+	}
+	if err = json.Unmarshal([]byte(val), &templates); err != nil {
+		log.Errorf("custom email templates format is invalid: %v", err)
+		return make([]*schema.CustomEmailTemplate, 0), nil
+	}
+	return templates, nil
+}
+
+// SetCustomEmailTemplates save the email templates customized by the admins
+func (es *EmailService) SetCustomEmailTemplates(ctx context.Context, templates []*schema.CustomEmailTemplate) (err error) {
+	data, _ := json.Marshal(templates)
+	return es.configService.UpdateConfig(ctx, constant.EmailTemplatesConfigKey, string(data))
+}
+
+// ValidateCustomEmailTemplate check the subject and the bodies of the custom email template can be parsed
+// and only use the known variables. The first unknown variable is returned if any.
+func ValidateCustomEmailTemplate(tpl *schema.CustomEmailTemplate) (unknownVariable string, err error) {
+	for _, text := range []string{tpl.Subject, tpl.HTMLBody, tpl.TextBody} {
+		t, err := template.New("email").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		if t.Tree == nil {
+			continue
+		}
+		if name := findUnknownTemplateVariable(t.Tree.Root); len(name) > 0 {
+			return name, nil
+		}
+	}
+	if _, err = htmltemplate.New("email").Parse(tpl.HTMLBody); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// findUnknownTemplateVariable walk the parse tree of the template and return the first field that is not a known variable
+func findUnknownTemplateVariable(node parse.Node) string {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return ""
+		}
+		for _, child := range n.Nodes {
+			if name := findUnknownTemplateVariable(child); len(name) > 0 {
+				return name
+			}
+		}
+	case *parse.ActionNode:
+		return findUnknownTemplateVariable(n.Pipe)
+	case *parse.IfNode:
+		return findUnknownTemplateVariable(&n.BranchNode)
+	case *parse.RangeNode:
+		return findUnknownTemplateVariable(&n.BranchNode)
+	case *parse.WithNode:
+		return findUnknownTemplateVariable(&n.BranchNode)
+	case *parse.BranchNode:
+		for _, child := range []parse.Node{n.Pipe, n.List, n.ElseList} {
+			if name := findUnknownTemplateVariable(child); len(name) > 0 {
+				return name
+			}
+		}
+	case *parse.PipeNode:
+		if n == nil {
+			return ""
+		}
+		for _, cmd := range n.Cmds {
+			if name := findUnknownTemplateVariable(cmd); len(name) > 0 {
+				return name
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if name := findUnknownTemplateVariable(arg); len(name) > 0 {
+				return name
+			}
+		}
+	case *parse.ChainNode:
+		return findUnknownTemplateVariable(n.Node)
+	case *parse.FieldNode:
+		if !slices.Contains(constant.EmailTemplateVariables, n.Ident[0]) {
+			return n.Ident[0]
+		}
+	case *parse.VariableNode:
+		// $.SiteName refers to the variable of the root data
+		if len(n.Ident) > 1 && n.Ident[0] == "$" && !slices.Contains(constant.EmailTemplateVariables, n.Ident[1]) {
+			return n.Ident[1]
+		}
+	case *parse.TemplateNode:
+		// the other templates can not be defined or included in the email template
+		return n.Name
+	}
+	return ""
+}
+
+// renderCustomEmailTemplate render the custom email template of the type in the language,
+// ok is false if there is no custom template for the language or it fails to render, then the default template is used.
+func (es *EmailService) renderCustomEmailTemplate(ctx context.Context, lang i18n.Language,
+	tplType constant.EmailTemplateType, data *schema.CustomEmailTemplateData) (title, body, textBody string, ok bool) {
+	templates, err := es.GetCustomEmailTemplates(ctx)
+	if err != nil {
+		log.Errorf("get custom email templates failed: %v", err)
+		return "", "", "", false
+	}
+	idx := slices.IndexFunc(templates, func(t *schema.CustomEmailTemplate) bool {
+		return t.Type == tplType && t.Language == string(lang)
+	})
+	if idx < 0 {
+		return "", "", "", false
+	}
+	tpl := templates[idx]
+
+	var err1, err2, err3 error
+	title, err1 = renderTextTemplate(tpl.Subject, data)
+	body, err2 = renderHTMLTemplate(tpl.HTMLBody, data)
+	if len(tpl.TextBody) > 0 {
+		textBody, err3 = renderTextTemplate(tpl.TextBody, data)
+	}
+	for _, err = range []error{err1, err2, err3} {
+		if err != nil {
+			log.Errorf("render custom email template %s of %s failed: %v", tplType, lang, err)
+			return "", "", "", false
+		}
+	}
+	return title, body, textBody, true
+}
+
+func renderTextTemplate(text string, data *schema.CustomEmailTemplateData) (string, error) {
+	t, err := template.New("email").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err = t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderHTMLTemplate render the html body, the values of the variables are escaped by the context of the html
+func renderHTMLTemplate(text string, data *schema.CustomEmailTemplateData) (string, error) {
+	t, err := htmltemplate.New("email").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err = t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
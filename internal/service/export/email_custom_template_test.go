@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCustomEmailTemplate(t *testing.T) {
+	tpl := &schema.CustomEmailTemplate{
+		Subject:  "[{{.SiteName}}] {{.UserName}} answered {{.PostTitle}}",
+		HTMLBody: "{{if .Summary}}<p>{{.Summary}}</p>{{end}}<a href='{{.ActionUrl}}'>{{$.PostTitle}}</a>",
+		TextBody: "{{.ActionUrl}}\n{{.UnsubscribeUrl}}",
+	}
+	unknown, err := ValidateCustomEmailTemplate(tpl)
+	assert.NoError(t, err)
+	assert.Empty(t, unknown)
+
+	tpl.TextBody = "{{with .PostTitle}}{{.}}{{end}} {{.Password}}"
+	unknown, err = ValidateCustomEmailTemplate(tpl)
+	assert.NoError(t, err)
+	assert.Equal(t, "Password", unknown)
+
+	tpl.TextBody = "{{$.Email}}"
+	unknown, _ = ValidateCustomEmailTemplate(tpl)
+	assert.Equal(t, "Email", unknown)
+
+	tpl.TextBody = `{{template "other"}}`
+	unknown, _ = ValidateCustomEmailTemplate(tpl)
+	assert.Equal(t, "other", unknown)
+
+	tpl.TextBody = "{{.SiteName"
+	_, err = ValidateCustomEmailTemplate(tpl)
+	assert.Error(t, err)
+}
+
+func TestRenderCustomEmailTemplate(t *testing.T) {
+	data := &schema.CustomEmailTemplateData{
+		SiteName:  "Answer",
+		PostTitle: "<script>alert(1)</script>",
+		ActionUrl: "https://example.com/questions/1",
+	}
+	body, err := renderHTMLTemplate("<a href='{{.ActionUrl}}'>{{.PostTitle}}</a>", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "<a href='https://example.com/questions/1'>&lt;script&gt;alert(1)&lt;/script&gt;</a>", body)
+
+	text, err := renderTextTemplate("{{.SiteName}}: {{.PostTitle}}", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Answer: <script>alert(1)</script>", text)
+}
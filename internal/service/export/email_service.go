@@ -26,10 +26,12 @@ import (
 	"fmt"
 	"html"
 	"mime"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/display"
 
 	"github.com/apache/answer/internal/base/constant"
@@ -38,8 +40,10 @@ import (
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/jobqueue"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"gopkg.in/gomail.v2"
 )
@@ -49,6 +53,7 @@ type EmailService struct {
 	configService   *config.ConfigService
 	emailRepo       EmailRepo
 	siteInfoService siteinfo_common.SiteInfoCommonService
+	jobQueue        *jobqueue.Service
 }
 
 // EmailRepo email repository
@@ -62,12 +67,18 @@ func NewEmailService(
 	configService *config.ConfigService,
 	emailRepo EmailRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	jobQueue *jobqueue.Service,
 ) *EmailService {
-	return &EmailService{
+	es := &EmailService{
 		configService:   configService,
 		emailRepo:       emailRepo,
 		siteInfoService: siteInfoService,
+		jobQueue:        jobQueue,
 	}
+	if jobQueue != nil {
+		jobqueue.Register(jobQueue, constant.JobTypeEmailSend, es.sendEmailJob, nil)
+	}
+	return es
 }
 
 // EmailConfig email config
@@ -119,25 +130,110 @@ func (es *EmailService) SendAndSaveCodeWithTime(
 	es.Send(ctx, toEmailAddr, subject, body)
 }
 
-// Send email send
+// Send send email by the background job, it is retried if the smtp server is unavailable
 func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
-	log.Infof("try to send email to %s", toEmailAddr)
+	es.enqueue(ctx, &schema.EmailJob{To: toEmailAddr, Subject: subject, Body: body})
+}
+
+// SendWithUnsubscribe send the notification email with the List-Unsubscribe headers of RFC 8058,
+// so that the mail clients can unsubscribe it in one click with the signed unsubscribe token.
+// The textBody is sent as the plain text alternative of the html body if it is not empty.
+func (es *EmailService) SendWithUnsubscribe(ctx context.Context, toEmailAddr, subject, body, textBody,
+	unsubscribeToken string) {
+	es.enqueue(ctx, &schema.EmailJob{
+		To:               toEmailAddr,
+		Subject:          subject,
+		Body:             body,
+		TextBody:         textBody,
+		UnsubscribeToken: unsubscribeToken,
+	})
+}
+
+// enqueue add the job of the email, it is sent at once if the job queue is unavailable
+func (es *EmailService) enqueue(ctx context.Context, msg *schema.EmailJob) {
+	if es.jobQueue != nil {
+		err := es.jobQueue.Enqueue(context.WithoutCancel(ctx), constant.JobTypeEmailSend, msg)
+		if err == nil {
+			return
+		}
+		log.Errorf("enqueue email to %s failed, send it now: %v", msg.To, err)
+	}
+	if err := es.sendEmailJob(ctx, msg); err != nil {
+		log.Error(err)
+	}
+}
+
+// sendEmailJob send the email of the job, the error is returned to retry it
+func (es *EmailService) sendEmailJob(ctx context.Context, msg *schema.EmailJob) (err error) {
+	log.Infof("try to send email to %s", msg.To)
 	ec, err := es.GetEmailConfig(ctx)
 	if err != nil {
-		log.Errorf("get email config failed: %s", err)
-		return
+		return fmt.Errorf("get email config failed: %w", err)
 	}
 	if len(ec.SMTPHost) == 0 {
 		log.Warnf("smtp host is empty, skip send email")
-		return
+		return nil
+	}
+	var headers map[string][]string
+	if len(msg.UnsubscribeToken) > 0 {
+		siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+		if err != nil {
+			return fmt.Errorf("get site general failed: %w", err)
+		}
+		headers = map[string][]string{
+			"List-Unsubscribe": {fmt.Sprintf("<%s/answer/api/v1/user/notification/unsubscribe/one-click?code=%s>",
+				siteInfo.SiteUrl, url.QueryEscape(msg.UnsubscribeToken))},
+			"List-Unsubscribe-Post": {"List-Unsubscribe=One-Click"},
+		}
+	}
+
+	if err = es.dialAndSend(ec, msg.To, msg.Subject, msg.Body, msg.TextBody, headers); err != nil {
+		return fmt.Errorf("send email to %s failed: %w", msg.To, err)
+	}
+	log.Infof("send email to %s success", msg.To)
+	return nil
+}
+
+// SendWithTimeout send email and wait for the result, the SMTP error is returned as it is
+// so that the caller can tell what is wrong with the config. Give up if it takes longer than timeout,
+// gomail can not be interrupted, so the sending goroutine exits by itself when the connection fails.
+func (es *EmailService) SendWithTimeout(ctx context.Context, toEmailAddr, subject, body string,
+	timeout time.Duration) (err error) {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if len(ec.SMTPHost) == 0 {
+		return errors.BadRequest(reason.SMTPHostEmpty)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- es.dialAndSend(ec, toEmailAddr, subject, body, "", nil)
+	}()
+	select {
+	case err = <-errCh:
+		return err
+	case <-time.After(timeout):
+		return errors.BadRequest(reason.SMTPTestEmailSendTimeout).
+			WithError(fmt.Errorf("send email to %s timeout after %s", toEmailAddr, timeout)).WithStack()
 	}
+}
 
+func (es *EmailService) dialAndSend(ec *EmailConfig, toEmailAddr, subject, body, textBody string,
+	headers map[string][]string) error {
 	m := gomail.NewMessage()
 	fromName := mime.QEncoding.Encode("utf-8", ec.FromName)
 	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
 	m.SetHeader("To", toEmailAddr)
 	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", body)
+	m.SetHeaders(headers)
+	if len(textBody) > 0 {
+		m.SetBody("text/plain", textBody)
+		m.AddAlternative("text/html", body)
+	} else {
+		m.SetBody("text/html", body)
+	}
 
 	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
 	if ec.IsSSL() {
@@ -149,11 +245,7 @@ func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body str
 	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
 		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
 	}
-	if err := d.DialAndSend(m); err != nil {
-		log.Errorf("send email to %s failed: %s", toEmailAddr, err)
-	} else {
-		log.Infof("send email to %s success", toEmailAddr)
-	}
+	return d.DialAndSend(m)
 }
 
 // VerifyUrlExpired email send
@@ -195,6 +287,175 @@ func (es *EmailService) PassResetTemplate(ctx context.Context, passResetUrl stri
 	return title, body, nil
 }
 
+// UserDataExportTemplate the email that sends the download link of the user data export
+func (es *EmailService) UserDataExportTemplate(ctx context.Context, downloadUrl string) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.UserDataExportTemplateData{SiteName: siteInfo.Name, DownloadUrl: downloadUrl}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyUserDataExportTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyUserDataExportBody, &schema.UserDataExportTemplateData{
+		SiteName:    escapeEmailHTMLText(templateData.SiteName),
+		DownloadUrl: templateData.DownloadUrl,
+	})
+	return title, body, nil
+}
+
+// ModerationWarningTemplate the email that sends the warning of the moderators to the author of the flagged content
+func (es *EmailService) ModerationWarningTemplate(ctx context.Context, message string) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.ModerationWarningTemplateData{SiteName: siteInfo.Name, Message: message}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyModerationWarningTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyModerationWarningBody, &schema.ModerationWarningTemplateData{
+		SiteName: escapeEmailHTMLText(templateData.SiteName),
+		Message:  escapeEmailHTMLText(templateData.Message),
+	})
+	return title, body, nil
+}
+
+// GuestQuestionClaimTemplate the email to the guest when the question is approved, the link in it claims the question
+func (es *EmailService) GuestQuestionClaimTemplate(ctx context.Context, questionTitle, claimUrl string) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.GuestQuestionClaimTemplateData{
+		SiteName:      siteInfo.Name,
+		QuestionTitle: questionTitle,
+		ClaimUrl:      claimUrl,
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyGuestQuestionClaimTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyGuestQuestionClaimBody, &schema.GuestQuestionClaimTemplateData{
+		SiteName:      escapeEmailHTMLText(templateData.SiteName),
+		QuestionTitle: escapeEmailHTMLText(templateData.QuestionTitle),
+		ClaimUrl:      templateData.ClaimUrl,
+	})
+	return title, body, nil
+}
+
+// NotificationEventTemplate the email of the notification events that have no dedicated template
+func (es *EmailService) NotificationEventTemplate(ctx context.Context, raw *schema.NotificationEventTemplateRawData) (
+	title, body, textBody string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	lang := handler.GetLangByCtx(ctx)
+	templateData := es.notificationEventTemplateData(lang, siteInfo, seoInfo, raw)
+	templateData.SiteName = siteInfo.Name
+	templateData.UnsubscribeUrl = fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode)
+
+	title, body, textBody, ok := es.renderCustomEmailTemplate(ctx, lang, constant.EmailTemplateTypeNotificationEvent,
+		&schema.CustomEmailTemplateData{
+			SiteName:       templateData.SiteName,
+			UserName:       raw.TriggerUserDisplayName,
+			PostTitle:      templateData.Title,
+			ActionUrl:      templateData.Url,
+			Summary:        templateData.Action,
+			UnsubscribeUrl: templateData.UnsubscribeUrl,
+		})
+	if ok {
+		return title, body, textBody, nil
+	}
+	title = translator.TrWithData(lang, constant.EmailTplKeyNotificationEventTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyNotificationEventBody, &schema.NotificationEventTemplateData{
+		SiteName:       escapeEmailHTMLText(templateData.SiteName),
+		Action:         escapeEmailHTMLText(templateData.Action),
+		Title:          escapeEmailHTMLText(templateData.Title),
+		Url:            templateData.Url,
+		UnsubscribeUrl: templateData.UnsubscribeUrl,
+	})
+	return title, body, "", nil
+}
+
+// NotificationDigestTemplate the digest email of the notification events, they are grouped by the event
+func (es *EmailService) NotificationDigestTemplate(ctx context.Context, raw *schema.NotificationDigestTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	lang := handler.GetLangByCtx(ctx)
+	templateData := &schema.NotificationDigestTemplateData{
+		SiteName:          siteInfo.Name,
+		NotificationCount: len(raw.Notifications),
+		UnsubscribeUrl:    fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+	bodyData := &schema.NotificationDigestTemplateData{
+		SiteName:          escapeEmailHTMLText(templateData.SiteName),
+		NotificationCount: templateData.NotificationCount,
+		UnsubscribeUrl:    templateData.UnsubscribeUrl,
+	}
+	groups := make(map[constant.NotificationSource]*schema.NotificationDigestGroup)
+	for _, notification := range raw.Notifications {
+		group, ok := groups[notification.Event]
+		if !ok {
+			group = &schema.NotificationDigestGroup{
+				Name: escapeEmailHTMLText(translator.Tr(lang,
+					constant.EmailTplKeyNotificationDigestGroupPrefix+string(notification.Event))),
+			}
+			groups[notification.Event] = group
+		}
+		item := es.notificationEventTemplateData(lang, siteInfo, seoInfo, notification)
+		item.Action = escapeEmailHTMLText(item.Action)
+		item.Title = escapeEmailHTMLText(item.Title)
+		group.Items = append(group.Items, item)
+	}
+	for _, source := range constant.NotificationPreferenceSources {
+		if group, ok := groups[source]; ok {
+			bodyData.Groups = append(bodyData.Groups, group)
+		}
+	}
+
+	title = translator.TrWithData(lang, constant.EmailTplKeyNotificationDigestTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyNotificationDigestBody, bodyData)
+	return title, body, nil
+}
+
+// notificationEventTemplateData the action, the title and the link of the notification event, they are not escaped
+func (es *EmailService) notificationEventTemplateData(lang i18n.Language, siteInfo *schema.SiteGeneralResp,
+	seoInfo *schema.SiteSeoResp, raw *schema.NotificationEventTemplateRawData) (data *schema.NotificationEventTemplateData) {
+	data = &schema.NotificationEventTemplateData{Title: raw.Title}
+	switch {
+	case len(raw.BadgeID) > 0:
+		data.Title = translator.Tr(lang, raw.Title)
+		data.Action = translator.TrWithData(lang, raw.NotificationAction, struct {
+			BadgeName string
+		}{BadgeName: data.Title})
+		data.Url = fmt.Sprintf("%s/badges/%s", siteInfo.SiteUrl, raw.BadgeID)
+	case len(raw.CommentID) > 0:
+		data.Action = raw.TriggerUserDisplayName + " " + translator.Tr(lang, raw.NotificationAction)
+		data.Url = display.CommentURL(seoInfo.Permalink,
+			siteInfo.SiteUrl, raw.QuestionID, raw.Title, raw.AnswerID, raw.CommentID)
+	case len(raw.AnswerID) > 0:
+		data.Action = raw.TriggerUserDisplayName + " " + translator.Tr(lang, raw.NotificationAction)
+		data.Url = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.Title, raw.AnswerID)
+	default:
+		data.Action = raw.TriggerUserDisplayName + " " + translator.Tr(lang, raw.NotificationAction)
+		data.Url = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.Title)
+	}
+	return data
+}
+
 func (es *EmailService) ChangeEmailTemplate(ctx context.Context, changeEmailUrl string) (title, body string, err error) {
 	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
@@ -211,6 +472,25 @@ func (es *EmailService) ChangeEmailTemplate(ctx context.Context, changeEmailUrl
 	return title, body, nil
 }
 
+// ChangeEmailNoticeTemplate the notice to the old address that the change of the email is requested,
+// the new address is masked
+func (es *EmailService) ChangeEmailNoticeTemplate(ctx context.Context, newEmail string) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.ChangeEmailNoticeTemplateData{SiteName: siteInfo.Name, NewEmail: converter.MaskEmail(newEmail)}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyChangeEmailNoticeTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyChangeEmailNoticeBody, &schema.ChangeEmailNoticeTemplateData{
+		SiteName: escapeEmailHTMLText(templateData.SiteName),
+		NewEmail: escapeEmailHTMLText(templateData.NewEmail),
+	})
+	return title, body, nil
+}
+
 // TestTemplate send test email template parse
 func (es *EmailService) TestTemplate(ctx context.Context) (title, body string, err error) {
 	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
@@ -231,7 +511,7 @@ func escapeEmailHTMLText(text string) string {
 
 // NewAnswerTemplate new answer template
 func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAnswerTemplateRawData) (
-	title, body string, err error) {
+	title, body, textBody string, err error) {
 	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
 		return
@@ -250,6 +530,18 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 	}
 
 	lang := handler.GetLangByCtx(ctx)
+	title, body, textBody, ok := es.renderCustomEmailTemplate(ctx, lang, constant.EmailTemplateTypeNewAnswer,
+		&schema.CustomEmailTemplateData{
+			SiteName:       templateData.SiteName,
+			UserName:       templateData.DisplayName,
+			PostTitle:      templateData.QuestionTitle,
+			ActionUrl:      templateData.AnswerUrl,
+			Summary:        templateData.AnswerSummary,
+			UnsubscribeUrl: templateData.UnsubscribeUrl,
+		})
+	if ok {
+		return title, body, textBody, nil
+	}
 	title = translator.TrWithData(lang, constant.EmailTplKeyNewAnswerTitle, templateData)
 	body = translator.TrWithData(lang, constant.EmailTplKeyNewAnswerBody, &schema.NewAnswerTemplateData{
 		SiteName:       escapeEmailHTMLText(templateData.SiteName),
@@ -259,12 +551,12 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 		AnswerSummary:  escapeEmailHTMLText(templateData.AnswerSummary),
 		UnsubscribeUrl: templateData.UnsubscribeUrl,
 	})
-	return title, body, nil
+	return title, body, "", nil
 }
 
 // NewInviteAnswerTemplate new invite answer template
 func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema.NewInviteAnswerTemplateRawData) (
-	title, body string, err error) {
+	title, body, textBody string, err error) {
 	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
 		return
@@ -282,6 +574,17 @@ func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema
 	}
 
 	lang := handler.GetLangByCtx(ctx)
+	title, body, textBody, ok := es.renderCustomEmailTemplate(ctx, lang, constant.EmailTemplateTypeInvitedAnswer,
+		&schema.CustomEmailTemplateData{
+			SiteName:       templateData.SiteName,
+			UserName:       templateData.DisplayName,
+			PostTitle:      templateData.QuestionTitle,
+			ActionUrl:      templateData.InviteUrl,
+			UnsubscribeUrl: templateData.UnsubscribeUrl,
+		})
+	if ok {
+		return title, body, textBody, nil
+	}
 	title = translator.TrWithData(lang, constant.EmailTplKeyInvitedAnswerTitle, templateData)
 	body = translator.TrWithData(lang, constant.EmailTplKeyInvitedAnswerBody, &schema.NewInviteAnswerTemplateData{
 		SiteName:       escapeEmailHTMLText(templateData.SiteName),
@@ -290,12 +593,12 @@ func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema
 		InviteUrl:      templateData.InviteUrl,
 		UnsubscribeUrl: templateData.UnsubscribeUrl,
 	})
-	return title, body, nil
+	return title, body, "", nil
 }
 
 // NewCommentTemplate new comment template
 func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewCommentTemplateRawData) (
-	title, body string, err error) {
+	title, body, textBody string, err error) {
 	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
 		return
@@ -315,6 +618,18 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 		siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID, raw.CommentID)
 
 	lang := handler.GetLangByCtx(ctx)
+	title, body, textBody, ok := es.renderCustomEmailTemplate(ctx, lang, constant.EmailTemplateTypeNewComment,
+		&schema.CustomEmailTemplateData{
+			SiteName:       templateData.SiteName,
+			UserName:       templateData.DisplayName,
+			PostTitle:      templateData.QuestionTitle,
+			ActionUrl:      templateData.CommentUrl,
+			Summary:        templateData.CommentSummary,
+			UnsubscribeUrl: templateData.UnsubscribeUrl,
+		})
+	if ok {
+		return title, body, textBody, nil
+	}
 	title = translator.TrWithData(lang, constant.EmailTplKeyNewCommentTitle, templateData)
 	body = translator.TrWithData(lang, constant.EmailTplKeyNewCommentBody, &schema.NewCommentTemplateData{
 		SiteName:       escapeEmailHTMLText(templateData.SiteName),
@@ -324,12 +639,12 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 		CommentSummary: escapeEmailHTMLText(templateData.CommentSummary),
 		UnsubscribeUrl: templateData.UnsubscribeUrl,
 	})
-	return title, body, nil
+	return title, body, "", nil
 }
 
 // NewQuestionTemplate new question template
 func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.NewQuestionTemplateRawData) (
-	title, body string, err error) {
+	title, body, textBody string, err error) {
 	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
 		return
@@ -348,6 +663,17 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 		seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle)
 
 	lang := handler.GetLangByCtx(ctx)
+	title, body, textBody, ok := es.renderCustomEmailTemplate(ctx, lang, constant.EmailTemplateTypeNewQuestion,
+		&schema.CustomEmailTemplateData{
+			SiteName:       templateData.SiteName,
+			PostTitle:      templateData.QuestionTitle,
+			ActionUrl:      templateData.QuestionUrl,
+			Summary:        templateData.Tags,
+			UnsubscribeUrl: templateData.UnsubscribeUrl,
+		})
+	if ok {
+		return title, body, textBody, nil
+	}
 	title = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionTitle, templateData)
 	body = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionBody, &schema.NewQuestionTemplateData{
 		SiteName:       escapeEmailHTMLText(templateData.SiteName),
@@ -356,6 +682,83 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 		Tags:           escapeEmailHTMLText(templateData.Tags),
 		UnsubscribeUrl: templateData.UnsubscribeUrl,
 	})
+	return title, body, "", nil
+}
+
+// NewQuestionDigestTemplate the digest of the new questions in the following tags
+func (es *EmailService) NewQuestionDigestTemplate(ctx context.Context, raw *schema.NewQuestionDigestTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.NewQuestionDigestTemplateData{
+		SiteName:       siteInfo.Name,
+		QuestionCount:  len(raw.Questions),
+		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+	bodyData := &schema.NewQuestionDigestTemplateData{
+		SiteName:       escapeEmailHTMLText(templateData.SiteName),
+		QuestionCount:  templateData.QuestionCount,
+		UnsubscribeUrl: templateData.UnsubscribeUrl,
+	}
+	for _, question := range raw.Questions {
+		bodyData.Questions = append(bodyData.Questions, &schema.NewQuestionTemplateData{
+			QuestionTitle: escapeEmailHTMLText(question.QuestionTitle),
+			QuestionUrl: display.QuestionURL(
+				seoInfo.Permalink, siteInfo.SiteUrl, question.QuestionID, question.QuestionTitle),
+			Tags: escapeEmailHTMLText(strings.Join(question.Tags, ", ")),
+		})
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionDigestTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionDigestBody, bodyData)
+	return title, body, nil
+}
+
+// SavedSearchAlertTemplate the new content matching the saved search of the user
+func (es *EmailService) SavedSearchAlertTemplate(ctx context.Context, raw *schema.SavedSearchAlertTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.SavedSearchAlertTemplateData{
+		SiteName:    siteInfo.Name,
+		SearchName:  raw.SearchName,
+		ResultCount: len(raw.Results),
+		SearchUrl:   fmt.Sprintf("%s/search?q=%s", siteInfo.SiteUrl, url.QueryEscape(raw.Query)),
+	}
+	bodyData := &schema.SavedSearchAlertTemplateData{
+		SiteName:    escapeEmailHTMLText(templateData.SiteName),
+		SearchName:  escapeEmailHTMLText(templateData.SearchName),
+		ResultCount: templateData.ResultCount,
+		SearchUrl:   templateData.SearchUrl,
+	}
+	for _, result := range raw.Results {
+		object := result.Object
+		resultUrl := display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, object.QuestionID, object.Title)
+		if result.ObjectType == constant.AnswerObjectType {
+			resultUrl = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, object.QuestionID, object.Title, object.ID)
+		}
+		bodyData.Results = append(bodyData.Results, &schema.SavedSearchAlertResultTemplateData{
+			Title: escapeEmailHTMLText(object.Title),
+			Url:   resultUrl,
+		})
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeySavedSearchAlertTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeySavedSearchAlertBody, bodyData)
 	return title, body, nil
 }
 
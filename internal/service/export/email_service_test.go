@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emailConfigRepo keeps the email config in memory
+type emailConfigRepo struct {
+	config.ConfigRepo
+	value string
+}
+
+func (r *emailConfigRepo) GetConfigByKey(_ context.Context, key string) (*entity.Config, error) {
+	return &entity.Config{Key: key, Value: r.value}, nil
+}
+
+func newTestEmailService(t *testing.T, ec *EmailConfig) *EmailService {
+	value, err := json.Marshal(ec)
+	require.NoError(t, err)
+	return NewEmailService(config.NewConfigService(&emailConfigRepo{value: string(value)}), nil, nil, nil)
+}
+
+func TestEmailService_SendWithTimeout_EmptyHost(t *testing.T) {
+	es := newTestEmailService(t, &EmailConfig{FromEmail: "answer@example.com"})
+	err := es.SendWithTimeout(context.TODO(), "user@example.com", "subject", "body", time.Second)
+	var myErr *errors.Error
+	require.ErrorAs(t, err, &myErr)
+	assert.Equal(t, reason.SMTPHostEmpty, myErr.Reason)
+}
+
+func TestEmailService_SendWithTimeout_Timeout(t *testing.T) {
+	// the server accepts the connection but never sends the greeting
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	conns := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conns <- conn
+		}
+	}()
+	defer func() {
+		_ = listener.Close()
+		select {
+		case conn := <-conns:
+			_ = conn.Close()
+		case <-time.After(time.Second):
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	es := newTestEmailService(t, &EmailConfig{
+		FromEmail: "answer@example.com",
+		SMTPHost:  addr.IP.String(),
+		SMTPPort:  addr.Port,
+	})
+	err = es.SendWithTimeout(context.TODO(), "user@example.com", "subject", "body", 100*time.Millisecond)
+	var myErr *errors.Error
+	require.ErrorAs(t, err, &myErr)
+	assert.Equal(t, reason.SMTPTestEmailSendTimeout, myErr.Reason)
+}
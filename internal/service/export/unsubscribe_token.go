@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+)
+
+// unsubscribeTokenContent the content signed in the unsubscribe token
+type unsubscribeTokenContent struct {
+	UserID  string                        `json:"u"`
+	Sources []constant.NotificationSource `json:"s"`
+	// Nonce makes the tokens of the emails different from each other
+	Nonce     string `json:"n,omitempty"`
+	ExpiredAt int64  `json:"e"`
+}
+
+// GenerateUnsubscribeToken generate the signed unsubscribe token of the user and the notification sources,
+// it works without login until it expires. It is empty if the secret can not be loaded.
+func (es *EmailService) GenerateUnsubscribeToken(ctx context.Context, userID, nonce string,
+	sources ...constant.NotificationSource) string {
+	secret, err := es.getUnsubscribeSecret(ctx)
+	if err != nil {
+		log.Errorf("get unsubscribe secret failed: %v", err)
+		return ""
+	}
+	return signUnsubscribeToken(secret, &unsubscribeTokenContent{
+		UserID:    userID,
+		Sources:   sources,
+		Nonce:     nonce,
+		ExpiredAt: time.Now().Add(constant.UnsubscribeTokenExpiration).Unix(),
+	})
+}
+
+// VerifyUnsubscribeToken verify the signed unsubscribe token, returns the email code content of it or empty if invalid
+func (es *EmailService) VerifyUnsubscribeToken(ctx context.Context, token string) (content string) {
+	secret, err := es.getUnsubscribeSecret(ctx)
+	if err != nil {
+		log.Errorf("get unsubscribe secret failed: %v", err)
+		return ""
+	}
+	c, ok := parseUnsubscribeToken(secret, token, time.Now())
+	if !ok {
+		return ""
+	}
+	codeContent := &schema.EmailCodeContent{
+		SourceType:          schema.UnsubscribeSourceType,
+		UserID:              c.UserID,
+		NotificationSources: c.Sources,
+	}
+	return codeContent.ToJSONString()
+}
+
+// getUnsubscribeSecret get the secret that signs the unsubscribe tokens, it is generated when the site is
+// installed or upgraded, so all the instances share the same one
+func (es *EmailService) getUnsubscribeSecret(ctx context.Context) (secret string, err error) {
+	secret, err = es.configService.GetStringValue(ctx, constant.EmailUnsubscribeSecretKey)
+	if err != nil {
+		return "", err
+	}
+	if len(secret) == 0 {
+		return "", fmt.Errorf("the unsubscribe secret is not generated")
+	}
+	return secret, nil
+}
+
+func signUnsubscribeToken(secret string, c *unsubscribeTokenContent) string {
+	data, _ := json.Marshal(c)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + unsubscribeTokenSignature(secret, payload)
+}
+
+func parseUnsubscribeToken(secret, token string, now time.Time) (c *unsubscribeTokenContent, ok bool) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(unsubscribeTokenSignature(secret, payload))) {
+		return nil, false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, false
+	}
+	c = &unsubscribeTokenContent{}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, false
+	}
+	if len(c.UserID) == 0 || len(c.Sources) == 0 || now.Unix() > c.ExpiredAt {
+		return nil, false
+	}
+	return c, true
+}
+
+func unsubscribeTokenSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	now := time.Now()
+	content := &unsubscribeTokenContent{
+		UserID:    "1",
+		Sources:   []constant.NotificationSource{constant.CommentOnMyPostSource},
+		Nonce:     "nonce",
+		ExpiredAt: now.Add(time.Hour).Unix(),
+	}
+	token := signUnsubscribeToken("secret", content)
+
+	c, ok := parseUnsubscribeToken("secret", token, now)
+	assert.True(t, ok)
+	assert.Equal(t, content, c)
+
+	_, ok = parseUnsubscribeToken("other secret", token, now)
+	assert.False(t, ok, "signed by another secret")
+
+	_, ok = parseUnsubscribeToken("secret", token, now.Add(2*time.Hour))
+	assert.False(t, ok, "expired")
+
+	payload, signature, _ := strings.Cut(token, ".")
+	tampered := signUnsubscribeToken("secret", &unsubscribeTokenContent{
+		UserID: "2", Sources: content.Sources, ExpiredAt: content.ExpiredAt})
+	tamperedPayload, _, _ := strings.Cut(tampered, ".")
+	_, ok = parseUnsubscribeToken("secret", tamperedPayload+"."+signature, now)
+	assert.False(t, ok, "payload changed")
+
+	_, ok = parseUnsubscribeToken("secret", payload, now)
+	assert.False(t, ok, "signature missing")
+}
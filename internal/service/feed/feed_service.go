@@ -0,0 +1,306 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package feed
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/feed"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// FeedService feed service
+type FeedService struct {
+	questionRepo     questioncommon.QuestionRepo
+	answerRepo       answercommon.AnswerRepo
+	tagCommonService *tagcommon.TagCommonService
+	userCommon       *usercommon.UserCommon
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+}
+
+// NewFeedService new feed service
+func NewFeedService(
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *FeedService {
+	return &FeedService{
+		questionRepo:     questionRepo,
+		answerRepo:       answerRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+		siteInfoService:  siteInfoService,
+	}
+}
+
+// feedSite the site settings used to build the feed
+type feedSite struct {
+	general  *schema.SiteGeneralResp
+	seo      *schema.SiteSeoResp
+	security *schema.SiteSecurityResp
+}
+
+// GetFeed get the feed of the newest questions, the questions of the tag or the activities of the user
+func (fs *FeedService) GetFeed(ctx context.Context, req *schema.GetFeedReq) (resp *feed.Feed, err error) {
+	site, err := fs.getFeedSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := site.seo.GetFeedItemLimit()
+	resp = &feed.Feed{
+		Title:       site.general.Name,
+		Link:        site.general.SiteUrl,
+		SelfLink:    fs.feedSelfLink(site, req),
+		Description: site.general.Description,
+	}
+
+	var items []*feed.Item
+	switch {
+	case len(req.TagName) > 0:
+		tagInfo, exist, err := fs.tagCommonService.GetTagBySlugName(ctx, strings.ToLower(req.TagName))
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.NotFound(reason.TagNotFound)
+		}
+		if tagInfo.MainTagID > 0 {
+			tagInfo, exist, err = fs.tagCommonService.GetTagByID(ctx, converter.IntToString(tagInfo.MainTagID))
+			if err != nil {
+				return nil, err
+			}
+			if !exist {
+				return nil, errors.NotFound(reason.TagNotFound)
+			}
+		}
+		tagIDs, err := fs.tagCommonService.GetTagIDsByMainTagID(ctx, tagInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, tagInfo.ID)
+		resp.Title = tagInfo.DisplayName + " - " + site.general.Name
+		resp.Link = site.general.SiteUrl + "/tags/" + tagInfo.SlugName
+		items, err = fs.getQuestionItems(ctx, site, tagIDs, "", limit)
+		if err != nil {
+			return nil, err
+		}
+	case len(req.Username) > 0:
+		userInfo, exist, err := fs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.NotFound(reason.UserNotFound)
+		}
+		resp.Title = userInfo.DisplayName + " - " + site.general.Name
+		resp.Link = display.UserURL(site.general.SiteUrl, userInfo.Username)
+		items, err = fs.getUserActivityItems(ctx, site, userInfo.ID, limit)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		items, err = fs.getQuestionItems(ctx, site, nil, "", limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp.Items = items
+	for _, item := range items {
+		if item.Updated.After(resp.Updated) {
+			resp.Updated = item.Updated
+		}
+	}
+	return resp, nil
+}
+
+func (fs *FeedService) getFeedSite(ctx context.Context) (site *feedSite, err error) {
+	site = &feedSite{}
+	if site.general, err = fs.siteInfoService.GetSiteGeneral(ctx); err != nil {
+		return nil, err
+	}
+	if site.seo, err = fs.siteInfoService.GetSiteSeo(ctx); err != nil {
+		return nil, err
+	}
+	if site.security, err = fs.siteInfoService.GetSiteSecurity(ctx); err != nil {
+		return nil, err
+	}
+	return site, nil
+}
+
+func (fs *FeedService) feedSelfLink(site *feedSite, req *schema.GetFeedReq) string {
+	link := site.general.SiteUrl + "/feed/" + req.Format
+	switch {
+	case len(req.TagName) > 0:
+		return link + "/tags/" + req.TagName
+	case len(req.Username) > 0:
+		return link + "/users/" + req.Username
+	default:
+		return link + "/questions"
+	}
+}
+
+// getQuestionItems get the newest questions as the feed items
+func (fs *FeedService) getQuestionItems(ctx context.Context, site *feedSite, tagIDs []string, userID string, limit int) (
+	items []*feed.Item, err error) {
+	questions, _, err := fs.questionRepo.GetQuestionPage(ctx, 1, limit, tagIDs, userID,
+		schema.QuestionOrderCondNewest, 0, false, false, "")
+	if err != nil {
+		return nil, err
+	}
+	// pinned questions are listed first, the feed is in chronological order
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].CreatedAt.After(questions[j].CreatedAt)
+	})
+
+	questionIDs := make([]string, 0, len(questions))
+	userIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+		userIDs = append(userIDs, question.UserID)
+	}
+	questionTags, err := fs.tagCommonService.BatchGetObjectTag(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	users, err := fs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items = make([]*feed.Item, 0, len(questions))
+	for _, question := range questions {
+		item := &feed.Item{
+			Title:   question.Title,
+			Link:    display.QuestionURL(site.seo.Permalink, site.general.SiteUrl, question.ID, question.Title),
+			Content: fs.feedContent(site, question.ParsedText),
+			Created: question.CreatedAt,
+			Updated: questionUpdatedAt(question),
+		}
+		if user, ok := users[question.UserID]; ok {
+			item.Author = user.DisplayName
+		}
+		for _, tag := range questionTags[question.ID] {
+			item.Categories = append(item.Categories, tag.SlugName)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// getUserActivityItems get the newest questions and answers of the user as the feed items
+func (fs *FeedService) getUserActivityItems(ctx context.Context, site *feedSite, userID string, limit int) (
+	items []*feed.Item, err error) {
+	items, err = fs.getQuestionItems(ctx, site, nil, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, _, err := fs.answerRepo.GetPersonalAnswerPage(ctx, &entity.PersonalAnswerPageQueryCond{
+		Page:     1,
+		PageSize: limit,
+		UserID:   userID,
+		Order:    entity.AnswerSearchOrderByTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	questionIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		questionIDs = append(questionIDs, answer.QuestionID)
+	}
+	questions, err := fs.questionRepo.FindByID(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	questionMapping := make(map[string]*entity.Question, len(questions))
+	for _, question := range questions {
+		questionMapping[uid.DeShortID(question.ID)] = question
+	}
+	users, err := fs.userCommon.BatchUserBasicInfoByID(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, answer := range answers {
+		question, ok := questionMapping[uid.DeShortID(answer.QuestionID)]
+		// the answer of the hidden or deleted question is not in the feed
+		if !ok || question.Show != entity.QuestionShow ||
+			(question.Status != entity.QuestionStatusAvailable && question.Status != entity.QuestionStatusClosed) {
+			continue
+		}
+		item := &feed.Item{
+			Title: question.Title,
+			Link: display.AnswerURL(site.seo.Permalink, site.general.SiteUrl,
+				question.ID, question.Title, answer.ID),
+			Content: fs.feedContent(site, answer.ParsedText),
+			Created: answer.CreatedAt,
+			Updated: answer.UpdatedAt,
+		}
+		if item.Updated.Before(item.Created) {
+			item.Updated = item.Created
+		}
+		if user, ok := users[userID]; ok {
+			item.Author = user.DisplayName
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Created.After(items[j].Created)
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// feedContent the external images are only links when the site asks before display the external content
+func (fs *FeedService) feedContent(site *feedSite, html string) string {
+	if site.security.ExternalContentDisplay == "ask_before_display" {
+		return htmltext.ReplaceExternalImages(html, site.general.SiteUrl)
+	}
+	return html
+}
+
+func questionUpdatedAt(question *entity.Question) (updatedAt time.Time) {
+	updatedAt = question.CreatedAt
+	if question.UpdatedAt.After(updatedAt) {
+		updatedAt = question.UpdatedAt
+	}
+	return updatedAt
+}
@@ -182,16 +182,46 @@ func (fs *FileRecordService) DeleteAndMoveFileRecord(ctx context.Context, fileRe
 		return fmt.Errorf("delete file record error: %v", err)
 	}
 
+	if err := fs.deleteAndMoveFile(ctx, fileRecord.FilePath); err != nil {
+		return err
+	}
+	// The resized variants of the post image are deleted with it, they do not exist if the image is small
+	if strings.HasPrefix(fileRecord.FilePath, constant.PostSubPath+"/") {
+		for _, size := range constant.ImageVariants {
+			variantPath := constant.ImageVariantSubPath(fileRecord.FilePath, size)
+			if err := fs.deleteAndMoveFile(ctx, variantPath); err != nil {
+				log.Debugf("delete image variant %s: %v", variantPath, err)
+			}
+		}
+	}
+
+	log.Debugf("delete and move file: %s", fileRecord.FileURL)
+	return nil
+}
+
+func (fs *FileRecordService) deleteAndMoveFile(ctx context.Context, filePath string) error {
 	// Move the file to the deleted directory
-	oldFilename := filepath.Base(fileRecord.FilePath)
-	oldFilePath := filepath.Join(fs.serviceConfig.UploadPath, fileRecord.FilePath)
+	oldFilename := filepath.Base(filePath)
+	oldFilePath := filepath.Join(fs.serviceConfig.UploadPath, filePath)
 	deletedPath := filepath.Join(fs.serviceConfig.UploadPath, constant.DeletedSubPath, oldFilename)
 
+	// The file in the object storage is deleted directly, it is not on the local disk
+	if !dir.CheckFileExist(oldFilePath) {
+		storage, err := fs.siteInfoService.GetSiteStorage(ctx)
+		if err != nil {
+			return err
+		}
+		if storage.IsObjectStorage() {
+			if err := storage.NewClient().DeleteObject(ctx, storage.ObjectKey(filePath)); err != nil {
+				return fmt.Errorf("delete object error: %v", err)
+			}
+			return nil
+		}
+	}
+
 	if err := writer.MoveFile(oldFilePath, deletedPath); err != nil {
 		return fmt.Errorf("move file error: %v", err)
 	}
-
-	log.Debugf("delete and move file: %s", fileRecord.FileURL)
 	return nil
 }
 
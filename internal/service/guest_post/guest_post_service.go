@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package guest_post
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/export"
+	metacommon "github.com/apache/answer/internal/service/meta_common"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// GuestPostRepo guest post repository
+type GuestPostRepo interface {
+	AcquireGuestUser(ctx context.Context) (userID string, err error)
+	ChangeQuestionAuthor(ctx context.Context, questionID, guestUserID, userID string) (changed bool, err error)
+}
+
+// GuestPostService the questions asked by the guests without logging in. They are owned by the guest
+// placeholder and always held for review, the guest can claim the question by the link in the email
+// sent when the question is approved.
+type GuestPostService struct {
+	guestPostRepo     GuestPostRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	metaCommonService *metacommon.MetaCommonService
+	emailService      *export.EmailService
+	questionRepo      questioncommon.QuestionRepo
+	userCommon        *usercommon.UserCommon
+}
+
+// NewGuestPostService new guest post service
+func NewGuestPostService(
+	guestPostRepo GuestPostRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	metaCommonService *metacommon.MetaCommonService,
+	emailService *export.EmailService,
+	questionRepo questioncommon.QuestionRepo,
+	userCommon *usercommon.UserCommon,
+) *GuestPostService {
+	return &GuestPostService{
+		guestPostRepo:     guestPostRepo,
+		siteInfoService:   siteInfoService,
+		metaCommonService: metaCommonService,
+		emailService:      emailService,
+		questionRepo:      questionRepo,
+		userCommon:        userCommon,
+	}
+}
+
+// CheckGuestPostingEnabled the guests can post only if it is enabled and a captcha is available to stop the bots
+func (gs *GuestPostService) CheckGuestPostingEnabled(ctx context.Context) (err error) {
+	config, err := gs.siteInfoService.GetSiteGuestPosting(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.Enabled {
+		return errors.Forbidden(reason.GuestPostingDisabled)
+	}
+	if plugin.CaptchaEnabled() {
+		return nil
+	}
+	captchaConfig, err := gs.siteInfoService.GetSiteCaptcha(ctx)
+	if err != nil {
+		return err
+	}
+	if !captchaConfig.IsSceneEnabled(constant.CaptchaSceneGuestPost) {
+		return errors.Forbidden(reason.GuestPostingDisabled)
+	}
+	return nil
+}
+
+// GetGuestUserID get the id of the guest placeholder
+func (gs *GuestPostService) GetGuestUserID(ctx context.Context) (userID string, err error) {
+	return gs.guestPostRepo.AcquireGuestUser(ctx)
+}
+
+// SaveGuestInfo save the contact of the guest who asks the question
+func (gs *GuestPostService) SaveGuestInfo(ctx context.Context, questionID, email, ip string) (err error) {
+	content, _ := json.Marshal(&schema.GuestQuestionMeta{Email: email, IP: ip})
+	return gs.metaCommonService.AddMeta(ctx, uid.DeShortID(questionID), entity.GuestQuestionKey, string(content))
+}
+
+// getGuestInfo get the contact of the guest, nil if the question is not asked by a guest or has been claimed
+func (gs *GuestPostService) getGuestInfo(ctx context.Context, questionID string) (
+	metaID int, info *schema.GuestQuestionMeta) {
+	meta, err := gs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionID, entity.GuestQuestionKey)
+	if err != nil {
+		return 0, nil
+	}
+	info = &schema.GuestQuestionMeta{}
+	if err = json.Unmarshal([]byte(meta.Value), info); err != nil {
+		log.Errorf("parse guest question meta failed, question id: %s, err: %v", questionID, err)
+		return 0, nil
+	}
+	return meta.ID, info
+}
+
+// SendClaimEmail tell the guest the question is approved, nothing is sent if the guest left no email
+func (gs *GuestPostService) SendClaimEmail(ctx context.Context, question *entity.Question) {
+	_, info := gs.getGuestInfo(ctx, question.ID)
+	if info == nil || len(info.Email) == 0 {
+		return
+	}
+	siteGeneral, err := gs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Errorf("get site general failed, err: %v", err)
+		return
+	}
+
+	data := &schema.EmailCodeContent{
+		SourceType:               schema.GuestQuestionClaimSourceType,
+		Email:                    info.Email,
+		UserID:                   question.UserID,
+		QuestionID:               question.ID,
+		SkipValidationLatestCode: true,
+	}
+	code := token.GenerateToken()
+	claimURL := fmt.Sprintf("%s/users/claim-question?code=%s", siteGeneral.SiteUrl, code)
+	title, body, err := gs.emailService.GuestQuestionClaimTemplate(ctx, question.Title, claimURL)
+	if err != nil {
+		log.Errorf("get guest question claim email template failed, err: %v", err)
+		return
+	}
+	go gs.emailService.SendAndSaveCodeWithTime(ctx, question.UserID, info.Email, title, body, code,
+		data.ToJSONString(), constant.GuestQuestionClaimCodeCacheTime)
+}
+
+// ClaimQuestion the user claims the question asked as a guest by the code in the email
+func (gs *GuestPostService) ClaimQuestion(ctx context.Context, req *schema.ClaimGuestQuestionReq) (
+	resp *schema.ClaimGuestQuestionResp, err error) {
+	data := &schema.EmailCodeContent{}
+	content := gs.emailService.VerifyUrlExpired(ctx, req.Code)
+	if len(content) == 0 || data.FromJSONString(content) != nil ||
+		data.SourceType != schema.GuestQuestionClaimSourceType || len(data.QuestionID) == 0 {
+		return nil, errors.BadRequest(reason.GuestQuestionClaimInvalid)
+	}
+
+	guestUserID, err := gs.guestPostRepo.AcquireGuestUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := gs.guestPostRepo.ChangeQuestionAuthor(ctx, data.QuestionID, guestUserID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return nil, errors.BadRequest(reason.GuestQuestionAlreadyClaimed)
+	}
+
+	if metaID, _ := gs.getGuestInfo(ctx, data.QuestionID); metaID > 0 {
+		if err = gs.metaCommonService.RemoveMeta(ctx, metaID); err != nil {
+			log.Errorf("remove guest question meta failed, err: %v", err)
+		}
+	}
+	for _, userID := range []string{guestUserID, req.UserID} {
+		count, err := gs.questionRepo.GetUserQuestionCount(ctx, userID, 0)
+		if err != nil {
+			log.Errorf("get user question count failed, err: %v", err)
+			continue
+		}
+		if err = gs.userCommon.UpdateQuestionCount(ctx, userID, count); err != nil {
+			log.Errorf("update user question count failed, err: %v", err)
+		}
+	}
+	if err = gs.questionRepo.RemoveQuestionPayloadCache(ctx, data.QuestionID); err != nil {
+		log.Errorf("remove question payload cache failed, err: %v", err)
+	}
+	if err = gs.questionRepo.UpdateSearch(ctx, data.QuestionID); err != nil {
+		log.Errorf("update question search failed, err: %v", err)
+	}
+
+	resp = &schema.ClaimGuestQuestionResp{QuestionID: data.QuestionID}
+	if handler.GetEnableShortID(ctx) {
+		resp.QuestionID = uid.EnShortID(data.QuestionID)
+	}
+	return resp, nil
+}
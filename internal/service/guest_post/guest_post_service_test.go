@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package guest_post
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuestPostService_CheckGuestPostingEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *schema.SiteGuestPostingResp
+		captcha  *schema.SiteCaptchaResp
+		expected string
+	}{
+		{name: "disabled", config: &schema.SiteGuestPostingResp{}, expected: reason.GuestPostingDisabled},
+		{name: "no captcha", config: &schema.SiteGuestPostingResp{Enabled: true},
+			captcha: &schema.SiteCaptchaResp{}, expected: reason.GuestPostingDisabled},
+		{name: "captcha without the secret key", config: &schema.SiteGuestPostingResp{Enabled: true},
+			captcha: &schema.SiteCaptchaResp{Enabled: true, SiteKey: "site"}, expected: reason.GuestPostingDisabled},
+		{name: "enabled with captcha", config: &schema.SiteGuestPostingResp{Enabled: true},
+			captcha: &schema.SiteCaptchaResp{Enabled: true, SiteKey: "site", SecretKey: "secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+			siteInfoService.EXPECT().GetSiteGuestPosting(gomock.Any()).Return(tt.config, nil)
+			if tt.captcha != nil {
+				siteInfoService.EXPECT().GetSiteCaptcha(gomock.Any()).Return(tt.captcha, nil)
+			}
+			gs := NewGuestPostService(nil, siteInfoService, nil, nil, nil, nil)
+
+			err := gs.CheckGuestPostingEnabled(context.TODO())
+			if len(tt.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tt.expected, err.(*errors.Error).Reason)
+		})
+	}
+}
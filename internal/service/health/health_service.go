@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// checkTimeout the slow dependency is reported as timeout, the probe does not wait for it
+	checkTimeout = 2 * time.Second
+	// readinessCacheTime the result is reused by the probes in a short time, so that probing frequently is cheap
+	readinessCacheTime = 2 * time.Second
+)
+
+// errSkipped the dependency is not used
+var errSkipped = errors.New("skipped")
+
+// HealthService check whether the dependencies are ready
+type HealthService struct {
+	data *data.Data
+
+	mu        sync.Mutex
+	last      *schema.ReadinessResp
+	checkedAt time.Time
+}
+
+// NewHealthService new health service
+func NewHealthService(data *data.Data) *HealthService {
+	return &HealthService{data: data}
+}
+
+// CheckReadiness check the database, the cache and the search backend at the same time.
+// The details of the failures are only logged, because the probe is not authenticated.
+func (hs *HealthService) CheckReadiness(ctx context.Context) *schema.ReadinessResp {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.last != nil && time.Since(hs.checkedAt) < readinessCacheTime {
+		return hs.last
+	}
+
+	checks := map[string]func(ctx context.Context) error{
+		schema.HealthCheckDatabase: func(ctx context.Context) error {
+			return data.PingDB(ctx, hs.data.DB)
+		},
+		schema.HealthCheckCache: func(ctx context.Context) error {
+			return data.PingCache(ctx, hs.data.Cache)
+		},
+		schema.HealthCheckSearch: checkSearch,
+	}
+	resp := &schema.ReadinessResp{Checks: make(map[string]*schema.DependencyHealth, len(checks))}
+	var (
+		wg sync.WaitGroup
+		rw sync.Mutex
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := runCheck(ctx, name, check)
+			rw.Lock()
+			resp.Checks[name] = result
+			rw.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	resp.Status = schema.HealthStatusOK
+	if !resp.IsReady() {
+		resp.Status = schema.HealthStatusUnavailable
+	}
+	hs.last, hs.checkedAt = resp, time.Now()
+	return resp
+}
+
+// runCheck run the check with the timeout, it returns when the timeout is reached even if the check ignores it
+func runCheck(ctx context.Context, name string, check func(ctx context.Context) error) *schema.DependencyHealth {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), checkTimeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	result := &schema.DependencyHealth{}
+	select {
+	case err := <-done:
+		switch {
+		case err == nil:
+			result.Status = schema.HealthStatusOK
+		case errors.Is(err, errSkipped):
+			result.Status = schema.HealthStatusSkipped
+		case errors.Is(err, context.DeadlineExceeded):
+			result.Status = schema.HealthStatusTimeout
+		default:
+			log.Warnf("readiness check of %s failed, err: %v", name, err)
+			result.Status = schema.HealthStatusUnavailable
+		}
+	case <-ctx.Done():
+		result.Status = schema.HealthStatusTimeout
+	}
+	if result.Status == schema.HealthStatusTimeout {
+		log.Warnf("readiness check of %s timeout", name)
+	}
+	result.Duration = time.Since(start).Milliseconds()
+	return result
+}
+
+// checkSearch the built-in search uses the database, only the search plugin is checked
+func checkSearch(ctx context.Context) (err error) {
+	err = errSkipped
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		if checker, ok := search.(plugin.SearchHealthChecker); ok {
+			err = checker.CheckHealth(ctx)
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func TestRunCheck(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, schema.HealthStatusOK, runCheck(ctx, "ok", func(ctx context.Context) error {
+		return nil
+	}).Status)
+	assert.Equal(t, schema.HealthStatusSkipped, runCheck(ctx, "skipped", func(ctx context.Context) error {
+		return errSkipped
+	}).Status)
+	assert.Equal(t, schema.HealthStatusUnavailable, runCheck(ctx, "failed", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}).Status)
+
+	// the check that ignores the context does not hang the probe
+	start := time.Now()
+	blocked := make(chan struct{})
+	defer close(blocked)
+	result := runCheck(ctx, "hang", func(ctx context.Context) error {
+		<-blocked
+		return nil
+	})
+	assert.Equal(t, schema.HealthStatusTimeout, result.Status)
+	assert.Less(t, time.Since(start), checkTimeout+time.Second)
+}
+
+func TestCheckReadiness(t *testing.T) {
+	engine, err := xorm.NewEngine("sqlite", ":memory:")
+	require.NoError(t, err)
+	d, _, err := data.NewData(engine, nil, memory.NewCache())
+	require.NoError(t, err)
+	hs := NewHealthService(d)
+
+	resp := hs.CheckReadiness(context.Background())
+	assert.True(t, resp.IsReady())
+	assert.Equal(t, schema.HealthStatusOK, resp.Status)
+	assert.Equal(t, schema.HealthStatusOK, resp.Checks[schema.HealthCheckDatabase].Status)
+	assert.Equal(t, schema.HealthStatusOK, resp.Checks[schema.HealthCheckCache].Status)
+	assert.Equal(t, schema.HealthStatusSkipped, resp.Checks[schema.HealthCheckSearch].Status)
+
+	// the result is reused in a short time, then the closed database is found
+	_ = engine.Close()
+	assert.Same(t, resp, hs.CheckReadiness(context.Background()))
+	hs.checkedAt = time.Now().Add(-readinessCacheTime)
+	resp = hs.CheckReadiness(context.Background())
+	assert.False(t, resp.IsReady())
+	assert.Equal(t, schema.HealthStatusUnavailable, resp.Status)
+	assert.Equal(t, schema.HealthStatusUnavailable, resp.Checks[schema.HealthCheckDatabase].Status)
+}
@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package impersonation
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ImpersonationService the admins view as the users to support them, the impersonation sessions are
+// flagged, limited by the site security config and recorded in the audit log
+type ImpersonationService struct {
+	authService        *auth.AuthService
+	userCommon         *usercommon.UserCommon
+	userRoleRelService *role.UserRoleRelService
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	auditLogService    *audit_log.AuditLogService
+}
+
+// NewImpersonationService new impersonation service
+func NewImpersonationService(
+	authService *auth.AuthService,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *ImpersonationService {
+	return &ImpersonationService{
+		authService:        authService,
+		userCommon:         userCommon,
+		userRoleRelService: userRoleRelService,
+		siteInfoService:    siteInfoService,
+		auditLogService:    auditLogService,
+	}
+}
+
+// StartImpersonation start a session of the user for the admin, the admins can not view as themselves or
+// the other admins
+func (is *ImpersonationService) StartImpersonation(ctx context.Context, req *schema.StartImpersonationReq) (
+	resp *schema.StartImpersonationResp, err error) {
+	siteSecurity, err := is.siteInfoService.GetSiteSecurity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mode := siteSecurity.GetImpersonationMode()
+	if mode == constant.ImpersonationModeDisabled {
+		return nil, errors.Forbidden(reason.ImpersonationDisabled)
+	}
+	if req.UserID == req.AdminUserID {
+		return nil, errors.BadRequest(reason.ImpersonationNotAllowed)
+	}
+
+	userCacheInfo, exist, err := is.userCommon.GetUserCacheInfoByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userCacheInfo.UserStatus == entity.UserStatusDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if is.userRoleRelService.CheckRolePower(ctx, userCacheInfo.RoleID, permission.AdminAccess) {
+		return nil, errors.BadRequest(reason.ImpersonationNotAllowed)
+	}
+	userInfo, _, err := is.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	userCacheInfo.ImpersonatorID = req.AdminUserID
+	userCacheInfo.ImpersonationMode = mode
+	accessToken, visitToken, err := is.authService.SetUserCacheInfo(ctx, userCacheInfo)
+	if err != nil {
+		return nil, err
+	}
+	is.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.AdminUserID,
+		Action:     constant.AuditActionUserImpersonateStart,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		After:      map[string]any{"mode": mode, "expire_at": userCacheInfo.ExpireAt},
+	})
+	return &schema.StartImpersonationResp{
+		AccessToken: accessToken,
+		VisitToken:  visitToken,
+		Mode:        mode,
+		ExpireAt:    userCacheInfo.ExpireAt,
+		UserInfo:    *userInfo,
+	}, nil
+}
+
+// StopImpersonation end the impersonation session, the token of it can not be used anymore
+func (is *ImpersonationService) StopImpersonation(ctx context.Context, req *schema.StopImpersonationReq) (err error) {
+	if len(req.ImpersonatorID) == 0 {
+		return errors.BadRequest(reason.ImpersonationNotInSession)
+	}
+	if err = is.authService.RemoveUserCacheInfo(ctx, req.AccessToken); err != nil {
+		return err
+	}
+	if err = is.authService.RemoveUserVisitCacheInfo(ctx, req.VisitToken); err != nil {
+		log.Errorf("remove visit token of impersonation session failed, err: %v", err)
+	}
+	is.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.ImpersonatorID,
+		Action:     constant.AuditActionUserImpersonateStop,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		Before:     map[string]any{"mode": req.Mode},
+	})
+	return nil
+}
@@ -0,0 +1,355 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/service_config"
+	"github.com/segmentfault/pacman/log"
+)
+
+// JobRepo job repository
+type JobRepo interface {
+	AddJob(ctx context.Context, job *entity.Job) (err error)
+	// AcquireJobs lock the due jobs of the types until the time, the attempts of them are increased
+	AcquireJobs(ctx context.Context, types []string, limit int, lockUntil time.Time) (jobs []*entity.Job, err error)
+	DeleteJob(ctx context.Context, id int64) (err error)
+	RetryJob(ctx context.Context, id int64, runAt time.Time, lastError string) (err error)
+	// MoveToDeadLetter add the dead letter of the job and delete the job
+	MoveToDeadLetter(ctx context.Context, job *entity.Job, lastError string) (err error)
+	GetJobStats(ctx context.Context) (stats []*entity.JobStat, err error)
+	GetDeadLetterStats(ctx context.Context) (stats []*entity.JobStat, err error)
+	GetDeadLetterPage(ctx context.Context, page, pageSize int, jobType string) (
+		deadLetters []*entity.JobDeadLetter, total int64, err error)
+}
+
+// Handler handle the payload of the job, the job is retried later if an error is returned
+type Handler func(ctx context.Context, payload []byte) error
+
+// Options the options of the job type
+type Options struct {
+	// MaxAttempts the job is moved to the dead letters after these attempts, 0 means 5
+	MaxAttempts int
+	// OnDeadLetter is called after the job is moved to the dead letters
+	OnDeadLetter func(ctx context.Context, payload []byte, attempts int, err error)
+}
+
+type registration struct {
+	handler Handler
+	options Options
+}
+
+// permanentError the job will not succeed by retrying
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent mark the error of the job as permanent, the job is moved to the dead letters without retrying
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Service the background job queue persisted in the database. The jobs survive the restarts and are retried
+// with exponential backoff, the jobs failed after all the attempts are moved to the dead letters.
+type Service struct {
+	jobRepo     JobRepo
+	concurrency int
+
+	mu       sync.RWMutex
+	handlers map[string]*registration
+
+	running atomic.Int64
+	wake    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+}
+
+// NewService new job queue service, the workers start at once and only run the jobs of the registered types
+func NewService(jobRepo JobRepo, serviceConfig *service_config.ServiceConfig) *Service {
+	concurrency := constant.JobDefaultConcurrency
+	if serviceConfig != nil && serviceConfig.JobConcurrency > 0 {
+		concurrency = serviceConfig.JobConcurrency
+	}
+	s := &Service{
+		jobRepo:     jobRepo,
+		concurrency: concurrency,
+		handlers:    make(map[string]*registration),
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	s.wg.Go(s.run)
+	return s
+}
+
+// Register set the handler of the job type
+func (s *Service) Register(jobType string, handler Handler, options *Options) {
+	reg := &registration{handler: handler}
+	if options != nil {
+		reg.options = *options
+	}
+	if reg.options.MaxAttempts <= 0 {
+		reg.options.MaxAttempts = constant.JobDefaultMaxAttempts
+	}
+	s.mu.Lock()
+	s.handlers[jobType] = reg
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Register set the handler of the job type whose payload is the json of T
+func Register[T any](s *Service, jobType string, handler func(ctx context.Context, msg *T) error, options *Options) {
+	s.Register(jobType, func(ctx context.Context, payload []byte) error {
+		msg := new(T)
+		if err := json.Unmarshal(payload, msg); err != nil {
+			return Permanent(err)
+		}
+		return handler(ctx, msg)
+	}, options)
+}
+
+// NewJob new job of the type whose payload is the json of the msg, it runs as soon as possible
+func NewJob(jobType string, msg any) (job *entity.Job, err error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Job{
+		Type:    jobType,
+		Payload: string(payload),
+		Status:  entity.JobStatusPending,
+		RunAt:   time.Now(),
+	}, nil
+}
+
+// Enqueue add the job of the type whose payload is the json of the msg
+func (s *Service) Enqueue(ctx context.Context, jobType string, msg any) (err error) {
+	job, err := NewJob(jobType, msg)
+	if err != nil {
+		return err
+	}
+	if err = s.jobRepo.AddJob(ctx, job); err != nil {
+		return err
+	}
+	s.notify()
+	return nil
+}
+
+// Close stop the workers and wait for the running jobs, the jobs not run are kept in the database
+func (s *Service) Close() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+	s.wg.Wait()
+}
+
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(constant.JobPollInterval)
+	defer ticker.Stop()
+	for {
+		s.dispatch()
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// dispatch acquire the due jobs as many as the free workers and run them
+func (s *Service) dispatch() {
+	free := s.concurrency - int(s.running.Load())
+	if free <= 0 {
+		return
+	}
+	s.mu.RLock()
+	types := slices.Sorted(maps.Keys(s.handlers))
+	s.mu.RUnlock()
+	if len(types) == 0 {
+		return
+	}
+	jobs, err := s.jobRepo.AcquireJobs(context.Background(), types, free, time.Now().Add(constant.JobLockTimeout))
+	if err != nil {
+		log.Errorf("acquire jobs failed: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		s.running.Add(1)
+		s.wg.Go(func() {
+			defer s.running.Add(-1)
+			s.runJob(job)
+			s.notify()
+		})
+	}
+}
+
+func (s *Service) runJob(job *entity.Job) {
+	s.mu.RLock()
+	reg := s.handlers[job.Type]
+	s.mu.RUnlock()
+	if reg == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constant.JobTimeout)
+	defer cancel()
+	err := s.handle(ctx, reg, job)
+	if err == nil {
+		if err = s.jobRepo.DeleteJob(ctx, job.ID); err != nil {
+			log.Errorf("delete job %d failed: %v", job.ID, err)
+		}
+		return
+	}
+
+	var permanent *permanentError
+	if job.Attempts < reg.options.MaxAttempts && !errors.As(err, &permanent) {
+		log.Warnf("job %d of %s failed at attempt %d, err: %v", job.ID, job.Type, job.Attempts, err)
+		if err = s.jobRepo.RetryJob(ctx, job.ID, time.Now().Add(retryDelay(job.Attempts)), err.Error()); err != nil {
+			log.Errorf("retry job %d failed: %v", job.ID, err)
+		}
+		return
+	}
+	log.Errorf("job %d of %s failed after %d attempts, err: %v", job.ID, job.Type, job.Attempts, err)
+	if moveErr := s.jobRepo.MoveToDeadLetter(ctx, job, err.Error()); moveErr != nil {
+		log.Errorf("move job %d to dead letter failed: %v", job.ID, moveErr)
+		return
+	}
+	if reg.options.OnDeadLetter != nil {
+		reg.options.OnDeadLetter(ctx, []byte(job.Payload), job.Attempts, err)
+	}
+}
+
+// handle run the handler of the job, the panic of the handler fails the job
+func (s *Service) handle(ctx context.Context, reg *registration, job *entity.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return reg.handler(ctx, []byte(job.Payload))
+}
+
+// retryDelay the delay doubles for each attempt
+func retryDelay(attempts int) time.Duration {
+	delay := constant.JobRetryBaseDelay
+	for i := 1; i < attempts && delay < constant.JobRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, constant.JobRetryMaxDelay)
+}
+
+// GetQueueStatus get the amount of the jobs of each type
+func (s *Service) GetQueueStatus(ctx context.Context) (resp *schema.GetJobQueueStatusResp, err error) {
+	stats, err := s.jobRepo.GetJobStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadLetterStats, err := s.jobRepo.GetDeadLetterStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queues := make(map[string]*schema.JobQueueStatus)
+	getQueue := func(jobType string) *schema.JobQueueStatus {
+		if q, ok := queues[jobType]; ok {
+			return q
+		}
+		queues[jobType] = &schema.JobQueueStatus{Type: jobType}
+		return queues[jobType]
+	}
+	s.mu.RLock()
+	for jobType := range s.handlers {
+		getQueue(jobType)
+	}
+	s.mu.RUnlock()
+
+	resp = &schema.GetJobQueueStatusResp{}
+	for _, stat := range stats {
+		q := getQueue(stat.Type)
+		switch stat.Status {
+		case entity.JobStatusPending:
+			q.Pending += stat.Amount
+			q.Retrying += stat.Retrying
+			resp.Depth += stat.Amount
+		case entity.JobStatusRunning:
+			q.Running += stat.Amount
+		}
+	}
+	for _, stat := range deadLetterStats {
+		getQueue(stat.Type).DeadLetters += stat.Amount
+		resp.DeadLetters += stat.Amount
+	}
+	resp.Queues = make([]*schema.JobQueueStatus, 0, len(queues))
+	for _, jobType := range slices.Sorted(maps.Keys(queues)) {
+		resp.Queues = append(resp.Queues, queues[jobType])
+	}
+	return resp, nil
+}
+
+// GetDeadLetterPage get the jobs failed after all the attempts, the newest first
+func (s *Service) GetDeadLetterPage(ctx context.Context, req *schema.GetJobDeadLetterPageReq) (
+	resp *pager.PageModel, err error) {
+	deadLetters, total, err := s.jobRepo.GetDeadLetterPage(ctx, req.Page, req.PageSize, req.Type)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.GetJobDeadLetterResp, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		list = append(list, &schema.GetJobDeadLetterResp{
+			ID:        deadLetter.ID,
+			CreatedAt: deadLetter.CreatedAt.Unix(),
+			JobID:     deadLetter.JobID,
+			Type:      deadLetter.Type,
+			Payload:   deadLetter.Payload,
+			Attempts:  deadLetter.Attempts,
+			Error:     deadLetter.Error,
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryJobRepo struct {
+	mu          sync.Mutex
+	jobs        map[int64]*entity.Job
+	deadLetters []*entity.JobDeadLetter
+	lastID      int64
+}
+
+func newMemoryJobRepo() *memoryJobRepo {
+	return &memoryJobRepo{jobs: make(map[int64]*entity.Job)}
+}
+
+func (r *memoryJobRepo) AddJob(_ context.Context, job *entity.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	job.ID = r.lastID
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *memoryJobRepo) AcquireJobs(_ context.Context, types []string, limit int, lockUntil time.Time) (
+	[]*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := make([]*entity.Job, 0)
+	for _, job := range r.jobs {
+		if len(jobs) >= limit || job.Status != entity.JobStatusPending || job.RunAt.After(time.Now()) {
+			continue
+		}
+		for _, t := range types {
+			if t == job.Type {
+				job.Status, job.Attempts, job.LockedUntil = entity.JobStatusRunning, job.Attempts+1, lockUntil
+				copied := *job
+				jobs = append(jobs, &copied)
+			}
+		}
+	}
+	return jobs, nil
+}
+
+func (r *memoryJobRepo) DeleteJob(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, id)
+	return nil
+}
+
+func (r *memoryJobRepo) RetryJob(_ context.Context, id int64, runAt time.Time, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[id]; ok {
+		job.Status, job.RunAt, job.LastError = entity.JobStatusPending, runAt, lastError
+	}
+	return nil
+}
+
+func (r *memoryJobRepo) MoveToDeadLetter(_ context.Context, job *entity.Job, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deadLetters = append(r.deadLetters, &entity.JobDeadLetter{
+		JobID: job.ID, Type: job.Type, Payload: job.Payload, Attempts: job.Attempts, Error: lastError})
+	delete(r.jobs, job.ID)
+	return nil
+}
+
+func (r *memoryJobRepo) GetJobStats(context.Context) ([]*entity.JobStat, error) {
+	return nil, nil
+}
+
+func (r *memoryJobRepo) GetDeadLetterStats(context.Context) ([]*entity.JobStat, error) {
+	return nil, nil
+}
+
+func (r *memoryJobRepo) GetDeadLetterPage(context.Context, int, int, string) ([]*entity.JobDeadLetter, int64, error) {
+	return r.deadLetters, int64(len(r.deadLetters)), nil
+}
+
+func (r *memoryJobRepo) get(id int64) (job entity.Job, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if j, ok := r.jobs[id]; ok {
+		return *j, true
+	}
+	return job, false
+}
+
+// newTestService new service without the workers, the jobs are run by calling runJob directly
+func newTestService(repo JobRepo) *Service {
+	return &Service{jobRepo: repo, concurrency: 1, handlers: make(map[string]*registration), wake: make(chan struct{}, 1)}
+}
+
+func acquireOne(t *testing.T, repo *memoryJobRepo, jobType string) *entity.Job {
+	jobs, err := repo.AcquireJobs(context.TODO(), []string{jobType}, 1, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	return jobs[0]
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, constant.JobRetryBaseDelay, retryDelay(1))
+	assert.Equal(t, 2*constant.JobRetryBaseDelay, retryDelay(2))
+	assert.Equal(t, 4*constant.JobRetryBaseDelay, retryDelay(3))
+	assert.Equal(t, constant.JobRetryMaxDelay, retryDelay(100))
+}
+
+func TestService_RunJob(t *testing.T) {
+	type msg struct {
+		Value string `json:"value"`
+	}
+	repo := newMemoryJobRepo()
+	s := newTestService(repo)
+	var got string
+	Register(s, "test", func(ctx context.Context, m *msg) error {
+		got = m.Value
+		return nil
+	}, nil)
+	require.NoError(t, s.Enqueue(context.TODO(), "test", &msg{Value: "hello"}))
+
+	job := acquireOne(t, repo, "test")
+	s.runJob(job)
+	assert.Equal(t, "hello", got)
+	_, ok := repo.get(job.ID)
+	assert.False(t, ok)
+}
+
+func TestService_RunJob_Retry(t *testing.T) {
+	repo := newMemoryJobRepo()
+	s := newTestService(repo)
+	var deadLetterAttempts int
+	s.Register("test", func(ctx context.Context, payload []byte) error {
+		return errors.New("unavailable")
+	}, &Options{MaxAttempts: 2, OnDeadLetter: func(ctx context.Context, payload []byte, attempts int, err error) {
+		deadLetterAttempts = attempts
+	}})
+	require.NoError(t, s.Enqueue(context.TODO(), "test", map[string]string{}))
+
+	job := acquireOne(t, repo, "test")
+	s.runJob(job)
+	retried, ok := repo.get(job.ID)
+	require.True(t, ok)
+	assert.Equal(t, entity.JobStatusPending, retried.Status)
+	assert.Equal(t, "unavailable", retried.LastError)
+	assert.True(t, retried.RunAt.After(time.Now()))
+	assert.Empty(t, repo.deadLetters)
+
+	// the second attempt is the last one
+	repo.jobs[job.ID].RunAt = time.Now()
+	job = acquireOne(t, repo, "test")
+	s.runJob(job)
+	_, ok = repo.get(job.ID)
+	assert.False(t, ok)
+	require.Len(t, repo.deadLetters, 1)
+	assert.Equal(t, 2, repo.deadLetters[0].Attempts)
+	assert.Equal(t, 2, deadLetterAttempts)
+}
+
+func TestService_RunJob_Permanent(t *testing.T) {
+	repo := newMemoryJobRepo()
+	s := newTestService(repo)
+	s.Register("test", func(ctx context.Context, payload []byte) error {
+		return Permanent(errors.New("invalid payload"))
+	}, nil)
+	s.Register("panic", func(ctx context.Context, payload []byte) error {
+		panic("boom")
+	}, &Options{MaxAttempts: 1})
+	require.NoError(t, s.Enqueue(context.TODO(), "test", map[string]string{}))
+	require.NoError(t, s.Enqueue(context.TODO(), "panic", map[string]string{}))
+
+	s.runJob(acquireOne(t, repo, "test"))
+	s.runJob(acquireOne(t, repo, "panic"))
+	require.Len(t, repo.deadLetters, 2)
+	assert.Equal(t, 1, repo.deadLetters[0].Attempts)
+	assert.Equal(t, "invalid payload", repo.deadLetters[0].Error)
+	assert.Equal(t, "panic: boom", repo.deadLetters[1].Error)
+}
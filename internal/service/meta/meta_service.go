@@ -35,8 +35,10 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/comment_common"
 	metacommon "github.com/apache/answer/internal/service/meta_common"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/obj"
 	myErrors "github.com/segmentfault/pacman/errors"
@@ -48,6 +50,8 @@ type MetaService struct {
 	userCommon        *usercommon.UserCommon
 	questionRepo      questioncommon.QuestionRepo
 	answerRepo        answercommon.AnswerRepo
+	commentRepo       comment_common.CommentCommonRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
 	eventQueueService eventqueue.Service
 }
 
@@ -56,6 +60,8 @@ func NewMetaService(
 	userCommon *usercommon.UserCommon,
 	answerRepo answercommon.AnswerRepo,
 	questionRepo questioncommon.QuestionRepo,
+	commentRepo comment_common.CommentCommonRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
 	eventQueueService eventqueue.Service,
 ) *MetaService {
 	return &MetaService{
@@ -63,6 +69,8 @@ func NewMetaService(
 		questionRepo:      questionRepo,
 		userCommon:        userCommon,
 		answerRepo:        answerRepo,
+		commentRepo:       commentRepo,
+		siteInfoService:   siteInfoService,
 		eventQueueService: eventQueueService,
 	}
 }
@@ -89,8 +97,19 @@ func (ms *MetaService) GetReactionByObjectId(ctx context.Context, req *schema.Ge
 	return ms.convertToReactionResp(ctx, req.UserID, &reaction)
 }
 
-// AddOrUpdateReaction add or update reaction
+// AddOrUpdateReaction add or update reaction, the reactions never change the votes or the reputation.
+// The users can always remove their reaction even if the emoji is not allowed anymore.
 func (ms *MetaService) AddOrUpdateReaction(ctx context.Context, req *schema.UpdateReactionReq) (resp *schema.GetReactionByObjectIdResp, err error) {
+	if req.Reaction == "activate" {
+		reactionConf, err := ms.siteInfoService.GetSiteReaction(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !reactionConf.IsEmojiAllowed(req.Emoji) {
+			return nil, myErrors.BadRequest(reason.MetaReactionEmojiNotAllowed)
+		}
+	}
+
 	// check if object exist and it's answer or question
 	objectType, err := obj.GetObjectTypeStrByObjectID(req.ObjectID)
 	if err != nil {
@@ -118,6 +137,16 @@ func (ms *MetaService) AddOrUpdateReaction(ctx context.Context, req *schema.Upda
 		}
 		event = schema.NewEvent(constant.EventQuestionReact, req.UserID).TID(questionInfo.ID).
 			QID(questionInfo.ID, questionInfo.UserID)
+	case constant.CommentObjectType:
+		commentInfo, exist, err := ms.commentRepo.GetComment(ctx, req.ObjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, myErrors.BadRequest(reason.CommentNotFound)
+		}
+		event = schema.NewEvent(constant.EventCommentReact, req.UserID).TID(commentInfo.ID).
+			CID(commentInfo.ID, commentInfo.UserID)
 	default:
 		return nil, myErrors.BadRequest(reason.ObjectNotFound)
 	}
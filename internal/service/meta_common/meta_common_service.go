@@ -35,6 +35,7 @@ type MetaRepo interface {
 	AddOrUpdateMetaByObjectIdAndKey(ctx context.Context, objectId, key string, f func(*entity.Meta, bool) (*entity.Meta, error)) error
 	GetMetaByObjectIdAndKey(ctx context.Context, objectId, key string) (meta *entity.Meta, exist bool, err error)
 	GetMetaList(ctx context.Context, meta *entity.Meta) (metas []*entity.Meta, err error)
+	GetMetaListByObjectIDsAndKey(ctx context.Context, objectIDs []string, key string) (metas []*entity.Meta, err error)
 }
 
 // MetaCommonService user service
@@ -89,6 +90,12 @@ func (ms *MetaCommonService) GetMetaByObjectIdAndKey(ctx context.Context, object
 	return meta, nil
 }
 
+// GetMetaListByObjectIDsAndKey get the metas of the objects with the key
+func (ms *MetaCommonService) GetMetaListByObjectIDsAndKey(ctx context.Context, objectIDs []string, key string) (
+	metas []*entity.Meta, err error) {
+	return ms.metaRepo.GetMetaListByObjectIDsAndKey(ctx, objectIDs, key)
+}
+
 // GetMetaList get meta list all
 func (ms *MetaCommonService) GetMetaList(ctx context.Context, objID string) (metas []*entity.Meta, err error) {
 	metas, err = ms.metaRepo.GetMetaList(ctx, &entity.Meta{ObjectID: objID})
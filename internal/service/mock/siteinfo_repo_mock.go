@@ -193,6 +193,36 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteAdvanced(ctx any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteAdvanced", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteAdvanced), ctx)
 }
 
+// GetSiteAutoClose mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteAutoClose(ctx context.Context) (*schema.SiteAutoCloseResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteAutoClose", ctx)
+	ret0, _ := ret[0].(*schema.SiteAutoCloseResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteAutoClose indicates an expected call of GetSiteAutoClose.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteAutoClose(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteAutoClose", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteAutoClose), ctx)
+}
+
+// GetSiteSlowQuery mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSlowQuery(ctx context.Context) (*schema.SlowQueryConfigResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSlowQuery", ctx)
+	ret0, _ := ret[0].(*schema.SlowQueryConfigResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSlowQuery indicates an expected call of GetSiteSlowQuery.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSlowQuery(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSlowQuery", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSlowQuery), ctx)
+}
+
 // GetSiteBranding mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteBranding(ctx context.Context) (*schema.SiteBrandingResp, error) {
 	m.ctrl.T.Helper()
@@ -208,6 +238,36 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteBranding(ctx any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteBranding", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteBranding), ctx)
 }
 
+// GetSiteCORS mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteCORS(ctx context.Context) (*schema.SiteCORSResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteCORS", ctx)
+	ret0, _ := ret[0].(*schema.SiteCORSResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteCORS indicates an expected call of GetSiteCORS.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteCORS(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteCORS", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteCORS), ctx)
+}
+
+// GetSiteCaptcha mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteCaptcha(ctx context.Context) (*schema.SiteCaptchaResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteCaptcha", ctx)
+	ret0, _ := ret[0].(*schema.SiteCaptchaResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteCaptcha indicates an expected call of GetSiteCaptcha.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteCaptcha(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteCaptcha", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteCaptcha), ctx)
+}
+
 // GetSiteCustomCssHTML mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteCustomCssHTML(ctx context.Context) (*schema.SiteCustomCssHTMLResp, error) {
 	m.ctrl.T.Helper()
@@ -267,6 +327,21 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteInterface(ctx any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteInterface", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteInterface), ctx)
 }
 
+// GetSiteLDAP mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteLDAP(ctx context.Context) (*schema.SiteLDAPResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteLDAP", ctx)
+	ret0, _ := ret[0].(*schema.SiteLDAPResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteLDAP indicates an expected call of GetSiteLDAP.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLDAP(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLDAP", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLDAP), ctx)
+}
+
 // GetSiteLogin mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteLogin(ctx context.Context) (*schema.SiteLoginResp, error) {
 	m.ctrl.T.Helper()
@@ -282,6 +357,36 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLogin(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLogin", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLogin), ctx)
 }
 
+// GetSiteMaintenance mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteMaintenance(ctx context.Context) (*schema.SiteMaintenanceResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteMaintenance", ctx)
+	ret0, _ := ret[0].(*schema.SiteMaintenanceResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteMaintenance indicates an expected call of GetSiteMaintenance.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteMaintenance(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteMaintenance", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteMaintenance), ctx)
+}
+
+// GetSiteGuestPosting mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteGuestPosting(ctx context.Context) (*schema.SiteGuestPostingResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteGuestPosting", ctx)
+	ret0, _ := ret[0].(*schema.SiteGuestPostingResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteGuestPosting indicates an expected call of GetSiteGuestPosting.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteGuestPosting(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteGuestPosting", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteGuestPosting), ctx)
+}
+
 // GetSiteMCP mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteMCP(ctx context.Context) (*schema.SiteMCPResp, error) {
 	m.ctrl.T.Helper()
@@ -327,6 +432,111 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteQuestion(ctx any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteQuestion", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteQuestion), ctx)
 }
 
+// GetSiteReaction mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteReaction(ctx context.Context) (*schema.SiteReactionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteReaction", ctx)
+	ret0, _ := ret[0].(*schema.SiteReactionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteReaction indicates an expected call of GetSiteReaction.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteReaction(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteReaction", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteReaction), ctx)
+}
+
+// GetSiteUsernamePolicy mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteUsernamePolicy(ctx context.Context) (*schema.SiteUsernamePolicyResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteUsernamePolicy", ctx)
+	ret0, _ := ret[0].(*schema.SiteUsernamePolicyResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteUsernamePolicy indicates an expected call of GetSiteUsernamePolicy.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteUsernamePolicy(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteUsernamePolicy", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteUsernamePolicy), ctx)
+}
+
+// GetSiteRegistrationBlocklist mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteRegistrationBlocklist(ctx context.Context) (*schema.SiteRegistrationBlocklistResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteRegistrationBlocklist", ctx)
+	ret0, _ := ret[0].(*schema.SiteRegistrationBlocklistResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteRegistrationBlocklist indicates an expected call of GetSiteRegistrationBlocklist.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteRegistrationBlocklist(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteRegistrationBlocklist", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteRegistrationBlocklist), ctx)
+}
+
+// GetSiteSAML mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSAML(ctx context.Context) (*schema.SiteSAMLResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSAML", ctx)
+	ret0, _ := ret[0].(*schema.SiteSAMLResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSAML indicates an expected call of GetSiteSAML.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSAML(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSAML", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSAML), ctx)
+}
+
+// GetSitePostingRestriction mocks base method.
+func (m *MockSiteInfoCommonService) GetSitePostingRestriction(ctx context.Context) (*schema.SitePostingRestrictionResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSitePostingRestriction", ctx)
+	ret0, _ := ret[0].(*schema.SitePostingRestrictionResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSitePostingRestriction indicates an expected call of GetSitePostingRestriction.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSitePostingRestriction(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSitePostingRestriction", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSitePostingRestriction), ctx)
+}
+
+// GetSiteSpam mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteSpam(ctx context.Context) (*schema.SiteSpamResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteSpam", ctx)
+	ret0, _ := ret[0].(*schema.SiteSpamResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteSpam indicates an expected call of GetSiteSpam.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteSpam(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteSpam", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteSpam), ctx)
+}
+
+// GetSiteStorage mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteStorage(ctx context.Context) (*schema.SiteStorageResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteStorage", ctx)
+	ret0, _ := ret[0].(*schema.SiteStorageResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteStorage indicates an expected call of GetSiteStorage.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteStorage(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteStorage", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteStorage), ctx)
+}
+
 // GetSiteSecurity mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteSecurity(ctx context.Context) (*schema.SiteSecurityResp, error) {
 	m.ctrl.T.Helper()
@@ -417,6 +627,21 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteUsersSettings(ctx any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteUsersSettings", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteUsersSettings), ctx)
 }
 
+// GetSiteWebhook mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteWebhook(ctx context.Context) (*schema.SiteWebhookResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteWebhook", ctx)
+	ret0, _ := ret[0].(*schema.SiteWebhookResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteWebhook indicates an expected call of GetSiteWebhook.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteWebhook(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteWebhook", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteWebhook), ctx)
+}
+
 // GetSiteWrite mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteWrite(ctx context.Context) (*schema.SiteWriteResp, error) {
 	m.ctrl.T.Helper()
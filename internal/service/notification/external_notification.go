@@ -22,6 +22,7 @@ package notification
 import (
 	"context"
 
+	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
@@ -37,15 +38,18 @@ import (
 )
 
 type ExternalNotificationService struct {
-	data                       *data.Data
-	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
-	followRepo                 activity_common.FollowRepo
-	emailService               *export.EmailService
-	userRepo                   usercommon.UserRepo
-	notificationQueueService   noticequeue.ExternalService
-	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
-	siteInfoService            siteinfo_common.SiteInfoCommonService
-	newQuestionEmailWorker     *newQuestionEmailWorker
+	data                          *data.Data
+	userNotificationConfigRepo    user_notification_config.UserNotificationConfigRepo
+	followRepo                    activity_common.FollowRepo
+	emailService                  *export.EmailService
+	userRepo                      usercommon.UserRepo
+	notificationQueueService      noticequeue.ExternalService
+	userExternalLoginRepo         user_external_login.UserExternalLoginRepo
+	siteInfoService               siteinfo_common.SiteInfoCommonService
+	newQuestionEmailWorker        *newQuestionEmailWorker
+	inboxNotificationQueueService noticequeue.Service
+	newQuestionDigestRepo         NewQuestionDigestRepo
+	notificationDigestRepo        NotificationDigestRepo
 }
 
 func NewExternalNotificationService(
@@ -57,16 +61,22 @@ func NewExternalNotificationService(
 	notificationQueueService noticequeue.ExternalService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	inboxNotificationQueueService noticequeue.Service,
+	newQuestionDigestRepo NewQuestionDigestRepo,
+	notificationDigestRepo NotificationDigestRepo,
 ) *ExternalNotificationService {
 	n := &ExternalNotificationService{
-		data:                       data,
-		userNotificationConfigRepo: userNotificationConfigRepo,
-		followRepo:                 followRepo,
-		emailService:               emailService,
-		userRepo:                   userRepo,
-		notificationQueueService:   notificationQueueService,
-		userExternalLoginRepo:      userExternalLoginRepo,
-		siteInfoService:            siteInfoService,
+		data:                          data,
+		userNotificationConfigRepo:    userNotificationConfigRepo,
+		followRepo:                    followRepo,
+		emailService:                  emailService,
+		userRepo:                      userRepo,
+		notificationQueueService:      notificationQueueService,
+		userExternalLoginRepo:         userExternalLoginRepo,
+		siteInfoService:               siteInfoService,
+		inboxNotificationQueueService: inboxNotificationQueueService,
+		newQuestionDigestRepo:         newQuestionDigestRepo,
+		notificationDigestRepo:        notificationDigestRepo,
 	}
 	n.newQuestionEmailWorker = newQuestionEmailWorkerWithDefaults(
 		newQuestionNotificationEmailSendInterval,
@@ -97,6 +107,9 @@ func (ns *ExternalNotificationService) Handler(ctx context.Context, msg *schema.
 	if msg.NewInviteAnswerTemplateRawData != nil {
 		return ns.handleInviteAnswerNotification(ctx, msg)
 	}
+	if msg.NotificationEventTemplateRawData != nil {
+		return ns.handleNotificationEventNotification(ctx, msg)
+	}
 	log.Errorf("unknown notification message: %+v", msg)
 	return nil
 }
@@ -113,3 +126,15 @@ func (ns *ExternalNotificationService) checkUserStatusBeforeNotification(ctx con
 	}
 	return false
 }
+
+// getNotificationPreference get the preference of the notification event of the receiver,
+// and how often the receiver gets the emails of the notification events
+func (ns *ExternalNotificationService) getNotificationPreference(ctx context.Context, userID string,
+	source constant.NotificationSource) (preference *schema.NotificationPreference, emailFrequency string, err error) {
+	notificationConfigs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	return schema.NewNotificationPreference(source, notificationConfigs),
+		schema.NewNotificationEmailFrequency(notificationConfigs), nil
+}
@@ -21,7 +21,6 @@ package notification
 
 import (
 	"context"
-	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/schema"
@@ -57,26 +56,18 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 	if unavailable := ns.checkUserStatusBeforeNotification(ctx, userID); unavailable {
 		return
 	}
-	codeContent := &schema.EmailCodeContent{
-		SourceType: schema.UnsubscribeSourceType,
-		NotificationSources: []constant.NotificationSource{
-			constant.InboxSource,
-		},
-		Email:                    email,
-		UserID:                   userID,
-		SkipValidationLatestCode: true,
-	}
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userID, rawData.UnsubscribeCode,
+		constant.InboxSource)
 
 	// If receiver has set language, use it to send email.
 	if len(lang) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(lang))
 	}
-	title, body, err := ns.emailService.NewInviteAnswerTemplate(ctx, rawData)
+	title, body, textBody, err := ns.emailService.NewInviteAnswerTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	ns.emailService.SendWithUnsubscribe(ctx, email, title, body, textBody, rawData.UnsubscribeCode)
 }
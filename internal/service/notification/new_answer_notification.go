@@ -21,7 +21,6 @@ package notification
 
 import (
 	"context"
-	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/schema"
@@ -33,22 +32,28 @@ func (ns *ExternalNotificationService) handleNewAnswerNotification(ctx context.C
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
+	preference, emailFrequency, err := ns.getNotificationPreference(ctx, msg.ReceiverUserID,
+		constant.AnswerToMyQuestionSource)
 	if err != nil {
 		return err
 	}
-	if !exist {
+	if !preference.Email {
 		return nil
 	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		if channel.Key == constant.EmailChannel {
-			ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
-		}
+	if emailFrequency != constant.NotificationFrequencyImmediate {
+		rawData := msg.NewAnswerTemplateRawData
+		ns.addNotificationDigest(ctx, msg.ReceiverUserID, &schema.NotificationEventTemplateRawData{
+			Event:                  constant.AnswerToMyQuestionSource,
+			NotificationAction:     constant.NotificationAnswerTheQuestion,
+			TriggerUserDisplayName: rawData.AnswerUserDisplayName,
+			Title:                  rawData.QuestionTitle,
+			QuestionID:             rawData.QuestionID,
+			AnswerID:               rawData.AnswerID,
+			ObjectID:               rawData.AnswerID,
+		})
+		return nil
 	}
+	ns.sendNewAnswerNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewAnswerTemplateRawData)
 	return nil
 }
 
@@ -57,26 +62,18 @@ func (ns *ExternalNotificationService) sendNewAnswerNotificationEmail(ctx contex
 	if unavailable := ns.checkUserStatusBeforeNotification(ctx, userID); unavailable {
 		return
 	}
-	codeContent := &schema.EmailCodeContent{
-		SourceType: schema.UnsubscribeSourceType,
-		NotificationSources: []constant.NotificationSource{
-			constant.InboxSource,
-		},
-		Email:                    email,
-		UserID:                   userID,
-		SkipValidationLatestCode: true,
-	}
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userID, rawData.UnsubscribeCode,
+		constant.AnswerToMyQuestionSource)
 
 	// If receiver has set language, use it to send email.
 	if len(lang) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(lang))
 	}
-	title, body, err := ns.emailService.NewAnswerTemplate(ctx, rawData)
+	title, body, textBody, err := ns.emailService.NewAnswerTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	ns.emailService.SendWithUnsubscribe(ctx, email, title, body, textBody, rawData.UnsubscribeCode)
 }
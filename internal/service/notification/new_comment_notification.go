@@ -21,7 +21,6 @@ package notification
 
 import (
 	"context"
-	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/schema"
@@ -33,22 +32,33 @@ func (ns *ExternalNotificationService) handleNewCommentNotification(ctx context.
 	msg *schema.ExternalNotificationMsg) error {
 	log.Debugf("try to send new comment notification %+v", msg)
 
-	notificationConfig, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(ctx, msg.ReceiverUserID, constant.InboxSource)
+	preference, emailFrequency, err := ns.getNotificationPreference(ctx, msg.ReceiverUserID,
+		constant.CommentOnMyPostSource)
 	if err != nil {
 		return err
 	}
-	if !exist {
+	if !preference.Email {
 		return nil
 	}
-	channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
-	for _, channel := range channels {
-		if !channel.Enable {
-			continue
-		}
-		if channel.Key == constant.EmailChannel {
-			ns.sendNewCommentNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewCommentTemplateRawData)
+	if emailFrequency != constant.NotificationFrequencyImmediate {
+		rawData := msg.NewCommentTemplateRawData
+		action := constant.NotificationCommentQuestion
+		if len(rawData.AnswerID) > 0 {
+			action = constant.NotificationCommentAnswer
 		}
+		ns.addNotificationDigest(ctx, msg.ReceiverUserID, &schema.NotificationEventTemplateRawData{
+			Event:                  constant.CommentOnMyPostSource,
+			NotificationAction:     action,
+			TriggerUserDisplayName: rawData.CommentUserDisplayName,
+			Title:                  rawData.QuestionTitle,
+			QuestionID:             rawData.QuestionID,
+			AnswerID:               rawData.AnswerID,
+			CommentID:              rawData.CommentID,
+			ObjectID:               rawData.CommentID,
+		})
+		return nil
 	}
+	ns.sendNewCommentNotificationEmail(ctx, msg.ReceiverUserID, msg.ReceiverEmail, msg.ReceiverLang, msg.NewCommentTemplateRawData)
 	return nil
 }
 
@@ -57,25 +67,17 @@ func (ns *ExternalNotificationService) sendNewCommentNotificationEmail(ctx conte
 	if unavailable := ns.checkUserStatusBeforeNotification(ctx, userID); unavailable {
 		return
 	}
-	codeContent := &schema.EmailCodeContent{
-		SourceType: schema.UnsubscribeSourceType,
-		NotificationSources: []constant.NotificationSource{
-			constant.InboxSource,
-		},
-		Email:                    email,
-		UserID:                   userID,
-		SkipValidationLatestCode: true,
-	}
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userID, rawData.UnsubscribeCode,
+		constant.CommentOnMyPostSource)
 	// If receiver has set language, use it to send email.
 	if len(lang) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(lang))
 	}
-	title, body, err := ns.emailService.NewCommentTemplate(ctx, rawData)
+	title, body, textBody, err := ns.emailService.NewCommentTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	ns.emailService.SendWithUnsubscribe(ctx, email, title, body, textBody, rawData.UnsubscribeCode)
 }
@@ -0,0 +1,200 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// NewQuestionDigestRepo new question digest repository
+type NewQuestionDigestRepo interface {
+	AddNewQuestionDigests(ctx context.Context, digests []*entity.NewQuestionDigest) (err error)
+	GetNewQuestionDigestUserIDs(ctx context.Context, before time.Time) (userIDs []string, err error)
+	GetNewQuestionDigests(ctx context.Context, userID string, limit int) (digests []*entity.NewQuestionDigest, err error)
+	RemoveNewQuestionDigests(ctx context.Context, userID, maxID string) (err error)
+	// ClaimNewQuestionDigests remove the waiting questions before they are sent, returns the ones removed by this call,
+	// so the instances sending the digests at the same time never send the same question twice
+	ClaimNewQuestionDigests(ctx context.Context, digests []*entity.NewQuestionDigest) (
+		claimed []*entity.NewQuestionDigest, err error)
+}
+
+// splitNewQuestionDigestSubscribers pick out the followers of the tags who want the daily or weekly digest
+func splitNewQuestionDigestSubscribers(subscribers []*NewQuestionSubscriber) (
+	immediateSubscribers, digestSubscribers []*NewQuestionSubscriber) {
+	for _, subscriber := range subscribers {
+		if subscriber == nil {
+			continue
+		}
+		channel := getEmailChannel(subscriber.Channels)
+		if subscriber.NotificationSource == constant.AllNewQuestionForFollowingTagsSource &&
+			channel != nil && channel.Enable && channel.IsDigest() {
+			digestSubscribers = append(digestSubscribers, subscriber)
+			continue
+		}
+		immediateSubscribers = append(immediateSubscribers, subscriber)
+	}
+	return immediateSubscribers, digestSubscribers
+}
+
+func getEmailChannel(channels schema.NotificationChannels) *schema.NotificationChannelConfig {
+	for _, channel := range channels {
+		if channel != nil && channel.Key == constant.EmailChannel {
+			return channel
+		}
+	}
+	return nil
+}
+
+// newQuestionDigestInterval how long the questions wait before they are sent in the digest
+func newQuestionDigestInterval(frequency string) time.Duration {
+	switch frequency {
+	case constant.NotificationFrequencyDaily:
+		return 24 * time.Hour
+	case constant.NotificationFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+func (ns *ExternalNotificationService) addNewQuestionDigests(ctx context.Context,
+	subscribers []*NewQuestionSubscriber, rawData *schema.NewQuestionTemplateRawData) {
+	if len(subscribers) == 0 {
+		return
+	}
+	digests := make([]*entity.NewQuestionDigest, 0, len(subscribers))
+	for _, subscriber := range subscribers {
+		digests = append(digests, &entity.NewQuestionDigest{
+			UserID:        subscriber.UserID,
+			QuestionID:    rawData.QuestionID,
+			QuestionTitle: rawData.QuestionTitle,
+			Tags:          strings.Join(rawData.Tags, ","),
+		})
+	}
+	if err := ns.newQuestionDigestRepo.AddNewQuestionDigests(ctx, digests); err != nil {
+		log.Errorf("add new question digests for question %s failed: %v", rawData.QuestionID, err)
+	}
+}
+
+// SendNewQuestionDigests send the digest email to the users whose questions in the following tags have waited long enough
+func (ns *ExternalNotificationService) SendNewQuestionDigests(ctx context.Context) {
+	now := time.Now()
+	userIDs, err := ns.newQuestionDigestRepo.GetNewQuestionDigestUserIDs(ctx,
+		now.Add(-newQuestionDigestInterval(constant.NotificationFrequencyDaily)))
+	if err != nil {
+		log.Errorf("get new question digest users failed: %v", err)
+		return
+	}
+	for _, userID := range userIDs {
+		ns.sendNewQuestionDigest(ctx, userID, now)
+	}
+}
+
+func (ns *ExternalNotificationService) sendNewQuestionDigest(ctx context.Context, userID string, now time.Time) {
+	config, exist, err := ns.userNotificationConfigRepo.GetByUserIDAndSource(
+		ctx, userID, constant.AllNewQuestionForFollowingTagsSource)
+	if err != nil {
+		log.Errorf("get user %s notification config failed: %v", userID, err)
+		return
+	}
+	channel := schema.NotificationChannelConfig{}
+	if exist {
+		channel = schema.NewNotificationChannelConfigFormJson(config.Channels)
+	}
+	// the user does not want the emails anymore, or the user is not available
+	if !channel.Enable || ns.checkUserStatusBeforeNotification(ctx, userID) {
+		if err = ns.newQuestionDigestRepo.RemoveNewQuestionDigests(ctx, userID, ""); err != nil {
+			log.Errorf("remove new question digests of user %s failed: %v", userID, err)
+		}
+		return
+	}
+
+	digests, err := ns.newQuestionDigestRepo.GetNewQuestionDigests(ctx, userID, constant.NewQuestionDigestMaxQuestions)
+	if err != nil {
+		log.Errorf("get new question digests of user %s failed: %v", userID, err)
+		return
+	}
+	if len(digests) == 0 {
+		_ = ns.newQuestionDigestRepo.RemoveNewQuestionDigests(ctx, userID, "")
+		return
+	}
+	if now.Sub(digests[0].CreatedAt) < newQuestionDigestInterval(channel.Frequency) {
+		return
+	}
+	maxID := digests[len(digests)-1].ID
+	digests, err = ns.newQuestionDigestRepo.ClaimNewQuestionDigests(ctx, digests)
+	if err != nil {
+		log.Errorf("claim new question digests of user %s failed: %v", userID, err)
+		return
+	}
+	if len(digests) == 0 {
+		return
+	}
+
+	rawData := &schema.NewQuestionDigestTemplateRawData{UnsubscribeCode: token.GenerateToken()}
+	for _, digest := range digests {
+		question := &schema.NewQuestionTemplateRawData{
+			QuestionID:    digest.QuestionID,
+			QuestionTitle: digest.QuestionTitle,
+		}
+		if len(digest.Tags) > 0 {
+			question.Tags = strings.Split(digest.Tags, ",")
+		}
+		rawData.Questions = append(rawData.Questions, question)
+	}
+	ns.sendNewQuestionDigestEmail(ctx, userID, rawData)
+
+	// the waiting questions which are hidden or deleted meanwhile are removed too
+	if err = ns.newQuestionDigestRepo.RemoveNewQuestionDigests(ctx, userID, maxID); err != nil {
+		log.Errorf("remove new question digests of user %s failed: %v", userID, err)
+	}
+}
+
+func (ns *ExternalNotificationService) sendNewQuestionDigestEmail(ctx context.Context,
+	userID string, rawData *schema.NewQuestionDigestTemplateRawData) {
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		log.Errorf("user %s not exist", userID)
+		return
+	}
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(userInfo.Language))
+	}
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userID, rawData.UnsubscribeCode,
+		constant.AllNewQuestionForFollowingTagsSource)
+	title, body, err := ns.emailService.NewQuestionDigestTemplate(ctx, rawData)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ns.emailService.SendWithUnsubscribe(ctx, userInfo.EMail, title, body, "", rawData.UnsubscribeCode)
+}
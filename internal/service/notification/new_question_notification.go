@@ -22,7 +22,6 @@ package notification
 import (
 	"context"
 	"strings"
-	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/translator"
@@ -49,11 +48,57 @@ func (ns *ExternalNotificationService) handleNewQuestionNotification(ctx context
 	}
 	log.Debugf("get subscribers %d for question %s", len(subscribers), msg.NewQuestionTemplateRawData.QuestionID)
 
+	ns.sendNewQuestionInboxNotifications(ctx, msg)
 	ns.syncNewQuestionNotificationToPlugin(ctx, msg)
-	ns.enqueueNewQuestionNotificationEmails(subscribers, msg.NewQuestionTemplateRawData)
+	immediateSubscribers, digestSubscribers := splitNewQuestionDigestSubscribers(subscribers)
+	ns.addNewQuestionDigests(ctx, digestSubscribers, msg.NewQuestionTemplateRawData)
+	ns.enqueueNewQuestionNotificationEmails(immediateSubscribers, msg.NewQuestionTemplateRawData)
 	return nil
 }
 
+// getTagsFollowerIDs get the followers of the tags, the user who follows multiple tags only once
+func (ns *ExternalNotificationService) getTagsFollowerIDs(ctx context.Context, tagIDs []string) []string {
+	tagsFollowerIDs := make([]string, 0)
+	followerMapping := make(map[string]bool)
+	for _, tagID := range tagIDs {
+		userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		for _, userID := range userIDs {
+			if _, ok := followerMapping[userID]; ok {
+				continue
+			}
+			followerMapping[userID] = true
+			tagsFollowerIDs = append(tagsFollowerIDs, userID)
+		}
+	}
+	return tagsFollowerIDs
+}
+
+// sendNewQuestionInboxNotifications notify the followers of the question's tags in the inbox
+func (ns *ExternalNotificationService) sendNewQuestionInboxNotifications(ctx context.Context,
+	msg *schema.ExternalNotificationMsg) {
+	rawData := msg.NewQuestionTemplateRawData
+	for _, userID := range ns.getTagsFollowerIDs(ctx, rawData.TagIDs) {
+		if userID == rawData.QuestionAuthorUserID {
+			continue
+		}
+		if unavailable := ns.checkUserStatusBeforeNotification(ctx, userID); unavailable {
+			continue
+		}
+		ns.inboxNotificationQueueService.Send(ctx, &schema.NotificationMsg{
+			TriggerUserID:      rawData.QuestionAuthorUserID,
+			ReceiverUserID:     userID,
+			Type:               schema.NotificationTypeInbox,
+			ObjectID:           rawData.QuestionID,
+			ObjectType:         constant.QuestionObjectType,
+			NotificationAction: constant.NotificationNewQuestionInFollowingTag,
+		})
+	}
+}
+
 func (ns *ExternalNotificationService) enqueueNewQuestionNotificationEmails(
 	subscribers []*NewQuestionSubscriber,
 	rawData *schema.NewQuestionTemplateRawData,
@@ -92,22 +137,7 @@ func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Con
 	subscribersMapping := make(map[string]*NewQuestionSubscriber)
 
 	// 1. get all this new question's tags followers
-	tagsFollowerIDs := make([]string, 0)
-	followerMapping := make(map[string]bool)
-	for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
-		userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
-		if err != nil {
-			log.Error(err)
-			continue
-		}
-		for _, userID := range userIDs {
-			if _, ok := followerMapping[userID]; ok {
-				continue
-			}
-			followerMapping[userID] = true
-			tagsFollowerIDs = append(tagsFollowerIDs, userID)
-		}
-	}
+	tagsFollowerIDs := ns.getTagsFollowerIDs(ctx, msg.NewQuestionTemplateRawData.TagIDs)
 	userNotificationConfigs, err := ns.userNotificationConfigRepo.GetByUsersAndSource(
 		ctx, tagsFollowerIDs, constant.AllNewQuestionForFollowingTagsSource)
 	if err != nil {
@@ -193,24 +223,14 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 	if len(userInfo.Language) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(userInfo.Language))
 	}
-	title, body, err := ns.emailService.NewQuestionTemplate(ctx, rawData)
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userID, rawData.UnsubscribeCode,
+		constant.AllNewQuestionSource, constant.AllNewQuestionForFollowingTagsSource)
+	title, body, textBody, err := ns.emailService.NewQuestionTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
 		return
 	}
-
-	codeContent := &schema.EmailCodeContent{
-		SourceType: schema.UnsubscribeSourceType,
-		Email:      userInfo.EMail,
-		UserID:     userID,
-		NotificationSources: []constant.NotificationSource{
-			constant.AllNewQuestionSource,
-			constant.AllNewQuestionForFollowingTagsSource,
-		},
-		SkipValidationLatestCode: true,
-	}
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userInfo.ID, userInfo.EMail, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	ns.emailService.SendWithUnsubscribe(ctx, userInfo.EMail, title, body, textBody, rawData.UnsubscribeCode)
 }
 
 func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx context.Context,
@@ -156,11 +156,13 @@ func TestHandleNewQuestionNotificationEnqueuesEmailTask(t *testing.T) {
 			newQuestionNotificationConfig("author", constant.AllNewQuestionSource, true),
 		},
 	}
+	inboxQueue := &newQuestionNotificationTestInboxQueue{}
 	service := &ExternalNotificationService{
 		data: &basedata.Data{
 			Cache: cache,
 		},
-		userNotificationConfigRepo: notificationConfigRepo,
+		inboxNotificationQueueService: inboxQueue,
+		userNotificationConfigRepo:    notificationConfigRepo,
 		followRepo: &newQuestionNotificationTestFollowRepo{
 			followersByObjectID: map[string][]string{
 				"tag-1": {"tag-user", "dup-user", "author"},
@@ -170,6 +172,7 @@ func TestHandleNewQuestionNotificationEnqueuesEmailTask(t *testing.T) {
 			config.NewConfigService(newQuestionNotificationTestConfigRepo{}),
 			emailRepo,
 			siteInfoService,
+			nil,
 		),
 		userRepo: &newQuestionNotificationTestUserRepo{
 			users: map[string]*entity.User{
@@ -214,6 +217,48 @@ func TestHandleNewQuestionNotificationEnqueuesEmailTask(t *testing.T) {
 	if len(emailRepo.codesByUserID) > 0 {
 		t.Fatalf("handler sent emails synchronously: %v", emailRepo.codesByUserID)
 	}
+
+	inboxUsers := make([]string, 0, len(inboxQueue.msgs))
+	for _, msg := range inboxQueue.msgs {
+		if msg.NotificationAction != constant.NotificationNewQuestionInFollowingTag || msg.ObjectID != "1" {
+			t.Fatalf("inbox notification = %+v", msg)
+		}
+		inboxUsers = append(inboxUsers, msg.ReceiverUserID)
+	}
+	assertStringSet(t, inboxUsers, []string{"dup-user", "tag-user"})
+}
+
+func TestSplitNewQuestionDigestSubscribers(t *testing.T) {
+	digestChannel := newQuestionEmailChannel(true)
+	digestChannel.Frequency = constant.NotificationFrequencyDaily
+	subscribers := []*NewQuestionSubscriber{
+		{
+			UserID:             "immediate-user",
+			Channels:           schema.NotificationChannels{newQuestionEmailChannel(true)},
+			NotificationSource: constant.AllNewQuestionForFollowingTagsSource,
+		},
+		{
+			UserID:             "digest-user",
+			Channels:           schema.NotificationChannels{digestChannel},
+			NotificationSource: constant.AllNewQuestionForFollowingTagsSource,
+		},
+		{
+			UserID:             "all-user",
+			Channels:           schema.NotificationChannels{digestChannel},
+			NotificationSource: constant.AllNewQuestionSource,
+		},
+		nil,
+	}
+
+	immediate, digest := splitNewQuestionDigestSubscribers(subscribers)
+	immediateUsers := make([]string, 0, len(immediate))
+	for _, subscriber := range immediate {
+		immediateUsers = append(immediateUsers, subscriber.UserID)
+	}
+	assertStringSet(t, immediateUsers, []string{"all-user", "immediate-user"})
+	if len(digest) != 1 || digest[0].UserID != "digest-user" {
+		t.Fatalf("digest subscribers = %+v", digest)
+	}
 }
 
 func TestHandleNewQuestionNotificationSkipsEnqueueWithoutEnabledEmailAttempts(t *testing.T) {
@@ -224,7 +269,8 @@ func TestHandleNewQuestionNotificationSkipsEnqueueWithoutEnabledEmailAttempts(t
 	t.Cleanup(cleanup)
 
 	service := &ExternalNotificationService{
-		data: &basedata.Data{Cache: cache},
+		data:                          &basedata.Data{Cache: cache},
+		inboxNotificationQueueService: &newQuestionNotificationTestInboxQueue{},
 		userNotificationConfigRepo: &newQuestionNotificationTestUserNotificationConfigRepo{
 			followedTagConfigs: map[string]*entity.UserNotificationConfig{
 				"tag-user": newQuestionNotificationConfig(
@@ -276,7 +322,8 @@ func TestHandleNewQuestionNotificationReturnsWhenEmailWorkerQueueFull(t *testing
 		t.Fatalf("pre-fill TryEnqueue() = false, want true")
 	}
 	service := &ExternalNotificationService{
-		data: &basedata.Data{Cache: cache},
+		data:                          &basedata.Data{Cache: cache},
+		inboxNotificationQueueService: &newQuestionNotificationTestInboxQueue{},
 		userNotificationConfigRepo: &newQuestionNotificationTestUserNotificationConfigRepo{
 			allQuestionConfigs: []*entity.UserNotificationConfig{
 				newQuestionNotificationConfig("all-user", constant.AllNewQuestionSource, true),
@@ -334,7 +381,8 @@ func TestHandleNewQuestionNotificationSyncsPluginBeforeEmailEnqueue(t *testing.T
 
 	worker := newUnstartedNewQuestionEmailWorkerForTest()
 	service := &ExternalNotificationService{
-		data: &basedata.Data{Cache: cache},
+		data:                          &basedata.Data{Cache: cache},
+		inboxNotificationQueueService: &newQuestionNotificationTestInboxQueue{},
 		userNotificationConfigRepo: &newQuestionNotificationTestUserNotificationConfigRepo{
 			followedTagConfigs: map[string]*entity.UserNotificationConfig{
 				"tag-user": newQuestionNotificationConfig(
@@ -475,6 +523,20 @@ func assertStringSet(t *testing.T, got, want []string) {
 	}
 }
 
+type newQuestionNotificationTestInboxQueue struct {
+	msgs []*schema.NotificationMsg
+}
+
+func (q *newQuestionNotificationTestInboxQueue) Send(_ context.Context, msg *schema.NotificationMsg) {
+	q.msgs = append(q.msgs, msg)
+}
+
+func (q *newQuestionNotificationTestInboxQueue) RegisterHandler(
+	func(ctx context.Context, msg *schema.NotificationMsg) error) {
+}
+
+func (q *newQuestionNotificationTestInboxQueue) Close() {}
+
 type newQuestionNotificationTestFollowRepo struct {
 	followersByObjectID map[string][]string
 }
@@ -813,3 +875,8 @@ func (newQuestionNotificationTestUserExternalLoginRepo) DeleteCacheOAuthState(
 	context.Context, string) error {
 	return nil
 }
+
+func (newQuestionNotificationTestUserExternalLoginRepo) ConsumeCacheSAMLAssertionID(
+	context.Context, string, time.Duration) (bool, error) {
+	return true, nil
+}
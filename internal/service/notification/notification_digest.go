@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/token"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// NotificationDigestRepo notification digest repository
+type NotificationDigestRepo interface {
+	AddNotificationDigest(ctx context.Context, digest *entity.NotificationDigest) (err error)
+	GetNotificationDigestUserIDs(ctx context.Context, before time.Time) (userIDs []string, err error)
+	GetNotificationDigests(ctx context.Context, userID string, limit int) (digests []*entity.NotificationDigest, err error)
+	GetOldestNotificationDigest(ctx context.Context, userID string) (
+		digest *entity.NotificationDigest, exist bool, err error)
+	RemoveNotificationDigests(ctx context.Context, userID, maxID string) (err error)
+	// ClaimNotificationDigests remove the waiting notifications before they are sent, returns the ones removed by
+	// this call, so the instances sending the digests at the same time never send the same notification twice
+	ClaimNotificationDigests(ctx context.Context, digests []*entity.NotificationDigest) (
+		claimed []*entity.NotificationDigest, err error)
+}
+
+// notificationDigestInterval how long the notifications wait before they are sent in the digest
+func notificationDigestInterval(frequency string) time.Duration {
+	switch frequency {
+	case constant.NotificationFrequencyHourly:
+		return time.Hour
+	case constant.NotificationFrequencyDaily:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// addNotificationDigest queue the email of the notification event for the digest instead of sending it
+func (ns *ExternalNotificationService) addNotificationDigest(ctx context.Context, userID string,
+	rawData *schema.NotificationEventTemplateRawData) {
+	content, _ := json.Marshal(rawData)
+	err := ns.notificationDigestRepo.AddNotificationDigest(ctx, &entity.NotificationDigest{
+		UserID:   userID,
+		Event:    string(rawData.Event),
+		ObjectID: uid.DeShortID(rawData.ObjectID),
+		Content:  string(content),
+	})
+	if err != nil {
+		log.Errorf("add notification digest for user %s failed: %v", userID, err)
+	}
+}
+
+// SendNotificationDigests send the digest email to the users whose notifications have waited long enough
+func (ns *ExternalNotificationService) SendNotificationDigests(ctx context.Context) {
+	now := time.Now()
+	userIDs, err := ns.notificationDigestRepo.GetNotificationDigestUserIDs(ctx,
+		now.Add(-notificationDigestInterval(constant.NotificationFrequencyHourly)))
+	if err != nil {
+		log.Errorf("get notification digest users failed: %v", err)
+		return
+	}
+	for _, userID := range userIDs {
+		ns.sendNotificationDigest(ctx, userID, now)
+	}
+}
+
+func (ns *ExternalNotificationService) sendNotificationDigest(ctx context.Context, userID string, now time.Time) {
+	if ns.checkUserStatusBeforeNotification(ctx, userID) {
+		if err := ns.notificationDigestRepo.RemoveNotificationDigests(ctx, userID, ""); err != nil {
+			log.Errorf("remove notification digests of user %s failed: %v", userID, err)
+		}
+		return
+	}
+	notificationConfigs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Errorf("get user %s notification config failed: %v", userID, err)
+		return
+	}
+	oldest, exist, err := ns.notificationDigestRepo.GetOldestNotificationDigest(ctx, userID)
+	if err != nil || !exist {
+		return
+	}
+	// the notifications queued before the user switches back to the immediate emails are sent at once
+	frequency := schema.NewNotificationEmailFrequency(notificationConfigs)
+	if now.Sub(oldest.CreatedAt) < notificationDigestInterval(frequency) {
+		return
+	}
+
+	digests, err := ns.notificationDigestRepo.GetNotificationDigests(ctx, userID, constant.NotificationDigestMaxItems)
+	if err != nil {
+		log.Errorf("get notification digests of user %s failed: %v", userID, err)
+		return
+	}
+	maxID := ""
+	if len(digests) > 0 {
+		maxID = digests[len(digests)-1].ID
+	}
+	digests, err = ns.notificationDigestRepo.ClaimNotificationDigests(ctx, digests)
+	if err != nil {
+		log.Errorf("claim notification digests of user %s failed: %v", userID, err)
+		return
+	}
+	if len(maxID) > 0 && len(digests) == 0 {
+		return
+	}
+	rawData := &schema.NotificationDigestTemplateRawData{UnsubscribeCode: token.GenerateToken()}
+	sources := make([]constant.NotificationSource, 0)
+	for _, digest := range digests {
+		notification := &schema.NotificationEventTemplateRawData{}
+		if err = json.Unmarshal([]byte(digest.Content), notification); err != nil {
+			log.Errorf("unmarshal notification digest %s failed: %v", digest.ID, err)
+			continue
+		}
+		// the user may not want the emails of the event anymore after it is queued
+		if !schema.NewNotificationPreference(notification.Event, notificationConfigs).Email {
+			continue
+		}
+		rawData.Notifications = append(rawData.Notifications, notification)
+		if !slices.Contains(sources, notification.Event) {
+			sources = append(sources, notification.Event)
+		}
+	}
+	if len(rawData.Notifications) > 0 {
+		ns.sendNotificationDigestEmail(ctx, userID, rawData, sources)
+	}
+
+	// the ones read in-app are removed too, all of them if they are all read in-app,
+	// the later ones wait for the next digest
+	if err = ns.notificationDigestRepo.RemoveNotificationDigests(ctx, userID, maxID); err != nil {
+		log.Errorf("remove notification digests of user %s failed: %v", userID, err)
+	}
+}
+
+func (ns *ExternalNotificationService) sendNotificationDigestEmail(ctx context.Context,
+	userID string, rawData *schema.NotificationDigestTemplateRawData, sources []constant.NotificationSource) {
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist {
+		log.Errorf("user %s not exist", userID)
+		return
+	}
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(userInfo.Language))
+	}
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userID, rawData.UnsubscribeCode, sources...)
+	title, body, err := ns.emailService.NotificationDigestTemplate(ctx, rawData)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ns.emailService.SendWithUnsubscribe(ctx, userInfo.EMail, title, body, "", rawData.UnsubscribeCode)
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package notification
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+func (ns *ExternalNotificationService) handleNotificationEventNotification(ctx context.Context,
+	msg *schema.ExternalNotificationMsg) error {
+	log.Debugf("try to send notification event email %+v", msg)
+
+	rawData := msg.NotificationEventTemplateRawData
+	preference, emailFrequency, err := ns.getNotificationPreference(ctx, msg.ReceiverUserID, rawData.Event)
+	if err != nil {
+		return err
+	}
+	if !preference.Email {
+		return nil
+	}
+	if emailFrequency != constant.NotificationFrequencyImmediate {
+		ns.addNotificationDigest(ctx, msg.ReceiverUserID, rawData)
+		return nil
+	}
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, msg.ReceiverUserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable {
+		return nil
+	}
+	lang := msg.ReceiverLang
+	if len(userInfo.Language) > 0 && userInfo.Language != translator.DefaultLangOption {
+		lang = userInfo.Language
+	}
+
+	rawData.UnsubscribeCode = ns.emailService.GenerateUnsubscribeToken(ctx, userInfo.ID, rawData.UnsubscribeCode,
+		rawData.Event)
+	if len(lang) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(lang))
+	}
+	title, body, textBody, err := ns.emailService.NotificationEventTemplate(ctx, rawData)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	ns.emailService.SendWithUnsubscribe(ctx, userInfo.EMail, title, body, textBody, rawData.UnsubscribeCode)
+	return nil
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_notification_config"
 	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/token"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
@@ -69,6 +71,9 @@ type NotificationCommon struct {
 	notificationQueueService noticequeue.Service
 	userExternalLoginRepo    user_external_login.UserExternalLoginRepo
 	siteInfoService          siteinfo_common.SiteInfoCommonService
+	// the emails of the notification events are sent by the external notification service
+	externalNotificationQueueService noticequeue.ExternalService
+	userNotificationConfigRepo       user_notification_config.UserNotificationConfigRepo
 }
 
 func NewNotificationCommon(
@@ -81,6 +86,8 @@ func NewNotificationCommon(
 	notificationQueueService noticequeue.Service,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	externalNotificationQueueService noticequeue.ExternalService,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
 ) *NotificationCommon {
 	notification := &NotificationCommon{
 		data:                     data,
@@ -92,6 +99,9 @@ func NewNotificationCommon(
 		notificationQueueService: notificationQueueService,
 		userExternalLoginRepo:    userExternalLoginRepo,
 		siteInfoService:          siteInfoService,
+
+		externalNotificationQueueService: externalNotificationQueueService,
+		userNotificationConfigRepo:       userNotificationConfigRepo,
 	}
 	notificationQueueService.RegisterHandler(notification.AddNotification)
 	return notification
@@ -140,6 +150,9 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 			objectMap["question"] = uid.DeShortID(objInfo.QuestionID)
 			objectMap["answer"] = uid.DeShortID(objInfo.AnswerID)
 			objectMap["comment"] = objInfo.CommentID
+			if len(msg.ExtraInfo["reason"]) > 0 {
+				objectMap["reason"] = msg.ExtraInfo["reason"]
+			}
 			req.ObjectInfo.ObjectMap = objectMap
 		}
 	}
@@ -190,24 +203,40 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 		return fmt.Errorf("user not exist: %s", req.TriggerUserID)
 	}
 	req.UserInfo = userBasicInfo
-	content, _ := json.Marshal(req)
-	_, ok := constant.NotificationMsgTypeMapping[req.NotificationAction]
-	if ok {
-		info.MsgType = constant.NotificationMsgTypeMapping[req.NotificationAction]
-	}
-	info.Content = string(content)
-	err = ns.notificationRepo.AddNotification(ctx, info)
-	if err != nil {
-		return fmt.Errorf("add notification error: %w", err)
-	}
-	err = ns.addRedDot(ctx, info.UserID, msg.Type)
-	if err != nil {
-		log.Error("addRedDot Error", err.Error())
+
+	inApp := true
+	if source, ok := constant.NotificationActionPreferenceSource[msg.NotificationAction]; ok && !msg.SentToFollower {
+		notificationConfigs, err := ns.userNotificationConfigRepo.GetByUserID(ctx, req.ReceiverUserID)
+		if err != nil {
+			return err
+		}
+		preference := schema.NewNotificationPreference(source, notificationConfigs)
+		inApp = preference.InApp
+		if preference.Email {
+			ns.sendNotificationEventEmail(ctx, source, req)
+		}
 	}
-	if req.ObjectInfo.ObjectType == constant.BadgeAwardObjectType {
-		err = ns.AddBadgeAwardAlertCache(ctx, info.UserID, info.ID, req.ObjectInfo.ObjectMap["badge_id"])
+
+	if inApp {
+		content, _ := json.Marshal(req)
+		_, ok := constant.NotificationMsgTypeMapping[req.NotificationAction]
+		if ok {
+			info.MsgType = constant.NotificationMsgTypeMapping[req.NotificationAction]
+		}
+		info.Content = string(content)
+		err = ns.notificationRepo.AddNotification(ctx, info)
 		if err != nil {
-			log.Error("AddBadgeAwardAlertCache Error", err.Error())
+			return fmt.Errorf("add notification error: %w", err)
+		}
+		err = ns.addRedDot(ctx, info.UserID, msg.Type)
+		if err != nil {
+			log.Error("addRedDot Error", err.Error())
+		}
+		if req.ObjectInfo.ObjectType == constant.BadgeAwardObjectType {
+			err = ns.AddBadgeAwardAlertCache(ctx, info.UserID, info.ID, req.ObjectInfo.ObjectMap["badge_id"])
+			if err != nil {
+				log.Error("AddBadgeAwardAlertCache Error", err.Error())
+			}
 		}
 	}
 
@@ -219,6 +248,36 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 	return nil
 }
 
+// sendNotificationEventEmail send the email of the notification events that have no dedicated email,
+// the emails of the answers and the comments are sent by the services that create them
+func (ns *NotificationCommon) sendNotificationEventEmail(ctx context.Context, source constant.NotificationSource,
+	req *schema.NotificationContent) {
+	if source != constant.MentionSource && source != constant.AcceptedSource && source != constant.BadgeEarnedSource {
+		return
+	}
+	rawData := &schema.NotificationEventTemplateRawData{
+		Event:              source,
+		NotificationAction: req.NotificationAction,
+		Title:              req.ObjectInfo.Title,
+		ObjectID:           req.ObjectInfo.ObjectID,
+		UnsubscribeCode:    token.GenerateToken(),
+	}
+	if req.ObjectInfo.ObjectType == constant.BadgeAwardObjectType {
+		rawData.BadgeID = req.ObjectInfo.ObjectMap["badge_id"]
+	} else {
+		rawData.QuestionID = req.ObjectInfo.ObjectMap["question"]
+		rawData.AnswerID = req.ObjectInfo.ObjectMap["answer"]
+		rawData.CommentID = req.ObjectInfo.ObjectMap["comment"]
+	}
+	if req.UserInfo != nil {
+		rawData.TriggerUserDisplayName = req.UserInfo.DisplayName
+	}
+	ns.externalNotificationQueueService.Send(ctx, &schema.ExternalNotificationMsg{
+		ReceiverUserID:                   req.ReceiverUserID,
+		NotificationEventTemplateRawData: rawData,
+	})
+}
+
 func (ns *NotificationCommon) addRedDot(ctx context.Context, userID string, noticeType int) error {
 	var key string
 	if noticeType == schema.NotificationTypeInbox {
@@ -352,6 +411,7 @@ func (ns *NotificationCommon) SendNotificationToAllFollower(ctx context.Context,
 		t.ReceiverUserID = userID
 		t.TriggerUserID = msg.TriggerUserID
 		t.NoNeedPushAllFollow = true
+		t.SentToFollower = true
 		ns.notificationQueueService.Send(ctx, t)
 	}
 }
@@ -209,6 +209,7 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			ObjectType:            objectType,
 			Title:                 questionInfo.Title,
 			Content:               questionInfo.ParsedText, // todo trim
+			Wiki:                  questionInfo.Wiki,
 		}
 	case constant.AnswerObjectType:
 		answerInfo, exist, err := os.answerRepo.GetAnswer(ctx, objectID)
@@ -237,6 +238,7 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			ObjectType:            objectType,
 			Title:                 questionInfo.Title,    // this should be question title
 			Content:               answerInfo.ParsedText, // todo trim
+			Wiki:                  answerInfo.Wiki,
 		}
 	case constant.CommentObjectType:
 		commentInfo, exist, err := os.commentRepo.GetComment(ctx, objectID)
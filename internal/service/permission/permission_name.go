@@ -21,6 +21,7 @@ package permission
 
 const (
 	AdminAccess                 = "admin.access"
+	ModerationAccess            = "moderation.access"
 	QuestionAdd                 = "question.add"
 	QuestionEdit                = "question.edit"
 	QuestionEditWithoutReview   = "question.edit_without_review"
@@ -63,6 +64,7 @@ const (
 	AnswerUnDelete              = "answer.undeleted"
 	QuestionUnDelete            = "question.undeleted"
 	TagUnDelete                 = "tag.undeleted"
+	PostWikiEdit                = "post.wiki_edit"
 )
 
 const (
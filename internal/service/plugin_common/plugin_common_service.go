@@ -81,8 +81,33 @@ func NewPluginCommonService(
 	return p
 }
 
-// UpdatePluginStatus update plugin status
-func (ps *PluginCommonService) UpdatePluginStatus(ctx context.Context) (err error) {
+// UpdatePluginStatus enable or disable the plugin at runtime, the Init or Shutdown hooks of the plugins whose status
+// is changed are called. The plugin is kept disabled if its Init hook fails.
+func (ps *PluginCommonService) UpdatePluginStatus(ctx context.Context, req *schema.UpdatePluginStatusReq) (err error) {
+	before := make(map[string]bool)
+	_ = plugin.CallBase(func(base plugin.Base) error {
+		before[base.Info().SlugName] = plugin.StatusManager.IsEnabled(base.Info().SlugName)
+		return nil
+	})
+	plugin.StatusManager.Enable(req.PluginSlugName, req.Enabled)
+
+	// the other plugins of the same kind may be disabled together, such as the captcha plugins
+	for slugName, enabled := range before {
+		if !enabled || plugin.StatusManager.IsEnabled(slugName) {
+			continue
+		}
+		if err := plugin.ShutdownPlugin(slugName); err != nil {
+			log.Errorf("shutdown plugin %s failed: %v", slugName, err)
+		}
+	}
+	if req.Enabled && !before[req.PluginSlugName] {
+		if err = plugin.InitPlugin(req.PluginSlugName); err != nil {
+			log.Errorf("init plugin %s failed: %v", req.PluginSlugName, err)
+			plugin.StatusManager.Enable(req.PluginSlugName, false)
+			return errors.BadRequest(reason.PluginInitFailed).WithError(err)
+		}
+	}
+
 	content, err := plugin.StatusManager.MarshalJSON()
 	if err != nil {
 		return errors.InternalServer(reason.UnknownError).WithError(err)
@@ -183,6 +208,19 @@ func (ps *PluginCommonService) initPluginData() {
 		})
 	}
 
+	// init the enabled plugins after they received their config
+	_ = plugin.CallLifecycle(func(p plugin.Lifecycle) error {
+		slugName := p.Info().SlugName
+		if !plugin.StatusManager.IsEnabled(slugName) {
+			return nil
+		}
+		if err := p.Init(); err != nil {
+			log.Errorf("init plugin %s failed: %v", slugName, err)
+			plugin.StatusManager.Enable(slugName, false)
+		}
+		return nil
+	})
+
 	// register syncer for vector search plugins on startup
 	_ = plugin.CallVectorSearch(func(vs plugin.VectorSearch) error {
 		vs.RegisterSyncer(context.Background(), vector_search_sync.NewPluginSyncer(ps.data))
@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package post_lock
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	metacommon "github.com/apache/answer/internal/service/meta_common"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// PostLockService lock the questions and the answers. The locked posts are still readable,
+// but they can not be answered, commented on or edited except by the admins and the moderators.
+type PostLockService struct {
+	metaService       *metacommon.MetaCommonService
+	objectInfoService *object_info.ObjService
+	auditLogService   *audit_log.AuditLogService
+}
+
+// NewPostLockService new post lock service
+func NewPostLockService(
+	metaService *metacommon.MetaCommonService,
+	objectInfoService *object_info.ObjService,
+	auditLogService *audit_log.AuditLogService,
+) *PostLockService {
+	return &PostLockService{
+		metaService:       metaService,
+		objectInfoService: objectInfoService,
+		auditLogService:   auditLogService,
+	}
+}
+
+// LockPost lock the question or the answer, the reason and the options are updated if it is already locked
+func (ps *PostLockService) LockPost(ctx context.Context, req *schema.LockPostReq) (err error) {
+	objectType, err := ps.getLockableObjectType(ctx, req.ObjectID)
+	if err != nil {
+		return err
+	}
+	lock := &schema.PostLockMeta{
+		UserID:   req.UserID,
+		Reason:   req.Reason,
+		LockVote: req.LockVote,
+		LockedAt: time.Now().Unix(),
+	}
+	value, _ := json.Marshal(lock)
+
+	var before any
+	err = ps.metaService.AddOrUpdateMetaByObjectIdAndKey(ctx, req.ObjectID, entity.PostLockKey,
+		func(meta *entity.Meta, exist bool) (*entity.Meta, error) {
+			if exist {
+				before = parsePostLockMeta(meta)
+			}
+			return &entity.Meta{ObjectID: req.ObjectID, Key: entity.PostLockKey, Value: string(value)}, nil
+		})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	ps.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionPostLock,
+		ObjectType: objectType,
+		ObjectID:   req.ObjectID,
+		Before:     before,
+		After:      lock,
+	})
+	return nil
+}
+
+// UnlockPost unlock the question or the answer
+func (ps *PostLockService) UnlockPost(ctx context.Context, req *schema.UnlockPostReq) (err error) {
+	objectType, err := ps.getLockableObjectType(ctx, req.ObjectID)
+	if err != nil {
+		return err
+	}
+	metas, err := ps.metaService.GetMetaListByObjectIDsAndKey(ctx, []string{req.ObjectID}, entity.PostLockKey)
+	if err != nil {
+		return err
+	}
+	if len(metas) == 0 {
+		return errors.BadRequest(reason.PostNotLocked)
+	}
+	for _, meta := range metas {
+		if err = ps.metaService.RemoveMeta(ctx, meta.ID); err != nil {
+			return err
+		}
+	}
+	ps.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionPostUnlock,
+		ObjectType: objectType,
+		ObjectID:   req.ObjectID,
+		Before:     parsePostLockMeta(metas[0]),
+	})
+	return nil
+}
+
+// GetPostLocks get the locks of the posts, the posts that are not locked are not in the mapping
+func (ps *PostLockService) GetPostLocks(ctx context.Context, objectIDs ...string) (
+	locks map[string]*schema.PostLockMeta, err error) {
+	metas, err := ps.metaService.GetMetaListByObjectIDsAndKey(ctx, objectIDs, entity.PostLockKey)
+	if err != nil {
+		return nil, err
+	}
+	locks = make(map[string]*schema.PostLockMeta, len(metas))
+	for _, meta := range metas {
+		locks[meta.ObjectID] = parsePostLockMeta(meta)
+	}
+	return locks, nil
+}
+
+// GetPostLockInfo get the lock of the post shown to the users, nil if the post is not locked
+func (ps *PostLockService) GetPostLockInfo(ctx context.Context, objectID string) (info *schema.PostLockInfo, err error) {
+	locks, err := ps.GetPostLocks(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	return locks[objectID].ToInfo(), nil
+}
+
+// CheckPostEditable check the object can be answered, commented on or edited. The object is locked if the question
+// it belongs to or the answer it belongs to is locked. The admins and the moderators are not restricted.
+func (ps *PostLockService) CheckPostEditable(ctx context.Context, objectID string, isAdminModerator bool) (err error) {
+	if isAdminModerator {
+		return nil
+	}
+	return ps.checkPostLocked(ctx, objectID, false)
+}
+
+// CheckPostVotable check the object can be voted, only the posts locked with the votes can not be voted
+func (ps *PostLockService) CheckPostVotable(ctx context.Context, objectID string, isAdminModerator bool) (err error) {
+	if isAdminModerator {
+		return nil
+	}
+	return ps.checkPostLocked(ctx, objectID, true)
+}
+
+func (ps *PostLockService) checkPostLocked(ctx context.Context, objectID string, vote bool) (err error) {
+	objInfo, err := ps.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	locks, err := ps.GetPostLocks(ctx, objInfo.QuestionID, objInfo.AnswerID)
+	if err != nil {
+		return err
+	}
+	return checkLocks(locks, objInfo.QuestionID, objInfo.AnswerID, vote)
+}
+
+// checkLocks the lock of the question takes precedence over the lock of the answer
+func checkLocks(locks map[string]*schema.PostLockMeta, questionID, answerID string, vote bool) error {
+	if lock, ok := locks[questionID]; ok && len(questionID) > 0 && (!vote || lock.LockVote) {
+		return errors.BadRequest(reason.QuestionLocked)
+	}
+	if lock, ok := locks[answerID]; ok && len(answerID) > 0 && (!vote || lock.LockVote) {
+		return errors.BadRequest(reason.AnswerLocked)
+	}
+	return nil
+}
+
+// getLockableObjectType only the questions and the answers can be locked
+func (ps *PostLockService) getLockableObjectType(ctx context.Context, objectID string) (objectType string, err error) {
+	objectType, err = obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return "", errors.BadRequest(reason.ObjectNotFound)
+	}
+	if objectType != constant.QuestionObjectType && objectType != constant.AnswerObjectType {
+		return "", errors.BadRequest(reason.PostLockUnsupported)
+	}
+	if _, err = ps.objectInfoService.GetInfo(ctx, objectID); err != nil {
+		return "", err
+	}
+	return objectType, nil
+}
+
+func parsePostLockMeta(meta *entity.Meta) *schema.PostLockMeta {
+	lock := &schema.PostLockMeta{}
+	if err := json.Unmarshal([]byte(meta.Value), lock); err != nil {
+		log.Errorf("parse post lock meta failed, object id: %s, err: %v", meta.ObjectID, err)
+	}
+	return lock
+}
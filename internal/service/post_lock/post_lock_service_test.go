@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package post_lock
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertReason(t *testing.T, err error, expected string) {
+	t.Helper()
+	if len(expected) == 0 {
+		assert.NoError(t, err)
+		return
+	}
+	assert.Error(t, err)
+	assert.Equal(t, expected, err.(*errors.Error).Reason)
+}
+
+func TestCheckLocks(t *testing.T) {
+	locks := map[string]*schema.PostLockMeta{
+		"10010000000000001": {Reason: "heated"},
+		"10020000000000002": {Reason: "spam", LockVote: true},
+	}
+	tests := []struct {
+		name       string
+		questionID string
+		answerID   string
+		vote       bool
+		expected   string
+	}{
+		{name: "question locked", questionID: "10010000000000001", expected: reason.QuestionLocked},
+		{name: "question locked without the votes", questionID: "10010000000000001", vote: true},
+		{name: "answer locked", questionID: "10010000000000003", answerID: "10020000000000002",
+			expected: reason.AnswerLocked},
+		{name: "answer locked with the votes", questionID: "10010000000000003", answerID: "10020000000000002",
+			vote: true, expected: reason.AnswerLocked},
+		{name: "question lock takes precedence", questionID: "10010000000000001", answerID: "10020000000000002",
+			expected: reason.QuestionLocked},
+		{name: "not locked", questionID: "10010000000000003", answerID: "10020000000000004"},
+		{name: "empty answer id", questionID: "10010000000000003"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertReason(t, checkLocks(locks, tt.questionID, tt.answerID, tt.vote), tt.expected)
+		})
+	}
+}
+
+func TestPostLockMeta_ToInfo(t *testing.T) {
+	var lock *schema.PostLockMeta
+	assert.Nil(t, lock.ToInfo())
+
+	lock = &schema.PostLockMeta{UserID: "1", Reason: "heated", LockVote: true, LockedAt: 100}
+	assert.Equal(t, &schema.PostLockInfo{Reason: "heated", LockVote: true, LockedAt: 100}, lock.ToInfo())
+}
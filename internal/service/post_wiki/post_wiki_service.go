@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package post_wiki
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PostWikiService convert the questions and the answers to the community wiki posts. The wiki posts are owned by
+// the community, the users who meet the rank can edit them without the review and the votes earn no reputation.
+type PostWikiService struct {
+	questionRepo    questioncommon.QuestionRepo
+	answerRepo      answercommon.AnswerRepo
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewPostWikiService new post wiki service
+func NewPostWikiService(
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	auditLogService *audit_log.AuditLogService,
+) *PostWikiService {
+	return &PostWikiService{
+		questionRepo:    questionRepo,
+		answerRepo:      answerRepo,
+		auditLogService: auditLogService,
+	}
+}
+
+// UpdatePostWiki convert the post to the community wiki post, it is one-way except for the admins
+func (ps *PostWikiService) UpdatePostWiki(ctx context.Context, req *schema.UpdatePostWikiReq) (err error) {
+	if !req.Wiki && !req.IsAdmin {
+		return errors.Forbidden(reason.PostWikiRevertForbidden)
+	}
+	objectType, err := obj.GetObjectTypeStrByObjectID(req.ObjectID)
+	if err != nil {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+
+	var wiki bool
+	switch objectType {
+	case constant.QuestionObjectType:
+		questionInfo, exist, err := ps.questionRepo.GetQuestion(ctx, req.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+			return errors.NotFound(reason.QuestionNotFound)
+		}
+		wiki = questionInfo.Wiki
+		if wiki == req.Wiki {
+			return nil
+		}
+		err = ps.questionRepo.UpdateQuestion(ctx, &entity.Question{ID: req.ObjectID, Wiki: req.Wiki}, []string{"wiki"})
+		if err != nil {
+			return err
+		}
+	case constant.AnswerObjectType:
+		answerInfo, exist, err := ps.answerRepo.GetAnswer(ctx, req.ObjectID)
+		if err != nil {
+			return err
+		}
+		if !exist || answerInfo.Status == entity.AnswerStatusDeleted {
+			return errors.NotFound(reason.AnswerNotFound)
+		}
+		wiki = answerInfo.Wiki
+		if wiki == req.Wiki {
+			return nil
+		}
+		err = ps.answerRepo.UpdateAnswer(ctx, &entity.Answer{ID: req.ObjectID, Wiki: req.Wiki}, []string{"wiki"})
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.BadRequest(reason.PostWikiUnsupported)
+	}
+
+	ps.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionPostWikiChange,
+		ObjectType: objectType,
+		ObjectID:   req.ObjectID,
+		Before:     map[string]any{"wiki": wiki},
+		After:      map[string]any{"wiki": req.Wiki},
+	})
+	return nil
+}
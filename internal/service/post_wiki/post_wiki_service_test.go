@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package post_wiki
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeQuestionRepo struct {
+	questioncommon.QuestionRepo
+	question *entity.Question
+	updated  []string
+}
+
+func (r *fakeQuestionRepo) GetQuestion(_ context.Context, id string) (*entity.Question, bool, error) {
+	if r.question == nil || r.question.ID != id {
+		return nil, false, nil
+	}
+	return r.question, true, nil
+}
+
+func (r *fakeQuestionRepo) UpdateQuestion(_ context.Context, question *entity.Question, cols []string) error {
+	r.question.Wiki = question.Wiki
+	r.updated = cols
+	return nil
+}
+
+type fakeAnswerRepo struct {
+	answercommon.AnswerRepo
+	answer *entity.Answer
+}
+
+func (r *fakeAnswerRepo) GetAnswer(_ context.Context, id string) (*entity.Answer, bool, error) {
+	if r.answer == nil || r.answer.ID != id {
+		return nil, false, nil
+	}
+	return r.answer, true, nil
+}
+
+func (r *fakeAnswerRepo) UpdateAnswer(_ context.Context, answer *entity.Answer, _ []string) error {
+	r.answer.Wiki = answer.Wiki
+	return nil
+}
+
+type fakeAuditLogRepo struct {
+	audit_log.AuditLogRepo
+	logs []*entity.AuditLog
+}
+
+func (r *fakeAuditLogRepo) AddAuditLog(_ context.Context, auditLog *entity.AuditLog) error {
+	r.logs = append(r.logs, auditLog)
+	return nil
+}
+
+func assertReason(t *testing.T, err error, expected string) {
+	t.Helper()
+	assert.Error(t, err)
+	assert.Equal(t, expected, err.(*errors.Error).Reason)
+}
+
+func TestPostWikiService_UpdatePostWiki(t *testing.T) {
+	questionRepo := &fakeQuestionRepo{question: &entity.Question{ID: "10010000000000001",
+		Status: entity.QuestionStatusAvailable}}
+	answerRepo := &fakeAnswerRepo{answer: &entity.Answer{ID: "10020000000000001", Status: entity.AnswerStatusAvailable}}
+	auditLogRepo := &fakeAuditLogRepo{}
+	ps := NewPostWikiService(questionRepo, answerRepo, audit_log.NewAuditLogService(auditLogRepo, nil))
+	ctx := context.TODO()
+
+	// the unchanged state is neither updated nor recorded
+	assert.NoError(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10010000000000001", IsAdmin: true}))
+	assert.Nil(t, questionRepo.updated)
+	assert.Empty(t, auditLogRepo.logs)
+
+	assert.NoError(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10010000000000001", Wiki: true,
+		UserID: "1"}))
+	assert.True(t, questionRepo.question.Wiki)
+	assert.Equal(t, []string{"wiki"}, questionRepo.updated)
+	assert.Len(t, auditLogRepo.logs, 1)
+	assert.JSONEq(t, `{"wiki":true}`, auditLogRepo.logs[0].After)
+
+	assert.NoError(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10020000000000001", Wiki: true}))
+	assert.True(t, answerRepo.answer.Wiki)
+
+	assertReason(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10010000000000001"}),
+		reason.PostWikiRevertForbidden)
+	assert.True(t, questionRepo.question.Wiki)
+	assert.NoError(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10010000000000001", IsAdmin: true}))
+	assert.False(t, questionRepo.question.Wiki)
+
+	assertReason(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10030000000000001", Wiki: true}),
+		reason.PostWikiUnsupported)
+	assertReason(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10010000000000002", Wiki: true}),
+		reason.QuestionNotFound)
+
+	answerRepo.answer.Status = entity.AnswerStatusDeleted
+	assertReason(t, ps.UpdatePostWiki(ctx, &schema.UpdatePostWikiReq{ObjectID: "10020000000000001", Wiki: true}),
+		reason.AnswerNotFound)
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package posting_restriction
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// PostingRestrictionRepo posting restriction repository
+type PostingRestrictionRepo interface {
+	// GetUserPostStat get the amount of the questions and answers of the user since the time and the time of the last one
+	GetUserPostStat(ctx context.Context, userID string, since time.Time) (postAmount int64, lastPostAt time.Time, err error)
+}
+
+// PostingRestrictionService restrict the posting of the new accounts and the users with low reputation
+type PostingRestrictionService struct {
+	postingRestrictionRepo PostingRestrictionRepo
+	siteInfoService        siteinfo_common.SiteInfoCommonService
+	userRepo               usercommon.UserRepo
+	userRoleRelService     *role.UserRoleRelService
+}
+
+// NewPostingRestrictionService new posting restriction service
+func NewPostingRestrictionService(
+	postingRestrictionRepo PostingRestrictionRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+) *PostingRestrictionService {
+	return &PostingRestrictionService{
+		postingRestrictionRepo: postingRestrictionRepo,
+		siteInfoService:        siteInfoService,
+		userRepo:               userRepo,
+		userRoleRelService:     userRoleRelService,
+	}
+}
+
+// CheckCanPost check the user can post the questions or answers with the html contents submitted together.
+// The admins, the moderators and the trusted users are not restricted.
+func (ps *PostingRestrictionService) CheckCanPost(ctx context.Context, userID string, htmls ...string) (err error) {
+	config, err := ps.siteInfoService.GetSitePostingRestriction(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.Enabled {
+		return nil
+	}
+	user, exist, err := ps.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if config.IsTrusted(user.Rank, user.CreatedAt) {
+		return nil
+	}
+	roleID, err := ps.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		log.Errorf("get user role failed, err: %v", err)
+	}
+	if ps.userRoleRelService.IsAdminModeratorRole(ctx, roleID) {
+		return nil
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	if config.MaxLinksPerPost > 0 {
+		for _, html := range htmls {
+			if htmltext.CountLinks(html) > config.MaxLinksPerPost {
+				msg := translator.TrWithData(lang, reason.PostingTooManyLinks,
+					&schema.PostingRestrictionTrTplData{Amount: config.MaxLinksPerPost})
+				return errors.BadRequest(reason.PostingTooManyLinks).WithMsg(msg)
+			}
+		}
+	}
+	if config.MaxPostsPerDay <= 0 && config.PostIntervalSeconds <= 0 {
+		return nil
+	}
+
+	postAmount, lastPostAt, err := ps.postingRestrictionRepo.GetUserPostStat(ctx, userID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+	if config.MaxPostsPerDay > 0 && postAmount+int64(len(htmls)) > int64(config.MaxPostsPerDay) {
+		msg := translator.TrWithData(lang, reason.PostingDailyLimitReached,
+			&schema.PostingRestrictionTrTplData{Amount: config.MaxPostsPerDay})
+		return errors.BadRequest(reason.PostingDailyLimitReached).WithMsg(msg)
+	}
+	interval := time.Duration(config.PostIntervalSeconds) * time.Second
+	if interval > 0 && !lastPostAt.IsZero() && time.Since(lastPostAt) < interval {
+		msg := translator.TrWithData(lang, reason.PostingTooFrequent,
+			&schema.PostingRestrictionTrTplData{Seconds: config.PostIntervalSeconds})
+		return errors.BadRequest(reason.PostingTooFrequent).WithMsg(msg)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package posting_restriction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/apache/answer/internal/service/role"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeUserRepo struct {
+	usercommon.UserRepo
+	user *entity.User
+}
+
+func (r *fakeUserRepo) GetByUserID(_ context.Context, _ string) (*entity.User, bool, error) {
+	return r.user, true, nil
+}
+
+type fakeUserRoleRelRepo struct {
+	role.UserRoleRelRepo
+	roleID int
+}
+
+func (r *fakeUserRoleRelRepo) GetUserRoleRel(_ context.Context, userID string) (*entity.UserRoleRel, bool, error) {
+	return &entity.UserRoleRel{UserID: userID, RoleID: r.roleID}, true, nil
+}
+
+type fakePostingRestrictionRepo struct {
+	postAmount int64
+	lastPostAt time.Time
+}
+
+func (r *fakePostingRestrictionRepo) GetUserPostStat(_ context.Context, _ string, _ time.Time) (int64, time.Time, error) {
+	return r.postAmount, r.lastPostAt, nil
+}
+
+func newTestPostingRestrictionService(t *testing.T, config *schema.SitePostingRestrictionResp, user *entity.User,
+	roleID int, stat *fakePostingRestrictionRepo) *PostingRestrictionService {
+	ctrl := gomock.NewController(t)
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+	siteInfoService.EXPECT().GetSitePostingRestriction(gomock.Any()).Return(config, nil).AnyTimes()
+	return NewPostingRestrictionService(stat, siteInfoService, &fakeUserRepo{user: user},
+		role.NewUserRoleRelService(&fakeUserRoleRelRepo{roleID: roleID}, nil))
+}
+
+func assertReason(t *testing.T, err error, r string) {
+	t.Helper()
+	if assert.Error(t, err) {
+		assert.Equal(t, r, err.(*errors.Error).Reason)
+	}
+}
+
+func TestPostingRestrictionService_CheckCanPost(t *testing.T) {
+	ctx := context.TODO()
+	config := &schema.SitePostingRestrictionResp{
+		Enabled:             true,
+		MaxPostsPerDay:      3,
+		MaxLinksPerPost:     1,
+		PostIntervalSeconds: 60,
+	}
+	newUser := &entity.User{ID: "1", Rank: 1, CreatedAt: time.Now()}
+	twoLinks := `<a href="https://a.test">a</a> <a href="https://b.test">b</a>`
+
+	ps := newTestPostingRestrictionService(t, config, newUser, role.RoleUserID, &fakePostingRestrictionRepo{})
+	assert.NoError(t, ps.CheckCanPost(ctx, "1", `<a href="https://a.test">a</a>`))
+	assertReason(t, ps.CheckCanPost(ctx, "1", twoLinks), reason.PostingTooManyLinks)
+
+	ps = newTestPostingRestrictionService(t, config, newUser, role.RoleUserID,
+		&fakePostingRestrictionRepo{postAmount: 2, lastPostAt: time.Now().Add(-time.Hour)})
+	assert.NoError(t, ps.CheckCanPost(ctx, "1", "<p>question</p>"))
+	// the question and the answer posted together are both counted
+	assertReason(t, ps.CheckCanPost(ctx, "1", "<p>question</p>", "<p>answer</p>"), reason.PostingDailyLimitReached)
+
+	ps = newTestPostingRestrictionService(t, config, newUser, role.RoleUserID,
+		&fakePostingRestrictionRepo{postAmount: 1, lastPostAt: time.Now().Add(-10 * time.Second)})
+	assertReason(t, ps.CheckCanPost(ctx, "1", "<p>answer</p>"), reason.PostingTooFrequent)
+}
+
+func TestPostingRestrictionService_Bypass(t *testing.T) {
+	ctx := context.TODO()
+	config := &schema.SitePostingRestrictionResp{Enabled: true, MaxLinksPerPost: 1, TrustedReputation: 50, TrustedAccountDays: 1}
+	twoLinks := `<a href="https://a.test">a</a> <a href="https://b.test">b</a>`
+	stat := &fakePostingRestrictionRepo{}
+
+	trusted := &entity.User{ID: "1", Rank: 50, CreatedAt: time.Now().AddDate(0, 0, -2)}
+	ps := newTestPostingRestrictionService(t, config, trusted, role.RoleUserID, stat)
+	assert.NoError(t, ps.CheckCanPost(ctx, "1", twoLinks))
+
+	// the reputation is enough but the account is too new
+	newAccount := &entity.User{ID: "1", Rank: 50, CreatedAt: time.Now()}
+	ps = newTestPostingRestrictionService(t, config, newAccount, role.RoleUserID, stat)
+	assertReason(t, ps.CheckCanPost(ctx, "1", twoLinks), reason.PostingTooManyLinks)
+
+	ps = newTestPostingRestrictionService(t, config, newAccount, role.RoleModeratorID, stat)
+	assert.NoError(t, ps.CheckCanPost(ctx, "1", twoLinks))
+
+	config.Enabled = false
+	ps = newTestPostingRestrictionService(t, config, newAccount, role.RoleUserID, stat)
+	assert.NoError(t, ps.CheckCanPost(ctx, "1", twoLinks))
+}
@@ -27,22 +27,31 @@ import (
 	"github.com/apache/answer/internal/service/ai_conversation"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/apikey"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/auth"
 	"github.com/apache/answer/internal/service/badge"
+	"github.com/apache/answer/internal/service/bounty"
 	"github.com/apache/answer/internal/service/collection"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
 	"github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/content_processor"
 	"github.com/apache/answer/internal/service/dashboard"
+	"github.com/apache/answer/internal/service/draft"
 	"github.com/apache/answer/internal/service/embedding"
 	"github.com/apache/answer/internal/service/eventqueue"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/feature_toggle"
+	"github.com/apache/answer/internal/service/feed"
 	"github.com/apache/answer/internal/service/file_record"
 	"github.com/apache/answer/internal/service/follow"
+	"github.com/apache/answer/internal/service/guest_post"
+	"github.com/apache/answer/internal/service/health"
+	"github.com/apache/answer/internal/service/impersonation"
 	"github.com/apache/answer/internal/service/importer"
+	"github.com/apache/answer/internal/service/jobqueue"
 	"github.com/apache/answer/internal/service/meta"
 	metacommon "github.com/apache/answer/internal/service/meta_common"
 	"github.com/apache/answer/internal/service/noticequeue"
@@ -50,7 +59,11 @@ import (
 	notficationcommon "github.com/apache/answer/internal/service/notification_common"
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/plugin_common"
+	"github.com/apache/answer/internal/service/post_lock"
+	"github.com/apache/answer/internal/service/post_wiki"
+	"github.com/apache/answer/internal/service/posting_restriction"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_view"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/reason"
 	"github.com/apache/answer/internal/service/report"
@@ -58,17 +71,26 @@ import (
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/saved_search"
 	"github.com/apache/answer/internal/service/search_parser"
 	"github.com/apache/answer/internal/service/siteinfo"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/sitemap"
+	"github.com/apache/answer/internal/service/spam"
+	"github.com/apache/answer/internal/service/stale_question"
 	"github.com/apache/answer/internal/service/tag"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/internal/service/tag_moderator"
 	"github.com/apache/answer/internal/service/uploader"
 	"github.com/apache/answer/internal/service/user_admin"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/internal/service/user_data_export"
 	"github.com/apache/answer/internal/service/user_external_login"
+	"github.com/apache/answer/internal/service/user_mute"
 	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/user_two_factor"
 	"github.com/apache/answer/internal/service/vector_sync"
+	"github.com/apache/answer/internal/service/webhook"
 	"github.com/google/wire"
 )
 
@@ -117,6 +139,12 @@ var ProviderSetService = wire.NewSet(
 	role.NewRolePowerRelService,
 	user_external_login.NewUserExternalLoginService,
 	user_external_login.NewUserCenterLoginService,
+	user_two_factor.NewUserTwoFactorService,
+	webhook.NewWebhookService,
+	spam.NewSpamService,
+	bounty.NewBountyService,
+	feed.NewFeedService,
+	health.NewHealthService,
 	plugin_common.NewPluginCommonService,
 	config.NewConfigService,
 	noticequeue.NewService,
@@ -127,6 +155,7 @@ var ProviderSetService = wire.NewSet(
 	review.NewReviewService,
 	meta.NewMetaService,
 	eventqueue.NewService,
+	jobqueue.NewService,
 	badge.NewBadgeService,
 	badge.NewBadgeEventService,
 	badge.NewBadgeAwardService,
@@ -138,4 +167,19 @@ var ProviderSetService = wire.NewSet(
 	feature_toggle.NewFeatureToggleService,
 	embedding.NewEmbeddingService,
 	vector_sync.NewService,
+	audit_log.NewAuditLogService,
+	stale_question.NewStaleQuestionService,
+	user_data_export.NewUserDataExportService,
+	draft.NewDraftService,
+	user_mute.NewUserMuteService,
+	posting_restriction.NewPostingRestrictionService,
+	post_lock.NewPostLockService,
+	post_wiki.NewPostWikiService,
+	question_view.NewQuestionViewService,
+	saved_search.NewSavedSearchService,
+	guest_post.NewGuestPostService,
+	sitemap.NewSitemapService,
+	tag_moderator.NewTagModeratorService,
+	impersonation.NewImpersonationService,
+	content_processor.NewContentProcessorService,
 )
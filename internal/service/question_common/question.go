@@ -23,9 +23,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/apache/answer/internal/service/siteinfo_common"
 
@@ -33,9 +34,12 @@ import (
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activityqueue"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/jobqueue"
 	metacommon "github.com/apache/answer/internal/service/meta_common"
 	"github.com/apache/answer/internal/service/revision"
 	"github.com/apache/answer/pkg/checker"
@@ -57,18 +61,40 @@ type QuestionRepo interface {
 	AddQuestion(ctx context.Context, question *entity.Question) (err error)
 	RemoveQuestion(ctx context.Context, id string) (err error)
 	UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error)
+	UpdateTrendingScores(ctx context.Context, scores map[string]int) (err error)
+	ResetTrendingScores(ctx context.Context, createdBefore time.Time) (err error)
 	GetQuestion(ctx context.Context, id string) (question *entity.Question, exist bool, err error)
 	GetQuestionList(ctx context.Context, question *entity.Question) (questions []*entity.Question, err error)
-	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool, mutedByUserID string) (
 		questionList []*entity.Question, total int64, err error)
+	GetQuestionPageByCursor(ctx context.Context, cond *entity.QuestionPageCond, cursor string, pageSize int) (
+		questionList []*entity.Question, nextCursor string, err error)
 	GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, followedQuestionIDs []string, page, pageSize int) (questionList []*entity.Question, total int64, err error)
 	UpdateQuestionStatus(ctx context.Context, questionID string, status int) (err error)
 	UpdateQuestionStatusWithOutUpdateTime(ctx context.Context, question *entity.Question) (err error)
+	UpdateQuestionSchedule(ctx context.Context, questionID string, scheduledAt time.Time) (updated bool, err error)
+	GetDueScheduledQuestions(ctx context.Context, now time.Time, limit int) (questionList []*entity.Question, err error)
+	GetStaleQuestions(ctx context.Context, inactiveBefore time.Time, exemptTagIDs []string, limit int) (
+		questionList []*entity.Question, err error)
+	PublishScheduledQuestion(ctx context.Context, questionID string, publishAt time.Time) (published bool, err error)
 	DeletePermanentlyQuestions(ctx context.Context) (err error)
+	PurgeDeletedQuestions(ctx context.Context, deletedBefore time.Time) (count int64, err error)
+	GetDeletedQuestionPage(ctx context.Context, page, pageSize int, deletedAfter time.Time) (
+		questionList []*entity.Question, total int64, err error)
 	RecoverQuestion(ctx context.Context, questionID string) (err error)
 	UpdateQuestionOperation(ctx context.Context, question *entity.Question) (err error)
+	GetPinnedQuestionCount(ctx context.Context) (count int64, err error)
+	UnpinExpiredQuestions(ctx context.Context, now time.Time) (count int64, err error)
 	GetQuestionsByTitle(ctx context.Context, title string, pageSize int) (questionList []*entity.Question, err error)
-	UpdatePvCount(ctx context.Context, questionID string) (err error)
+	GetRelatedQuestionsByTags(ctx context.Context, questionID string, tagIDs []string, limit int) (
+		questionList []*entity.Question, sharedTagCount map[string]int, err error)
+	GetRelatedQuestionsCache(ctx context.Context, questionID string) (resp []*schema.RelatedQuestionResp, exist bool, err error)
+	SetRelatedQuestionsCache(ctx context.Context, questionID string, resp []*schema.RelatedQuestionResp) (err error)
+	GetQuestionPayloadVersion(ctx context.Context, questionID string) (version string, err error)
+	GetQuestionPayloadCache(ctx context.Context, questionID, version, variant string, value any) (exist bool, err error)
+	SetQuestionPayloadCache(ctx context.Context, questionID, version, variant string, value any) (err error)
+	RemoveQuestionPayloadCache(ctx context.Context, questionID string) (err error)
+	UpdatePvCount(ctx context.Context, questionID string, count int) (err error)
 	UpdateAnswerCount(ctx context.Context, questionID string, num int) (err error)
 	UpdateCollectionCount(ctx context.Context, questionID string) (count int64, err error)
 	UpdateAccepted(ctx context.Context, question *entity.Question) (err error)
@@ -79,9 +105,9 @@ type QuestionRepo interface {
 	GetUnansweredQuestionCount(ctx context.Context) (count int64, err error)
 	GetResolvedQuestionCount(ctx context.Context) (count int64, err error)
 	GetUserQuestionCount(ctx context.Context, userID string, show int) (count int64, err error)
-	SitemapQuestions(ctx context.Context, page, pageSize int) (questionIDList []*schema.SiteMapQuestionInfo, err error)
 	RemoveAllUserQuestion(ctx context.Context, userID string) (err error)
 	UpdateSearch(ctx context.Context, questionID string) (err error)
+	IndexSearch(ctx context.Context, questionID string) (err error)
 	LinkQuestion(ctx context.Context, link ...*entity.QuestionLink) (err error)
 	GetLinkedQuestionIDs(ctx context.Context, questionID string, status int) (questionIDs []string, err error)
 	UpdateQuestionLinkCount(ctx context.Context, questionID string) (err error)
@@ -123,7 +149,15 @@ func NewQuestionCommon(questionRepo QuestionRepo,
 	revisionRepo revision.RevisionRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	data *data.Data,
+	jobQueue *jobqueue.Service,
 ) *QuestionCommon {
+	// the search index is updated by the background jobs after the questions and the answers are changed
+	jobqueue.Register(jobQueue, constant.JobTypeSearchIndexQuestion, func(ctx context.Context, msg *schema.SearchIndexJob) error {
+		return questionRepo.IndexSearch(ctx, msg.ObjectID)
+	}, nil)
+	jobqueue.Register(jobQueue, constant.JobTypeSearchIndexAnswer, func(ctx context.Context, msg *schema.SearchIndexJob) error {
+		return answerRepo.IndexSearch(ctx, msg.ObjectID)
+	}, nil)
 	return &QuestionCommon{
 		questionRepo:         questionRepo,
 		answerRepo:           answerRepo,
@@ -154,10 +188,6 @@ func (qs *QuestionCommon) GetPersonalUserQuestionCount(ctx context.Context, logi
 	return qs.questionRepo.GetUserQuestionCount(ctx, userID, show)
 }
 
-func (qs *QuestionCommon) UpdatePv(ctx context.Context, questionID string) error {
-	return qs.questionRepo.UpdatePvCount(ctx, questionID)
-}
-
 func (qs *QuestionCommon) UpdateAnswerCount(ctx context.Context, questionID string) error {
 	count, err := qs.answerRepo.GetCountByQuestionID(ctx, questionID)
 	if err != nil {
@@ -251,7 +281,89 @@ func (qs *QuestionCommon) InviteUserInfo(ctx context.Context, questionID string)
 	return InviteUserInfo, nil
 }
 
+// questionInfoPayload the cached question info that is the same for all the viewers, the ids hidden from the
+// json response are kept beside it
+type questionInfoPayload struct {
+	Info               *schema.QuestionInfoResp `json:"info"`
+	UpdateTime         int64                    `json:"update_time"`
+	UserID             string                   `json:"user_id"`
+	LastEditUserID     string                   `json:"last_edit_user_id"`
+	LastAnsweredUserID string                   `json:"last_answered_user_id"`
+}
+
 func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUserID string) (resp *schema.QuestionInfoResp, err error) {
+	resp, err = qs.sharedInfo(ctx, questionID)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if len(loginUserID) == 0 {
+		return resp, nil
+	}
+
+	questionID = uid.DeShortID(questionID)
+	resp.VoteStatus = qs.voteRepo.GetVoteStatus(ctx, questionID, loginUserID)
+	resp.IsFollowed, _ = qs.followCommon.IsFollowed(ctx, loginUserID, questionID)
+
+	ids, err := qs.AnswerCommon.SearchAnswerIDs(ctx, loginUserID, questionID)
+	if err != nil {
+		log.Error("AnswerFunc.SearchAnswerIDs", err)
+	}
+	resp.Answered = len(ids) > 0
+	if resp.Answered {
+		resp.FirstAnswerId = ids[0]
+	}
+
+	collectedMap, err := qs.collectionCommon.SearchObjectCollected(ctx, loginUserID, []string{questionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(collectedMap) > 0 {
+		resp.Collected = true
+	}
+	return resp, nil
+}
+
+// sharedInfo get the question info that is the same for all the viewers through the cache,
+// the version of the cache is got before the question is read so that the concurrent edits are not lost
+func (qs *QuestionCommon) sharedInfo(ctx context.Context, questionID string) (resp *schema.QuestionInfoResp, err error) {
+	variant := fmt.Sprintf("info:%s:%t", handler.GetLangByCtx(ctx), handler.GetEnableShortID(ctx))
+	version, err := qs.questionRepo.GetQuestionPayloadVersion(ctx, questionID)
+	if err != nil {
+		log.Error(err)
+	} else {
+		payload := &questionInfoPayload{}
+		exist, err := qs.questionRepo.GetQuestionPayloadCache(ctx, questionID, version, variant, payload)
+		if err != nil {
+			log.Error(err)
+		}
+		if exist && payload.Info != nil {
+			resp = payload.Info
+			resp.UpdateTime = payload.UpdateTime
+			resp.UserID = payload.UserID
+			resp.LastEditUserID = payload.LastEditUserID
+			resp.LastAnsweredUserID = payload.LastAnsweredUserID
+			return resp, nil
+		}
+	}
+
+	resp, err = qs.formatSharedInfo(ctx, questionID)
+	if err != nil || resp == nil || len(version) == 0 {
+		return resp, err
+	}
+	err = qs.questionRepo.SetQuestionPayloadCache(ctx, questionID, version, variant, &questionInfoPayload{
+		Info:               resp,
+		UpdateTime:         resp.UpdateTime,
+		UserID:             resp.UserID,
+		LastEditUserID:     resp.LastEditUserID,
+		LastAnsweredUserID: resp.LastAnsweredUserID,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return resp, nil
+}
+
+func (qs *QuestionCommon) formatSharedInfo(ctx context.Context, questionID string) (resp *schema.QuestionInfoResp, err error) {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, questionID)
 	if err != nil {
 		return resp, err
@@ -284,6 +396,9 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 					operation.Msg = closeMsg.CloseMsg
 					operation.Time = metaInfo.CreatedAt.Unix()
 					operation.Level = schema.OperationLevelInfo
+					if cfg.Key == constant.ReasonADuplicate {
+						operation.DuplicateQuestion = qs.getDuplicateQuestionInfo(ctx, closeMsg)
+					}
 					resp.Operation = operation
 				}
 			}
@@ -335,29 +450,6 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 	resp.UserInfo = userInfoMap[questionInfo.UserID]
 	resp.UpdateUserInfo = userInfoMap[questionInfo.LastEditUserID]
 	resp.LastAnsweredUserInfo = userInfoMap[resp.LastAnsweredUserID]
-	if len(loginUserID) == 0 {
-		return resp, nil
-	}
-
-	resp.VoteStatus = qs.voteRepo.GetVoteStatus(ctx, questionID, loginUserID)
-	resp.IsFollowed, _ = qs.followCommon.IsFollowed(ctx, loginUserID, questionID)
-
-	ids, err := qs.AnswerCommon.SearchAnswerIDs(ctx, loginUserID, questionInfo.ID)
-	if err != nil {
-		log.Error("AnswerFunc.SearchAnswerIDs", err)
-	}
-	resp.Answered = len(ids) > 0
-	if resp.Answered {
-		resp.FirstAnswerId = ids[0]
-	}
-
-	collectedMap, err := qs.collectionCommon.SearchObjectCollected(ctx, loginUserID, []string{questionInfo.ID})
-	if err != nil {
-		return nil, err
-	}
-	if len(collectedMap) > 0 {
-		resp.Collected = true
-	}
 	return resp, nil
 }
 
@@ -598,30 +690,6 @@ func (qs *QuestionCommon) RemoveAnswer(ctx context.Context, id string) (err erro
 	return qs.answerRepo.RemoveAnswer(ctx, id)
 }
 
-func (qs *QuestionCommon) SitemapCron(ctx context.Context) {
-	questionNum, err := qs.questionRepo.GetQuestionCount(ctx)
-	if err != nil {
-		log.Error(err)
-		return
-	}
-	if questionNum <= constant.SitemapMaxSize {
-		_, err = qs.questionRepo.SitemapQuestions(ctx, 1, int(questionNum))
-		if err != nil {
-			log.Errorf("get site map question error: %v", err)
-		}
-		return
-	}
-
-	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
-	for i := 1; i <= totalPages; i++ {
-		_, err = qs.questionRepo.SitemapQuestions(ctx, i, constant.SitemapMaxSize)
-		if err != nil {
-			log.Errorf("get site map question error: %v", err)
-			return
-		}
-	}
-}
-
 func (qs *QuestionCommon) SetCache(ctx context.Context, cachekey string, info any) error {
 	infoStr, err := json.Marshal(info)
 	if err != nil {
@@ -668,8 +736,15 @@ func (qs *QuestionCommon) ShowFormat(ctx context.Context, data *entity.Question)
 		info.QuestionUpdateTime = 0
 	}
 	info.Status = data.Status
+	if data.Status == entity.QuestionStatusScheduled && !data.ScheduledAt.IsZero() {
+		info.ScheduledAt = data.ScheduledAt.Unix()
+	}
 	info.Pin = data.Pin
+	if data.Pin == entity.QuestionPin && !data.PinExpiredAt.IsZero() {
+		info.PinExpiredAt = data.PinExpiredAt.Unix()
+	}
 	info.Show = data.Show
+	info.Wiki = data.Wiki
 	info.UserID = data.UserID
 	info.LastEditUserID = data.LastEditUserID
 	if data.LastAnswerID != "0" {
@@ -827,15 +902,14 @@ func (qs *QuestionCommon) UpdateQuestionLink(ctx context.Context, questionID, an
 	return parsedText, nil
 }
 
-// AddQuestionLinkForCloseReason When the reason about close question is a question link, add the link to the question
+// AddQuestionLinkForCloseReason When the question is closed as a duplicate, add the link to the original question
 func (qs *QuestionCommon) AddQuestionLinkForCloseReason(ctx context.Context,
-	questionInfo *entity.Question, closeMsg string) {
-	questionID := qs.tryToGetQuestionIDFromMsg(ctx, closeMsg)
-	if len(questionID) == 0 {
+	questionInfo *entity.Question, linkedQuestionID string) {
+	if len(linkedQuestionID) == 0 {
 		return
 	}
 
-	linkedQuestion, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	linkedQuestion, exist, err := qs.questionRepo.GetQuestion(ctx, linkedQuestionID)
 	if err != nil {
 		log.Errorf("get question error %s", err)
 		return
@@ -853,6 +927,7 @@ func (qs *QuestionCommon) AddQuestionLinkForCloseReason(ctx context.Context,
 	}
 }
 
+// RemoveQuestionLinkForReopen remove the link to the original question and clear the duplicate marker
 func (qs *QuestionCommon) RemoveQuestionLinkForReopen(ctx context.Context, questionInfo *entity.Question) {
 	questionInfo.ID = uid.DeShortID(questionInfo.ID)
 	metaInfo, err := qs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionInfo.ID, entity.QuestionCloseReasonKey)
@@ -863,7 +938,7 @@ func (qs *QuestionCommon) RemoveQuestionLinkForReopen(ctx context.Context, quest
 	closeMsgMeta := &schema.CloseQuestionMeta{}
 	_ = json.Unmarshal([]byte(metaInfo.Value), closeMsgMeta)
 
-	linkedQuestionID := qs.tryToGetQuestionIDFromMsg(ctx, closeMsgMeta.CloseMsg)
+	linkedQuestionID := qs.GetDuplicateQuestionID(ctx, closeMsgMeta)
 	if len(linkedQuestionID) == 0 {
 		return
 	}
@@ -874,6 +949,114 @@ func (qs *QuestionCommon) RemoveQuestionLinkForReopen(ctx context.Context, quest
 	if err != nil {
 		log.Errorf("remove question link error %s", err)
 	}
+
+	// keep the close message for the timeline, only the duplicate marker is cleared
+	if len(closeMsgMeta.DuplicateQuestionID) == 0 {
+		return
+	}
+	closeMsgMeta.DuplicateQuestionID = ""
+	closeMeta, _ := json.Marshal(closeMsgMeta)
+	err = qs.metaCommonService.UpdateMeta(ctx, metaInfo.ID, entity.QuestionCloseReasonKey, string(closeMeta))
+	if err != nil {
+		log.Errorf("clear duplicate question error %s", err)
+	}
+}
+
+// GetDuplicateQuestionID get the original question id from the close reason,
+// the reasons closed before the duplicate question id exists only have the question url in the message
+func (qs *QuestionCommon) GetDuplicateQuestionID(ctx context.Context, closeMeta *schema.CloseQuestionMeta) (questionID string) {
+	if len(closeMeta.DuplicateQuestionID) > 0 {
+		return uid.DeShortID(closeMeta.DuplicateQuestionID)
+	}
+	return qs.tryToGetQuestionIDFromMsg(ctx, closeMeta.CloseMsg)
+}
+
+// CheckDuplicateQuestion check the question can be closed as a duplicate of the original question.
+// The original question must exist and must not be closed as a duplicate of the question, directly or not.
+func (qs *QuestionCommon) CheckDuplicateQuestion(ctx context.Context, questionID, originalQuestionID string) (
+	originalQuestion *entity.Question, err error) {
+	questionID = uid.DeShortID(questionID)
+	originalQuestionID = uid.DeShortID(originalQuestionID)
+	if len(originalQuestionID) == 0 {
+		return nil, errors.BadRequest(reason.QuestionDuplicateNotFound)
+	}
+	if originalQuestionID == questionID {
+		return nil, errors.BadRequest(reason.QuestionDuplicateSelf)
+	}
+	originalQuestion, exist, err := qs.questionRepo.GetQuestion(ctx, originalQuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || originalQuestion.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.QuestionDuplicateNotFound)
+	}
+
+	if duplicateChainReaches(originalQuestionID, questionID, func(id string) string {
+		return qs.getClosedDuplicateQuestionID(ctx, id)
+	}) {
+		return nil, errors.BadRequest(reason.QuestionDuplicateCycle)
+	}
+	return originalQuestion, nil
+}
+
+// getDuplicateQuestionInfo get the original question shown on the duplicate question
+func (qs *QuestionCommon) getDuplicateQuestionInfo(ctx context.Context, closeMeta *schema.CloseQuestionMeta) (
+	info *schema.DuplicateQuestionInfo) {
+	questionID := qs.GetDuplicateQuestionID(ctx, closeMeta)
+	if len(questionID) == 0 {
+		return nil
+	}
+	questionInfo, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		log.Errorf("get duplicate question error %s", err)
+		return nil
+	}
+	if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+		return nil
+	}
+	info = &schema.DuplicateQuestionInfo{
+		ID:       uid.DeShortID(questionInfo.ID),
+		Title:    questionInfo.Title,
+		UrlTitle: htmltext.UrlTitle(questionInfo.Title),
+		Status:   questionInfo.Status,
+	}
+	if handler.GetEnableShortID(ctx) {
+		info.ID = uid.EnShortID(info.ID)
+	}
+	return info
+}
+
+// duplicateChainReaches follow the original questions from the start question, report whether the target is reached
+func duplicateChainReaches(startID, targetID string, next func(id string) string) bool {
+	visited := map[string]bool{startID: true}
+	for id := next(startID); len(id) > 0 && !visited[id]; id = next(id) {
+		if id == targetID {
+			return true
+		}
+		visited[id] = true
+	}
+	return false
+}
+
+// getClosedDuplicateQuestionID get the original question id of the question, empty if it is not closed as a duplicate
+func (qs *QuestionCommon) getClosedDuplicateQuestionID(ctx context.Context, questionID string) string {
+	questionInfo, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil || !exist || questionInfo.Status != entity.QuestionStatusClosed {
+		return ""
+	}
+	metaInfo, err := qs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionID, entity.QuestionCloseReasonKey)
+	if err != nil {
+		return ""
+	}
+	closeMeta := &schema.CloseQuestionMeta{}
+	if err = json.Unmarshal([]byte(metaInfo.Value), closeMeta); err != nil {
+		return ""
+	}
+	cfg, err := qs.configService.GetConfigByID(ctx, closeMeta.CloseType)
+	if err != nil || cfg.Key != constant.ReasonADuplicate {
+		return ""
+	}
+	return qs.GetDuplicateQuestionID(ctx, closeMeta)
 }
 
 func (qs *QuestionCommon) tryToGetQuestionIDFromMsg(ctx context.Context, closeMsg string) (questionID string) {
@@ -899,10 +1082,63 @@ func (qs *QuestionCommon) tryToGetQuestionIDFromMsg(ctx context.Context, closeMs
 	return questionID
 }
 
-func (qs *QuestionCommon) GetMinimumContentLength(ctx context.Context) (int, error) {
+// CheckQuestionQuality check the title and the body of the question by the quality rules of the site,
+// one error field is returned for each failing rule and the error is the reason of the first one
+func (qs *QuestionCommon) CheckQuestionQuality(ctx context.Context, title, content, html string) (
+	errFields []*validator.FormErrorField, err error) {
 	siteInfo, err := qs.siteInfoService.GetSiteQuestion(ctx)
 	if err != nil {
-		return 6, err
+		return nil, err
+	}
+	failures := checkQuestionQuality(siteInfo, title, content, html)
+	if len(failures) == 0 {
+		return nil, nil
+	}
+	lang := handler.GetLangByCtx(ctx)
+	errFields = make([]*validator.FormErrorField, 0, len(failures))
+	for _, f := range failures {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: f.field,
+			ErrorMsg:   translator.Tr(lang, f.reason),
+		})
+	}
+	return errFields, errors.BadRequest(failures[0].reason)
+}
+
+type questionQualityFailure struct {
+	field  string
+	reason string
+}
+
+// checkQuestionQuality get the failing quality rules, the rule is disabled if it is not configured
+func checkQuestionQuality(siteInfo *schema.SiteQuestionsResp, title, content, html string) (
+	failures []*questionQualityFailure) {
+	if siteInfo.MinimumTitle > 0 && utf8.RuneCountInString(strings.TrimSpace(title)) < siteInfo.MinimumTitle {
+		failures = append(failures, &questionQualityFailure{field: "title", reason: reason.QuestionTitleLessThanMinimum})
+	}
+	if len(content) < siteInfo.MinimumContent {
+		failures = append(failures, &questionQualityFailure{field: "content", reason: reason.QuestionContentLessThanMinimum})
+	}
+	if siteInfo.RejectTitleAsContent && isTitleRepeated(title, htmltext.ClearText(html)) {
+		failures = append(failures, &questionQualityFailure{field: "content", reason: reason.QuestionContentRepeatsTitle})
+	}
+	return failures
+}
+
+// isTitleRepeated whether the body is only the title repeated once or more times,
+// the case, the spaces and the punctuations are ignored
+func isTitleRepeated(title, body string) bool {
+	normalize := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsNumber(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, s)
+	}
+	normalizedTitle, normalizedBody := normalize(title), normalize(body)
+	if len(normalizedTitle) == 0 || len(normalizedBody) == 0 {
+		return false
 	}
-	return siteInfo.MinimumContent, nil
+	return len(strings.ReplaceAll(normalizedBody, normalizedTitle, "")) == 0
 }
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package questioncommon
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateChainReaches(t *testing.T) {
+	// 3 is closed as a duplicate of 2, 2 is closed as a duplicate of 1
+	originals := map[string]string{"3": "2", "2": "1"}
+	next := func(id string) string { return originals[id] }
+
+	assert.True(t, duplicateChainReaches("3", "1", next))
+	assert.True(t, duplicateChainReaches("2", "1", next))
+	assert.False(t, duplicateChainReaches("1", "3", next))
+	assert.False(t, duplicateChainReaches("2", "3", next))
+
+	// a broken chain which already has a cycle must end
+	originals["1"] = "3"
+	assert.False(t, duplicateChainReaches("3", "4", next))
+}
+
+func TestCheckQuestionQuality(t *testing.T) {
+	siteInfo := &schema.SiteQuestionsResp{MinimumTitle: 15, MinimumContent: 10, RejectTitleAsContent: true}
+	tests := []struct {
+		name     string
+		siteInfo *schema.SiteQuestionsResp
+		title    string
+		content  string
+		html     string
+		expected []string
+	}{
+		{name: "passed", siteInfo: siteInfo, title: "How to connect to mysql",
+			content: "The connection is refused", html: "<p>The connection is refused</p>"},
+		{name: "all failed", siteInfo: siteInfo, title: "help pls", content: "help pls", html: "<p>help pls</p>",
+			expected: []string{reason.QuestionTitleLessThanMinimum, reason.QuestionContentLessThanMinimum,
+				reason.QuestionContentRepeatsTitle}},
+		{name: "title counted in characters", siteInfo: siteInfo, title: "如何连接数据库", content: "连接总是被拒绝了怎么办",
+			html: "<p>连接总是被拒绝了怎么办</p>", expected: []string{reason.QuestionTitleLessThanMinimum}},
+		{name: "title repeated", siteInfo: siteInfo, title: "How to connect to mysql?",
+			content:  "how to connect to MySQL how to connect to mysql!",
+			html:     "<p>how to connect to MySQL how to connect to mysql!</p>",
+			expected: []string{reason.QuestionContentRepeatsTitle}},
+		{name: "rules disabled", siteInfo: &schema.SiteQuestionsResp{}, title: "help pls", html: "<p>help pls</p>",
+			content: "help pls"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := checkQuestionQuality(tt.siteInfo, tt.title, tt.content, tt.html)
+			reasons := make([]string, 0, len(failures))
+			for _, f := range failures {
+				reasons = append(reasons, f.reason)
+			}
+			if len(tt.expected) == 0 {
+				assert.Empty(t, reasons)
+				return
+			}
+			assert.Equal(t, tt.expected, reasons)
+		})
+	}
+}
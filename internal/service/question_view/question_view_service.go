@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_view
+
+import (
+	"context"
+
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionViewRepo buffers the views of the questions before they are written to the database
+type QuestionViewRepo interface {
+	// MarkViewed marks the question as viewed by the viewer in the dedupe window,
+	// returns false if the viewer has already viewed it in the window
+	MarkViewed(ctx context.Context, questionID, viewer string) (first bool, err error)
+	AddPendingView(ctx context.Context, questionID string) (err error)
+	GetPendingView(ctx context.Context, questionID string) (count int64, err error)
+	GetPendingViews(ctx context.Context) (views map[string]int64, err error)
+	// RemovePendingView removes the flushed amount of the views, the views added after the flush are kept
+	RemovePendingView(ctx context.Context, questionID string, count int64) (err error)
+	// LockFlush locks the flush of the pending views, so the instances sharing the cache do not flush them twice
+	LockFlush(ctx context.Context) (locked bool, err error)
+	UnlockFlush(ctx context.Context) (err error)
+}
+
+// QuestionViewService count the views of the questions
+type QuestionViewService struct {
+	questionViewRepo QuestionViewRepo
+	questionRepo     questioncommon.QuestionRepo
+}
+
+// NewQuestionViewService new question view service
+func NewQuestionViewService(
+	questionViewRepo QuestionViewRepo,
+	questionRepo questioncommon.QuestionRepo,
+) *QuestionViewService {
+	return &QuestionViewService{
+		questionViewRepo: questionViewRepo,
+		questionRepo:     questionRepo,
+	}
+}
+
+// AddView count the view of the question. The views of the author are ignored and every viewer, identified by
+// the user id or the ip for the guests, is counted once in the dedupe window. Returns whether the view is counted.
+func (qs *QuestionViewService) AddView(ctx context.Context, questionID, authorID, viewerUserID, ip string) (
+	counted bool) {
+	if len(viewerUserID) > 0 && viewerUserID == authorID {
+		return false
+	}
+	viewer := viewerUserID
+	if len(viewer) == 0 {
+		if len(ip) == 0 {
+			return false
+		}
+		viewer = "ip:" + ip
+	}
+	questionID = uid.DeShortID(questionID)
+	first, err := qs.questionViewRepo.MarkViewed(ctx, questionID, viewer)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if !first {
+		return false
+	}
+	if err = qs.questionViewRepo.AddPendingView(ctx, questionID); err != nil {
+		log.Error(err)
+		return false
+	}
+	return true
+}
+
+// GetPendingView get the amount of the views of the question that are not flushed yet
+func (qs *QuestionViewService) GetPendingView(ctx context.Context, questionID string) int64 {
+	count, err := qs.questionViewRepo.GetPendingView(ctx, uid.DeShortID(questionID))
+	if err != nil {
+		log.Error(err)
+		return 0
+	}
+	return count
+}
+
+// FlushPendingViews write the buffered views to the view count of the questions.
+// The views that fail to be written are kept in the buffer and retried in the next flush.
+func (qs *QuestionViewService) FlushPendingViews(ctx context.Context) {
+	locked, err := qs.questionViewRepo.LockFlush(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !locked {
+		log.Debugf("the pending views are being flushed by another instance")
+		return
+	}
+	defer func() {
+		if err := qs.questionViewRepo.UnlockFlush(ctx); err != nil {
+			log.Error(err)
+		}
+	}()
+
+	views, err := qs.questionViewRepo.GetPendingViews(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for questionID, count := range views {
+		if count <= 0 {
+			continue
+		}
+		if err = qs.questionRepo.UpdatePvCount(ctx, questionID, int(count)); err != nil {
+			log.Errorf("flush views of question %s failed: %v", questionID, err)
+			continue
+		}
+		if err = qs.questionViewRepo.RemovePendingView(ctx, questionID, count); err != nil {
+			log.Error(err)
+		}
+	}
+}
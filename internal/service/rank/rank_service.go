@@ -21,6 +21,8 @@ package rank
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
@@ -34,10 +36,14 @@ import (
 	"github.com/apache/answer/internal/service/object_info"
 	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/internal/service/tag_moderator"
 	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/uid"
 	"github.com/apache/answer/plugin"
+	"github.com/jinzhu/now"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"xorm.io/xorm"
@@ -54,16 +60,30 @@ type UserRankRepo interface {
 		userID string, userCurrentScore, deltaRank int) (err error)
 	TriggerUserRank(ctx context.Context, session *xorm.Session, userId string, rank int, activityType int) (isReachStandard bool, err error)
 	UserRankPage(ctx context.Context, userId string, page, pageSize int) (rankPage []*entity.Activity, total int64, err error)
+	UserReputationChangePage(ctx context.Context, userID string, activityTypes []int, reversal bool, page, pageSize int) (
+		changes []*entity.ActivityReputationChange, total, newerSum int64, err error)
+	GetReputationLeaderboard(ctx context.Context, since time.Time, tagIDs []string, page, pageSize int) (
+		stats []*entity.ActivityUserRankStat, total int64, err error)
+	GetReputationLeaderboardCache(ctx context.Context, key string) (
+		list []*schema.GetReputationLeaderboardResp, total int64, exist bool, err error)
+	SetReputationLeaderboardCache(ctx context.Context, key string,
+		list []*schema.GetReputationLeaderboardResp, total int64) (err error)
+	ChangeUserTagRank(ctx context.Context, session *xorm.Session,
+		userID, objectID string, activityType, deltaRank int) (err error)
+	GetUserTagRankPage(ctx context.Context, userID string, tagIDs []string, page, pageSize int) (
+		tagRanks []*entity.UserTagRank, total int64, err error)
 }
 
 // RankService rank service
 type RankService struct {
-	userCommon        *usercommon.UserCommon
-	configService     *config.ConfigService
-	userRankRepo      UserRankRepo
-	objectInfoService *object_info.ObjService
-	roleService       *role.UserRoleRelService
-	rolePowerService  *role.RolePowerRelService
+	userCommon          *usercommon.UserCommon
+	configService       *config.ConfigService
+	userRankRepo        UserRankRepo
+	objectInfoService   *object_info.ObjService
+	roleService         *role.UserRoleRelService
+	rolePowerService    *role.RolePowerRelService
+	tagCommonService    *tag_common.TagCommonService
+	tagModeratorService *tag_moderator.TagModeratorService
 }
 
 // NewRankService new rank service
@@ -73,14 +93,18 @@ func NewRankService(
 	objectInfoService *object_info.ObjService,
 	roleService *role.UserRoleRelService,
 	rolePowerService *role.RolePowerRelService,
-	configService *config.ConfigService) *RankService {
+	configService *config.ConfigService,
+	tagCommonService *tag_common.TagCommonService,
+	tagModeratorService *tag_moderator.TagModeratorService) *RankService {
 	return &RankService{
-		userCommon:        userCommon,
-		configService:     configService,
-		userRankRepo:      userRankRepo,
-		objectInfoService: objectInfoService,
-		roleService:       roleService,
-		rolePowerService:  rolePowerService,
+		userCommon:          userCommon,
+		configService:       configService,
+		userRankRepo:        userRankRepo,
+		objectInfoService:   objectInfoService,
+		roleService:         roleService,
+		rolePowerService:    rolePowerService,
+		tagCommonService:    tagCommonService,
+		tagModeratorService: tagModeratorService,
 	}
 }
 
@@ -158,6 +182,51 @@ func (rs *RankService) CheckOperationPermissions(ctx context.Context, userID str
 	return can, err
 }
 
+// tagModeratorActions the actions the tag moderators can do on the questions with the tags they moderate
+var tagModeratorActions = map[string]bool{
+	permission.QuestionEdit:              true,
+	permission.QuestionEditWithoutReview: true,
+	permission.QuestionClose:             true,
+	permission.QuestionReopen:            true,
+}
+
+// tagModeratorTagActions the actions the tag moderators can do within the tags they moderate, the questions are
+// pinned in the tag instead of globally
+var tagModeratorTagActions = map[string]bool{
+	permission.QuestionPin:   true,
+	permission.QuestionUnPin: true,
+}
+
+// CheckTagModeratorPermission check the action is a tag moderator action and the user moderates
+// one of the tags of the question or the question of the answer
+func (rs *RankService) CheckTagModeratorPermission(ctx context.Context, userID, action, objectID string) bool {
+	return tagModeratorActions[action] && rs.tagModeratorService.IsObjectModerator(ctx, userID, uid.DeShortID(objectID))
+}
+
+// CheckTagModeratorTagPermission check the action can be done within a tag and the user moderates the tag
+func (rs *RankService) CheckTagModeratorTagPermission(ctx context.Context, userID, action, tagSlugName string) bool {
+	return tagModeratorTagActions[action] && rs.tagModeratorService.IsTagModerator(ctx, userID, tagSlugName)
+}
+
+// CheckTagModeratorPermissions grant the tag moderator actions of the question or the answer to the user
+// who moderates one of the tags of the question, can[i] is set to true if actions[i] is granted.
+func (rs *RankService) CheckTagModeratorPermissions(ctx context.Context, userID, objectID string,
+	actions []string, can []bool) {
+	granted := false
+	for idx, action := range actions {
+		if can[idx] || !tagModeratorActions[action] {
+			continue
+		}
+		if !granted {
+			if !rs.tagModeratorService.IsObjectModerator(ctx, userID, uid.DeShortID(objectID)) {
+				return
+			}
+			granted = true
+		}
+		can[idx] = true
+	}
+}
+
 // CheckOperationObjectOwner check operation object owner
 func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, objectID string) bool {
 	objectID = uid.DeShortID(objectID)
@@ -174,6 +243,28 @@ func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, ob
 	return false
 }
 
+// CheckWikiEditPermission check the object is a community wiki post and the user meets the rank to edit the wiki posts,
+// the edits of the wiki posts by these users are applied directly without the review
+func (rs *RankService) CheckWikiEditPermission(ctx context.Context, userID, objectID string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	objectInfo, err := rs.objectInfoService.GetInfo(ctx, uid.DeShortID(objectID))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if !objectInfo.Wiki {
+		return false
+	}
+	can, err := rs.CheckOperationPermission(ctx, userID, permission.PostWikiEdit, "")
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return can
+}
+
 // CheckVotePermission verify that the user has vote permission
 func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID string, voteUp bool) (
 	can bool, needRank int, err error) {
@@ -327,3 +418,262 @@ func (rs *RankService) decorateRankPersonalPageResp(
 	}
 	return resp
 }
+
+// GetReputationLedgerPage get the reputation changes of the user, the newest first
+func (rs *RankService) GetReputationLedgerPage(ctx context.Context, req *schema.GetReputationLedgerWithPageReq) (
+	pageModel *pager.PageModel, err error) {
+	if plugin.RankAgentEnabled() {
+		return pager.NewPageModel(0, []string{}), nil
+	}
+	var userInfo *schema.UserBasicInfo
+	var exist bool
+	if len(req.Username) > 0 {
+		userInfo, exist, err = rs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	} else if len(req.UserID) > 0 {
+		userInfo, exist, err = rs.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+
+	var activityTypes []int
+	reversal := req.Reason == activity_type.ReputationReasonReversal
+	if len(req.Reason) > 0 && !reversal {
+		activityTypes, err = rs.getReputationReasonActivityTypes(ctx, req.Reason)
+		if err != nil {
+			return nil, err
+		}
+		if len(activityTypes) == 0 {
+			return pager.NewPageModel(0, []string{}), nil
+		}
+	}
+
+	changes, total, newerSum, err := rs.userRankRepo.UserReputationChangePage(
+		ctx, userInfo.ID, activityTypes, reversal, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := rs.decorateReputationLedgerResp(ctx, changes)
+	// the balance is only consistent when all changes are listed
+	if len(req.Reason) == 0 {
+		balance := userInfo.Rank - int(newerSum)
+		for _, item := range resp {
+			itemBalance := balance
+			item.Balance = &itemBalance
+			balance -= item.Delta
+		}
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// getReputationReasonActivityTypes get the activity types of the reputation reason
+func (rs *RankService) getReputationReasonActivityTypes(ctx context.Context, reputationReason string) (
+	activityTypes []int, err error) {
+	for key, val := range activity_type.ReputationReasonMapping {
+		if val != reputationReason {
+			continue
+		}
+		cfg, err := rs.configService.GetConfigByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		activityTypes = append(activityTypes, cfg.ID)
+	}
+	return activityTypes, nil
+}
+
+func (rs *RankService) decorateReputationLedgerResp(
+	ctx context.Context, changes []*entity.ActivityReputationChange) []*schema.GetReputationLedgerResp {
+	resp := make([]*schema.GetReputationLedgerResp, 0, len(changes))
+	lang := handler.GetLangByCtx(ctx)
+
+	for _, change := range changes {
+		item := &schema.GetReputationLedgerResp{
+			CreatedAt: change.HappenedAt.Unix(),
+			Delta:     change.Delta,
+			Reason:    activity_type.ReputationReasonOther,
+			ObjectID:  change.ObjectID,
+		}
+		cfg, err := rs.configService.GetConfigByID(ctx, change.ActivityType)
+		if err != nil {
+			log.Error(err)
+		} else {
+			if r, ok := activity_type.ReputationReasonMapping[cfg.Key]; ok {
+				item.Reason = r
+			}
+			if key, ok := activity_type.ActivityTypeFlagMapping[cfg.Key]; ok {
+				item.RankType = translator.Tr(lang, key)
+			}
+		}
+		if change.Reversal == 1 {
+			item.Reason = activity_type.ReputationReasonReversal
+		}
+
+		// the change is always listed even if the object can not be found, to keep the balance correct
+		if len(change.ObjectID) > 0 && change.ObjectID != "0" {
+			objInfo, err := rs.objectInfoService.GetInfo(ctx, change.ObjectID)
+			if err != nil {
+				log.Error(err)
+			} else {
+				item.ObjectID = objInfo.ObjectID
+				item.ObjectType = objInfo.ObjectType
+				item.Title = objInfo.Title
+				item.UrlTitle = htmltext.UrlTitle(objInfo.Title)
+				if objInfo.QuestionStatus == entity.QuestionStatusDeleted {
+					item.Title = translator.Tr(lang, constant.DeletedQuestionTitleTrKey)
+				}
+				item.QuestionID = objInfo.QuestionID
+				item.AnswerID = objInfo.AnswerID
+			}
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+// GetReputationLeaderboard get the users who gained the most reputation in the window, the most first.
+// The windows except all the time sum up the reputation changes since the beginning of the day, week or month.
+// The pages are cached for a short while, so the leaderboard may be a few minutes behind.
+func (rs *RankService) GetReputationLeaderboard(ctx context.Context, req *schema.GetReputationLeaderboardReq) (
+	pageModel *pager.PageModel, err error) {
+	if plugin.RankAgentEnabled() {
+		return pager.NewPageModel(0, []string{}), nil
+	}
+	req.Page, req.PageSize = pager.ValPageAndPageSize(req.Page, req.PageSize)
+	if len(req.Window) == 0 {
+		req.Window = constant.ReputationLeaderboardWindowAll
+	}
+	cacheKey := fmt.Sprintf("%s:%s:%d:%d", req.Window, req.Tag, req.Page, req.PageSize)
+	list, total, exist, err := rs.userRankRepo.GetReputationLeaderboardCache(ctx, cacheKey)
+	if err != nil {
+		log.Error(err)
+	} else if exist {
+		return pager.NewPageModel(total, list), nil
+	}
+
+	var tagIDs []string
+	if len(req.Tag) > 0 {
+		if tagIDs, err = rs.getTagIDsWithSynonyms(ctx, req.Tag); err != nil {
+			return nil, err
+		}
+	}
+
+	var since time.Time
+	switch req.Window {
+	case constant.ReputationLeaderboardWindowToday:
+		since = now.BeginningOfDay()
+	case constant.ReputationLeaderboardWindowWeek:
+		since = now.BeginningOfWeek()
+	case constant.ReputationLeaderboardWindowMonth:
+		since = now.BeginningOfMonth()
+	}
+	stats, total, err := rs.userRankRepo.GetReputationLeaderboard(ctx, since, tagIDs, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		userIDs = append(userIDs, stat.UserID)
+	}
+	userInfoMapping, err := rs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	list = make([]*schema.GetReputationLeaderboardResp, 0, len(stats))
+	for i, stat := range stats {
+		item := &schema.GetReputationLeaderboardResp{
+			Position:         (req.Page-1)*req.PageSize + i + 1,
+			ReputationGained: stat.Rank,
+		}
+		if userInfo, ok := userInfoMapping[stat.UserID]; ok {
+			item.Username = userInfo.Username
+			item.DisplayName = userInfo.DisplayName
+			item.Avatar = userInfo.Avatar
+			item.Rank = userInfo.Rank
+		}
+		list = append(list, item)
+	}
+	if err = rs.userRankRepo.SetReputationLeaderboardCache(ctx, cacheKey, list, total); err != nil {
+		log.Error(err)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// GetUserTagRankPage get the reputation of the user in the tags, the highest first
+func (rs *RankService) GetUserTagRankPage(ctx context.Context, req *schema.GetUserTagRankWithPageReq) (
+	pageModel *pager.PageModel, err error) {
+	if plugin.RankAgentEnabled() {
+		return pager.NewPageModel(0, []string{}), nil
+	}
+	var userInfo *schema.UserBasicInfo
+	var exist bool
+	if len(req.Username) > 0 {
+		userInfo, exist, err = rs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	} else if len(req.UserID) > 0 {
+		userInfo, exist, err = rs.userCommon.GetUserBasicInfoByID(ctx, req.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+
+	var tagIDs []string
+	if len(req.Tag) > 0 {
+		if tagIDs, err = rs.getTagIDsWithSynonyms(ctx, req.Tag); err != nil {
+			return nil, err
+		}
+	}
+	tagRanks, total, err := rs.userRankRepo.GetUserTagRankPage(ctx, userInfo.ID, tagIDs, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	tagRankIDs := make([]string, 0, len(tagRanks))
+	for _, tagRank := range tagRanks {
+		tagRankIDs = append(tagRankIDs, tagRank.TagID)
+	}
+	tags, err := rs.tagCommonService.GetTagListByIDs(ctx, tagRankIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = tag
+	}
+	resp := make([]*schema.GetUserTagRankResp, 0, len(tagRanks))
+	for _, tagRank := range tagRanks {
+		item := &schema.GetUserTagRankResp{TagID: tagRank.TagID, Rank: tagRank.Rank}
+		if tag, ok := tagMapping[tagRank.TagID]; ok {
+			item.SlugName = tag.SlugName
+			item.DisplayName = tag.DisplayName
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// getTagIDsWithSynonyms get the id of the tag and its synonyms, the synonym is replaced by its main tag
+func (rs *RankService) getTagIDsWithSynonyms(ctx context.Context, slugName string) (tagIDs []string, err error) {
+	tagInfo, exist, err := rs.tagCommonService.GetTagBySlugName(ctx, slugName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TagNotFound)
+	}
+	mainTagID := tagInfo.ID
+	if tagInfo.MainTagID > 0 {
+		mainTagID = converter.IntToString(tagInfo.MainTagID)
+	}
+	synonymIDs, err := rs.tagCommonService.GetTagIDsByMainTagID(ctx, mainTagID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{mainTagID}, synonymIDs...), nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/internal/service/tag_moderator"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTagModeratorRepo struct {
+	tag_moderator.TagModeratorRepo
+	moderatedTagIDs map[string][]string
+}
+
+func (r *testTagModeratorRepo) GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	return r.moderatedTagIDs[userID], nil
+}
+
+type testTagCommonRepo struct {
+	tag_common.TagCommonRepo
+	tags []*entity.Tag
+}
+
+func (r *testTagCommonRepo) GetTagBySlugName(ctx context.Context, slugName string) (
+	tagInfo *entity.Tag, exist bool, err error) {
+	for _, tag := range r.tags {
+		if tag.SlugName == slugName {
+			return tag, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+type testSiteInfoService struct {
+	siteinfo_common.SiteInfoCommonService
+}
+
+func (s *testSiteInfoService) GetSiteTag(ctx context.Context) (resp *schema.SiteTagsResp, err error) {
+	return &schema.SiteTagsResp{}, nil
+}
+
+func TestRankService_CheckTagModeratorTagPermission(t *testing.T) {
+	tagCommonService := tag_common.NewTagCommonService(&testTagCommonRepo{tags: []*entity.Tag{
+		{ID: "101", SlugName: "go"},
+		{ID: "102", SlugName: "golang", MainTagID: 101},
+		{ID: "103", SlugName: "rust"},
+	}}, nil, nil, nil, nil, &testSiteInfoService{}, nil)
+	tagModeratorService := tag_moderator.NewTagModeratorService(&testTagModeratorRepo{
+		moderatedTagIDs: map[string][]string{"1": {"101"}},
+	}, tagCommonService, nil, nil, nil)
+	rs := &RankService{tagModeratorService: tagModeratorService}
+
+	tests := []struct {
+		name   string
+		userID string
+		action string
+		tag    string
+		want   bool
+	}{
+		{name: "global pin", userID: "1", action: permission.QuestionPin, tag: "", want: false},
+		{name: "pin in the moderated tag", userID: "1", action: permission.QuestionPin, tag: "go", want: true},
+		{name: "unpin in the moderated tag", userID: "1", action: permission.QuestionUnPin, tag: "Go", want: true},
+		{name: "pin in the synonym", userID: "1", action: permission.QuestionPin, tag: "golang", want: true},
+		{name: "pin in the other tag", userID: "1", action: permission.QuestionPin, tag: "rust", want: false},
+		{name: "pin in the missing tag", userID: "1", action: permission.QuestionPin, tag: "java", want: false},
+		{name: "not a tag action", userID: "1", action: permission.QuestionHide, tag: "go", want: false},
+		{name: "not a tag moderator", userID: "2", action: permission.QuestionPin, tag: "go", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rs.CheckTagModeratorTagPermission(context.TODO(), tt.userID, tt.action, tt.tag))
+		})
+	}
+}
+
+func TestRankService_CheckTagModeratorPermissions_NoGlobalPin(t *testing.T) {
+	rs := &RankService{}
+	actions := []string{permission.QuestionPin, permission.QuestionUnPin}
+	can := []bool{false, false}
+	// the pin actions are not granted by the tags of the question, the moderator of them is not checked
+	rs.CheckTagModeratorPermissions(context.TODO(), "1", "10010000000000001", actions, can)
+	assert.Equal(t, []bool{false, false}, can)
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package rank
+
+import (
+	"testing"
+
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testReputationRule struct {
+	slugName string
+	adjust   func(event *plugin.ReputationEvent) int
+}
+
+func (r *testReputationRule) Info() plugin.Info {
+	return plugin.Info{SlugName: r.slugName}
+}
+
+func (r *testReputationRule) AdjustReputation(event *plugin.ReputationEvent) int {
+	return r.adjust(event)
+}
+
+func TestApplyReputationRules(t *testing.T) {
+	event := &plugin.ReputationEvent{EventType: plugin.ReputationEventVote, Delta: 10}
+	assert.Equal(t, 10, ApplyReputationRules(event))
+
+	plugin.Register(&testReputationRule{slugName: "test_double_rule", adjust: func(event *plugin.ReputationEvent) int {
+		return event.Delta * 2
+	}})
+	plugin.Register(&testReputationRule{slugName: "test_veto_rule", adjust: func(event *plugin.ReputationEvent) int {
+		if event.EventType == plugin.ReputationEventFlag {
+			return 0
+		}
+		return event.Delta + 1
+	}})
+	plugin.StatusManager.Enable("test_double_rule", true)
+	plugin.StatusManager.Enable("test_veto_rule", true)
+
+	event = &plugin.ReputationEvent{EventType: plugin.ReputationEventVote, Delta: 10}
+	assert.Equal(t, 21, ApplyReputationRules(event))
+	event = &plugin.ReputationEvent{EventType: plugin.ReputationEventFlag, Delta: -5}
+	assert.Equal(t, 0, ApplyReputationRules(event))
+}
@@ -22,6 +22,8 @@ package report
 import (
 	"context"
 	"encoding/json"
+	"sort"
+	"time"
 
 	"github.com/apache/answer/internal/service/eventqueue"
 
@@ -31,9 +33,12 @@ import (
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/object_info"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/report_common"
@@ -58,6 +63,10 @@ type ReportService struct {
 	reportHandle      *report_handle.ReportHandle
 	configService     *config.ConfigService
 	eventQueueService eventqueue.Service
+	userRepo          usercommon.UserRepo
+	emailService      *export.EmailService
+	auditLogService   *audit_log.AuditLogService
+	flagActivityRepo  activity.FlagActivityRepo
 }
 
 // NewReportService new report service
@@ -71,6 +80,10 @@ func NewReportService(
 	reportHandle *report_handle.ReportHandle,
 	configService *config.ConfigService,
 	eventQueueService eventqueue.Service,
+	userRepo usercommon.UserRepo,
+	emailService *export.EmailService,
+	auditLogService *audit_log.AuditLogService,
+	flagActivityRepo activity.FlagActivityRepo,
 ) *ReportService {
 	return &ReportService{
 		reportRepo:        reportRepo,
@@ -82,11 +95,18 @@ func NewReportService(
 		reportHandle:      reportHandle,
 		configService:     configService,
 		eventQueueService: eventQueueService,
+		userRepo:          userRepo,
+		emailService:      emailService,
+		auditLogService:   auditLogService,
+		flagActivityRepo:  flagActivityRepo,
 	}
 }
 
 // AddReport add report
 func (rs *ReportService) AddReport(ctx context.Context, req *schema.AddReportReq) (err error) {
+	if req.ObjectType == constant.UserObjectType {
+		return rs.addUserReport(ctx, req)
+	}
 	objectTypeNumber, err := obj.GetObjectTypeNumberByObjectID(req.ObjectID)
 	if err != nil {
 		return err
@@ -125,6 +145,35 @@ func (rs *ReportService) AddReport(ctx context.Context, req *schema.AddReportReq
 	return nil
 }
 
+// addUserReport flag the user, the object id of the report is the user id
+func (rs *ReportService) addUserReport(ctx context.Context, req *schema.AddReportReq) (err error) {
+	if req.ObjectID == req.UserID {
+		return errors.BadRequest(reason.ReportCannotFlagSelf)
+	}
+	_, exist, err := rs.commonUser.GetUserBasicInfoByID(ctx, req.ObjectID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+
+	cf, err := rs.configService.GetConfigByID(ctx, req.ReportType)
+	if err != nil || cf == nil {
+		return errors.BadRequest(reason.ReportNotFound)
+	}
+
+	return rs.reportRepo.AddReport(ctx, &entity.Report{
+		UserID:         req.UserID,
+		ReportedUserID: req.ObjectID,
+		ObjectID:       req.ObjectID,
+		ObjectType:     constant.ObjectTypeStrMapping[constant.UserObjectType],
+		ReportType:     req.ReportType,
+		Content:        req.Content,
+		Status:         entity.ReportStatusPending,
+	})
+}
+
 // GetUnreviewedReportPostPage get unreviewed report post page
 func (rs *ReportService) GetUnreviewedReportPostPage(ctx context.Context, req *schema.GetUnreviewedReportPostPageReq) (
 	pageModel *pager.PageModel, err error) {
@@ -226,7 +275,236 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 		return
 	}
 
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	if err = rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted); err != nil {
+		return err
+	}
+	rs.flagAccepted(ctx, report, req.UserID)
+	return nil
+}
+
+// flagAccepted let the reputation rules change the reputation of the author of the flagged object
+func (rs *ReportService) flagAccepted(ctx context.Context, report *entity.Report, moderatorID string) {
+	err := rs.flagActivityRepo.FlagAccepted(ctx, &schema.FlagAcceptedActivity{
+		UserID:        report.ReportedUserID,
+		TriggerUserID: moderatorID,
+		ObjectID:      report.ObjectID,
+	})
+	if err != nil {
+		log.Errorf("save flag accepted activity failed, object id: %s, err: %v", report.ObjectID, err)
+	}
+}
+
+// GetModerationQueue get the flagged objects with their pending flags, the objects with more flags come first
+func (rs *ReportService) GetModerationQueue(ctx context.Context, req *schema.GetModerationQueueReq) (
+	pageModel *pager.PageModel, err error) {
+	if !req.IsAdmin {
+		return pager.NewPageModel(0, make([]*schema.ModerationQueueItem, 0)), nil
+	}
+	dto := &schema.ModerationQueueDTO{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		ObjectType: constant.ObjectTypeStrMapping[req.ObjectType],
+		ReportType: req.ReportType,
+	}
+	now := time.Now()
+	if req.MinAge > 0 {
+		dto.FlaggedBefore = now.Add(-time.Duration(req.MinAge) * time.Hour)
+	}
+	if req.MaxAge > 0 {
+		dto.FlaggedAfter = now.Add(-time.Duration(req.MaxAge) * time.Hour)
+	}
+	stats, total, err := rs.reportRepo.GetPendingReportObjectPage(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	objectIDs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		objectIDs = append(objectIDs, stat.ObjectID)
+	}
+	reports, err := rs.reportRepo.GetPendingReportsByObjectIDs(ctx, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+	objectReports := make(map[string][]*entity.Report, len(stats))
+	userIDs := make([]string, 0, len(reports))
+	for _, report := range reports {
+		objectReports[report.ObjectID] = append(objectReports[report.ObjectID], report)
+		userIDs = append(userIDs, report.UserID, report.ReportedUserID)
+	}
+	userInfoMapping, err := rs.commonUser.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	reasonMapping := make(map[int]*schema.ReasonItem)
+	getReason := func(reportType int) *schema.ReasonItem {
+		if reportType <= 0 {
+			return nil
+		}
+		if r, ok := reasonMapping[reportType]; ok {
+			return r
+		}
+		r := &schema.ReasonItem{ReasonType: reportType}
+		cf, err := rs.configService.GetConfigByID(ctx, reportType)
+		if err != nil {
+			log.Error(err)
+		} else {
+			_ = json.Unmarshal([]byte(cf.Value), r)
+			r.Translate(cf.Key, lang)
+		}
+		reasonMapping[reportType] = r
+		return r
+	}
+
+	resp := make([]*schema.ModerationQueueItem, 0, len(stats))
+	for _, stat := range stats {
+		flags := objectReports[stat.ObjectID]
+		if len(flags) == 0 {
+			continue
+		}
+		item := &schema.ModerationQueueItem{
+			ObjectID:       stat.ObjectID,
+			ObjectType:     constant.ObjectTypeNumberMapping[stat.ObjectType],
+			FlagCount:      len(flags),
+			Reasons:        make([]*schema.ModerationQueueReason, 0),
+			Flags:          make([]*schema.ModerationQueueFlag, 0, len(flags)),
+			FirstFlaggedAt: flags[0].CreatedAt.Unix(),
+			LastFlaggedAt:  flags[len(flags)-1].CreatedAt.Unix(),
+		}
+		if author, ok := userInfoMapping[flags[0].ReportedUserID]; ok {
+			item.AuthorUserInfo = *author
+		}
+		if item.ObjectType == constant.UserObjectType {
+			item.Title = item.AuthorUserInfo.DisplayName
+		} else {
+			info, err := rs.objectInfoService.GetInfo(ctx, stat.ObjectID)
+			if err != nil {
+				log.Errorf("get object info failed, object id: %s, err: %v", stat.ObjectID, err)
+			} else {
+				item.QuestionID = info.QuestionID
+				item.AnswerID = info.AnswerID
+				item.CommentID = info.CommentID
+				item.Title = info.Title
+				item.UrlTitle = htmltext.UrlTitle(info.Title)
+				item.OriginalText = info.Content
+			}
+		}
+
+		reasonCount := make(map[int]*schema.ModerationQueueReason)
+		for _, flag := range flags {
+			f := &schema.ModerationQueueFlag{
+				FlagID:        flag.ID,
+				Reason:        getReason(flag.ReportType),
+				ReasonContent: flag.Content,
+				SubmitAt:      flag.CreatedAt.Unix(),
+			}
+			if submitter, ok := userInfoMapping[flag.UserID]; ok {
+				f.SubmitterUser = *submitter
+			}
+			item.Flags = append(item.Flags, f)
+
+			if c, ok := reasonCount[flag.ReportType]; ok {
+				c.Count++
+				continue
+			}
+			reasonCount[flag.ReportType] = &schema.ModerationQueueReason{Reason: f.Reason, Count: 1}
+			item.Reasons = append(item.Reasons, reasonCount[flag.ReportType])
+		}
+		sort.SliceStable(item.Reasons, func(i, j int) bool {
+			return item.Reasons[i].Count > item.Reasons[j].Count
+		})
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// ResolveModerationQueueItem handle the flagged object and resolve all its pending flags at once
+func (rs *ReportService) ResolveModerationQueueItem(ctx context.Context, req *schema.ResolveModerationQueueItemReq) (
+	resp *schema.ResolveModerationQueueItemResp, err error) {
+	reports, err := rs.reportRepo.GetPendingReportsByObjectIDs(ctx, []string{req.ObjectID})
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 || constant.ObjectTypeNumberMapping[reports[0].ObjectType] != req.ObjectType {
+		return nil, errors.NotFound(reason.ReportNotFound)
+	}
+	report := reports[0]
+
+	status := entity.ReportStatusCompleted
+	switch req.OperationType {
+	case constant.ModerationOperationDismiss:
+		status = entity.ReportStatusIgnore
+	case constant.ModerationOperationDelete, constant.ModerationOperationEdit:
+		if req.ObjectType == constant.UserObjectType {
+			return nil, errors.BadRequest(reason.ReportOperationNotSupported)
+		}
+		reviewReq := &schema.ReviewReportReq{
+			FlagID:        report.ID,
+			OperationType: constant.ReportOperationDeletePost,
+			UserID:        req.UserID,
+			IsAdmin:       req.IsAdmin,
+		}
+		if req.OperationType == constant.ModerationOperationEdit {
+			if len(req.Content) == 0 {
+				return nil, errors.BadRequest(reason.RequestFormatError)
+			}
+			reviewReq.OperationType = constant.ReportOperationEditPost
+			reviewReq.Title = req.Title
+			reviewReq.Content = req.Content
+			reviewReq.Tags = req.Tags
+		}
+		if err = rs.reportHandle.UpdateReportedObject(ctx, report, reviewReq); err != nil {
+			return nil, err
+		}
+	case constant.ModerationOperationWarn:
+		if len(req.Message) == 0 {
+			return nil, errors.BadRequest(reason.ReportWarnMessageRequired)
+		}
+		if err = rs.sendModerationWarning(ctx, report.ReportedUserID, req.Message); err != nil {
+			return nil, err
+		}
+	}
+
+	resolvedCount, err := rs.reportRepo.ResolvePendingReports(ctx, req.ObjectID, status)
+	if err != nil {
+		return nil, err
+	}
+	if status == entity.ReportStatusCompleted {
+		rs.flagAccepted(ctx, report, req.UserID)
+	}
+
+	flagIDs := make([]string, 0, len(reports))
+	for _, r := range reports {
+		flagIDs = append(flagIDs, r.ID)
+	}
+	rs.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionModerationResolve,
+		ObjectType: req.ObjectType,
+		ObjectID:   req.ObjectID,
+		Before:     map[string]any{"flag_ids": flagIDs},
+		After:      map[string]any{"operation": req.OperationType, "resolved_count": resolvedCount},
+	})
+	return &schema.ResolveModerationQueueItemResp{ResolvedCount: resolvedCount}, nil
+}
+
+// sendModerationWarning send the warning of the moderators to the user by email
+func (rs *ReportService) sendModerationWarning(ctx context.Context, userID, message string) (err error) {
+	userInfo, exist, err := rs.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	title, body, err := rs.emailService.ModerationWarningTemplate(ctx, message)
+	if err != nil {
+		return err
+	}
+	go rs.emailService.Send(ctx, userInfo.EMail, title, body)
+	return nil
 }
 
 func (rs *ReportService) sendEvent(ctx context.Context,
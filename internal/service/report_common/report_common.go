@@ -34,4 +34,8 @@ type ReportRepo interface {
 	GetByID(ctx context.Context, id string) (report *entity.Report, exist bool, err error)
 	UpdateStatus(ctx context.Context, id string, status int) (err error)
 	GetReportCount(ctx context.Context) (count int64, err error)
+	GetPendingReportObjectPage(ctx context.Context, dto *schema.ModerationQueueDTO) (
+		stats []*entity.ReportObjectStat, total int64, err error)
+	GetPendingReportsByObjectIDs(ctx context.Context, objectIDs []string) (reports []*entity.Report, err error)
+	ResolvePendingReports(ctx context.Context, objectID string, status int) (affected int64, err error)
 }
@@ -21,6 +21,7 @@ package review
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/pager"
@@ -29,11 +30,13 @@ import (
 	"github.com/apache/answer/internal/schema"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	commentcommon "github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/guest_post"
 	"github.com/apache/answer/internal/service/noticequeue"
 	"github.com/apache/answer/internal/service/object_info"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/internal/service/spam"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/vector_sync"
@@ -72,6 +75,8 @@ type ReviewService struct {
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
 	commentCommonRepo                commentcommon.CommentCommonRepo
 	vectorSyncService                vector_sync.Service
+	spamService                      *spam.SpamService
+	guestPostService                 *guest_post.GuestPostService
 }
 
 // NewReviewService new review service
@@ -90,6 +95,8 @@ func NewReviewService(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	commentCommonRepo commentcommon.CommentCommonRepo,
 	vectorSyncService vector_sync.Service,
+	spamService *spam.SpamService,
+	guestPostService *guest_post.GuestPostService,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -106,6 +113,8 @@ func NewReviewService(
 		siteInfoService:                  siteInfoService,
 		commentCommonRepo:                commentCommonRepo,
 		vectorSyncService:                vectorSyncService,
+		spamService:                      spamService,
+		guestPostService:                 guestPostService,
 	}
 }
 
@@ -124,6 +133,33 @@ func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 	}
 	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
 	reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
+	return cs.convertQuestionReviewStatus(reviewStatus)
+}
+
+// AddGuestQuestionReview add review for the question of the guest, it is always held for review unless it is deleted
+func (cs *ReviewService) AddGuestQuestionReview(ctx context.Context,
+	question *entity.Question, tags []*schema.TagItem, email, ip, ua string) (questionStatus int) {
+	// the contact of the guest is saved before the review, it is used to send the claim email when approved
+	if err := cs.guestPostService.SaveGuestInfo(ctx, question.ID, email, ip); err != nil {
+		log.Errorf("save guest question info failed, err: %v", err)
+	}
+	reviewContent := &plugin.ReviewContent{
+		ObjectType: constant.QuestionObjectType,
+		Title:      question.Title,
+		Content:    question.ParsedText,
+		IP:         ip,
+		UserAgent:  ua,
+	}
+	for _, tag := range tags {
+		reviewContent.Tags = append(reviewContent.Tags, tag.SlugName)
+	}
+	// the guest is scored by the spam check as a new account that has never posted
+	author := &spam.Author{ID: question.UserID, Email: email}
+	reviewStatus := cs.reviewWithPlugins(ctx, question.UserID, question.ID, reviewContent, author, true)
+	return cs.convertQuestionReviewStatus(reviewStatus)
+}
+
+func (cs *ReviewService) convertQuestionReviewStatus(reviewStatus plugin.ReviewStatus) (questionStatus int) {
 	switch reviewStatus {
 	case plugin.ReviewStatusApproved:
 		questionStatus = entity.QuestionStatusAvailable
@@ -203,9 +239,39 @@ func (cs *ReviewService) getReviewContentAuthorInfo(ctx context.Context, userID
 	return
 }
 
+// get the author info for the spam check
+func (cs *ReviewService) getSpamCheckAuthor(ctx context.Context, userID string,
+	reviewContent *plugin.ReviewContent) (author *spam.Author) {
+	author = &spam.Author{
+		ID:                 userID,
+		Rank:               reviewContent.Author.Rank,
+		Role:               reviewContent.Author.Role,
+		ApprovedPostAmount: reviewContent.Author.ApprovedQuestionAmount + reviewContent.Author.ApprovedAnswerAmount,
+	}
+	user, exist, err := cs.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		log.Errorf("get user info failed, err: %v", err)
+		return author
+	}
+	if exist {
+		author.Username = user.Username
+		author.Email = user.EMail
+		author.CreatedAt = user.CreatedAt
+	}
+	return author
+}
+
 // call plugin to review
 func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectID string,
 	reviewContent *plugin.ReviewContent) (reviewStatus plugin.ReviewStatus) {
+	author := cs.getSpamCheckAuthor(ctx, userID, reviewContent)
+	return cs.reviewWithPlugins(ctx, userID, objectID, reviewContent, author, false)
+}
+
+// reviewWithPlugins check the content for spam and call the reviewer plugins,
+// the approved content is still held for review if alwaysReview is true
+func (cs *ReviewService) reviewWithPlugins(ctx context.Context, userID, objectID string,
+	reviewContent *plugin.ReviewContent, author *spam.Author, alwaysReview bool) (reviewStatus plugin.ReviewStatus) {
 	// As default, no need review
 	reviewStatus = plugin.ReviewStatusApproved
 	objectID = uid.DeShortID(objectID)
@@ -221,6 +287,17 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 		reviewContent.Language = siteInterface.Language
 	}
 
+	// The spam content is held for review before the reviewer plugins, or added to review after being published
+	isSpam := false
+	if result := cs.spamService.Check(ctx, reviewContent, author); result != nil && result.IsSpam {
+		isSpam = true
+		r.Reason = strings.Join(result.Reasons, "; ")
+		r.Submitter = constant.SpamCheckSubmitter
+		if result.Hold {
+			reviewStatus = plugin.ReviewStatusNeedReview
+		}
+	}
+
 	_ = plugin.CallReviewer(func(reviewer plugin.Reviewer) error {
 		// If one of the reviewer plugin return false, then the review is not approved
 		if reviewStatus != plugin.ReviewStatusApproved {
@@ -234,7 +311,13 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 		return nil
 	})
 
-	if reviewStatus == plugin.ReviewStatusNeedReview {
+	if alwaysReview && reviewStatus == plugin.ReviewStatusApproved {
+		reviewStatus = plugin.ReviewStatusNeedReview
+		if len(r.Submitter) == 0 {
+			r.Submitter = constant.GuestPostSubmitter
+		}
+	}
+	if reviewStatus == plugin.ReviewStatusNeedReview || (isSpam && reviewStatus == plugin.ReviewStatusApproved) {
 		if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
 			log.Errorf("add review failed, err: %v", err)
 		}
@@ -295,6 +378,7 @@ func (cs *ReviewService) updateObjectStatus(ctx context.Context, review *entity.
 			cs.externalNotificationQueueService.Send(ctx,
 				schema.CreateNewQuestionNotificationMsg(questionInfo.ID, questionInfo.Title, questionInfo.UserID, tags))
 			cs.vectorSyncService.Send(ctx, &vector_sync.Task{Action: vector_sync.ActionUpsert, ObjectType: vector_sync.ObjectTypeQuestion, ObjectID: questionInfo.ID})
+			cs.guestPostService.SendClaimEmail(ctx, questionInfo)
 		} else {
 			cs.vectorSyncService.Send(ctx, &vector_sync.Task{Action: vector_sync.ActionDelete, ObjectType: vector_sync.ObjectTypeQuestion, ObjectID: questionInfo.ID})
 		}
@@ -38,4 +38,5 @@ type RevisionRepo interface {
 	GetUnreviewedRevisionPage(ctx context.Context, page, pageSize int, objectTypes []int) ([]*entity.Revision, int64, error)
 	CountUnreviewedRevision(ctx context.Context, objectTypeList []int) (count int64, err error)
 	UpdateStatus(ctx context.Context, id string, status int, reviewUserID string) (err error)
+	ReviewRevision(ctx context.Context, id string, status int, reviewUserID, rejectReason string) (reviewed bool, err error)
 }
@@ -26,6 +26,8 @@ import (
 // RolePowerRelRepo rolePowerRel repository
 type RolePowerRelRepo interface {
 	GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error)
+	GetRoleIDsByPowerTypes(ctx context.Context, powerTypes []string) (roleIDs []int, err error)
+	SaveRolePowers(ctx context.Context, roleID int, powerTypes []string) (err error)
 }
 
 // RolePowerRelService user service
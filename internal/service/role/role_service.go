@@ -21,18 +21,22 @@ package role
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/permission"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in roles are kept as the defaults, their information is translated directly.
+	// The custom roles added by the admins are shown as they are.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -55,19 +59,39 @@ const (
 type RoleRepo interface {
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
+	AddRole(ctx context.Context, role *entity.Role) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
 }
 
 // RoleService user service
 type RoleService struct {
-	roleRepo RoleRepo
+	roleRepo         RoleRepo
+	rolePowerRelRepo RolePowerRelRepo
+	powerRepo        PowerRepo
+	userRoleRelRepo  UserRoleRelRepo
 }
 
-func NewRoleService(roleRepo RoleRepo) *RoleService {
+func NewRoleService(
+	roleRepo RoleRepo,
+	rolePowerRelRepo RolePowerRelRepo,
+	powerRepo PowerRepo,
+	userRoleRelRepo UserRoleRelRepo,
+) *RoleService {
 	return &RoleService{
-		roleRepo: roleRepo,
+		roleRepo:         roleRepo,
+		rolePowerRelRepo: rolePowerRelRepo,
+		powerRepo:        powerRepo,
+		userRoleRelRepo:  userRoleRelRepo,
 	}
 }
 
+// IsBuiltInRole the built-in roles user, admin and moderator can not be modified or deleted
+func IsBuiltInRole(roleID int) bool {
+	return roleID == RoleUserID || roleID == RoleAdminID || roleID == RoleModeratorID
+}
+
 // GetRoleList get role list all
 func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleResp, err error) {
 	roles, err := rs.roleRepo.GetRoleAllList(ctx)
@@ -81,9 +105,190 @@ func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleR
 
 	resp = []*schema.GetRoleResp{}
 	_ = copier.Copy(&resp, roles)
+	for _, item := range resp {
+		item.BuiltIn = IsBuiltInRole(item.ID)
+		item.Powers, err = rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, item.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
+// GetPowerList get all the permissions that can be granted to the roles
+func (rs *RoleService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp, err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetPowerResp, 0, len(powers))
+	for _, power := range powers {
+		resp = append(resp, &schema.GetPowerResp{
+			PowerType:   power.PowerType,
+			Name:        power.Name,
+			Description: power.Description,
+		})
+	}
+	return resp, nil
+}
+
+// AddRole add the custom role with the powers
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (resp *schema.AddRoleResp, err error) {
+	if err = rs.checkRoleName(ctx, 0, req.Name); err != nil {
+		return nil, err
+	}
+	powers, err := rs.checkPowers(ctx, req.Powers)
+	if err != nil {
+		return nil, err
+	}
+	role := &entity.Role{Name: strings.TrimSpace(req.Name), Description: req.Description}
+	if err = rs.roleRepo.AddRole(ctx, role); err != nil {
+		return nil, err
+	}
+	if err = rs.rolePowerRelRepo.SaveRolePowers(ctx, role.ID, powers); err != nil {
+		return nil, err
+	}
+	return &schema.AddRoleResp{ID: role.ID}, nil
+}
+
+// UpdateRole update the custom role, its powers are replaced by the new ones
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleBuiltInCannotModify)
+	}
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	if err = rs.checkRoleName(ctx, req.ID, req.Name); err != nil {
+		return err
+	}
+	powers, err := rs.checkPowers(ctx, req.Powers)
+	if err != nil {
+		return err
+	}
+	role := &entity.Role{ID: req.ID, Name: strings.TrimSpace(req.Name), Description: req.Description}
+	if err = rs.roleRepo.UpdateRole(ctx, role); err != nil {
+		return err
+	}
+	return rs.rolePowerRelRepo.SaveRolePowers(ctx, req.ID, powers)
+}
+
+// RemoveRole delete the custom role that is not assigned to any user
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleBuiltInCannotModify)
+	}
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	rels, err := rs.userRoleRelRepo.GetUserRoleRelListByRoleID(ctx, []int{req.ID})
+	if err != nil {
+		return err
+	}
+	if len(rels) > 0 {
+		return errors.BadRequest(reason.RoleInUse)
+	}
+	return rs.roleRepo.RemoveRole(ctx, req.ID)
+}
+
+// CheckRoleExist check the role exists, it is used before the role is assigned to the users
+func (rs *RoleService) CheckRoleExist(ctx context.Context, roleID int) (err error) {
+	_, exist, err := rs.roleRepo.GetRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	return nil
+}
+
+// CheckRolePower check the role has the power. The powers of the built-in roles are used if they can not be read.
+func (rs *RoleService) CheckRolePower(ctx context.Context, roleID int, power string) bool {
+	powers, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
+	if err != nil {
+		log.Errorf("get role powers failed, role id: %d, err: %v", roleID, err)
+		return builtInRoleHasPower(roleID, power)
+	}
+	for _, p := range powers {
+		if p == power {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdminModeratorRole the role can access the admin or moderate the posts
+func (rs *RoleService) IsAdminModeratorRole(ctx context.Context, roleID int) bool {
+	return rs.CheckRolePower(ctx, roleID, permission.AdminAccess) ||
+		rs.CheckRolePower(ctx, roleID, permission.ModerationAccess)
+}
+
+// GetAdminModeratorRoleIDs get the ids of the roles that can access the admin or moderate the posts
+func (rs *RoleService) GetAdminModeratorRoleIDs(ctx context.Context) (roleIDs []int, err error) {
+	return rs.rolePowerRelRepo.GetRoleIDsByPowerTypes(ctx,
+		[]string{permission.AdminAccess, permission.ModerationAccess})
+}
+
+// checkRoleName the names of the roles are unique, the built-in role names are reserved too
+func (rs *RoleService) checkRoleName(ctx context.Context, roleID int, name string) (err error) {
+	roles, err := rs.roleRepo.GetRoleAllList(ctx)
+	if err != nil {
+		return err
+	}
+	name = strings.TrimSpace(name)
+	for _, role := range roles {
+		if role.ID != roleID && strings.EqualFold(role.Name, name) {
+			return errors.BadRequest(reason.RoleNameDuplicate)
+		}
+	}
+	return nil
+}
+
+// checkPowers check all the powers exist, the duplicated powers are removed
+func (rs *RoleService) checkPowers(ctx context.Context, powerTypes []string) (powers []string, err error) {
+	allPowers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	existPowers := make(map[string]bool, len(allPowers))
+	for _, power := range allPowers {
+		existPowers[power.PowerType] = true
+	}
+	powers = make([]string, 0, len(powerTypes))
+	added := make(map[string]bool, len(powerTypes))
+	for _, powerType := range powerTypes {
+		if !existPowers[powerType] {
+			return nil, errors.BadRequest(reason.RolePowerNotFound)
+		}
+		if added[powerType] {
+			continue
+		}
+		added[powerType] = true
+		powers = append(powers, powerType)
+	}
+	return powers, nil
+}
+
+// builtInRoleHasPower the admin and the moderator access powers of the built-in roles
+func builtInRoleHasPower(roleID int, power string) bool {
+	switch power {
+	case permission.AdminAccess:
+		return roleID == RoleAdminID
+	case permission.ModerationAccess:
+		return roleID == RoleAdminID || roleID == RoleModeratorID
+	}
+	return false
+}
+
 func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error) {
 	return rs.roleRepo.GetRoleAllMapping(ctx)
 }
@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRoleRepo struct {
+	RoleRepo
+	roles []*entity.Role
+}
+
+func (r *fakeRoleRepo) GetRoleAllList(_ context.Context) ([]*entity.Role, error) {
+	return r.roles, nil
+}
+
+func (r *fakeRoleRepo) GetRole(_ context.Context, roleID int) (*entity.Role, bool, error) {
+	for _, role := range r.roles {
+		if role.ID == roleID {
+			return role, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+type fakeRolePowerRelRepo struct {
+	RolePowerRelRepo
+	powers map[int][]string
+}
+
+func (r *fakeRolePowerRelRepo) GetRolePowerTypeList(_ context.Context, roleID int) ([]string, error) {
+	return r.powers[roleID], nil
+}
+
+type fakePowerRepo struct{}
+
+func (r *fakePowerRepo) GetPowerList(_ context.Context, _ *entity.Power) ([]*entity.Power, error) {
+	return []*entity.Power{
+		{PowerType: permission.AdminAccess},
+		{PowerType: permission.ModerationAccess},
+		{PowerType: permission.QuestionDelete},
+	}, nil
+}
+
+type fakeUserRoleRelRepo struct {
+	UserRoleRelRepo
+	rels []*entity.UserRoleRel
+}
+
+func (r *fakeUserRoleRelRepo) GetUserRoleRelListByRoleID(_ context.Context, roleIDs []int) (
+	[]*entity.UserRoleRel, error) {
+	rels := make([]*entity.UserRoleRel, 0)
+	for _, rel := range r.rels {
+		for _, id := range roleIDs {
+			if rel.RoleID == id {
+				rels = append(rels, rel)
+			}
+		}
+	}
+	return rels, nil
+}
+
+func newTestRoleService() *RoleService {
+	roles := &fakeRoleRepo{roles: []*entity.Role{
+		{ID: RoleUserID, Name: roleUserName},
+		{ID: RoleAdminID, Name: roleAdminName},
+		{ID: RoleModeratorID, Name: roleModeratorName},
+		{ID: 4, Name: "Curator"},
+	}}
+	rels := &fakeRolePowerRelRepo{powers: map[int][]string{
+		RoleAdminID:     {permission.AdminAccess, permission.ModerationAccess},
+		RoleModeratorID: {permission.ModerationAccess},
+		4:               {permission.QuestionDelete},
+	}}
+	users := &fakeUserRoleRelRepo{rels: []*entity.UserRoleRel{{UserID: "1", RoleID: 4}}}
+	return NewRoleService(roles, rels, &fakePowerRepo{}, users)
+}
+
+func assertReason(t *testing.T, err error, expected string) {
+	t.Helper()
+	e, ok := err.(*errors.Error)
+	if assert.True(t, ok) {
+		assert.Equal(t, expected, e.Reason)
+	}
+}
+
+func TestRoleService_CheckRolePower(t *testing.T) {
+	rs := newTestRoleService()
+	ctx := context.Background()
+	assert.True(t, rs.IsAdminModeratorRole(ctx, RoleAdminID))
+	assert.True(t, rs.IsAdminModeratorRole(ctx, RoleModeratorID))
+	assert.False(t, rs.IsAdminModeratorRole(ctx, RoleUserID))
+	assert.False(t, rs.IsAdminModeratorRole(ctx, 4))
+	assert.True(t, rs.CheckRolePower(ctx, 4, permission.QuestionDelete))
+	assert.False(t, rs.CheckRolePower(ctx, RoleModeratorID, permission.AdminAccess))
+}
+
+func TestRoleService_checkPowers(t *testing.T) {
+	rs := newTestRoleService()
+	powers, err := rs.checkPowers(context.Background(),
+		[]string{permission.QuestionDelete, permission.ModerationAccess, permission.QuestionDelete})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{permission.QuestionDelete, permission.ModerationAccess}, powers)
+
+	_, err = rs.checkPowers(context.Background(), []string{"unknown.power"})
+	assertReason(t, err, reason.RolePowerNotFound)
+}
+
+func TestRoleService_AddRoleNameDuplicate(t *testing.T) {
+	rs := newTestRoleService()
+	_, err := rs.AddRole(context.Background(), &schema.AddRoleReq{Name: " admin "})
+	assertReason(t, err, reason.RoleNameDuplicate)
+}
+
+func TestRoleService_RemoveRole(t *testing.T) {
+	rs := newTestRoleService()
+	ctx := context.Background()
+	assertReason(t, rs.RemoveRole(ctx, &schema.RemoveRoleReq{ID: RoleModeratorID}), reason.RoleBuiltInCannotModify)
+	assertReason(t, rs.RemoveRole(ctx, &schema.RemoveRoleReq{ID: 9}), reason.RoleNotFound)
+	assertReason(t, rs.RemoveRole(ctx, &schema.RemoveRoleReq{ID: 4}), reason.RoleInUse)
+}
+
+func TestUserRoleRelService_WithoutRoleService(t *testing.T) {
+	us := NewUserRoleRelService(&fakeUserRoleRelRepo{}, nil)
+	ctx := context.Background()
+	assert.True(t, us.IsAdminModeratorRole(ctx, RoleModeratorID))
+	assert.False(t, us.IsAdminModeratorRole(ctx, RoleUserID))
+	assert.True(t, us.CheckRolePower(ctx, RoleAdminID, permission.AdminAccess))
+	assert.False(t, us.CheckRolePower(ctx, RoleModeratorID, permission.AdminAccess))
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/permission"
 )
 
 // UserRoleRelRepo userRoleRel repository
@@ -123,3 +124,37 @@ func (us *UserRoleRelService) GetUserByRoleID(ctx context.Context, roleIDs []int
 	}
 	return rolePowerRels, nil
 }
+
+// IsAdminModeratorRole the role can access the admin or moderate the posts, only the built-in roles are
+// recognized if the role service is not available
+func (us *UserRoleRelService) IsAdminModeratorRole(ctx context.Context, roleID int) bool {
+	if us.roleService == nil {
+		return builtInRoleHasPower(roleID, permission.ModerationAccess)
+	}
+	return us.roleService.IsAdminModeratorRole(ctx, roleID)
+}
+
+// CheckRolePower check the role has the power, only the built-in roles are recognized if the role service
+// is not available
+func (us *UserRoleRelService) CheckRolePower(ctx context.Context, roleID int, power string) bool {
+	if us.roleService == nil {
+		return builtInRoleHasPower(roleID, power)
+	}
+	return us.roleService.CheckRolePower(ctx, roleID, power)
+}
+
+// GetAdminModeratorRoleIDs get the ids of the roles that can access the admin or moderate the posts
+func (us *UserRoleRelService) GetAdminModeratorRoleIDs(ctx context.Context) (roleIDs []int, err error) {
+	if us.roleService == nil {
+		return []int{RoleAdminID, RoleModeratorID}, nil
+	}
+	return us.roleService.GetAdminModeratorRoleIDs(ctx)
+}
+
+// CheckRoleExist check the role exists before it is assigned to the user
+func (us *UserRoleRelService) CheckRoleExist(ctx context.Context, roleID int) (err error) {
+	if us.roleService == nil {
+		return nil
+	}
+	return us.roleService.CheckRoleExist(ctx, roleID)
+}
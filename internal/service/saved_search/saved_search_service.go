@@ -0,0 +1,302 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/noticequeue"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+// SavedSearchRepo saved search repository
+type SavedSearchRepo interface {
+	AddSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (err error)
+	UpdateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch, cols ...string) (err error)
+	// ClaimSavedSearchAlert update the check time of the alert, returns false if it is checked by another
+	// instance meanwhile, so the alerts are never sent twice
+	ClaimSavedSearchAlert(ctx context.Context, savedSearch *entity.SavedSearch, checkedAt time.Time) (
+		claimed bool, err error)
+	RemoveSavedSearch(ctx context.Context, userID, id string) (err error)
+	GetSavedSearch(ctx context.Context, userID, id string) (savedSearch *entity.SavedSearch, exist bool, err error)
+	GetSavedSearchPage(ctx context.Context, userID string, page, pageSize int) (
+		savedSearches []*entity.SavedSearch, total int64, err error)
+	// CountSavedSearch count the saved searches of the user and the ones of them that alert
+	CountSavedSearch(ctx context.Context, userID string) (total, alerts int64, err error)
+	// GetAlertSavedSearches get the alerts that are not checked since checkedBefore, the earliest checked come first
+	GetAlertSavedSearches(ctx context.Context, checkedBefore time.Time, limit int) (
+		savedSearches []*entity.SavedSearch, err error)
+}
+
+// SavedSearchService the searches saved by the users, the alerts of them notify the users of the new matching content
+type SavedSearchService struct {
+	savedSearchRepo          SavedSearchRepo
+	searchService            *content.SearchService
+	userRepo                 usercommon.UserRepo
+	emailService             *export.EmailService
+	notificationQueueService noticequeue.Service
+}
+
+// NewSavedSearchService new saved search service
+func NewSavedSearchService(
+	savedSearchRepo SavedSearchRepo,
+	searchService *content.SearchService,
+	userRepo usercommon.UserRepo,
+	emailService *export.EmailService,
+	notificationQueueService noticequeue.Service,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo:          savedSearchRepo,
+		searchService:            searchService,
+		userRepo:                 userRepo,
+		emailService:             emailService,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// AddSavedSearch save the search query of the login user
+func (ss *SavedSearchService) AddSavedSearch(ctx context.Context, req *schema.AddSavedSearchReq) (
+	resp *schema.SavedSearchResp, err error) {
+	total, alerts, err := ss.savedSearchRepo.CountSavedSearch(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if total >= constant.SavedSearchMaxAmount {
+		return nil, errors.BadRequest(reason.SavedSearchLimitExceeded)
+	}
+	if req.AlertEnabled && alerts >= constant.SavedSearchMaxAlerts {
+		return nil, errors.BadRequest(reason.SavedSearchAlertLimitExceeded)
+	}
+	if err = ss.searchService.CheckSearchQuery(ctx, req.Query, req.UserID); err != nil {
+		return nil, err
+	}
+
+	savedSearch := &entity.SavedSearch{UserID: req.UserID}
+	setSavedSearch(savedSearch, req.Name, req.Query, req.Order, req.AlertEnabled, req.AlertEmail)
+	if err = ss.savedSearchRepo.AddSavedSearch(ctx, savedSearch); err != nil {
+		return nil, err
+	}
+	return convertSavedSearchResp(savedSearch), nil
+}
+
+// UpdateSavedSearch update the saved search of the login user, the alert only notifies the content created
+// after it is enabled or the query is changed
+func (ss *SavedSearchService) UpdateSavedSearch(ctx context.Context, req *schema.UpdateSavedSearchReq) (
+	resp *schema.SavedSearchResp, err error) {
+	savedSearch, exist, err := ss.savedSearchRepo.GetSavedSearch(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.SavedSearchNotFound)
+	}
+	if req.AlertEnabled && !savedSearch.AlertEnabled {
+		_, alerts, err := ss.savedSearchRepo.CountSavedSearch(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if alerts >= constant.SavedSearchMaxAlerts {
+			return nil, errors.BadRequest(reason.SavedSearchAlertLimitExceeded)
+		}
+	}
+	if err = ss.searchService.CheckSearchQuery(ctx, req.Query, req.UserID); err != nil {
+		return nil, err
+	}
+
+	setSavedSearch(savedSearch, req.Name, req.Query, req.Order, req.AlertEnabled, req.AlertEmail)
+	err = ss.savedSearchRepo.UpdateSavedSearch(ctx, savedSearch,
+		"name", "query", "normalized_query", "search_order", "alert_enabled", "alert_email", "last_checked_at")
+	if err != nil {
+		return nil, err
+	}
+	return convertSavedSearchResp(savedSearch), nil
+}
+
+// RemoveSavedSearch remove the saved search of the login user
+func (ss *SavedSearchService) RemoveSavedSearch(ctx context.Context, req *schema.RemoveSavedSearchReq) (err error) {
+	return ss.savedSearchRepo.RemoveSavedSearch(ctx, req.UserID, req.ID)
+}
+
+// GetSavedSearchPage get the saved searches of the login user, the latest saved ones come first
+func (ss *SavedSearchService) GetSavedSearchPage(ctx context.Context, req *schema.GetSavedSearchPageReq) (
+	pageModel *pager.PageModel, err error) {
+	savedSearches, total, err := ss.savedSearchRepo.GetSavedSearchPage(ctx, req.UserID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.SavedSearchResp, 0, len(savedSearches))
+	for _, savedSearch := range savedSearches {
+		resp = append(resp, convertSavedSearchResp(savedSearch))
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// setSavedSearch set the fields of the saved search, the check time of the alert is reset when the alert is
+// enabled or the query is changed, so the content created before is never notified
+func setSavedSearch(savedSearch *entity.SavedSearch, name, query, order string, alertEnabled, alertEmail bool) {
+	normalizedQuery := schema.NormalizeSearchQuery(query)
+	if alertEnabled && (!savedSearch.AlertEnabled || savedSearch.NormalizedQuery != normalizedQuery) {
+		savedSearch.LastCheckedAt = time.Now()
+	}
+	if !alertEnabled {
+		savedSearch.LastCheckedAt = time.Time{}
+	}
+	if len(order) == 0 {
+		order = "relevance"
+	}
+	savedSearch.Name = name
+	savedSearch.Query = query
+	savedSearch.NormalizedQuery = normalizedQuery
+	savedSearch.SearchOrder = order
+	savedSearch.AlertEnabled = alertEnabled
+	savedSearch.AlertEmail = alertEnabled && alertEmail
+}
+
+func convertSavedSearchResp(savedSearch *entity.SavedSearch) *schema.SavedSearchResp {
+	resp := &schema.SavedSearchResp{
+		ID:              savedSearch.ID,
+		Name:            savedSearch.Name,
+		Query:           savedSearch.Query,
+		NormalizedQuery: savedSearch.NormalizedQuery,
+		Order:           savedSearch.SearchOrder,
+		AlertEnabled:    savedSearch.AlertEnabled,
+		AlertEmail:      savedSearch.AlertEmail,
+		CreatedAt:       savedSearch.CreatedAt.Unix(),
+	}
+	if !savedSearch.LastCheckedAt.IsZero() {
+		resp.LastCheckedAt = savedSearch.LastCheckedAt.Unix()
+	}
+	return resp
+}
+
+// SendSavedSearchAlerts check the alerts of the saved searches and notify the users of the content created since
+// the last check. It runs the normalized query with the newest order, at most SavedSearchAlertMaxResults of the new
+// content are notified by one check.
+func (ss *SavedSearchService) SendSavedSearchAlerts(ctx context.Context) {
+	now := time.Now()
+	for range constant.SavedSearchAlertMaxBatches {
+		savedSearches, err := ss.savedSearchRepo.GetAlertSavedSearches(ctx, now, constant.SavedSearchAlertBatchSize)
+		if err != nil {
+			log.Errorf("get saved search alerts failed, err: %v", err)
+			return
+		}
+		for _, savedSearch := range savedSearches {
+			ss.checkSavedSearchAlert(ctx, savedSearch, now)
+		}
+		if len(savedSearches) < constant.SavedSearchAlertBatchSize {
+			return
+		}
+	}
+}
+
+func (ss *SavedSearchService) checkSavedSearchAlert(ctx context.Context, savedSearch *entity.SavedSearch,
+	now time.Time) {
+	lastCheckedAt := savedSearch.LastCheckedAt
+	// the check time is updated first, the alert is not checked again and again if it keeps failing
+	claimed, err := ss.savedSearchRepo.ClaimSavedSearchAlert(ctx, savedSearch, now)
+	if err != nil {
+		log.Errorf("update saved search %s check time failed, err: %v", savedSearch.ID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	userInfo, exist, err := ss.userRepo.GetByUserID(ctx, savedSearch.UserID)
+	if err != nil {
+		log.Errorf("get user %s failed, err: %v", savedSearch.UserID, err)
+		return
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable {
+		return
+	}
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageContextKey, i18n.Language(userInfo.Language))
+	}
+
+	searchResp, err := ss.searchService.Search(ctx, &schema.SearchDTO{
+		Query:  savedSearch.NormalizedQuery,
+		Page:   1,
+		Size:   constant.SavedSearchAlertMaxResults,
+		Order:  "newest",
+		UserID: savedSearch.UserID,
+	})
+	if err != nil {
+		log.Errorf("search for saved search %s failed, err: %v", savedSearch.ID, err)
+		return
+	}
+	results := newSavedSearchResults(searchResp.SearchResults, savedSearch.UserID, lastCheckedAt, now)
+	if len(results) == 0 {
+		return
+	}
+
+	for _, result := range results {
+		ss.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+			TriggerUserID:      result.Object.UserInfo.ID,
+			ReceiverUserID:     savedSearch.UserID,
+			Type:               schema.NotificationTypeInbox,
+			ObjectID:           uid.DeShortID(result.Object.ID),
+			ObjectType:         result.ObjectType,
+			NotificationAction: constant.NotificationSavedSearchAlert,
+		})
+	}
+	if !savedSearch.AlertEmail {
+		return
+	}
+	title, body, err := ss.emailService.SavedSearchAlertTemplate(ctx, &schema.SavedSearchAlertTemplateRawData{
+		SearchName: savedSearch.Name,
+		Query:      savedSearch.NormalizedQuery,
+		Results:    results,
+	})
+	if err != nil {
+		log.Errorf("get saved search alert email template failed, err: %v", err)
+		return
+	}
+	ss.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+// newSavedSearchResults pick out the content created in (after, before] by the other users
+func newSavedSearchResults(results []*schema.SearchResult, userID string, after, before time.Time) (
+	newResults []*schema.SearchResult) {
+	newResults = make([]*schema.SearchResult, 0, len(results))
+	for _, result := range results {
+		if result == nil || result.Object == nil || result.Object.UserInfo == nil {
+			continue
+		}
+		createdAt := result.Object.CreatedAtParsed
+		if createdAt <= after.Unix() || createdAt > before.Unix() || result.Object.UserInfo.ID == userID {
+			continue
+		}
+		newResults = append(newResults, result)
+	}
+	return newResults
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saved_search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSavedSearch(t *testing.T) {
+	savedSearch := &entity.SavedSearch{}
+	setSavedSearch(savedSearch, "go", "error  [go]", "", false, true)
+	assert.Equal(t, "[go] error", savedSearch.NormalizedQuery)
+	assert.Equal(t, "relevance", savedSearch.SearchOrder)
+	// the email alert only works with the alert enabled
+	assert.False(t, savedSearch.AlertEmail)
+	assert.True(t, savedSearch.LastCheckedAt.IsZero())
+
+	setSavedSearch(savedSearch, "go", "error [go]", "newest", true, true)
+	assert.True(t, savedSearch.AlertEmail)
+	assert.False(t, savedSearch.LastCheckedAt.IsZero())
+
+	// the check time is kept if the query is not changed
+	checkedAt := time.Now().Add(-time.Hour)
+	savedSearch.LastCheckedAt = checkedAt
+	setSavedSearch(savedSearch, "golang", "[go] error", "newest", true, false)
+	assert.Equal(t, checkedAt, savedSearch.LastCheckedAt)
+	setSavedSearch(savedSearch, "golang", "[go] panic", "newest", true, false)
+	assert.True(t, savedSearch.LastCheckedAt.After(checkedAt))
+
+	setSavedSearch(savedSearch, "golang", "[go] panic", "newest", false, false)
+	assert.True(t, savedSearch.LastCheckedAt.IsZero())
+}
+
+func TestNewSavedSearchResults(t *testing.T) {
+	before := time.Now()
+	after := before.Add(-time.Hour)
+	newResult := func(id, userID string, createdAt time.Time) *schema.SearchResult {
+		return &schema.SearchResult{ObjectType: "question", Object: &schema.SearchObject{
+			ID: id, CreatedAtParsed: createdAt.Unix(), UserInfo: &schema.SearchObjectUser{ID: userID}}}
+	}
+	results := []*schema.SearchResult{
+		newResult("1", "2", before),
+		// posted by the owner of the saved search
+		newResult("2", "1", before.Add(-time.Minute)),
+		newResult("3", "3", after.Add(time.Second)),
+		// checked last time
+		newResult("4", "2", after),
+		nil,
+	}
+	picked := newSavedSearchResults(results, "1", after, before)
+	if assert.Len(t, picked, 2) {
+		assert.Equal(t, "1", picked[0].Object.ID)
+		assert.Equal(t, "3", picked[1].Object.ID)
+	}
+}
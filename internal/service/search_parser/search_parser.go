@@ -24,13 +24,17 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
-
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
 )
 
 type SearchParser struct {
@@ -45,21 +49,35 @@ func NewSearchParser(tagCommonService *tag_common.TagCommonService, userCommon *
 	}
 }
 
+// searchOperatorRegexp the key:value tokens of the search query, the values starting with // are the urls
+var searchOperatorRegexp = regexp.MustCompile(`(?:^|\s)(\w+):(\S+)`)
+
+// searchOperators the supported operators of the search query
+var searchOperators = map[string]bool{
+	"tag": true, "user": true, "score": true, "created": true, "views": true, "answers": true,
+	"is": true, "hasaccepted": true, "isaccepted": true, "inquestion": true,
+}
+
 // ParseStructure parse search structure, maybe match one of type all/questions/answers,
-// but if match two type, it will return false
-func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDTO) (cond *schema.SearchCondition) {
+// but if match two type, it will return false.
+// The operator that is not supported or has an invalid value returns the error instead of being searched as the text.
+func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDTO) (
+	cond *schema.SearchCondition, err error) {
 	cond = &schema.SearchCondition{}
 	var (
 		query      = dto.Query
 		limitWords = 5
 	)
+	operatorTokens := findSearchOperators(query)
+	tagTokens := regexp.MustCompile(`\[(.*?)\]`).FindAllStringSubmatch(query, -1)
 
 	// match tags
 	cond.Tags = sp.parseTags(ctx, &query)
 
 	// match all
 	cond.UserID = sp.parseUserID(ctx, &query, dto.UserID)
-	cond.VoteAmount = sp.parseVotes(&query)
+	cond.VoteAmount, cond.VoteOperator = sp.parseVotes(&query)
+	cond.CreatedAfter, cond.CreatedBefore = sp.parseCreated(&query)
 	cond.Words = sp.parseWithin(&query)
 
 	// match questions
@@ -75,6 +93,12 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if cond.AnswerAmount != -1 {
 		cond.TargetType = constant.QuestionObjectType
 	}
+	if sp.parseIsAnswered(&query) {
+		cond.TargetType = constant.QuestionObjectType
+		if cond.AnswerAmount == -1 {
+			cond.AnswerAmount = 1
+		}
+	}
 
 	// match answers
 	cond.Accepted = sp.parseAccepted(&query)
@@ -93,6 +117,19 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 		cond.TargetType = constant.AnswerObjectType
 	}
 
+	// the operators left in the query are not supported or have invalid values
+	unparsed := findSearchOperators(query)
+	if len(unparsed) > 0 {
+		return nil, searchOperatorError(ctx, unparsed[0])
+	}
+	cond.Operators = make([]*schema.SearchOperator, 0, len(operatorTokens)+len(tagTokens))
+	for _, tag := range tagTokens {
+		cond.Operators = append(cond.Operators, &schema.SearchOperator{Operator: "tag", Value: tag[1]})
+	}
+	for _, token := range operatorTokens {
+		cond.Operators = append(cond.Operators, &schema.SearchOperator{Operator: token[1], Value: token[2]})
+	}
+
 	if len(strings.TrimSpace(query)) > 0 {
 		words := strings.Split(strings.TrimSpace(query), " ")
 		cond.Words = append(cond.Words, words...)
@@ -102,14 +139,36 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if len(cond.Words) > limitWords {
 		cond.Words = cond.Words[:limitWords]
 	}
-	return
+	return cond, nil
+}
+
+// findSearchOperators find the key:value tokens in the query, the urls are not the operators
+func findSearchOperators(query string) (tokens [][]string) {
+	for _, match := range searchOperatorRegexp.FindAllStringSubmatch(query, -1) {
+		if strings.HasPrefix(match[2], "//") {
+			continue
+		}
+		tokens = append(tokens, match)
+	}
+	return tokens
 }
 
-// parseTags parse search tags, return tag ids array
+func searchOperatorError(ctx context.Context, token []string) error {
+	errReason := reason.SearchOperatorUnsupported
+	if searchOperators[token[1]] {
+		errReason = reason.SearchOperatorInvalid
+	}
+	msg := translator.TrWithData(handler.GetLangByCtx(ctx), errReason, map[string]string{
+		"Operator": strings.TrimSpace(token[0]),
+	})
+	return errors.BadRequest(errReason).WithMsg(msg)
+}
+
+// parseTags parse search tags like: [tag] or tag:tag, return tag ids array
 func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]string) {
 	var (
 		// expire tag pattern
-		exprTag = `\[(.*?)\]`
+		exprTag = `\[(.*?)\]|(?:^|\s)tag:(\S+)`
 		q       = *query
 		limit   = 5
 	)
@@ -119,6 +178,11 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 	if len(res) == 0 {
 		return
 	}
+	for _, item := range res {
+		if len(item[1]) == 0 {
+			item[1] = item[2]
+		}
+	}
 
 	tags = make([][]string, 0)
 	for _, item := range res {
@@ -146,7 +210,7 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 		tags = tags[:limit]
 	}
 
-	q = strings.TrimSpace(re.ReplaceAllString(q, ""))
+	q = strings.TrimSpace(re.ReplaceAllString(q, " "))
 	*query = q
 	return
 }
@@ -176,19 +240,76 @@ func (sp *SearchParser) parseUserID(ctx context.Context, query *string, currentU
 	return
 }
 
-// parseVotes return the votes of search query
-func (sp *SearchParser) parseVotes(query *string) (votes int) {
+// parseVotes return the votes of search query like: score:3, score:>3, score:>=3 or score:=3.
+// The operator is empty for score:3, it means the votes equal to 0 for score:0 and not less than the votes for the others.
+func (sp *SearchParser) parseVotes(query *string) (votes int, operator string) {
 	var (
-		expr = `score:(\d+)`
+		expr = `score:(>=|>|=)?(-?\d+)(\s|$)`
 		q    = *query
 	)
 	votes = -1
 
 	re := regexp.MustCompile(expr)
 	res := re.FindStringSubmatch(q)
-	if len(res) > 1 {
-		votes = converter.StringToInt(res[1])
-		q = re.ReplaceAllString(q, "")
+	if len(res) > 2 {
+		operator = res[1]
+		votes = converter.StringToInt(res[2])
+		if len(operator) == 0 && votes < 0 {
+			votes = -1
+		}
+		q = re.ReplaceAllString(q, " ")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseCreated parse created time range like: created:2024-01-01..2024-02-01, created:2024-01-01..
+// or created:2024-01-01 for the whole day, and the comparisons like: created:>2024-01-01 or created:<=2024-01-01.
+// The after is inclusive and the before is exclusive, both are returned in unix seconds, zero means unlimited.
+func (sp *SearchParser) parseCreated(query *string) (after, before int64) {
+	var (
+		expr        = `created:(\d{4}-\d{2}-\d{2})?(\.\.)?(\d{4}-\d{2}-\d{2})?(\s|$)`
+		compareExpr = `created:(>=|>|<=|<)(\d{4}-\d{2}-\d{2})(\s|$)`
+		q           = *query
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) > 3 && (len(res[1]) > 0 || len(res[3]) > 0) {
+		from, fromErr := time.Parse(time.DateOnly, res[1])
+		to, toErr := time.Parse(time.DateOnly, res[3])
+		if (len(res[1]) == 0 || fromErr == nil) && (len(res[3]) == 0 || toErr == nil) {
+			if fromErr == nil {
+				after = from.Unix()
+				if len(res[2]) == 0 {
+					before = from.AddDate(0, 0, 1).Unix()
+				}
+			}
+			if toErr == nil && len(res[2]) > 0 {
+				before = to.AddDate(0, 0, 1).Unix()
+			}
+			q = re.ReplaceAllString(q, " ")
+		}
+	}
+
+	re = regexp.MustCompile(compareExpr)
+	for _, res := range re.FindAllStringSubmatch(q, -1) {
+		date, err := time.Parse(time.DateOnly, res[2])
+		if err != nil {
+			continue
+		}
+		switch res[1] {
+		case ">":
+			after = date.AddDate(0, 0, 1).Unix()
+		case ">=":
+			after = date.Unix()
+		case "<":
+			before = date.Unix()
+		case "<=":
+			before = date.AddDate(0, 0, 1).Unix()
+		}
+		q = strings.Replace(q, strings.TrimSpace(res[0]), "", 1)
 	}
 
 	*query = strings.TrimSpace(q)
@@ -318,6 +439,22 @@ func (sp *SearchParser) parseQuestionID(query *string) (questionID string) {
 	return
 }
 
+// parseIsAnswered check the result if only limit the questions that have answers or not
+func (sp *SearchParser) parseIsAnswered(query *string) (isAnswered bool) {
+	var (
+		q    = *query
+		expr = `is:answered`
+	)
+
+	if strings.Contains(q, expr) {
+		isAnswered = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
 // parseIsAnswer check the result if only limit answer or not
 func (sp *SearchParser) parseIsAnswer(query *string) (isAnswer bool) {
 	var (
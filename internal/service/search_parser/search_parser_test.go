@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_parser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchParser_ParseStructure(t *testing.T) {
+	sp := &SearchParser{}
+	ctx := context.TODO()
+
+	cond, err := sp.ParseStructure(ctx, &schema.SearchDTO{Query: "score:>5 is:answered created:>=2024-01-01 golang"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, cond.VoteAmount)
+	assert.Equal(t, ">", cond.VoteOperator)
+	assert.Equal(t, constant.QuestionObjectType, cond.TargetType)
+	assert.Equal(t, 1, cond.AnswerAmount)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), cond.CreatedAfter)
+	assert.Zero(t, cond.CreatedBefore)
+	assert.Equal(t, []string{"golang"}, cond.Words)
+	assert.Equal(t, []*schema.SearchOperator{
+		{Operator: "score", Value: ">5"},
+		{Operator: "is", Value: "answered"},
+		{Operator: "created", Value: ">=2024-01-01"},
+	}, cond.Operators)
+
+	// is:answered is not is:answer
+	cond, err = sp.ParseStructure(ctx, &schema.SearchDTO{Query: "is:answer score:3 created:<2024-02-01"})
+	require.NoError(t, err)
+	assert.Equal(t, constant.AnswerObjectType, cond.TargetType)
+	assert.Equal(t, 3, cond.VoteAmount)
+	assert.Empty(t, cond.VoteOperator)
+	assert.Zero(t, cond.CreatedAfter)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Unix(), cond.CreatedBefore)
+	assert.Empty(t, cond.Words)
+
+	// the urls are searched as the text
+	cond, err = sp.ParseStructure(ctx, &schema.SearchDTO{Query: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com"}, cond.Words)
+	assert.Empty(t, cond.Operators)
+}
+
+func TestSearchParser_ParseStructureInvalidOperator(t *testing.T) {
+	sp := &SearchParser{}
+	ctx := context.TODO()
+
+	for query, expected := range map[string]string{
+		"golang foo:bar":         reason.SearchOperatorUnsupported,
+		"score:abc":              reason.SearchOperatorInvalid,
+		"score:<3":               reason.SearchOperatorInvalid,
+		"is:closed":              reason.SearchOperatorInvalid,
+		"created:2024-13-45":     reason.SearchOperatorInvalid,
+		"created:>yesterday":     reason.SearchOperatorInvalid,
+		"hasaccepted:yes golang": reason.SearchOperatorInvalid,
+		"views:many golang":      reason.SearchOperatorInvalid,
+	} {
+		_, err := sp.ParseStructure(ctx, &schema.SearchDTO{Query: query})
+		var myErr *errors.Error
+		if assert.ErrorAs(t, err, &myErr, query) {
+			assert.Equal(t, expected, myErr.Reason, query)
+		}
+	}
+}
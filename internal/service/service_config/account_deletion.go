@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package service_config
+
+const (
+	// AccountDeletionContentAnonymize the posts of the deleted user are kept and reassigned to the deleted user placeholder
+	AccountDeletionContentAnonymize = "anonymize"
+	// AccountDeletionContentDelete the posts of the deleted user are deleted, they are purged with the other deleted posts
+	AccountDeletionContentDelete = "delete"
+	// AccountDeletionVotesKeep the reputation the other users got from the votes of the deleted user is kept
+	AccountDeletionVotesKeep = "keep"
+	// AccountDeletionVotesRevert the reputation the other users got from the votes of the deleted user is taken back
+	AccountDeletionVotesRevert = "revert"
+)
+
+// AccountDeletion how the posts and the votes of a user are handled when the account is deleted
+type AccountDeletion struct {
+	ContentPolicy string `json:"content_policy" mapstructure:"content_policy" yaml:"content_policy"`
+	VotePolicy    string `json:"vote_policy" mapstructure:"vote_policy" yaml:"vote_policy"`
+}
+
+// GetAccountDeletion get the account deletion policy, unset or unknown values fall back to anonymize and keep
+func (c *ServiceConfig) GetAccountDeletion() (policy AccountDeletion) {
+	policy = AccountDeletion{ContentPolicy: AccountDeletionContentAnonymize, VotePolicy: AccountDeletionVotesKeep}
+	if c == nil || c.AccountDeletion == nil {
+		return policy
+	}
+	if c.AccountDeletion.ContentPolicy == AccountDeletionContentDelete {
+		policy.ContentPolicy = AccountDeletionContentDelete
+	}
+	if c.AccountDeletion.VotePolicy == AccountDeletionVotesRevert {
+		policy.VotePolicy = AccountDeletionVotesRevert
+	}
+	return policy
+}
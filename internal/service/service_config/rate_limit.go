@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package service_config
+
+import "time"
+
+const (
+	RateLimitActionLogin         = "login"
+	RateLimitActionRegister      = "register"
+	RateLimitActionPasswordReset = "password_reset"
+)
+
+// RateLimit rate limit config of the actions that can be abused without login
+type RateLimit struct {
+	Login         *RateLimitRule `json:"login" mapstructure:"login" yaml:"login,omitempty"`
+	Register      *RateLimitRule `json:"register" mapstructure:"register" yaml:"register,omitempty"`
+	PasswordReset *RateLimitRule `json:"password_reset" mapstructure:"password_reset" yaml:"password_reset,omitempty"`
+}
+
+// RateLimitRule limits the requests of an action in a fixed time window, by the client IP and by the account.
+// A zero value uses the default value and a negative limit turns off that check.
+type RateLimitRule struct {
+	WindowSeconds int `json:"window_seconds" mapstructure:"window_seconds" yaml:"window_seconds"`
+	IPLimit       int `json:"ip_limit" mapstructure:"ip_limit" yaml:"ip_limit"`
+	AccountLimit  int `json:"account_limit" mapstructure:"account_limit" yaml:"account_limit"`
+}
+
+var defaultRateLimitRules = map[string]RateLimitRule{
+	RateLimitActionLogin:         {WindowSeconds: 300, IPLimit: 30, AccountLimit: 10},
+	RateLimitActionRegister:      {WindowSeconds: 3600, IPLimit: 10, AccountLimit: 3},
+	RateLimitActionPasswordReset: {WindowSeconds: 3600, IPLimit: 10, AccountLimit: 5},
+}
+
+// Window returns the length of the time window
+func (r RateLimitRule) Window() time.Duration {
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// GetRateLimitRule get the rate limit rule of the action, unset values fall back to the default rule
+func (c *ServiceConfig) GetRateLimitRule(action string) (rule RateLimitRule) {
+	rule = defaultRateLimitRules[action]
+	var configured *RateLimitRule
+	if c != nil && c.RateLimit != nil {
+		switch action {
+		case RateLimitActionLogin:
+			configured = c.RateLimit.Login
+		case RateLimitActionRegister:
+			configured = c.RateLimit.Register
+		case RateLimitActionPasswordReset:
+			configured = c.RateLimit.PasswordReset
+		}
+	}
+	if configured == nil {
+		return rule
+	}
+	if configured.WindowSeconds > 0 {
+		rule.WindowSeconds = configured.WindowSeconds
+	}
+	if configured.IPLimit != 0 {
+		rule.IPLimit = configured.IPLimit
+	}
+	if configured.AccountLimit != 0 {
+		rule.AccountLimit = configured.AccountLimit
+	}
+	return rule
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package service_config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceConfig_GetRateLimitRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *ServiceConfig
+		action string
+		want   RateLimitRule
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			action: RateLimitActionLogin,
+			want:   RateLimitRule{WindowSeconds: 300, IPLimit: 30, AccountLimit: 10},
+		},
+		{
+			name:   "no rate limit config",
+			config: &ServiceConfig{},
+			action: RateLimitActionRegister,
+			want:   RateLimitRule{WindowSeconds: 3600, IPLimit: 10, AccountLimit: 3},
+		},
+		{
+			name:   "other action configured",
+			config: &ServiceConfig{RateLimit: &RateLimit{Login: &RateLimitRule{IPLimit: 1}}},
+			action: RateLimitActionPasswordReset,
+			want:   RateLimitRule{WindowSeconds: 3600, IPLimit: 10, AccountLimit: 5},
+		},
+		{
+			name: "all values configured",
+			config: &ServiceConfig{RateLimit: &RateLimit{
+				Login: &RateLimitRule{WindowSeconds: 60, IPLimit: 5, AccountLimit: 2},
+			}},
+			action: RateLimitActionLogin,
+			want:   RateLimitRule{WindowSeconds: 60, IPLimit: 5, AccountLimit: 2},
+		},
+		{
+			name: "unset values use the default",
+			config: &ServiceConfig{RateLimit: &RateLimit{
+				Register: &RateLimitRule{AccountLimit: 1},
+			}},
+			action: RateLimitActionRegister,
+			want:   RateLimitRule{WindowSeconds: 3600, IPLimit: 10, AccountLimit: 1},
+		},
+		{
+			name: "negative limit turns off the check",
+			config: &ServiceConfig{RateLimit: &RateLimit{
+				PasswordReset: &RateLimitRule{IPLimit: -1},
+			}},
+			action: RateLimitActionPasswordReset,
+			want:   RateLimitRule{WindowSeconds: 3600, IPLimit: -1, AccountLimit: 5},
+		},
+		{
+			name: "negative window uses the default",
+			config: &ServiceConfig{RateLimit: &RateLimit{
+				Login: &RateLimitRule{WindowSeconds: -1},
+			}},
+			action: RateLimitActionLogin,
+			want:   RateLimitRule{WindowSeconds: 300, IPLimit: 30, AccountLimit: 10},
+		},
+		{
+			name:   "unknown action",
+			config: &ServiceConfig{RateLimit: &RateLimit{Login: &RateLimitRule{IPLimit: 1}}},
+			action: "unknown",
+			want:   RateLimitRule{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.GetRateLimitRule(tt.action))
+		})
+	}
+}
+
+func TestRateLimitRule_Window(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, RateLimitRule{WindowSeconds: 300}.Window())
+	assert.Equal(t, time.Duration(0), RateLimitRule{}.Window())
+}
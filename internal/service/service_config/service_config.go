@@ -24,4 +24,15 @@ type ServiceConfig struct {
 	CleanUpUploads                bool   `json:"clean_up_uploads" mapstructure:"clean_up_uploads" yaml:"clean_up_uploads"`
 	CleanOrphanUploadsPeriodHours int    `json:"clean_orphan_uploads_period_hours" mapstructure:"clean_orphan_uploads_period_hours" yaml:"clean_orphan_uploads_period_hours"`
 	PurgeDeletedFilesPeriodDays   int    `json:"purge_deleted_files_period_days" mapstructure:"purge_deleted_files_period_days" yaml:"purge_deleted_files_period_days"`
+	// DeletedPostsRetentionDays deleted questions and answers can be recovered in these days, then they will be purged.
+	// 0 means keep them forever.
+	DeletedPostsRetentionDays int `json:"deleted_posts_retention_days" mapstructure:"deleted_posts_retention_days" yaml:"deleted_posts_retention_days"`
+	// DraftTTLHours the auto-saved drafts of the editors expire after these hours since the last save, 0 means 7 days.
+	DraftTTLHours int `json:"draft_ttl_hours" mapstructure:"draft_ttl_hours" yaml:"draft_ttl_hours"`
+	// RateLimit limits the login, registration and password reset requests
+	RateLimit *RateLimit `json:"rate_limit" mapstructure:"rate_limit" yaml:"rate_limit,omitempty"`
+	// AccountDeletion how the posts and the votes of a user are handled when the account is deleted
+	AccountDeletion *AccountDeletion `json:"account_deletion" mapstructure:"account_deletion" yaml:"account_deletion,omitempty"`
+	// JobConcurrency the amount of the background jobs run at the same time, 0 means 4
+	JobConcurrency int `json:"job_concurrency" mapstructure:"job_concurrency" yaml:"job_concurrency,omitempty"`
 }
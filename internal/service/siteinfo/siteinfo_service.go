@@ -24,14 +24,19 @@ import (
 	"encoding/json"
 	errpkg "errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_type"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
@@ -53,6 +58,7 @@ type SiteInfoService struct {
 	configService         *config.ConfigService
 	questioncommon        *questioncommon.QuestionCommon
 	fileRecordService     *file_record.FileRecordService
+	auditLogService       *audit_log.AuditLogService
 }
 
 func NewSiteInfoService(
@@ -63,7 +69,7 @@ func NewSiteInfoService(
 	configService *config.ConfigService,
 	questioncommon *questioncommon.QuestionCommon,
 	fileRecordService *file_record.FileRecordService,
-
+	auditLogService *audit_log.AuditLogService,
 ) *SiteInfoService {
 	plugin.RegisterGetSiteURLFunc(func() string {
 		generalSiteInfo, err := siteInfoCommonService.GetSiteGeneral(context.Background())
@@ -82,9 +88,39 @@ func NewSiteInfoService(
 		configService:         configService,
 		questioncommon:        questioncommon,
 		fileRecordService:     fileRecordService,
+		auditLogService:       auditLogService,
 	}
 }
 
+// saveSiteInfo save the site info and record the change in the audit log
+func (s *SiteInfoService) saveSiteInfo(ctx context.Context, siteType string, data *entity.SiteInfo) (err error) {
+	if s.auditLogService == nil {
+		return s.siteInfoRepo.SaveByType(ctx, siteType, data)
+	}
+	var before json.RawMessage
+	old, exist, err := s.siteInfoRepo.GetByType(ctx, siteType, true)
+	if err != nil {
+		return err
+	}
+	if exist && json.Valid([]byte(old.Content)) {
+		before = json.RawMessage(old.Content)
+	}
+	if err = s.siteInfoRepo.SaveByType(ctx, siteType, data); err != nil {
+		return err
+	}
+	msg := &schema.AuditLogMsg{
+		Action:     constant.AuditActionSettingChange,
+		ObjectType: constant.SettingObjectType,
+		ObjectID:   siteType,
+		After:      json.RawMessage(data.Content),
+	}
+	if before != nil {
+		msg.Before = before
+	}
+	s.auditLogService.Record(ctx, msg)
+	return nil
+}
+
 // GetSiteGeneral get site info general
 func (s *SiteInfoService) GetSiteGeneral(ctx context.Context) (resp *schema.SiteGeneralResp, err error) {
 	return s.siteInfoCommonService.GetSiteGeneral(ctx)
@@ -172,7 +208,7 @@ func (s *SiteInfoService) SaveSiteGeneral(ctx context.Context, req schema.SiteGe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeGeneral, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeGeneral, data)
 }
 
 func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.SiteInterfaceReq) (err error) {
@@ -186,7 +222,7 @@ func (s *SiteInfoService) SaveSiteInterface(ctx context.Context, req schema.Site
 		Type:    constant.SiteTypeInterfaceSettings,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeInterfaceSettings, &data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeInterfaceSettings, &data)
 }
 
 // SaveSiteUsersSettings save site users settings
@@ -196,7 +232,7 @@ func (s *SiteInfoService) SaveSiteUsersSettings(ctx context.Context, req schema.
 		Type:    constant.SiteTypeInterfaceSettings,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsersSettings, &data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeUsersSettings, &data)
 }
 
 // SaveSiteBranding save site branding information
@@ -207,7 +243,7 @@ func (s *SiteInfoService) SaveSiteBranding(ctx context.Context, req *schema.Site
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeBranding, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeBranding, data)
 }
 
 // SaveSiteAdvanced save site advanced configuration
@@ -218,7 +254,7 @@ func (s *SiteInfoService) SaveSiteAdvanced(ctx context.Context, req *schema.Site
 		Content: string(content),
 		Status:  1,
 	}
-	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeAdvanced, data)
+	return nil, s.saveSiteInfo(ctx, constant.SiteTypeAdvanced, data)
 }
 
 // SaveSiteQuestions save site questions configuration
@@ -229,7 +265,7 @@ func (s *SiteInfoService) SaveSiteQuestions(ctx context.Context, req *schema.Sit
 		Content: string(content),
 		Status:  1,
 	}
-	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeQuestions, data)
+	return nil, s.saveSiteInfo(ctx, constant.SiteTypeQuestions, data)
 }
 
 // SaveSiteTags save site tags configuration
@@ -264,7 +300,7 @@ func (s *SiteInfoService) SaveSiteTags(ctx context.Context, req *schema.SiteTags
 		Content: string(content),
 		Status:  1,
 	}
-	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeTags, data)
+	return nil, s.saveSiteInfo(ctx, constant.SiteTypeTags, data)
 }
 
 // SaveSitePolicies save site policies configuration
@@ -275,7 +311,7 @@ func (s *SiteInfoService) SaveSitePolicies(ctx context.Context, req *schema.Site
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypePolicies, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypePolicies, data)
 }
 
 // SaveSiteSecurity save site security configuration
@@ -286,7 +322,7 @@ func (s *SiteInfoService) SaveSiteSecurity(ctx context.Context, req *schema.Site
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeSecurity, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeSecurity, data)
 }
 
 // SaveSiteLogin save site legal configuration
@@ -301,6 +337,9 @@ func (s *SiteInfoService) SaveSiteLogin(ctx context.Context, req *schema.SiteLog
 		AllowPasswordLogin:       req.AllowPasswordLogin,
 		AllowEmailDomains:        req.AllowEmailDomains,
 		RequireEmailVerification: *req.RequireEmailVerification,
+		SessionIdleTimeout:       req.SessionIdleTimeout,
+		SessionAbsoluteTimeout:   req.SessionAbsoluteTimeout,
+		RememberMeTimeout:        req.RememberMeTimeout,
 	}
 	content, _ := json.Marshal(loginConfig)
 	data := &entity.SiteInfo{
@@ -308,7 +347,7 @@ func (s *SiteInfoService) SaveSiteLogin(ctx context.Context, req *schema.SiteLog
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeLogin, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeLogin, data)
 }
 
 // SaveSiteCustomCssHTML save site custom html configuration
@@ -319,7 +358,7 @@ func (s *SiteInfoService) SaveSiteCustomCssHTML(ctx context.Context, req *schema
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeCustomCssHTML, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeCustomCssHTML, data)
 }
 
 // SaveSiteTheme save site custom html configuration
@@ -333,7 +372,7 @@ func (s *SiteInfoService) SaveSiteTheme(ctx context.Context, req *schema.SiteThe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeTheme, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeTheme, data)
 }
 
 // SaveSiteUsers save site users
@@ -344,7 +383,7 @@ func (s *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUse
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsers, data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeUsers, data)
 }
 
 // GetSiteAI get site AI configuration
@@ -420,7 +459,7 @@ func (s *SiteInfoService) SaveSiteAI(ctx context.Context, req *schema.SiteAIReq)
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeAI, siteInfo)
+	return s.saveSiteInfo(ctx, constant.SiteTypeAI, siteInfo)
 }
 
 func (s *SiteInfoService) maskAIKeys(resp *schema.SiteAIResp) {
@@ -492,7 +531,460 @@ func (s *SiteInfoService) SaveSiteMCP(ctx context.Context, req *schema.SiteMCPRe
 		Content: string(content),
 		Status:  1,
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeMCP, siteInfo)
+	return s.saveSiteInfo(ctx, constant.SiteTypeMCP, siteInfo)
+}
+
+// GetSiteLDAP get site LDAP login configuration
+func (s *SiteInfoService) GetSiteLDAP(ctx context.Context) (resp *schema.SiteLDAPResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteLDAP(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.BindPassword) > 0 {
+		resp.BindPassword = strings.Repeat("*", len(resp.BindPassword))
+	}
+	return resp, nil
+}
+
+// SaveSiteLDAP save site LDAP login configuration
+func (s *SiteInfoService) SaveSiteLDAP(ctx context.Context, req *schema.SiteLDAPReq) (err error) {
+	if len(req.BindPassword) > 0 && isAllMask(req.BindPassword) {
+		current, err := s.siteInfoCommonService.GetSiteLDAP(ctx)
+		if err != nil {
+			return err
+		}
+		req.BindPassword = current.BindPassword
+	}
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeLDAP,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeLDAP, siteInfo)
+}
+
+// GetSiteSAML get site SAML configuration
+func (s *SiteInfoService) GetSiteSAML(ctx context.Context) (resp *schema.SiteSAMLResp, err error) {
+	return s.siteInfoCommonService.GetSiteSAML(ctx)
+}
+
+// SaveSiteSAML save site SAML configuration
+func (s *SiteInfoService) SaveSiteSAML(ctx context.Context, req *schema.SiteSAMLReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeSAML,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeSAML, siteInfo)
+}
+
+// GetSiteWebhook get site outgoing webhook configuration
+func (s *SiteInfoService) GetSiteWebhook(ctx context.Context) (resp *schema.SiteWebhookResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteWebhook(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Secret) > 0 {
+		resp.Secret = strings.Repeat("*", len(resp.Secret))
+	}
+	return resp, nil
+}
+
+// SaveSiteWebhook save site outgoing webhook configuration
+func (s *SiteInfoService) SaveSiteWebhook(ctx context.Context, req *schema.SiteWebhookReq) (err error) {
+	if len(req.Secret) > 0 && isAllMask(req.Secret) {
+		current, err := s.siteInfoCommonService.GetSiteWebhook(ctx)
+		if err != nil {
+			return err
+		}
+		req.Secret = current.Secret
+	}
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeWebhook,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeWebhook, siteInfo)
+}
+
+// GetSiteStorage get site upload file storage configuration
+func (s *SiteInfoService) GetSiteStorage(ctx context.Context) (resp *schema.SiteStorageResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Type) == 0 {
+		resp.Type = constant.StorageTypeLocal
+	}
+	if len(resp.SecretAccessKey) > 0 {
+		resp.SecretAccessKey = strings.Repeat("*", len(resp.SecretAccessKey))
+	}
+	return resp, nil
+}
+
+// SaveSiteStorage save site upload file storage configuration
+func (s *SiteInfoService) SaveSiteStorage(ctx context.Context, req *schema.SiteStorageReq) (err error) {
+	if len(req.SecretAccessKey) > 0 && isAllMask(req.SecretAccessKey) {
+		current, err := s.siteInfoCommonService.GetSiteStorage(ctx)
+		if err != nil {
+			return err
+		}
+		req.SecretAccessKey = current.SecretAccessKey
+	}
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeStorage,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeStorage, siteInfo)
+}
+
+// GetSiteSpam get site spam check configuration, the Akismet API key is masked
+func (s *SiteInfoService) GetSiteSpam(ctx context.Context) (resp *schema.SiteSpamResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteSpam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.Threshold = resp.GetThreshold()
+	resp.BypassReputation = resp.GetBypassReputation()
+	resp.NewAccountDays = resp.GetNewAccountDays()
+	if len(resp.AkismetAPIKey) > 0 {
+		resp.AkismetAPIKey = strings.Repeat("*", len(resp.AkismetAPIKey))
+	}
+	return resp, nil
+}
+
+// SaveSiteSpam save site spam check configuration
+func (s *SiteInfoService) SaveSiteSpam(ctx context.Context, req *schema.SiteSpamReq) (err error) {
+	if len(req.AkismetAPIKey) > 0 && isAllMask(req.AkismetAPIKey) {
+		current, err := s.siteInfoCommonService.GetSiteSpam(ctx)
+		if err != nil {
+			return err
+		}
+		req.AkismetAPIKey = current.AkismetAPIKey
+	}
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeSpam,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeSpam, siteInfo)
+}
+
+// GetSitePostingRestriction get site posting restriction configuration
+func (s *SiteInfoService) GetSitePostingRestriction(ctx context.Context) (
+	resp *schema.SitePostingRestrictionResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSitePostingRestriction(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.TrustedReputation = resp.GetTrustedReputation()
+	resp.TrustedAccountDays = resp.GetTrustedAccountDays()
+	return resp, nil
+}
+
+// SaveSitePostingRestriction save site posting restriction configuration
+func (s *SiteInfoService) SaveSitePostingRestriction(ctx context.Context, req *schema.SitePostingRestrictionReq) (
+	err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypePostingRestriction,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypePostingRestriction, siteInfo)
+}
+
+// GetSiteAcceptReputation get site accepted answer reputation configuration.
+// The amounts in effect are kept in the config, the asker bonus is enabled by default.
+func (s *SiteInfoService) GetSiteAcceptReputation(ctx context.Context) (
+	resp *schema.SiteAcceptReputationResp, err error) {
+	askerReputation, err := s.configService.GetIntValue(ctx, activity_type.AnswerAccept)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.SiteAcceptReputationResp{
+		AskerBonusEnabled: askerReputation > 0,
+		AskerReputation:   askerReputation,
+	}
+	if err = s.siteInfoCommonService.GetSiteInfoByType(ctx, constant.SiteTypeAcceptReputation, resp); err != nil {
+		return nil, err
+	}
+	if resp.AskerReputation <= 0 {
+		resp.AskerReputation = constant.DefaultAcceptAskerReputation
+	}
+	resp.AnswererReputation, err = s.configService.GetIntValue(ctx, activity_type.AnswerAccepted)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SaveSiteAcceptReputation save site accepted answer reputation configuration.
+// The asker gains nothing if the bonus is disabled, the amount is kept for enabling it again.
+// The reputation already awarded is not changed, cancelling the acceptance takes back what was awarded.
+func (s *SiteInfoService) SaveSiteAcceptReputation(ctx context.Context, req *schema.SiteAcceptReputationReq) (
+	err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeAcceptReputation,
+		Content: string(content),
+		Status:  1,
+	}
+	if err = s.saveSiteInfo(ctx, constant.SiteTypeAcceptReputation, siteInfo); err != nil {
+		return err
+	}
+
+	askerReputation := 0
+	if req.AskerBonusEnabled {
+		askerReputation = req.AskerReputation
+	}
+	err = s.configService.UpdateConfig(ctx, activity_type.AnswerAccepted, fmt.Sprintf("%d", req.AnswererReputation))
+	if err != nil {
+		return err
+	}
+	return s.configService.UpdateConfig(ctx, activity_type.AnswerAccept, fmt.Sprintf("%d", askerReputation))
+}
+
+// GetSiteCORS get site CORS configuration
+func (s *SiteInfoService) GetSiteCORS(ctx context.Context) (resp *schema.SiteCORSResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteCORS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.AllowedMethods = resp.GetAllowedMethods()
+	resp.AllowedHeaders = resp.GetAllowedHeaders()
+	resp.MaxAge = resp.GetMaxAge()
+	return resp, nil
+}
+
+// SaveSiteCORS save site CORS configuration
+func (s *SiteInfoService) SaveSiteCORS(ctx context.Context, req *schema.SiteCORSReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeCORS,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeCORS, siteInfo)
+}
+
+// GetSiteCaptcha get site captcha configuration, the secret key is masked
+func (s *SiteInfoService) GetSiteCaptcha(ctx context.Context) (resp *schema.SiteCaptchaResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteCaptcha(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Provider) == 0 {
+		resp.Provider = constant.CaptchaProviderHCaptcha
+	}
+	if len(resp.SecretKey) > 0 {
+		resp.SecretKey = strings.Repeat("*", len(resp.SecretKey))
+	}
+	return resp, nil
+}
+
+// SaveSiteCaptcha save site captcha configuration
+func (s *SiteInfoService) SaveSiteCaptcha(ctx context.Context, req *schema.SiteCaptchaReq) (err error) {
+	if len(req.SecretKey) > 0 && isAllMask(req.SecretKey) {
+		current, err := s.siteInfoCommonService.GetSiteCaptcha(ctx)
+		if err != nil {
+			return err
+		}
+		req.SecretKey = current.SecretKey
+	}
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeCaptcha,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeCaptcha, siteInfo)
+}
+
+// GetSiteMaintenance get site maintenance mode
+func (s *SiteInfoService) GetSiteMaintenance(ctx context.Context) (resp *schema.SiteMaintenanceResp, err error) {
+	return s.siteInfoCommonService.GetSiteMaintenance(ctx)
+}
+
+// SaveSiteMaintenance save site maintenance mode
+func (s *SiteInfoService) SaveSiteMaintenance(ctx context.Context, req *schema.SiteMaintenanceReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeMaintenance,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeMaintenance, siteInfo)
+}
+
+// GetSiteGuestPosting get site guest posting configuration
+func (s *SiteInfoService) GetSiteGuestPosting(ctx context.Context) (resp *schema.SiteGuestPostingResp, err error) {
+	return s.siteInfoCommonService.GetSiteGuestPosting(ctx)
+}
+
+// SaveSiteGuestPosting save site guest posting configuration
+func (s *SiteInfoService) SaveSiteGuestPosting(ctx context.Context, req *schema.SiteGuestPostingReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeGuestPosting,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeGuestPosting, siteInfo)
+}
+
+// GetSlowQueryConfig get the threshold of the slow query log
+func (s *SiteInfoService) GetSlowQueryConfig(ctx context.Context) (resp *schema.SlowQueryConfigResp, err error) {
+	return s.siteInfoCommonService.GetSiteSlowQuery(ctx)
+}
+
+// SaveSlowQueryConfig save the threshold of the slow query log, the other instances apply it with the cron job
+func (s *SiteInfoService) SaveSlowQueryConfig(ctx context.Context, req *schema.SlowQueryConfigReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeSlowQuery,
+		Content: string(content),
+		Status:  1,
+	}
+	if err = s.saveSiteInfo(ctx, constant.SiteTypeSlowQuery, siteInfo); err != nil {
+		return err
+	}
+	data.SetSlowQueryThreshold(time.Duration(req.Threshold) * time.Millisecond)
+	return nil
+}
+
+// GetSiteAutoClose get site auto-close of the stale questions
+func (s *SiteInfoService) GetSiteAutoClose(ctx context.Context) (resp *schema.SiteAutoCloseResp, err error) {
+	return s.siteInfoCommonService.GetSiteAutoClose(ctx)
+}
+
+// SaveSiteAutoClose save site auto-close of the stale questions
+func (s *SiteInfoService) SaveSiteAutoClose(ctx context.Context, req *schema.SiteAutoCloseReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeAutoClose,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeAutoClose, siteInfo)
+}
+
+// GetSiteReaction get site reaction
+func (s *SiteInfoService) GetSiteReaction(ctx context.Context) (resp *schema.SiteReactionResp, err error) {
+	return s.siteInfoCommonService.GetSiteReaction(ctx)
+}
+
+// SaveSiteReaction save site reaction
+func (s *SiteInfoService) SaveSiteReaction(ctx context.Context, req *schema.SiteReactionReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeReaction,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeReaction, siteInfo)
+}
+
+// GetSiteRegistrationBlocklist get site registration blocklist
+func (s *SiteInfoService) GetSiteRegistrationBlocklist(ctx context.Context) (
+	resp *schema.SiteRegistrationBlocklistResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteRegistrationBlocklist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp.BlockedIPRanges == nil {
+		resp.BlockedIPRanges = make([]string, 0)
+	}
+	if resp.BlockedEmailDomains == nil {
+		resp.BlockedEmailDomains = make([]string, 0)
+	}
+	if resp.DisposableEmailDomains == nil {
+		resp.DisposableEmailDomains = make([]string, 0)
+	}
+	return resp, nil
+}
+
+// SaveSiteRegistrationBlocklist save site registration blocklist, the imported disposable email domains are kept
+func (s *SiteInfoService) SaveSiteRegistrationBlocklist(ctx context.Context, req *schema.SiteRegistrationBlocklistReq) (
+	err error) {
+	current, err := s.siteInfoCommonService.GetSiteRegistrationBlocklist(ctx)
+	if err != nil {
+		return err
+	}
+	return s.saveSiteRegistrationBlocklist(ctx, &schema.SiteRegistrationBlocklistResp{
+		BlockedIPRanges:        req.BlockedIPRanges,
+		BlockedEmailDomains:    req.BlockedEmailDomains,
+		BlockDisposableEmail:   req.BlockDisposableEmail,
+		DisposableEmailDomains: current.DisposableEmailDomains,
+	})
+}
+
+// GetSiteUsernamePolicy get site username policy, the length bounds in effect are filled in
+func (s *SiteInfoService) GetSiteUsernamePolicy(ctx context.Context) (resp *schema.SiteUsernamePolicyResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteUsernamePolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.MinLength = resp.GetMinLength()
+	resp.MaxLength = resp.GetMaxLength()
+	if resp.ReservedUsernames == nil {
+		resp.ReservedUsernames = make([]string, 0)
+	}
+	return resp, nil
+}
+
+// SaveSiteUsernamePolicy save site username policy
+func (s *SiteInfoService) SaveSiteUsernamePolicy(ctx context.Context, req *schema.SiteUsernamePolicyReq) (err error) {
+	content, _ := json.Marshal(req)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeUsernamePolicy,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeUsernamePolicy, siteInfo)
+}
+
+// ImportDisposableEmailDomains bulk import the disposable email domains into the registration blocklist
+func (s *SiteInfoService) ImportDisposableEmailDomains(ctx context.Context, req *schema.ImportDisposableEmailDomainsReq) (
+	resp *schema.ImportDisposableEmailDomainsResp, err error) {
+	current, err := s.siteInfoCommonService.GetSiteRegistrationBlocklist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := current.DisposableEmailDomains
+	if req.Replace {
+		existing = nil
+	}
+	domains := schema.NormalizeEmailDomains(append(existing, req.ParseDomains()...))
+	if len(domains) > constant.MaxDisposableEmailDomains {
+		return nil, errors.BadRequest(reason.RegistrationBlocklistTooManyDomains)
+	}
+	resp = &schema.ImportDisposableEmailDomainsResp{
+		Imported: len(domains) - len(existing),
+		Total:    len(domains),
+	}
+	current.DisposableEmailDomains = domains
+	if err = s.saveSiteRegistrationBlocklist(ctx, current); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *SiteInfoService) saveSiteRegistrationBlocklist(ctx context.Context,
+	blocklist *schema.SiteRegistrationBlocklistResp) (err error) {
+	content, _ := json.Marshal(blocklist)
+	siteInfo := &entity.SiteInfo{
+		Type:    constant.SiteTypeRegistrationBlocklist,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.saveSiteInfo(ctx, constant.SiteTypeRegistrationBlocklist, siteInfo)
 }
 
 // GetSMTPConfig get smtp config
@@ -525,6 +1017,15 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	if err != nil {
 		return err
 	}
+	if s.auditLogService != nil {
+		s.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			Action:     constant.AuditActionSettingChange,
+			ObjectType: constant.SettingObjectType,
+			ObjectID:   "smtp",
+			Before:     emailConfig,
+			After:      ec,
+		})
+	}
 	if len(req.TestEmailRecipient) > 0 {
 		title, body, err := s.emailService.TestTemplate(ctx)
 		if err != nil {
@@ -535,6 +1036,120 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	return nil
 }
 
+// SendTestEmail send a test email with the saved smtp config and report the smtp error if any
+func (s *SiteInfoService) SendTestEmail(ctx context.Context, req *schema.SendTestEmailReq) (err error) {
+	title, body, err := s.emailService.TestTemplate(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.emailService.SendWithTimeout(ctx, req.Email, title, body, constant.TestEmailSendTimeout)
+	if err != nil {
+		var myErr *errors.Error
+		if errpkg.As(err, &myErr) {
+			return err
+		}
+		return errors.BadRequest(reason.SMTPTestEmailSendFailed).WithMsg(err.Error()).WithError(err)
+	}
+	return nil
+}
+
+// GetEmailTemplates get the custom email templates, the types of them and the variables that can be used
+func (s *SiteInfoService) GetEmailTemplates(ctx context.Context) (resp *schema.GetEmailTemplatesResp, err error) {
+	templates, err := s.emailService.GetCustomEmailTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lang := handler.GetLangByCtx(ctx)
+	resp = &schema.GetEmailTemplatesResp{
+		Types:     constant.EmailTemplateTypes,
+		Variables: make([]*schema.EmailTemplateVariable, 0, len(constant.EmailTemplateVariables)),
+		Templates: templates,
+	}
+	for _, name := range constant.EmailTemplateVariables {
+		resp.Variables = append(resp.Variables, &schema.EmailTemplateVariable{
+			Name:        name,
+			Description: translator.Tr(lang, constant.EmailTplKeyVariablePrefix+name),
+		})
+	}
+	return resp, nil
+}
+
+// SaveEmailTemplate add or update the custom email template of the type in the language,
+// the template is rejected if it can not be parsed or uses an unknown variable
+func (s *SiteInfoService) SaveEmailTemplate(ctx context.Context, req *schema.SaveEmailTemplateReq) (err error) {
+	tpl := &schema.CustomEmailTemplate{
+		Type:     constant.EmailTemplateType(req.Type),
+		Language: req.Language,
+		Subject:  req.Subject,
+		HTMLBody: req.HTMLBody,
+		TextBody: req.TextBody,
+	}
+	unknownVariable, err := export.ValidateCustomEmailTemplate(tpl)
+	if err != nil {
+		return errors.BadRequest(reason.EmailTemplateInvalid).WithMsg(err.Error()).WithError(err)
+	}
+	if len(unknownVariable) > 0 {
+		lang := handler.GetLangByCtx(ctx)
+		msg := translator.TrWithData(lang, reason.EmailTemplateUnknownVariable, map[string]string{"Name": unknownVariable})
+		return errors.BadRequest(reason.EmailTemplateUnknownVariable).WithMsg(msg)
+	}
+
+	templates, err := s.emailService.GetCustomEmailTemplates(ctx)
+	if err != nil {
+		return err
+	}
+	var before *schema.CustomEmailTemplate
+	idx := slices.IndexFunc(templates, func(t *schema.CustomEmailTemplate) bool {
+		return t.Type == tpl.Type && t.Language == tpl.Language
+	})
+	if idx >= 0 {
+		before = templates[idx]
+		templates[idx] = tpl
+	} else {
+		templates = append(templates, tpl)
+	}
+	if err = s.emailService.SetCustomEmailTemplates(ctx, templates); err != nil {
+		return err
+	}
+	if s.auditLogService != nil {
+		s.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			Action:     constant.AuditActionSettingChange,
+			ObjectType: constant.SettingObjectType,
+			ObjectID:   "email_template",
+			Before:     before,
+			After:      tpl,
+		})
+	}
+	return nil
+}
+
+// RemoveEmailTemplate remove the custom email template, the default template is used again
+func (s *SiteInfoService) RemoveEmailTemplate(ctx context.Context, req *schema.RemoveEmailTemplateReq) (err error) {
+	templates, err := s.emailService.GetCustomEmailTemplates(ctx)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(templates, func(t *schema.CustomEmailTemplate) bool {
+		return t.Type == constant.EmailTemplateType(req.Type) && t.Language == req.Language
+	})
+	if idx < 0 {
+		return nil
+	}
+	before := templates[idx]
+	if err = s.emailService.SetCustomEmailTemplates(ctx, slices.Delete(templates, idx, idx+1)); err != nil {
+		return err
+	}
+	if s.auditLogService != nil {
+		s.auditLogService.Record(ctx, &schema.AuditLogMsg{
+			Action:     constant.AuditActionSettingChange,
+			ObjectType: constant.SettingObjectType,
+			ObjectID:   "email_template",
+			Before:     before,
+		})
+	}
+	return nil
+}
+
 func (s *SiteInfoService) GetSeo(ctx context.Context) (resp *schema.SiteSeoReq, err error) {
 	resp = &schema.SiteSeoReq{}
 	if err = s.siteInfoCommonService.GetSiteInfoByType(ctx, constant.SiteTypeSeo, resp); err != nil {
@@ -559,7 +1174,7 @@ func (s *SiteInfoService) SaveSeo(ctx context.Context, req schema.SiteSeoReq) (e
 		Type:    constant.SiteTypeSeo,
 		Content: string(content),
 	}
-	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeSeo, &data)
+	return s.saveSiteInfo(ctx, constant.SiteTypeSeo, &data)
 }
 
 func (s *SiteInfoService) GetPrivilegesConfig(ctx context.Context) (resp *schema.GetPrivilegesConfigResp, err error) {
@@ -569,10 +1184,14 @@ func (s *SiteInfoService) GetPrivilegesConfig(ctx context.Context) (resp *schema
 	}
 	privilegeOptions := schema.DefaultPrivilegeOptions
 	if len(privilege.CustomPrivileges) > 0 {
+		customPrivileges, err := s.completeCustomPrivileges(ctx, privilege.CustomPrivileges)
+		if err != nil {
+			return nil, err
+		}
 		privilegeOptions = append(privilegeOptions, &schema.PrivilegeOption{
 			Level:      schema.PrivilegeLevelCustom,
 			LevelDesc:  reason.PrivilegeLevelCustomDesc,
-			Privileges: privilege.CustomPrivileges,
+			Privileges: customPrivileges,
 		})
 	} else {
 		privilegeOptions = append(privilegeOptions, schema.DefaultCustomPrivilegeOption)
@@ -609,7 +1228,11 @@ func (s *SiteInfoService) translatePrivilegeOptions(ctx context.Context, privile
 func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schema.UpdatePrivilegesConfigReq) (err error) {
 	var choosePrivileges []*constant.Privilege
 	if req.Level == schema.PrivilegeLevelCustom {
-		choosePrivileges = req.CustomPrivileges
+		// only the known privileges can be changed, the missing ones keep the current value
+		choosePrivileges, err = s.completeCustomPrivileges(ctx, req.CustomPrivileges)
+		if err != nil {
+			return err
+		}
 	} else {
 		chooseOption := schema.DefaultPrivilegeOptions.Choose(req.Level)
 		if chooseOption == nil {
@@ -623,19 +1246,7 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 
 	// update site info that user choose which privilege level
 	if req.Level == schema.PrivilegeLevelCustom {
-		privilegeMap := make(map[string]int)
-		for _, privilege := range req.CustomPrivileges {
-			privilegeMap[privilege.Key] = privilege.Value
-		}
-		var privileges []*constant.Privilege
-		for _, privilege := range constant.RankAllPrivileges {
-			privileges = append(privileges, &constant.Privilege{
-				Key:   privilege.Key,
-				Label: privilege.Label,
-				Value: privilegeMap[privilege.Key],
-			})
-		}
-		req.CustomPrivileges = privileges
+		req.CustomPrivileges = choosePrivileges
 	} else {
 		privilege := &schema.UpdatePrivilegesConfigReq{}
 		if err = s.siteInfoCommonService.GetSiteInfoByType(ctx, constant.SiteTypePrivileges, privilege); err != nil {
@@ -650,7 +1261,7 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 		Content: string(content),
 		Status:  1,
 	}
-	err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypePrivileges, data)
+	err = s.saveSiteInfo(ctx, constant.SiteTypePrivileges, data)
 	if err != nil {
 		return err
 	}
@@ -665,6 +1276,31 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 	return
 }
 
+// completeCustomPrivileges build all privileges with the custom values,
+// the privilege that has no custom value uses the reputation currently required.
+func (s *SiteInfoService) completeCustomPrivileges(ctx context.Context, customPrivileges []*constant.Privilege) (
+	privileges []*constant.Privilege, err error) {
+	privilegeMap := make(map[string]int)
+	for _, privilege := range customPrivileges {
+		privilegeMap[privilege.Key] = privilege.Value
+	}
+	for _, privilege := range constant.RankAllPrivileges {
+		value, ok := privilegeMap[privilege.Key]
+		if !ok || value < 1 {
+			value, err = s.configService.GetIntValue(ctx, privilege.Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+		privileges = append(privileges, &constant.Privilege{
+			Key:   privilege.Key,
+			Label: privilege.Label,
+			Value: value,
+		})
+	}
+	return privileges, nil
+}
+
 func (s *SiteInfoService) CleanUpRemovedBrandingFiles(
 	ctx context.Context,
 	newBranding *schema.SiteBrandingReq,
@@ -23,11 +23,16 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_type"
+	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/mock"
+	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -95,3 +100,112 @@ func TestSiteInfoService_SaveSiteLoginRequiresEmailVerificationValue(t *testing.
 
 	require.Error(t, service.SaveSiteLogin(context.TODO(), req))
 }
+
+type fakeConfigRepo struct {
+	values map[string]string
+}
+
+func (r *fakeConfigRepo) GetConfigByID(_ context.Context, _ int) (*entity.Config, error) {
+	return &entity.Config{}, nil
+}
+
+func (r *fakeConfigRepo) GetConfigByKey(_ context.Context, key string) (*entity.Config, error) {
+	return &entity.Config{Key: key, Value: r.values[key]}, nil
+}
+
+func (r *fakeConfigRepo) GetConfigByKeyFromDB(ctx context.Context, key string) (*entity.Config, error) {
+	return r.GetConfigByKey(ctx, key)
+}
+
+func (r *fakeConfigRepo) UpdateConfig(_ context.Context, key, value string) error {
+	r.values[key] = value
+	return nil
+}
+
+func TestSiteInfoService_SaveSiteAcceptReputation(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	var savedContent string
+	repo := mock.NewMockSiteInfoRepo(ctl)
+	repo.EXPECT().SaveByType(gomock.Any(), constant.SiteTypeAcceptReputation, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, data *entity.SiteInfo) error {
+			savedContent = data.Content
+			return nil
+		}).Times(2)
+	repo.EXPECT().GetByType(gomock.Any(), constant.SiteTypeAcceptReputation).
+		DoAndReturn(func(_ context.Context, _ string, _ ...bool) (*entity.SiteInfo, bool, error) {
+			if len(savedContent) == 0 {
+				return nil, false, nil
+			}
+			return &entity.SiteInfo{Content: savedContent}, true, nil
+		}).AnyTimes()
+
+	configRepo := &fakeConfigRepo{values: map[string]string{
+		activity_type.AnswerAccepted: "15",
+		activity_type.AnswerAccept:   "2",
+	}}
+	service := &SiteInfoService{
+		siteInfoRepo:          repo,
+		siteInfoCommonService: siteinfo_common.NewSiteInfoCommonService(repo),
+		configService:         config.NewConfigService(configRepo),
+	}
+	ctx := context.TODO()
+
+	// the asker bonus in the config is enabled before the site is configured
+	resp, err := service.GetSiteAcceptReputation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &schema.SiteAcceptReputationResp{
+		AskerBonusEnabled: true, AnswererReputation: 15, AskerReputation: 2}, resp)
+
+	// the asker gains nothing while the bonus is disabled, but the amount is kept
+	require.NoError(t, service.SaveSiteAcceptReputation(ctx, &schema.SiteAcceptReputationReq{
+		AskerBonusEnabled: false, AnswererReputation: 20, AskerReputation: 5}))
+	assert.Equal(t, "20", configRepo.values[activity_type.AnswerAccepted])
+	assert.Equal(t, "0", configRepo.values[activity_type.AnswerAccept])
+	resp, err = service.GetSiteAcceptReputation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &schema.SiteAcceptReputationResp{
+		AskerBonusEnabled: false, AnswererReputation: 20, AskerReputation: 5}, resp)
+
+	require.NoError(t, service.SaveSiteAcceptReputation(ctx, &schema.SiteAcceptReputationReq{
+		AskerBonusEnabled: true, AnswererReputation: 20, AskerReputation: 5}))
+	assert.Equal(t, "5", configRepo.values[activity_type.AnswerAccept])
+}
+
+func TestSiteInfoService_SaveSlowQueryConfig(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	defer data.SetSlowQueryThreshold(data.GetSlowQueryThreshold())
+
+	var savedContent string
+	repo := mock.NewMockSiteInfoRepo(ctl)
+	repo.EXPECT().SaveByType(gomock.Any(), constant.SiteTypeSlowQuery, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, siteInfo *entity.SiteInfo) error {
+			savedContent = siteInfo.Content
+			return nil
+		})
+	repo.EXPECT().GetByType(gomock.Any(), constant.SiteTypeSlowQuery).
+		DoAndReturn(func(_ context.Context, _ string, _ ...bool) (*entity.SiteInfo, bool, error) {
+			if len(savedContent) == 0 {
+				return nil, false, nil
+			}
+			return &entity.SiteInfo{Content: savedContent}, true, nil
+		}).AnyTimes()
+	service := &SiteInfoService{
+		siteInfoRepo:          repo,
+		siteInfoCommonService: siteinfo_common.NewSiteInfoCommonService(repo),
+	}
+	ctx := context.TODO()
+
+	// the threshold in the config file is used before the admin changes it
+	resp, err := service.GetSlowQueryConfig(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int(data.GetConfigSlowQueryThreshold().Milliseconds()), resp.Threshold)
+
+	require.NoError(t, service.SaveSlowQueryConfig(ctx, &schema.SlowQueryConfigReq{Threshold: 200}))
+	assert.Equal(t, 200*time.Millisecond, data.GetSlowQueryThreshold())
+	resp, err = service.GetSlowQueryConfig(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.Threshold)
+}
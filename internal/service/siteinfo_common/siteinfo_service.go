@@ -25,6 +25,7 @@ import (
 	"html"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/pkg/checker"
@@ -66,6 +67,21 @@ type SiteInfoCommonService interface {
 	IsBrandingFileUsed(ctx context.Context, filePath string) bool
 	GetSiteAI(ctx context.Context) (resp *schema.SiteAIResp, err error)
 	GetSiteMCP(ctx context.Context) (resp *schema.SiteMCPResp, err error)
+	GetSiteLDAP(ctx context.Context) (resp *schema.SiteLDAPResp, err error)
+	GetSiteSAML(ctx context.Context) (resp *schema.SiteSAMLResp, err error)
+	GetSiteWebhook(ctx context.Context) (resp *schema.SiteWebhookResp, err error)
+	GetSiteStorage(ctx context.Context) (resp *schema.SiteStorageResp, err error)
+	GetSiteSpam(ctx context.Context) (resp *schema.SiteSpamResp, err error)
+	GetSitePostingRestriction(ctx context.Context) (resp *schema.SitePostingRestrictionResp, err error)
+	GetSiteCORS(ctx context.Context) (resp *schema.SiteCORSResp, err error)
+	GetSiteCaptcha(ctx context.Context) (resp *schema.SiteCaptchaResp, err error)
+	GetSiteRegistrationBlocklist(ctx context.Context) (resp *schema.SiteRegistrationBlocklistResp, err error)
+	GetSiteUsernamePolicy(ctx context.Context) (resp *schema.SiteUsernamePolicyResp, err error)
+	GetSiteMaintenance(ctx context.Context) (resp *schema.SiteMaintenanceResp, err error)
+	GetSiteReaction(ctx context.Context) (resp *schema.SiteReactionResp, err error)
+	GetSiteAutoClose(ctx context.Context) (resp *schema.SiteAutoCloseResp, err error)
+	GetSiteGuestPosting(ctx context.Context) (resp *schema.SiteGuestPostingResp, err error)
+	GetSiteSlowQuery(ctx context.Context) (resp *schema.SlowQueryConfigResp, err error)
 }
 
 // NewSiteInfoCommonService new site info common service
@@ -324,3 +340,151 @@ func (s *siteInfoCommonService) GetSiteMCP(ctx context.Context) (resp *schema.Si
 	}
 	return resp, nil
 }
+
+// GetSiteLDAP get site LDAP login configuration
+func (s *siteInfoCommonService) GetSiteLDAP(ctx context.Context) (resp *schema.SiteLDAPResp, err error) {
+	resp = &schema.SiteLDAPResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeLDAP, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteSAML get site SAML configuration, including the service provider URLs
+func (s *siteInfoCommonService) GetSiteSAML(ctx context.Context) (resp *schema.SiteSAMLResp, err error) {
+	resp = &schema.SiteSAMLResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSAML, resp); err != nil {
+		return nil, err
+	}
+	siteGeneral, err := s.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.SetServiceProviderURLs(siteGeneral.SiteUrl)
+	return resp, nil
+}
+
+// GetSiteWebhook get site outgoing webhook configuration
+func (s *siteInfoCommonService) GetSiteWebhook(ctx context.Context) (resp *schema.SiteWebhookResp, err error) {
+	resp = &schema.SiteWebhookResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeWebhook, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteStorage get site upload file storage configuration
+func (s *siteInfoCommonService) GetSiteStorage(ctx context.Context) (resp *schema.SiteStorageResp, err error) {
+	resp = &schema.SiteStorageResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeStorage, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteSpam get site spam check configuration
+func (s *siteInfoCommonService) GetSiteSpam(ctx context.Context) (resp *schema.SiteSpamResp, err error) {
+	resp = &schema.SiteSpamResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSpam, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSitePostingRestriction get site posting restriction configuration
+func (s *siteInfoCommonService) GetSitePostingRestriction(ctx context.Context) (
+	resp *schema.SitePostingRestrictionResp, err error) {
+	resp = &schema.SitePostingRestrictionResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypePostingRestriction, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteCORS get site CORS configuration
+func (s *siteInfoCommonService) GetSiteCORS(ctx context.Context) (resp *schema.SiteCORSResp, err error) {
+	resp = &schema.SiteCORSResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeCORS, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteCaptcha get site captcha configuration
+func (s *siteInfoCommonService) GetSiteCaptcha(ctx context.Context) (resp *schema.SiteCaptchaResp, err error) {
+	resp = &schema.SiteCaptchaResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeCaptcha, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteRegistrationBlocklist get site registration blocklist
+func (s *siteInfoCommonService) GetSiteRegistrationBlocklist(ctx context.Context) (
+	resp *schema.SiteRegistrationBlocklistResp, err error) {
+	resp = &schema.SiteRegistrationBlocklistResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeRegistrationBlocklist, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteUsernamePolicy get site username policy
+func (s *siteInfoCommonService) GetSiteUsernamePolicy(ctx context.Context) (
+	resp *schema.SiteUsernamePolicyResp, err error) {
+	resp = &schema.SiteUsernamePolicyResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeUsernamePolicy, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteMaintenance get site maintenance mode
+func (s *siteInfoCommonService) GetSiteMaintenance(ctx context.Context) (resp *schema.SiteMaintenanceResp, err error) {
+	resp = &schema.SiteMaintenanceResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeMaintenance, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteGuestPosting get site guest posting configuration
+func (s *siteInfoCommonService) GetSiteGuestPosting(ctx context.Context) (resp *schema.SiteGuestPostingResp, err error) {
+	resp = &schema.SiteGuestPostingResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeGuestPosting, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteReaction get site reaction, the default emojis are returned if the admins have not configured them
+func (s *siteInfoCommonService) GetSiteReaction(ctx context.Context) (resp *schema.SiteReactionResp, err error) {
+	resp = &schema.SiteReactionResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeReaction, resp); err != nil {
+		return nil, err
+	}
+	resp.Emojis = resp.GetEmojis()
+	return resp, nil
+}
+
+// GetSiteAutoClose get site auto-close of the stale questions
+func (s *siteInfoCommonService) GetSiteAutoClose(ctx context.Context) (resp *schema.SiteAutoCloseResp, err error) {
+	resp = &schema.SiteAutoCloseResp{}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeAutoClose, resp); err != nil {
+		return nil, err
+	}
+	if resp.ExemptTags == nil {
+		resp.ExemptTags = make([]string, 0)
+	}
+	return resp, nil
+}
+
+// GetSiteSlowQuery get the threshold of the slow query log, the one in the config file is used
+// if the admins have not changed it
+func (s *siteInfoCommonService) GetSiteSlowQuery(ctx context.Context) (resp *schema.SlowQueryConfigResp, err error) {
+	resp = &schema.SlowQueryConfigResp{Threshold: int(data.GetConfigSlowQueryThreshold().Milliseconds())}
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeSlowQuery, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package sitemap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// singleSitemapFileName the name of the file that has all the urls, it is served as the sitemap.xml
+const singleSitemapFileName = schema.SitemapTypeAll + "-1.xml"
+
+// SitemapRepo sitemap repository
+type SitemapRepo interface {
+	GetQuestionPage(ctx context.Context, page, pageSize int) (questions []*entity.Question, err error)
+	GetTagPage(ctx context.Context, page, pageSize int) (tags []*entity.Tag, err error)
+	GetUserPage(ctx context.Context, page, pageSize int) (users []*entity.User, err error)
+	GetSitemapIndex(ctx context.Context) (index *schema.SitemapIndex, exist bool, err error)
+	SetSitemapIndex(ctx context.Context, index *schema.SitemapIndex) (err error)
+	GetSitemapFile(ctx context.Context, name string) (urls []*schema.SitemapURL, exist bool, err error)
+	SetSitemapFile(ctx context.Context, name string, urls []*schema.SitemapURL) (err error)
+	RemoveSitemapFile(ctx context.Context, name string) (err error)
+}
+
+// SitemapService the sitemap of the questions, the tags and the users. It is generated by the schedule and
+// cached, the requests only read the cache.
+type SitemapService struct {
+	sitemapRepo     SitemapRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	// maxSize the max amount of the urls in a sitemap file
+	maxSize int
+}
+
+// NewSitemapService new sitemap service
+func NewSitemapService(
+	sitemapRepo SitemapRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *SitemapService {
+	return &SitemapService{
+		sitemapRepo:     sitemapRepo,
+		siteInfoService: siteInfoService,
+		maxSize:         constant.SitemapMaxSize,
+	}
+}
+
+// GetSitemapIndex get the index of the sitemap files, exist is false if the sitemap has not been generated
+func (ss *SitemapService) GetSitemapIndex(ctx context.Context) (index *schema.SitemapIndex, exist bool, err error) {
+	return ss.sitemapRepo.GetSitemapIndex(ctx)
+}
+
+// GetSitemapFile get the urls of the sitemap file by the name, e.g. question-1.xml
+func (ss *SitemapService) GetSitemapFile(ctx context.Context, name string) (
+	urls []*schema.SitemapURL, exist bool, err error) {
+	return ss.sitemapRepo.GetSitemapFile(ctx, name)
+}
+
+// SitemapCron generate all the sitemap files. All the urls are in one file if they are not more than
+// the limit of a sitemap file, otherwise every type has its own files listed in the sitemap index.
+func (ss *SitemapService) SitemapCron(ctx context.Context) {
+	siteSeo, err := ss.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		log.Errorf("get site seo failed, err: %v", err)
+		return
+	}
+	oldIndex, _, err := ss.sitemapRepo.GetSitemapIndex(ctx)
+	if err != nil {
+		log.Error(err)
+	}
+
+	generators := []struct {
+		sitemapType string
+		getPage     func(page int) ([]*schema.SitemapURL, error)
+	}{
+		{sitemapType: schema.SitemapTypeQuestion, getPage: func(page int) ([]*schema.SitemapURL, error) {
+			return ss.getQuestionURLs(ctx, siteSeo, page)
+		}},
+		{sitemapType: schema.SitemapTypeTag, getPage: func(page int) ([]*schema.SitemapURL, error) {
+			return ss.getTagURLs(ctx, page)
+		}},
+		{sitemapType: schema.SitemapTypeUser, getPage: func(page int) ([]*schema.SitemapURL, error) {
+			return ss.getUserURLs(ctx, page)
+		}},
+	}
+	index := &schema.SitemapIndex{Files: make([]string, 0), GeneratedAt: time.Now().Unix()}
+	allURLs := make([]*schema.SitemapURL, 0)
+	total := 0
+	for _, generator := range generators {
+		for page := 1; ; page++ {
+			urls, err := generator.getPage(page)
+			if err != nil {
+				log.Errorf("generate %s sitemap failed, err: %v", generator.sitemapType, err)
+				return
+			}
+			if len(urls) == 0 {
+				break
+			}
+			total += len(urls)
+			if total <= ss.maxSize {
+				allURLs = append(allURLs, urls...)
+			}
+			name := fmt.Sprintf("%s-%d.xml", generator.sitemapType, page)
+			if err = ss.sitemapRepo.SetSitemapFile(ctx, name, urls); err != nil {
+				log.Error(err)
+				return
+			}
+			index.Files = append(index.Files, name)
+			if len(urls) < ss.maxSize {
+				break
+			}
+		}
+	}
+	if total <= ss.maxSize {
+		for _, name := range index.Files {
+			_ = ss.sitemapRepo.RemoveSitemapFile(ctx, name)
+		}
+		index.Files = make([]string, 0)
+		if err = ss.sitemapRepo.SetSitemapFile(ctx, singleSitemapFileName, allURLs); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+	if err = ss.sitemapRepo.SetSitemapIndex(ctx, index); err != nil {
+		log.Error(err)
+		return
+	}
+	ss.removeStaleFiles(ctx, oldIndex, index)
+}
+
+// GetSingleSitemapFile get the urls of the single sitemap
+func (ss *SitemapService) GetSingleSitemapFile(ctx context.Context) (urls []*schema.SitemapURL, exist bool, err error) {
+	return ss.sitemapRepo.GetSitemapFile(ctx, singleSitemapFileName)
+}
+
+// removeStaleFiles remove the files of the last generation that are not in the new index
+func (ss *SitemapService) removeStaleFiles(ctx context.Context, oldIndex, newIndex *schema.SitemapIndex) {
+	if oldIndex == nil {
+		return
+	}
+	oldFiles := oldIndex.Files
+	if oldIndex.IsSingle() {
+		oldFiles = []string{singleSitemapFileName}
+	}
+	newFiles := make(map[string]bool, len(newIndex.Files))
+	for _, name := range newIndex.Files {
+		newFiles[name] = true
+	}
+	if newIndex.IsSingle() {
+		newFiles[singleSitemapFileName] = true
+	}
+	for _, name := range oldFiles {
+		if newFiles[name] {
+			continue
+		}
+		if err := ss.sitemapRepo.RemoveSitemapFile(ctx, name); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (ss *SitemapService) getQuestionURLs(ctx context.Context, siteSeo *schema.SiteSeoResp, page int) (
+	urls []*schema.SitemapURL, err error) {
+	questions, err := ss.sitemapRepo.GetQuestionPage(ctx, page, ss.maxSize)
+	if err != nil {
+		return nil, err
+	}
+	hasTitle := siteSeo.Permalink == constant.PermalinkQuestionIDAndTitle ||
+		siteSeo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID
+	urls = make([]*schema.SitemapURL, 0, len(questions))
+	for _, question := range questions {
+		id := question.ID
+		if siteSeo.IsShortLink() {
+			id = uid.EnShortID(id)
+		}
+		item := &schema.SitemapURL{Path: "/questions/" + id}
+		if hasTitle {
+			item.Path += "/" + htmltext.UrlTitle(question.Title)
+		}
+		if question.PostUpdateTime.IsZero() {
+			item.LastMod = formatLastMod(question.CreatedAt)
+		} else {
+			item.LastMod = formatLastMod(question.PostUpdateTime)
+		}
+		urls = append(urls, item)
+	}
+	return urls, nil
+}
+
+func (ss *SitemapService) getTagURLs(ctx context.Context, page int) (urls []*schema.SitemapURL, err error) {
+	tags, err := ss.sitemapRepo.GetTagPage(ctx, page, ss.maxSize)
+	if err != nil {
+		return nil, err
+	}
+	urls = make([]*schema.SitemapURL, 0, len(tags))
+	for _, tag := range tags {
+		urls = append(urls, &schema.SitemapURL{
+			Path:    "/tags/" + url.PathEscape(tag.SlugName),
+			LastMod: formatLastMod(tag.UpdatedAt),
+		})
+	}
+	return urls, nil
+}
+
+func (ss *SitemapService) getUserURLs(ctx context.Context, page int) (urls []*schema.SitemapURL, err error) {
+	users, err := ss.sitemapRepo.GetUserPage(ctx, page, ss.maxSize)
+	if err != nil {
+		return nil, err
+	}
+	urls = make([]*schema.SitemapURL, 0, len(users))
+	for _, user := range users {
+		urls = append(urls, &schema.SitemapURL{
+			Path:    "/users/" + url.PathEscape(user.Username),
+			LastMod: formatLastMod(user.UpdatedAt),
+		})
+	}
+	return urls, nil
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
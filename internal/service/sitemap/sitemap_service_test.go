@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package sitemap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeSitemapRepo struct {
+	questions []*entity.Question
+	tags      []*entity.Tag
+	users     []*entity.User
+	index     *schema.SitemapIndex
+	files     map[string][]*schema.SitemapURL
+}
+
+func paginate[T any](list []T, page, pageSize int) []T {
+	start := (page - 1) * pageSize
+	if start >= len(list) {
+		return nil
+	}
+	return list[start:min(start+pageSize, len(list))]
+}
+
+func (r *fakeSitemapRepo) GetQuestionPage(_ context.Context, page, pageSize int) ([]*entity.Question, error) {
+	return paginate(r.questions, page, pageSize), nil
+}
+
+func (r *fakeSitemapRepo) GetTagPage(_ context.Context, page, pageSize int) ([]*entity.Tag, error) {
+	return paginate(r.tags, page, pageSize), nil
+}
+
+func (r *fakeSitemapRepo) GetUserPage(_ context.Context, page, pageSize int) ([]*entity.User, error) {
+	return paginate(r.users, page, pageSize), nil
+}
+
+func (r *fakeSitemapRepo) GetSitemapIndex(_ context.Context) (*schema.SitemapIndex, bool, error) {
+	return r.index, r.index != nil, nil
+}
+
+func (r *fakeSitemapRepo) SetSitemapIndex(_ context.Context, index *schema.SitemapIndex) error {
+	r.index = index
+	return nil
+}
+
+func (r *fakeSitemapRepo) GetSitemapFile(_ context.Context, name string) ([]*schema.SitemapURL, bool, error) {
+	urls, ok := r.files[name]
+	return urls, ok, nil
+}
+
+func (r *fakeSitemapRepo) SetSitemapFile(_ context.Context, name string, urls []*schema.SitemapURL) error {
+	r.files[name] = urls
+	return nil
+}
+
+func (r *fakeSitemapRepo) RemoveSitemapFile(_ context.Context, name string) error {
+	delete(r.files, name)
+	return nil
+}
+
+func newTestSitemapService(t *testing.T, repo *fakeSitemapRepo, permalink int) *SitemapService {
+	ctrl := gomock.NewController(t)
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+	siteInfoService.EXPECT().GetSiteSeo(gomock.Any()).Return(&schema.SiteSeoResp{Permalink: permalink}, nil).AnyTimes()
+	ss := NewSitemapService(repo, siteInfoService)
+	ss.maxSize = 3
+	return ss
+}
+
+func TestSitemapService_SitemapCron_Single(t *testing.T) {
+	repo := &fakeSitemapRepo{
+		questions: []*entity.Question{{ID: "10010000000000001", Title: "How to use it"}},
+		tags:      []*entity.Tag{{SlugName: "c++"}},
+		users:     []*entity.User{{Username: "alice"}},
+		files:     map[string][]*schema.SitemapURL{},
+	}
+	ss := newTestSitemapService(t, repo, constant.PermalinkQuestionIDAndTitle)
+	ss.SitemapCron(context.TODO())
+
+	assert.True(t, repo.index.IsSingle())
+	urls, exist, err := ss.GetSingleSitemapFile(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	paths := make([]string, 0, len(urls))
+	for _, u := range urls {
+		paths = append(paths, u.Path)
+	}
+	assert.Equal(t, []string{"/questions/10010000000000001/how-to-use-it", "/tags/c++", "/users/alice"}, paths)
+	assert.Len(t, repo.files, 1)
+}
+
+func TestSitemapService_SitemapCron_Index(t *testing.T) {
+	repo := &fakeSitemapRepo{files: map[string][]*schema.SitemapURL{}}
+	for i := 1; i <= 4; i++ {
+		repo.questions = append(repo.questions, &entity.Question{ID: fmt.Sprintf("1001000000000000%d", i)})
+	}
+	repo.users = []*entity.User{{Username: "alice"}}
+	ss := newTestSitemapService(t, repo, constant.PermalinkQuestionID)
+	ss.SitemapCron(context.TODO())
+
+	assert.Equal(t, []string{"question-1.xml", "question-2.xml", "user-1.xml"}, repo.index.Files)
+	assert.Len(t, repo.files["question-1.xml"], 3)
+	assert.Len(t, repo.files["question-2.xml"], 1)
+	assert.Equal(t, "/questions/10010000000000004", repo.files["question-2.xml"][0].Path)
+	_, exist := repo.files[singleSitemapFileName]
+	assert.False(t, exist)
+
+	// the files that are not generated anymore are removed
+	repo.questions = repo.questions[:1]
+	ss.SitemapCron(context.TODO())
+	assert.True(t, repo.index.IsSingle())
+	assert.Len(t, repo.files, 1)
+	assert.Len(t, repo.files[singleSitemapFileName], 2)
+}
@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package spam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	linkScore          = 10
+	maxLinkScore       = 50
+	denseLinkScore     = 20
+	denseLinkWordCount = 20
+	newAccountScore    = 25
+	firstPostScore     = 15
+)
+
+var (
+	linkTagRegexp = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=`)
+	bareURLRegexp = regexp.MustCompile(`(?i)https?://\S+`)
+)
+
+// linkDensityChecker scores the content by the amount of the links and how dense they are
+type linkDensityChecker struct{}
+
+func (c *linkDensityChecker) Name() string {
+	return "link_density"
+}
+
+func (c *linkDensityChecker) Check(_ context.Context, content *plugin.ReviewContent, _ *Author,
+	_ *schema.SiteSpamResp) (score int, reason string) {
+	text := htmltext.ClearText(content.Content)
+	links := max(len(linkTagRegexp.FindAllString(content.Content, -1)), len(bareURLRegexp.FindAllString(text, -1)))
+	if links == 0 {
+		return 0, ""
+	}
+	score = min(links*linkScore, maxLinkScore)
+	words := len(strings.Fields(text)) + len(strings.Fields(content.Title))
+	if words <= links*denseLinkWordCount {
+		score += denseLinkScore
+	}
+	return score, fmt.Sprintf("contains %d links in %d words", links, words)
+}
+
+// newAccountChecker scores the content of the accounts that are just registered or have never posted
+type newAccountChecker struct{}
+
+func (c *newAccountChecker) Name() string {
+	return "new_account"
+}
+
+func (c *newAccountChecker) Check(_ context.Context, _ *plugin.ReviewContent, author *Author,
+	config *schema.SiteSpamResp) (score int, reason string) {
+	reasons := make([]string, 0, 2)
+	newAccountDays := config.GetNewAccountDays()
+	if !author.CreatedAt.IsZero() && time.Since(author.CreatedAt) < time.Duration(newAccountDays)*24*time.Hour {
+		score += newAccountScore
+		reasons = append(reasons, fmt.Sprintf("account registered within %d days", newAccountDays))
+	}
+	if author.ApprovedPostAmount == 0 {
+		score += firstPostScore
+		reasons = append(reasons, "first post of the account")
+	}
+	return score, strings.Join(reasons, ", ")
+}
+
+// akismetChecker asks Akismet whether the content is spam, the content reported as spam reaches the threshold directly
+type akismetChecker struct {
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	httpClient      *http.Client
+	// endpoint the url of the comment check api, %s is replaced by the api key
+	endpoint string
+}
+
+func newAkismetChecker(siteInfoService siteinfo_common.SiteInfoCommonService) *akismetChecker {
+	return &akismetChecker{
+		siteInfoService: siteInfoService,
+		httpClient:      &http.Client{Timeout: constant.DefaultSpamCheckTimeout},
+		endpoint:        "https://%s.rest.akismet.com/1.1/comment-check",
+	}
+}
+
+func (c *akismetChecker) Name() string {
+	return "akismet"
+}
+
+func (c *akismetChecker) Check(ctx context.Context, content *plugin.ReviewContent, author *Author,
+	config *schema.SiteSpamResp) (score int, reason string) {
+	if !config.IsAkismetEnabled() {
+		return 0, ""
+	}
+	siteGeneral, err := c.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Errorf("get site general failed, err: %v", err)
+		return 0, ""
+	}
+
+	commentType := "reply"
+	if content.ObjectType == constant.QuestionObjectType {
+		commentType = "forum-post"
+	}
+	form := url.Values{}
+	form.Set("blog", siteGeneral.SiteUrl)
+	form.Set("user_ip", content.IP)
+	form.Set("user_agent", content.UserAgent)
+	form.Set("comment_type", commentType)
+	form.Set("comment_author", author.Username)
+	form.Set("comment_author_email", author.Email)
+	form.Set("comment_content", strings.TrimSpace(content.Title+"\n"+htmltext.ClearText(content.Content)))
+	form.Set("blog_lang", content.Language)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(c.endpoint, config.AkismetAPIKey),
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		log.Errorf("create akismet request failed, err: %v", err)
+		return 0, ""
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Errorf("request akismet failed, err: %v", err)
+		return 0, ""
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	// the content is approved if akismet is unavailable, the other checkers still score it
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "true" {
+		return 0, ""
+	}
+	return config.GetThreshold(), "reported as spam by Akismet"
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package spam
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// Author the author of the checked content
+type Author struct {
+	ID       string
+	Username string
+	Email    string
+	Rank     int
+	// Role the role id of the author, the built-in ones are 1:User 2:Admin 3:Moderator
+	Role int
+	// CreatedAt the time the account was registered
+	CreatedAt time.Time
+	// ApprovedPostAmount the amount of the approved questions and answers of the author
+	ApprovedPostAmount int64
+}
+
+// Checker scores the content, the higher the score is the more likely the content is spam.
+// The scores of all the checkers are summed up and compared with the threshold.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context, content *plugin.ReviewContent, author *Author, config *schema.SiteSpamResp) (
+		score int, reason string)
+}
+
+// Result the result of the spam check
+type Result struct {
+	Score int
+	// Reasons why the content is scored, one for each checker that scores it
+	Reasons []string
+	// IsSpam the score reaches the threshold
+	IsSpam bool
+	// Hold the spam content should be held for review instead of being published
+	Hold bool
+}
+
+// SpamService check the new posts for spam
+type SpamService struct {
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	userRoleRelService *role.UserRoleRelService
+	checkers           []Checker
+}
+
+// NewSpamService new spam service with the built-in checkers
+func NewSpamService(
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRoleRelService *role.UserRoleRelService,
+) *SpamService {
+	return &SpamService{
+		siteInfoService:    siteInfoService,
+		userRoleRelService: userRoleRelService,
+		checkers: []Checker{
+			&linkDensityChecker{},
+			&newAccountChecker{},
+			newAkismetChecker(siteInfoService),
+		},
+	}
+}
+
+// RegisterChecker add a checker that scores the content together with the built-in checkers
+func (ss *SpamService) RegisterChecker(checker Checker) {
+	ss.checkers = append(ss.checkers, checker)
+}
+
+// Check score the content with all the checkers, returns nil if the spam check is disabled or the author bypasses it
+func (ss *SpamService) Check(ctx context.Context, content *plugin.ReviewContent, author *Author) (result *Result) {
+	config, err := ss.siteInfoService.GetSiteSpam(ctx)
+	if err != nil {
+		log.Errorf("get spam config failed, err: %v", err)
+		return nil
+	}
+	if !config.Enabled || ss.isBypassed(ctx, author, config) {
+		return nil
+	}
+
+	result = &Result{Reasons: make([]string, 0)}
+	for _, checker := range ss.checkers {
+		score, reason := checker.Check(ctx, content, author, config)
+		if score <= 0 {
+			continue
+		}
+		result.Score += score
+		if len(reason) > 0 {
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+	result.IsSpam = result.Score >= config.GetThreshold()
+	result.Hold = result.IsSpam && config.AutoHold
+	return result
+}
+
+// isBypassed the admins, the moderators and the users with enough reputation are trusted,
+// the custom roles with the admin or moderation access are trusted too
+func (ss *SpamService) isBypassed(ctx context.Context, author *Author, config *schema.SiteSpamResp) bool {
+	if ss.userRoleRelService.IsAdminModeratorRole(ctx, author.Role) {
+		return true
+	}
+	return author.Rank >= config.GetBypassReputation()
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package spam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testRolePowerRelRepo struct {
+	role.RolePowerRelRepo
+	powers map[int][]string
+}
+
+func (r *testRolePowerRelRepo) GetRolePowerTypeList(_ context.Context, roleID int) ([]string, error) {
+	return r.powers[roleID], nil
+}
+
+func newTestSpamService(t *testing.T, config *schema.SiteSpamResp) (*SpamService, *mock.MockSiteInfoCommonService) {
+	ctrl := gomock.NewController(t)
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+	siteInfoService.EXPECT().GetSiteSpam(gomock.Any()).Return(config, nil).AnyTimes()
+	siteInfoService.EXPECT().GetSiteGeneral(gomock.Any()).Return(&schema.SiteGeneralResp{
+		SiteUrl: "https://answer.test",
+	}, nil).AnyTimes()
+	// the role 4 is a custom role with the moderation access
+	roleService := role.NewRoleService(nil, &testRolePowerRelRepo{powers: map[int][]string{
+		role.RoleAdminID:     {permission.AdminAccess},
+		role.RoleModeratorID: {permission.ModerationAccess},
+		4:                    {permission.ModerationAccess},
+		5:                    {permission.QuestionEdit},
+	}}, nil, nil)
+	return NewSpamService(siteInfoService, role.NewUserRoleRelService(nil, roleService)), siteInfoService
+}
+
+func newAuthor() *Author {
+	return &Author{ID: "1", Username: "new", Rank: 1, Role: role.RoleUserID, CreatedAt: time.Now()}
+}
+
+func TestSpamService_NewAccountWithLinks(t *testing.T) {
+	ss, _ := newTestSpamService(t, &schema.SiteSpamResp{Enabled: true, AutoHold: true})
+	ctx := context.TODO()
+
+	result := ss.Check(ctx, &plugin.ReviewContent{
+		Content: `<p>buy now <a href="https://spam.test">here</a></p>`,
+	}, newAuthor())
+	require.NotNil(t, result)
+	assert.True(t, result.IsSpam)
+	assert.True(t, result.Hold)
+	assert.Len(t, result.Reasons, 2)
+
+	// a new account without links is fine
+	result = ss.Check(ctx, &plugin.ReviewContent{Content: `<p>how to configure the proxy of the server</p>`}, newAuthor())
+	require.NotNil(t, result)
+	assert.False(t, result.IsSpam)
+}
+
+func TestSpamService_Bypass(t *testing.T) {
+	ss, _ := newTestSpamService(t, &schema.SiteSpamResp{Enabled: true, AutoHold: true, BypassReputation: 100})
+	ctx := context.TODO()
+	content := &plugin.ReviewContent{Content: `<a href="https://a.test">a</a> <a href="https://b.test">b</a>`}
+
+	author := newAuthor()
+	author.Rank = 100
+	assert.Nil(t, ss.Check(ctx, content, author))
+
+	author = newAuthor()
+	author.Role = role.RoleModeratorID
+	assert.Nil(t, ss.Check(ctx, content, author))
+
+	author = newAuthor()
+	author.Role = 4
+	assert.Nil(t, ss.Check(ctx, content, author))
+
+	// the custom role without the moderation access is checked
+	author = newAuthor()
+	author.Role = 5
+	assert.NotNil(t, ss.Check(ctx, content, author))
+
+	disabled, _ := newTestSpamService(t, &schema.SiteSpamResp{})
+	assert.Nil(t, disabled.Check(ctx, content, newAuthor()))
+}
+
+func TestSpamService_NotHold(t *testing.T) {
+	ss, _ := newTestSpamService(t, &schema.SiteSpamResp{Enabled: true, Threshold: 30})
+	result := ss.Check(context.TODO(), &plugin.ReviewContent{
+		Content: `<a href="https://a.test">a</a> <a href="https://b.test">b</a> <a href="https://c.test">c</a>`,
+	}, &Author{Rank: 1, ApprovedPostAmount: 10, CreatedAt: time.Now().AddDate(-1, 0, 0)})
+	require.NotNil(t, result)
+	assert.True(t, result.IsSpam)
+	assert.False(t, result.Hold)
+}
+
+func TestSpamService_Akismet(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		form = map[string]string{
+			"blog":                 r.PostForm.Get("blog"),
+			"comment_type":         r.PostForm.Get("comment_type"),
+			"comment_author_email": r.PostForm.Get("comment_author_email"),
+		}
+		_, _ = w.Write([]byte("true"))
+	}))
+	defer server.Close()
+
+	ss, siteInfoService := newTestSpamService(t, &schema.SiteSpamResp{
+		Enabled: true, AutoHold: true, AkismetEnabled: true, AkismetAPIKey: "key"})
+	akismet := newAkismetChecker(siteInfoService)
+	akismet.endpoint = server.URL + "/%s"
+	ss.checkers = []Checker{akismet}
+
+	author := &Author{Rank: 1, Email: "old@answer.test", ApprovedPostAmount: 10}
+	result := ss.Check(context.TODO(), &plugin.ReviewContent{ObjectType: "question", Content: "<p>hello</p>"}, author)
+	require.NotNil(t, result)
+	assert.True(t, result.Hold)
+	assert.Equal(t, "https://answer.test", form["blog"])
+	assert.Equal(t, "forum-post", form["comment_type"])
+	assert.Equal(t, "old@answer.test", form["comment_author_email"])
+}
@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package stale_question
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/comment"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/content"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/segmentfault/pacman/log"
+)
+
+// StaleQuestionService close or archive the stale unanswered questions
+type StaleQuestionService struct {
+	questionRepo    questioncommon.QuestionRepo
+	questionService *content.QuestionService
+	commentRepo     comment.CommentRepo
+	userRepo        usercommon.UserRepo
+	tagCommon       *tagcommon.TagCommonService
+	configService   *config.ConfigService
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	auditLogService *audit_log.AuditLogService
+}
+
+// NewStaleQuestionService new stale question service
+func NewStaleQuestionService(
+	questionRepo questioncommon.QuestionRepo,
+	questionService *content.QuestionService,
+	commentRepo comment.CommentRepo,
+	userRepo usercommon.UserRepo,
+	tagCommon *tagcommon.TagCommonService,
+	configService *config.ConfigService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *StaleQuestionService {
+	return &StaleQuestionService{
+		questionRepo:    questionRepo,
+		questionService: questionService,
+		commentRepo:     commentRepo,
+		userRepo:        userRepo,
+		tagCommon:       tagCommon,
+		configService:   configService,
+		siteInfoService: siteInfoService,
+		auditLogService: auditLogService,
+	}
+}
+
+// AutoCloseStaleQuestions close or archive the questions without answers and activity for the configured days.
+// The job acts as the configured user, every question it touches is recorded in the audit log.
+func (s *StaleQuestionService) AutoCloseStaleQuestions(ctx context.Context) {
+	conf, err := s.siteInfoService.GetSiteAutoClose(ctx)
+	if err != nil {
+		log.Errorf("get auto-close config failed, err: %v", err)
+		return
+	}
+	if !conf.Enabled || conf.Days <= 0 {
+		return
+	}
+	actor, exist, err := s.userRepo.GetByUsername(ctx, conf.Username)
+	if err != nil {
+		log.Errorf("get auto-close user failed, err: %v", err)
+		return
+	}
+	if !exist || actor.Status != entity.UserStatusAvailable {
+		log.Warnf("auto-close user %s is not available, the stale questions are not closed", conf.Username)
+		return
+	}
+	closeType, err := s.configService.GetIDByKey(ctx, constant.ReasonStale)
+	if err != nil {
+		log.Errorf("get stale close reason failed, err: %v", err)
+		return
+	}
+	exemptTagIDs, err := s.getExemptTagIDs(ctx, conf.ExemptTags)
+	if err != nil {
+		log.Errorf("get auto-close exempt tags failed, err: %v", err)
+		return
+	}
+
+	inactiveBefore := time.Now().AddDate(0, 0, -conf.Days)
+	for range constant.StaleQuestionMaxBatches {
+		questions, err := s.questionRepo.GetStaleQuestions(ctx, inactiveBefore, exemptTagIDs,
+			constant.StaleQuestionBatchSize)
+		if err != nil {
+			log.Errorf("get stale questions failed, err: %v", err)
+			return
+		}
+		for _, question := range questions {
+			if err = s.closeStaleQuestion(ctx, question, actor.ID, closeType, conf); err != nil {
+				log.Errorf("auto-close stale question %s failed, err: %v", question.ID, err)
+			}
+		}
+		if len(questions) < constant.StaleQuestionBatchSize {
+			return
+		}
+	}
+}
+
+// closeStaleQuestion close or archive the question and post the comment to it
+func (s *StaleQuestionService) closeStaleQuestion(ctx context.Context, question *entity.Question,
+	actorID string, closeType int, conf *schema.SiteAutoCloseResp) (err error) {
+	if conf.Action == constant.AutoCloseActionArchive {
+		err = s.questionService.OperationQuestion(ctx, &schema.OperationQuestionReq{
+			ID:        question.ID,
+			Operation: schema.QuestionOperationHide,
+			UserID:    actorID,
+		})
+	} else {
+		err = s.questionService.CloseQuestion(ctx, &schema.CloseQuestionReq{
+			ID:        question.ID,
+			CloseType: closeType,
+			UserID:    actorID,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	after := map[string]any{"action": conf.Action, "inactive_days": conf.Days}
+	if len(conf.Comment) > 0 {
+		c := &entity.Comment{
+			UserID:       actorID,
+			ObjectID:     question.ID,
+			QuestionID:   question.ID,
+			Status:       entity.CommentStatusAvailable,
+			OriginalText: conf.Comment,
+			ParsedText:   converter.Markdown2HTML(conf.Comment),
+		}
+		if err = s.commentRepo.AddComment(ctx, c); err != nil {
+			log.Errorf("add auto-close comment to question %s failed, err: %v", question.ID, err)
+		} else {
+			after["comment_id"] = c.ID
+		}
+	}
+
+	s.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    actorID,
+		Action:     constant.AuditActionQuestionAutoClose,
+		ObjectType: constant.QuestionObjectType,
+		ObjectID:   question.ID,
+		Before:     map[string]any{"status": question.Status, "show": question.Show},
+		After:      after,
+	})
+	return nil
+}
+
+// getExemptTagIDs get the ids of the exempt tags and their synonyms
+func (s *StaleQuestionService) getExemptTagIDs(ctx context.Context, tagNames []string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	if len(tagNames) == 0 {
+		return tagIDs, nil
+	}
+	tags, err := s.tagCommon.GetTagListByNames(ctx, tagNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+		synonymIDs, err := s.tagCommon.GetTagIDsByMainTagID(ctx, tag.ID)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = append(tagIDs, synonymIDs...)
+	}
+	return tagIDs, nil
+}
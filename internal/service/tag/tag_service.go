@@ -49,6 +49,7 @@ type TagService struct {
 	tagCommonService     *tagcommonser.TagCommonService
 	revisionService      *revision_common.RevisionService
 	followCommon         activity_common.FollowRepo
+	activityRepo         activity_common.ActivityRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activityqueue.Service
 }
@@ -59,6 +60,7 @@ func NewTagService(
 	tagCommonService *tagcommonser.TagCommonService,
 	revisionService *revision_common.RevisionService,
 	followCommon activity_common.FollowRepo,
+	activityRepo activity_common.ActivityRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activityqueue.Service,
 ) *TagService {
@@ -67,6 +69,7 @@ func NewTagService(
 		tagCommonService:     tagCommonService,
 		revisionService:      revisionService,
 		followCommon:         followCommon,
+		activityRepo:         activityRepo,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
 	}
@@ -273,11 +276,12 @@ func (ts *TagService) GetTagSynonyms(ctx context.Context, req *schema.GetTagSyno
 
 	// get main tag slug name
 	if tag.MainTagID > 0 {
-		for _, tagInfo := range tagList {
-			if tag.MainTagID == 0 {
-				mainTagSlugName = tagInfo.SlugName
-				break
-			}
+		mainTag, exist, err := ts.tagCommonService.GetTagByID(ctx, converter.IntToString(tag.MainTagID))
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			mainTagSlugName = mainTag.SlugName
 		}
 	} else {
 		mainTagSlugName = tag.SlugName
@@ -396,6 +400,20 @@ func (ts *TagService) UpdateTagSynonym(ctx context.Context, req *schema.UpdateTa
 			return err
 		}
 	}
+
+	// move the questions of the existing tags which become synonyms to the main tag
+	needRefreshTagIDs := make([]string, 0, len(tagListInDB)+1)
+	for _, tag := range tagListInDB {
+		err = ts.tagCommonService.MigrateTagQuestions(ctx, tag.ID, mainTagInfo.ID)
+		if err != nil {
+			return err
+		}
+		needRefreshTagIDs = append(needRefreshTagIDs, tag.ID)
+	}
+	if len(needRefreshTagIDs) > 0 {
+		needRefreshTagIDs = append(needRefreshTagIDs, mainTagInfo.ID)
+		return ts.tagCommonService.RefreshTagQuestionCount(ctx, needRefreshTagIDs)
+	}
 	return nil
 }
 
@@ -436,9 +454,11 @@ func (ts *TagService) GetTagWithPage(ctx context.Context, req *schema.GetTagWith
 	return pager.NewPageModel(total, resp), nil
 }
 
-// MergeTag merge tag
+// MergeTag merge the source tag into the target tag, the source tag is deleted
 func (ts *TagService) MergeTag(ctx context.Context, req *schema.MergeTagReq) (err error) {
-	// 1. get source tag and its synonyms
+	if req.SourceTagID == req.TargetTagID {
+		return errors.BadRequest(reason.TagCannotMergeWithItself)
+	}
 	sourceTag, exist, err := ts.tagCommonService.GetTagByID(ctx, req.SourceTagID)
 	if err != nil {
 		return err
@@ -446,54 +466,61 @@ func (ts *TagService) MergeTag(ctx context.Context, req *schema.MergeTagReq) (er
 	if !exist {
 		return errors.BadRequest(reason.TagNotFound)
 	}
-
-	sourceTagSynonyms, err := ts.tagRepo.GetTagList(ctx, &entity.Tag{MainTagID: converter.StringToInt64(sourceTag.ID)})
-	if err != nil {
-		return err
-	}
-
-	addSynonymTagList := make([]string, 0)
-	addSynonymTagList = append(addSynonymTagList, sourceTag.SlugName)
-	for _, tag := range sourceTagSynonyms {
-		addSynonymTagList = append(addSynonymTagList, tag.SlugName)
-	}
-
-	// 2. get target tag
-	targetTagInfo, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TargetTagID)
+	targetTag, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TargetTagID)
 	if err != nil {
 		return err
 	}
 	if !exist {
 		return errors.BadRequest(reason.TagNotFound)
 	}
-
-	// 3. update source tag and its synonyms as synonyms of target tag
-	if len(addSynonymTagList) > 0 {
-		err = ts.tagRepo.UpdateTagSynonym(ctx, addSynonymTagList, converter.StringToInt64(targetTagInfo.ID), targetTagInfo.SlugName)
+	// merge into the main tag if the target tag is a synonym
+	if targetTag.MainTagID > 0 {
+		targetTag, exist, err = ts.tagCommonService.GetTagByID(ctx, converter.IntToString(targetTag.MainTagID))
 		if err != nil {
 			return err
 		}
+		if !exist {
+			return errors.BadRequest(reason.TagNotFound)
+		}
+		if targetTag.ID == sourceTag.ID {
+			return errors.BadRequest(reason.TagCannotMergeWithItself)
+		}
 	}
 
-	// 4. update tag followers
-	err = ts.followCommon.MigrateFollowers(ctx, sourceTag.ID, targetTagInfo.ID, "follow")
-	if err != nil {
-		return err
+	if keepMergeSourceTagDescription(req.Description, sourceTag, targetTag) {
+		targetTag.OriginalText = sourceTag.OriginalText
+		targetTag.ParsedText = sourceTag.ParsedText
 	}
 
-	// 5. update question tags
-	err = ts.tagCommonService.MigrateTagQuestions(ctx, sourceTag.ID, targetTagInfo.ID)
+	followActivityType, err := ts.activityRepo.GetActivityTypeByObjectType(ctx, constant.TagObjectType, "follow")
 	if err != nil {
 		return err
 	}
-	err = ts.tagCommonService.RefreshTagQuestionCount(ctx, []string{targetTagInfo.ID, sourceTag.ID})
+	err = ts.tagRepo.MergeTag(ctx, sourceTag, targetTag, followActivityType)
 	if err != nil {
 		return err
 	}
-
+	ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		ObjectID:         sourceTag.ID,
+		OriginalObjectID: sourceTag.ID,
+		ActivityTypeKey:  constant.ActTagDeleted,
+	})
 	return nil
 }
 
+// keepMergeSourceTagDescription whether the target tag keeps the description of the source tag
+func keepMergeSourceTagDescription(keep string, sourceTag, targetTag *entity.Tag) bool {
+	switch keep {
+	case schema.MergeTagKeepSourceDescription:
+		return true
+	case schema.MergeTagKeepTargetDescription:
+		return false
+	default:
+		return len(sourceTag.OriginalText) > len(targetTag.OriginalText)
+	}
+}
+
 // checkTagIsFollow get tag list page
 func (ts *TagService) checkTagIsFollow(ctx context.Context, userID, tagID string) bool {
 	if len(userID) == 0 {
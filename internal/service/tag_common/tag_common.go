@@ -23,8 +23,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/handler"
@@ -64,6 +66,7 @@ type TagRepo interface {
 	GetTagSynonymCount(ctx context.Context, tagID string) (count int64, err error)
 	GetIDsByMainTagId(ctx context.Context, mainTagID string) (tagIDs []string, err error)
 	GetTagList(ctx context.Context, tag *entity.Tag) (tagList []*entity.Tag, err error)
+	MergeTag(ctx context.Context, sourceTag, targetTag *entity.Tag, followActivityType int) (err error)
 }
 
 type TagRelRepo interface {
@@ -80,6 +83,14 @@ type TagRelRepo interface {
 	CountTagRelByTagID(ctx context.Context, tagID string) (count int64, err error)
 	GetTagRelDefaultStatusByObjectID(ctx context.Context, objectID string) (status int, err error)
 	MigrateTagObjects(ctx context.Context, sourceTagId, targetTagId string) error
+	UpdateTagRelPin(ctx context.Context, objectID, tagID string, pin int, pinExpiredAt time.Time) (err error)
+	CountPinnedTagRel(ctx context.Context, tagID string) (count int64, err error)
+	UnpinExpiredTagRels(ctx context.Context, now time.Time) (count int64, err error)
+}
+
+// UserTagRankRepo keep the reputation of the users in the tags consistent with the tags of the questions
+type UserTagRankRepo interface {
+	ChangeQuestionTagRank(ctx context.Context, questionID string, tagIDs []string, add bool) (err error)
 }
 
 // TagCommonService user service
@@ -88,6 +99,7 @@ type TagCommonService struct {
 	tagCommonRepo        TagCommonRepo
 	tagRelRepo           TagRelRepo
 	tagRepo              TagRepo
+	userTagRankRepo      UserTagRankRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activityqueue.Service
 }
@@ -97,6 +109,7 @@ func NewTagCommonService(
 	tagCommonRepo TagCommonRepo,
 	tagRelRepo TagRelRepo,
 	tagRepo TagRepo,
+	userTagRankRepo UserTagRankRepo,
 	revisionService *revision_common.RevisionService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activityqueue.Service,
@@ -105,6 +118,7 @@ func NewTagCommonService(
 		tagCommonRepo:        tagCommonRepo,
 		tagRelRepo:           tagRelRepo,
 		tagRepo:              tagRepo,
+		userTagRankRepo:      userTagRankRepo,
 		revisionService:      revisionService,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
@@ -684,10 +698,24 @@ func (ts *TagCommonService) ObjectChangeTag(ctx context.Context, objectTagData *
 		return nil, err
 	}
 
+	// the synonym tag is replaced by its main tag silently
+	mainTagMapping, err := ts.getMainTagMapping(ctx, tagListInDb)
+	if err != nil {
+		return nil, err
+	}
 	tagInDbMapping := make(map[string]*entity.Tag)
+	thisObjTagIDMapping := make(map[string]bool)
 	for _, tag := range tagListInDb {
 		tagInDbMapping[strings.ToLower(tag.SlugName)] = tag
-		thisObjTagIDList = append(thisObjTagIDList, tag.ID)
+		tagID := tag.ID
+		if mainTag, ok := mainTagMapping[tag.ID]; ok {
+			tagID = mainTag.ID
+		}
+		if thisObjTagIDMapping[tagID] {
+			continue
+		}
+		thisObjTagIDMapping[tagID] = true
+		thisObjTagIDList = append(thisObjTagIDList, tagID)
 	}
 
 	addTagList := make([]*entity.Tag, 0)
@@ -741,6 +769,35 @@ func (ts *TagCommonService) ObjectChangeTag(ctx context.Context, objectTagData *
 	return nil, nil
 }
 
+// getMainTagMapping get the main tag of each synonym tag in the list, the key is the synonym tag id
+func (ts *TagCommonService) getMainTagMapping(ctx context.Context, tags []*entity.Tag) (
+	mainTagMapping map[string]*entity.Tag, err error) {
+	mainTagMapping = make(map[string]*entity.Tag)
+	mainTagIDs := make([]string, 0)
+	for _, tag := range tags {
+		if tag.MainTagID != 0 {
+			mainTagIDs = append(mainTagIDs, converter.IntToString(tag.MainTagID))
+		}
+	}
+	if len(mainTagIDs) == 0 {
+		return mainTagMapping, nil
+	}
+	mainTagList, err := ts.tagCommonRepo.GetTagListByIDs(ctx, mainTagIDs)
+	if err != nil {
+		return nil, err
+	}
+	mainTags := make(map[string]*entity.Tag, len(mainTagList))
+	for _, tag := range mainTagList {
+		mainTags[tag.ID] = tag
+	}
+	for _, tag := range tags {
+		if mainTag, ok := mainTags[converter.IntToString(tag.MainTagID)]; ok {
+			mainTagMapping[tag.ID] = mainTag
+		}
+	}
+	return mainTagMapping, nil
+}
+
 func (ts *TagCommonService) CountTagRelByTagID(ctx context.Context, tagID string) (count int64, err error) {
 	return ts.tagRelRepo.CountTagRelByTagID(ctx, tagID)
 }
@@ -779,12 +836,60 @@ func (ts *TagCommonService) RefreshTagCountByQuestionID(ctx context.Context, que
 
 // RemoveTagRelListByObjectID remove tag relation by object id
 func (ts *TagCommonService) RemoveTagRelListByObjectID(ctx context.Context, objectID string) (err error) {
-	return ts.tagRelRepo.RemoveTagRelListByObjectID(ctx, objectID)
+	oldTagRelList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if err = ts.tagRelRepo.RemoveTagRelListByObjectID(ctx, objectID); err != nil {
+		return err
+	}
+	ts.changeQuestionTagRank(ctx, objectID, oldTagRelList)
+	return nil
 }
 
 // RecoverTagRelListByObjectID recover tag relation by object id
 func (ts *TagCommonService) RecoverTagRelListByObjectID(ctx context.Context, objectID string) (err error) {
-	return ts.tagRelRepo.RecoverTagRelListByObjectID(ctx, objectID)
+	oldTagRelList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if err = ts.tagRelRepo.RecoverTagRelListByObjectID(ctx, objectID); err != nil {
+		return err
+	}
+	ts.changeQuestionTagRank(ctx, objectID, oldTagRelList)
+	return nil
+}
+
+// changeQuestionTagRank keep the reputation of the users in the tags consistent after the tags of the question
+// changed, the reputation gained from the question is taken back from the removed tags and added to the new ones.
+func (ts *TagCommonService) changeQuestionTagRank(ctx context.Context, objectID string, oldTagRelList []*entity.TagRel) {
+	newTagRelList, err := ts.tagRelRepo.GetObjectTagRelList(ctx, objectID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	oldTagIDs := make(map[string]bool, len(oldTagRelList))
+	for _, rel := range oldTagRelList {
+		oldTagIDs[rel.TagID] = true
+	}
+	addedTagIDs := make([]string, 0)
+	for _, rel := range newTagRelList {
+		if oldTagIDs[rel.TagID] {
+			delete(oldTagIDs, rel.TagID)
+			continue
+		}
+		addedTagIDs = append(addedTagIDs, rel.TagID)
+	}
+	removedTagIDs := make([]string, 0, len(oldTagIDs))
+	for tagID := range oldTagIDs {
+		removedTagIDs = append(removedTagIDs, tagID)
+	}
+	if err = ts.userTagRankRepo.ChangeQuestionTagRank(ctx, objectID, removedTagIDs, false); err != nil {
+		log.Error(err)
+	}
+	if err = ts.userTagRankRepo.ChangeQuestionTagRank(ctx, objectID, addedTagIDs, true); err != nil {
+		log.Error(err)
+	}
 }
 
 func (ts *TagCommonService) HideTagRelListByObjectID(ctx context.Context, objectID string) (err error) {
@@ -795,6 +900,41 @@ func (ts *TagCommonService) ShowTagRelListByObjectID(ctx context.Context, object
 	return ts.tagRelRepo.ShowTagRelListByObjectID(ctx, objectID)
 }
 
+// UpdateTagRelPin pin or unpin the object within the tag
+func (ts *TagCommonService) UpdateTagRelPin(ctx context.Context, objectID, tagID string, pin int,
+	pinExpiredAt time.Time) (err error) {
+	return ts.tagRelRepo.UpdateTagRelPin(ctx, objectID, tagID, pin, pinExpiredAt)
+}
+
+// CountPinnedTagRel count the objects pinned within the tag
+func (ts *TagCommonService) CountPinnedTagRel(ctx context.Context, tagID string) (count int64, err error) {
+	return ts.tagRelRepo.CountPinnedTagRel(ctx, tagID)
+}
+
+// UnpinExpiredTagRels unpin the objects whose pin within the tag has expired
+func (ts *TagCommonService) UnpinExpiredTagRels(ctx context.Context, now time.Time) (count int64, err error) {
+	return ts.tagRelRepo.UnpinExpiredTagRels(ctx, now)
+}
+
+// GetTagPinnedObjectIDs get the objects that are pinned within any of the tags
+func (ts *TagCommonService) GetTagPinnedObjectIDs(ctx context.Context, objectIDs, tagIDs []string) (
+	pinned map[string]bool, err error) {
+	pinned = make(map[string]bool)
+	if len(objectIDs) == 0 || len(tagIDs) == 0 {
+		return pinned, nil
+	}
+	tagRelList, err := ts.tagRelRepo.BatchGetObjectTagRelList(ctx, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range tagRelList {
+		if rel.Pin == entity.QuestionPin && slices.Contains(tagIDs, rel.TagID) {
+			pinned[rel.ObjectID] = true
+		}
+	}
+	return pinned, nil
+}
+
 // CreateOrUpdateTagRelList if tag relation is exists update status, if not create it
 func (ts *TagCommonService) CreateOrUpdateTagRelList(ctx context.Context, objectId string, tagIDs []string) (err error) {
 	addTagIDMapping := make(map[string]struct{})
@@ -855,6 +995,7 @@ func (ts *TagCommonService) CreateOrUpdateTagRelList(ctx context.Context, object
 			return err
 		}
 	}
+	ts.changeQuestionTagRank(ctx, objectId, oldTagRelList)
 
 	err = ts.RefreshTagQuestionCount(ctx, needRefreshTagIDs)
 	if err != nil {
@@ -933,7 +1074,7 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 
 	tagInfoJson, _ := json.Marshal(tagInfo)
 	revisionDTO.Content = string(tagInfoJson)
-	revisionID, err := ts.revisionService.AddRevision(ctx, revisionDTO, true)
+	revisionID, err := ts.revisionService.AddRevision(ctx, revisionDTO, canUpdate)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,210 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// TagModeratorRepo tag moderator repository
+type TagModeratorRepo interface {
+	AddTagModerator(ctx context.Context, tagID, userID string) (err error)
+	RemoveTagModerator(ctx context.Context, tagID, userID string) (err error)
+	GetTagModerators(ctx context.Context, tagID string) (moderators []*entity.TagModerator, err error)
+	GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error)
+}
+
+// TagModeratorService the tag moderators moderate the questions with the tags assigned to them by the admins,
+// they do not have the global moderator powers
+type TagModeratorService struct {
+	tagModeratorRepo  TagModeratorRepo
+	tagCommonService  *tag_common.TagCommonService
+	objectInfoService *object_info.ObjService
+	userCommon        *usercommon.UserCommon
+	auditLogService   *audit_log.AuditLogService
+}
+
+// NewTagModeratorService new tag moderator service
+func NewTagModeratorService(
+	tagModeratorRepo TagModeratorRepo,
+	tagCommonService *tag_common.TagCommonService,
+	objectInfoService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+	auditLogService *audit_log.AuditLogService,
+) *TagModeratorService {
+	return &TagModeratorService{
+		tagModeratorRepo:  tagModeratorRepo,
+		tagCommonService:  tagCommonService,
+		objectInfoService: objectInfoService,
+		userCommon:        userCommon,
+		auditLogService:   auditLogService,
+	}
+}
+
+// IsObjectModerator check the user moderates one of the tags of the question, or of the question of the answer.
+// The synonyms are moderated by the moderators of their main tag.
+func (ts *TagModeratorService) IsObjectModerator(ctx context.Context, userID, objectID string) bool {
+	if len(userID) == 0 || len(objectID) == 0 {
+		return false
+	}
+	moderatedTagIDs, err := ts.tagModeratorRepo.GetModeratedTagIDs(ctx, userID)
+	if err != nil {
+		log.Errorf("get moderated tags failed, err: %v", err)
+		return false
+	}
+	if len(moderatedTagIDs) == 0 {
+		return false
+	}
+	objectInfo, err := ts.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		log.Errorf("get object info failed, object id: %s, err: %v", objectID, err)
+		return false
+	}
+	if objectInfo.ObjectType != constant.QuestionObjectType && objectInfo.ObjectType != constant.AnswerObjectType {
+		return false
+	}
+	tags, err := ts.tagCommonService.GetObjectEntityTag(ctx, objectInfo.QuestionID)
+	if err != nil {
+		log.Errorf("get question tags failed, question id: %s, err: %v", objectInfo.QuestionID, err)
+		return false
+	}
+	return intersectTags(moderatedTagIDs, tags)
+}
+
+// IsTagModerator check the user moderates the tag of the slug name, or its main tag if it is a synonym
+func (ts *TagModeratorService) IsTagModerator(ctx context.Context, userID, tagSlugName string) bool {
+	if len(userID) == 0 || len(tagSlugName) == 0 {
+		return false
+	}
+	moderatedTagIDs, err := ts.tagModeratorRepo.GetModeratedTagIDs(ctx, userID)
+	if err != nil {
+		log.Errorf("get moderated tags failed, err: %v", err)
+		return false
+	}
+	if len(moderatedTagIDs) == 0 {
+		return false
+	}
+	tag, exist, err := ts.tagCommonService.GetTagBySlugName(ctx, strings.ToLower(tagSlugName))
+	if err != nil {
+		log.Errorf("get tag failed, slug name: %s, err: %v", tagSlugName, err)
+		return false
+	}
+	if !exist {
+		return false
+	}
+	return intersectTags(moderatedTagIDs, []*entity.Tag{tag})
+}
+
+// intersectTags check any of the tags or their main tags is in the moderated tags
+func intersectTags(moderatedTagIDs []string, tags []*entity.Tag) bool {
+	moderated := make(map[string]bool, len(moderatedTagIDs))
+	for _, id := range moderatedTagIDs {
+		moderated[id] = true
+	}
+	for _, tag := range tags {
+		if moderated[tag.ID] || (tag.MainTagID != 0 && moderated[converter.IntToString(tag.MainTagID)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTagModerators get the moderators of the tag
+func (ts *TagModeratorService) GetTagModerators(ctx context.Context, req *schema.GetTagModeratorsReq) (
+	resp []*schema.TagModeratorResp, err error) {
+	moderators, err := ts.tagModeratorRepo.GetTagModerators(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(moderators))
+	for _, moderator := range moderators {
+		userIDs = append(userIDs, moderator.UserID)
+	}
+	userInfoMapping, err := ts.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.TagModeratorResp, 0, len(moderators))
+	for _, moderator := range moderators {
+		userInfo, ok := userInfoMapping[moderator.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.TagModeratorResp{UserInfo: userInfo, CreatedAt: moderator.CreatedAt.Unix()})
+	}
+	return resp, nil
+}
+
+// AddTagModerator assign the user as the moderator of the tag, assigning the moderator again changes nothing
+func (ts *TagModeratorService) AddTagModerator(ctx context.Context, req *schema.AddTagModeratorReq) (err error) {
+	tag, exist, err := ts.tagCommonService.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	userInfo, exist, err := ts.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status == constant.UserDeleted {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = ts.tagModeratorRepo.AddTagModerator(ctx, tag.ID, userInfo.ID); err != nil {
+		return err
+	}
+	ts.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionTagModeratorAdd,
+		ObjectType: constant.TagObjectType,
+		ObjectID:   tag.ID,
+		After:      map[string]any{"user_id": userInfo.ID},
+	})
+	return nil
+}
+
+// RemoveTagModerator remove the user from the moderators of the tag
+func (ts *TagModeratorService) RemoveTagModerator(ctx context.Context, req *schema.RemoveTagModeratorReq) (err error) {
+	if err = ts.tagModeratorRepo.RemoveTagModerator(ctx, req.TagID, req.ModeratorUserID); err != nil {
+		return err
+	}
+	ts.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.UserID,
+		Action:     constant.AuditActionTagModeratorRemove,
+		ObjectType: constant.TagObjectType,
+		ObjectID:   req.TagID,
+		Before:     map[string]any{"user_id": req.ModeratorUserID},
+	})
+	return nil
+}
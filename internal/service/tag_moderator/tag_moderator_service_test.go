@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_moderator
+
+import (
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntersectTags(t *testing.T) {
+	tags := []*entity.Tag{
+		{ID: "101"},
+		{ID: "102", MainTagID: 201},
+	}
+	tests := []struct {
+		name            string
+		moderatedTagIDs []string
+		want            bool
+	}{
+		{name: "no moderated tags", moderatedTagIDs: nil, want: false},
+		{name: "other tags", moderatedTagIDs: []string{"103"}, want: false},
+		{name: "question tag", moderatedTagIDs: []string{"101"}, want: true},
+		{name: "main tag of synonym", moderatedTagIDs: []string{"201"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, intersectTags(tt.moderatedTagIDs, tags))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+	"image"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/dir"
+	"github.com/disintegration/imaging"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// hasImageVariants only the images of the posts have the resized variants
+func hasImageVariants(fileSubPath string) bool {
+	return strings.HasPrefix(fileSubPath, constant.PostSubPath+"/")
+}
+
+// generateImageVariants make the thumbnail and the web version of the image next to it, the image is shrunk with
+// its aspect ratio kept. The variant is not made if the image is small enough already, or it is an animated GIF
+// or a format that can not be encoded, the original is served instead of it.
+func (us *uploaderService) generateImageVariants(fileSubPath, mimeType string, siteAdvanced *schema.SiteAdvancedResp) (
+	variantSubPaths []string, err error) {
+	filePath := filepath.Join(us.serviceConfig.UploadPath, fileSubPath)
+	if _, err = imaging.FormatFromFilename(filePath); err != nil {
+		return nil, nil
+	}
+	if mimeType == "image/gif" && isAnimatedGIF(filePath) {
+		return nil, nil
+	}
+	img, err := imaging.Open(filePath)
+	if err != nil {
+		return nil, errors.BadRequest(reason.UploadFileUnsupportedFileFormat).WithError(err)
+	}
+
+	for _, size := range constant.ImageVariants {
+		variant := resizeImageVariant(img, size, siteAdvanced)
+		if variant == nil {
+			continue
+		}
+		variantSubPath := constant.ImageVariantSubPath(fileSubPath, size)
+		if err = imaging.Save(variant, filepath.Join(us.serviceConfig.UploadPath, variantSubPath)); err != nil {
+			return variantSubPaths, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+		variantSubPaths = append(variantSubPaths, variantSubPath)
+	}
+	return variantSubPaths, nil
+}
+
+// resizeImageVariant returns nil if the image is not larger than the size of the variant, it is never upscaled.
+func resizeImageVariant(img image.Image, size string, siteAdvanced *schema.SiteAdvancedResp) image.Image {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	switch size {
+	case constant.ImageSizeThumb:
+		maxSize := siteAdvanced.GetThumbnailSize()
+		if width <= maxSize && height <= maxSize {
+			return nil
+		}
+		return imaging.Fit(img, maxSize, maxSize, imaging.Lanczos)
+	case constant.ImageSizeWeb:
+		maxWidth := siteAdvanced.GetWebImageMaxWidth()
+		if width <= maxWidth {
+			return nil
+		}
+		return imaging.Resize(img, maxWidth, 0, imaging.Lanczos)
+	}
+	return nil
+}
+
+func isAnimatedGIF(filePath string) bool {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	g, err := gif.DecodeAll(f)
+	return err == nil && len(g.Image) > 1
+}
+
+// ImageVariantSubPath returns the sub path of the resized variant of the image, the sub path of the original is returned
+// if the size is unknown or the variant is not made, because the image is small enough or uploaded before.
+func (us *uploaderService) ImageVariantSubPath(ctx context.Context, fileSubPath, size string) string {
+	if !hasImageVariants(fileSubPath) || (size != constant.ImageSizeThumb && size != constant.ImageSizeWeb) {
+		return fileSubPath
+	}
+	variantSubPath := constant.ImageVariantSubPath(fileSubPath, size)
+	if dir.CheckFileExist(filepath.Join(us.serviceConfig.UploadPath, variantSubPath)) {
+		return variantSubPath
+	}
+	storage, err := us.getObjectStorage(ctx)
+	if err != nil || storage == nil {
+		return fileSubPath
+	}
+	exists, err := storage.NewClient().ObjectExists(ctx, storage.ObjectKey(variantSubPath))
+	if err != nil {
+		log.Errorf("check image variant %s failed: %v", variantSubPath, err)
+		return fileSubPath
+	}
+	if !exists {
+		return fileSubPath
+	}
+	return variantSubPath
+}
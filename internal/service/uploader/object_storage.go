@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package uploader
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/dir"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// getObjectStorage returns the configuration of the object storage, it is nil if the files are stored locally.
+func (us *uploaderService) getObjectStorage(ctx context.Context) (storage *schema.SiteStorageResp, err error) {
+	storage, err = us.siteInfoService.GetSiteStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !storage.IsObjectStorage() {
+		return nil, nil
+	}
+	return storage, nil
+}
+
+// saveToObjectStorage upload the local saved file to the object storage if it is enabled,
+// the local file is removed after uploading, so that all replicas read the file from the object storage.
+func (us *uploaderService) saveToObjectStorage(ctx context.Context, fileSubPath, contentType string) error {
+	storage, err := us.getObjectStorage(ctx)
+	if err != nil {
+		return err
+	}
+	if storage == nil {
+		return nil
+	}
+	filePath := filepath.Join(us.serviceConfig.UploadPath, fileSubPath)
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = storage.NewClient().PutObject(ctx, storage.ObjectKey(fileSubPath), content, contentType); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = os.Remove(filePath); err != nil {
+		log.Warnf("remove local file %s after uploading to object storage failed: %v", filePath, err)
+	}
+	return nil
+}
+
+// StorageFileURL returns the URL of the uploaded file in the object storage that the request should be redirected to.
+// It is empty if the files are stored locally or the file is still on the local disk, such as the files uploaded
+// before switching to the object storage and not migrated yet. The file is downloaded as the download filename if it is set.
+func (us *uploaderService) StorageFileURL(ctx context.Context, fileSubPath, downloadFilename string) (
+	fileURL string, err error) {
+	if dir.CheckFileExist(filepath.Join(us.serviceConfig.UploadPath, fileSubPath)) {
+		return "", nil
+	}
+	storage, err := us.getObjectStorage(ctx)
+	if err != nil || storage == nil {
+		return "", err
+	}
+	siteSecurity, err := us.siteInfoService.GetSiteSecurity(ctx)
+	if err != nil {
+		return "", err
+	}
+	// the files of the private site are only accessible by the signed URLs that expire
+	if publicURL := storage.PublicObjectURL(fileSubPath); len(publicURL) > 0 &&
+		!siteSecurity.LoginRequired && len(downloadFilename) == 0 {
+		return publicURL, nil
+	}
+	query := url.Values{}
+	if len(downloadFilename) > 0 {
+		query.Set("response-content-disposition", "attachment; filename*=UTF-8''"+url.PathEscape(downloadFilename))
+	}
+	fileURL, err = storage.NewClient().PresignGetObject(storage.ObjectKey(fileSubPath),
+		storage.GetSignedURLExpiry(), time.Now(), query)
+	if err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return fileURL, nil
+}
+
+// localFilePath returns the local path of the uploaded file, the file is downloaded from the object storage
+// into the local upload directory if it is missing, such as the original avatar that the thumbnails are made from.
+func (us *uploaderService) localFilePath(ctx context.Context, fileSubPath string) (filePath string, err error) {
+	filePath = filepath.Join(us.serviceConfig.UploadPath, fileSubPath)
+	if dir.CheckFileExist(filePath) {
+		return filePath, nil
+	}
+	storage, err := us.getObjectStorage(ctx)
+	if err != nil {
+		return "", err
+	}
+	if storage == nil {
+		return filePath, nil
+	}
+	content, err := storage.NewClient().GetObject(ctx, storage.ObjectKey(fileSubPath))
+	if err != nil {
+		return "", errors.NotFound(reason.UnknownError).WithError(err)
+	}
+	if err = dir.CreateDirIfNotExist(filepath.Dir(filePath)); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = os.WriteFile(filePath, content, 0644); err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return filePath, nil
+}
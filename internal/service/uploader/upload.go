@@ -21,6 +21,8 @@ package uploader
 
 import (
 	"bytes"
+	"context"
+	errpkg "errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -48,6 +50,9 @@ import (
 	"github.com/segmentfault/pacman/log"
 )
 
+// uploadFormOverhead the size of the multipart form beyond the file, the size of the file is checked separately
+const uploadFormOverhead = 64 * 1024
+
 var (
 	subPathList = []string{
 		constant.AvatarSubPath,
@@ -71,6 +76,9 @@ type UploaderService interface {
 	UploadPostAttachment(ctx *gin.Context, userID string) (url string, err error)
 	UploadBrandingFile(ctx *gin.Context, userID string) (url string, err error)
 	AvatarThumbFile(ctx *gin.Context, fileName string, size int) (url string, err error)
+	AvatarFile(ctx *gin.Context, fileName string) (filePath string, err error)
+	StorageFileURL(ctx context.Context, fileSubPath, downloadFilename string) (fileURL string, err error)
+	ImageVariantSubPath(ctx context.Context, fileSubPath, size string) string
 }
 
 // uploaderService uploader service
@@ -114,22 +122,22 @@ func (us *uploaderService) UploadAvatarFile(ctx *gin.Context, userID string) (ur
 		return "", err
 	}
 
-	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, siteAdvanced.GetMaxImageSize())
-	file, fileHeader, err := ctx.Request.FormFile("file")
+	file, fileHeader, mimeType, err := openUploadFile(ctx,
+		siteAdvanced.GetMaxAvatarSize(), siteAdvanced.AuthorizedAvatarMimeTypes)
 	if err != nil {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", err
 	}
 	defer func() {
 		_ = file.Close()
 	}()
 	fileExt := strings.ToLower(path.Ext(fileHeader.Filename))
 	if _, ok := plugin.DefaultFileTypeCheckMapping[plugin.UserAvatar][fileExt]; !ok {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", errors.BadRequest(reason.UploadFileTypeNotAllowed)
 	}
 
 	newFilename := fmt.Sprintf("%s%s", uid.IDStr12(), fileExt)
 	avatarFilePath := path.Join(constant.AvatarSubPath, newFilename)
-	url, err = us.uploadImageFile(ctx, fileHeader, avatarFilePath)
+	url, err = us.uploadImageFile(ctx, fileHeader, avatarFilePath, mimeType)
 	if err != nil {
 		return "", err
 	}
@@ -137,11 +145,16 @@ func (us *uploaderService) UploadAvatarFile(ctx *gin.Context, userID string) (ur
 	return url, nil
 }
 
+// AvatarFile returns the local path of the original avatar file
+func (us *uploaderService) AvatarFile(ctx *gin.Context, fileName string) (filePath string, err error) {
+	return us.localFilePath(ctx, path.Join(constant.AvatarSubPath, fileName))
+}
+
 func (us *uploaderService) AvatarThumbFile(ctx *gin.Context, fileName string, size int) (url string, err error) {
 	fileSuffix := path.Ext(fileName)
 	if _, ok := supportedThumbFileExtMapping[fileSuffix]; !ok {
 		// if file type is not supported, return original file
-		return path.Join(us.serviceConfig.UploadPath, constant.AvatarSubPath, fileName), nil
+		return us.AvatarFile(ctx, fileName)
 	}
 	if size > 1024 {
 		size = 1024
@@ -153,7 +166,10 @@ func (us *uploaderService) AvatarThumbFile(ctx *gin.Context, fileName string, si
 	if err == nil {
 		return thumbFilePath, nil
 	}
-	filePath := fmt.Sprintf("%s/%s/%s", us.serviceConfig.UploadPath, constant.AvatarSubPath, fileName)
+	filePath, err := us.AvatarFile(ctx, fileName)
+	if err != nil {
+		return "", err
+	}
 	avatarFile, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", errors.NotFound(reason.UnknownError).WithError(err)
@@ -206,22 +222,22 @@ func (us *uploaderService) UploadPostFile(ctx *gin.Context, userID string) (
 		return "", err
 	}
 
-	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, siteAdvanced.GetMaxImageSize())
-	file, fileHeader, err := ctx.Request.FormFile("file")
+	file, fileHeader, mimeType, err := openUploadFile(ctx,
+		siteAdvanced.GetMaxImageSize(), siteAdvanced.AuthorizedImageMimeTypes)
 	if err != nil {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", err
 	}
 	defer func() {
 		_ = file.Close()
 	}()
 	if checker.IsUnAuthorizedExtension(fileHeader.Filename, siteAdvanced.AuthorizedImageExtensions) {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", errors.BadRequest(reason.UploadFileTypeNotAllowed)
 	}
 
 	fileExt := strings.ToLower(path.Ext(fileHeader.Filename))
 	newFilename := fmt.Sprintf("%s%s", uid.IDStr12(), fileExt)
 	avatarFilePath := path.Join(constant.PostSubPath, newFilename)
-	url, err = us.uploadImageFile(ctx, fileHeader, avatarFilePath)
+	url, err = us.uploadImageFile(ctx, fileHeader, avatarFilePath, mimeType)
 	if err != nil {
 		return "", err
 	}
@@ -244,22 +260,21 @@ func (us *uploaderService) UploadPostAttachment(ctx *gin.Context, userID string)
 		return "", err
 	}
 
-	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, resp.GetMaxAttachmentSize())
-	file, fileHeader, err := ctx.Request.FormFile("file")
+	file, fileHeader, mimeType, err := openUploadFile(ctx, resp.GetMaxAttachmentSize(), resp.AuthorizedAttachmentMimeTypes)
 	if err != nil {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", err
 	}
 	defer func() {
 		_ = file.Close()
 	}()
 	if checker.IsUnAuthorizedExtension(fileHeader.Filename, resp.AuthorizedAttachmentExtensions) {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", errors.BadRequest(reason.UploadFileTypeNotAllowed)
 	}
 
 	fileExt := strings.ToLower(path.Ext(fileHeader.Filename))
 	newFilename := fmt.Sprintf("%s%s", uid.IDStr12(), fileExt)
 	attachmentFilePath := path.Join(constant.FilesPostSubPath, newFilename)
-	url, err = us.uploadAttachmentFile(ctx, fileHeader, fileHeader.Filename, attachmentFilePath)
+	url, err = us.uploadAttachmentFile(ctx, fileHeader, fileHeader.Filename, attachmentFilePath, mimeType)
 	if err != nil {
 		return "", err
 	}
@@ -282,22 +297,21 @@ func (us *uploaderService) UploadBrandingFile(ctx *gin.Context, userID string) (
 		return "", err
 	}
 
-	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, siteAdvanced.GetMaxImageSize())
-	file, fileHeader, err := ctx.Request.FormFile("file")
+	file, fileHeader, mimeType, err := openUploadFile(ctx, siteAdvanced.GetMaxImageSize(), nil)
 	if err != nil {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", err
 	}
 	defer func() {
 		_ = file.Close()
 	}()
 	fileExt := strings.ToLower(path.Ext(fileHeader.Filename))
 	if _, ok := plugin.DefaultFileTypeCheckMapping[plugin.AdminBranding][fileExt]; !ok {
-		return "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+		return "", errors.BadRequest(reason.UploadFileTypeNotAllowed)
 	}
 
 	newFilename := fmt.Sprintf("%s%s", uid.IDStr12(), fileExt)
 	avatarFilePath := path.Join(constant.BrandingSubPath, newFilename)
-	url, err = us.uploadImageFile(ctx, fileHeader, avatarFilePath)
+	url, err = us.uploadImageFile(ctx, fileHeader, avatarFilePath, mimeType)
 	if err != nil {
 		return "", err
 	}
@@ -305,7 +319,36 @@ func (us *uploaderService) UploadBrandingFile(ctx *gin.Context, userID string) (
 	return url, nil
 }
 
-func (us *uploaderService) uploadImageFile(ctx *gin.Context, file *multipart.FileHeader, fileSubPath string) (
+// openUploadFile open the file of the request, the size of the file and the mime type detected from its content
+// are checked, the declared extension and content type are not trusted.
+func openUploadFile(ctx *gin.Context, maxSize int64, allowedMimeTypes []string) (
+	file multipart.File, fileHeader *multipart.FileHeader, mimeType string, err error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+uploadFormOverhead)
+	file, fileHeader, err = ctx.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errpkg.As(err, &maxBytesErr) {
+			return nil, nil, "", errors.BadRequest(reason.UploadFileTooLarge)
+		}
+		return nil, nil, "", errors.BadRequest(reason.RequestFormatError).WithError(err)
+	}
+	if fileHeader.Size > maxSize {
+		_ = file.Close()
+		return nil, nil, "", errors.BadRequest(reason.UploadFileTooLarge)
+	}
+	mimeType, err = checker.DetectFileMimeType(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if checker.IsUnAuthorizedMimeType(mimeType, allowedMimeTypes) {
+		_ = file.Close()
+		return nil, nil, "", errors.BadRequest(reason.UploadFileTypeNotAllowed)
+	}
+	return file, fileHeader, mimeType, nil
+}
+
+func (us *uploaderService) uploadImageFile(ctx *gin.Context, file *multipart.FileHeader, fileSubPath, mimeType string) (
 	url string, err error) {
 	siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
@@ -332,15 +375,29 @@ func (us *uploaderService) uploadImageFile(ctx *gin.Context, file *multipart.Fil
 		return "", errors.BadRequest(reason.UploadFileUnsupportedFileFormat)
 	}
 
-	if err := removeExif(filePath); err != nil {
+	if err := removeExif(filePath, mimeType); err != nil {
 		log.Error(err)
 	}
 
+	var variantSubPaths []string
+	if hasImageVariants(fileSubPath) {
+		variantSubPaths, err = us.generateImageVariants(fileSubPath, mimeType, siteAdvanced)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	for _, subPath := range append([]string{fileSubPath}, variantSubPaths...) {
+		if err := us.saveToObjectStorage(ctx, subPath, mimeType); err != nil {
+			return "", err
+		}
+	}
+
 	url = fmt.Sprintf("%s/uploads/%s", siteGeneral.SiteUrl, fileSubPath)
 	return url, nil
 }
 
-func (us *uploaderService) uploadAttachmentFile(ctx *gin.Context, file *multipart.FileHeader, originalFilename, fileSubPath string) (
+func (us *uploaderService) uploadAttachmentFile(ctx *gin.Context, file *multipart.FileHeader, originalFilename, fileSubPath, mimeType string) (
 	downloadUrl string, err error) {
 	siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx)
 	if err != nil {
@@ -350,6 +407,9 @@ func (us *uploaderService) uploadAttachmentFile(ctx *gin.Context, file *multipar
 	if err := ctx.SaveUploadedFile(file, filePath); err != nil {
 		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
 	}
+	if err := us.saveToObjectStorage(ctx, fileSubPath, mimeType); err != nil {
+		return "", err
+	}
 
 	// Need url encode the original filename. Because the filename may contain special characters that conflict with the markdown syntax.
 	originalFilename = url.QueryEscape(originalFilename)
@@ -372,9 +432,13 @@ func (us *uploaderService) tryToUploadByPlugin(ctx *gin.Context, source plugin.U
 		Source:                         source,
 		MaxImageSize:                   siteAdvanced.MaxImageSize,
 		MaxAttachmentSize:              siteAdvanced.MaxAttachmentSize,
+		MaxAvatarSize:                  siteAdvanced.MaxAvatarSize,
 		MaxImageMegapixel:              siteAdvanced.MaxImageMegapixel,
 		AuthorizedImageExtensions:      siteAdvanced.AuthorizedImageExtensions,
 		AuthorizedAttachmentExtensions: siteAdvanced.AuthorizedAttachmentExtensions,
+		AuthorizedAvatarMimeTypes:      siteAdvanced.AuthorizedAvatarMimeTypes,
+		AuthorizedImageMimeTypes:       siteAdvanced.AuthorizedImageMimeTypes,
+		AuthorizedAttachmentMimeTypes:  siteAdvanced.AuthorizedAttachmentMimeTypes,
 	}
 	_ = plugin.CallStorage(func(fn plugin.Storage) error {
 		resp := fn.UploadFile(ctx, cond)
@@ -389,11 +453,10 @@ func (us *uploaderService) tryToUploadByPlugin(ctx *gin.Context, source plugin.U
 	return url, err
 }
 
-// removeExif remove exif
-// only support jpg/jpeg/png
-func removeExif(path string) error {
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
-	if ext != "jpeg" && ext != "jpg" && ext != "png" {
+// removeExif remove exif, the location where the photo was taken is in it.
+// only support jpg/jpeg/png, the type is detected from the content of the file
+func removeExif(path, mimeType string) error {
+	if mimeType != "image/jpeg" && mimeType != "image/png" {
 		return nil
 	}
 	img, err := os.ReadFile(path)
@@ -34,6 +34,7 @@ import (
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/badge"
 	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/eventqueue"
 	"github.com/apache/answer/internal/service/export"
 	notificationcommon "github.com/apache/answer/internal/service/notification_common"
 	"github.com/apache/answer/internal/service/plugin_common"
@@ -46,8 +47,10 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
 	"github.com/apache/answer/internal/service/apikey"
+	"github.com/apache/answer/internal/service/audit_log"
 	"github.com/apache/answer/internal/service/auth"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_external_login"
@@ -70,6 +73,7 @@ type UserAdminRepo interface {
 	UpdateUserPassword(ctx context.Context, userID string, password string) (err error)
 	DeletePermanentlyUsers(ctx context.Context) (err error)
 	GetExpiredSuspendedUsers(ctx context.Context) (users []*entity.User, err error)
+	EraseUser(ctx context.Context, userID string, deleteContent, revertVotes bool) (questionIDs []string, err error)
 }
 
 // UserAdminService user service
@@ -89,6 +93,9 @@ type UserAdminService struct {
 	pluginUserConfigRepo  plugin_common.PluginUserConfigRepo
 	badgeAwardRepo        badge.BadgeAwardRepo
 	apiKeyRepo            apikey.APIKeyRepo
+	auditLogService       *audit_log.AuditLogService
+	serviceConfig         *service_config.ServiceConfig
+	eventQueueService     eventqueue.Service
 }
 
 // NewUserAdminService new user admin service
@@ -108,6 +115,9 @@ func NewUserAdminService(
 	pluginUserConfigRepo plugin_common.PluginUserConfigRepo,
 	badgeAwardRepo badge.BadgeAwardRepo,
 	apiKeyRepo apikey.APIKeyRepo,
+	auditLogService *audit_log.AuditLogService,
+	serviceConfig *service_config.ServiceConfig,
+	eventQueueService eventqueue.Service,
 ) *UserAdminService {
 	return &UserAdminService{
 		userRepo:              userRepo,
@@ -125,6 +135,9 @@ func NewUserAdminService(
 		pluginUserConfigRepo:  pluginUserConfigRepo,
 		badgeAwardRepo:        badgeAwardRepo,
 		apiKeyRepo:            apiKeyRepo,
+		auditLogService:       auditLogService,
+		serviceConfig:         serviceConfig,
+		eventQueueService:     eventQueueService,
 	}
 }
 
@@ -146,6 +159,7 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 		return nil
 	}
 
+	before := map[string]any{"status": userInfo.Status, "mail_status": userInfo.MailStatus}
 	if req.IsInactive() {
 		userInfo.MailStatus = entity.EmailStatusToBeVerified
 	}
@@ -166,6 +180,23 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if err != nil {
 		return err
 	}
+	after := map[string]any{"status": userInfo.Status, "mail_status": userInfo.MailStatus,
+		"remove_all_content": req.RemoveAllContent}
+	if req.IsSuspended() {
+		after["suspended_until"] = suspendedUntil.Unix()
+	}
+	us.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.LoginUserID,
+		Action:     constant.AuditActionUserStatusChange,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+		Before:     before,
+		After:      after,
+	})
+	if req.IsSuspended() {
+		us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserBan, req.LoginUserID).TID(userInfo.ID).
+			ChangedFields("status", "suspended_until"))
+	}
 	if req.IsInactive() || req.IsSuspended() || req.IsDeleted() {
 		if err := us.revokeUserAPIKeys(ctx, userInfo.ID); err != nil {
 			return err
@@ -188,6 +219,69 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	return nil
 }
 
+// EraseUser delete the account of the user and remove the personal data of it. Unlike the deleted status,
+// the user can not be recovered. The posts are kept for the community and handled by the account deletion policy.
+func (us *UserAdminService) EraseUser(ctx context.Context, req *schema.EraseUserReq) (err error) {
+	if req.UserID == req.LoginUserID {
+		return errors.BadRequest(reason.AdminCannotModifySelfStatus)
+	}
+	userInfo, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Username == constant.DeletedUserPlaceholderUsername {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+
+	policy := us.serviceConfig.GetAccountDeletion()
+	questionIDs, err := us.userRepo.EraseUser(ctx, userInfo.ID,
+		policy.ContentPolicy == service_config.AccountDeletionContentDelete,
+		policy.VotePolicy == service_config.AccountDeletionVotesRevert)
+	if err != nil {
+		return err
+	}
+	us.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	for _, questionID := range questionIDs {
+		if err := us.questionCommonRepo.UpdateSearch(ctx, questionID); err != nil {
+			log.Errorf("update search of question %s failed: %v", questionID, err)
+		}
+	}
+	us.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.LoginUserID,
+		Action:     constant.AuditActionUserErase,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   userInfo.ID,
+		Before:     map[string]any{"status": userInfo.Status},
+		After:      map[string]any{"content_policy": policy.ContentPolicy, "vote_policy": policy.VotePolicy},
+	})
+	return nil
+}
+
+// RevokeUserSessions log out all the sessions of the user immediately
+func (us *UserAdminService) RevokeUserSessions(ctx context.Context, req *schema.RevokeUserSessionsReq) (err error) {
+	if req.UserID == req.LoginUserID {
+		return errors.BadRequest(reason.AdminCannotModifySelfStatus)
+	}
+	_, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = us.authService.RevokeUserSessions(ctx, req.UserID, req.RevokeAPIKeys); err != nil {
+		return err
+	}
+	us.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.LoginUserID,
+		Action:     constant.AuditActionUserSessionRevoke,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		After:      map[string]any{"revoke_api_keys": req.RevokeAPIKeys},
+	})
+	return nil
+}
+
 // removeAllUserConfiguration remove all user configuration
 func (us *UserAdminService) removeAllUserConfiguration(ctx context.Context, userID string) {
 	err := us.userExternalLoginRepo.DeleteUserExternalLoginByUserID(ctx, userID)
@@ -231,11 +325,30 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 	if req.UserID == req.LoginUserID {
 		return errors.BadRequest(reason.UserCannotUpdateYourRole)
 	}
+	if err = us.userRoleRelService.CheckRoleExist(ctx, req.RoleID); err != nil {
+		return err
+	}
 
+	oldRoleID, err := us.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
 	err = us.userRoleRelService.SaveUserRole(ctx, req.UserID, req.RoleID)
 	if err != nil {
 		return err
 	}
+	us.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.LoginUserID,
+		Action:     constant.AuditActionUserRoleChange,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+		Before:     map[string]any{"role_id": oldRoleID},
+		After:      map[string]any{"role_id": req.RoleID},
+	})
+	if oldRoleID != req.RoleID {
+		us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserRole, req.LoginUserID).TID(req.UserID).
+			ChangedFields("role_id"))
+	}
 	if err := us.revokeUserAPIKeys(ctx, req.UserID); err != nil {
 		return err
 	}
@@ -280,6 +393,7 @@ func (us *UserAdminService) AddUser(ctx context.Context, req *schema.AddUserReq)
 	if err != nil {
 		return err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, req.LoginUserID).TID(userInfo.ID))
 	return
 }
 
@@ -301,7 +415,21 @@ func (us *UserAdminService) AddUsers(ctx context.Context, req *schema.AddUsersRe
 	if errData != nil {
 		return errData.GetErrField(ctx), errors.BadRequest(reason.RequestFormatError)
 	}
-	return nil, us.userRepo.AddUsers(ctx, users)
+	if err = us.userRepo.AddUsers(ctx, users); err != nil {
+		return nil, err
+	}
+	// the ids are not filled by the batch insert, the new users are found by their emails
+	for _, user := range users {
+		newUser, exist, err := us.userRepo.GetUserInfoByEmail(ctx, user.EMail)
+		if err != nil {
+			log.Errorf("get new user %s failed: %v", user.EMail, err)
+			continue
+		}
+		if exist {
+			us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, req.LoginUserID).TID(newUser.ID))
+		}
+	}
+	return nil, nil
 }
 
 func (us *UserAdminService) checkUserDuplicateInner(ctx context.Context, users []*schema.AddUserReq) (
@@ -416,7 +544,7 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if req.UserID == req.LoginUserID {
 		return nil, errors.BadRequest(reason.AdminCannotEditTheirProfile)
 	}
-	_, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
+	oldUserInfo, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -424,7 +552,9 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
 
-	if checker.IsInvalidUsername(req.Username) || checker.IsUsersIgnorePath(req.Username) {
+	// the existing username is kept even if it breaks the current username policy
+	if req.Username != oldUserInfo.Username &&
+		(us.userCommonService.GetUsernamePolicy(ctx).IsInvalidUsername(req.Username) || checker.IsUsersIgnorePath(req.Username)) {
 		return append(errFields, &validator.FormErrorField{
 			ErrorField: "username",
 			ErrorMsg:   reason.UsernameInvalid,
@@ -463,6 +593,10 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if err != nil {
 		return nil, err
 	}
+	updated := *oldUserInfo
+	updated.DisplayName, updated.Username, updated.EMail = user.DisplayName, user.Username, user.EMail
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserUpdate, req.LoginUserID).TID(req.UserID).
+		ChangedFields(usercommon.ProfileChangedFields(oldUserInfo, &updated)...))
 	return
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/pkg/checker"
@@ -202,11 +203,12 @@ func (us *UserCommon) MakeUsername(ctx context.Context, displayName string) (use
 	username = strings.ToLower(username)
 	suffix := ""
 
-	if checker.IsInvalidUsername(username) {
+	policy := us.GetUsernamePolicy(ctx)
+	if policy.IsInvalidUsername(username) {
 		return "", errors.BadRequest(reason.UsernameInvalid)
 	}
 
-	if checker.IsReservedUsername(username) {
+	if policy.IsReservedUsername(username) {
 		return "", errors.BadRequest(reason.UsernameInvalid)
 	}
 
@@ -223,6 +225,16 @@ func (us *UserCommon) MakeUsername(ctx context.Context, displayName string) (use
 	return username + suffix, nil
 }
 
+// GetUsernamePolicy get the policy the new usernames are checked with, the built-in policy is used if the config is unavailable
+func (us *UserCommon) GetUsernamePolicy(ctx context.Context) *checker.UsernamePolicy {
+	config, err := us.siteInfoCommonService.GetSiteUsernamePolicy(ctx)
+	if err != nil {
+		log.Errorf("get username policy failed, err: %v", err)
+		config = &schema.SiteUsernamePolicyResp{}
+	}
+	return config.ToPolicy()
+}
+
 func (us *UserCommon) CacheLoginUserInfo(ctx context.Context, userID string, userStatus, emailStatus int, externalID string) (
 	accessToken string, userCacheInfo *entity.UserCacheInfo, err error) {
 	roleID, err := us.userRoleService.GetUserRole(ctx, userID)
@@ -242,7 +254,7 @@ func (us *UserCommon) CacheLoginUserInfo(ctx context.Context, userID string, use
 	if err != nil {
 		return "", nil, err
 	}
-	if userCacheInfo.RoleID == role.RoleAdminID {
+	if us.userRoleService.CheckRolePower(ctx, userCacheInfo.RoleID, permission.AdminAccess) {
 		if err = us.authService.SetAdminUserCacheInfo(ctx, accessToken, userCacheInfo); err != nil {
 			return "", nil, err
 		}
@@ -250,6 +262,27 @@ func (us *UserCommon) CacheLoginUserInfo(ctx context.Context, userID string, use
 	return accessToken, userCacheInfo, nil
 }
 
+// GetUserCacheInfoByID get the cache info of the user from the database, it authenticates the requests
+// that are not made with the login token, such as the requests with the personal api keys.
+func (us *UserCommon) GetUserCacheInfoByID(ctx context.Context, userID string) (
+	userCacheInfo *entity.UserCacheInfo, exist bool, err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil || !exist {
+		return nil, exist, err
+	}
+	roleID, err := us.userRoleService.GetUserRole(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	userCacheInfo = &entity.UserCacheInfo{
+		UserID:      userInfo.ID,
+		EmailStatus: userInfo.MailStatus,
+		UserStatus:  userInfo.Status,
+		RoleID:      roleID,
+	}
+	return userCacheInfo, true, nil
+}
+
 func (us *UserCommon) IsAvatarFileUsed(ctx context.Context, filePath string) bool {
 	used, err := us.userRepo.IsAvatarFileUsed(ctx, filePath)
 	if err != nil {
@@ -259,3 +292,25 @@ func (us *UserCommon) IsAvatarFileUsed(ctx context.Context, filePath string) boo
 	}
 	return used
 }
+
+// ProfileChangedFields get the profile fields that are different between the old and the new user info
+func ProfileChangedFields(oldUserInfo, newUserInfo *entity.User) (fields []string) {
+	profile := []struct {
+		field      string
+		old, value string
+	}{
+		{field: "display_name", old: oldUserInfo.DisplayName, value: newUserInfo.DisplayName},
+		{field: "username", old: oldUserInfo.Username, value: newUserInfo.Username},
+		{field: "e_mail", old: oldUserInfo.EMail, value: newUserInfo.EMail},
+		{field: "avatar", old: oldUserInfo.Avatar, value: newUserInfo.Avatar},
+		{field: "bio", old: oldUserInfo.Bio, value: newUserInfo.Bio},
+		{field: "website", old: oldUserInfo.Website, value: newUserInfo.Website},
+		{field: "location", old: oldUserInfo.Location, value: newUserInfo.Location},
+	}
+	for _, p := range profile {
+		if p.value != p.old {
+			fields = append(fields, p.field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,324 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_data_export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_type"
+	"github.com/apache/answer/internal/service/audit_log"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// voteActivityKeys the activity types of the votes cast by the user
+var voteActivityKeys = []string{
+	activity_type.QuestionVoteUp,
+	activity_type.QuestionVoteDown,
+	activity_type.AnswerVoteUp,
+	activity_type.AnswerVoteDown,
+	activity_type.CommentVoteUp,
+}
+
+// exportFileExpiration how long the export file can be downloaded after it is ready
+const exportFileExpiration = 48 * time.Hour
+
+// UserDataExportRepo user data export repository
+type UserDataExportRepo interface {
+	ExportUserProfile(ctx context.Context, userID string, fn func(row map[string]any) error) (err error)
+	ExportUserQuestions(ctx context.Context, userID string, fn func(row map[string]any) error) (err error)
+	ExportUserAnswers(ctx context.Context, userID string, fn func(row map[string]any) error) (err error)
+	ExportUserComments(ctx context.Context, userID string, fn func(row map[string]any) error) (err error)
+	ExportUserNotifications(ctx context.Context, userID string, fn func(row map[string]any) error) (err error)
+	ExportUserVotes(ctx context.Context, userID string, activityTypes []int,
+		fn func(row map[string]any) error) (err error)
+	ExportUserReputationEvents(ctx context.Context, userID string, fn func(row map[string]any) error) (err error)
+	SetExportRunning(ctx context.Context, userID string) (ok bool, err error)
+	ClearExportRunning(ctx context.Context, userID string) (err error)
+	SetExportFile(ctx context.Context, token string, file *schema.UserDataExportFile, expiredAt time.Time) (err error)
+	GetExportFile(ctx context.Context, token string) (file *schema.UserDataExportFile, err error)
+	RemoveExpiredExportFiles(ctx context.Context) (err error)
+}
+
+// UserDataExportService compile all the data of a user into a zip file of json files for the data export requests
+type UserDataExportService struct {
+	userDataExportRepo    UserDataExportRepo
+	userRepo              usercommon.UserRepo
+	configService         *config.ConfigService
+	emailService          *export.EmailService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	auditLogService       *audit_log.AuditLogService
+}
+
+// NewUserDataExportService new user data export service
+func NewUserDataExportService(
+	userDataExportRepo UserDataExportRepo,
+	userRepo usercommon.UserRepo,
+	configService *config.ConfigService,
+	emailService *export.EmailService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	auditLogService *audit_log.AuditLogService,
+) *UserDataExportService {
+	return &UserDataExportService{
+		userDataExportRepo:    userDataExportRepo,
+		userRepo:              userRepo,
+		configService:         configService,
+		emailService:          emailService,
+		siteInfoCommonService: siteInfoCommonService,
+		auditLogService:       auditLogService,
+	}
+}
+
+// ExportUserData the user export their own data, the download link is sent to their email
+func (ds *UserDataExportService) ExportUserData(ctx context.Context, userID string) (err error) {
+	userInfo, exist, err := ds.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return ds.startExport(ctx, userID, userInfo.EMail)
+}
+
+// AdminExportUserData the admin export the data of a user, the download link is sent to the email of the admin
+func (ds *UserDataExportService) AdminExportUserData(ctx context.Context, req *schema.UserDataExportReq) (err error) {
+	_, exist, err := ds.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	adminInfo, exist, err := ds.userRepo.GetByUserID(ctx, req.LoginUserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if err = ds.startExport(ctx, req.UserID, adminInfo.EMail); err != nil {
+		return err
+	}
+	ds.auditLogService.Record(ctx, &schema.AuditLogMsg{
+		ActorID:    req.LoginUserID,
+		Action:     constant.AuditActionUserDataExport,
+		ObjectType: constant.UserObjectType,
+		ObjectID:   req.UserID,
+	})
+	return nil
+}
+
+// startExport start to export the data of the user in background, the large accounts take a while.
+// Only one export of a user can be running at the same time.
+func (ds *UserDataExportService) startExport(ctx context.Context, userID, receiverEmail string) (err error) {
+	ok, err := ds.userDataExportRepo.SetExportRunning(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.BadRequest(reason.UserDataExportRunning)
+	}
+
+	// The email is rendered with the language of the request before the export starts
+	downloadToken := token.GenerateToken()
+	siteInfo, err := ds.siteInfoCommonService.GetSiteGeneral(ctx)
+	if err != nil {
+		_ = ds.userDataExportRepo.ClearExportRunning(ctx, userID)
+		return err
+	}
+	downloadURL := fmt.Sprintf("%s/answer/api/v1/user/data-export/download?token=%s", siteInfo.SiteUrl, downloadToken)
+	title, body, err := ds.emailService.UserDataExportTemplate(ctx, downloadURL)
+	if err != nil {
+		_ = ds.userDataExportRepo.ClearExportRunning(ctx, userID)
+		return err
+	}
+
+	go func() {
+		ctx := context.Background()
+		defer func() {
+			if err := ds.userDataExportRepo.ClearExportRunning(ctx, userID); err != nil {
+				log.Error(err)
+			}
+		}()
+		if err := ds.userDataExportRepo.RemoveExpiredExportFiles(ctx); err != nil {
+			log.Error(err)
+		}
+		file, err := ds.exportUserData(ctx, userID)
+		if err != nil {
+			log.Errorf("export data of user %s failed: %v", userID, err)
+			return
+		}
+		err = ds.userDataExportRepo.SetExportFile(ctx, downloadToken, file, time.Now().Add(exportFileExpiration))
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		ds.emailService.Send(ctx, receiverEmail, title, body)
+	}()
+	return nil
+}
+
+// GetExportFile get the export file to be downloaded by the token in the email
+func (ds *UserDataExportService) GetExportFile(ctx context.Context, req *schema.UserDataExportDownloadReq) (
+	file *schema.UserDataExportFile, err error) {
+	file, err = ds.userDataExportRepo.GetExportFile(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, errors.BadRequest(reason.UserDataExportExpired)
+	}
+	return file, nil
+}
+
+// exportUserData write the data of the user into a zip file, each kind of the data is a json file
+func (ds *UserDataExportService) exportUserData(ctx context.Context, userID string) (
+	file *schema.UserDataExportFile, err error) {
+	userInfo, exist, err := ds.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	voteActivityTypes := make([]int, 0, len(voteActivityKeys))
+	for _, key := range voteActivityKeys {
+		id, err := ds.configService.GetIDByKey(ctx, key)
+		if err != nil {
+			continue
+		}
+		voteActivityTypes = append(voteActivityTypes, id)
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	exports := []struct {
+		name string
+		fn   func(fn func(row map[string]any) error) error
+	}{
+		{name: "profile.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserProfile(ctx, userID, fn)
+		}},
+		{name: "questions.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserQuestions(ctx, userID, fn)
+		}},
+		{name: "answers.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserAnswers(ctx, userID, fn)
+		}},
+		{name: "comments.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserComments(ctx, userID, fn)
+		}},
+		{name: "votes.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserVotes(ctx, userID, voteActivityTypes, ds.withActivityTypeKey(ctx, fn))
+		}},
+		{name: "reputation.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserReputationEvents(ctx, userID, ds.withActivityTypeKey(ctx, fn))
+		}},
+		{name: "notifications.json", fn: func(fn func(row map[string]any) error) error {
+			return ds.userDataExportRepo.ExportUserNotifications(ctx, userID, fn)
+		}},
+	}
+	for _, e := range exports {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			return nil, err
+		}
+		if err = writeJSONArray(w, e.fn); err != nil {
+			return nil, fmt.Errorf("export %s failed: %w", e.name, err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+	return &schema.UserDataExportFile{
+		UserID:   userID,
+		FileName: fmt.Sprintf("%s_data_%s.zip", userInfo.Username, time.Now().Format("2006-01-02")),
+		Content:  buf.Bytes(),
+	}, nil
+}
+
+// withActivityTypeKey replace the id of the activity type in the row with the key of it, such as answer.vote_up
+func (ds *UserDataExportService) withActivityTypeKey(ctx context.Context,
+	fn func(row map[string]any) error) func(row map[string]any) error {
+	keys := make(map[int]string)
+	return func(row map[string]any) error {
+		activityType, ok := row["activity_type"].(int)
+		if !ok {
+			return fn(row)
+		}
+		key, ok := keys[activityType]
+		if !ok {
+			cfg, err := ds.configService.GetConfigByID(ctx, activityType)
+			if err != nil {
+				return err
+			}
+			key = cfg.Key
+			keys[activityType] = key
+		}
+		row["activity_type"] = key
+		return fn(row)
+	}
+}
+
+// writeJSONArray write the rows into w as a json array, the rows are streamed one by one
+func writeJSONArray(w io.Writer, export func(fn func(row map[string]any) error) error) (err error) {
+	buf := bufio.NewWriter(w)
+	if _, err = buf.WriteString("["); err != nil {
+		return err
+	}
+	count := 0
+	err = export(func(row map[string]any) error {
+		if count > 0 {
+			if _, err := buf.WriteString(","); err != nil {
+				return err
+			}
+		}
+		count++
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		_, err = buf.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = buf.WriteString("]\n"); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_external_login
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/ldap"
+	"github.com/segmentfault/pacman/log"
+)
+
+// LDAPLogin authenticates the login name and password against the configured LDAP directory.
+// The first successful login creates a local user, or binds the local user with the same email.
+// It returns nil user info when LDAP login is disabled or the directory rejects the credentials.
+func (us *UserExternalLoginService) LDAPLogin(ctx context.Context, loginName, password string) (
+	userInfo *entity.User, err error) {
+	ldapConfig, err := us.siteInfoCommonService.GetSiteLDAP(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ldapConfig.Enabled {
+		return nil, nil
+	}
+
+	entry, err := ldap.Authenticate(&ldap.AuthConfig{
+		ServerURL:          ldapConfig.ServerURL,
+		StartTLS:           ldapConfig.StartTLS,
+		InsecureSkipVerify: ldapConfig.InsecureSkipVerify,
+		BindDN:             ldapConfig.BindDN,
+		BindPassword:       ldapConfig.BindPassword,
+		BaseDN:             ldapConfig.BaseDN,
+		UserFilter:         ldapConfig.GetUserFilter(),
+		Attributes: []string{
+			ldapConfig.GetUsernameAttribute(),
+			ldapConfig.GetEmailAttribute(),
+			ldapConfig.GetDisplayNameAttribute(),
+		},
+		Timeout: constant.DefaultLDAPTimeout,
+	}, loginName, password)
+	if err != nil {
+		if errors.Is(err, ldap.ErrInvalidCredentials) || errors.Is(err, ldap.ErrUserNotFound) {
+			log.Debugf("ldap login %s failed: %v", loginName, err)
+		} else {
+			log.Errorf("ldap login %s failed: %v", loginName, err)
+		}
+		return nil, nil
+	}
+
+	metaInfo, _ := json.Marshal(entry.Attributes)
+	externalUserInfo := &schema.ExternalLoginUserInfoCache{
+		Provider:    constant.LDAPProvider,
+		ExternalID:  entry.DN,
+		DisplayName: entry.GetAttributeValue(ldapConfig.GetDisplayNameAttribute()),
+		Username:    entry.GetAttributeValue(ldapConfig.GetUsernameAttribute()),
+		Email:       entry.GetAttributeValue(ldapConfig.GetEmailAttribute()),
+		MetaInfo:    string(metaInfo),
+	}
+	userInfo, err = us.getOrCreateTrustedUser(ctx, externalUserInfo)
+	if err != nil || userInfo == nil {
+		return nil, err
+	}
+
+	return userInfo, nil
+}
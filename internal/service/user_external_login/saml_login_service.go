@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_external_login
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/saml"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+func (us *UserExternalLoginService) samlServiceProvider(ctx context.Context) (
+	sp *saml.ServiceProvider, samlConfig *schema.SiteSAMLResp, err error) {
+	samlConfig, err = us.siteInfoCommonService.GetSiteSAML(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !samlConfig.Enabled {
+		return nil, nil, errors.BadRequest(reason.UserAccessDenied)
+	}
+	certificates, err := saml.ParseCertificates(samlConfig.IdPCertificate)
+	if err != nil {
+		return nil, nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	sp = &saml.ServiceProvider{
+		EntityID:          samlConfig.SPEntityID,
+		AcsURL:            samlConfig.AcsURL,
+		IdPEntityID:       samlConfig.IdPEntityID,
+		IdPSSOURL:         samlConfig.IdPSSOURL,
+		IdPCertificates:   certificates,
+		AllowIdPInitiated: samlConfig.AllowIdPInitiated,
+	}
+	return sp, samlConfig, nil
+}
+
+// SAMLMetadata returns the service provider metadata
+func (us *UserExternalLoginService) SAMLMetadata(ctx context.Context) (metadata []byte, err error) {
+	samlConfig, err := us.siteInfoCommonService.GetSiteSAML(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sp := &saml.ServiceProvider{EntityID: samlConfig.SPEntityID, AcsURL: samlConfig.AcsURL}
+	return sp.Metadata()
+}
+
+// SAMLLoginURL returns the identity provider URL that starts the SAML login.
+// If state is not empty, it must be a state generated for binding the SAML login to a user.
+func (us *UserExternalLoginService) SAMLLoginURL(ctx context.Context, state string) (redirectURL string, err error) {
+	sp, _, err := us.samlServiceProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+	stateInfo := &schema.ExternalLoginOAuthState{
+		Provider: constant.SAMLProvider,
+		Intent:   schema.ExternalLoginOAuthStateLoginIntent,
+	}
+	duration := constant.ConnectorOAuthStateCacheTime
+	if len(state) > 0 {
+		stateInfo, err = us.GetOAuthState(ctx, state)
+		if err != nil {
+			return "", err
+		}
+		if stateInfo == nil || stateInfo.Provider != constant.SAMLProvider {
+			return "", errors.BadRequest(reason.UserAccessDenied)
+		}
+		if stateInfo.Intent == schema.ExternalLoginOAuthStateBindIntent {
+			duration = constant.ConnectorOAuthBindStateCacheTime
+		}
+	} else {
+		state = token.GenerateToken()
+	}
+
+	redirectURL, stateInfo.RequestID, err = sp.AuthnRequestURL(state)
+	if err != nil {
+		return "", errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = us.userExternalLoginRepo.SetCacheOAuthState(ctx, state, stateInfo, duration); err != nil {
+		return "", err
+	}
+	return redirectURL, nil
+}
+
+// SAMLConsumeResponse verifies the response posted to the assertion consumer service,
+// and returns the state it belongs to, which is nil for identity provider initiated logins.
+func (us *UserExternalLoginService) SAMLConsumeResponse(ctx context.Context, samlResponse, relayState string) (
+	stateInfo *schema.ExternalLoginOAuthState, externalUserInfo *schema.ExternalLoginUserInfoCache, err error) {
+	sp, samlConfig, err := us.samlServiceProvider(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var requestIDs []string
+	if len(relayState) > 0 {
+		stateInfo, err = us.ConsumeOAuthState(ctx, relayState)
+		if err != nil {
+			return nil, nil, err
+		}
+		if stateInfo != nil && stateInfo.Provider == constant.SAMLProvider {
+			requestIDs = append(requestIDs, stateInfo.RequestID)
+		} else {
+			stateInfo = nil
+		}
+	}
+
+	assertion, err := sp.ParseResponse(samlResponse, requestIDs...)
+	if err != nil {
+		return nil, nil, errors.BadRequest(reason.UserAccessDenied).WithError(err)
+	}
+	first, err := us.userExternalLoginRepo.ConsumeCacheSAMLAssertionID(ctx, assertion.ID,
+		time.Until(assertion.NotOnOrAfter)+time.Hour)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !first {
+		return nil, nil, errors.BadRequest(reason.UserAccessDenied).WithMsg("saml assertion has been used")
+	}
+
+	email := assertion.GetAttributeValue(samlConfig.GetEmailAttribute())
+	if len(email) == 0 && assertion.NameIDFormat == saml.NameIDFormatEmailAddress {
+		email = assertion.NameID
+	}
+	metaInfo, _ := json.Marshal(assertion.Attributes)
+	externalUserInfo = &schema.ExternalLoginUserInfoCache{
+		Provider:    constant.SAMLProvider,
+		ExternalID:  assertion.NameID,
+		DisplayName: assertion.GetAttributeValue(samlConfig.GetDisplayNameAttribute()),
+		Username:    assertion.GetAttributeValue(samlConfig.GetUsernameAttribute()),
+		Email:       email,
+		MetaInfo:    string(metaInfo),
+	}
+	return stateInfo, externalUserInfo, nil
+}
+
+// SAMLLogin logs in the user of the verified SAML assertion, creating or binding the local user on first login.
+func (us *UserExternalLoginService) SAMLLogin(ctx context.Context,
+	externalUserInfo *schema.ExternalLoginUserInfoCache) (resp *schema.UserExternalLoginResp, err error) {
+	userInfo, err := us.getOrCreateTrustedUser(ctx, externalUserInfo)
+	if err != nil {
+		return nil, err
+	}
+	if userInfo == nil {
+		return &schema.UserExternalLoginResp{
+			ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+			ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+		}, nil
+	}
+	if err := us.userRepo.UpdateLastLoginDate(ctx, userInfo.ID); err != nil {
+		log.Errorf("update user last login date failed: %v", err)
+	}
+	accessToken, _, err := us.userCommonService.CacheLoginUserInfo(
+		ctx, userInfo.ID, userInfo.MailStatus, userInfo.Status, externalUserInfo.ExternalID)
+	return &schema.UserExternalLoginResp{AccessToken: accessToken}, err
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
+	"github.com/apache/answer/internal/service/eventqueue"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/checker"
@@ -47,6 +48,7 @@ type UserCenterLoginService struct {
 	userCommonService     *usercommon.UserCommon
 	userActivity          activity.UserActiveActivityRepo
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	eventQueueService     eventqueue.Service
 }
 
 // NewUserCenterLoginService new user external login service
@@ -56,6 +58,7 @@ func NewUserCenterLoginService(
 	userExternalLoginRepo UserExternalLoginRepo,
 	userActivity activity.UserActiveActivityRepo,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	eventQueueService eventqueue.Service,
 ) *UserCenterLoginService {
 	return &UserCenterLoginService{
 		userRepo:              userRepo,
@@ -63,6 +66,7 @@ func NewUserCenterLoginService(
 		userExternalLoginRepo: userExternalLoginRepo,
 		userActivity:          userActivity,
 		siteInfoCommonService: siteInfoCommonService,
+		eventQueueService:     eventQueueService,
 	}
 }
 
@@ -89,6 +93,12 @@ func (us *UserCenterLoginService) ExternalLogin(
 				ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.EmailIllegalDomainError),
 			}, nil
 		}
+		if isRegistrationBlocked(ctx, us.siteInfoCommonService, basicUserInfo.Email) {
+			return &schema.UserExternalLoginResp{
+				ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+				ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.RegistrationBlocked),
+			}, nil
+		}
 	}
 
 	oldExternalLoginUserInfo, exist, err := us.userExternalLoginRepo.GetByExternalID(ctx,
@@ -168,6 +178,7 @@ func (us *UserCenterLoginService) registerNewUser(ctx context.Context, provider
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, userInfo.ID).TID(userInfo.ID))
 
 	metaInfo, _ := json.Marshal(basicUserInfo)
 	newExternalUserInfo := &entity.UserExternalLogin{
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/activity"
+	"github.com/apache/answer/internal/service/eventqueue"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
@@ -40,6 +41,7 @@ import (
 	"github.com/apache/answer/pkg/random"
 	"github.com/apache/answer/pkg/token"
 	"github.com/apache/answer/plugin"
+	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
@@ -58,6 +60,7 @@ type UserExternalLoginRepo interface {
 		duration time.Duration) (err error)
 	GetCacheOAuthState(ctx context.Context, state string) (info *schema.ExternalLoginOAuthState, err error)
 	DeleteCacheOAuthState(ctx context.Context, state string) (err error)
+	ConsumeCacheSAMLAssertionID(ctx context.Context, assertionID string, duration time.Duration) (first bool, err error)
 }
 
 // UserExternalLoginService user external login service
@@ -69,6 +72,7 @@ type UserExternalLoginService struct {
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userActivity                  activity.UserActiveActivityRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	eventQueueService             eventqueue.Service
 }
 
 // NewUserExternalLoginService new user external login service
@@ -80,6 +84,7 @@ func NewUserExternalLoginService(
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userActivity activity.UserActiveActivityRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	eventQueueService eventqueue.Service,
 ) *UserExternalLoginService {
 	return &UserExternalLoginService{
 		userRepo:                      userRepo,
@@ -89,6 +94,7 @@ func NewUserExternalLoginService(
 		siteInfoCommonService:         siteInfoCommonService,
 		userActivity:                  userActivity,
 		userNotificationConfigService: userNotificationConfigService,
+		eventQueueService:             eventQueueService,
 	}
 }
 
@@ -185,13 +191,21 @@ func (us *UserExternalLoginService) ExternalLogin(
 			ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.EmailIllegalDomainError),
 		}, nil
 	}
+	if isRegistrationBlocked(ctx, us.siteInfoCommonService, externalUserInfo.Email) {
+		return &schema.UserExternalLoginResp{
+			ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+			ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.RegistrationBlocked),
+		}, nil
+	}
 
+	// The email belongs to an existing user, who has to log in and connect this login from the account settings,
+	// because the email reported by the provider can not prove the ownership of the existing account.
 	if _, exist, err := us.userRepo.GetByEmail(ctx, externalUserInfo.Email); err != nil {
 		return nil, err
 	} else if exist {
 		return &schema.UserExternalLoginResp{
 			ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
-			ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+			ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.UserExternalLoginEmailExist),
 		}, nil
 	}
 	// if user is not a member, register a new user
@@ -239,7 +253,7 @@ func (us *UserExternalLoginService) BindExternalLoginToUser(ctx context.Context,
 		return err
 	}
 	if exist && oldExternalLoginUserInfo.UserID != userID {
-		return errors.BadRequest(reason.UserAccessDenied)
+		return errors.BadRequest(reason.UserExternalLoginBoundToOtherUser)
 	}
 	currentExternalLoginUserInfo, exist, err := us.userExternalLoginRepo.GetByUserID(ctx,
 		externalUserInfo.Provider, userID)
@@ -247,7 +261,7 @@ func (us *UserExternalLoginService) BindExternalLoginToUser(ctx context.Context,
 		return err
 	}
 	if exist && currentExternalLoginUserInfo.ExternalID != externalUserInfo.ExternalID {
-		return errors.BadRequest(reason.UserAccessDenied)
+		return errors.BadRequest(reason.UserExternalLoginProviderBound)
 	}
 	return us.bindOldUser(ctx, externalUserInfo, oldUserInfo)
 }
@@ -282,6 +296,7 @@ func (us *UserExternalLoginService) registerNewUser(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, schema.NewEvent(constant.EventUserCreate, userInfo.ID).TID(userInfo.ID))
 	return userInfo, nil
 }
 
@@ -343,6 +358,75 @@ func (us *UserExternalLoginService) activeUser(ctx context.Context, oldUserInfo
 	return entity.EmailStatusAvailable, nil
 }
 
+// getOrCreateTrustedUser returns the user bound to the external login of an identity source configured by the
+// site admin, such as LDAP or SAML. Unlike ExternalLogin, the email is trusted, so an existing user with
+// the same email is bound directly and the email is activated.
+func (us *UserExternalLoginService) getOrCreateTrustedUser(ctx context.Context,
+	externalUserInfo *schema.ExternalLoginUserInfoCache) (userInfo *entity.User, err error) {
+	oldExternalLoginUserInfo, exist, err := us.userExternalLoginRepo.GetByExternalID(ctx,
+		externalUserInfo.Provider, externalUserInfo.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		userInfo, exist, err = us.userRepo.GetByUserID(ctx, oldExternalLoginUserInfo.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if exist && userInfo.Status != entity.UserStatusDeleted {
+			return us.bindTrustedUser(ctx, externalUserInfo, userInfo)
+		}
+	}
+
+	if len(externalUserInfo.Email) == 0 {
+		log.Warnf("%s user %s has no email, can not login", externalUserInfo.Provider, externalUserInfo.ExternalID)
+		return nil, nil
+	}
+	userInfo, exist, err = us.userRepo.GetByEmail(ctx, externalUserInfo.Email)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		if userInfo.Status == entity.UserStatusDeleted {
+			return nil, nil
+		}
+		return us.bindTrustedUser(ctx, externalUserInfo, userInfo)
+	}
+
+	siteInfo, err := us.siteInfoCommonService.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !checker.EmailInAllowEmailDomain(externalUserInfo.Email, siteInfo.AllowEmailDomains) {
+		log.Debugf("email domain not allowed: %s", externalUserInfo.Email)
+		return nil, nil
+	}
+	if isRegistrationBlocked(ctx, us.siteInfoCommonService, externalUserInfo.Email) {
+		return nil, nil
+	}
+	userInfo, err = us.registerNewUser(ctx, externalUserInfo)
+	if err != nil {
+		return nil, err
+	}
+	if err := us.userNotificationConfigService.SetDefaultUserNotificationConfig(ctx, []string{userInfo.ID}); err != nil {
+		log.Errorf("set default user notification config failed, err: %v", err)
+	}
+	return us.bindTrustedUser(ctx, externalUserInfo, userInfo)
+}
+
+func (us *UserExternalLoginService) bindTrustedUser(ctx context.Context,
+	externalUserInfo *schema.ExternalLoginUserInfoCache, userInfo *entity.User) (*entity.User, error) {
+	if err := us.bindOldUser(ctx, externalUserInfo, userInfo); err != nil {
+		return nil, err
+	}
+	newMailStatus, err := us.activeUser(ctx, userInfo, externalUserInfo)
+	if err != nil {
+		log.Error(err)
+	}
+	userInfo.MailStatus = newMailStatus
+	return userInfo, nil
+}
+
 // ExternalLoginBindingUserSendEmail Send an email for third-party account login for binding user
 func (us *UserExternalLoginService) ExternalLoginBindingUserSendEmail(
 	ctx context.Context, req *schema.ExternalLoginBindingUserSendEmailReq) (
@@ -426,7 +510,7 @@ func (us *UserExternalLoginService) GetExternalLoginUserInfoList(
 // ExternalLoginUnbinding external login unbinding
 func (us *UserExternalLoginService) ExternalLoginUnbinding(
 	ctx context.Context, req *schema.ExternalLoginUnbindingReq) (resp any, err error) {
-	// If user has only one external login and never set password, he can't unbind it.
+	// At least one login method must remain. The password only counts if password login is allowed.
 	userInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
 	if err != nil {
 		return nil, err
@@ -434,7 +518,11 @@ func (us *UserExternalLoginService) ExternalLoginUnbinding(
 	if !exist {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
-	if len(userInfo.Pass) == 0 {
+	siteLogin, err := us.siteInfoCommonService.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(userInfo.Pass) == 0 || !siteLogin.AllowPasswordLogin {
 		loginList, err := us.userExternalLoginRepo.GetUserExternalLoginList(ctx, req.UserID)
 		if err != nil {
 			return nil, err
@@ -476,3 +564,22 @@ func (us *UserExternalLoginService) CheckUserStatusInUserCenter(ctx context.Cont
 	}
 	return true, thisUcUserInfo.ExternalID, nil
 }
+
+// isRegistrationBlocked whether the registration from the client ip or with the email is in the blocklist
+func isRegistrationBlocked(ctx context.Context, siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	email string) bool {
+	blocklist, err := siteInfoCommonService.GetSiteRegistrationBlocklist(ctx)
+	if err != nil {
+		log.Errorf("get registration blocklist failed, err: %v", err)
+		return false
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok && blocklist.IsIPBlocked(ginCtx.ClientIP()) {
+		log.Debugf("registration ip is blocked: %s", ginCtx.ClientIP())
+		return true
+	}
+	if blocklist.IsEmailBlocked(email) {
+		log.Debugf("registration email is blocked: %s", email)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_mute
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserMuteRepo user mute repository
+type UserMuteRepo interface {
+	AddUserMute(ctx context.Context, userID, mutedUserID string) (err error)
+	RemoveUserMute(ctx context.Context, userID, mutedUserID string) (err error)
+	CountUserMute(ctx context.Context, userID string) (count int64, err error)
+	GetUserMutePage(ctx context.Context, userID string, page, pageSize int) (
+		mutes []*entity.UserMute, total int64, err error)
+	GetMutedUserIDs(ctx context.Context, userID string) (mutedUserIDs []string, err error)
+}
+
+// UserMuteService the personal ignore list of the users, it only changes what the muting user sees
+type UserMuteService struct {
+	userMuteRepo UserMuteRepo
+	userCommon   *usercommon.UserCommon
+}
+
+// NewUserMuteService new user mute service
+func NewUserMuteService(userMuteRepo UserMuteRepo, userCommon *usercommon.UserCommon) *UserMuteService {
+	return &UserMuteService{
+		userMuteRepo: userMuteRepo,
+		userCommon:   userCommon,
+	}
+}
+
+// MuteUser add the user to the ignore list of the login user, muting the muted user again changes nothing
+func (us *UserMuteService) MuteUser(ctx context.Context, req *schema.MuteUserReq) (err error) {
+	mutedUser, exist, err := us.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if mutedUser.ID == req.UserID {
+		return errors.BadRequest(reason.UserMuteSelf)
+	}
+	count, err := us.userMuteRepo.CountUserMute(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if count >= constant.UserMuteMaxAmount {
+		return errors.BadRequest(reason.UserMuteLimitExceeded)
+	}
+	return us.userMuteRepo.AddUserMute(ctx, req.UserID, mutedUser.ID)
+}
+
+// UnmuteUser remove the user from the ignore list of the login user
+func (us *UserMuteService) UnmuteUser(ctx context.Context, req *schema.MuteUserReq) (err error) {
+	mutedUser, exist, err := us.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return us.userMuteRepo.RemoveUserMute(ctx, req.UserID, mutedUser.ID)
+}
+
+// GetMutedUserPage get the users muted by the login user, the latest muted ones come first
+func (us *UserMuteService) GetMutedUserPage(ctx context.Context, req *schema.GetMutedUserPageReq) (
+	pageModel *pager.PageModel, err error) {
+	mutes, total, err := us.userMuteRepo.GetUserMutePage(ctx, req.UserID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(mutes))
+	for _, mute := range mutes {
+		userIDs = append(userIDs, mute.MutedUserID)
+	}
+	userInfoMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.MutedUserResp, 0, len(mutes))
+	for _, mute := range mutes {
+		userInfo, ok := userInfoMapping[mute.MutedUserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.MutedUserResp{UserInfo: userInfo, MutedAt: mute.CreatedAt.Unix()})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// GetMutedUserMapping get the ids of the users muted by the user, it is empty for the guests
+func (us *UserMuteService) GetMutedUserMapping(ctx context.Context, userID string) (
+	mutedUsers map[string]bool, err error) {
+	mutedUsers = make(map[string]bool)
+	if len(userID) == 0 {
+		return mutedUsers, nil
+	}
+	mutedUserIDs, err := us.userMuteRepo.GetMutedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range mutedUserIDs {
+		mutedUsers[id] = true
+	}
+	return mutedUsers, nil
+}
@@ -89,6 +89,44 @@ func (us *UserNotificationConfigService) UpdateUserNotificationConfig(
 	return nil
 }
 
+// GetNotificationPreferences get the notification preferences of all the events of the user
+func (us *UserNotificationConfigService) GetNotificationPreferences(ctx context.Context, userID string) (
+	resp *schema.GetNotificationPreferencesResp, err error) {
+	notificationConfigs, err := us.userNotificationConfigRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetNotificationPreferencesResp{
+		Preferences: make([]*schema.NotificationPreference, 0, len(constant.NotificationPreferenceSources)),
+	}
+	for _, source := range constant.NotificationPreferenceSources {
+		resp.Preferences = append(resp.Preferences, schema.NewNotificationPreference(source, notificationConfigs))
+	}
+	resp.EmailFrequency = schema.NewNotificationEmailFrequency(notificationConfigs)
+	return resp, nil
+}
+
+// UpdateNotificationPreferences update the notification preferences of the events in the request
+func (us *UserNotificationConfigService) UpdateNotificationPreferences(
+	ctx context.Context, req *schema.UpdateNotificationPreferencesReq) (err error) {
+	for _, preference := range req.Preferences {
+		if preference == nil {
+			continue
+		}
+		if err = us.userNotificationConfigRepo.Save(ctx, preference.ToEntity(req.UserID)); err != nil {
+			return err
+		}
+	}
+	if len(req.EmailFrequency) > 0 {
+		err = us.userNotificationConfigRepo.Save(ctx, us.convertToEntity(ctx, req.UserID, constant.NotificationDigestSource,
+			schema.NotificationChannelConfig{Key: constant.EmailChannel, Enable: true, Frequency: req.EmailFrequency}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetDefaultUserNotificationConfig set default user notification config for user register
 func (us *UserNotificationConfigService) SetDefaultUserNotificationConfig(ctx context.Context, userIDs []string) (
 	err error) {
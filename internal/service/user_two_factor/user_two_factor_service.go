@@ -0,0 +1,325 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_two_factor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/apache/answer/pkg/totp"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	// recoveryCodeCount number of recovery codes generated at enrollment
+	recoveryCodeCount = 10
+	// maxChallengeAttempts number of wrong codes allowed before the login has to be restarted
+	maxChallengeAttempts = 5
+	// codeSkew number of time steps of clock skew allowed between the server and the authenticator
+	codeSkew = 1
+)
+
+// UserTwoFactorRepo user two-factor authentication repository
+type UserTwoFactorRepo interface {
+	GetByUserID(ctx context.Context, userID string) (info *entity.UserTwoFactor, exist bool, err error)
+	SaveUserTwoFactor(ctx context.Context, info *entity.UserTwoFactor) (err error)
+	EnableUserTwoFactor(ctx context.Context, id, step int64) (err error)
+	ConsumeStep(ctx context.Context, id, step int64) (ok bool, err error)
+	ConsumeRecoveryCodes(ctx context.Context, id int64, oldCodes, newCodes string) (ok bool, err error)
+	DeleteUserTwoFactor(ctx context.Context, userID string) (err error)
+	SetCacheLoginChallenge(ctx context.Context, token string,
+		info *schema.UserTwoFactorLoginChallenge, duration time.Duration) (err error)
+	GetCacheLoginChallenge(ctx context.Context, token string) (info *schema.UserTwoFactorLoginChallenge, err error)
+	IncreaseLoginChallengeAttempts(ctx context.Context, token string) (attempts int64, err error)
+	DeleteCacheLoginChallenge(ctx context.Context, token string) (err error)
+}
+
+// UserTwoFactorService user two-factor authentication service
+type UserTwoFactorService struct {
+	userTwoFactorRepo     UserTwoFactorRepo
+	userRepo              usercommon.UserRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+}
+
+// NewUserTwoFactorService new user two-factor authentication service
+func NewUserTwoFactorService(
+	userTwoFactorRepo UserTwoFactorRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+) *UserTwoFactorService {
+	return &UserTwoFactorService{
+		userTwoFactorRepo:     userTwoFactorRepo,
+		userRepo:              userRepo,
+		siteInfoCommonService: siteInfoCommonService,
+	}
+}
+
+// GetStatus get the two-factor authentication status of the user
+func (us *UserTwoFactorService) GetStatus(ctx context.Context, userID string) (
+	resp *schema.UserTwoFactorStatusResp, err error) {
+	info, exist, err := us.userTwoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.UserTwoFactorStatusResp{}
+	if exist && info.Enabled {
+		resp.Enabled = true
+		resp.RecoveryCodesRemaining = len(decodeRecoveryCodes(info.RecoveryCodes))
+	}
+	return resp, nil
+}
+
+// IsEnabled check whether the user has enabled two-factor authentication
+func (us *UserTwoFactorService) IsEnabled(ctx context.Context, userID string) (enabled bool, err error) {
+	info, exist, err := us.userTwoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return exist && info.Enabled, nil
+}
+
+// Enroll generate a new secret and recovery codes for the user.
+// The two-factor authentication is not enabled until the first code is verified by Activate.
+func (us *UserTwoFactorService) Enroll(ctx context.Context, userID string) (
+	resp *schema.UserTwoFactorEnrollResp, err error) {
+	enabled, err := us.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, errors.BadRequest(reason.UserTwoFactorAlreadyEnabled)
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	siteGeneral, err := us.siteInfoCommonService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	recoveryCodes, hashedCodes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = us.userTwoFactorRepo.SaveUserTwoFactor(ctx, &entity.UserTwoFactor{
+		UserID:        userID,
+		Secret:        secret,
+		RecoveryCodes: encodeRecoveryCodes(hashedCodes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account := userInfo.EMail
+	if len(account) == 0 {
+		account = userInfo.Username
+	}
+	return &schema.UserTwoFactorEnrollResp{
+		Secret:        secret,
+		OTPAuthURL:    totp.URL(siteGeneral.Name, account, secret),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// Activate enable the two-factor authentication after the first code generated by the authenticator is verified
+func (us *UserTwoFactorService) Activate(ctx context.Context, req *schema.UserTwoFactorActivateReq) (err error) {
+	info, exist, err := us.userTwoFactorRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserTwoFactorNotEnrolled)
+	}
+	if info.Enabled {
+		return errors.BadRequest(reason.UserTwoFactorAlreadyEnabled)
+	}
+	step, ok := totp.Validate(info.Secret, req.Code, time.Now(), codeSkew)
+	if !ok {
+		return errors.BadRequest(reason.UserTwoFactorCodeInvalid)
+	}
+	return us.userTwoFactorRepo.EnableUserTwoFactor(ctx, info.ID, step)
+}
+
+// Disable turn off the two-factor authentication, a valid code or recovery code is required
+func (us *UserTwoFactorService) Disable(ctx context.Context, req *schema.UserTwoFactorDisableReq) (err error) {
+	info, exist, err := us.userTwoFactorRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist || !info.Enabled {
+		return errors.BadRequest(reason.UserTwoFactorNotEnrolled)
+	}
+	ok, err := us.verifyCode(ctx, info, req.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.BadRequest(reason.UserTwoFactorCodeInvalid)
+	}
+	return us.userTwoFactorRepo.DeleteUserTwoFactor(ctx, req.UserID)
+}
+
+// ResetUserTwoFactor admin turn off the two-factor authentication of a locked-out user
+func (us *UserTwoFactorService) ResetUserTwoFactor(ctx context.Context, req *schema.ResetUserTwoFactorReq) (err error) {
+	_, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return us.userTwoFactorRepo.DeleteUserTwoFactor(ctx, req.UserID)
+}
+
+// CreateLoginChallenge create a pending login for the user who passed the password check
+func (us *UserTwoFactorService) CreateLoginChallenge(ctx context.Context, userID string, rememberMe bool) (
+	challengeToken string, err error) {
+	challengeToken = token.GenerateToken()
+	err = us.userTwoFactorRepo.SetCacheLoginChallenge(ctx, challengeToken, &schema.UserTwoFactorLoginChallenge{
+		UserID:     userID,
+		RememberMe: rememberMe,
+		ExpiresAt:  time.Now().Add(constant.UserTwoFactorChallengeCacheTime).Unix(),
+	}, constant.UserTwoFactorChallengeCacheTime)
+	if err != nil {
+		return "", err
+	}
+	return challengeToken, nil
+}
+
+// VerifyLoginChallenge verify the code of the pending login, returns the challenge if the login can be finished.
+// The challenge is removed after it is used or when too many wrong codes are tried. The attempt is counted
+// before the code is verified, so the concurrent attempts can not try more codes than allowed.
+func (us *UserTwoFactorService) VerifyLoginChallenge(ctx context.Context, req *schema.UserTwoFactorLoginReq) (
+	challenge *schema.UserTwoFactorLoginChallenge, err error) {
+	challenge, err = us.userTwoFactorRepo.GetCacheLoginChallenge(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || time.Now().Unix() >= challenge.ExpiresAt {
+		return nil, errors.BadRequest(reason.UserTwoFactorChallengeExpired)
+	}
+	info, exist, err := us.userTwoFactorRepo.GetByUserID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || !info.Enabled {
+		// two-factor authentication has been reset after the password check, the login should be restarted
+		_ = us.userTwoFactorRepo.DeleteCacheLoginChallenge(ctx, req.Token)
+		return nil, errors.BadRequest(reason.UserTwoFactorChallengeExpired)
+	}
+
+	attempts, err := us.userTwoFactorRepo.IncreaseLoginChallengeAttempts(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > maxChallengeAttempts {
+		_ = us.userTwoFactorRepo.DeleteCacheLoginChallenge(ctx, req.Token)
+		return nil, errors.BadRequest(reason.UserTwoFactorChallengeExpired)
+	}
+	ok, err := us.verifyCode(ctx, info, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if attempts >= maxChallengeAttempts {
+			_ = us.userTwoFactorRepo.DeleteCacheLoginChallenge(ctx, req.Token)
+			return nil, errors.BadRequest(reason.UserTwoFactorChallengeExpired)
+		}
+		return nil, errors.BadRequest(reason.UserTwoFactorCodeInvalid)
+	}
+	if err := us.userTwoFactorRepo.DeleteCacheLoginChallenge(ctx, req.Token); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// verifyCode verify a code of the authenticator or a recovery code, each of them can only be used once
+func (us *UserTwoFactorService) verifyCode(ctx context.Context, info *entity.UserTwoFactor, code string) (
+	ok bool, err error) {
+	if step, valid := totp.Validate(info.Secret, code, time.Now(), codeSkew); valid {
+		return us.userTwoFactorRepo.ConsumeStep(ctx, info.ID, step)
+	}
+
+	hashedCodes := decodeRecoveryCodes(info.RecoveryCodes)
+	hashed := hashRecoveryCode(code)
+	for i, c := range hashedCodes {
+		if c != hashed {
+			continue
+		}
+		remaining := append(append([]string{}, hashedCodes[:i]...), hashedCodes[i+1:]...)
+		return us.userTwoFactorRepo.ConsumeRecoveryCodes(ctx, info.ID, info.RecoveryCodes, encodeRecoveryCodes(remaining))
+	}
+	return false, nil
+}
+
+// generateRecoveryCodes returns the recovery codes shown to the user and the hashes to be saved
+func generateRecoveryCodes() (codes, hashedCodes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err = rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := hex.EncodeToString(b)
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashedCodes = append(hashedCodes, hashRecoveryCode(code))
+	}
+	return codes, hashedCodes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func encodeRecoveryCodes(hashedCodes []string) string {
+	if hashedCodes == nil {
+		hashedCodes = []string{}
+	}
+	data, _ := json.Marshal(hashedCodes)
+	return string(data)
+}
+
+func decodeRecoveryCodes(content string) (hashedCodes []string) {
+	_ = json.Unmarshal([]byte(content), &hashedCodes)
+	return hashedCodes
+}
@@ -0,0 +1,393 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/eventqueue"
+	"github.com/apache/answer/internal/service/jobqueue"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/google/uuid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// WebhookRepo webhook repository
+type WebhookRepo interface {
+	AddDeadLetter(ctx context.Context, deadLetter *entity.WebhookDeadLetter) (err error)
+	GetDeadLetterPage(ctx context.Context, page, pageSize int) (
+		deadLetters []*entity.WebhookDeadLetter, total int64, err error)
+}
+
+// webhookEvents the events that can be delivered to the outgoing webhook
+var webhookEvents = map[constant.EventType]string{
+	constant.EventQuestionCreate: constant.WebhookEventQuestionCreated,
+	constant.EventAnswerCreate:   constant.WebhookEventAnswerCreated,
+	constant.EventQuestionAccept: constant.WebhookEventAnswerAccepted,
+	constant.EventUserCreate:     constant.WebhookEventUserCreated,
+	constant.EventUserUpdate:     constant.WebhookEventUserUpdated,
+	constant.EventUserBan:        constant.WebhookEventUserBanned,
+	constant.EventUserRole:       constant.WebhookEventUserRoleChanged,
+}
+
+// WebhookService delivers content and user lifecycle events to the admin configured outgoing webhook
+type WebhookService struct {
+	webhookRepo       WebhookRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	objectInfoService *object_info.ObjService
+	userRepo          usercommon.UserRepo
+	httpClient        *http.Client
+	retryBaseDelay    time.Duration
+	jobQueue          *jobqueue.Service
+}
+
+// NewWebhookService new webhook service
+func NewWebhookService(
+	webhookRepo WebhookRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	objectInfoService *object_info.ObjService,
+	userRepo usercommon.UserRepo,
+	eventQueueService eventqueue.Service,
+	jobQueue *jobqueue.Service,
+) *WebhookService {
+	ws := &WebhookService{
+		webhookRepo:       webhookRepo,
+		siteInfoService:   siteInfoService,
+		objectInfoService: objectInfoService,
+		userRepo:          userRepo,
+		httpClient:        &http.Client{Timeout: constant.WebhookTimeout},
+		retryBaseDelay:    constant.WebhookRetryBaseDelay,
+		jobQueue:          jobQueue,
+	}
+	eventQueueService.RegisterHandler(ws.Handler)
+	if jobQueue != nil {
+		jobqueue.Register(jobQueue, constant.JobTypeWebhookDeliver, ws.deliverJob, &jobqueue.Options{
+			MaxAttempts:  constant.WebhookMaxAttempts,
+			OnDeadLetter: ws.addJobDeadLetter,
+		})
+	}
+	return ws
+}
+
+// Handler handle the event and deliver it to the webhook if it is enabled
+func (ws *WebhookService) Handler(ctx context.Context, msg *schema.EventMsg) error {
+	event, ok := webhookEvents[msg.EventType]
+	if !ok {
+		return nil
+	}
+	config, err := ws.siteInfoService.GetSiteWebhook(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.IsEventEnabled(event) {
+		return nil
+	}
+	payload, err := ws.buildPayload(ctx, event, msg)
+	if err != nil {
+		return err
+	}
+	if payload == nil {
+		return nil
+	}
+	// deliver by the background job, the retries should not block the other event handlers
+	if ws.jobQueue != nil {
+		return ws.jobQueue.Enqueue(ctx, constant.JobTypeWebhookDeliver, &schema.WebhookDeliveryJob{
+			URL:     config.URL,
+			Payload: payload,
+		})
+	}
+	go ws.deliver(context.Background(), config.URL, config.Secret, payload)
+	return nil
+}
+
+func (ws *WebhookService) buildPayload(ctx context.Context, event string, msg *schema.EventMsg) (
+	payload *schema.WebhookPayload, err error) {
+	switch msg.EventType {
+	case constant.EventUserCreate, constant.EventUserUpdate, constant.EventUserBan, constant.EventUserRole:
+		return ws.buildUserPayload(ctx, event, msg)
+	}
+	objInfo, err := ws.objectInfoService.GetInfo(ctx, msg.GetObjectID())
+	if err != nil {
+		return nil, err
+	}
+	if objInfo.IsDeleted() {
+		log.Debugf("webhook object %s is deleted, skip %s", objInfo.ObjectID, event)
+		return nil, nil
+	}
+	siteGeneral, err := ws.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seoInfo, err := ws.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload = &schema.WebhookPayload{
+		Event:      event,
+		DeliveryID: uuid.NewString(),
+		Timestamp:  time.Now().Unix(),
+		ObjectID:   objInfo.ObjectID,
+		ObjectType: objInfo.ObjectType,
+		QuestionID: objInfo.QuestionID,
+		AnswerID:   objInfo.AnswerID,
+		Title:      objInfo.Title,
+	}
+	if objInfo.ObjectType == constant.AnswerObjectType {
+		payload.URL = display.AnswerURL(seoInfo.Permalink, siteGeneral.SiteUrl,
+			objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
+	} else {
+		payload.URL = display.QuestionURL(seoInfo.Permalink, siteGeneral.SiteUrl,
+			objInfo.QuestionID, objInfo.Title)
+	}
+
+	payload.Author, err = ws.getWebhookUser(ctx, siteGeneral.SiteUrl, objInfo.ObjectCreatorUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.Text = fmt.Sprintf("[%s] %s: %s %s", event, payload.Author.DisplayName, payload.Title, payload.URL)
+	payload.Content = payload.Text
+	return payload, nil
+}
+
+// buildUserPayload build the payload of the user lifecycle events, the user of the event is the trigger object
+// and the user who triggered it is the actor, they are the same one if the user registers or edits the profile.
+func (ws *WebhookService) buildUserPayload(ctx context.Context, event string, msg *schema.EventMsg) (
+	payload *schema.WebhookPayload, err error) {
+	changedFields := msg.GetChangedFields()
+	if msg.EventType == constant.EventUserUpdate && len(changedFields) == 0 {
+		log.Debugf("webhook user %s is not changed, skip %s", msg.TriggerObjectID, event)
+		return nil, nil
+	}
+	siteGeneral, err := ws.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload = &schema.WebhookPayload{
+		Event:         event,
+		DeliveryID:    uuid.NewString(),
+		Timestamp:     time.Now().Unix(),
+		ObjectID:      msg.TriggerObjectID,
+		ObjectType:    constant.UserObjectType,
+		ChangedFields: changedFields,
+	}
+	payload.User, err = ws.getWebhookUser(ctx, siteGeneral.SiteUrl, msg.TriggerObjectID)
+	if err != nil {
+		return nil, err
+	}
+	payload.Actor, err = ws.getWebhookUser(ctx, siteGeneral.SiteUrl, msg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	payload.Title = payload.User.DisplayName
+	payload.URL = payload.User.URL
+
+	payload.Text = fmt.Sprintf("[%s] %s: %s %s", event, payload.Actor.DisplayName, payload.Title, payload.URL)
+	payload.Content = payload.Text
+	return payload, nil
+}
+
+// getWebhookUser get the user info in the payload, only the user id is set if the user does not exist
+func (ws *WebhookService) getWebhookUser(ctx context.Context, siteURL, userID string) (
+	user *schema.WebhookUser, err error) {
+	user = &schema.WebhookUser{UserID: userID}
+	userInfo, exist, err := ws.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		user.Username = userInfo.Username
+		user.DisplayName = userInfo.DisplayName
+		user.URL = display.UserURL(siteURL, userInfo.Username)
+	}
+	return user, nil
+}
+
+// deliver post the payload to the webhook, it retries with exponential backoff
+// on network errors and 5xx responses and dead-letters the delivery when it gives up.
+func (ws *WebhookService) deliver(ctx context.Context, url, secret string, payload *schema.WebhookPayload) {
+	body, _ := json.Marshal(payload)
+	deadLetter := &entity.WebhookDeadLetter{
+		DeliveryID: payload.DeliveryID,
+		Event:      payload.Event,
+		ObjectID:   payload.ObjectID,
+		URL:        url,
+		Payload:    string(body),
+	}
+	for attempt := 1; attempt <= constant.WebhookMaxAttempts; attempt++ {
+		deadLetter.Attempts = attempt
+		statusCode, err := ws.post(ctx, url, secret, payload, body)
+		deadLetter.StatusCode = statusCode
+		if err == nil && statusCode < http.StatusMultipleChoices {
+			return
+		}
+		if err != nil {
+			deadLetter.Error = err.Error()
+		} else {
+			deadLetter.Error = http.StatusText(statusCode)
+		}
+		// client errors will not succeed by retrying
+		if err == nil && statusCode < http.StatusInternalServerError {
+			break
+		}
+		if attempt < constant.WebhookMaxAttempts {
+			time.Sleep(ws.retryBaseDelay << (attempt - 1))
+		}
+	}
+	log.Errorf("webhook delivery %s of %s failed after %d attempts: %s",
+		deadLetter.DeliveryID, deadLetter.Event, deadLetter.Attempts, deadLetter.Error)
+	if err := ws.webhookRepo.AddDeadLetter(ctx, deadLetter); err != nil {
+		log.Errorf("add webhook dead letter failed: %v", err)
+	}
+}
+
+// deliverJob post the payload of the job once, the job queue retries it with exponential backoff
+// on network errors and 5xx responses. The client errors are dead-lettered at once.
+// The secret is not saved in the job, the current one is used to sign the payload.
+func (ws *WebhookService) deliverJob(ctx context.Context, job *schema.WebhookDeliveryJob) error {
+	if job.Payload == nil {
+		return nil
+	}
+	config, err := ws.siteInfoService.GetSiteWebhook(ctx)
+	if err != nil {
+		return err
+	}
+	body, _ := json.Marshal(job.Payload)
+	statusCode, err := ws.post(ctx, job.URL, config.Secret, job.Payload, body)
+	if err != nil {
+		return err
+	}
+	if statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	if statusCode < http.StatusInternalServerError {
+		return jobqueue.Permanent(&deliveryStatusError{statusCode: statusCode})
+	}
+	return &deliveryStatusError{statusCode: statusCode}
+}
+
+// addJobDeadLetter record the delivery failed by the job, it is listed with the other failed deliveries
+func (ws *WebhookService) addJobDeadLetter(ctx context.Context, payload []byte, attempts int, err error) {
+	job := &schema.WebhookDeliveryJob{}
+	if jsonErr := json.Unmarshal(payload, job); jsonErr != nil || job.Payload == nil {
+		return
+	}
+	body, _ := json.Marshal(job.Payload)
+	deadLetter := &entity.WebhookDeadLetter{
+		DeliveryID: job.Payload.DeliveryID,
+		Event:      job.Payload.Event,
+		ObjectID:   job.Payload.ObjectID,
+		URL:        job.URL,
+		Payload:    string(body),
+		Error:      err.Error(),
+		Attempts:   attempts,
+	}
+	var statusErr *deliveryStatusError
+	if errors.As(err, &statusErr) {
+		deadLetter.StatusCode = statusErr.statusCode
+	}
+	if err := ws.webhookRepo.AddDeadLetter(ctx, deadLetter); err != nil {
+		log.Errorf("add webhook dead letter failed: %v", err)
+	}
+}
+
+// deliveryStatusError the webhook responds with the error status
+type deliveryStatusError struct {
+	statusCode int
+}
+
+func (e *deliveryStatusError) Error() string {
+	return http.StatusText(e.statusCode)
+}
+
+func (ws *WebhookService) post(ctx context.Context, url, secret string,
+	payload *schema.WebhookPayload, body []byte) (statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constant.WebhookEventHeader, payload.Event)
+	req.Header.Set(constant.WebhookDeliveryHeader, payload.DeliveryID)
+	req.Header.Set(constant.WebhookTimestampHeader, strconv.FormatInt(payload.Timestamp, 10))
+	if len(secret) > 0 {
+		req.Header.Set(constant.WebhookSignatureHeader, Sign(secret, body))
+	}
+	resp, err := ws.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value of the body, the receiver should compute
+// the HMAC-SHA256 of the raw request body with the shared secret and compare them.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetDeadLetterPage get the permanently failed deliveries
+func (ws *WebhookService) GetDeadLetterPage(ctx context.Context, req *schema.GetWebhookDeadLetterPageReq) (
+	resp *pager.PageModel, err error) {
+	deadLetters, total, err := ws.webhookRepo.GetDeadLetterPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.GetWebhookDeadLetterResp, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		list = append(list, &schema.GetWebhookDeadLetterResp{
+			ID:         deadLetter.ID,
+			CreatedAt:  deadLetter.CreatedAt.Unix(),
+			DeliveryID: deadLetter.DeliveryID,
+			Event:      deadLetter.Event,
+			ObjectID:   deadLetter.ObjectID,
+			URL:        deadLetter.URL,
+			Payload:    deadLetter.Payload,
+			StatusCode: deadLetter.StatusCode,
+			Error:      deadLetter.Error,
+			Attempts:   deadLetter.Attempts,
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/mock"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeWebhookRepo struct {
+	mu          sync.Mutex
+	deadLetters []*entity.WebhookDeadLetter
+}
+
+func (f *fakeWebhookRepo) AddDeadLetter(_ context.Context, deadLetter *entity.WebhookDeadLetter) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deadLetters = append(f.deadLetters, deadLetter)
+	return nil
+}
+
+func (f *fakeWebhookRepo) GetDeadLetterPage(_ context.Context, _, _ int) ([]*entity.WebhookDeadLetter, int64, error) {
+	return f.deadLetters, int64(len(f.deadLetters)), nil
+}
+
+func newTestWebhookService(repo WebhookRepo) *WebhookService {
+	return &WebhookService{
+		webhookRepo: repo,
+		httpClient:  http.DefaultClient,
+	}
+}
+
+func testPayload() *schema.WebhookPayload {
+	return &schema.WebhookPayload{
+		Event:      constant.WebhookEventQuestionCreated,
+		DeliveryID: "delivery",
+		ObjectID:   "10010000000000001",
+	}
+}
+
+func TestWebhookService_DeliverSigned(t *testing.T) {
+	var signature, event string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(constant.WebhookSignatureHeader)
+		event = r.Header.Get(constant.WebhookEventHeader)
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{}
+	newTestWebhookService(repo).deliver(context.TODO(), server.URL, "secret", testPayload())
+
+	assert.Equal(t, constant.WebhookEventQuestionCreated, event)
+	assert.Equal(t, Sign("secret", body), signature)
+	assert.Empty(t, repo.deadLetters)
+}
+
+func TestWebhookService_DeliverRetryServerError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{}
+	newTestWebhookService(repo).deliver(context.TODO(), server.URL, "", testPayload())
+
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Empty(t, repo.deadLetters)
+}
+
+func TestWebhookService_DeliverDeadLetter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{}
+	newTestWebhookService(repo).deliver(context.TODO(), server.URL, "", testPayload())
+
+	assert.Equal(t, int32(constant.WebhookMaxAttempts), calls.Load())
+	if assert.Len(t, repo.deadLetters, 1) {
+		assert.Equal(t, http.StatusServiceUnavailable, repo.deadLetters[0].StatusCode)
+		assert.Equal(t, constant.WebhookMaxAttempts, repo.deadLetters[0].Attempts)
+	}
+}
+
+func TestWebhookService_DeliverClientErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	repo := &fakeWebhookRepo{}
+	newTestWebhookService(repo).deliver(context.TODO(), server.URL, "", testPayload())
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Len(t, repo.deadLetters, 1)
+}
+
+type fakeUserRepo struct {
+	usercommon.UserRepo
+	users map[string]*entity.User
+}
+
+func (f *fakeUserRepo) GetByUserID(_ context.Context, userID string) (*entity.User, bool, error) {
+	user, ok := f.users[userID]
+	return user, ok, nil
+}
+
+func newTestUserWebhookService(t *testing.T) *WebhookService {
+	ctrl := gomock.NewController(t)
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctrl)
+	siteInfoService.EXPECT().GetSiteGeneral(gomock.Any()).Return(&schema.SiteGeneralResp{
+		SiteUrl: "https://answer.example.com",
+	}, nil).AnyTimes()
+	ws := newTestWebhookService(&fakeWebhookRepo{})
+	ws.siteInfoService = siteInfoService
+	ws.userRepo = &fakeUserRepo{users: map[string]*entity.User{
+		"10010000000000001": {ID: "10010000000000001", Username: "admin", DisplayName: "Admin"},
+		"10010000000000002": {ID: "10010000000000002", Username: "alice", DisplayName: "Alice"},
+	}}
+	return ws
+}
+
+func TestWebhookService_BuildUserPayload(t *testing.T) {
+	ws := newTestUserWebhookService(t)
+	msg := schema.NewEvent(constant.EventUserRole, "10010000000000001").TID("10010000000000002").ChangedFields("role_id")
+	payload, err := ws.buildPayload(context.TODO(), webhookEvents[msg.EventType], msg)
+	assert.NoError(t, err)
+	if assert.NotNil(t, payload) {
+		assert.Equal(t, constant.WebhookEventUserRoleChanged, payload.Event)
+		assert.Equal(t, constant.UserObjectType, payload.ObjectType)
+		assert.Equal(t, "10010000000000002", payload.ObjectID)
+		assert.Equal(t, "alice", payload.User.Username)
+		assert.Equal(t, "admin", payload.Actor.Username)
+		assert.Equal(t, []string{"role_id"}, payload.ChangedFields)
+		assert.Nil(t, payload.Author)
+	}
+}
+
+func TestWebhookService_BuildUserPayloadNotChanged(t *testing.T) {
+	ws := newTestUserWebhookService(t)
+	msg := schema.NewEvent(constant.EventUserUpdate, "10010000000000002").TID("10010000000000002")
+	payload, err := ws.buildPayload(context.TODO(), webhookEvents[msg.EventType], msg)
+	assert.NoError(t, err)
+	assert.Nil(t, payload)
+}
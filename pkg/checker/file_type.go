@@ -26,6 +26,8 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"mime"
+	"net/http"
 	"os"
 	"path/filepath"
 	"slices"
@@ -42,6 +44,43 @@ func IsUnAuthorizedExtension(fileName string, allowedExtensions []string) bool {
 	return !slices.Contains(allowedExtensions, ext)
 }
 
+// DetectFileMimeType detect the mime type of the file by its content instead of the declared extension,
+// the file is rewound to the start after detecting.
+func DetectFileMimeType(file io.ReadSeeker) (mimeType string, err error) {
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	mimeType, _, err = mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	if err != nil {
+		return "", err
+	}
+	return mimeType, nil
+}
+
+// IsUnAuthorizedMimeType check whether the mime type is not in the allowedMimeTypes, `image/*` allows all image types.
+// All mime types are allowed if allowedMimeTypes is empty.
+func IsUnAuthorizedMimeType(mimeType string, allowedMimeTypes []string) bool {
+	if len(allowedMimeTypes) == 0 {
+		return false
+	}
+	mimeType = strings.ToLower(mimeType)
+	for _, allowed := range allowedMimeTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mimeType {
+			return false
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return false
+		}
+	}
+	return true
+}
+
 // DecodeAndCheckImageFile currently answers support image type is
 // `image/jpeg, image/jpg, image/png, image/gif, image/webp`
 func DecodeAndCheckImageFile(localFilePath string, maxImageMegapixel int) bool {
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/apache/answer/pkg/checker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFileMimeType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	file := bytes.NewReader(png)
+	mimeType, err := checker.DetectFileMimeType(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", mimeType)
+
+	// the file is rewound for the upload
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, png, content)
+
+	mimeType, err = checker.DetectFileMimeType(bytes.NewReader([]byte("plain text")))
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", mimeType)
+}
+
+func TestIsUnAuthorizedMimeType(t *testing.T) {
+	assert.False(t, checker.IsUnAuthorizedMimeType("application/zip", nil))
+	assert.False(t, checker.IsUnAuthorizedMimeType("image/png", []string{"image/jpeg", "Image/PNG"}))
+	assert.False(t, checker.IsUnAuthorizedMimeType("image/webp", []string{"image/*"}))
+	assert.True(t, checker.IsUnAuthorizedMimeType("text/html", []string{"image/*"}))
+	assert.True(t, checker.IsUnAuthorizedMimeType("imagex/png", []string{"image/*"}))
+	assert.True(t, checker.IsUnAuthorizedMimeType("application/zip", []string{"application/pdf"}))
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 )
 
 const (
@@ -36,6 +37,7 @@ const (
 
 const (
 	PasswordCannotContainSpaces = "error.password.space_invalid"
+	PasswordTooWeak             = "error.password.too_weak"
 )
 
 // CheckPassword checks the password strength
@@ -65,3 +67,20 @@ func CheckPassword(password string) error {
 	}
 	return nil
 }
+
+// CheckPasswordStrength checks the password contains both letters and digits
+func CheckPasswordStrength(password string) error {
+	hasLetter, hasDigit := false, false
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New(PasswordTooWeak)
+	}
+	return nil
+}
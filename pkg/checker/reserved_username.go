@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/apache/answer/configs"
@@ -47,12 +48,13 @@ func initReservedUsername() {
 	var usernames []string
 	_ = json.Unmarshal(configs.ReservedUsernames, &usernames)
 	for _, username := range usernames {
-		reservedUsernameMapping[username] = true
+		reservedUsernameMapping[strings.ToLower(username)] = true
 	}
 }
 
-// IsReservedUsername checks whether the username is reserved
+// IsReservedUsername checks whether the username is reserved, the case is ignored so that
+// the lookalikes such as "Admin" are reserved too
 func IsReservedUsername(username string) bool {
 	reservedUsernameInit.Do(initReservedUsername)
-	return reservedUsernameMapping[username]
+	return reservedUsernameMapping[strings.ToLower(username)]
 }
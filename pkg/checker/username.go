@@ -19,7 +19,12 @@
 
 package checker
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 var (
 	usernameReg = regexp.MustCompile(`^[\w.\- ]{2,30}$`)
@@ -28,3 +33,52 @@ var (
 func IsInvalidUsername(username string) bool {
 	return !usernameReg.MatchString(username)
 }
+
+// UsernamePolicy the configurable rules of the usernames, the letters and the digits are always allowed
+type UsernamePolicy struct {
+	// AllowUnicode the letters and the digits of all the languages are allowed, otherwise only the ascii ones
+	AllowUnicode bool
+	// AllowedSymbols the symbols allowed besides the letters and the digits
+	AllowedSymbols string
+	// MinLength and MaxLength the bounds of the amount of the characters
+	MinLength int
+	MaxLength int
+	// ReservedUsernames they are reserved in addition to the built-in reserved usernames
+	ReservedUsernames []string
+}
+
+// IsInvalidUsername checks whether the username breaks the length bounds or has the characters not allowed
+func (p *UsernamePolicy) IsInvalidUsername(username string) bool {
+	if length := utf8.RuneCountInString(username); length < p.MinLength || length > p.MaxLength {
+		return true
+	}
+	for _, r := range username {
+		if strings.ContainsRune(p.AllowedSymbols, r) {
+			continue
+		}
+		if r < utf8.RuneSelf {
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				continue
+			}
+			return true
+		}
+		if p.AllowUnicode && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// IsReservedUsername checks whether the username is reserved by the policy or built in, case-insensitively
+func (p *UsernamePolicy) IsReservedUsername(username string) bool {
+	if IsReservedUsername(username) {
+		return true
+	}
+	for _, reserved := range p.ReservedUsernames {
+		if strings.EqualFold(reserved, username) {
+			return true
+		}
+	}
+	return false
+}
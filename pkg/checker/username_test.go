@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsernamePolicy_IsInvalidUsername(t *testing.T) {
+	policy := &UsernamePolicy{AllowedSymbols: "_-", MinLength: 3, MaxLength: 10}
+	assert.False(t, policy.IsInvalidUsername("john_doe-1"))
+	assert.True(t, policy.IsInvalidUsername("jo"))
+	assert.True(t, policy.IsInvalidUsername("john_doe-12"))
+	assert.True(t, policy.IsInvalidUsername("john.doe"))
+	assert.True(t, policy.IsInvalidUsername("张小四"))
+
+	policy.AllowUnicode = true
+	assert.False(t, policy.IsInvalidUsername("张小四"))
+	assert.True(t, policy.IsInvalidUsername("张小四😀"))
+}
+
+func TestUsernamePolicy_IsReservedUsername(t *testing.T) {
+	policy := &UsernamePolicy{ReservedUsernames: []string{"staff"}}
+	assert.True(t, policy.IsReservedUsername("Staff"))
+	assert.True(t, policy.IsReservedUsername("ADMIN"))
+	assert.True(t, IsReservedUsername("Root"))
+	assert.False(t, policy.IsReservedUsername("john"))
+}
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"bytes"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// CodeHighlightClass the class of the pre element which is highlighted
+const CodeHighlightClass = "chroma"
+
+// CodeHighlightExtension highlight the fenced code block by the language hint
+type CodeHighlightExtension struct {
+}
+
+func (e *CodeHighlightExtension) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&CodeHighlightRenderer{}, 1),
+	))
+}
+
+type CodeHighlightRenderer struct {
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.RegisterFuncs.
+func (r *CodeHighlightRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *CodeHighlightRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (
+	ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+	language := n.Language(source)
+	var code bytes.Buffer
+	for i := range n.Lines().Len() {
+		line := n.Lines().At(i)
+		code.Write(line.Value(source))
+	}
+
+	var tokens []chroma.Token
+	if lexer := lexers.Get(string(language)); len(language) > 0 && lexer != nil {
+		iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code.String())
+		if err == nil {
+			tokens = iterator.Tokens()
+		}
+	}
+
+	// unknown or missing language is rendered as plain code
+	if len(tokens) == 0 {
+		_, _ = w.WriteString("<pre><code")
+	} else {
+		_, _ = w.WriteString(`<pre class="` + CodeHighlightClass + `"><code`)
+	}
+	if len(language) > 0 {
+		_, _ = w.WriteString(` class="language-`)
+		_, _ = w.Write(util.EscapeHTML(language))
+		_ = w.WriteByte('"')
+	}
+	_ = w.WriteByte('>')
+	if len(tokens) == 0 {
+		_, _ = w.Write(util.EscapeHTML(code.Bytes()))
+	}
+	for _, token := range tokens {
+		class := codeHighlightTokenClass(token.Type)
+		if len(class) == 0 {
+			_, _ = w.Write(util.EscapeHTML([]byte(token.Value)))
+			continue
+		}
+		_, _ = w.WriteString(`<span class="` + class + `">`)
+		_, _ = w.Write(util.EscapeHTML([]byte(token.Value)))
+		_, _ = w.WriteString("</span>")
+	}
+	_, _ = w.WriteString("</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+// codeHighlightTokenClass get the short class name of the token type, fall back to its parent category
+func codeHighlightTokenClass(tokenType chroma.TokenType) string {
+	for _, t := range []chroma.TokenType{tokenType, tokenType.SubCategory(), tokenType.Category()} {
+		if class, ok := chroma.StandardTypes[t]; ok {
+			return class
+		}
+	}
+	return ""
+}
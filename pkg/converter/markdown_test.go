@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdown2HTMLTable(t *testing.T) {
+	got := Markdown2HTML("| a | b |\n|:--|--:|\n| 1 | 2 |")
+	for _, want := range []string{"<table>", `<th align="left">a</th>`, `<td align="right">2</td>`} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("Markdown2HTML() = %q, want contains %q", got, want)
+		}
+	}
+}
+
+func TestMarkdown2HTMLTaskList(t *testing.T) {
+	got := Markdown2HTML("- [ ] todo\n- [x] done")
+	for _, want := range []string{
+		`<input disabled="" type="checkbox"> todo`,
+		`<input checked="" disabled="" type="checkbox"> done`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("Markdown2HTML() = %q, want contains %q", got, want)
+		}
+	}
+}
+
+func TestMarkdown2HTMLFilterInput(t *testing.T) {
+	got := Markdown2HTML(`<input type="text" value="x" onclick="alert(1)">`)
+	if strings.Contains(got, "<input") {
+		t.Fatalf("Markdown2HTML() = %q, want input removed", got)
+	}
+}
+
+func TestMarkdown2HTMLCodeHighlight(t *testing.T) {
+	got := Markdown2HTML("```go\nfunc main() {}\n```")
+	for _, want := range []string{
+		`<pre class="chroma"><code class="language-go">`,
+		`<span class="kd">func</span>`,
+		`<span class="nf">main</span>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("Markdown2HTML() = %q, want contains %q", got, want)
+		}
+	}
+}
+
+func TestMarkdown2HTMLCodeHighlightFallback(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "unknown language",
+			source: "```not-a-language\nx < y\n```",
+			want:   "<pre><code class=\"language-not-a-language\">x &lt; y\n</code></pre>",
+		},
+		{
+			name:   "missing language",
+			source: "```\nx < y\n```",
+			want:   "<pre><code>x &lt; y\n</code></pre>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Markdown2HTML(tt.source); got != tt.want {
+				t.Fatalf("Markdown2HTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
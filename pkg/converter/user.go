@@ -21,6 +21,7 @@ package converter
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/segmentfault/pacman/utils"
 )
@@ -41,3 +42,17 @@ func GetMentionUsernameList(text string) []string {
 	}
 	return usernames
 }
+
+// MaskEmail hide the most part of the name of the email address, the domain is kept so that the user can
+// recognize the address, such as "j***n@example.com"
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return strings.Repeat("*", len(email))
+	}
+	name, domain := []rune(email[:at]), email[at:]
+	if len(name) <= 2 {
+		return strings.Repeat("*", len(name)) + domain
+	}
+	return string(name[0]) + "***" + string(name[len(name)-1]) + domain
+}
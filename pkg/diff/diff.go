@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	LineTypeEqual  = "equal"
+	LineTypeInsert = "insert"
+	LineTypeDelete = "delete"
+)
+
+// Line a line of the diff result
+type Line struct {
+	// equal, insert or delete
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Lines compare the text line by line, the deleted lines are in front of the inserted lines when a part is replaced
+func Lines(from, to string) []*Line {
+	return Strings(splitLines(from), splitLines(to))
+}
+
+// Strings compare two lists of strings
+func Strings(from, to []string) []*Line {
+	lines := make([]*Line, 0, len(to))
+	matcher := difflib.NewMatcher(from, to)
+	for _, op := range matcher.GetOpCodes() {
+		switch op.Tag {
+		case 'e':
+			lines = appendLines(lines, LineTypeEqual, to[op.J1:op.J2])
+		case 'd':
+			lines = appendLines(lines, LineTypeDelete, from[op.I1:op.I2])
+		case 'i':
+			lines = appendLines(lines, LineTypeInsert, to[op.J1:op.J2])
+		case 'r':
+			lines = appendLines(lines, LineTypeDelete, from[op.I1:op.I2])
+			lines = appendLines(lines, LineTypeInsert, to[op.J1:op.J2])
+		}
+	}
+	return lines
+}
+
+func appendLines(lines []*Line, lineType string, texts []string) []*Line {
+	for _, text := range texts {
+		lines = append(lines, &Line{Type: lineType, Text: text})
+	}
+	return lines
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return []string{}
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(text, "\n")
+}
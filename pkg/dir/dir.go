@@ -39,6 +39,17 @@ func CheckFileExist(path string) bool {
 	return err == nil && !f.IsDir()
 }
 
+// CheckDirWritable check whether a file can be created in the directory
+func CheckDirWritable(path string) bool {
+	f, err := os.CreateTemp(path, ".write-check-*")
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return true
+}
+
 func DirSize(path string) (int64, error) {
 	var size int64
 	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
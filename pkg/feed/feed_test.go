@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *Feed {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &Feed{
+		Title:    "Answer",
+		Link:     "https://answer.test",
+		SelfLink: "https://answer.test/feed/rss/questions",
+		Updated:  created,
+		Items: []*Item{
+			{
+				Title:      "How to <escape>?",
+				Link:       "https://answer.test/questions/1",
+				Author:     "alice",
+				Content:    "<p>content & more</p>",
+				Categories: []string{"go"},
+				Created:    created,
+			},
+		},
+	}
+}
+
+func TestFeedRSS(t *testing.T) {
+	data, err := testFeed().RSS()
+	if err != nil {
+		t.Fatalf("RSS() error = %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`<rss version="2.0"`,
+		`<title>How to &lt;escape&gt;?</title>`,
+		`<guid isPermaLink="true">https://answer.test/questions/1</guid>`,
+		`<description>&lt;p&gt;content &amp; more&lt;/p&gt;</description>`,
+		`<pubDate>Tue, 02 Jan 2024 03:04:05 +0000</pubDate>`,
+		`<category>go</category>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("RSS() = %s, want contains %s", got, want)
+		}
+	}
+	if err = xml.Unmarshal(data, new(any)); err != nil {
+		t.Fatalf("RSS() is not valid xml: %v", err)
+	}
+}
+
+func TestFeedAtom(t *testing.T) {
+	data, err := testFeed().Atom()
+	if err != nil {
+		t.Fatalf("Atom() error = %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<link href="https://answer.test/feed/rss/questions" rel="self" type="application/atom+xml"></link>`,
+		`<updated>2024-01-02T03:04:05Z</updated>`,
+		`<name>alice</name>`,
+		`<category term="go"></category>`,
+		`<content type="html">&lt;p&gt;content &amp; more&lt;/p&gt;</content>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("Atom() = %s, want contains %s", got, want)
+		}
+	}
+}
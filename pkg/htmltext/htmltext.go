@@ -44,6 +44,9 @@ var (
 	reLinkReplace  = " [$1] "
 	reSpace        = regexp.MustCompile(` +`)
 	reSpaceReplace = " "
+	reImg          = regexp.MustCompile(`(?is)<img[^>]*>`)
+	reImgSrc       = regexp.MustCompile(`(?is)\ssrc="([^"]*)"`)
+	reLinkHref     = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=`)
 
 	spaceReplacer = strings.NewReplacer(
 		"\n", " ",
@@ -86,6 +89,11 @@ func ClearText(html string) string {
 	return strings.TrimSpace(reSpace.ReplaceAllString(text, reSpaceReplace))
 }
 
+// CountLinks count the links in the html, the urls in the markdown are rendered as links too
+func CountLinks(html string) int {
+	return len(reLinkHref.FindAllStringIndex(html, -1))
+}
+
 func UrlTitle(title string) (text string) {
 	title = convertChinese(title)
 	if transliterateNonLatin.Load() {
@@ -238,6 +246,42 @@ func FetchMatchedExcerpt(html string, words []string, trimMarker string, trimLen
 	return FetchRangedExcerpt(html, trimMarker, runeOffset, runeLimit)
 }
 
+// ReplaceExternalImages replace the images which are not from the site with the links to them
+func ReplaceExternalImages(html, siteURL string) string {
+	return reImg.ReplaceAllStringFunc(html, func(img string) string {
+		matched := reImgSrc.FindStringSubmatch(img)
+		if len(matched) != 2 {
+			return img
+		}
+		src := matched[1]
+		if (strings.HasPrefix(src, "/") && !strings.HasPrefix(src, "//")) ||
+			(len(siteURL) > 0 && strings.HasPrefix(src, strings.TrimSuffix(siteURL, "/")+"/")) {
+			return img
+		}
+		return `<a href="` + src + `">` + src + `</a>`
+	})
+}
+
+// FetchFirstImage get the url of the first image uploaded to the site in the html,
+// the relative url is joined with the site url. It returns empty if there is no uploaded image.
+func FetchFirstImage(html, siteURL string) string {
+	siteURL = strings.TrimSuffix(siteURL, "/")
+	for _, img := range reImg.FindAllString(html, -1) {
+		matched := reImgSrc.FindStringSubmatch(img)
+		if len(matched) != 2 {
+			continue
+		}
+		src := matched[1]
+		if strings.HasPrefix(src, "/") && !strings.HasPrefix(src, "//") {
+			return siteURL + src
+		}
+		if len(siteURL) > 0 && strings.HasPrefix(src, siteURL+"/") {
+			return src
+		}
+	}
+	return ""
+}
+
 func GetPicByUrl(url string) string {
 	res, err := http.Get(url)
 	if err != nil {
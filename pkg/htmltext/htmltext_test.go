@@ -50,6 +50,12 @@ func TestClearText(t *testing.T) {
 	assert.Equal(t, expected, clearedText)
 }
 
+func TestCountLinks(t *testing.T) {
+	assert.Equal(t, 0, CountLinks("<p>hello world</p>"))
+	assert.Equal(t, 1, CountLinks("<p>hello <a href=\"http://example.com/\">example.com</a></p>"))
+	assert.Equal(t, 2, CountLinks("<a class=\"x\" href='/a'>a</a><A HREF=\"/b\">b</A><a name=\"c\">c</a>"))
+}
+
 func TestFetchExcerpt(t *testing.T) {
 	var (
 		expected,
@@ -331,3 +337,76 @@ func TestFetchMatchedExcerpt(t *testing.T) {
 	actual = FetchMatchedExcerpt(html, []string{"中文", "😂"}, "...", 6)
 	assert.Equal(t, expected, actual)
 }
+
+func TestReplaceExternalImages(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "relative image",
+			html: `<p><img src="/uploads/a.png" alt="a"></p>`,
+			want: `<p><img src="/uploads/a.png" alt="a"></p>`,
+		},
+		{
+			name: "site image",
+			html: `<img src="https://answer.test/uploads/a.png">`,
+			want: `<img src="https://answer.test/uploads/a.png">`,
+		},
+		{
+			name: "external image",
+			html: `<p><img alt="b" src="https://example.com/b.png"/></p>`,
+			want: `<p><a href="https://example.com/b.png">https://example.com/b.png</a></p>`,
+		},
+		{
+			name: "protocol relative image",
+			html: `<img src="//example.com/c.png">`,
+			want: `<a href="//example.com/c.png">//example.com/c.png</a>`,
+		},
+		{
+			name: "site prefix of another domain",
+			html: `<img src="https://answer.test.example.com/d.png">`,
+			want: `<a href="https://answer.test.example.com/d.png">https://answer.test.example.com/d.png</a>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReplaceExternalImages(tt.html, "https://answer.test"))
+		})
+	}
+}
+
+func TestFetchFirstImage(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "no image",
+			html: `<p>text</p>`,
+			want: "",
+		},
+		{
+			name: "relative image",
+			html: `<p><img src="/uploads/a.png" alt="a"></p>`,
+			want: "https://answer.test/uploads/a.png",
+		},
+		{
+			name: "skip external image",
+			html: `<img src="https://example.com/b.png"><img alt="c" src="https://answer.test/uploads/c.png"/>`,
+			want: "https://answer.test/uploads/c.png",
+		},
+		{
+			name: "protocol relative image",
+			html: `<img src="//example.com/c.png">`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FetchFirstImage(tt.html, "https://answer.test/"))
+		})
+	}
+}
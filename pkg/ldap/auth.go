@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"strings"
+	"time"
+)
+
+// LoginPlaceholder is replaced by the escaped login name in the user filter.
+const LoginPlaceholder = "%s"
+
+var (
+	// ErrUserNotFound the user filter matched no entry
+	ErrUserNotFound = errors.New("ldap: user not found")
+	// ErrUserNotUnique the user filter matched more than one entry
+	ErrUserNotUnique = errors.New("ldap: user filter matched more than one entry")
+	// ErrInvalidCredentials the user password is wrong
+	ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+)
+
+// AuthConfig describes how to find and authenticate users in the directory.
+type AuthConfig struct {
+	// ServerURL e.g. ldap://ldap.example.com:389 or ldaps://ldap.example.com
+	ServerURL string
+	// StartTLS upgrades a ldap:// connection before binding
+	StartTLS bool
+	// InsecureSkipVerify disables server certificate verification
+	InsecureSkipVerify bool
+	// BindDN and BindPassword are the service account used to search for users.
+	// Leave them empty to search anonymously.
+	BindDN       string
+	BindPassword string
+	// BaseDN the subtree that contains the users
+	BaseDN string
+	// UserFilter e.g. (&(objectClass=person)(mail=%s))
+	UserFilter string
+	// Attributes requested for the user entry
+	Attributes []string
+	Timeout    time.Duration
+}
+
+// Authenticate searches the user with the service account and validates the password by binding as the user.
+func Authenticate(cfg *AuthConfig, login, password string) (entry *Entry, err error) {
+	if len(login) == 0 || len(password) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify} //nolint:gosec // configured by admin
+	conn, err := Dial(cfg.ServerURL, cfg.Timeout, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if cfg.StartTLS && !strings.HasPrefix(strings.ToLower(cfg.ServerURL), "ldaps://") {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.BindDN) > 0 {
+		if err = conn.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := conn.Search(&SearchRequest{
+		BaseDN:     cfg.BaseDN,
+		Scope:      ScopeWholeSubtree,
+		Filter:     strings.ReplaceAll(cfg.UserFilter, LoginPlaceholder, EscapeFilter(login)),
+		Attributes: cfg.Attributes,
+		SizeLimit:  2,
+	})
+	if IsResultCode(err, ResultSizeLimitExceeded) {
+		return nil, ErrUserNotUnique
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrUserNotFound
+	}
+	if len(entries) > 1 {
+		return nil, ErrUserNotUnique
+	}
+
+	if err = conn.Bind(entries[0].DN, password); err != nil {
+		if IsResultCode(err, ResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	return entries[0], nil
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ldap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BER identifier classes and flags used by LDAP messages.
+const (
+	classApplication byte = 0x40
+	classContext     byte = 0x80
+	typeConstructed  byte = 0x20
+
+	tagBoolean     byte = 0x01
+	tagInteger     byte = 0x02
+	tagOctetString byte = 0x04
+	tagEnumerated  byte = 0x0a
+	tagSequence    byte = 0x10 | typeConstructed
+	tagSet         byte = 0x11 | typeConstructed
+)
+
+// maxPacketSize limits the size of a single response read from the server.
+const maxPacketSize = 16 << 20
+
+var errMalformedPacket = errors.New("ldap: malformed packet")
+
+// element is a decoded BER TLV element.
+type element struct {
+	tag   byte
+	value []byte
+}
+
+func (e element) children() ([]element, error) {
+	return parseElements(e.value)
+}
+
+func (e element) int() int {
+	n := 0
+	for i, b := range e.value {
+		if i == 0 && b&0x80 != 0 {
+			n = -1
+		}
+		n = n<<8 | int(b)
+	}
+	return n
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var buf []byte
+	for n > 0 {
+		buf = append([]byte{byte(n)}, buf...)
+		n >>= 8
+	}
+	return append([]byte{0x80 | byte(len(buf))}, buf...)
+}
+
+func encode(tag byte, content ...[]byte) []byte {
+	size := 0
+	for _, c := range content {
+		size += len(c)
+	}
+	buf := append([]byte{tag}, encodeLength(size)...)
+	for _, c := range content {
+		buf = append(buf, c...)
+	}
+	return buf
+}
+
+func encodeInt(tag byte, n int) []byte {
+	var buf []byte
+	for {
+		buf = append([]byte{byte(n)}, buf...)
+		n >>= 8
+		if (n == 0 && buf[0]&0x80 == 0) || (n == -1 && buf[0]&0x80 != 0) {
+			break
+		}
+	}
+	return encode(tag, buf)
+}
+
+func encodeString(tag byte, s string) []byte {
+	return encode(tag, []byte(s))
+}
+
+func encodeBool(b bool) []byte {
+	if b {
+		return encode(tagBoolean, []byte{0xff})
+	}
+	return encode(tagBoolean, []byte{0x00})
+}
+
+// parseElements splits the content of a constructed element into its children.
+func parseElements(data []byte) (elements []element, err error) {
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nil, errMalformedPacket
+		}
+		tag := data[0]
+		length, header, err := decodeLength(data[1:])
+		if err != nil {
+			return nil, err
+		}
+		start := 1 + header
+		if length > len(data)-start {
+			return nil, errMalformedPacket
+		}
+		elements = append(elements, element{tag: tag, value: data[start : start+length]})
+		data = data[start+length:]
+	}
+	return elements, nil
+}
+
+func decodeLength(data []byte) (length, header int, err error) {
+	if len(data) == 0 {
+		return 0, 0, errMalformedPacket
+	}
+	if data[0] < 0x80 {
+		return int(data[0]), 1, nil
+	}
+	n := int(data[0] & 0x7f)
+	if n == 0 || n > 4 || len(data) < 1+n {
+		return 0, 0, errMalformedPacket
+	}
+	for _, b := range data[1 : 1+n] {
+		length = length<<8 | int(b)
+	}
+	return length, 1 + n, nil
+}
+
+// readElement reads a single top level element from the connection.
+func readElement(r *bufio.Reader) (element, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return element{}, err
+	}
+	first, err := r.ReadByte()
+	if err != nil {
+		return element{}, err
+	}
+	length := int(first)
+	if first >= 0x80 {
+		n := int(first & 0x7f)
+		if n == 0 || n > 4 {
+			return element{}, errMalformedPacket
+		}
+		length = 0
+		for i := 0; i < n; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return element{}, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxPacketSize {
+		return element{}, fmt.Errorf("ldap: packet too large: %d bytes", length)
+	}
+	value := make([]byte, length)
+	if _, err = io.ReadFull(r, value); err != nil {
+		return element{}, err
+	}
+	return element{tag: tag, value: value}, nil
+}
@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// filter choice tags, see RFC 4511 section 4.5.1
+const (
+	filterAnd            = classContext | typeConstructed | 0
+	filterOr             = classContext | typeConstructed | 1
+	filterNot            = classContext | typeConstructed | 2
+	filterEqualityMatch  = classContext | typeConstructed | 3
+	filterSubstrings     = classContext | typeConstructed | 4
+	filterGreaterOrEqual = classContext | typeConstructed | 5
+	filterLessOrEqual    = classContext | typeConstructed | 6
+	filterPresent        = classContext | 7
+	filterApproxMatch    = classContext | typeConstructed | 8
+
+	substringInitial = classContext | 0
+	substringAny     = classContext | 1
+	substringFinal   = classContext | 2
+)
+
+// EscapeFilter escapes the special characters of a filter assertion value, see RFC 4515 section 3.
+// It must be used for every user supplied value that is placed into a search filter.
+func EscapeFilter(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&b, "\\%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// compileFilter converts a string filter like (&(objectClass=person)(uid=foo)) to its BER encoding.
+func compileFilter(filter string) ([]byte, error) {
+	filter = strings.TrimSpace(filter)
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("ldap: empty filter")
+	}
+	if filter[0] != '(' {
+		filter = "(" + filter + ")"
+	}
+	packet, pos, err := parseFilter(filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(filter) {
+		return nil, fmt.Errorf("ldap: unexpected trailing characters in filter %q", filter)
+	}
+	return packet, nil
+}
+
+func parseFilter(filter string, pos int) (packet []byte, next int, err error) {
+	if pos >= len(filter) || filter[pos] != '(' {
+		return nil, 0, fmt.Errorf("ldap: filter %q: expected '(' at %d", filter, pos)
+	}
+	pos++
+	if pos >= len(filter) {
+		return nil, 0, fmt.Errorf("ldap: filter %q: unexpected end", filter)
+	}
+	switch filter[pos] {
+	case '&', '|':
+		tag := filterAnd
+		if filter[pos] == '|' {
+			tag = filterOr
+		}
+		pos++
+		var children [][]byte
+		for pos < len(filter) && filter[pos] == '(' {
+			child, n, err := parseFilter(filter, pos)
+			if err != nil {
+				return nil, 0, err
+			}
+			children = append(children, child)
+			pos = n
+		}
+		if len(children) == 0 {
+			return nil, 0, fmt.Errorf("ldap: filter %q: empty set", filter)
+		}
+		packet = encode(tag, children...)
+	case '!':
+		child, n, err := parseFilter(filter, pos+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		packet, pos = encode(filterNot, child), n
+	default:
+		end := strings.IndexByte(filter[pos:], ')')
+		if end < 0 {
+			return nil, 0, fmt.Errorf("ldap: filter %q: missing ')'", filter)
+		}
+		packet, err = parseItem(filter[pos : pos+end])
+		if err != nil {
+			return nil, 0, err
+		}
+		pos += end
+	}
+	if pos >= len(filter) || filter[pos] != ')' {
+		return nil, 0, fmt.Errorf("ldap: filter %q: expected ')' at %d", filter, pos)
+	}
+	return packet, pos + 1, nil
+}
+
+func parseItem(item string) ([]byte, error) {
+	eq := strings.IndexByte(item, '=')
+	if eq <= 0 {
+		return nil, fmt.Errorf("ldap: invalid filter item %q", item)
+	}
+	attr, value := item[:eq], item[eq+1:]
+	tag := filterEqualityMatch
+	switch attr[len(attr)-1] {
+	case '>':
+		tag, attr = filterGreaterOrEqual, attr[:len(attr)-1]
+	case '<':
+		tag, attr = filterLessOrEqual, attr[:len(attr)-1]
+	case '~':
+		tag, attr = filterApproxMatch, attr[:len(attr)-1]
+	}
+	if len(attr) == 0 {
+		return nil, fmt.Errorf("ldap: invalid filter item %q", item)
+	}
+	if tag != filterEqualityMatch || !strings.Contains(value, "*") {
+		unescaped, err := unescapeFilterValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return encode(tag, encodeString(tagOctetString, attr), encodeString(tagOctetString, unescaped)), nil
+	}
+	if value == "*" {
+		return encodeString(filterPresent, attr), nil
+	}
+
+	parts := strings.Split(value, "*")
+	var substrings [][]byte
+	for i, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		unescaped, err := unescapeFilterValue(part)
+		if err != nil {
+			return nil, err
+		}
+		partTag := substringAny
+		if i == 0 {
+			partTag = substringInitial
+		} else if i == len(parts)-1 {
+			partTag = substringFinal
+		}
+		substrings = append(substrings, encodeString(partTag, unescaped))
+	}
+	return encode(filterSubstrings, encodeString(tagOctetString, attr), encode(tagSequence, substrings...)), nil
+}
+
+func unescapeFilterValue(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+3 > len(value) {
+			return "", fmt.Errorf("ldap: invalid escape in filter value %q", value)
+		}
+		decoded, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("ldap: invalid escape in filter value %q", value)
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,371 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// protocol operation tags, see RFC 4511 section 4.2
+const (
+	opBindRequest       = classApplication | typeConstructed | 0
+	opBindResponse      = classApplication | typeConstructed | 1
+	opUnbindRequest     = classApplication | 2
+	opSearchRequest     = classApplication | typeConstructed | 3
+	opSearchResultEntry = classApplication | typeConstructed | 4
+	opSearchResultDone  = classApplication | typeConstructed | 5
+	opSearchResultRef   = classApplication | typeConstructed | 19
+	opExtendedRequest   = classApplication | typeConstructed | 23
+	opExtendedResponse  = classApplication | typeConstructed | 24
+
+	authSimple = classContext | 0
+
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+)
+
+// result codes used by callers, see RFC 4511 appendix A
+const (
+	ResultSuccess            = 0
+	ResultSizeLimitExceeded  = 4
+	ResultInvalidCredentials = 49
+)
+
+// search scopes
+const (
+	ScopeBaseObject   = 0
+	ScopeSingleLevel  = 1
+	ScopeWholeSubtree = 2
+)
+
+// DefaultPort and DefaultTLSPort are used when the server url has no port.
+const (
+	DefaultPort    = "389"
+	DefaultTLSPort = "636"
+)
+
+// ErrEmptyPassword is returned when binding with an empty password, which most servers
+// would otherwise treat as an unauthenticated bind and report as successful.
+var ErrEmptyPassword = errors.New("ldap: empty password")
+
+// Error is a non-success result returned by the server.
+type Error struct {
+	ResultCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("ldap: result code %d: %s", e.ResultCode, e.Message)
+}
+
+// IsResultCode reports whether err is an ldap Error with the given result code.
+func IsResultCode(err error, code int) bool {
+	var ldapErr *Error
+	return errors.As(err, &ldapErr) && ldapErr.ResultCode == code
+}
+
+// Entry is a single search result.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// GetAttributeValue returns the first value of the attribute, attribute names are case-insensitive.
+func (e *Entry) GetAttributeValue(name string) string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// SearchRequest search request
+type SearchRequest struct {
+	BaseDN     string
+	Scope      int
+	Filter     string
+	Attributes []string
+	SizeLimit  int
+}
+
+// Conn is a connection to an LDAP server. It is not safe for concurrent use.
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	messageID int
+	timeout   time.Duration
+}
+
+// Dial connects to the server at rawURL, using ldap:// for plain and ldaps:// for TLS connections.
+func Dial(rawURL string, timeout time.Duration, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid server url: %w", err)
+	}
+	host, port := u.Hostname(), u.Port()
+	if len(host) == 0 {
+		return nil, fmt.Errorf("ldap: invalid server url %q", rawURL)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		if len(port) == 0 {
+			port = DefaultPort
+		}
+		conn, err = dialer.Dial("tcp", net.JoinHostPort(host, port))
+	case "ldaps":
+		if len(port) == 0 {
+			port = DefaultTLSPort
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), withServerName(tlsConfig, host))
+	default:
+		return nil, fmt.Errorf("ldap: unsupported scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn, timeout), nil
+}
+
+// NewConn wraps an established connection.
+func NewConn(conn net.Conn, timeout time.Duration) *Conn {
+	return &Conn{conn: conn, reader: bufio.NewReader(conn), timeout: timeout}
+}
+
+// StartTLS upgrades a plain connection with the StartTLS extended operation.
+func (c *Conn) StartTLS(tlsConfig *tls.Config) error {
+	resp, err := c.request(encode(opExtendedRequest, encodeString(classContext|0, startTLSOID)))
+	if err != nil {
+		return err
+	}
+	if resp.tag != opExtendedResponse {
+		return errMalformedPacket
+	}
+	if err = checkResult(resp); err != nil {
+		return err
+	}
+	host, _, _ := net.SplitHostPort(c.conn.RemoteAddr().String())
+	tlsConn := tls.Client(c.conn, withServerName(tlsConfig, host))
+	if err = c.setDeadline(); err != nil {
+		return err
+	}
+	if err = tlsConn.Handshake(); err != nil {
+		return err
+	}
+	c.conn = tlsConn
+	c.reader = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// Bind authenticates the connection with a simple bind.
+func (c *Conn) Bind(dn, password string) error {
+	if len(password) == 0 {
+		return ErrEmptyPassword
+	}
+	resp, err := c.request(encode(opBindRequest,
+		encodeInt(tagInteger, 3),
+		encodeString(tagOctetString, dn),
+		encodeString(authSimple, password),
+	))
+	if err != nil {
+		return err
+	}
+	if resp.tag != opBindResponse {
+		return errMalformedPacket
+	}
+	return checkResult(resp)
+}
+
+// Search runs a search and collects all returned entries.
+func (c *Conn) Search(req *SearchRequest) (entries []*Entry, err error) {
+	filter, err := compileFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make([][]byte, 0, len(req.Attributes))
+	for _, attr := range req.Attributes {
+		attributes = append(attributes, encodeString(tagOctetString, attr))
+	}
+	timeLimit := int(c.timeout / time.Second)
+	id, err := c.send(encode(opSearchRequest,
+		encodeString(tagOctetString, req.BaseDN),
+		encodeInt(tagEnumerated, req.Scope),
+		encodeInt(tagEnumerated, 0),
+		encodeInt(tagInteger, req.SizeLimit),
+		encodeInt(tagInteger, timeLimit),
+		encodeBool(false),
+		filter,
+		encode(tagSequence, attributes...),
+	))
+	if err != nil {
+		return nil, err
+	}
+	for {
+		resp, err := c.receive(id)
+		if err != nil {
+			return nil, err
+		}
+		switch resp.tag {
+		case opSearchResultEntry:
+			entry, err := parseEntry(resp)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case opSearchResultRef:
+			// referrals are not followed
+		case opSearchResultDone:
+			return entries, checkResult(resp)
+		default:
+			return nil, errMalformedPacket
+		}
+	}
+}
+
+// Close sends an unbind request and closes the connection.
+func (c *Conn) Close() error {
+	_, _ = c.send(encode(opUnbindRequest))
+	return c.conn.Close()
+}
+
+func (c *Conn) request(op []byte) (element, error) {
+	id, err := c.send(op)
+	if err != nil {
+		return element{}, err
+	}
+	return c.receive(id)
+}
+
+func (c *Conn) send(op []byte) (id int, err error) {
+	c.messageID++
+	if err = c.setDeadline(); err != nil {
+		return 0, err
+	}
+	_, err = c.conn.Write(encode(tagSequence, encodeInt(tagInteger, c.messageID), op))
+	return c.messageID, err
+}
+
+// receive reads the next message for id and returns its protocol operation.
+func (c *Conn) receive(id int) (element, error) {
+	for {
+		if err := c.setDeadline(); err != nil {
+			return element{}, err
+		}
+		msg, err := readElement(c.reader)
+		if err != nil {
+			return element{}, err
+		}
+		if msg.tag != tagSequence {
+			return element{}, errMalformedPacket
+		}
+		parts, err := msg.children()
+		if err != nil {
+			return element{}, err
+		}
+		if len(parts) < 2 || parts[0].tag != tagInteger {
+			return element{}, errMalformedPacket
+		}
+		// message id 0 is an unsolicited notification, e.g. notice of disconnection
+		if parts[0].int() == 0 {
+			return element{}, checkResult(parts[1])
+		}
+		if parts[0].int() == id {
+			return parts[1], nil
+		}
+	}
+}
+
+func (c *Conn) setDeadline() error {
+	if c.timeout <= 0 {
+		return nil
+	}
+	return c.conn.SetDeadline(time.Now().Add(c.timeout))
+}
+
+func checkResult(op element) error {
+	parts, err := op.children()
+	if err != nil {
+		return err
+	}
+	if len(parts) < 3 || parts[0].tag != tagEnumerated {
+		return errMalformedPacket
+	}
+	if code := parts[0].int(); code != ResultSuccess {
+		return &Error{ResultCode: code, Message: string(parts[2].value)}
+	}
+	return nil
+}
+
+func parseEntry(op element) (*Entry, error) {
+	parts, err := op.children()
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) < 2 {
+		return nil, errMalformedPacket
+	}
+	entry := &Entry{DN: string(parts[0].value), Attributes: make(map[string][]string)}
+	attributes, err := parts[1].children()
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		fields, err := attribute.children()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) < 2 {
+			return nil, errMalformedPacket
+		}
+		values, err := fields[1].children()
+		if err != nil {
+			return nil, err
+		}
+		name := string(fields[0].value)
+		for _, value := range values {
+			entry.Attributes[name] = append(entry.Attributes[name], string(value.value))
+		}
+	}
+	return entry, nil
+}
+
+func withServerName(tlsConfig *tls.Config, host string) *tls.Config {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+	if len(tlsConfig.ServerName) == 0 {
+		tlsConfig.ServerName = host
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+	return tlsConfig
+}
@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ldap
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeFilter(t *testing.T) {
+	assert.Equal(t, "foo", EscapeFilter("foo"))
+	assert.Equal(t, `\2a\28uid=\5c\29\00`, EscapeFilter("*(uid=\\)\x00"))
+}
+
+func TestCompileFilter(t *testing.T) {
+	packet, err := compileFilter("(uid=bob)")
+	require.NoError(t, err)
+	assert.Equal(t, encode(filterEqualityMatch,
+		encodeString(tagOctetString, "uid"), encodeString(tagOctetString, "bob")), packet)
+
+	packet, err = compileFilter("(&(objectClass=*)(!(cn~=al))(mail=" + EscapeFilter("a*b") + "))")
+	require.NoError(t, err)
+	assert.Equal(t, encode(filterAnd,
+		encodeString(filterPresent, "objectClass"),
+		encode(filterNot, encode(filterApproxMatch,
+			encodeString(tagOctetString, "cn"), encodeString(tagOctetString, "al"))),
+		encode(filterEqualityMatch,
+			encodeString(tagOctetString, "mail"), encodeString(tagOctetString, "a*b")),
+	), packet)
+
+	packet, err = compileFilter("cn=ab*c*d")
+	require.NoError(t, err)
+	assert.Equal(t, encode(filterSubstrings, encodeString(tagOctetString, "cn"), encode(tagSequence,
+		encodeString(substringInitial, "ab"),
+		encodeString(substringAny, "c"),
+		encodeString(substringFinal, "d"),
+	)), packet)
+
+	for _, filter := range []string{"", "(uid=bob", "(&)", "(=bob)", "(uid=\\2)", "(uid=bob))"} {
+		_, err = compileFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+// fakeServer serves one connection with a single user uid=bob,dc=example,dc=com
+func fakeServer(listener net.Listener) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(id int, op []byte) {
+		_, _ = conn.Write(encode(tagSequence, encodeInt(tagInteger, id), op))
+	}
+	result := func(tag byte, code int) []byte {
+		return encode(tag, encodeInt(tagEnumerated, code),
+			encodeString(tagOctetString, ""), encodeString(tagOctetString, ""))
+	}
+	for {
+		msg, err := readElement(reader)
+		if err != nil {
+			return
+		}
+		parts, _ := msg.children()
+		id, op := parts[0].int(), parts[1]
+		fields, _ := op.children()
+		switch op.tag {
+		case opBindRequest:
+			dn, password := string(fields[1].value), string(fields[2].value)
+			code := ResultInvalidCredentials
+			if (dn == "cn=admin" && password == "secret") ||
+				(dn == "uid=bob,dc=example,dc=com" && password == "bob-pass") {
+				code = ResultSuccess
+			}
+			reply(id, result(opBindResponse, code))
+		case opSearchRequest:
+			expected, _ := compileFilter("(&(objectClass=person)(mail=bob@example.com))")
+			if string(encodeElement(fields[6])) == string(expected) {
+				reply(id, encode(opSearchResultEntry,
+					encodeString(tagOctetString, "uid=bob,dc=example,dc=com"),
+					encode(tagSequence,
+						encode(tagSequence, encodeString(tagOctetString, "mail"),
+							encode(tagSet, encodeString(tagOctetString, "bob@example.com"))),
+						encode(tagSequence, encodeString(tagOctetString, "displayName"),
+							encode(tagSet, encodeString(tagOctetString, "Bob"))),
+					)))
+			}
+			reply(id, result(opSearchResultDone, ResultSuccess))
+		case opUnbindRequest:
+			return
+		}
+	}
+}
+
+func encodeElement(e element) []byte {
+	return encode(e.tag, e.value)
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		err      error
+	}{
+		{name: "success", login: "bob@example.com", password: "bob-pass"},
+		{name: "wrong password", login: "bob@example.com", password: "wrong", err: ErrInvalidCredentials},
+		{name: "empty password", login: "bob@example.com", password: "", err: ErrInvalidCredentials},
+		{name: "unknown user", login: "alice@example.com", password: "bob-pass", err: ErrUserNotFound},
+		{name: "filter injection", login: "*", password: "bob-pass", err: ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer listener.Close()
+			go fakeServer(listener)
+
+			entry, err := Authenticate(&AuthConfig{
+				ServerURL:    "ldap://" + listener.Addr().String(),
+				BindDN:       "cn=admin",
+				BindPassword: "secret",
+				BaseDN:       "dc=example,dc=com",
+				UserFilter:   "(&(objectClass=person)(mail=%s))",
+				Attributes:   []string{"mail", "displayName"},
+				Timeout:      time.Second,
+			}, tt.login, tt.password)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "uid=bob,dc=example,dc=com", entry.DN)
+			assert.Equal(t, "bob@example.com", entry.GetAttributeValue("mail"))
+			assert.Equal(t, "Bob", entry.GetAttributeValue("displayname"))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"sort"
+	"strings"
+)
+
+// exclusiveCanonicalizer implements Exclusive XML Canonicalization 1.0,
+// see https://www.w3.org/TR/xml-exc-c14n/
+type exclusiveCanonicalizer struct {
+	withComments bool
+	// inclusivePrefixes are handled as in inclusive canonicalization, from the InclusiveNamespaces PrefixList
+	inclusivePrefixes map[string]bool
+	// exclude is omitted from the output, used by the enveloped signature transform
+	exclude *elementNode
+}
+
+func newExclusiveCanonicalizer(withComments bool, prefixList string) *exclusiveCanonicalizer {
+	c := &exclusiveCanonicalizer{withComments: withComments, inclusivePrefixes: map[string]bool{}}
+	for _, prefix := range strings.Fields(prefixList) {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		c.inclusivePrefixes[prefix] = true
+	}
+	return c
+}
+
+func (c *exclusiveCanonicalizer) canonicalize(el *elementNode) []byte {
+	var b strings.Builder
+	c.writeElement(&b, el, map[string]string{})
+	return []byte(b.String())
+}
+
+// writeElement writes el, rendered holds the namespace declarations already in the output.
+func (c *exclusiveCanonicalizer) writeElement(b *strings.Builder, el *elementNode, rendered map[string]string) {
+	if el == c.exclude {
+		return
+	}
+
+	utilized := map[string]bool{el.prefix: true}
+	for _, a := range el.attrs {
+		if a.prefix != "" {
+			utilized[a.prefix] = true
+		}
+	}
+	for prefix := range c.inclusivePrefixes {
+		if _, ok := el.lookupNamespace(prefix); ok {
+			utilized[prefix] = true
+		}
+	}
+
+	current := make(map[string]string, len(rendered))
+	for prefix, uri := range rendered {
+		current[prefix] = uri
+	}
+	var prefixes []string
+	for prefix := range utilized {
+		if prefix == "xml" {
+			continue
+		}
+		uri, _ := el.lookupNamespace(prefix)
+		renderedURI, ok := rendered[prefix]
+		if ok && renderedURI == uri {
+			continue
+		}
+		// the empty default namespace only needs to be rendered to undeclare an output ancestor's
+		if !ok && prefix == "" && uri == "" {
+			continue
+		}
+		current[prefix] = uri
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	b.WriteByte('<')
+	b.WriteString(qualifiedName(el.prefix, el.local))
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			b.WriteString(` xmlns="`)
+		} else {
+			b.WriteString(` xmlns:` + prefix + `="`)
+		}
+		b.WriteString(escapeAttrValue(current[prefix]))
+		b.WriteByte('"')
+	}
+
+	attrs := make([]attr, len(el.attrs))
+	copy(attrs, el.attrs)
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ns1, ns2 := attrNamespace(el, attrs[i]), attrNamespace(el, attrs[j])
+		if ns1 != ns2 {
+			return ns1 < ns2
+		}
+		return attrs[i].local < attrs[j].local
+	})
+	for _, a := range attrs {
+		b.WriteString(" " + qualifiedName(a.prefix, a.local) + `="`)
+		b.WriteString(escapeAttrValue(a.value))
+		b.WriteByte('"')
+	}
+	b.WriteByte('>')
+
+	for _, child := range el.children {
+		switch n := child.(type) {
+		case *elementNode:
+			c.writeElement(b, n, current)
+		case textNode:
+			b.WriteString(escapeText(string(n)))
+		case commentNode:
+			if c.withComments {
+				b.WriteString("<!--" + string(n) + "-->")
+			}
+		case procInstNode:
+			b.WriteString("<?" + n.target)
+			if len(n.inst) > 0 {
+				b.WriteString(" " + n.inst)
+			}
+			b.WriteString("?>")
+		}
+	}
+	b.WriteString("</" + qualifiedName(el.prefix, el.local) + ">")
+}
+
+func attrNamespace(el *elementNode, a attr) string {
+	if a.prefix == "" {
+		return ""
+	}
+	uri, _ := el.lookupNamespace(a.prefix)
+	return uri
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;",
+		"\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttrValue(s string) string {
+	return attrEscaper.Replace(s)
+}
@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256" // register the digest algorithms
+	_ "crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	namespaceDSig = "http://www.w3.org/2000/09/xmldsig#"
+
+	algorithmExcC14N             = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmExcC14NWithComments = "http://www.w3.org/2001/10/xml-exc-c14n#WithComments"
+	algorithmEnvelopedSignature  = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+
+	algorithmRSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmRSASHA512   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	algorithmECDSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	algorithmECDSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+
+	algorithmSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algorithmSHA512 = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+var (
+	// ErrSignatureMissing the element is not signed
+	ErrSignatureMissing = errors.New("saml: signature missing")
+	// ErrSignatureInvalid the signature does not match any trusted certificate
+	ErrSignatureInvalid = errors.New("saml: signature invalid")
+)
+
+var digestAlgorithms = map[string]crypto.Hash{
+	algorithmSHA256: crypto.SHA256,
+	algorithmSHA512: crypto.SHA512,
+}
+
+var signatureAlgorithms = map[string]crypto.Hash{
+	algorithmRSASHA256:   crypto.SHA256,
+	algorithmRSASHA512:   crypto.SHA512,
+	algorithmECDSASHA256: crypto.SHA256,
+	algorithmECDSASHA512: crypto.SHA512,
+}
+
+// verifySignature checks the enveloped signature of el. The signature must reference el itself,
+// so that a signed element can not be wrapped into a different, unsigned message.
+// SHA-1 based algorithms are not accepted.
+func verifySignature(el *elementNode, certificates []*x509.Certificate) error {
+	signatures := el.findChildren(namespaceDSig, "Signature")
+	if len(signatures) == 0 {
+		return ErrSignatureMissing
+	}
+	if len(signatures) > 1 {
+		return errors.New("saml: multiple signatures")
+	}
+	signature := signatures[0]
+	signedInfo := signature.findChild(namespaceDSig, "SignedInfo")
+	signatureValue := signature.findChild(namespaceDSig, "SignatureValue")
+	if signedInfo == nil || signatureValue == nil {
+		return errors.New("saml: malformed signature")
+	}
+
+	canonicalizer, err := signedInfoCanonicalizer(signedInfo)
+	if err != nil {
+		return err
+	}
+	signatureMethod := signedInfo.findChild(namespaceDSig, "SignatureMethod")
+	if signatureMethod == nil {
+		return errors.New("saml: signature method missing")
+	}
+	signatureHash, ok := signatureAlgorithms[signatureMethod.attrValue("Algorithm")]
+	if !ok {
+		return fmt.Errorf("saml: unsupported signature method %s", signatureMethod.attrValue("Algorithm"))
+	}
+
+	if err = verifyReference(el, signature, signedInfo); err != nil {
+		return err
+	}
+
+	value, err := base64.StdEncoding.DecodeString(stripSpaces(signatureValue.text()))
+	if err != nil {
+		return fmt.Errorf("saml: invalid signature value: %w", err)
+	}
+	hash := signatureHash.New()
+	hash.Write(canonicalizer.canonicalize(signedInfo))
+	hashed := hash.Sum(nil)
+	for _, certificate := range certificates {
+		if verifyWithPublicKey(certificate.PublicKey, signatureHash, hashed, value) {
+			return nil
+		}
+	}
+	return ErrSignatureInvalid
+}
+
+func signedInfoCanonicalizer(signedInfo *elementNode) (*exclusiveCanonicalizer, error) {
+	method := signedInfo.findChild(namespaceDSig, "CanonicalizationMethod")
+	if method == nil {
+		return nil, errors.New("saml: canonicalization method missing")
+	}
+	return canonicalizerFor(method)
+}
+
+func canonicalizerFor(method *elementNode) (*exclusiveCanonicalizer, error) {
+	prefixList := ""
+	for _, child := range method.childElements() {
+		if child.local == "InclusiveNamespaces" && child.namespace() == algorithmExcC14N {
+			prefixList = child.attrValue("PrefixList")
+		}
+	}
+	switch method.attrValue("Algorithm") {
+	case algorithmExcC14N:
+		return newExclusiveCanonicalizer(false, prefixList), nil
+	case algorithmExcC14NWithComments:
+		return newExclusiveCanonicalizer(true, prefixList), nil
+	default:
+		return nil, fmt.Errorf("saml: unsupported canonicalization method %s", method.attrValue("Algorithm"))
+	}
+}
+
+func verifyReference(el, signature, signedInfo *elementNode) error {
+	references := signedInfo.findChildren(namespaceDSig, "Reference")
+	if len(references) != 1 {
+		return errors.New("saml: signature must have exactly one reference")
+	}
+	reference := references[0]
+	id := el.attrValue("ID")
+	if len(id) == 0 || reference.attrValue("URI") != "#"+id {
+		return errors.New("saml: signature does not reference the signed element")
+	}
+
+	var canonicalizer *exclusiveCanonicalizer
+	enveloped := false
+	if transforms := reference.findChild(namespaceDSig, "Transforms"); transforms != nil {
+		for _, transform := range transforms.findChildren(namespaceDSig, "Transform") {
+			if transform.attrValue("Algorithm") == algorithmEnvelopedSignature {
+				enveloped = true
+				continue
+			}
+			c, err := canonicalizerFor(transform)
+			if err != nil {
+				return err
+			}
+			canonicalizer = c
+		}
+	}
+	if !enveloped {
+		return errors.New("saml: signature must be enveloped")
+	}
+	if canonicalizer == nil {
+		canonicalizer = newExclusiveCanonicalizer(false, "")
+	}
+	canonicalizer.exclude = signature
+
+	digestMethod := reference.findChild(namespaceDSig, "DigestMethod")
+	digestValue := reference.findChild(namespaceDSig, "DigestValue")
+	if digestMethod == nil || digestValue == nil {
+		return errors.New("saml: malformed signature reference")
+	}
+	digestHash, ok := digestAlgorithms[digestMethod.attrValue("Algorithm")]
+	if !ok {
+		return fmt.Errorf("saml: unsupported digest method %s", digestMethod.attrValue("Algorithm"))
+	}
+	expected, err := base64.StdEncoding.DecodeString(stripSpaces(digestValue.text()))
+	if err != nil {
+		return fmt.Errorf("saml: invalid digest value: %w", err)
+	}
+	hash := digestHash.New()
+	hash.Write(canonicalizer.canonicalize(el))
+	if subtle.ConstantTimeCompare(hash.Sum(nil), expected) != 1 {
+		return errors.New("saml: digest mismatch")
+	}
+	return nil
+}
+
+func verifyWithPublicKey(publicKey any, hash crypto.Hash, hashed, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, hashed, signature) == nil
+	case *ecdsa.PublicKey:
+		// xmldsig encodes ecdsa signatures as the concatenation of r and s
+		if len(signature)%2 != 0 {
+			return false
+		}
+		size := len(signature) / 2
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+		if err != nil {
+			return false
+		}
+		return ecdsa.VerifyASN1(key, hashed, der)
+	}
+	return false
+}
+
+func stripSpaces(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
@@ -0,0 +1,517 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+
+	NameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIDFormatUnspecified  = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	statusSuccess        = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	subjectMethodBearer  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	defaultClockSkew     = 3 * time.Minute
+	maxResponseSize      = 1 << 20
+	requestIDRandomBytes = 20
+)
+
+// ServiceProvider is a SAML 2.0 service provider using the HTTP-Redirect binding to send
+// authentication requests and the HTTP-POST binding to receive responses.
+type ServiceProvider struct {
+	// EntityID identifies this service provider, usually the metadata URL
+	EntityID string
+	// AcsURL the assertion consumer service URL
+	AcsURL string
+	// IdPEntityID is checked against the assertion issuer if not empty
+	IdPEntityID string
+	// IdPSSOURL the single sign on URL of the identity provider
+	IdPSSOURL string
+	// IdPCertificates are trusted to sign responses and assertions
+	IdPCertificates []*x509.Certificate
+	// AllowIdPInitiated accepts responses which are not an answer to a request of this provider
+	AllowIdPInitiated bool
+	// ClockSkew tolerated when checking validity periods, 3 minutes by default
+	ClockSkew time.Duration
+	// Now returns the current time, time.Now by default
+	Now func() time.Time
+}
+
+// Assertion is the verified information about the authenticated subject.
+type Assertion struct {
+	ID           string
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	Attributes   map[string][]string
+	// NotOnOrAfter is when the assertion expires, it can be used to remember the assertion ID against replay
+	NotOnOrAfter time.Time
+}
+
+// GetAttributeValue returns the first value of the attribute matched by name or friendly name.
+func (a *Assertion) GetAttributeValue(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ParseCertificates parses PEM encoded certificates. Bare base64 as copied from IdP metadata is accepted too.
+func ParseCertificates(data string) (certificates []*x509.Certificate, err error) {
+	rest := []byte(strings.TrimSpace(data))
+	if !bytes.HasPrefix(rest, []byte("-----BEGIN")) {
+		der, err := base64.StdEncoding.DecodeString(stripSpaces(string(rest)))
+		if err != nil {
+			return nil, fmt.Errorf("saml: invalid certificate: %w", err)
+		}
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		return []*x509.Certificate{certificate}, nil
+	}
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("saml: no certificate found")
+	}
+	return certificates, nil
+}
+
+type metadataEntityDescriptor struct {
+	XMLName         xml.Name                `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string                  `xml:"entityID,attr"`
+	SPSSODescriptor metadataSPSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type metadataSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                      `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                      `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                    `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormats              []string                  `xml:"NameIDFormat"`
+	AssertionConsumerServices  []metadataIndexedEndpoint `xml:"AssertionConsumerService"`
+}
+
+type metadataIndexedEndpoint struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// Metadata returns the service provider metadata to register at the identity provider.
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	metadata := &metadataEntityDescriptor{
+		EntityID: sp.EntityID,
+		SPSSODescriptor: metadataSPSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: namespaceProtocol,
+			NameIDFormats:              []string{NameIDFormatEmailAddress, NameIDFormatUnspecified},
+			AssertionConsumerServices: []metadataIndexedEndpoint{{
+				Binding:   BindingHTTPPost,
+				Location:  sp.AcsURL,
+				Index:     1,
+				IsDefault: true,
+			}},
+		},
+	}
+	data, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name           `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string             `xml:"ID,attr"`
+	Version                     string             `xml:"Version,attr"`
+	IssueInstant                string             `xml:"IssueInstant,attr"`
+	Destination                 string             `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string             `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string             `xml:"ProtocolBinding,attr"`
+	Issuer                      authnRequestIssuer `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                authnNameIDPolicy  `xml:"NameIDPolicy"`
+}
+
+type authnRequestIssuer struct {
+	Value string `xml:",chardata"`
+}
+
+type authnNameIDPolicy struct {
+	Format      string `xml:"Format,attr"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+// AuthnRequestURL returns the identity provider URL to redirect the user to, and the request ID
+// which must be remembered to validate the response.
+func (sp *ServiceProvider) AuthnRequestURL(relayState string) (redirectURL, requestID string, err error) {
+	if len(sp.IdPSSOURL) == 0 {
+		return "", "", errors.New("saml: identity provider sso url is empty")
+	}
+	requestID, err = newRequestID()
+	if err != nil {
+		return "", "", err
+	}
+	request := &authnRequest{
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                sp.now().UTC().Format(time.RFC3339),
+		Destination:                 sp.IdPSSOURL,
+		AssertionConsumerServiceURL: sp.AcsURL,
+		ProtocolBinding:             BindingHTTPPost,
+		Issuer:                      authnRequestIssuer{Value: sp.EntityID},
+		NameIDPolicy:                authnNameIDPolicy{Format: NameIDFormatUnspecified, AllowCreate: true},
+	}
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return "", "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	u, err := url.Parse(sp.IdPSSOURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := u.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if len(relayState) > 0 {
+		query.Set("RelayState", relayState)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), requestID, nil
+}
+
+// ParseResponse verifies the base64 encoded SAMLResponse form value and returns its assertion.
+// possibleRequestIDs are the IDs of the requests sent by this provider that the response may answer.
+func (sp *ServiceProvider) ParseResponse(samlResponse string, possibleRequestIDs ...string) (*Assertion, error) {
+	data, err := base64.StdEncoding.DecodeString(stripSpaces(samlResponse))
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid response encoding: %w", err)
+	}
+	if len(data) > maxResponseSize {
+		return nil, errors.New("saml: response too large")
+	}
+	response, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if !response.is(namespaceProtocol, "Response") {
+		return nil, errors.New("saml: not a response")
+	}
+	if response.attrValue("Version") != "2.0" {
+		return nil, errors.New("saml: unsupported version")
+	}
+	if destination := response.attrValue("Destination"); len(destination) > 0 && destination != sp.AcsURL {
+		return nil, fmt.Errorf("saml: unexpected destination %s", destination)
+	}
+	inResponseTo := response.attrValue("InResponseTo")
+	if err = sp.checkInResponseTo(inResponseTo, possibleRequestIDs); err != nil {
+		return nil, err
+	}
+	if err = sp.checkIssuer(response, false); err != nil {
+		return nil, err
+	}
+	if err = checkStatus(response); err != nil {
+		return nil, err
+	}
+
+	if len(response.findChildren(namespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("saml: encrypted assertions are not supported")
+	}
+	// an extra assertion next to the signed one is the setup of the signature wrapping attacks
+	assertions := response.findChildren(namespaceAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.New("saml: response must contain exactly one assertion")
+	}
+	assertion := assertions[0]
+
+	// Either the response or the assertion must be signed, and every present signature must be valid.
+	responseErr := verifySignature(response, sp.IdPCertificates)
+	if responseErr != nil && !errors.Is(responseErr, ErrSignatureMissing) {
+		return nil, responseErr
+	}
+	assertionErr := verifySignature(assertion, sp.IdPCertificates)
+	if assertionErr != nil && !errors.Is(assertionErr, ErrSignatureMissing) {
+		return nil, assertionErr
+	}
+	if responseErr != nil && assertionErr != nil {
+		return nil, ErrSignatureMissing
+	}
+	return sp.parseAssertion(assertion, inResponseTo, possibleRequestIDs)
+}
+
+func (sp *ServiceProvider) parseAssertion(assertion *elementNode, inResponseTo string, possibleRequestIDs []string) (
+	*Assertion, error) {
+	if assertion.attrValue("Version") != "2.0" {
+		return nil, errors.New("saml: unsupported assertion version")
+	}
+	if err := sp.checkIssuer(assertion, true); err != nil {
+		return nil, err
+	}
+	now := sp.now()
+	result := &Assertion{ID: assertion.attrValue("ID"), Attributes: map[string][]string{}}
+	if len(result.ID) == 0 {
+		return nil, errors.New("saml: assertion id missing")
+	}
+
+	subject := assertion.findChild(namespaceAssertion, "Subject")
+	if subject == nil {
+		return nil, errors.New("saml: assertion subject missing")
+	}
+	nameID := subject.findChild(namespaceAssertion, "NameID")
+	if nameID == nil || len(strings.TrimSpace(nameID.text())) == 0 {
+		return nil, errors.New("saml: assertion name id missing")
+	}
+	result.NameID = strings.TrimSpace(nameID.text())
+	result.NameIDFormat = nameID.attrValue("Format")
+	if err := sp.checkSubjectConfirmation(subject, inResponseTo, possibleRequestIDs, now); err != nil {
+		return nil, err
+	}
+
+	conditions := assertion.findChild(namespaceAssertion, "Conditions")
+	if conditions == nil {
+		return nil, errors.New("saml: assertion conditions missing")
+	}
+	notOnOrAfter, err := sp.checkValidity(conditions, now)
+	if err != nil {
+		return nil, err
+	}
+	result.NotOnOrAfter = notOnOrAfter
+	if err = sp.checkAudience(conditions); err != nil {
+		return nil, err
+	}
+
+	if authnStatement := assertion.findChild(namespaceAssertion, "AuthnStatement"); authnStatement != nil {
+		result.SessionIndex = authnStatement.attrValue("SessionIndex")
+	}
+	for _, statement := range assertion.findChildren(namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range statement.findChildren(namespaceAssertion, "Attribute") {
+			var values []string
+			for _, value := range attribute.findChildren(namespaceAssertion, "AttributeValue") {
+				values = append(values, strings.TrimSpace(value.text()))
+			}
+			for _, name := range []string{attribute.attrValue("Name"), attribute.attrValue("FriendlyName")} {
+				if len(name) > 0 {
+					result.Attributes[name] = append(result.Attributes[name], values...)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (sp *ServiceProvider) checkInResponseTo(inResponseTo string, possibleRequestIDs []string) error {
+	if len(inResponseTo) == 0 {
+		if sp.AllowIdPInitiated {
+			return nil
+		}
+		return errors.New("saml: unsolicited responses are not allowed")
+	}
+	for _, id := range possibleRequestIDs {
+		if id == inResponseTo {
+			return nil
+		}
+	}
+	return fmt.Errorf("saml: unexpected InResponseTo %s", inResponseTo)
+}
+
+func (sp *ServiceProvider) checkIssuer(el *elementNode, required bool) error {
+	issuer := el.findChild(namespaceAssertion, "Issuer")
+	if issuer == nil {
+		if required {
+			return errors.New("saml: issuer missing")
+		}
+		return nil
+	}
+	if len(sp.IdPEntityID) > 0 && strings.TrimSpace(issuer.text()) != sp.IdPEntityID {
+		return fmt.Errorf("saml: unexpected issuer %s", strings.TrimSpace(issuer.text()))
+	}
+	return nil
+}
+
+func checkStatus(response *elementNode) error {
+	status := response.findChild(namespaceProtocol, "Status")
+	if status == nil {
+		return errors.New("saml: status missing")
+	}
+	code := status.findChild(namespaceProtocol, "StatusCode")
+	if code == nil {
+		return errors.New("saml: status code missing")
+	}
+	if code.attrValue("Value") != statusSuccess {
+		message := ""
+		if statusMessage := status.findChild(namespaceProtocol, "StatusMessage"); statusMessage != nil {
+			message = statusMessage.text()
+		}
+		return fmt.Errorf("saml: login failed with status %s %s", code.attrValue("Value"), message)
+	}
+	return nil
+}
+
+func (sp *ServiceProvider) checkSubjectConfirmation(subject *elementNode, inResponseTo string,
+	possibleRequestIDs []string, now time.Time) error {
+	for _, confirmation := range subject.findChildren(namespaceAssertion, "SubjectConfirmation") {
+		if confirmation.attrValue("Method") != subjectMethodBearer {
+			continue
+		}
+		data := confirmation.findChild(namespaceAssertion, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if recipient := data.attrValue("Recipient"); recipient != sp.AcsURL {
+			continue
+		}
+		if id := data.attrValue("InResponseTo"); id != inResponseTo ||
+			sp.checkInResponseTo(id, possibleRequestIDs) != nil {
+			continue
+		}
+		notOnOrAfter, err := parseTime(data.attrValue("NotOnOrAfter"))
+		if err != nil || !now.Before(notOnOrAfter.Add(sp.clockSkew())) {
+			continue
+		}
+		if notBefore, ok := data.attr("NotBefore"); ok {
+			t, err := parseTime(notBefore)
+			if err != nil || now.Add(sp.clockSkew()).Before(t) {
+				continue
+			}
+		}
+		return nil
+	}
+	return errors.New("saml: no valid bearer subject confirmation")
+}
+
+func (sp *ServiceProvider) checkValidity(conditions *elementNode, now time.Time) (notOnOrAfter time.Time, err error) {
+	if value, ok := conditions.attr("NotBefore"); ok {
+		notBefore, err := parseTime(value)
+		if err != nil {
+			return notOnOrAfter, err
+		}
+		if now.Add(sp.clockSkew()).Before(notBefore) {
+			return notOnOrAfter, errors.New("saml: assertion is not yet valid")
+		}
+	}
+	if value, ok := conditions.attr("NotOnOrAfter"); ok {
+		notOnOrAfter, err = parseTime(value)
+		if err != nil {
+			return notOnOrAfter, err
+		}
+		if !now.Before(notOnOrAfter.Add(sp.clockSkew())) {
+			return notOnOrAfter, errors.New("saml: assertion has expired")
+		}
+	} else {
+		notOnOrAfter = now.Add(time.Hour)
+	}
+	return notOnOrAfter, nil
+}
+
+func (sp *ServiceProvider) checkAudience(conditions *elementNode) error {
+	restrictions := conditions.findChildren(namespaceAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return errors.New("saml: assertion audience missing")
+	}
+	for _, restriction := range restrictions {
+		matched := false
+		for _, audience := range restriction.findChildren(namespaceAssertion, "Audience") {
+			if strings.TrimSpace(audience.text()) == sp.EntityID {
+				matched = true
+			}
+		}
+		if !matched {
+			return errors.New("saml: assertion audience mismatch")
+		}
+	}
+	return nil
+}
+
+func (sp *ServiceProvider) now() time.Time {
+	if sp.Now != nil {
+		return sp.Now()
+	}
+	return time.Now()
+}
+
+func (sp *ServiceProvider) clockSkew() time.Duration {
+	if sp.ClockSkew > 0 {
+		return sp.ClockSkew
+	}
+	return defaultClockSkew
+}
+
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return t, fmt.Errorf("saml: invalid time %q", value)
+	}
+	return t, nil
+}
+
+// newRequestID returns a random ID, which must not start with a digit.
+func newRequestID() (string, error) {
+	buf := make([]byte, requestIDRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "id-" + hex.EncodeToString(buf), nil
+}
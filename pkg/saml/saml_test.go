@@ -0,0 +1,230 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExclusiveCanonicalize(t *testing.T) {
+	doc := `<root xmlns="urn:default" xmlns:a="urn:a" xmlns:unused="urn:unused">` +
+		`<a:child z="1" a:b="2" b="&lt;&quot;&#9;"><empty/>text &amp; &gt;<!-- comment --></a:child>` +
+		`<plain xmlns=""/></root>`
+	root, err := parseDocument([]byte(doc))
+	require.NoError(t, err)
+	child := root.childElements()[0]
+
+	assert.Equal(t,
+		`<a:child xmlns:a="urn:a" b="&lt;&quot;&#x9;" z="1" a:b="2"><empty xmlns="urn:default"></empty>text &amp; &gt;</a:child>`,
+		string(newExclusiveCanonicalizer(false, "").canonicalize(child)))
+	assert.Equal(t,
+		`<a:child xmlns="urn:default" xmlns:a="urn:a" xmlns:unused="urn:unused" b="&lt;&quot;&#x9;" z="1" a:b="2"><empty></empty>text &amp; &gt;<!-- comment --></a:child>`,
+		string(newExclusiveCanonicalizer(true, "#default unused").canonicalize(child)))
+	assert.Equal(t,
+		`<root xmlns="urn:default"><a:child xmlns:a="urn:a" b="&lt;&quot;&#x9;" z="1" a:b="2"><empty></empty>text &amp; &gt;</a:child><plain xmlns=""></plain></root>`,
+		string(newExclusiveCanonicalizer(false, "").canonicalize(root)))
+}
+
+func TestParseDocumentRejectsDirectives(t *testing.T) {
+	_, err := parseDocument([]byte(`<!DOCTYPE foo [<!ENTITY x "y">]><foo>&x;</foo>`))
+	assert.Error(t, err)
+	_, err = parseDocument([]byte(`<foo:bar/>`))
+	assert.Error(t, err)
+}
+
+const (
+	testEntityID = "https://answer.example.com/answer/api/v1/saml/metadata"
+	testAcsURL   = "https://answer.example.com/answer/api/v1/saml/acs"
+	testIdP      = "http://www.okta.com/exk1"
+)
+
+func newTestCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, certificate
+}
+
+type testResponse struct {
+	inResponseTo string
+	audience     string
+	notOnOrAfter time.Time
+	nameID       string
+}
+
+func (r testResponse) assertion() string {
+	return `<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="assertion-1" Version="2.0" IssueInstant="2024-01-01T00:00:00Z">` +
+		`<saml:Issuer>` + testIdP + `</saml:Issuer>` +
+		`<saml:Subject><saml:NameID Format="` + NameIDFormatEmailAddress + `">` + r.nameID + `</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">` +
+		`<saml:SubjectConfirmationData InResponseTo="` + r.inResponseTo + `" NotOnOrAfter="` + r.notOnOrAfter.Format(time.RFC3339) +
+		`" Recipient="` + testAcsURL + `"/></saml:SubjectConfirmation></saml:Subject>` +
+		`<saml:Conditions NotBefore="2024-01-01T00:00:00Z" NotOnOrAfter="` + r.notOnOrAfter.Format(time.RFC3339) + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + r.audience + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AuthnStatement SessionIndex="session-1"/>` +
+		`<saml:AttributeStatement><saml:Attribute Name="email"><saml:AttributeValue>bob@example.com</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="urn:oid:2.16.840.1.113730.3.1.241" FriendlyName="displayName"><saml:AttributeValue>Bob</saml:AttributeValue></saml:Attribute>` +
+		`</saml:AttributeStatement></saml:Assertion>`
+}
+
+func (r testResponse) wrap(assertion string) string {
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="response-1" Version="2.0" IssueInstant="2024-01-01T00:00:00Z"` +
+		` Destination="` + testAcsURL + `" InResponseTo="` + r.inResponseTo + `">` +
+		`<saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">` + testIdP + `</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		assertion + `</samlp:Response>`
+}
+
+// signAssertion inserts an enveloped signature after the assertion issuer
+func signAssertion(t *testing.T, key *rsa.PrivateKey, assertion string) string {
+	el, err := parseDocument([]byte(assertion))
+	require.NoError(t, err)
+	digest := sha256.Sum256(newExclusiveCanonicalizer(false, "").canonicalize(el))
+
+	signature := `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>` +
+		`<ds:Reference URI="#assertion-1"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"/>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/></ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue>` +
+		`</ds:Reference></ds:SignedInfo><ds:SignatureValue>SIGNATURE</ds:SignatureValue></ds:Signature>`
+	issuerEnd := strings.Index(assertion, "</saml:Issuer>") + len("</saml:Issuer>")
+	signed := assertion[:issuerEnd] + signature + assertion[issuerEnd:]
+
+	el, err = parseDocument([]byte(signed))
+	require.NoError(t, err)
+	signedInfo := el.findChild(namespaceDSig, "Signature").findChild(namespaceDSig, "SignedInfo")
+	hashed := sha256.Sum256(newExclusiveCanonicalizer(false, "").canonicalize(signedInfo))
+	value, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+	return strings.Replace(signed, "SIGNATURE", base64.StdEncoding.EncodeToString(value), 1)
+}
+
+func TestParseResponse(t *testing.T) {
+	key, certificate := newTestCertificate(t)
+	_, otherCertificate := newTestCertificate(t)
+	now := time.Now()
+	sp := &ServiceProvider{
+		EntityID:        testEntityID,
+		AcsURL:          testAcsURL,
+		IdPEntityID:     testIdP,
+		IdPCertificates: []*x509.Certificate{certificate},
+	}
+	valid := testResponse{inResponseTo: "id-1", audience: testEntityID, notOnOrAfter: now.Add(5 * time.Minute), nameID: "bob@example.com"}
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	assertion, err := sp.ParseResponse(encode(valid.wrap(signAssertion(t, key, valid.assertion()))), "id-1")
+	require.NoError(t, err)
+	assert.Equal(t, "assertion-1", assertion.ID)
+	assert.Equal(t, "bob@example.com", assertion.NameID)
+	assert.Equal(t, "session-1", assertion.SessionIndex)
+	assert.Equal(t, "bob@example.com", assertion.GetAttributeValue("email"))
+	assert.Equal(t, "Bob", assertion.GetAttributeValue("displayName"))
+
+	tests := []struct {
+		name     string
+		response string
+		ids      []string
+	}{
+		{name: "unsigned", response: valid.wrap(valid.assertion()), ids: []string{"id-1"}},
+		{name: "tampered", ids: []string{"id-1"}, response: valid.wrap(strings.Replace(
+			signAssertion(t, key, valid.assertion()), "bob@example.com</saml:NameID>", "admin@example.com</saml:NameID>", 1))},
+		{name: "unknown request", response: valid.wrap(signAssertion(t, key, valid.assertion())), ids: []string{"id-2"}},
+		{name: "unsolicited", response: valid.wrap(signAssertion(t, key, valid.assertion()))},
+		{name: "wrong audience", ids: []string{"id-1"}, response: func() string {
+			r := valid
+			r.audience = "https://other.example.com"
+			return r.wrap(signAssertion(t, key, r.assertion()))
+		}()},
+		{name: "expired", ids: []string{"id-1"}, response: func() string {
+			r := valid
+			r.notOnOrAfter = now.Add(-time.Hour)
+			return r.wrap(signAssertion(t, key, r.assertion()))
+		}()},
+		{name: "wrapped assertion", ids: []string{"id-1"}, response: func() string {
+			evil := valid
+			evil.nameID = "admin@example.com"
+			return valid.wrap(evil.assertion() + signAssertion(t, key, valid.assertion()))
+		}()},
+		{name: "unsigned assertion after the signed one", ids: []string{"id-1"}, response: func() string {
+			evil := valid
+			evil.nameID = "admin@example.com"
+			return valid.wrap(signAssertion(t, key, valid.assertion()) + evil.assertion())
+		}()},
+		{name: "two signed assertions", ids: []string{"id-1"}, response: valid.wrap(
+			signAssertion(t, key, valid.assertion()) + signAssertion(t, key, valid.assertion()))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sp.ParseResponse(encode(tt.response), tt.ids...)
+			assert.Error(t, err)
+		})
+	}
+
+	otherSP := *sp
+	otherSP.IdPCertificates = []*x509.Certificate{otherCertificate}
+	_, err = otherSP.ParseResponse(encode(valid.wrap(signAssertion(t, key, valid.assertion()))), "id-1")
+	assert.ErrorIs(t, err, ErrSignatureInvalid)
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	sp := &ServiceProvider{EntityID: testEntityID, AcsURL: testAcsURL, IdPSSOURL: "https://idp.example.com/sso?app=1"}
+	redirectURL, requestID, err := sp.AuthnRequestURL("state")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(requestID, "id-"))
+	u, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	assert.Equal(t, "1", u.Query().Get("app"))
+	assert.Equal(t, "state", u.Query().Get("RelayState"))
+	assert.NotEmpty(t, u.Query().Get("SAMLRequest"))
+
+	metadata, err := sp.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), fmt.Sprintf(`entityID="%s"`, testEntityID))
+	assert.Contains(t, string(metadata), fmt.Sprintf(`Location="%s"`, testAcsURL))
+}
@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// node is a minimal xml tree keeping the original prefixes, which is needed for canonicalization.
+type node interface{}
+
+type textNode string
+
+type commentNode string
+
+type procInstNode struct {
+	target string
+	inst   string
+}
+
+type attr struct {
+	prefix string
+	local  string
+	value  string
+}
+
+type elementNode struct {
+	parent   *elementNode
+	prefix   string
+	local    string
+	attrs    []attr
+	nsDecls  map[string]string
+	children []node
+}
+
+// parseDocument parses the xml document and returns the root element.
+// DTDs are rejected since no SAML message needs them.
+func parseDocument(data []byte) (*elementNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *elementNode
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := &elementNode{parent: current, prefix: t.Name.Space, local: t.Name.Local, nsDecls: map[string]string{}}
+			for _, a := range t.Attr {
+				switch {
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					el.nsDecls[""] = a.Value
+				case a.Name.Space == "xmlns":
+					el.nsDecls[a.Name.Local] = a.Value
+				default:
+					el.attrs = append(el.attrs, attr{prefix: a.Name.Space, local: a.Name.Local, value: a.Value})
+				}
+			}
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("saml: multiple root elements")
+				}
+				root = el
+			} else {
+				current.children = append(current.children, el)
+			}
+			current = el
+		case xml.EndElement:
+			if current == nil || current.prefix != t.Name.Space || current.local != t.Name.Local {
+				return nil, fmt.Errorf("saml: unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, textNode(t))
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("saml: text outside of root element")
+			}
+		case xml.Comment:
+			if current != nil {
+				current.children = append(current.children, commentNode(t))
+			}
+		case xml.ProcInst:
+			if current != nil {
+				current.children = append(current.children, procInstNode{target: t.Target, inst: string(t.Inst)})
+			}
+		case xml.Directive:
+			return nil, errors.New("saml: xml directives are not allowed")
+		}
+	}
+	if root == nil {
+		return nil, errors.New("saml: empty document")
+	}
+	if current != nil {
+		return nil, errors.New("saml: unexpected end of document")
+	}
+	if err := root.checkNamespaces(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func (e *elementNode) checkNamespaces() error {
+	if _, ok := e.lookupNamespace(e.prefix); !ok && e.prefix != "" {
+		return fmt.Errorf("saml: undeclared namespace prefix %q", e.prefix)
+	}
+	for _, a := range e.attrs {
+		if _, ok := e.lookupNamespace(a.prefix); !ok && a.prefix != "" {
+			return fmt.Errorf("saml: undeclared namespace prefix %q", a.prefix)
+		}
+	}
+	for _, child := range e.childElements() {
+		if err := child.checkNamespaces(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupNamespace returns the namespace uri bound to prefix in the scope of the element.
+func (e *elementNode) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for el := e; el != nil; el = el.parent {
+		if uri, ok := el.nsDecls[prefix]; ok {
+			return uri, true
+		}
+	}
+	return "", prefix == ""
+}
+
+func (e *elementNode) namespace() string {
+	uri, _ := e.lookupNamespace(e.prefix)
+	return uri
+}
+
+func (e *elementNode) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+func (e *elementNode) attr(local string) (string, bool) {
+	for _, a := range e.attrs {
+		if a.prefix == "" && a.local == local {
+			return a.value, true
+		}
+	}
+	return "", false
+}
+
+func (e *elementNode) attrValue(local string) string {
+	value, _ := e.attr(local)
+	return value
+}
+
+func (e *elementNode) childElements() (elements []*elementNode) {
+	for _, child := range e.children {
+		if el, ok := child.(*elementNode); ok {
+			elements = append(elements, el)
+		}
+	}
+	return elements
+}
+
+func (e *elementNode) findChildren(namespace, local string) (elements []*elementNode) {
+	for _, child := range e.childElements() {
+		if child.is(namespace, local) {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+// findChild returns the only child with the name, or nil if there is not exactly one.
+func (e *elementNode) findChild(namespace, local string) *elementNode {
+	children := e.findChildren(namespace, local)
+	if len(children) != 1 {
+		return nil
+	}
+	return children[0]
+}
+
+func (e *elementNode) text() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		if t, ok := child.(textNode); ok {
+			b.WriteString(string(t))
+		}
+	}
+	return b.String()
+}
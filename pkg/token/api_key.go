@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyPrefix the prefix of the api keys, it distinguishes the api keys from the login tokens
+const APIKeyPrefix = "sk_"
+
+// GenerateAPIKey generate a random api key, it is only shown to the user once
+func GenerateAPIKey() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return APIKeyPrefix + hex.EncodeToString(b)
+}
+
+// IsAPIKey the token is an api key instead of a login token
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// HashAPIKey hash the api key, only the hash is stored
+func HashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// MaskAPIKey hide the middle part of the api key, the masked key helps the user to recognize the key
+func MaskAPIKey(apiKey string) string {
+	// If the access key is too short, do not show any part of it
+	if len(apiKey) < 10 {
+		return strings.Repeat("*", len(apiKey))
+	}
+	return apiKey[:7] + strings.Repeat("*", 8) + apiKey[len(apiKey)-4:]
+}
@@ -37,6 +37,27 @@ type Cache interface {
 	Flush(ctx context.Context) (err error)
 }
 
+// CacheAtomic is an optional interface of the cache plugin.
+// The operations are done atomically in the cache, so that they are consistent when several instances share the cache.
+type CacheAtomic interface {
+	// SetStringIfAbsent set the value of the key only if the key does not exist, set is false if it exists
+	SetStringIfAbsent(ctx context.Context, key, value string, ttl time.Duration) (set bool, err error)
+	// SetInt64IfAbsent set the value of the key only if the key does not exist, set is false if it exists.
+	// The value can be increased and decreased after it is set.
+	SetInt64IfAbsent(ctx context.Context, key string, value int64, ttl time.Duration) (set bool, err error)
+}
+
+// CacheSet is an optional interface of the cache plugin.
+// The members of the set are added and removed atomically in the cache, the set of the key never expires.
+type CacheSet interface {
+	// SetAdd add the members into the set of the key, the set is created if it does not exist
+	SetAdd(ctx context.Context, key string, members ...string) (err error)
+	// SetRemove remove the members from the set of the key
+	SetRemove(ctx context.Context, key string, members ...string) (err error)
+	// SetMembers get all the members of the set of the key, it is empty if the set does not exist
+	SetMembers(ctx context.Context, key string) (members []string, err error)
+}
+
 var (
 	// CallCache is a function that calls all registered cache
 	CallCache,
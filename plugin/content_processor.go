@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+// ContentProcessor processes the question, the answer and the comment after they are parsed and before they
+// are saved, both for the new posts and the edits. The processors are called in the order of registration,
+// each one gets the content processed by the previous ones.
+type ContentProcessor interface {
+	Base
+	// ProcessContent transforms the content or annotates the metadata of it in place.
+	// Return ContentRejectedError to reject the submission, the other errors are logged and the
+	// changes of this processor are discarded.
+	ProcessContent(content *ProcessingContent) (err error)
+}
+
+// ProcessingContent the content that is being submitted
+type ProcessingContent struct {
+	// The type of the content, e.g. question, answer, comment
+	ObjectType string
+	// The id of the edited content, empty for the new content
+	ObjectID string
+	// The id of the user who submits the content
+	UserID string
+	// The title of the content, only available for the question, the changes of it are ignored
+	Title string
+	// The markdown of the content
+	OriginalText string
+	// The html parsed from the markdown, it is displayed to the users
+	ParsedText string
+	// The tags of the content, only available for the question, the changes of them are ignored
+	Tags []string
+	// Metadata the processors annotate the content with, it is saved with the content
+	Metadata map[string]string
+}
+
+// ContentRejectedError rejects the submission of the content, the reason is shown to the user
+type ContentRejectedError struct {
+	Reason string
+}
+
+func (e *ContentRejectedError) Error() string {
+	return e.Reason
+}
+
+var (
+	// CallContentProcessor is a function that calls all registered content processors
+	CallContentProcessor,
+	registerContentProcessor = MakePlugin[ContentProcessor](false)
+)
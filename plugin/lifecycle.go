@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+// Lifecycle is implemented by the plugins that need to prepare or release their resources, such as the jobs and the
+// hooks, when they are enabled or disabled. The plugins can be enabled and disabled at runtime without restart.
+type Lifecycle interface {
+	Base
+	// Init is called when the plugin is enabled, and on boot if it is enabled. The config of the plugin is
+	// received before it is called. The plugin is kept disabled if it returns an error.
+	Init() error
+	// Shutdown is called when the plugin is disabled, the plugin no longer receives the events after that.
+	Shutdown() error
+}
+
+var (
+	// CallLifecycle is a function that calls all registered lifecycle plugins whether they are enabled or not
+	CallLifecycle,
+	registerLifecycle = MakePlugin[Lifecycle](true)
+)
+
+// InitPlugin calls the Init hook of the plugin if it implements Lifecycle
+func InitPlugin(slugName string) error {
+	return CallLifecycle(func(p Lifecycle) error {
+		if p.Info().SlugName != slugName {
+			return nil
+		}
+		return p.Init()
+	})
+}
+
+// ShutdownPlugin calls the Shutdown hook of the plugin if it implements Lifecycle
+func ShutdownPlugin(slugName string) error {
+	return CallLifecycle(func(p Lifecycle) error {
+		if p.Info().SlugName != slugName {
+			return nil
+		}
+		return p.Shutdown()
+	})
+}
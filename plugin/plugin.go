@@ -134,6 +134,18 @@ func Register(p Base) {
 	if _, ok := p.(VectorSearch); ok {
 		registerVectorSearch(p.(VectorSearch))
 	}
+
+	if _, ok := p.(ContentProcessor); ok {
+		registerContentProcessor(p.(ContentProcessor))
+	}
+
+	if _, ok := p.(ReputationRule); ok {
+		registerReputationRule(p.(ReputationRule))
+	}
+
+	if _, ok := p.(Lifecycle); ok {
+		registerLifecycle(p.(Lifecycle))
+	}
 }
 
 type Stack[T Base] struct {
@@ -202,6 +214,8 @@ func (m *statusManager) Enable(name string, enabled bool) {
 }
 
 func (m *statusManager) IsEnabled(name string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	if status, ok := m.status[name]; ok {
 		return status
 	}
@@ -210,11 +224,15 @@ func (m *statusManager) IsEnabled(name string) bool {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (m *statusManager) MarshalJSON() ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	return json.Marshal(m.status)
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (m *statusManager) UnmarshalJSON(data []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 	return json.Unmarshal(data, &m.status)
 }
 
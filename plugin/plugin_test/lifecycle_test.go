@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apache/answer/plugin"
+)
+
+type testLifecyclePlugin struct {
+	initErr  error
+	running  bool
+	shutdown int
+}
+
+func (p *testLifecyclePlugin) Info() plugin.Info {
+	return plugin.Info{SlugName: "test_lifecycle_plugin"}
+}
+
+func (p *testLifecyclePlugin) Init() error {
+	if p.initErr != nil {
+		return p.initErr
+	}
+	p.running = true
+	return nil
+}
+
+func (p *testLifecyclePlugin) Shutdown() error {
+	p.running = false
+	p.shutdown++
+	return nil
+}
+
+func TestLifecycle(t *testing.T) {
+	p := &testLifecyclePlugin{}
+	plugin.Register(p)
+
+	if err := plugin.InitPlugin("test_lifecycle_plugin"); err != nil || !p.running {
+		t.Fatalf("expected the plugin to be running, err: %v", err)
+	}
+	if err := plugin.InitPlugin("not_exist_plugin"); err != nil {
+		t.Fatalf("expected no error for the unknown plugin, got %v", err)
+	}
+	if err := plugin.ShutdownPlugin("test_lifecycle_plugin"); err != nil || p.running || p.shutdown != 1 {
+		t.Fatalf("expected the plugin to be shut down, err: %v", err)
+	}
+
+	p.initErr = errors.New("missing config")
+	if err := plugin.InitPlugin("test_lifecycle_plugin"); err == nil {
+		t.Fatal("expected the init error to be returned")
+	}
+}
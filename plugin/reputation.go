@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+// ReputationRule adjusts the reputation changes of the events before they are applied. The rules are called
+// in the order of registration, each one gets the delta returned by the previous ones.
+type ReputationRule interface {
+	Base
+	// AdjustReputation returns the delta that is applied to the reputation of the target user, 0 vetoes the change.
+	// It is called inside the transaction of the event, so it should return quickly.
+	AdjustReputation(event *ReputationEvent) (delta int)
+}
+
+type ReputationEventType string
+
+const (
+	ReputationEventVote   ReputationEventType = "vote"
+	ReputationEventAccept ReputationEventType = "accept"
+	ReputationEventEdit   ReputationEventType = "edit"
+	// ReputationEventFlag the flag of the content is accepted by the moderators, the core proposes no change
+	// for it, the rules decide the penalty of the author
+	ReputationEventFlag ReputationEventType = "flag"
+)
+
+// ReputationEvent the event that changes the reputation of the target user
+type ReputationEvent struct {
+	EventType ReputationEventType
+	// The key of the activity, e.g. question.voted_up, answer.accepted
+	ActivityType string
+	// The user who performs the action, e.g. the voter
+	ActorUserID string
+	// The user whose reputation is changed, e.g. the author of the voted answer
+	TargetUserID string
+	// The id of the question, the answer or the comment
+	ObjectID string
+	// The proposed reputation change
+	Delta int
+}
+
+var (
+	// CallReputationRule is a function that calls all registered reputation rules
+	CallReputationRule,
+	registerReputationRule = MakePlugin[ReputationRule](false)
+)
@@ -76,6 +76,10 @@ type SearchBasicCond struct {
 	ViewAmount int
 	// greater than or equal to the number of answers. Only support search question.
 	AnswerAmount int
+
+	// Created time range in unix seconds, zero means unlimited.
+	CreatedAfter  int64
+	CreatedBefore int64
 }
 
 type SearchAcceptedCond int
@@ -111,6 +115,18 @@ type Search interface {
 	DeleteContent(ctx context.Context, objectID string) (err error)
 }
 
+// SearchIndexer is an optional interface of the search plugin.
+// The index is dropped and created again before it is rebuilt from the database by the reindex command.
+type SearchIndexer interface {
+	ResetIndex(ctx context.Context) (err error)
+}
+
+// SearchHealthChecker is an optional interface of the search plugin.
+// It is called by the readiness probe, an error means the search backend is not ready.
+type SearchHealthChecker interface {
+	CheckHealth(ctx context.Context) (err error)
+}
+
 type SearchDesc struct {
 	// A svg icon it wil be display in search result page. optional
 	Icon string `json:"icon"`
@@ -65,6 +65,14 @@ type UploadFileCondition struct {
 	AuthorizedImageExtensions []string
 	// AuthorizedAttachmentExtensions is the list of authorized attachment extensions
 	AuthorizedAttachmentExtensions []string
+	// MaxAvatarSize is the maximum size of the avatar in MB, the MaxImageSize is used if it is zero
+	MaxAvatarSize int
+	// AuthorizedAvatarMimeTypes is the list of authorized avatar mime types, all types are allowed if it is empty
+	AuthorizedAvatarMimeTypes []string
+	// AuthorizedImageMimeTypes is the list of authorized image mime types, all types are allowed if it is empty
+	AuthorizedImageMimeTypes []string
+	// AuthorizedAttachmentMimeTypes is the list of authorized attachment mime types, all types are allowed if it is empty
+	AuthorizedAttachmentMimeTypes []string
 }
 
 type UploadFileResponse struct {
@@ -142,8 +142,11 @@ export const ADMIN_NAV_MENUS = [
       { name: 'security' },
       { name: 'files' },
       { name: 'login' },
+      { name: 'saml' },
       { name: 'seo' },
       { name: 'smtp' },
+      { name: 'storage' },
+      { name: 'webhook' },
       { name: 'apikeys' },
     ],
   },
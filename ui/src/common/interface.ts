@@ -170,9 +170,28 @@ export interface UserInfoRes extends UserInfoBase {
   language: string;
   e_mail?: string;
   have_password: boolean;
+  /** the password login is pending until the two-factor code is verified */
+  two_factor_required?: boolean;
+  two_factor_token?: string;
   [prop: string]: any;
 }
 
+export interface TwoFactorStatus {
+  enabled: boolean;
+  recovery_codes_remaining: number;
+}
+
+export interface TwoFactorEnrollment {
+  secret: string;
+  otpauth_url: string;
+  recovery_codes: string[];
+}
+
+export interface TwoFactorLoginReq {
+  token: string;
+  code: string;
+}
+
 export type UploadType = 'post' | 'avatar' | 'branding' | 'post_attachment';
 export interface UploadReq {
   file: FormData;
@@ -302,7 +321,8 @@ export type QuestionOrderBy =
   | 'hot'
   | 'score'
   | 'unanswered'
-  | 'frequent';
+  | 'frequent'
+  | 'trending';
 
 export interface QueryQuestionsReq extends Paging {
   order: QuestionOrderBy;
@@ -447,9 +467,15 @@ export interface AdminSettingsLegal {
 export interface AdminSettingsWrite {
   max_image_size?: number;
   max_attachment_size?: number;
+  max_avatar_size?: number;
   max_image_megapixel?: number;
   authorized_image_extensions?: string[];
   authorized_attachment_extensions?: string[];
+  authorized_avatar_mime_types?: string[];
+  authorized_image_mime_types?: string[];
+  authorized_attachment_mime_types?: string[];
+  thumbnail_size?: number;
+  web_image_max_width?: number;
 }
 
 export interface AdminSettingsSeo {
@@ -460,6 +486,10 @@ export interface AdminSettingsSeo {
    * 2: no title
    */
   permalink: number;
+  /**
+   * 0: use the default limit
+   */
+  feed_item_limit?: number;
 }
 
 export type themeConfig = {
@@ -706,6 +736,7 @@ export interface UserOauthConnectorItem {
 export interface NotificationConfigItem {
   enable: boolean;
   key: string;
+  frequency?: 'immediate' | 'daily' | 'weekly';
 }
 export interface NotificationConfig {
   all_new_question: NotificationConfigItem;
@@ -886,6 +917,7 @@ export interface AdminQuestionSetting {
   min_tags: number;
   min_content: number;
   restrict_answer: boolean;
+  trending_half_life?: number;
 }
 
 export interface AdminTagsSetting {
@@ -893,3 +925,16 @@ export interface AdminTagsSetting {
   required_tag: boolean;
   reserved_tags: Tag[];
 }
+
+export interface QuestionBounty {
+  id: string;
+  question_id: string;
+  amount: number;
+  status: 'active' | 'awarded' | 'expired';
+  created_at: number;
+  expires_at: number;
+  awarded_answer_id: string;
+  awarded_amount: number;
+  user_info: UserInfoBase;
+  awarded_user_info?: UserInfoBase;
+}
@@ -21,7 +21,7 @@ const pattern = {
   email:
     /^(([^<>()[\]\\.,;:\s@"]+(\.[^<>()[\]\\.,;:\s@"]+)*)|(".+"))@((\[[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}])|(([a-zA-Z\-0-9\u00A0-\uD7FF\uF900-\uFDCF\uFDF0-\uFFEF]+\.)+[a-zA-Z\u00A0-\uD7FF\uF900-\uFDCF\uFDF0-\uFFEF]{2,}))$/,
   search:
-    /(\[.*\])|(is:answer)|(is:question)|(score:\d*)|(user:\S*)|(answers:\d*)/g,
+    /(\[.*\])|(tag:\S*)|(is:answered)|(is:answer)|(is:question)|(score:[>=]*-?\d*)|(user:\S*)|(answers:\d*)|(created:\S*)/g,
   uaWeChat: /micromessenger/i,
   uaWeCom: /wxwork/i,
   uaDingTalk: /dingtalk/i,
@@ -23,6 +23,8 @@ import { Modal } from 'react-bootstrap';
 import './index.css';
 import classnames from 'classnames';
 
+import { originalImageSrc } from '@/utils';
+
 const Index: FC<{
   children: ReactNode;
   className?: classnames.Argument;
@@ -60,7 +62,7 @@ const Index: FC<{
     }
     const src = img.currentSrc || img.src;
     if (src && checkIfInLink(img) === false) {
-      setImgSrc(src);
+      setImgSrc(originalImageSrc(src));
       setVisible(true);
     }
   };
@@ -43,6 +43,7 @@ import { LIST_VIEW_STORAGE_KEY } from '@/common/constants';
 export const QUESTION_ORDER_KEYS: Type.QuestionOrderBy[] = [
   'newest',
   'active',
+  'trending',
   'unanswered',
   'recommend',
   'frequent',
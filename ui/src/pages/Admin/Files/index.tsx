@@ -38,11 +38,26 @@ const initFormData = {
     errorMsg: '',
     isInvalid: false,
   },
+  max_avatar_size: {
+    value: 0,
+    errorMsg: '',
+    isInvalid: false,
+  },
   max_image_megapixel: {
     value: 0,
     errorMsg: '',
     isInvalid: false,
   },
+  thumbnail_size: {
+    value: 0,
+    errorMsg: '',
+    isInvalid: false,
+  },
+  web_image_max_width: {
+    value: 0,
+    errorMsg: '',
+    isInvalid: false,
+  },
   authorized_image_extensions: {
     value: '',
     errorMsg: '',
@@ -53,6 +68,28 @@ const initFormData = {
     errorMsg: '',
     isInvalid: false,
   },
+  authorized_avatar_mime_types: {
+    value: '',
+    errorMsg: '',
+    isInvalid: false,
+  },
+  authorized_image_mime_types: {
+    value: '',
+    errorMsg: '',
+    isInvalid: false,
+  },
+  authorized_attachment_mime_types: {
+    value: '',
+    errorMsg: '',
+    isInvalid: false,
+  },
+};
+
+const splitMimeTypes = (value: string) => {
+  return value
+    ?.split(',')
+    .map((item) => item.trim().toLowerCase())
+    .filter((item) => item.length > 0);
 };
 
 const Index: FC = () => {
@@ -77,7 +114,10 @@ const Index: FC = () => {
     const reqParams: Type.AdminSettingsWrite = {
       max_image_size: Number(formData.max_image_size.value),
       max_attachment_size: Number(formData.max_attachment_size.value),
+      max_avatar_size: Number(formData.max_avatar_size.value),
       max_image_megapixel: Number(formData.max_image_megapixel.value),
+      thumbnail_size: Number(formData.thumbnail_size.value),
+      web_image_max_width: Number(formData.web_image_max_width.value),
       authorized_image_extensions:
         formData.authorized_image_extensions.value?.length > 0
           ? formData.authorized_image_extensions.value
@@ -90,6 +130,15 @@ const Index: FC = () => {
               .split(',')
               ?.map((item) => item.trim().toLowerCase())
           : [],
+      authorized_avatar_mime_types: splitMimeTypes(
+        formData.authorized_avatar_mime_types.value,
+      ),
+      authorized_image_mime_types: splitMimeTypes(
+        formData.authorized_image_mime_types.value,
+      ),
+      authorized_attachment_mime_types: splitMimeTypes(
+        formData.authorized_attachment_mime_types.value,
+      ),
     };
     updateAdminFilesSetting(reqParams)
       .then(() => {
@@ -113,11 +162,20 @@ const Index: FC = () => {
     getAdminFilesSetting().then((res) => {
       formData.max_image_size.value = res.max_image_size;
       formData.max_attachment_size.value = res.max_attachment_size;
+      formData.max_avatar_size.value = res.max_avatar_size;
       formData.max_image_megapixel.value = res.max_image_megapixel;
+      formData.thumbnail_size.value = res.thumbnail_size;
+      formData.web_image_max_width.value = res.web_image_max_width;
       formData.authorized_image_extensions.value =
         res.authorized_image_extensions?.join(', ').toLowerCase();
       formData.authorized_attachment_extensions.value =
         res.authorized_attachment_extensions?.join(', ').toLowerCase();
+      formData.authorized_avatar_mime_types.value =
+        res.authorized_avatar_mime_types?.join(', ') || '';
+      formData.authorized_image_mime_types.value =
+        res.authorized_image_mime_types?.join(', ') || '';
+      formData.authorized_attachment_mime_types.value =
+        res.authorized_attachment_mime_types?.join(', ') || '';
       setFormData({ ...formData });
     });
   };
@@ -179,6 +237,30 @@ const Index: FC = () => {
             </Form.Control.Feedback>
           </Form.Group>
 
+          <Form.Group className="mb-3" controlId="max_avatar_size">
+            <Form.Label>{t('avatar_size.label')}</Form.Label>
+            <Form.Control
+              type="number"
+              inputMode="numeric"
+              min={0}
+              value={formData.max_avatar_size.value}
+              isInvalid={formData.max_avatar_size.isInvalid}
+              onChange={(evt) => {
+                handleValueChange({
+                  max_avatar_size: {
+                    value: evt.target.value,
+                    errorMsg: '',
+                    isInvalid: false,
+                  },
+                });
+              }}
+            />
+            <Form.Text>{t('avatar_size.text')}</Form.Text>
+            <Form.Control.Feedback type="invalid">
+              {formData.max_avatar_size.errorMsg}
+            </Form.Control.Feedback>
+          </Form.Group>
+
           <Form.Group className="mb-3" controlId="max_image_megapixel">
             <Form.Label>{t('image_megapixels.label')}</Form.Label>
             <Form.Control
@@ -203,6 +285,54 @@ const Index: FC = () => {
             </Form.Control.Feedback>
           </Form.Group>
 
+          <Form.Group className="mb-3" controlId="thumbnail_size">
+            <Form.Label>{t('thumbnail_size.label')}</Form.Label>
+            <Form.Control
+              type="number"
+              inputMode="numeric"
+              min={0}
+              value={formData.thumbnail_size.value}
+              isInvalid={formData.thumbnail_size.isInvalid}
+              onChange={(evt) => {
+                handleValueChange({
+                  thumbnail_size: {
+                    value: evt.target.value,
+                    errorMsg: '',
+                    isInvalid: false,
+                  },
+                });
+              }}
+            />
+            <Form.Text>{t('thumbnail_size.text')}</Form.Text>
+            <Form.Control.Feedback type="invalid">
+              {formData.thumbnail_size.errorMsg}
+            </Form.Control.Feedback>
+          </Form.Group>
+
+          <Form.Group className="mb-3" controlId="web_image_max_width">
+            <Form.Label>{t('web_image_max_width.label')}</Form.Label>
+            <Form.Control
+              type="number"
+              inputMode="numeric"
+              min={0}
+              value={formData.web_image_max_width.value}
+              isInvalid={formData.web_image_max_width.isInvalid}
+              onChange={(evt) => {
+                handleValueChange({
+                  web_image_max_width: {
+                    value: evt.target.value,
+                    errorMsg: '',
+                    isInvalid: false,
+                  },
+                });
+              }}
+            />
+            <Form.Text>{t('web_image_max_width.text')}</Form.Text>
+            <Form.Control.Feedback type="invalid">
+              {formData.web_image_max_width.errorMsg}
+            </Form.Control.Feedback>
+          </Form.Group>
+
           <Form.Group className="mb-3" controlId="authorized_image_extensions">
             <Form.Label>{t('image_extensions.label')}</Form.Label>
             <Form.Control
@@ -249,6 +379,72 @@ const Index: FC = () => {
             </Form.Control.Feedback>
           </Form.Group>
 
+          <Form.Group className="mb-3" controlId="authorized_avatar_mime_types">
+            <Form.Label>{t('avatar_mime_types.label')}</Form.Label>
+            <Form.Control
+              type="text"
+              value={formData.authorized_avatar_mime_types.value}
+              isInvalid={formData.authorized_avatar_mime_types.isInvalid}
+              onChange={(evt) => {
+                handleValueChange({
+                  authorized_avatar_mime_types: {
+                    value: evt.target.value.toLowerCase(),
+                    errorMsg: '',
+                    isInvalid: false,
+                  },
+                });
+              }}
+            />
+            <Form.Text>{t('avatar_mime_types.text')}</Form.Text>
+            <Form.Control.Feedback type="invalid">
+              {formData.authorized_avatar_mime_types.errorMsg}
+            </Form.Control.Feedback>
+          </Form.Group>
+
+          <Form.Group className="mb-3" controlId="authorized_image_mime_types">
+            <Form.Label>{t('image_mime_types.label')}</Form.Label>
+            <Form.Control
+              type="text"
+              value={formData.authorized_image_mime_types.value}
+              isInvalid={formData.authorized_image_mime_types.isInvalid}
+              onChange={(evt) => {
+                handleValueChange({
+                  authorized_image_mime_types: {
+                    value: evt.target.value.toLowerCase(),
+                    errorMsg: '',
+                    isInvalid: false,
+                  },
+                });
+              }}
+            />
+            <Form.Text>{t('image_mime_types.text')}</Form.Text>
+            <Form.Control.Feedback type="invalid">
+              {formData.authorized_image_mime_types.errorMsg}
+            </Form.Control.Feedback>
+          </Form.Group>
+
+          <Form.Group className="mb-3" controlId="authorized_attachment_mime_types">
+            <Form.Label>{t('attachment_mime_types.label')}</Form.Label>
+            <Form.Control
+              type="text"
+              value={formData.authorized_attachment_mime_types.value}
+              isInvalid={formData.authorized_attachment_mime_types.isInvalid}
+              onChange={(evt) => {
+                handleValueChange({
+                  authorized_attachment_mime_types: {
+                    value: evt.target.value.toLowerCase(),
+                    errorMsg: '',
+                    isInvalid: false,
+                  },
+                });
+              }}
+            />
+            <Form.Text>{t('attachment_mime_types.text')}</Form.Text>
+            <Form.Control.Feedback type="invalid">
+              {formData.authorized_attachment_mime_types.errorMsg}
+            </Form.Control.Feedback>
+          </Form.Group>
+
           <Form.Group className="mb-3">
             <Button type="submit">{t('save', { keyPrefix: 'btns' })}</Button>
           </Form.Group>
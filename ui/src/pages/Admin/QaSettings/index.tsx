@@ -61,6 +61,12 @@ const QaSettings = () => {
         title: t('restrict_answer.label'),
         description: t('restrict_answer.text'),
       },
+      trending_half_life: {
+        type: 'number',
+        title: t('trending_half_life.label'),
+        description: t('trending_half_life.text'),
+        default: 0,
+      },
     },
   };
   const uiSchema: UISchema = {
@@ -82,6 +88,12 @@ const QaSettings = () => {
         label: t('restrict_answer.label'),
       },
     },
+    trending_half_life: {
+      'ui:widget': 'input',
+      'ui:options': {
+        inputType: 'number',
+      },
+    },
   };
   const [formData, setFormData] = useState<Type.FormDataType>(
     initFormData(schema),
@@ -99,6 +111,7 @@ const QaSettings = () => {
       min_tags: formData.min_tags.value,
       min_content: formData.min_content.value,
       restrict_answer: formData.restrict_answer.value,
+      trending_half_life: Number(formData.trending_half_life.value) || 0,
     };
     updateQuestionSetting(reqParams)
       .then(() => {
@@ -125,6 +138,7 @@ const QaSettings = () => {
         formMeta.min_tags.value = res.min_tags;
         formMeta.min_content.value = res.min_content;
         formMeta.restrict_answer.value = res.restrict_answer;
+        formMeta.trending_half_life.value = res.trending_half_life || 0;
         console.log('res', res, formMeta);
         setFormData(formMeta);
       }
@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FormEvent, useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import { Form, Button } from 'react-bootstrap';
+
+import { useToast } from '@/hooks';
+import { getSamlConfig, saveSamlConfig } from '@/services';
+import type { SamlConfig } from '@/services/admin/saml';
+
+const textFields = [
+  'display_name',
+  'idp_entity_id',
+  'idp_sso_url',
+  'username_attribute',
+  'email_attribute',
+  'display_name_attribute',
+];
+
+const readonlyFields = ['sp_entity_id', 'acs_url', 'metadata_url'];
+
+const Saml = () => {
+  const toast = useToast();
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'admin.saml',
+  });
+  const [formData, setFormData] = useState<SamlConfig>({
+    enabled: false,
+    display_name: '',
+    idp_entity_id: '',
+    idp_sso_url: '',
+    idp_certificate: '',
+    allow_idp_initiated: false,
+    username_attribute: '',
+    email_attribute: '',
+    display_name_attribute: '',
+  });
+
+  const handleOnChange = (form) => {
+    setFormData({ ...formData, ...form });
+  };
+  const onSubmit = (evt: FormEvent) => {
+    evt.preventDefault();
+    evt.stopPropagation();
+    saveSamlConfig(formData).then(() => {
+      toast.onShow({
+        msg: t('update', { keyPrefix: 'toast' }),
+        variant: 'success',
+      });
+    });
+  };
+
+  useEffect(() => {
+    getSamlConfig().then((resp) => {
+      setFormData(resp);
+    });
+  }, []);
+
+  return (
+    <>
+      <h3 className="mb-4">{t('saml', { keyPrefix: 'nav_menus' })}</h3>
+      <div className="max-w-748">
+        <Form onSubmit={onSubmit}>
+          {readonlyFields.map((field) => (
+            <Form.Group className="mb-3" controlId={field} key={field}>
+              <Form.Label>{t(`${field}.label`)}</Form.Label>
+              <Form.Control type="text" disabled value={formData[field]} />
+              <Form.Text className="text-muted">{t(`${field}.text`)}</Form.Text>
+            </Form.Group>
+          ))}
+          <Form.Group className="mb-3" controlId="enabled">
+            <Form.Label>{t('enabled.label')}</Form.Label>
+            <Form.Check
+              type="switch"
+              label={t('enabled.switch')}
+              checked={formData.enabled}
+              onChange={(e) => handleOnChange({ enabled: e.target.checked })}
+            />
+          </Form.Group>
+          {textFields.map((field) => (
+            <Form.Group className="mb-3" controlId={field} key={field}>
+              <Form.Label>{t(`${field}.label`)}</Form.Label>
+              <Form.Control
+                type="text"
+                value={formData[field]}
+                onChange={(e) => handleOnChange({ [field]: e.target.value })}
+              />
+              <Form.Text className="text-muted">{t(`${field}.text`)}</Form.Text>
+            </Form.Group>
+          ))}
+          <Form.Group className="mb-3" controlId="idp_certificate">
+            <Form.Label>{t('idp_certificate.label')}</Form.Label>
+            <Form.Control
+              as="textarea"
+              rows={6}
+              value={formData.idp_certificate}
+              onChange={(e) =>
+                handleOnChange({ idp_certificate: e.target.value })
+              }
+            />
+            <Form.Text className="text-muted">
+              {t('idp_certificate.text')}
+            </Form.Text>
+          </Form.Group>
+          <Form.Group className="mb-3" controlId="allow_idp_initiated">
+            <Form.Label>{t('allow_idp_initiated.label')}</Form.Label>
+            <Form.Check
+              type="switch"
+              label={t('allow_idp_initiated.switch')}
+              checked={formData.allow_idp_initiated}
+              onChange={(e) =>
+                handleOnChange({ allow_idp_initiated: e.target.checked })
+              }
+            />
+            <Form.Text className="text-muted">
+              {t('allow_idp_initiated.text')}
+            </Form.Text>
+          </Form.Group>
+          <Button variant="primary" type="submit">
+            {t('save', { keyPrefix: 'btns' })}
+          </Button>
+        </Form>
+      </div>
+    </>
+  );
+};
+
+export default Saml;
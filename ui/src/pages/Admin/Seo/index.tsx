@@ -53,6 +53,12 @@ const Index: FC = () => {
         title: t('robots.label'),
         description: t('robots.text'),
       },
+      feed_item_limit: {
+        type: 'number',
+        title: t('feed_item_limit.label'),
+        description: t('feed_item_limit.text'),
+        default: 20,
+      },
     },
   };
   const uiSchema: UISchema = {
@@ -66,6 +72,12 @@ const Index: FC = () => {
         className: 'font-monospace',
       },
     },
+    feed_item_limit: {
+      'ui:widget': 'input',
+      'ui:options': {
+        inputType: 'number',
+      },
+    },
   };
   const [formData, setFormData] = useState(initFormData(schema));
 
@@ -76,6 +88,7 @@ const Index: FC = () => {
     const reqParams: Type.AdminSettingsSeo = {
       permalink: Number(formData.permalink.value),
       robots: formData.robots.value,
+      feed_item_limit: Number(formData.feed_item_limit.value),
     };
 
     putSeoSetting(reqParams)
@@ -102,6 +115,7 @@ const Index: FC = () => {
         const formMeta = { ...formData };
         formMeta.robots.value = setting.robots;
         formMeta.permalink.value = setting.permalink;
+        formMeta.feed_item_limit.value = setting.feed_item_limit || 20;
         if (!/[1234]/.test(formMeta.permalink.value)) {
           formMeta.permalink.value = 4;
         }
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FormEvent, useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import { Form, Button } from 'react-bootstrap';
+
+import { useToast } from '@/hooks';
+import { getStorageConfig, saveStorageConfig } from '@/services';
+import type { StorageConfig } from '@/services/admin/storage';
+
+const textFields = [
+  'endpoint',
+  'region',
+  'bucket',
+  'access_key_id',
+  'secret_access_key',
+  'path_prefix',
+  'public_url',
+];
+
+const Storage = () => {
+  const toast = useToast();
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'admin.storage',
+  });
+  const [formData, setFormData] = useState<StorageConfig>({
+    type: 'local',
+    endpoint: '',
+    region: '',
+    bucket: '',
+    access_key_id: '',
+    secret_access_key: '',
+    path_prefix: '',
+    use_path_style: false,
+    public_url: '',
+    signed_url_expiry: 0,
+  });
+
+  const handleOnChange = (form) => {
+    setFormData({ ...formData, ...form });
+  };
+  const onSubmit = (evt: FormEvent) => {
+    evt.preventDefault();
+    evt.stopPropagation();
+    saveStorageConfig({
+      ...formData,
+      signed_url_expiry: Number(formData.signed_url_expiry) || 0,
+    }).then(() => {
+      toast.onShow({
+        msg: t('update', { keyPrefix: 'toast' }),
+        variant: 'success',
+      });
+    });
+  };
+
+  useEffect(() => {
+    getStorageConfig().then((resp) => {
+      setFormData({ ...formData, ...resp });
+    });
+  }, []);
+
+  return (
+    <>
+      <h3 className="mb-4">{t('storage', { keyPrefix: 'nav_menus' })}</h3>
+      <div className="max-w-748">
+        <Form onSubmit={onSubmit}>
+          <Form.Group className="mb-3" controlId="type">
+            <Form.Label>{t('type.label')}</Form.Label>
+            <Form.Select
+              value={formData.type}
+              onChange={(e) =>
+                handleOnChange({
+                  type: e.target.value as StorageConfig['type'],
+                })
+              }>
+              <option value="local">{t('type.local')}</option>
+              <option value="s3">{t('type.s3')}</option>
+            </Form.Select>
+            <Form.Text className="text-muted">{t('type.text')}</Form.Text>
+          </Form.Group>
+          {formData.type === 's3' && (
+            <>
+              {textFields.map((field) => (
+                <Form.Group className="mb-3" controlId={field} key={field}>
+                  <Form.Label>{t(`${field}.label`)}</Form.Label>
+                  <Form.Control
+                    type={field === 'secret_access_key' ? 'password' : 'text'}
+                    value={formData[field]}
+                    onChange={(e) =>
+                      handleOnChange({ [field]: e.target.value })
+                    }
+                  />
+                  <Form.Text className="text-muted">
+                    {t(`${field}.text`, { defaultValue: '' })}
+                  </Form.Text>
+                </Form.Group>
+              ))}
+              <Form.Group className="mb-3" controlId="use_path_style">
+                <Form.Label>{t('use_path_style.label')}</Form.Label>
+                <Form.Check
+                  type="switch"
+                  label={t('use_path_style.switch')}
+                  checked={formData.use_path_style}
+                  onChange={(e) =>
+                    handleOnChange({ use_path_style: e.target.checked })
+                  }
+                />
+                <Form.Text className="text-muted">
+                  {t('use_path_style.text')}
+                </Form.Text>
+              </Form.Group>
+              <Form.Group className="mb-3" controlId="signed_url_expiry">
+                <Form.Label>{t('signed_url_expiry.label')}</Form.Label>
+                <Form.Control
+                  type="number"
+                  min={0}
+                  value={formData.signed_url_expiry}
+                  onChange={(e) =>
+                    handleOnChange({ signed_url_expiry: e.target.value })
+                  }
+                />
+                <Form.Text className="text-muted">
+                  {t('signed_url_expiry.text')}
+                </Form.Text>
+              </Form.Group>
+            </>
+          )}
+          <Button variant="primary" type="submit">
+            {t('save', { keyPrefix: 'btns' })}
+          </Button>
+        </Form>
+      </div>
+    </>
+  );
+};
+
+export default Storage;
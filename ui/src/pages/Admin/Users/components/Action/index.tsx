@@ -32,6 +32,7 @@ import {
   updateUserPassword,
   changeUserStatus,
   updateUserProfile,
+  resetUserTwoFactor,
 } from '@/services';
 import { toastStore } from '@/stores';
 
@@ -146,6 +147,25 @@ const UserOperation = ({
       changePasswordModal.onShow(user_id);
     }
 
+    if (type === 'two_factor') {
+      Modal.confirm({
+        title: t('reset_two_factor.title'),
+        content: t('reset_two_factor.content'),
+        cancelBtnVariant: 'link',
+        confirmBtnVariant: 'danger',
+        cancelText: t('cancel', { keyPrefix: 'btns' }),
+        confirmText: t('reset_two_factor.btn'),
+        onConfirm: () => {
+          resetUserTwoFactor({ user_id }).then(() => {
+            Toast.onShow({
+              msg: t('reset_two_factor.success'),
+              variant: 'success',
+            });
+          });
+        },
+      });
+    }
+
     if (type === 'profile') {
       changeProfileModal.onShow(user_id);
     }
@@ -203,6 +223,11 @@ const UserOperation = ({
               {t('set_new_password')}
             </Dropdown.Item>
           ) : null}
+          {showActionPassword ? (
+            <Dropdown.Item onClick={() => handleAction('two_factor')}>
+              {t('reset_two_factor.btn')}
+            </Dropdown.Item>
+          ) : null}
           <Dropdown.Item onClick={() => handleAction('profile')}>
             {t('edit_profile')}
           </Dropdown.Item>
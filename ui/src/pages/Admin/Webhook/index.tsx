@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FormEvent, useEffect, useState } from 'react';
+import { useTranslation } from 'react-i18next';
+import { Form, Button, Table } from 'react-bootstrap';
+import { useSearchParams } from 'react-router-dom';
+
+import { Empty, FormatTime, Pagination } from '@/components';
+import { useToast } from '@/hooks';
+import {
+  getWebhookConfig,
+  saveWebhookConfig,
+  useQueryWebhookDeadLetters,
+} from '@/services';
+import type { WebhookConfig } from '@/services/admin/webhook';
+
+const EVENTS = ['question.created', 'answer.created', 'answer.accepted'];
+
+const PAGE_SIZE = 10;
+
+const Webhook = () => {
+  const toast = useToast();
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'admin.webhook',
+  });
+  const [urlSearchParams] = useSearchParams();
+  const curPage = Number(urlSearchParams.get('page') || '1');
+  const [formData, setFormData] = useState<WebhookConfig>({
+    enabled: false,
+    url: '',
+    secret: '',
+    events: [],
+  });
+  const { data: deadLetters, isLoading } = useQueryWebhookDeadLetters({
+    page: curPage,
+    page_size: PAGE_SIZE,
+  });
+
+  const handleOnChange = (form) => {
+    setFormData({ ...formData, ...form });
+  };
+  const handleEventChange = (event: string, checked: boolean) => {
+    const events = (formData.events || []).filter((e) => e !== event);
+    if (checked) {
+      events.push(event);
+    }
+    handleOnChange({ events });
+  };
+  const onSubmit = (evt: FormEvent) => {
+    evt.preventDefault();
+    evt.stopPropagation();
+    saveWebhookConfig(formData).then(() => {
+      toast.onShow({
+        msg: t('update', { keyPrefix: 'toast' }),
+        variant: 'success',
+      });
+    });
+  };
+
+  useEffect(() => {
+    getWebhookConfig().then((resp) => {
+      setFormData({ ...resp, events: resp.events || [] });
+    });
+  }, []);
+
+  return (
+    <>
+      <h3 className="mb-4">{t('webhook', { keyPrefix: 'nav_menus' })}</h3>
+      <div className="max-w-748">
+        <Form onSubmit={onSubmit}>
+          <Form.Group className="mb-3" controlId="enabled">
+            <Form.Label>{t('enabled.label')}</Form.Label>
+            <Form.Check
+              type="switch"
+              label={t('enabled.switch')}
+              checked={formData.enabled}
+              onChange={(e) => handleOnChange({ enabled: e.target.checked })}
+            />
+          </Form.Group>
+          <Form.Group className="mb-3" controlId="url">
+            <Form.Label>{t('url.label')}</Form.Label>
+            <Form.Control
+              type="url"
+              value={formData.url}
+              onChange={(e) => handleOnChange({ url: e.target.value })}
+            />
+            <Form.Text className="text-muted">{t('url.text')}</Form.Text>
+          </Form.Group>
+          <Form.Group className="mb-3" controlId="secret">
+            <Form.Label>{t('secret.label')}</Form.Label>
+            <Form.Control
+              type="password"
+              autoComplete="new-password"
+              value={formData.secret}
+              onChange={(e) => handleOnChange({ secret: e.target.value })}
+            />
+            <Form.Text className="text-muted">{t('secret.text')}</Form.Text>
+          </Form.Group>
+          <Form.Group className="mb-3" controlId="events">
+            <Form.Label>{t('events.label')}</Form.Label>
+            {EVENTS.map((event) => (
+              <Form.Check
+                key={event}
+                id={`event-${event}`}
+                type="checkbox"
+                label={t(`events.${event.replace('.', '_')}`)}
+                checked={formData.events?.includes(event)}
+                onChange={(e) => handleEventChange(event, e.target.checked)}
+              />
+            ))}
+            <Form.Text className="text-muted">{t('events.text')}</Form.Text>
+          </Form.Group>
+          <Button variant="primary" type="submit">
+            {t('save', { keyPrefix: 'btns' })}
+          </Button>
+        </Form>
+      </div>
+
+      <h5 className="mt-5 mb-3">{t('dead_letters.title')}</h5>
+      <p className="text-secondary small">{t('dead_letters.text')}</p>
+      <Table responsive="md">
+        <thead>
+          <tr>
+            <th>{t('dead_letters.event')}</th>
+            <th>{t('dead_letters.object_id')}</th>
+            <th>{t('dead_letters.status')}</th>
+            <th>{t('dead_letters.attempts')}</th>
+            <th>{t('dead_letters.error')}</th>
+            <th>{t('dead_letters.created_at')}</th>
+          </tr>
+        </thead>
+        <tbody className="align-middle">
+          {deadLetters?.list?.map((item) => (
+            <tr key={item.id}>
+              <td>{item.event}</td>
+              <td>{item.object_id}</td>
+              <td>{item.status_code || '-'}</td>
+              <td>{item.attempts}</td>
+              <td className="text-break">{item.error}</td>
+              <td>
+                <FormatTime
+                  className="small text-secondary"
+                  time={item.created_at}
+                />
+              </td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+      {Number(deadLetters?.count) <= 0 && !isLoading && <Empty />}
+      <div className="mt-4 mb-2 d-flex justify-content-center">
+        <Pagination
+          currentPage={curPage}
+          totalSize={deadLetters?.count || 0}
+          pageSize={PAGE_SIZE}
+        />
+      </div>
+    </>
+  );
+};
+
+export default Webhook;
@@ -34,7 +34,11 @@ interface Props {
 const sqlData = [
   {
     value: 'mysql',
-    label: 'MariaDB/MySQL',
+    label: 'MySQL',
+  },
+  {
+    value: 'mariadb',
+    label: 'MariaDB',
   },
   {
     value: 'sqlite3',
@@ -262,7 +266,8 @@ const Index: FC<Props> = ({ visible, data, changeCallback, nextCallback }) => {
           )}
           {data.db_type.value === 'postgres' &&
             data.ssl_enabled.value &&
-            (data.ssl_mode.value === 'verify-ca' ||
+            (data.ssl_mode.value === 'require' ||
+              data.ssl_mode.value === 'verify-ca' ||
               data.ssl_mode.value === 'verify-full') && (
               <Row className="mb-3">
                 <Form.Group as={Col} controlId="ssl_root_cert">
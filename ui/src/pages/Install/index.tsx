@@ -173,7 +173,10 @@ const Index: FC = () => {
   const updateFormData = (params: FormDataType) => {
     if (Object.keys(params)?.[0] === 'db_type') {
       let updatedFormData = formData;
-      if (params.db_type.value === 'mysql') {
+      if (
+        params.db_type.value === 'mysql' ||
+        params.db_type.value === 'mariadb'
+      ) {
         updatedFormData = {
           ...updatedFormData,
           db_username: { ...updatedFormData.db_username, value: 'root' },
@@ -20,9 +20,12 @@
 import { memo, FC } from 'react';
 import { Alert } from 'react-bootstrap';
 import { useTranslation } from 'react-i18next';
+import { Link } from 'react-router-dom';
 
 import dayjs from 'dayjs';
 
+import { pathFactory } from '@/router/pathFactory';
+
 interface Props {
   data;
 }
@@ -32,7 +35,19 @@ const Index: FC<Props> = ({ data }) => {
     <Alert className="mb-4" variant={data.level}>
       {data.level === 'info' ? (
         <div>
-          {data.msg.startsWith('http') ? (
+          {data.duplicate_question ? (
+            <p>
+              {data.description} {t('question_detail.duplicate_of')}{' '}
+              <Link
+                to={pathFactory.questionLanding(
+                  data.duplicate_question.id,
+                  data.duplicate_question.url_title,
+                )}
+                className="alert-exist">
+                <strong>{data.duplicate_question.title}</strong>
+              </Link>
+            </p>
+          ) : data.msg.startsWith('http') ? (
             <p>
               {data.description}{' '}
               <a href={data.msg} className="alert-exist">
@@ -31,7 +31,7 @@ import {
   htmlRender,
   ImgViewer,
 } from '@/components';
-import { scrollToElementTop, bgFadeOut } from '@/utils';
+import { scrollToElementTop, bgFadeOut, webImageHTML } from '@/utils';
 import { AnswerItem } from '@/common/interface';
 import { acceptanceAnswer } from '@/services';
 import { useRenderHtmlPlugin } from '@/utils/pluginKit';
@@ -133,7 +133,7 @@ const Index: FC<Props> = ({
       <ImgViewer>
         <article
           className="fmt text-break text-wrap"
-          dangerouslySetInnerHTML={{ __html: data?.html }}
+          dangerouslySetInnerHTML={{ __html: webImageHTML(data?.html) }}
         />
       </ImgViewer>
       <div className="d-flex align-items-center my-4">
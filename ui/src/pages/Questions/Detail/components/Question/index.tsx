@@ -33,7 +33,7 @@ import {
   ImgViewer,
 } from '@/components';
 import { useRenderHtmlPlugin } from '@/utils/pluginKit';
-import { formatCount, guard } from '@/utils';
+import { formatCount, guard, webImageHTML } from '@/utils';
 import { following } from '@/services';
 import { pathFactory } from '@/router/pathFactory';
 
@@ -161,7 +161,7 @@ const Index: FC<Props> = ({ data, initPage, hasAnswer, isLogged }) => {
         <article
           ref={ref}
           className="fmt text-break text-wrap last-p mb-4"
-          dangerouslySetInnerHTML={{ __html: data?.html }}
+          dangerouslySetInnerHTML={{ __html: webImageHTML(data?.html) }}
         />
       </ImgViewer>
 
@@ -34,7 +34,11 @@ interface Props {
   visible: boolean;
   sourceTag: TagInfo;
   onClose: () => void;
-  onConfirm: (sourceTagID: string, targetTagID: string) => void;
+  onConfirm: (
+    sourceTagID: string,
+    targetTagID: string,
+    description: '' | 'source' | 'target',
+  ) => void;
 }
 
 interface SearchTagResp {
@@ -59,6 +63,9 @@ const MergeTagModal: FC<Props> = ({
   const [tags, setTags] = useState<SearchTagResp[]>([]);
   const [currentIndex, setCurrentIndex] = useState(0);
   const [dropdownVisible, setDropdownVisible] = useState(false);
+  const [description, setDescription] = useState<'' | 'source' | 'target'>(
+    '',
+  );
   const inputRef = useRef<HTMLInputElement>(null);
 
   const searchTags = useCallback(
@@ -79,7 +86,7 @@ const MergeTagModal: FC<Props> = ({
 
   const handleConfirm = () => {
     if (!targetTag) return;
-    onConfirm(sourceTag.tag_id, targetTag.tag_id);
+    onConfirm(sourceTag.tag_id, targetTag.tag_id, description);
   };
 
   const handleSelect = (tag: SearchTagResp) => {
@@ -137,6 +144,7 @@ const MergeTagModal: FC<Props> = ({
       setTargetTag(null);
       setCurrentIndex(0);
       setDropdownVisible(false);
+      setDescription('');
     }
   }, [visible]);
 
@@ -189,6 +197,18 @@ const MergeTagModal: FC<Props> = ({
               {t('target_tag_description')}
             </Form.Text>
           </Form.Group>
+          <Form.Group className="mt-3">
+            <Form.Label>{t('description_title')}</Form.Label>
+            <Form.Select
+              value={description}
+              onChange={(e) =>
+                setDescription(e.target.value as '' | 'source' | 'target')
+              }>
+              <option value="">{t('description_auto')}</option>
+              <option value="source">{t('description_source')}</option>
+              <option value="target">{t('description_target')}</option>
+            </Form.Select>
+          </Form.Group>
         </Form>
       </Modal.Body>
       <Modal.Footer>
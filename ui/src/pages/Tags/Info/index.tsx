@@ -165,13 +165,19 @@ const TagIntroduction = () => {
     setShowMergeModal(true);
   };
 
-  const handleMergeConfirm = (sourceTagID: string, targetTagID: string) => {
-    mergeTag({ source_tag_id: sourceTagID, target_tag_id: targetTagID }).then(
-      () => {
-        setShowMergeModal(false);
-        navigate('/tags', { replace: true });
-      },
-    );
+  const handleMergeConfirm = (
+    sourceTagID: string,
+    targetTagID: string,
+    description: '' | 'source' | 'target',
+  ) => {
+    mergeTag({
+      source_tag_id: sourceTagID,
+      target_tag_id: targetTagID,
+      description,
+    }).then(() => {
+      setShowMergeModal(false);
+      navigate('/tags', { replace: true });
+    });
   };
 
   const onAction = (params) => {
@@ -38,7 +38,7 @@ import {
   scrollToElementTop,
 } from '@/utils';
 import { PluginType, useCaptchaPlugin } from '@/utils/pluginKit';
-import { login, UcAgent } from '@/services';
+import { login, loginByTwoFactor, UcAgent } from '@/services';
 import { setupAppTheme } from '@/utils/localize';
 
 const Index: React.FC = () => {
@@ -70,6 +70,12 @@ const Index: React.FC = () => {
   });
 
   const [step, setStep] = useState(1);
+  const [twoFactorToken, setTwoFactorToken] = useState('');
+  const [twoFactorCode, setTwoFactorCode] = useState({
+    value: '',
+    isInvalid: false,
+    errorMsg: '',
+  });
 
   const handleChange = (params: FormDataType) => {
     setFormData({ ...formData, ...params });
@@ -113,6 +119,47 @@ const Index: React.FC = () => {
     return bol;
   };
 
+  const loginSuccess = (res) => {
+    updateUser(res);
+    setupAppTheme();
+    const userStat = guard.deriveLoginState();
+    if (userStat.isNotActivated) {
+      // inactive
+      setStep(2);
+    } else {
+      guard.handleLoginRedirect(navigate);
+    }
+  };
+
+  const handleTwoFactorSubmit = (event: FormEvent) => {
+    event.preventDefault();
+    event.stopPropagation();
+    if (!twoFactorCode.value) {
+      setTwoFactorCode({
+        value: '',
+        isInvalid: true,
+        errorMsg: t('two_factor.msg.empty'),
+      });
+      return;
+    }
+    loginByTwoFactor({ token: twoFactorToken, code: twoFactorCode.value })
+      .then(loginSuccess)
+      .catch((err) => {
+        if (err.isError && err.list?.length) {
+          setTwoFactorCode({
+            ...twoFactorCode,
+            isInvalid: true,
+            errorMsg: err.list[0].error_msg,
+          });
+          return;
+        }
+        // the challenge expired, the password has to be entered again
+        setTwoFactorToken('');
+        setTwoFactorCode({ value: '', isInvalid: false, errorMsg: '' });
+        setStep(1);
+      });
+  };
+
   const handleLogin = (event?: any) => {
     if (event) {
       event.preventDefault();
@@ -131,15 +178,12 @@ const Index: React.FC = () => {
     login(params)
       .then(async (res) => {
         await passwordCaptcha?.close?.();
-        updateUser(res);
-        setupAppTheme();
-        const userStat = guard.deriveLoginState();
-        if (userStat.isNotActivated) {
-          // inactive
-          setStep(2);
-        } else {
-          guard.handleLoginRedirect(navigate);
+        if (res.two_factor_required && res.two_factor_token) {
+          setTwoFactorToken(res.two_factor_token);
+          setStep(3);
+          return;
         }
+        loginSuccess(res);
       })
       .catch((err) => {
         if (err.isError) {
@@ -291,6 +335,39 @@ const Index: React.FC = () => {
       ) : null}
 
       {step === 2 && <Unactivate visible={step === 2} />}
+
+      {step === 3 && (
+        <Col className="mx-auto" md={6} lg={4} xl={3}>
+          <Form noValidate onSubmit={handleTwoFactorSubmit}>
+            <Form.Group controlId="code" className="mb-3">
+              <Form.Label>{t('two_factor.label')}</Form.Label>
+              <Form.Control
+                required
+                autoFocus
+                autoComplete="one-time-code"
+                value={twoFactorCode.value}
+                isInvalid={twoFactorCode.isInvalid}
+                onChange={(e) =>
+                  setTwoFactorCode({
+                    value: e.target.value,
+                    isInvalid: false,
+                    errorMsg: '',
+                  })
+                }
+              />
+              <Form.Control.Feedback type="invalid">
+                {twoFactorCode.errorMsg}
+              </Form.Control.Feedback>
+              <Form.Text>{t('two_factor.text')}</Form.Text>
+            </Form.Group>
+            <div className="d-grid">
+              <Button variant="primary" type="submit">
+                {t('two_factor.btn_verify')}
+              </Button>
+            </div>
+          </Form>
+        </Col>
+      )}
     </Container>
   );
 };
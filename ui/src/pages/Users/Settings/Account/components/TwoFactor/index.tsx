@@ -0,0 +1,197 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+
+import { memo, FormEvent, useState } from 'react';
+import { Form, Button } from 'react-bootstrap';
+import { useTranslation } from 'react-i18next';
+
+import QrCode from 'qrcode';
+
+import { useToast } from '@/hooks';
+import type { TwoFactorEnrollment } from '@/common/interface';
+import {
+  useTwoFactorStatus,
+  enrollTwoFactor,
+  activateTwoFactor,
+  disableTwoFactor,
+} from '@/services';
+
+const Index = () => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'settings.two_factor',
+  });
+  const toast = useToast();
+  const { data, mutate } = useTwoFactorStatus();
+  const [enrollment, setEnrollment] = useState<TwoFactorEnrollment>();
+  const [qrcodeDataUrl, setQrCodeDataUrl] = useState('');
+  const [code, setCode] = useState({
+    value: '',
+    isInvalid: false,
+    errorMsg: '',
+  });
+
+  const resetCode = () => {
+    setCode({ value: '', isInvalid: false, errorMsg: '' });
+  };
+
+  const handleError = (err) => {
+    if (err?.isError && err.list?.length) {
+      setCode({ ...code, isInvalid: true, errorMsg: err.list[0].error_msg });
+    }
+  };
+
+  const handleEnroll = () => {
+    enrollTwoFactor().then((res) => {
+      setEnrollment(res);
+      resetCode();
+      QrCode.toDataURL(
+        res.otpauth_url,
+        { width: 200, margin: 0 },
+        (err, url) => {
+          if (!err) {
+            setQrCodeDataUrl(url);
+          }
+        },
+      );
+    });
+  };
+
+  const checkValidated = (): boolean => {
+    if (!code.value) {
+      setCode({ value: '', isInvalid: true, errorMsg: t('code.msg.empty') });
+      return false;
+    }
+    return true;
+  };
+
+  const handleActivate = (event: FormEvent) => {
+    event.preventDefault();
+    event.stopPropagation();
+    if (!checkValidated()) {
+      return;
+    }
+    activateTwoFactor({ code: code.value })
+      .then(() => {
+        setEnrollment(undefined);
+        setQrCodeDataUrl('');
+        resetCode();
+        mutate();
+        toast.onShow({
+          msg: t('enable_success'),
+          variant: 'success',
+        });
+      })
+      .catch(handleError);
+  };
+
+  const handleDisable = (event: FormEvent) => {
+    event.preventDefault();
+    event.stopPropagation();
+    if (!checkValidated()) {
+      return;
+    }
+    disableTwoFactor({ code: code.value })
+      .then(() => {
+        resetCode();
+        mutate();
+        toast.onShow({
+          msg: t('disable_success'),
+          variant: 'success',
+        });
+      })
+      .catch(handleError);
+  };
+
+  if (!data) return null;
+
+  const codeGroup = (
+    <Form.Group controlId="two_factor_code" className="mb-3">
+      <Form.Label>{t('code.label')}</Form.Label>
+      <Form.Control
+        required
+        autoComplete="one-time-code"
+        value={code.value}
+        isInvalid={code.isInvalid}
+        onChange={(e) =>
+          setCode({ value: e.target.value, isInvalid: false, errorMsg: '' })
+        }
+      />
+      <Form.Control.Feedback type="invalid">
+        {code.errorMsg}
+      </Form.Control.Feedback>
+    </Form.Group>
+  );
+
+  return (
+    <div className="mt-5">
+      <div className="form-label">{t('title')}</div>
+      {data.enabled ? (
+        <>
+          <small className="form-text mt-0">
+            {t('enabled', { count: data.recovery_codes_remaining })}
+          </small>
+          <Form noValidate className="mt-3" onSubmit={handleDisable}>
+            {codeGroup}
+            <Button variant="outline-danger" type="submit">
+              {t('btn_disable')}
+            </Button>
+          </Form>
+        </>
+      ) : null}
+      {!data.enabled && !enrollment ? (
+        <>
+          <small className="form-text mt-0">{t('label')}</small>
+          <div className="mt-3">
+            <Button variant="outline-secondary" onClick={handleEnroll}>
+              {t('btn_setup')}
+            </Button>
+          </div>
+        </>
+      ) : null}
+      {!data.enabled && enrollment ? (
+        <Form noValidate className="mt-3" onSubmit={handleActivate}>
+          <p className="small">{t('scan')}</p>
+          {qrcodeDataUrl ? (
+            <img
+              className="mb-2"
+              width={200}
+              height={200}
+              src={qrcodeDataUrl}
+              alt="otpauth"
+            />
+          ) : null}
+          <p className="small">
+            {t('secret')} <code>{enrollment.secret}</code>
+          </p>
+          <p className="small mb-1">{t('recovery_codes')}</p>
+          <pre className="small bg-light p-2">
+            {enrollment.recovery_codes.join('\n')}
+          </pre>
+          {codeGroup}
+          <Button variant="primary" type="submit">
+            {t('btn_enable')}
+          </Button>
+        </Form>
+      ) : null}
+    </div>
+  );
+};
+
+export default memo(Index);
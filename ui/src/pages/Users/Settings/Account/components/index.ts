@@ -20,5 +20,6 @@
 import ModifyEmail from './ModifyEmail';
 import ModifyPassword from './ModifyPass';
 import MyLogins from './MyLogins';
+import TwoFactor from './TwoFactor';
 
-export { ModifyEmail, ModifyPassword, MyLogins };
+export { ModifyEmail, ModifyPassword, MyLogins, TwoFactor };
@@ -23,7 +23,12 @@ import { useTranslation } from 'react-i18next';
 import { userCenterStore } from '@/stores';
 import { getUcSettings, UcSettingAgent } from '@/services';
 
-import { ModifyEmail, ModifyPassword, MyLogins } from './components';
+import {
+  ModifyEmail,
+  ModifyPassword,
+  MyLogins,
+  TwoFactor,
+} from './components';
 
 const Index = () => {
   const { t } = useTranslation('translation', {
@@ -55,6 +60,7 @@ const Index = () => {
           <ModifyEmail />
           <ModifyPassword />
           <MyLogins />
+          <TwoFactor />
         </>
       ) : null}
     </>
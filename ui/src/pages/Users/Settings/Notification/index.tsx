@@ -53,6 +53,20 @@ const Index = () => {
         description: t('all_new_question_for_following_tags.description'),
         default: configData?.all_new_question_for_following_tags.enable,
       },
+      frequency: {
+        type: 'string',
+        title: t('frequency.label'),
+        description: t('frequency.text'),
+        enum: ['immediate', 'daily', 'weekly'],
+        enumNames: [
+          t('frequency.immediate'),
+          t('frequency.daily'),
+          t('frequency.weekly'),
+        ],
+        default:
+          configData?.all_new_question_for_following_tags.frequency ||
+          'immediate',
+      },
     },
   };
   const uiSchema: UISchema = {
@@ -75,6 +89,9 @@ const Index = () => {
         text: t('all_new_question_for_following_tags.description'),
       },
     },
+    frequency: {
+      'ui:widget': 'select',
+    },
   };
   const [formData, setFormData] = useState<FormDataType>(initFormData(schema));
 
@@ -97,6 +114,7 @@ const Index = () => {
       all_new_question_for_following_tags: {
         enable: formData.all_new_question_for_following_tags.value,
         key: configData?.all_new_question_for_following_tags.key,
+        frequency: formData.frequency.value,
       },
     } as NotificationConfig;
 
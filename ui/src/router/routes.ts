@@ -461,6 +461,18 @@ const routes: RouteNode[] = [
             path: 'mcp',
             page: 'pages/Admin/Mcp',
           },
+          {
+            path: 'saml',
+            page: 'pages/Admin/Saml',
+          },
+          {
+            path: 'storage',
+            page: 'pages/Admin/Storage',
+          },
+          {
+            path: 'webhook',
+            page: 'pages/Admin/Webhook',
+          },
         ],
       },
       {
@@ -29,3 +29,6 @@ export * from './ai';
 export * from './tags';
 export * from './apikeys';
 export * from './mcp';
+export * from './saml';
+export * from './storage';
+export * from './webhook';
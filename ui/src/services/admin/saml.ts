@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import request from '@/utils/request';
+
+export type SamlConfig = {
+  enabled: boolean;
+  display_name: string;
+  idp_entity_id: string;
+  idp_sso_url: string;
+  idp_certificate: string;
+  allow_idp_initiated: boolean;
+  username_attribute: string;
+  email_attribute: string;
+  display_name_attribute: string;
+  sp_entity_id?: string;
+  acs_url?: string;
+  metadata_url?: string;
+};
+
+export const getSamlConfig = () => {
+  return request.get<SamlConfig>(`/answer/admin/api/setting/saml`);
+};
+
+export const saveSamlConfig = (params: SamlConfig) => {
+  return request.put(`/answer/admin/api/setting/saml`, params);
+};
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import request from '@/utils/request';
+
+export type StorageConfig = {
+  type: 'local' | 's3';
+  endpoint: string;
+  region: string;
+  bucket: string;
+  access_key_id: string;
+  secret_access_key: string;
+  path_prefix: string;
+  use_path_style: boolean;
+  public_url: string;
+  signed_url_expiry: number;
+};
+
+export const getStorageConfig = () => {
+  return request.get<StorageConfig>(`/answer/admin/api/setting/storage`);
+};
+
+export const saveStorageConfig = (params: StorageConfig) => {
+  return request.put(`/answer/admin/api/setting/storage`, params);
+};
@@ -68,6 +68,10 @@ export const updateUserPassword = (params: {
   return request.put('/answer/admin/api/user/password', params);
 };
 
+export const resetUserTwoFactor = (params: { user_id: string }) => {
+  return request.delete('/answer/admin/api/user/2fa', params);
+};
+
 export const updateUserProfile = (params: {
   display_name: string;
   username: string;
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import qs from 'qs';
+import useSWR from 'swr';
+
+import request from '@/utils/request';
+import type * as Type from '@/common/interface';
+
+export type WebhookConfig = {
+  enabled: boolean;
+  url: string;
+  secret: string;
+  events: string[];
+};
+
+export type WebhookDeadLetter = {
+  id: number;
+  created_at: number;
+  delivery_id: string;
+  event: string;
+  object_id: string;
+  url: string;
+  payload: string;
+  status_code: number;
+  error: string;
+  attempts: number;
+};
+
+export const getWebhookConfig = () => {
+  return request.get<WebhookConfig>(`/answer/admin/api/setting/webhook`);
+};
+
+export const saveWebhookConfig = (params: WebhookConfig) => {
+  return request.put(`/answer/admin/api/setting/webhook`, params);
+};
+
+export const useQueryWebhookDeadLetters = (params) => {
+  const apiUrl = `/answer/admin/api/webhook/dead-letters?${qs.stringify(
+    params,
+    { skipNulls: true },
+  )}`;
+  const { data, error, mutate } = useSWR<
+    Type.ListResult<WebhookDeadLetter>,
+    Error
+  >(apiUrl, request.instance.get);
+  return {
+    data,
+    isLoading: !data && !error,
+    error,
+    mutate,
+  };
+};
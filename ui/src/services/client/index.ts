@@ -29,6 +29,7 @@ export * from './timeline';
 export * from './revision';
 export * from './user';
 export * from './Oauth';
+export * from './twoFactor';
 export * from './review';
 export * from './badges';
 export * from './ai';
@@ -118,3 +118,33 @@ export const unDeleteQuestion = (qid) => {
     question_id: qid,
   });
 };
+
+export const useQuestionBounties = (qid: string) => {
+  const apiUrl = `/answer/api/v1/question/bounty?${qs.stringify({
+    question_id: qid,
+  })}`;
+  const { data, error, mutate } = useSWR<Type.QuestionBounty[], Error>(
+    qid ? apiUrl : null,
+    request.instance.get,
+  );
+  return {
+    data,
+    isLoading: !data && !error,
+    error,
+    mutate,
+  };
+};
+
+export const addQuestionBounty = (qid: string, amount: number) => {
+  return request.post('/answer/api/v1/question/bounty', {
+    question_id: qid,
+    amount,
+  });
+};
+
+export const awardQuestionBounty = (qid: string, answerId: string) => {
+  return request.post('/answer/api/v1/question/bounty/award', {
+    question_id: qid,
+    answer_id: answerId,
+  });
+};
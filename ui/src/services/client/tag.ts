@@ -34,6 +34,7 @@ export const modifyTag = (params) => {
 export const mergeTag = (params: {
   source_tag_id: string;
   target_tag_id: string;
+  description?: '' | 'source' | 'target';
 }) => {
   return request.post('/answer/api/v1/tag/merge', params);
 };
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import useSWR from 'swr';
+
+import request from '@/utils/request';
+import type * as Type from '@/common/interface';
+
+export const useTwoFactorStatus = () => {
+  const { data, error, mutate } = useSWR<Type.TwoFactorStatus>(
+    '/answer/api/v1/user/2fa',
+    request.instance.get,
+  );
+  return {
+    data,
+    mutate,
+    isLoading: !data && !error,
+    error,
+  };
+};
+
+export const enrollTwoFactor = () => {
+  return request.post<Type.TwoFactorEnrollment>(
+    '/answer/api/v1/user/2fa/enrollment',
+  );
+};
+
+export const activateTwoFactor = (data: { code: string }) => {
+  return request.post('/answer/api/v1/user/2fa/activation', data);
+};
+
+export const disableTwoFactor = (data: { code: string }) => {
+  return request.delete('/answer/api/v1/user/2fa', data);
+};
+
+export const loginByTwoFactor = (data: Type.TwoFactorLoginReq) => {
+  return request.post<Type.UserInfoRes>('/answer/api/v1/user/login/2fa', data);
+};
@@ -303,6 +303,24 @@ function pageTitleType() {
   return 'posts';
 }
 
+/**
+ * Show the uploaded post images in their resized web version,
+ * the original is served if the image is small enough to have no resized version.
+ */
+function webImageHTML(html = '') {
+  return html.replace(
+    /(<img\s[^>]*src=")([^"?]*\/uploads\/post\/[^"?]+)"/g,
+    '$1$2?size=web"',
+  );
+}
+
+/**
+ * The original of the image that is shown in the resized version
+ */
+function originalImageSrc(src = '') {
+  return src.replace(/\?size=(thumb|web)$/, '');
+}
+
 export {
   thousandthDivision,
   formatCount,
@@ -322,4 +340,6 @@ export {
   changeTheme,
   isDarkTheme,
   pageTitleType,
+  webImageHTML,
+  originalImageSrc,
 };
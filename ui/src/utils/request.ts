@@ -204,6 +204,15 @@ class Request {
           return Promise.reject(false);
         }
 
+        if (status === 429) {
+          // too many requests, the message tells the user to try again later
+          toastStore.getState().show({
+            msg,
+            variant: 'danger',
+          });
+          return Promise.reject(false);
+        }
+
         if (status >= 500) {
           if (isIgnoredPath(IGNORE_PATH_LIST)) {
             return Promise.reject(false);